@@ -1,5 +1,7 @@
 module github.com/mithrandie/csvq
 
+go 1.27.1
+
 require (
 	github.com/mitchellh/go-homedir v1.0.0
 	github.com/mithrandie/go-file/v2 v2.0.1
@@ -9,4 +11,10 @@ require (
 	github.com/urfave/cli v1.20.0
 	golang.org/x/crypto v0.0.0-20181112202954-3d3f9f413869
 	golang.org/x/sys v0.0.0-20181107165924-66b7b1311ac8
+	golang.org/x/text v0.3.0
+)
+
+require (
+	github.com/chzyer/logex v1.1.10 // indirect
+	github.com/chzyer/test v0.0.0-20180213035817-a1ea475d72b1 // indirect
 )
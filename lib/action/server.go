@@ -0,0 +1,46 @@
+package action
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/mithrandie/csvq/lib/query"
+	"github.com/mithrandie/csvq/lib/server"
+)
+
+// Serve starts an HTTP server executing queries posted to the query API on the address.
+// If token is not empty, requests are required to have the token as a bearer token.
+func Serve(proc *query.Processor, addr string, token string) error {
+	defer func() {
+		if err := proc.ReleaseResourcesWithErrors(); err != nil {
+			proc.LogError(err.Error())
+		}
+	}()
+
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	proc.Log(fmt.Sprintf("csvq server is listening on %s.", l.Addr().String()), proc.Tx.Flags.Quiet)
+	return http.Serve(l, server.NewServer(proc.Tx, token))
+}
+
+// ServePG starts a server accepting connections from PostgreSQL clients on the address.
+// If password is not empty, clients are required to be authenticated with the password.
+func ServePG(proc *query.Processor, addr string, password string) error {
+	defer func() {
+		if err := proc.ReleaseResourcesWithErrors(); err != nil {
+			proc.LogError(err.Error())
+		}
+	}()
+
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	proc.Log(fmt.Sprintf("csvq pgserver is listening on %s.", l.Addr().String()), proc.Tx.Flags.Quiet)
+	return server.NewPGServer(proc.Tx, password).Serve(l)
+}
@@ -4,12 +4,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
 	"time"
 
+	"github.com/mithrandie/csvq/lib/file"
 	"github.com/mithrandie/go-text"
 	"github.com/mithrandie/go-text/color"
 	txjson "github.com/mithrandie/go-text/json"
@@ -27,7 +29,10 @@ const (
 	RepositoryFlag              = "REPOSITORY"
 	TimezoneFlag                = "TIMEZONE"
 	DatetimeFormatFlag          = "DATETIME_FORMAT"
+	CaseSensitiveFlag           = "CASE_SENSITIVE"
 	WaitTimeoutFlag             = "WAIT_TIMEOUT"
+	LockRetryLimitFlag          = "LOCK_RETRY_LIMIT"
+	LockBackoffFlag             = "LOCK_BACKOFF"
 	ImportFormatFlag            = "IMPORT_FORMAT"
 	DelimiterFlag               = "DELIMITER"
 	DelimiterPositionsFlag      = "DELIMITER_POSITIONS"
@@ -50,6 +55,24 @@ const (
 	ColorFlag                   = "COLOR"
 	QuietFlag                   = "QUIET"
 	CPUFlag                     = "CPU"
+	LimitRecursionFlag          = "LIMIT_RECURSION"
+	RandomSeedFlag              = "RANDOM_SEED"
+	SortBufferSizeFlag          = "SORT_BUFFER_SIZE"
+	MaxMemoryFlag               = "MAX_MEMORY"
+	MmapFlag                    = "MMAP"
+	ColumnarFlag                = "COLUMNAR"
+	StatisticsCacheFlag         = "STATISTICS_CACHE"
+	ResultCacheFlag             = "RESULT_CACHE"
+	ProgressFlag                = "PROGRESS"
+	BackupExtensionFlag         = "BACKUP_EXTENSION"
+	BackupDirFlag               = "BACKUP_DIR"
+	AuditLogFlag                = "AUDIT_LOG"
+	EncryptionKeyFileFlag       = "ENCRYPTION_KEY_FILE"
+	OnCommitFlag                = "ON_COMMIT"
+	CoordinatorTokenFlag        = "COORDINATOR_TOKEN"
+	ParticipantsFlag            = "PARTICIPANTS"
+	ReadOnlyFlag                = "READ_ONLY"
+	DryRunFlag                  = "DRY_RUN"
 	StatsFlag                   = "STATS"
 )
 
@@ -57,7 +80,10 @@ var FlagList = []string{
 	RepositoryFlag,
 	TimezoneFlag,
 	DatetimeFormatFlag,
+	CaseSensitiveFlag,
 	WaitTimeoutFlag,
+	LockRetryLimitFlag,
+	LockBackoffFlag,
 	ImportFormatFlag,
 	DelimiterFlag,
 	DelimiterPositionsFlag,
@@ -80,6 +106,24 @@ var FlagList = []string{
 	ColorFlag,
 	QuietFlag,
 	CPUFlag,
+	LimitRecursionFlag,
+	RandomSeedFlag,
+	SortBufferSizeFlag,
+	MaxMemoryFlag,
+	MmapFlag,
+	ColumnarFlag,
+	StatisticsCacheFlag,
+	ResultCacheFlag,
+	ProgressFlag,
+	BackupExtensionFlag,
+	BackupDirFlag,
+	AuditLogFlag,
+	EncryptionKeyFileFlag,
+	OnCommitFlag,
+	CoordinatorTokenFlag,
+	ParticipantsFlag,
+	ReadOnlyFlag,
+	DryRunFlag,
 	StatsFlag,
 }
 
@@ -147,9 +191,12 @@ type Flags struct {
 	Repository     string
 	Location       string
 	DatetimeFormat []string
+	CaseSensitive  bool
 
 	// Must be updated from Transaction
-	WaitTimeout float64
+	WaitTimeout    float64
+	LockRetryLimit int
+	LockBackoff    file.Backoff
 
 	// For Import
 	ImportFormat       Format
@@ -182,10 +229,36 @@ type Flags struct {
 	Color bool
 
 	// System Use
-	Quiet bool
-	CPU   int
-	Stats bool
-}
+	Quiet           bool
+	CPU             int
+	LimitRecursion  int
+	RandomSeed      int64
+	SortBufferSize  int
+	MaxMemory       int
+	Mmap            bool
+	Columnar        bool
+	StatisticsCache bool
+	ResultCache     bool
+	Progress        bool
+	BackupExtension string
+	BackupDir       string
+	AuditLog        string
+	OnCommit        string
+	ReadOnly        bool
+	DryRun          bool
+	Stats           bool
+
+	EncryptionKeyFile string
+
+	// Commits coordinated with other processes
+	CoordinatorToken string
+	Participants     int
+
+	// Key read from the EncryptionKeyFile
+	encryptionKey []byte
+}
+
+const DefaultLimitRecursion = 1000
 
 func GetDefaultNumberOfCPU() int {
 	n := runtime.NumCPU() / 2
@@ -210,7 +283,10 @@ func NewFlags(env *Environment) *Flags {
 		Repository:              "",
 		Location:                "Local",
 		DatetimeFormat:          datetimeFormat,
+		CaseSensitive:           false,
 		WaitTimeout:             10,
+		LockRetryLimit:          -1,
+		LockBackoff:             file.FixedBackoff,
 		ImportFormat:            CSV,
 		Delimiter:               ',',
 		DelimiterPositions:      nil,
@@ -235,10 +311,146 @@ func NewFlags(env *Environment) *Flags {
 		Color:                   false,
 		Quiet:                   false,
 		CPU:                     GetDefaultNumberOfCPU(),
+		LimitRecursion:          DefaultLimitRecursion,
+		RandomSeed:              -1,
+		SortBufferSize:          -1,
+		MaxMemory:               -1,
+		Mmap:                    false,
+		Columnar:                false,
+		StatisticsCache:         false,
+		ResultCache:             false,
+		Progress:                false,
+		BackupExtension:         "",
+		BackupDir:               "",
+		AuditLog:                "",
+		EncryptionKeyFile:       "",
+		OnCommit:                "",
+		CoordinatorToken:        "",
+		Participants:            2,
+		ReadOnly:                false,
+		DryRun:                  false,
 		Stats:                   false,
 	}
 }
 
+func (f *Flags) Copy() *Flags {
+	ret := *f
+	ret.DatetimeFormat = append(make([]string, 0, len(f.DatetimeFormat)), f.DatetimeFormat...)
+	if f.DelimiterPositions != nil {
+		ret.DelimiterPositions = append(make([]int, 0, len(f.DelimiterPositions)), f.DelimiterPositions...)
+	}
+	if f.WriteDelimiterPositions != nil {
+		ret.WriteDelimiterPositions = append(make([]int, 0, len(f.WriteDelimiterPositions)), f.WriteDelimiterPositions...)
+	}
+	return &ret
+}
+
+// Restore sets the value of the flag specified by name back to the value in src.
+func (f *Flags) Restore(name string, src *Flags) {
+	switch strings.ToUpper(name) {
+	case RepositoryFlag:
+		f.Repository = src.Repository
+	case TimezoneFlag:
+		_ = f.SetLocation(src.Location)
+	case DatetimeFormatFlag:
+		f.DatetimeFormat = src.DatetimeFormat
+	case CaseSensitiveFlag:
+		f.CaseSensitive = src.CaseSensitive
+	case WaitTimeoutFlag:
+		f.WaitTimeout = src.WaitTimeout
+	case LockRetryLimitFlag:
+		f.LockRetryLimit = src.LockRetryLimit
+	case LockBackoffFlag:
+		f.LockBackoff = src.LockBackoff
+	case ImportFormatFlag:
+		f.ImportFormat = src.ImportFormat
+	case DelimiterFlag:
+		f.Delimiter = src.Delimiter
+	case DelimiterPositionsFlag:
+		f.DelimiterPositions = src.DelimiterPositions
+		f.SingleLine = src.SingleLine
+	case JsonQueryFlag:
+		f.JsonQuery = src.JsonQuery
+	case EncodingFlag:
+		f.Encoding = src.Encoding
+	case NoHeaderFlag:
+		f.NoHeader = src.NoHeader
+	case WithoutNullFlag:
+		f.WithoutNull = src.WithoutNull
+	case FormatFlag:
+		f.Format = src.Format
+		f.JsonEscape = src.JsonEscape
+	case WriteEncodingFlag:
+		f.WriteEncoding = src.WriteEncoding
+	case WriteDelimiterFlag:
+		f.WriteDelimiter = src.WriteDelimiter
+	case WriteDelimiterPositionsFlag:
+		f.WriteDelimiterPositions = src.WriteDelimiterPositions
+		f.WriteAsSingleLine = src.WriteAsSingleLine
+	case WithoutHeaderFlag:
+		f.WithoutHeader = src.WithoutHeader
+	case LineBreakFlag:
+		f.LineBreak = src.LineBreak
+	case EncloseAll:
+		f.EncloseAll = src.EncloseAll
+	case JsonEscape:
+		f.JsonEscape = src.JsonEscape
+	case PrettyPrintFlag:
+		f.PrettyPrint = src.PrettyPrint
+	case EastAsianEncodingFlag:
+		f.EastAsianEncoding = src.EastAsianEncoding
+	case CountDiacriticalSignFlag:
+		f.CountDiacriticalSign = src.CountDiacriticalSign
+	case CountFormatCodeFlag:
+		f.CountFormatCode = src.CountFormatCode
+	case ColorFlag:
+		f.SetColor(src.Color)
+	case QuietFlag:
+		f.Quiet = src.Quiet
+	case CPUFlag:
+		f.CPU = src.CPU
+	case LimitRecursionFlag:
+		f.LimitRecursion = src.LimitRecursion
+	case RandomSeedFlag:
+		f.RandomSeed = src.RandomSeed
+	case SortBufferSizeFlag:
+		f.SortBufferSize = src.SortBufferSize
+	case MaxMemoryFlag:
+		f.MaxMemory = src.MaxMemory
+	case MmapFlag:
+		f.Mmap = src.Mmap
+	case ColumnarFlag:
+		f.Columnar = src.Columnar
+	case StatisticsCacheFlag:
+		f.StatisticsCache = src.StatisticsCache
+	case ResultCacheFlag:
+		f.ResultCache = src.ResultCache
+	case ProgressFlag:
+		f.Progress = src.Progress
+	case BackupExtensionFlag:
+		f.BackupExtension = src.BackupExtension
+	case BackupDirFlag:
+		f.BackupDir = src.BackupDir
+	case AuditLogFlag:
+		f.AuditLog = src.AuditLog
+	case EncryptionKeyFileFlag:
+		f.EncryptionKeyFile = src.EncryptionKeyFile
+		f.encryptionKey = src.encryptionKey
+	case OnCommitFlag:
+		f.OnCommit = src.OnCommit
+	case CoordinatorTokenFlag:
+		f.CoordinatorToken = src.CoordinatorToken
+	case ParticipantsFlag:
+		f.Participants = src.Participants
+	case ReadOnlyFlag:
+		f.ReadOnly = src.ReadOnly
+	case DryRunFlag:
+		f.DryRun = src.DryRun
+	case StatsFlag:
+		f.Stats = src.Stats
+	}
+}
+
 func (f *Flags) SetRepository(s string) error {
 	if len(s) < 1 {
 		f.Repository = ""
@@ -294,6 +506,10 @@ func (f *Flags) SetDatetimeFormat(s string) {
 	}
 }
 
+func (f *Flags) SetCaseSensitive(b bool) {
+	f.CaseSensitive = b
+}
+
 func (f *Flags) SetWaitTimeout(t float64) {
 	if t < 0 {
 		t = 0
@@ -303,6 +519,26 @@ func (f *Flags) SetWaitTimeout(t float64) {
 	return
 }
 
+func (f *Flags) SetLockRetryLimit(i int) {
+	if i < 0 {
+		i = -1
+	}
+	f.LockRetryLimit = i
+}
+
+func (f *Flags) SetLockBackoff(s string) error {
+	if len(s) < 1 {
+		return nil
+	}
+
+	b, err := file.ParseBackoff(s)
+	if err != nil {
+		return err
+	}
+	f.LockBackoff = b
+	return nil
+}
+
 func (f *Flags) SetImportFormat(s string) error {
 	fm, _, err := ParseFormat(s, f.JsonEscape)
 	if err != nil {
@@ -523,6 +759,207 @@ func (f *Flags) SetCPU(i int) {
 	f.CPU = i
 }
 
+func (f *Flags) SetLimitRecursion(i int) {
+	if i < 0 {
+		i = -1
+	}
+	f.LimitRecursion = i
+}
+
+func (f *Flags) SetRandomSeed(i int64) {
+	if i < 0 {
+		i = -1
+	} else {
+		SetRandSeed(i)
+	}
+	f.RandomSeed = i
+}
+
+func (f *Flags) SetSortBufferSize(i int) {
+	if i < 0 {
+		i = -1
+	}
+	f.SortBufferSize = i
+}
+
+func (f *Flags) SetMaxMemory(i int) {
+	if i < 0 {
+		i = -1
+	}
+	f.MaxMemory = i
+}
+
+func (f *Flags) SetMmap(b bool) {
+	f.Mmap = b
+}
+
+func (f *Flags) SetColumnar(b bool) {
+	f.Columnar = b
+}
+
+func (f *Flags) SetStatisticsCache(b bool) {
+	f.StatisticsCache = b
+}
+
+func (f *Flags) SetResultCache(b bool) {
+	f.ResultCache = b
+}
+
+func (f *Flags) SetProgress(b bool) {
+	f.Progress = b
+}
+
+func (f *Flags) SetBackupExtension(s string) error {
+	if strings.ContainsAny(s, `/\`) {
+		return errors.New("backup-extension must not contain path separators")
+	}
+
+	f.BackupExtension = s
+	return nil
+}
+
+func (f *Flags) SetBackupDir(s string) error {
+	if len(s) < 1 {
+		f.BackupDir = ""
+		return nil
+	}
+
+	path, err := filepath.Abs(s)
+	if err != nil {
+		path = s
+	}
+
+	stat, err := os.Stat(path)
+	if err != nil {
+		return errors.New("backup-dir does not exist")
+	}
+	if !stat.IsDir() {
+		return errors.New("backup-dir must be a directory path")
+	}
+
+	f.BackupDir = path
+	return nil
+}
+
+func (f *Flags) SetAuditLog(s string) error {
+	if len(s) < 1 {
+		f.AuditLog = ""
+		return nil
+	}
+
+	path, err := filepath.Abs(s)
+	if err != nil {
+		path = s
+	}
+
+	if stat, err := os.Stat(path); err == nil && stat.IsDir() {
+		return errors.New("audit-log must be a file path")
+	}
+	if stat, err := os.Stat(filepath.Dir(path)); err != nil || !stat.IsDir() {
+		return errors.New("directory of audit-log does not exist")
+	}
+
+	f.AuditLog = path
+	return nil
+}
+
+func (f *Flags) SetEncryptionKeyFile(s string) error {
+	if len(s) < 1 {
+		f.EncryptionKeyFile = ""
+		f.encryptionKey = nil
+		return nil
+	}
+
+	path, err := filepath.Abs(s)
+	if err != nil {
+		path = s
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.New("encryption-key-file cannot be read")
+	}
+	key, err := file.ParseEncryptionKey(string(b))
+	if err != nil {
+		return err
+	}
+
+	f.EncryptionKeyFile = path
+	f.encryptionKey = key
+	return nil
+}
+
+// EncryptionKey returns the key to encrypt and decrypt files.
+// The key is read from the key file, or from the environment variable CSVQ_ENCRYPTION_KEY if no key file is specified.
+func (f *Flags) EncryptionKey() ([]byte, error) {
+	if f.encryptionKey != nil {
+		return f.encryptionKey, nil
+	}
+
+	s, ok := os.LookupEnv(file.EncryptionKeyEnv)
+	if !ok || len(s) < 1 {
+		return nil, errors.New("encryption key is not specified")
+	}
+	return file.ParseEncryptionKey(s)
+}
+
+func (f *Flags) SetOnCommit(s string) error {
+	if len(s) < 1 {
+		f.OnCommit = ""
+		return nil
+	}
+
+	path, err := filepath.Abs(s)
+	if err != nil {
+		path = s
+	}
+
+	stat, err := os.Stat(path)
+	if err != nil {
+		return errors.New("on-commit file does not exist")
+	}
+	if stat.IsDir() {
+		return errors.New("on-commit must be a file path")
+	}
+
+	f.OnCommit = path
+	return nil
+}
+
+func (f *Flags) SetCoordinatorToken(s string) error {
+	for _, r := range s {
+		if !('0' <= r && r <= '9' || 'A' <= r && r <= 'Z' || 'a' <= r && r <= 'z' || r == '-' || r == '_') {
+			return errors.New("coordinator-token must consist of alphanumeric characters, hyphens and underscores")
+		}
+	}
+
+	f.CoordinatorToken = s
+	return nil
+}
+
+func (f *Flags) SetParticipants(i int) error {
+	if i < 2 {
+		return errors.New("participants must be at least 2")
+	}
+
+	f.Participants = i
+	return nil
+}
+
+// SetReadOnly enables the read-only mode. Once enabled, the mode cannot be disabled.
+func (f *Flags) SetReadOnly(b bool) error {
+	if f.ReadOnly && !b {
+		return errors.New("read-only mode cannot be disabled")
+	}
+
+	f.ReadOnly = b
+	return nil
+}
+
+func (f *Flags) SetDryRun(b bool) {
+	f.DryRun = b
+}
+
 func (f *Flags) SetStats(b bool) {
 	f.Stats = b
 }
@@ -1,11 +1,16 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
 	"path/filepath"
 	"reflect"
 	"runtime"
 	"testing"
 
+	"github.com/mithrandie/csvq/lib/file"
 	"github.com/mithrandie/go-text"
 	"github.com/mithrandie/go-text/json"
 )
@@ -105,6 +110,15 @@ func TestFlags_SetDatetimeFormat(t *testing.T) {
 	}
 }
 
+func TestFlags_SetCaseSensitive(t *testing.T) {
+	flags := NewFlags(nil)
+
+	flags.SetCaseSensitive(true)
+	if !flags.CaseSensitive {
+		t.Errorf("case sensitive = %t, expect to set %t", flags.CaseSensitive, true)
+	}
+}
+
 func TestFlags_SetWaitTimeout(t *testing.T) {
 	flags := NewFlags(nil)
 
@@ -121,6 +135,44 @@ func TestFlags_SetWaitTimeout(t *testing.T) {
 	}
 }
 
+func TestFlags_SetLockRetryLimit(t *testing.T) {
+	flags := NewFlags(nil)
+
+	i := -5
+	flags.SetLockRetryLimit(i)
+	if flags.LockRetryLimit != -1 {
+		t.Errorf("lock retry limit = %d, expect to set %d for %d", flags.LockRetryLimit, -1, i)
+	}
+
+	i = 3
+	flags.SetLockRetryLimit(i)
+	if flags.LockRetryLimit != 3 {
+		t.Errorf("lock retry limit = %d, expect to set %d for %d", flags.LockRetryLimit, 3, i)
+	}
+}
+
+func TestFlags_SetLockBackoff(t *testing.T) {
+	flags := NewFlags(nil)
+
+	_ = flags.SetLockBackoff("")
+	if flags.LockBackoff != file.FixedBackoff {
+		t.Errorf("lock backoff = %s, expect to set %s for empty string", flags.LockBackoff, file.FixedBackoff)
+	}
+
+	_ = flags.SetLockBackoff("jitter")
+	if flags.LockBackoff != file.JitterBackoff {
+		t.Errorf("lock backoff = %s, expect to set %s for %q", flags.LockBackoff, file.JitterBackoff, "jitter")
+	}
+
+	expectErr := "backoff must be one of FIXED|EXPONENTIAL|JITTER"
+	err := flags.SetLockBackoff("error")
+	if err == nil {
+		t.Errorf("no error, want error %q for %s", expectErr, "error")
+	} else if err.Error() != expectErr {
+		t.Errorf("error = %q, want error %q for %s", err.Error(), expectErr, "error")
+	}
+}
+
 func TestFlags_SetImportFormat(t *testing.T) {
 	flags := NewFlags(nil)
 
@@ -593,6 +645,348 @@ func TestFlags_SetCPU(t *testing.T) {
 	}
 }
 
+func TestFlags_SetLimitRecursion(t *testing.T) {
+	flags := NewFlags(nil)
+
+	flags.SetLimitRecursion(10)
+	if flags.LimitRecursion != 10 {
+		t.Errorf("limit recursion = %d, expect to set %d", flags.LimitRecursion, 10)
+	}
+
+	flags.SetLimitRecursion(-100)
+	if flags.LimitRecursion != -1 {
+		t.Errorf("limit recursion = %d, expect to set %d", flags.LimitRecursion, -1)
+	}
+}
+
+func TestFlags_SetRandomSeed(t *testing.T) {
+	flags := NewFlags(nil)
+
+	flags.SetRandomSeed(10)
+	if flags.RandomSeed != 10 {
+		t.Errorf("random seed = %d, expect to set %d", flags.RandomSeed, 10)
+	}
+	n := GetRand().Int63()
+
+	flags.SetRandomSeed(10)
+	if GetRand().Int63() != n {
+		t.Error("random numbers are not reproduced with the same seed")
+	}
+
+	flags.SetRandomSeed(-100)
+	if flags.RandomSeed != -1 {
+		t.Errorf("random seed = %d, expect to set %d", flags.RandomSeed, -1)
+	}
+}
+
+func TestFlags_SetSortBufferSize(t *testing.T) {
+	flags := NewFlags(nil)
+
+	flags.SetSortBufferSize(256)
+	if flags.SortBufferSize != 256 {
+		t.Errorf("sort buffer size = %d, expect to set %d", flags.SortBufferSize, 256)
+	}
+
+	flags.SetSortBufferSize(-100)
+	if flags.SortBufferSize != -1 {
+		t.Errorf("sort buffer size = %d, expect to set %d", flags.SortBufferSize, -1)
+	}
+}
+
+func TestFlags_SetMaxMemory(t *testing.T) {
+	flags := NewFlags(nil)
+
+	flags.SetMaxMemory(1024)
+	if flags.MaxMemory != 1024 {
+		t.Errorf("max memory = %d, expect to set %d", flags.MaxMemory, 1024)
+	}
+
+	flags.SetMaxMemory(-100)
+	if flags.MaxMemory != -1 {
+		t.Errorf("max memory = %d, expect to set %d", flags.MaxMemory, -1)
+	}
+}
+
+func TestFlags_SetMmap(t *testing.T) {
+	flags := NewFlags(nil)
+
+	flags.SetMmap(true)
+	if !flags.Mmap {
+		t.Errorf("mmap = %t, expect to set %t", flags.Mmap, true)
+	}
+}
+
+func TestFlags_SetColumnar(t *testing.T) {
+	flags := NewFlags(nil)
+
+	flags.SetColumnar(true)
+	if !flags.Columnar {
+		t.Errorf("columnar = %t, expect to set %t", flags.Columnar, true)
+	}
+}
+
+func TestFlags_SetStatisticsCache(t *testing.T) {
+	flags := NewFlags(nil)
+
+	flags.SetStatisticsCache(true)
+	if !flags.StatisticsCache {
+		t.Errorf("statistics cache = %t, expect to set %t", flags.StatisticsCache, true)
+	}
+}
+
+func TestFlags_SetResultCache(t *testing.T) {
+	flags := NewFlags(nil)
+
+	flags.SetResultCache(true)
+	if !flags.ResultCache {
+		t.Errorf("result cache = %t, expect to set %t", flags.ResultCache, true)
+	}
+}
+
+func TestFlags_SetProgress(t *testing.T) {
+	flags := NewFlags(nil)
+
+	flags.SetProgress(true)
+	if !flags.Progress {
+		t.Errorf("progress = %t, expect to set %t", flags.Progress, true)
+	}
+}
+
+func TestFlags_SetOnCommit(t *testing.T) {
+	flags := NewFlags(nil)
+
+	_ = flags.SetOnCommit("")
+	if flags.OnCommit != "" {
+		t.Errorf("on commit = %s, expect to set %q for %q", flags.OnCommit, "", "")
+	}
+
+	fpath := filepath.Join("..", "..", "lib", "cmd", "flags.go")
+	abspath, _ := filepath.Abs(fpath)
+	_ = flags.SetOnCommit(fpath)
+	if flags.OnCommit != abspath {
+		t.Errorf("on commit = %s, expect to set %s for %s", flags.OnCommit, abspath, fpath)
+	}
+
+	expectErr := "on-commit must be a file path"
+	err := flags.SetOnCommit(filepath.Join("..", "..", "lib", "cmd"))
+	if err == nil {
+		t.Errorf("no error, want error %q for %s", expectErr, "directory")
+	} else if err.Error() != expectErr {
+		t.Errorf("error = %q, want error %q for %s", err.Error(), expectErr, "directory")
+	}
+
+	expectErr = "on-commit file does not exist"
+	err = flags.SetOnCommit(filepath.Join("notexists", "trigger.sql"))
+	if err == nil {
+		t.Errorf("no error, want error %q for %s", expectErr, "notexists")
+	} else if err.Error() != expectErr {
+		t.Errorf("error = %q, want error %q for %s", err.Error(), expectErr, "notexists")
+	}
+}
+
+func TestFlags_SetCoordinatorToken(t *testing.T) {
+	flags := NewFlags(nil)
+
+	_ = flags.SetCoordinatorToken("pipeline-20120203_1")
+	if flags.CoordinatorToken != "pipeline-20120203_1" {
+		t.Errorf("coordinator token = %s, expect to set %s", flags.CoordinatorToken, "pipeline-20120203_1")
+	}
+
+	_ = flags.SetCoordinatorToken("")
+	if flags.CoordinatorToken != "" {
+		t.Errorf("coordinator token = %s, expect to set %q", flags.CoordinatorToken, "")
+	}
+
+	expectErr := "coordinator-token must consist of alphanumeric characters, hyphens and underscores"
+	err := flags.SetCoordinatorToken("../token")
+	if err == nil {
+		t.Errorf("no error, want error %q for %s", expectErr, "../token")
+	} else if err.Error() != expectErr {
+		t.Errorf("error = %q, want error %q for %s", err.Error(), expectErr, "../token")
+	}
+}
+
+func TestFlags_SetParticipants(t *testing.T) {
+	flags := NewFlags(nil)
+
+	_ = flags.SetParticipants(3)
+	if flags.Participants != 3 {
+		t.Errorf("participants = %d, expect to set %d", flags.Participants, 3)
+	}
+
+	expectErr := "participants must be at least 2"
+	err := flags.SetParticipants(1)
+	if err == nil {
+		t.Errorf("no error, want error %q for %d", expectErr, 1)
+	} else if err.Error() != expectErr {
+		t.Errorf("error = %q, want error %q for %d", err.Error(), expectErr, 1)
+	}
+}
+
+func TestFlags_SetReadOnly(t *testing.T) {
+	flags := NewFlags(nil)
+
+	_ = flags.SetReadOnly(true)
+	if !flags.ReadOnly {
+		t.Errorf("read only = %t, expect to set %t", flags.ReadOnly, true)
+	}
+
+	expectErr := "read-only mode cannot be disabled"
+	err := flags.SetReadOnly(false)
+	if err == nil {
+		t.Errorf("no error, want error %q for %t", expectErr, false)
+	} else if err.Error() != expectErr {
+		t.Errorf("error = %q, want error %q for %t", err.Error(), expectErr, false)
+	}
+	if !flags.ReadOnly {
+		t.Errorf("read only = %t, expect to keep %t", flags.ReadOnly, true)
+	}
+}
+
+func TestFlags_SetDryRun(t *testing.T) {
+	flags := NewFlags(nil)
+
+	flags.SetDryRun(true)
+	if !flags.DryRun {
+		t.Errorf("dry run = %t, expect to set %t", flags.DryRun, true)
+	}
+}
+
+func TestFlags_SetBackupExtension(t *testing.T) {
+	flags := NewFlags(nil)
+
+	_ = flags.SetBackupExtension(".bak")
+	if flags.BackupExtension != ".bak" {
+		t.Errorf("backup extension = %q, expect to set %q", flags.BackupExtension, ".bak")
+	}
+
+	expectErr := "backup-extension must not contain path separators"
+	err := flags.SetBackupExtension("/bak")
+	if err == nil {
+		t.Errorf("no error, want error %q for %s", expectErr, "/bak")
+	} else if err.Error() != expectErr {
+		t.Errorf("error = %q, want error %q for %s", err.Error(), expectErr, "/bak")
+	}
+}
+
+func TestFlags_SetBackupDir(t *testing.T) {
+	flags := NewFlags(nil)
+
+	_ = flags.SetBackupDir("")
+	if flags.BackupDir != "" {
+		t.Errorf("backup dir = %s, expect to set %q for %q", flags.BackupDir, "", "")
+	}
+
+	dir := filepath.Join("..", "..", "lib", "cmd")
+	absdir, _ := filepath.Abs(dir)
+	_ = flags.SetBackupDir(dir)
+	if flags.BackupDir != absdir {
+		t.Errorf("backup dir = %s, expect to set %s for %s", flags.BackupDir, absdir, dir)
+	}
+
+	expectErr := "backup-dir does not exist"
+	err := flags.SetBackupDir("notexists")
+	if err == nil {
+		t.Errorf("no error, want error %q for %s", expectErr, "notexists")
+	} else if err.Error() != expectErr {
+		t.Errorf("error = %q, want error %q for %s", err.Error(), expectErr, "notexists")
+	}
+
+	expectErr = "backup-dir must be a directory path"
+	err = flags.SetBackupDir("flags_test.go")
+	if err == nil {
+		t.Errorf("no error, want error %q for %s", expectErr, "flags_test.go")
+	} else if err.Error() != expectErr {
+		t.Errorf("error = %q, want error %q for %s", err.Error(), expectErr, "flags_test.go")
+	}
+}
+
+func TestFlags_SetAuditLog(t *testing.T) {
+	flags := NewFlags(nil)
+
+	_ = flags.SetAuditLog("")
+	if flags.AuditLog != "" {
+		t.Errorf("audit log = %s, expect to set %q for %q", flags.AuditLog, "", "")
+	}
+
+	fpath := filepath.Join("..", "..", "lib", "cmd", "audit.jsonl")
+	abspath, _ := filepath.Abs(fpath)
+	_ = flags.SetAuditLog(fpath)
+	if flags.AuditLog != abspath {
+		t.Errorf("audit log = %s, expect to set %s for %s", flags.AuditLog, abspath, fpath)
+	}
+
+	expectErr := "audit-log must be a file path"
+	err := flags.SetAuditLog(filepath.Join("..", "..", "lib", "cmd"))
+	if err == nil {
+		t.Errorf("no error, want error %q for %s", expectErr, "directory")
+	} else if err.Error() != expectErr {
+		t.Errorf("error = %q, want error %q for %s", err.Error(), expectErr, "directory")
+	}
+
+	expectErr = "directory of audit-log does not exist"
+	err = flags.SetAuditLog(filepath.Join("notexists", "audit.jsonl"))
+	if err == nil {
+		t.Errorf("no error, want error %q for %s", expectErr, "notexists")
+	} else if err.Error() != expectErr {
+		t.Errorf("error = %q, want error %q for %s", err.Error(), expectErr, "notexists")
+	}
+}
+
+func TestFlags_SetEncryptionKeyFile(t *testing.T) {
+	flags := NewFlags(nil)
+
+	key := bytes.Repeat([]byte{1}, 32)
+	fpath := filepath.Join(TestDir, "encryption.key")
+	if err := ioutil.WriteFile(fpath, []byte(base64.StdEncoding.EncodeToString(key)+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := flags.SetEncryptionKeyFile(fpath); err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	if flags.EncryptionKeyFile != fpath {
+		t.Errorf("encryption key file = %s, expect to set %s", flags.EncryptionKeyFile, fpath)
+	}
+	if result, _ := flags.EncryptionKey(); !bytes.Equal(result, key) {
+		t.Errorf("encryption key = %v, expect %v", result, key)
+	}
+
+	_ = flags.SetEncryptionKeyFile("")
+	if flags.EncryptionKeyFile != "" {
+		t.Errorf("encryption key file = %s, expect to set %q for %q", flags.EncryptionKeyFile, "", "")
+	}
+
+	expectErr := "encryption-key-file cannot be read"
+	err := flags.SetEncryptionKeyFile(filepath.Join(TestDir, "notexist.key"))
+	if err == nil {
+		t.Errorf("no error, want error %q for %s", expectErr, "notexist.key")
+	} else if err.Error() != expectErr {
+		t.Errorf("error = %q, want error %q for %s", err.Error(), expectErr, "notexist.key")
+	}
+}
+
+func TestFlags_EncryptionKey(t *testing.T) {
+	flags := NewFlags(nil)
+	key := bytes.Repeat([]byte{2}, 16)
+
+	_ = os.Setenv(file.EncryptionKeyEnv, base64.StdEncoding.EncodeToString(key))
+	result, err := flags.EncryptionKey()
+	if err != nil {
+		t.Errorf("unexpected error %q", err)
+	} else if !bytes.Equal(result, key) {
+		t.Errorf("encryption key = %v, expect %v", result, key)
+	}
+
+	_ = os.Unsetenv(file.EncryptionKeyEnv)
+	expectErr := "encryption key is not specified"
+	if _, err = flags.EncryptionKey(); err == nil {
+		t.Errorf("no error, want error %q", expectErr)
+	} else if err.Error() != expectErr {
+		t.Errorf("error = %q, want error %q", err.Error(), expectErr)
+	}
+}
+
 func TestFlags_SetStats(t *testing.T) {
 	flags := NewFlags(nil)
 
@@ -601,3 +995,26 @@ func TestFlags_SetStats(t *testing.T) {
 		t.Errorf("stats = %t, expect to set %t", flags.Stats, true)
 	}
 }
+
+func TestFlags_Restore(t *testing.T) {
+	flags := NewFlags(nil)
+	_ = flags.SetDelimiterPositions("s[1, 3]")
+	saved := flags.Copy()
+
+	_ = flags.SetFormat("jsonh", "")
+	_ = flags.SetDelimiterPositions("[2, 5]")
+	flags.DelimiterPositions[0] = 4
+	if !reflect.DeepEqual(saved.DelimiterPositions, []int{1, 3}) {
+		t.Errorf("delimiter positions = %v, expect not to be changed", saved.DelimiterPositions)
+	}
+
+	flags.Restore("format", saved)
+	if flags.Format != TEXT || flags.JsonEscape != json.Backslash {
+		t.Errorf("format = %s, json escape = %s, expect to restore %s, %s", flags.Format, JsonEscapeTypeToString(flags.JsonEscape), TEXT, JsonEscapeTypeToString(json.Backslash))
+	}
+
+	flags.Restore("DELIMITER_POSITIONS", saved)
+	if !reflect.DeepEqual(flags.DelimiterPositions, []int{1, 3}) || !flags.SingleLine {
+		t.Errorf("delimiter positions = %v, single line = %t, expect to restore %v, %t", flags.DelimiterPositions, flags.SingleLine, []int{1, 3}, true)
+	}
+}
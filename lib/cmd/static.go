@@ -1,7 +1,10 @@
 package cmd
 
 import (
+	"errors"
+	"fmt"
 	"math/rand"
+	"strings"
 	"sync"
 	"time"
 )
@@ -13,17 +16,65 @@ var (
 	getRand sync.Once
 )
 
+// lockedSource is a source of pseudo-random numbers that is safe for concurrent use.
+type lockedSource struct {
+	mtx *sync.Mutex
+	src rand.Source64
+}
+
+func (s lockedSource) Int63() int64 {
+	s.mtx.Lock()
+	n := s.src.Int63()
+	s.mtx.Unlock()
+	return n
+}
+
+func (s lockedSource) Uint64() uint64 {
+	s.mtx.Lock()
+	n := s.src.Uint64()
+	s.mtx.Unlock()
+	return n
+}
+
+func (s lockedSource) Seed(seed int64) {
+	s.mtx.Lock()
+	s.src.Seed(seed)
+	s.mtx.Unlock()
+}
+
 func GetRand() *rand.Rand {
 	getRand.Do(func() {
-		random = rand.New(rand.NewSource(time.Now().UnixNano()))
+		random = rand.New(lockedSource{
+			mtx: &sync.Mutex{},
+			src: rand.NewSource(time.Now().UnixNano()).(rand.Source64),
+		})
 	})
 	return random
 }
 
+func SetRandSeed(seed int64) {
+	GetRand().Seed(seed)
+}
+
 func GetLocation() *time.Location {
 	return time.Local
 }
 
+func LoadLocation(s string) (*time.Location, error) {
+	if len(s) < 1 || strings.EqualFold(s, "Local") {
+		return GetLocation(), nil
+	}
+	if strings.EqualFold(s, "UTC") {
+		return time.UTC, nil
+	}
+
+	location, err := time.LoadLocation(s)
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("timezone %q does not exist", s))
+	}
+	return location, nil
+}
+
 func Now() time.Time {
 	if !TestTime.IsZero() {
 		return TestTime
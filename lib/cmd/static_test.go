@@ -23,6 +23,27 @@ func TestGetLocation(t *testing.T) {
 	}
 }
 
+func TestLoadLocation(t *testing.T) {
+	if l, _ := LoadLocation("local"); l != GetLocation() {
+		t.Errorf("location = %s, want %s for %q", l, GetLocation(), "local")
+	}
+
+	if l, _ := LoadLocation("utc"); l != time.UTC {
+		t.Errorf("location = %s, want %s for %q", l, time.UTC, "utc")
+	}
+
+	if l, _ := LoadLocation("America/Los_Angeles"); l == nil || l.String() != "America/Los_Angeles" {
+		t.Errorf("location = %s, want %s for %q", l, "America/Los_Angeles", "America/Los_Angeles")
+	}
+
+	expectErr := "timezone \"America/NotExist\" does not exist"
+	if _, err := LoadLocation("America/NotExist"); err == nil {
+		t.Errorf("no error, want error %q", expectErr)
+	} else if err.Error() != expectErr {
+		t.Errorf("error = %q, want error %q", err.Error(), expectErr)
+	}
+}
+
 func TestNow(t *testing.T) {
 	TestTime, _ = time.ParseInLocation("2006-01-02 15:04:05.999999999", "2012-02-01 12:03:23", GetLocation())
 
@@ -0,0 +1,73 @@
+package file
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// BackupFilePath returns the path of the backup of the file.
+// The backup is placed in dir, or in the same directory as the file if dir is empty, and named
+// the name of the file followed by ext.
+// If both dir and ext are empty, then an empty string is returned.
+func BackupFilePath(path string, dir string, ext string) string {
+	if len(dir) < 1 && len(ext) < 1 {
+		return ""
+	}
+	if len(dir) < 1 {
+		dir = filepath.Dir(path)
+	}
+	return filepath.Join(dir, filepath.Base(path)+ext)
+}
+
+// Backup preserves the current contents of the file in backupPath, replacing an existing backup.
+//
+// The backup is created as a hard link to the file if possible. Committed files are replaced with
+// other files instead of being rewritten, so the link keeps the contents before the commit.
+func Backup(path string, backupPath string) error {
+	if filepath.Clean(path) == filepath.Clean(backupPath) {
+		return NewIOError(fmt.Sprintf("backup of file %s must be a different file", path))
+	}
+
+	if err := os.Remove(backupPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	if err := os.Link(path, backupPath); err == nil {
+		return nil
+	}
+	return copyFile(path, backupPath)
+}
+
+func copyFile(src string, dst string) error {
+	stat, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+
+	sfp, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = sfp.Close()
+	}()
+
+	dfp, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, stat.Mode().Perm())
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(dfp, sfp)
+	if err == nil {
+		err = dfp.Sync()
+	}
+	if e := dfp.Close(); err == nil {
+		err = e
+	}
+	if err != nil {
+		_ = os.Remove(dst)
+	}
+	return err
+}
@@ -0,0 +1,104 @@
+package file
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var backupFilePathTests = []struct {
+	Path   string
+	Dir    string
+	Ext    string
+	Expect string
+}{
+	{
+		Path:   filepath.Join("dir", "table.csv"),
+		Expect: "",
+	},
+	{
+		Path:   filepath.Join("dir", "table.csv"),
+		Ext:    ".bak",
+		Expect: filepath.Join("dir", "table.csv.bak"),
+	},
+	{
+		Path:   filepath.Join("dir", "table.csv"),
+		Dir:    "backup",
+		Expect: filepath.Join("backup", "table.csv"),
+	},
+	{
+		Path:   filepath.Join("dir", "table.csv"),
+		Dir:    "backup",
+		Ext:    "~",
+		Expect: filepath.Join("backup", "table.csv~"),
+	},
+}
+
+func TestBackupFilePath(t *testing.T) {
+	for _, v := range backupFilePathTests {
+		result := BackupFilePath(v.Path, v.Dir, v.Ext)
+		if result != v.Expect {
+			t.Errorf("backup file path = %q, expect %q for %q, %q, %q", result, v.Expect, v.Path, v.Dir, v.Ext)
+		}
+	}
+}
+
+func TestBackup(t *testing.T) {
+	fpath := GetTestFilePath("backup.txt")
+	backupPath := GetTestFilePath("backup.txt.bak")
+	defer func() {
+		_ = os.Remove(fpath)
+		_ = os.Remove(backupPath)
+	}()
+
+	if err := ioutil.WriteFile(fpath, []byte("first"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(backupPath, []byte("old backup"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Backup(fpath, backupPath); err != nil {
+		t.Fatalf("error = %#v, expect no error", err)
+	}
+	if b, _ := ioutil.ReadFile(backupPath); string(b) != "first" {
+		t.Errorf("backup = %q, expect %q", string(b), "first")
+	}
+
+	tempPath := GetTestFilePath("backup.txt.new")
+	if err := ioutil.WriteFile(tempPath, []byte("second"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(tempPath, fpath); err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := ioutil.ReadFile(backupPath); string(b) != "first" {
+		t.Errorf("backup after the file is replaced = %q, expect %q", string(b), "first")
+	}
+
+	if err := Backup(fpath, fpath); err == nil {
+		t.Errorf("no error, want IOError")
+	} else if _, ok := err.(*IOError); !ok {
+		t.Errorf("error = %#v, want IOError", err)
+	}
+	if b, _ := ioutil.ReadFile(fpath); string(b) != "second" {
+		t.Errorf("contents = %q, expect %q", string(b), "second")
+	}
+
+	copyPath := GetTestFilePath("backup_copy.txt")
+	defer func() {
+		_ = os.Remove(copyPath)
+	}()
+	if err := copyFile(fpath, copyPath); err != nil {
+		t.Fatalf("error = %#v, expect no error", err)
+	}
+	if b, _ := ioutil.ReadFile(copyPath); string(b) != "second" {
+		t.Errorf("copy = %q, expect %q", string(b), "second")
+	}
+	if stat, err := os.Stat(copyPath); err != nil {
+		t.Errorf("error = %#v, expect no error", err)
+	} else if stat.Mode().Perm() != 0600 {
+		t.Errorf("mode = %v, expect %v", stat.Mode().Perm(), os.FileMode(0600))
+	}
+}
@@ -6,6 +6,8 @@ const DefaultWaitTimeout = 10 * time.Second
 const DefaultRetryDelay = 10 * time.Millisecond
 
 const (
-	LockFileSuffix = ".lock"
-	TempFileSuffix = ".temp"
+	LockFileSuffix     = ".lock"
+	TempFileSuffix     = ".temp"
+	JournalFileSuffix  = ".journal"
+	CommitMarkerSuffix = ".commit"
 )
@@ -8,11 +8,15 @@ import (
 
 type Container struct {
 	m map[string]*Handler
+
+	// Policy of retries to lock the files opened in the container
+	RetryPolicy RetryPolicy
 }
 
 func NewContainer() *Container {
 	return &Container{
-		m: make(map[string]*Handler),
+		m:           make(map[string]*Handler),
+		RetryPolicy: DefaultRetryPolicy,
 	}
 }
 
@@ -0,0 +1,178 @@
+package file
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	PreparedMarkerSuffix = ".prepared"
+	DecisionFileName     = "decision"
+
+	DecisionCommit = "COMMIT"
+	DecisionAbort  = "ABORT"
+)
+
+// Coordinator makes the commits of multiple processes sharing the same token succeed or fail together.
+//
+// Each process writes its changes to temporary files, and then creates a prepared marker in the directory
+// of the token. The first process that finds the prepared markers of all the participants decides to commit,
+// and the first process that fails or times out before that decides to abort.
+// The decision is written to a file only once, and all the participants follow it.
+//
+// The prepared marker is recorded in the journal of the commit, so that the commit of a participant
+// interrupted by a crash is completed or rolled back by Recover in accordance with the decision.
+type Coordinator struct {
+	dir          string
+	participants int
+	markerPath   string
+}
+
+// NewCoordinator returns the coordinator of the commit identified by the token.
+func NewCoordinator(dir string, token string, participants int) *Coordinator {
+	tokenDir := filepath.Join(dir, token)
+	return &Coordinator{
+		dir:          tokenDir,
+		participants: participants,
+		markerPath:   filepath.Join(tokenDir, fmt.Sprintf("%d-%d%s", os.Getpid(), time.Now().UnixNano(), PreparedMarkerSuffix)),
+	}
+}
+
+// Prepare creates the prepared marker, and waits until the decision is made.
+// The decision to abort is made if not all the participants are prepared before the context is done.
+// It reports whether the decision is to commit.
+func (c *Coordinator) Prepare(ctx context.Context, retryDelay time.Duration) (bool, error) {
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return false, NewIOError(fmt.Sprintf("unable to create coordinator directory %s", c.dir))
+	}
+	if _, ok := readDecision(c.dir); ok {
+		// The decision has been made without this participant.
+		return false, nil
+	}
+	if err := syncFile(c.markerPath, nil); err != nil {
+		return false, NewIOError(fmt.Sprintf("unable to create prepared marker %s", c.markerPath))
+	}
+
+	for {
+		if decision, ok := readDecision(c.dir); ok {
+			return decision == DecisionCommit, nil
+		}
+		if c.participants <= countPreparedMarkers(c.dir) {
+			return c.decide(DecisionCommit)
+		}
+
+		select {
+		case <-ctx.Done():
+			return c.decide(DecisionAbort)
+		case <-time.After(retryDelay):
+		}
+	}
+}
+
+// Abort makes the decision to abort unless the decision has already been made.
+func (c *Coordinator) Abort() error {
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return NewIOError(fmt.Sprintf("unable to create coordinator directory %s", c.dir))
+	}
+	_, err := c.decide(DecisionAbort)
+	return err
+}
+
+// Close removes the prepared marker, and removes the directory of the token after all the participants
+// have finished.
+func (c *Coordinator) Close() error {
+	return leaveCoordination(c.markerPath)
+}
+
+// MarkerPath returns the path of the prepared marker recorded in the journal.
+func (c *Coordinator) MarkerPath() string {
+	return c.markerPath
+}
+
+func (c *Coordinator) decide(decision string) (bool, error) {
+	tempPath := filepath.Join(c.dir, fmt.Sprintf("%d-%d%s", os.Getpid(), time.Now().UnixNano(), TempFileSuffix))
+	if err := syncFile(tempPath, []byte(decision)); err != nil {
+		return false, NewIOError(fmt.Sprintf("unable to write decision in %s", c.dir))
+	}
+	defer func() {
+		_ = os.Remove(tempPath)
+	}()
+
+	// The link fails if another participant has already made the decision.
+	_ = os.Link(tempPath, filepath.Join(c.dir, DecisionFileName))
+
+	d, ok := readDecision(c.dir)
+	if !ok {
+		return false, NewIOError(fmt.Sprintf("unable to write decision in %s", c.dir))
+	}
+	return d == DecisionCommit, nil
+}
+
+// CoordinatedDecision returns the decision of the coordinated commit in which the prepared marker was created.
+// If the marker does not exist, then the participant has never been counted and the decision is to abort.
+// It reports false if the decision has not been made yet.
+func CoordinatedDecision(markerPath string) (string, bool) {
+	if !Exists(markerPath) {
+		return DecisionAbort, true
+	}
+	return readDecision(filepath.Dir(markerPath))
+}
+
+func readDecision(dir string) (string, bool) {
+	b, err := ioutil.ReadFile(filepath.Join(dir, DecisionFileName))
+	if err != nil {
+		return "", false
+	}
+	return string(b), true
+}
+
+func countPreparedMarkers(dir string) int {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return 0
+	}
+
+	n := 0
+	for _, f := range files {
+		if !f.IsDir() && strings.HasSuffix(f.Name(), PreparedMarkerSuffix) {
+			n++
+		}
+	}
+	return n
+}
+
+func leaveCoordination(markerPath string) error {
+	if err := removeIfExists(markerPath); err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(markerPath)
+	if 0 < countPreparedMarkers(dir) {
+		return nil
+	}
+	if err := removeIfExists(filepath.Join(dir, DecisionFileName)); err != nil {
+		return err
+	}
+	// The directory is left if another participant has just created a file in it.
+	_ = os.Remove(dir)
+	return nil
+}
+
+func syncFile(path string, contents []byte) error {
+	fp, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err = fp.Write(contents); err == nil {
+		err = fp.Sync()
+	}
+	if e := fp.Close(); err == nil {
+		err = e
+	}
+	return err
+}
@@ -0,0 +1,106 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestCoordinator_Prepare(t *testing.T) {
+	dir := GetTestFilePath("coordinator")
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	results := make([]bool, 3)
+	errs := make([]error, 3)
+	wg := &sync.WaitGroup{}
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c := NewCoordinator(dir, "commit", 3)
+			ctx, cancel := context.WithTimeout(context.Background(), waitTimeoutForTests*10)
+			defer cancel()
+			results[i], errs[i] = c.Prepare(ctx, retryDelayForTests)
+		}(i)
+	}
+	wg.Wait()
+
+	for i := range results {
+		if errs[i] != nil {
+			t.Errorf("participant %d: unexpected error %q", i, errs[i])
+		} else if !results[i] {
+			t.Errorf("participant %d: decision is to abort, expect to commit", i)
+		}
+	}
+
+	c := NewCoordinator(dir, "timeout", 2)
+	ctx, cancel := context.WithTimeout(context.Background(), waitTimeoutForTests)
+	defer cancel()
+	if committed, err := c.Prepare(ctx, retryDelayForTests); err != nil {
+		t.Errorf("unexpected error %q", err)
+	} else if committed {
+		t.Errorf("decision is to commit, expect to abort when the other participant is not prepared")
+	}
+	if err := c.Close(); err != nil {
+		t.Errorf("unexpected error %q", err)
+	}
+	if Exists(filepath.Join(dir, "timeout")) {
+		t.Errorf("coordinator directory is not removed")
+	}
+
+	c = NewCoordinator(dir, "abort", 2)
+	if err := c.Abort(); err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	other := NewCoordinator(dir, "abort", 2)
+	if committed, err := other.Prepare(context.Background(), retryDelayForTests); err != nil {
+		t.Errorf("unexpected error %q", err)
+	} else if committed {
+		t.Errorf("decision is to commit, expect to abort after the other participant aborted")
+	}
+}
+
+func TestRecover_CoordinatedCommitUndecided(t *testing.T) {
+	dir := GetTestFilePath("recover_undecided")
+	fpath := GetTestFilePath("recover_undecided.txt")
+	defer func() {
+		_ = os.RemoveAll(dir)
+		_ = os.Remove(fpath)
+		_ = os.Remove(TempFilePath(fpath))
+		_ = os.Remove(LockFilePath(fpath))
+	}()
+
+	_ = os.MkdirAll(dir, 0700)
+	_ = ioutil.WriteFile(fpath, []byte("old"), 0600)
+	_ = ioutil.WriteFile(TempFilePath(fpath), []byte("new"), 0600)
+	_ = ioutil.WriteFile(LockFilePath(fpath), nil, 0600)
+
+	c := NewCoordinator(filepath.Join(dir, "coordinator"), "token", 2)
+	_ = os.MkdirAll(filepath.Dir(c.MarkerPath()), 0700)
+	_ = ioutil.WriteFile(c.MarkerPath(), nil, 0600)
+
+	journalPath := filepath.Join(dir, "1-1"+JournalFileSuffix)
+	b, _ := json.Marshal([]JournalEntry{{Path: fpath, TempPath: TempFilePath(fpath), LockPath: LockFilePath(fpath), Coordinator: c.MarkerPath()}})
+	_ = ioutil.WriteFile(journalPath, b, 0600)
+
+	if committed, rolledBack, err := Recover(dir); err != nil || committed != nil || rolledBack != nil {
+		t.Errorf("recover = %v, %v, %v, expect the undecided commit to be left", committed, rolledBack, err)
+	}
+	if !Exists(journalPath) || !Exists(TempFilePath(fpath)) {
+		t.Errorf("journal or temporary file is removed, expect to be left until the decision is made")
+	}
+
+	_ = ioutil.WriteFile(filepath.Join(filepath.Dir(c.MarkerPath()), DecisionFileName), []byte(DecisionCommit), 0600)
+	if committed, _, err := Recover(dir); err != nil || len(committed) != 1 {
+		t.Errorf("recover = %v, %v, expect the commit to be completed", committed, err)
+	}
+	if b, _ := ioutil.ReadFile(fpath); string(b) != "new" {
+		t.Errorf("contents = %q, expect %q", string(b), "new")
+	}
+}
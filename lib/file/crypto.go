@@ -0,0 +1,109 @@
+package file
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"io"
+	"os"
+	"strings"
+)
+
+// EncryptionKeyEnv is the environment variable to supply the key of encrypted files
+// when no key file is specified.
+const EncryptionKeyEnv = "CSVQ_ENCRYPTION_KEY"
+
+// EncryptedFileHeader is written at the beginning of encrypted files, followed by the nonce and
+// the contents of the file encrypted with AES-GCM.
+var EncryptedFileHeader = []byte("CSVQ\x00ENC1")
+
+// ParseEncryptionKey decodes the base64 encoded key of 16, 24 or 32 bytes
+// to select AES-128, AES-192 or AES-256.
+func ParseEncryptionKey(s string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, errors.New("encryption key must be encoded in base64")
+	}
+	switch len(key) {
+	case 16, 24, 32:
+	default:
+		return nil, errors.New("encryption key must be 16, 24 or 32 bytes")
+	}
+	return key, nil
+}
+
+// IsEncrypted reports whether the data read from r starts with the header of encrypted files.
+// The offset of r is restored.
+func IsEncrypted(r io.ReadSeeker) (bool, error) {
+	buf := make([]byte, len(EncryptedFileHeader))
+	n, err := io.ReadFull(r, buf)
+	if _, e := r.Seek(0, io.SeekStart); e != nil {
+		return false, e
+	}
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return false, err
+	}
+	return bytes.Equal(buf[:n], EncryptedFileHeader), nil
+}
+
+// IsEncryptedFile reports whether the file at path is encrypted.
+func IsEncryptedFile(path string) bool {
+	fp, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer func() {
+		_ = fp.Close()
+	}()
+
+	encrypted, _ := IsEncrypted(fp)
+	return encrypted
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Encrypt returns the contents of an encrypted file holding the data.
+func Encrypt(key []byte, data []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, 0, len(EncryptedFileHeader)+len(nonce)+len(data)+gcm.Overhead())
+	buf = append(buf, EncryptedFileHeader...)
+	buf = append(buf, nonce...)
+	return gcm.Seal(buf, nonce, data, EncryptedFileHeader), nil
+}
+
+// Decrypt returns the data held in the contents of an encrypted file.
+// An error is returned if the key is wrong or the contents have been altered.
+func Decrypt(key []byte, contents []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.HasPrefix(contents, EncryptedFileHeader) || len(contents) < len(EncryptedFileHeader)+gcm.NonceSize() {
+		return nil, errors.New("file is not encrypted")
+	}
+	nonce := contents[len(EncryptedFileHeader) : len(EncryptedFileHeader)+gcm.NonceSize()]
+	data, err := gcm.Open(nil, nonce, contents[len(EncryptedFileHeader)+gcm.NonceSize():], EncryptedFileHeader)
+	if err != nil {
+		return nil, errors.New("decryption failed with the encryption key")
+	}
+	return data, nil
+}
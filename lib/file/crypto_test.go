@@ -0,0 +1,87 @@
+package file
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+)
+
+var parseEncryptionKeyTests = []struct {
+	Key   string
+	Len   int
+	Error string
+}{
+	{
+		Key: base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32)) + "\n",
+		Len: 32,
+	},
+	{
+		Key: base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 16)),
+		Len: 16,
+	},
+	{
+		Key:   base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 20)),
+		Error: "encryption key must be 16, 24 or 32 bytes",
+	},
+	{
+		Key:   "not base64!",
+		Error: "encryption key must be encoded in base64",
+	},
+}
+
+func TestParseEncryptionKey(t *testing.T) {
+	for _, v := range parseEncryptionKeyTests {
+		key, err := ParseEncryptionKey(v.Key)
+		if err != nil {
+			if len(v.Error) < 1 {
+				t.Errorf("unexpected error %q for %q", err, v.Key)
+			} else if err.Error() != v.Error {
+				t.Errorf("error %q, want error %q for %q", err, v.Error, v.Key)
+			}
+			continue
+		}
+		if 0 < len(v.Error) {
+			t.Errorf("no error, want error %q for %q", v.Error, v.Key)
+			continue
+		}
+		if len(key) != v.Len {
+			t.Errorf("key length = %d, want %d for %q", len(key), v.Len, v.Key)
+		}
+	}
+}
+
+func TestEncrypt(t *testing.T) {
+	key := bytes.Repeat([]byte{1}, 32)
+	data := []byte("c1,c2\n1,a\n")
+
+	contents, err := Encrypt(key, data)
+	if err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	if bytes.Contains(contents, data) {
+		t.Errorf("encrypted contents contain the data")
+	}
+	if encrypted, _ := IsEncrypted(bytes.NewReader(contents)); !encrypted {
+		t.Errorf("encrypted contents are not detected")
+	}
+	if encrypted, _ := IsEncrypted(bytes.NewReader(data)); encrypted {
+		t.Errorf("plain data is detected as encrypted contents")
+	}
+
+	result, err := Decrypt(key, contents)
+	if err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	if !bytes.Equal(result, data) {
+		t.Errorf("decrypted data = %q, want %q", result, data)
+	}
+
+	if _, err = Decrypt(bytes.Repeat([]byte{2}, 32), contents); err == nil {
+		t.Errorf("no error, want error for a wrong key")
+	}
+
+	contents[len(contents)-1] ^= 1
+	if _, err = Decrypt(key, contents); err == nil {
+		t.Errorf("no error, want error for altered contents")
+	}
+}
@@ -66,6 +66,20 @@ func (e TimeoutError) Error() string {
 	return e.message
 }
 
+type RetryLimitError struct {
+	message string
+}
+
+func NewRetryLimitError(path string, limit int) error {
+	return &RetryLimitError{
+		message: fmt.Sprintf("file %s: lock retry limit %d exceeded", path, limit),
+	}
+}
+
+func (e RetryLimitError) Error() string {
+	return e.message
+}
+
 type ContextIsDone struct {
 	message string
 }
@@ -80,6 +94,20 @@ func (e ContextIsDone) Error() string {
 	return e.message
 }
 
+type ModifiedError struct {
+	message string
+}
+
+func NewModifiedError(path string) error {
+	return &ModifiedError{
+		message: fmt.Sprintf("file %s has been modified by another process since it was loaded", path),
+	}
+}
+
+func (e ModifiedError) Error() string {
+	return e.message
+}
+
 type ForcedUnlockError struct {
 	Errors []error
 }
@@ -3,6 +3,8 @@ package file
 import (
 	"context"
 	"fmt"
+	"hash/crc32"
+	"io"
 	"os"
 	"time"
 
@@ -29,9 +31,16 @@ type Handler struct {
 	tempFilePath string
 	tempFp       *os.File
 
+	// Status and checksum of the file opened to be updated
+	modTime  time.Time
+	size     int64
+	checksum uint32
+
 	closed bool
 }
 
+var checksumTable = crc32.MakeTable(crc32.Castagnoli)
+
 func NewHandlerForRead(ctx context.Context, container *Container, path string, defaultWaitTimeout time.Duration, retryDelay time.Duration) (*Handler, error) {
 	tctx, cancel := GetTimeoutContext(ctx, defaultWaitTimeout)
 	defer cancel()
@@ -41,11 +50,11 @@ func NewHandlerForRead(ctx context.Context, container *Container, path string, d
 		openType: ForRead,
 	}
 
-	if err := h.PrepareToRead(tctx, retryDelay); err != nil {
+	if err := h.PrepareToRead(tctx, retryDelay, container.RetryPolicy); err != nil {
 		return h, err
 	}
 
-	fp, err := file.OpenToReadContext(tctx, retryDelay, h.path)
+	fp, err := file.OpenContext(tctx, retryDelay, h.path, os.O_RDONLY, container.RetryPolicy.rlockContext)
 	if err != nil {
 		return h, ParseError(err)
 	}
@@ -77,6 +86,13 @@ func NewHandlerForCreate(container *Container, path string) (*Handler, error) {
 	}
 	h.fp = fp
 
+	if err := h.TryCreateTempFile(); err != nil {
+		if e := h.close(); e != nil {
+			err = NewCompositeError(err, e)
+		}
+		return h, err
+	}
+
 	if err := container.Add(h.path, h); err != nil {
 		return h, err
 	}
@@ -96,7 +112,7 @@ func NewHandlerForUpdate(ctx context.Context, container *Container, path string,
 		return h, NewIOError(fmt.Sprintf("file %s does not exist", h.path))
 	}
 
-	if err := h.CreateLockFileContext(tctx, retryDelay); err != nil {
+	if err := h.CreateLockFileContext(tctx, retryDelay, container.RetryPolicy); err != nil {
 		return h, err
 	}
 
@@ -111,6 +127,13 @@ func NewHandlerForUpdate(ctx context.Context, container *Container, path string,
 	}
 	h.fp = fp
 
+	if err := h.recordState(); err != nil {
+		if e := h.close(); e != nil {
+			err = NewCompositeError(err, e)
+		}
+		return h, err
+	}
+
 	if err := h.TryCreateTempFile(); err != nil {
 		return h, err
 	}
@@ -121,6 +144,69 @@ func NewHandlerForUpdate(ctx context.Context, container *Container, path string,
 	return h, nil
 }
 
+// recordState records the modification time, the size and the checksum of the file,
+// so that modifications by other processes are detected before the file is replaced.
+func (h *Handler) recordState() error {
+	stat, err := h.fp.Stat()
+	if err != nil {
+		return NewIOError(err.Error())
+	}
+
+	sum, err := fileChecksum(h.fp)
+	if err != nil {
+		return NewIOError(err.Error())
+	}
+	if _, err = h.fp.Seek(0, io.SeekStart); err != nil {
+		return NewIOError(err.Error())
+	}
+
+	h.modTime = stat.ModTime()
+	h.size = stat.Size()
+	h.checksum = sum
+	return nil
+}
+
+// VerifyUnmodified returns an error if the file opened to be updated has been modified by another process.
+// The file is regarded as unmodified if the modification time and the size have not changed,
+// or the contents have the same checksum as the contents when the file was opened.
+func (h *Handler) VerifyUnmodified() error {
+	if h.openType != ForUpdate {
+		return nil
+	}
+
+	stat, err := os.Stat(h.path)
+	if err != nil {
+		return NewModifiedError(h.path)
+	}
+	if stat.ModTime().Equal(h.modTime) && stat.Size() == h.size {
+		return nil
+	}
+
+	if stat.Size() == h.size {
+		fp, err := os.Open(h.path)
+		if err != nil {
+			return NewIOError(err.Error())
+		}
+		sum, err := fileChecksum(fp)
+		_ = fp.Close()
+		if err != nil {
+			return NewIOError(err.Error())
+		}
+		if sum == h.checksum {
+			return nil
+		}
+	}
+	return NewModifiedError(h.path)
+}
+
+func fileChecksum(r io.Reader) (uint32, error) {
+	hash := crc32.New(checksumTable)
+	if _, err := io.Copy(hash, r); err != nil {
+		return 0, err
+	}
+	return hash.Sum32(), nil
+}
+
 func (h *Handler) Path() string {
 	return h.path
 }
@@ -129,8 +215,11 @@ func (h *Handler) FileForRead() *os.File {
 	return h.fp
 }
 
+// FileForUpdate returns the temporary file that the contents are written to.
+// The file is replaced with the temporary file when the handler is committed.
 func (h *Handler) FileForUpdate() *os.File {
-	if h.openType == ForUpdate {
+	switch h.openType {
+	case ForCreate, ForUpdate:
 		return h.tempFp
 	}
 	return h.fp
@@ -184,6 +273,26 @@ func (h *Handler) close() error {
 	return nil
 }
 
+// detach closes the files of the handler without removing the temporary file and the lock file,
+// so that the file is replaced by Recover.
+func (h *Handler) detach() error {
+	if h.closed {
+		return nil
+	}
+
+	for _, fp := range []**os.File{&h.fp, &h.tempFp, &h.lockFileFp} {
+		if *fp != nil {
+			if err := file.Close(*fp); err != nil {
+				return err
+			}
+			*fp = nil
+		}
+	}
+
+	h.closed = true
+	return nil
+}
+
 func (h *Handler) commit() error {
 	if h.closed {
 		return nil
@@ -196,21 +305,8 @@ func (h *Handler) commit() error {
 		h.fp = nil
 	}
 
-	if h.openType == ForUpdate {
-		if h.tempFp != nil {
-			if err := file.Close(h.tempFp); err != nil {
-				return err
-			}
-			h.tempFp = nil
-		}
-
-		if Exists(h.path) {
-			if err := os.Remove(h.path); err != nil {
-				return err
-			}
-		}
-
-		if err := os.Rename(h.tempFilePath, h.path); err != nil {
+	if h.openType == ForCreate || h.openType == ForUpdate {
+		if err := h.replaceWithTempFile(); err != nil {
 			return err
 		}
 	} else {
@@ -245,6 +341,44 @@ func (h *Handler) commit() error {
 	return nil
 }
 
+// prepareCommit flushes the contents of the temporary file to the storage and closes the temporary file,
+// so that the file can be replaced with the temporary file.
+func (h *Handler) prepareCommit() error {
+	if h.tempFp == nil {
+		return nil
+	}
+
+	if err := h.tempFp.Sync(); err != nil {
+		return err
+	}
+
+	if h.openType == ForUpdate {
+		if stat, err := os.Stat(h.path); err == nil {
+			if err = h.tempFp.Chmod(stat.Mode().Perm()); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := file.Close(h.tempFp); err != nil {
+		return err
+	}
+	h.tempFp = nil
+	return nil
+}
+
+// replaceWithTempFile replaces the file with the temporary file.
+//
+// The contents of the temporary file are flushed to the storage before the file is renamed, and the file
+// is replaced by the rename, which is atomic, so the file has either the old contents or the new contents
+// even if the process is interrupted while committing.
+func (h *Handler) replaceWithTempFile() error {
+	if err := h.prepareCommit(); err != nil {
+		return err
+	}
+	return os.Rename(h.tempFilePath, h.path)
+}
+
 func (h *Handler) closeWithErrors() error {
 	if h.closed {
 		return nil
@@ -300,21 +434,18 @@ func (h *Handler) closeWithErrors() error {
 	return nil
 }
 
-func (h *Handler) CreateLockFileContext(ctx context.Context, retryDelay time.Duration) error {
+func (h *Handler) CreateLockFileContext(ctx context.Context, retryDelay time.Duration, policy RetryPolicy) error {
 	if ctx.Err() != nil {
 		return NewContextIsDone(ctx.Err().Error())
 	}
 
-	for {
+	for retries := 0; ; retries++ {
 		if err := h.TryCreateLockFile(); err == nil {
 			return nil
 		}
 
-		select {
-		case <-ctx.Done():
-			return NewTimeoutError(h.path)
-		case <-time.After(retryDelay):
-			// try again
+		if err := policy.wait(ctx, h.path, retryDelay, retries); err != nil {
+			return err
 		}
 	}
 }
@@ -357,7 +488,7 @@ func (h *Handler) TryCreateTempFile() error {
 	return nil
 }
 
-func (h *Handler) PrepareToRead(ctx context.Context, retryDelay time.Duration) error {
+func (h *Handler) PrepareToRead(ctx context.Context, retryDelay time.Duration, policy RetryPolicy) error {
 	if ctx.Err() != nil {
 		return NewContextIsDone(ctx.Err().Error())
 	}
@@ -368,16 +499,13 @@ func (h *Handler) PrepareToRead(ctx context.Context, retryDelay time.Duration) e
 
 	lockFilePath := LockFilePath(h.path)
 
-	for {
+	for retries := 0; ; retries++ {
 		if _, err := os.Stat(lockFilePath); err != nil {
 			return nil
 		}
 
-		select {
-		case <-ctx.Done():
-			return NewTimeoutError(h.path)
-		case <-time.After(retryDelay):
-			// try again
+		if err := policy.wait(ctx, h.path, retryDelay, retries); err != nil {
+			return err
 		}
 	}
 }
@@ -2,7 +2,10 @@ package file
 
 import (
 	"context"
+	"io/ioutil"
+	"os"
 	"testing"
+	"time"
 )
 
 func TestHandler(t *testing.T) {
@@ -56,9 +59,9 @@ func TestHandler(t *testing.T) {
 		t.Fatalf("filename to read = %q, expect %q", ch.FileForRead().Name(), fileForCreate)
 	}
 
-	if ch.FileForUpdate().Name() != fileForCreate {
+	if ch.FileForUpdate().Name() != TempFilePath(fileForCreate) {
 		_ = container.Close(uh)
-		t.Fatalf("filename to update = %q, expect %q", ch.FileForUpdate().Name(), fileForCreate)
+		t.Fatalf("filename to update = %q, expect %q", ch.FileForUpdate().Name(), TempFilePath(fileForCreate))
 	}
 
 	rh, err = NewHandlerForRead(ctx, container, fileForCreate, waitTimeoutForTests, retryDelayForTests)
@@ -137,3 +140,105 @@ func TestHandler(t *testing.T) {
 	}
 	_ = container.Close(rh)
 }
+
+func TestHandler_Commit(t *testing.T) {
+	fileForUpdate := GetTestFilePath("commit_update.txt")
+	fileForCreate := GetTestFilePath("commit_create.txt")
+	if err := ioutil.WriteFile(fileForUpdate, []byte("old"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	container := NewContainer()
+
+	uh, err := NewHandlerForUpdate(ctx, container, fileForUpdate, waitTimeoutForTests, retryDelayForTests)
+	if err != nil {
+		t.Fatalf("error = %#v, expect no error", err)
+	}
+	ch, err := NewHandlerForCreate(container, fileForCreate)
+	if err != nil {
+		_ = container.Close(uh)
+		t.Fatalf("error = %#v, expect no error", err)
+	}
+
+	_, _ = uh.FileForUpdate().WriteString("new")
+	_, _ = ch.FileForUpdate().WriteString("created")
+
+	if b, _ := ioutil.ReadFile(fileForUpdate); string(b) != "old" {
+		t.Errorf("contents before commit = %q, expect %q", string(b), "old")
+	}
+	if b, _ := ioutil.ReadFile(fileForCreate); string(b) != "" {
+		t.Errorf("contents before commit = %q, expect %q", string(b), "")
+	}
+
+	if err = container.Commit(uh); err != nil {
+		t.Fatalf("error = %#v, expect no error", err)
+	}
+	if err = container.Commit(ch); err != nil {
+		t.Fatalf("error = %#v, expect no error", err)
+	}
+
+	if b, _ := ioutil.ReadFile(fileForUpdate); string(b) != "new" {
+		t.Errorf("contents = %q, expect %q", string(b), "new")
+	}
+	if b, _ := ioutil.ReadFile(fileForCreate); string(b) != "created" {
+		t.Errorf("contents = %q, expect %q", string(b), "created")
+	}
+	if stat, err := os.Stat(fileForUpdate); err != nil {
+		t.Errorf("error = %#v, expect no error", err)
+	} else if stat.Mode().Perm() != 0600 {
+		t.Errorf("mode = %v, expect %v", stat.Mode().Perm(), os.FileMode(0600))
+	}
+	for _, fpath := range []string{fileForUpdate, fileForCreate} {
+		if Exists(TempFilePath(fpath)) || Exists(LockFilePath(fpath)) {
+			t.Errorf("temporary file or lock file for %q is not removed", fpath)
+		}
+	}
+}
+
+func TestHandler_VerifyUnmodified(t *testing.T) {
+	fpath := GetTestFilePath("verify_unmodified.txt")
+	if err := ioutil.WriteFile(fpath, []byte("contents"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	container := NewContainer()
+	h, err := NewHandlerForUpdate(context.Background(), container, fpath, waitTimeoutForTests, retryDelayForTests)
+	if err != nil {
+		t.Fatalf("error = %#v, expect no error", err)
+	}
+	defer func() {
+		_ = container.Close(h)
+		_ = os.Remove(fpath)
+	}()
+
+	if err = h.VerifyUnmodified(); err != nil {
+		t.Errorf("error = %#v, expect no error", err)
+	}
+
+	later := time.Now().Add(time.Hour)
+	if err = os.Chtimes(fpath, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if err = h.VerifyUnmodified(); err != nil {
+		t.Errorf("error = %#v, expect no error for the file with the same contents", err)
+	}
+
+	if err = ioutil.WriteFile(fpath, []byte("modified"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err = h.VerifyUnmodified(); err == nil {
+		t.Errorf("no error, expect ModifiedError for the file with the same size")
+	} else if _, ok := err.(*ModifiedError); !ok {
+		t.Errorf("error = %#v, expect ModifiedError", err)
+	}
+
+	if err = ioutil.WriteFile(fpath, []byte("appended contents"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err = h.VerifyUnmodified(); err == nil {
+		t.Errorf("no error, expect ModifiedError for the file with a different size")
+	} else if _, ok := err.(*ModifiedError); !ok {
+		t.Errorf("error = %#v, expect ModifiedError", err)
+	}
+}
@@ -0,0 +1,324 @@
+package file
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mithrandie/go-file/v2"
+)
+
+// JournalEntry is a file to be replaced with its temporary file in a commit.
+type JournalEntry struct {
+	Path     string `json:"path"`
+	TempPath string `json:"temp_path"`
+	LockPath string `json:"lock_path"`
+	Created  bool   `json:"created"`
+
+	// Prepared marker of the coordinated commit, see Coordinator
+	Coordinator string `json:"coordinator,omitempty"`
+}
+
+// Journal records the files to be replaced in a commit, so that the commit interrupted by a crash
+// can be completed or rolled back by Recover.
+//
+// The journal is written before any temporary file is flushed. After all the temporary files are flushed,
+// a commit marker is created next to the journal, and then the files are replaced.
+// If the commit marker exists, then Recover replaces the remaining files with the temporary files.
+// Otherwise, Recover discards the temporary files and the files are left unchanged.
+//
+// The journal is locked while the process is committing, so that Recover ignores the commits in progress.
+type Journal struct {
+	path     string
+	fp       *os.File
+	handlers []*Handler
+}
+
+// WriteJournal writes the journal of the commit of the handlers in dir.
+func WriteJournal(dir string, handlers []*Handler) (*Journal, error) {
+	return WriteCoordinatedJournal(dir, handlers, nil)
+}
+
+// WriteCoordinatedJournal writes the journal of the commit of the handlers coordinated with other processes.
+// If the coordinator is nil, then the commit is not coordinated.
+func WriteCoordinatedJournal(dir string, handlers []*Handler, coordinator *Coordinator) (*Journal, error) {
+	markerPath := ""
+	if coordinator != nil {
+		markerPath = coordinator.MarkerPath()
+	}
+
+	entries := make([]JournalEntry, 0, len(handlers))
+	for _, h := range handlers {
+		if h.openType == ForRead {
+			continue
+		}
+		entries = append(entries, JournalEntry{
+			Path:     h.path,
+			TempPath: h.tempFilePath,
+			LockPath: h.lockFilePath,
+			Created:  h.openType == ForCreate,
+
+			Coordinator: markerPath,
+		})
+	}
+
+	buf, err := json.Marshal(entries)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = os.MkdirAll(dir, 0700); err != nil {
+		return nil, NewIOError(fmt.Sprintf("unable to create journal directory %s", dir))
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("%d-%d%s", os.Getpid(), time.Now().UnixNano(), JournalFileSuffix))
+	fp, err := file.Create(path)
+	if err != nil {
+		return nil, NewIOError(fmt.Sprintf("unable to create journal %s", path))
+	}
+
+	j := &Journal{
+		path:     path,
+		fp:       fp,
+		handlers: handlers,
+	}
+
+	if _, err = fp.Write(buf); err == nil {
+		err = fp.Sync()
+	}
+	if err != nil {
+		err = NewIOError(fmt.Sprintf("unable to write journal %s", path))
+		if e := j.Close(); e != nil {
+			err = NewCompositeError(err, e)
+		}
+		return nil, err
+	}
+	return j, nil
+}
+
+// Prepare flushes all the temporary files.
+func (j *Journal) Prepare() error {
+	if j == nil {
+		return nil
+	}
+
+	for _, h := range j.handlers {
+		if err := h.prepareCommit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Commit flushes all the temporary files and creates the commit marker.
+// After that, an interrupted commit is completed by Recover.
+func (j *Journal) Commit() error {
+	if j == nil {
+		return nil
+	}
+
+	if err := j.Prepare(); err != nil {
+		return err
+	}
+
+	fp, err := os.Create(CommitMarkerPath(j.path))
+	if err != nil {
+		return err
+	}
+	err = fp.Sync()
+	if e := fp.Close(); err == nil {
+		err = e
+	}
+	return err
+}
+
+// Close removes the journal and the commit marker.
+func (j *Journal) Close() error {
+	if j == nil {
+		return nil
+	}
+
+	// The journal is removed while it is locked if the system allows it, so that no other process
+	// recovers the commit after it is unlocked.
+	removed := os.Remove(j.path) == nil
+	if j.fp != nil {
+		if err := file.Close(j.fp); err != nil {
+			return err
+		}
+		j.fp = nil
+	}
+	if !removed {
+		if err := removeIfExists(j.path); err != nil {
+			return err
+		}
+	}
+	return removeIfExists(CommitMarkerPath(j.path))
+}
+
+// Release closes the journal without removing it, so that the commit is completed by Recover.
+// It is used when some of the files cannot be replaced after the commit marker is created.
+// The handlers of the files not replaced yet are detached, and their temporary files and lock files
+// are left to be used by Recover.
+func (j *Journal) Release() error {
+	if j == nil {
+		return nil
+	}
+
+	for _, h := range j.handlers {
+		if err := h.detach(); err != nil {
+			return err
+		}
+	}
+	if j.fp != nil {
+		if err := file.Close(j.fp); err != nil {
+			return err
+		}
+		j.fp = nil
+	}
+	return nil
+}
+
+// CommitMarkerPath returns the path of the commit marker of the journal.
+func CommitMarkerPath(journalPath string) string {
+	return strings.TrimSuffix(journalPath, JournalFileSuffix) + CommitMarkerSuffix
+}
+
+// Recover completes or rolls back the commits interrupted by crashes of processes, which journals are left in dir.
+// The files replaced with new contents are returned as committed, and the files left unchanged are returned
+// as rolled back.
+func Recover(dir string) (committed []string, rolledBack []string, err error) {
+	files, e := ioutil.ReadDir(dir)
+	if e != nil {
+		return nil, nil, nil
+	}
+
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != JournalFileSuffix {
+			continue
+		}
+
+		path := filepath.Join(dir, f.Name())
+		c, r, e := recoverCommit(path)
+		committed = append(committed, c...)
+		rolledBack = append(rolledBack, r...)
+		if e != nil {
+			return committed, rolledBack, NewIOError(fmt.Sprintf("failed to recover commit recorded in %s: %s", path, e.Error()))
+		}
+	}
+	return committed, rolledBack, nil
+}
+
+func recoverCommit(path string) (committed []string, rolledBack []string, err error) {
+	fp, err := file.TryOpenToUpdate(path)
+	if err != nil {
+		// The commit is in progress.
+		return nil, nil, nil
+	}
+
+	j := &Journal{
+		path: path,
+		fp:   fp,
+	}
+
+	var entries []JournalEntry
+	buf, err := ioutil.ReadAll(fp)
+	if err != nil {
+		_ = file.Close(fp)
+		return nil, nil, err
+	}
+	if err = json.Unmarshal(buf, &entries); err != nil {
+		// The process was interrupted while writing the journal, and no file has been changed.
+		return nil, nil, j.Close()
+	}
+
+	isCommitted := Exists(CommitMarkerPath(path))
+
+	// A coordinated commit follows the decision made by the participants.
+	coordinator := ""
+	if 0 < len(entries) && 0 < len(entries[0].Coordinator) {
+		coordinator = entries[0].Coordinator
+		if !isCommitted {
+			decision, ok := CoordinatedDecision(coordinator)
+			if !ok {
+				// The other participants have not made the decision yet.
+				return nil, nil, file.Close(fp)
+			}
+			isCommitted = decision == DecisionCommit
+		}
+	}
+
+	for _, entry := range entries {
+		if !isStaleLockFile(entry.LockPath) {
+			// The file has been already replaced and unlocked, or the lock file is used by another process.
+			if isCommitted {
+				committed = append(committed, entry.Path)
+			}
+			continue
+		}
+
+		if isCommitted {
+			if Exists(entry.TempPath) {
+				if err = os.Rename(entry.TempPath, entry.Path); err != nil {
+					_ = file.Close(fp)
+					return committed, rolledBack, err
+				}
+			}
+			committed = append(committed, entry.Path)
+		} else {
+			if err = removeIfExists(entry.TempPath); err != nil {
+				_ = file.Close(fp)
+				return committed, rolledBack, err
+			}
+			if entry.Created {
+				if stat, e := os.Stat(entry.Path); e == nil && stat.Size() == 0 {
+					if err = os.Remove(entry.Path); err != nil {
+						_ = file.Close(fp)
+						return committed, rolledBack, err
+					}
+				}
+			}
+			rolledBack = append(rolledBack, entry.Path)
+		}
+
+		if err = removeIfExists(entry.LockPath); err != nil {
+			_ = file.Close(fp)
+			return committed, rolledBack, err
+		}
+	}
+
+	if 0 < len(coordinator) {
+		if err = leaveCoordination(coordinator); err != nil {
+			_ = file.Close(fp)
+			return committed, rolledBack, err
+		}
+	}
+	return committed, rolledBack, j.Close()
+}
+
+// isStaleLockFile reports whether the lock file exists and is not locked by any process.
+func isStaleLockFile(path string) bool {
+	if !Exists(path) {
+		return false
+	}
+
+	fp, err := file.TryOpenToUpdate(path)
+	if err != nil {
+		return false
+	}
+	_ = file.Close(fp)
+	return true
+}
+
+func removeIfExists(path string) error {
+	if len(path) < 1 {
+		return nil
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
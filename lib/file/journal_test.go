@@ -0,0 +1,293 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestJournal(t *testing.T) {
+	dir := GetTestFilePath("journal")
+	fpath := GetTestFilePath("journal_update.txt")
+	if err := ioutil.WriteFile(fpath, []byte("old"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = os.RemoveAll(dir)
+		_ = os.Remove(fpath)
+	}()
+
+	container := NewContainer()
+	h, err := NewHandlerForUpdate(context.Background(), container, fpath, waitTimeoutForTests, retryDelayForTests)
+	if err != nil {
+		t.Fatalf("error = %#v, expect no error", err)
+	}
+	_, _ = h.FileForUpdate().WriteString("new")
+
+	journal, err := WriteJournal(dir, []*Handler{h})
+	if err != nil {
+		_ = container.Close(h)
+		t.Fatalf("error = %#v, expect no error", err)
+	}
+
+	var entries []JournalEntry
+	if b, err := ioutil.ReadFile(journal.path); err != nil {
+		t.Errorf("error = %#v, expect no error", err)
+	} else if err = json.Unmarshal(b, &entries); err != nil {
+		t.Errorf("error = %#v, expect no error", err)
+	} else {
+		expect := []JournalEntry{{Path: fpath, TempPath: TempFilePath(fpath), LockPath: LockFilePath(fpath)}}
+		if !reflect.DeepEqual(entries, expect) {
+			t.Errorf("journal entries = %v, expect %v", entries, expect)
+		}
+	}
+
+	if c, r, err := Recover(dir); err != nil || c != nil || r != nil {
+		t.Errorf("recover = %v, %v, %v, expect the commit in progress to be ignored", c, r, err)
+	}
+
+	if err = journal.Commit(); err != nil {
+		t.Fatalf("error = %#v, expect no error", err)
+	}
+	if !Exists(CommitMarkerPath(journal.path)) {
+		t.Errorf("commit marker is not created")
+	}
+
+	if err = container.Commit(h); err != nil {
+		t.Fatalf("error = %#v, expect no error", err)
+	}
+	if err = journal.Close(); err != nil {
+		t.Fatalf("error = %#v, expect no error", err)
+	}
+
+	if b, _ := ioutil.ReadFile(fpath); string(b) != "new" {
+		t.Errorf("contents = %q, expect %q", string(b), "new")
+	}
+	if files, _ := ioutil.ReadDir(dir); len(files) != 0 {
+		t.Errorf("%d files are left in the journal directory, expect no file", len(files))
+	}
+}
+
+func TestJournal_Release(t *testing.T) {
+	dir := GetTestFilePath("journal")
+	fpath := GetTestFilePath("journal_release.txt")
+	if err := ioutil.WriteFile(fpath, []byte("old"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = os.RemoveAll(dir)
+		_ = os.Remove(fpath)
+		_ = os.Remove(TempFilePath(fpath))
+		_ = os.Remove(LockFilePath(fpath))
+	}()
+
+	container := NewContainer()
+	h, err := NewHandlerForUpdate(context.Background(), container, fpath, waitTimeoutForTests, retryDelayForTests)
+	if err != nil {
+		t.Fatalf("error = %#v, expect no error", err)
+	}
+	_, _ = h.FileForUpdate().WriteString("new")
+
+	journal, err := WriteJournal(dir, []*Handler{h})
+	if err != nil {
+		_ = container.Close(h)
+		t.Fatalf("error = %#v, expect no error", err)
+	}
+	if err = journal.Commit(); err != nil {
+		t.Fatalf("error = %#v, expect no error", err)
+	}
+
+	if err = journal.Release(); err != nil {
+		t.Fatalf("error = %#v, expect no error", err)
+	}
+	if err = container.Close(h); err != nil {
+		t.Fatalf("error = %#v, expect no error", err)
+	}
+	if !Exists(journal.path) || !Exists(CommitMarkerPath(journal.path)) {
+		t.Fatalf("journal is removed, expect it to be left")
+	}
+	if !Exists(TempFilePath(fpath)) || !Exists(LockFilePath(fpath)) {
+		t.Fatalf("temporary file or lock file is removed, expect them to be left")
+	}
+
+	if c, r, err := Recover(dir); err != nil || !reflect.DeepEqual(c, []string{fpath}) || r != nil {
+		t.Errorf("recover = %v, %v, %v, expect %v to be committed", c, r, err, []string{fpath})
+	}
+	if b, _ := ioutil.ReadFile(fpath); string(b) != "new" {
+		t.Errorf("contents = %q, expect %q", string(b), "new")
+	}
+	if files, _ := ioutil.ReadDir(dir); len(files) != 0 {
+		t.Errorf("%d files are left in the journal directory, expect no file", len(files))
+	}
+}
+
+var recoverTests = []struct {
+	Name       string
+	Journal    string
+	Committed  bool
+	Created    bool
+	Temp       bool
+	Lock       bool
+	Decision   string
+	Contents   string
+	Exists     bool
+	Recovered  []string
+	RolledBack []string
+}{
+	{
+		Name:      "Interrupted Commit",
+		Committed: true,
+		Temp:      true,
+		Lock:      true,
+		Contents:  "new",
+		Exists:    true,
+		Recovered: []string{"recover.txt"},
+	},
+	{
+		Name:      "Interrupted Commit Already Replaced",
+		Committed: true,
+		Contents:  "old",
+		Exists:    true,
+		Recovered: []string{"recover.txt"},
+	},
+	{
+		Name:       "Interrupted Before Commit",
+		Temp:       true,
+		Lock:       true,
+		Contents:   "old",
+		Exists:     true,
+		RolledBack: []string{"recover.txt"},
+	},
+	{
+		Name:       "Interrupted Before Creation",
+		Created:    true,
+		Temp:       true,
+		Lock:       true,
+		Exists:     false,
+		RolledBack: []string{"recover.txt"},
+	},
+	{
+		Name:      "Interrupted Coordinated Commit",
+		Temp:      true,
+		Lock:      true,
+		Decision:  DecisionCommit,
+		Contents:  "new",
+		Exists:    true,
+		Recovered: []string{"recover.txt"},
+	},
+	{
+		Name:       "Interrupted Coordinated Commit Aborted",
+		Temp:       true,
+		Lock:       true,
+		Decision:   DecisionAbort,
+		Contents:   "old",
+		Exists:     true,
+		RolledBack: []string{"recover.txt"},
+	},
+	{
+		Name:     "Broken Journal",
+		Journal:  "[{\"path\":",
+		Temp:     true,
+		Lock:     true,
+		Contents: "old",
+		Exists:   true,
+	},
+}
+
+func TestRecover(t *testing.T) {
+	dir := GetTestFilePath("recover")
+	coordinatorDir := GetTestFilePath("recover_coordinator")
+	fpath := GetTestFilePath("recover.txt")
+	defer func() {
+		_ = os.RemoveAll(dir)
+		_ = os.RemoveAll(coordinatorDir)
+		_ = os.Remove(fpath)
+		_ = os.Remove(TempFilePath(fpath))
+		_ = os.Remove(LockFilePath(fpath))
+	}()
+
+	for _, v := range recoverTests {
+		_ = os.RemoveAll(dir)
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			t.Fatal(err)
+		}
+
+		contents := "old"
+		if v.Created {
+			contents = ""
+		}
+		if err := ioutil.WriteFile(fpath, []byte(contents), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if v.Temp {
+			_ = ioutil.WriteFile(TempFilePath(fpath), []byte("new"), 0600)
+		}
+		if v.Lock {
+			_ = ioutil.WriteFile(LockFilePath(fpath), nil, 0600)
+		}
+
+		markerPath := ""
+		if 0 < len(v.Decision) {
+			c := NewCoordinator(coordinatorDir, "token", 2)
+			markerPath = c.MarkerPath()
+			_ = os.MkdirAll(filepath.Dir(markerPath), 0700)
+			_ = ioutil.WriteFile(markerPath, nil, 0600)
+			_ = ioutil.WriteFile(filepath.Join(filepath.Dir(markerPath), DecisionFileName), []byte(v.Decision), 0600)
+		}
+
+		journalPath := filepath.Join(dir, "1-1"+JournalFileSuffix)
+		journal := v.Journal
+		if len(journal) < 1 {
+			b, _ := json.Marshal([]JournalEntry{{Path: fpath, TempPath: TempFilePath(fpath), LockPath: LockFilePath(fpath), Created: v.Created, Coordinator: markerPath}})
+			journal = string(b)
+		}
+		_ = ioutil.WriteFile(journalPath, []byte(journal), 0600)
+		if v.Committed {
+			_ = ioutil.WriteFile(CommitMarkerPath(journalPath), nil, 0600)
+		}
+
+		committed, rolledBack, err := Recover(dir)
+		if err != nil {
+			t.Errorf("%s: unexpected error %q", v.Name, err)
+			continue
+		}
+
+		var expectCommitted, expectRolledBack []string
+		for _, s := range v.Recovered {
+			expectCommitted = append(expectCommitted, GetTestFilePath(s))
+		}
+		for _, s := range v.RolledBack {
+			expectRolledBack = append(expectRolledBack, GetTestFilePath(s))
+		}
+		if !reflect.DeepEqual(committed, expectCommitted) {
+			t.Errorf("%s: committed = %v, expect %v", v.Name, committed, expectCommitted)
+		}
+		if !reflect.DeepEqual(rolledBack, expectRolledBack) {
+			t.Errorf("%s: rolled back = %v, expect %v", v.Name, rolledBack, expectRolledBack)
+		}
+
+		if b, err := ioutil.ReadFile(fpath); err != nil {
+			if v.Exists {
+				t.Errorf("%s: file does not exist", v.Name)
+			}
+		} else if !v.Exists {
+			t.Errorf("%s: file exists, expect to be removed", v.Name)
+		} else if string(b) != v.Contents {
+			t.Errorf("%s: contents = %q, expect %q", v.Name, string(b), v.Contents)
+		}
+
+		if len(v.Journal) < 1 && (Exists(TempFilePath(fpath)) || Exists(LockFilePath(fpath))) {
+			t.Errorf("%s: temporary file or lock file is not removed", v.Name)
+		}
+		if files, _ := ioutil.ReadDir(dir); len(files) != 0 {
+			t.Errorf("%s: %d files are left in the journal directory, expect no file", v.Name, len(files))
+		}
+		if 0 < len(markerPath) && Exists(filepath.Dir(markerPath)) {
+			t.Errorf("%s: coordinator directory is not removed", v.Name)
+		}
+	}
+}
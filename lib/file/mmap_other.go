@@ -0,0 +1,13 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !solaris
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!solaris
+
+package file
+
+import (
+	"os"
+)
+
+// Map always returns an error because memory-mapped files are not supported on this platform.
+func Map(fp *os.File) ([]byte, error) {
+	return nil, NewIOError("memory-mapped files are not supported on this platform")
+}
@@ -0,0 +1,29 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package file
+
+import (
+	"os"
+	"syscall"
+)
+
+// Map maps the whole regular file into memory as read-only.
+// The mapping is never released so that the slices and the strings referring to the mapped memory
+// remain valid until the process exits.
+// If the file cannot be mapped, then an error is returned and the file must be read in the usual way.
+func Map(fp *os.File) ([]byte, error) {
+	stat, err := fp.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if !stat.Mode().IsRegular() || stat.Size() < 1 || int64(int(stat.Size())) != stat.Size() {
+		return nil, NewIOError("file " + fp.Name() + " cannot be mapped into memory")
+	}
+
+	data, err := syscall.Mmap(int(fp.Fd()), 0, int(stat.Size()), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, NewIOError(err.Error())
+	}
+	return data, nil
+}
@@ -0,0 +1,40 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package file
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestMap(t *testing.T) {
+	path := GetTestFilePath("mmap.txt")
+	if err := ioutil.WriteFile(path, []byte("mapped data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fp, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := Map(fp)
+	_ = fp.Close()
+	if err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	if string(data) != "mapped data" {
+		t.Errorf("data = %q, want %q", string(data), "mapped data")
+	}
+
+	fp, err = os.Open(GetTestFilePath("open.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = Map(fp)
+	_ = fp.Close()
+	if err == nil {
+		t.Errorf("no error, want error for an empty file")
+	}
+}
@@ -0,0 +1,114 @@
+package file
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mithrandie/go-file/v2"
+)
+
+// MaxRetryDelay is the upper limit of the delays between retries growing with ExponentialBackoff and JitterBackoff.
+const MaxRetryDelay = time.Second
+
+type Backoff int
+
+const (
+	// FixedBackoff waits for the same delay before every retry.
+	FixedBackoff Backoff = iota
+	// ExponentialBackoff doubles the delay after every retry.
+	ExponentialBackoff
+	// JitterBackoff waits for a random delay between the initial delay and the delay of ExponentialBackoff,
+	// so that processes waiting for the same file do not retry at the same time.
+	JitterBackoff
+)
+
+var BackoffLiteral = map[Backoff]string{
+	FixedBackoff:       "FIXED",
+	ExponentialBackoff: "EXPONENTIAL",
+	JitterBackoff:      "JITTER",
+}
+
+func (b Backoff) String() string {
+	return BackoffLiteral[b]
+}
+
+func ParseBackoff(s string) (Backoff, error) {
+	switch strings.ToUpper(s) {
+	case "FIXED":
+		return FixedBackoff, nil
+	case "EXPONENTIAL":
+		return ExponentialBackoff, nil
+	case "JITTER":
+		return JitterBackoff, nil
+	}
+	return FixedBackoff, errors.New("backoff must be one of FIXED|EXPONENTIAL|JITTER")
+}
+
+// RetryPolicy determines how long to wait before trying to lock a file again.
+type RetryPolicy struct {
+	// Maximum number of retries. A negative number means no limit, and retries continue until the wait timeout.
+	Limit   int
+	Backoff Backoff
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	Limit:   -1,
+	Backoff: FixedBackoff,
+}
+
+// Delay returns the delay before the retry following the retries already made,
+// where retryDelay is the delay before the first retry.
+func (p RetryPolicy) Delay(retryDelay time.Duration, retries int) time.Duration {
+	if p.Backoff == FixedBackoff || retryDelay <= 0 {
+		return retryDelay
+	}
+
+	d := retryDelay
+	for i := 0; i < retries && d < MaxRetryDelay; i++ {
+		d = d * 2
+	}
+	if MaxRetryDelay < d && retryDelay < MaxRetryDelay {
+		d = MaxRetryDelay
+	}
+
+	if p.Backoff == JitterBackoff && retryDelay < d {
+		d = retryDelay + time.Duration(rand.Int63n(int64(d-retryDelay)+1))
+	}
+	return d
+}
+
+// wait waits for the delay before the next retry of locking the file at path.
+// An error is returned if the retry limit is reached or the context is done before the delay.
+func (p RetryPolicy) wait(ctx context.Context, path string, retryDelay time.Duration, retries int) error {
+	if -1 < p.Limit && p.Limit <= retries {
+		return NewRetryLimitError(path, p.Limit)
+	}
+
+	select {
+	case <-ctx.Done():
+		return NewTimeoutError(path)
+	case <-time.After(p.Delay(retryDelay, retries)):
+		return nil
+	}
+}
+
+// rlockContext places the shared lock on the file, retrying in accordance with the policy.
+func (p RetryPolicy) rlockContext(ctx context.Context, retryDelay time.Duration, fp *os.File) error {
+	if ctx.Err() != nil {
+		return NewContextIsDone(ctx.Err().Error())
+	}
+
+	for retries := 0; ; retries++ {
+		if err := file.TryRLock(fp); err == nil {
+			return nil
+		}
+
+		if err := p.wait(ctx, fp.Name(), retryDelay, retries); err != nil {
+			return err
+		}
+	}
+}
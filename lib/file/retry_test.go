@@ -0,0 +1,126 @@
+package file
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+var retryPolicyDelayTests = []struct {
+	Policy     RetryPolicy
+	RetryDelay time.Duration
+	Retries    int
+	Expect     time.Duration
+}{
+	{
+		Policy:     RetryPolicy{Limit: -1, Backoff: FixedBackoff},
+		RetryDelay: 10 * time.Millisecond,
+		Retries:    5,
+		Expect:     10 * time.Millisecond,
+	},
+	{
+		Policy:     RetryPolicy{Limit: -1, Backoff: ExponentialBackoff},
+		RetryDelay: 10 * time.Millisecond,
+		Retries:    0,
+		Expect:     10 * time.Millisecond,
+	},
+	{
+		Policy:     RetryPolicy{Limit: -1, Backoff: ExponentialBackoff},
+		RetryDelay: 10 * time.Millisecond,
+		Retries:    3,
+		Expect:     80 * time.Millisecond,
+	},
+	{
+		Policy:     RetryPolicy{Limit: -1, Backoff: ExponentialBackoff},
+		RetryDelay: 10 * time.Millisecond,
+		Retries:    100,
+		Expect:     MaxRetryDelay,
+	},
+	{
+		Policy:     RetryPolicy{Limit: -1, Backoff: ExponentialBackoff},
+		RetryDelay: 2 * time.Second,
+		Retries:    3,
+		Expect:     2 * time.Second,
+	},
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	for _, v := range retryPolicyDelayTests {
+		result := v.Policy.Delay(v.RetryDelay, v.Retries)
+		if result != v.Expect {
+			t.Errorf("delay = %s, expect %s for %s backoff, %s, %d retries", result, v.Expect, v.Policy.Backoff, v.RetryDelay, v.Retries)
+		}
+	}
+
+	policy := RetryPolicy{Limit: -1, Backoff: JitterBackoff}
+	for i := 0; i < 100; i++ {
+		result := policy.Delay(10*time.Millisecond, 3)
+		if result < 10*time.Millisecond || 80*time.Millisecond < result {
+			t.Fatalf("delay = %s, expect a delay between %s and %s for jitter backoff", result, 10*time.Millisecond, 80*time.Millisecond)
+		}
+	}
+}
+
+func TestParseBackoff(t *testing.T) {
+	for _, b := range []Backoff{FixedBackoff, ExponentialBackoff, JitterBackoff} {
+		result, err := ParseBackoff(b.String())
+		if err != nil {
+			t.Errorf("error = %q, expect no error for %q", err.Error(), b.String())
+		} else if result != b {
+			t.Errorf("backoff = %s, expect %s for %q", result, b, b.String())
+		}
+	}
+
+	if _, err := ParseBackoff("linear"); err == nil {
+		t.Errorf("no error, want error for %q", "linear")
+	}
+}
+
+func TestHandler_RetryLimit(t *testing.T) {
+	fpath := GetTestFilePath("retry_limit.txt")
+	if err := ioutil.WriteFile(fpath, []byte("contents"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = os.Remove(fpath)
+	}()
+
+	ctx := context.Background()
+	container := NewContainer()
+
+	uh, err := NewHandlerForUpdate(ctx, container, fpath, waitTimeoutForTests, retryDelayForTests)
+	if err != nil {
+		t.Fatalf("error = %#v, expect no error", err)
+	}
+	defer func() {
+		_ = container.Close(uh)
+	}()
+
+	other := NewContainer()
+	other.RetryPolicy = RetryPolicy{Limit: 2, Backoff: ExponentialBackoff}
+
+	start := time.Now()
+	rh, err := NewHandlerForRead(ctx, other, fpath, time.Minute, retryDelayForTests)
+	if err == nil {
+		_ = other.Close(rh)
+		t.Fatalf("no error, want RetryLimitError")
+	}
+	if _, ok := err.(*RetryLimitError); !ok {
+		t.Fatalf("error = %#v, want RetryLimitError", err)
+	}
+	if time.Minute <= time.Since(start) {
+		t.Errorf("retries continued until the wait timeout")
+	}
+
+	other.RetryPolicy = RetryPolicy{Limit: 0, Backoff: FixedBackoff}
+	uh2, err := NewHandlerForUpdate(ctx, other, fpath, time.Minute, retryDelayForTests)
+	if err == nil {
+		_ = other.Close(uh2)
+		t.Fatalf("no error, want RetryLimitError")
+	}
+	if _, ok := err.(*RetryLimitError); !ok {
+		t.Fatalf("error = %#v, want RetryLimitError", err)
+	}
+}
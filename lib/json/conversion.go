@@ -28,7 +28,7 @@ func ConvertToValue(structure json.Structure) value.Primary {
 	case json.Null:
 		p = value.NewNull()
 	default:
-		p = value.NewString(structure.Encode())
+		p = value.NewJson(structure)
 	}
 
 	return p
@@ -181,6 +181,15 @@ func ParseValueToStructure(val value.Primary) json.Structure {
 		}
 	case value.Datetime:
 		s = json.String(val.(value.Datetime).Format(time.RFC3339Nano))
+	case value.Array:
+		values := val.(value.Array).Raw()
+		array := make(json.Array, len(values))
+		for i, v := range values {
+			array[i] = ParseValueToStructure(v)
+		}
+		s = array
+	case value.Json:
+		s = val.(value.Json).Raw()
 	case value.Null:
 		s = json.Null{}
 	}
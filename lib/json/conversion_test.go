@@ -38,7 +38,10 @@ var convertToValueTests = []struct {
 			json.String("abc"),
 			json.String("def"),
 		},
-		Expect: value.NewString("[\"abc\",\"def\"]"),
+		Expect: value.NewJson(json.Array{
+			json.String("abc"),
+			json.String("def"),
+		}),
 	},
 	{
 		Input: json.Object{
@@ -53,7 +56,12 @@ var convertToValueTests = []struct {
 				},
 			},
 		},
-		Expect: value.NewString("{\"key1\":\"value1\",\"key2\":\"value2\"}"),
+		Expect: value.NewJson(json.Object{
+			Members: []json.ObjectMember{
+				{Key: "key1", Value: json.String("value1")},
+				{Key: "key2", Value: json.String("value2")},
+			},
+		}),
 	},
 }
 
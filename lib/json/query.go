@@ -18,6 +18,20 @@ func LoadValue(queryString string, jsontext string) (value.Primary, error) {
 	return ConvertToValue(structure), nil
 }
 
+func ExtractValue(queryString string, data json.Structure) (value.Primary, error) {
+	query, err := Query.Parse(queryString)
+	if err != nil {
+		return nil, err
+	}
+
+	structure, err := Extract(query, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return ConvertToValue(structure), nil
+}
+
 func LoadArray(queryString string, jsontext string) ([]value.Primary, error) {
 	structure, _, err := load(queryString, jsontext)
 	if err != nil {
@@ -137,16 +151,7 @@ func Extract(query QueryExpression, data json.Structure) (json.Structure, error)
 			if table.Fields == nil {
 				extracted = json.Array{data}
 			} else {
-				obj := json.NewObject(len(table.Fields))
-				for _, field := range table.Fields {
-					e, err := Extract(field.Element, data)
-					if err != nil {
-						return extracted, err
-					}
-
-					obj.Add(field.FieldLabel(), e)
-				}
-				extracted = json.Array{obj}
+				extracted, err = extractTableRows(table.Fields, data)
 			}
 		case json.Array:
 			table := query.(TableExpr)
@@ -177,16 +182,11 @@ func Extract(query QueryExpression, data json.Structure) (json.Structure, error)
 
 			elems := make(json.Array, 0, len(array))
 			for _, v := range array {
-				obj := json.NewObject(len(fields))
-				for _, field := range fields {
-					e, err := Extract(field.Element, v)
-					if err != nil {
-						return extracted, err
-					}
-
-					obj.Add(field.FieldLabel(), e)
+				rows, err := extractTableRows(fields, v)
+				if err != nil {
+					return extracted, err
 				}
-				elems = append(elems, obj)
+				elems = append(elems, rows...)
 			}
 			extracted = elems
 		default:
@@ -199,6 +199,76 @@ func Extract(query QueryExpression, data json.Structure) (json.Structure, error)
 	return extracted, err
 }
 
+func extractTableRows(fields []FieldExpr, data json.Structure) (json.Array, error) {
+	rows := []json.Object{json.NewObject(len(fields))}
+
+	for _, field := range fields {
+		e, err := Extract(field.Element, data)
+		if err != nil {
+			return nil, err
+		}
+
+		if field.Nested == nil {
+			for i := range rows {
+				rows[i].Add(field.FieldLabel(), e)
+			}
+			continue
+		}
+
+		nestedRows, err := extractNestedRows(field.Nested.(TableExpr), e)
+		if err != nil {
+			return nil, err
+		}
+
+		merged := make([]json.Object, 0, len(rows)*len(nestedRows))
+		for _, row := range rows {
+			for _, nestedRow := range nestedRows {
+				obj := json.NewObject(row.Len() + nestedRow.Len())
+				obj.Members = append(append(obj.Members, row.Members...), nestedRow.Members...)
+				merged = append(merged, obj)
+			}
+		}
+		rows = merged
+	}
+
+	array := make(json.Array, 0, len(rows))
+	for _, row := range rows {
+		array = append(array, row)
+	}
+	return array, nil
+}
+
+func extractNestedRows(table TableExpr, data json.Structure) ([]json.Object, error) {
+	isEmpty := false
+	switch data.(type) {
+	case json.Null:
+		isEmpty = true
+	case json.Array:
+		isEmpty = len(data.(json.Array)) < 1
+	}
+
+	if isEmpty {
+		labels := table.FieldLabels()
+		obj := json.NewObject(len(labels))
+		for _, label := range labels {
+			obj.Add(label, json.Null{})
+		}
+		return []json.Object{obj}, nil
+	}
+
+	extracted, err := Extract(table, data)
+	if err != nil {
+		return nil, err
+	}
+
+	array := extracted.(json.Array)
+	rows := make([]json.Object, 0, len(array))
+	for _, v := range array {
+		rows = append(rows, v.(json.Object))
+	}
+	return rows, nil
+}
+
 func existsKeyInFields(key string, list []FieldExpr) bool {
 	for _, v := range list {
 		if key == v.Element.Label {
@@ -37,13 +37,14 @@ var jqToknames = [...]string{
 	"'}'",
 	"','",
 }
+
 var jqStatenames = [...]string{}
 
 const jqEofCode = 1
 const jqErrCode = 2
 const jqInitialStackSize = 16
 
-//line query_parser.y:185
+//line query_parser.y:189
 
 func ParseQuery(src string) (QueryExpression, error) {
 	l := new(QueryLexer)
@@ -53,7 +54,7 @@ func ParseQuery(src string) (QueryExpression, error) {
 }
 
 //line yacctab:1
-var jqExca = [...]int{
+var jqExca = [...]int8{
 	-1, 1,
 	1, -1,
 	-2, 0,
@@ -61,61 +62,61 @@ var jqExca = [...]int{
 
 const jqPrivate = 57344
 
-const jqLast = 50
-
-var jqAct = [...]int{
+const jqLast = 52
 
-	18, 3, 23, 16, 26, 6, 5, 30, 8, 4,
-	9, 40, 20, 13, 12, 10, 8, 11, 9, 27,
-	7, 25, 29, 34, 8, 35, 9, 33, 32, 38,
-	36, 31, 39, 14, 41, 24, 19, 15, 28, 24,
-	22, 24, 43, 42, 21, 7, 37, 17, 2, 1,
+var jqAct = [...]int8{
+	18, 3, 23, 5, 16, 6, 4, 31, 8, 26,
+	9, 12, 20, 13, 11, 10, 8, 27, 9, 41,
+	25, 9, 30, 35, 28, 33, 21, 34, 32, 36,
+	39, 37, 7, 40, 17, 14, 8, 2, 9, 15,
+	42, 24, 1, 44, 43, 29, 24, 22, 24, 19,
+	7, 38,
 }
-var jqPact = [...]int{
 
-	16, -1000, -1000, -1000, -1000, -1000, -1000, 8, 28, 32,
-	41, -1000, -1000, -1000, 35, 33, 10, -8, 13, 31,
-	-1000, 0, 32, -1000, 20, -1000, 32, 42, 32, -1000,
-	41, -1000, -1000, -1000, -1000, 2, -1000, -1000, -1000, -1000,
-	27, 32, -1000, -1000,
+var jqPact = [...]int16{
+	28, -32768, -32768, -32768, -32768, -32768, -32768, 8, 30, 45,
+	46, -32768, -32768, -32768, 17, 40, 9, -3, 11, 38,
+	-32768, 0, 45, -32768, 24, -32768, 45, 47, -32768, 45,
+	-32768, 46, -32768, -32768, -32768, -32768, 10, -32768, -32768, -32768,
+	-32768, 33, 45, -32768, -32768,
 }
-var jqPgo = [...]int{
 
-	0, 49, 48, 1, 0, 9, 2, 6, 5, 47,
-	3,
+var jqPgo = [...]int8{
+	0, 42, 37, 1, 0, 6, 2, 3, 5, 34,
+	4,
 }
-var jqR1 = [...]int{
 
+var jqR1 = [...]int8{
 	0, 1, 1, 2, 2, 2, 2, 3, 3, 3,
 	3, 3, 4, 4, 4, 5, 5, 5, 5, 5,
-	6, 6, 6, 7, 7, 7, 8, 9, 9, 10,
-	10, 10,
+	6, 6, 6, 7, 7, 7, 8, 9, 9, 9,
+	10, 10, 10,
 }
-var jqR2 = [...]int{
 
+var jqR2 = [...]int8{
 	0, 0, 1, 1, 1, 1, 1, 1, 3, 2,
 	2, 2, 1, 3, 2, 3, 5, 4, 4, 4,
-	3, 5, 4, 2, 4, 3, 3, 1, 3, 0,
-	1, 3,
+	3, 5, 4, 2, 4, 3, 3, 1, 3, 2,
+	0, 1, 3,
 }
-var jqChk = [...]int{
 
-	-1000, -1, -2, -3, -5, -7, -8, 4, 8, 10,
+var jqChk = [...]int16{
+	-32768, -1, -2, -3, -5, -7, -8, 4, 8, 10,
 	7, -5, -7, -8, 5, 9, -10, -9, -4, 4,
-	-3, 9, 7, -6, 8, 11, 12, 6, 7, -6,
-	7, -5, -7, -8, -4, 5, -10, 4, -4, -3,
-	9, 7, -6, -4,
+	-3, 9, 7, -6, 8, 11, 12, 6, -8, 7,
+	-6, 7, -5, -7, -8, -4, 5, -10, 4, -4,
+	-3, 9, 7, -6, -4,
 }
-var jqDef = [...]int{
 
-	1, -2, 2, 3, 4, 5, 6, 7, 0, 29,
-	0, 9, 10, 11, 0, 23, 0, 30, 27, 12,
-	8, 15, 0, 25, 0, 26, 29, 0, 0, 14,
-	0, 17, 18, 19, 24, 0, 31, 28, 13, 16,
-	20, 0, 22, 21,
+var jqDef = [...]int8{
+	1, -2, 2, 3, 4, 5, 6, 7, 0, 30,
+	0, 9, 10, 11, 0, 23, 0, 31, 27, 12,
+	8, 15, 0, 25, 0, 26, 30, 0, 29, 0,
+	14, 0, 17, 18, 19, 24, 0, 32, 28, 13,
+	16, 20, 0, 22, 21,
 }
-var jqTok1 = [...]int{
 
+var jqTok1 = [...]int8{
 	1, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
@@ -130,11 +131,12 @@ var jqTok1 = [...]int{
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 10, 3, 11,
 }
-var jqTok2 = [...]int{
 
+var jqTok2 = [...]int8{
 	2, 3, 4, 5, 6,
 }
-var jqTok3 = [...]int{
+
+var jqTok3 = [...]int8{
 	0,
 }
 
@@ -177,7 +179,7 @@ func jqNewParser() jqParser {
 	return &jqParserImpl{}
 }
 
-const jqFlag = -1000
+const jqFlag = -32768
 
 func jqTokname(c int) string {
 	if c >= 1 && c-1 < len(jqToknames) {
@@ -216,9 +218,9 @@ func jqErrorMessage(state, lookAhead int) string {
 	expected := make([]int, 0, 4)
 
 	// Look for shiftable tokens.
-	base := jqPact[state]
+	base := int(jqPact[state])
 	for tok := TOKSTART; tok-1 < len(jqToknames); tok++ {
-		if n := base + tok; n >= 0 && n < jqLast && jqChk[jqAct[n]] == tok {
+		if n := base + tok; n >= 0 && n < jqLast && int(jqChk[int(jqAct[n])]) == tok {
 			if len(expected) == cap(expected) {
 				return res
 			}
@@ -228,13 +230,13 @@ func jqErrorMessage(state, lookAhead int) string {
 
 	if jqDef[state] == -2 {
 		i := 0
-		for jqExca[i] != -1 || jqExca[i+1] != state {
+		for jqExca[i] != -1 || int(jqExca[i+1]) != state {
 			i += 2
 		}
 
 		// Look for tokens that we accept or reduce.
 		for i += 2; jqExca[i] >= 0; i += 2 {
-			tok := jqExca[i]
+			tok := int(jqExca[i])
 			if tok < TOKSTART || jqExca[i+1] == 0 {
 				continue
 			}
@@ -265,30 +267,30 @@ func jqlex1(lex jqLexer, lval *jqSymType) (char, token int) {
 	token = 0
 	char = lex.Lex(lval)
 	if char <= 0 {
-		token = jqTok1[0]
+		token = int(jqTok1[0])
 		goto out
 	}
 	if char < len(jqTok1) {
-		token = jqTok1[char]
+		token = int(jqTok1[char])
 		goto out
 	}
 	if char >= jqPrivate {
 		if char < jqPrivate+len(jqTok2) {
-			token = jqTok2[char-jqPrivate]
+			token = int(jqTok2[char-jqPrivate])
 			goto out
 		}
 	}
 	for i := 0; i < len(jqTok3); i += 2 {
-		token = jqTok3[i+0]
+		token = int(jqTok3[i+0])
 		if token == char {
-			token = jqTok3[i+1]
+			token = int(jqTok3[i+1])
 			goto out
 		}
 	}
 
 out:
 	if token == 0 {
-		token = jqTok2[1] /* unknown char */
+		token = int(jqTok2[1]) /* unknown char */
 	}
 	if jqDebug >= 3 {
 		__yyfmt__.Printf("lex %s(%d)\n", jqTokname(token), uint(char))
@@ -343,7 +345,7 @@ jqstack:
 	jqS[jqp].yys = jqstate
 
 jqnewstate:
-	jqn = jqPact[jqstate]
+	jqn = int(jqPact[jqstate])
 	if jqn <= jqFlag {
 		goto jqdefault /* simple state */
 	}
@@ -354,8 +356,8 @@ jqnewstate:
 	if jqn < 0 || jqn >= jqLast {
 		goto jqdefault
 	}
-	jqn = jqAct[jqn]
-	if jqChk[jqn] == jqtoken { /* valid shift */
+	jqn = int(jqAct[jqn])
+	if int(jqChk[jqn]) == jqtoken { /* valid shift */
 		jqrcvr.char = -1
 		jqtoken = -1
 		jqVAL = jqrcvr.lval
@@ -368,7 +370,7 @@ jqnewstate:
 
 jqdefault:
 	/* default state action */
-	jqn = jqDef[jqstate]
+	jqn = int(jqDef[jqstate])
 	if jqn == -2 {
 		if jqrcvr.char < 0 {
 			jqrcvr.char, jqtoken = jqlex1(jqlex, &jqrcvr.lval)
@@ -377,18 +379,18 @@ jqdefault:
 		/* look through exception table */
 		xi := 0
 		for {
-			if jqExca[xi+0] == -1 && jqExca[xi+1] == jqstate {
+			if jqExca[xi+0] == -1 && int(jqExca[xi+1]) == jqstate {
 				break
 			}
 			xi += 2
 		}
 		for xi += 2; ; xi += 2 {
-			jqn = jqExca[xi+0]
+			jqn = int(jqExca[xi+0])
 			if jqn < 0 || jqn == jqtoken {
 				break
 			}
 		}
-		jqn = jqExca[xi+1]
+		jqn = int(jqExca[xi+1])
 		if jqn < 0 {
 			goto ret0
 		}
@@ -410,10 +412,10 @@ jqdefault:
 
 			/* find a state where "error" is a legal shift action */
 			for jqp >= 0 {
-				jqn = jqPact[jqS[jqp].yys] + jqErrCode
+				jqn = int(jqPact[jqS[jqp].yys]) + jqErrCode
 				if jqn >= 0 && jqn < jqLast {
-					jqstate = jqAct[jqn] /* simulate a shift of "error" */
-					if jqChk[jqstate] == jqErrCode {
+					jqstate = int(jqAct[jqn]) /* simulate a shift of "error" */
+					if int(jqChk[jqstate]) == jqErrCode {
 						goto jqstack
 					}
 				}
@@ -449,7 +451,7 @@ jqdefault:
 	jqpt := jqp
 	_ = jqpt // guard against "declared and not used"
 
-	jqp -= jqR2[jqn]
+	jqp -= int(jqR2[jqn])
 	// jqp is now the index of $0. Perform the default action. Iff the
 	// reduced production is ε, $1 is possibly out of range.
 	if jqp+1 >= len(jqS) {
@@ -460,16 +462,16 @@ jqdefault:
 	jqVAL = jqS[jqp+1]
 
 	/* consult goto table to find next state */
-	jqn = jqR1[jqn]
-	jqg := jqPgo[jqn]
+	jqn = int(jqR1[jqn])
+	jqg := int(jqPgo[jqn])
 	jqj := jqg + jqS[jqp].yys + 1
 
 	if jqj >= jqLast {
-		jqstate = jqAct[jqg]
+		jqstate = int(jqAct[jqg])
 	} else {
-		jqstate = jqAct[jqj]
-		if jqChk[jqstate] != -jqn {
-			jqstate = jqAct[jqg]
+		jqstate = int(jqAct[jqj])
+		if int(jqChk[jqstate]) != -jqn {
+			jqstate = int(jqAct[jqg])
 		}
 	}
 	// dummy call; replaced with literal code
@@ -477,197 +479,203 @@ jqdefault:
 
 	case 1:
 		jqDollar = jqS[jqpt-0 : jqpt+1]
-		//line query_parser.y:33
+//line query_parser.y:33
 		{
 			jqVAL.expression = nil
 			jqlex.(*QueryLexer).query = jqVAL.expression
 		}
 	case 2:
 		jqDollar = jqS[jqpt-1 : jqpt+1]
-		//line query_parser.y:38
+//line query_parser.y:38
 		{
 			jqVAL.expression = jqDollar[1].expression
 			jqlex.(*QueryLexer).query = jqVAL.expression
 		}
 	case 3:
 		jqDollar = jqS[jqpt-1 : jqpt+1]
-		//line query_parser.y:45
+//line query_parser.y:45
 		{
 			jqVAL.expression = jqDollar[1].element
 		}
 	case 4:
 		jqDollar = jqS[jqpt-1 : jqpt+1]
-		//line query_parser.y:49
+//line query_parser.y:49
 		{
 			jqVAL.expression = jqDollar[1].expression
 		}
 	case 5:
 		jqDollar = jqS[jqpt-1 : jqpt+1]
-		//line query_parser.y:53
+//line query_parser.y:53
 		{
 			jqVAL.expression = jqDollar[1].expression
 		}
 	case 6:
 		jqDollar = jqS[jqpt-1 : jqpt+1]
-		//line query_parser.y:57
+//line query_parser.y:57
 		{
 			jqVAL.expression = jqDollar[1].expression
 		}
 	case 7:
 		jqDollar = jqS[jqpt-1 : jqpt+1]
-		//line query_parser.y:63
+//line query_parser.y:63
 		{
 			jqVAL.element = Element{Label: jqDollar[1].token.Literal}
 		}
 	case 8:
 		jqDollar = jqS[jqpt-3 : jqpt+1]
-		//line query_parser.y:67
+//line query_parser.y:67
 		{
 			jqVAL.element = Element{Label: jqDollar[1].token.Literal, Child: jqDollar[3].element}
 		}
 	case 9:
 		jqDollar = jqS[jqpt-2 : jqpt+1]
-		//line query_parser.y:71
+//line query_parser.y:71
 		{
 			jqVAL.element = Element{Label: jqDollar[1].token.Literal, Child: jqDollar[2].expression}
 		}
 	case 10:
 		jqDollar = jqS[jqpt-2 : jqpt+1]
-		//line query_parser.y:75
+//line query_parser.y:75
 		{
 			jqVAL.element = Element{Label: jqDollar[1].token.Literal, Child: jqDollar[2].expression}
 		}
 	case 11:
 		jqDollar = jqS[jqpt-2 : jqpt+1]
-		//line query_parser.y:79
+//line query_parser.y:79
 		{
 			jqVAL.element = Element{Label: jqDollar[1].token.Literal, Child: jqDollar[2].expression}
 		}
 	case 12:
 		jqDollar = jqS[jqpt-1 : jqpt+1]
-		//line query_parser.y:85
+//line query_parser.y:85
 		{
 			jqVAL.element = Element{Label: jqDollar[1].token.Literal}
 		}
 	case 13:
 		jqDollar = jqS[jqpt-3 : jqpt+1]
-		//line query_parser.y:89
+//line query_parser.y:89
 		{
 			jqVAL.element = Element{Label: jqDollar[1].token.Literal, Child: jqDollar[3].element}
 		}
 	case 14:
 		jqDollar = jqS[jqpt-2 : jqpt+1]
-		//line query_parser.y:93
+//line query_parser.y:93
 		{
 			jqVAL.element = Element{Label: jqDollar[1].token.Literal, Child: jqDollar[2].expression}
 		}
 	case 15:
 		jqDollar = jqS[jqpt-3 : jqpt+1]
-		//line query_parser.y:99
+//line query_parser.y:99
 		{
 			i, _ := strconv.Atoi(jqDollar[2].token.Literal)
 			jqVAL.expression = ArrayItem{Index: i}
 		}
 	case 16:
 		jqDollar = jqS[jqpt-5 : jqpt+1]
-		//line query_parser.y:104
+//line query_parser.y:104
 		{
 			i, _ := strconv.Atoi(jqDollar[2].token.Literal)
 			jqVAL.expression = ArrayItem{Index: i, Child: jqDollar[5].element}
 		}
 	case 17:
 		jqDollar = jqS[jqpt-4 : jqpt+1]
-		//line query_parser.y:109
+//line query_parser.y:109
 		{
 			i, _ := strconv.Atoi(jqDollar[2].token.Literal)
 			jqVAL.expression = ArrayItem{Index: i, Child: jqDollar[4].expression}
 		}
 	case 18:
 		jqDollar = jqS[jqpt-4 : jqpt+1]
-		//line query_parser.y:114
+//line query_parser.y:114
 		{
 			i, _ := strconv.Atoi(jqDollar[2].token.Literal)
 			jqVAL.expression = ArrayItem{Index: i, Child: jqDollar[4].expression}
 		}
 	case 19:
 		jqDollar = jqS[jqpt-4 : jqpt+1]
-		//line query_parser.y:119
+//line query_parser.y:119
 		{
 			i, _ := strconv.Atoi(jqDollar[2].token.Literal)
 			jqVAL.expression = ArrayItem{Index: i, Child: jqDollar[4].expression}
 		}
 	case 20:
 		jqDollar = jqS[jqpt-3 : jqpt+1]
-		//line query_parser.y:126
+//line query_parser.y:126
 		{
 			i, _ := strconv.Atoi(jqDollar[2].token.Literal)
 			jqVAL.expression = ArrayItem{Index: i}
 		}
 	case 21:
 		jqDollar = jqS[jqpt-5 : jqpt+1]
-		//line query_parser.y:131
+//line query_parser.y:131
 		{
 			i, _ := strconv.Atoi(jqDollar[2].token.Literal)
 			jqVAL.expression = ArrayItem{Index: i, Child: jqDollar[5].element}
 		}
 	case 22:
 		jqDollar = jqS[jqpt-4 : jqpt+1]
-		//line query_parser.y:136
+//line query_parser.y:136
 		{
 			i, _ := strconv.Atoi(jqDollar[2].token.Literal)
 			jqVAL.expression = ArrayItem{Index: i, Child: jqDollar[4].expression}
 		}
 	case 23:
 		jqDollar = jqS[jqpt-2 : jqpt+1]
-		//line query_parser.y:143
+//line query_parser.y:143
 		{
 			jqVAL.expression = RowValueExpr{}
 		}
 	case 24:
 		jqDollar = jqS[jqpt-4 : jqpt+1]
-		//line query_parser.y:147
+//line query_parser.y:147
 		{
 			jqVAL.expression = RowValueExpr{Child: jqDollar[4].element}
 		}
 	case 25:
 		jqDollar = jqS[jqpt-3 : jqpt+1]
-		//line query_parser.y:151
+//line query_parser.y:151
 		{
 			jqVAL.expression = RowValueExpr{Child: jqDollar[3].expression}
 		}
 	case 26:
 		jqDollar = jqS[jqpt-3 : jqpt+1]
-		//line query_parser.y:157
+//line query_parser.y:157
 		{
 			jqVAL.expression = TableExpr{Fields: jqDollar[2].fields}
 		}
 	case 27:
 		jqDollar = jqS[jqpt-1 : jqpt+1]
-		//line query_parser.y:163
+//line query_parser.y:163
 		{
 			jqVAL.field = FieldExpr{Element: jqDollar[1].element}
 		}
 	case 28:
 		jqDollar = jqS[jqpt-3 : jqpt+1]
-		//line query_parser.y:167
+//line query_parser.y:167
 		{
 			jqVAL.field = FieldExpr{Element: jqDollar[1].element, Alias: jqDollar[3].token.Literal}
 		}
 	case 29:
+		jqDollar = jqS[jqpt-2 : jqpt+1]
+//line query_parser.y:171
+		{
+			jqVAL.field = FieldExpr{Element: jqDollar[1].element, Nested: jqDollar[2].expression}
+		}
+	case 30:
 		jqDollar = jqS[jqpt-0 : jqpt+1]
-		//line query_parser.y:173
+//line query_parser.y:177
 		{
 			jqVAL.fields = nil
 		}
-	case 30:
+	case 31:
 		jqDollar = jqS[jqpt-1 : jqpt+1]
-		//line query_parser.y:177
+//line query_parser.y:181
 		{
 			jqVAL.fields = []FieldExpr{jqDollar[1].field}
 		}
-	case 31:
+	case 32:
 		jqDollar = jqS[jqpt-3 : jqpt+1]
-		//line query_parser.y:181
+//line query_parser.y:185
 		{
 			jqVAL.fields = append([]FieldExpr{jqDollar[1].field}, jqDollar[3].fields...)
 		}
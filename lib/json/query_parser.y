@@ -167,6 +167,10 @@ field
     {
         $$ = FieldExpr{Element: $1, Alias: $3.Literal}
     }
+    | single_value_element table
+    {
+        $$ = FieldExpr{Element: $1, Nested: $2}
+    }
 
 fields
     :
@@ -162,6 +162,30 @@ var parseQueryTests = []struct {
 			},
 		},
 	},
+	{
+		Input: "{abc, def{ghi, jkl as alias}}",
+		Expect: TableExpr{
+			Fields: []FieldExpr{
+				{
+					Element: Element{Label: "abc"},
+				},
+				{
+					Element: Element{Label: "def"},
+					Nested: TableExpr{
+						Fields: []FieldExpr{
+							{
+								Element: Element{Label: "ghi"},
+							},
+							{
+								Element: Element{Label: "jkl"},
+								Alias:   "alias",
+							},
+						},
+					},
+				},
+			},
+		},
+	},
 	{
 		Input: "{abc.def, ghi[2]}",
 		Expect: TableExpr{
@@ -217,11 +241,21 @@ var parseQueryTests = []struct {
 	},
 	{
 		Input: "abc{def{}}",
-		Error: "column 8: unexpected token \"{\"",
+		Expect: Element{
+			Label: "abc",
+			Child: TableExpr{
+				Fields: []FieldExpr{
+					{
+						Element: Element{Label: "def"},
+						Nested:  TableExpr{},
+					},
+				},
+			},
+		},
 	},
 	{
-		Input: "abc{def{}}",
-		Error: "column 8: unexpected token \"{\"",
+		Input: "abc{def{} as alias}",
+		Error: "column 11: unexpected token \"as\"",
 	},
 	{
 		Input: "`abc",
@@ -51,9 +51,22 @@ type TableExpr struct {
 	Fields []FieldExpr
 }
 
+func (e TableExpr) FieldLabels() []string {
+	labels := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		if field.Nested != nil {
+			labels = append(labels, field.Nested.(TableExpr).FieldLabels()...)
+		} else {
+			labels = append(labels, field.FieldLabel())
+		}
+	}
+	return labels
+}
+
 type FieldExpr struct {
 	Element Element
 	Alias   string
+	Nested  QueryExpression
 }
 
 func (e FieldExpr) FieldLabel() string {
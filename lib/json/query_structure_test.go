@@ -1,6 +1,7 @@
 package json
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -115,3 +116,26 @@ func TestColumnExpr_FieldLabel(t *testing.T) {
 		}
 	}
 }
+
+func TestTableExpr_FieldLabels(t *testing.T) {
+	e := TableExpr{
+		Fields: []FieldExpr{
+			{Element: Element{Label: "key1"}},
+			{
+				Element: Element{Label: "key2"},
+				Nested: TableExpr{
+					Fields: []FieldExpr{
+						{Element: Element{Label: "key3"}},
+						{Element: Element{Label: "key4"}, Alias: "alias"},
+					},
+				},
+			},
+		},
+	}
+	expect := []string{"key1", "key3", "alias"}
+
+	result := e.FieldLabels()
+	if !reflect.DeepEqual(result, expect) {
+		t.Errorf("result = %q, want %q for %#v", result, expect, e)
+	}
+}
@@ -124,6 +124,38 @@ var loadTableTests = []struct {
 			},
 		},
 	},
+	{
+		Query:        "{id, items{sku, qty as quantity}}",
+		Json:         "[{\"id\":1, \"items\":[{\"sku\":\"a\", \"qty\":2}, {\"sku\":\"b\", \"qty\":3}]}, {\"id\":2, \"items\":[]}, {\"id\":3}]",
+		ExpectHeader: []string{"id", "sku", "quantity"},
+		ExpectValues: [][]value.Primary{
+			{
+				value.NewInteger(1),
+				value.NewString("a"),
+				value.NewInteger(2),
+			},
+			{
+				value.NewInteger(1),
+				value.NewString("b"),
+				value.NewInteger(3),
+			},
+			{
+				value.NewInteger(2),
+				value.NewNull(),
+				value.NewNull(),
+			},
+			{
+				value.NewInteger(3),
+				value.NewNull(),
+				value.NewNull(),
+			},
+		},
+	},
+	{
+		Query: "{id, items{sku}}",
+		Json:  "[{\"id\":1, \"items\":[1, 2]}]",
+		Error: "all elements in array must be objects",
+	},
 	{
 		Query: "notexist{}",
 		Json:  "{\"key\":[{\"key2\":2, \"key3\": 3}]}",
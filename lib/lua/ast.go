@@ -0,0 +1,140 @@
+package lua
+
+type expr interface{}
+
+type stmt interface{}
+
+type (
+	constExpr struct {
+		value Value
+	}
+
+	varargExpr struct{}
+
+	nameExpr struct {
+		name string
+		line int
+	}
+
+	indexExpr struct {
+		object expr
+		key    expr
+		line   int
+	}
+
+	callExpr struct {
+		fn   expr
+		args []expr
+		line int
+	}
+
+	methodCallExpr struct {
+		object expr
+		method string
+		args   []expr
+		line   int
+	}
+
+	functionExpr struct {
+		proto *proto
+	}
+
+	binaryExpr struct {
+		op   string
+		lhs  expr
+		rhs  expr
+		line int
+	}
+
+	unaryExpr struct {
+		op      string
+		operand expr
+		line    int
+	}
+
+	parenExpr struct {
+		inner expr
+	}
+
+	tableField struct {
+		key   expr // nil for positional fields
+		value expr
+	}
+
+	tableExpr struct {
+		fields []tableField
+	}
+)
+
+type (
+	assignStmt struct {
+		targets []expr
+		values  []expr
+		line    int
+	}
+
+	localStmt struct {
+		names  []string
+		values []expr
+	}
+
+	localFunctionStmt struct {
+		name  string
+		proto *proto
+	}
+
+	callStmt struct {
+		call expr
+	}
+
+	doStmt struct {
+		body []stmt
+	}
+
+	whileStmt struct {
+		cond expr
+		body []stmt
+		line int
+	}
+
+	repeatStmt struct {
+		body []stmt
+		cond expr
+		line int
+	}
+
+	ifStmt struct {
+		conds  []expr
+		blocks [][]stmt
+		orElse []stmt
+	}
+
+	numericForStmt struct {
+		name  string
+		start expr
+		limit expr
+		step  expr
+		body  []stmt
+		line  int
+	}
+
+	genericForStmt struct {
+		names []string
+		exprs []expr
+		body  []stmt
+		line  int
+	}
+
+	returnStmt struct {
+		values []expr
+	}
+
+	breakStmt struct{}
+)
+
+type proto struct {
+	name     string
+	params   []string
+	isVararg bool
+	body     []stmt
+}
@@ -0,0 +1,841 @@
+package lua
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+)
+
+// Limits restricts the resources that a call can use.
+type Limits struct {
+	MaxSteps        int64
+	MaxCallDepth    int
+	MaxStringLength int
+}
+
+var DefaultLimits = Limits{
+	MaxSteps:        10000000,
+	MaxCallDepth:    200,
+	MaxStringLength: 16 * 1024 * 1024,
+}
+
+// Error is an error raised by a script.
+type Error struct {
+	Message string
+	Value   Value
+
+	// A fatal error cannot be caught by pcall.
+	fatal bool
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+type variable struct {
+	name  string
+	value Value
+	prev  *variable
+}
+
+func (v *variable) lookup(name string) *variable {
+	for ; v != nil; v = v.prev {
+		if v.name == name {
+			return v
+		}
+	}
+	return nil
+}
+
+type frame struct {
+	varargs []Value
+}
+
+type signal int
+
+const (
+	signalNone signal = iota
+	signalBreak
+	signalReturn
+)
+
+// State holds the global environment of scripts.
+//
+// Scripts have access only to a subset of the standard library which
+// cannot touch the host environment, so that the libraries such as io, os,
+// and require are not available.
+type State struct {
+	mtx     sync.Mutex
+	globals *Table
+	strings *Table
+	limits  Limits
+
+	ctx   context.Context
+	steps int64
+	depth int
+	line  int
+}
+
+func NewState(limits Limits) *State {
+	s := &State{
+		globals: NewTable(),
+		limits:  limits,
+	}
+	openLibraries(s)
+	return s
+}
+
+// Compile compiles source as the body of a function with the parameters.
+func Compile(name string, source string, params []string) (*Function, error) {
+	p, err := parse(source, name, params)
+	if err != nil {
+		return nil, err
+	}
+	return &Function{proto: p}, nil
+}
+
+// Call calls fn with args and returns the results.
+func (s *State) Call(ctx context.Context, fn *Function, args ...Value) (results []Value, err error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.ctx = ctx
+	s.steps = 0
+	s.depth = 0
+	s.line = 0
+
+	defer func() {
+		if r := recover(); r != nil {
+			results = nil
+			if e, ok := r.(*Error); ok {
+				err = e
+			} else {
+				err = errors.New(fmt.Sprintf("line %d: %v", s.line, r))
+			}
+		}
+	}()
+
+	return s.call(fn, args), nil
+}
+
+func (s *State) errorf(format string, args ...interface{}) {
+	msg := fmt.Sprintf("line %d: %s", s.line, fmt.Sprintf(format, args...))
+	panic(&Error{Message: msg, Value: msg})
+}
+
+func (s *State) fatalf(format string, args ...interface{}) {
+	panic(&Error{Message: fmt.Sprintf("line %d: %s", s.line, fmt.Sprintf(format, args...)), fatal: true})
+}
+
+func (s *State) step() {
+	s.steps++
+	if s.limits.MaxSteps < s.steps {
+		s.fatalf("execution exceeded the step limit")
+	}
+	if s.steps&0x3ff == 0 && s.ctx != nil {
+		select {
+		case <-s.ctx.Done():
+			s.fatalf("%s", s.ctx.Err().Error())
+		default:
+		}
+	}
+}
+
+func (s *State) checkStringLength(str string) string {
+	if s.limits.MaxStringLength < len(str) {
+		s.fatalf("string length exceeded the limit")
+	}
+	return str
+}
+
+func (s *State) call(fn Value, args []Value) []Value {
+	switch f := fn.(type) {
+	case *Function:
+		s.depth++
+		if s.limits.MaxCallDepth < s.depth {
+			s.fatalf("stack overflow")
+		}
+		line := s.line
+
+		env := f.env
+		for i, name := range f.proto.params {
+			var v Value
+			if i < len(args) {
+				v = args[i]
+			}
+			env = &variable{name: name, value: v, prev: env}
+		}
+		fr := &frame{}
+		if f.proto.isVararg && len(f.proto.params) < len(args) {
+			fr.varargs = args[len(f.proto.params):]
+		}
+
+		sig, results := s.execBlock(fr, env, f.proto.body)
+		s.depth--
+		s.line = line
+		if sig == signalReturn {
+			return results
+		}
+		return nil
+	case *GoFunction:
+		return f.Fn(s, args)
+	}
+	s.errorf("attempt to call a %s value", TypeName(fn))
+	return nil
+}
+
+func (s *State) execBlock(fr *frame, env *variable, body []stmt) (signal, []Value) {
+	sig, results, _ := s.execScope(fr, env, body)
+	return sig, results
+}
+
+func (s *State) exec(fr *frame, env *variable, st stmt) (signal, []Value) {
+	switch x := st.(type) {
+	case assignStmt:
+		s.line = x.line
+		values := s.evalList(fr, env, x.values, len(x.targets))
+		if len(x.targets) == 1 {
+			s.assign(fr, env, x.targets[0], values[0])
+			break
+		}
+		type slot struct {
+			table *Table
+			key   Value
+		}
+		slots := make([]slot, len(x.targets))
+		for i, t := range x.targets {
+			if ie, ok := t.(indexExpr); ok {
+				slots[i].table = s.indexTarget(fr, env, ie)
+				slots[i].key = s.eval(fr, env, ie.key)
+			}
+		}
+		for i, t := range x.targets {
+			if slots[i].table != nil {
+				s.setIndex(slots[i].table, slots[i].key, values[i])
+			} else {
+				s.assign(fr, env, t, values[i])
+			}
+		}
+	case callStmt:
+		s.evalMulti(fr, env, x.call)
+	case doStmt:
+		return s.execBlock(fr, env, x.body)
+	case whileStmt:
+		for s.line = x.line; truthy(s.eval(fr, env, x.cond)); s.line = x.line {
+			sig, results := s.execBlock(fr, env, x.body)
+			if sig == signalBreak {
+				break
+			}
+			if sig == signalReturn {
+				return sig, results
+			}
+		}
+	case repeatStmt:
+		for {
+			s.line = x.line
+			sig, results, inner := s.execScope(fr, env, x.body)
+			if sig == signalBreak {
+				break
+			}
+			if sig == signalReturn {
+				return sig, results
+			}
+			if truthy(s.eval(fr, inner, x.cond)) {
+				break
+			}
+		}
+	case ifStmt:
+		for i, cond := range x.conds {
+			if truthy(s.eval(fr, env, cond)) {
+				return s.execBlock(fr, env, x.blocks[i])
+			}
+		}
+		if x.orElse != nil {
+			return s.execBlock(fr, env, x.orElse)
+		}
+	case numericForStmt:
+		return s.execNumericFor(fr, env, x)
+	case genericForStmt:
+		return s.execGenericFor(fr, env, x)
+	case returnStmt:
+		return signalReturn, s.evalList(fr, env, x.values, -1)
+	case breakStmt:
+		return signalBreak, nil
+	}
+	return signalNone, nil
+}
+
+// execScope executes body and returns the innermost scope, in which the
+// condition of a repeat statement is evaluated.
+func (s *State) execScope(fr *frame, env *variable, body []stmt) (signal, []Value, *variable) {
+	// Executing a block counts as a step so that loops with empty bodies
+	// are also bounded by the step limit.
+	s.step()
+	for _, st := range body {
+		s.step()
+		switch x := st.(type) {
+		case localStmt:
+			values := s.evalList(fr, env, x.values, len(x.names))
+			for i, name := range x.names {
+				env = &variable{name: name, value: values[i], prev: env}
+			}
+		case localFunctionStmt:
+			env = &variable{name: x.name, prev: env}
+			env.value = &Function{proto: x.proto, env: env}
+		default:
+			if sig, results := s.exec(fr, env, st); sig != signalNone {
+				return sig, results, env
+			}
+		}
+	}
+	return signalNone, nil, env
+}
+
+func (s *State) execNumericFor(fr *frame, env *variable, x numericForStmt) (signal, []Value) {
+	s.line = x.line
+	start, ok1 := toNumber(s.eval(fr, env, x.start))
+	limit, ok2 := toNumber(s.eval(fr, env, x.limit))
+	var step Value = int64(1)
+	ok3 := true
+	if x.step != nil {
+		step, ok3 = toNumber(s.eval(fr, env, x.step))
+	}
+	if !ok1 {
+		s.errorf("'for' initial value must be a number")
+	}
+	if !ok2 {
+		s.errorf("'for' limit must be a number")
+	}
+	if !ok3 {
+		s.errorf("'for' step must be a number")
+	}
+
+	run := func(v Value) (signal, []Value, bool) {
+		sig, results := s.execBlock(fr, &variable{name: x.name, value: v, prev: env}, x.body)
+		if sig == signalBreak {
+			return signalNone, nil, true
+		}
+		return sig, results, sig == signalReturn
+	}
+
+	i0, isInt1 := start.(int64)
+	st, isInt2 := step.(int64)
+	if isInt1 && isInt2 {
+		if st == 0 {
+			s.errorf("'for' step is zero")
+		}
+		var lim int64
+		switch l := limit.(type) {
+		case int64:
+			lim = l
+		case float64:
+			if math.IsNaN(l) {
+				return signalNone, nil
+			}
+			switch {
+			case 0 < st && 9223372036854775807 <= l:
+				lim = math.MaxInt64
+			case st < 0 && l <= -9223372036854775808:
+				lim = math.MinInt64
+			case 0 < st:
+				lim = int64(math.Floor(l))
+			default:
+				lim = int64(math.Ceil(l))
+			}
+		}
+		if 0 < st && lim < i0 || st < 0 && i0 < lim {
+			return signalNone, nil
+		}
+		var count uint64
+		if 0 < st {
+			count = (uint64(lim) - uint64(i0)) / uint64(st)
+		} else {
+			count = (uint64(i0) - uint64(lim)) / (uint64(-(st + 1)) + 1)
+		}
+		for i := i0; ; i += st {
+			if sig, results, stop := run(i); stop {
+				return sig, results
+			}
+			if count == 0 {
+				break
+			}
+			count--
+		}
+		return signalNone, nil
+	}
+
+	f0, _ := toFloat(start)
+	fl, _ := toFloat(limit)
+	fs, _ := toFloat(step)
+	if fs == 0 {
+		s.errorf("'for' step is zero")
+	}
+	for f := f0; 0 < fs && f <= fl || fs < 0 && fl <= f; f += fs {
+		if sig, results, stop := run(f); stop {
+			return sig, results
+		}
+	}
+	return signalNone, nil
+}
+
+func (s *State) execGenericFor(fr *frame, env *variable, x genericForStmt) (signal, []Value) {
+	s.line = x.line
+	init := s.evalList(fr, env, x.exprs, 3)
+	fn, state, control := init[0], init[1], init[2]
+
+	for {
+		s.step()
+		s.line = x.line
+		values := s.call(fn, []Value{state, control})
+		if len(values) < 1 || values[0] == nil {
+			return signalNone, nil
+		}
+		control = values[0]
+
+		inner := env
+		for i, name := range x.names {
+			var v Value
+			if i < len(values) {
+				v = values[i]
+			}
+			inner = &variable{name: name, value: v, prev: inner}
+		}
+		sig, results := s.execBlock(fr, inner, x.body)
+		if sig == signalBreak {
+			return signalNone, nil
+		}
+		if sig == signalReturn {
+			return sig, results
+		}
+	}
+}
+
+func (s *State) assign(fr *frame, env *variable, target expr, val Value) {
+	switch t := target.(type) {
+	case nameExpr:
+		if v := env.lookup(t.name); v != nil {
+			v.value = val
+		} else {
+			s.globals.Set(t.name, val)
+		}
+	case indexExpr:
+		table := s.indexTarget(fr, env, t)
+		s.setIndex(table, s.eval(fr, env, t.key), val)
+	}
+}
+
+func (s *State) indexTarget(fr *frame, env *variable, e indexExpr) *Table {
+	obj := s.eval(fr, env, e.object)
+	table, ok := obj.(*Table)
+	if !ok {
+		s.line = e.line
+		s.errorf("attempt to index a %s value%s", TypeName(obj), describe(e.object))
+	}
+	return table
+}
+
+func (s *State) setIndex(table *Table, key Value, val Value) {
+	defer func() {
+		if r := recover(); r != nil {
+			if e, ok := r.(*Error); ok && e.Value == nil && !e.fatal {
+				s.errorf("%s", e.Message)
+			}
+			panic(r)
+		}
+	}()
+	table.Set(key, val)
+}
+
+func describe(e expr) string {
+	switch x := e.(type) {
+	case nameExpr:
+		return fmt.Sprintf(" (variable '%s')", x.name)
+	case indexExpr:
+		if k, ok := x.key.(constExpr); ok {
+			if name, ok := k.value.(string); ok {
+				return fmt.Sprintf(" (field '%s')", name)
+			}
+		}
+	case methodCallExpr:
+		return fmt.Sprintf(" (method '%s')", x.method)
+	}
+	return ""
+}
+
+// evalList evaluates exprs and adjusts the number of values to n.
+// If n is negative, all the values are returned.
+func (s *State) evalList(fr *frame, env *variable, exprs []expr, n int) []Value {
+	values := make([]Value, 0, len(exprs))
+	for i, e := range exprs {
+		if i == len(exprs)-1 {
+			values = append(values, s.evalMulti(fr, env, e)...)
+		} else {
+			values = append(values, s.eval(fr, env, e))
+		}
+	}
+	if n < 0 {
+		return values
+	}
+	if len(values) < n {
+		values = append(values, make([]Value, n-len(values))...)
+	}
+	return values[:n]
+}
+
+// evalMulti evaluates e that can return multiple values.
+func (s *State) evalMulti(fr *frame, env *variable, e expr) []Value {
+	switch x := e.(type) {
+	case callExpr:
+		fn := s.eval(fr, env, x.fn)
+		args := s.evalList(fr, env, x.args, -1)
+		s.step()
+		s.line = x.line
+		if _, ok := fn.(*Function); !ok {
+			if _, ok := fn.(*GoFunction); !ok {
+				s.errorf("attempt to call a %s value%s", TypeName(fn), describe(x.fn))
+			}
+		}
+		return s.call(fn, args)
+	case methodCallExpr:
+		obj := s.eval(fr, env, x.object)
+		s.line = x.line
+		fn := s.index(obj, x.method, x.object)
+		args := append([]Value{obj}, s.evalList(fr, env, x.args, -1)...)
+		s.step()
+		s.line = x.line
+		if _, ok := fn.(*Function); !ok {
+			if _, ok := fn.(*GoFunction); !ok {
+				s.errorf("attempt to call a %s value%s", TypeName(fn), describe(x))
+			}
+		}
+		return s.call(fn, args)
+	case varargExpr:
+		return fr.varargs
+	}
+	return []Value{s.eval(fr, env, e)}
+}
+
+func (s *State) index(obj Value, key Value, e expr) Value {
+	switch o := obj.(type) {
+	case *Table:
+		return o.Get(key)
+	case string:
+		return s.strings.Get(key)
+	}
+	s.errorf("attempt to index a %s value%s", TypeName(obj), describe(e))
+	return nil
+}
+
+func (s *State) eval(fr *frame, env *variable, e expr) Value {
+	switch x := e.(type) {
+	case constExpr:
+		return x.value
+	case nameExpr:
+		if v := env.lookup(x.name); v != nil {
+			return v.value
+		}
+		return s.globals.Get(x.name)
+	case indexExpr:
+		obj := s.eval(fr, env, x.object)
+		key := s.eval(fr, env, x.key)
+		s.line = x.line
+		return s.index(obj, key, x.object)
+	case callExpr, methodCallExpr, varargExpr:
+		if values := s.evalMulti(fr, env, e); 0 < len(values) {
+			return values[0]
+		}
+		return nil
+	case parenExpr:
+		return s.eval(fr, env, x.inner)
+	case functionExpr:
+		return &Function{proto: x.proto, env: env}
+	case tableExpr:
+		return s.evalTable(fr, env, x)
+	case unaryExpr:
+		v := s.eval(fr, env, x.operand)
+		s.line = x.line
+		return s.unaryOp(x.op, v)
+	case binaryExpr:
+		switch x.op {
+		case "and":
+			lhs := s.eval(fr, env, x.lhs)
+			if !truthy(lhs) {
+				return lhs
+			}
+			return s.eval(fr, env, x.rhs)
+		case "or":
+			lhs := s.eval(fr, env, x.lhs)
+			if truthy(lhs) {
+				return lhs
+			}
+			return s.eval(fr, env, x.rhs)
+		}
+		lhs := s.eval(fr, env, x.lhs)
+		rhs := s.eval(fr, env, x.rhs)
+		s.line = x.line
+		return s.binaryOp(x.op, lhs, rhs)
+	}
+	s.errorf("invalid expression")
+	return nil
+}
+
+func (s *State) evalTable(fr *frame, env *variable, x tableExpr) *Table {
+	t := NewTable()
+	n := int64(1)
+	for i, f := range x.fields {
+		if f.key != nil {
+			key := s.eval(fr, env, f.key)
+			s.setIndex(t, key, s.eval(fr, env, f.value))
+			continue
+		}
+		if i == len(x.fields)-1 {
+			for _, v := range s.evalMulti(fr, env, f.value) {
+				t.Set(n, v)
+				n++
+			}
+			continue
+		}
+		t.Set(n, s.eval(fr, env, f.value))
+		n++
+	}
+	return t
+}
+
+func (s *State) unaryOp(op string, v Value) Value {
+	switch op {
+	case "not":
+		return !truthy(v)
+	case "-":
+		n, ok := toNumber(v)
+		if !ok {
+			s.errorf("attempt to perform arithmetic on a %s value", TypeName(v))
+		}
+		if i, ok := n.(int64); ok {
+			return -i
+		}
+		return -n.(float64)
+	case "~":
+		return ^s.toBitwiseInteger(v)
+	default: // #
+		switch x := v.(type) {
+		case string:
+			return int64(len(x))
+		case *Table:
+			return x.Len()
+		}
+		s.errorf("attempt to get length of a %s value", TypeName(v))
+	}
+	return nil
+}
+
+func (s *State) toBitwiseInteger(v Value) int64 {
+	n, ok := toNumber(v)
+	if !ok {
+		s.errorf("attempt to perform bitwise operation on a %s value", TypeName(v))
+	}
+	i, ok := toInteger(n)
+	if !ok {
+		s.errorf("number has no integer representation")
+	}
+	return i
+}
+
+func (s *State) binaryOp(op string, a Value, b Value) Value {
+	switch op {
+	case "==":
+		return rawEqual(a, b)
+	case "~=":
+		return !rawEqual(a, b)
+	case "<":
+		return s.lessThan(a, b)
+	case "<=":
+		return s.lessEqual(a, b)
+	case ">":
+		return s.lessThan(b, a)
+	case ">=":
+		return s.lessEqual(b, a)
+	case "..":
+		return s.concat(a, b)
+	case "&", "|", "~", "<<", ">>":
+		x := s.toBitwiseInteger(a)
+		y := s.toBitwiseInteger(b)
+		switch op {
+		case "&":
+			return x & y
+		case "|":
+			return x | y
+		case "~":
+			return x ^ y
+		case "<<":
+			return shiftLeft(x, y)
+		default:
+			return shiftLeft(x, -y)
+		}
+	}
+	return s.arith(op, a, b)
+}
+
+func shiftLeft(x int64, n int64) int64 {
+	switch {
+	case n <= -64 || 64 <= n:
+		return 0
+	case n < 0:
+		return int64(uint64(x) >> uint(-n))
+	}
+	return int64(uint64(x) << uint(n))
+}
+
+func (s *State) arith(op string, a Value, b Value) Value {
+	x, ok := toNumber(a)
+	if !ok {
+		s.errorf("attempt to perform arithmetic on a %s value", TypeName(a))
+	}
+	y, ok := toNumber(b)
+	if !ok {
+		s.errorf("attempt to perform arithmetic on a %s value", TypeName(b))
+	}
+
+	xi, isInt1 := x.(int64)
+	yi, isInt2 := y.(int64)
+	if isInt1 && isInt2 {
+		switch op {
+		case "+":
+			return xi + yi
+		case "-":
+			return xi - yi
+		case "*":
+			return xi * yi
+		case "//":
+			if yi == 0 {
+				s.errorf("attempt to perform 'n//0'")
+			}
+			if yi == -1 {
+				return -xi
+			}
+			q := xi / yi
+			if (xi%yi != 0) && ((xi < 0) != (yi < 0)) {
+				q--
+			}
+			return q
+		case "%":
+			if yi == 0 {
+				s.errorf("attempt to perform 'n%%0'")
+			}
+			if yi == -1 {
+				return int64(0)
+			}
+			r := xi % yi
+			if r != 0 && (r < 0) != (yi < 0) {
+				r += yi
+			}
+			return r
+		}
+	}
+
+	xf, _ := toFloat(x)
+	yf, _ := toFloat(y)
+	switch op {
+	case "+":
+		return xf + yf
+	case "-":
+		return xf - yf
+	case "*":
+		return xf * yf
+	case "/":
+		return xf / yf
+	case "//":
+		return math.Floor(xf / yf)
+	case "%":
+		if math.IsInf(yf, 0) && !math.IsNaN(xf) && !math.IsInf(xf, 0) {
+			if (xf < 0) != (yf < 0) && xf != 0 {
+				return yf
+			}
+			return xf
+		}
+		m := math.Mod(xf, yf)
+		if m != 0 && (m < 0) != (yf < 0) {
+			m += yf
+		}
+		return m
+	default: // ^
+		return math.Pow(xf, yf)
+	}
+}
+
+func (s *State) concat(a Value, b Value) Value {
+	var buf strings.Builder
+	for _, v := range []Value{a, b} {
+		switch v.(type) {
+		case string, int64, float64:
+			buf.WriteString(ToString(v))
+		default:
+			s.errorf("attempt to concatenate a %s value", TypeName(v))
+		}
+	}
+	return s.checkStringLength(buf.String())
+}
+
+func (s *State) compare(a Value, b Value) (int, bool) {
+	switch x := a.(type) {
+	case int64:
+		switch y := b.(type) {
+		case int64:
+			return compareInt(x, y), true
+		case float64:
+			return compareFloat(float64(x), y)
+		}
+	case float64:
+		switch y := b.(type) {
+		case int64:
+			return compareFloat(x, float64(y))
+		case float64:
+			return compareFloat(x, y)
+		}
+	case string:
+		if y, ok := b.(string); ok {
+			return strings.Compare(x, y), true
+		}
+	}
+	t1, t2 := TypeName(a), TypeName(b)
+	if t1 == t2 {
+		s.errorf("attempt to compare two %s values", t1)
+	}
+	s.errorf("attempt to compare %s with %s", t1, t2)
+	return 0, false
+}
+
+func compareInt(x int64, y int64) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
+
+// compareFloat returns false if either operand is NaN.
+func compareFloat(x float64, y float64) (int, bool) {
+	switch {
+	case x < y:
+		return -1, true
+	case x > y:
+		return 1, true
+	case x == y:
+		return 0, true
+	}
+	return 0, false
+}
+
+func (s *State) lessThan(a Value, b Value) bool {
+	c, ok := s.compare(a, b)
+	return ok && c < 0
+}
+
+func (s *State) lessEqual(a Value, b Value) bool {
+	c, ok := s.compare(a, b)
+	return ok && c <= 0
+}
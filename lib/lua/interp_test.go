@@ -0,0 +1,345 @@
+package lua
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+)
+
+var stateCallTests = []struct {
+	Name   string
+	Source string
+	Params []string
+	Args   []Value
+	Result []Value
+	Error  string
+}{
+	{
+		Name:   "Arithmetic",
+		Source: "return 1 + 2 * 3, 7 // 2, 7 / 2, -7 % 3, 2 ^ 10, '10' + 1",
+		Result: []Value{int64(7), int64(3), 3.5, int64(2), 1024.0, int64(11)},
+	},
+	{
+		Name:   "Bitwise Operators",
+		Source: "return 5 & 3, 5 | 3, 5 ~ 3, ~0, 1 << 4, 256 >> 4",
+		Result: []Value{int64(1), int64(7), int64(6), int64(-1), int64(16), int64(16)},
+	},
+	{
+		Name:   "Comparison and Logical Operators",
+		Source: "return 1 == 1.0, 'a' < 'b', nil or 'x', false and 1, not nil, 1 ~= 2",
+		Result: []Value{true, true, "x", false, true, true},
+	},
+	{
+		Name:   "Concatenation",
+		Source: "return 'a' .. 1 .. 2.5 .. 'b', 10.0 .. ''",
+		Result: []Value{"a12.5b", "10.0"},
+	},
+	{
+		Name:   "Parameters",
+		Source: "return a * b, select('#', ...)",
+		Params: []string{"a", "b"},
+		Args:   []Value{int64(6), int64(7), "extra"},
+		Result: []Value{int64(42), int64(1)},
+	},
+	{
+		Name: "Control Structures",
+		Source: `
+local sum = 0
+for i = 1, 10 do
+  if i % 2 == 0 then
+    sum = sum + i
+  elseif i == 5 then
+    sum = sum + 100
+  else
+    sum = sum - 1
+  end
+end
+local n = 0
+while true do
+  n = n + 1
+  if n >= 3 then break end
+end
+repeat
+  local m = n
+  n = n + 1
+until m >= 5
+for i = 10, 1, -3 do sum = sum + i end
+return sum, n`,
+		Result: []Value{int64(148), int64(6)},
+	},
+	{
+		Name: "Closures",
+		Source: `
+local function counter()
+  local c = 0
+  return function() c = c + 1; return c end
+end
+local f, g = counter(), counter()
+f(); f()
+local fns = {}
+for i = 1, 3 do fns[i] = function() return i end end
+return f(), g(), fns[1]() + fns[3]()`,
+		Result: []Value{int64(3), int64(1), int64(4)},
+	},
+	{
+		Name: "Recursion",
+		Source: `
+local function fib(n)
+  if n < 2 then return n end
+  return fib(n - 1) + fib(n - 2)
+end
+return fib(20)`,
+		Result: []Value{int64(6765)},
+	},
+	{
+		Name: "Tables",
+		Source: `
+local t = {10, 20, 30, x = 1, ["y z"] = 2}
+t[#t + 1] = 40
+t.x = nil
+local keys = 0
+for k, v in pairs(t) do keys = keys + 1 end
+local sum = 0
+for i, v in ipairs(t) do sum = sum + v end
+return #t, keys, sum, t["y z"]`,
+		Result: []Value{int64(4), int64(5), int64(100), int64(2)},
+	},
+	{
+		Name: "Methods",
+		Source: `
+local obj = {n = 2}
+function obj:mul(x) return self.n * x end
+return obj:mul(21), ("abc"):upper(), #"hello"`,
+		Result: []Value{int64(42), "ABC", int64(5)},
+	},
+	{
+		Name: "Multiple Assignment",
+		Source: `
+local a, b, c = 1, 2
+a, b = b, a
+local t = {}
+t[1], t[2] = (function() return 'x', 'y' end)()
+return a, b, c, t[1], t[2]`,
+		Result: []Value{int64(2), int64(1), nil, "x", "y"},
+	},
+	{
+		Name:   "Globals",
+		Source: "counter = (counter or 0) + 1 return counter",
+		Result: []Value{int64(1)},
+	},
+	{
+		Name: "Table Library",
+		Source: `
+local t = {5, 2, 8, 1}
+table.sort(t)
+table.insert(t, 1, 0)
+table.insert(t, 9)
+local removed = table.remove(t, 2)
+table.sort(t, function(a, b) return a > b end)
+return table.concat(t, ","), removed, table.unpack({1, 2})`,
+		Result: []Value{"9,8,5,2,0", int64(1), int64(1), int64(2)},
+	},
+	{
+		Name:   "Math Library",
+		Source: "return math.floor(3.7), math.ceil(3.2), math.max(1, 5, 3), math.min(2.5, 1), math.abs(-3), math.tointeger(4.0), math.type(1), math.huge",
+		Result: []Value{int64(3), int64(4), int64(5), int64(1), int64(3), int64(4), "integer", math.Inf(1)},
+	},
+	{
+		Name:   "Base Library",
+		Source: "return tonumber('0x10'), tonumber('z', 36), tonumber('abc'), tostring(1.5), type({}), select(2, 'a', 'b', 'c')",
+		Result: []Value{int64(16), int64(35), nil, "1.5", "table", "b", "c"},
+	},
+	{
+		Name:   "String Library",
+		Source: "return ('hello'):sub(2, -2), ('abc'):rep(3, '-'), ('abc'):reverse(), string.byte('A'), string.char(72, 105), ('%5.2f|%-3d|%x|%s|%q'):format(3.14159, 7, 255, nil, 'a\"b')",
+		Result: []Value{"ell", "abc-abc-abc", "cba", int64(65), "Hi", " 3.14|7  |ff|nil|\"a\\\"b\""},
+	},
+	{
+		Name:   "String Find and Match",
+		Source: "local i, j = ('hello world'):find('l+') return ('hello world'):find('o w'), i, j, ('  trim  '):match('^%s*(.-)%s*$'), ('x'):find('.', 1, true), ('key=value'):match('(%w+)=(%w+)')",
+		Result: []Value{int64(5), int64(3), int64(4), "trim", nil, "key", "value"},
+	},
+	{
+		Name: "String Gsub and Gmatch",
+		Source: `
+local words = {}
+for w in ('one two  three'):gmatch('%a+') do words[#words + 1] = w end
+local s1, n1 = ('hello world'):gsub('o', '0')
+local s2 = ('$name is $age'):gsub('%$(%w+)', {name = 'Bob', age = 42})
+local s3 = ('abc'):gsub('%w', function(c) return c:upper() .. '.' end)
+local s4 = ('hello'):gsub('(l)(l)', '%2%1[%0]')
+return table.concat(words, ','), s1, n1, s2, s3, s4`,
+		Result: []Value{"one,two,three", "hell0 w0rld", int64(2), "Bob is 42", "A.B.C.", "hell[ll]o"},
+	},
+	{
+		Name:   "Pattern Classes",
+		Source: "local i, j = ('THE (quick) fox'):find('%f[%a]%a+', 5) return i, j, ('x = [[a]]'):match('%b[]'), ('2024-01-15'):match('(%d+)-(%d+)-(%d+)')",
+		Result: []Value{int64(6), int64(10), "[[a]]", "2024", "01", "15"},
+	},
+	{
+		Name: "Protected Call",
+		Source: `
+local ok1, err1 = pcall(error, {code = 1})
+local ok2, err2 = pcall(function() local x = nil; return x.y end)
+local ok3, v = pcall(function(a) return a * 2 end, 21)
+return ok1, err1.code, ok2, err2, ok3, v`,
+		Result: []Value{false, int64(1), false, "line 3: attempt to index a nil value (variable 'x')", true, int64(42)},
+	},
+	{
+		Name:   "Runtime Error",
+		Source: "local t = {}\nreturn t.a.b",
+		Error:  "line 2: attempt to index a nil value (field 'a')",
+	},
+	{
+		Name:   "Call Error",
+		Source: "return undefined_function(1)",
+		Error:  "line 1: attempt to call a nil value (variable 'undefined_function')",
+	},
+	{
+		Name:   "Arithmetic Error",
+		Source: "return 1 // 0",
+		Error:  "line 1: attempt to perform 'n//0'",
+	},
+	{
+		Name:   "Error Function",
+		Source: "\nerror('custom error')",
+		Error:  "line 2: custom error",
+	},
+	{
+		Name:   "Argument Error",
+		Source: "return ('x'):rep({})",
+		Error:  "line 1: bad argument #2 to 'rep' (number expected, got table)",
+	},
+	{
+		Name:   "Step Limit Error",
+		Source: "while true do end",
+		Error:  "line 1: execution exceeded the step limit",
+	},
+	{
+		Name:   "Step Limit Cannot Be Caught",
+		Source: "pcall(function() while true do end end) return 1",
+		Error:  "line 1: execution exceeded the step limit",
+	},
+	{
+		Name:   "Stack Overflow Error",
+		Source: "local function f() return 1 + f() end return f()",
+		Error:  "line 1: stack overflow",
+	},
+	{
+		Name:   "String Length Error",
+		Source: "return ('x'):rep(100000)",
+		Error:  "line 1: string length exceeded the limit",
+	},
+	{
+		Name:   "Sandboxed Libraries",
+		Source: "return io, os, require, load, dofile",
+		Result: []Value{nil, nil, nil, nil, nil},
+	},
+}
+
+func TestState_Call(t *testing.T) {
+	for _, v := range stateCallTests {
+		fn, err := Compile(v.Name, v.Source, v.Params)
+		if err != nil {
+			t.Errorf("%s: unexpected compile error %q", v.Name, err)
+			continue
+		}
+
+		s := NewState(Limits{MaxSteps: 100000, MaxCallDepth: 50, MaxStringLength: 10000})
+		result, err := s.Call(context.Background(), fn, v.Args...)
+		if err != nil {
+			if len(v.Error) < 1 {
+				t.Errorf("%s: unexpected error %q", v.Name, err)
+			} else if err.Error() != v.Error {
+				t.Errorf("%s: error %q, want error %q", v.Name, err.Error(), v.Error)
+			}
+			continue
+		}
+		if 0 < len(v.Error) {
+			t.Errorf("%s: no error, want error %q", v.Name, v.Error)
+			continue
+		}
+		if !reflect.DeepEqual(result, v.Result) {
+			t.Errorf("%s: result = %#v, want %#v", v.Name, result, v.Result)
+		}
+	}
+}
+
+func TestState_CallCanceled(t *testing.T) {
+	fn, _ := Compile("loop", "while true do end", nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	s := NewState(DefaultLimits)
+	_, err := s.Call(ctx, fn)
+	if err == nil || err.Error() != "line 1: context canceled" {
+		t.Errorf("error = %v, want error %q", err, "line 1: context canceled")
+	}
+}
+
+var compileTests = []struct {
+	Name   string
+	Source string
+	Error  string
+}{
+	{
+		Name:   "Valid Source",
+		Source: "local t = {[1] = 'a'; b = [[long\nstring]]} -- comment\n--[==[ block\ncomment ]==]\nreturn t",
+	},
+	{
+		Name:   "Unfinished String",
+		Source: "return 'abc",
+		Error:  "line 1: unfinished string",
+	},
+	{
+		Name:   "Missing End",
+		Source: "if true then\nreturn 1",
+		Error:  "line 2: 'end' expected (to close 'if' at line 1) near <eof>",
+	},
+	{
+		Name:   "Statement After Return",
+		Source: "return 1\nx = 2",
+		Error:  "line 2: '<eof>' expected near 'x'",
+	},
+	{
+		Name:   "Goto",
+		Source: "goto continue",
+		Error:  "line 1: goto statements and labels are not supported",
+	},
+	{
+		Name:   "Invalid Assignment",
+		Source: "f() = 1",
+		Error:  "line 1: syntax error near '='",
+	},
+	{
+		Name:   "Too Many Nested Levels",
+		Source: "return " + repeatString("(", 300) + "1" + repeatString(")", 300),
+		Error:  "line 1: too many nested levels",
+	},
+}
+
+func repeatString(s string, n int) string {
+	r := ""
+	for i := 0; i < n; i++ {
+		r += s
+	}
+	return r
+}
+
+func TestCompile(t *testing.T) {
+	for _, v := range compileTests {
+		_, err := Compile(v.Name, v.Source, nil)
+		if err != nil {
+			if len(v.Error) < 1 {
+				t.Errorf("%s: unexpected error %q", v.Name, err)
+			} else if err.Error() != v.Error {
+				t.Errorf("%s: error %q, want error %q", v.Name, err.Error(), v.Error)
+			}
+			continue
+		}
+		if 0 < len(v.Error) {
+			t.Errorf("%s: no error, want error %q", v.Name, v.Error)
+		}
+	}
+}
@@ -0,0 +1,390 @@
+package lua
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokName
+	tokString
+	tokInteger
+	tokFloat
+	tokKeyword
+	tokSymbol
+)
+
+var keywords = map[string]bool{
+	"and": true, "break": true, "do": true, "else": true, "elseif": true, "end": true,
+	"false": true, "for": true, "function": true, "goto": true, "if": true, "in": true,
+	"local": true, "nil": true, "not": true, "or": true, "repeat": true, "return": true,
+	"then": true, "true": true, "until": true, "while": true,
+}
+
+// Symbols are ordered so that longer symbols are matched first.
+var symbols = []string{
+	"...", "..", "==", "~=", "<=", ">=", "<<", ">>", "//", "::",
+	"+", "-", "*", "/", "%", "^", "#", "&", "~", "|", "<", ">", "=",
+	"(", ")", "{", "}", "[", "]", ";", ":", ",", ".",
+}
+
+type token struct {
+	kind tokenKind
+	text string
+	ival int64
+	fval float64
+	line int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "<eof>"
+	case tokString:
+		return strconv.Quote(t.text)
+	}
+	return "'" + t.text + "'"
+}
+
+type lexer struct {
+	src  string
+	pos  int
+	line int
+}
+
+func newLexer(src string) *lexer {
+	return &lexer{src: src, line: 1}
+}
+
+func (l *lexer) errorf(format string, args ...interface{}) {
+	panic(&Error{Message: fmt.Sprintf("line %d: %s", l.line, fmt.Sprintf(format, args...))})
+}
+
+func (l *lexer) peekByte(offset int) byte {
+	if len(l.src) <= l.pos+offset {
+		return 0
+	}
+	return l.src[l.pos+offset]
+}
+
+func (l *lexer) skipSpacesAndComments() {
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case c == '\n':
+			l.line++
+			l.pos++
+		case c == ' ' || c == '\t' || c == '\r' || c == '\v' || c == '\f':
+			l.pos++
+		case c == '-' && l.peekByte(1) == '-':
+			l.pos += 2
+			if l.peekByte(0) == '[' {
+				if level := l.longBracketLevel(); 0 <= level {
+					l.readLongString(level)
+					continue
+				}
+			}
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				l.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// longBracketLevel returns the level of the opening long bracket at the
+// current position, or -1 if there is no long bracket.
+func (l *lexer) longBracketLevel() int {
+	i := l.pos + 1
+	for i < len(l.src) && l.src[i] == '=' {
+		i++
+	}
+	if i < len(l.src) && l.src[i] == '[' {
+		return i - l.pos - 1
+	}
+	return -1
+}
+
+func (l *lexer) readLongString(level int) string {
+	start := l.pos + level + 2
+	closing := "]" + strings.Repeat("=", level) + "]"
+	end := strings.Index(l.src[start:], closing)
+	if end < 0 {
+		l.errorf("unfinished long string")
+	}
+	s := l.src[start : start+end]
+	l.line += strings.Count(s, "\n")
+	l.pos = start + end + len(closing)
+	if strings.HasPrefix(s, "\r\n") {
+		s = s[2:]
+	} else if strings.HasPrefix(s, "\n") {
+		s = s[1:]
+	}
+	return s
+}
+
+func (l *lexer) next() token {
+	l.skipSpacesAndComments()
+	if len(l.src) <= l.pos {
+		return token{kind: tokEOF, line: l.line}
+	}
+
+	c := l.src[l.pos]
+	switch {
+	case isNameStart(l.src[l.pos:]):
+		start := l.pos
+		for l.pos < len(l.src) {
+			r, size := utf8.DecodeRuneInString(l.src[l.pos:])
+			if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+				break
+			}
+			l.pos += size
+		}
+		text := l.src[start:l.pos]
+		if keywords[text] {
+			return token{kind: tokKeyword, text: text, line: l.line}
+		}
+		return token{kind: tokName, text: text, line: l.line}
+	case '0' <= c && c <= '9' || (c == '.' && '0' <= l.peekByte(1) && l.peekByte(1) <= '9'):
+		return l.readNumber()
+	case c == '"' || c == '\'':
+		return l.readString(c)
+	case c == '[':
+		if level := l.longBracketLevel(); 0 <= level {
+			line := l.line
+			return token{kind: tokString, text: l.readLongString(level), line: line}
+		}
+	}
+
+	for _, sym := range symbols {
+		if strings.HasPrefix(l.src[l.pos:], sym) {
+			l.pos += len(sym)
+			return token{kind: tokSymbol, text: sym, line: l.line}
+		}
+	}
+	l.errorf("unexpected symbol near '%c'", c)
+	return token{}
+}
+
+func isNameStart(s string) bool {
+	r, _ := utf8.DecodeRuneInString(s)
+	return r == '_' || unicode.IsLetter(r)
+}
+
+func (l *lexer) readNumber() token {
+	start := l.pos
+	isHex := l.src[l.pos] == '0' && (l.peekByte(1) == 'x' || l.peekByte(1) == 'X')
+	if isHex {
+		l.pos += 2
+	}
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		if isHex && (c == 'p' || c == 'P') || !isHex && (c == 'e' || c == 'E') {
+			l.pos++
+			if l.peekByte(0) == '+' || l.peekByte(0) == '-' {
+				l.pos++
+			}
+			continue
+		}
+		if c == '.' || '0' <= c && c <= '9' || isHex && ('a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+			l.pos++
+			continue
+		}
+		break
+	}
+
+	text := l.src[start:l.pos]
+	v, ok := parseNumber(text)
+	if !ok {
+		l.errorf("malformed number near '%s'", text)
+	}
+	if i, ok := v.(int64); ok {
+		return token{kind: tokInteger, text: text, ival: i, line: l.line}
+	}
+	return token{kind: tokFloat, text: text, fval: v.(float64), line: l.line}
+}
+
+// parseNumber converts a numeral to an int64 or a float64.
+func parseNumber(s string) (Value, bool) {
+	s = strings.TrimSpace(s)
+	neg := false
+	body := s
+	if strings.HasPrefix(body, "-") {
+		neg = true
+		body = body[1:]
+	} else if strings.HasPrefix(body, "+") {
+		body = body[1:]
+	}
+	if len(body) < 1 {
+		return nil, false
+	}
+
+	if strings.HasPrefix(body, "0x") || strings.HasPrefix(body, "0X") {
+		hex := body[2:]
+		if !strings.ContainsAny(hex, ".pP") {
+			if len(hex) < 1 {
+				return nil, false
+			}
+			var u uint64
+			for _, c := range hex {
+				d, ok := hexDigit(c)
+				if !ok {
+					return nil, false
+				}
+				u = u<<4 | uint64(d)
+			}
+			if neg {
+				return -int64(u), true
+			}
+			return int64(u), true
+		}
+		if !strings.ContainsAny(hex, "pP") {
+			hex += "p0"
+		}
+		f, err := strconv.ParseFloat("0x"+hex, 64)
+		if err != nil {
+			return nil, false
+		}
+		if neg {
+			f = -f
+		}
+		return f, true
+	}
+
+	for _, c := range body {
+		if !('0' <= c && c <= '9' || c == '.' || c == 'e' || c == 'E' || c == '+' || c == '-') {
+			return nil, false
+		}
+	}
+	if !strings.ContainsAny(body, ".eE") {
+		if i, err := strconv.ParseInt(body, 10, 64); err == nil {
+			if neg {
+				return -i, true
+			}
+			return i, true
+		}
+	}
+	f, err := strconv.ParseFloat(body, 64)
+	if err != nil && !(math.IsInf(f, 0)) {
+		return nil, false
+	}
+	if neg {
+		f = -f
+	}
+	return f, true
+}
+
+func hexDigit(c rune) (int, bool) {
+	switch {
+	case '0' <= c && c <= '9':
+		return int(c - '0'), true
+	case 'a' <= c && c <= 'f':
+		return int(c-'a') + 10, true
+	case 'A' <= c && c <= 'F':
+		return int(c-'A') + 10, true
+	}
+	return 0, false
+}
+
+func (l *lexer) readString(quote byte) token {
+	line := l.line
+	l.pos++
+	buf := &bytes.Buffer{}
+	for {
+		if len(l.src) <= l.pos {
+			l.errorf("unfinished string")
+		}
+		c := l.src[l.pos]
+		switch c {
+		case quote:
+			l.pos++
+			return token{kind: tokString, text: buf.String(), line: line}
+		case '\n':
+			l.errorf("unfinished string")
+		case '\\':
+			l.pos++
+			l.readEscape(buf)
+		default:
+			buf.WriteByte(c)
+			l.pos++
+		}
+	}
+}
+
+func (l *lexer) readEscape(buf *bytes.Buffer) {
+	c := l.peekByte(0)
+	l.pos++
+	switch c {
+	case 'n':
+		buf.WriteByte('\n')
+	case 't':
+		buf.WriteByte('\t')
+	case 'r':
+		buf.WriteByte('\r')
+	case 'a':
+		buf.WriteByte('\a')
+	case 'b':
+		buf.WriteByte('\b')
+	case 'f':
+		buf.WriteByte('\f')
+	case 'v':
+		buf.WriteByte('\v')
+	case '\\', '"', '\'':
+		buf.WriteByte(c)
+	case '\n':
+		l.line++
+		buf.WriteByte('\n')
+	case 'x':
+		h1, ok1 := hexDigit(rune(l.peekByte(0)))
+		h2, ok2 := hexDigit(rune(l.peekByte(1)))
+		if !ok1 || !ok2 {
+			l.errorf("hexadecimal digit expected")
+		}
+		buf.WriteByte(byte(h1<<4 | h2))
+		l.pos += 2
+	case 'z':
+		for l.pos < len(l.src) && strings.IndexByte(" \t\r\n\v\f", l.src[l.pos]) >= 0 {
+			if l.src[l.pos] == '\n' {
+				l.line++
+			}
+			l.pos++
+		}
+	case 'u':
+		if l.peekByte(0) != '{' {
+			l.errorf("missing '{' in \\u{xxxx}")
+		}
+		end := strings.IndexByte(l.src[l.pos:], '}')
+		if end < 0 {
+			l.errorf("missing '}' in \\u{xxxx}")
+		}
+		r, err := strconv.ParseUint(l.src[l.pos+1:l.pos+end], 16, 32)
+		if err != nil || unicode.MaxRune < rune(r) {
+			l.errorf("UTF-8 value too large")
+		}
+		buf.WriteRune(rune(r))
+		l.pos += end + 1
+	default:
+		if '0' <= c && c <= '9' {
+			n := int(c - '0')
+			for i := 0; i < 2 && '0' <= l.peekByte(0) && l.peekByte(0) <= '9'; i++ {
+				n = n*10 + int(l.peekByte(0)-'0')
+				l.pos++
+			}
+			if 255 < n {
+				l.errorf("decimal escape too large")
+			}
+			buf.WriteByte(byte(n))
+			return
+		}
+		l.errorf("invalid escape sequence '\\%c'", c)
+	}
+}
@@ -0,0 +1,480 @@
+package lua
+
+const maxNestingLevel = 200
+
+type parser struct {
+	lexer *lexer
+	tok   token
+	ahead *token
+	level int
+}
+
+func parse(src string, name string, params []string) (p *proto, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if e, ok := r.(*Error); ok {
+				p = nil
+				err = e
+				return
+			}
+			panic(r)
+		}
+	}()
+
+	ps := &parser{lexer: newLexer(src)}
+	ps.advance()
+	body := ps.block()
+	if ps.tok.kind != tokEOF {
+		ps.errorf("'<eof>' expected near %s", ps.tok)
+	}
+	return &proto{name: name, params: params, isVararg: true, body: body}, nil
+}
+
+func (p *parser) errorf(format string, args ...interface{}) {
+	p.lexer.line = p.tok.line
+	p.lexer.errorf(format, args...)
+}
+
+func (p *parser) advance() {
+	if p.ahead != nil {
+		p.tok = *p.ahead
+		p.ahead = nil
+		return
+	}
+	p.tok = p.lexer.next()
+}
+
+func (p *parser) peek() token {
+	if p.ahead == nil {
+		t := p.lexer.next()
+		p.ahead = &t
+	}
+	return *p.ahead
+}
+
+func (p *parser) is(text string) bool {
+	return (p.tok.kind == tokSymbol || p.tok.kind == tokKeyword) && p.tok.text == text
+}
+
+func (p *parser) accept(text string) bool {
+	if p.is(text) {
+		p.advance()
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(text string) {
+	if !p.accept(text) {
+		p.errorf("'%s' expected near %s", text, p.tok)
+	}
+}
+
+func (p *parser) expectMatch(text string, open string, line int) {
+	if !p.accept(text) {
+		if line == p.tok.line {
+			p.errorf("'%s' expected near %s", text, p.tok)
+		}
+		p.errorf("'%s' expected (to close '%s' at line %d) near %s", text, open, line, p.tok)
+	}
+}
+
+func (p *parser) name() string {
+	if p.tok.kind != tokName {
+		p.errorf("<name> expected near %s", p.tok)
+	}
+	s := p.tok.text
+	p.advance()
+	return s
+}
+
+func (p *parser) blockFollows() bool {
+	if p.tok.kind == tokEOF {
+		return true
+	}
+	return p.tok.kind == tokKeyword && (p.tok.text == "else" || p.tok.text == "elseif" || p.tok.text == "end" || p.tok.text == "until")
+}
+
+func (p *parser) enter() {
+	p.level++
+	if maxNestingLevel < p.level {
+		p.errorf("too many nested levels")
+	}
+}
+
+func (p *parser) leave() {
+	p.level--
+}
+
+func (p *parser) block() []stmt {
+	p.enter()
+	defer p.leave()
+
+	stmts := make([]stmt, 0, 8)
+	for !p.blockFollows() {
+		if p.is("return") {
+			stmts = append(stmts, p.returnStatement())
+			break
+		}
+		if s := p.statement(); s != nil {
+			stmts = append(stmts, s)
+		}
+	}
+	return stmts
+}
+
+func (p *parser) returnStatement() stmt {
+	p.advance()
+	s := returnStmt{}
+	if !p.blockFollows() && !p.is(";") {
+		s.values = p.exprList()
+	}
+	p.accept(";")
+	if !p.blockFollows() {
+		p.errorf("'<eof>' expected near %s", p.tok)
+	}
+	return s
+}
+
+func (p *parser) statement() stmt {
+	line := p.tok.line
+	switch {
+	case p.accept(";"):
+		return nil
+	case p.accept("if"):
+		s := ifStmt{}
+		s.conds = append(s.conds, p.expr())
+		p.expect("then")
+		s.blocks = append(s.blocks, p.block())
+		for p.accept("elseif") {
+			s.conds = append(s.conds, p.expr())
+			p.expect("then")
+			s.blocks = append(s.blocks, p.block())
+		}
+		if p.accept("else") {
+			s.orElse = p.block()
+		}
+		p.expectMatch("end", "if", line)
+		return s
+	case p.accept("while"):
+		s := whileStmt{cond: p.expr(), line: line}
+		p.expect("do")
+		s.body = p.block()
+		p.expectMatch("end", "while", line)
+		return s
+	case p.accept("do"):
+		s := doStmt{body: p.block()}
+		p.expectMatch("end", "do", line)
+		return s
+	case p.accept("for"):
+		return p.forStatement(line)
+	case p.accept("repeat"):
+		s := repeatStmt{body: p.block(), line: line}
+		p.expectMatch("until", "repeat", line)
+		s.cond = p.expr()
+		return s
+	case p.accept("function"):
+		return p.functionStatement(line)
+	case p.accept("local"):
+		if p.accept("function") {
+			name := p.name()
+			return localFunctionStmt{name: name, proto: p.functionBody(name, false, line)}
+		}
+		s := localStmt{}
+		s.names = append(s.names, p.localName())
+		for p.accept(",") {
+			s.names = append(s.names, p.localName())
+		}
+		if p.accept("=") {
+			s.values = p.exprList()
+		}
+		return s
+	case p.accept("break"):
+		return breakStmt{}
+	case p.is("goto") || p.is("::"):
+		p.errorf("goto statements and labels are not supported")
+	}
+	return p.exprStatement()
+}
+
+func (p *parser) localName() string {
+	name := p.name()
+	if p.accept("<") {
+		attr := p.name()
+		if attr != "const" {
+			p.errorf("unknown attribute '%s'", attr)
+		}
+		p.expect(">")
+	}
+	return name
+}
+
+func (p *parser) forStatement(line int) stmt {
+	first := p.name()
+	if p.accept("=") {
+		s := numericForStmt{name: first, line: line}
+		s.start = p.expr()
+		p.expect(",")
+		s.limit = p.expr()
+		if p.accept(",") {
+			s.step = p.expr()
+		}
+		p.expect("do")
+		s.body = p.block()
+		p.expectMatch("end", "for", line)
+		return s
+	}
+
+	s := genericForStmt{names: []string{first}, line: line}
+	for p.accept(",") {
+		s.names = append(s.names, p.name())
+	}
+	p.expect("in")
+	s.exprs = p.exprList()
+	p.expect("do")
+	s.body = p.block()
+	p.expectMatch("end", "for", line)
+	return s
+}
+
+func (p *parser) functionStatement(line int) stmt {
+	fullName := p.tok.text
+	var target expr = nameExpr{name: p.name(), line: line}
+	isMethod := false
+	for p.is(".") || p.is(":") {
+		isMethod = p.is(":")
+		p.advance()
+		key := p.name()
+		fullName += "." + key
+		target = indexExpr{object: target, key: constExpr{value: key}, line: line}
+		if isMethod {
+			break
+		}
+	}
+	fn := functionExpr{proto: p.functionBody(fullName, isMethod, line)}
+	return assignStmt{targets: []expr{target}, values: []expr{fn}, line: line}
+}
+
+func (p *parser) functionBody(name string, isMethod bool, line int) *proto {
+	pr := &proto{name: name}
+	if isMethod {
+		pr.params = append(pr.params, "self")
+	}
+	p.expect("(")
+	if !p.is(")") {
+		for {
+			if p.accept("...") {
+				pr.isVararg = true
+				break
+			}
+			pr.params = append(pr.params, p.name())
+			if !p.accept(",") {
+				break
+			}
+		}
+	}
+	p.expect(")")
+	pr.body = p.block()
+	p.expectMatch("end", "function", line)
+	return pr
+}
+
+func (p *parser) exprStatement() stmt {
+	line := p.tok.line
+	e := p.suffixedExpr()
+	if p.is("=") || p.is(",") {
+		targets := []expr{e}
+		for p.accept(",") {
+			targets = append(targets, p.suffixedExpr())
+		}
+		for _, t := range targets {
+			switch t.(type) {
+			case nameExpr, indexExpr:
+			default:
+				p.errorf("syntax error near %s", p.tok)
+			}
+		}
+		p.expect("=")
+		return assignStmt{targets: targets, values: p.exprList(), line: line}
+	}
+
+	switch e.(type) {
+	case callExpr, methodCallExpr:
+		return callStmt{call: e}
+	}
+	p.errorf("syntax error near %s", p.tok)
+	return nil
+}
+
+func (p *parser) exprList() []expr {
+	list := []expr{p.expr()}
+	for p.accept(",") {
+		list = append(list, p.expr())
+	}
+	return list
+}
+
+func (p *parser) primaryExpr() expr {
+	switch {
+	case p.tok.kind == tokName:
+		line := p.tok.line
+		return nameExpr{name: p.name(), line: line}
+	case p.is("("):
+		line := p.tok.line
+		p.advance()
+		e := p.expr()
+		p.expectMatch(")", "(", line)
+		return parenExpr{inner: e}
+	}
+	p.errorf("unexpected symbol near %s", p.tok)
+	return nil
+}
+
+func (p *parser) suffixedExpr() expr {
+	e := p.primaryExpr()
+	for {
+		line := p.tok.line
+		switch {
+		case p.accept("."):
+			e = indexExpr{object: e, key: constExpr{value: p.name()}, line: line}
+		case p.accept("["):
+			key := p.expr()
+			p.expect("]")
+			e = indexExpr{object: e, key: key, line: line}
+		case p.accept(":"):
+			method := p.name()
+			e = methodCallExpr{object: e, method: method, args: p.callArgs(), line: line}
+		case p.is("(") || p.is("{") || p.tok.kind == tokString:
+			e = callExpr{fn: e, args: p.callArgs(), line: line}
+		default:
+			return e
+		}
+	}
+}
+
+func (p *parser) callArgs() []expr {
+	switch {
+	case p.tok.kind == tokString:
+		s := p.tok.text
+		p.advance()
+		return []expr{constExpr{value: s}}
+	case p.is("{"):
+		return []expr{p.tableConstructor()}
+	}
+	line := p.tok.line
+	p.expect("(")
+	if p.accept(")") {
+		return nil
+	}
+	args := p.exprList()
+	p.expectMatch(")", "(", line)
+	return args
+}
+
+func (p *parser) tableConstructor() expr {
+	line := p.tok.line
+	p.expect("{")
+	t := tableExpr{}
+	for !p.is("}") {
+		switch {
+		case p.is("["):
+			p.advance()
+			key := p.expr()
+			p.expect("]")
+			p.expect("=")
+			t.fields = append(t.fields, tableField{key: key, value: p.expr()})
+		case p.tok.kind == tokName && p.peek().kind == tokSymbol && p.peek().text == "=":
+			key := p.name()
+			p.advance()
+			t.fields = append(t.fields, tableField{key: constExpr{value: key}, value: p.expr()})
+		default:
+			t.fields = append(t.fields, tableField{value: p.expr()})
+		}
+		if !p.accept(",") && !p.accept(";") {
+			break
+		}
+	}
+	p.expectMatch("}", "{", line)
+	return t
+}
+
+func (p *parser) simpleExpr() expr {
+	t := p.tok
+	switch t.kind {
+	case tokInteger:
+		p.advance()
+		return constExpr{value: t.ival}
+	case tokFloat:
+		p.advance()
+		return constExpr{value: t.fval}
+	case tokString:
+		p.advance()
+		return constExpr{value: t.text}
+	case tokKeyword:
+		switch t.text {
+		case "nil":
+			p.advance()
+			return constExpr{value: nil}
+		case "true":
+			p.advance()
+			return constExpr{value: true}
+		case "false":
+			p.advance()
+			return constExpr{value: false}
+		case "function":
+			p.advance()
+			return functionExpr{proto: p.functionBody("anonymous", false, t.line)}
+		}
+	case tokSymbol:
+		switch t.text {
+		case "...":
+			p.advance()
+			return varargExpr{}
+		case "{":
+			return p.tableConstructor()
+		}
+	}
+	return p.suffixedExpr()
+}
+
+var binaryPriority = map[string][2]int{
+	"or": {1, 1}, "and": {2, 2},
+	"<": {3, 3}, ">": {3, 3}, "<=": {3, 3}, ">=": {3, 3}, "~=": {3, 3}, "==": {3, 3},
+	"|": {4, 4}, "~": {5, 5}, "&": {6, 6}, "<<": {7, 7}, ">>": {7, 7},
+	"..": {9, 8}, "+": {10, 10}, "-": {10, 10},
+	"*": {11, 11}, "/": {11, 11}, "//": {11, 11}, "%": {11, 11},
+	"^": {14, 13},
+}
+
+const unaryPriority = 12
+
+func (p *parser) expr() expr {
+	return p.subExpr(0)
+}
+
+func (p *parser) subExpr(limit int) expr {
+	p.enter()
+	defer p.leave()
+
+	var e expr
+	if p.is("not") || p.is("-") || p.is("#") || p.is("~") {
+		op := p.tok.text
+		line := p.tok.line
+		p.advance()
+		e = unaryExpr{op: op, operand: p.subExpr(unaryPriority), line: line}
+	} else {
+		e = p.simpleExpr()
+	}
+
+	for p.tok.kind == tokSymbol || p.tok.kind == tokKeyword {
+		prio, ok := binaryPriority[p.tok.text]
+		if !ok || prio[0] <= limit {
+			break
+		}
+		op := p.tok.text
+		line := p.tok.line
+		p.advance()
+		e = binaryExpr{op: op, lhs: e, rhs: p.subExpr(prio[1]), line: line}
+	}
+	return e
+}
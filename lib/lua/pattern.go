@@ -0,0 +1,404 @@
+package lua
+
+import (
+	"strings"
+)
+
+const (
+	maxCaptures     = 32
+	maxMatchDepth   = 200
+	captureUnclosed = -1
+	capturePosition = -2
+	patternSpecials = "^$*+?.([%-"
+)
+
+type capture struct {
+	init int
+	len  int
+}
+
+// matchState is a port of the pattern matching in lstrlib.c.
+type matchState struct {
+	state    *State
+	src      string
+	pat      string
+	level    int
+	depth    int
+	captures [maxCaptures]capture
+}
+
+func newMatchState(s *State, src string, pat string) *matchState {
+	return &matchState{state: s, src: src, pat: pat, depth: maxMatchDepth}
+}
+
+func (ms *matchState) reset() {
+	ms.level = 0
+	ms.depth = maxMatchDepth
+}
+
+func (ms *matchState) patByte(p int) byte {
+	if p < len(ms.pat) {
+		return ms.pat[p]
+	}
+	return 0
+}
+
+func (ms *matchState) classEnd(p int) int {
+	c := ms.pat[p]
+	p++
+	switch c {
+	case '%':
+		if len(ms.pat) <= p {
+			ms.state.errorf("malformed pattern (ends with '%%')")
+		}
+		return p + 1
+	case '[':
+		if ms.patByte(p) == '^' {
+			p++
+		}
+		for {
+			if len(ms.pat) <= p {
+				ms.state.errorf("malformed pattern (missing ']')")
+			}
+			c := ms.pat[p]
+			p++
+			if c == '%' && p < len(ms.pat) {
+				p++
+			}
+			if len(ms.pat) <= p {
+				ms.state.errorf("malformed pattern (missing ']')")
+			}
+			if ms.pat[p] == ']' {
+				return p + 1
+			}
+		}
+	}
+	return p
+}
+
+func isAlpha(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isLower(c byte) bool {
+	return 'a' <= c && c <= 'z'
+}
+
+func isUpper(c byte) bool {
+	return 'A' <= c && c <= 'Z'
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || '\t' <= c && c <= '\r'
+}
+
+func isControl(c byte) bool {
+	return c < 0x20 || c == 0x7f
+}
+
+func isPunct(c byte) bool {
+	return 0x21 <= c && c <= 0x7e && !isAlpha(c) && !isDigit(c)
+}
+
+func isHexDigit(c byte) bool {
+	return isDigit(c) || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+func matchClass(c byte, cl byte) bool {
+	var res bool
+	switch cl | 0x20 {
+	case 'a':
+		res = isAlpha(c)
+	case 'c':
+		res = isControl(c)
+	case 'd':
+		res = isDigit(c)
+	case 'g':
+		res = 0x21 <= c && c <= 0x7e
+	case 'l':
+		res = isLower(c)
+	case 'p':
+		res = isPunct(c)
+	case 's':
+		res = isSpace(c)
+	case 'u':
+		res = isUpper(c)
+	case 'w':
+		res = isAlpha(c) || isDigit(c)
+	case 'x':
+		res = isHexDigit(c)
+	default:
+		return cl == c
+	}
+	if isUpper(cl) {
+		return !res
+	}
+	return res
+}
+
+// matchBracketClass matches c with the class from p ('[') to ec (']').
+func (ms *matchState) matchBracketClass(c byte, p int, ec int) bool {
+	sig := true
+	if ms.pat[p+1] == '^' {
+		sig = false
+		p++
+	}
+	for p++; p < ec; p++ {
+		switch {
+		case ms.pat[p] == '%':
+			p++
+			if matchClass(c, ms.pat[p]) {
+				return sig
+			}
+		case ms.pat[p+1] == '-' && p+2 < ec:
+			p += 2
+			if ms.pat[p-2] <= c && c <= ms.pat[p] {
+				return sig
+			}
+		case ms.pat[p] == c:
+			return sig
+		}
+	}
+	return !sig
+}
+
+func (ms *matchState) singleMatch(s int, p int, ep int) bool {
+	if len(ms.src) <= s {
+		return false
+	}
+	c := ms.src[s]
+	switch ms.pat[p] {
+	case '.':
+		return true
+	case '%':
+		return matchClass(c, ms.pat[p+1])
+	case '[':
+		return ms.matchBracketClass(c, p, ep-1)
+	}
+	return ms.pat[p] == c
+}
+
+// match returns the end of the match of pat[p:] at src[s:], or -1.
+func (ms *matchState) match(s int, p int) int {
+	ms.depth--
+	if ms.depth == 0 {
+		ms.state.errorf("pattern too complex")
+	}
+	defer func() {
+		ms.depth++
+	}()
+
+	for {
+		ms.state.step()
+		if len(ms.pat) <= p {
+			return s
+		}
+		switch ms.pat[p] {
+		case '(':
+			if ms.patByte(p+1) == ')' {
+				return ms.startCapture(s, p+2, capturePosition)
+			}
+			return ms.startCapture(s, p+1, captureUnclosed)
+		case ')':
+			return ms.endCapture(s, p+1)
+		case '$':
+			if p+1 == len(ms.pat) {
+				if s == len(ms.src) {
+					return s
+				}
+				return -1
+			}
+		case '%':
+			switch next := ms.patByte(p + 1); {
+			case next == 'b':
+				if s = ms.matchBalance(s, p+2); s < 0 {
+					return -1
+				}
+				p += 4
+				continue
+			case next == 'f':
+				p += 2
+				if ms.patByte(p) != '[' {
+					ms.state.errorf("missing '[' after '%%f' in pattern")
+				}
+				ep := ms.classEnd(p)
+				var prev, cur byte
+				if 0 < s {
+					prev = ms.src[s-1]
+				}
+				if s < len(ms.src) {
+					cur = ms.src[s]
+				}
+				if !ms.matchBracketClass(prev, p, ep-1) && ms.matchBracketClass(cur, p, ep-1) {
+					p = ep
+					continue
+				}
+				return -1
+			case isDigit(next):
+				if s = ms.matchCapture(s, next); s < 0 {
+					return -1
+				}
+				p += 2
+				continue
+			}
+		}
+
+		ep := ms.classEnd(p)
+		epc := ms.patByte(ep)
+		if !ms.singleMatch(s, p, ep) {
+			if epc == '*' || epc == '?' || epc == '-' {
+				p = ep + 1
+				continue
+			}
+			return -1
+		}
+
+		switch epc {
+		case '?':
+			if res := ms.match(s+1, ep+1); res >= 0 {
+				return res
+			}
+			p = ep + 1
+		case '+':
+			return ms.maxExpand(s+1, p, ep)
+		case '*':
+			return ms.maxExpand(s, p, ep)
+		case '-':
+			return ms.minExpand(s, p, ep)
+		default:
+			s++
+			p = ep
+		}
+	}
+}
+
+func (ms *matchState) maxExpand(s int, p int, ep int) int {
+	i := 0
+	for ms.singleMatch(s+i, p, ep) {
+		i++
+	}
+	for ; 0 <= i; i-- {
+		if res := ms.match(s+i, ep+1); res >= 0 {
+			return res
+		}
+	}
+	return -1
+}
+
+func (ms *matchState) minExpand(s int, p int, ep int) int {
+	for {
+		if res := ms.match(s, ep+1); res >= 0 {
+			return res
+		}
+		if !ms.singleMatch(s, p, ep) {
+			return -1
+		}
+		s++
+	}
+}
+
+func (ms *matchState) startCapture(s int, p int, what int) int {
+	if maxCaptures <= ms.level {
+		ms.state.errorf("too many captures")
+	}
+	ms.captures[ms.level] = capture{init: s, len: what}
+	ms.level++
+	res := ms.match(s, p)
+	if res < 0 {
+		ms.level--
+	}
+	return res
+}
+
+func (ms *matchState) endCapture(s int, p int) int {
+	l := -1
+	for i := ms.level - 1; 0 <= i; i-- {
+		if ms.captures[i].len == captureUnclosed {
+			l = i
+			break
+		}
+	}
+	if l < 0 {
+		ms.state.errorf("invalid pattern capture")
+	}
+	ms.captures[l].len = s - ms.captures[l].init
+	res := ms.match(s, p)
+	if res < 0 {
+		ms.captures[l].len = captureUnclosed
+	}
+	return res
+}
+
+func (ms *matchState) matchBalance(s int, p int) int {
+	if len(ms.pat)-1 <= p {
+		ms.state.errorf("malformed pattern (missing arguments to '%%b')")
+	}
+	if len(ms.src) <= s || ms.src[s] != ms.pat[p] {
+		return -1
+	}
+	b, e := ms.pat[p], ms.pat[p+1]
+	cont := 1
+	for s++; s < len(ms.src); s++ {
+		switch ms.src[s] {
+		case e:
+			cont--
+			if cont == 0 {
+				return s + 1
+			}
+		case b:
+			cont++
+		}
+	}
+	return -1
+}
+
+func (ms *matchState) matchCapture(s int, c byte) int {
+	l := int(c - '1')
+	if l < 0 || ms.level <= l || ms.captures[l].len == captureUnclosed {
+		ms.state.errorf("invalid capture index %%%d", l+1)
+	}
+	cp := ms.captures[l]
+	if 0 <= cp.len && cp.len <= len(ms.src)-s && ms.src[cp.init:cp.init+cp.len] == ms.src[s:s+cp.len] {
+		return s + cp.len
+	}
+	return -1
+}
+
+// getCapture returns the i-th capture. The whole match src[s:e] is
+// returned as the first capture if the pattern has no captures.
+func (ms *matchState) getCapture(i int, s int, e int) Value {
+	if ms.level <= i {
+		if i != 0 {
+			ms.state.errorf("invalid capture index %%%d", i+1)
+		}
+		return ms.src[s:e]
+	}
+	cp := ms.captures[i]
+	switch cp.len {
+	case captureUnclosed:
+		ms.state.errorf("unfinished capture")
+	case capturePosition:
+		return int64(cp.init + 1)
+	}
+	return ms.src[cp.init : cp.init+cp.len]
+}
+
+func (ms *matchState) getCaptures(s int, e int, wholeIfNone bool) []Value {
+	n := ms.level
+	if n == 0 && wholeIfNone {
+		n = 1
+	}
+	values := make([]Value, n)
+	for i := range values {
+		values[i] = ms.getCapture(i, s, e)
+	}
+	return values
+}
+
+func hasPatternSpecials(pat string) bool {
+	return strings.ContainsAny(pat, patternSpecials)
+}
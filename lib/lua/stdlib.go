@@ -0,0 +1,564 @@
+package lua
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func openLibraries(s *State) {
+	g := s.globals
+	register(g, map[string]func(*State, []Value) []Value{
+		"assert":   baseAssert,
+		"error":    baseError,
+		"ipairs":   baseIpairs,
+		"next":     baseNext,
+		"pairs":    basePairs,
+		"pcall":    basePcall,
+		"rawequal": baseRawequal,
+		"rawget":   baseRawget,
+		"rawlen":   baseRawlen,
+		"rawset":   baseRawset,
+		"select":   baseSelect,
+		"tonumber": baseTonumber,
+		"tostring": baseTostring,
+		"type":     baseType,
+	})
+	g.Set("_G", g)
+	g.Set("_VERSION", "Lua 5.3")
+
+	g.Set("math", openMath())
+	g.Set("table", openTable())
+
+	s.strings = openString()
+	g.Set("string", s.strings)
+}
+
+func register(t *Table, fns map[string]func(*State, []Value) []Value) {
+	for name, fn := range fns {
+		t.Set(name, &GoFunction{Name: name, Fn: fn})
+	}
+}
+
+func (s *State) argError(n int, fname string, format string, args ...interface{}) {
+	s.errorf("bad argument #%d to '%s' (%s)", n, fname, fmt.Sprintf(format, args...))
+}
+
+func arg(args []Value, n int) Value {
+	if n <= len(args) {
+		return args[n-1]
+	}
+	return nil
+}
+
+func (s *State) checkAny(args []Value, n int, fname string) Value {
+	if len(args) < n {
+		s.argError(n, fname, "value expected")
+	}
+	return args[n-1]
+}
+
+func (s *State) checkTable(args []Value, n int, fname string) *Table {
+	t, ok := arg(args, n).(*Table)
+	if !ok {
+		s.argError(n, fname, "table expected, got %s", typeNameForArg(args, n))
+	}
+	return t
+}
+
+func (s *State) checkNumber(args []Value, n int, fname string) Value {
+	v, ok := toNumber(arg(args, n))
+	if !ok {
+		s.argError(n, fname, "number expected, got %s", typeNameForArg(args, n))
+	}
+	return v
+}
+
+func (s *State) checkFloat(args []Value, n int, fname string) float64 {
+	f, _ := toFloat(s.checkNumber(args, n, fname))
+	return f
+}
+
+func (s *State) checkInteger(args []Value, n int, fname string) int64 {
+	v := s.checkNumber(args, n, fname)
+	i, ok := toInteger(v)
+	if !ok {
+		s.argError(n, fname, "number has no integer representation")
+	}
+	return i
+}
+
+func (s *State) optInteger(args []Value, n int, fname string, def int64) int64 {
+	if arg(args, n) == nil {
+		return def
+	}
+	return s.checkInteger(args, n, fname)
+}
+
+func (s *State) checkString(args []Value, n int, fname string) string {
+	switch v := arg(args, n).(type) {
+	case string:
+		return v
+	case int64, float64:
+		return ToString(v)
+	}
+	s.argError(n, fname, "string expected, got %s", typeNameForArg(args, n))
+	return ""
+}
+
+func (s *State) optString(args []Value, n int, fname string, def string) string {
+	if arg(args, n) == nil {
+		return def
+	}
+	return s.checkString(args, n, fname)
+}
+
+func typeNameForArg(args []Value, n int) string {
+	if len(args) < n {
+		return "no value"
+	}
+	return TypeName(args[n-1])
+}
+
+func baseAssert(s *State, args []Value) []Value {
+	if !truthy(s.checkAny(args, 1, "assert")) {
+		if len(args) < 2 {
+			s.errorf("assertion failed!")
+		}
+		raise(args[1])
+	}
+	return args
+}
+
+func raise(v Value) {
+	msg, ok := v.(string)
+	if !ok {
+		if n, ok := v.(int64); ok {
+			msg = ToString(n)
+		} else if f, ok := v.(float64); ok {
+			msg = ToString(f)
+		} else {
+			msg = fmt.Sprintf("(error object is a %s value)", TypeName(v))
+		}
+	}
+	panic(&Error{Message: msg, Value: v})
+}
+
+func baseError(s *State, args []Value) []Value {
+	v := arg(args, 1)
+	level := s.optInteger(args, 2, "error", 1)
+	if str, ok := v.(string); ok && 0 < level {
+		v = fmt.Sprintf("line %d: %s", s.line, str)
+	}
+	raise(v)
+	return nil
+}
+
+func ipairsIterator(s *State, args []Value) []Value {
+	i := s.checkInteger(args, 2, "ipairs") + 1
+	v := s.index(arg(args, 1), i, nil)
+	if v == nil {
+		return []Value{nil}
+	}
+	return []Value{i, v}
+}
+
+var ipairsIteratorFunction = &GoFunction{Name: "ipairs_iterator", Fn: ipairsIterator}
+
+func baseIpairs(s *State, args []Value) []Value {
+	return []Value{ipairsIteratorFunction, s.checkAny(args, 1, "ipairs"), int64(0)}
+}
+
+func baseNext(s *State, args []Value) []Value {
+	t := s.checkTable(args, 1, "next")
+	k, v, ok := t.Next(arg(args, 2))
+	if !ok {
+		s.errorf("invalid key to 'next'")
+	}
+	if k == nil {
+		return []Value{nil}
+	}
+	return []Value{k, v}
+}
+
+var nextFunction = &GoFunction{Name: "next", Fn: baseNext}
+
+func basePairs(s *State, args []Value) []Value {
+	return []Value{nextFunction, s.checkTable(args, 1, "pairs"), nil}
+}
+
+func basePcall(s *State, args []Value) (results []Value) {
+	fn := s.checkAny(args, 1, "pcall")
+	depth, line := s.depth, s.line
+
+	defer func() {
+		if r := recover(); r != nil {
+			e, ok := r.(*Error)
+			if !ok || e.fatal {
+				panic(r)
+			}
+			s.depth, s.line = depth, line
+			results = []Value{false, e.Value}
+		}
+	}()
+
+	return append([]Value{true}, s.call(fn, args[1:])...)
+}
+
+func baseRawequal(s *State, args []Value) []Value {
+	return []Value{rawEqual(s.checkAny(args, 1, "rawequal"), s.checkAny(args, 2, "rawequal"))}
+}
+
+func baseRawget(s *State, args []Value) []Value {
+	return []Value{s.checkTable(args, 1, "rawget").Get(s.checkAny(args, 2, "rawget"))}
+}
+
+func baseRawlen(s *State, args []Value) []Value {
+	switch v := arg(args, 1).(type) {
+	case *Table:
+		return []Value{v.Len()}
+	case string:
+		return []Value{int64(len(v))}
+	}
+	s.argError(1, "rawlen", "table or string expected")
+	return nil
+}
+
+func baseRawset(s *State, args []Value) []Value {
+	t := s.checkTable(args, 1, "rawset")
+	s.setIndex(t, s.checkAny(args, 2, "rawset"), s.checkAny(args, 3, "rawset"))
+	return []Value{t}
+}
+
+func baseSelect(s *State, args []Value) []Value {
+	if str, ok := arg(args, 1).(string); ok && str == "#" {
+		return []Value{int64(len(args) - 1)}
+	}
+	n := s.checkInteger(args, 1, "select")
+	switch {
+	case n < 0:
+		n = int64(len(args)) + n
+		if n < 1 {
+			s.argError(1, "select", "index out of range")
+		}
+	case n == 0:
+		s.argError(1, "select", "index out of range")
+	case int64(len(args)) <= n:
+		return nil
+	}
+	return args[n:]
+}
+
+func baseTonumber(s *State, args []Value) []Value {
+	if arg(args, 2) == nil {
+		v := s.checkAny(args, 1, "tonumber")
+		if n, ok := toNumber(v); ok {
+			return []Value{n}
+		}
+		return []Value{nil}
+	}
+
+	base := s.checkInteger(args, 2, "tonumber")
+	str, ok := arg(args, 1).(string)
+	if !ok {
+		s.argError(1, "tonumber", "string expected, got %s", typeNameForArg(args, 1))
+	}
+	if base < 2 || 36 < base {
+		s.argError(2, "tonumber", "base out of range")
+	}
+	i, err := strconv.ParseInt(strings.ToLower(strings.TrimSpace(str)), int(base), 64)
+	if err != nil {
+		return []Value{nil}
+	}
+	return []Value{i}
+}
+
+func baseTostring(s *State, args []Value) []Value {
+	return []Value{ToString(s.checkAny(args, 1, "tostring"))}
+}
+
+func baseType(s *State, args []Value) []Value {
+	return []Value{TypeName(s.checkAny(args, 1, "type"))}
+}
+
+func openMath() *Table {
+	t := NewTable()
+	register(t, map[string]func(*State, []Value) []Value{
+		"abs": func(s *State, args []Value) []Value {
+			switch v := s.checkNumber(args, 1, "abs").(type) {
+			case int64:
+				if v < 0 {
+					return []Value{-v}
+				}
+				return []Value{v}
+			default:
+				return []Value{math.Abs(v.(float64))}
+			}
+		},
+		"ceil": func(s *State, args []Value) []Value {
+			return []Value{floatToValue(math.Ceil(s.checkFloat(args, 1, "ceil")))}
+		},
+		"floor": func(s *State, args []Value) []Value {
+			if i, ok := s.checkNumber(args, 1, "floor").(int64); ok {
+				return []Value{i}
+			}
+			return []Value{floatToValue(math.Floor(s.checkFloat(args, 1, "floor")))}
+		},
+		"fmod": func(s *State, args []Value) []Value {
+			a := s.checkNumber(args, 1, "fmod")
+			b := s.checkNumber(args, 2, "fmod")
+			if x, ok := a.(int64); ok {
+				if y, ok := b.(int64); ok {
+					if y == 0 {
+						s.argError(2, "fmod", "zero")
+					}
+					if y == -1 {
+						return []Value{int64(0)}
+					}
+					return []Value{x % y}
+				}
+			}
+			x, _ := toFloat(a)
+			y, _ := toFloat(b)
+			return []Value{math.Mod(x, y)}
+		},
+		"log": func(s *State, args []Value) []Value {
+			x := s.checkFloat(args, 1, "log")
+			if arg(args, 2) == nil {
+				return []Value{math.Log(x)}
+			}
+			switch base := s.checkFloat(args, 2, "log"); base {
+			case 2:
+				return []Value{math.Log2(x)}
+			case 10:
+				return []Value{math.Log10(x)}
+			default:
+				return []Value{math.Log(x) / math.Log(base)}
+			}
+		},
+		"exp": func(s *State, args []Value) []Value {
+			return []Value{math.Exp(s.checkFloat(args, 1, "exp"))}
+		},
+		"sqrt": func(s *State, args []Value) []Value {
+			return []Value{math.Sqrt(s.checkFloat(args, 1, "sqrt"))}
+		},
+		"sin": func(s *State, args []Value) []Value {
+			return []Value{math.Sin(s.checkFloat(args, 1, "sin"))}
+		},
+		"cos": func(s *State, args []Value) []Value {
+			return []Value{math.Cos(s.checkFloat(args, 1, "cos"))}
+		},
+		"tan": func(s *State, args []Value) []Value {
+			return []Value{math.Tan(s.checkFloat(args, 1, "tan"))}
+		},
+		"asin": func(s *State, args []Value) []Value {
+			return []Value{math.Asin(s.checkFloat(args, 1, "asin"))}
+		},
+		"acos": func(s *State, args []Value) []Value {
+			return []Value{math.Acos(s.checkFloat(args, 1, "acos"))}
+		},
+		"atan": func(s *State, args []Value) []Value {
+			y := s.checkFloat(args, 1, "atan")
+			x := 1.0
+			if arg(args, 2) != nil {
+				x = s.checkFloat(args, 2, "atan")
+			}
+			return []Value{math.Atan2(y, x)}
+		},
+		"max": func(s *State, args []Value) []Value {
+			return []Value{mathMinMax(s, args, "max", 1)}
+		},
+		"min": func(s *State, args []Value) []Value {
+			return []Value{mathMinMax(s, args, "min", -1)}
+		},
+		"modf": func(s *State, args []Value) []Value {
+			f := s.checkFloat(args, 1, "modf")
+			if math.IsInf(f, 0) {
+				return []Value{f, 0.0}
+			}
+			i, frac := math.Modf(f)
+			return []Value{floatToValue(i), frac}
+		},
+		"tointeger": func(s *State, args []Value) []Value {
+			switch v := arg(args, 1).(type) {
+			case int64:
+				return []Value{v}
+			case float64:
+				if i, ok := floatToInteger(v); ok {
+					return []Value{i}
+				}
+			}
+			return []Value{nil}
+		},
+		"type": func(s *State, args []Value) []Value {
+			switch s.checkAny(args, 1, "type").(type) {
+			case int64:
+				return []Value{"integer"}
+			case float64:
+				return []Value{"float"}
+			}
+			return []Value{nil}
+		},
+		"ult": func(s *State, args []Value) []Value {
+			return []Value{uint64(s.checkInteger(args, 1, "ult")) < uint64(s.checkInteger(args, 2, "ult"))}
+		},
+	})
+	t.Set("huge", math.Inf(1))
+	t.Set("pi", math.Pi)
+	t.Set("maxinteger", int64(math.MaxInt64))
+	t.Set("mininteger", int64(math.MinInt64))
+	return t
+}
+
+// floatToValue converts f to an integer if f has an integer representation.
+func floatToValue(f float64) Value {
+	if i, ok := floatToInteger(f); ok {
+		return i
+	}
+	return f
+}
+
+func mathMinMax(s *State, args []Value, fname string, sign int) Value {
+	result := s.checkNumber(args, 1, fname)
+	for i := 2; i <= len(args); i++ {
+		v := s.checkNumber(args, i, fname)
+		if c, ok := s.compare(v, result); ok && c*sign > 0 {
+			result = v
+		}
+	}
+	return result
+}
+
+func openTable() *Table {
+	t := NewTable()
+	register(t, map[string]func(*State, []Value) []Value{
+		"concat": tableConcat,
+		"insert": tableInsert,
+		"pack":   tablePack,
+		"remove": tableRemove,
+		"sort":   tableSort,
+		"unpack": tableUnpack,
+	})
+	return t
+}
+
+func tableConcat(s *State, args []Value) []Value {
+	t := s.checkTable(args, 1, "concat")
+	sep := s.optString(args, 2, "concat", "")
+	i := s.optInteger(args, 3, "concat", 1)
+	j := s.optInteger(args, 4, "concat", t.Len())
+
+	var buf strings.Builder
+	for k := i; k <= j; k++ {
+		switch v := t.Get(k).(type) {
+		case string, int64, float64:
+			buf.WriteString(ToString(v))
+		default:
+			s.errorf("invalid value (at index %d) in table for 'concat'", k)
+		}
+		if k < j {
+			buf.WriteString(sep)
+		}
+		if s.limits.MaxStringLength < buf.Len() {
+			s.fatalf("string length exceeded the limit")
+		}
+		s.step()
+	}
+	return []Value{buf.String()}
+}
+
+func tableInsert(s *State, args []Value) []Value {
+	t := s.checkTable(args, 1, "insert")
+	n := t.Len() + 1
+	switch len(args) {
+	case 2:
+		t.Set(n, args[1])
+	case 3:
+		pos := s.checkInteger(args, 2, "insert")
+		if pos < 1 || n < pos {
+			s.argError(2, "insert", "position out of bounds")
+		}
+		for i := n; pos < i; i-- {
+			t.Set(i, t.Get(i-1))
+		}
+		t.Set(pos, args[2])
+	default:
+		s.errorf("wrong number of arguments to 'insert'")
+	}
+	return nil
+}
+
+func tablePack(s *State, args []Value) []Value {
+	t := NewTable()
+	for i, v := range args {
+		t.Set(int64(i+1), v)
+	}
+	t.Set("n", int64(len(args)))
+	return []Value{t}
+}
+
+func tableRemove(s *State, args []Value) []Value {
+	t := s.checkTable(args, 1, "remove")
+	n := t.Len()
+	pos := s.optInteger(args, 2, "remove", n)
+	if 2 <= len(args) && n+1 != pos && (pos < 1 || n+1 < pos) && !(n == 0 && pos == 0) {
+		s.argError(2, "remove", "position out of bounds")
+	}
+	v := t.Get(pos)
+	for i := pos; i < n; i++ {
+		t.Set(i, t.Get(i+1))
+	}
+	if pos <= n {
+		t.Set(n, nil)
+	}
+	return []Value{v}
+}
+
+func tableSort(s *State, args []Value) []Value {
+	t := s.checkTable(args, 1, "sort")
+	comp := arg(args, 2)
+	if comp != nil {
+		switch comp.(type) {
+		case *Function, *GoFunction:
+		default:
+			s.argError(2, "sort", "function expected, got %s", TypeName(comp))
+		}
+	}
+
+	n := t.Len()
+	values := make([]Value, n)
+	for i := range values {
+		values[i] = t.Get(int64(i + 1))
+	}
+	sort.SliceStable(values, func(i, j int) bool {
+		s.step()
+		if comp != nil {
+			r := s.call(comp, []Value{values[i], values[j]})
+			return 0 < len(r) && truthy(r[0])
+		}
+		return s.lessThan(values[i], values[j])
+	})
+	for i, v := range values {
+		t.Set(int64(i+1), v)
+	}
+	return nil
+}
+
+func tableUnpack(s *State, args []Value) []Value {
+	t := s.checkTable(args, 1, "unpack")
+	i := s.optInteger(args, 2, "unpack", 1)
+	j := s.optInteger(args, 3, "unpack", t.Len())
+	if j < i {
+		return nil
+	}
+	if 1000000 <= j-i {
+		s.errorf("too many results to unpack")
+	}
+	values := make([]Value, 0, j-i+1)
+	for k := i; k <= j; k++ {
+		values = append(values, t.Get(k))
+	}
+	return values
+}
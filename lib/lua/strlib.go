@@ -0,0 +1,422 @@
+package lua
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+func openString() *Table {
+	t := NewTable()
+	register(t, map[string]func(*State, []Value) []Value{
+		"byte":    strByte,
+		"char":    strChar,
+		"find":    strFind,
+		"format":  strFormat,
+		"gmatch":  strGmatch,
+		"gsub":    strGsub,
+		"len":     strLen,
+		"lower":   strLower,
+		"match":   strMatch,
+		"rep":     strRep,
+		"reverse": strReverse,
+		"sub":     strSub,
+		"upper":   strUpper,
+	})
+	return t
+}
+
+// relativePosition converts a negative position to the position from the
+// beginning of a string with the length.
+func relativePosition(pos int64, length int) int64 {
+	switch {
+	case 0 <= pos:
+		return pos
+	case int64(length) < -pos:
+		return 0
+	}
+	return int64(length) + pos + 1
+}
+
+func strByte(s *State, args []Value) []Value {
+	str := s.checkString(args, 1, "byte")
+	i := relativePosition(s.optInteger(args, 2, "byte", 1), len(str))
+	j := relativePosition(s.optInteger(args, 3, "byte", i), len(str))
+	if i < 1 {
+		i = 1
+	}
+	if int64(len(str)) < j {
+		j = int64(len(str))
+	}
+	if j < i {
+		return nil
+	}
+	values := make([]Value, 0, j-i+1)
+	for k := i; k <= j; k++ {
+		values = append(values, int64(str[k-1]))
+	}
+	return values
+}
+
+func strChar(s *State, args []Value) []Value {
+	b := make([]byte, len(args))
+	for i := range args {
+		c := s.checkInteger(args, i+1, "char")
+		if c < 0 || 255 < c {
+			s.argError(i+1, "char", "value out of range")
+		}
+		b[i] = byte(c)
+	}
+	return []Value{string(b)}
+}
+
+func strLen(s *State, args []Value) []Value {
+	return []Value{int64(len(s.checkString(args, 1, "len")))}
+}
+
+func strLower(s *State, args []Value) []Value {
+	return []Value{strings.ToLower(s.checkString(args, 1, "lower"))}
+}
+
+func strUpper(s *State, args []Value) []Value {
+	return []Value{strings.ToUpper(s.checkString(args, 1, "upper"))}
+}
+
+func strReverse(s *State, args []Value) []Value {
+	str := s.checkString(args, 1, "reverse")
+	b := make([]byte, len(str))
+	for i := range b {
+		b[i] = str[len(str)-1-i]
+	}
+	return []Value{string(b)}
+}
+
+func strRep(s *State, args []Value) []Value {
+	str := s.checkString(args, 1, "rep")
+	n := s.checkInteger(args, 2, "rep")
+	sep := s.optString(args, 3, "rep", "")
+	if n <= 0 {
+		return []Value{""}
+	}
+	if int64(s.limits.MaxStringLength) < int64(len(str)+len(sep))*n-int64(len(sep)) {
+		s.fatalf("string length exceeded the limit")
+	}
+	if len(sep) < 1 {
+		return []Value{strings.Repeat(str, int(n))}
+	}
+	return []Value{strings.Repeat(str+sep, int(n-1)) + str}
+}
+
+func strSub(s *State, args []Value) []Value {
+	str := s.checkString(args, 1, "sub")
+	i := relativePosition(s.checkInteger(args, 2, "sub"), len(str))
+	j := relativePosition(s.optInteger(args, 3, "sub", -1), len(str))
+	if i < 1 {
+		i = 1
+	}
+	if int64(len(str)) < j {
+		j = int64(len(str))
+	}
+	if j < i {
+		return []Value{""}
+	}
+	return []Value{str[i-1 : j]}
+}
+
+func strFind(s *State, args []Value) []Value {
+	return strFindAux(s, args, "find", true)
+}
+
+func strMatch(s *State, args []Value) []Value {
+	return strFindAux(s, args, "match", false)
+}
+
+func strFindAux(s *State, args []Value, fname string, find bool) []Value {
+	str := s.checkString(args, 1, fname)
+	pat := s.checkString(args, 2, fname)
+	init := relativePosition(s.optInteger(args, 3, fname, 1), len(str))
+	if init < 1 {
+		init = 1
+	}
+	if int64(len(str))+1 < init {
+		return []Value{nil}
+	}
+
+	if find && (truthy(arg(args, 4)) || !hasPatternSpecials(pat)) {
+		if idx := strings.Index(str[init-1:], pat); 0 <= idx {
+			start := int64(idx) + init
+			return []Value{start, start + int64(len(pat)) - 1}
+		}
+		return []Value{nil}
+	}
+
+	ms := newMatchState(s, str, pat)
+	p := 0
+	anchor := strings.HasPrefix(pat, "^")
+	if anchor {
+		p = 1
+	}
+	for src := int(init - 1); src <= len(str); src++ {
+		ms.reset()
+		if e := ms.match(src, p); 0 <= e {
+			if find {
+				return append([]Value{int64(src + 1), int64(e)}, ms.getCaptures(-1, -1, false)...)
+			}
+			return ms.getCaptures(src, e, true)
+		}
+		if anchor {
+			break
+		}
+	}
+	return []Value{nil}
+}
+
+func strGmatch(s *State, args []Value) []Value {
+	str := s.checkString(args, 1, "gmatch")
+	pat := s.checkString(args, 2, "gmatch")
+	ms := newMatchState(s, str, pat)
+	src := 0
+	lastMatch := -1
+
+	iter := func(s *State, _ []Value) []Value {
+		ms.state = s
+		for ; src <= len(str); src++ {
+			ms.reset()
+			if e := ms.match(src, 0); 0 <= e && e != lastMatch {
+				start := src
+				src, lastMatch = e, e
+				return ms.getCaptures(start, e, true)
+			}
+		}
+		return []Value{nil}
+	}
+	return []Value{&GoFunction{Name: "gmatch_iterator", Fn: iter}}
+}
+
+func strGsub(s *State, args []Value) []Value {
+	str := s.checkString(args, 1, "gsub")
+	pat := s.checkString(args, 2, "gsub")
+	repl := arg(args, 3)
+	switch repl.(type) {
+	case string, int64, float64, *Table, *Function, *GoFunction:
+	default:
+		s.argError(3, "gsub", "string/function/table expected, got %s", typeNameForArg(args, 3))
+	}
+	maxN := s.optInteger(args, 4, "gsub", int64(len(str)+1))
+
+	p := 0
+	anchor := strings.HasPrefix(pat, "^")
+	if anchor {
+		p = 1
+	}
+
+	ms := newMatchState(s, str, pat)
+	buf := &bytes.Buffer{}
+	src := 0
+	lastMatch := -1
+	n := int64(0)
+	for n < maxN {
+		ms.reset()
+		if e := ms.match(src, p); 0 <= e && e != lastMatch {
+			n++
+			gsubAddValue(s, ms, buf, src, e, repl)
+			src, lastMatch = e, e
+		} else if src < len(str) {
+			buf.WriteByte(str[src])
+			src++
+		} else {
+			break
+		}
+		if s.limits.MaxStringLength < buf.Len() {
+			s.fatalf("string length exceeded the limit")
+		}
+		if anchor {
+			break
+		}
+	}
+	if src < len(str) {
+		buf.WriteString(str[src:])
+	}
+	return []Value{buf.String(), n}
+}
+
+func gsubAddValue(s *State, ms *matchState, buf *bytes.Buffer, start int, end int, repl Value) {
+	var v Value
+	switch r := repl.(type) {
+	case *Table:
+		v = s.index(r, ms.getCapture(0, start, end), nil)
+	case *Function, *GoFunction:
+		results := s.call(r, ms.getCaptures(start, end, true))
+		if 0 < len(results) {
+			v = results[0]
+		}
+	default:
+		rs := ToString(r)
+		for i := 0; i < len(rs); i++ {
+			c := rs[i]
+			if c != '%' {
+				buf.WriteByte(c)
+				continue
+			}
+			i++
+			if len(rs) <= i {
+				s.errorf("invalid use of '%%' in replacement string")
+			}
+			switch c = rs[i]; {
+			case c == '%':
+				buf.WriteByte('%')
+			case c == '0':
+				buf.WriteString(ms.src[start:end])
+			case isDigit(c):
+				buf.WriteString(ToString(ms.getCapture(int(c-'1'), start, end)))
+			default:
+				s.errorf("invalid use of '%%' in replacement string")
+			}
+		}
+		return
+	}
+
+	switch v.(type) {
+	case nil:
+		buf.WriteString(ms.src[start:end])
+	case bool:
+		if v.(bool) {
+			s.errorf("invalid replacement value (a boolean)")
+		}
+		buf.WriteString(ms.src[start:end])
+	case string, int64, float64:
+		buf.WriteString(ToString(v))
+	default:
+		s.errorf("invalid replacement value (a %s)", TypeName(v))
+	}
+}
+
+func strFormat(s *State, args []Value) []Value {
+	format := s.checkString(args, 1, "format")
+	buf := &strings.Builder{}
+	n := 1
+
+	for i := 0; i < len(format); i++ {
+		c := format[i]
+		if c != '%' {
+			buf.WriteByte(c)
+			continue
+		}
+		i++
+		if len(format) <= i {
+			s.errorf("invalid conversion '%%' to 'format'")
+		}
+		if format[i] == '%' {
+			buf.WriteByte('%')
+			continue
+		}
+
+		start := i
+		for i < len(format) && strings.IndexByte("-+ #0", format[i]) >= 0 {
+			i++
+		}
+		for i < len(format) && isDigit(format[i]) {
+			i++
+		}
+		hasPrecision := false
+		if i < len(format) && format[i] == '.' {
+			hasPrecision = true
+			i++
+			for i < len(format) && isDigit(format[i]) {
+				i++
+			}
+		}
+		if len(format) <= i || 22 < i-start {
+			s.errorf("invalid conversion '%%%s' to 'format'", format[start:])
+		}
+		spec := "%" + format[start:i]
+		verb := format[i]
+
+		n++
+		switch verb {
+		case 'c':
+			buf.WriteByte(byte(s.checkInteger(args, n, "format")))
+		case 'd', 'i':
+			fmt.Fprintf(buf, spec+"d", s.checkInteger(args, n, "format"))
+		case 'o', 'x', 'X':
+			fmt.Fprintf(buf, spec+string(verb), uint64(s.checkInteger(args, n, "format")))
+		case 'a', 'A':
+			f := strconv.FormatFloat(s.checkFloat(args, n, "format"), 'x', -1, 64)
+			if verb == 'A' {
+				f = strings.ToUpper(f)
+			}
+			buf.WriteString(f)
+		case 'e', 'E', 'f', 'F', 'g', 'G':
+			if !hasPrecision {
+				spec += ".6"
+			}
+			if verb == 'F' {
+				verb = 'f'
+			}
+			fmt.Fprintf(buf, spec+string(verb), s.checkFloat(args, n, "format"))
+		case 's':
+			fmt.Fprintf(buf, spec+"s", ToString(s.checkAny(args, n, "format")))
+		case 'q':
+			buf.WriteString(quoteString(s, s.checkAny(args, n, "format")))
+		default:
+			s.errorf("invalid conversion '%s' to 'format'", spec+string(verb))
+		}
+		if s.limits.MaxStringLength < buf.Len() {
+			s.fatalf("string length exceeded the limit")
+		}
+	}
+	return []Value{buf.String()}
+}
+
+func quoteString(s *State, v Value) string {
+	switch x := v.(type) {
+	case string:
+		buf := &strings.Builder{}
+		buf.WriteByte('"')
+		for i := 0; i < len(x); i++ {
+			c := x[i]
+			switch {
+			case c == '"' || c == '\\':
+				buf.WriteByte('\\')
+				buf.WriteByte(c)
+			case c == '\n':
+				buf.WriteString("\\n")
+			case c == '\r':
+				buf.WriteString("\\r")
+			case c == 0:
+				if i+1 < len(x) && isDigit(x[i+1]) {
+					buf.WriteString("\\000")
+				} else {
+					buf.WriteString("\\0")
+				}
+			case isControl(c):
+				if i+1 < len(x) && isDigit(x[i+1]) {
+					fmt.Fprintf(buf, "\\%03d", c)
+				} else {
+					fmt.Fprintf(buf, "\\%d", c)
+				}
+			default:
+				buf.WriteByte(c)
+			}
+		}
+		buf.WriteByte('"')
+		return buf.String()
+	case int64:
+		return strconv.FormatInt(x, 10)
+	case float64:
+		switch f := formatFloat(x); f {
+		case "inf":
+			return "1e9999"
+		case "-inf":
+			return "-1e9999"
+		case "nan":
+			return "(0/0)"
+		}
+		return strconv.FormatFloat(x, 'x', -1, 64)
+	case nil, bool:
+		return ToString(x)
+	}
+	s.errorf("value has no literal form")
+	return ""
+}
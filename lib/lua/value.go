@@ -0,0 +1,309 @@
+package lua
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Value is a Lua value. The dynamic type is one of nil, bool, int64,
+// float64, string, *Table, *Function and *GoFunction.
+type Value interface{}
+
+type Function struct {
+	proto *proto
+	env   *variable
+}
+
+type GoFunction struct {
+	Name string
+	Fn   func(s *State, args []Value) []Value
+}
+
+type Table struct {
+	array []Value
+	keys  []Value
+	vals  []Value
+	index map[Value]int
+	count int
+}
+
+func NewTable() *Table {
+	return &Table{}
+}
+
+func normalizeKey(key Value) Value {
+	if f, ok := key.(float64); ok {
+		if i, ok := floatToInteger(f); ok {
+			return i
+		}
+	}
+	return key
+}
+
+func (t *Table) Get(key Value) Value {
+	key = normalizeKey(key)
+	if i, ok := key.(int64); ok && 1 <= i && i <= int64(len(t.array)) {
+		return t.array[i-1]
+	}
+	if t.index == nil {
+		return nil
+	}
+	if idx, ok := t.index[key]; ok {
+		return t.vals[idx]
+	}
+	return nil
+}
+
+func (t *Table) Set(key Value, val Value) {
+	key = normalizeKey(key)
+	switch k := key.(type) {
+	case nil:
+		panic(&Error{Message: "table index is nil"})
+	case float64:
+		if math.IsNaN(k) {
+			panic(&Error{Message: "table index is NaN"})
+		}
+	case int64:
+		if 1 <= k && k <= int64(len(t.array)) {
+			t.array[k-1] = val
+			if val == nil && k == int64(len(t.array)) {
+				n := len(t.array)
+				for 0 < n && t.array[n-1] == nil {
+					n--
+				}
+				t.array = t.array[:n]
+			}
+			return
+		}
+		if k == int64(len(t.array))+1 && val != nil {
+			t.deleteHash(key)
+			t.array = append(t.array, val)
+			t.migrate()
+			return
+		}
+	}
+
+	if val == nil {
+		t.deleteHash(key)
+		return
+	}
+	if t.index == nil {
+		t.index = make(map[Value]int)
+	}
+	if idx, ok := t.index[key]; ok {
+		t.vals[idx] = val
+		return
+	}
+	if len(t.keys) > 16 && len(t.keys) > 2*t.count {
+		t.compact()
+	}
+	t.index[key] = len(t.keys)
+	t.keys = append(t.keys, key)
+	t.vals = append(t.vals, val)
+	t.count++
+}
+
+func (t *Table) deleteHash(key Value) {
+	if t.index == nil {
+		return
+	}
+	if idx, ok := t.index[key]; ok && t.vals[idx] != nil {
+		t.vals[idx] = nil
+		t.count--
+	}
+}
+
+// migrate moves the integer keys following the array part from the hash part.
+func (t *Table) migrate() {
+	if t.index == nil {
+		return
+	}
+	for {
+		key := int64(len(t.array)) + 1
+		idx, ok := t.index[key]
+		if !ok || t.vals[idx] == nil {
+			return
+		}
+		t.array = append(t.array, t.vals[idx])
+		t.vals[idx] = nil
+		t.count--
+	}
+}
+
+func (t *Table) compact() {
+	keys := make([]Value, 0, t.count)
+	vals := make([]Value, 0, t.count)
+	index := make(map[Value]int, t.count)
+	for i, v := range t.vals {
+		if v != nil {
+			index[t.keys[i]] = len(keys)
+			keys = append(keys, t.keys[i])
+			vals = append(vals, v)
+		}
+	}
+	t.keys, t.vals, t.index = keys, vals, index
+}
+
+func (t *Table) Len() int64 {
+	return int64(len(t.array))
+}
+
+// Next returns the key and the value following key in the traversal order.
+// The first pair is returned for a nil key, and a nil key is returned at the end.
+func (t *Table) Next(key Value) (Value, Value, bool) {
+	key = normalizeKey(key)
+	start := 0
+	if key != nil {
+		i, isInt := key.(int64)
+		if isInt && 1 <= i && i <= int64(len(t.array)) {
+			start = int(i)
+		} else if idx, ok := t.index[key]; ok {
+			start = len(t.array) + idx + 1
+		} else if isInt && 1 <= i {
+			// The key was removed from the end of the array part during the traversal.
+			start = len(t.array)
+		} else {
+			return nil, nil, false
+		}
+	}
+
+	for i := start; i < len(t.array); i++ {
+		if t.array[i] != nil {
+			return int64(i + 1), t.array[i], true
+		}
+	}
+	if start < len(t.array) {
+		start = len(t.array)
+	}
+	for i := start - len(t.array); i < len(t.keys); i++ {
+		if t.vals[i] != nil {
+			return t.keys[i], t.vals[i], true
+		}
+	}
+	return nil, nil, true
+}
+
+func TypeName(v Value) string {
+	switch v.(type) {
+	case nil:
+		return "nil"
+	case bool:
+		return "boolean"
+	case int64, float64:
+		return "number"
+	case string:
+		return "string"
+	case *Table:
+		return "table"
+	case *Function, *GoFunction:
+		return "function"
+	}
+	return "userdata"
+}
+
+func truthy(v Value) bool {
+	switch b := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return b
+	}
+	return true
+}
+
+func floatToInteger(f float64) (int64, bool) {
+	if math.Floor(f) != f || f < -9223372036854775808 || 9223372036854775808 <= f {
+		return 0, false
+	}
+	return int64(f), true
+}
+
+// toNumber converts a number or a numeric string to an int64 or a float64.
+func toNumber(v Value) (Value, bool) {
+	switch n := v.(type) {
+	case int64, float64:
+		return n, true
+	case string:
+		return parseNumber(n)
+	}
+	return nil, false
+}
+
+func toFloat(v Value) (float64, bool) {
+	n, ok := toNumber(v)
+	if !ok {
+		return 0, false
+	}
+	if i, ok := n.(int64); ok {
+		return float64(i), true
+	}
+	return n.(float64), true
+}
+
+func toInteger(v Value) (int64, bool) {
+	n, ok := toNumber(v)
+	if !ok {
+		return 0, false
+	}
+	if f, ok := n.(float64); ok {
+		return floatToInteger(f)
+	}
+	return n.(int64), true
+}
+
+func formatFloat(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "inf"
+	case math.IsInf(f, -1):
+		return "-inf"
+	case math.IsNaN(f):
+		return "nan"
+	}
+	s := strconv.FormatFloat(f, 'g', 14, 64)
+	if !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+	return s
+}
+
+func ToString(v Value) string {
+	switch x := v.(type) {
+	case nil:
+		return "nil"
+	case bool:
+		if x {
+			return "true"
+		}
+		return "false"
+	case int64:
+		return strconv.FormatInt(x, 10)
+	case float64:
+		return formatFloat(x)
+	case string:
+		return x
+	case *Table:
+		return fmt.Sprintf("table: %p", x)
+	case *Function:
+		return fmt.Sprintf("function: %p", x)
+	case *GoFunction:
+		return fmt.Sprintf("function: builtin: %p", x)
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+func rawEqual(a Value, b Value) bool {
+	switch x := a.(type) {
+	case int64:
+		if y, ok := b.(float64); ok {
+			return float64(x) == y
+		}
+	case float64:
+		if y, ok := b.(int64); ok {
+			return x == float64(y)
+		}
+	}
+	return a == b
+}
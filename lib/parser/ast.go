@@ -239,6 +239,25 @@ func (e ValueList) String() string {
 	return putParentheses(listQueryExpressions(e.Values))
 }
 
+type ArrayConstructor struct {
+	*BaseExpr
+	Values []QueryExpression
+}
+
+func (e ArrayConstructor) String() string {
+	return "[" + listQueryExpressions(e.Values) + "]"
+}
+
+type ArrayElement struct {
+	*BaseExpr
+	Array QueryExpression
+	Index QueryExpression
+}
+
+func (e ArrayElement) String() string {
+	return e.Array.String() + "[" + e.Index.String() + "]"
+}
+
 type RowValueList struct {
 	*BaseExpr
 	RowValues []QueryExpression
@@ -266,6 +285,13 @@ func (e SelectQuery) String() string {
 	if e.OrderByClause != nil {
 		s = append(s, e.OrderByClause.String())
 	}
+	if e.LimitClause != nil && e.LimitClause.(LimitClause).IsFetch() {
+		if e.OffsetClause != nil {
+			s = append(s, e.OffsetClause.String())
+		}
+		s = append(s, e.LimitClause.String())
+		return joinWithSpace(s)
+	}
 	if e.LimitClause != nil {
 		s = append(s, e.LimitClause.String())
 	}
@@ -292,6 +318,16 @@ func (e SelectSet) String() string {
 	return joinWithSpace(s)
 }
 
+type ValuesTable struct {
+	*BaseExpr
+	Values     string
+	ValuesList []QueryExpression
+}
+
+func (e ValuesTable) String() string {
+	return joinWithSpace([]string{e.Values, listQueryExpressions(e.ValuesList)})
+}
+
 type SelectEntity struct {
 	*BaseExpr
 	SelectClause  QueryExpression
@@ -320,9 +356,11 @@ func (e SelectEntity) String() string {
 
 type SelectClause struct {
 	*BaseExpr
-	Select   string
-	Distinct Token
-	Fields   []QueryExpression
+	Select     string
+	Hints      []Hint
+	Distinct   Token
+	DistinctOn QueryExpression
+	Fields     []QueryExpression
 }
 
 func (sc SelectClause) IsDistinct() bool {
@@ -331,13 +369,30 @@ func (sc SelectClause) IsDistinct() bool {
 
 func (sc SelectClause) String() string {
 	s := []string{sc.Select}
+	if 0 < len(sc.Hints) {
+		s = append(s, HintsString(sc.Hints))
+	}
 	if sc.IsDistinct() {
 		s = append(s, sc.Distinct.Literal)
 	}
+	if sc.DistinctOn != nil {
+		s = append(s, sc.DistinctOn.String())
+	}
 	s = append(s, listQueryExpressions(sc.Fields))
 	return joinWithSpace(s)
 }
 
+type DistinctOn struct {
+	*BaseExpr
+	On     string
+	Values []QueryExpression
+}
+
+func (e DistinctOn) String() string {
+	s := []string{e.On, putParentheses(listQueryExpressions(e.Values))}
+	return joinWithSpace(s)
+}
+
 type FromClause struct {
 	*BaseExpr
 	From   string
@@ -371,6 +426,39 @@ func (gb GroupByClause) String() string {
 	return joinWithSpace(s)
 }
 
+type Rollup struct {
+	*BaseExpr
+	Rollup string
+	Items  []QueryExpression
+}
+
+func (e Rollup) String() string {
+	s := []string{e.Rollup, putParentheses(listQueryExpressions(e.Items))}
+	return joinWithSpace(s)
+}
+
+type Cube struct {
+	*BaseExpr
+	Cube  string
+	Items []QueryExpression
+}
+
+func (e Cube) String() string {
+	s := []string{e.Cube, putParentheses(listQueryExpressions(e.Items))}
+	return joinWithSpace(s)
+}
+
+type GroupingSets struct {
+	*BaseExpr
+	GroupingSets string
+	Items        []QueryExpression
+}
+
+func (e GroupingSets) String() string {
+	s := []string{e.GroupingSets, putParentheses(listQueryExpressions(e.Items))}
+	return joinWithSpace(s)
+}
+
 type HavingClause struct {
 	*BaseExpr
 	Having string
@@ -395,23 +483,36 @@ func (ob OrderByClause) String() string {
 
 type LimitClause struct {
 	*BaseExpr
-	Limit   string
-	Value   QueryExpression
-	Percent string
-	With    QueryExpression
+	Limit    string
+	Position Token
+	Value    QueryExpression
+	Percent  string
+	Unit     Token
+	With     QueryExpression
 }
 
 func (e LimitClause) String() string {
-	s := []string{e.Limit, e.Value.String()}
+	s := []string{e.Limit}
+	if e.IsFetch() {
+		s = append(s, e.Position.Literal)
+	}
+	s = append(s, e.Value.String())
 	if e.IsPercentage() {
 		s = append(s, e.Percent)
 	}
+	if e.IsFetch() {
+		s = append(s, e.Unit.Literal)
+	}
 	if e.With != nil {
 		s = append(s, e.With.String())
 	}
 	return joinWithSpace(s)
 }
 
+func (e LimitClause) IsFetch() bool {
+	return !e.Position.IsEmpty()
+}
+
 func (e LimitClause) IsPercentage() bool {
 	return 0 < len(e.Percent)
 }
@@ -430,6 +531,9 @@ type LimitWith struct {
 }
 
 func (e LimitWith) String() string {
+	if len(e.With) < 1 {
+		return e.Type.Literal
+	}
 	s := []string{e.With, e.Type.Literal}
 	return joinWithSpace(s)
 }
@@ -438,10 +542,14 @@ type OffsetClause struct {
 	*BaseExpr
 	Offset string
 	Value  QueryExpression
+	Unit   Token
 }
 
 func (e OffsetClause) String() string {
 	s := []string{e.Offset, e.Value.String()}
+	if !e.Unit.IsEmpty() {
+		s = append(s, e.Unit.Literal)
+	}
 	return joinWithSpace(s)
 }
 
@@ -458,11 +566,13 @@ func (e WithClause) String() string {
 
 type InlineTable struct {
 	*BaseExpr
-	Recursive Token
-	Name      Identifier
-	Fields    []QueryExpression
-	As        string
-	Query     SelectQuery
+	Recursive       Token
+	Name            Identifier
+	Fields          []QueryExpression
+	As              string
+	Materialization Token
+	Query           SelectQuery
+	CycleClause     QueryExpression
 }
 
 func (e InlineTable) String() string {
@@ -474,7 +584,14 @@ func (e InlineTable) String() string {
 	if e.Fields != nil {
 		s = append(s, putParentheses(listQueryExpressions(e.Fields)))
 	}
-	s = append(s, e.As, putParentheses(e.Query.String()))
+	s = append(s, e.As)
+	if !e.Materialization.IsEmpty() {
+		s = append(s, e.Materialization.Literal)
+	}
+	s = append(s, putParentheses(e.Query.String()))
+	if e.CycleClause != nil {
+		s = append(s, e.CycleClause.String())
+	}
 	return joinWithSpace(s)
 }
 
@@ -482,6 +599,21 @@ func (e InlineTable) IsRecursive() bool {
 	return !e.Recursive.IsEmpty()
 }
 
+func (e InlineTable) IsNotMaterialized() bool {
+	return e.Materialization.Token == NOT
+}
+
+type CycleClause struct {
+	*BaseExpr
+	Cycle    string
+	Fields   []QueryExpression
+	Restrict string
+}
+
+func (e CycleClause) String() string {
+	return joinWithSpace([]string{e.Cycle, listQueryExpressions(e.Fields), e.Restrict})
+}
+
 type Subquery struct {
 	*BaseExpr
 	Query SelectQuery
@@ -522,18 +654,56 @@ func (e JsonQuery) String() string {
 	return e.JsonQuery + putParentheses(e.Query.String()+", "+e.JsonText.String())
 }
 
+type Unnest struct {
+	*BaseExpr
+	Unnest     string
+	Array      QueryExpression
+	With       string
+	Ordinality string
+}
+
+func (e Unnest) String() string {
+	s := e.Unnest + putParentheses(e.Array.String())
+	if e.WithOrdinality() {
+		s = joinWithSpace([]string{s, e.With, e.Ordinality})
+	}
+	return s
+}
+
+func (e Unnest) WithOrdinality() bool {
+	return 0 < len(e.Ordinality)
+}
+
 type Comparison struct {
 	*BaseExpr
-	LHS      QueryExpression
-	Operator string
-	RHS      QueryExpression
+	LHS       QueryExpression
+	Operator  string
+	RHS       QueryExpression
+	Collation Collation
 }
 
 func (c Comparison) String() string {
 	s := []string{c.LHS.String(), c.Operator, c.RHS.String()}
+	if !c.Collation.IsEmpty() {
+		s = append(s, c.Collation.String())
+	}
 	return joinWithSpace(s)
 }
 
+type Collation struct {
+	*BaseExpr
+	Collate string
+	Name    string
+}
+
+func (e Collation) String() string {
+	return joinWithSpace([]string{e.Collate, quoteString(e.Name)})
+}
+
+func (e Collation) IsEmpty() bool {
+	return len(e.Collate) < 1
+}
+
 type Is struct {
 	*BaseExpr
 	Is       string
@@ -637,6 +807,10 @@ func (l Like) IsNegated() bool {
 	return !l.Negation.IsEmpty()
 }
 
+func (l Like) IgnoresCase() bool {
+	return strings.EqualFold(l.Like, TokenLiteral(ILIKE))
+}
+
 func (l Like) String() string {
 	s := []string{l.LHS.String()}
 	if l.IsNegated() {
@@ -751,9 +925,11 @@ func (e AggregateFunction) IsDistinct() bool {
 
 type Table struct {
 	*BaseExpr
-	Object QueryExpression
-	As     string
-	Alias  QueryExpression
+	Object  QueryExpression
+	As      string
+	Alias   QueryExpression
+	Columns []QueryExpression
+	Sample  QueryExpression
 }
 
 func (t Table) String() string {
@@ -762,11 +938,39 @@ func (t Table) String() string {
 		s = append(s, t.As)
 	}
 	if t.Alias != nil {
-		s = append(s, t.Alias.String())
+		if t.Columns != nil {
+			s = append(s, t.Alias.String()+putParentheses(listQueryExpressions(t.Columns)))
+		} else {
+			s = append(s, t.Alias.String())
+		}
+	}
+	if t.Sample != nil {
+		s = append(s, t.Sample.String())
 	}
 	return joinWithSpace(s)
 }
 
+type TableSample struct {
+	*BaseExpr
+	TableSample string
+	Value       QueryExpression
+	Unit        Token
+	Repeatable  string
+	Seed        QueryExpression
+}
+
+func (e TableSample) String() string {
+	s := []string{e.TableSample, putParentheses(joinWithSpace([]string{e.Value.String(), e.Unit.Literal}))}
+	if e.Seed != nil {
+		s = append(s, e.Repeatable, putParentheses(e.Seed.String()))
+	}
+	return joinWithSpace(s)
+}
+
+func (e TableSample) IsPercentage() bool {
+	return e.Unit.Token == PERCENT
+}
+
 func (t Table) Name() Identifier {
 	if t.Alias != nil {
 		return t.Alias.(Identifier)
@@ -815,6 +1019,38 @@ func (j Join) String() string {
 	return joinWithSpace(s)
 }
 
+type Pivot struct {
+	*BaseExpr
+	Table     QueryExpression
+	Pivot     string
+	Aggregate QueryExpression
+	For       string
+	Column    QueryExpression
+	In        string
+	Values    []QueryExpression
+}
+
+func (e Pivot) String() string {
+	s := []string{e.Aggregate.String(), e.For, e.Column.String(), e.In, putParentheses(listQueryExpressions(e.Values))}
+	return joinWithSpace([]string{e.Table.String(), e.Pivot, putParentheses(joinWithSpace(s))})
+}
+
+type Unpivot struct {
+	*BaseExpr
+	Table   QueryExpression
+	Unpivot string
+	Value   Identifier
+	For     string
+	Name    Identifier
+	In      string
+	Columns []QueryExpression
+}
+
+func (e Unpivot) String() string {
+	s := []string{e.Value.String(), e.For, e.Name.String(), e.In, putParentheses(listQueryExpressions(e.Columns))}
+	return joinWithSpace([]string{e.Table.String(), e.Unpivot, putParentheses(joinWithSpace(s))})
+}
+
 type JoinCondition struct {
 	*BaseExpr
 	Literal string
@@ -892,6 +1128,7 @@ func (si Stdin) String() string {
 type OrderItem struct {
 	*BaseExpr
 	Value     QueryExpression
+	Collation Collation
 	Direction Token
 	Nulls     string
 	Position  Token
@@ -899,6 +1136,9 @@ type OrderItem struct {
 
 func (e OrderItem) String() string {
 	s := []string{e.Value.String()}
+	if !e.Collation.IsEmpty() {
+		s = append(s, e.Collation.String())
+	}
 	if !e.Direction.IsEmpty() {
 		s = append(s, e.Direction.Literal)
 	}
@@ -971,6 +1211,9 @@ func (e ListFunction) String() string {
 		option = append(option, e.Distinct.Literal)
 	}
 	option = append(option, listQueryExpressions(e.Args))
+	if len(e.WithinGroup) < 1 && e.OrderBy != nil {
+		option = append(option, e.OrderBy.String())
+	}
 
 	s := []string{e.Name + "(" + joinWithSpace(option) + ")"}
 	if 0 < len(e.WithinGroup) {
@@ -1064,11 +1307,14 @@ func (e PartitionClause) String() string {
 
 type WindowingClause struct {
 	*BaseExpr
-	Rows      string
-	FrameLow  QueryExpression
-	FrameHigh QueryExpression
-	Between   string
-	And       string
+	Rows         string
+	Groups       bool
+	FrameLow     QueryExpression
+	FrameHigh    QueryExpression
+	Between      string
+	And          string
+	Exclusion    int
+	ExclusionLit string
 }
 
 func (e WindowingClause) String() string {
@@ -1078,6 +1324,9 @@ func (e WindowingClause) String() string {
 	} else {
 		s = append(s, e.Between, e.FrameLow.String(), e.And, e.FrameHigh.String())
 	}
+	if 0 < len(e.ExclusionLit) {
+		s = append(s, e.ExclusionLit)
+	}
 	return joinWithSpace(s)
 }
 
@@ -1164,21 +1413,104 @@ type UnsetEnvVar struct {
 }
 
 type InsertQuery struct {
+	*BaseExpr
+	WithClause      QueryExpression
+	Hints           []Hint
+	Table           Table
+	Fields          []QueryExpression
+	ValuesList      []QueryExpression
+	Query           QueryExpression
+	ReturningClause QueryExpression
+}
+
+func (e InsertQuery) String() string {
+	s := make([]string, 0)
+	if e.WithClause != nil {
+		s = append(s, e.WithClause.String())
+	}
+	s = append(s, TokenLiteral(INSERT))
+	if 0 < len(e.Hints) {
+		s = append(s, HintsString(e.Hints))
+	}
+	s = append(s, TokenLiteral(INTO), e.Table.String())
+	if e.Fields != nil {
+		s = append(s, putParentheses(listQueryExpressions(e.Fields)))
+	}
+	if e.ValuesList != nil {
+		s = append(s, TokenLiteral(VALUES), listQueryExpressions(e.ValuesList))
+	} else if e.Query != nil {
+		s = append(s, e.Query.String())
+	}
+	if e.ReturningClause != nil {
+		s = append(s, e.ReturningClause.String())
+	}
+	return joinWithSpace(s)
+}
+
+type ReplaceQuery struct {
 	*BaseExpr
 	WithClause QueryExpression
+	Hints      []Hint
 	Table      Table
 	Fields     []QueryExpression
+	Keys       []QueryExpression
 	ValuesList []QueryExpression
 	Query      QueryExpression
 }
 
+func (e ReplaceQuery) String() string {
+	s := make([]string, 0)
+	if e.WithClause != nil {
+		s = append(s, e.WithClause.String())
+	}
+	s = append(s, TokenLiteral(REPLACE))
+	if 0 < len(e.Hints) {
+		s = append(s, HintsString(e.Hints))
+	}
+	s = append(s, TokenLiteral(INTO), e.Table.String())
+	if e.Fields != nil {
+		s = append(s, putParentheses(listQueryExpressions(e.Fields)))
+	}
+	s = append(s, TokenLiteral(USING), putParentheses(listQueryExpressions(e.Keys)))
+	if e.ValuesList != nil {
+		s = append(s, TokenLiteral(VALUES), listQueryExpressions(e.ValuesList))
+	} else if e.Query != nil {
+		s = append(s, e.Query.String())
+	}
+	return joinWithSpace(s)
+}
+
 type UpdateQuery struct {
 	*BaseExpr
-	WithClause  QueryExpression
-	Tables      []QueryExpression
-	SetList     []UpdateSet
-	FromClause  QueryExpression
-	WhereClause QueryExpression
+	WithClause      QueryExpression
+	Hints           []Hint
+	Tables          []QueryExpression
+	SetList         []UpdateSet
+	FromClause      QueryExpression
+	WhereClause     QueryExpression
+	ReturningClause QueryExpression
+}
+
+func (e UpdateQuery) String() string {
+	s := make([]string, 0)
+	if e.WithClause != nil {
+		s = append(s, e.WithClause.String())
+	}
+	s = append(s, TokenLiteral(UPDATE))
+	if 0 < len(e.Hints) {
+		s = append(s, HintsString(e.Hints))
+	}
+	s = append(s, listQueryExpressions(e.Tables), TokenLiteral(SET), listUpdateSets(e.SetList))
+	if e.FromClause != nil {
+		s = append(s, e.FromClause.String())
+	}
+	if e.WhereClause != nil {
+		s = append(s, e.WhereClause.String())
+	}
+	if e.ReturningClause != nil {
+		s = append(s, e.ReturningClause.String())
+	}
+	return joinWithSpace(s)
 }
 
 type UpdateSet struct {
@@ -1187,21 +1519,168 @@ type UpdateSet struct {
 	Value QueryExpression
 }
 
+func (us UpdateSet) String() string {
+	return joinWithSpace([]string{us.Field.String(), "=", us.Value.String()})
+}
+
 type DeleteQuery struct {
 	*BaseExpr
-	WithClause  QueryExpression
-	Tables      []QueryExpression
-	FromClause  FromClause
-	WhereClause QueryExpression
+	WithClause      QueryExpression
+	Hints           []Hint
+	Tables          []QueryExpression
+	FromClause      FromClause
+	Using           []QueryExpression
+	WhereClause     QueryExpression
+	ReturningClause QueryExpression
+}
+
+func (e DeleteQuery) String() string {
+	s := make([]string, 0)
+	if e.WithClause != nil {
+		s = append(s, e.WithClause.String())
+	}
+	s = append(s, TokenLiteral(DELETE))
+	if 0 < len(e.Hints) {
+		s = append(s, HintsString(e.Hints))
+	}
+	if e.Tables != nil {
+		s = append(s, listQueryExpressions(e.Tables))
+	}
+	s = append(s, e.FromClause.String())
+	if e.Using != nil {
+		s = append(s, TokenLiteral(USING), listQueryExpressions(e.Using))
+	}
+	if e.WhereClause != nil {
+		s = append(s, e.WhereClause.String())
+	}
+	if e.ReturningClause != nil {
+		s = append(s, e.ReturningClause.String())
+	}
+	return joinWithSpace(s)
+}
+
+type ReturningClause struct {
+	*BaseExpr
+	Returning string
+	Fields    []QueryExpression
+}
+
+func (rc ReturningClause) String() string {
+	s := []string{rc.Returning, listQueryExpressions(rc.Fields)}
+	return joinWithSpace(s)
+}
+
+type MergeQuery struct {
+	*BaseExpr
+	WithClause QueryExpression
+	Table      Table
+	Source     QueryExpression
+	Condition  QueryExpression
+	WhenList   []MergeWhen
+}
+
+func (e MergeQuery) String() string {
+	s := make([]string, 0)
+	if e.WithClause != nil {
+		s = append(s, e.WithClause.String())
+	}
+	s = append(s, TokenLiteral(MERGE), TokenLiteral(INTO), e.Table.String(), TokenLiteral(USING), e.Source.String(), TokenLiteral(ON), e.Condition.String())
+	for _, when := range e.WhenList {
+		s = append(s, when.String())
+	}
+	return joinWithSpace(s)
+}
+
+func (e MergeQuery) HasOperation(operation int) bool {
+	for _, when := range e.WhenList {
+		if when.Operation.Token == operation {
+			return true
+		}
+	}
+	return false
+}
+
+type MergeWhen struct {
+	*BaseExpr
+	NotMatched bool
+	Condition  QueryExpression
+	Operation  Token
+	SetList    []UpdateSet
+	Fields     []QueryExpression
+	Values     QueryExpression
+}
+
+func (e MergeWhen) String() string {
+	s := []string{TokenLiteral(WHEN)}
+	if e.NotMatched {
+		s = append(s, TokenLiteral(NOT))
+	}
+	s = append(s, TokenLiteral(MATCHED))
+	if e.Condition != nil {
+		s = append(s, TokenLiteral(AND), e.Condition.String())
+	}
+	s = append(s, TokenLiteral(THEN))
+	switch e.Operation.Token {
+	case UPDATE:
+		s = append(s, TokenLiteral(UPDATE), TokenLiteral(SET), listUpdateSets(e.SetList))
+	case DELETE:
+		s = append(s, TokenLiteral(DELETE))
+	default:
+		s = append(s, TokenLiteral(INSERT))
+		if e.Fields != nil {
+			s = append(s, putParentheses(listQueryExpressions(e.Fields)))
+		}
+		s = append(s, TokenLiteral(VALUES), e.Values.String())
+	}
+	return joinWithSpace(s)
 }
 
 type CreateTable struct {
 	*BaseExpr
-	Table  Identifier
+	Table       Identifier
+	Fields      []QueryExpression
+	Constraints []ColumnConstraint
+	Query       QueryExpression
+}
+
+type ColumnDefinition struct {
+	Column      Identifier
+	Constraints []ColumnConstraint
+}
+
+type ColumnConstraint struct {
+	*BaseExpr
+	Column    Identifier
+	Type      Token
+	Condition QueryExpression
+}
+
+func (e ColumnConstraint) String() string {
+	switch e.Type.Token {
+	case NOT:
+		return joinWithSpace([]string{e.Column.String(), e.Type.Literal, TokenLiteral(NULL)})
+	case CHECK:
+		return joinWithSpace([]string{e.Column.String(), e.Type.Literal, putParentheses(e.Condition.String())})
+	}
+	return joinWithSpace([]string{e.Column.String(), e.Type.Literal})
+}
+
+type CreateView struct {
+	*BaseExpr
+	View   Identifier
 	Fields []QueryExpression
 	Query  QueryExpression
 }
 
+func (e CreateView) String() string {
+	s := []string{TokenLiteral(CREATE), TokenLiteral(VIEW), e.View.String()}
+	if e.Fields != nil {
+		s = append(s, putParentheses(listQueryExpressions(e.Fields)))
+	}
+	s = append(s, TokenLiteral(AS), e.Query.String())
+	return joinWithSpace(s)
+}
+
 type AddColumns struct {
 	*BaseExpr
 	Table    QueryExpression
@@ -1243,9 +1722,20 @@ type SetTableAttribute struct {
 
 type FunctionDeclaration struct {
 	*BaseExpr
-	Name       Identifier
-	Parameters []VariableAssignment
-	Statements []Statement
+	Name          Identifier
+	Parameters    []VariableAssignment
+	Deterministic Token
+	Statements    []Statement
+	Language      Identifier
+	Source        value.String
+}
+
+func (e FunctionDeclaration) IsDeterministic() bool {
+	return !e.Deterministic.IsEmpty()
+}
+
+func (e FunctionDeclaration) IsExternalLanguage() bool {
+	return 0 < len(e.Language.Literal)
 }
 
 type AggregateDeclaration struct {
@@ -1301,6 +1791,12 @@ type Reload struct {
 	Type Identifier
 }
 
+type Explain struct {
+	*BaseExpr
+	Option Identifier
+	Query  QueryExpression
+}
+
 type Execute struct {
 	*BaseExpr
 	Statements QueryExpression
@@ -1314,10 +1810,15 @@ type Syntax struct {
 
 type SetFlag struct {
 	*BaseExpr
+	Local Token
 	Name  string
 	Value QueryExpression
 }
 
+func (e SetFlag) IsLocal() bool {
+	return !e.Local.IsEmpty()
+}
+
 type AddFlagElement struct {
 	*BaseExpr
 	Name  string
@@ -1464,9 +1965,10 @@ func (e CursorAttrebute) String() string {
 
 type ViewDeclaration struct {
 	*BaseExpr
-	View   Identifier
-	Fields []QueryExpression
-	Query  QueryExpression
+	View       Identifier
+	Fields     []QueryExpression
+	PrimaryKey []QueryExpression
+	Query      QueryExpression
 }
 
 type DisposeView struct {
@@ -1474,10 +1976,45 @@ type DisposeView struct {
 	View Identifier
 }
 
+type IndexDeclaration struct {
+	*BaseExpr
+	Index   Identifier
+	Table   QueryExpression
+	Columns []QueryExpression
+}
+
+type DisposeIndex struct {
+	*BaseExpr
+	Index Identifier
+}
+
+type SequenceDeclaration struct {
+	*BaseExpr
+	Sequence  Identifier
+	Start     QueryExpression
+	Increment QueryExpression
+}
+
+type DisposeSequence struct {
+	*BaseExpr
+	Sequence Identifier
+}
+
 type StatementPreparation struct {
 	*BaseExpr
-	Name      Identifier
-	Statement value.String
+	Name       Identifier
+	Parameters []StatementParameter
+	Statement  value.String
+}
+
+type StatementParameter struct {
+	*BaseExpr
+	Name Identifier
+	Type Identifier
+}
+
+func (e StatementParameter) IsNamed() bool {
+	return 0 < len(e.Name.Literal)
 }
 
 type ReplaceValue struct {
@@ -1500,6 +2037,17 @@ type DisposeStatement struct {
 type TransactionControl struct {
 	*BaseExpr
 	Token int
+	Table Identifier
+}
+
+type LockTable struct {
+	*BaseExpr
+	Tables []QueryExpression
+}
+
+type UnlockTable struct {
+	*BaseExpr
+	Tables []QueryExpression
 }
 
 type FlowControl struct {
@@ -1540,6 +2088,14 @@ func listQueryExpressions(exprs []QueryExpression) string {
 	return strings.Join(s, ", ")
 }
 
+func listUpdateSets(list []UpdateSet) string {
+	s := make([]string, len(list))
+	for i, v := range list {
+		s[i] = v.String()
+	}
+	return strings.Join(s, ", ")
+}
+
 func quoteString(s string) string {
 	return "'" + s + "'"
 }
@@ -280,6 +280,46 @@ func TestSelectQuery_String(t *testing.T) {
 	if e.String() != expect {
 		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
 	}
+
+	e.LimitClause = LimitClause{
+		Limit:    "fetch",
+		Position: Token{Token: NEXT, Literal: "next"},
+		Value:    NewIntegerValueFromString("10"),
+		Unit:     Token{Token: ROWS, Literal: "rows"},
+		With:     LimitWith{With: "with", Type: Token{Token: TIES, Literal: "ties"}},
+	}
+	expect = "with ct as (select 1) select column from table order by column offset 10 fetch next 10 rows with ties"
+	if e.String() != expect {
+		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
+	}
+}
+
+func TestValuesTable_String(t *testing.T) {
+	e := ValuesTable{
+		Values: "values",
+		ValuesList: []QueryExpression{
+			RowValue{
+				Value: ValueList{
+					Values: []QueryExpression{
+						NewIntegerValueFromString("1"),
+						NewStringValue("a"),
+					},
+				},
+			},
+			RowValue{
+				Value: ValueList{
+					Values: []QueryExpression{
+						NewIntegerValueFromString("2"),
+						NewStringValue("b"),
+					},
+				},
+			},
+		},
+	}
+	expect := "values (1, 'a'), (2, 'b')"
+	if e.String() != expect {
+		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
+	}
 }
 
 func TestSelectSet_String(t *testing.T) {
@@ -376,6 +416,52 @@ func TestSelectClause_String(t *testing.T) {
 	if e.String() != expect {
 		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
 	}
+
+	e = SelectClause{
+		Select: "select",
+		Hints:  []Hint{{Name: "CPU", Args: []string{"8"}}, {Name: "NO_CACHE"}},
+		Fields: []QueryExpression{
+			Field{
+				Object: Identifier{Literal: "column1"},
+			},
+		},
+	}
+	expect = "select /*+ CPU(8) NO_CACHE */ column1"
+	if e.String() != expect {
+		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
+	}
+
+	e = SelectClause{
+		Select:   "select",
+		Distinct: Token{Token: DISTINCT, Literal: "distinct"},
+		DistinctOn: DistinctOn{
+			On:     "on",
+			Values: []QueryExpression{Identifier{Literal: "column1"}},
+		},
+		Fields: []QueryExpression{
+			Field{
+				Object: Identifier{Literal: "column2"},
+			},
+		},
+	}
+	expect = "select distinct on (column1) column2"
+	if e.String() != expect {
+		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
+	}
+}
+
+func TestDistinctOn_String(t *testing.T) {
+	e := DistinctOn{
+		On: "on",
+		Values: []QueryExpression{
+			Identifier{Literal: "column1"},
+			Identifier{Literal: "column2"},
+		},
+	}
+	expect := "on (column1, column2)"
+	if e.String() != expect {
+		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
+	}
 }
 
 func TestFromClause_String(t *testing.T) {
@@ -407,6 +493,26 @@ func TestWhereClause_String(t *testing.T) {
 	}
 }
 
+func TestReturningClause_String(t *testing.T) {
+	e := ReturningClause{
+		Returning: "returning",
+		Fields: []QueryExpression{
+			Field{
+				Object: Identifier{Literal: "column1"},
+			},
+			Field{
+				Object: Identifier{Literal: "column2"},
+				As:     "as",
+				Alias:  Identifier{Literal: "alias"},
+			},
+		},
+	}
+	expect := "returning column1, column2 as alias"
+	if e.String() != expect {
+		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
+	}
+}
+
 func TestGroupByClause_String(t *testing.T) {
 	e := GroupByClause{
 		GroupBy: "group by",
@@ -421,6 +527,54 @@ func TestGroupByClause_String(t *testing.T) {
 	}
 }
 
+func TestRollup_String(t *testing.T) {
+	e := Rollup{
+		Rollup: "rollup",
+		Items: []QueryExpression{
+			Identifier{Literal: "column1"},
+			Identifier{Literal: "column2"},
+		},
+	}
+	expect := "rollup (column1, column2)"
+	if e.String() != expect {
+		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
+	}
+}
+
+func TestCube_String(t *testing.T) {
+	e := Cube{
+		Cube: "cube",
+		Items: []QueryExpression{
+			Identifier{Literal: "column1"},
+			Identifier{Literal: "column2"},
+		},
+	}
+	expect := "cube (column1, column2)"
+	if e.String() != expect {
+		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
+	}
+}
+
+func TestGroupingSets_String(t *testing.T) {
+	e := GroupingSets{
+		GroupingSets: "grouping sets",
+		Items: []QueryExpression{
+			ValueList{
+				Values: []QueryExpression{
+					Identifier{Literal: "column1"},
+					Identifier{Literal: "column2"},
+				},
+			},
+			Identifier{Literal: "column1"},
+			ValueList{},
+		},
+	}
+	expect := "grouping sets ((column1, column2), column1, ())"
+	if e.String() != expect {
+		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
+	}
+}
+
 func TestHavingClause_String(t *testing.T) {
 	e := HavingClause{
 		Having: "having",
@@ -467,6 +621,19 @@ func TestLimitClause_String(t *testing.T) {
 	if e.String() != expect {
 		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
 	}
+
+	e = LimitClause{
+		Limit:    "fetch",
+		Position: Token{Token: FIRST, Literal: "first"},
+		Value:    NewIntegerValueFromString("10"),
+		Percent:  "percent",
+		Unit:     Token{Token: ROWS, Literal: "rows"},
+		With:     LimitWith{Type: Token{Token: ONLY, Literal: "only"}},
+	}
+	expect = "fetch first 10 percent rows only"
+	if e.String() != expect {
+		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
+	}
 }
 
 func TestLimitClause_IsPercentage(t *testing.T) {
@@ -507,6 +674,12 @@ func TestOffsetClause_String(t *testing.T) {
 	if e.String() != expect {
 		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
 	}
+
+	e = OffsetClause{Offset: "offset", Value: NewIntegerValueFromString("10"), Unit: Token{Token: ROWS, Literal: "rows"}}
+	expect = "offset 10 rows"
+	if e.String() != expect {
+		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
+	}
 }
 
 func TestWithClause_String(t *testing.T) {
@@ -550,6 +723,172 @@ func TestWithClause_String(t *testing.T) {
 	}
 }
 
+func TestColumnConstraint_String(t *testing.T) {
+	e := ColumnConstraint{
+		Column: Identifier{Literal: "column1"},
+		Type:   Token{Token: NOT, Literal: "not"},
+	}
+	expect := "column1 not NULL"
+	if e.String() != expect {
+		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
+	}
+
+	e = ColumnConstraint{
+		Column:    Identifier{Literal: "column1"},
+		Type:      Token{Token: CHECK, Literal: "check"},
+		Condition: Comparison{LHS: FieldReference{Column: Identifier{Literal: "column1"}}, Operator: ">", RHS: NewIntegerValueFromString("0")},
+	}
+	expect = "column1 check (column1 > 0)"
+	if e.String() != expect {
+		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
+	}
+}
+
+func TestArrayConstructor_String(t *testing.T) {
+	e := ArrayConstructor{
+		Values: []QueryExpression{
+			NewIntegerValueFromString("1"),
+			NewStringValue("a"),
+		},
+	}
+	expect := "[1, 'a']"
+	if e.String() != expect {
+		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
+	}
+}
+
+func TestArrayElement_String(t *testing.T) {
+	e := ArrayElement{
+		Array: FieldReference{Column: Identifier{Literal: "column1"}},
+		Index: NewIntegerValueFromString("0"),
+	}
+	expect := "column1[0]"
+	if e.String() != expect {
+		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
+	}
+}
+
+func TestInsertQuery_String(t *testing.T) {
+	e := InsertQuery{
+		Hints:  []Hint{{Name: "WAIT_TIMEOUT", Args: []string{"5"}}},
+		Table:  Table{Object: Identifier{Literal: "table1"}},
+		Fields: []QueryExpression{Identifier{Literal: "column1"}, Identifier{Literal: "column2"}},
+		ValuesList: []QueryExpression{
+			RowValue{Value: ValueList{Values: []QueryExpression{NewIntegerValueFromString("1"), NewStringValue("a")}}},
+			RowValue{Value: ValueList{Values: []QueryExpression{NewIntegerValueFromString("2"), NewStringValue("b")}}},
+		},
+	}
+	expect := "INSERT /*+ WAIT_TIMEOUT(5) */ INTO table1 (column1, column2) VALUES (1, 'a'), (2, 'b')"
+	if e.String() != expect {
+		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
+	}
+}
+
+func TestReplaceQuery_String(t *testing.T) {
+	e := ReplaceQuery{
+		Table:  Table{Object: Identifier{Literal: "table1"}},
+		Fields: []QueryExpression{Identifier{Literal: "column1"}, Identifier{Literal: "column2"}},
+		Keys:   []QueryExpression{Identifier{Literal: "column1"}},
+		ValuesList: []QueryExpression{
+			RowValue{Value: ValueList{Values: []QueryExpression{NewIntegerValueFromString("1"), NewStringValue("a")}}},
+		},
+	}
+	expect := "REPLACE INTO table1 (column1, column2) USING (column1) VALUES (1, 'a')"
+	if e.String() != expect {
+		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
+	}
+}
+
+func TestUpdateQuery_String(t *testing.T) {
+	e := UpdateQuery{
+		Tables: []QueryExpression{Table{Object: Identifier{Literal: "table1"}}},
+		SetList: []UpdateSet{
+			{Field: FieldReference{Column: Identifier{Literal: "column1"}}, Value: NewIntegerValueFromString("1")},
+			{Field: FieldReference{Column: Identifier{Literal: "column2"}}, Value: NewStringValue("a")},
+		},
+		WhereClause: WhereClause{Where: "where", Filter: NewTernaryValueFromString("true")},
+	}
+	expect := "UPDATE table1 SET column1 = 1, column2 = 'a' where true"
+	if e.String() != expect {
+		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
+	}
+}
+
+func TestDeleteQuery_String(t *testing.T) {
+	e := DeleteQuery{
+		FromClause:  FromClause{From: "from", Tables: []QueryExpression{Table{Object: Identifier{Literal: "table1"}}}},
+		WhereClause: WhereClause{Where: "where", Filter: NewTernaryValueFromString("true")},
+		ReturningClause: ReturningClause{
+			Returning: "returning",
+			Fields:    []QueryExpression{Field{Object: Identifier{Literal: "column1"}}},
+		},
+	}
+	expect := "DELETE from table1 where true returning column1"
+	if e.String() != expect {
+		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
+	}
+}
+
+func TestMergeQuery_String(t *testing.T) {
+	e := MergeQuery{
+		Table:     Table{Object: Identifier{Literal: "table1"}},
+		Source:    Table{Object: Identifier{Literal: "table2"}},
+		Condition: NewTernaryValueFromString("true"),
+		WhenList: []MergeWhen{
+			{
+				Operation: Token{Token: UPDATE, Literal: "update"},
+				SetList: []UpdateSet{
+					{Field: FieldReference{Column: Identifier{Literal: "column1"}}, Value: NewIntegerValueFromString("1")},
+				},
+			},
+			{
+				NotMatched: true,
+				Condition:  NewTernaryValueFromString("true"),
+				Operation:  Token{Token: INSERT, Literal: "insert"},
+				Values:     RowValue{Value: ValueList{Values: []QueryExpression{NewIntegerValueFromString("2")}}},
+			},
+		},
+	}
+	expect := "MERGE INTO table1 USING table2 ON true WHEN MATCHED THEN UPDATE SET column1 = 1 WHEN NOT MATCHED AND true THEN INSERT VALUES (2)"
+	if e.String() != expect {
+		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
+	}
+}
+
+func TestMergeQuery_HasOperation(t *testing.T) {
+	e := MergeQuery{
+		WhenList: []MergeWhen{
+			{Operation: Token{Token: UPDATE, Literal: "update"}},
+			{NotMatched: true, Operation: Token{Token: INSERT, Literal: "insert"}},
+		},
+	}
+	if !e.HasOperation(UPDATE) {
+		t.Errorf("has operation = %t, want %t for %#v", e.HasOperation(UPDATE), true, e)
+	}
+	if e.HasOperation(DELETE) {
+		t.Errorf("has operation = %t, want %t for %#v", e.HasOperation(DELETE), false, e)
+	}
+}
+
+func TestCreateView_String(t *testing.T) {
+	e := CreateView{
+		View:   Identifier{Literal: "view1"},
+		Fields: []QueryExpression{Identifier{Literal: "column1"}},
+		Query: SelectQuery{
+			SelectEntity: SelectEntity{
+				SelectClause: SelectClause{
+					Select: "select",
+					Fields: []QueryExpression{Field{Object: NewIntegerValueFromString("1")}},
+				},
+			},
+		},
+	}
+	expect := "CREATE VIEW view1 (column1) AS select 1"
+	if e.String() != expect {
+		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
+	}
+}
+
 func TestInlineTable_String(t *testing.T) {
 	e := InlineTable{
 		Recursive: Token{Token: RECURSIVE, Literal: "recursive"},
@@ -573,6 +912,54 @@ func TestInlineTable_String(t *testing.T) {
 	if e.String() != expect {
 		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
 	}
+
+	e.CycleClause = CycleClause{
+		Cycle:    "cycle",
+		Fields:   []QueryExpression{Identifier{Literal: "column1"}},
+		Restrict: "restrict",
+	}
+	expect = "recursive alias (column1) as (select 1) cycle column1 restrict"
+	if e.String() != expect {
+		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
+	}
+
+	e.CycleClause = nil
+	e.Materialization = Token{Token: NOT, Literal: "not materialized"}
+	expect = "recursive alias (column1) as not materialized (select 1)"
+	if e.String() != expect {
+		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
+	}
+}
+
+func TestInlineTable_IsNotMaterialized(t *testing.T) {
+	e := InlineTable{
+		Name:            Identifier{Literal: "alias"},
+		As:              "as",
+		Materialization: Token{Token: NOT, Literal: "not materialized"},
+	}
+	if e.IsNotMaterialized() != true {
+		t.Errorf("IsNotMaterialized = %t, want %t for %#v", e.IsNotMaterialized(), true, e)
+	}
+
+	e.Materialization = Token{Token: MATERIALIZED, Literal: "materialized"}
+	if e.IsNotMaterialized() != false {
+		t.Errorf("IsNotMaterialized = %t, want %t for %#v", e.IsNotMaterialized(), false, e)
+	}
+}
+
+func TestCycleClause_String(t *testing.T) {
+	e := CycleClause{
+		Cycle: "cycle",
+		Fields: []QueryExpression{
+			Identifier{Literal: "column1"},
+			Identifier{Literal: "column2"},
+		},
+		Restrict: "restrict",
+	}
+	expect := "cycle column1, column2 restrict"
+	if e.String() != expect {
+		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
+	}
 }
 
 func TestInlineTable_IsRecursive(t *testing.T) {
@@ -685,6 +1072,28 @@ func TestJsonQuery_String(t *testing.T) {
 	}
 }
 
+func TestUnnest_String(t *testing.T) {
+	e := Unnest{
+		Unnest: "unnest",
+		Array:  Identifier{Literal: "column1"},
+	}
+	expect := "unnest(column1)"
+	if e.String() != expect {
+		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
+	}
+
+	e = Unnest{
+		Unnest:     "unnest",
+		Array:      Identifier{Literal: "column1"},
+		With:       "with",
+		Ordinality: "ordinality",
+	}
+	expect = "unnest(column1) with ordinality"
+	if e.String() != expect {
+		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
+	}
+}
+
 func TestComparison_String(t *testing.T) {
 	e := Comparison{
 		LHS:      Identifier{Literal: "column"},
@@ -695,6 +1104,17 @@ func TestComparison_String(t *testing.T) {
 	if e.String() != expect {
 		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
 	}
+
+	e = Comparison{
+		LHS:       Identifier{Literal: "column"},
+		Operator:  "=",
+		RHS:       NewStringValue("abc"),
+		Collation: Collation{Collate: "collate", Name: "de_DE"},
+	}
+	expect = "column = 'abc' collate 'de_DE'"
+	if e.String() != expect {
+		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
+	}
 }
 
 func TestIs_IsNegated(t *testing.T) {
@@ -867,6 +1287,18 @@ func TestLike_IsNegated(t *testing.T) {
 	}
 }
 
+func TestLike_IgnoresCase(t *testing.T) {
+	e := Like{Like: "like"}
+	if e.IgnoresCase() == true {
+		t.Errorf("ignores case = %t, want %t for %#v", e.IgnoresCase(), false, e)
+	}
+
+	e = Like{Like: "ilike"}
+	if e.IgnoresCase() == false {
+		t.Errorf("ignores case = %t, want %t for %#v", e.IgnoresCase(), true, e)
+	}
+}
+
 func TestLike_String(t *testing.T) {
 	e := Like{
 		Like:     "like",
@@ -1033,6 +1465,60 @@ func TestTable_String(t *testing.T) {
 	if e.String() != expect {
 		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
 	}
+
+	e = Table{
+		Object: Identifier{Literal: "table"},
+		Alias:  Identifier{Literal: "alias"},
+		Sample: TableSample{
+			TableSample: "tablesample",
+			Value:       NewIntegerValueFromString("10"),
+			Unit:        Token{Token: ROWS, Literal: "rows"},
+		},
+	}
+	expect = "table alias tablesample (10 rows)"
+	if e.String() != expect {
+		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
+	}
+
+	e = Table{
+		Object: Identifier{Literal: "table"},
+		As:     "as",
+		Alias:  Identifier{Literal: "alias"},
+		Columns: []QueryExpression{
+			Identifier{Literal: "c1"},
+			Identifier{Literal: "c2"},
+		},
+	}
+	expect = "table as alias(c1, c2)"
+	if e.String() != expect {
+		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
+	}
+}
+
+func TestTableSample_String(t *testing.T) {
+	e := TableSample{
+		TableSample: "tablesample",
+		Value:       NewFloatValueFromString("12.5"),
+		Unit:        Token{Token: PERCENT, Literal: "percent"},
+		Repeatable:  "repeatable",
+		Seed:        NewIntegerValueFromString("1"),
+	}
+	expect := "tablesample (12.5 percent) repeatable (1)"
+	if e.String() != expect {
+		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
+	}
+}
+
+func TestTableSample_IsPercentage(t *testing.T) {
+	e := TableSample{Unit: Token{Token: PERCENT, Literal: "percent"}}
+	if !e.IsPercentage() {
+		t.Errorf("percentage = %t, want %t for %#v", e.IsPercentage(), true, e)
+	}
+
+	e = TableSample{Unit: Token{Token: ROWS, Literal: "rows"}}
+	if e.IsPercentage() {
+		t.Errorf("percentage = %t, want %t for %#v", e.IsPercentage(), false, e)
+	}
 }
 
 func TestTable_Name(t *testing.T) {
@@ -1111,6 +1597,47 @@ func TestJoin_String(t *testing.T) {
 	}
 }
 
+func TestPivot_String(t *testing.T) {
+	e := Pivot{
+		Table: Table{Object: Identifier{Literal: "table1"}},
+		Pivot: "pivot",
+		Aggregate: AggregateFunction{
+			Name: "sum",
+			Args: []QueryExpression{FieldReference{Column: Identifier{Literal: "column1"}}},
+		},
+		For:    "for",
+		Column: FieldReference{Column: Identifier{Literal: "column2"}},
+		In:     "in",
+		Values: []QueryExpression{
+			Field{Object: NewStringValue("a")},
+			Field{Object: NewStringValue("b"), As: "as", Alias: Identifier{Literal: "alias"}},
+		},
+	}
+	expect := "table1 pivot (sum(column1) for column2 in ('a', 'b' as alias))"
+	if e.String() != expect {
+		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
+	}
+}
+
+func TestUnpivot_String(t *testing.T) {
+	e := Unpivot{
+		Table:   Table{Object: Identifier{Literal: "table1"}},
+		Unpivot: "unpivot",
+		Value:   Identifier{Literal: "value"},
+		For:     "for",
+		Name:    Identifier{Literal: "name"},
+		In:      "in",
+		Columns: []QueryExpression{
+			FieldReference{Column: Identifier{Literal: "column1"}},
+			FieldReference{Column: Identifier{Literal: "column2"}},
+		},
+	}
+	expect := "table1 unpivot (value for name in (column1, column2))"
+	if e.String() != expect {
+		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
+	}
+}
+
 func TestJoinCondition_String(t *testing.T) {
 	e := JoinCondition{
 		Literal: "on",
@@ -1255,6 +1782,16 @@ func TestOrderItem_String(t *testing.T) {
 	if e.String() != expect {
 		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
 	}
+
+	e = OrderItem{
+		Value:     Identifier{Literal: "column"},
+		Collation: Collation{Collate: "collate", Name: "de_DE"},
+		Direction: Token{Token: DESC, Literal: "desc"},
+	}
+	expect = "column collate 'de_DE' desc"
+	if e.String() != expect {
+		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
+	}
 }
 
 func TestCase_String(t *testing.T) {
@@ -1349,6 +1886,21 @@ func TestListFunction_String(t *testing.T) {
 		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
 	}
 
+	e = ListFunction{
+		Name: "first",
+		Args: []QueryExpression{
+			Identifier{Literal: "column1"},
+		},
+		OrderBy: OrderByClause{
+			OrderBy: "order by",
+			Items:   []QueryExpression{Identifier{Literal: "column2"}},
+		},
+	}
+	expect = "first(column1 order by column2)"
+	if e.String() != expect {
+		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
+	}
+
 	e = ListFunction{
 		Name:     "listagg",
 		Distinct: Token{Token: DISTINCT, Literal: "distinct"},
@@ -1520,6 +2072,22 @@ func TestWindowingClause_String(t *testing.T) {
 	if e.String() != expect {
 		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
 	}
+
+	e = WindowingClause{
+		Rows:   "groups",
+		Groups: true,
+		FrameLow: WindowFramePosition{
+			Direction: PRECEDING,
+			Offset:    1,
+			Literal:   "1 preceding",
+		},
+		Exclusion:    TIES,
+		ExclusionLit: "exclude ties",
+	}
+	expect = "groups 1 preceding exclude ties"
+	if e.String() != expect {
+		t.Errorf("string = %q, want %q for %#v", e.String(), expect, e)
+	}
 }
 
 func TestVariable_String(t *testing.T) {
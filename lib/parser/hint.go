@@ -0,0 +1,87 @@
+package parser
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Hint is an optimizer hint written in a comment beginning with "/*+" immediately after a SELECT keyword.
+type Hint struct {
+	Name string
+	Args []string
+}
+
+func (h Hint) String() string {
+	if h.Args == nil {
+		return h.Name
+	}
+	return h.Name + "(" + strings.Join(h.Args, ", ") + ")"
+}
+
+func HintsString(hints []Hint) string {
+	list := make([]string, 0, len(hints))
+	for _, h := range hints {
+		list = append(list, h.String())
+	}
+	return "/*" + string(HintSign) + " " + strings.Join(list, " ") + " */"
+}
+
+// ParseHints parses the text of a hint comment.
+// Hints are separated by spaces, and each hint is a name optionally followed by arguments
+// separated by commas in parentheses, such as "CPU(8)".
+// Malformed hints are ignored in the same way as comments.
+func ParseHints(text string) []Hint {
+	src := []rune(text)
+	pos := 0
+
+	skipSpaces := func() {
+		for pos < len(src) && unicode.IsSpace(src[pos]) {
+			pos++
+		}
+	}
+
+	hints := make([]Hint, 0, 1)
+	for {
+		skipSpaces()
+		if len(src) <= pos {
+			break
+		}
+
+		start := pos
+		for pos < len(src) && (unicode.IsLetter(src[pos]) || unicode.IsDigit(src[pos]) || src[pos] == '_') {
+			pos++
+		}
+		if pos == start {
+			// Skip an unrecognizable character.
+			pos++
+			continue
+		}
+		hint := Hint{Name: strings.ToUpper(string(src[start:pos]))}
+
+		skipSpaces()
+		if pos < len(src) && src[pos] == '(' {
+			end := pos + 1
+			for end < len(src) && src[end] != ')' {
+				end++
+			}
+			if len(src) <= end {
+				break
+			}
+
+			hint.Args = make([]string, 0, 1)
+			for _, arg := range strings.Split(string(src[pos+1:end]), ",") {
+				if arg = strings.TrimSpace(arg); 0 < len(arg) {
+					hint.Args = append(hint.Args, arg)
+				}
+			}
+			pos = end + 1
+		}
+
+		hints = append(hints, hint)
+	}
+
+	if len(hints) < 1 {
+		return nil
+	}
+	return hints
+}
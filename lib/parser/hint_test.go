@@ -0,0 +1,41 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+var parseHintsTests = []struct {
+	Text   string
+	Result []Hint
+}{
+	{
+		Text:   " cpu(8) ",
+		Result: []Hint{{Name: "CPU", Args: []string{"8"}}},
+	},
+	{
+		Text:   "CPU (4)no_cache\nhint( a , b,)",
+		Result: []Hint{{Name: "CPU", Args: []string{"4"}}, {Name: "NO_CACHE"}, {Name: "HINT", Args: []string{"a", "b"}}},
+	},
+	{
+		Text:   "!? cpu()",
+		Result: []Hint{{Name: "CPU", Args: []string{}}},
+	},
+	{
+		Text:   "cpu(8",
+		Result: nil,
+	},
+	{
+		Text:   "  ",
+		Result: nil,
+	},
+}
+
+func TestParseHints(t *testing.T) {
+	for _, v := range parseHintsTests {
+		result := ParseHints(v.Text)
+		if !reflect.DeepEqual(result, v.Result) {
+			t.Errorf("%q: result = %#v, want %#v", v.Text, result, v.Result)
+		}
+	}
+}
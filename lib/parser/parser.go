@@ -1,4 +1,4 @@
-// Code generated by goyacc -o parser.go -v parser.output parser.y. DO NOT EDIT.
+// Code generated by goyacc -o parser.go -v /tmp/p.output parser.y. DO NOT EDIT.
 
 //line parser.y:2
 package parser
@@ -30,8 +30,14 @@ type yySymType struct {
 	envvar      EnvironmentVariable
 	updateset   UpdateSet
 	updatesets  []UpdateSet
+	mergewhen   MergeWhen
+	mergewhens  []MergeWhen
 	columndef   ColumnDefault
 	columndefs  []ColumnDefault
+	constraint  ColumnConstraint
+	constraints []ColumnConstraint
+	columnspec  ColumnDefinition
+	columnspecs []ColumnDefinition
 	elseif      []ElseIf
 	elseexpr    Else
 	casewhen    []CaseWhen
@@ -39,6 +45,10 @@ type yySymType struct {
 	fetchpos    FetchPosition
 	replaceval  ReplaceValue
 	replacevals []ReplaceValue
+	stmtparam   StatementParameter
+	stmtparams  []StatementParameter
+	collation   Collation
+	hints       []Hint
 	token       Token
 }
 
@@ -55,143 +65,185 @@ const ENVIRONMENT_VARIABLE = 57355
 const RUNTIME_INFORMATION = 57356
 const EXTERNAL_COMMAND = 57357
 const PLACEHOLDER = 57358
-const SELECT = 57359
-const FROM = 57360
-const UPDATE = 57361
-const SET = 57362
-const UNSET = 57363
-const DELETE = 57364
-const WHERE = 57365
-const INSERT = 57366
-const INTO = 57367
-const VALUES = 57368
-const AS = 57369
-const DUAL = 57370
-const STDIN = 57371
-const RECURSIVE = 57372
-const CREATE = 57373
-const ADD = 57374
-const DROP = 57375
-const ALTER = 57376
-const TABLE = 57377
-const FIRST = 57378
-const LAST = 57379
-const AFTER = 57380
-const BEFORE = 57381
-const DEFAULT = 57382
-const RENAME = 57383
-const TO = 57384
-const VIEW = 57385
-const ORDER = 57386
-const GROUP = 57387
-const HAVING = 57388
-const BY = 57389
-const ASC = 57390
-const DESC = 57391
-const LIMIT = 57392
-const OFFSET = 57393
-const PERCENT = 57394
-const JOIN = 57395
-const INNER = 57396
-const OUTER = 57397
-const LEFT = 57398
-const RIGHT = 57399
-const FULL = 57400
-const CROSS = 57401
-const ON = 57402
-const USING = 57403
-const NATURAL = 57404
-const UNION = 57405
-const INTERSECT = 57406
-const EXCEPT = 57407
-const ALL = 57408
-const ANY = 57409
-const EXISTS = 57410
-const IN = 57411
-const AND = 57412
-const OR = 57413
-const NOT = 57414
-const BETWEEN = 57415
-const LIKE = 57416
-const IS = 57417
-const NULL = 57418
-const DISTINCT = 57419
-const WITH = 57420
-const RANGE = 57421
-const UNBOUNDED = 57422
-const PRECEDING = 57423
-const FOLLOWING = 57424
-const CURRENT = 57425
-const ROW = 57426
-const CASE = 57427
-const IF = 57428
-const ELSEIF = 57429
-const WHILE = 57430
-const WHEN = 57431
-const THEN = 57432
-const ELSE = 57433
-const DO = 57434
-const END = 57435
-const DECLARE = 57436
-const CURSOR = 57437
-const FOR = 57438
-const FETCH = 57439
-const OPEN = 57440
-const CLOSE = 57441
-const DISPOSE = 57442
-const PREPARE = 57443
-const NEXT = 57444
-const PRIOR = 57445
-const ABSOLUTE = 57446
-const RELATIVE = 57447
-const SEPARATOR = 57448
-const PARTITION = 57449
-const OVER = 57450
-const COMMIT = 57451
-const ROLLBACK = 57452
-const CONTINUE = 57453
-const BREAK = 57454
-const EXIT = 57455
-const ECHO = 57456
-const PRINT = 57457
-const PRINTF = 57458
-const SOURCE = 57459
-const EXECUTE = 57460
-const CHDIR = 57461
-const PWD = 57462
-const RELOAD = 57463
-const REMOVE = 57464
-const SYNTAX = 57465
-const TRIGGER = 57466
-const FUNCTION = 57467
-const AGGREGATE = 57468
-const BEGIN = 57469
-const RETURN = 57470
-const IGNORE = 57471
-const WITHIN = 57472
-const VAR = 57473
-const SHOW = 57474
-const TIES = 57475
-const NULLS = 57476
-const ROWS = 57477
-const CSV = 57478
-const JSON = 57479
-const FIXED = 57480
-const LTSV = 57481
-const JSON_ROW = 57482
-const JSON_TABLE = 57483
-const COUNT = 57484
-const JSON_OBJECT = 57485
-const AGGREGATE_FUNCTION = 57486
-const LIST_FUNCTION = 57487
-const ANALYTIC_FUNCTION = 57488
-const FUNCTION_NTH = 57489
-const FUNCTION_WITH_INS = 57490
-const COMPARISON_OP = 57491
-const STRING_OP = 57492
-const SUBSTITUTION_OP = 57493
-const UMINUS = 57494
-const UPLUS = 57495
+const HINT = 57359
+const SELECT = 57360
+const FROM = 57361
+const UPDATE = 57362
+const SET = 57363
+const UNSET = 57364
+const DELETE = 57365
+const WHERE = 57366
+const INSERT = 57367
+const INTO = 57368
+const VALUES = 57369
+const AS = 57370
+const DUAL = 57371
+const STDIN = 57372
+const RECURSIVE = 57373
+const CREATE = 57374
+const ADD = 57375
+const DROP = 57376
+const ALTER = 57377
+const TABLE = 57378
+const FIRST = 57379
+const LAST = 57380
+const AFTER = 57381
+const BEFORE = 57382
+const DEFAULT = 57383
+const RENAME = 57384
+const TO = 57385
+const VIEW = 57386
+const ORDER = 57387
+const GROUP = 57388
+const HAVING = 57389
+const BY = 57390
+const ASC = 57391
+const DESC = 57392
+const LIMIT = 57393
+const OFFSET = 57394
+const PERCENT = 57395
+const ROLLUP = 57396
+const CUBE = 57397
+const GROUPING = 57398
+const SETS = 57399
+const JOIN = 57400
+const INNER = 57401
+const OUTER = 57402
+const LEFT = 57403
+const RIGHT = 57404
+const FULL = 57405
+const CROSS = 57406
+const ON = 57407
+const USING = 57408
+const NATURAL = 57409
+const UNION = 57410
+const INTERSECT = 57411
+const EXCEPT = 57412
+const ALL = 57413
+const ANY = 57414
+const EXISTS = 57415
+const IN = 57416
+const AND = 57417
+const OR = 57418
+const NOT = 57419
+const BETWEEN = 57420
+const LIKE = 57421
+const ILIKE = 57422
+const IS = 57423
+const NULL = 57424
+const DISTINCT = 57425
+const WITH = 57426
+const RANGE = 57427
+const UNBOUNDED = 57428
+const PRECEDING = 57429
+const FOLLOWING = 57430
+const CURRENT = 57431
+const ROW = 57432
+const CASE = 57433
+const IF = 57434
+const ELSEIF = 57435
+const WHILE = 57436
+const WHEN = 57437
+const THEN = 57438
+const ELSE = 57439
+const DO = 57440
+const END = 57441
+const DECLARE = 57442
+const CURSOR = 57443
+const FOR = 57444
+const FETCH = 57445
+const OPEN = 57446
+const CLOSE = 57447
+const DISPOSE = 57448
+const PREPARE = 57449
+const NEXT = 57450
+const PRIOR = 57451
+const ABSOLUTE = 57452
+const RELATIVE = 57453
+const SEPARATOR = 57454
+const PARTITION = 57455
+const OVER = 57456
+const COMMIT = 57457
+const ROLLBACK = 57458
+const LOCK = 57459
+const UNLOCK = 57460
+const EXCLUSIVE = 57461
+const CONTINUE = 57462
+const BREAK = 57463
+const EXIT = 57464
+const ECHO = 57465
+const PRINT = 57466
+const PRINTF = 57467
+const SOURCE = 57468
+const EXECUTE = 57469
+const CHDIR = 57470
+const PWD = 57471
+const RELOAD = 57472
+const REMOVE = 57473
+const SYNTAX = 57474
+const TRIGGER = 57475
+const FUNCTION = 57476
+const AGGREGATE = 57477
+const BEGIN = 57478
+const RETURN = 57479
+const IGNORE = 57480
+const WITHIN = 57481
+const VAR = 57482
+const SHOW = 57483
+const EXPLAIN = 57484
+const TIES = 57485
+const NULLS = 57486
+const ROWS = 57487
+const ONLY = 57488
+const GROUPS = 57489
+const EXCLUDE = 57490
+const NO = 57491
+const OTHERS = 57492
+const CSV = 57493
+const JSON = 57494
+const FIXED = 57495
+const LTSV = 57496
+const JSON_ROW = 57497
+const JSON_TABLE = 57498
+const TABLESAMPLE = 57499
+const REPEATABLE = 57500
+const PIVOT = 57501
+const UNPIVOT = 57502
+const MERGE = 57503
+const MATCHED = 57504
+const REPLACE = 57505
+const RETURNING = 57506
+const CYCLE = 57507
+const RESTRICT = 57508
+const MATERIALIZED = 57509
+const INDEX = 57510
+const UNIQUE = 57511
+const CHECK = 57512
+const SEQUENCE = 57513
+const START = 57514
+const INCREMENT = 57515
+const TEMPORARY = 57516
+const PRIMARY = 57517
+const KEY = 57518
+const UNNEST = 57519
+const ORDINALITY = 57520
+const LOCAL = 57521
+const COLLATE = 57522
+const DETERMINISTIC = 57523
+const LANGUAGE = 57524
+const COUNT = 57525
+const MODE = 57526
+const JSON_OBJECT = 57527
+const AGGREGATE_FUNCTION = 57528
+const LIST_FUNCTION = 57529
+const ANALYTIC_FUNCTION = 57530
+const FUNCTION_NTH = 57531
+const FUNCTION_WITH_INS = 57532
+const COMPARISON_OP = 57533
+const STRING_OP = 57534
+const SUBSTITUTION_OP = 57535
+const UMINUS = 57536
+const UPLUS = 57537
 
 var yyToknames = [...]string{
 	"$end",
@@ -210,6 +262,7 @@ var yyToknames = [...]string{
 	"RUNTIME_INFORMATION",
 	"EXTERNAL_COMMAND",
 	"PLACEHOLDER",
+	"HINT",
 	"SELECT",
 	"FROM",
 	"UPDATE",
@@ -246,6 +299,10 @@ var yyToknames = [...]string{
 	"LIMIT",
 	"OFFSET",
 	"PERCENT",
+	"ROLLUP",
+	"CUBE",
+	"GROUPING",
+	"SETS",
 	"JOIN",
 	"INNER",
 	"OUTER",
@@ -268,6 +325,7 @@ var yyToknames = [...]string{
 	"NOT",
 	"BETWEEN",
 	"LIKE",
+	"ILIKE",
 	"IS",
 	"NULL",
 	"DISTINCT",
@@ -304,6 +362,9 @@ var yyToknames = [...]string{
 	"OVER",
 	"COMMIT",
 	"ROLLBACK",
+	"LOCK",
+	"UNLOCK",
+	"EXCLUSIVE",
 	"CONTINUE",
 	"BREAK",
 	"EXIT",
@@ -326,16 +387,49 @@ var yyToknames = [...]string{
 	"WITHIN",
 	"VAR",
 	"SHOW",
+	"EXPLAIN",
 	"TIES",
 	"NULLS",
 	"ROWS",
+	"ONLY",
+	"GROUPS",
+	"EXCLUDE",
+	"NO",
+	"OTHERS",
 	"CSV",
 	"JSON",
 	"FIXED",
 	"LTSV",
 	"JSON_ROW",
 	"JSON_TABLE",
+	"TABLESAMPLE",
+	"REPEATABLE",
+	"PIVOT",
+	"UNPIVOT",
+	"MERGE",
+	"MATCHED",
+	"REPLACE",
+	"RETURNING",
+	"CYCLE",
+	"RESTRICT",
+	"MATERIALIZED",
+	"INDEX",
+	"UNIQUE",
+	"CHECK",
+	"SEQUENCE",
+	"START",
+	"INCREMENT",
+	"TEMPORARY",
+	"PRIMARY",
+	"KEY",
+	"UNNEST",
+	"ORDINALITY",
+	"LOCAL",
+	"COLLATE",
+	"DETERMINISTIC",
+	"LANGUAGE",
 	"COUNT",
+	"MODE",
 	"JSON_OBJECT",
 	"AGGREGATE_FUNCTION",
 	"LIST_FUNCTION",
@@ -355,24 +449,37 @@ var yyToknames = [...]string{
 	"'!'",
 	"'('",
 	"')'",
+	"'['",
+	"']'",
 	"'/'",
 	"'%'",
 	"','",
 	"'.'",
 }
+
 var yyStatenames = [...]string{}
 
 const yyEofCode = 1
 const yyErrCode = 2
 const yyInitialStackSize = 16
 
-//line parser.y:2413
+//line parser.y:3209
 
 func SetDebugLevel(level int, verbose bool) {
 	yyDebug = level
 	yyErrorVerbose = verbose
 }
 
+func splitColumnDefinitions(defs []ColumnDefinition) ([]QueryExpression, []ColumnConstraint) {
+	fields := make([]QueryExpression, 0, len(defs))
+	var constraints []ColumnConstraint
+	for _, def := range defs {
+		fields = append(fields, def.Column)
+		constraints = append(constraints, def.Constraints...)
+	}
+	return fields, constraints
+}
+
 func Parse(s string, sourceFile string, datetimeFormats []string, forPrepared bool) ([]Statement, int, error) {
 	l := new(Lexer)
 	l.Init(s, sourceFile, datetimeFormats, forPrepared)
@@ -381,1144 +488,1679 @@ func Parse(s string, sourceFile string, datetimeFormats []string, forPrepared bo
 }
 
 //line yacctab:1
-var yyExca = [...]int{
+var yyExca = [...]int16{
 	-1, 0,
 	1, 1,
-	-2, 195,
+	-2, 269,
 	-1, 1,
 	1, -1,
 	-2, 0,
-	-1, 30,
-	1, 74,
-	87, 74,
-	89, 74,
-	91, 74,
-	93, 74,
-	154, 74,
-	-2, 225,
-	-1, 106,
-	17, 195,
-	19, 195,
-	22, 195,
-	24, 195,
-	-2, 1,
-	-1, 124,
-	161, 283,
-	-2, 195,
-	-1, 130,
-	63, 175,
-	64, 175,
-	65, 175,
-	-2, 186,
-	-1, 164,
-	1, 116,
-	87, 116,
-	89, 116,
-	91, 116,
-	93, 116,
-	154, 116,
-	-2, 209,
-	-1, 173,
-	1, 155,
-	87, 155,
-	89, 155,
-	91, 155,
-	93, 155,
-	154, 155,
-	-2, 209,
-	-1, 177,
-	1, 163,
-	87, 163,
-	89, 163,
-	91, 163,
-	93, 163,
-	154, 163,
-	-2, 209,
-	-1, 218,
-	69, 0,
-	73, 0,
+	-1, 36,
+	1, 78,
+	93, 78,
+	95, 78,
+	97, 78,
+	99, 78,
+	196, 78,
+	-2, 306,
+	-1, 135,
+	1, 1,
+	93, 1,
+	95, 1,
+	97, 1,
+	99, 1,
+	-2, 269,
+	-1, 155,
+	203, 374,
+	-2, 269,
+	-1, 162,
+	68, 226,
+	69, 226,
+	70, 226,
+	-2, 251,
+	-1, 209,
+	1, 153,
+	93, 153,
+	95, 153,
+	97, 153,
+	99, 153,
+	196, 153,
+	-2, 290,
+	-1, 222,
+	1, 198,
+	93, 198,
+	95, 198,
+	97, 198,
+	99, 198,
+	196, 198,
+	-2, 290,
+	-1, 226,
+	1, 206,
+	93, 206,
+	95, 206,
+	97, 206,
+	99, 206,
+	196, 206,
+	-2, 290,
+	-1, 276,
 	74, 0,
-	75, 0,
-	149, 0,
-	156, 0,
-	-2, 253,
-	-1, 219,
-	69, 0,
-	73, 0,
+	78, 0,
+	79, 0,
+	80, 0,
+	81, 0,
+	191, 0,
+	198, 0,
+	-2, 340,
+	-1, 277,
 	74, 0,
-	75, 0,
-	149, 0,
-	156, 0,
-	-2, 255,
-	-1, 228,
-	69, 0,
-	73, 0,
+	78, 0,
+	79, 0,
+	80, 0,
+	81, 0,
+	191, 0,
+	198, 0,
+	-2, 342,
+	-1, 287,
 	74, 0,
-	75, 0,
-	149, 0,
-	156, 0,
-	-2, 265,
-	-1, 238,
-	87, 1,
-	91, 1,
-	93, 1,
-	-2, 195,
-	-1, 256,
-	160, 326,
-	-2, 426,
-	-1, 257,
-	160, 327,
-	-2, 427,
-	-1, 258,
-	160, 328,
-	-2, 428,
-	-1, 259,
-	160, 329,
-	-2, 429,
-	-1, 304,
-	93, 4,
-	-2, 195,
-	-1, 351,
-	69, 0,
-	73, 0,
+	78, 0,
+	79, 0,
+	80, 0,
+	81, 0,
+	191, 0,
+	198, 0,
+	-2, 354,
+	-1, 288,
 	74, 0,
-	75, 0,
-	149, 0,
-	156, 0,
-	-2, 266,
-	-1, 358,
-	93, 1,
-	-2, 195,
-	-1, 370,
-	53, 444,
-	-2, 370,
-	-1, 403,
-	1, 77,
-	87, 77,
-	89, 77,
-	91, 77,
-	93, 77,
-	154, 77,
-	-2, 209,
-	-1, 405,
-	1, 79,
-	87, 79,
-	89, 79,
-	91, 79,
-	93, 79,
-	154, 79,
-	-2, 209,
-	-1, 406,
-	1, 143,
-	87, 143,
-	89, 143,
-	91, 143,
-	93, 143,
-	154, 143,
-	-2, 209,
-	-1, 408,
-	1, 145,
-	87, 145,
-	89, 145,
-	91, 145,
-	93, 145,
-	154, 145,
-	-2, 209,
-	-1, 472,
-	93, 1,
-	-2, 195,
-	-1, 479,
-	89, 1,
-	91, 1,
-	93, 1,
-	-2, 195,
-	-1, 546,
-	87, 4,
-	89, 4,
-	91, 4,
-	93, 4,
-	-2, 195,
-	-1, 549,
-	93, 4,
-	-2, 195,
-	-1, 550,
-	93, 4,
-	-2, 195,
-	-1, 618,
-	17, 454,
-	78, 454,
-	160, 454,
-	-2, 83,
-	-1, 643,
-	87, 4,
-	91, 4,
-	93, 4,
-	-2, 195,
-	-1, 648,
-	93, 4,
-	-2, 195,
-	-1, 649,
-	93, 4,
-	-2, 195,
-	-1, 670,
-	87, 1,
-	91, 1,
+	78, 0,
+	79, 0,
+	80, 0,
+	81, 0,
+	191, 0,
+	198, 0,
+	-2, 356,
+	-1, 298,
 	93, 1,
-	-2, 195,
-	-1, 704,
-	1, 91,
-	87, 91,
-	89, 91,
-	91, 91,
-	93, 91,
-	154, 91,
-	-2, 209,
-	-1, 707,
-	93, 6,
-	-2, 195,
-	-1, 718,
-	93, 4,
-	-2, 195,
-	-1, 775,
-	93, 6,
-	-2, 195,
-	-1, 776,
-	93, 6,
-	-2, 195,
-	-1, 780,
+	97, 1,
+	99, 1,
+	-2, 269,
+	-1, 333,
+	202, 431,
+	-2, 574,
+	-1, 334,
+	202, 432,
+	-2, 575,
+	-1, 335,
+	202, 433,
+	-2, 576,
+	-1, 336,
+	202, 434,
+	-2, 577,
+	-1, 383,
+	99, 4,
+	-2, 269,
+	-1, 440,
+	74, 0,
+	78, 0,
+	79, 0,
+	80, 0,
+	81, 0,
+	191, 0,
+	198, 0,
+	-2, 355,
+	-1, 441,
+	74, 0,
+	78, 0,
+	79, 0,
+	80, 0,
+	81, 0,
+	191, 0,
+	198, 0,
+	-2, 357,
+	-1, 448,
+	99, 1,
+	-2, 269,
+	-1, 469,
+	58, 602,
+	-2, 493,
+	-1, 511,
+	1, 81,
+	93, 81,
+	95, 81,
+	97, 81,
+	99, 81,
+	196, 81,
+	-2, 290,
+	-1, 513,
+	1, 83,
+	93, 83,
+	95, 83,
+	97, 83,
+	99, 83,
+	196, 83,
+	-2, 290,
+	-1, 514,
+	1, 182,
+	93, 182,
+	95, 182,
+	97, 182,
+	99, 182,
+	196, 182,
+	-2, 290,
+	-1, 516,
+	1, 184,
+	93, 184,
+	95, 184,
+	97, 184,
+	99, 184,
+	196, 184,
+	-2, 290,
+	-1, 592,
+	99, 1,
+	-2, 269,
+	-1, 599,
+	95, 1,
+	97, 1,
+	99, 1,
+	-2, 269,
+	-1, 689,
+	1, 186,
+	93, 186,
+	95, 186,
+	97, 186,
+	99, 186,
+	196, 186,
+	-2, 290,
+	-1, 691,
+	1, 188,
+	93, 188,
+	95, 188,
+	97, 188,
+	99, 188,
+	196, 188,
+	-2, 290,
+	-1, 702,
 	93, 4,
-	-2, 195,
-	-1, 784,
-	89, 4,
-	91, 4,
+	95, 4,
+	97, 4,
+	99, 4,
+	-2, 269,
+	-1, 705,
+	99, 4,
+	-2, 269,
+	-1, 706,
+	99, 4,
+	-2, 269,
+	-1, 754,
+	84, 268,
+	146, 268,
+	-2, 572,
+	-1, 799,
+	18, 612,
+	27, 612,
+	84, 612,
+	202, 612,
+	-2, 92,
+	-1, 843,
 	93, 4,
-	-2, 195,
-	-1, 804,
-	89, 1,
-	91, 1,
+	97, 4,
+	99, 4,
+	-2, 269,
+	-1, 848,
+	99, 4,
+	-2, 269,
+	-1, 849,
+	99, 4,
+	-2, 269,
+	-1, 873,
 	93, 1,
-	-2, 195,
-	-1, 815,
-	87, 6,
-	89, 6,
-	91, 6,
-	93, 6,
-	-2, 195,
-	-1, 855,
-	87, 6,
-	91, 6,
-	93, 6,
-	-2, 195,
-	-1, 858,
-	93, 8,
-	-2, 195,
-	-1, 863,
-	93, 6,
-	-2, 195,
-	-1, 866,
-	87, 4,
-	91, 4,
-	93, 4,
-	-2, 195,
-	-1, 888,
-	93, 6,
-	-2, 195,
-	-1, 916,
-	93, 6,
-	-2, 195,
-	-1, 920,
-	89, 6,
-	91, 6,
+	97, 1,
+	99, 1,
+	-2, 269,
+	-1, 941,
+	1, 102,
+	93, 102,
+	95, 102,
+	97, 102,
+	99, 102,
+	196, 102,
+	-2, 290,
+	-1, 960,
+	99, 4,
+	-2, 269,
+	-1, 1043,
+	99, 6,
+	-2, 269,
+	-1, 1047,
+	99, 6,
+	-2, 269,
+	-1, 1052,
+	99, 4,
+	-2, 269,
+	-1, 1056,
+	95, 4,
+	97, 4,
+	99, 4,
+	-2, 269,
+	-1, 1079,
+	95, 1,
+	97, 1,
+	99, 1,
+	-2, 269,
+	-1, 1129,
+	99, 6,
+	-2, 269,
+	-1, 1187,
 	93, 6,
-	-2, 195,
-	-1, 922,
-	87, 8,
-	89, 8,
-	91, 8,
-	93, 8,
-	-2, 195,
-	-1, 925,
-	93, 8,
-	-2, 195,
-	-1, 926,
-	93, 8,
-	-2, 195,
-	-1, 929,
-	89, 4,
-	91, 4,
+	95, 6,
+	97, 6,
+	99, 6,
+	-2, 269,
+	-1, 1198,
+	99, 6,
+	-2, 269,
+	-1, 1201,
 	93, 4,
-	-2, 195,
-	-1, 941,
-	87, 8,
-	91, 8,
-	93, 8,
-	-2, 195,
-	-1, 950,
-	87, 6,
-	91, 6,
+	97, 4,
+	99, 4,
+	-2, 269,
+	-1, 1238,
 	93, 6,
-	-2, 195,
-	-1, 955,
-	93, 8,
-	-2, 195,
-	-1, 969,
+	97, 6,
+	99, 6,
+	-2, 269,
+	-1, 1241,
+	99, 8,
+	-2, 269,
+	-1, 1274,
+	99, 6,
+	-2, 269,
+	-1, 1289,
+	95, 4,
+	97, 4,
+	99, 4,
+	-2, 269,
+	-1, 1306,
+	99, 6,
+	-2, 269,
+	-1, 1310,
+	95, 6,
+	97, 6,
+	99, 6,
+	-2, 269,
+	-1, 1312,
 	93, 8,
-	-2, 195,
-	-1, 973,
-	89, 8,
-	91, 8,
+	95, 8,
+	97, 8,
+	99, 8,
+	-2, 269,
+	-1, 1315,
+	99, 8,
+	-2, 269,
+	-1, 1316,
+	99, 8,
+	-2, 269,
+	-1, 1336,
 	93, 8,
-	-2, 195,
-	-1, 985,
-	89, 6,
-	91, 6,
+	97, 8,
+	99, 8,
+	-2, 269,
+	-1, 1353,
 	93, 6,
-	-2, 195,
-	-1, 999,
-	87, 8,
-	91, 8,
+	97, 6,
+	99, 6,
+	-2, 269,
+	-1, 1358,
+	99, 8,
+	-2, 269,
+	-1, 1381,
+	99, 8,
+	-2, 269,
+	-1, 1385,
+	95, 8,
+	97, 8,
+	99, 8,
+	-2, 269,
+	-1, 1400,
+	95, 6,
+	97, 6,
+	99, 6,
+	-2, 269,
+	-1, 1416,
 	93, 8,
-	-2, 195,
-	-1, 1010,
-	89, 8,
-	91, 8,
-	93, 8,
-	-2, 195,
+	97, 8,
+	99, 8,
+	-2, 269,
+	-1, 1427,
+	95, 8,
+	97, 8,
+	99, 8,
+	-2, 269,
 }
 
 const yyPrivate = 57344
 
-const yyLast = 3825
-
-var yyAct = [...]int{
+const yyLast = 7122
 
-	19, 968, 128, 978, 967, 325, 772, 942, 856, 914,
-	483, 915, 771, 779, 644, 871, 836, 316, 521, 125,
-	30, 834, 123, 129, 471, 835, 778, 830, 430, 24,
-	752, 429, 23, 938, 570, 625, 25, 620, 595, 165,
-	188, 86, 166, 167, 370, 170, 171, 172, 174, 176,
-	178, 244, 535, 537, 389, 605, 491, 538, 587, 243,
-	240, 412, 323, 380, 501, 263, 175, 500, 182, 470,
-	186, 431, 320, 585, 1, 626, 375, 369, 251, 261,
-	207, 200, 201, 459, 991, 183, 193, 383, 198, 211,
-	212, 135, 859, 197, 249, 448, 79, 53, 77, 141,
-	197, 518, 198, 812, 185, 197, 94, 197, 217, 218,
-	219, 290, 221, 750, 199, 228, 751, 231, 232, 233,
-	234, 235, 236, 237, 305, 182, 30, 130, 129, 144,
-	242, 73, 700, 438, 680, 24, 425, 3, 23, 198,
-	600, 63, 239, 601, 197, 505, 246, 506, 507, 502,
-	499, 637, 107, 503, 638, 225, 663, 118, 635, 117,
-	116, 185, 287, 288, 119, 120, 883, 634, 619, 598,
-	143, 143, 94, 146, 181, 185, 118, 590, 117, 116,
-	216, 298, 300, 119, 120, 922, 306, 306, 543, 446,
-	379, 220, 505, 367, 506, 507, 502, 499, 310, 176,
-	503, 272, 90, 324, 932, 488, 181, 931, 911, 250,
-	198, 187, 262, 118, 910, 197, 345, 271, 909, 306,
-	119, 120, 908, 349, 136, 351, 132, 176, 306, 133,
-	309, 131, 907, 885, 884, 95, 96, 97, 98, 99,
-	100, 101, 176, 3, 183, 882, 361, 336, 337, 880,
-	879, 870, 441, 869, 851, 504, 71, 71, 30, 314,
-	105, 268, 528, 185, 777, 350, 749, 24, 731, 324,
-	23, 352, 353, 730, 396, 729, 728, 727, 724, 702,
-	226, 599, 699, 402, 404, 407, 409, 130, 679, 662,
-	660, 414, 176, 659, 658, 652, 176, 176, 176, 612,
-	422, 95, 96, 97, 98, 99, 100, 101, 651, 415,
-	633, 631, 354, 419, 420, 421, 176, 347, 105, 346,
-	618, 575, 568, 567, 30, 365, 566, 555, 525, 136,
-	462, 308, 423, 445, 443, 176, 176, 355, 226, 489,
-	399, 387, 382, 302, 303, 176, 390, 435, 881, 468,
-	460, 815, 534, 842, 385, 386, 841, 474, 840, 839,
-	838, 478, 808, 802, 482, 486, 799, 138, 797, 497,
-	487, 418, 395, 796, 790, 3, 444, 789, 30, 572,
-	458, 553, 516, 511, 454, 442, 453, 24, 452, 451,
-	23, 450, 449, 401, 400, 455, 456, 440, 457, 368,
-	241, 215, 185, 214, 138, 466, 204, 203, 202, 209,
-	285, 185, 283, 546, 143, 106, 273, 532, 181, 510,
-	342, 947, 800, 465, 463, 464, 185, 798, 547, 129,
-	678, 548, 476, 498, 185, 676, 185, 666, 863, 848,
-	846, 795, 776, 775, 250, 707, 275, 324, 436, 176,
-	735, 542, 794, 176, 176, 176, 512, 793, 262, 513,
-	315, 554, 495, 666, 493, 334, 335, 524, 576, 792,
-	577, 736, 138, 398, 581, 517, 344, 519, 520, 388,
-	584, 791, 586, 732, 733, 726, 205, 343, 837, 574,
-	527, 529, 30, 206, 397, 3, 926, 185, 274, 30,
-	998, 24, 571, 986, 23, 734, 971, 958, 24, 558,
-	957, 23, 613, 563, 564, 565, 949, 933, 573, 556,
-	927, 921, 918, 594, 284, 865, 282, 862, 276, 277,
-	571, 861, 825, 814, 788, 787, 782, 721, 720, 669,
-	578, 579, 540, 545, 414, 477, 580, 475, 925, 970,
-	94, 917, 436, 969, 969, 916, 955, 597, 607, 649,
-	176, 176, 176, 176, 260, 648, 30, 628, 609, 30,
-	30, 608, 550, 664, 642, 254, 614, 646, 647, 549,
-	90, 781, 610, 671, 473, 780, 916, 596, 472, 888,
-	185, 486, 780, 718, 472, 360, 487, 358, 1001, 952,
-	683, 677, 176, 943, 868, 639, 857, 674, 645, 3,
-	356, 661, 148, 245, 975, 974, 3, 693, 176, 939,
-	653, 654, 655, 657, 656, 832, 596, 94, 701, 831,
-	786, 705, 785, 641, 970, 694, 672, 713, 696, 917,
-	781, 473, 115, 1005, 997, 673, 719, 675, 686, 687,
-	964, 948, 73, 902, 864, 682, 740, 668, 5, 990,
-	937, 681, 684, 30, 147, 691, 829, 583, 30, 30,
-	149, 716, 996, 695, 979, 742, 722, 723, 983, 95,
-	96, 97, 98, 99, 100, 101, 994, 995, 710, 711,
-	30, 715, 709, 760, 150, 979, 493, 1008, 737, 24,
-	993, 982, 23, 981, 665, 571, 71, 589, 94, 269,
-	559, 560, 561, 562, 339, 209, 185, 102, 338, 748,
-	992, 697, 698, 672, 159, 160, 184, 30, 569, 208,
-	860, 509, 185, 755, 756, 757, 763, 762, 30, 801,
-	439, 765, 746, 185, 741, 223, 783, 307, 1003, 222,
-	224, 980, 176, 384, 807, 266, 95, 96, 97, 98,
-	99, 100, 101, 341, 340, 606, 803, 230, 229, 977,
-	816, 129, 980, 817, 818, 821, 540, 712, 596, 962,
-	540, 805, 828, 184, 103, 584, 809, 758, 571, 690,
-	157, 158, 161, 162, 71, 30, 30, 184, 481, 505,
-	30, 506, 507, 811, 30, 689, 826, 3, 827, 822,
-	823, 853, 806, 844, 820, 688, 844, 604, 850, 845,
-	603, 363, 843, 905, 30, 847, 185, 852, 265, 266,
-	267, 592, 593, 24, 873, 30, 23, 95, 96, 97,
-	98, 99, 100, 101, 767, 867, 960, 617, 364, 854,
-	616, 739, 515, 961, 247, 872, 963, 630, 889, 844,
-	874, 875, 876, 877, 629, 897, 636, 394, 878, 904,
-	627, 896, 744, 745, 176, 30, 140, 139, 30, 391,
-	392, 196, 824, 30, 725, 184, 30, 714, 393, 886,
-	64, 906, 708, 706, 903, 923, 129, 901, 924, 390,
-	844, 632, 447, 912, 410, 248, 486, 381, 30, 913,
-	366, 487, 767, 767, 264, 819, 930, 928, 936, 378,
-	294, 584, 919, 151, 153, 934, 289, 152, 91, 897,
-	898, 91, 897, 897, 417, 896, 30, 416, 896, 896,
-	30, 3, 30, 890, 956, 30, 30, 951, 897, 30,
-	935, 90, 767, 966, 896, 621, 622, 623, 624, 192,
-	411, 30, 897, 195, 65, 142, 954, 887, 896, 717,
-	30, 989, 357, 987, 584, 30, 897, 984, 8, 492,
-	897, 7, 896, 6, 965, 359, 896, 60, 321, 30,
-	322, 372, 767, 30, 898, 892, 1004, 898, 898, 1000,
-	767, 371, 1007, 252, 255, 30, 897, 940, 1009, 1002,
-	944, 945, 896, 898, 976, 959, 946, 897, 85, 30,
-	59, 58, 62, 896, 490, 767, 953, 898, 55, 61,
-	30, 56, 743, 184, 591, 485, 94, 484, 54, 194,
-	972, 898, 480, 362, 615, 898, 514, 134, 523, 18,
-	72, 17, 66, 767, 988, 156, 531, 767, 533, 892,
-	373, 254, 892, 892, 505, 15, 506, 507, 502, 499,
-	810, 898, 503, 539, 536, 14, 413, 13, 892, 12,
-	145, 9, 898, 16, 1006, 154, 155, 767, 163, 164,
-	11, 10, 892, 893, 169, 768, 891, 766, 173, 426,
-	177, 424, 179, 180, 94, 4, 892, 189, 2, 0,
-	892, 0, 0, 0, 0, 0, 57, 0, 0, 184,
-	0, 0, 767, 0, 0, 0, 0, 0, 0, 254,
-	0, 0, 0, 0, 0, 0, 892, 0, 0, 0,
-	0, 0, 137, 94, 213, 318, 0, 892, 0, 0,
-	94, 74, 75, 76, 0, 102, 78, 90, 0, 91,
-	92, 0, 68, 0, 0, 95, 96, 97, 256, 257,
-	258, 259, 0, 376, 0, 73, 113, 122, 121, 112,
-	111, 114, 110, 253, 253, 0, 0, 0, 0, 0,
-	270, 253, 374, 0, 0, 94, 0, 0, 278, 279,
-	280, 281, 0, 0, 210, 0, 0, 286, 0, 0,
-	0, 0, 650, 0, 87, 0, 0, 0, 88, 0,
-	254, 505, 103, 506, 507, 502, 499, 753, 754, 503,
-	227, 127, 126, 95, 96, 97, 98, 99, 100, 101,
-	94, 93, 313, 0, 0, 0, 311, 0, 312, 0,
-	317, 0, 0, 327, 0, 0, 108, 107, 0, 0,
-	0, 0, 118, 109, 117, 116, 94, 0, 301, 119,
-	120, 297, 95, 96, 97, 98, 99, 100, 101, 95,
-	96, 97, 98, 99, 100, 101, 105, 0, 329, 82,
-	328, 330, 331, 332, 333, 0, 0, 0, 0, 253,
-	0, 326, 137, 80, 81, 89, 67, 319, 0, 0,
-	0, 253, 0, 0, 0, 253, 0, 0, 0, 327,
-	0, 0, 227, 227, 95, 96, 97, 256, 257, 258,
-	259, 0, 0, 403, 405, 406, 408, 0, 747, 0,
-	227, 0, 0, 0, 0, 253, 227, 227, 0, 94,
-	0, 0, 0, 0, 761, 0, 434, 168, 437, 0,
-	0, 0, 0, 0, 0, 764, 0, 0, 0, 95,
-	96, 97, 98, 99, 100, 101, 0, 377, 0, 0,
-	0, 377, 94, 74, 75, 76, 0, 102, 78, 90,
-	0, 91, 92, 0, 68, 95, 96, 97, 98, 99,
-	100, 101, 0, 0, 0, 0, 0, 73, 0, 0,
-	0, 0, 0, 0, 0, 327, 0, 494, 253, 496,
-	0, 0, 508, 0, 0, 253, 0, 0, 0, 253,
-	253, 0, 0, 0, 0, 0, 0, 0, 0, 522,
-	0, 0, 526, 494, 494, 530, 87, 0, 833, 522,
-	88, 0, 541, 0, 103, 227, 461, 461, 461, 0,
-	0, 0, 0, 127, 126, 113, 122, 121, 112, 111,
-	114, 110, 0, 93, 0, 0, 0, 0, 95, 96,
-	97, 98, 99, 100, 101, 94, 1010, 0, 0, 551,
-	552, 377, 90, 522, 0, 377, 296, 327, 557, 0,
-	137, 0, 137, 137, 113, 122, 121, 112, 111, 114,
-	110, 95, 96, 97, 98, 99, 100, 101, 105, 0,
-	329, 82, 328, 330, 331, 332, 333, 0, 0, 0,
-	0, 0, 94, 326, 0, 80, 81, 89, 67, 0,
-	494, 0, 0, 0, 0, 108, 107, 0, 0, 0,
-	0, 118, 109, 117, 116, 253, 373, 254, 119, 120,
-	611, 94, 74, 75, 76, 0, 102, 78, 90, 0,
-	91, 92, 0, 68, 0, 0, 526, 227, 0, 494,
-	0, 0, 0, 0, 108, 107, 73, 0, 0, 0,
-	118, 109, 117, 116, 0, 640, 0, 119, 120, 295,
-	0, 0, 0, 0, 0, 227, 71, 0, 0, 0,
-	0, 0, 0, 0, 95, 96, 97, 98, 99, 100,
-	101, 377, 0, 0, 0, 87, 0, 0, 0, 88,
-	0, 0, 0, 103, 0, 0, 0, 0, 0, 0,
-	0, 327, 127, 126, 0, 0, 0, 0, 0, 494,
-	0, 0, 93, 685, 253, 253, 0, 0, 0, 0,
-	0, 95, 96, 97, 256, 257, 258, 259, 0, 376,
-	522, 0, 0, 0, 494, 494, 0, 0, 0, 0,
-	703, 704, 0, 0, 0, 0, 227, 0, 374, 0,
-	95, 96, 97, 98, 99, 100, 101, 105, 0, 329,
-	82, 328, 330, 331, 332, 333, 113, 122, 121, 112,
-	111, 114, 110, 0, 80, 81, 89, 67, 0, 0,
-	377, 377, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 494, 0, 0, 0, 0, 0, 0, 0, 253,
-	253, 253, 0, 759, 0, 0, 0, 0, 0, 0,
-	0, 526, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 113, 122, 121, 112, 111, 114, 110, 0, 0,
+var yyAct = [...]int16{
+	23, 1380, 1337, 1366, 1409, 1341, 1379, 763, 1304, 1364,
+	1305, 1239, 1362, 314, 98, 1333, 1266, 607, 160, 1051,
+	1172, 1223, 818, 1111, 844, 1125, 154, 161, 1088, 1144,
+	1207, 1050, 1013, 1406, 242, 635, 992, 901, 591, 815,
+	400, 545, 28, 1146, 651, 210, 72, 810, 662, 304,
+	215, 216, 678, 219, 220, 221, 223, 225, 227, 1145,
+	749, 611, 889, 826, 466, 681, 801, 544, 27, 655,
+	781, 680, 490, 468, 312, 756, 403, 235, 225, 746,
+	240, 183, 183, 1138, 188, 303, 522, 525, 328, 745,
+	1, 253, 254, 628, 627, 463, 460, 432, 169, 475,
+	590, 266, 267, 816, 365, 453, 454, 576, 262, 1242,
+	395, 173, 89, 87, 646, 361, 250, 632, 252, 633,
+	634, 629, 626, 181, 480, 630, 241, 384, 1040, 251,
+	1124, 553, 1300, 1041, 62, 546, 250, 274, 275, 276,
+	277, 1228, 279, 1007, 162, 287, 288, 939, 291, 292,
+	293, 294, 295, 296, 297, 390, 235, 936, 284, 184,
+	161, 562, 433, 892, 540, 3, 832, 382, 250, 251,
+	822, 833, 830, 469, 137, 823, 250, 28, 302, 149,
+	829, 148, 147, 315, 800, 755, 136, 385, 150, 151,
+	137, 697, 300, 306, 695, 149, 617, 148, 147, 251,
+	1107, 614, 136, 27, 150, 151, 250, 357, 358, 560,
+	530, 479, 224, 149, 337, 148, 147, 624, 625, 322,
+	136, 733, 150, 151, 733, 273, 136, 232, 234, 102,
+	760, 1398, 236, 239, 376, 378, 1327, 632, 170, 633,
+	634, 629, 626, 385, 134, 630, 234, 168, 225, 278,
+	1349, 251, 225, 149, 1324, 1323, 404, 225, 250, 1010,
+	136, 385, 150, 151, 1302, 385, 631, 1299, 1294, 1293,
+	1258, 428, 429, 430, 1257, 419, 420, 1256, 388, 1255,
+	327, 438, 1254, 440, 441, 1253, 225, 232, 1250, 1236,
+	387, 285, 170, 1233, 164, 1227, 439, 165, 134, 163,
+	3, 168, 225, 1221, 442, 443, 451, 1219, 1217, 1216,
+	1206, 299, 1185, 1171, 1170, 1116, 1106, 405, 1105, 367,
+	1077, 1061, 1049, 1048, 1039, 1029, 1028, 1020, 997, 984,
+	983, 973, 972, 971, 970, 969, 967, 624, 625, 501,
+	28, 938, 935, 930, 375, 285, 162, 863, 638, 861,
+	860, 859, 510, 512, 515, 517, 852, 828, 825, 806,
+	799, 798, 527, 225, 726, 638, 27, 725, 416, 417,
+	418, 724, 225, 225, 225, 434, 723, 711, 537, 974,
+	427, 792, 732, 694, 183, 709, 663, 570, 444, 559,
+	579, 436, 435, 917, 359, 557, 225, 555, 504, 464,
+	492, 491, 487, 445, 256, 761, 1312, 380, 381, 461,
+	788, 465, 249, 661, 1234, 1232, 1231, 225, 225, 225,
+	550, 677, 172, 1220, 1218, 1350, 1153, 1152, 225, 1151,
+	484, 1150, 551, 1149, 486, 166, 575, 577, 588, 1148,
+	389, 236, 482, 483, 394, 1143, 1113, 594, 1100, 415,
+	1096, 598, 1076, 1073, 1071, 1070, 602, 603, 1063, 610,
+	1009, 500, 1008, 3, 932, 928, 850, 538, 623, 834,
+	533, 796, 795, 790, 778, 777, 172, 729, 643, 642,
+	569, 568, 567, 566, 565, 404, 564, 563, 506, 505,
+	28, 556, 499, 644, 315, 574, 247, 301, 272, 271,
+	673, 270, 269, 172, 259, 258, 257, 256, 255, 264,
+	688, 519, 893, 354, 352, 338, 27, 1187, 528, 690,
+	692, 710, 702, 135, 234, 649, 840, 534, 535, 536,
+	1045, 580, 581, 946, 582, 425, 615, 827, 596, 758,
+	759, 196, 103, 703, 161, 1109, 405, 809, 30, 687,
+	156, 36, 683, 663, 803, 693, 177, 509, 600, 612,
+	601, 404, 704, 225, 178, 797, 1235, 551, 225, 225,
+	225, 653, 503, 248, 493, 489, 488, 360, 1112, 645,
+	890, 647, 648, 321, 1225, 1181, 1167, 736, 558, 1408,
+	737, 728, 315, 654, 741, 638, 1389, 247, 660, 664,
+	744, 712, 1319, 1363, 1320, 752, 1074, 750, 675, 571,
+	572, 573, 1162, 3, 978, 1080, 1082, 989, 1072, 883,
+	583, 991, 405, 260, 995, 885, 632, 879, 633, 634,
+	261, 667, 670, 1069, 28, 700, 866, 426, 979, 1388,
+	976, 28, 1198, 793, 794, 1159, 804, 805, 641, 632,
+	1129, 633, 634, 629, 626, 1014, 1015, 630, 866, 1047,
+	27, 652, 751, 1043, 977, 753, 518, 27, 353, 351,
+	433, 1166, 787, 715, 716, 717, 718, 719, 1081, 988,
+	767, 882, 740, 699, 1046, 1157, 36, 947, 835, 340,
+	769, 1068, 739, 1390, 179, 1067, 204, 205, 527, 1391,
+	783, 1066, 1065, 1064, 1147, 786, 464, 757, 197, 975,
+	968, 766, 605, 770, 461, 731, 225, 225, 225, 225,
+	225, 457, 320, 1012, 785, 784, 624, 625, 502, 1415,
+	864, 1401, 1383, 851, 1361, 610, 610, 308, 309, 310,
+	146, 1360, 862, 874, 319, 730, 339, 1352, 765, 624,
+	625, 1328, 868, 869, 1311, 714, 610, 3, 455, 456,
+	720, 721, 722, 1308, 3, 1287, 807, 202, 203, 206,
+	207, 1244, 1200, 884, 887, 315, 900, 903, 907, 341,
+	342, 1197, 837, 606, 888, 838, 842, 1186, 1133, 846,
+	847, 918, 771, 1060, 875, 857, 225, 1059, 1054, 82,
+	963, 962, 872, 819, 738, 701, 1297, 597, 595, 1316,
+	457, 1382, 1315, 880, 849, 1381, 1307, 1053, 937, 848,
+	1306, 1052, 942, 706, 225, 916, 705, 878, 1381, 1358,
+	894, 876, 953, 886, 896, 185, 1306, 1274, 891, 263,
+	199, 200, 895, 208, 209, 819, 961, 1052, 593, 36,
+	960, 218, 592, 592, 450, 222, 914, 226, 448, 228,
+	230, 233, 1261, 1418, 1355, 1338, 1240, 1203, 610, 926,
+	925, 1090, 924, 966, 102, 877, 683, 952, 987, 845,
+	683, 446, 305, 1387, 1386, 982, 955, 1334, 1140, 1139,
+	1058, 404, 948, 915, 1001, 949, 819, 1057, 1004, 841,
+	1000, 950, 951, 1382, 1307, 268, 1053, 190, 853, 854,
+	855, 856, 858, 593, 1423, 28, 1414, 1376, 1351, 1247,
+	1026, 1199, 985, 871, 875, 1405, 1332, 958, 1137, 1032,
+	743, 998, 964, 965, 36, 1407, 1396, 1367, 1367, 956,
+	1371, 27, 990, 1394, 1395, 1420, 898, 1393, 933, 934,
+	996, 921, 405, 909, 910, 1370, 999, 1369, 865, 1003,
+	1002, 232, 1038, 986, 189, 1027, 1290, 1141, 1030, 1084,
+	193, 748, 396, 485, 129, 323, 1037, 324, 325, 1034,
+	330, 1075, 927, 1036, 264, 819, 343, 344, 1344, 345,
+	346, 347, 348, 349, 350, 1344, 422, 194, 281, 36,
+	421, 356, 280, 282, 283, 1397, 1091, 1243, 903, 225,
+	225, 363, 364, 366, 366, 1099, 943, 1410, 1365, 1392,
+	1368, 1368, 1021, 232, 232, 1078, 232, 765, 1224, 404,
+	727, 191, 1083, 1177, 192, 1176, 554, 1035, 3, 481,
+	529, 386, 225, 317, 1055, 1093, 467, 130, 650, 1101,
+	392, 1062, 397, 899, 1136, 407, 1104, 744, 772, 1118,
+	1347, 507, 1086, 1131, 782, 1087, 1251, 1342, 1343, 1110,
+	1019, 1345, 913, 1006, 819, 1343, 424, 423, 1345, 290,
+	289, 912, 911, 1134, 456, 1016, 1017, 1018, 768, 632,
+	405, 633, 634, 1169, 316, 317, 318, 1174, 1163, 315,
+	780, 1156, 779, 1179, 758, 759, 1209, 1161, 867, 330,
+	330, 330, 776, 330, 1155, 735, 734, 1155, 1165, 458,
+	1168, 28, 775, 1188, 161, 981, 494, 1190, 1193, 622,
+	1154, 307, 1208, 1158, 821, 820, 1135, 811, 812, 813,
+	814, 1180, 1189, 36, 831, 1195, 1097, 27, 1164, 817,
+	36, 511, 513, 514, 516, 1204, 315, 993, 994, 326,
+	524, 1192, 498, 1202, 1094, 1095, 1205, 73, 214, 213,
+	330, 212, 211, 1191, 495, 496, 1230, 176, 1210, 1211,
+	1212, 1213, 1214, 497, 549, 180, 552, 1196, 1132, 1128,
+	1115, 330, 954, 1103, 945, 929, 491, 1155, 1226, 923,
+	808, 561, 1413, 1237, 1249, 1178, 195, 198, 1120, 520,
+	225, 459, 1120, 1215, 1246, 391, 311, 1182, 1322, 1295,
+	467, 1321, 1296, 613, 313, 1262, 1222, 836, 616, 1174,
+	370, 249, 1268, 103, 1117, 1270, 532, 531, 355, 102,
+	246, 1275, 1245, 1194, 3, 957, 587, 1263, 1259, 1264,
+	521, 175, 610, 36, 1272, 1269, 36, 36, 407, 330,
+	1271, 74, 330, 182, 1155, 619, 225, 1283, 1288, 1292,
+	636, 1357, 639, 1273, 330, 1276, 959, 447, 819, 1089,
+	1260, 1313, 161, 10, 407, 1248, 9, 764, 366, 657,
+	1309, 8, 7, 366, 1120, 666, 669, 669, 671, 672,
+	1314, 1268, 6, 366, 1317, 315, 684, 685, 1331, 449,
+	69, 744, 401, 402, 1329, 471, 1022, 1325, 689, 691,
+	1267, 472, 1330, 470, 696, 329, 1346, 332, 1318, 1348,
+	366, 236, 97, 68, 67, 71, 64, 70, 1283, 1359,
+	65, 1283, 1283, 609, 608, 1354, 1335, 63, 174, 1339,
+	1340, 604, 1120, 707, 708, 66, 1378, 1373, 452, 1372,
+	407, 713, 1283, 1120, 1374, 1252, 774, 819, 1173, 1377,
+	1356, 902, 1282, 1375, 621, 167, 22, 1284, 21, 75,
+	29, 201, 19, 1404, 1283, 171, 744, 1402, 1399, 682,
+	679, 18, 1384, 523, 36, 526, 1411, 686, 508, 36,
+	36, 1411, 1412, 1120, 17, 16, 1278, 1283, 15, 1417,
+	14, 1283, 656, 669, 330, 1403, 330, 330, 330, 1425,
+	773, 1298, 1419, 802, 36, 1421, 1426, 11, 1422, 20,
+	13, 330, 12, 1279, 1121, 1277, 1119, 789, 541, 1120,
+	791, 231, 1283, 1282, 539, 4, 1282, 1282, 1284, 243,
+	1424, 1284, 1284, 1283, 2, 265, 765, 231, 0, 0,
+	0, 366, 0, 0, 0, 666, 0, 1282, 669, 0,
+	0, 1120, 1284, 0, 0, 1120, 0, 1278, 0, 0,
+	1278, 1278, 0, 0, 0, 819, 0, 0, 0, 1282,
+	0, 0, 0, 0, 1284, 524, 0, 0, 839, 286,
+	0, 1278, 0, 0, 0, 0, 0, 0, 0, 5,
+	669, 36, 1282, 0, 0, 0, 1282, 1284, 1120, 0,
+	0, 1284, 0, 1278, 286, 0, 0, 0, 0, 0,
+	372, 0, 0, 0, 407, 407, 231, 0, 143, 153,
+	152, 142, 141, 144, 145, 140, 1278, 1282, 0, 0,
+	1278, 0, 1284, 231, 0, 407, 0, 0, 1282, 0,
+	0, 669, 0, 1284, 0, 1120, 0, 0, 330, 0,
+	229, 0, 330, 0, 0, 0, 0, 0, 908, 330,
+	330, 1278, 0, 0, 0, 0, 237, 171, 366, 0,
+	0, 0, 1278, 0, 36, 0, 0, 0, 36, 0,
+	657, 0, 0, 36, 0, 0, 0, 36, 0, 0,
+	0, 231, 0, 669, 669, 0, 286, 286, 0, 0,
+	940, 941, 0, 632, 944, 633, 634, 629, 626, 1102,
+	36, 630, 0, 0, 366, 0, 143, 286, 0, 142,
+	141, 144, 145, 140, 0, 286, 286, 0, 0, 0,
+	669, 0, 0, 0, 0, 138, 137, 0, 0, 0,
+	0, 149, 139, 148, 147, 237, 231, 407, 136, 478,
+	150, 151, 371, 632, 0, 633, 634, 629, 626, 1092,
+	36, 630, 237, 632, 0, 633, 634, 629, 626, 1005,
+	407, 630, 669, 0, 0, 0, 0, 0, 0, 330,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 330, 330, 330, 0, 0, 0, 366, 0, 1025,
+	0, 0, 0, 624, 625, 0, 0, 0, 0, 0,
+	0, 0, 366, 0, 0, 0, 666, 0, 36, 669,
+	374, 0, 0, 0, 0, 0, 0, 1044, 0, 36,
+	0, 0, 36, 138, 137, 0, 0, 0, 0, 149,
+	139, 148, 147, 0, 0, 0, 136, 0, 150, 151,
+	0, 0, 0, 624, 625, 0, 0, 286, 578, 578,
+	578, 0, 0, 624, 625, 0, 0, 0, 0, 36,
+	0, 0, 36, 0, 0, 237, 143, 153, 152, 142,
+	141, 144, 145, 140, 0, 0, 0, 0, 0, 0,
+	0, 669, 1098, 0, 0, 0, 0, 0, 478, 330,
+	0, 0, 0, 0, 0, 36, 0, 0, 407, 0,
+	478, 0, 0, 0, 0, 286, 171, 0, 171, 171,
+	36, 0, 0, 0, 0, 0, 1130, 0, 0, 0,
+	0, 0, 0, 0, 0, 231, 0, 36, 0, 0,
+	0, 36, 0, 36, 0, 0, 36, 36, 0, 0,
+	0, 231, 0, 231, 0, 0, 632, 0, 633, 634,
+	629, 626, 897, 231, 630, 231, 0, 36, 0, 0,
+	0, 0, 143, 153, 152, 142, 141, 144, 145, 140,
+	366, 0, 0, 0, 36, 0, 0, 0, 0, 36,
+	0, 0, 366, 138, 137, 0, 0, 0, 0, 149,
+	139, 148, 147, 0, 0, 379, 136, 0, 150, 151,
+	1265, 0, 36, 286, 0, 0, 36, 0, 0, 0,
+	0, 143, 153, 669, 142, 141, 144, 145, 140, 0,
+	0, 36, 0, 0, 0, 0, 0, 0, 231, 0,
+	0, 0, 0, 0, 0, 0, 0, 36, 0, 0,
+	0, 0, 478, 0, 478, 0, 624, 625, 36, 0,
+	0, 0, 0, 0, 237, 0, 0, 478, 0, 0,
+	0, 0, 0, 231, 0, 0, 0, 0, 0, 0,
+	658, 0, 659, 0, 0, 0, 0, 0, 0, 138,
+	137, 0, 674, 0, 676, 149, 139, 148, 147, 0,
+	0, 379, 136, 0, 150, 151, 373, 0, 0, 0,
+	0, 0, 669, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 1285, 1286, 0, 0, 0, 0, 0, 0,
+	0, 407, 0, 0, 0, 0, 0, 0, 138, 137,
+	0, 0, 0, 0, 149, 139, 148, 147, 0, 0,
+	0, 136, 0, 150, 151, 0, 0, 0, 0, 106,
+	84, 85, 86, 286, 129, 88, 102, 237, 103, 104,
+	24, 78, 0, 0, 0, 0, 38, 39, 0, 0,
+	0, 1326, 0, 0, 0, 83, 0, 32, 49, 0,
+	33, 0, 132, 133, 0, 0, 286, 0, 0, 0,
+	0, 669, 762, 0, 478, 0, 0, 0, 478, 0,
+	0, 93, 119, 0, 0, 478, 478, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 99, 0,
+	669, 0, 100, 0, 0, 0, 0, 130, 0, 31,
+	0, 0, 0, 0, 0, 0, 1281, 1280, 0, 1126,
+	0, 0, 0, 0, 0, 35, 105, 231, 42, 40,
+	41, 37, 43, 0, 0, 0, 0, 0, 0, 231,
+	45, 46, 47, 48, 128, 547, 548, 0, 52, 53,
+	54, 55, 44, 57, 58, 59, 50, 56, 61, 0,
+	0, 0, 1127, 0, 0, 34, 51, 60, 107, 112,
+	113, 114, 108, 109, 110, 111, 115, 116, 117, 118,
+	134, 0, 0, 0, 0, 0, 0, 120, 81, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 121,
+	122, 123, 0, 124, 125, 478, 126, 127, 96, 95,
+	92, 94, 131, 0, 0, 0, 0, 478, 478, 478,
+	0, 0, 0, 0, 90, 91, 101, 76, 0, 77,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 231, 922, 0, 0, 0,
+	231, 0, 0, 0, 0, 0, 0, 0, 931, 0,
+	0, 0, 0, 0, 0, 0, 231, 0, 0, 0,
+	0, 106, 84, 85, 86, 0, 129, 88, 102, 0,
+	103, 104, 0, 78, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 83, 0, 0,
+	0, 0, 0, 0, 132, 133, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 478, 0, 0, 231, 0,
+	0, 0, 0, 93, 119, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 881,
+	99, 0, 0, 0, 100, 0, 0, 0, 0, 130,
+	0, 0, 0, 0, 0, 0, 0, 0, 159, 157,
+	143, 153, 152, 142, 141, 144, 145, 140, 105, 0,
+	0, 0, 0, 0, 1031, 0, 750, 0, 0, 1033,
+	286, 0, 231, 0, 0, 0, 128, 0, 0, 0,
+	0, 0, 0, 0, 0, 1042, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	107, 112, 113, 114, 108, 109, 110, 111, 115, 116,
+	117, 118, 134, 0, 0, 0, 0, 0, 0, 120,
+	158, 751, 0, 0, 231, 0, 231, 286, 0, 0,
+	0, 121, 122, 123, 0, 124, 125, 1085, 126, 127,
+	410, 409, 92, 408, 411, 412, 413, 414, 0, 0,
+	0, 0, 0, 0, 406, 0, 90, 91, 101, 76,
+	399, 77, 0, 0, 0, 0, 0, 138, 137, 0,
+	0, 0, 0, 149, 139, 148, 147, 0, 0, 0,
+	136, 0, 150, 151, 0, 0, 231, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 1142, 0, 0, 0, 106, 84, 85, 86, 0,
+	129, 88, 102, 0, 103, 104, 24, 78, 0, 0,
+	0, 0, 38, 39, 0, 0, 0, 0, 0, 0,
+	0, 83, 0, 32, 49, 0, 33, 0, 132, 133,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 1183, 0, 1184, 0, 93, 119, 0,
+	0, 231, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 99, 0, 286, 0, 100, 0,
+	0, 0, 0, 130, 0, 31, 0, 0, 0, 0,
+	0, 0, 543, 542, 0, 79, 0, 0, 0, 0,
+	0, 35, 105, 0, 42, 40, 41, 37, 43, 0,
+	0, 0, 0, 0, 0, 237, 45, 46, 47, 48,
+	128, 547, 548, 80, 52, 53, 54, 55, 44, 57,
+	58, 59, 50, 56, 61, 286, 0, 0, 0, 0,
+	0, 34, 51, 60, 107, 112, 113, 114, 108, 109,
+	110, 111, 115, 116, 117, 118, 134, 0, 0, 0,
+	0, 0, 0, 120, 81, 143, 153, 152, 142, 141,
+	144, 145, 140, 0, 0, 121, 122, 123, 0, 124,
+	125, 0, 126, 127, 96, 95, 92, 94, 131, 0,
+	1291, 0, 0, 0, 0, 0, 0, 0, 0, 286,
+	90, 91, 101, 76, 0, 77, 106, 84, 85, 86,
+	0, 129, 88, 102, 0, 103, 104, 24, 78, 0,
+	0, 0, 0, 38, 39, 0, 0, 0, 0, 0,
+	0, 0, 83, 0, 32, 49, 0, 33, 0, 132,
+	133, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 93, 119,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 99, 0, 0, 0, 100,
+	0, 0, 138, 137, 130, 0, 31, 0, 149, 139,
+	148, 147, 0, 1123, 1122, 136, 1126, 150, 151, 980,
+	0, 0, 35, 105, 0, 42, 40, 41, 37, 43,
+	0, 0, 0, 0, 0, 0, 0, 45, 46, 47,
+	48, 128, 0, 0, 0, 52, 53, 54, 55, 44,
+	57, 58, 59, 50, 56, 61, 0, 0, 0, 1127,
+	0, 0, 34, 51, 60, 107, 112, 113, 114, 108,
+	109, 110, 111, 115, 116, 117, 118, 134, 0, 0,
+	0, 0, 0, 0, 120, 81, 143, 153, 152, 142,
+	141, 144, 145, 140, 0, 0, 121, 122, 123, 0,
+	124, 125, 0, 126, 127, 96, 95, 92, 94, 131,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 90, 91, 101, 76, 0, 77, 106, 84, 85,
+	86, 0, 129, 88, 102, 0, 103, 104, 24, 78,
+	0, 0, 0, 0, 38, 39, 0, 0, 0, 0,
+	0, 0, 0, 83, 0, 32, 49, 0, 33, 0,
+	132, 133, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 93,
+	119, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 99, 0, 0, 0,
+	100, 0, 0, 138, 137, 130, 0, 31, 0, 149,
+	139, 148, 147, 0, 26, 25, 136, 79, 150, 151,
+	919, 0, 0, 35, 105, 0, 42, 40, 41, 37,
+	43, 0, 0, 0, 0, 0, 0, 0, 45, 46,
+	47, 48, 128, 0, 0, 80, 52, 53, 54, 55,
+	44, 57, 58, 59, 50, 56, 61, 0, 0, 0,
+	0, 0, 0, 34, 51, 60, 107, 112, 113, 114,
+	108, 109, 110, 111, 115, 116, 117, 118, 134, 0,
+	0, 0, 0, 0, 0, 120, 81, 0, 0, 0,
+	0, 1023, 0, 0, 0, 0, 0, 121, 122, 123,
+	0, 124, 125, 0, 126, 127, 96, 95, 92, 94,
+	131, 0, 143, 153, 152, 142, 141, 144, 145, 140,
+	0, 0, 90, 91, 101, 76, 0, 77, 106, 84,
+	85, 86, 0, 129, 88, 102, 0, 103, 104, 0,
+	78, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 83, 0, 0, 0, 0, 0,
+	0, 132, 133, 0, 0, 0, 0, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	227, 0, 0, 0, 0, 0, 108, 107, 0, 0,
-	0, 0, 118, 109, 117, 116, 0, 0, 0, 119,
-	120, 738, 0, 0, 0, 377, 377, 377, 0, 253,
-	94, 74, 75, 76, 0, 102, 78, 90, 0, 91,
-	92, 20, 68, 0, 0, 0, 32, 33, 0, 0,
-	0, 0, 0, 0, 0, 73, 0, 26, 41, 0,
-	27, 108, 107, 0, 0, 0, 0, 118, 109, 117,
-	116, 0, 0, 0, 119, 120, 692, 0, 0, 522,
-	0, 0, 0, 227, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 87, 377, 0, 0, 88, 0,
-	0, 0, 103, 0, 71, 0, 0, 0, 0, 0,
-	0, 895, 894, 0, 773, 0, 0, 0, 0, 0,
-	29, 93, 0, 36, 34, 35, 31, 37, 0, 0,
-	899, 900, 0, 0, 0, 39, 40, 432, 433, 0,
-	44, 45, 46, 47, 38, 49, 50, 51, 42, 48,
-	52, 0, 0, 0, 774, 0, 0, 28, 43, 95,
-	96, 97, 98, 99, 100, 101, 105, 0, 84, 82,
-	83, 104, 0, 0, 0, 0, 327, 0, 0, 0,
-	0, 0, 0, 80, 81, 89, 67, 94, 74, 75,
-	76, 0, 102, 78, 90, 0, 91, 92, 20, 68,
-	0, 0, 0, 32, 33, 0, 0, 0, 0, 0,
-	0, 0, 73, 0, 26, 41, 0, 27, 0, 0,
+	93, 119, 0, 1024, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 99, 0, 0,
+	0, 100, 0, 0, 0, 0, 130, 0, 0, 0,
+	0, 0, 0, 0, 0, 159, 157, 0, 0, 0,
+	0, 0, 0, 0, 0, 105, 0, 0, 0, 138,
+	137, 0, 0, 0, 0, 149, 139, 148, 147, 0,
+	0, 0, 136, 128, 150, 151, 0, 0, 0, 0,
+	0, 0, 0, 143, 153, 152, 142, 141, 144, 145,
+	140, 0, 0, 0, 0, 0, 0, 107, 112, 113,
+	114, 108, 109, 110, 111, 115, 116, 117, 118, 134,
+	0, 0, 0, 0, 0, 0, 120, 158, 143, 153,
+	152, 142, 141, 144, 145, 140, 0, 0, 121, 122,
+	123, 0, 124, 125, 750, 126, 127, 410, 409, 92,
+	408, 411, 412, 413, 414, 0, 0, 0, 0, 0,
+	0, 406, 0, 90, 91, 101, 76, 0, 77, 106,
+	84, 85, 86, 0, 129, 88, 102, 0, 103, 104,
+	0, 78, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 83, 0, 0, 0, 751,
+	0, 0, 132, 133, 0, 0, 0, 0, 0, 0,
+	138, 137, 0, 0, 0, 0, 149, 139, 148, 147,
+	0, 93, 119, 136, 0, 150, 151, 824, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 99, 0,
+	0, 0, 100, 0, 0, 138, 137, 130, 747, 0,
+	0, 149, 139, 148, 147, 0, 159, 157, 136, 0,
+	150, 151, 0, 0, 0, 0, 105, 0, 0, 143,
+	153, 152, 142, 141, 144, 145, 140, 0, 0, 748,
+	0, 0, 0, 0, 128, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 143, 153, 152, 142, 141, 144,
+	145, 140, 0, 0, 0, 0, 0, 0, 107, 112,
+	113, 114, 108, 109, 110, 111, 115, 116, 117, 118,
+	134, 0, 0, 0, 0, 0, 0, 120, 158, 143,
+	153, 152, 142, 141, 144, 145, 140, 0, 0, 121,
+	122, 123, 0, 124, 125, 0, 126, 127, 410, 409,
+	92, 408, 411, 412, 413, 414, 143, 153, 152, 142,
+	141, 144, 145, 140, 90, 91, 101, 76, 0, 77,
+	106, 84, 85, 86, 0, 129, 88, 102, 1427, 103,
+	104, 0, 78, 0, 0, 0, 138, 137, 0, 0,
+	0, 0, 149, 139, 148, 147, 83, 0, 0, 136,
+	0, 150, 151, 132, 133, 0, 0, 0, 0, 0,
+	0, 138, 137, 0, 0, 0, 0, 149, 139, 148,
+	147, 0, 93, 119, 136, 0, 150, 151, 586, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 99,
+	0, 0, 0, 100, 0, 0, 138, 137, 130, 0,
+	232, 0, 149, 139, 148, 147, 0, 159, 157, 136,
+	0, 150, 151, 373, 0, 0, 0, 105, 0, 0,
+	0, 0, 0, 138, 137, 0, 0, 0, 0, 149,
+	139, 148, 147, 0, 1301, 128, 136, 0, 150, 151,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 107,
+	112, 113, 114, 108, 109, 110, 111, 115, 116, 117,
+	118, 134, 0, 0, 0, 0, 0, 0, 120, 158,
+	143, 153, 152, 142, 141, 144, 145, 140, 0, 0,
+	121, 122, 123, 0, 124, 125, 0, 126, 127, 96,
+	95, 92, 94, 131, 0, 0, 0, 143, 153, 152,
+	142, 141, 144, 145, 140, 90, 91, 101, 76, 1229,
+	77, 106, 84, 85, 86, 0, 129, 88, 102, 1416,
+	103, 104, 0, 78, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 83, 0, 0,
+	0, 0, 0, 0, 132, 133, 0, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 904, 905, 906, 119, 0, 0, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 87, 0, 0, 0, 88, 0, 0, 0, 103,
-	0, 71, 0, 0, 0, 0, 0, 0, 428, 427,
-	0, 69, 0, 0, 0, 0, 0, 29, 93, 0,
-	36, 34, 35, 31, 37, 0, 0, 0, 0, 0,
-	0, 0, 39, 40, 432, 433, 70, 44, 45, 46,
-	47, 38, 49, 50, 51, 42, 48, 52, 0, 0,
-	0, 0, 0, 0, 28, 43, 95, 96, 97, 98,
-	99, 100, 101, 105, 0, 84, 82, 83, 104, 0,
+	99, 0, 0, 0, 100, 0, 0, 138, 137, 130,
+	0, 0, 0, 149, 139, 148, 147, 0, 159, 157,
+	136, 0, 150, 151, 0, 0, 0, 0, 105, 0,
+	0, 0, 0, 0, 138, 137, 0, 0, 0, 0,
+	149, 139, 148, 147, 0, 0, 128, 136, 0, 150,
+	151, 0, 0, 0, 0, 0, 0, 143, 153, 152,
+	142, 141, 144, 145, 140, 0, 0, 0, 0, 0,
+	107, 112, 113, 114, 108, 109, 110, 111, 115, 116,
+	117, 118, 134, 0, 0, 0, 0, 0, 0, 120,
+	158, 143, 153, 152, 142, 141, 144, 145, 140, 0,
+	0, 121, 122, 123, 0, 124, 125, 0, 126, 127,
+	96, 95, 92, 94, 131, 1241, 0, 0, 143, 153,
+	152, 142, 141, 144, 145, 140, 90, 91, 101, 76,
+	0, 77, 106, 84, 85, 86, 0, 129, 88, 102,
+	1400, 103, 104, 0, 78, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 83, 0,
+	0, 0, 0, 0, 0, 132, 133, 0, 0, 0,
+	0, 0, 0, 0, 138, 137, 0, 0, 0, 0,
+	149, 139, 148, 147, 93, 119, 1303, 136, 0, 150,
+	151, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 99, 0, 0, 0, 100, 0, 0, 138, 137,
+	130, 0, 0, 0, 149, 139, 148, 147, 0, 159,
+	157, 136, 0, 150, 151, 0, 0, 0, 245, 105,
+	0, 0, 0, 0, 0, 138, 137, 0, 0, 0,
+	0, 149, 139, 148, 147, 0, 698, 128, 136, 0,
+	150, 151, 0, 0, 0, 0, 0, 0, 143, 153,
+	152, 142, 141, 144, 145, 140, 0, 0, 244, 0,
+	0, 107, 112, 113, 114, 108, 109, 110, 111, 115,
+	116, 117, 118, 134, 0, 0, 0, 0, 0, 0,
+	120, 158, 143, 153, 152, 142, 141, 144, 145, 140,
+	0, 0, 121, 122, 123, 0, 124, 125, 0, 126,
+	127, 96, 95, 92, 94, 131, 0, 0, 0, 143,
+	153, 152, 142, 141, 144, 145, 140, 90, 91, 101,
+	76, 0, 77, 106, 84, 85, 86, 0, 129, 88,
+	102, 1385, 103, 104, 0, 78, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 83,
+	0, 0, 0, 0, 0, 0, 132, 133, 0, 0,
+	0, 0, 0, 0, 0, 138, 137, 0, 0, 0,
+	0, 149, 139, 148, 147, 93, 119, 1160, 136, 0,
+	150, 151, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 99, 0, 0, 0, 100, 0, 0, 138,
+	137, 130, 0, 0, 0, 149, 139, 148, 147, 0,
+	159, 157, 136, 0, 150, 151, 0, 0, 0, 0,
+	105, 0, 0, 0, 0, 0, 138, 137, 0, 0,
+	0, 0, 149, 139, 148, 147, 0, 0, 128, 136,
+	0, 150, 151, 143, 153, 152, 142, 141, 144, 145,
+	140, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 107, 112, 113, 114, 108, 109, 110, 111,
+	115, 116, 117, 118, 134, 0, 0, 0, 0, 0,
+	0, 120, 158, 0, 143, 153, 152, 142, 141, 144,
+	145, 140, 0, 121, 122, 123, 0, 124, 125, 0,
+	126, 127, 96, 95, 92, 94, 131, 0, 0, 0,
+	0, 0, 1011, 0, 0, 0, 0, 0, 90, 91,
+	101, 76, 0, 77, 238, 106, 84, 85, 86, 0,
+	129, 88, 102, 0, 103, 104, 0, 78, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	80, 81, 89, 67, 94, 74, 75, 76, 0, 102,
-	78, 90, 0, 91, 92, 20, 68, 0, 0, 0,
-	32, 33, 0, 0, 0, 0, 0, 0, 0, 73,
-	0, 26, 41, 0, 27, 0, 0, 0, 0, 0,
+	0, 83, 0, 0, 0, 0, 0, 0, 132, 133,
+	138, 137, 0, 0, 0, 0, 149, 139, 148, 147,
+	0, 0, 1114, 136, 0, 150, 151, 93, 119, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 99, 0, 0, 0, 100, 0,
+	0, 138, 137, 130, 0, 0, 0, 149, 139, 148,
+	147, 0, 159, 157, 136, 0, 150, 151, 0, 0,
+	0, 0, 105, 143, 153, 152, 142, 141, 144, 145,
+	140, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	128, 0, 0, 0, 0, 1353, 0, 0, 0, 0,
+	0, 143, 153, 152, 142, 141, 144, 145, 140, 0,
+	0, 0, 0, 0, 107, 112, 113, 114, 108, 109,
+	110, 111, 115, 116, 117, 118, 134, 0, 0, 0,
+	0, 0, 0, 120, 158, 143, 153, 152, 142, 141,
+	144, 145, 140, 0, 0, 121, 122, 123, 0, 124,
+	125, 0, 126, 127, 96, 95, 92, 94, 131, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 406, 0,
+	90, 91, 101, 76, 0, 77, 106, 84, 85, 86,
+	0, 129, 88, 102, 0, 103, 104, 0, 78, 0,
+	138, 137, 0, 0, 0, 0, 149, 139, 148, 147,
+	0, 0, 83, 136, 0, 150, 151, 0, 0, 132,
+	133, 0, 0, 0, 0, 0, 0, 0, 138, 137,
+	0, 0, 0, 0, 149, 139, 148, 147, 93, 119,
+	1108, 136, 0, 150, 151, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 99, 0, 0, 0, 100,
+	0, 0, 138, 137, 130, 0, 0, 0, 149, 139,
+	148, 147, 750, 159, 157, 136, 431, 150, 151, 0,
+	0, 0, 0, 105, 143, 153, 152, 142, 141, 144,
+	145, 140, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 128, 0, 0, 0, 0, 1336, 0, 0, 0,
+	0, 0, 143, 153, 152, 142, 141, 144, 145, 140,
+	0, 0, 0, 0, 0, 107, 112, 754, 114, 108,
+	109, 110, 111, 115, 116, 117, 118, 134, 0, 0,
+	0, 0, 0, 0, 120, 158, 143, 153, 152, 142,
+	141, 144, 145, 140, 0, 0, 121, 122, 123, 0,
+	124, 125, 0, 126, 127, 96, 95, 92, 94, 131,
+	383, 0, 0, 143, 153, 152, 142, 141, 144, 145,
+	140, 90, 91, 101, 76, 0, 77, 106, 84, 85,
+	86, 0, 129, 88, 102, 1310, 103, 104, 0, 78,
+	0, 138, 137, 0, 0, 0, 0, 149, 139, 148,
+	147, 0, 0, 83, 136, 0, 150, 151, 0, 0,
+	132, 133, 0, 0, 0, 0, 0, 0, 0, 138,
+	137, 0, 0, 0, 0, 149, 139, 148, 147, 93,
+	119, 920, 136, 0, 150, 151, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 99, 0, 0, 0,
+	100, 0, 0, 138, 137, 130, 396, 0, 0, 149,
+	139, 148, 147, 0, 159, 157, 136, 0, 150, 151,
+	0, 0, 0, 0, 105, 0, 0, 0, 0, 0,
+	138, 137, 369, 0, 0, 0, 149, 139, 148, 147,
+	0, 0, 128, 136, 0, 150, 151, 0, 0, 0,
+	0, 0, 0, 143, 153, 152, 142, 141, 144, 145,
+	140, 0, 0, 0, 0, 0, 107, 112, 113, 114,
+	108, 109, 110, 111, 115, 116, 117, 118, 134, 0,
+	0, 0, 0, 0, 0, 120, 158, 143, 153, 152,
+	142, 141, 144, 145, 140, 0, 0, 121, 122, 123,
+	0, 124, 125, 0, 126, 127, 96, 95, 92, 94,
+	131, 0, 0, 0, 143, 153, 152, 142, 141, 144,
+	145, 140, 90, 91, 101, 76, 0, 77, 106, 84,
+	85, 86, 0, 129, 88, 102, 1289, 103, 104, 0,
+	78, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 83, 0, 0, 0, 0, 0,
+	0, 132, 133, 0, 0, 0, 0, 0, 0, 0,
+	138, 137, 0, 0, 0, 0, 149, 139, 148, 147,
+	93, 119, 870, 136, 0, 150, 151, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 99, 0, 0,
+	0, 100, 0, 0, 138, 137, 130, 0, 232, 0,
+	149, 139, 148, 147, 0, 159, 157, 136, 0, 150,
+	151, 0, 0, 0, 585, 105, 0, 0, 0, 0,
+	0, 138, 137, 0, 0, 0, 0, 149, 139, 148,
+	147, 0, 0, 128, 136, 0, 150, 151, 0, 0,
+	0, 0, 0, 143, 153, 152, 142, 141, 144, 145,
+	140, 0, 0, 0, 0, 0, 0, 107, 112, 113,
+	114, 108, 109, 110, 111, 115, 116, 117, 118, 134,
+	362, 0, 0, 0, 0, 0, 120, 158, 143, 153,
+	152, 142, 141, 144, 145, 140, 0, 0, 121, 122,
+	123, 0, 124, 125, 0, 126, 127, 96, 95, 92,
+	94, 131, 0, 0, 0, 143, 153, 152, 142, 141,
+	144, 145, 140, 90, 91, 101, 76, 0, 77, 106,
+	84, 85, 86, 0, 129, 88, 102, 1238, 103, 104,
+	0, 78, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 83, 0, 0, 0, 0,
+	0, 0, 132, 133, 0, 0, 0, 0, 0, 0,
+	138, 137, 0, 0, 0, 0, 149, 139, 148, 147,
+	0, 93, 119, 136, 0, 150, 151, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 99, 0,
+	0, 0, 100, 0, 0, 138, 137, 130, 0, 0,
+	0, 149, 139, 148, 147, 0, 159, 157, 136, 0,
+	150, 151, 0, 0, 0, 584, 105, 0, 0, 0,
+	0, 0, 138, 137, 0, 0, 0, 0, 149, 139,
+	148, 147, 0, 0, 128, 136, 0, 150, 151, 0,
+	0, 0, 0, 0, 143, 153, 152, 142, 141, 144,
+	145, 140, 0, 0, 0, 0, 0, 0, 107, 112,
+	113, 114, 108, 109, 110, 111, 115, 116, 117, 118,
+	134, 0, 0, 0, 0, 0, 0, 120, 158, 143,
+	153, 152, 142, 141, 144, 145, 140, 0, 0, 121,
+	122, 123, 0, 124, 125, 0, 126, 127, 96, 95,
+	92, 94, 131, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 90, 91, 101, 76, 0, 77,
+	106, 84, 85, 86, 0, 129, 88, 102, 0, 103,
+	104, 0, 78, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 83, 0, 0, 0,
+	0, 0, 0, 132, 133, 0, 0, 0, 0, 0,
+	0, 138, 137, 0, 0, 0, 0, 149, 139, 148,
+	147, 0, 93, 119, 136, 0, 150, 151, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 99,
+	0, 0, 0, 100, 0, 0, 138, 137, 130, 0,
+	0, 0, 149, 139, 148, 147, 0, 159, 157, 136,
+	0, 150, 151, 0, 368, 0, 0, 105, 143, 153,
+	152, 142, 141, 144, 145, 140, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 128, 0, 0, 0, 1090,
+	0, 0, 0, 0, 0, 143, 153, 152, 142, 141,
+	144, 145, 140, 0, 0, 0, 0, 0, 0, 107,
+	112, 113, 114, 108, 109, 110, 111, 115, 116, 117,
+	118, 134, 0, 0, 0, 0, 0, 0, 120, 158,
+	143, 589, 152, 142, 141, 144, 145, 140, 0, 0,
+	121, 122, 123, 0, 124, 125, 0, 126, 127, 96,
+	95, 92, 94, 131, 0, 0, 0, 143, 153, 152,
+	142, 141, 144, 145, 140, 90, 91, 101, 155, 0,
+	77, 106, 84, 85, 86, 0, 129, 88, 102, 1201,
+	103, 104, 0, 78, 0, 138, 137, 0, 0, 0,
+	0, 149, 139, 148, 147, 0, 0, 83, 136, 0,
+	150, 151, 0, 0, 132, 133, 0, 0, 0, 0,
+	0, 0, 138, 137, 0, 0, 0, 0, 149, 139,
+	148, 147, 0, 93, 119, 136, 0, 150, 151, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 0, 87, 0,
-	0, 0, 88, 0, 0, 0, 103, 0, 71, 0,
-	0, 0, 0, 0, 0, 770, 769, 0, 773, 0,
-	0, 0, 0, 0, 29, 93, 0, 36, 34, 35,
-	31, 37, 0, 0, 0, 0, 0, 0, 0, 39,
-	40, 0, 0, 0, 44, 45, 46, 47, 38, 49,
-	50, 51, 42, 48, 52, 0, 0, 0, 774, 0,
-	0, 28, 43, 95, 96, 97, 98, 99, 100, 101,
-	105, 0, 84, 82, 83, 104, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 80, 81, 89,
-	67, 94, 74, 75, 76, 0, 102, 78, 90, 0,
-	91, 92, 20, 68, 0, 0, 0, 32, 33, 0,
-	0, 0, 0, 0, 0, 0, 73, 0, 26, 41,
-	0, 27, 0, 0, 0, 0, 0, 0, 0, 0,
+	99, 0, 0, 0, 100, 0, 0, 138, 137, 130,
+	0, 0, 0, 149, 139, 148, 147, 0, 159, 157,
+	136, 0, 150, 151, 0, 0, 0, 0, 105, 0,
+	0, 0, 0, 0, 138, 137, 0, 0, 0, 0,
+	149, 139, 148, 147, 0, 0, 128, 136, 0, 150,
+	151, 0, 0, 0, 0, 0, 143, 437, 152, 142,
+	141, 144, 145, 140, 0, 0, 0, 0, 0, 0,
+	107, 112, 113, 114, 108, 109, 110, 111, 115, 116,
+	117, 118, 134, 0, 0, 0, 0, 0, 0, 120,
+	158, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 121, 122, 123, 0, 124, 125, 0, 126, 127,
+	96, 95, 92, 94, 131, 0, 0, 0, 143, 153,
+	152, 142, 141, 144, 145, 140, 90, 91, 101, 1175,
+	0, 77, 106, 84, 377, 86, 0, 129, 88, 102,
+	1079, 103, 104, 0, 78, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 83, 0,
+	0, 0, 0, 0, 0, 132, 133, 0, 0, 0,
+	0, 0, 0, 138, 137, 0, 0, 0, 0, 149,
+	139, 148, 147, 0, 93, 119, 136, 0, 150, 151,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 99, 0, 0, 0, 100, 106, 0, 0, 0,
+	130, 0, 0, 0, 0, 0, 0, 0, 0, 159,
+	157, 0, 0, 0, 0, 0, 0, 0, 0, 105,
+	0, 473, 331, 0, 0, 138, 137, 0, 0, 0,
+	0, 149, 139, 148, 147, 0, 0, 128, 136, 0,
+	150, 151, 0, 0, 0, 0, 0, 0, 0, 119,
+	0, 0, 0, 0, 0, 0, 0, 106, 0, 0,
+	0, 107, 112, 113, 114, 108, 109, 110, 111, 115,
+	116, 117, 118, 134, 0, 0, 232, 0, 0, 0,
+	120, 158, 473, 331, 0, 0, 0, 0, 0, 0,
+	0, 0, 121, 122, 123, 0, 124, 125, 106, 126,
+	127, 96, 95, 92, 94, 131, 0, 0, 0, 0,
+	119, 128, 0, 0, 0, 0, 0, 90, 91, 101,
+	76, 0, 77, 0, 83, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 107, 112, 113, 114, 108,
+	109, 110, 111, 333, 334, 335, 336, 0, 476, 0,
+	0, 119, 0, 0, 120, 0, 0, 0, 0, 106,
+	0, 0, 0, 0, 0, 0, 121, 122, 123, 477,
+	124, 125, 128, 126, 127, 0, 0, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 87, 0, 0, 0, 88,
-	0, 0, 0, 103, 0, 71, 0, 0, 0, 0,
-	0, 0, 22, 21, 0, 69, 0, 0, 0, 0,
-	0, 29, 93, 0, 36, 34, 35, 31, 37, 0,
-	0, 0, 0, 0, 0, 0, 39, 40, 0, 0,
-	70, 44, 45, 46, 47, 38, 49, 50, 51, 42,
-	48, 52, 0, 0, 0, 0, 0, 0, 28, 43,
-	95, 96, 97, 98, 99, 100, 101, 105, 0, 84,
-	82, 83, 104, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 80, 81, 89, 67, 94, 74,
-	75, 76, 0, 102, 78, 90, 0, 91, 92, 0,
-	68, 0, 0, 0, 0, 0, 113, 122, 121, 112,
-	111, 114, 110, 73, 0, 0, 94, 74, 75, 76,
-	0, 102, 78, 90, 0, 91, 92, 0, 68, 0,
+	0, 0, 0, 0, 474, 0, 107, 112, 113, 114,
+	108, 109, 110, 111, 333, 334, 335, 336, 0, 476,
+	0, 0, 119, 128, 0, 120, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 121, 122, 123,
+	477, 124, 125, 0, 126, 127, 0, 107, 112, 113,
+	114, 108, 109, 110, 111, 115, 116, 117, 118, 0,
+	0, 0, 0, 0, 0, 474, 120, 143, 153, 152,
+	142, 141, 144, 145, 140, 0, 0, 0, 121, 122,
+	123, 0, 124, 125, 128, 126, 127, 0, 0, 1056,
+	0, 0, 0, 0, 0, 0, 0, 0, 143, 153,
+	152, 142, 141, 144, 145, 140, 668, 0, 107, 112,
+	113, 114, 108, 109, 110, 111, 115, 116, 117, 118,
+	873, 0, 0, 0, 0, 0, 0, 120, 143, 153,
+	152, 142, 141, 144, 145, 140, 0, 0, 0, 121,
+	122, 123, 0, 124, 125, 0, 126, 127, 0, 446,
+	143, 153, 152, 142, 141, 144, 145, 140, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 665, 0, 0,
+	0, 0, 843, 143, 153, 152, 142, 141, 144, 145,
+	140, 0, 0, 0, 138, 137, 0, 0, 0, 0,
+	149, 139, 148, 147, 0, 742, 0, 136, 0, 150,
+	151, 0, 0, 143, 153, 152, 142, 141, 144, 145,
+	140, 0, 0, 0, 0, 138, 137, 0, 0, 0,
+	0, 149, 139, 148, 147, 599, 0, 0, 136, 0,
+	150, 151, 0, 143, 153, 152, 142, 141, 144, 145,
+	140, 0, 0, 0, 0, 138, 137, 0, 0, 106,
+	0, 149, 139, 148, 147, 298, 102, 0, 136, 0,
+	150, 151, 0, 0, 0, 0, 0, 138, 137, 0,
+	0, 0, 0, 149, 139, 148, 147, 0, 0, 0,
+	136, 0, 150, 151, 0, 0, 0, 0, 0, 106,
+	138, 137, 0, 0, 0, 0, 149, 139, 148, 147,
+	0, 0, 119, 136, 0, 150, 151, 0, 0, 0,
+	0, 0, 0, 637, 0, 0, 0, 0, 0, 0,
+	138, 137, 0, 0, 0, 0, 149, 139, 148, 147,
+	0, 0, 0, 136, 0, 150, 151, 0, 0, 0,
+	0, 0, 119, 0, 0, 0, 0, 106, 0, 0,
+	138, 137, 0, 0, 0, 0, 149, 139, 148, 147,
+	0, 0, 0, 136, 128, 150, 151, 0, 0, 0,
+	0, 620, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 107, 112,
+	113, 114, 108, 109, 110, 111, 115, 116, 117, 118,
+	119, 0, 0, 0, 128, 0, 0, 120, 0, 618,
+	0, 0, 0, 186, 0, 0, 187, 0, 0, 121,
+	122, 123, 0, 124, 125, 106, 126, 127, 107, 112,
+	113, 114, 108, 109, 110, 111, 115, 116, 117, 118,
+	462, 0, 638, 0, 0, 0, 0, 120, 0, 0,
+	0, 331, 0, 0, 0, 0, 0, 0, 0, 121,
+	122, 123, 128, 124, 125, 0, 126, 127, 0, 0,
+	0, 0, 0, 106, 0, 0, 0, 0, 119, 0,
+	0, 0, 0, 0, 0, 0, 107, 112, 113, 114,
+	108, 109, 110, 111, 115, 116, 117, 118, 0, 83,
+	0, 0, 0, 0, 0, 120, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 121, 122, 123,
+	0, 124, 125, 106, 126, 127, 119, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 73, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 87, 0, 0, 0, 88, 0, 0, 0,
-	103, 0, 0, 0, 0, 0, 0, 0, 0, 127,
-	126, 0, 0, 0, 0, 0, 0, 0, 191, 93,
-	87, 0, 0, 0, 88, 0, 108, 107, 103, 0,
-	0, 0, 118, 109, 117, 116, 0, 127, 126, 119,
-	120, 602, 0, 0, 0, 0, 0, 93, 0, 0,
-	0, 0, 0, 0, 0, 190, 0, 95, 96, 97,
-	98, 99, 100, 101, 105, 0, 84, 82, 83, 104,
-	0, 0, 0, 0, 113, 122, 121, 112, 111, 114,
-	110, 80, 81, 89, 67, 95, 96, 97, 98, 99,
-	100, 101, 105, 0, 84, 82, 83, 104, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 326, 0, 80,
-	81, 89, 67, 94, 74, 75, 76, 0, 102, 78,
-	90, 0, 91, 92, 0, 68, 0, 0, 0, 0,
-	0, 113, 122, 121, 112, 111, 114, 110, 73, 0,
-	0, 94, 74, 75, 76, 0, 102, 78, 90, 0,
-	91, 92, 0, 68, 108, 107, 0, 0, 0, 0,
-	118, 109, 117, 116, 0, 0, 73, 119, 120, 467,
-	0, 0, 0, 0, 0, 0, 0, 87, 0, 0,
-	0, 88, 0, 0, 0, 103, 269, 0, 0, 0,
-	0, 0, 0, 0, 127, 126, 0, 0, 0, 0,
-	0, 0, 0, 0, 93, 87, 0, 0, 293, 88,
-	0, 108, 107, 103, 0, 71, 0, 118, 109, 117,
-	116, 0, 127, 126, 119, 120, 297, 0, 0, 0,
-	0, 0, 93, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 95, 96, 97, 98, 99, 100, 101, 105,
-	0, 84, 82, 83, 104, 0, 0, 0, 0, 113,
-	122, 121, 112, 111, 114, 110, 80, 81, 89, 67,
-	95, 96, 97, 98, 99, 100, 101, 105, 0, 84,
-	82, 83, 104, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 80, 81, 89, 67, 94, 74,
-	75, 76, 0, 102, 78, 90, 0, 91, 92, 0,
-	68, 0, 0, 0, 0, 0, 113, 122, 121, 112,
-	111, 114, 110, 73, 0, 0, 94, 74, 75, 76,
-	0, 102, 78, 90, 0, 91, 92, 999, 68, 108,
-	107, 0, 0, 0, 0, 118, 109, 117, 116, 0,
-	0, 73, 119, 120, 0, 0, 0, 0, 0, 0,
-	0, 0, 87, 0, 0, 0, 88, 0, 0, 0,
-	103, 0, 0, 0, 0, 0, 0, 0, 0, 127,
-	126, 0, 0, 0, 0, 0, 0, 0, 0, 93,
-	87, 0, 0, 0, 88, 0, 108, 107, 103, 0,
-	0, 0, 118, 109, 117, 116, 0, 127, 126, 119,
-	120, 0, 0, 0, 0, 0, 0, 93, 0, 0,
-	0, 0, 0, 588, 0, 0, 0, 95, 96, 97,
-	98, 99, 100, 101, 105, 0, 84, 82, 83, 104,
-	113, 122, 121, 112, 111, 114, 110, 0, 0, 589,
-	0, 80, 81, 89, 67, 95, 96, 97, 98, 99,
-	100, 101, 105, 0, 84, 82, 83, 104, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 0, 0, 80,
-	81, 89, 124, 94, 74, 299, 76, 0, 102, 78,
-	90, 0, 91, 92, 0, 68, 113, 122, 121, 112,
-	111, 114, 110, 0, 0, 0, 0, 0, 73, 0,
-	0, 0, 0, 0, 0, 0, 0, 985, 0, 0,
-	108, 107, 0, 0, 0, 0, 118, 109, 117, 116,
-	0, 0, 0, 119, 120, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 87, 0, 0,
-	0, 88, 0, 0, 0, 103, 0, 0, 0, 0,
-	0, 0, 0, 0, 127, 126, 113, 122, 121, 112,
-	111, 114, 110, 0, 93, 0, 108, 107, 0, 0,
-	0, 0, 118, 109, 117, 116, 0, 973, 0, 119,
-	120, 0, 0, 0, 0, 0, 0, 0, 0, 113,
-	122, 121, 112, 111, 114, 110, 0, 0, 0, 0,
-	0, 0, 95, 96, 97, 98, 99, 100, 101, 105,
-	950, 84, 82, 83, 104, 0, 0, 113, 122, 121,
-	112, 111, 114, 110, 0, 0, 80, 81, 89, 67,
-	0, 0, 0, 0, 0, 0, 108, 107, 941, 0,
-	0, 0, 118, 109, 117, 116, 0, 0, 0, 119,
-	120, 113, 122, 121, 112, 111, 114, 110, 0, 0,
-	0, 113, 122, 121, 112, 111, 114, 110, 0, 108,
-	107, 0, 929, 0, 0, 118, 109, 117, 116, 0,
-	0, 0, 119, 120, 858, 0, 113, 122, 121, 112,
-	111, 114, 110, 0, 0, 0, 0, 108, 107, 0,
-	0, 0, 0, 118, 109, 117, 116, 920, 0, 0,
-	119, 120, 113, 122, 121, 112, 111, 114, 110, 0,
-	0, 0, 113, 122, 121, 112, 111, 114, 110, 0,
-	0, 108, 107, 866, 0, 0, 0, 118, 109, 117,
-	116, 108, 107, 855, 119, 120, 0, 118, 109, 117,
-	116, 0, 0, 0, 119, 120, 113, 122, 121, 112,
-	111, 114, 110, 0, 0, 0, 108, 107, 0, 0,
-	0, 0, 118, 109, 117, 116, 0, 0, 0, 119,
-	120, 113, 122, 121, 112, 111, 114, 110, 0, 0,
-	0, 0, 108, 107, 0, 0, 0, 0, 118, 109,
-	117, 116, 108, 107, 0, 119, 120, 0, 118, 109,
-	117, 116, 0, 0, 0, 119, 120, 113, 122, 121,
-	112, 111, 114, 110, 0, 0, 0, 113, 122, 121,
-	112, 111, 114, 110, 0, 0, 108, 107, 804, 0,
-	0, 0, 118, 109, 117, 116, 0, 356, 849, 119,
-	120, 113, 122, 121, 112, 111, 114, 110, 0, 0,
-	0, 108, 107, 0, 0, 0, 0, 118, 109, 117,
-	116, 0, 784, 813, 119, 120, 0, 0, 113, 122,
-	121, 112, 111, 114, 110, 0, 0, 0, 113, 122,
-	121, 112, 111, 114, 110, 0, 0, 108, 107, 670,
-	0, 0, 0, 118, 109, 117, 116, 108, 107, 0,
-	119, 120, 0, 118, 109, 117, 116, 0, 0, 0,
-	119, 120, 113, 122, 121, 112, 111, 114, 110, 0,
-	0, 108, 107, 0, 0, 0, 0, 118, 109, 117,
-	116, 544, 0, 643, 119, 120, 0, 0, 0, 113,
-	122, 121, 112, 111, 114, 110, 0, 0, 108, 107,
-	0, 0, 0, 0, 118, 109, 117, 116, 108, 107,
-	582, 119, 120, 0, 118, 109, 117, 116, 0, 0,
-	667, 119, 120, 113, 122, 121, 112, 111, 114, 110,
-	0, 0, 0, 113, 122, 121, 112, 111, 114, 110,
-	0, 0, 108, 107, 0, 0, 0, 0, 118, 109,
-	117, 116, 0, 0, 479, 119, 120, 113, 122, 121,
-	112, 111, 114, 110, 292, 0, 0, 0, 0, 108,
-	107, 0, 0, 0, 0, 118, 109, 117, 116, 0,
-	304, 0, 119, 120, 0, 0, 0, 0, 0, 0,
-	0, 113, 122, 121, 112, 111, 114, 110, 0, 0,
-	0, 0, 0, 108, 107, 0, 0, 0, 0, 118,
-	109, 117, 116, 108, 107, 0, 119, 120, 0, 118,
-	109, 117, 116, 0, 0, 0, 119, 120, 113, 122,
-	121, 112, 111, 114, 110, 0, 0, 108, 107, 0,
-	0, 0, 0, 118, 109, 117, 116, 291, 0, 238,
-	119, 120, 0, 0, 0, 113, 122, 121, 112, 111,
-	114, 110, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 108, 107, 0, 0, 0, 0, 118, 109, 117,
-	116, 0, 0, 0, 119, 120, 113, 122, 121, 112,
-	111, 114, 110, 0, 0, 0, 113, 469, 121, 112,
-	111, 114, 110, 0, 0, 0, 0, 113, 108, 107,
-	112, 111, 114, 110, 118, 109, 117, 116, 0, 0,
-	0, 119, 120, 113, 348, 121, 112, 111, 114, 110,
-	0, 0, 0, 0, 0, 108, 107, 0, 0, 0,
-	0, 118, 109, 117, 116, 0, 0, 0, 119, 120,
-	113, 122, 0, 112, 111, 114, 110, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 108, 107, 0, 0,
-	0, 0, 118, 109, 117, 116, 108, 107, 0, 119,
-	120, 0, 118, 109, 117, 116, 0, 108, 107, 119,
-	120, 0, 0, 118, 109, 117, 116, 0, 0, 0,
-	119, 120, 0, 108, 107, 0, 0, 0, 0, 118,
-	109, 117, 116, 0, 0, 0, 119, 120, 0, 0,
+	128, 0, 0, 0, 0, 0, 0, 0, 0, 331,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	108, 107, 0, 0, 0, 0, 118, 109, 117, 116,
-	0, 0, 0, 119, 120,
+	0, 0, 0, 0, 107, 112, 113, 114, 108, 109,
+	110, 111, 115, 116, 117, 118, 119, 0, 0, 0,
+	0, 106, 0, 120, 0, 0, 0, 0, 128, 0,
+	0, 0, 0, 0, 0, 121, 122, 123, 0, 124,
+	125, 0, 126, 127, 0, 0, 0, 331, 0, 0,
+	0, 0, 107, 112, 113, 114, 108, 109, 110, 111,
+	115, 116, 117, 118, 0, 106, 0, 0, 0, 0,
+	0, 120, 0, 0, 119, 0, 0, 0, 128, 0,
+	0, 0, 0, 121, 122, 123, 0, 124, 125, 640,
+	126, 127, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 107, 112, 113, 114, 108, 109, 110, 111,
+	115, 116, 117, 118, 0, 0, 0, 0, 119, 0,
+	0, 120, 0, 106, 0, 398, 0, 0, 0, 0,
+	0, 0, 0, 121, 122, 123, 128, 124, 125, 0,
+	126, 127, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	107, 112, 113, 114, 108, 109, 110, 111, 333, 334,
+	335, 336, 106, 0, 393, 0, 119, 0, 0, 120,
+	128, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 121, 122, 123, 0, 124, 125, 0, 126, 127,
+	0, 0, 0, 0, 107, 112, 113, 114, 108, 109,
+	110, 111, 115, 116, 117, 118, 0, 0, 0, 0,
+	0, 0, 106, 120, 0, 119, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 121, 122, 123, 128, 124,
+	125, 0, 126, 127, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 107, 112, 113, 114, 108, 109, 110, 111,
+	115, 116, 117, 118, 106, 119, 0, 0, 0, 0,
+	0, 120, 217, 0, 0, 0, 0, 128, 0, 0,
+	0, 0, 0, 121, 122, 123, 0, 124, 125, 0,
+	126, 127, 232, 0, 0, 0, 0, 0, 0, 0,
+	0, 107, 112, 113, 114, 108, 109, 110, 111, 115,
+	116, 117, 118, 106, 0, 0, 0, 119, 0, 0,
+	120, 0, 0, 0, 0, 0, 0, 128, 0, 0,
+	0, 0, 121, 122, 123, 0, 124, 125, 0, 126,
+	127, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 107, 112, 113, 114, 108, 109, 110, 111, 115,
+	116, 117, 118, 0, 0, 0, 119, 0, 0, 0,
+	120, 0, 0, 0, 0, 0, 0, 0, 0, 128,
+	0, 0, 121, 122, 123, 0, 124, 125, 0, 126,
+	127, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 107, 112, 113, 114, 108, 109, 110,
+	111, 115, 116, 117, 118, 0, 0, 0, 0, 0,
+	0, 0, 120, 0, 0, 0, 0, 0, 128, 0,
+	0, 0, 0, 0, 121, 122, 123, 0, 124, 125,
+	0, 126, 127, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 107, 112, 113, 114, 108, 109, 110, 111,
+	115, 116, 117, 118, 0, 0, 0, 0, 0, 0,
+	0, 120, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 121, 122, 123, 0, 124, 125, 0,
+	126, 127,
 }
-var yyPact = [...]int{
 
-	2277, -1000, 261, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, 3597,
-	-1000, 2832, 2804, -1000, -1000, 207, 842, 841, 940, 1481,
-	-1000, 569, 915, 918, 1262, 1262, 688, 1262, 2804, -1000,
-	-1000, 2804, 2804, 1345, 2804, 2804, 2804, 2804, 2804, 2804,
-	-1000, 1262, 1262, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, 267, -1000, -1000, -1000, 2647, -1000, 2434,
-	953, 851, -72, -51, -1000, -1000, -1000, -1000, -1000, -1000,
-	2804, 2804, 248, 247, 246, -1000, 337, 244, 2804, 2804,
-	-1000, -1000, -1000, 1262, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, 243, 241, 2277, 2804, 2804, 2804,
-	643, 2804, 676, 120, 2804, 701, 2804, 2804, 2804, 2804,
-	2804, 2804, 2804, 3539, 2647, -1000, 240, 2804, 524, 3597,
-	810, 880, 1191, 546, 896, 765, 632, -1000, 628, 1262,
-	1191, -1000, 37, 265, -1000, 403, -1000, 1262, 1262, 1262,
-	1262, 370, 368, -1000, -1000, -1000, 1262, -1000, -1000, -1000,
-	-1000, 2804, 2804, 908, 50, 3566, 3502, 2700, -1000, 902,
-	3597, 3597, 1435, -72, 3597, -1000, 2572, -72, 3597, -1000,
-	2989, 2804, 1107, 182, 183, 312, 3468, 55, 678, 940,
-	-1000, -1000, -1000, -1000, 34, 1262, -1000, 1236, 2619, 1139,
-	-1000, -1000, 1146, 632, 632, 120, 120, 645, 697, -1000,
-	-1000, 3618, -1000, 345, 632, 2804, -1000, 21, 2, 2,
-	708, 3634, 2804, 120, 2804, -1000, 2647, -1000, 2, 120,
-	120, 58, 58, -1000, -1000, -1000, 3661, 3618, 2277, 182,
-	176, 2804, 521, 506, 504, 2804, 771, 801, 1191, 890,
-	29, -1000, -1000, -1000, -1000, 239, -1000, -1000, -1000, -1000,
-	1032, 901, 26, 884, 1032, 687, 687, 687, 1378, -1000,
-	319, 847, 940, 2804, 398, 313, 234, 233, -1000, -1000,
-	-1000, -1000, 2804, 2804, 2804, 2804, 879, 3597, 3597, 955,
-	2804, 2804, 925, 922, 1191, 2804, 2804, 2804, 3597, 2804,
-	3597, -1000, -1000, -1000, 1963, 1262, 940, 1262, 64, 671,
-	851, 225, -1000, -1000, 173, 2804, -1000, -1000, -1000, -1000,
-	172, 25, 875, -1000, 3597, -1000, -1000, -65, 232, 231,
-	229, 228, 226, 224, 2804, 2462, -1000, -1000, 120, 190,
-	190, 190, 643, -1000, 2804, 2515, -1000, -1000, 2804, 3607,
-	-1000, 2, -1000, -1000, 497, -1000, 2804, 454, 2277, 452,
-	2804, 3444, 747, 2804, 1557, 179, 623, 1191, 2804, 884,
-	91, 704, -1000, -1000, 1528, -1000, 223, -1000, 1032, 1100,
-	807, 2804, -1000, 312, -1000, 312, 312, -1000, 1262, 628,
-	-1000, 168, 102, 623, 1262, -1000, 3597, 628, 1262, 628,
-	191, 1262, 3597, -72, 3597, -72, -72, 3597, -72, 3597,
-	940, -1000, -1000, 24, 3434, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, 3597, 450, 259, -1000, -1000, 2832, 2804, -1000,
-	-1000, -1000, -1000, -1000, 487, -1000, 22, 480, 1262, 1262,
-	-1000, 221, 1262, -1000, 166, -1000, 1378, 1262, 2619, 632,
-	632, 632, 2804, 2804, 2804, 165, 162, 161, 658, -1000,
-	178, -1000, 219, -1000, -1000, 420, 160, 2804, 3618, 2804,
-	447, 503, 2277, 2804, 3400, 582, -1000, -1000, 3597, 2277,
-	-1000, 2804, 2881, -1000, 13, 783, 3597, -1000, 120, 623,
-	-1000, 896, 5, 125, -60, -1000, -21, 2387, -1000, 767,
-	764, 710, 710, 745, 1032, -1000, -1000, -1000, -1000, 1262,
-	138, 2804, 884, -1000, 804, 800, 3597, 691, -1000, -1000,
-	691, 159, 4, -1000, 919, 1262, 830, -1000, 623, 822,
-	815, -1000, 150, -1000, 874, 149, 3, -1000, -1000, -6,
-	826, -10, -1000, 2804, 1262, 545, 1963, 3373, 519, 1963,
-	1963, 473, 467, 628, 147, -1000, -1000, -1000, 134, 2804,
-	2804, 2462, 2804, 133, 132, 129, -1000, -1000, -1000, 120,
-	128, -8, 2804, -1000, 625, 307, 3339, 3618, 571, 446,
-	-1000, 3329, 2804, -1000, 3278, 518, 3597, -1000, 629, 302,
-	1557, 296, -1000, -1000, -1000, 127, -30, 884, 623, 2804,
-	-1000, 2804, 1262, 1032, 1032, 762, -1000, 752, 736, 710,
-	-1000, -1000, -1000, 1692, -1000, -1000, 2804, 2804, 872, 1262,
-	-1000, -1000, -1000, 623, 623, 121, -32, 2804, 118, 1262,
-	2804, 866, 318, 865, 940, 940, 2804, 860, 940, -1000,
-	-1000, -1000, -1000, 1963, 502, 2804, 445, 444, 1963, 1963,
-	117, 857, 377, 116, 115, 114, 112, 107, 375, 376,
-	342, -1000, -1000, 120, 1637, -1000, 806, -1000, -1000, 570,
-	2277, 3278, -1000, -1000, 2804, -1000, -1000, -1000, 836, 716,
-	623, -1000, -1000, 3597, 105, -48, 745, 1167, 1032, 1032,
-	1032, 734, 2804, 3597, -1000, 628, -1000, -1000, -1000, 919,
-	1262, 3597, -1000, -1000, -72, 3597, 628, 2120, 316, -1000,
-	-1000, -1000, 826, 3597, 315, 103, 494, 443, 1963, 3302,
-	544, 542, 442, 441, -1000, 217, 214, 373, 361, 349,
-	344, 333, 213, 208, 293, 206, 288, -1000, 2804, 203,
-	-1000, 554, 3268, -1000, -1000, -1000, 120, -1000, -1000, -1000,
-	-1000, 2804, -1000, 2804, 202, 1167, 1010, 745, 1032, -58,
-	3232, -1000, -1000, -1000, -1000, 440, 197, -1000, -1000, 2832,
-	2804, -1000, -1000, 2804, 2804, 2120, 2120, 855, 439, 501,
-	1963, 2804, 581, -1000, 1963, -1000, -1000, 541, 537, 628,
-	381, 200, 199, 198, 196, 193, 381, 381, 332, 381,
-	331, 3207, 810, -1000, 2277, -1000, 93, 3597, 1262, -1000,
-	2804, 745, -1000, -1000, -1000, 2120, 3173, 517, 3112, 23,
-	661, 3597, 438, 434, 311, 568, 432, -1000, 3163, -1000,
-	515, -1000, -1000, 92, 90, -1000, 811, 787, 381, 381,
-	381, 381, 381, 89, 810, 88, 188, 84, 6, -1000,
-	73, -1000, 72, 3597, -1000, 2120, 498, 2804, 1806, 1262,
-	1262, -1000, -1000, 2120, -1000, 567, 1963, -1000, 2804, -1000,
-	-1000, -1000, 776, 2804, 71, 61, 57, 53, 47, -1000,
-	-1000, 381, -1000, 381, -1000, -1000, 464, 429, 2120, 3137,
-	428, 31, -1000, -1000, 2832, 2804, -1000, -1000, -1000, 456,
-	404, 427, -1000, 553, 3102, 1557, -1000, -1000, -1000, -1000,
-	-1000, -1000, 46, 43, 424, 495, 2120, 2804, 575, -1000,
-	2120, 531, 1806, 3068, 514, 1806, 1806, -1000, -1000, 1963,
-	286, -1000, -1000, 565, 423, -1000, 3040, -1000, 510, -1000,
-	-1000, 1806, 465, 2804, 417, 414, -1000, 773, -1000, 564,
-	2120, -1000, 2804, 462, 413, 1806, 3007, 527, 526, -1000,
-	689, 622, 620, 594, -1000, 552, 2937, 410, 463, 1806,
-	2804, 574, -1000, 1806, -1000, -1000, 650, 619, -1000, 605,
-	588, -1000, -1000, -1000, -1000, 2120, 558, 407, -1000, 2757,
-	-1000, 509, 668, -1000, -1000, -1000, -1000, -1000, 557, 1806,
-	-1000, 2804, -1000, 615, -1000, -1000, 547, 1396, -1000, -1000,
-	1806,
+var yyPact = [...]int16{
+	2973, -32768, 327, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, 5275, -32768, 5386, 5185, -32768, -32768, 274,
+	-32768, 1146, 520, 1149, 1228, 6305, -32768, 863, 529, 1220,
+	6939, 6939, 659, 6939, 5185, 1136, 1135, 1133, 1132, 5185,
+	5185, 6890, 5185, 5185, 5185, 5185, 5185, 5185, -32768, 6939,
+	6838, 6939, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, -32768, 331, -32768, -32768, -32768, 4984, 4179, -32768, 3978,
+	1234, 395, -73, -91, -32768, -32768, -32768, -32768, -32768, -32768,
+	5185, 5185, 306, 305, 304, 303, 302, -32768, 432, 301,
+	5185, 5185, -32768, -32768, -32768, 6939, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, 300, 299, 297, 296, 2973, 5185, 5185, 5185, 5185,
+	907, 5185, 924, 89, 5185, 5185, 1008, 5185, 5185, 5185,
+	5185, 5185, 5185, 5185, 6219, 4984, -32768, 295, 294, 5185,
+	787, 5275, 1086, 1214, 1214, 1214, 1190, 1205, 89, 1026,
+	1214, -32768, 877, 420, 11, 6939, -32768, 6939, 6939, 1123,
+	6637, -32768, 6, 322, -32768, 645, 6939, 6939, -32768, 6939,
+	6939, 6939, 6939, 6939, 6939, 471, 470, 1226, -32768, -32768,
+	-32768, 6939, -32768, -32768, -32768, -32768, 5185, 5185, 375, 49,
+	5074, 6939, 6939, 6939, 6939, 5441, 4873, -32768, 1211, 5275,
+	5275, 1464, -73, 5275, -32768, 3465, -73, 5275, -32768, -32768,
+	877, 220, 1146, 5788, 5185, 1818, 204, 205, -32768, -38,
+	4672, 53, 967, 1228, -32768, -32768, -32768, 5185, 1189, -32768,
+	6788, 4783, 6739, 22, 22, 2327, 5185, 889, 889, 889,
+	89, 89, 922, 1005, -32768, -32768, 1562, 22, 454, 889,
+	5185, 5185, 5185, -32768, 4471, 16, -18, -18, 965, 5642,
+	5185, 89, 5185, 5185, -32768, 4984, -32768, -2, -2, 89,
+	89, 56, 56, 22, 22, 22, 1867, 1562, 2973, 204,
+	200, 5185, 786, 761, 757, 5185, 707, 1071, 1185, 6637,
+	6481, 6637, 1196, 5923, -32768, 3, 968, 968, 968, 890,
+	-32768, 1214, 1146, 374, 373, 372, 6939, 1141, -32768, -32768,
+	-32768, -32768, 290, -32768, -32768, -32768, -32768, 1228, 5185, 626,
+	370, 287, 286, 996, 385, -32768, -32768, -32768, -32768, -32768,
+	-32768, 5185, 5185, 5185, 5185, 468, 1183, 5275, 5275, 1245,
+	6939, 5185, 5185, -32768, -32768, 966, 2, -32768, 1225, 1224,
+	6637, 5185, 5185, 5185, -32768, -32768, 5275, 5185, 5275, -32768,
+	-32768, -32768, -32768, 2571, 6939, 1228, 6939, 57, 962, 194,
+	-32768, 6637, -32768, -32768, 192, 5185, -32768, -32768, -32768, -32768,
+	186, 1, 1173, -32768, 5275, -32768, -32768, -41, 285, 284,
+	282, 281, 280, 279, 278, 184, 5185, 5185, 4381, -32768,
+	-32768, 89, 235, 235, 235, 907, -32768, 5185, 5240, 5039,
+	3430, -32768, -32768, 1241, -32768, -32768, -32768, 5185, 5476, -32768,
+	-2, -2, -32768, -32768, 755, -32768, 5185, 709, 2973, 708,
+	5185, 6189, 1032, 618, -32768, 5185, 5185, 675, 3375, 6637,
+	1202, -7, 5923, 1209, -12, 6403, 1083, 5185, -32768, 58,
+	6345, -32768, 6681, -32768, 5862, -32768, 277, 276, -32768, 89,
+	220, -32768, 220, 220, 3174, 983, -32768, 494, 6939, 6939,
+	877, -32768, 877, 6939, 211, 6025, 5964, 6529, 6939, 5185,
+	-32768, 5275, 877, 6939, 877, 218, 6939, 6939, 376, 5185,
+	5275, -73, 5275, -73, -73, 5275, -73, 5275, 5185, 5185,
+	1228, -32768, 180, -14, 6939, -32768, -17, 4068, -32768, 564,
+	6939, -32768, -32768, -32768, -32768, -32768, -32768, 5275, 706, 326,
+	-32768, -32768, 5386, 5185, -32768, -32768, -32768, -32768, -32768, 728,
+	-32768, -21, 725, 6939, 6939, -32768, 319, -32768, 174, -32768,
+	3174, 6939, 4783, 889, 889, 889, 889, 5185, 5185, 5185,
+	-32768, 173, 168, 164, 161, 955, -32768, 143, -32768, 275,
+	-32768, -32768, 641, 179, 1068, 1067, 5185, -32768, 1562, 5185,
+	705, 756, 2973, 5185, 6159, 839, -32768, -32768, 5275, 2973,
+	-32768, -32768, 3405, 3264, 4582, -32768, -32768, -32768, -23, 490,
+	5275, -32768, 203, 6529, 6637, 1022, 5923, 6579, 5923, 992,
+	6939, 1075, 1064, 5275, 273, 272, 1044, 1042, 1004, 1004,
+	1030, 5923, -32768, -32768, -32768, -32768, 208, 6939, 271, -32768,
+	6939, 178, 5185, 5185, -32768, 974, -32768, -32768, 974, -32768,
+	270, 269, -32768, 398, 158, 157, -24, 477, -32768, -32768,
+	156, 6939, 1172, 371, 1100, 6939, 1108, -32768, 6529, -93,
+	1092, 1091, -33, 3229, -32768, 155, -32768, 356, 154, -28,
+	-32768, -32768, -36, 1103, -37, 267, -32768, 5185, 5275, -73,
+	5275, -73, 5275, -32768, 1208, 6939, -32768, 5185, 6939, 342,
+	-32768, 805, 2571, 6136, 784, 2571, 2571, 721, 716, 264,
+	6529, -32768, -32768, -32768, 153, 5185, 5185, 5185, 4381, 5185,
+	148, 147, 146, -32768, -32768, -32768, -32768, 89, 144, 5185,
+	-32768, 873, 497, 1060, 3375, 3375, 4839, 1562, 831, 703,
+	-32768, 6084, 5185, -32768, 6114, 780, -32768, 887, 484, -32768,
+	-32768, -32768, 2346, 535, -32768, 3375, 481, 1055, -32768, -32768,
+	89, 6529, 416, 1205, -45, 314, -32768, 416, 5923, 1196,
+	-32768, 1817, 5923, 987, -32768, 5185, 3777, 5185, 6939, 5923,
+	5923, 1024, -32768, 1023, 1014, 1004, -32768, -32768, 6939, 191,
+	5185, -32768, -32768, 2862, 4638, 5185, 877, -32768, 1171, 1168,
+	6939, -32768, 477, 900, -32768, 263, 1167, 140, 877, 262,
+	-32768, -32768, -32768, 6529, 6529, 139, -51, 5185, 138, -61,
+	6939, 5185, -32768, 5185, 6939, 1166, 505, -32768, 356, 1228,
+	1228, 5185, 1164, 1228, 6939, 5275, 1240, -32768, -32768, -32768,
+	-32768, -32768, -32768, 2571, 753, 5185, 702, 701, 2571, 2571,
+	6529, 133, 596, 132, 131, 130, 129, 128, 176, 595,
+	526, 500, -32768, -32768, 2661, -32768, 1079, 3375, 127, 126,
+	-32768, -32768, 830, 2973, 6114, -32768, -32768, 5185, -32768, -32768,
+	533, 517, -32768, 478, -32768, 1120, 480, 416, 125, -32768,
+	3174, 1196, 6529, 5185, -32768, 1196, 416, 5185, 1624, 5923,
+	5275, -32768, -65, 5275, 260, 258, 202, 4270, 621, 567,
+	590, 5923, 5923, 5923, 1012, 124, -32768, 6939, 3088, 5185,
+	881, 123, 122, 494, 877, -32768, -32768, -32768, 5185, 877,
+	378, -32768, 6939, -32768, -32768, 1100, 6939, 5275, -32768, 6529,
+	-32768, -73, 5275, 121, -75, 877, 527, 6939, 502, -32768,
+	-32768, -32768, 1103, 5275, 523, 120, 119, -32768, 724, 699,
+	2571, 6053, 803, 796, 698, 694, 118, 985, 256, 589,
+	588, 587, 581, 577, 519, 253, 252, 474, 251, 462,
+	5185, 250, 117, -32768, -32768, -32768, 820, 5704, -32768, 472,
+	532, -32768, -32768, -32768, -32768, 1120, -32768, 942, -32768, 416,
+	-32768, 5275, 416, -32768, 5414, 5185, 1614, 3777, 5185, 5185,
+	248, 6529, 6939, -32768, 5185, 246, 590, 1564, 567, 5923,
+	438, 115, 113, -32768, -32768, -3, 4437, 367, 3174, 413,
+	244, -32768, 4229, -32768, 1162, 112, -32768, -32768, -32768, -32768,
+	-32768, 5185, -32768, 2772, 1161, 514, 6939, 2772, 1160, -32768,
+	689, 750, 2571, 5185, 837, -32768, 2571, -32768, -32768, 795,
+	794, 940, 243, 591, 237, 231, 229, 227, 225, 224,
+	591, 591, 571, 591, 531, 4034, 1086, -32768, -32768, 2973,
+	-32768, -32768, 469, -32768, 89, 416, -32768, -32768, -32768, 776,
+	509, 5414, 5185, -32768, 111, 110, 5587, 961, 959, 5275,
+	6939, -32768, 5185, 567, -32768, 438, 427, -32768, -32768, -32768,
+	-32768, -32768, 6939, 877, -32768, 877, -32768, 109, 688, 321,
+	-32768, -32768, 5386, 5185, -32768, -32768, 5185, 5185, 1238, 2772,
+	1159, 682, 506, 829, 673, -32768, 5503, -32768, 772, -32768,
+	-32768, 89, -32768, 6529, 107, -32768, 1087, 1058, 591, 591,
+	591, 591, 591, 591, 106, 1086, 105, 222, 104, 221,
+	-32768, 100, -32768, 416, -32768, -32768, 953, 422, -32768, 5414,
+	-32768, -32768, 92, -67, 5275, 3576, 214, 213, 90, 5275,
+	-32768, 212, 400, 86, -32768, -32768, -32768, 2772, 5101, 771,
+	3867, 35, 933, 5275, -32768, 672, 1237, -32768, 2772, -32768,
+	827, 2571, -32768, 5185, -32768, 85, -32768, -32768, 1018, 5185,
+	82, 79, 76, 74, 71, 67, -32768, -32768, 591, -32768,
+	591, -32768, -32768, 766, 5185, 953, -32768, -32768, 5587, -32768,
+	1722, 5185, 6529, -32768, 5185, -32768, 413, -32768, 2772, 740,
+	5185, 2075, 6939, 6939, -32768, -32768, 666, -32768, 813, 4900,
+	939, 3375, -32768, -32768, -32768, -32768, -32768, -32768, -32768, 66,
+	65, 1199, 5275, 710, -32768, 5185, 64, -76, 3666, 61,
+	3833, -32768, 723, 664, 2772, 4699, 655, 210, -32768, -32768,
+	5386, 5185, -32768, -32768, -32768, 714, 711, -32768, -32768, 2571,
+	89, -32768, 457, -32768, -32768, 1200, -32768, 1193, 52, 51,
+	5185, 6939, 33, -32768, 652, 739, 2772, 5185, 835, -32768,
+	2772, 793, 2075, 4610, 770, 2075, 2075, -32768, -32768, 989,
+	982, 6529, 223, -32768, -32768, -32768, -32768, -32768, 826, 648,
+	-32768, 4409, -32768, 769, -32768, -32768, 2075, 732, 5185, 642,
+	635, 455, 932, 870, 868, 850, 455, 932, -32768, 89,
+	6529, -32768, 825, 2772, -32768, 5185, 718, 633, 2075, 4095,
+	790, 789, -32768, 550, 944, 860, -32768, 856, 846, -32768,
+	-32768, -32768, -32768, 930, -32768, 28, -32768, 811, 3894, 632,
+	731, 2075, 5185, 834, -32768, 2075, -32768, -32768, 845, -32768,
+	-32768, 439, 931, -32768, -32768, -32768, -32768, 931, 1175, -32768,
+	2772, 824, 630, -32768, 3693, -32768, 768, -32768, -32768, 455,
+	857, -32768, 455, 89, -32768, 822, 2075, -32768, 5185, -32768,
+	-32768, -32768, -32768, -32768, 810, 3492, -32768, 2075,
 }
-var yyPgo = [...]int{
 
-	0, 73, 27, 33, 84, 136, 71, 1108, 31, 1107,
-	28, 1105, 1101, 1099, 1097, 12, 6, 1096, 1095, 1093,
-	1091, 1090, 1083, 1081, 75, 35, 37, 1079, 1077, 1076,
-	61, 1075, 57, 1074, 1073, 53, 52, 1065, 1055, 1052,
-	1051, 1049, 658, 101, 91, 1047, 65, 63, 1046, 1044,
-	15, 1043, 58, 1042, 36, 1039, 86, 1038, 98, 96,
-	97, 0, 62, 41, 34, 10, 1037, 1035, 1034, 1032,
-	1116, 1031, 83, 1029, 1028, 1022, 60, 1021, 1020, 1018,
-	5, 25, 21, 16, 1016, 1015, 3, 1014, 1009, 78,
-	1004, 1003, 76, 79, 94, 1001, 44, 991, 30, 990,
-	988, 987, 2, 51, 985, 38, 17, 77, 18, 72,
-	983, 981, 979, 56, 978, 24, 69, 13, 26, 11,
-	9, 1, 4, 59, 972, 14, 969, 8, 967, 7,
-	966, 1050, 141, 40, 19, 965, 99, 890, 964, 261,
-	80, 67, 55, 64, 87, 963, 54, 642,
+var yyPgo = [...]int16{
+	0, 89, 83, 15, 33, 164, 135, 1454, 67, 1449,
+	41, 1445, 1444, 1438, 1436, 130, 25, 1435, 1434, 1433,
+	1432, 1430, 1429, 1427, 103, 39, 1423, 66, 1412, 69,
+	47, 1410, 1408, 48, 1405, 1404, 1398, 1397, 1395, 87,
+	1393, 86, 97, 1391, 65, 1390, 1389, 71, 52, 1382,
+	1381, 1379, 1378, 1376, 1509, 114, 98, 1375, 573, 74,
+	64, 1374, 1371, 37, 1368, 20, 1366, 30, 1358, 79,
+	105, 106, 1351, 60, 1380, 1348, 111, 23, 44, 63,
+	1347, 113, 112, 134, 0, 76, 14, 13, 17, 1344,
+	1343, 75, 36, 1355, 1340, 107, 1337, 1336, 1335, 192,
+	1334, 1333, 1332, 61, 59, 29, 43, 1328, 12, 5,
+	3, 9, 4, 88, 1327, 1325, 99, 95, 96, 1323,
+	173, 35, 1321, 1320, 16, 1316, 1315, 32, 1313, 1312,
+	1310, 18, 49, 1309, 22, 155, 73, 104, 40, 1302,
+	1292, 548, 1291, 1287, 7, 1286, 62, 1283, 1279, 28,
+	21, 38, 100, 19, 31, 10, 8, 1, 6, 85,
+	1277, 24, 1276, 11, 1273, 2, 1271, 799, 46, 34,
+	550, 1263, 123, 1167, 1261, 110, 108, 94, 70, 93,
+	124, 1251, 72, 740,
 }
-var yyR1 = [...]int{
 
+var yyR1 = [...]uint8{
 	0, 1, 1, 1, 2, 2, 3, 3, 4, 4,
 	5, 5, 5, 5, 5, 5, 5, 5, 5, 5,
-	5, 5, 5, 5, 5, 5, 6, 6, 7, 7,
-	8, 8, 8, 8, 8, 9, 9, 10, 10, 12,
-	12, 11, 11, 11, 11, 11, 13, 13, 13, 13,
-	13, 13, 14, 14, 15, 15, 15, 16, 16, 17,
-	17, 18, 18, 18, 18, 18, 19, 19, 19, 19,
-	19, 19, 20, 20, 20, 20, 21, 21, 21, 21,
-	21, 22, 22, 23, 23, 23, 23, 23, 23, 23,
-	23, 23, 23, 24, 24, 25, 25, 26, 26, 26,
-	26, 26, 27, 27, 27, 27, 27, 28, 28, 28,
-	28, 29, 29, 30, 30, 31, 31, 31, 31, 32,
-	33, 33, 34, 35, 35, 36, 36, 36, 37, 37,
-	37, 37, 37, 38, 38, 38, 38, 38, 38, 38,
-	39, 39, 39, 40, 40, 40, 40, 40, 40, 40,
-	40, 40, 40, 40, 40, 40, 40, 40, 40, 40,
-	40, 40, 40, 40, 40, 40, 40, 41, 41, 41,
-	42, 43, 43, 43, 43, 44, 44, 45, 46, 46,
-	47, 47, 48, 48, 49, 49, 50, 50, 51, 51,
-	51, 52, 52, 53, 53, 54, 54, 55, 55, 56,
-	56, 57, 57, 57, 57, 57, 57, 58, 59, 60,
-	60, 60, 60, 60, 61, 61, 61, 61, 61, 61,
-	61, 61, 61, 61, 61, 61, 61, 61, 61, 61,
-	61, 62, 63, 63, 63, 64, 64, 65, 65, 66,
-	66, 67, 67, 68, 68, 68, 69, 69, 70, 71,
-	72, 72, 72, 73, 73, 73, 73, 73, 73, 73,
-	73, 73, 73, 73, 73, 73, 73, 73, 73, 73,
-	73, 73, 74, 74, 74, 74, 74, 74, 74, 75,
-	75, 75, 75, 76, 76, 77, 77, 77, 77, 78,
-	78, 78, 78, 78, 79, 79, 80, 80, 80, 80,
-	80, 80, 80, 80, 80, 80, 80, 81, 82, 82,
-	83, 83, 84, 84, 85, 85, 85, 86, 86, 86,
-	87, 87, 88, 88, 89, 89, 90, 90, 90, 90,
-	91, 91, 91, 91, 92, 92, 95, 95, 95, 95,
-	96, 96, 96, 96, 96, 96, 97, 97, 97, 97,
-	97, 97, 98, 98, 99, 99, 100, 100, 100, 101,
-	102, 102, 103, 103, 104, 104, 105, 105, 106, 106,
-	107, 107, 93, 93, 94, 94, 108, 108, 109, 109,
-	110, 110, 110, 110, 111, 112, 113, 113, 114, 114,
-	115, 115, 116, 116, 117, 117, 118, 118, 119, 119,
-	120, 120, 121, 121, 122, 122, 123, 123, 124, 124,
-	125, 125, 126, 126, 127, 127, 128, 128, 129, 129,
-	130, 130, 131, 131, 131, 131, 131, 131, 131, 131,
-	132, 133, 133, 134, 135, 135, 136, 136, 137, 138,
-	139, 139, 140, 140, 141, 141, 142, 142, 143, 143,
-	144, 144, 145, 145, 146, 146, 147, 147,
+	5, 5, 5, 5, 5, 5, 5, 5, 5, 5,
+	6, 6, 7, 7, 8, 8, 8, 8, 8, 9,
+	9, 10, 10, 12, 12, 11, 11, 11, 11, 11,
+	13, 13, 13, 13, 13, 13, 14, 14, 15, 15,
+	15, 16, 16, 17, 17, 18, 18, 18, 18, 18,
+	19, 19, 19, 19, 19, 19, 20, 20, 20, 20,
+	21, 21, 21, 21, 21, 22, 22, 22, 22, 22,
+	22, 22, 23, 23, 23, 23, 23, 23, 23, 23,
+	23, 23, 23, 23, 24, 24, 25, 25, 26, 26,
+	26, 27, 27, 28, 29, 29, 30, 30, 30, 30,
+	30, 31, 31, 31, 31, 31, 32, 32, 32, 32,
+	32, 32, 32, 33, 33, 34, 34, 35, 35, 36,
+	36, 37, 37, 38, 38, 39, 39, 40, 40, 41,
+	41, 43, 43, 43, 43, 43, 44, 45, 45, 46,
+	47, 47, 48, 48, 48, 49, 49, 49, 49, 49,
+	49, 49, 50, 50, 50, 50, 50, 50, 50, 51,
+	51, 51, 52, 52, 52, 52, 52, 52, 52, 52,
+	52, 52, 52, 52, 52, 52, 52, 52, 52, 52,
+	52, 52, 52, 52, 52, 52, 52, 52, 52, 52,
+	52, 52, 53, 53, 53, 54, 54, 54, 54, 54,
+	54, 55, 55, 55, 55, 55, 56, 56, 57, 57,
+	58, 58, 59, 59, 60, 60, 61, 61, 62, 62,
+	62, 62, 63, 63, 64, 64, 64, 65, 65, 66,
+	66, 67, 67, 68, 68, 69, 69, 70, 70, 71,
+	71, 71, 71, 71, 71, 72, 72, 73, 73, 74,
+	74, 75, 75, 79, 79, 78, 78, 78, 77, 77,
+	76, 76, 80, 80, 80, 80, 80, 80, 81, 82,
+	83, 83, 83, 83, 83, 84, 84, 84, 84, 84,
+	84, 84, 84, 84, 84, 84, 84, 84, 84, 84,
+	84, 84, 84, 84, 84, 85, 86, 86, 86, 87,
+	87, 88, 88, 89, 89, 89, 89, 90, 90, 42,
+	91, 91, 91, 92, 92, 93, 94, 95, 95, 95,
+	96, 96, 96, 96, 96, 96, 96, 96, 96, 96,
+	96, 96, 96, 96, 96, 96, 96, 96, 96, 96,
+	96, 96, 96, 97, 97, 97, 97, 97, 97, 97,
+	98, 98, 98, 98, 99, 99, 100, 100, 100, 100,
+	100, 100, 101, 101, 101, 101, 101, 101, 102, 102,
+	102, 102, 102, 103, 103, 103, 103, 103, 103, 103,
+	103, 103, 103, 103, 103, 104, 105, 105, 106, 106,
+	107, 107, 107, 107, 108, 108, 108, 108, 108, 109,
+	109, 109, 110, 110, 110, 111, 111, 112, 112, 113,
+	113, 114, 114, 114, 114, 115, 115, 115, 115, 116,
+	116, 119, 119, 119, 119, 119, 119, 120, 120, 120,
+	120, 120, 120, 120, 120, 120, 120, 120, 121, 121,
+	121, 125, 125, 122, 122, 123, 123, 124, 124, 126,
+	126, 126, 126, 126, 126, 127, 127, 128, 128, 129,
+	129, 129, 130, 131, 131, 132, 132, 133, 133, 134,
+	134, 135, 135, 136, 136, 117, 117, 118, 118, 137,
+	137, 138, 138, 139, 139, 139, 139, 140, 140, 141,
+	141, 141, 141, 142, 143, 144, 144, 145, 145, 145,
+	146, 146, 147, 147, 147, 148, 148, 148, 148, 149,
+	149, 150, 150, 151, 151, 152, 152, 153, 153, 154,
+	154, 155, 155, 156, 156, 157, 157, 158, 158, 159,
+	159, 160, 160, 161, 161, 162, 162, 163, 163, 164,
+	164, 165, 165, 166, 166, 167, 167, 167, 167, 167,
+	167, 167, 167, 167, 167, 167, 167, 167, 167, 167,
+	167, 167, 167, 167, 167, 167, 167, 167, 168, 169,
+	169, 170, 171, 171, 172, 172, 173, 174, 175, 175,
+	176, 176, 177, 177, 178, 178, 179, 179, 180, 180,
+	181, 181, 182, 182, 183, 183,
 }
-var yyR2 = [...]int{
 
+var yyR2 = [...]int8{
 	0, 0, 1, 3, 0, 3, 0, 3, 0, 3,
 	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
 	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
-	6, 8, 8, 9, 9, 1, 1, 1, 2, 1,
-	1, 7, 8, 6, 1, 1, 7, 8, 6, 1,
-	1, 1, 1, 1, 6, 8, 8, 1, 2, 1,
-	1, 7, 8, 6, 1, 1, 7, 8, 6, 1,
-	1, 1, 2, 2, 1, 2, 4, 4, 4, 4,
-	2, 1, 1, 6, 8, 5, 6, 8, 5, 7,
-	7, 7, 7, 1, 3, 1, 3, 0, 1, 1,
-	2, 2, 5, 2, 2, 3, 5, 6, 8, 5,
-	3, 1, 3, 1, 3, 4, 2, 4, 3, 1,
-	1, 3, 3, 1, 3, 1, 1, 3, 9, 10,
-	10, 12, 3, 0, 1, 1, 1, 1, 2, 2,
-	5, 6, 3, 4, 4, 4, 4, 4, 4, 2,
-	2, 2, 2, 4, 4, 2, 2, 2, 4, 1,
-	2, 2, 4, 2, 2, 1, 2, 2, 3, 4,
-	5, 5, 4, 4, 4, 1, 1, 3, 0, 2,
-	0, 2, 0, 3, 0, 2, 0, 3, 0, 3,
-	4, 0, 2, 0, 2, 0, 2, 6, 9, 1,
-	3, 1, 1, 1, 1, 1, 1, 1, 1, 1,
-	3, 3, 3, 3, 1, 1, 1, 1, 1, 1,
-	1, 1, 1, 1, 1, 1, 1, 1, 1, 3,
-	1, 1, 3, 1, 6, 1, 3, 1, 3, 2,
-	4, 1, 1, 0, 1, 1, 1, 1, 3, 3,
-	3, 1, 6, 3, 3, 3, 3, 4, 4, 5,
-	6, 6, 3, 4, 4, 3, 4, 4, 4, 4,
-	4, 2, 3, 3, 3, 3, 3, 2, 2, 3,
-	3, 2, 2, 0, 1, 4, 3, 4, 4, 5,
-	5, 5, 5, 1, 5, 10, 8, 9, 9, 9,
-	9, 9, 8, 8, 10, 8, 10, 2, 1, 5,
-	0, 3, 2, 5, 2, 2, 2, 2, 2, 2,
-	2, 1, 2, 1, 1, 1, 1, 1, 1, 1,
-	4, 6, 6, 8, 1, 1, 1, 6, 6, 1,
-	1, 2, 3, 1, 1, 3, 4, 5, 6, 7,
-	5, 6, 2, 4, 1, 1, 1, 3, 1, 5,
-	0, 1, 4, 5, 0, 2, 1, 3, 1, 3,
-	1, 3, 1, 3, 1, 3, 1, 3, 1, 3,
-	6, 9, 5, 8, 7, 3, 1, 3, 5, 6,
-	4, 5, 0, 2, 4, 5, 0, 2, 4, 5,
-	0, 2, 4, 5, 0, 2, 4, 5, 0, 2,
-	4, 5, 0, 2, 4, 5, 0, 2, 4, 5,
-	0, 2, 1, 1, 1, 1, 1, 1, 1, 1,
-	1, 1, 3, 3, 1, 3, 1, 3, 1, 1,
-	0, 1, 0, 1, 0, 1, 0, 1, 1, 1,
-	0, 1, 0, 1, 0, 1, 1, 1,
+	1, 1, 1, 1, 6, 8, 8, 9, 9, 1,
+	1, 1, 2, 1, 1, 7, 8, 6, 1, 1,
+	7, 8, 6, 1, 1, 1, 1, 1, 6, 8,
+	8, 1, 2, 1, 1, 7, 8, 6, 1, 1,
+	7, 8, 6, 1, 1, 1, 2, 2, 1, 2,
+	4, 4, 4, 4, 2, 1, 1, 3, 3, 6,
+	1, 3, 6, 8, 5, 5, 8, 6, 8, 5,
+	7, 7, 7, 7, 1, 3, 1, 3, 2, 1,
+	4, 0, 2, 2, 1, 3, 0, 1, 1, 2,
+	2, 5, 2, 2, 3, 5, 6, 8, 5, 8,
+	10, 7, 3, 0, 5, 8, 3, 5, 3, 0,
+	2, 0, 2, 1, 3, 1, 3, 1, 2, 1,
+	3, 4, 7, 2, 4, 3, 1, 1, 3, 3,
+	1, 3, 1, 1, 3, 10, 11, 10, 11, 10,
+	12, 3, 0, 1, 1, 1, 1, 2, 2, 5,
+	6, 3, 4, 4, 4, 4, 5, 5, 5, 5,
+	4, 4, 2, 2, 2, 2, 4, 4, 2, 2,
+	2, 4, 1, 2, 2, 4, 2, 2, 1, 2,
+	2, 3, 2, 3, 4, 3, 4, 5, 4, 5,
+	4, 5, 2, 4, 4, 4, 1, 1, 4, 8,
+	0, 1, 0, 2, 0, 2, 0, 3, 1, 4,
+	4, 5, 1, 3, 1, 2, 5, 1, 3, 0,
+	2, 0, 3, 3, 4, 0, 2, 2, 3, 5,
+	6, 6, 7, 4, 5, 1, 1, 1, 1, 0,
+	2, 8, 11, 0, 1, 0, 1, 2, 0, 3,
+	1, 3, 1, 1, 1, 1, 1, 1, 1, 1,
+	1, 3, 3, 3, 3, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+	3, 2, 3, 4, 1, 1, 3, 1, 6, 1,
+	3, 1, 3, 2, 4, 3, 5, 1, 1, 2,
+	0, 1, 1, 1, 1, 3, 3, 3, 1, 6,
+	3, 3, 3, 4, 4, 3, 4, 4, 5, 6,
+	6, 3, 4, 4, 3, 4, 3, 4, 4, 4,
+	4, 4, 2, 3, 3, 3, 3, 3, 2, 2,
+	3, 3, 2, 2, 0, 1, 4, 3, 4, 4,
+	4, 4, 5, 5, 5, 5, 5, 1, 5, 10,
+	8, 7, 7, 8, 9, 9, 9, 9, 9, 9,
+	8, 8, 10, 8, 10, 2, 1, 5, 0, 3,
+	3, 6, 3, 6, 0, 3, 2, 2, 3, 2,
+	2, 2, 2, 2, 2, 2, 1, 2, 1, 1,
+	1, 1, 1, 1, 1, 4, 6, 6, 8, 1,
+	1, 1, 6, 6, 4, 6, 1, 2, 3, 4,
+	6, 7, 1, 1, 2, 3, 1, 3, 0, 5,
+	9, 1, 1, 11, 11, 1, 3, 1, 3, 4,
+	5, 6, 7, 5, 6, 2, 4, 1, 1, 1,
+	3, 1, 5, 0, 1, 4, 5, 0, 2, 1,
+	3, 1, 3, 1, 3, 1, 3, 1, 3, 1,
+	3, 1, 3, 8, 11, 7, 10, 1, 3, 10,
+	13, 9, 12, 9, 3, 1, 3, 7, 8, 9,
+	0, 2, 9, 10, 11, 7, 5, 8, 11, 1,
+	2, 0, 2, 4, 5, 0, 2, 4, 5, 0,
+	2, 4, 5, 0, 2, 4, 5, 0, 2, 4,
+	5, 0, 2, 4, 5, 0, 2, 4, 5, 0,
+	2, 4, 5, 0, 2, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+	3, 3, 1, 3, 1, 3, 1, 1, 0, 1,
+	0, 1, 0, 1, 0, 1, 1, 1, 0, 1,
+	0, 1, 0, 1, 1, 1,
 }
-var yyChk = [...]int{
 
-	-1000, -1, -7, -5, -11, -42, -110, -111, -114, -23,
-	-20, -21, -27, -28, -31, -37, -22, -40, -41, -61,
-	15, 86, 85, -8, -10, -54, 31, 34, 131, 94,
-	-134, 100, 20, 21, 98, 99, 97, 101, 118, 109,
-	110, 32, 122, 132, 114, 115, 116, 117, 123, 119,
-	120, 121, 124, -60, -57, -74, -71, -70, -77, -78,
-	-101, -73, -75, -132, -137, -138, -39, 160, 16, 88,
-	113, 78, -131, 29, 5, 6, 7, -58, 10, -59,
-	157, 158, 143, 144, 142, -79, -63, 68, 72, 159,
-	11, 13, 14, 95, 4, 133, 134, 135, 136, 137,
-	138, 139, 9, 76, 145, 140, 154, 150, 149, 156,
-	75, 73, 72, 69, 74, -147, 158, 157, 155, 162,
-	163, 71, 70, -61, 160, -134, 86, 85, -102, -61,
-	-43, 24, 19, 22, -45, -44, 17, -70, 160, 35,
-	35, -136, -135, -132, -136, -131, -132, 95, 43, 101,
-	125, -137, 12, -137, -131, -131, -38, 102, 103, 36,
-	37, 104, 105, -131, -131, -61, -61, -61, 12, -131,
-	-61, -61, -61, -131, -61, -106, -61, -131, -61, -131,
-	-131, 151, -61, -106, -42, -54, -61, -132, -133, -9,
-	131, 94, 6, -56, -55, -145, 30, 165, 160, 165,
-	-61, -61, 160, 160, 160, 149, 156, -140, -147, 72,
-	-70, -61, -61, -131, 160, 160, -1, -61, -61, -61,
-	-140, -61, 73, 69, 74, -63, 160, -70, -61, 67,
-	66, -61, -61, -61, -61, -61, -61, -61, 90, -106,
-	-76, 160, -102, -123, -103, 89, -50, 44, 25, -94,
-	-92, -89, -91, -131, 29, -90, 136, 137, 138, 139,
-	18, -93, -89, -46, 18, 63, 64, 65, -139, 77,
-	-131, -92, 164, 151, 95, 43, 125, 126, -131, -131,
-	-131, -131, 156, 42, 156, 42, -131, -61, -61, 18,
-	61, 61, 42, 18, 18, 164, 61, 164, -61, 6,
-	-61, 161, 161, 161, 92, 69, 164, 69, -132, -133,
-	164, -131, -131, 6, -76, -139, -106, -131, 6, 161,
-	-109, -100, -99, -62, -61, -80, 155, -131, 144, 142,
-	145, 146, 147, 148, -139, -139, -63, -63, 73, 69,
-	67, 66, 75, 142, -139, -61, -58, -59, 70, -61,
-	-63, -61, -63, -63, -1, 161, 89, -124, 91, -104,
-	91, -61, -51, 50, 47, -92, 20, 164, 160, -107,
-	-96, -95, -97, 28, 160, -92, 141, -70, 18, 164,
-	-47, 23, -107, -144, 66, -144, -144, -109, 160, -146,
-	27, 32, 33, 41, 20, -136, -61, 96, 160, 27,
-	160, 160, -61, -131, -61, -131, -131, -61, -131, -61,
-	25, 5, -30, -29, -61, -106, 12, 12, -92, -106,
-	-106, -106, -61, -2, -12, -5, -13, 86, 85, -8,
-	-10, -6, 111, 112, -131, -133, -132, -131, 69, 69,
-	-56, 27, 160, 161, -76, 161, 164, 27, 160, 160,
-	160, 160, 160, 160, 160, -76, -76, -62, -63, -72,
-	160, -70, 140, -72, -72, -140, -76, 164, -61, 70,
-	-116, -115, 91, 87, -61, 93, -1, 93, -61, 90,
-	-53, 51, -61, -65, -66, -67, -61, -80, 26, 160,
-	-42, -113, -112, -60, -131, -94, -131, -61, -47, 59,
-	-141, -143, 58, 62, 164, 54, 56, 57, -131, 27,
-	-96, 160, -107, -93, -48, 45, -61, -44, -43, -44,
-	-44, -108, -131, -42, -24, 160, -131, -60, 160, -60,
-	-131, -42, -108, -42, 161, -36, -33, -35, -32, -34,
-	-132, -131, -133, 164, 27, 93, 154, -61, -102, 92,
-	92, -131, -131, 160, -108, 161, -109, -131, -76, -139,
-	-139, -139, -139, -76, -76, -76, 161, 161, 161, 70,
-	-64, -63, 160, 98, 69, 161, -61, -61, 93, -116,
-	-1, -61, 90, 85, -61, -1, -61, -52, 52, 78,
-	164, -68, 48, 49, -64, -105, -60, -46, 164, 156,
-	161, 164, 164, 53, 53, -142, 55, -142, -141, -143,
-	-107, -131, 161, -61, -47, -49, 46, 47, 161, 164,
-	-26, 36, 37, 38, 39, -25, -24, 40, -105, 42,
-	42, 161, 27, 161, 164, 164, 40, 161, 164, -30,
-	-131, 88, -2, 90, -125, 89, -2, -2, 92, 92,
-	-42, 161, 161, -76, -76, -76, -62, -76, 161, 161,
-	161, -63, 161, 164, -61, 79, 130, 161, 86, 93,
-	90, -61, -103, -123, 89, -52, 133, -65, 134, 161,
-	164, -47, -113, -61, -76, -131, -96, -96, 53, 53,
-	53, -142, 164, -61, -106, -146, -108, -60, -60, 161,
-	164, -61, 161, -131, -131, -61, 27, 127, 27, -32,
-	-35, -35, -132, -61, 27, -36, -2, -126, 91, -61,
-	93, 93, -2, -2, 161, 27, 108, 161, 161, 161,
-	161, 161, 108, 108, 129, 108, 129, -64, 164, 45,
-	86, -1, -61, -69, 36, 37, 26, -42, -105, 161,
-	161, 164, -98, 60, 61, -96, -96, -96, 53, -131,
-	-61, -42, -26, -25, -42, -3, -14, -5, -18, 86,
-	85, -15, -16, 88, 128, 127, 127, 161, -118, -117,
-	91, 87, 93, -2, 90, 88, 88, 93, 93, 160,
-	160, 108, 108, 108, 108, 108, 160, 160, 134, 160,
-	134, -61, 160, -115, 90, -64, -76, -61, 160, -98,
-	60, -96, 161, 161, 93, 154, -61, -102, -61, -132,
-	-133, -61, -3, -3, 27, 93, -118, -2, -61, 85,
-	-2, 88, 88, -42, -82, -81, -83, 107, 160, 160,
-	160, 160, 160, -81, -83, -82, 108, -81, 108, 161,
-	-50, 161, -108, -61, -3, 90, -127, 89, 92, 69,
-	69, 93, 93, 127, 86, 93, 90, -125, 89, 161,
-	161, -50, 44, 47, -82, -82, -82, -82, -81, 161,
-	161, 160, 161, 160, 161, 161, -3, -128, 91, -61,
-	-4, -17, -5, -19, 86, 85, -15, -16, -6, -131,
-	-131, -3, 86, -2, -61, 47, -106, 161, 161, 161,
-	161, 161, -82, -81, -120, -119, 91, 87, 93, -3,
-	90, 93, 154, -61, -102, 92, 92, 93, -117, 90,
-	-65, 161, 161, 93, -120, -3, -61, 85, -3, 88,
-	-4, 90, -129, 89, -4, -4, -84, 135, 86, 93,
-	90, -127, 89, -4, -130, 91, -61, 93, 93, -85,
-	73, 80, 6, 83, 86, -3, -61, -122, -121, 91,
-	87, 93, -4, 90, 88, 88, -87, 80, -86, 6,
-	83, 81, 81, 84, -119, 90, 93, -122, -4, -61,
-	85, -4, 70, 81, 81, 82, 84, 86, 93, 90,
-	-129, 89, -88, 80, -86, 86, -4, -61, 82, -121,
-	90,
+var yyChk = [...]int16{
+	-32768, -1, -7, -5, -11, -54, -139, -140, -142, -145,
+	-147, -23, -20, -21, -31, -32, -34, -35, -43, -49,
+	-22, -52, -53, -84, 15, 92, 91, -8, -10, -74,
+	-141, 84, 32, 35, 140, 100, -170, 106, 21, 22,
+	104, 105, 103, 107, 127, 115, 116, 117, 118, 33,
+	131, 141, 123, 124, 125, 126, 132, 128, 129, 130,
+	142, 133, -83, -80, -97, -94, -93, -100, -101, -130,
+	-96, -98, -168, -173, -174, -51, 202, 204, 16, 94,
+	122, 163, -167, 30, 5, 6, 7, -81, 10, -82,
+	199, 200, 185, 56, 186, 184, 183, -102, -86, 73,
+	77, 201, 11, 13, 14, 101, 4, 143, 147, 148,
+	149, 150, 144, 145, 146, 151, 152, 153, 154, 57,
+	162, 174, 175, 176, 178, 179, 181, 182, 119, 9,
+	82, 187, 37, 38, 155, 196, 204, 192, 191, 198,
+	81, 78, 77, 74, 79, 80, -183, 200, 199, 197,
+	206, 207, 76, 75, -84, 202, -170, 92, 163, 91,
+	-131, -84, -55, 25, 20, 23, 161, -57, 27, -56,
+	18, -93, 202, -76, -75, -181, 31, 36, 44, 174,
+	36, -172, -171, -168, -172, -167, 168, 171, -168, 101,
+	44, 168, 171, 107, 134, -173, 12, 179, -173, -167,
+	-167, -50, 108, 109, 37, 38, 110, 111, -167, -167,
+	-84, 36, 36, 36, 36, -84, -84, 12, -167, -84,
+	-84, -84, -167, -84, -135, -84, -167, -84, -167, -54,
+	-167, -74, 84, -167, 193, -84, -135, -54, 205, -135,
+	-84, -168, -169, -9, 140, 100, 6, 202, -58, 17,
+	209, 202, 209, -84, -84, 202, 202, 202, 202, 202,
+	191, 198, -176, -183, 77, -93, -84, -84, -167, 202,
+	202, 202, 202, -1, -84, -84, -84, -84, -176, -84,
+	78, 74, 79, 80, -86, 202, -93, -84, -84, 72,
+	71, -84, -84, -84, -84, -84, -84, -84, 96, -135,
+	-99, 202, -131, -159, -132, 95, -67, 45, -58, -58,
+	-58, 26, -59, 19, -87, -86, 68, 69, 70, -58,
+	-141, 163, 208, -167, -167, -167, 36, -116, -113, -115,
+	-167, 30, -114, 151, 152, 153, 154, 208, 193, 101,
+	44, 134, 135, -167, -167, -167, -167, -167, -167, -167,
+	-167, 198, 43, 198, 43, 12, -167, -84, -84, 19,
+	202, 66, 66, -167, -167, -137, -167, -137, 43, 19,
+	19, 208, 66, 208, -54, -76, -84, 6, -84, 203,
+	203, 203, 205, 98, 74, 208, 74, -168, -169, -99,
+	-135, 26, -167, 6, -99, -175, 83, -167, 6, 203,
+	-138, -129, -128, -85, -84, -103, 197, -167, 186, 184,
+	183, 187, 188, 189, 190, -99, -175, -175, -175, -86,
+	-86, 78, 74, 72, 71, 81, 183, -175, -84, -84,
+	-84, 205, -42, 180, -42, -81, -82, 75, -84, -86,
+	-84, -84, -86, -86, -1, 203, 95, -160, 97, -133,
+	97, -84, -68, -70, -71, 51, 52, 103, 48, 26,
+	-118, -116, 19, -117, -113, -116, -60, 24, -136, -120,
+	-119, -126, -122, 29, 202, -116, 156, 177, -93, 208,
+	-180, 71, -180, -180, -175, 83, -76, 28, 202, 202,
+	-182, 28, 28, 202, -167, 33, 34, 42, 21, 202,
+	-172, -84, 102, 202, 28, 202, 202, 65, -36, 172,
+	-84, -167, -84, -167, -167, -84, -167, -84, 198, 43,
+	26, 5, -41, -40, -167, -39, -38, -84, -135, 74,
+	208, 12, 12, -116, -135, -135, -135, -84, -2, -12,
+	-5, -13, 92, 91, -8, -10, -6, 120, 121, -167,
+	-169, -168, -167, 74, 74, 203, -116, 203, -99, 203,
+	208, 28, 202, 202, 202, 202, 202, 202, 202, 202,
+	203, -99, -99, -99, -85, -86, -95, 202, -93, 155,
+	-95, -95, -176, -99, 45, 45, 208, 5, -84, 75,
+	-152, -151, 97, 93, -84, 99, -1, 99, -84, 96,
+	-70, -71, -84, -84, -72, 37, 108, -88, -89, -90,
+	-84, -103, -116, 21, 208, -136, 19, 208, 66, -167,
+	28, -61, 46, -84, 159, 160, 64, -177, -179, 63,
+	67, 208, 59, 61, 62, -121, -167, 28, 157, -167,
+	28, -120, 202, 202, -87, -56, -55, -56, -56, -138,
+	65, -78, 167, 77, -137, -29, -28, -167, -54, -54,
+	-137, 202, -33, 175, -24, 202, -167, -83, 202, -167,
+	-83, -167, -167, -84, -54, -137, -54, 203, -48, -45,
+	-47, -44, -46, -168, -167, -167, -37, 173, -84, -167,
+	-84, -167, -84, -169, 203, 208, -167, 208, 28, 119,
+	-137, 99, 196, -84, -131, 98, 98, -167, -167, 66,
+	202, 203, -138, -167, -99, -175, -175, -175, -175, -175,
+	-99, -99, -99, 203, 203, 203, 203, 75, -87, 202,
+	104, 74, 203, 45, 48, 48, -84, -84, 99, -152,
+	-1, -84, 96, 91, -84, -1, -69, 53, 84, -73,
+	90, 145, -84, -73, 145, 208, -91, -42, 49, 50,
+	27, 202, -54, -144, -143, -83, -118, -60, 66, -136,
+	-117, -120, 66, -167, -66, 47, 48, 202, 202, 58,
+	58, -178, 60, -178, -177, -179, -136, -121, 202, -167,
+	202, -167, 203, -84, -84, 202, 202, 167, 203, 203,
+	208, -27, -26, 77, 169, 170, 203, -137, 28, 176,
+	-30, 37, 38, 39, 40, -25, -24, 41, -134, -83,
+	43, 43, 203, 208, 208, 203, -79, 181, 203, 208,
+	208, 41, 203, 208, 202, -84, 19, -41, -39, -167,
+	184, 94, -2, 96, -161, 95, -2, -2, 98, 98,
+	202, -134, 203, -99, -99, -99, -99, -85, -99, 203,
+	203, 203, -86, 203, -84, 85, 139, 48, -88, -88,
+	203, 92, 99, 96, -84, -132, -159, 95, -69, 143,
+	-73, 53, 146, 84, -88, 144, -91, -87, -134, -146,
+	164, -59, 208, 198, -146, -136, -60, 65, -120, 66,
+	-84, -63, -62, -84, 54, 55, 56, -84, -167, -120,
+	-120, 58, 58, 58, -178, -137, -121, 202, -84, 208,
+	203, -135, -54, 28, -182, -29, -27, 82, 202, 28,
+	203, -54, 202, -83, -83, 203, 208, -84, 203, 208,
+	-167, -167, -84, -99, -167, 28, 28, 182, -79, -44,
+	-47, -47, -168, -84, 28, -48, -137, 5, -2, -162,
+	97, -84, 99, 99, -2, -2, -134, 203, 114, 203,
+	203, 203, 203, 203, 203, 114, 114, 138, 114, 138,
+	208, 46, -88, 203, 203, 92, -1, -84, 146, 84,
+	-73, 143, -92, 37, 38, 144, -146, 203, -138, -60,
+	-144, -84, -60, -146, -84, 65, -120, 208, 202, 202,
+	57, 102, 102, -127, 65, 66, -120, -120, -120, 58,
+	203, -137, -125, 53, 145, -167, -84, 84, 203, 203,
+	-78, -54, -84, -54, -33, -137, -30, -25, -134, 203,
+	203, 208, -54, 136, -167, 28, 182, 136, 203, 203,
+	-154, -153, 97, 93, 99, -2, 96, 94, 94, 99,
+	99, 203, 66, 202, 114, 114, 114, 114, 114, 114,
+	202, 202, 144, 202, 144, -84, 202, 203, -151, 96,
+	143, 146, 84, -92, 27, -54, -146, -146, -149, -148,
+	95, -84, 65, -63, -135, -135, 202, -83, -167, -84,
+	202, -127, 65, -120, -121, 203, 203, 203, 203, 178,
+	-138, -77, 165, 202, 203, 28, 203, -99, -3, -14,
+	-5, -18, 92, 91, -15, -16, 94, 137, 28, 136,
+	-167, -3, 28, 99, -154, -2, -84, 91, -2, 94,
+	94, 27, -54, 202, -105, -104, -106, 113, 202, 202,
+	202, 202, 202, 202, -104, -106, -105, 114, -104, 114,
+	203, -67, 143, -87, -146, -149, 162, 77, -149, -84,
+	203, 203, -65, -64, -84, 202, 74, 74, -137, -84,
+	-121, 158, -137, -54, -54, 203, 99, 196, -84, -131,
+	-84, -168, -169, -84, 5, -3, 28, 99, 136, 92,
+	99, 96, -161, 95, -87, -134, 203, -67, 45, 48,
+	-105, -105, -105, -105, -105, -104, 203, 203, 202, 203,
+	202, 203, -146, -150, 75, 162, -149, 203, 208, 203,
+	-84, 202, 202, 203, 202, 166, 203, -3, 96, -163,
+	95, 98, 74, 74, 99, 5, -3, 92, -2, -84,
+	203, 48, -135, 203, 203, 203, 203, 203, 203, -105,
+	-104, 96, -84, -150, -65, 208, -124, -123, -84, -134,
+	-84, -77, -3, -164, 97, -84, -4, -17, -5, -19,
+	92, 91, -15, -16, -6, -167, -167, 99, -153, 96,
+	27, -54, -88, 203, 203, 20, 23, 96, -135, 203,
+	208, 28, 203, 203, -156, -155, 97, 93, 99, -3,
+	96, 99, 196, -84, -131, 98, 98, -87, -107, 145,
+	147, 21, 25, 203, 203, -124, -167, 203, 99, -156,
+	-3, -84, 91, -3, 94, -4, 96, -165, 95, -4,
+	-4, -109, 78, 86, 6, 89, -109, 78, -144, 27,
+	202, 92, 99, 96, -163, 95, -4, -166, 97, -84,
+	99, 99, -108, 148, -111, 86, -110, 6, 89, 87,
+	87, 90, -108, -111, -86, -134, 92, -3, -84, -158,
+	-157, 97, 93, 99, -4, 96, 94, 94, 89, 46,
+	143, 149, 75, 87, 87, 88, 90, 75, 203, -155,
+	96, 99, -158, -4, -84, 91, -4, 90, 150, -112,
+	86, -110, -112, 27, 92, 99, 96, -165, 95, -108,
+	88, -108, -86, 92, -4, -84, -157, 96,
 }
-var yyDef = [...]int{
 
-	-2, -2, 2, 28, 29, 10, 11, 12, 13, 14,
+var yyDef = [...]int16{
+	-2, -2, 2, 32, 33, 10, 11, 12, 13, 14,
 	15, 16, 17, 18, 19, 20, 21, 22, 23, 24,
-	25, 0, 360, 44, 45, 0, 0, 0, 0, 0,
-	-2, 0, 0, 0, 0, 0, 133, 0, 0, 81,
-	82, 0, 0, 0, 0, 0, 0, 0, 159, 0,
-	165, 0, 0, 214, 215, 216, 217, 218, 219, 220,
-	221, 222, 223, 224, 226, 227, 228, 195, 230, 0,
-	37, 452, 209, 0, 201, 202, 203, 204, 205, 206,
-	0, 0, 0, 0, 0, 293, 442, 0, 0, 0,
-	430, 438, 439, 0, 422, 423, 424, 425, 426, 427,
-	428, 429, 207, 208, 0, 0, -2, 0, 456, 457,
-	442, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, -2, 225, 0, 360, 0, 361,
-	-2, 0, 0, 0, 178, 0, 440, 176, 195, 0,
-	0, 72, 436, 434, 73, 0, 75, 0, 0, 0,
-	0, 0, 0, 80, 103, 104, 0, 134, 135, 136,
-	137, 0, 0, 0, -2, 157, 0, 0, 149, 161,
-	150, 151, 152, -2, 156, 160, 368, -2, 164, 166,
-	167, 0, 0, 0, 0, 0, 0, 224, 0, 0,
-	35, 36, 38, 196, 199, 0, 453, 0, 283, 0,
-	277, 278, 0, 440, 440, 456, 457, 0, 0, 443,
-	271, 281, 282, 0, 440, 0, 3, 249, -2, -2,
-	0, 0, 0, 0, 0, 262, 195, 233, -2, 0,
-	0, 272, 273, 274, 275, 276, 279, 280, -2, 0,
-	0, 283, 0, 408, 364, 0, 188, 0, 0, 0,
-	374, 334, 335, 324, 325, 0, -2, -2, -2, -2,
-	0, 0, 372, 180, 0, 450, 450, 450, 0, 441,
-	454, 0, 0, 0, 0, 0, 0, 0, 105, 110,
-	118, 132, 0, 0, 0, 0, 0, 138, 139, 0,
-	0, 0, 0, 0, 0, 0, 0, 0, 168, 202,
-	433, 229, 232, 248, -2, 0, 0, 0, 0, 0,
-	452, 0, 210, 212, 0, 283, 284, 211, 213, 286,
-	0, 378, 356, 358, 354, 355, 231, 209, 0, 0,
-	0, 0, 0, 0, 283, 283, 254, 256, 0, 0,
-	0, 0, 442, 142, 283, 0, 257, 258, 0, 0,
-	263, -2, 267, 269, 392, 288, 0, 0, -2, 0,
-	0, 0, 193, 0, 0, 195, 0, 0, 0, 180,
-	-2, 340, 343, 344, 195, 336, 0, 339, 0, 0,
-	182, 0, 179, 0, 451, 0, 0, 177, 0, 195,
-	455, 0, 0, 0, 0, 437, 435, 195, 0, 195,
-	0, 0, 76, -2, 78, -2, -2, 144, -2, 146,
-	0, 115, 117, 113, 111, 158, 147, 148, 162, 153,
-	154, 369, 169, 0, 0, 39, 40, 0, 360, 49,
-	50, 51, 26, 27, 0, 432, 431, 0, 0, 0,
-	200, 0, 0, 285, 0, 287, 0, 0, 283, 440,
-	440, 440, 283, 283, 283, 0, 0, 0, 0, 264,
-	195, 251, 0, 268, 270, 0, 0, 0, 259, 0,
-	0, 392, -2, 0, 0, 0, 409, 359, 365, -2,
-	170, 0, 191, 187, 237, 243, 241, 242, 0, 0,
-	382, 178, 386, 0, 209, 375, 209, 0, 388, 0,
-	0, 446, 446, 444, 0, 445, 448, 449, 341, 0,
-	444, 0, 180, 373, 184, 0, 181, 172, 175, 173,
-	174, 0, 376, 85, 97, 0, 93, 88, 0, 0,
-	0, 102, 0, 109, 0, 0, 125, 126, 120, 123,
-	119, 0, 106, 0, 0, 0, -2, 0, 0, -2,
-	-2, 0, 0, 195, 0, 289, 379, 357, 0, 283,
-	283, 283, 283, 0, 0, 0, 290, 291, 292, 0,
-	0, 235, 0, 140, 0, 294, 0, 260, 0, 0,
-	393, 0, 0, 43, 24, 406, 194, 189, 191, 0,
-	0, 239, 244, 245, 380, 0, 366, 180, 0, 0,
-	330, 283, 0, 0, 0, 0, 447, 0, 0, 446,
-	371, 342, 345, 0, 389, 171, 0, 0, -2, 0,
-	86, 98, 99, 0, 0, 0, 95, 0, 0, 0,
-	0, 107, 0, 0, 0, 0, 0, 0, 0, 114,
-	112, 30, 5, -2, 412, 0, 0, 0, -2, -2,
-	0, 0, 285, 0, 0, 0, 0, 0, 0, 0,
-	0, 261, 250, 0, 0, 141, 0, 234, 41, 0,
-	-2, 362, 363, 407, 0, 190, 192, 238, 0, 195,
-	0, 384, 387, 385, 0, 0, 346, 444, 0, 0,
-	0, 0, 0, 185, 183, 195, 377, 100, 101, 97,
-	0, 94, 89, 90, -2, 92, 195, -2, 0, 121,
-	127, 124, 0, 122, 0, 0, 396, 0, -2, 0,
-	0, 0, 0, 0, 197, 0, 0, 289, 290, 291,
-	292, 294, 0, 0, 0, 0, 0, 236, 0, 0,
-	42, 390, 0, 240, 246, 247, 0, 383, 367, 331,
-	332, 283, 347, 0, 0, 444, 444, 350, 0, 209,
-	0, 84, 87, 96, 108, 0, 0, 52, 53, 0,
-	360, 64, 65, 0, 57, -2, -2, 0, 0, 396,
-	-2, 0, 0, 413, -2, 31, 32, 0, 0, 195,
-	310, 0, 0, 0, 0, 0, 310, 310, 0, 310,
-	0, 0, 186, 391, -2, 381, 0, 352, 0, 348,
-	0, 351, 337, 338, 128, -2, 0, 0, 0, 224,
-	0, 58, 0, 0, 0, 0, 0, 397, 0, 48,
-	410, 33, 34, 0, 0, 308, 186, 0, 310, 310,
-	310, 310, 310, 0, 186, 0, 0, 0, 0, 252,
-	0, 333, 0, 349, 7, -2, 416, 0, -2, 0,
-	0, 129, 130, -2, 46, 0, -2, 411, 0, 198,
-	296, 307, 0, 0, 0, 0, 0, 0, 0, 302,
-	303, 310, 305, 310, 295, 353, 400, 0, -2, 0,
-	0, 0, 59, 60, 0, 360, 69, 70, 71, 0,
-	0, 0, 47, 394, 0, 0, 311, 297, 298, 299,
-	300, 301, 0, 0, 0, 400, -2, 0, 0, 417,
-	-2, 0, -2, 0, 0, -2, -2, 131, 395, -2,
-	187, 304, 306, 0, 0, 401, 0, 63, 414, 54,
-	9, -2, 420, 0, 0, 0, 309, 0, 61, 0,
-	-2, 415, 0, 404, 0, -2, 0, 0, 0, 312,
-	0, 0, 0, 0, 62, 398, 0, 0, 404, -2,
-	0, 0, 421, -2, 55, 56, 0, 0, 321, 0,
-	0, 314, 315, 316, 399, -2, 0, 0, 405, 0,
-	68, 418, 0, 320, 317, 318, 319, 66, 0, -2,
-	419, 0, 313, 0, 323, 67, 402, 0, 322, 403,
-	-2,
+	25, 26, 27, 28, 29, 0, 483, 48, 49, 0,
+	507, 610, 0, 0, 0, 0, -2, 0, 0, 0,
+	0, 0, 172, 0, 0, 85, 86, 0, 90, 0,
+	0, 0, 0, 0, 0, 0, 202, 0, 208, 0,
+	269, 0, 295, 296, 297, 298, 299, 300, 301, 302,
+	303, 304, 305, 307, 308, 309, 269, 0, 314, 0,
+	41, 230, 290, 0, 282, 283, 284, 285, 286, 287,
+	0, 0, 0, 0, 0, 0, 0, 387, 600, 0,
+	0, 0, 588, 596, 597, 0, 565, 566, 567, 568,
+	569, 570, 571, 572, 573, 574, 575, 576, 577, 578,
+	579, 580, 581, 582, 583, 584, 585, 586, 587, 288,
+	289, 0, 0, 0, 0, -2, 0, 0, 614, 615,
+	600, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, -2, 306, 0, 0, 483,
+	0, 484, -2, 230, 230, 230, 0, 232, 0, 0,
+	230, 227, 269, 270, 280, 0, 611, 0, 0, 0,
+	0, 76, 594, 592, 77, 0, 0, 0, 79, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 84, 122,
+	123, 0, 173, 174, 175, 176, 0, 0, 0, -2,
+	200, 0, 0, 0, 0, 0, 0, 192, 204, 193,
+	194, 195, -2, 199, 203, 491, -2, 207, 209, 210,
+	269, 0, 610, 212, 0, 0, 0, 0, 311, 0,
+	0, 305, 0, 0, 39, 40, 42, 374, 0, 231,
+	0, 374, 0, 368, 369, 0, 374, 598, 598, 598,
+	614, 615, 0, 0, 601, 362, 372, 373, 0, 598,
+	0, 0, 0, 3, 0, 336, -2, -2, 0, 0,
+	0, 0, 0, 0, 351, 269, 317, -2, -2, 0,
+	0, 363, 364, 365, 366, 367, 370, 371, -2, 0,
+	0, 374, 0, 551, 487, 0, 215, 0, 0, 0,
+	0, 0, 234, 0, 222, 319, 608, 608, 608, 598,
+	508, 230, 610, 0, 612, 0, 0, 0, 439, 440,
+	429, 430, 0, -2, -2, -2, -2, 0, 0, 0,
+	0, 0, 0, 0, 139, 124, 132, 136, 138, 155,
+	171, 0, 0, 0, 0, 0, 0, 177, 178, 0,
+	0, 0, 0, 87, 88, 0, 499, 91, 0, 0,
+	0, 0, 0, 0, 211, 270, 213, 283, 591, 310,
+	316, 335, 312, -2, 0, 0, 0, 0, 0, 0,
+	375, 0, 291, 293, 0, 374, 599, 292, 294, 377,
+	0, 501, 479, 481, 477, 478, 315, 290, 0, 0,
+	0, 0, 0, 0, 0, 0, 374, 374, 374, 341,
+	345, 0, 0, 0, 0, 600, 181, 374, 0, 0,
+	0, 313, 343, 0, 344, 346, 347, 0, 0, 352,
+	-2, -2, 358, 360, 535, 379, 0, 0, -2, 0,
+	0, 0, 216, 218, 220, 0, 0, 0, 0, 0,
+	0, 497, 0, 0, 495, 0, 236, 0, 233, -2,
+	458, 452, 453, 456, 269, 441, 0, 0, 446, 0,
+	0, 609, 0, 0, 0, 599, 281, 275, 0, 0,
+	269, 613, 269, 0, 133, 0, 0, 0, 0, 0,
+	595, 593, 269, 0, 269, 0, 0, 0, 141, 0,
+	80, -2, 82, -2, -2, 183, -2, 185, 0, 0,
+	0, 151, 0, 149, 147, 154, 145, 143, 201, 0,
+	0, 190, 191, 205, 196, 197, 492, 214, 0, 0,
+	43, 44, 0, 483, 53, 54, 55, 30, 31, 0,
+	590, 589, 0, 0, 0, 381, 0, 376, 0, 378,
+	0, 0, 374, 598, 598, 598, 598, 374, 374, 374,
+	380, 0, 0, 0, 0, 0, 353, 269, 338, 0,
+	359, 361, 0, 0, 0, 0, 0, 329, 348, 0,
+	0, 535, -2, 0, 0, 0, 552, 482, 488, -2,
+	217, 219, 255, 257, 0, 265, 266, 252, 321, 330,
+	327, 328, 269, 0, 0, 234, 0, 0, 0, 0,
+	0, 249, 0, 235, 0, 0, 0, 0, 604, 604,
+	602, 0, 603, 606, 607, 447, 458, 0, 0, 454,
+	0, 602, 0, 0, 320, 223, 226, 224, 225, 228,
+	0, 0, 276, 0, 0, 0, 114, 111, 94, 95,
+	0, 0, 0, 0, 116, 0, 104, 99, 0, 290,
+	0, 0, 290, 0, 121, 0, 128, 273, 0, 162,
+	163, 157, 160, 156, 0, 0, 137, 0, 140, -2,
+	187, -2, 189, 125, 0, 0, 148, 0, 0, 0,
+	500, 0, -2, 0, 0, -2, -2, 0, 0, 0,
+	0, 382, 502, 480, 0, 374, 374, 374, 374, 374,
+	0, 0, 0, 383, 384, 385, 386, 0, 0, 0,
+	179, 0, 388, 0, 0, 0, 0, 349, 0, 0,
+	536, 0, 0, 47, 28, 549, 253, 255, 0, 258,
+	267, 268, 0, 0, -2, 0, 323, 330, 331, 332,
+	0, 0, 520, 232, 515, 0, 498, 520, 0, 234,
+	496, 602, 0, 0, 221, 0, 0, 0, 0, 0,
+	0, 0, 605, 0, 0, 604, 494, 448, 0, 458,
+	0, 455, 457, 0, 0, 0, 269, 277, 0, -2,
+	0, 113, 111, 0, 109, 0, 0, 0, 269, 0,
+	97, 117, 118, 0, 0, 0, 106, 0, 0, 489,
+	0, 0, 435, 374, 0, 126, 0, 274, 273, 0,
+	0, 0, 0, 0, 0, 142, 0, 150, 146, 144,
+	89, 34, 5, -2, 555, 0, 0, 0, -2, -2,
+	0, 0, 376, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 350, 337, 0, 180, 0, 0, 0, 0,
+	318, 45, 0, -2, 485, 486, 550, 0, 254, 256,
+	0, 0, 263, 0, 322, 0, 325, 520, 0, 505,
+	0, 234, 0, 0, 517, 234, 520, 0, 602, 0,
+	250, 237, 242, 238, 0, 0, 0, 0, 0, 469,
+	602, 0, 0, 0, 0, 0, 449, 0, 0, 0,
+	444, 0, 0, 275, 269, 115, 112, 108, 0, 269,
+	133, 131, 0, 119, 120, 116, 0, 105, 100, 0,
+	101, -2, 103, 0, 0, 269, 0, 0, 0, 158,
+	164, 161, 0, 159, 0, 0, 0, 152, 539, 0,
+	-2, 0, 0, 0, 0, 0, 0, 0, 0, 382,
+	383, 384, 385, 386, 388, 0, 0, 0, 0, 0,
+	0, 0, 0, 391, 392, 46, 533, 0, 259, 0,
+	0, 264, 324, 333, 334, 0, 503, 269, 521, 520,
+	516, 514, 520, 518, 0, 0, 602, 0, 0, 0,
+	0, 0, 0, 470, 0, 0, 602, 602, 473, 0,
+	458, 0, 0, 461, 462, 290, 0, 0, 0, 278,
+	0, 93, 0, 96, 129, 0, 98, 107, 490, 436,
+	437, 374, 127, -2, 0, 0, 0, -2, 0, 135,
+	0, 539, -2, 0, 0, 556, -2, 35, 36, 0,
+	0, 269, 0, 408, 0, 0, 0, 0, 0, 0,
+	408, 408, 0, 408, 0, 0, 251, 390, 534, -2,
+	260, 261, 0, 326, 0, 520, 513, 519, 522, 529,
+	0, 0, 0, 243, 0, 0, 0, 0, 0, 475,
+	0, 471, 0, 474, 450, 458, 459, 442, 443, 445,
+	229, 271, 0, 269, 110, 269, 134, 0, 0, 0,
+	56, 57, 0, 483, 68, 69, 0, 61, 0, -2,
+	0, 0, 0, 0, 0, 540, 0, 52, 553, 37,
+	38, 0, 511, 0, 0, 406, 251, 0, 408, 408,
+	408, 408, 408, 408, 0, 251, 0, 0, 0, 0,
+	339, 0, 262, 520, 506, 530, 531, 0, 523, 0,
+	239, 240, 0, 247, 244, 269, 0, 0, 0, 472,
+	451, 0, 0, 0, 130, 438, 165, -2, 0, 0,
+	0, 305, 0, 62, 167, 0, 0, 169, -2, 50,
+	0, -2, 554, 0, 509, 0, 393, 405, 0, 0,
+	0, 0, 0, 0, 0, 0, 400, 401, 408, 403,
+	408, 389, 504, 0, 0, 531, 524, 241, 0, 245,
+	0, 0, 0, 476, 0, 279, 278, 7, -2, 559,
+	0, -2, 0, 0, 166, 168, 0, 51, 537, 0,
+	269, 0, 409, 394, 395, 396, 397, 398, 399, 0,
+	0, 0, 532, 0, 248, 0, 0, 467, 465, 0,
+	0, 272, 543, 0, -2, 0, 0, 0, 63, 64,
+	0, 483, 73, 74, 75, 0, 0, 170, 538, -2,
+	0, 512, 252, 402, 404, 0, 526, 0, 0, 0,
+	0, 0, 0, 460, 0, 543, -2, 0, 0, 560,
+	-2, 0, -2, 0, 0, -2, -2, 510, 407, 0,
+	0, 0, 0, 246, 463, 468, 466, 464, 0, 0,
+	544, 0, 67, 557, 58, 9, -2, 563, 0, 0,
+	0, 414, 0, 0, 0, 0, 414, 0, 525, 0,
+	0, 65, 0, -2, 558, 0, 547, 0, -2, 0,
+	0, 0, 410, 0, 0, 0, 426, 0, 0, 419,
+	420, 421, 412, 0, 527, 0, 66, 541, 0, 0,
+	547, -2, 0, 0, 564, -2, 59, 60, 0, 416,
+	417, 0, 0, 425, 422, 423, 424, 0, 0, 542,
+	-2, 0, 0, 548, 0, 72, 561, 415, 418, 414,
+	0, 428, 414, 0, 70, 0, -2, 562, 0, 411,
+	427, 413, 528, 71, 545, 0, 546, -2,
 }
-var yyTok1 = [...]int{
 
+var yyTok1 = [...]uint8{
 	1, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	3, 3, 3, 159, 3, 3, 3, 163, 3, 3,
-	160, 161, 155, 158, 164, 157, 165, 162, 3, 3,
-	3, 3, 3, 3, 3, 3, 3, 3, 3, 154,
-	3, 156,
+	3, 3, 3, 201, 3, 3, 3, 207, 3, 3,
+	202, 203, 197, 200, 208, 199, 209, 206, 3, 3,
+	3, 3, 3, 3, 3, 3, 3, 3, 3, 196,
+	3, 198, 3, 3, 3, 3, 3, 3, 3, 3,
+	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
+	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
+	3, 204, 3, 205,
 }
-var yyTok2 = [...]int{
 
+var yyTok2 = [...]uint8{
 	2, 3, 4, 5, 6, 7, 8, 9, 10, 11,
 	12, 13, 14, 15, 16, 17, 18, 19, 20, 21,
 	22, 23, 24, 25, 26, 27, 28, 29, 30, 31,
@@ -1534,9 +2176,14 @@ var yyTok2 = [...]int{
 	122, 123, 124, 125, 126, 127, 128, 129, 130, 131,
 	132, 133, 134, 135, 136, 137, 138, 139, 140, 141,
 	142, 143, 144, 145, 146, 147, 148, 149, 150, 151,
-	152, 153,
+	152, 153, 154, 155, 156, 157, 158, 159, 160, 161,
+	162, 163, 164, 165, 166, 167, 168, 169, 170, 171,
+	172, 173, 174, 175, 176, 177, 178, 179, 180, 181,
+	182, 183, 184, 185, 186, 187, 188, 189, 190, 191,
+	192, 193, 194, 195,
 }
-var yyTok3 = [...]int{
+
+var yyTok3 = [...]int8{
 	0,
 }
 
@@ -1579,7 +2226,7 @@ func yyNewParser() yyParser {
 	return &yyParserImpl{}
 }
 
-const yyFlag = -1000
+const yyFlag = -32768
 
 func yyTokname(c int) string {
 	if c >= 1 && c-1 < len(yyToknames) {
@@ -1618,9 +2265,9 @@ func yyErrorMessage(state, lookAhead int) string {
 	expected := make([]int, 0, 4)
 
 	// Look for shiftable tokens.
-	base := yyPact[state]
+	base := int(yyPact[state])
 	for tok := TOKSTART; tok-1 < len(yyToknames); tok++ {
-		if n := base + tok; n >= 0 && n < yyLast && yyChk[yyAct[n]] == tok {
+		if n := base + tok; n >= 0 && n < yyLast && int(yyChk[int(yyAct[n])]) == tok {
 			if len(expected) == cap(expected) {
 				return res
 			}
@@ -1630,13 +2277,13 @@ func yyErrorMessage(state, lookAhead int) string {
 
 	if yyDef[state] == -2 {
 		i := 0
-		for yyExca[i] != -1 || yyExca[i+1] != state {
+		for yyExca[i] != -1 || int(yyExca[i+1]) != state {
 			i += 2
 		}
 
 		// Look for tokens that we accept or reduce.
 		for i += 2; yyExca[i] >= 0; i += 2 {
-			tok := yyExca[i]
+			tok := int(yyExca[i])
 			if tok < TOKSTART || yyExca[i+1] == 0 {
 				continue
 			}
@@ -1667,30 +2314,30 @@ func yylex1(lex yyLexer, lval *yySymType) (char, token int) {
 	token = 0
 	char = lex.Lex(lval)
 	if char <= 0 {
-		token = yyTok1[0]
+		token = int(yyTok1[0])
 		goto out
 	}
 	if char < len(yyTok1) {
-		token = yyTok1[char]
+		token = int(yyTok1[char])
 		goto out
 	}
 	if char >= yyPrivate {
 		if char < yyPrivate+len(yyTok2) {
-			token = yyTok2[char-yyPrivate]
+			token = int(yyTok2[char-yyPrivate])
 			goto out
 		}
 	}
 	for i := 0; i < len(yyTok3); i += 2 {
-		token = yyTok3[i+0]
+		token = int(yyTok3[i+0])
 		if token == char {
-			token = yyTok3[i+1]
+			token = int(yyTok3[i+1])
 			goto out
 		}
 	}
 
 out:
 	if token == 0 {
-		token = yyTok2[1] /* unknown char */
+		token = int(yyTok2[1]) /* unknown char */
 	}
 	if yyDebug >= 3 {
 		__yyfmt__.Printf("lex %s(%d)\n", yyTokname(token), uint(char))
@@ -1745,7 +2392,7 @@ yystack:
 	yyS[yyp].yys = yystate
 
 yynewstate:
-	yyn = yyPact[yystate]
+	yyn = int(yyPact[yystate])
 	if yyn <= yyFlag {
 		goto yydefault /* simple state */
 	}
@@ -1756,8 +2403,8 @@ yynewstate:
 	if yyn < 0 || yyn >= yyLast {
 		goto yydefault
 	}
-	yyn = yyAct[yyn]
-	if yyChk[yyn] == yytoken { /* valid shift */
+	yyn = int(yyAct[yyn])
+	if int(yyChk[yyn]) == yytoken { /* valid shift */
 		yyrcvr.char = -1
 		yytoken = -1
 		yyVAL = yyrcvr.lval
@@ -1770,7 +2417,7 @@ yynewstate:
 
 yydefault:
 	/* default state action */
-	yyn = yyDef[yystate]
+	yyn = int(yyDef[yystate])
 	if yyn == -2 {
 		if yyrcvr.char < 0 {
 			yyrcvr.char, yytoken = yylex1(yylex, &yyrcvr.lval)
@@ -1779,18 +2426,18 @@ yydefault:
 		/* look through exception table */
 		xi := 0
 		for {
-			if yyExca[xi+0] == -1 && yyExca[xi+1] == yystate {
+			if yyExca[xi+0] == -1 && int(yyExca[xi+1]) == yystate {
 				break
 			}
 			xi += 2
 		}
 		for xi += 2; ; xi += 2 {
-			yyn = yyExca[xi+0]
+			yyn = int(yyExca[xi+0])
 			if yyn < 0 || yyn == yytoken {
 				break
 			}
 		}
-		yyn = yyExca[xi+1]
+		yyn = int(yyExca[xi+1])
 		if yyn < 0 {
 			goto ret0
 		}
@@ -1812,10 +2459,10 @@ yydefault:
 
 			/* find a state where "error" is a legal shift action */
 			for yyp >= 0 {
-				yyn = yyPact[yyS[yyp].yys] + yyErrCode
+				yyn = int(yyPact[yyS[yyp].yys]) + yyErrCode
 				if yyn >= 0 && yyn < yyLast {
-					yystate = yyAct[yyn] /* simulate a shift of "error" */
-					if yyChk[yystate] == yyErrCode {
+					yystate = int(yyAct[yyn]) /* simulate a shift of "error" */
+					if int(yyChk[yystate]) == yyErrCode {
 						goto yystack
 					}
 				}
@@ -1851,7 +2498,7 @@ yydefault:
 	yypt := yyp
 	_ = yypt // guard against "declared and not used"
 
-	yyp -= yyR2[yyn]
+	yyp -= int(yyR2[yyn])
 	// yyp is now the index of $0. Perform the default action. Iff the
 	// reduced production is ε, $1 is possibly out of range.
 	if yyp+1 >= len(yyS) {
@@ -1862,16 +2509,16 @@ yydefault:
 	yyVAL = yyS[yyp+1]
 
 	/* consult goto table to find next state */
-	yyn = yyR1[yyn]
-	yyg := yyPgo[yyn]
+	yyn = int(yyR1[yyn])
+	yyg := int(yyPgo[yyn])
 	yyj := yyg + yyS[yyp].yys + 1
 
 	if yyj >= yyLast {
-		yystate = yyAct[yyg]
+		yystate = int(yyAct[yyg])
 	} else {
-		yystate = yyAct[yyj]
-		if yyChk[yystate] != -yyn {
-			yystate = yyAct[yyg]
+		yystate = int(yyAct[yyj])
+		if int(yyChk[yystate]) != -yyn {
+			yystate = int(yyAct[yyg])
 		}
 	}
 	// dummy call; replaced with literal code
@@ -1879,1024 +2526,1320 @@ yydefault:
 
 	case 1:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line parser.y:234
+//line parser.y:292
 		{
 			yyVAL.program = nil
 			yylex.(*Lexer).program = yyVAL.program
 		}
 	case 2:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:239
+//line parser.y:297
 		{
 			yyVAL.program = []Statement{yyDollar[1].statement}
 			yylex.(*Lexer).program = yyVAL.program
 		}
 	case 3:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:244
+//line parser.y:302
 		{
 			yyVAL.program = append([]Statement{yyDollar[1].statement}, yyDollar[3].program...)
 			yylex.(*Lexer).program = yyVAL.program
 		}
 	case 4:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line parser.y:251
+//line parser.y:309
 		{
 			yyVAL.program = nil
 		}
 	case 5:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:255
+//line parser.y:313
 		{
 			yyVAL.program = append([]Statement{yyDollar[1].statement}, yyDollar[3].program...)
 		}
 	case 6:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line parser.y:261
+//line parser.y:319
 		{
 			yyVAL.program = nil
 		}
 	case 7:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:265
+//line parser.y:323
 		{
 			yyVAL.program = append([]Statement{yyDollar[1].statement}, yyDollar[3].program...)
 		}
 	case 8:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line parser.y:271
+//line parser.y:329
 		{
 			yyVAL.program = nil
 		}
 	case 9:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:275
+//line parser.y:333
 		{
 			yyVAL.program = append([]Statement{yyDollar[1].statement}, yyDollar[3].program...)
 		}
 	case 10:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:281
+//line parser.y:339
 		{
 			yyVAL.statement = yyDollar[1].queryexpr
 		}
 	case 11:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:285
+//line parser.y:343
 		{
 			yyVAL.statement = yyDollar[1].expression
 		}
 	case 12:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:289
+//line parser.y:347
 		{
 			yyVAL.statement = yyDollar[1].expression
 		}
 	case 13:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:293
+//line parser.y:351
 		{
 			yyVAL.statement = yyDollar[1].expression
 		}
 	case 14:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:297
+//line parser.y:355
 		{
-			yyVAL.statement = yyDollar[1].statement
+			yyVAL.statement = yyDollar[1].expression
 		}
 	case 15:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:301
+//line parser.y:359
 		{
-			yyVAL.statement = yyDollar[1].statement
+			yyVAL.statement = yyDollar[1].expression
 		}
 	case 16:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:305
+//line parser.y:363
 		{
 			yyVAL.statement = yyDollar[1].statement
 		}
 	case 17:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:309
+//line parser.y:367
 		{
 			yyVAL.statement = yyDollar[1].statement
 		}
 	case 18:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:313
+//line parser.y:371
 		{
 			yyVAL.statement = yyDollar[1].statement
 		}
 	case 19:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:317
+//line parser.y:375
 		{
 			yyVAL.statement = yyDollar[1].statement
 		}
 	case 20:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:321
+//line parser.y:379
 		{
 			yyVAL.statement = yyDollar[1].statement
 		}
 	case 21:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:325
+//line parser.y:383
 		{
 			yyVAL.statement = yyDollar[1].statement
 		}
 	case 22:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:329
+//line parser.y:387
 		{
 			yyVAL.statement = yyDollar[1].statement
 		}
 	case 23:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:333
+//line parser.y:391
 		{
 			yyVAL.statement = yyDollar[1].statement
 		}
 	case 24:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:337
+//line parser.y:395
 		{
-			yyVAL.statement = yyDollar[1].queryexpr
+			yyVAL.statement = yyDollar[1].statement
 		}
 	case 25:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:341
+//line parser.y:399
 		{
-			yyVAL.statement = ExternalCommand{BaseExpr: NewBaseExpr(yyDollar[1].token), Command: yyDollar[1].token.Literal}
+			yyVAL.statement = yyDollar[1].statement
 		}
 	case 26:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:347
+//line parser.y:403
 		{
-			yyVAL.statement = FlowControl{Token: yyDollar[1].token.Token}
+			yyVAL.statement = yyDollar[1].statement
 		}
 	case 27:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:351
+//line parser.y:407
 		{
-			yyVAL.statement = FlowControl{Token: yyDollar[1].token.Token}
+			yyVAL.statement = yyDollar[1].statement
 		}
 	case 28:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:357
+//line parser.y:411
 		{
-			yyVAL.statement = yyDollar[1].statement
+			yyVAL.statement = yyDollar[1].queryexpr
 		}
 	case 29:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:361
+//line parser.y:415
 		{
-			yyVAL.statement = yyDollar[1].statement
+			yyVAL.statement = ExternalCommand{BaseExpr: NewBaseExpr(yyDollar[1].token), Command: yyDollar[1].token.Literal}
 		}
 	case 30:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:421
+		{
+			yyVAL.statement = FlowControl{Token: yyDollar[1].token.Token}
+		}
+	case 31:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:425
+		{
+			yyVAL.statement = FlowControl{Token: yyDollar[1].token.Token}
+		}
+	case 32:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:431
+		{
+			yyVAL.statement = yyDollar[1].statement
+		}
+	case 33:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:435
+		{
+			yyVAL.statement = yyDollar[1].statement
+		}
+	case 34:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line parser.y:367
+//line parser.y:441
 		{
 			yyVAL.statement = While{Condition: yyDollar[2].queryexpr, Statements: yyDollar[4].program}
 		}
-	case 31:
+	case 35:
 		yyDollar = yyS[yypt-8 : yypt+1]
-		//line parser.y:371
+//line parser.y:445
 		{
 			yyVAL.statement = WhileInCursor{Variables: []Variable{yyDollar[2].variable}, Cursor: yyDollar[4].identifier, Statements: yyDollar[6].program}
 		}
-	case 32:
+	case 36:
 		yyDollar = yyS[yypt-8 : yypt+1]
-		//line parser.y:375
+//line parser.y:449
 		{
 			yyVAL.statement = WhileInCursor{Variables: yyDollar[2].variables, Cursor: yyDollar[4].identifier, Statements: yyDollar[6].program}
 		}
-	case 33:
+	case 37:
 		yyDollar = yyS[yypt-9 : yypt+1]
-		//line parser.y:379
+//line parser.y:453
 		{
 			yyVAL.statement = WhileInCursor{WithDeclaration: true, Variables: []Variable{yyDollar[3].variable}, Cursor: yyDollar[5].identifier, Statements: yyDollar[7].program}
 		}
-	case 34:
+	case 38:
 		yyDollar = yyS[yypt-9 : yypt+1]
-		//line parser.y:383
+//line parser.y:457
 		{
 			yyVAL.statement = WhileInCursor{WithDeclaration: true, Variables: yyDollar[3].variables, Cursor: yyDollar[5].identifier, Statements: yyDollar[7].program}
 		}
-	case 35:
+	case 39:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:389
+//line parser.y:463
 		{
 			yyVAL.token = yyDollar[1].token
 		}
-	case 36:
+	case 40:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:393
+//line parser.y:467
 		{
 			yyVAL.token = yyDollar[1].token
 		}
-	case 37:
+	case 41:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:399
+//line parser.y:473
 		{
 			yyVAL.statement = Exit{}
 		}
-	case 38:
+	case 42:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:403
+//line parser.y:477
 		{
 			yyVAL.statement = Exit{Code: value.NewIntegerFromString(yyDollar[2].token.Literal)}
 		}
-	case 39:
+	case 43:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:409
+//line parser.y:483
 		{
 			yyVAL.statement = yyDollar[1].statement
 		}
-	case 40:
+	case 44:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:413
+//line parser.y:487
 		{
 			yyVAL.statement = yyDollar[1].statement
 		}
-	case 41:
+	case 45:
 		yyDollar = yyS[yypt-7 : yypt+1]
-		//line parser.y:419
+//line parser.y:493
 		{
 			yyVAL.statement = If{Condition: yyDollar[2].queryexpr, Statements: yyDollar[4].program, Else: yyDollar[5].elseexpr}
 		}
-	case 42:
+	case 46:
 		yyDollar = yyS[yypt-8 : yypt+1]
-		//line parser.y:423
+//line parser.y:497
 		{
 			yyVAL.statement = If{Condition: yyDollar[2].queryexpr, Statements: yyDollar[4].program, ElseIf: yyDollar[5].elseif, Else: yyDollar[6].elseexpr}
 		}
-	case 43:
+	case 47:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line parser.y:427
+//line parser.y:501
 		{
 			yyVAL.statement = Case{Value: yyDollar[2].queryexpr, When: yyDollar[3].casewhen, Else: yyDollar[4].caseelse}
 		}
-	case 44:
+	case 48:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:431
+//line parser.y:505
 		{
 			yyVAL.statement = yyDollar[1].statement
 		}
-	case 45:
+	case 49:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:435
+//line parser.y:509
 		{
 			yyVAL.statement = yyDollar[1].statement
 		}
-	case 46:
+	case 50:
 		yyDollar = yyS[yypt-7 : yypt+1]
-		//line parser.y:441
+//line parser.y:515
 		{
 			yyVAL.statement = If{Condition: yyDollar[2].queryexpr, Statements: yyDollar[4].program, Else: yyDollar[5].elseexpr}
 		}
-	case 47:
+	case 51:
 		yyDollar = yyS[yypt-8 : yypt+1]
-		//line parser.y:445
+//line parser.y:519
 		{
 			yyVAL.statement = If{Condition: yyDollar[2].queryexpr, Statements: yyDollar[4].program, ElseIf: yyDollar[5].elseif, Else: yyDollar[6].elseexpr}
 		}
-	case 48:
+	case 52:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line parser.y:449
+//line parser.y:523
 		{
 			yyVAL.statement = Case{Value: yyDollar[2].queryexpr, When: yyDollar[3].casewhen, Else: yyDollar[4].caseelse}
 		}
-	case 49:
+	case 53:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:453
+//line parser.y:527
 		{
 			yyVAL.statement = yyDollar[1].statement
 		}
-	case 50:
+	case 54:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:457
+//line parser.y:531
 		{
 			yyVAL.statement = yyDollar[1].statement
 		}
-	case 51:
+	case 55:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:461
+//line parser.y:535
 		{
 			yyVAL.statement = yyDollar[1].statement
 		}
-	case 52:
+	case 56:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:467
+//line parser.y:541
 		{
 			yyVAL.statement = yyDollar[1].statement
 		}
-	case 53:
+	case 57:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:471
+//line parser.y:545
 		{
 			yyVAL.statement = yyDollar[1].statement
 		}
-	case 54:
+	case 58:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line parser.y:477
+//line parser.y:551
 		{
 			yyVAL.statement = While{Condition: yyDollar[2].queryexpr, Statements: yyDollar[4].program}
 		}
-	case 55:
+	case 59:
 		yyDollar = yyS[yypt-8 : yypt+1]
-		//line parser.y:481
+//line parser.y:555
 		{
 			yyVAL.statement = WhileInCursor{Variables: []Variable{yyDollar[2].variable}, Cursor: yyDollar[4].identifier, Statements: yyDollar[6].program}
 		}
-	case 56:
+	case 60:
 		yyDollar = yyS[yypt-8 : yypt+1]
-		//line parser.y:485
+//line parser.y:559
 		{
 			yyVAL.statement = WhileInCursor{Variables: yyDollar[2].variables, Cursor: yyDollar[4].identifier, Statements: yyDollar[6].program}
 		}
-	case 57:
+	case 61:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:491
+//line parser.y:565
 		{
 			yyVAL.statement = Return{Value: NewNullValue()}
 		}
-	case 58:
+	case 62:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:495
+//line parser.y:569
 		{
 			yyVAL.statement = Return{Value: yyDollar[2].queryexpr}
 		}
-	case 59:
+	case 63:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:501
+//line parser.y:575
 		{
 			yyVAL.statement = yyDollar[1].statement
 		}
-	case 60:
+	case 64:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:505
+//line parser.y:579
 		{
 			yyVAL.statement = yyDollar[1].statement
 		}
-	case 61:
+	case 65:
 		yyDollar = yyS[yypt-7 : yypt+1]
-		//line parser.y:511
+//line parser.y:585
 		{
 			yyVAL.statement = If{Condition: yyDollar[2].queryexpr, Statements: yyDollar[4].program, Else: yyDollar[5].elseexpr}
 		}
-	case 62:
+	case 66:
 		yyDollar = yyS[yypt-8 : yypt+1]
-		//line parser.y:515
+//line parser.y:589
 		{
 			yyVAL.statement = If{Condition: yyDollar[2].queryexpr, Statements: yyDollar[4].program, ElseIf: yyDollar[5].elseif, Else: yyDollar[6].elseexpr}
 		}
-	case 63:
+	case 67:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line parser.y:519
+//line parser.y:593
 		{
 			yyVAL.statement = Case{Value: yyDollar[2].queryexpr, When: yyDollar[3].casewhen, Else: yyDollar[4].caseelse}
 		}
-	case 64:
+	case 68:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:523
+//line parser.y:597
 		{
 			yyVAL.statement = yyDollar[1].statement
 		}
-	case 65:
+	case 69:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:527
+//line parser.y:601
 		{
 			yyVAL.statement = yyDollar[1].statement
 		}
-	case 66:
+	case 70:
 		yyDollar = yyS[yypt-7 : yypt+1]
-		//line parser.y:533
+//line parser.y:607
 		{
 			yyVAL.statement = If{Condition: yyDollar[2].queryexpr, Statements: yyDollar[4].program, Else: yyDollar[5].elseexpr}
 		}
-	case 67:
+	case 71:
 		yyDollar = yyS[yypt-8 : yypt+1]
-		//line parser.y:537
+//line parser.y:611
 		{
 			yyVAL.statement = If{Condition: yyDollar[2].queryexpr, Statements: yyDollar[4].program, ElseIf: yyDollar[5].elseif, Else: yyDollar[6].elseexpr}
 		}
-	case 68:
+	case 72:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line parser.y:541
+//line parser.y:615
 		{
 			yyVAL.statement = Case{Value: yyDollar[2].queryexpr, When: yyDollar[3].casewhen, Else: yyDollar[4].caseelse}
 		}
-	case 69:
+	case 73:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:545
+//line parser.y:619
 		{
 			yyVAL.statement = yyDollar[1].statement
 		}
-	case 70:
+	case 74:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:549
+//line parser.y:623
 		{
 			yyVAL.statement = yyDollar[1].statement
 		}
-	case 71:
+	case 75:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:553
+//line parser.y:627
 		{
 			yyVAL.statement = yyDollar[1].statement
 		}
-	case 72:
+	case 76:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:559
+//line parser.y:633
 		{
 			yyVAL.statement = VariableDeclaration{Assignments: yyDollar[2].varassigns}
 		}
-	case 73:
+	case 77:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:563
+//line parser.y:637
 		{
 			yyVAL.statement = VariableDeclaration{Assignments: yyDollar[2].varassigns}
 		}
-	case 74:
+	case 78:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:567
+//line parser.y:641
 		{
 			yyVAL.statement = yyDollar[1].queryexpr
 		}
-	case 75:
+	case 79:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:571
+//line parser.y:645
 		{
 			yyVAL.statement = DisposeVariable{Variable: yyDollar[2].variable}
 		}
-	case 76:
+	case 80:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:577
+//line parser.y:651
 		{
 			yyVAL.statement = SetEnvVar{EnvVar: yyDollar[2].envvar, Value: yyDollar[4].queryexpr}
 		}
-	case 77:
+	case 81:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:581
+//line parser.y:655
 		{
 			yyVAL.statement = SetEnvVar{EnvVar: yyDollar[2].envvar, Value: yyDollar[4].identifier}
 		}
-	case 78:
+	case 82:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:585
+//line parser.y:659
 		{
 			yyVAL.statement = SetEnvVar{EnvVar: yyDollar[2].envvar, Value: yyDollar[4].queryexpr}
 		}
-	case 79:
+	case 83:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:589
+//line parser.y:663
 		{
 			yyVAL.statement = SetEnvVar{EnvVar: yyDollar[2].envvar, Value: yyDollar[4].identifier}
 		}
-	case 80:
+	case 84:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:593
+//line parser.y:667
 		{
 			yyVAL.statement = UnsetEnvVar{EnvVar: yyDollar[2].envvar}
 		}
-	case 81:
+	case 85:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:599
+//line parser.y:673
 		{
 			yyVAL.statement = TransactionControl{BaseExpr: NewBaseExpr(yyDollar[1].token), Token: yyDollar[1].token.Token}
 		}
-	case 82:
+	case 86:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:603
+//line parser.y:677
 		{
 			yyVAL.statement = TransactionControl{BaseExpr: NewBaseExpr(yyDollar[1].token), Token: yyDollar[1].token.Token}
 		}
-	case 83:
+	case 87:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:681
+		{
+			yyVAL.statement = TransactionControl{BaseExpr: NewBaseExpr(yyDollar[1].token), Token: yyDollar[1].token.Token, Table: yyDollar[3].identifier}
+		}
+	case 88:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:685
+		{
+			yyVAL.statement = TransactionControl{BaseExpr: NewBaseExpr(yyDollar[1].token), Token: yyDollar[1].token.Token, Table: yyDollar[3].identifier}
+		}
+	case 89:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line parser.y:609
+//line parser.y:689
 		{
-			yyVAL.statement = CreateTable{Table: yyDollar[3].identifier, Fields: yyDollar[5].queryexprs}
+			yyVAL.statement = LockTable{BaseExpr: NewBaseExpr(yyDollar[1].token), Tables: yyDollar[3].queryexprs}
 		}
-	case 84:
+	case 90:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:693
+		{
+			yyVAL.statement = UnlockTable{BaseExpr: NewBaseExpr(yyDollar[1].token)}
+		}
+	case 91:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:697
+		{
+			yyVAL.statement = UnlockTable{BaseExpr: NewBaseExpr(yyDollar[1].token), Tables: yyDollar[3].queryexprs}
+		}
+	case 92:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line parser.y:703
+		{
+			fields, constraints := splitColumnDefinitions(yyDollar[5].columnspecs)
+			yyVAL.statement = CreateTable{Table: yyDollar[3].identifier, Fields: fields, Constraints: constraints}
+		}
+	case 93:
 		yyDollar = yyS[yypt-8 : yypt+1]
-		//line parser.y:613
+//line parser.y:708
 		{
-			yyVAL.statement = CreateTable{Table: yyDollar[3].identifier, Fields: yyDollar[5].queryexprs, Query: yyDollar[8].queryexpr}
+			fields, constraints := splitColumnDefinitions(yyDollar[5].columnspecs)
+			yyVAL.statement = CreateTable{Table: yyDollar[3].identifier, Fields: fields, Constraints: constraints, Query: yyDollar[8].queryexpr}
 		}
-	case 85:
+	case 94:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line parser.y:617
+//line parser.y:713
 		{
 			yyVAL.statement = CreateTable{Table: yyDollar[3].identifier, Query: yyDollar[5].queryexpr}
 		}
-	case 86:
+	case 95:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line parser.y:717
+		{
+			yyVAL.statement = CreateView{BaseExpr: NewBaseExpr(yyDollar[1].token), View: yyDollar[3].identifier, Query: yyDollar[5].queryexpr}
+		}
+	case 96:
+		yyDollar = yyS[yypt-8 : yypt+1]
+//line parser.y:721
+		{
+			yyVAL.statement = CreateView{BaseExpr: NewBaseExpr(yyDollar[1].token), View: yyDollar[3].identifier, Fields: yyDollar[5].queryexprs, Query: yyDollar[8].queryexpr}
+		}
+	case 97:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line parser.y:621
+//line parser.y:725
 		{
 			yyVAL.statement = AddColumns{Table: yyDollar[3].queryexpr, Columns: []ColumnDefault{yyDollar[5].columndef}, Position: yyDollar[6].expression}
 		}
-	case 87:
+	case 98:
 		yyDollar = yyS[yypt-8 : yypt+1]
-		//line parser.y:625
+//line parser.y:729
 		{
 			yyVAL.statement = AddColumns{Table: yyDollar[3].queryexpr, Columns: yyDollar[6].columndefs, Position: yyDollar[8].expression}
 		}
-	case 88:
+	case 99:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line parser.y:629
+//line parser.y:733
 		{
 			yyVAL.statement = DropColumns{Table: yyDollar[3].queryexpr, Columns: []QueryExpression{yyDollar[5].queryexpr}}
 		}
-	case 89:
+	case 100:
 		yyDollar = yyS[yypt-7 : yypt+1]
-		//line parser.y:633
+//line parser.y:737
 		{
 			yyVAL.statement = DropColumns{Table: yyDollar[3].queryexpr, Columns: yyDollar[6].queryexprs}
 		}
-	case 90:
+	case 101:
 		yyDollar = yyS[yypt-7 : yypt+1]
-		//line parser.y:637
+//line parser.y:741
 		{
 			yyVAL.statement = RenameColumn{Table: yyDollar[3].queryexpr, Old: yyDollar[5].queryexpr, New: yyDollar[7].identifier}
 		}
-	case 91:
+	case 102:
 		yyDollar = yyS[yypt-7 : yypt+1]
-		//line parser.y:641
+//line parser.y:745
 		{
 			yyVAL.statement = SetTableAttribute{BaseExpr: NewBaseExpr(yyDollar[1].token), Table: yyDollar[3].queryexpr, Attribute: yyDollar[5].identifier, Value: yyDollar[7].identifier}
 		}
-	case 92:
+	case 103:
 		yyDollar = yyS[yypt-7 : yypt+1]
-		//line parser.y:645
+//line parser.y:749
 		{
 			yyVAL.statement = SetTableAttribute{BaseExpr: NewBaseExpr(yyDollar[1].token), Table: yyDollar[3].queryexpr, Attribute: yyDollar[5].identifier, Value: yyDollar[7].queryexpr}
 		}
-	case 93:
+	case 104:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:651
+//line parser.y:755
 		{
 			yyVAL.columndef = ColumnDefault{Column: yyDollar[1].identifier}
 		}
-	case 94:
+	case 105:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:655
+//line parser.y:759
 		{
 			yyVAL.columndef = ColumnDefault{Column: yyDollar[1].identifier, Value: yyDollar[3].queryexpr}
 		}
-	case 95:
+	case 106:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:661
+//line parser.y:765
 		{
 			yyVAL.columndefs = []ColumnDefault{yyDollar[1].columndef}
 		}
-	case 96:
+	case 107:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:665
+//line parser.y:769
 		{
 			yyVAL.columndefs = append([]ColumnDefault{yyDollar[1].columndef}, yyDollar[3].columndefs...)
 		}
-	case 97:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line parser.y:671
-		{
+	case 108:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line parser.y:775
+		{
+			yyVAL.constraint = ColumnConstraint{BaseExpr: NewBaseExpr(yyDollar[1].token), Type: yyDollar[1].token}
+		}
+	case 109:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:779
+		{
+			yyVAL.constraint = ColumnConstraint{BaseExpr: NewBaseExpr(yyDollar[1].token), Type: yyDollar[1].token}
+		}
+	case 110:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line parser.y:783
+		{
+			yyVAL.constraint = ColumnConstraint{BaseExpr: NewBaseExpr(yyDollar[1].token), Type: yyDollar[1].token, Condition: yyDollar[3].queryexpr}
+		}
+	case 111:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line parser.y:789
+		{
+			yyVAL.constraints = nil
+		}
+	case 112:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line parser.y:793
+		{
+			yyVAL.constraints = append([]ColumnConstraint{yyDollar[1].constraint}, yyDollar[2].constraints...)
+		}
+	case 113:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line parser.y:799
+		{
+			for i := range yyDollar[2].constraints {
+				yyDollar[2].constraints[i].Column = yyDollar[1].identifier
+			}
+			yyVAL.columnspec = ColumnDefinition{Column: yyDollar[1].identifier, Constraints: yyDollar[2].constraints}
+		}
+	case 114:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:808
+		{
+			yyVAL.columnspecs = []ColumnDefinition{yyDollar[1].columnspec}
+		}
+	case 115:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:812
+		{
+			yyVAL.columnspecs = append([]ColumnDefinition{yyDollar[1].columnspec}, yyDollar[3].columnspecs...)
+		}
+	case 116:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line parser.y:818
+		{
 			yyVAL.expression = nil
 		}
-	case 98:
+	case 117:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:675
+//line parser.y:822
 		{
 			yyVAL.expression = ColumnPosition{Position: yyDollar[1].token}
 		}
-	case 99:
+	case 118:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:679
+//line parser.y:826
 		{
 			yyVAL.expression = ColumnPosition{Position: yyDollar[1].token}
 		}
-	case 100:
+	case 119:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:683
+//line parser.y:830
 		{
 			yyVAL.expression = ColumnPosition{Position: yyDollar[1].token, Column: yyDollar[2].queryexpr}
 		}
-	case 101:
+	case 120:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:687
+//line parser.y:834
 		{
 			yyVAL.expression = ColumnPosition{Position: yyDollar[1].token, Column: yyDollar[2].queryexpr}
 		}
-	case 102:
+	case 121:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line parser.y:693
+//line parser.y:840
 		{
 			yyVAL.statement = CursorDeclaration{Cursor: yyDollar[2].identifier, Query: yyDollar[5].queryexpr.(SelectQuery)}
 		}
-	case 103:
+	case 122:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:697
+//line parser.y:844
 		{
 			yyVAL.statement = OpenCursor{Cursor: yyDollar[2].identifier}
 		}
-	case 104:
+	case 123:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:701
+//line parser.y:848
 		{
 			yyVAL.statement = CloseCursor{Cursor: yyDollar[2].identifier}
 		}
-	case 105:
+	case 124:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:705
+//line parser.y:852
 		{
 			yyVAL.statement = DisposeCursor{Cursor: yyDollar[3].identifier}
 		}
-	case 106:
+	case 125:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line parser.y:709
+//line parser.y:856
 		{
 			yyVAL.statement = FetchCursor{Position: yyDollar[2].fetchpos, Cursor: yyDollar[3].identifier, Variables: yyDollar[5].variables}
 		}
-	case 107:
+	case 126:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line parser.y:715
+//line parser.y:862
 		{
 			yyVAL.statement = ViewDeclaration{View: yyDollar[2].identifier, Fields: yyDollar[5].queryexprs}
 		}
-	case 108:
+	case 127:
 		yyDollar = yyS[yypt-8 : yypt+1]
-		//line parser.y:719
+//line parser.y:866
 		{
 			yyVAL.statement = ViewDeclaration{View: yyDollar[2].identifier, Fields: yyDollar[5].queryexprs, Query: yyDollar[8].queryexpr}
 		}
-	case 109:
+	case 128:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line parser.y:723
+//line parser.y:870
 		{
 			yyVAL.statement = ViewDeclaration{View: yyDollar[2].identifier, Query: yyDollar[5].queryexpr}
 		}
-	case 110:
+	case 129:
+		yyDollar = yyS[yypt-8 : yypt+1]
+//line parser.y:874
+		{
+			yyVAL.statement = ViewDeclaration{View: yyDollar[4].identifier, Fields: yyDollar[6].queryexprs, PrimaryKey: yyDollar[8].queryexprs}
+		}
+	case 130:
+		yyDollar = yyS[yypt-10 : yypt+1]
+//line parser.y:878
+		{
+			yyVAL.statement = ViewDeclaration{View: yyDollar[4].identifier, Fields: yyDollar[6].queryexprs, PrimaryKey: yyDollar[8].queryexprs, Query: yyDollar[10].queryexpr}
+		}
+	case 131:
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line parser.y:882
+		{
+			yyVAL.statement = ViewDeclaration{View: yyDollar[4].identifier, PrimaryKey: yyDollar[5].queryexprs, Query: yyDollar[7].queryexpr}
+		}
+	case 132:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:727
+//line parser.y:886
 		{
 			yyVAL.statement = DisposeView{View: yyDollar[3].identifier}
 		}
-	case 111:
+	case 133:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line parser.y:892
+		{
+			yyVAL.queryexprs = nil
+		}
+	case 134:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line parser.y:896
+		{
+			yyVAL.queryexprs = yyDollar[4].queryexprs
+		}
+	case 135:
+		yyDollar = yyS[yypt-8 : yypt+1]
+//line parser.y:902
+		{
+			yyVAL.statement = IndexDeclaration{Index: yyDollar[3].identifier, Table: yyDollar[5].identifier, Columns: yyDollar[7].queryexprs}
+		}
+	case 136:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:906
+		{
+			yyVAL.statement = DisposeIndex{Index: yyDollar[3].identifier}
+		}
+	case 137:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line parser.y:912
+		{
+			yyVAL.statement = SequenceDeclaration{Sequence: yyDollar[3].identifier, Start: yyDollar[4].queryexpr, Increment: yyDollar[5].queryexpr}
+		}
+	case 138:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:916
+		{
+			yyVAL.statement = DisposeSequence{Sequence: yyDollar[3].identifier}
+		}
+	case 139:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line parser.y:922
+		{
+			yyVAL.queryexpr = nil
+		}
+	case 140:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line parser.y:926
+		{
+			yyVAL.queryexpr = yyDollar[2].queryexpr
+		}
+	case 141:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line parser.y:932
+		{
+			yyVAL.queryexpr = nil
+		}
+	case 142:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line parser.y:936
+		{
+			yyVAL.queryexpr = yyDollar[2].queryexpr
+		}
+	case 143:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:733
+//line parser.y:942
 		{
 			yyVAL.replaceval = ReplaceValue{Value: yyDollar[1].queryexpr}
 		}
-	case 112:
+	case 144:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:737
+//line parser.y:946
 		{
 			yyVAL.replaceval = ReplaceValue{Value: yyDollar[1].queryexpr, Name: yyDollar[3].identifier}
 		}
-	case 113:
+	case 145:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:743
+//line parser.y:952
 		{
 			yyVAL.replacevals = []ReplaceValue{yyDollar[1].replaceval}
 		}
-	case 114:
+	case 146:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:747
+//line parser.y:956
 		{
 			yyVAL.replacevals = append([]ReplaceValue{yyDollar[1].replaceval}, yyDollar[3].replacevals...)
 		}
-	case 115:
+	case 147:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:962
+		{
+			yyVAL.stmtparam = StatementParameter{BaseExpr: yyDollar[1].identifier.BaseExpr, Type: yyDollar[1].identifier}
+		}
+	case 148:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line parser.y:966
+		{
+			yyVAL.stmtparam = StatementParameter{BaseExpr: yyDollar[1].identifier.BaseExpr, Name: yyDollar[1].identifier, Type: yyDollar[2].identifier}
+		}
+	case 149:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:972
+		{
+			yyVAL.stmtparams = []StatementParameter{yyDollar[1].stmtparam}
+		}
+	case 150:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:976
+		{
+			yyVAL.stmtparams = append([]StatementParameter{yyDollar[1].stmtparam}, yyDollar[3].stmtparams...)
+		}
+	case 151:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:753
+//line parser.y:982
 		{
 			yyVAL.statement = StatementPreparation{Name: yyDollar[2].identifier, Statement: value.NewString(yyDollar[4].token.Literal)}
 		}
-	case 116:
+	case 152:
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line parser.y:986
+		{
+			yyVAL.statement = StatementPreparation{Name: yyDollar[2].identifier, Parameters: yyDollar[4].stmtparams, Statement: value.NewString(yyDollar[7].token.Literal)}
+		}
+	case 153:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:757
+//line parser.y:990
 		{
 			yyVAL.statement = ExecuteStatement{BaseExpr: NewBaseExpr(yyDollar[1].token), Name: yyDollar[2].identifier}
 		}
-	case 117:
+	case 154:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:761
+//line parser.y:994
 		{
 			yyVAL.statement = ExecuteStatement{BaseExpr: NewBaseExpr(yyDollar[1].token), Name: yyDollar[2].identifier, Values: yyDollar[4].replacevals}
 		}
-	case 118:
+	case 155:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:765
+//line parser.y:998
 		{
 			yyVAL.statement = DisposeStatement{Name: yyDollar[3].identifier}
 		}
-	case 119:
+	case 156:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:771
+//line parser.y:1004
 		{
 			yyVAL.varassign = VariableAssignment{Variable: yyDollar[1].variable}
 		}
-	case 120:
+	case 157:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:777
+//line parser.y:1010
 		{
 			yyVAL.varassigns = []VariableAssignment{yyDollar[1].varassign}
 		}
-	case 121:
+	case 158:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:781
+//line parser.y:1014
 		{
 			yyVAL.varassigns = append(yyDollar[1].varassigns, yyDollar[3].varassign)
 		}
-	case 122:
+	case 159:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:787
+//line parser.y:1020
 		{
 			yyVAL.varassign = VariableAssignment{Variable: yyDollar[1].variable, Value: yyDollar[3].queryexpr}
 		}
-	case 123:
+	case 160:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:793
+//line parser.y:1026
 		{
 			yyVAL.varassigns = []VariableAssignment{yyDollar[1].varassign}
 		}
-	case 124:
+	case 161:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:797
+//line parser.y:1030
 		{
 			yyVAL.varassigns = append([]VariableAssignment{yyDollar[1].varassign}, yyDollar[3].varassigns...)
 		}
-	case 125:
+	case 162:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:803
+//line parser.y:1036
 		{
 			yyVAL.varassigns = yyDollar[1].varassigns
 		}
-	case 126:
+	case 163:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:807
+//line parser.y:1040
 		{
 			yyVAL.varassigns = yyDollar[1].varassigns
 		}
-	case 127:
+	case 164:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:811
+//line parser.y:1044
 		{
 			yyVAL.varassigns = append(yyDollar[1].varassigns, yyDollar[3].varassigns...)
 		}
-	case 128:
-		yyDollar = yyS[yypt-9 : yypt+1]
-		//line parser.y:817
+	case 165:
+		yyDollar = yyS[yypt-10 : yypt+1]
+//line parser.y:1050
 		{
-			yyVAL.statement = FunctionDeclaration{Name: yyDollar[2].identifier, Statements: yyDollar[8].program}
+			yyVAL.statement = FunctionDeclaration{Name: yyDollar[2].identifier, Deterministic: yyDollar[6].token, Statements: yyDollar[9].program}
 		}
-	case 129:
+	case 166:
+		yyDollar = yyS[yypt-11 : yypt+1]
+//line parser.y:1054
+		{
+			yyVAL.statement = FunctionDeclaration{Name: yyDollar[2].identifier, Parameters: yyDollar[5].varassigns, Deterministic: yyDollar[7].token, Statements: yyDollar[10].program}
+		}
+	case 167:
 		yyDollar = yyS[yypt-10 : yypt+1]
-		//line parser.y:821
+//line parser.y:1058
 		{
-			yyVAL.statement = FunctionDeclaration{Name: yyDollar[2].identifier, Parameters: yyDollar[5].varassigns, Statements: yyDollar[9].program}
+			yyVAL.statement = FunctionDeclaration{Name: yyDollar[2].identifier, Deterministic: yyDollar[6].token, Language: yyDollar[8].identifier, Source: value.NewString(yyDollar[10].token.Literal)}
 		}
-	case 130:
+	case 168:
+		yyDollar = yyS[yypt-11 : yypt+1]
+//line parser.y:1062
+		{
+			yyVAL.statement = FunctionDeclaration{Name: yyDollar[2].identifier, Parameters: yyDollar[5].varassigns, Deterministic: yyDollar[7].token, Language: yyDollar[9].identifier, Source: value.NewString(yyDollar[11].token.Literal)}
+		}
+	case 169:
 		yyDollar = yyS[yypt-10 : yypt+1]
-		//line parser.y:825
+//line parser.y:1066
 		{
 			yyVAL.statement = AggregateDeclaration{Name: yyDollar[2].identifier, Cursor: yyDollar[5].identifier, Statements: yyDollar[9].program}
 		}
-	case 131:
+	case 170:
 		yyDollar = yyS[yypt-12 : yypt+1]
-		//line parser.y:829
+//line parser.y:1070
 		{
 			yyVAL.statement = AggregateDeclaration{Name: yyDollar[2].identifier, Cursor: yyDollar[5].identifier, Parameters: yyDollar[7].varassigns, Statements: yyDollar[11].program}
 		}
-	case 132:
+	case 171:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:833
+//line parser.y:1074
 		{
 			yyVAL.statement = DisposeFunction{Name: yyDollar[3].identifier}
 		}
-	case 133:
+	case 172:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line parser.y:839
+//line parser.y:1080
 		{
 			yyVAL.fetchpos = FetchPosition{}
 		}
-	case 134:
+	case 173:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:843
+//line parser.y:1084
 		{
 			yyVAL.fetchpos = FetchPosition{Position: yyDollar[1].token}
 		}
-	case 135:
+	case 174:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:847
+//line parser.y:1088
 		{
 			yyVAL.fetchpos = FetchPosition{Position: yyDollar[1].token}
 		}
-	case 136:
+	case 175:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:851
+//line parser.y:1092
 		{
 			yyVAL.fetchpos = FetchPosition{Position: yyDollar[1].token}
 		}
-	case 137:
+	case 176:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:855
+//line parser.y:1096
 		{
 			yyVAL.fetchpos = FetchPosition{Position: yyDollar[1].token}
 		}
-	case 138:
+	case 177:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:859
+//line parser.y:1100
 		{
 			yyVAL.fetchpos = FetchPosition{BaseExpr: NewBaseExpr(yyDollar[1].token), Position: yyDollar[1].token, Number: yyDollar[2].queryexpr}
 		}
-	case 139:
+	case 178:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:863
+//line parser.y:1104
 		{
 			yyVAL.fetchpos = FetchPosition{BaseExpr: NewBaseExpr(yyDollar[1].token), Position: yyDollar[1].token, Number: yyDollar[2].queryexpr}
 		}
-	case 140:
+	case 179:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line parser.y:869
+//line parser.y:1110
 		{
 			yyVAL.queryexpr = CursorStatus{CursorLit: yyDollar[1].token.Literal, Cursor: yyDollar[2].identifier, Is: yyDollar[3].token.Literal, Negation: yyDollar[4].token, Type: yyDollar[5].token.Token, TypeLit: yyDollar[5].token.Literal}
 		}
-	case 141:
+	case 180:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line parser.y:873
+//line parser.y:1114
 		{
 			yyVAL.queryexpr = CursorStatus{CursorLit: yyDollar[1].token.Literal, Cursor: yyDollar[2].identifier, Is: yyDollar[3].token.Literal, Negation: yyDollar[4].token, Type: yyDollar[6].token.Token, TypeLit: yyDollar[5].token.Literal + " " + yyDollar[6].token.Literal}
 		}
-	case 142:
+	case 181:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:877
+//line parser.y:1118
 		{
 			yyVAL.queryexpr = CursorAttrebute{CursorLit: yyDollar[1].token.Literal, Cursor: yyDollar[2].identifier, Attrebute: yyDollar[3].token}
 		}
-	case 143:
+	case 182:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:883
+//line parser.y:1124
 		{
 			yyVAL.statement = SetFlag{BaseExpr: NewBaseExpr(yyDollar[1].token), Name: yyDollar[2].token.Literal, Value: yyDollar[4].identifier}
 		}
-	case 144:
+	case 183:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:887
+//line parser.y:1128
 		{
 			yyVAL.statement = SetFlag{BaseExpr: NewBaseExpr(yyDollar[1].token), Name: yyDollar[2].token.Literal, Value: yyDollar[4].queryexpr}
 		}
-	case 145:
+	case 184:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:891
+//line parser.y:1132
 		{
 			yyVAL.statement = SetFlag{BaseExpr: NewBaseExpr(yyDollar[1].token), Name: yyDollar[2].token.Literal, Value: yyDollar[4].identifier}
 		}
-	case 146:
+	case 185:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:895
+//line parser.y:1136
 		{
 			yyVAL.statement = SetFlag{BaseExpr: NewBaseExpr(yyDollar[1].token), Name: yyDollar[2].token.Literal, Value: yyDollar[4].queryexpr}
 		}
-	case 147:
+	case 186:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line parser.y:1140
+		{
+			yyVAL.statement = SetFlag{BaseExpr: NewBaseExpr(yyDollar[1].token), Local: yyDollar[2].token, Name: yyDollar[3].token.Literal, Value: yyDollar[5].identifier}
+		}
+	case 187:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line parser.y:1144
+		{
+			yyVAL.statement = SetFlag{BaseExpr: NewBaseExpr(yyDollar[1].token), Local: yyDollar[2].token, Name: yyDollar[3].token.Literal, Value: yyDollar[5].queryexpr}
+		}
+	case 188:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line parser.y:1148
+		{
+			yyVAL.statement = SetFlag{BaseExpr: NewBaseExpr(yyDollar[1].token), Local: yyDollar[2].token, Name: yyDollar[3].token.Literal, Value: yyDollar[5].identifier}
+		}
+	case 189:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line parser.y:1152
+		{
+			yyVAL.statement = SetFlag{BaseExpr: NewBaseExpr(yyDollar[1].token), Local: yyDollar[2].token, Name: yyDollar[3].token.Literal, Value: yyDollar[5].queryexpr}
+		}
+	case 190:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:899
+//line parser.y:1156
 		{
 			yyVAL.statement = AddFlagElement{BaseExpr: NewBaseExpr(yyDollar[1].token), Name: yyDollar[4].token.Literal, Value: yyDollar[2].queryexpr}
 		}
-	case 148:
+	case 191:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:903
+//line parser.y:1160
 		{
 			yyVAL.statement = RemoveFlagElement{BaseExpr: NewBaseExpr(yyDollar[1].token), Name: yyDollar[4].token.Literal, Value: yyDollar[2].queryexpr}
 		}
-	case 149:
+	case 192:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:907
+//line parser.y:1164
 		{
 			yyVAL.statement = ShowFlag{BaseExpr: NewBaseExpr(yyDollar[1].token), Name: yyDollar[2].token.Literal}
 		}
-	case 150:
+	case 193:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:911
+//line parser.y:1168
 		{
 			yyVAL.statement = Echo{Value: yyDollar[2].queryexpr}
 		}
-	case 151:
+	case 194:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:915
+//line parser.y:1172
 		{
 			yyVAL.statement = Print{Value: yyDollar[2].queryexpr}
 		}
-	case 152:
+	case 195:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:919
+//line parser.y:1176
 		{
 			yyVAL.statement = Printf{BaseExpr: NewBaseExpr(yyDollar[1].token), Format: yyDollar[2].queryexpr}
 		}
-	case 153:
+	case 196:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:923
+//line parser.y:1180
 		{
 			yyVAL.statement = Printf{BaseExpr: NewBaseExpr(yyDollar[1].token), Format: yyDollar[2].queryexpr, Values: yyDollar[4].queryexprs}
 		}
-	case 154:
+	case 197:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:927
+//line parser.y:1184
 		{
 			yyVAL.statement = Printf{BaseExpr: NewBaseExpr(yyDollar[1].token), Format: yyDollar[2].queryexpr, Values: yyDollar[4].queryexprs}
 		}
-	case 155:
+	case 198:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:931
+//line parser.y:1188
 		{
 			yyVAL.statement = Source{BaseExpr: NewBaseExpr(yyDollar[1].token), FilePath: yyDollar[2].identifier}
 		}
-	case 156:
+	case 199:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:935
+//line parser.y:1192
 		{
 			yyVAL.statement = Source{BaseExpr: NewBaseExpr(yyDollar[1].token), FilePath: yyDollar[2].queryexpr}
 		}
-	case 157:
+	case 200:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:939
+//line parser.y:1196
 		{
 			yyVAL.statement = Execute{BaseExpr: NewBaseExpr(yyDollar[1].token), Statements: yyDollar[2].queryexpr}
 		}
-	case 158:
+	case 201:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:943
+//line parser.y:1200
 		{
 			yyVAL.statement = Execute{BaseExpr: NewBaseExpr(yyDollar[1].token), Statements: yyDollar[2].queryexpr, Values: yyDollar[4].queryexprs}
 		}
-	case 159:
+	case 202:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:947
+//line parser.y:1204
 		{
 			yyVAL.statement = Syntax{BaseExpr: NewBaseExpr(yyDollar[1].token)}
 		}
-	case 160:
+	case 203:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:951
+//line parser.y:1208
 		{
 			yyVAL.statement = Syntax{BaseExpr: NewBaseExpr(yyDollar[1].token), Keywords: yyDollar[2].queryexprs}
 		}
-	case 161:
+	case 204:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:955
+//line parser.y:1212
 		{
 			yyVAL.statement = ShowObjects{BaseExpr: NewBaseExpr(yyDollar[1].token), Type: yyDollar[2].identifier}
 		}
-	case 162:
+	case 205:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:959
+//line parser.y:1216
 		{
 			yyVAL.statement = ShowFields{BaseExpr: NewBaseExpr(yyDollar[1].token), Type: yyDollar[2].identifier, Table: yyDollar[4].queryexpr}
 		}
-	case 163:
+	case 206:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:963
+//line parser.y:1220
 		{
 			yyVAL.statement = Chdir{BaseExpr: NewBaseExpr(yyDollar[1].token), DirPath: yyDollar[2].identifier}
 		}
-	case 164:
+	case 207:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:967
+//line parser.y:1224
 		{
 			yyVAL.statement = Chdir{BaseExpr: NewBaseExpr(yyDollar[1].token), DirPath: yyDollar[2].queryexpr}
 		}
-	case 165:
+	case 208:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:971
+//line parser.y:1228
 		{
 			yyVAL.statement = Pwd{BaseExpr: NewBaseExpr(yyDollar[1].token)}
 		}
-	case 166:
+	case 209:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:975
+//line parser.y:1232
 		{
 			yyVAL.statement = Reload{BaseExpr: NewBaseExpr(yyDollar[1].token), Type: yyDollar[2].identifier}
 		}
-	case 167:
+	case 210:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line parser.y:1236
+		{
+			yyVAL.statement = Explain{BaseExpr: NewBaseExpr(yyDollar[1].token), Query: yyDollar[2].queryexpr}
+		}
+	case 211:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:1240
+		{
+			yyVAL.statement = Explain{BaseExpr: NewBaseExpr(yyDollar[1].token), Option: yyDollar[2].identifier, Query: yyDollar[3].queryexpr}
+		}
+	case 212:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:981
+//line parser.y:1246
 		{
 			yyVAL.statement = Trigger{BaseExpr: NewBaseExpr(yyDollar[1].token), Event: yyDollar[2].identifier}
 		}
-	case 168:
+	case 213:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:985
+//line parser.y:1250
 		{
 			yyVAL.statement = Trigger{BaseExpr: NewBaseExpr(yyDollar[1].token), Event: yyDollar[2].identifier, Message: yyDollar[3].queryexpr}
 		}
-	case 169:
+	case 214:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:989
+//line parser.y:1254
 		{
 			yyVAL.statement = Trigger{BaseExpr: NewBaseExpr(yyDollar[1].token), Event: yyDollar[2].identifier, Message: yyDollar[4].queryexpr, Code: value.NewIntegerFromString(yyDollar[3].token.Literal)}
 		}
-	case 170:
+	case 215:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:1260
+		{
+			yyVAL.queryexpr = SelectQuery{
+				WithClause:    yyDollar[1].queryexpr,
+				SelectEntity:  yyDollar[2].queryexpr,
+				OrderByClause: yyDollar[3].queryexpr,
+			}
+		}
+	case 216:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line parser.y:1268
+		{
+			yyVAL.queryexpr = SelectQuery{
+				WithClause:    yyDollar[1].queryexpr,
+				SelectEntity:  yyDollar[2].queryexpr,
+				OrderByClause: yyDollar[3].queryexpr,
+				LimitClause:   yyDollar[4].queryexpr,
+			}
+		}
+	case 217:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line parser.y:995
+//line parser.y:1277
 		{
 			yyVAL.queryexpr = SelectQuery{
 				WithClause:    yyDollar[1].queryexpr,
@@ -2906,9 +3849,43 @@ yydefault:
 				OffsetClause:  yyDollar[5].queryexpr,
 			}
 		}
-	case 171:
+	case 218:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line parser.y:1287
+		{
+			yyVAL.queryexpr = SelectQuery{
+				WithClause:    yyDollar[1].queryexpr,
+				SelectEntity:  yyDollar[2].queryexpr,
+				OrderByClause: yyDollar[3].queryexpr,
+				OffsetClause:  yyDollar[4].queryexpr,
+			}
+		}
+	case 219:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line parser.y:1296
+		{
+			yyVAL.queryexpr = SelectQuery{
+				WithClause:    yyDollar[1].queryexpr,
+				SelectEntity:  yyDollar[2].queryexpr,
+				OrderByClause: yyDollar[3].queryexpr,
+				LimitClause:   yyDollar[5].queryexpr,
+				OffsetClause:  yyDollar[4].queryexpr,
+			}
+		}
+	case 220:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line parser.y:1306
+		{
+			yyVAL.queryexpr = SelectQuery{
+				WithClause:    yyDollar[1].queryexpr,
+				SelectEntity:  yyDollar[2].queryexpr,
+				OrderByClause: yyDollar[3].queryexpr,
+				LimitClause:   yyDollar[4].queryexpr,
+			}
+		}
+	case 221:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line parser.y:1007
+//line parser.y:1317
 		{
 			yyVAL.queryexpr = SelectEntity{
 				SelectClause:  yyDollar[1].queryexpr,
@@ -2918,9 +3895,15 @@ yydefault:
 				HavingClause:  yyDollar[5].queryexpr,
 			}
 		}
-	case 172:
+	case 222:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line parser.y:1327
+		{
+			yyVAL.queryexpr = ValuesTable{BaseExpr: NewBaseExpr(yyDollar[1].token), Values: yyDollar[1].token.Literal, ValuesList: yyDollar[2].queryexprs}
+		}
+	case 223:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:1017
+//line parser.y:1331
 		{
 			yyVAL.queryexpr = SelectSet{
 				LHS:      yyDollar[1].queryexpr,
@@ -2929,9 +3912,9 @@ yydefault:
 				RHS:      yyDollar[4].queryexpr,
 			}
 		}
-	case 173:
+	case 224:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:1026
+//line parser.y:1340
 		{
 			yyVAL.queryexpr = SelectSet{
 				LHS:      yyDollar[1].queryexpr,
@@ -2940,9 +3923,9 @@ yydefault:
 				RHS:      yyDollar[4].queryexpr,
 			}
 		}
-	case 174:
+	case 225:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:1035
+//line parser.y:1349
 		{
 			yyVAL.queryexpr = SelectSet{
 				LHS:      yyDollar[1].queryexpr,
@@ -2951,339 +3934,538 @@ yydefault:
 				RHS:      yyDollar[4].queryexpr,
 			}
 		}
-	case 175:
+	case 226:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1046
+//line parser.y:1360
 		{
 			yyVAL.queryexpr = yyDollar[1].queryexpr
 		}
-	case 176:
+	case 227:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1050
+//line parser.y:1364
 		{
 			yyVAL.queryexpr = yyDollar[1].queryexpr
 		}
-	case 177:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:1056
+	case 228:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line parser.y:1370
 		{
-			yyVAL.queryexpr = SelectClause{BaseExpr: NewBaseExpr(yyDollar[1].token), Select: yyDollar[1].token.Literal, Distinct: yyDollar[2].token, Fields: yyDollar[3].queryexprs}
+			yyVAL.queryexpr = SelectClause{BaseExpr: NewBaseExpr(yyDollar[1].token), Select: yyDollar[1].token.Literal, Hints: yyDollar[2].hints, Distinct: yyDollar[3].token, Fields: yyDollar[4].queryexprs}
 		}
-	case 178:
+	case 229:
+		yyDollar = yyS[yypt-8 : yypt+1]
+//line parser.y:1374
+		{
+			yyVAL.queryexpr = SelectClause{BaseExpr: NewBaseExpr(yyDollar[1].token), Select: yyDollar[1].token.Literal, Hints: yyDollar[2].hints, Distinct: yyDollar[3].token, DistinctOn: DistinctOn{BaseExpr: NewBaseExpr(yyDollar[4].token), On: yyDollar[4].token.Literal, Values: yyDollar[6].queryexprs}, Fields: yyDollar[8].queryexprs}
+		}
+	case 230:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line parser.y:1062
+//line parser.y:1380
+		{
+			yyVAL.hints = nil
+		}
+	case 231:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:1384
+		{
+			yyVAL.hints = ParseHints(yyDollar[1].token.Literal)
+		}
+	case 232:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line parser.y:1390
 		{
 			yyVAL.queryexpr = nil
 		}
-	case 179:
+	case 233:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:1066
+//line parser.y:1394
 		{
 			yyVAL.queryexpr = FromClause{From: yyDollar[1].token.Literal, Tables: yyDollar[2].queryexprs}
 		}
-	case 180:
+	case 234:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line parser.y:1072
+//line parser.y:1400
 		{
 			yyVAL.queryexpr = nil
 		}
-	case 181:
+	case 235:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:1076
+//line parser.y:1404
 		{
 			yyVAL.queryexpr = WhereClause{Where: yyDollar[1].token.Literal, Filter: yyDollar[2].queryexpr}
 		}
-	case 182:
+	case 236:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line parser.y:1082
+//line parser.y:1410
 		{
 			yyVAL.queryexpr = nil
 		}
-	case 183:
+	case 237:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:1086
+//line parser.y:1414
 		{
 			yyVAL.queryexpr = GroupByClause{GroupBy: yyDollar[1].token.Literal + " " + yyDollar[2].token.Literal, Items: yyDollar[3].queryexprs}
 		}
-	case 184:
+	case 238:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:1420
+		{
+			yyVAL.queryexpr = yyDollar[1].queryexpr
+		}
+	case 239:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line parser.y:1424
+		{
+			yyVAL.queryexpr = Rollup{BaseExpr: NewBaseExpr(yyDollar[1].token), Rollup: yyDollar[1].token.Literal, Items: yyDollar[3].queryexprs}
+		}
+	case 240:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line parser.y:1428
+		{
+			yyVAL.queryexpr = Cube{BaseExpr: NewBaseExpr(yyDollar[1].token), Cube: yyDollar[1].token.Literal, Items: yyDollar[3].queryexprs}
+		}
+	case 241:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line parser.y:1432
+		{
+			yyVAL.queryexpr = GroupingSets{BaseExpr: NewBaseExpr(yyDollar[1].token), GroupingSets: yyDollar[1].token.Literal + " " + yyDollar[2].token.Literal, Items: yyDollar[4].queryexprs}
+		}
+	case 242:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:1438
+		{
+			yyVAL.queryexprs = []QueryExpression{yyDollar[1].queryexpr}
+		}
+	case 243:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:1442
+		{
+			yyVAL.queryexprs = append([]QueryExpression{yyDollar[1].queryexpr}, yyDollar[3].queryexprs...)
+		}
+	case 244:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:1448
+		{
+			yyVAL.queryexpr = yyDollar[1].queryexpr
+		}
+	case 245:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line parser.y:1452
+		{
+			yyVAL.queryexpr = ValueList{BaseExpr: NewBaseExpr(yyDollar[1].token)}
+		}
+	case 246:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line parser.y:1456
+		{
+			yyVAL.queryexpr = ValueList{BaseExpr: NewBaseExpr(yyDollar[1].token), Values: append([]QueryExpression{yyDollar[2].queryexpr}, yyDollar[4].queryexprs...)}
+		}
+	case 247:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:1462
+		{
+			yyVAL.queryexprs = []QueryExpression{yyDollar[1].queryexpr}
+		}
+	case 248:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:1466
+		{
+			yyVAL.queryexprs = append([]QueryExpression{yyDollar[1].queryexpr}, yyDollar[3].queryexprs...)
+		}
+	case 249:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line parser.y:1092
+//line parser.y:1472
 		{
 			yyVAL.queryexpr = nil
 		}
-	case 185:
+	case 250:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:1096
+//line parser.y:1476
 		{
 			yyVAL.queryexpr = HavingClause{Having: yyDollar[1].token.Literal, Filter: yyDollar[2].queryexpr}
 		}
-	case 186:
+	case 251:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line parser.y:1102
+//line parser.y:1482
 		{
 			yyVAL.queryexpr = nil
 		}
-	case 187:
+	case 252:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:1106
+//line parser.y:1486
 		{
 			yyVAL.queryexpr = OrderByClause{OrderBy: yyDollar[1].token.Literal + " " + yyDollar[2].token.Literal, Items: yyDollar[3].queryexprs}
 		}
-	case 188:
+	case 253:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:1492
+		{
+			yyVAL.queryexpr = LimitClause{BaseExpr: NewBaseExpr(yyDollar[1].token), Limit: yyDollar[1].token.Literal, Value: yyDollar[2].queryexpr, With: yyDollar[3].queryexpr}
+		}
+	case 254:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line parser.y:1496
+		{
+			yyVAL.queryexpr = LimitClause{BaseExpr: NewBaseExpr(yyDollar[1].token), Limit: yyDollar[1].token.Literal, Value: yyDollar[2].queryexpr, Percent: yyDollar[3].token.Literal, With: yyDollar[4].queryexpr}
+		}
+	case 255:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line parser.y:1112
+//line parser.y:1502
 		{
 			yyVAL.queryexpr = nil
 		}
-	case 189:
+	case 256:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line parser.y:1506
+		{
+			yyVAL.queryexpr = LimitWith{With: yyDollar[1].token.Literal, Type: yyDollar[2].token}
+		}
+	case 257:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line parser.y:1512
+		{
+			yyVAL.queryexpr = OffsetClause{BaseExpr: NewBaseExpr(yyDollar[1].token), Offset: yyDollar[1].token.Literal, Value: yyDollar[2].queryexpr}
+		}
+	case 258:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:1116
+//line parser.y:1516
 		{
-			yyVAL.queryexpr = LimitClause{BaseExpr: NewBaseExpr(yyDollar[1].token), Limit: yyDollar[1].token.Literal, Value: yyDollar[2].queryexpr, With: yyDollar[3].queryexpr}
+			yyVAL.queryexpr = OffsetClause{BaseExpr: NewBaseExpr(yyDollar[1].token), Offset: yyDollar[1].token.Literal, Value: yyDollar[2].queryexpr, Unit: yyDollar[3].token}
 		}
-	case 190:
+	case 259:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line parser.y:1522
+		{
+			yyVAL.queryexpr = LimitClause{BaseExpr: NewBaseExpr(yyDollar[1].token), Limit: yyDollar[1].token.Literal, Position: yyDollar[2].token, Value: yyDollar[3].queryexpr, Unit: yyDollar[4].token, With: LimitWith{Type: yyDollar[5].token}}
+		}
+	case 260:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line parser.y:1526
+		{
+			yyVAL.queryexpr = LimitClause{BaseExpr: NewBaseExpr(yyDollar[1].token), Limit: yyDollar[1].token.Literal, Position: yyDollar[2].token, Value: yyDollar[3].queryexpr, Unit: yyDollar[4].token, With: LimitWith{With: yyDollar[5].token.Literal, Type: yyDollar[6].token}}
+		}
+	case 261:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line parser.y:1530
+		{
+			yyVAL.queryexpr = LimitClause{BaseExpr: NewBaseExpr(yyDollar[1].token), Limit: yyDollar[1].token.Literal, Position: yyDollar[2].token, Value: yyDollar[3].queryexpr, Percent: yyDollar[4].token.Literal, Unit: yyDollar[5].token, With: LimitWith{Type: yyDollar[6].token}}
+		}
+	case 262:
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line parser.y:1534
+		{
+			yyVAL.queryexpr = LimitClause{BaseExpr: NewBaseExpr(yyDollar[1].token), Limit: yyDollar[1].token.Literal, Position: yyDollar[2].token, Value: yyDollar[3].queryexpr, Percent: yyDollar[4].token.Literal, Unit: yyDollar[5].token, With: LimitWith{With: yyDollar[6].token.Literal, Type: yyDollar[7].token}}
+		}
+	case 263:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:1120
+//line parser.y:1538
+		{
+			yyVAL.queryexpr = LimitClause{BaseExpr: NewBaseExpr(yyDollar[1].token), Limit: yyDollar[1].token.Literal, Position: yyDollar[2].token, Value: NewIntegerValue(1), Unit: yyDollar[3].token, With: LimitWith{Type: yyDollar[4].token}}
+		}
+	case 264:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line parser.y:1542
+		{
+			yyVAL.queryexpr = LimitClause{BaseExpr: NewBaseExpr(yyDollar[1].token), Limit: yyDollar[1].token.Literal, Position: yyDollar[2].token, Value: NewIntegerValue(1), Unit: yyDollar[3].token, With: LimitWith{With: yyDollar[4].token.Literal, Type: yyDollar[5].token}}
+		}
+	case 265:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:1548
+		{
+			yyVAL.token = yyDollar[1].token
+		}
+	case 266:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:1552
+		{
+			yyVAL.token = yyDollar[1].token
+		}
+	case 267:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:1558
+		{
+			yyVAL.token = yyDollar[1].token
+		}
+	case 268:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:1562
 		{
-			yyVAL.queryexpr = LimitClause{BaseExpr: NewBaseExpr(yyDollar[1].token), Limit: yyDollar[1].token.Literal, Value: yyDollar[2].queryexpr, Percent: yyDollar[3].token.Literal, With: yyDollar[4].queryexpr}
+			yyVAL.token = yyDollar[1].token
 		}
-	case 191:
+	case 269:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line parser.y:1126
+//line parser.y:1568
 		{
 			yyVAL.queryexpr = nil
 		}
-	case 192:
+	case 270:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:1130
+//line parser.y:1572
 		{
-			yyVAL.queryexpr = LimitWith{With: yyDollar[1].token.Literal, Type: yyDollar[2].token}
+			yyVAL.queryexpr = WithClause{With: yyDollar[1].token.Literal, InlineTables: yyDollar[2].queryexprs}
 		}
-	case 193:
+	case 271:
+		yyDollar = yyS[yypt-8 : yypt+1]
+//line parser.y:1578
+		{
+			yyVAL.queryexpr = InlineTable{Recursive: yyDollar[1].token, Name: yyDollar[2].identifier, As: yyDollar[3].token.Literal, Materialization: yyDollar[4].token, Query: yyDollar[6].queryexpr.(SelectQuery), CycleClause: yyDollar[8].queryexpr}
+		}
+	case 272:
+		yyDollar = yyS[yypt-11 : yypt+1]
+//line parser.y:1582
+		{
+			yyVAL.queryexpr = InlineTable{Recursive: yyDollar[1].token, Name: yyDollar[2].identifier, Fields: yyDollar[4].queryexprs, As: yyDollar[6].token.Literal, Materialization: yyDollar[7].token, Query: yyDollar[9].queryexpr.(SelectQuery), CycleClause: yyDollar[11].queryexpr}
+		}
+	case 273:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line parser.y:1136
+//line parser.y:1588
 		{
-			yyVAL.queryexpr = nil
+			yyVAL.token = Token{}
 		}
-	case 194:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:1140
+	case 274:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:1592
 		{
-			yyVAL.queryexpr = OffsetClause{BaseExpr: NewBaseExpr(yyDollar[1].token), Offset: yyDollar[1].token.Literal, Value: yyDollar[2].queryexpr}
+			yyVAL.token = yyDollar[1].token
 		}
-	case 195:
+	case 275:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line parser.y:1146
+//line parser.y:1598
 		{
-			yyVAL.queryexpr = nil
+			yyVAL.token = Token{}
 		}
-	case 196:
+	case 276:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:1602
+		{
+			yyVAL.token = yyDollar[1].token
+		}
+	case 277:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:1150
+//line parser.y:1606
 		{
-			yyVAL.queryexpr = WithClause{With: yyDollar[1].token.Literal, InlineTables: yyDollar[2].queryexprs}
+			yyDollar[1].token.Literal = yyDollar[1].token.Literal + " " + yyDollar[2].token.Literal
+			yyVAL.token = yyDollar[1].token
 		}
-	case 197:
-		yyDollar = yyS[yypt-6 : yypt+1]
-		//line parser.y:1156
+	case 278:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line parser.y:1613
 		{
-			yyVAL.queryexpr = InlineTable{Recursive: yyDollar[1].token, Name: yyDollar[2].identifier, As: yyDollar[3].token.Literal, Query: yyDollar[5].queryexpr.(SelectQuery)}
+			yyVAL.queryexpr = nil
 		}
-	case 198:
-		yyDollar = yyS[yypt-9 : yypt+1]
-		//line parser.y:1160
+	case 279:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:1617
 		{
-			yyVAL.queryexpr = InlineTable{Recursive: yyDollar[1].token, Name: yyDollar[2].identifier, Fields: yyDollar[4].queryexprs, As: yyDollar[6].token.Literal, Query: yyDollar[8].queryexpr.(SelectQuery)}
+			yyVAL.queryexpr = CycleClause{BaseExpr: NewBaseExpr(yyDollar[1].token), Cycle: yyDollar[1].token.Literal, Fields: yyDollar[2].queryexprs, Restrict: yyDollar[3].token.Literal}
 		}
-	case 199:
+	case 280:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1166
+//line parser.y:1623
 		{
 			yyVAL.queryexprs = []QueryExpression{yyDollar[1].queryexpr}
 		}
-	case 200:
+	case 281:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:1170
+//line parser.y:1627
 		{
 			yyVAL.queryexprs = append([]QueryExpression{yyDollar[1].queryexpr}, yyDollar[3].queryexprs...)
 		}
-	case 201:
+	case 282:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1176
+//line parser.y:1633
 		{
 			yyVAL.queryexpr = NewStringValue(yyDollar[1].token.Literal)
 		}
-	case 202:
+	case 283:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1180
+//line parser.y:1637
 		{
 			yyVAL.queryexpr = NewIntegerValueFromString(yyDollar[1].token.Literal)
 		}
-	case 203:
+	case 284:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1184
+//line parser.y:1641
 		{
 			yyVAL.queryexpr = NewFloatValueFromString(yyDollar[1].token.Literal)
 		}
-	case 204:
+	case 285:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1188
+//line parser.y:1645
 		{
 			yyVAL.queryexpr = yyDollar[1].queryexpr
 		}
-	case 205:
+	case 286:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1192
+//line parser.y:1649
 		{
 			yyVAL.queryexpr = NewDatetimeValueFromString(yyDollar[1].token.Literal, yylex.(*Lexer).GetDatetimeFormats())
 		}
-	case 206:
+	case 287:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1196
+//line parser.y:1653
 		{
 			yyVAL.queryexpr = yyDollar[1].queryexpr
 		}
-	case 207:
+	case 288:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1202
+//line parser.y:1659
 		{
 			yyVAL.queryexpr = NewTernaryValueFromString(yyDollar[1].token.Literal)
 		}
-	case 208:
+	case 289:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1208
+//line parser.y:1665
 		{
 			yyVAL.queryexpr = NewNullValueFromString(yyDollar[1].token.Literal)
 		}
-	case 209:
+	case 290:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1214
+//line parser.y:1671
 		{
 			yyVAL.queryexpr = FieldReference{BaseExpr: yyDollar[1].identifier.BaseExpr, Column: yyDollar[1].identifier}
 		}
-	case 210:
+	case 291:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:1218
+//line parser.y:1675
 		{
 			yyVAL.queryexpr = FieldReference{BaseExpr: yyDollar[1].identifier.BaseExpr, View: yyDollar[1].identifier, Column: yyDollar[3].identifier}
 		}
-	case 211:
+	case 292:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:1222
+//line parser.y:1679
 		{
 			yyVAL.queryexpr = FieldReference{BaseExpr: NewBaseExpr(yyDollar[1].token), View: Identifier{BaseExpr: NewBaseExpr(yyDollar[1].token), Literal: yyDollar[1].token.Literal}, Column: yyDollar[3].identifier}
 		}
-	case 212:
+	case 293:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:1226
+//line parser.y:1683
 		{
 			yyVAL.queryexpr = ColumnNumber{BaseExpr: yyDollar[1].identifier.BaseExpr, View: yyDollar[1].identifier, Number: value.NewIntegerFromString(yyDollar[3].token.Literal)}
 		}
-	case 213:
+	case 294:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:1230
+//line parser.y:1687
 		{
 			yyVAL.queryexpr = ColumnNumber{BaseExpr: NewBaseExpr(yyDollar[1].token), View: Identifier{BaseExpr: NewBaseExpr(yyDollar[1].token), Literal: yyDollar[1].token.Literal}, Number: value.NewIntegerFromString(yyDollar[3].token.Literal)}
 		}
-	case 214:
+	case 295:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1236
+//line parser.y:1693
 		{
 			yyVAL.queryexpr = yyDollar[1].queryexpr
 		}
-	case 215:
+	case 296:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1240
+//line parser.y:1697
 		{
 			yyVAL.queryexpr = yyDollar[1].queryexpr
 		}
-	case 216:
+	case 297:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1244
+//line parser.y:1701
 		{
 			yyVAL.queryexpr = yyDollar[1].queryexpr
 		}
-	case 217:
+	case 298:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1248
+//line parser.y:1705
 		{
 			yyVAL.queryexpr = yyDollar[1].queryexpr
 		}
-	case 218:
+	case 299:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1252
+//line parser.y:1709
 		{
 			yyVAL.queryexpr = yyDollar[1].queryexpr
 		}
-	case 219:
+	case 300:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1256
+//line parser.y:1713
 		{
 			yyVAL.queryexpr = yyDollar[1].queryexpr
 		}
-	case 220:
+	case 301:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1260
+//line parser.y:1717
 		{
 			yyVAL.queryexpr = yyDollar[1].queryexpr
 		}
-	case 221:
+	case 302:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1264
+//line parser.y:1721
 		{
 			yyVAL.queryexpr = yyDollar[1].queryexpr
 		}
-	case 222:
+	case 303:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1268
+//line parser.y:1725
 		{
 			yyVAL.queryexpr = yyDollar[1].queryexpr
 		}
-	case 223:
+	case 304:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1272
+//line parser.y:1729
 		{
 			yyVAL.queryexpr = yyDollar[1].queryexpr
 		}
-	case 224:
+	case 305:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1276
+//line parser.y:1733
 		{
 			yyVAL.queryexpr = yyDollar[1].variable
 		}
-	case 225:
+	case 306:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1280
+//line parser.y:1737
 		{
 			yyVAL.queryexpr = yyDollar[1].queryexpr
 		}
-	case 226:
+	case 307:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1284
+//line parser.y:1741
 		{
 			yyVAL.queryexpr = yyDollar[1].envvar
 		}
-	case 227:
+	case 308:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1288
+//line parser.y:1745
 		{
 			yyVAL.queryexpr = yyDollar[1].queryexpr
 		}
-	case 228:
+	case 309:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1292
+//line parser.y:1749
 		{
 			yyVAL.queryexpr = yyDollar[1].queryexpr
 		}
-	case 229:
+	case 310:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:1296
+//line parser.y:1753
 		{
 			yyVAL.queryexpr = Parentheses{Expr: yyDollar[2].queryexpr}
 		}
-	case 230:
+	case 311:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line parser.y:1757
+		{
+			yyVAL.queryexpr = ArrayConstructor{BaseExpr: NewBaseExpr(yyDollar[1].token)}
+		}
+	case 312:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:1761
+		{
+			yyVAL.queryexpr = ArrayConstructor{BaseExpr: NewBaseExpr(yyDollar[1].token), Values: yyDollar[2].queryexprs}
+		}
+	case 313:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line parser.y:1765
+		{
+			yyVAL.queryexpr = ArrayElement{BaseExpr: NewBaseExpr(yyDollar[2].token), Array: yyDollar[1].queryexpr, Index: yyDollar[3].queryexpr}
+		}
+	case 314:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1300
+//line parser.y:1769
 		{
 			name := ""
 			if yyDollar[1].token.Literal[0] == ':' {
@@ -3291,117 +4473,135 @@ yydefault:
 			}
 			yyVAL.queryexpr = Placeholder{BaseExpr: NewBaseExpr(yyDollar[1].token), Literal: yyDollar[1].token.Literal, Ordinal: yyDollar[1].token.HolderOrdinal, Name: name}
 		}
-	case 231:
+	case 315:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1310
+//line parser.y:1779
 		{
 			yyVAL.queryexpr = AllColumns{BaseExpr: NewBaseExpr(yyDollar[1].token)}
 		}
-	case 232:
+	case 316:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:1316
+//line parser.y:1785
 		{
 			yyVAL.queryexpr = RowValue{BaseExpr: NewBaseExpr(yyDollar[1].token), Value: ValueList{Values: yyDollar[2].queryexprs}}
 		}
-	case 233:
+	case 317:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1320
+//line parser.y:1789
 		{
 			yyVAL.queryexpr = RowValue{BaseExpr: yyDollar[1].queryexpr.GetBaseExpr(), Value: yyDollar[1].queryexpr}
 		}
-	case 234:
+	case 318:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line parser.y:1324
+//line parser.y:1793
 		{
 			yyVAL.queryexpr = RowValue{BaseExpr: NewBaseExpr(yyDollar[1].token), Value: JsonQuery{JsonQuery: yyDollar[1].token.Literal, Query: yyDollar[3].queryexpr, JsonText: yyDollar[5].queryexpr}}
 		}
-	case 235:
+	case 319:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1330
+//line parser.y:1799
 		{
 			yyVAL.queryexprs = []QueryExpression{yyDollar[1].queryexpr}
 		}
-	case 236:
+	case 320:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:1334
+//line parser.y:1803
 		{
 			yyVAL.queryexprs = append([]QueryExpression{yyDollar[1].queryexpr}, yyDollar[3].queryexprs...)
 		}
-	case 237:
+	case 321:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1340
+//line parser.y:1809
 		{
 			yyVAL.queryexprs = []QueryExpression{yyDollar[1].queryexpr}
 		}
-	case 238:
+	case 322:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:1344
+//line parser.y:1813
 		{
 			yyVAL.queryexprs = append([]QueryExpression{yyDollar[1].queryexpr}, yyDollar[3].queryexprs...)
 		}
-	case 239:
+	case 323:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:1350
+//line parser.y:1819
 		{
 			yyVAL.queryexpr = OrderItem{Value: yyDollar[1].queryexpr, Direction: yyDollar[2].token}
 		}
-	case 240:
+	case 324:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:1354
+//line parser.y:1823
 		{
 			yyVAL.queryexpr = OrderItem{Value: yyDollar[1].queryexpr, Direction: yyDollar[2].token, Nulls: yyDollar[3].token.Literal, Position: yyDollar[4].token}
 		}
-	case 241:
+	case 325:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:1827
+		{
+			yyVAL.queryexpr = OrderItem{Value: yyDollar[1].queryexpr, Collation: yyDollar[2].collation, Direction: yyDollar[3].token}
+		}
+	case 326:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line parser.y:1831
+		{
+			yyVAL.queryexpr = OrderItem{Value: yyDollar[1].queryexpr, Collation: yyDollar[2].collation, Direction: yyDollar[3].token, Nulls: yyDollar[4].token.Literal, Position: yyDollar[5].token}
+		}
+	case 327:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1360
+//line parser.y:1837
 		{
 			yyVAL.queryexpr = yyDollar[1].queryexpr
 		}
-	case 242:
+	case 328:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1364
+//line parser.y:1841
 		{
 			yyVAL.queryexpr = yyDollar[1].queryexpr
 		}
-	case 243:
+	case 329:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line parser.y:1847
+		{
+			yyVAL.collation = Collation{BaseExpr: NewBaseExpr(yyDollar[1].token), Collate: yyDollar[1].token.Literal, Name: yyDollar[2].token.Literal}
+		}
+	case 330:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line parser.y:1370
+//line parser.y:1853
 		{
 			yyVAL.token = Token{}
 		}
-	case 244:
+	case 331:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1374
+//line parser.y:1857
 		{
 			yyVAL.token = yyDollar[1].token
 		}
-	case 245:
+	case 332:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1378
+//line parser.y:1861
 		{
 			yyVAL.token = yyDollar[1].token
 		}
-	case 246:
+	case 333:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1384
+//line parser.y:1867
 		{
 			yyVAL.token = yyDollar[1].token
 		}
-	case 247:
+	case 334:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1388
+//line parser.y:1871
 		{
 			yyVAL.token = yyDollar[1].token
 		}
-	case 248:
+	case 335:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:1394
+//line parser.y:1877
 		{
 			yyVAL.queryexpr = Subquery{BaseExpr: NewBaseExpr(yyDollar[1].token), Query: yyDollar[2].queryexpr.(SelectQuery)}
 		}
-	case 249:
+	case 336:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:1400
+//line parser.y:1883
 		{
 			var item1 []QueryExpression
 			var item2 []QueryExpression
@@ -3422,1257 +4622,1686 @@ yydefault:
 
 			yyVAL.queryexpr = Concat{Items: append(item1, item2...)}
 		}
-	case 250:
+	case 337:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:1423
+//line parser.y:1906
 		{
 			yyVAL.queryexpr = RowValueList{RowValues: yyDollar[2].queryexprs}
 		}
-	case 251:
+	case 338:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1427
+//line parser.y:1910
 		{
 			yyVAL.queryexpr = yyDollar[1].queryexpr
 		}
-	case 252:
+	case 339:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line parser.y:1431
+//line parser.y:1914
 		{
 			yyVAL.queryexpr = JsonQuery{BaseExpr: NewBaseExpr(yyDollar[1].token), JsonQuery: yyDollar[1].token.Literal, Query: yyDollar[3].queryexpr, JsonText: yyDollar[5].queryexpr}
 		}
-	case 253:
+	case 340:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:1437
+//line parser.y:1920
 		{
 			yyVAL.queryexpr = Comparison{LHS: yyDollar[1].queryexpr, Operator: yyDollar[2].token.Literal, RHS: yyDollar[3].queryexpr}
 		}
-	case 254:
+	case 341:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:1441
+//line parser.y:1924
 		{
 			yyVAL.queryexpr = Comparison{LHS: yyDollar[1].queryexpr, Operator: yyDollar[2].token.Literal, RHS: yyDollar[3].queryexpr}
 		}
-	case 255:
+	case 342:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:1445
+//line parser.y:1928
 		{
 			yyVAL.queryexpr = Comparison{LHS: yyDollar[1].queryexpr, Operator: "=", RHS: yyDollar[3].queryexpr}
 		}
-	case 256:
+	case 343:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line parser.y:1932
+		{
+			yyVAL.queryexpr = Comparison{LHS: yyDollar[1].queryexpr, Operator: yyDollar[2].token.Literal, RHS: yyDollar[3].queryexpr, Collation: yyDollar[4].collation}
+		}
+	case 344:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line parser.y:1936
+		{
+			yyVAL.queryexpr = Comparison{LHS: yyDollar[1].queryexpr, Operator: "=", RHS: yyDollar[3].queryexpr, Collation: yyDollar[4].collation}
+		}
+	case 345:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:1449
+//line parser.y:1940
 		{
 			yyVAL.queryexpr = Comparison{LHS: yyDollar[1].queryexpr, Operator: "=", RHS: yyDollar[3].queryexpr}
 		}
-	case 257:
+	case 346:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:1453
+//line parser.y:1944
 		{
 			yyVAL.queryexpr = Is{Is: yyDollar[2].token.Literal, LHS: yyDollar[1].queryexpr, RHS: yyDollar[4].queryexpr, Negation: yyDollar[3].token}
 		}
-	case 258:
+	case 347:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:1457
+//line parser.y:1948
 		{
 			yyVAL.queryexpr = Is{Is: yyDollar[2].token.Literal, LHS: yyDollar[1].queryexpr, RHS: yyDollar[4].queryexpr, Negation: yyDollar[3].token}
 		}
-	case 259:
+	case 348:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line parser.y:1461
+//line parser.y:1952
 		{
 			yyVAL.queryexpr = Between{Between: yyDollar[2].token.Literal, And: yyDollar[4].token.Literal, LHS: yyDollar[1].queryexpr, Low: yyDollar[3].queryexpr, High: yyDollar[5].queryexpr}
 		}
-	case 260:
+	case 349:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line parser.y:1465
+//line parser.y:1956
 		{
 			yyVAL.queryexpr = Between{Between: yyDollar[3].token.Literal, And: yyDollar[5].token.Literal, LHS: yyDollar[1].queryexpr, Low: yyDollar[4].queryexpr, High: yyDollar[6].queryexpr, Negation: yyDollar[2].token}
 		}
-	case 261:
+	case 350:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line parser.y:1469
+//line parser.y:1960
 		{
 			yyVAL.queryexpr = Between{Between: yyDollar[3].token.Literal, And: yyDollar[5].token.Literal, LHS: yyDollar[1].queryexpr, Low: yyDollar[4].queryexpr, High: yyDollar[6].queryexpr, Negation: yyDollar[2].token}
 		}
-	case 262:
+	case 351:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:1473
+//line parser.y:1964
 		{
 			yyVAL.queryexpr = In{In: yyDollar[2].token.Literal, LHS: yyDollar[1].queryexpr, Values: yyDollar[3].queryexpr}
 		}
-	case 263:
+	case 352:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:1477
+//line parser.y:1968
 		{
 			yyVAL.queryexpr = In{In: yyDollar[3].token.Literal, LHS: yyDollar[1].queryexpr, Values: yyDollar[4].queryexpr, Negation: yyDollar[2].token}
 		}
-	case 264:
+	case 353:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:1481
+//line parser.y:1972
 		{
 			yyVAL.queryexpr = In{In: yyDollar[3].token.Literal, LHS: yyDollar[1].queryexpr, Values: yyDollar[4].queryexpr, Negation: yyDollar[2].token}
 		}
-	case 265:
+	case 354:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:1485
+//line parser.y:1976
 		{
 			yyVAL.queryexpr = Like{Like: yyDollar[2].token.Literal, LHS: yyDollar[1].queryexpr, Pattern: yyDollar[3].queryexpr}
 		}
-	case 266:
+	case 355:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:1489
+//line parser.y:1980
 		{
 			yyVAL.queryexpr = Like{Like: yyDollar[3].token.Literal, LHS: yyDollar[1].queryexpr, Pattern: yyDollar[4].queryexpr, Negation: yyDollar[2].token}
 		}
-	case 267:
+	case 356:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:1984
+		{
+			yyVAL.queryexpr = Like{Like: yyDollar[2].token.Literal, LHS: yyDollar[1].queryexpr, Pattern: yyDollar[3].queryexpr}
+		}
+	case 357:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:1493
+//line parser.y:1988
+		{
+			yyVAL.queryexpr = Like{Like: yyDollar[3].token.Literal, LHS: yyDollar[1].queryexpr, Pattern: yyDollar[4].queryexpr, Negation: yyDollar[2].token}
+		}
+	case 358:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line parser.y:1992
 		{
 			yyVAL.queryexpr = Any{Any: yyDollar[3].token.Literal, LHS: yyDollar[1].queryexpr, Operator: yyDollar[2].token.Literal, Values: yyDollar[4].queryexpr}
 		}
-	case 268:
+	case 359:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:1497
+//line parser.y:1996
 		{
 			yyVAL.queryexpr = Any{Any: yyDollar[3].token.Literal, LHS: yyDollar[1].queryexpr, Operator: yyDollar[2].token.Literal, Values: yyDollar[4].queryexpr}
 		}
-	case 269:
+	case 360:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:1501
+//line parser.y:2000
 		{
 			yyVAL.queryexpr = All{All: yyDollar[3].token.Literal, LHS: yyDollar[1].queryexpr, Operator: yyDollar[2].token.Literal, Values: yyDollar[4].queryexpr}
 		}
-	case 270:
+	case 361:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:1505
+//line parser.y:2004
 		{
 			yyVAL.queryexpr = All{All: yyDollar[3].token.Literal, LHS: yyDollar[1].queryexpr, Operator: yyDollar[2].token.Literal, Values: yyDollar[4].queryexpr}
 		}
-	case 271:
+	case 362:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:1509
+//line parser.y:2008
 		{
 			yyVAL.queryexpr = Exists{Exists: yyDollar[1].token.Literal, Query: yyDollar[2].queryexpr.(Subquery)}
 		}
-	case 272:
+	case 363:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:1515
+//line parser.y:2014
 		{
 			yyVAL.queryexpr = Arithmetic{LHS: yyDollar[1].queryexpr, Operator: int('+'), RHS: yyDollar[3].queryexpr}
 		}
-	case 273:
+	case 364:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:1519
+//line parser.y:2018
 		{
 			yyVAL.queryexpr = Arithmetic{LHS: yyDollar[1].queryexpr, Operator: int('-'), RHS: yyDollar[3].queryexpr}
 		}
-	case 274:
+	case 365:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:1523
+//line parser.y:2022
 		{
 			yyVAL.queryexpr = Arithmetic{LHS: yyDollar[1].queryexpr, Operator: int('*'), RHS: yyDollar[3].queryexpr}
 		}
-	case 275:
+	case 366:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:1527
+//line parser.y:2026
 		{
 			yyVAL.queryexpr = Arithmetic{LHS: yyDollar[1].queryexpr, Operator: int('/'), RHS: yyDollar[3].queryexpr}
 		}
-	case 276:
+	case 367:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:1531
+//line parser.y:2030
 		{
 			yyVAL.queryexpr = Arithmetic{LHS: yyDollar[1].queryexpr, Operator: int('%'), RHS: yyDollar[3].queryexpr}
 		}
-	case 277:
+	case 368:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:1535
+//line parser.y:2034
 		{
 			yyVAL.queryexpr = UnaryArithmetic{Operand: yyDollar[2].queryexpr, Operator: yyDollar[1].token}
 		}
-	case 278:
+	case 369:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:1539
+//line parser.y:2038
 		{
 			yyVAL.queryexpr = UnaryArithmetic{Operand: yyDollar[2].queryexpr, Operator: yyDollar[1].token}
 		}
-	case 279:
+	case 370:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:1545
+//line parser.y:2044
 		{
 			yyVAL.queryexpr = Logic{LHS: yyDollar[1].queryexpr, Operator: yyDollar[2].token, RHS: yyDollar[3].queryexpr}
 		}
-	case 280:
+	case 371:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:1549
+//line parser.y:2048
 		{
 			yyVAL.queryexpr = Logic{LHS: yyDollar[1].queryexpr, Operator: yyDollar[2].token, RHS: yyDollar[3].queryexpr}
 		}
-	case 281:
+	case 372:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:1553
+//line parser.y:2052
 		{
 			yyVAL.queryexpr = UnaryLogic{Operand: yyDollar[2].queryexpr, Operator: yyDollar[1].token}
 		}
-	case 282:
+	case 373:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:1557
+//line parser.y:2056
 		{
 			yyVAL.queryexpr = UnaryLogic{Operand: yyDollar[2].queryexpr, Operator: yyDollar[1].token}
 		}
-	case 283:
+	case 374:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line parser.y:1563
+//line parser.y:2062
 		{
 			yyVAL.queryexprs = nil
 		}
-	case 284:
+	case 375:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1567
+//line parser.y:2066
 		{
 			yyVAL.queryexprs = yyDollar[1].queryexprs
 		}
-	case 285:
+	case 376:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:1573
+//line parser.y:2072
 		{
 			yyVAL.queryexpr = Function{BaseExpr: yyDollar[1].identifier.BaseExpr, Name: yyDollar[1].identifier.Literal, Args: yyDollar[3].queryexprs}
 		}
-	case 286:
+	case 377:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:1577
+//line parser.y:2076
 		{
 			yyVAL.queryexpr = Function{BaseExpr: NewBaseExpr(yyDollar[1].token), Name: yyDollar[1].token.Literal}
 		}
-	case 287:
+	case 378:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:1581
+//line parser.y:2080
 		{
 			yyVAL.queryexpr = Function{BaseExpr: NewBaseExpr(yyDollar[1].token), Name: yyDollar[1].token.Literal, Args: yyDollar[3].queryexprs}
 		}
-	case 288:
+	case 379:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:1585
+//line parser.y:2084
 		{
 			yyVAL.queryexpr = Function{BaseExpr: NewBaseExpr(yyDollar[1].token), Name: yyDollar[1].token.Literal, Args: yyDollar[3].queryexprs}
 		}
-	case 289:
+	case 380:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line parser.y:2088
+		{
+			yyVAL.queryexpr = Function{BaseExpr: NewBaseExpr(yyDollar[1].token), Name: yyDollar[1].token.Literal, Args: yyDollar[3].queryexprs}
+		}
+	case 381:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line parser.y:2092
+		{
+			yyVAL.queryexpr = Function{BaseExpr: NewBaseExpr(yyDollar[1].token), Name: yyDollar[1].token.Literal, Args: yyDollar[3].queryexprs}
+		}
+	case 382:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line parser.y:1592
+//line parser.y:2099
 		{
 			yyVAL.queryexpr = AggregateFunction{BaseExpr: yyDollar[1].identifier.BaseExpr, Name: yyDollar[1].identifier.Literal, Distinct: yyDollar[3].token, Args: yyDollar[4].queryexprs}
 		}
-	case 290:
+	case 383:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line parser.y:1596
+//line parser.y:2103
 		{
 			yyVAL.queryexpr = AggregateFunction{BaseExpr: NewBaseExpr(yyDollar[1].token), Name: yyDollar[1].token.Literal, Distinct: yyDollar[3].token, Args: yyDollar[4].queryexprs}
 		}
-	case 291:
+	case 384:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line parser.y:1600
+//line parser.y:2107
 		{
 			yyVAL.queryexpr = AggregateFunction{BaseExpr: NewBaseExpr(yyDollar[1].token), Name: yyDollar[1].token.Literal, Distinct: yyDollar[3].token, Args: yyDollar[4].queryexprs}
 		}
-	case 292:
+	case 385:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line parser.y:2111
+		{
+			yyVAL.queryexpr = AggregateFunction{BaseExpr: NewBaseExpr(yyDollar[1].token), Name: yyDollar[1].token.Literal, Distinct: yyDollar[3].token, Args: yyDollar[4].queryexprs}
+		}
+	case 386:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line parser.y:1604
+//line parser.y:2115
 		{
 			yyVAL.queryexpr = AggregateFunction{BaseExpr: NewBaseExpr(yyDollar[1].token), Name: yyDollar[1].token.Literal, Distinct: yyDollar[3].token, Args: []QueryExpression{yyDollar[4].queryexpr}}
 		}
-	case 293:
+	case 387:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1608
+//line parser.y:2119
 		{
 			yyVAL.queryexpr = yyDollar[1].queryexpr
 		}
-	case 294:
+	case 388:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line parser.y:1614
+//line parser.y:2125
 		{
 			yyVAL.queryexpr = ListFunction{BaseExpr: NewBaseExpr(yyDollar[1].token), Name: yyDollar[1].token.Literal, Distinct: yyDollar[3].token, Args: yyDollar[4].queryexprs}
 		}
-	case 295:
+	case 389:
 		yyDollar = yyS[yypt-10 : yypt+1]
-		//line parser.y:1618
+//line parser.y:2129
 		{
 			yyVAL.queryexpr = ListFunction{BaseExpr: NewBaseExpr(yyDollar[1].token), Name: yyDollar[1].token.Literal, Distinct: yyDollar[3].token, Args: yyDollar[4].queryexprs, WithinGroup: yyDollar[6].token.Literal + " " + yyDollar[7].token.Literal, OrderBy: yyDollar[9].queryexpr}
 		}
-	case 296:
+	case 390:
+		yyDollar = yyS[yypt-8 : yypt+1]
+//line parser.y:2133
+		{
+			yyVAL.queryexpr = ListFunction{BaseExpr: NewBaseExpr(yyDollar[1].token), Name: yyDollar[1].token.Literal, Distinct: yyDollar[3].token, Args: yyDollar[4].queryexprs, OrderBy: OrderByClause{OrderBy: yyDollar[5].token.Literal + " " + yyDollar[6].token.Literal, Items: yyDollar[7].queryexprs}}
+		}
+	case 391:
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line parser.y:2137
+		{
+			yyVAL.queryexpr = ListFunction{BaseExpr: NewBaseExpr(yyDollar[1].token), Name: yyDollar[1].token.Literal, Args: []QueryExpression{yyDollar[3].queryexpr}, OrderBy: OrderByClause{OrderBy: yyDollar[4].token.Literal + " " + yyDollar[5].token.Literal, Items: yyDollar[6].queryexprs}}
+		}
+	case 392:
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line parser.y:2141
+		{
+			yyVAL.queryexpr = ListFunction{BaseExpr: NewBaseExpr(yyDollar[1].token), Name: yyDollar[1].token.Literal, Args: []QueryExpression{yyDollar[3].queryexpr}, OrderBy: OrderByClause{OrderBy: yyDollar[4].token.Literal + " " + yyDollar[5].token.Literal, Items: yyDollar[6].queryexprs}}
+		}
+	case 393:
 		yyDollar = yyS[yypt-8 : yypt+1]
-		//line parser.y:1624
+//line parser.y:2147
 		{
 			yyVAL.queryexpr = AnalyticFunction{BaseExpr: yyDollar[1].identifier.BaseExpr, Name: yyDollar[1].identifier.Literal, Args: yyDollar[3].queryexprs, Over: yyDollar[5].token.Literal, AnalyticClause: yyDollar[7].queryexpr.(AnalyticClause)}
 		}
-	case 297:
+	case 394:
 		yyDollar = yyS[yypt-9 : yypt+1]
-		//line parser.y:1628
+//line parser.y:2151
 		{
 			yyVAL.queryexpr = AnalyticFunction{BaseExpr: yyDollar[1].identifier.BaseExpr, Name: yyDollar[1].identifier.Literal, Distinct: yyDollar[3].token, Args: yyDollar[4].queryexprs, Over: yyDollar[6].token.Literal, AnalyticClause: yyDollar[8].queryexpr.(AnalyticClause)}
 		}
-	case 298:
+	case 395:
 		yyDollar = yyS[yypt-9 : yypt+1]
-		//line parser.y:1632
+//line parser.y:2155
 		{
 			yyVAL.queryexpr = AnalyticFunction{BaseExpr: NewBaseExpr(yyDollar[1].token), Name: yyDollar[1].token.Literal, Distinct: yyDollar[3].token, Args: yyDollar[4].queryexprs, Over: yyDollar[6].token.Literal, AnalyticClause: yyDollar[8].queryexpr.(AnalyticClause)}
 		}
-	case 299:
+	case 396:
 		yyDollar = yyS[yypt-9 : yypt+1]
-		//line parser.y:1636
+//line parser.y:2159
 		{
 			yyVAL.queryexpr = AnalyticFunction{BaseExpr: NewBaseExpr(yyDollar[1].token), Name: yyDollar[1].token.Literal, Distinct: yyDollar[3].token, Args: yyDollar[4].queryexprs, Over: yyDollar[6].token.Literal, AnalyticClause: yyDollar[8].queryexpr.(AnalyticClause)}
 		}
-	case 300:
+	case 397:
+		yyDollar = yyS[yypt-9 : yypt+1]
+//line parser.y:2163
+		{
+			yyVAL.queryexpr = AnalyticFunction{BaseExpr: NewBaseExpr(yyDollar[1].token), Name: yyDollar[1].token.Literal, Distinct: yyDollar[3].token, Args: yyDollar[4].queryexprs, Over: yyDollar[6].token.Literal, AnalyticClause: yyDollar[8].queryexpr.(AnalyticClause)}
+		}
+	case 398:
 		yyDollar = yyS[yypt-9 : yypt+1]
-		//line parser.y:1640
+//line parser.y:2167
 		{
 			yyVAL.queryexpr = AnalyticFunction{BaseExpr: NewBaseExpr(yyDollar[1].token), Name: yyDollar[1].token.Literal, Distinct: yyDollar[3].token, Args: []QueryExpression{yyDollar[4].queryexpr}, Over: yyDollar[6].token.Literal, AnalyticClause: yyDollar[8].queryexpr.(AnalyticClause)}
 		}
-	case 301:
+	case 399:
 		yyDollar = yyS[yypt-9 : yypt+1]
-		//line parser.y:1644
+//line parser.y:2171
 		{
 			yyVAL.queryexpr = AnalyticFunction{BaseExpr: NewBaseExpr(yyDollar[1].token), Name: yyDollar[1].token.Literal, Distinct: yyDollar[3].token, Args: yyDollar[4].queryexprs, Over: yyDollar[6].token.Literal, AnalyticClause: yyDollar[8].queryexpr.(AnalyticClause)}
 		}
-	case 302:
+	case 400:
 		yyDollar = yyS[yypt-8 : yypt+1]
-		//line parser.y:1648
+//line parser.y:2175
 		{
 			yyVAL.queryexpr = AnalyticFunction{BaseExpr: NewBaseExpr(yyDollar[1].token), Name: yyDollar[1].token.Literal, Args: yyDollar[3].queryexprs, Over: yyDollar[5].token.Literal, AnalyticClause: yyDollar[7].queryexpr.(AnalyticClause)}
 		}
-	case 303:
+	case 401:
 		yyDollar = yyS[yypt-8 : yypt+1]
-		//line parser.y:1652
+//line parser.y:2179
 		{
 			yyVAL.queryexpr = AnalyticFunction{BaseExpr: NewBaseExpr(yyDollar[1].token), Name: yyDollar[1].token.Literal, Args: yyDollar[3].queryexprs, Over: yyDollar[5].token.Literal, AnalyticClause: yyDollar[7].queryexpr.(AnalyticClause)}
 		}
-	case 304:
+	case 402:
 		yyDollar = yyS[yypt-10 : yypt+1]
-		//line parser.y:1656
+//line parser.y:2183
 		{
 			yyVAL.queryexpr = AnalyticFunction{BaseExpr: NewBaseExpr(yyDollar[1].token), Name: yyDollar[1].token.Literal, Args: yyDollar[3].queryexprs, IgnoreNulls: true, IgnoreNullsLit: yyDollar[5].token.Literal + " " + yyDollar[6].token.Literal, Over: yyDollar[7].token.Literal, AnalyticClause: yyDollar[9].queryexpr.(AnalyticClause)}
 		}
-	case 305:
+	case 403:
 		yyDollar = yyS[yypt-8 : yypt+1]
-		//line parser.y:1660
+//line parser.y:2187
 		{
 			yyVAL.queryexpr = AnalyticFunction{BaseExpr: NewBaseExpr(yyDollar[1].token), Name: yyDollar[1].token.Literal, Args: yyDollar[3].queryexprs, Over: yyDollar[5].token.Literal, AnalyticClause: yyDollar[7].queryexpr.(AnalyticClause)}
 		}
-	case 306:
+	case 404:
 		yyDollar = yyS[yypt-10 : yypt+1]
-		//line parser.y:1664
+//line parser.y:2191
 		{
 			yyVAL.queryexpr = AnalyticFunction{BaseExpr: NewBaseExpr(yyDollar[1].token), Name: yyDollar[1].token.Literal, Args: yyDollar[3].queryexprs, IgnoreNulls: true, IgnoreNullsLit: yyDollar[5].token.Literal + " " + yyDollar[6].token.Literal, Over: yyDollar[7].token.Literal, AnalyticClause: yyDollar[9].queryexpr.(AnalyticClause)}
 		}
-	case 307:
+	case 405:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:1670
+//line parser.y:2197
 		{
 			yyVAL.queryexpr = AnalyticClause{PartitionClause: yyDollar[1].queryexpr, OrderByClause: yyDollar[2].queryexpr}
 		}
-	case 308:
+	case 406:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1676
+//line parser.y:2203
 		{
 			yyVAL.queryexpr = yyDollar[1].queryexpr
 		}
-	case 309:
+	case 407:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line parser.y:1680
+//line parser.y:2207
 		{
 			orderByClause := OrderByClause{OrderBy: yyDollar[2].token.Literal + " " + yyDollar[3].token.Literal, Items: yyDollar[4].queryexprs}
 			yyVAL.queryexpr = AnalyticClause{PartitionClause: yyDollar[1].queryexpr, OrderByClause: orderByClause, WindowingClause: yyDollar[5].queryexpr}
 		}
-	case 310:
+	case 408:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line parser.y:1687
+//line parser.y:2214
 		{
 			yyVAL.queryexpr = nil
 		}
-	case 311:
+	case 409:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:1691
+//line parser.y:2218
 		{
 			yyVAL.queryexpr = PartitionClause{PartitionBy: yyDollar[1].token.Literal + " " + yyDollar[2].token.Literal, Values: yyDollar[3].queryexprs}
 		}
-	case 312:
+	case 410:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:2224
+		{
+			yyVAL.queryexpr = WindowingClause{Rows: yyDollar[1].token.Literal, FrameLow: yyDollar[2].queryexpr, Exclusion: yyDollar[3].token.Token, ExclusionLit: yyDollar[3].token.Literal}
+		}
+	case 411:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line parser.y:2228
+		{
+			yyVAL.queryexpr = WindowingClause{Rows: yyDollar[1].token.Literal, FrameLow: yyDollar[3].queryexpr, FrameHigh: yyDollar[5].queryexpr, Between: yyDollar[2].token.Literal, And: yyDollar[4].token.Literal, Exclusion: yyDollar[6].token.Token, ExclusionLit: yyDollar[6].token.Literal}
+		}
+	case 412:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:2232
+		{
+			yyVAL.queryexpr = WindowingClause{Rows: yyDollar[1].token.Literal, Groups: true, FrameLow: yyDollar[2].queryexpr, Exclusion: yyDollar[3].token.Token, ExclusionLit: yyDollar[3].token.Literal}
+		}
+	case 413:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line parser.y:2236
+		{
+			yyVAL.queryexpr = WindowingClause{Rows: yyDollar[1].token.Literal, Groups: true, FrameLow: yyDollar[3].queryexpr, FrameHigh: yyDollar[5].queryexpr, Between: yyDollar[2].token.Literal, And: yyDollar[4].token.Literal, Exclusion: yyDollar[6].token.Token, ExclusionLit: yyDollar[6].token.Literal}
+		}
+	case 414:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line parser.y:2242
+		{
+			yyVAL.token = Token{}
+		}
+	case 415:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:2246
+		{
+			yyVAL.token = Token{Token: yyDollar[2].token.Token, Literal: yyDollar[1].token.Literal + " " + yyDollar[2].token.Literal + " " + yyDollar[3].token.Literal}
+		}
+	case 416:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:1697
+//line parser.y:2250
 		{
-			yyVAL.queryexpr = WindowingClause{Rows: yyDollar[1].token.Literal, FrameLow: yyDollar[2].queryexpr}
+			yyVAL.token = Token{Token: yyDollar[2].token.Token, Literal: yyDollar[1].token.Literal + " " + yyDollar[2].token.Literal}
 		}
-	case 313:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line parser.y:1701
+	case 417:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line parser.y:2254
 		{
-			yyVAL.queryexpr = WindowingClause{Rows: yyDollar[1].token.Literal, FrameLow: yyDollar[3].queryexpr, FrameHigh: yyDollar[5].queryexpr, Between: yyDollar[2].token.Literal, And: yyDollar[4].token.Literal}
+			yyVAL.token = Token{Token: yyDollar[2].token.Token, Literal: yyDollar[1].token.Literal + " " + yyDollar[2].token.Literal}
 		}
-	case 314:
+	case 418:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:2258
+		{
+			yyVAL.token = Token{Token: yyDollar[2].token.Token, Literal: yyDollar[1].token.Literal + " " + yyDollar[2].token.Literal + " " + yyDollar[3].token.Literal}
+		}
+	case 419:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:1707
+//line parser.y:2264
 		{
 			yyVAL.queryexpr = WindowFramePosition{Direction: yyDollar[2].token.Token, Unbounded: true, Literal: yyDollar[1].token.Literal + " " + yyDollar[2].token.Literal}
 		}
-	case 315:
+	case 420:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:1711
+//line parser.y:2268
 		{
 			i, _ := strconv.Atoi(yyDollar[1].token.Literal)
 			yyVAL.queryexpr = WindowFramePosition{Direction: yyDollar[2].token.Token, Offset: i, Literal: yyDollar[1].token.Literal + " " + yyDollar[2].token.Literal}
 		}
-	case 316:
+	case 421:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:1716
+//line parser.y:2273
 		{
 			yyVAL.queryexpr = WindowFramePosition{Direction: yyDollar[1].token.Token, Literal: yyDollar[1].token.Literal + " " + yyDollar[2].token.Literal}
 		}
-	case 317:
+	case 422:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:1722
+//line parser.y:2279
 		{
 			i, _ := strconv.Atoi(yyDollar[1].token.Literal)
 			yyVAL.queryexpr = WindowFramePosition{Direction: yyDollar[2].token.Token, Offset: i, Literal: yyDollar[1].token.Literal + " " + yyDollar[2].token.Literal}
 		}
-	case 318:
+	case 423:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:1727
+//line parser.y:2284
 		{
 			i, _ := strconv.Atoi(yyDollar[1].token.Literal)
 			yyVAL.queryexpr = WindowFramePosition{Direction: yyDollar[2].token.Token, Offset: i, Literal: yyDollar[1].token.Literal + " " + yyDollar[2].token.Literal}
 		}
-	case 319:
+	case 424:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:1732
+//line parser.y:2289
 		{
 			yyVAL.queryexpr = WindowFramePosition{Direction: yyDollar[1].token.Token, Literal: yyDollar[1].token.Literal + " " + yyDollar[2].token.Literal}
 		}
-	case 320:
+	case 425:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:1738
+//line parser.y:2295
 		{
 			yyVAL.queryexpr = WindowFramePosition{Direction: yyDollar[2].token.Token, Unbounded: true, Literal: yyDollar[1].token.Literal + " " + yyDollar[2].token.Literal}
 		}
-	case 321:
+	case 426:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1742
+//line parser.y:2299
 		{
 			yyVAL.queryexpr = yyDollar[1].queryexpr
 		}
-	case 322:
+	case 427:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:1748
+//line parser.y:2305
 		{
 			yyVAL.queryexpr = WindowFramePosition{Direction: yyDollar[2].token.Token, Unbounded: true, Literal: yyDollar[1].token.Literal + " " + yyDollar[2].token.Literal}
 		}
-	case 323:
+	case 428:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1752
+//line parser.y:2309
 		{
 			yyVAL.queryexpr = yyDollar[1].queryexpr
 		}
-	case 324:
+	case 429:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1758
+//line parser.y:2315
 		{
 			yyVAL.queryexpr = yyDollar[1].identifier
 		}
-	case 325:
+	case 430:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1762
+//line parser.y:2319
 		{
 			yyVAL.queryexpr = Stdin{BaseExpr: NewBaseExpr(yyDollar[1].token), Stdin: yyDollar[1].token.Literal}
 		}
-	case 326:
+	case 431:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1768
+//line parser.y:2325
 		{
 			yyVAL.identifier = Identifier{BaseExpr: NewBaseExpr(yyDollar[1].token), Literal: yyDollar[1].token.Literal, Quoted: yyDollar[1].token.Quoted}
 		}
-	case 327:
+	case 432:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1772
+//line parser.y:2329
 		{
 			yyVAL.identifier = Identifier{BaseExpr: NewBaseExpr(yyDollar[1].token), Literal: yyDollar[1].token.Literal, Quoted: yyDollar[1].token.Quoted}
 		}
-	case 328:
+	case 433:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1776
+//line parser.y:2333
 		{
 			yyVAL.identifier = Identifier{BaseExpr: NewBaseExpr(yyDollar[1].token), Literal: yyDollar[1].token.Literal, Quoted: yyDollar[1].token.Quoted}
 		}
-	case 329:
+	case 434:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1780
+//line parser.y:2337
 		{
 			yyVAL.identifier = Identifier{BaseExpr: NewBaseExpr(yyDollar[1].token), Literal: yyDollar[1].token.Literal, Quoted: yyDollar[1].token.Quoted}
 		}
-	case 330:
+	case 435:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:1786
+//line parser.y:2343
 		{
 			yyVAL.queryexpr = TableObject{BaseExpr: yyDollar[1].identifier.BaseExpr, Type: yyDollar[1].identifier, Path: yyDollar[3].identifier, Args: nil}
 		}
-	case 331:
+	case 436:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line parser.y:1790
+//line parser.y:2347
 		{
 			yyVAL.queryexpr = TableObject{BaseExpr: yyDollar[1].identifier.BaseExpr, Type: yyDollar[1].identifier, Path: yyDollar[3].identifier, Args: yyDollar[5].queryexprs}
 		}
-	case 332:
+	case 437:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line parser.y:1794
+//line parser.y:2351
 		{
 			yyVAL.queryexpr = TableObject{BaseExpr: yyDollar[1].identifier.BaseExpr, Type: yyDollar[1].identifier, FormatElement: yyDollar[3].queryexpr, Path: yyDollar[5].identifier, Args: nil}
 		}
-	case 333:
+	case 438:
 		yyDollar = yyS[yypt-8 : yypt+1]
-		//line parser.y:1798
+//line parser.y:2355
 		{
 			yyVAL.queryexpr = TableObject{BaseExpr: yyDollar[1].identifier.BaseExpr, Type: yyDollar[1].identifier, FormatElement: yyDollar[3].queryexpr, Path: yyDollar[5].identifier, Args: yyDollar[7].queryexprs}
 		}
-	case 334:
+	case 439:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1804
+//line parser.y:2361
 		{
 			yyVAL.queryexpr = yyDollar[1].queryexpr
 		}
-	case 335:
+	case 440:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1808
+//line parser.y:2365
 		{
 			yyVAL.queryexpr = yyDollar[1].queryexpr
 		}
-	case 336:
+	case 441:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1814
+//line parser.y:2371
 		{
 			yyVAL.queryexpr = yyDollar[1].queryexpr
 		}
-	case 337:
+	case 442:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line parser.y:1818
+//line parser.y:2375
 		{
 			yyVAL.queryexpr = JsonQuery{BaseExpr: NewBaseExpr(yyDollar[1].token), JsonQuery: yyDollar[1].token.Literal, Query: yyDollar[3].queryexpr, JsonText: yyDollar[5].identifier}
 		}
-	case 338:
+	case 443:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line parser.y:1822
+//line parser.y:2379
 		{
 			yyVAL.queryexpr = JsonQuery{BaseExpr: NewBaseExpr(yyDollar[1].token), JsonQuery: yyDollar[1].token.Literal, Query: yyDollar[3].queryexpr, JsonText: yyDollar[5].queryexpr}
 		}
-	case 339:
+	case 444:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line parser.y:2383
+		{
+			yyVAL.queryexpr = Unnest{BaseExpr: NewBaseExpr(yyDollar[1].token), Unnest: yyDollar[1].token.Literal, Array: yyDollar[3].queryexpr}
+		}
+	case 445:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line parser.y:2387
+		{
+			yyVAL.queryexpr = Unnest{BaseExpr: NewBaseExpr(yyDollar[1].token), Unnest: yyDollar[1].token.Literal, Array: yyDollar[3].queryexpr, With: yyDollar[5].token.Literal, Ordinality: yyDollar[6].token.Literal}
+		}
+	case 446:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1826
+//line parser.y:2391
 		{
 			yyVAL.queryexpr = yyDollar[1].queryexpr
 		}
-	case 340:
+	case 447:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line parser.y:2397
+		{
+			yyVAL.queryexpr = Table{Object: yyDollar[1].queryexpr, Sample: yyDollar[2].queryexpr}
+		}
+	case 448:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:2401
+		{
+			yyVAL.queryexpr = Table{Object: yyDollar[1].queryexpr, Alias: yyDollar[2].identifier, Sample: yyDollar[3].queryexpr}
+		}
+	case 449:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line parser.y:2405
+		{
+			yyVAL.queryexpr = Table{Object: yyDollar[1].queryexpr, As: yyDollar[2].token.Literal, Alias: yyDollar[3].identifier, Sample: yyDollar[4].queryexpr}
+		}
+	case 450:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line parser.y:2409
+		{
+			yyVAL.queryexpr = Table{Object: yyDollar[1].queryexpr, Alias: yyDollar[2].identifier, Columns: yyDollar[4].queryexprs, Sample: yyDollar[6].queryexpr}
+		}
+	case 451:
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line parser.y:2413
+		{
+			yyVAL.queryexpr = Table{Object: yyDollar[1].queryexpr, As: yyDollar[2].token.Literal, Alias: yyDollar[3].identifier, Columns: yyDollar[5].queryexprs, Sample: yyDollar[7].queryexpr}
+		}
+	case 452:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1832
+//line parser.y:2417
 		{
 			yyVAL.queryexpr = Table{Object: yyDollar[1].queryexpr}
 		}
-	case 341:
+	case 453:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:2421
+		{
+			yyVAL.queryexpr = Table{Object: yyDollar[1].queryexpr}
+		}
+	case 454:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:1836
+//line parser.y:2425
 		{
 			yyVAL.queryexpr = Table{Object: yyDollar[1].queryexpr, Alias: yyDollar[2].identifier}
 		}
-	case 342:
+	case 455:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:1840
+//line parser.y:2429
 		{
 			yyVAL.queryexpr = Table{Object: yyDollar[1].queryexpr, As: yyDollar[2].token.Literal, Alias: yyDollar[3].identifier}
 		}
-	case 343:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1844
+	case 456:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:2433
+		{
+			yyVAL.queryexpr = Table{Object: Dual{Dual: yyDollar[1].token.Literal}}
+		}
+	case 457:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:2437
+		{
+			yyVAL.queryexpr = Parentheses{Expr: yyDollar[2].queryexpr}
+		}
+	case 458:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line parser.y:2443
+		{
+			yyVAL.queryexpr = nil
+		}
+	case 459:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line parser.y:2447
+		{
+			yyVAL.queryexpr = TableSample{BaseExpr: NewBaseExpr(yyDollar[1].token), TableSample: yyDollar[1].token.Literal, Value: yyDollar[3].queryexpr, Unit: yyDollar[4].token}
+		}
+	case 460:
+		yyDollar = yyS[yypt-9 : yypt+1]
+//line parser.y:2451
+		{
+			yyVAL.queryexpr = TableSample{BaseExpr: NewBaseExpr(yyDollar[1].token), TableSample: yyDollar[1].token.Literal, Value: yyDollar[3].queryexpr, Unit: yyDollar[4].token, Repeatable: yyDollar[6].token.Literal, Seed: yyDollar[8].queryexpr}
+		}
+	case 461:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:2457
+		{
+			yyVAL.token = yyDollar[1].token
+		}
+	case 462:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:2461
+		{
+			yyVAL.token = yyDollar[1].token
+		}
+	case 463:
+		yyDollar = yyS[yypt-11 : yypt+1]
+//line parser.y:2467
+		{
+			yyVAL.queryexpr = Pivot{BaseExpr: NewBaseExpr(yyDollar[2].token), Table: yyDollar[1].queryexpr, Pivot: yyDollar[2].token.Literal, Aggregate: yyDollar[4].queryexpr, For: yyDollar[5].token.Literal, Column: yyDollar[6].queryexpr, In: yyDollar[7].token.Literal, Values: yyDollar[9].queryexprs}
+		}
+	case 464:
+		yyDollar = yyS[yypt-11 : yypt+1]
+//line parser.y:2471
+		{
+			yyVAL.queryexpr = Unpivot{BaseExpr: NewBaseExpr(yyDollar[2].token), Table: yyDollar[1].queryexpr, Unpivot: yyDollar[2].token.Literal, Value: yyDollar[4].identifier, For: yyDollar[5].token.Literal, Name: yyDollar[6].identifier, In: yyDollar[7].token.Literal, Columns: yyDollar[9].queryexprs}
+		}
+	case 465:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:2477
+		{
+			yyVAL.queryexpr = Field{Object: yyDollar[1].queryexpr}
+		}
+	case 466:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:2481
 		{
-			yyVAL.queryexpr = Table{Object: yyDollar[1].queryexpr}
+			yyVAL.queryexpr = Field{Object: yyDollar[1].queryexpr, As: yyDollar[2].token.Literal, Alias: yyDollar[3].identifier}
 		}
-	case 344:
+	case 467:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1848
+//line parser.y:2487
 		{
-			yyVAL.queryexpr = Table{Object: Dual{Dual: yyDollar[1].token.Literal}}
+			yyVAL.queryexprs = []QueryExpression{yyDollar[1].queryexpr}
 		}
-	case 345:
+	case 468:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:1852
+//line parser.y:2491
 		{
-			yyVAL.queryexpr = Parentheses{Expr: yyDollar[2].queryexpr}
+			yyVAL.queryexprs = append([]QueryExpression{yyDollar[1].queryexpr}, yyDollar[3].queryexprs...)
 		}
-	case 346:
+	case 469:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:1858
+//line parser.y:2497
 		{
 			yyVAL.queryexpr = Join{Join: yyDollar[3].token.Literal, Table: yyDollar[1].queryexpr, JoinTable: yyDollar[4].queryexpr, JoinType: yyDollar[2].token, Condition: nil}
 		}
-	case 347:
+	case 470:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line parser.y:1862
+//line parser.y:2501
 		{
 			yyVAL.queryexpr = Join{Join: yyDollar[3].token.Literal, Table: yyDollar[1].queryexpr, JoinTable: yyDollar[4].queryexpr, JoinType: yyDollar[2].token, Condition: yyDollar[5].queryexpr}
 		}
-	case 348:
+	case 471:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line parser.y:1866
+//line parser.y:2505
 		{
 			yyVAL.queryexpr = Join{Join: yyDollar[4].token.Literal, Table: yyDollar[1].queryexpr, JoinTable: yyDollar[5].queryexpr, JoinType: yyDollar[3].token, Direction: yyDollar[2].token, Condition: yyDollar[6].queryexpr}
 		}
-	case 349:
+	case 472:
 		yyDollar = yyS[yypt-7 : yypt+1]
-		//line parser.y:1870
+//line parser.y:2509
 		{
 			yyVAL.queryexpr = Join{Join: yyDollar[4].token.Literal, Table: yyDollar[1].queryexpr, JoinTable: yyDollar[5].queryexpr, JoinType: yyDollar[3].token, Direction: yyDollar[2].token, Condition: JoinCondition{Literal: yyDollar[6].token.Literal, On: yyDollar[7].queryexpr}}
 		}
-	case 350:
+	case 473:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line parser.y:1874
+//line parser.y:2513
 		{
 			yyVAL.queryexpr = Join{Join: yyDollar[4].token.Literal, Table: yyDollar[1].queryexpr, JoinTable: yyDollar[5].queryexpr, JoinType: yyDollar[3].token, Natural: yyDollar[2].token}
 		}
-	case 351:
+	case 474:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line parser.y:1878
+//line parser.y:2517
 		{
 			yyVAL.queryexpr = Join{Join: yyDollar[5].token.Literal, Table: yyDollar[1].queryexpr, JoinTable: yyDollar[6].queryexpr, JoinType: yyDollar[4].token, Direction: yyDollar[3].token, Natural: yyDollar[2].token}
 		}
-	case 352:
+	case 475:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:1884
+//line parser.y:2523
 		{
 			yyVAL.queryexpr = JoinCondition{Literal: yyDollar[1].token.Literal, On: yyDollar[2].queryexpr}
 		}
-	case 353:
+	case 476:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:1888
+//line parser.y:2527
 		{
 			yyVAL.queryexpr = JoinCondition{Literal: yyDollar[1].token.Literal, Using: yyDollar[3].queryexprs}
 		}
-	case 354:
+	case 477:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1894
+//line parser.y:2533
 		{
 			yyVAL.queryexpr = yyDollar[1].queryexpr
 		}
-	case 355:
+	case 478:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1898
+//line parser.y:2537
 		{
 			yyVAL.queryexpr = yyDollar[1].queryexpr
 		}
-	case 356:
+	case 479:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1904
+//line parser.y:2543
 		{
 			yyVAL.queryexpr = Field{Object: yyDollar[1].queryexpr}
 		}
-	case 357:
+	case 480:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:1908
+//line parser.y:2547
 		{
 			yyVAL.queryexpr = Field{Object: yyDollar[1].queryexpr, As: yyDollar[2].token.Literal, Alias: yyDollar[3].identifier}
 		}
-	case 358:
+	case 481:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1912
+//line parser.y:2551
 		{
 			yyVAL.queryexpr = Field{Object: yyDollar[1].queryexpr}
 		}
-	case 359:
+	case 482:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line parser.y:1918
+//line parser.y:2557
 		{
 			yyVAL.queryexpr = CaseExpr{Case: yyDollar[1].token.Literal, End: yyDollar[5].token.Literal, Value: yyDollar[2].queryexpr, When: yyDollar[3].queryexprs, Else: yyDollar[4].queryexpr}
 		}
-	case 360:
+	case 483:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line parser.y:1924
+//line parser.y:2563
 		{
 			yyVAL.queryexpr = nil
 		}
-	case 361:
+	case 484:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1928
+//line parser.y:2567
 		{
 			yyVAL.queryexpr = yyDollar[1].queryexpr
 		}
-	case 362:
+	case 485:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:1934
+//line parser.y:2573
 		{
 			yyVAL.queryexprs = []QueryExpression{CaseExprWhen{When: yyDollar[1].token.Literal, Then: yyDollar[3].token.Literal, Condition: yyDollar[2].queryexpr, Result: yyDollar[4].queryexpr}}
 		}
-	case 363:
+	case 486:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line parser.y:1938
+//line parser.y:2577
 		{
 			yyVAL.queryexprs = append([]QueryExpression{CaseExprWhen{When: yyDollar[1].token.Literal, Then: yyDollar[3].token.Literal, Condition: yyDollar[2].queryexpr, Result: yyDollar[4].queryexpr}}, yyDollar[5].queryexprs...)
 		}
-	case 364:
+	case 487:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line parser.y:1944
+//line parser.y:2583
 		{
 			yyVAL.queryexpr = nil
 		}
-	case 365:
+	case 488:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:1948
+//line parser.y:2587
 		{
 			yyVAL.queryexpr = CaseExprElse{Else: yyDollar[1].token.Literal, Result: yyDollar[2].queryexpr}
 		}
-	case 366:
+	case 489:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1954
+//line parser.y:2593
 		{
 			yyVAL.queryexprs = []QueryExpression{yyDollar[1].queryexpr}
 		}
-	case 367:
+	case 490:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:1958
+//line parser.y:2597
 		{
 			yyVAL.queryexprs = append([]QueryExpression{yyDollar[1].queryexpr}, yyDollar[3].queryexprs...)
 		}
-	case 368:
+	case 491:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1964
+//line parser.y:2603
 		{
 			yyVAL.queryexprs = []QueryExpression{yyDollar[1].queryexpr}
 		}
-	case 369:
+	case 492:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:1968
+//line parser.y:2607
 		{
 			yyVAL.queryexprs = append([]QueryExpression{yyDollar[1].queryexpr}, yyDollar[3].queryexprs...)
 		}
-	case 370:
+	case 493:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1974
+//line parser.y:2613
 		{
 			yyVAL.queryexprs = []QueryExpression{yyDollar[1].queryexpr}
 		}
-	case 371:
+	case 494:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:1978
+//line parser.y:2617
 		{
 			yyVAL.queryexprs = append([]QueryExpression{yyDollar[1].queryexpr}, yyDollar[3].queryexprs...)
 		}
-	case 372:
+	case 495:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1984
+//line parser.y:2623
 		{
 			yyVAL.queryexprs = []QueryExpression{Table{Object: yyDollar[1].queryexpr}}
 		}
-	case 373:
+	case 496:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:1988
+//line parser.y:2627
 		{
 			yyVAL.queryexprs = append([]QueryExpression{Table{Object: yyDollar[1].queryexpr}}, yyDollar[3].queryexprs...)
 		}
-	case 374:
+	case 497:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:1994
+//line parser.y:2633
 		{
 			yyVAL.queryexprs = []QueryExpression{Table{Object: yyDollar[1].queryexpr}}
 		}
-	case 375:
+	case 498:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:1998
+//line parser.y:2637
 		{
 			yyVAL.queryexprs = append([]QueryExpression{Table{Object: yyDollar[1].queryexpr}}, yyDollar[3].queryexprs...)
 		}
-	case 376:
+	case 499:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:2004
+//line parser.y:2643
 		{
 			yyVAL.queryexprs = []QueryExpression{yyDollar[1].identifier}
 		}
-	case 377:
+	case 500:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:2008
+//line parser.y:2647
 		{
 			yyVAL.queryexprs = append([]QueryExpression{yyDollar[1].identifier}, yyDollar[3].queryexprs...)
 		}
-	case 378:
+	case 501:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:2014
+//line parser.y:2653
 		{
 			yyVAL.queryexprs = []QueryExpression{yyDollar[1].queryexpr}
 		}
-	case 379:
+	case 502:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:2018
+//line parser.y:2657
 		{
 			yyVAL.queryexprs = append([]QueryExpression{yyDollar[1].queryexpr}, yyDollar[3].queryexprs...)
 		}
-	case 380:
-		yyDollar = yyS[yypt-6 : yypt+1]
-		//line parser.y:2024
+	case 503:
+		yyDollar = yyS[yypt-8 : yypt+1]
+//line parser.y:2663
 		{
-			yyVAL.expression = InsertQuery{WithClause: yyDollar[1].queryexpr, Table: Table{Object: yyDollar[4].queryexpr}, ValuesList: yyDollar[6].queryexprs}
+			yyVAL.expression = InsertQuery{BaseExpr: NewBaseExpr(yyDollar[2].token), WithClause: yyDollar[1].queryexpr, Hints: yyDollar[3].hints, Table: Table{Object: yyDollar[5].queryexpr}, ValuesList: yyDollar[7].queryexprs, ReturningClause: yyDollar[8].queryexpr}
 		}
-	case 381:
-		yyDollar = yyS[yypt-9 : yypt+1]
-		//line parser.y:2028
+	case 504:
+		yyDollar = yyS[yypt-11 : yypt+1]
+//line parser.y:2667
 		{
-			yyVAL.expression = InsertQuery{WithClause: yyDollar[1].queryexpr, Table: Table{Object: yyDollar[4].queryexpr}, Fields: yyDollar[6].queryexprs, ValuesList: yyDollar[9].queryexprs}
+			yyVAL.expression = InsertQuery{BaseExpr: NewBaseExpr(yyDollar[2].token), WithClause: yyDollar[1].queryexpr, Hints: yyDollar[3].hints, Table: Table{Object: yyDollar[5].queryexpr}, Fields: yyDollar[7].queryexprs, ValuesList: yyDollar[10].queryexprs, ReturningClause: yyDollar[11].queryexpr}
 		}
-	case 382:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line parser.y:2032
+	case 505:
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line parser.y:2671
 		{
-			yyVAL.expression = InsertQuery{WithClause: yyDollar[1].queryexpr, Table: Table{Object: yyDollar[4].queryexpr}, Query: yyDollar[5].queryexpr.(SelectQuery)}
+			yyVAL.expression = InsertQuery{BaseExpr: NewBaseExpr(yyDollar[2].token), WithClause: yyDollar[1].queryexpr, Hints: yyDollar[3].hints, Table: Table{Object: yyDollar[5].queryexpr}, Query: yyDollar[6].queryexpr.(SelectQuery), ReturningClause: yyDollar[7].queryexpr}
 		}
-	case 383:
-		yyDollar = yyS[yypt-8 : yypt+1]
-		//line parser.y:2036
+	case 506:
+		yyDollar = yyS[yypt-10 : yypt+1]
+//line parser.y:2675
 		{
-			yyVAL.expression = InsertQuery{WithClause: yyDollar[1].queryexpr, Table: Table{Object: yyDollar[4].queryexpr}, Fields: yyDollar[6].queryexprs, Query: yyDollar[8].queryexpr.(SelectQuery)}
+			yyVAL.expression = InsertQuery{BaseExpr: NewBaseExpr(yyDollar[2].token), WithClause: yyDollar[1].queryexpr, Hints: yyDollar[3].hints, Table: Table{Object: yyDollar[5].queryexpr}, Fields: yyDollar[7].queryexprs, Query: yyDollar[9].queryexpr.(SelectQuery), ReturningClause: yyDollar[10].queryexpr}
 		}
-	case 384:
-		yyDollar = yyS[yypt-7 : yypt+1]
-		//line parser.y:2042
+	case 507:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:2681
 		{
-			yyVAL.expression = UpdateQuery{WithClause: yyDollar[1].queryexpr, Tables: yyDollar[3].queryexprs, SetList: yyDollar[5].updatesets, FromClause: yyDollar[6].queryexpr, WhereClause: yyDollar[7].queryexpr}
+			yyVAL.expression = yyDollar[1].expression
 		}
-	case 385:
+	case 508:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line parser.y:2685
+		{
+			query := yyDollar[3].expression.(ReplaceQuery)
+			query.WithClause = WithClause{With: yyDollar[1].token.Literal, InlineTables: yyDollar[2].queryexprs}
+			yyVAL.expression = query
+		}
+	case 509:
+		yyDollar = yyS[yypt-10 : yypt+1]
+//line parser.y:2693
+		{
+			yyVAL.expression = ReplaceQuery{BaseExpr: NewBaseExpr(yyDollar[1].token), Hints: yyDollar[2].hints, Table: Table{Object: yyDollar[4].queryexpr}, Keys: yyDollar[7].queryexprs, ValuesList: yyDollar[10].queryexprs}
+		}
+	case 510:
+		yyDollar = yyS[yypt-13 : yypt+1]
+//line parser.y:2697
+		{
+			yyVAL.expression = ReplaceQuery{BaseExpr: NewBaseExpr(yyDollar[1].token), Hints: yyDollar[2].hints, Table: Table{Object: yyDollar[4].queryexpr}, Fields: yyDollar[6].queryexprs, Keys: yyDollar[10].queryexprs, ValuesList: yyDollar[13].queryexprs}
+		}
+	case 511:
+		yyDollar = yyS[yypt-9 : yypt+1]
+//line parser.y:2701
+		{
+			yyVAL.expression = ReplaceQuery{BaseExpr: NewBaseExpr(yyDollar[1].token), Hints: yyDollar[2].hints, Table: Table{Object: yyDollar[4].queryexpr}, Keys: yyDollar[7].queryexprs, Query: yyDollar[9].queryexpr.(SelectQuery)}
+		}
+	case 512:
+		yyDollar = yyS[yypt-12 : yypt+1]
+//line parser.y:2705
+		{
+			yyVAL.expression = ReplaceQuery{BaseExpr: NewBaseExpr(yyDollar[1].token), Hints: yyDollar[2].hints, Table: Table{Object: yyDollar[4].queryexpr}, Fields: yyDollar[6].queryexprs, Keys: yyDollar[10].queryexprs, Query: yyDollar[12].queryexpr.(SelectQuery)}
+		}
+	case 513:
+		yyDollar = yyS[yypt-9 : yypt+1]
+//line parser.y:2711
+		{
+			yyVAL.expression = UpdateQuery{BaseExpr: NewBaseExpr(yyDollar[2].token), WithClause: yyDollar[1].queryexpr, Hints: yyDollar[3].hints, Tables: yyDollar[4].queryexprs, SetList: yyDollar[6].updatesets, FromClause: yyDollar[7].queryexpr, WhereClause: yyDollar[8].queryexpr, ReturningClause: yyDollar[9].queryexpr}
+		}
+	case 514:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:2048
+//line parser.y:2717
 		{
 			yyVAL.updateset = UpdateSet{Field: yyDollar[1].queryexpr, Value: yyDollar[3].queryexpr}
 		}
-	case 386:
+	case 515:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:2054
+//line parser.y:2723
 		{
 			yyVAL.updatesets = []UpdateSet{yyDollar[1].updateset}
 		}
-	case 387:
+	case 516:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:2058
+//line parser.y:2727
 		{
 			yyVAL.updatesets = append([]UpdateSet{yyDollar[1].updateset}, yyDollar[3].updatesets...)
 		}
-	case 388:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line parser.y:2064
+	case 517:
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line parser.y:2733
 		{
-			from := FromClause{From: yyDollar[3].token.Literal, Tables: yyDollar[4].queryexprs}
-			yyVAL.expression = DeleteQuery{BaseExpr: NewBaseExpr(yyDollar[2].token), WithClause: yyDollar[1].queryexpr, FromClause: from, WhereClause: yyDollar[5].queryexpr}
+			from := FromClause{From: yyDollar[4].token.Literal, Tables: yyDollar[5].queryexprs}
+			yyVAL.expression = DeleteQuery{BaseExpr: NewBaseExpr(yyDollar[2].token), WithClause: yyDollar[1].queryexpr, Hints: yyDollar[3].hints, FromClause: from, WhereClause: yyDollar[6].queryexpr, ReturningClause: yyDollar[7].queryexpr}
 		}
-	case 389:
-		yyDollar = yyS[yypt-6 : yypt+1]
-		//line parser.y:2069
+	case 518:
+		yyDollar = yyS[yypt-8 : yypt+1]
+//line parser.y:2738
+		{
+			from := FromClause{From: yyDollar[5].token.Literal, Tables: yyDollar[6].queryexprs}
+			yyVAL.expression = DeleteQuery{BaseExpr: NewBaseExpr(yyDollar[2].token), WithClause: yyDollar[1].queryexpr, Hints: yyDollar[3].hints, Tables: yyDollar[4].queryexprs, FromClause: from, WhereClause: yyDollar[7].queryexpr, ReturningClause: yyDollar[8].queryexpr}
+		}
+	case 519:
+		yyDollar = yyS[yypt-9 : yypt+1]
+//line parser.y:2743
 		{
 			from := FromClause{From: yyDollar[4].token.Literal, Tables: yyDollar[5].queryexprs}
-			yyVAL.expression = DeleteQuery{BaseExpr: NewBaseExpr(yyDollar[2].token), WithClause: yyDollar[1].queryexpr, Tables: yyDollar[3].queryexprs, FromClause: from, WhereClause: yyDollar[6].queryexpr}
+			yyVAL.expression = DeleteQuery{BaseExpr: NewBaseExpr(yyDollar[2].token), WithClause: yyDollar[1].queryexpr, Hints: yyDollar[3].hints, FromClause: from, Using: yyDollar[7].queryexprs, WhereClause: yyDollar[8].queryexpr, ReturningClause: yyDollar[9].queryexpr}
 		}
-	case 390:
+	case 520:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line parser.y:2750
+		{
+			yyVAL.queryexpr = nil
+		}
+	case 521:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line parser.y:2754
+		{
+			yyVAL.queryexpr = ReturningClause{BaseExpr: NewBaseExpr(yyDollar[1].token), Returning: yyDollar[1].token.Literal, Fields: yyDollar[2].queryexprs}
+		}
+	case 522:
+		yyDollar = yyS[yypt-9 : yypt+1]
+//line parser.y:2760
+		{
+			yyVAL.expression = MergeQuery{BaseExpr: NewBaseExpr(yyDollar[2].token), WithClause: yyDollar[1].queryexpr, Table: Table{Object: yyDollar[4].queryexpr}, Source: yyDollar[6].queryexpr, Condition: yyDollar[8].queryexpr, WhenList: yyDollar[9].mergewhens}
+		}
+	case 523:
+		yyDollar = yyS[yypt-10 : yypt+1]
+//line parser.y:2764
+		{
+			yyVAL.expression = MergeQuery{BaseExpr: NewBaseExpr(yyDollar[2].token), WithClause: yyDollar[1].queryexpr, Table: Table{Object: yyDollar[4].queryexpr, Alias: yyDollar[5].identifier}, Source: yyDollar[7].queryexpr, Condition: yyDollar[9].queryexpr, WhenList: yyDollar[10].mergewhens}
+		}
+	case 524:
+		yyDollar = yyS[yypt-11 : yypt+1]
+//line parser.y:2768
+		{
+			yyVAL.expression = MergeQuery{BaseExpr: NewBaseExpr(yyDollar[2].token), WithClause: yyDollar[1].queryexpr, Table: Table{Object: yyDollar[4].queryexpr, As: yyDollar[5].token.Literal, Alias: yyDollar[6].identifier}, Source: yyDollar[8].queryexpr, Condition: yyDollar[10].queryexpr, WhenList: yyDollar[11].mergewhens}
+		}
+	case 525:
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line parser.y:2774
+		{
+			yyVAL.mergewhen = MergeWhen{BaseExpr: NewBaseExpr(yyDollar[1].token), Condition: yyDollar[3].queryexpr, Operation: yyDollar[5].token, SetList: yyDollar[7].updatesets}
+		}
+	case 526:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line parser.y:2778
+		{
+			yyVAL.mergewhen = MergeWhen{BaseExpr: NewBaseExpr(yyDollar[1].token), Condition: yyDollar[3].queryexpr, Operation: yyDollar[5].token}
+		}
+	case 527:
+		yyDollar = yyS[yypt-8 : yypt+1]
+//line parser.y:2782
+		{
+			yyVAL.mergewhen = MergeWhen{BaseExpr: NewBaseExpr(yyDollar[1].token), NotMatched: true, Condition: yyDollar[4].queryexpr, Operation: yyDollar[6].token, Values: yyDollar[8].queryexpr}
+		}
+	case 528:
+		yyDollar = yyS[yypt-11 : yypt+1]
+//line parser.y:2786
+		{
+			yyVAL.mergewhen = MergeWhen{BaseExpr: NewBaseExpr(yyDollar[1].token), NotMatched: true, Condition: yyDollar[4].queryexpr, Operation: yyDollar[6].token, Fields: yyDollar[8].queryexprs, Values: yyDollar[11].queryexpr}
+		}
+	case 529:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:2792
+		{
+			yyVAL.mergewhens = []MergeWhen{yyDollar[1].mergewhen}
+		}
+	case 530:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line parser.y:2796
+		{
+			yyVAL.mergewhens = append([]MergeWhen{yyDollar[1].mergewhen}, yyDollar[2].mergewhens...)
+		}
+	case 531:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line parser.y:2802
+		{
+			yyVAL.queryexpr = nil
+		}
+	case 532:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line parser.y:2806
+		{
+			yyVAL.queryexpr = yyDollar[2].queryexpr
+		}
+	case 533:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:2076
+//line parser.y:2812
 		{
 			yyVAL.elseif = []ElseIf{{Condition: yyDollar[2].queryexpr, Statements: yyDollar[4].program}}
 		}
-	case 391:
+	case 534:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line parser.y:2080
+//line parser.y:2816
 		{
 			yyVAL.elseif = append([]ElseIf{{Condition: yyDollar[2].queryexpr, Statements: yyDollar[4].program}}, yyDollar[5].elseif...)
 		}
-	case 392:
+	case 535:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line parser.y:2086
+//line parser.y:2822
 		{
 			yyVAL.elseexpr = Else{}
 		}
-	case 393:
+	case 536:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:2090
+//line parser.y:2826
 		{
 			yyVAL.elseexpr = Else{Statements: yyDollar[2].program}
 		}
-	case 394:
+	case 537:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:2096
+//line parser.y:2832
 		{
 			yyVAL.elseif = []ElseIf{{Condition: yyDollar[2].queryexpr, Statements: yyDollar[4].program}}
 		}
-	case 395:
+	case 538:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line parser.y:2100
+//line parser.y:2836
 		{
 			yyVAL.elseif = append([]ElseIf{{Condition: yyDollar[2].queryexpr, Statements: yyDollar[4].program}}, yyDollar[5].elseif...)
 		}
-	case 396:
+	case 539:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line parser.y:2106
+//line parser.y:2842
 		{
 			yyVAL.elseexpr = Else{}
 		}
-	case 397:
+	case 540:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:2110
+//line parser.y:2846
 		{
 			yyVAL.elseexpr = Else{Statements: yyDollar[2].program}
 		}
-	case 398:
+	case 541:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:2116
+//line parser.y:2852
 		{
 			yyVAL.elseif = []ElseIf{{Condition: yyDollar[2].queryexpr, Statements: yyDollar[4].program}}
 		}
-	case 399:
+	case 542:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line parser.y:2120
+//line parser.y:2856
 		{
 			yyVAL.elseif = append([]ElseIf{{Condition: yyDollar[2].queryexpr, Statements: yyDollar[4].program}}, yyDollar[5].elseif...)
 		}
-	case 400:
+	case 543:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line parser.y:2126
+//line parser.y:2862
 		{
 			yyVAL.elseexpr = Else{}
 		}
-	case 401:
+	case 544:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:2130
+//line parser.y:2866
 		{
 			yyVAL.elseexpr = Else{Statements: yyDollar[2].program}
 		}
-	case 402:
+	case 545:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:2136
+//line parser.y:2872
 		{
 			yyVAL.elseif = []ElseIf{{Condition: yyDollar[2].queryexpr, Statements: yyDollar[4].program}}
 		}
-	case 403:
+	case 546:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line parser.y:2140
+//line parser.y:2876
 		{
 			yyVAL.elseif = append([]ElseIf{{Condition: yyDollar[2].queryexpr, Statements: yyDollar[4].program}}, yyDollar[5].elseif...)
 		}
-	case 404:
+	case 547:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line parser.y:2146
+//line parser.y:2882
 		{
 			yyVAL.elseexpr = Else{}
 		}
-	case 405:
+	case 548:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:2150
+//line parser.y:2886
 		{
 			yyVAL.elseexpr = Else{Statements: yyDollar[2].program}
 		}
-	case 406:
+	case 549:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:2156
+//line parser.y:2892
 		{
 			yyVAL.casewhen = []CaseWhen{{Condition: yyDollar[2].queryexpr, Statements: yyDollar[4].program}}
 		}
-	case 407:
+	case 550:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line parser.y:2160
+//line parser.y:2896
 		{
 			yyVAL.casewhen = append([]CaseWhen{{Condition: yyDollar[2].queryexpr, Statements: yyDollar[4].program}}, yyDollar[5].casewhen...)
 		}
-	case 408:
+	case 551:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line parser.y:2166
+//line parser.y:2902
 		{
 			yyVAL.caseelse = CaseElse{}
 		}
-	case 409:
+	case 552:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:2170
+//line parser.y:2906
 		{
 			yyVAL.caseelse = CaseElse{Statements: yyDollar[2].program}
 		}
-	case 410:
+	case 553:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:2176
+//line parser.y:2912
 		{
 			yyVAL.casewhen = []CaseWhen{{Condition: yyDollar[2].queryexpr, Statements: yyDollar[4].program}}
 		}
-	case 411:
+	case 554:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line parser.y:2180
+//line parser.y:2916
 		{
 			yyVAL.casewhen = append([]CaseWhen{{Condition: yyDollar[2].queryexpr, Statements: yyDollar[4].program}}, yyDollar[5].casewhen...)
 		}
-	case 412:
+	case 555:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line parser.y:2186
+//line parser.y:2922
 		{
 			yyVAL.caseelse = CaseElse{}
 		}
-	case 413:
+	case 556:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:2190
+//line parser.y:2926
 		{
 			yyVAL.caseelse = CaseElse{Statements: yyDollar[2].program}
 		}
-	case 414:
+	case 557:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:2196
+//line parser.y:2932
 		{
 			yyVAL.casewhen = []CaseWhen{{Condition: yyDollar[2].queryexpr, Statements: yyDollar[4].program}}
 		}
-	case 415:
+	case 558:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line parser.y:2200
+//line parser.y:2936
 		{
 			yyVAL.casewhen = append([]CaseWhen{{Condition: yyDollar[2].queryexpr, Statements: yyDollar[4].program}}, yyDollar[5].casewhen...)
 		}
-	case 416:
+	case 559:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line parser.y:2206
+//line parser.y:2942
 		{
 			yyVAL.caseelse = CaseElse{}
 		}
-	case 417:
+	case 560:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:2210
+//line parser.y:2946
 		{
 			yyVAL.caseelse = CaseElse{Statements: yyDollar[2].program}
 		}
-	case 418:
+	case 561:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line parser.y:2216
+//line parser.y:2952
 		{
 			yyVAL.casewhen = []CaseWhen{{Condition: yyDollar[2].queryexpr, Statements: yyDollar[4].program}}
 		}
-	case 419:
+	case 562:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line parser.y:2220
+//line parser.y:2956
 		{
 			yyVAL.casewhen = append([]CaseWhen{{Condition: yyDollar[2].queryexpr, Statements: yyDollar[4].program}}, yyDollar[5].casewhen...)
 		}
-	case 420:
+	case 563:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line parser.y:2226
+//line parser.y:2962
 		{
 			yyVAL.caseelse = CaseElse{}
 		}
-	case 421:
+	case 564:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line parser.y:2230
+//line parser.y:2966
 		{
 			yyVAL.caseelse = CaseElse{Statements: yyDollar[2].program}
 		}
-	case 422:
+	case 565:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:2236
+//line parser.y:2972
 		{
 			yyVAL.identifier = Identifier{BaseExpr: NewBaseExpr(yyDollar[1].token), Literal: yyDollar[1].token.Literal, Quoted: yyDollar[1].token.Quoted}
 		}
-	case 423:
+	case 566:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:2240
+//line parser.y:2976
 		{
 			yyVAL.identifier = Identifier{BaseExpr: NewBaseExpr(yyDollar[1].token), Literal: yyDollar[1].token.Literal, Quoted: yyDollar[1].token.Quoted}
 		}
-	case 424:
+	case 567:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:2244
+//line parser.y:2980
 		{
 			yyVAL.identifier = Identifier{BaseExpr: NewBaseExpr(yyDollar[1].token), Literal: yyDollar[1].token.Literal, Quoted: yyDollar[1].token.Quoted}
 		}
-	case 425:
+	case 568:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:2248
+//line parser.y:2984
 		{
 			yyVAL.identifier = Identifier{BaseExpr: NewBaseExpr(yyDollar[1].token), Literal: yyDollar[1].token.Literal, Quoted: yyDollar[1].token.Quoted}
 		}
-	case 426:
+	case 569:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:2252
+//line parser.y:2988
 		{
 			yyVAL.identifier = Identifier{BaseExpr: NewBaseExpr(yyDollar[1].token), Literal: yyDollar[1].token.Literal, Quoted: yyDollar[1].token.Quoted}
 		}
-	case 427:
+	case 570:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:2256
+//line parser.y:2992
 		{
 			yyVAL.identifier = Identifier{BaseExpr: NewBaseExpr(yyDollar[1].token), Literal: yyDollar[1].token.Literal, Quoted: yyDollar[1].token.Quoted}
 		}
-	case 428:
+	case 571:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:2260
+//line parser.y:2996
 		{
 			yyVAL.identifier = Identifier{BaseExpr: NewBaseExpr(yyDollar[1].token), Literal: yyDollar[1].token.Literal, Quoted: yyDollar[1].token.Quoted}
 		}
-	case 429:
+	case 572:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:2264
+//line parser.y:3000
 		{
 			yyVAL.identifier = Identifier{BaseExpr: NewBaseExpr(yyDollar[1].token), Literal: yyDollar[1].token.Literal, Quoted: yyDollar[1].token.Quoted}
 		}
-	case 430:
+	case 573:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:3004
+		{
+			yyVAL.identifier = Identifier{BaseExpr: NewBaseExpr(yyDollar[1].token), Literal: yyDollar[1].token.Literal, Quoted: yyDollar[1].token.Quoted}
+		}
+	case 574:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:3008
+		{
+			yyVAL.identifier = Identifier{BaseExpr: NewBaseExpr(yyDollar[1].token), Literal: yyDollar[1].token.Literal, Quoted: yyDollar[1].token.Quoted}
+		}
+	case 575:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:3012
+		{
+			yyVAL.identifier = Identifier{BaseExpr: NewBaseExpr(yyDollar[1].token), Literal: yyDollar[1].token.Literal, Quoted: yyDollar[1].token.Quoted}
+		}
+	case 576:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:3016
+		{
+			yyVAL.identifier = Identifier{BaseExpr: NewBaseExpr(yyDollar[1].token), Literal: yyDollar[1].token.Literal, Quoted: yyDollar[1].token.Quoted}
+		}
+	case 577:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:3020
+		{
+			yyVAL.identifier = Identifier{BaseExpr: NewBaseExpr(yyDollar[1].token), Literal: yyDollar[1].token.Literal, Quoted: yyDollar[1].token.Quoted}
+		}
+	case 578:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:3024
+		{
+			yyVAL.identifier = Identifier{BaseExpr: NewBaseExpr(yyDollar[1].token), Literal: yyDollar[1].token.Literal, Quoted: yyDollar[1].token.Quoted}
+		}
+	case 579:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:3028
+		{
+			yyVAL.identifier = Identifier{BaseExpr: NewBaseExpr(yyDollar[1].token), Literal: yyDollar[1].token.Literal, Quoted: yyDollar[1].token.Quoted}
+		}
+	case 580:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:3032
+		{
+			yyVAL.identifier = Identifier{BaseExpr: NewBaseExpr(yyDollar[1].token), Literal: yyDollar[1].token.Literal, Quoted: yyDollar[1].token.Quoted}
+		}
+	case 581:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:3036
+		{
+			yyVAL.identifier = Identifier{BaseExpr: NewBaseExpr(yyDollar[1].token), Literal: yyDollar[1].token.Literal, Quoted: yyDollar[1].token.Quoted}
+		}
+	case 582:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:3040
+		{
+			yyVAL.identifier = Identifier{BaseExpr: NewBaseExpr(yyDollar[1].token), Literal: yyDollar[1].token.Literal, Quoted: yyDollar[1].token.Quoted}
+		}
+	case 583:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:3044
+		{
+			yyVAL.identifier = Identifier{BaseExpr: NewBaseExpr(yyDollar[1].token), Literal: yyDollar[1].token.Literal, Quoted: yyDollar[1].token.Quoted}
+		}
+	case 584:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:3048
+		{
+			yyVAL.identifier = Identifier{BaseExpr: NewBaseExpr(yyDollar[1].token), Literal: yyDollar[1].token.Literal, Quoted: yyDollar[1].token.Quoted}
+		}
+	case 585:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:3052
+		{
+			yyVAL.identifier = Identifier{BaseExpr: NewBaseExpr(yyDollar[1].token), Literal: yyDollar[1].token.Literal, Quoted: yyDollar[1].token.Quoted}
+		}
+	case 586:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:3056
+		{
+			yyVAL.identifier = Identifier{BaseExpr: NewBaseExpr(yyDollar[1].token), Literal: yyDollar[1].token.Literal, Quoted: yyDollar[1].token.Quoted}
+		}
+	case 587:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line parser.y:3060
+		{
+			yyVAL.identifier = Identifier{BaseExpr: NewBaseExpr(yyDollar[1].token), Literal: yyDollar[1].token.Literal, Quoted: yyDollar[1].token.Quoted}
+		}
+	case 588:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:2270
+//line parser.y:3066
 		{
 			yyVAL.variable = Variable{BaseExpr: NewBaseExpr(yyDollar[1].token), Name: yyDollar[1].token.Literal}
 		}
-	case 431:
+	case 589:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:2276
+//line parser.y:3072
 		{
 			yyVAL.variables = []Variable{yyDollar[1].variable}
 		}
-	case 432:
+	case 590:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:2280
+//line parser.y:3076
 		{
 			yyVAL.variables = append([]Variable{yyDollar[1].variable}, yyDollar[3].variables...)
 		}
-	case 433:
+	case 591:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:2286
+//line parser.y:3082
 		{
 			yyVAL.queryexpr = VariableSubstitution{Variable: yyDollar[1].variable, Value: yyDollar[3].queryexpr}
 		}
-	case 434:
+	case 592:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:2292
+//line parser.y:3088
 		{
 			yyVAL.varassign = VariableAssignment{Variable: yyDollar[1].variable}
 		}
-	case 435:
+	case 593:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:2296
+//line parser.y:3092
 		{
 			yyVAL.varassign = VariableAssignment{Variable: yyDollar[1].variable, Value: yyDollar[3].queryexpr}
 		}
-	case 436:
+	case 594:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:2302
+//line parser.y:3098
 		{
 			yyVAL.varassigns = []VariableAssignment{yyDollar[1].varassign}
 		}
-	case 437:
+	case 595:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line parser.y:2306
+//line parser.y:3102
 		{
 			yyVAL.varassigns = append([]VariableAssignment{yyDollar[1].varassign}, yyDollar[3].varassigns...)
 		}
-	case 438:
+	case 596:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:2312
+//line parser.y:3108
 		{
 			yyVAL.envvar = EnvironmentVariable{BaseExpr: NewBaseExpr(yyDollar[1].token), Name: yyDollar[1].token.Literal, Quoted: yyDollar[1].token.Quoted}
 		}
-	case 439:
+	case 597:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:2318
+//line parser.y:3114
 		{
 			yyVAL.queryexpr = RuntimeInformation{BaseExpr: NewBaseExpr(yyDollar[1].token), Name: yyDollar[1].token.Literal}
 		}
-	case 440:
+	case 598:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line parser.y:2324
+//line parser.y:3120
 		{
 			yyVAL.token = Token{}
 		}
-	case 441:
+	case 599:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:2328
+//line parser.y:3124
 		{
 			yyVAL.token = yyDollar[1].token
 		}
-	case 442:
+	case 600:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line parser.y:2334
+//line parser.y:3130
 		{
 			yyVAL.token = Token{}
 		}
-	case 443:
+	case 601:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:2338
+//line parser.y:3134
 		{
 			yyVAL.token = yyDollar[1].token
 		}
-	case 444:
+	case 602:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line parser.y:2344
+//line parser.y:3140
 		{
 			yyVAL.token = Token{}
 		}
-	case 445:
+	case 603:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:2348
+//line parser.y:3144
 		{
 			yyVAL.token = yyDollar[1].token
 		}
-	case 446:
+	case 604:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line parser.y:2354
+//line parser.y:3150
 		{
 			yyVAL.token = Token{}
 		}
-	case 447:
+	case 605:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:2358
+//line parser.y:3154
 		{
 			yyVAL.token = yyDollar[1].token
 		}
-	case 448:
+	case 606:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:2364
+//line parser.y:3160
 		{
 			yyVAL.token = yyDollar[1].token
 		}
-	case 449:
+	case 607:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:2368
+//line parser.y:3164
 		{
 			yyVAL.token = yyDollar[1].token
 		}
-	case 450:
+	case 608:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line parser.y:2374
+//line parser.y:3170
 		{
 			yyVAL.token = Token{}
 		}
-	case 451:
+	case 609:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:2378
+//line parser.y:3174
 		{
 			yyVAL.token = yyDollar[1].token
 		}
-	case 452:
+	case 610:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line parser.y:2384
+//line parser.y:3180
 		{
 			yyVAL.token = Token{}
 		}
-	case 453:
+	case 611:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:2388
+//line parser.y:3184
 		{
 			yyVAL.token = yyDollar[1].token
 		}
-	case 454:
+	case 612:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line parser.y:2394
+//line parser.y:3190
 		{
 			yyVAL.token = Token{}
 		}
-	case 455:
+	case 613:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:2398
+//line parser.y:3194
 		{
 			yyVAL.token = yyDollar[1].token
 		}
-	case 456:
+	case 614:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:2404
+//line parser.y:3200
 		{
 			yyVAL.token = yyDollar[1].token
 		}
-	case 457:
+	case 615:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line parser.y:2408
+//line parser.y:3204
 		{
 			yyDollar[1].token.Token = COMPARISON_OP
 			yyVAL.token = yyDollar[1].token
@@ -23,8 +23,14 @@ import (
     envvar      EnvironmentVariable
     updateset   UpdateSet
     updatesets  []UpdateSet
+    mergewhen   MergeWhen
+    mergewhens  []MergeWhen
     columndef   ColumnDefault
     columndefs  []ColumnDefault
+    constraint  ColumnConstraint
+    constraints []ColumnConstraint
+    columnspec  ColumnDefinition
+    columnspecs []ColumnDefinition
     elseif      []ElseIf
     elseexpr    Else
     casewhen    []CaseWhen
@@ -32,6 +38,10 @@ import (
     fetchpos    FetchPosition
     replaceval  ReplaceValue
     replacevals []ReplaceValue
+    stmtparam   StatementParameter
+    stmtparams  []StatementParameter
+    collation   Collation
+    hints       []Hint
     token       Token
 }
 
@@ -60,11 +70,23 @@ import (
 %type<statement>   table_operation_statement
 %type<columndef>   column_default
 %type<columndefs>  column_defaults
+%type<constraint>  column_constraint
+%type<constraints> column_constraints
+%type<columnspec>  column_definition
+%type<columnspecs> column_definitions
 %type<expression>  column_position
 %type<statement>   cursor_statement
 %type<statement>   temporary_table_statement
+%type<queryexprs>  primary_key
+%type<statement>   index_statement
+%type<statement>   sequence_statement
+%type<queryexpr>   sequence_start
+%type<queryexpr>   sequence_increment
 %type<replaceval>  replace_value
 %type<replacevals> replace_values
+%type<stmtparam>   statement_parameter
+%type<stmtparams>  statement_parameters
+%type<collation>   collation
 %type<statement>   prepared_statement
 %type<varassign>   parameter
 %type<varassigns>  parameters
@@ -80,17 +102,28 @@ import (
 %type<queryexpr>   select_entity
 %type<queryexpr>   select_set_entity
 %type<queryexpr>   select_clause
+%type<hints>       hints
 %type<queryexpr>   from_clause
 %type<queryexpr>   where_clause
 %type<queryexpr>   group_by_clause
+%type<queryexpr>   group_item
+%type<queryexprs>  group_items
+%type<queryexpr>   grouping_set
+%type<queryexprs>  grouping_sets
 %type<queryexpr>   having_clause
 %type<queryexpr>   order_by_clause
 %type<queryexpr>   limit_clause
 %type<queryexpr>   limit_with
 %type<queryexpr>   offset_clause
+%type<queryexpr>   fetch_clause
+%type<token>       fetch_first_position
+%type<token>       row_unit
 %type<queryexpr>   with_clause
 %type<queryexpr>   inline_table
 %type<queryexprs>  inline_tables
+%type<queryexpr>   cycle_clause
+%type<token>       materialization
+%type<token>       deterministic
 %type<queryexpr>   primitive_type
 %type<queryexpr>   ternary
 %type<queryexpr>   null
@@ -119,6 +152,7 @@ import (
 %type<queryexpr>   analytic_clause_with_windowing
 %type<queryexpr>   partition_clause
 %type<queryexpr>   windowing_clause
+%type<token>       window_frame_exclusion
 %type<queryexpr>   window_position
 %type<queryexpr>   window_relative_position
 %type<queryexpr>   window_frame_low
@@ -131,6 +165,11 @@ import (
 %type<queryexprs>  updatable_tables
 %type<queryexpr>   virtual_table_object
 %type<queryexpr>   table
+%type<queryexpr>   table_sample
+%type<queryexpr>   pivot_table
+%type<queryexpr>   pivot_value
+%type<queryexprs>  pivot_values
+%type<token>       table_sample_unit
 %type<queryexpr>   join
 %type<queryexpr>   join_condition
 %type<queryexpr>   field_object
@@ -145,10 +184,17 @@ import (
 %type<queryexprs>  identifiers
 %type<queryexprs>  fields
 %type<expression>  insert_query
+%type<expression>  replace_query
+%type<expression>  replace_entity
 %type<expression>  update_query
 %type<updateset>   update_set
 %type<updatesets>  update_set_list
 %type<expression>  delete_query
+%type<queryexpr>   returning_clause
+%type<expression>  merge_query
+%type<mergewhen>   merge_when
+%type<mergewhens>  merge_when_list
+%type<queryexpr>   merge_condition
 %type<elseif>      elseif
 %type<elseexpr>    else
 %type<elseif>      in_loop_elseif
@@ -184,35 +230,46 @@ import (
 %type<token>       comparison_operator
 
 %token<token> IDENTIFIER STRING INTEGER FLOAT BOOLEAN TERNARY DATETIME
-%token<token> VARIABLE FLAG ENVIRONMENT_VARIABLE RUNTIME_INFORMATION EXTERNAL_COMMAND PLACEHOLDER
+%token<token> VARIABLE FLAG ENVIRONMENT_VARIABLE RUNTIME_INFORMATION EXTERNAL_COMMAND PLACEHOLDER HINT
 %token<token> SELECT FROM UPDATE SET UNSET DELETE WHERE INSERT INTO VALUES AS DUAL STDIN
 %token<token> RECURSIVE
 %token<token> CREATE ADD DROP ALTER TABLE FIRST LAST AFTER BEFORE DEFAULT RENAME TO VIEW
 %token<token> ORDER GROUP HAVING BY ASC DESC LIMIT OFFSET PERCENT
+%token<token> ROLLUP CUBE GROUPING SETS
 %token<token> JOIN INNER OUTER LEFT RIGHT FULL CROSS ON USING NATURAL
 %token<token> UNION INTERSECT EXCEPT
 %token<token> ALL ANY EXISTS IN
-%token<token> AND OR NOT BETWEEN LIKE IS NULL
+%token<token> AND OR NOT BETWEEN LIKE ILIKE IS NULL
 %token<token> DISTINCT WITH
 %token<token> RANGE UNBOUNDED PRECEDING FOLLOWING CURRENT ROW
 %token<token> CASE IF ELSEIF WHILE WHEN THEN ELSE DO END
 %token<token> DECLARE CURSOR FOR FETCH OPEN CLOSE DISPOSE PREPARE
 %token<token> NEXT PRIOR ABSOLUTE RELATIVE
 %token<token> SEPARATOR PARTITION OVER
-%token<token> COMMIT ROLLBACK
+%token<token> COMMIT ROLLBACK LOCK UNLOCK EXCLUSIVE
 %token<token> CONTINUE BREAK EXIT
 %token<token> ECHO PRINT PRINTF SOURCE EXECUTE CHDIR PWD RELOAD REMOVE SYNTAX TRIGGER
 %token<token> FUNCTION AGGREGATE BEGIN RETURN
 %token<token> IGNORE WITHIN
-%token<token> VAR SHOW
-%token<token> TIES NULLS ROWS
+%token<token> VAR SHOW EXPLAIN
+%token<token> TIES NULLS ROWS ONLY
+%token<token> GROUPS EXCLUDE NO OTHERS
 %token<token> CSV JSON FIXED LTSV
 %token<token> JSON_ROW JSON_TABLE
-%token<token> COUNT JSON_OBJECT
+%token<token> TABLESAMPLE REPEATABLE
+%token<token> PIVOT UNPIVOT
+%token<token> MERGE MATCHED REPLACE RETURNING
+%token<token> CYCLE RESTRICT MATERIALIZED
+%token<token> INDEX UNIQUE CHECK
+%token<token> SEQUENCE START INCREMENT
+%token<token> TEMPORARY PRIMARY KEY
+%token<token> UNNEST ORDINALITY
+%token<token> LOCAL COLLATE DETERMINISTIC LANGUAGE
+%token<token> COUNT MODE JSON_OBJECT
 %token<token> AGGREGATE_FUNCTION LIST_FUNCTION ANALYTIC_FUNCTION FUNCTION_NTH FUNCTION_WITH_INS
 %token<token> COMPARISON_OP STRING_OP SUBSTITUTION_OP
 %token<token> UMINUS UPLUS
-%token<token> ';' '*' '=' '-' '+' '!' '(' ')'
+%token<token> ';' '*' '=' '-' '+' '!' '(' ')' '[' ']'
 
 %right SUBSTITUTION_OP
 %left UNION EXCEPT
@@ -221,11 +278,12 @@ import (
 %left OR
 %left AND
 %right NOT
-%nonassoc '=' COMPARISON_OP IS BETWEEN IN LIKE
+%nonassoc '=' COMPARISON_OP IS BETWEEN IN LIKE ILIKE
 %left STRING_OP
 %left '+' '-'
 %left '*' '/' '%'
 %right UMINUS UPLUS '!'
+%left '['
 
 %%
 
@@ -285,6 +343,10 @@ common_statement
     {
         $$ = $1
     }
+    | replace_query
+    {
+        $$ = $1
+    }
     | update_query
     {
         $$ = $1
@@ -293,6 +355,10 @@ common_statement
     {
         $$ = $1
     }
+    | merge_query
+    {
+        $$ = $1
+    }
     | table_operation_statement
     {
         $$ = $1
@@ -313,6 +379,14 @@ common_statement
     {
         $$ = $1
     }
+    | index_statement
+    {
+        $$ = $1
+    }
+    | sequence_statement
+    {
+        $$ = $1
+    }
     | prepared_statement
     {
         $$ = $1
@@ -603,20 +677,50 @@ transaction_statement
     {
         $$ = TransactionControl{BaseExpr: NewBaseExpr($1), Token: $1.Token}
     }
+    | COMMIT TABLE identifier
+    {
+        $$ = TransactionControl{BaseExpr: NewBaseExpr($1), Token: $1.Token, Table: $3}
+    }
+    | ROLLBACK TABLE identifier
+    {
+        $$ = TransactionControl{BaseExpr: NewBaseExpr($1), Token: $1.Token, Table: $3}
+    }
+    | LOCK TABLE identifiers IN EXCLUSIVE MODE
+    {
+        $$ = LockTable{BaseExpr: NewBaseExpr($1), Tables: $3}
+    }
+    | UNLOCK
+    {
+        $$ = UnlockTable{BaseExpr: NewBaseExpr($1)}
+    }
+    | UNLOCK TABLE identifiers
+    {
+        $$ = UnlockTable{BaseExpr: NewBaseExpr($1), Tables: $3}
+    }
 
 table_operation_statement
-    : CREATE TABLE identifier '(' identifiers ')'
+    : CREATE TABLE identifier '(' column_definitions ')'
     {
-        $$ = CreateTable{Table: $3, Fields: $5}
+        fields, constraints := splitColumnDefinitions($5)
+        $$ = CreateTable{Table: $3, Fields: fields, Constraints: constraints}
     }
-    | CREATE TABLE identifier '(' identifiers ')' as select_query
+    | CREATE TABLE identifier '(' column_definitions ')' as select_query
     {
-        $$ = CreateTable{Table: $3, Fields: $5, Query: $8}
+        fields, constraints := splitColumnDefinitions($5)
+        $$ = CreateTable{Table: $3, Fields: fields, Constraints: constraints, Query: $8}
     }
     | CREATE TABLE identifier as select_query
     {
         $$ = CreateTable{Table: $3, Query: $5}
     }
+    | CREATE VIEW identifier AS select_query
+    {
+        $$ = CreateView{BaseExpr: NewBaseExpr($1), View: $3, Query: $5}
+    }
+    | CREATE VIEW identifier '(' identifiers ')' AS select_query
+    {
+        $$ = CreateView{BaseExpr: NewBaseExpr($1), View: $3, Fields: $5, Query: $8}
+    }
     | ALTER TABLE updatable_table_identifier ADD column_default column_position
     {
         $$ = AddColumns{Table: $3, Columns: []ColumnDefault{$5}, Position: $6}
@@ -666,6 +770,49 @@ column_defaults
         $$ = append([]ColumnDefault{$1}, $3...)
     }
 
+column_constraint
+    : NOT NULL
+    {
+        $$ = ColumnConstraint{BaseExpr: NewBaseExpr($1), Type: $1}
+    }
+    | UNIQUE
+    {
+        $$ = ColumnConstraint{BaseExpr: NewBaseExpr($1), Type: $1}
+    }
+    | CHECK '(' value ')'
+    {
+        $$ = ColumnConstraint{BaseExpr: NewBaseExpr($1), Type: $1, Condition: $3}
+    }
+
+column_constraints
+    :
+    {
+        $$ = nil
+    }
+    | column_constraint column_constraints
+    {
+        $$ = append([]ColumnConstraint{$1}, $2...)
+    }
+
+column_definition
+    : identifier column_constraints
+    {
+        for i := range $2 {
+            $2[i].Column = $1
+        }
+        $$ = ColumnDefinition{Column: $1, Constraints: $2}
+    }
+
+column_definitions
+    : column_definition
+    {
+        $$ = []ColumnDefinition{$1}
+    }
+    | column_definition ',' column_definitions
+    {
+        $$ = append([]ColumnDefinition{$1}, $3...)
+    }
+
 column_position
     :
     {
@@ -723,11 +870,73 @@ temporary_table_statement
     {
         $$ = ViewDeclaration{View: $2, Query: $5}
     }
+    | CREATE TEMPORARY TABLE identifier '(' identifiers ')' primary_key
+    {
+        $$ = ViewDeclaration{View: $4, Fields: $6, PrimaryKey: $8}
+    }
+    | CREATE TEMPORARY TABLE identifier '(' identifiers ')' primary_key AS select_query
+    {
+        $$ = ViewDeclaration{View: $4, Fields: $6, PrimaryKey: $8, Query: $10}
+    }
+    | CREATE TEMPORARY TABLE identifier primary_key AS select_query
+    {
+        $$ = ViewDeclaration{View: $4, PrimaryKey: $5, Query: $7}
+    }
     | DISPOSE VIEW identifier
     {
         $$ = DisposeView{View: $3}
     }
 
+primary_key
+    :
+    {
+        $$ = nil
+    }
+    | PRIMARY KEY '(' identifiers ')'
+    {
+        $$ = $4
+    }
+
+index_statement
+    : DECLARE INDEX identifier ON identifier '(' identifiers ')'
+    {
+        $$ = IndexDeclaration{Index: $3, Table: $5, Columns: $7}
+    }
+    | DISPOSE INDEX identifier
+    {
+        $$ = DisposeIndex{Index: $3}
+    }
+
+sequence_statement
+    : DECLARE SEQUENCE identifier sequence_start sequence_increment
+    {
+        $$ = SequenceDeclaration{Sequence: $3, Start: $4, Increment: $5}
+    }
+    | DISPOSE SEQUENCE identifier
+    {
+        $$ = DisposeSequence{Sequence: $3}
+    }
+
+sequence_start
+    :
+    {
+        $$ = nil
+    }
+    | START value
+    {
+        $$ = $2
+    }
+
+sequence_increment
+    :
+    {
+        $$ = nil
+    }
+    | INCREMENT value
+    {
+        $$ = $2
+    }
+
 replace_value
     : value
     {
@@ -748,11 +957,35 @@ replace_values
         $$ = append([]ReplaceValue{$1}, $3...)
     }
 
+statement_parameter
+    : identifier
+    {
+        $$ = StatementParameter{BaseExpr: $1.BaseExpr, Type: $1}
+    }
+    | identifier identifier
+    {
+        $$ = StatementParameter{BaseExpr: $1.BaseExpr, Name: $1, Type: $2}
+    }
+
+statement_parameters
+    : statement_parameter
+    {
+        $$ = []StatementParameter{$1}
+    }
+    | statement_parameter ',' statement_parameters
+    {
+        $$ = append([]StatementParameter{$1}, $3...)
+    }
+
 prepared_statement
     : PREPARE identifier FROM STRING
     {
         $$ = StatementPreparation{Name: $2, Statement: value.NewString($4.Literal)}
     }
+    | PREPARE identifier '(' statement_parameters ')' FROM STRING
+    {
+        $$ = StatementPreparation{Name: $2, Parameters: $4, Statement: value.NewString($7.Literal)}
+    }
     | EXECUTE identifier
     {
         $$ = ExecuteStatement{BaseExpr: NewBaseExpr($1), Name: $2}
@@ -813,13 +1046,21 @@ function_parameters
     }
 
 user_defined_function_statement
-    : DECLARE identifier FUNCTION '(' ')' AS BEGIN function_program END
+    : DECLARE identifier FUNCTION '(' ')' deterministic AS BEGIN function_program END
+    {
+        $$ = FunctionDeclaration{Name: $2, Deterministic: $6, Statements: $9}
+    }
+    | DECLARE identifier FUNCTION '(' function_parameters ')' deterministic AS BEGIN function_program END
     {
-        $$ = FunctionDeclaration{Name: $2, Statements: $8}
+        $$ = FunctionDeclaration{Name: $2, Parameters: $5, Deterministic: $7, Statements: $10}
     }
-    | DECLARE identifier FUNCTION '(' function_parameters ')' AS BEGIN function_program END
+    | DECLARE identifier FUNCTION '(' ')' deterministic LANGUAGE identifier AS STRING
     {
-        $$ = FunctionDeclaration{Name: $2, Parameters: $5, Statements: $9}
+        $$ = FunctionDeclaration{Name: $2, Deterministic: $6, Language: $8, Source: value.NewString($10.Literal)}
+    }
+    | DECLARE identifier FUNCTION '(' function_parameters ')' deterministic LANGUAGE identifier AS STRING
+    {
+        $$ = FunctionDeclaration{Name: $2, Parameters: $5, Deterministic: $7, Language: $9, Source: value.NewString($11.Literal)}
     }
     | DECLARE identifier AGGREGATE '(' identifier ')' AS BEGIN function_program END
     {
@@ -895,6 +1136,22 @@ command_statement
     {
         $$ = SetFlag{BaseExpr: NewBaseExpr($1), Name: $2.Literal, Value: $4}
     }
+    | SET LOCAL FLAG '=' identifier
+    {
+        $$ = SetFlag{BaseExpr: NewBaseExpr($1), Local: $2, Name: $3.Literal, Value: $5}
+    }
+    | SET LOCAL FLAG '=' value
+    {
+        $$ = SetFlag{BaseExpr: NewBaseExpr($1), Local: $2, Name: $3.Literal, Value: $5}
+    }
+    | SET LOCAL FLAG TO identifier
+    {
+        $$ = SetFlag{BaseExpr: NewBaseExpr($1), Local: $2, Name: $3.Literal, Value: $5}
+    }
+    | SET LOCAL FLAG TO value
+    {
+        $$ = SetFlag{BaseExpr: NewBaseExpr($1), Local: $2, Name: $3.Literal, Value: $5}
+    }
     | ADD value TO FLAG
     {
         $$ = AddFlagElement{BaseExpr: NewBaseExpr($1), Name: $4.Literal, Value: $2}
@@ -975,6 +1232,14 @@ command_statement
     {
         $$ = Reload{BaseExpr: NewBaseExpr($1), Type: $2}
     }
+    | EXPLAIN select_query
+    {
+        $$ = Explain{BaseExpr: NewBaseExpr($1), Query: $2}
+    }
+    | EXPLAIN identifier select_query
+    {
+        $$ = Explain{BaseExpr: NewBaseExpr($1), Option: $2, Query: $3}
+    }
 
 trigger_statement
     : TRIGGER identifier
@@ -991,7 +1256,24 @@ trigger_statement
     }
 
 select_query
-    : with_clause select_entity order_by_clause limit_clause offset_clause
+    : with_clause select_entity order_by_clause
+    {
+        $$ = SelectQuery{
+            WithClause:    $1,
+            SelectEntity:  $2,
+            OrderByClause: $3,
+        }
+    }
+    | with_clause select_entity order_by_clause limit_clause
+    {
+        $$ = SelectQuery{
+            WithClause:    $1,
+            SelectEntity:  $2,
+            OrderByClause: $3,
+            LimitClause:   $4,
+        }
+    }
+    | with_clause select_entity order_by_clause limit_clause offset_clause
     {
         $$ = SelectQuery{
             WithClause:    $1,
@@ -1001,6 +1283,34 @@ select_query
             OffsetClause:  $5,
         }
     }
+    | with_clause select_entity order_by_clause offset_clause
+    {
+        $$ = SelectQuery{
+            WithClause:    $1,
+            SelectEntity:  $2,
+            OrderByClause: $3,
+            OffsetClause:  $4,
+        }
+    }
+    | with_clause select_entity order_by_clause offset_clause fetch_clause
+    {
+        $$ = SelectQuery{
+            WithClause:    $1,
+            SelectEntity:  $2,
+            OrderByClause: $3,
+            LimitClause:   $5,
+            OffsetClause:  $4,
+        }
+    }
+    | with_clause select_entity order_by_clause fetch_clause
+    {
+        $$ = SelectQuery{
+            WithClause:    $1,
+            SelectEntity:  $2,
+            OrderByClause: $3,
+            LimitClause:   $4,
+        }
+    }
 
 select_entity
     : select_clause from_clause where_clause group_by_clause having_clause
@@ -1013,6 +1323,10 @@ select_entity
             HavingClause:  $5,
         }
     }
+    | VALUES row_values
+    {
+        $$ = ValuesTable{BaseExpr: NewBaseExpr($1), Values: $1.Literal, ValuesList: $2}
+    }
     | select_set_entity UNION all select_set_entity
     {
         $$ = SelectSet{
@@ -1052,9 +1366,23 @@ select_set_entity
     }
 
 select_clause
-    : SELECT distinct fields
+    : SELECT hints distinct fields
     {
-        $$ = SelectClause{BaseExpr: NewBaseExpr($1), Select: $1.Literal, Distinct: $2, Fields: $3}
+        $$ = SelectClause{BaseExpr: NewBaseExpr($1), Select: $1.Literal, Hints: $2, Distinct: $3, Fields: $4}
+    }
+    | SELECT hints DISTINCT ON '(' values ')' fields
+    {
+        $$ = SelectClause{BaseExpr: NewBaseExpr($1), Select: $1.Literal, Hints: $2, Distinct: $3, DistinctOn: DistinctOn{BaseExpr: NewBaseExpr($4), On: $4.Literal, Values: $6}, Fields: $8}
+    }
+
+hints
+    :
+    {
+        $$ = nil
+    }
+    | HINT
+    {
+        $$ = ParseHints($1.Literal)
     }
 
 from_clause
@@ -1082,11 +1410,63 @@ group_by_clause
     {
         $$ = nil
     }
-    | GROUP BY values
+    | GROUP BY group_items
     {
         $$ = GroupByClause{GroupBy: $1.Literal + " " + $2.Literal, Items: $3}
     }
 
+group_item
+    : value
+    {
+        $$ = $1
+    }
+    | ROLLUP '(' values ')'
+    {
+        $$ = Rollup{BaseExpr: NewBaseExpr($1), Rollup: $1.Literal, Items: $3}
+    }
+    | CUBE '(' values ')'
+    {
+        $$ = Cube{BaseExpr: NewBaseExpr($1), Cube: $1.Literal, Items: $3}
+    }
+    | GROUPING SETS '(' grouping_sets ')'
+    {
+        $$ = GroupingSets{BaseExpr: NewBaseExpr($1), GroupingSets: $1.Literal + " " + $2.Literal, Items: $4}
+    }
+
+group_items
+    : group_item
+    {
+        $$ = []QueryExpression{$1}
+    }
+    | group_item ',' group_items
+    {
+        $$ = append([]QueryExpression{$1}, $3...)
+    }
+
+grouping_set
+    : value
+    {
+        $$ = $1
+    }
+    | '(' ')'
+    {
+        $$ = ValueList{BaseExpr: NewBaseExpr($1)}
+    }
+    | '(' value ',' values ')'
+    {
+        $$ = ValueList{BaseExpr: NewBaseExpr($1), Values: append([]QueryExpression{$2}, $4...)}
+    }
+
+grouping_sets
+    : grouping_set
+    {
+        $$ = []QueryExpression{$1}
+    }
+    | grouping_set ',' grouping_sets
+    {
+        $$ = append([]QueryExpression{$1}, $3...)
+    }
+
 having_clause
     :
     {
@@ -1108,11 +1488,7 @@ order_by_clause
     }
 
 limit_clause
-    :
-    {
-        $$ = nil
-    }
-    | LIMIT value limit_with
+    : LIMIT value limit_with
     {
         $$ = LimitClause{BaseExpr: NewBaseExpr($1), Limit: $1.Literal, Value: $2, With: $3}
     }
@@ -1132,13 +1508,59 @@ limit_with
     }
 
 offset_clause
-    :
+    : OFFSET value
     {
-        $$ = nil
+        $$ = OffsetClause{BaseExpr: NewBaseExpr($1), Offset: $1.Literal, Value: $2}
     }
-    | OFFSET value
+    | OFFSET value row_unit
     {
-        $$ = OffsetClause{BaseExpr: NewBaseExpr($1), Offset: $1.Literal, Value: $2}
+        $$ = OffsetClause{BaseExpr: NewBaseExpr($1), Offset: $1.Literal, Value: $2, Unit: $3}
+    }
+
+fetch_clause
+    : FETCH fetch_first_position value row_unit ONLY
+    {
+        $$ = LimitClause{BaseExpr: NewBaseExpr($1), Limit: $1.Literal, Position: $2, Value: $3, Unit: $4, With: LimitWith{Type: $5}}
+    }
+    | FETCH fetch_first_position value row_unit WITH TIES
+    {
+        $$ = LimitClause{BaseExpr: NewBaseExpr($1), Limit: $1.Literal, Position: $2, Value: $3, Unit: $4, With: LimitWith{With: $5.Literal, Type: $6}}
+    }
+    | FETCH fetch_first_position value PERCENT row_unit ONLY
+    {
+        $$ = LimitClause{BaseExpr: NewBaseExpr($1), Limit: $1.Literal, Position: $2, Value: $3, Percent: $4.Literal, Unit: $5, With: LimitWith{Type: $6}}
+    }
+    | FETCH fetch_first_position value PERCENT row_unit WITH TIES
+    {
+        $$ = LimitClause{BaseExpr: NewBaseExpr($1), Limit: $1.Literal, Position: $2, Value: $3, Percent: $4.Literal, Unit: $5, With: LimitWith{With: $6.Literal, Type: $7}}
+    }
+    | FETCH fetch_first_position row_unit ONLY
+    {
+        $$ = LimitClause{BaseExpr: NewBaseExpr($1), Limit: $1.Literal, Position: $2, Value: NewIntegerValue(1), Unit: $3, With: LimitWith{Type: $4}}
+    }
+    | FETCH fetch_first_position row_unit WITH TIES
+    {
+        $$ = LimitClause{BaseExpr: NewBaseExpr($1), Limit: $1.Literal, Position: $2, Value: NewIntegerValue(1), Unit: $3, With: LimitWith{With: $4.Literal, Type: $5}}
+    }
+
+fetch_first_position
+    : FIRST
+    {
+        $$ = $1
+    }
+    | NEXT
+    {
+        $$ = $1
+    }
+
+row_unit
+    : ROW
+    {
+        $$ = $1
+    }
+    | ROWS
+    {
+        $$ = $1
     }
 
 with_clause
@@ -1152,13 +1574,48 @@ with_clause
     }
 
 inline_table
-    : recursive identifier AS '(' select_query ')'
+    : recursive identifier AS materialization '(' select_query ')' cycle_clause
+    {
+        $$ = InlineTable{Recursive: $1, Name: $2, As: $3.Literal, Materialization: $4, Query: $6.(SelectQuery), CycleClause: $8}
+    }
+    | recursive identifier '(' identifiers ')' AS materialization '(' select_query ')' cycle_clause
+    {
+        $$ = InlineTable{Recursive: $1, Name: $2, Fields: $4, As: $6.Literal, Materialization: $7, Query: $9.(SelectQuery), CycleClause: $11}
+    }
+
+deterministic
+    :
+    {
+        $$ = Token{}
+    }
+    | DETERMINISTIC
+    {
+        $$ = $1
+    }
+
+materialization
+    :
+    {
+        $$ = Token{}
+    }
+    | MATERIALIZED
+    {
+        $$ = $1
+    }
+    | NOT MATERIALIZED
+    {
+        $1.Literal = $1.Literal + " " + $2.Literal
+        $$ = $1
+    }
+
+cycle_clause
+    :
     {
-        $$ = InlineTable{Recursive: $1, Name: $2, As: $3.Literal, Query: $5.(SelectQuery)}
+        $$ = nil
     }
-    | recursive identifier '(' identifiers ')' AS '(' select_query ')'
+    | CYCLE identifiers RESTRICT
     {
-        $$ = InlineTable{Recursive: $1, Name: $2, Fields: $4, As: $6.Literal, Query: $8.(SelectQuery)}
+        $$ = CycleClause{BaseExpr: NewBaseExpr($1), Cycle: $1.Literal, Fields: $2, Restrict: $3.Literal}
     }
 
 inline_tables
@@ -1296,6 +1753,18 @@ value
     {
         $$ = Parentheses{Expr: $2}
     }
+    | '[' ']'
+    {
+        $$ = ArrayConstructor{BaseExpr: NewBaseExpr($1)}
+    }
+    | '[' values ']'
+    {
+        $$ = ArrayConstructor{BaseExpr: NewBaseExpr($1), Values: $2}
+    }
+    | value '[' value ']'
+    {
+        $$ = ArrayElement{BaseExpr: NewBaseExpr($2), Array: $1, Index: $3}
+    }
     | PLACEHOLDER
     {
         name := ""
@@ -1354,6 +1823,14 @@ order_item
     {
         $$ = OrderItem{Value: $1, Direction: $2, Nulls: $3.Literal, Position: $4}
     }
+    | order_value collation order_direction
+    {
+        $$ = OrderItem{Value: $1, Collation: $2, Direction: $3}
+    }
+    | order_value collation order_direction NULLS order_null_position
+    {
+        $$ = OrderItem{Value: $1, Collation: $2, Direction: $3, Nulls: $4.Literal, Position: $5}
+    }
 
 order_value
     : value
@@ -1365,6 +1842,12 @@ order_value
         $$ = $1
     }
 
+collation
+    : COLLATE STRING
+    {
+        $$ = Collation{BaseExpr: NewBaseExpr($1), Collate: $1.Literal, Name: $2.Literal}
+    }
+
 order_direction
     :
     {
@@ -1445,6 +1928,14 @@ comparison
     {
         $$ = Comparison{LHS: $1, Operator: "=", RHS: $3}
     }
+    | value COMPARISON_OP value collation
+    {
+        $$ = Comparison{LHS: $1, Operator: $2.Literal, RHS: $3, Collation: $4}
+    }
+    | value '=' value collation
+    {
+        $$ = Comparison{LHS: $1, Operator: "=", RHS: $3, Collation: $4}
+    }
     | row_value '=' row_value
     {
         $$ = Comparison{LHS: $1, Operator: "=", RHS: $3}
@@ -1489,6 +1980,14 @@ comparison
     {
         $$ = Like{Like: $3.Literal, LHS: $1, Pattern: $4, Negation: $2}
     }
+    | value ILIKE value
+    {
+        $$ = Like{Like: $2.Literal, LHS: $1, Pattern: $3}
+    }
+    | value NOT ILIKE value
+    {
+        $$ = Like{Like: $3.Literal, LHS: $1, Pattern: $4, Negation: $2}
+    }
     | value comparison_operator ANY row_value
     {
         $$ = Any{Any: $3.Literal, LHS: $1, Operator: $2.Literal, Values: $4}
@@ -1585,6 +2084,14 @@ function
     {
         $$ = Function{BaseExpr: NewBaseExpr($1), Name: $1.Literal, Args: $3}
     }
+    | GROUPING '(' arguments ')'
+    {
+        $$ = Function{BaseExpr: NewBaseExpr($1), Name: $1.Literal, Args: $3}
+    }
+    | REPLACE '(' arguments ')'
+    {
+        $$ = Function{BaseExpr: NewBaseExpr($1), Name: $1.Literal, Args: $3}
+    }
 
 
 aggregate_function
@@ -1596,6 +2103,10 @@ aggregate_function
     {
         $$ = AggregateFunction{BaseExpr: NewBaseExpr($1), Name: $1.Literal, Distinct: $3, Args: $4}
     }
+    | MODE '(' distinct arguments ')'
+    {
+        $$ = AggregateFunction{BaseExpr: NewBaseExpr($1), Name: $1.Literal, Distinct: $3, Args: $4}
+    }
     | COUNT '(' distinct arguments ')'
     {
         $$ = AggregateFunction{BaseExpr: NewBaseExpr($1), Name: $1.Literal, Distinct: $3, Args: $4}
@@ -1618,6 +2129,18 @@ list_function
     {
         $$ = ListFunction{BaseExpr: NewBaseExpr($1), Name: $1.Literal, Distinct: $3, Args: $4, WithinGroup: $6.Literal + " " + $7.Literal, OrderBy: $9}
     }
+    | LIST_FUNCTION '(' distinct arguments ORDER BY order_items ')'
+    {
+        $$ = ListFunction{BaseExpr: NewBaseExpr($1), Name: $1.Literal, Distinct: $3, Args: $4, OrderBy: OrderByClause{OrderBy: $5.Literal + " " + $6.Literal, Items: $7}}
+    }
+    | FIRST '(' value ORDER BY order_items ')'
+    {
+        $$ = ListFunction{BaseExpr: NewBaseExpr($1), Name: $1.Literal, Args: []QueryExpression{$3}, OrderBy: OrderByClause{OrderBy: $4.Literal + " " + $5.Literal, Items: $6}}
+    }
+    | LAST '(' value ORDER BY order_items ')'
+    {
+        $$ = ListFunction{BaseExpr: NewBaseExpr($1), Name: $1.Literal, Args: []QueryExpression{$3}, OrderBy: OrderByClause{OrderBy: $4.Literal + " " + $5.Literal, Items: $6}}
+    }
 
 analytic_function
     : identifier '(' arguments ')' OVER '(' analytic_clause_with_windowing ')'
@@ -1632,6 +2155,10 @@ analytic_function
     {
         $$ = AnalyticFunction{BaseExpr: NewBaseExpr($1), Name: $1.Literal, Distinct: $3, Args: $4, Over: $6.Literal, AnalyticClause: $8.(AnalyticClause)}
     }
+    | MODE '(' distinct arguments ')' OVER '(' analytic_clause_with_windowing ')'
+    {
+        $$ = AnalyticFunction{BaseExpr: NewBaseExpr($1), Name: $1.Literal, Distinct: $3, Args: $4, Over: $6.Literal, AnalyticClause: $8.(AnalyticClause)}
+    }
     | COUNT '(' distinct arguments ')' OVER '(' analytic_clause_with_windowing ')'
     {
         $$ = AnalyticFunction{BaseExpr: NewBaseExpr($1), Name: $1.Literal, Distinct: $3, Args: $4, Over: $6.Literal, AnalyticClause: $8.(AnalyticClause)}
@@ -1693,13 +2220,43 @@ partition_clause
     }
 
 windowing_clause
-    : ROWS window_position
+    : ROWS window_position window_frame_exclusion
+    {
+        $$ = WindowingClause{Rows: $1.Literal, FrameLow: $2, Exclusion: $3.Token, ExclusionLit: $3.Literal}
+    }
+    | ROWS BETWEEN window_frame_low AND window_frame_high window_frame_exclusion
+    {
+        $$ = WindowingClause{Rows: $1.Literal, FrameLow: $3, FrameHigh: $5, Between: $2.Literal, And: $4.Literal, Exclusion: $6.Token, ExclusionLit: $6.Literal}
+    }
+    | GROUPS window_position window_frame_exclusion
+    {
+        $$ = WindowingClause{Rows: $1.Literal, Groups: true, FrameLow: $2, Exclusion: $3.Token, ExclusionLit: $3.Literal}
+    }
+    | GROUPS BETWEEN window_frame_low AND window_frame_high window_frame_exclusion
+    {
+        $$ = WindowingClause{Rows: $1.Literal, Groups: true, FrameLow: $3, FrameHigh: $5, Between: $2.Literal, And: $4.Literal, Exclusion: $6.Token, ExclusionLit: $6.Literal}
+    }
+
+window_frame_exclusion
+    :
+    {
+        $$ = Token{}
+    }
+    | EXCLUDE CURRENT ROW
+    {
+        $$ = Token{Token: $2.Token, Literal: $1.Literal + " " + $2.Literal + " " + $3.Literal}
+    }
+    | EXCLUDE GROUP
+    {
+        $$ = Token{Token: $2.Token, Literal: $1.Literal + " " + $2.Literal}
+    }
+    | EXCLUDE TIES
     {
-        $$ = WindowingClause{Rows: $1.Literal, FrameLow: $2}
+        $$ = Token{Token: $2.Token, Literal: $1.Literal + " " + $2.Literal}
     }
-    | ROWS BETWEEN window_frame_low AND window_frame_high
+    | EXCLUDE NO OTHERS
     {
-        $$ = WindowingClause{Rows: $1.Literal, FrameLow: $3, FrameHigh: $5, Between: $2.Literal, And: $4.Literal}
+        $$ = Token{Token: $2.Token, Literal: $1.Literal + " " + $2.Literal + " " + $3.Literal}
     }
 
 window_position
@@ -1822,28 +2379,56 @@ virtual_table_object
     {
         $$ = JsonQuery{BaseExpr: NewBaseExpr($1), JsonQuery: $1.Literal, Query: $3, JsonText: $5}
     }
+    | UNNEST '(' value ')'
+    {
+        $$ = Unnest{BaseExpr: NewBaseExpr($1), Unnest: $1.Literal, Array: $3}
+    }
+    | UNNEST '(' value ')' WITH ORDINALITY
+    {
+        $$ = Unnest{BaseExpr: NewBaseExpr($1), Unnest: $1.Literal, Array: $3, With: $5.Literal, Ordinality: $6.Literal}
+    }
     | subquery
     {
         $$ = $1
     }
 
 table
-    : virtual_table_object
+    : virtual_table_object table_sample
     {
-        $$ = Table{Object: $1}
+        $$ = Table{Object: $1, Sample: $2}
     }
-    | virtual_table_object identifier
+    | virtual_table_object identifier table_sample
     {
-        $$ = Table{Object: $1, Alias: $2}
+        $$ = Table{Object: $1, Alias: $2, Sample: $3}
     }
-    | virtual_table_object AS identifier
+    | virtual_table_object AS identifier table_sample
     {
-        $$ = Table{Object: $1, As: $2.Literal, Alias: $3}
+        $$ = Table{Object: $1, As: $2.Literal, Alias: $3, Sample: $4}
+    }
+    | virtual_table_object identifier '(' identifiers ')' table_sample
+    {
+        $$ = Table{Object: $1, Alias: $2, Columns: $4, Sample: $6}
+    }
+    | virtual_table_object AS identifier '(' identifiers ')' table_sample
+    {
+        $$ = Table{Object: $1, As: $2.Literal, Alias: $3, Columns: $5, Sample: $7}
     }
     | join
     {
         $$ = Table{Object: $1}
     }
+    | pivot_table
+    {
+        $$ = Table{Object: $1}
+    }
+    | pivot_table identifier
+    {
+        $$ = Table{Object: $1, Alias: $2}
+    }
+    | pivot_table AS identifier
+    {
+        $$ = Table{Object: $1, As: $2.Literal, Alias: $3}
+    }
     | DUAL
     {
         $$ = Table{Object: Dual{Dual: $1.Literal}}
@@ -1853,6 +2438,60 @@ table
         $$ = Parentheses{Expr: $2}
     }
 
+table_sample
+    :
+    {
+        $$ = nil
+    }
+    | TABLESAMPLE '(' value table_sample_unit ')'
+    {
+        $$ = TableSample{BaseExpr: NewBaseExpr($1), TableSample: $1.Literal, Value: $3, Unit: $4}
+    }
+    | TABLESAMPLE '(' value table_sample_unit ')' REPEATABLE '(' value ')'
+    {
+        $$ = TableSample{BaseExpr: NewBaseExpr($1), TableSample: $1.Literal, Value: $3, Unit: $4, Repeatable: $6.Literal, Seed: $8}
+    }
+
+table_sample_unit
+    : PERCENT
+    {
+        $$ = $1
+    }
+    | ROWS
+    {
+        $$ = $1
+    }
+
+pivot_table
+    : table PIVOT '(' value FOR field_reference IN '(' pivot_values ')' ')'
+    {
+        $$ = Pivot{BaseExpr: NewBaseExpr($2), Table: $1, Pivot: $2.Literal, Aggregate: $4, For: $5.Literal, Column: $6, In: $7.Literal, Values: $9}
+    }
+    | table UNPIVOT '(' identifier FOR identifier IN '(' field_references ')' ')'
+    {
+        $$ = Unpivot{BaseExpr: NewBaseExpr($2), Table: $1, Unpivot: $2.Literal, Value: $4, For: $5.Literal, Name: $6, In: $7.Literal, Columns: $9}
+    }
+
+pivot_value
+    : value
+    {
+        $$ = Field{Object: $1}
+    }
+    | value AS identifier
+    {
+        $$ = Field{Object: $1, As: $2.Literal, Alias: $3}
+    }
+
+pivot_values
+    : pivot_value
+    {
+        $$ = []QueryExpression{$1}
+    }
+    | pivot_value ',' pivot_values
+    {
+        $$ = append([]QueryExpression{$1}, $3...)
+    }
+
 join
     : table CROSS JOIN table
     {
@@ -2020,27 +2659,57 @@ fields
     }
 
 insert_query
-    : with_clause INSERT INTO updatable_table_identifier VALUES row_values
+    : with_clause INSERT hints INTO updatable_table_identifier VALUES row_values returning_clause
+    {
+        $$ = InsertQuery{BaseExpr: NewBaseExpr($2), WithClause: $1, Hints: $3, Table: Table{Object: $5}, ValuesList: $7, ReturningClause: $8}
+    }
+    | with_clause INSERT hints INTO updatable_table_identifier '(' field_references ')' VALUES row_values returning_clause
+    {
+        $$ = InsertQuery{BaseExpr: NewBaseExpr($2), WithClause: $1, Hints: $3, Table: Table{Object: $5}, Fields: $7, ValuesList: $10, ReturningClause: $11}
+    }
+    | with_clause INSERT hints INTO updatable_table_identifier select_query returning_clause
+    {
+        $$ = InsertQuery{BaseExpr: NewBaseExpr($2), WithClause: $1, Hints: $3, Table: Table{Object: $5}, Query: $6.(SelectQuery), ReturningClause: $7}
+    }
+    | with_clause INSERT hints INTO updatable_table_identifier '(' field_references ')' select_query returning_clause
+    {
+        $$ = InsertQuery{BaseExpr: NewBaseExpr($2), WithClause: $1, Hints: $3, Table: Table{Object: $5}, Fields: $7, Query: $9.(SelectQuery), ReturningClause: $10}
+    }
+
+replace_query
+    : replace_entity
+    {
+        $$ = $1
+    }
+    | WITH inline_tables replace_entity
     {
-        $$ = InsertQuery{WithClause: $1, Table: Table{Object: $4}, ValuesList: $6}
+        query := $3.(ReplaceQuery)
+        query.WithClause = WithClause{With: $1.Literal, InlineTables: $2}
+        $$ = query
     }
-    | with_clause INSERT INTO updatable_table_identifier '(' field_references ')' VALUES row_values
+
+replace_entity
+    : REPLACE hints INTO updatable_table_identifier USING '(' field_references ')' VALUES row_values
+    {
+        $$ = ReplaceQuery{BaseExpr: NewBaseExpr($1), Hints: $2, Table: Table{Object: $4}, Keys: $7, ValuesList: $10}
+    }
+    | REPLACE hints INTO updatable_table_identifier '(' field_references ')' USING '(' field_references ')' VALUES row_values
     {
-        $$ = InsertQuery{WithClause: $1, Table: Table{Object: $4}, Fields: $6, ValuesList: $9}
+        $$ = ReplaceQuery{BaseExpr: NewBaseExpr($1), Hints: $2, Table: Table{Object: $4}, Fields: $6, Keys: $10, ValuesList: $13}
     }
-    | with_clause INSERT INTO updatable_table_identifier select_query
+    | REPLACE hints INTO updatable_table_identifier USING '(' field_references ')' select_query
     {
-        $$ = InsertQuery{WithClause: $1, Table: Table{Object: $4}, Query: $5.(SelectQuery)}
+        $$ = ReplaceQuery{BaseExpr: NewBaseExpr($1), Hints: $2, Table: Table{Object: $4}, Keys: $7, Query: $9.(SelectQuery)}
     }
-    | with_clause INSERT INTO updatable_table_identifier '(' field_references ')' select_query
+    | REPLACE hints INTO updatable_table_identifier '(' field_references ')' USING '(' field_references ')' select_query
     {
-        $$ = InsertQuery{WithClause: $1, Table: Table{Object: $4}, Fields: $6, Query: $8.(SelectQuery)}
+        $$ = ReplaceQuery{BaseExpr: NewBaseExpr($1), Hints: $2, Table: Table{Object: $4}, Fields: $6, Keys: $10, Query: $12.(SelectQuery)}
     }
 
 update_query
-    : with_clause UPDATE updatable_tables SET update_set_list from_clause where_clause
+    : with_clause UPDATE hints updatable_tables SET update_set_list from_clause where_clause returning_clause
     {
-        $$ = UpdateQuery{WithClause: $1, Tables: $3, SetList: $5, FromClause: $6, WhereClause: $7}
+        $$ = UpdateQuery{BaseExpr: NewBaseExpr($2), WithClause: $1, Hints: $3, Tables: $4, SetList: $6, FromClause: $7, WhereClause: $8, ReturningClause: $9}
     }
 
 update_set
@@ -2060,15 +2729,82 @@ update_set_list
     }
 
 delete_query
-    : with_clause DELETE FROM tables where_clause
+    : with_clause DELETE hints FROM tables where_clause returning_clause
+    {
+        from := FromClause{From: $4.Literal, Tables: $5}
+        $$ = DeleteQuery{BaseExpr: NewBaseExpr($2), WithClause: $1, Hints: $3, FromClause: from, WhereClause: $6, ReturningClause: $7}
+    }
+    | with_clause DELETE hints identified_tables FROM tables where_clause returning_clause
     {
-        from := FromClause{From: $3.Literal, Tables: $4}
-        $$ = DeleteQuery{BaseExpr: NewBaseExpr($2), WithClause: $1, FromClause: from, WhereClause: $5}
+        from := FromClause{From: $5.Literal, Tables: $6}
+        $$ = DeleteQuery{BaseExpr: NewBaseExpr($2), WithClause: $1, Hints: $3, Tables: $4, FromClause: from, WhereClause: $7, ReturningClause: $8}
     }
-    | with_clause DELETE identified_tables FROM tables where_clause
+    | with_clause DELETE hints FROM tables USING tables where_clause returning_clause
     {
         from := FromClause{From: $4.Literal, Tables: $5}
-        $$ = DeleteQuery{BaseExpr: NewBaseExpr($2), WithClause: $1, Tables: $3, FromClause: from, WhereClause: $6}
+        $$ = DeleteQuery{BaseExpr: NewBaseExpr($2), WithClause: $1, Hints: $3, FromClause: from, Using: $7, WhereClause: $8, ReturningClause: $9}
+    }
+
+returning_clause
+    :
+    {
+        $$ = nil
+    }
+    | RETURNING fields
+    {
+        $$ = ReturningClause{BaseExpr: NewBaseExpr($1), Returning: $1.Literal, Fields: $2}
+    }
+
+merge_query
+    : with_clause MERGE INTO updatable_table_identifier USING table ON value merge_when_list
+    {
+        $$ = MergeQuery{BaseExpr: NewBaseExpr($2), WithClause: $1, Table: Table{Object: $4}, Source: $6, Condition: $8, WhenList: $9}
+    }
+    | with_clause MERGE INTO updatable_table_identifier identifier USING table ON value merge_when_list
+    {
+        $$ = MergeQuery{BaseExpr: NewBaseExpr($2), WithClause: $1, Table: Table{Object: $4, Alias: $5}, Source: $7, Condition: $9, WhenList: $10}
+    }
+    | with_clause MERGE INTO updatable_table_identifier AS identifier USING table ON value merge_when_list
+    {
+        $$ = MergeQuery{BaseExpr: NewBaseExpr($2), WithClause: $1, Table: Table{Object: $4, As: $5.Literal, Alias: $6}, Source: $8, Condition: $10, WhenList: $11}
+    }
+
+merge_when
+    : WHEN MATCHED merge_condition THEN UPDATE SET update_set_list
+    {
+        $$ = MergeWhen{BaseExpr: NewBaseExpr($1), Condition: $3, Operation: $5, SetList: $7}
+    }
+    | WHEN MATCHED merge_condition THEN DELETE
+    {
+        $$ = MergeWhen{BaseExpr: NewBaseExpr($1), Condition: $3, Operation: $5}
+    }
+    | WHEN NOT MATCHED merge_condition THEN INSERT VALUES row_value
+    {
+        $$ = MergeWhen{BaseExpr: NewBaseExpr($1), NotMatched: true, Condition: $4, Operation: $6, Values: $8}
+    }
+    | WHEN NOT MATCHED merge_condition THEN INSERT '(' field_references ')' VALUES row_value
+    {
+        $$ = MergeWhen{BaseExpr: NewBaseExpr($1), NotMatched: true, Condition: $4, Operation: $6, Fields: $8, Values: $11}
+    }
+
+merge_when_list
+    : merge_when
+    {
+        $$ = []MergeWhen{$1}
+    }
+    | merge_when merge_when_list
+    {
+        $$ = append([]MergeWhen{$1}, $2...)
+    }
+
+merge_condition
+    :
+    {
+        $$ = nil
+    }
+    | AND value
+    {
+        $$ = $2
     }
 
 elseif
@@ -2240,6 +2976,22 @@ identifier
     {
         $$ = Identifier{BaseExpr: NewBaseExpr($1), Literal: $1.Literal, Quoted: $1.Quoted}
     }
+    | GROUPS
+    {
+        $$ = Identifier{BaseExpr: NewBaseExpr($1), Literal: $1.Literal, Quoted: $1.Quoted}
+    }
+    | EXCLUDE
+    {
+        $$ = Identifier{BaseExpr: NewBaseExpr($1), Literal: $1.Literal, Quoted: $1.Quoted}
+    }
+    | NO
+    {
+        $$ = Identifier{BaseExpr: NewBaseExpr($1), Literal: $1.Literal, Quoted: $1.Quoted}
+    }
+    | OTHERS
+    {
+        $$ = Identifier{BaseExpr: NewBaseExpr($1), Literal: $1.Literal, Quoted: $1.Quoted}
+    }
     | NULLS
     {
         $$ = Identifier{BaseExpr: NewBaseExpr($1), Literal: $1.Literal, Quoted: $1.Quoted}
@@ -2248,6 +3000,10 @@ identifier
     {
         $$ = Identifier{BaseExpr: NewBaseExpr($1), Literal: $1.Literal, Quoted: $1.Quoted}
     }
+    | ONLY
+    {
+        $$ = Identifier{BaseExpr: NewBaseExpr($1), Literal: $1.Literal, Quoted: $1.Quoted}
+    }
     | CSV
     {
         $$ = Identifier{BaseExpr: NewBaseExpr($1), Literal: $1.Literal, Quoted: $1.Quoted}
@@ -2264,6 +3020,46 @@ identifier
     {
         $$ = Identifier{BaseExpr: NewBaseExpr($1), Literal: $1.Literal, Quoted: $1.Quoted}
     }
+    | SETS
+    {
+        $$ = Identifier{BaseExpr: NewBaseExpr($1), Literal: $1.Literal, Quoted: $1.Quoted}
+    }
+    | MATCHED
+    {
+        $$ = Identifier{BaseExpr: NewBaseExpr($1), Literal: $1.Literal, Quoted: $1.Quoted}
+    }
+    | TEMPORARY
+    {
+        $$ = Identifier{BaseExpr: NewBaseExpr($1), Literal: $1.Literal, Quoted: $1.Quoted}
+    }
+    | PRIMARY
+    {
+        $$ = Identifier{BaseExpr: NewBaseExpr($1), Literal: $1.Literal, Quoted: $1.Quoted}
+    }
+    | KEY
+    {
+        $$ = Identifier{BaseExpr: NewBaseExpr($1), Literal: $1.Literal, Quoted: $1.Quoted}
+    }
+    | ORDINALITY
+    {
+        $$ = Identifier{BaseExpr: NewBaseExpr($1), Literal: $1.Literal, Quoted: $1.Quoted}
+    }
+    | LOCAL
+    {
+        $$ = Identifier{BaseExpr: NewBaseExpr($1), Literal: $1.Literal, Quoted: $1.Quoted}
+    }
+    | DETERMINISTIC
+    {
+        $$ = Identifier{BaseExpr: NewBaseExpr($1), Literal: $1.Literal, Quoted: $1.Quoted}
+    }
+    | LANGUAGE
+    {
+        $$ = Identifier{BaseExpr: NewBaseExpr($1), Literal: $1.Literal, Quoted: $1.Quoted}
+    }
+    | EXCLUSIVE
+    {
+        $$ = Identifier{BaseExpr: NewBaseExpr($1), Literal: $1.Literal, Quoted: $1.Quoted}
+    }
 
 variable
     : VARIABLE
@@ -2417,6 +3213,16 @@ func SetDebugLevel(level int, verbose bool) {
 	yyErrorVerbose = verbose
 }
 
+func splitColumnDefinitions(defs []ColumnDefinition) ([]QueryExpression, []ColumnConstraint) {
+    fields := make([]QueryExpression, 0, len(defs))
+    var constraints []ColumnConstraint
+    for _, def := range defs {
+        fields = append(fields, def.Column)
+        constraints = append(constraints, def.Constraints...)
+    }
+    return fields, constraints
+}
+
 func Parse(s string, sourceFile string, datetimeFormats []string, forPrepared bool) ([]Statement, int, error) {
     l := new(Lexer)
     l.Init(s, sourceFile, datetimeFormats, forPrepared)
@@ -152,6 +152,142 @@ var parseTests = []struct {
 			},
 		},
 	},
+	{
+		Input: "select c1 from table1 t tablesample (10 percent) repeatable (1)",
+		Output: []Statement{
+			SelectQuery{
+				SelectEntity: SelectEntity{
+					SelectClause: SelectClause{
+						BaseExpr: &BaseExpr{line: 1, char: 1},
+						Select:   "select",
+						Fields: []QueryExpression{
+							Field{
+								Object: FieldReference{BaseExpr: &BaseExpr{line: 1, char: 8}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 8}, Literal: "c1"}},
+							},
+						},
+					},
+					FromClause: FromClause{From: "from", Tables: []QueryExpression{
+						Table{
+							Object: Identifier{BaseExpr: &BaseExpr{line: 1, char: 16}, Literal: "table1"},
+							Alias:  Identifier{BaseExpr: &BaseExpr{line: 1, char: 23}, Literal: "t"},
+							Sample: TableSample{
+								BaseExpr:    &BaseExpr{line: 1, char: 25},
+								TableSample: "tablesample",
+								Value:       NewIntegerValueFromString("10"),
+								Unit:        Token{Token: PERCENT, Literal: "percent", Line: 1, Char: 41},
+								Repeatable:  "repeatable",
+								Seed:        NewIntegerValueFromString("1"),
+							},
+						},
+					}},
+				},
+			},
+		},
+	},
+	{
+		Input: "select c1 from table1 tablesample (100 rows)",
+		Output: []Statement{
+			SelectQuery{
+				SelectEntity: SelectEntity{
+					SelectClause: SelectClause{
+						BaseExpr: &BaseExpr{line: 1, char: 1},
+						Select:   "select",
+						Fields: []QueryExpression{
+							Field{
+								Object: FieldReference{BaseExpr: &BaseExpr{line: 1, char: 8}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 8}, Literal: "c1"}},
+							},
+						},
+					},
+					FromClause: FromClause{From: "from", Tables: []QueryExpression{
+						Table{
+							Object: Identifier{BaseExpr: &BaseExpr{line: 1, char: 16}, Literal: "table1"},
+							Sample: TableSample{
+								BaseExpr:    &BaseExpr{line: 1, char: 23},
+								TableSample: "tablesample",
+								Value:       NewIntegerValueFromString("100"),
+								Unit:        Token{Token: ROWS, Literal: "rows", Line: 1, Char: 40},
+							},
+						},
+					}},
+				},
+			},
+		},
+	},
+	{
+		Input: "select * from t pivot (sum(x) for b in ('p', 'q' as qq)) as pv",
+		Output: []Statement{
+			SelectQuery{
+				SelectEntity: SelectEntity{
+					SelectClause: SelectClause{
+						BaseExpr: &BaseExpr{line: 1, char: 1},
+						Select:   "select",
+						Fields: []QueryExpression{
+							Field{Object: AllColumns{BaseExpr: &BaseExpr{line: 1, char: 8}}},
+						},
+					},
+					FromClause: FromClause{From: "from", Tables: []QueryExpression{
+						Table{
+							Object: Pivot{
+								BaseExpr: &BaseExpr{line: 1, char: 17},
+								Table:    Table{Object: Identifier{BaseExpr: &BaseExpr{line: 1, char: 15}, Literal: "t"}},
+								Pivot:    "pivot",
+								Aggregate: AggregateFunction{
+									BaseExpr: &BaseExpr{line: 1, char: 24},
+									Name:     "sum",
+									Args: []QueryExpression{
+										FieldReference{BaseExpr: &BaseExpr{line: 1, char: 28}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 28}, Literal: "x"}},
+									},
+								},
+								For:    "for",
+								Column: FieldReference{BaseExpr: &BaseExpr{line: 1, char: 35}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 35}, Literal: "b"}},
+								In:     "in",
+								Values: []QueryExpression{
+									Field{Object: NewStringValue("p")},
+									Field{Object: NewStringValue("q"), As: "as", Alias: Identifier{BaseExpr: &BaseExpr{line: 1, char: 53}, Literal: "qq"}},
+								},
+							},
+							As:    "as",
+							Alias: Identifier{BaseExpr: &BaseExpr{line: 1, char: 61}, Literal: "pv"},
+						},
+					}},
+				},
+			},
+		},
+	},
+	{
+		Input: "select * from t unpivot (val for col in (x, y)) u",
+		Output: []Statement{
+			SelectQuery{
+				SelectEntity: SelectEntity{
+					SelectClause: SelectClause{
+						BaseExpr: &BaseExpr{line: 1, char: 1},
+						Select:   "select",
+						Fields: []QueryExpression{
+							Field{Object: AllColumns{BaseExpr: &BaseExpr{line: 1, char: 8}}},
+						},
+					},
+					FromClause: FromClause{From: "from", Tables: []QueryExpression{
+						Table{
+							Object: Unpivot{
+								BaseExpr: &BaseExpr{line: 1, char: 17},
+								Table:    Table{Object: Identifier{BaseExpr: &BaseExpr{line: 1, char: 15}, Literal: "t"}},
+								Unpivot:  "unpivot",
+								Value:    Identifier{BaseExpr: &BaseExpr{line: 1, char: 26}, Literal: "val"},
+								For:      "for",
+								Name:     Identifier{BaseExpr: &BaseExpr{line: 1, char: 34}, Literal: "col"},
+								In:       "in",
+								Columns: []QueryExpression{
+									FieldReference{BaseExpr: &BaseExpr{line: 1, char: 42}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 42}, Literal: "x"}},
+									FieldReference{BaseExpr: &BaseExpr{line: 1, char: 45}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 45}, Literal: "y"}},
+								},
+							},
+							Alias: Identifier{BaseExpr: &BaseExpr{line: 1, char: 49}, Literal: "u"},
+						},
+					}},
+				},
+			},
+		},
+	},
 	{
 		Input: "select c1 from csv(',', `table.csv`, 'utf8', null)",
 		Output: []Statement{
@@ -382,6 +518,118 @@ var parseTests = []struct {
 			},
 		},
 	},
+	{
+		Input: "select * from (values (1, 'a')) as t (id, name)",
+		Output: []Statement{
+			SelectQuery{
+				SelectEntity: SelectEntity{
+					SelectClause: SelectClause{BaseExpr: &BaseExpr{line: 1, char: 1}, Select: "select", Fields: []QueryExpression{Field{Object: AllColumns{BaseExpr: &BaseExpr{line: 1, char: 8}}}}},
+					FromClause: FromClause{
+						From: "from",
+						Tables: []QueryExpression{
+							Table{
+								Object: Subquery{
+									BaseExpr: &BaseExpr{line: 1, char: 15},
+									Query: SelectQuery{
+										SelectEntity: ValuesTable{
+											BaseExpr: &BaseExpr{line: 1, char: 16},
+											Values:   "values",
+											ValuesList: []QueryExpression{
+												RowValue{
+													BaseExpr: &BaseExpr{line: 1, char: 23},
+													Value: ValueList{
+														Values: []QueryExpression{
+															NewIntegerValueFromString("1"),
+															NewStringValue("a"),
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+								As:    "as",
+								Alias: Identifier{BaseExpr: &BaseExpr{line: 1, char: 36}, Literal: "t"},
+								Columns: []QueryExpression{
+									Identifier{BaseExpr: &BaseExpr{line: 1, char: 39}, Literal: "id"},
+									Identifier{BaseExpr: &BaseExpr{line: 1, char: 43}, Literal: "name"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		Input: "values (1) order by column1 collate 'de_DE' desc",
+		Output: []Statement{
+			SelectQuery{
+				SelectEntity: ValuesTable{
+					BaseExpr: &BaseExpr{line: 1, char: 1},
+					Values:   "values",
+					ValuesList: []QueryExpression{
+						RowValue{
+							BaseExpr: &BaseExpr{line: 1, char: 8},
+							Value: ValueList{
+								Values: []QueryExpression{
+									NewIntegerValueFromString("1"),
+								},
+							},
+						},
+					},
+				},
+				OrderByClause: OrderByClause{
+					OrderBy: "order by",
+					Items: []QueryExpression{
+						OrderItem{
+							Value:     FieldReference{BaseExpr: &BaseExpr{line: 1, char: 21}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 21}, Literal: "column1"}},
+							Collation: Collation{BaseExpr: &BaseExpr{line: 1, char: 29}, Collate: "collate", Name: "de_DE"},
+							Direction: Token{Token: DESC, Literal: "desc", Line: 1, Char: 45},
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		Input: "values (1), (2) order by column1 desc",
+		Output: []Statement{
+			SelectQuery{
+				SelectEntity: ValuesTable{
+					BaseExpr: &BaseExpr{line: 1, char: 1},
+					Values:   "values",
+					ValuesList: []QueryExpression{
+						RowValue{
+							BaseExpr: &BaseExpr{line: 1, char: 8},
+							Value: ValueList{
+								Values: []QueryExpression{
+									NewIntegerValueFromString("1"),
+								},
+							},
+						},
+						RowValue{
+							BaseExpr: &BaseExpr{line: 1, char: 13},
+							Value: ValueList{
+								Values: []QueryExpression{
+									NewIntegerValueFromString("2"),
+								},
+							},
+						},
+					},
+				},
+				OrderByClause: OrderByClause{
+					OrderBy: "order by",
+					Items: []QueryExpression{
+						OrderItem{
+							Value:     FieldReference{BaseExpr: &BaseExpr{line: 1, char: 26}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 26}, Literal: "column1"}},
+							Direction: Token{Token: DESC, Literal: "desc", Line: 1, Char: 34},
+						},
+					},
+				},
+			},
+		},
+	},
 	{
 		Input: "select 1 from table1 alias, (select 2 from dual) alias2",
 		Output: []Statement{
@@ -521,6 +769,141 @@ var parseTests = []struct {
 			},
 		},
 	},
+	{
+		Input: "select 1 from dual offset 10 rows fetch first 5 rows only",
+		Output: []Statement{
+			SelectQuery{
+				SelectEntity: SelectEntity{
+					SelectClause: SelectClause{BaseExpr: &BaseExpr{line: 1, char: 1}, Select: "select", Fields: []QueryExpression{Field{Object: NewIntegerValueFromString("1")}}},
+					FromClause:   FromClause{From: "from", Tables: []QueryExpression{Table{Object: Dual{Dual: "dual"}}}},
+				},
+				LimitClause: LimitClause{
+					BaseExpr: &BaseExpr{line: 1, char: 35},
+					Limit:    "fetch",
+					Position: Token{Token: FIRST, Literal: "first", Line: 1, Char: 41},
+					Value:    NewIntegerValueFromString("5"),
+					Unit:     Token{Token: ROWS, Literal: "rows", Line: 1, Char: 49},
+					With:     LimitWith{Type: Token{Token: ONLY, Literal: "only", Line: 1, Char: 54}},
+				},
+				OffsetClause: OffsetClause{
+					BaseExpr: &BaseExpr{line: 1, char: 20},
+					Offset:   "offset",
+					Value:    NewIntegerValueFromString("10"),
+					Unit:     Token{Token: ROWS, Literal: "rows", Line: 1, Char: 30},
+				},
+			},
+		},
+	},
+	{
+		Input: "select 1 from dual fetch next 10 percent row with ties",
+		Output: []Statement{
+			SelectQuery{
+				SelectEntity: SelectEntity{
+					SelectClause: SelectClause{BaseExpr: &BaseExpr{line: 1, char: 1}, Select: "select", Fields: []QueryExpression{Field{Object: NewIntegerValueFromString("1")}}},
+					FromClause:   FromClause{From: "from", Tables: []QueryExpression{Table{Object: Dual{Dual: "dual"}}}},
+				},
+				LimitClause: LimitClause{
+					BaseExpr: &BaseExpr{line: 1, char: 20},
+					Limit:    "fetch",
+					Position: Token{Token: NEXT, Literal: "next", Line: 1, Char: 26},
+					Value:    NewIntegerValueFromString("10"),
+					Percent:  "percent",
+					Unit:     Token{Token: ROW, Literal: "row", Line: 1, Char: 42},
+					With:     LimitWith{With: "with", Type: Token{Token: TIES, Literal: "ties", Line: 1, Char: 51}},
+				},
+			},
+		},
+	},
+	{
+		Input: "select 1 from dual fetch first row only",
+		Output: []Statement{
+			SelectQuery{
+				SelectEntity: SelectEntity{
+					SelectClause: SelectClause{BaseExpr: &BaseExpr{line: 1, char: 1}, Select: "select", Fields: []QueryExpression{Field{Object: NewIntegerValueFromString("1")}}},
+					FromClause:   FromClause{From: "from", Tables: []QueryExpression{Table{Object: Dual{Dual: "dual"}}}},
+				},
+				LimitClause: LimitClause{
+					BaseExpr: &BaseExpr{line: 1, char: 20},
+					Limit:    "fetch",
+					Position: Token{Token: FIRST, Literal: "first", Line: 1, Char: 26},
+					Value:    NewIntegerValue(1),
+					Unit:     Token{Token: ROW, Literal: "row", Line: 1, Char: 32},
+					With:     LimitWith{Type: Token{Token: ONLY, Literal: "only", Line: 1, Char: 36}},
+				},
+			},
+		},
+	},
+	{
+		Input: "select 1 from dual group by column1, rollup(column2, column3), cube(column4)",
+		Output: []Statement{
+			SelectQuery{
+				SelectEntity: SelectEntity{
+					SelectClause: SelectClause{BaseExpr: &BaseExpr{line: 1, char: 1}, Select: "select", Fields: []QueryExpression{Field{Object: NewIntegerValueFromString("1")}}},
+					FromClause:   FromClause{From: "from", Tables: []QueryExpression{Table{Object: Dual{Dual: "dual"}}}},
+					GroupByClause: GroupByClause{
+						GroupBy: "group by",
+						Items: []QueryExpression{
+							FieldReference{BaseExpr: &BaseExpr{line: 1, char: 29}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 29}, Literal: "column1"}},
+							Rollup{
+								BaseExpr: &BaseExpr{line: 1, char: 38},
+								Rollup:   "rollup",
+								Items: []QueryExpression{
+									FieldReference{BaseExpr: &BaseExpr{line: 1, char: 45}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 45}, Literal: "column2"}},
+									FieldReference{BaseExpr: &BaseExpr{line: 1, char: 54}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 54}, Literal: "column3"}},
+								},
+							},
+							Cube{
+								BaseExpr: &BaseExpr{line: 1, char: 64},
+								Cube:     "cube",
+								Items: []QueryExpression{
+									FieldReference{BaseExpr: &BaseExpr{line: 1, char: 69}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 69}, Literal: "column4"}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		Input: "select grouping(column1) from dual group by grouping sets ((column1, column2), column1, ())",
+		Output: []Statement{
+			SelectQuery{
+				SelectEntity: SelectEntity{
+					SelectClause: SelectClause{BaseExpr: &BaseExpr{line: 1, char: 1}, Select: "select", Fields: []QueryExpression{
+						Field{Object: Function{
+							BaseExpr: &BaseExpr{line: 1, char: 8},
+							Name:     "grouping",
+							Args: []QueryExpression{
+								FieldReference{BaseExpr: &BaseExpr{line: 1, char: 17}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 17}, Literal: "column1"}},
+							},
+						}},
+					}},
+					FromClause: FromClause{From: "from", Tables: []QueryExpression{Table{Object: Dual{Dual: "dual"}}}},
+					GroupByClause: GroupByClause{
+						GroupBy: "group by",
+						Items: []QueryExpression{
+							GroupingSets{
+								BaseExpr:     &BaseExpr{line: 1, char: 45},
+								GroupingSets: "grouping sets",
+								Items: []QueryExpression{
+									ValueList{
+										BaseExpr: &BaseExpr{line: 1, char: 60},
+										Values: []QueryExpression{
+											FieldReference{BaseExpr: &BaseExpr{line: 1, char: 61}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 61}, Literal: "column1"}},
+											FieldReference{BaseExpr: &BaseExpr{line: 1, char: 70}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 70}, Literal: "column2"}},
+										},
+									},
+									FieldReference{BaseExpr: &BaseExpr{line: 1, char: 80}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 80}, Literal: "column1"}},
+									ValueList{BaseExpr: &BaseExpr{line: 1, char: 89}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
 	{
 		Input: "select 1 \n" +
 			" from dual \n" +
@@ -577,6 +960,50 @@ var parseTests = []struct {
 			},
 		},
 	},
+	{
+		Input: "select /*+ cpu(8) no_cache */ distinct * from dual /*+ cpu(2) */",
+		Output: []Statement{
+			SelectQuery{
+				SelectEntity: SelectEntity{
+					SelectClause: SelectClause{
+						BaseExpr: &BaseExpr{line: 1, char: 1},
+						Select:   "select",
+						Hints:    []Hint{{Name: "CPU", Args: []string{"8"}}, {Name: "NO_CACHE"}},
+						Distinct: Token{Token: DISTINCT, Literal: "distinct", Line: 1, Char: 31},
+						Fields: []QueryExpression{
+							Field{Object: AllColumns{BaseExpr: &BaseExpr{line: 1, char: 40}}},
+						},
+					},
+					FromClause: FromClause{From: "from", Tables: []QueryExpression{Table{Object: Dual{Dual: "dual"}}}},
+				},
+			},
+		},
+	},
+	{
+		Input: "select distinct on (column1) * from dual",
+		Output: []Statement{
+			SelectQuery{
+				SelectEntity: SelectEntity{
+					SelectClause: SelectClause{
+						BaseExpr: &BaseExpr{line: 1, char: 1},
+						Select:   "select",
+						Distinct: Token{Token: DISTINCT, Literal: "distinct", Line: 1, Char: 8},
+						DistinctOn: DistinctOn{
+							BaseExpr: &BaseExpr{line: 1, char: 17},
+							On:       "on",
+							Values: []QueryExpression{
+								FieldReference{BaseExpr: &BaseExpr{line: 1, char: 21}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 21}, Literal: "column1"}},
+							},
+						},
+						Fields: []QueryExpression{
+							Field{Object: AllColumns{BaseExpr: &BaseExpr{line: 1, char: 30}}},
+						},
+					},
+					FromClause: FromClause{From: "from", Tables: []QueryExpression{Table{Object: Dual{Dual: "dual"}}}},
+				},
+			},
+		},
+	},
 	{
 		Input: "with ct as (select 1) select * from ct",
 		Output: []Statement{
@@ -710,6 +1137,111 @@ var parseTests = []struct {
 			},
 		},
 	},
+	{
+		Input: "with ct as materialized (select 1), ct2 as not materialized (select 2) select * from ct",
+		Output: []Statement{
+			SelectQuery{
+				WithClause: WithClause{
+					With: "with",
+					InlineTables: []QueryExpression{
+						InlineTable{
+							Name:            Identifier{BaseExpr: &BaseExpr{line: 1, char: 6}, Literal: "ct"},
+							As:              "as",
+							Materialization: Token{Token: MATERIALIZED, Literal: "materialized", Line: 1, Char: 12},
+							Query: SelectQuery{
+								SelectEntity: SelectEntity{
+									SelectClause: SelectClause{
+										BaseExpr: &BaseExpr{line: 1, char: 26},
+										Select:   "select",
+										Fields: []QueryExpression{
+											Field{Object: NewIntegerValueFromString("1")},
+										},
+									},
+								},
+							},
+						},
+						InlineTable{
+							Name:            Identifier{BaseExpr: &BaseExpr{line: 1, char: 37}, Literal: "ct2"},
+							As:              "as",
+							Materialization: Token{Token: NOT, Literal: "not materialized", Line: 1, Char: 44},
+							Query: SelectQuery{
+								SelectEntity: SelectEntity{
+									SelectClause: SelectClause{
+										BaseExpr: &BaseExpr{line: 1, char: 62},
+										Select:   "select",
+										Fields: []QueryExpression{
+											Field{Object: NewIntegerValueFromString("2")},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				SelectEntity: SelectEntity{
+					SelectClause: SelectClause{
+						BaseExpr: &BaseExpr{line: 1, char: 72},
+						Select:   "select",
+						Fields:   []QueryExpression{Field{Object: AllColumns{BaseExpr: &BaseExpr{line: 1, char: 79}}}},
+					},
+					FromClause: FromClause{
+						From:   "from",
+						Tables: []QueryExpression{Table{Object: Identifier{BaseExpr: &BaseExpr{line: 1, char: 86}, Literal: "ct"}}},
+					},
+				},
+			},
+		},
+	},
+	{
+		Input: "with recursive ct (n) as (select 1) cycle n restrict select * from ct",
+		Output: []Statement{
+			SelectQuery{
+				WithClause: WithClause{
+					With: "with",
+					InlineTables: []QueryExpression{
+						InlineTable{
+							Name:      Identifier{BaseExpr: &BaseExpr{line: 1, char: 16}, Literal: "ct"},
+							Recursive: Token{Token: RECURSIVE, Literal: "recursive", Line: 1, Char: 6},
+							Fields: []QueryExpression{
+								Identifier{BaseExpr: &BaseExpr{line: 1, char: 20}, Literal: "n"},
+							},
+							As: "as",
+							Query: SelectQuery{
+								SelectEntity: SelectEntity{
+									SelectClause: SelectClause{
+										BaseExpr: &BaseExpr{line: 1, char: 27},
+										Select:   "select",
+										Fields: []QueryExpression{
+											Field{Object: NewIntegerValueFromString("1")},
+										},
+									},
+								},
+							},
+							CycleClause: CycleClause{
+								BaseExpr: &BaseExpr{line: 1, char: 37},
+								Cycle:    "cycle",
+								Fields: []QueryExpression{
+									Identifier{BaseExpr: &BaseExpr{line: 1, char: 43}, Literal: "n"},
+								},
+								Restrict: "restrict",
+							},
+						},
+					},
+				},
+				SelectEntity: SelectEntity{
+					SelectClause: SelectClause{
+						BaseExpr: &BaseExpr{line: 1, char: 54},
+						Select:   "select",
+						Fields:   []QueryExpression{Field{Object: AllColumns{BaseExpr: &BaseExpr{line: 1, char: 61}}}},
+					},
+					FromClause: FromClause{
+						From:   "from",
+						Tables: []QueryExpression{Table{Object: Identifier{BaseExpr: &BaseExpr{line: 1, char: 68}, Literal: "ct"}}},
+					},
+				},
+			},
+		},
+	},
 	{
 		Input: "select ident, tbl.3, 'foo', 1, 1.234, true, '2010-01-01 12:00:00', null, ('bar') from dual",
 		Output: []Statement{
@@ -776,6 +1308,27 @@ var parseTests = []struct {
 			},
 		},
 	},
+	{
+		Input: "select column1 = 'a' collate 'de'",
+		Output: []Statement{
+			SelectQuery{
+				SelectEntity: SelectEntity{
+					SelectClause: SelectClause{
+						BaseExpr: &BaseExpr{line: 1, char: 1},
+						Select:   "select",
+						Fields: []QueryExpression{
+							Field{Object: Comparison{
+								LHS:       FieldReference{BaseExpr: &BaseExpr{line: 1, char: 8}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 8}, Literal: "column1"}},
+								Operator:  "=",
+								RHS:       NewStringValue("a"),
+								Collation: Collation{BaseExpr: &BaseExpr{line: 1, char: 22}, Collate: "collate", Name: "de"},
+							}},
+						},
+					},
+				},
+			},
+		},
+	},
 	{
 		Input: "select column1 = 1",
 		Output: []Statement{
@@ -1308,6 +1861,35 @@ var parseTests = []struct {
 			},
 		},
 	},
+	{
+		Input: "select column1 ilike 'pattern1' or column2 not ilike 'pattern2'",
+		Output: []Statement{
+			SelectQuery{
+				SelectEntity: SelectEntity{
+					SelectClause: SelectClause{
+						BaseExpr: &BaseExpr{line: 1, char: 1},
+						Select:   "select",
+						Fields: []QueryExpression{
+							Field{Object: Logic{
+								LHS: Like{
+									Like:    "ilike",
+									LHS:     FieldReference{BaseExpr: &BaseExpr{line: 1, char: 8}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 8}, Literal: "column1"}},
+									Pattern: NewStringValue("pattern1"),
+								},
+								Operator: Token{Token: OR, Literal: "or", Line: 1, Char: 33},
+								RHS: Like{
+									Like:     "ilike",
+									LHS:      FieldReference{BaseExpr: &BaseExpr{line: 1, char: 36}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 36}, Literal: "column2"}},
+									Pattern:  NewStringValue("pattern2"),
+									Negation: Token{Token: NOT, Literal: "not", Line: 1, Char: 44},
+								},
+							}},
+						},
+					},
+				},
+			},
+		},
+	},
 	{
 		Input: "select column1 = any (select 1)",
 		Output: []Statement{
@@ -1531,7 +2113,33 @@ var parseTests = []struct {
 									BaseExpr: &BaseExpr{line: 1, char: 33},
 									Query: SelectQuery{
 										SelectEntity: SelectEntity{
-											SelectClause: SelectClause{BaseExpr: &BaseExpr{line: 1, char: 34}, Select: "select", Fields: []QueryExpression{Field{Object: NewIntegerValueFromString("1")}}},
+											SelectClause: SelectClause{BaseExpr: &BaseExpr{line: 1, char: 34}, Select: "select", Fields: []QueryExpression{Field{Object: NewIntegerValueFromString("1")}}},
+										},
+									},
+								},
+							}},
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		Input: "select exists (select 1)",
+		Output: []Statement{
+			SelectQuery{
+				SelectEntity: SelectEntity{
+					SelectClause: SelectClause{
+						BaseExpr: &BaseExpr{line: 1, char: 1},
+						Select:   "select",
+						Fields: []QueryExpression{
+							Field{Object: Exists{
+								Exists: "exists",
+								Query: Subquery{
+									BaseExpr: &BaseExpr{line: 1, char: 15},
+									Query: SelectQuery{
+										SelectEntity: SelectEntity{
+											SelectClause: SelectClause{BaseExpr: &BaseExpr{line: 1, char: 16}, Select: "select", Fields: []QueryExpression{Field{Object: NewIntegerValueFromString("1")}}},
 										},
 									},
 								},
@@ -1543,7 +2151,7 @@ var parseTests = []struct {
 		},
 	},
 	{
-		Input: "select exists (select 1)",
+		Input: "select tag from t, unnest(split(t.tags, ';')) with ordinality as u(tag, ordinality)",
 		Output: []Statement{
 			SelectQuery{
 				SelectEntity: SelectEntity{
@@ -1551,16 +2159,65 @@ var parseTests = []struct {
 						BaseExpr: &BaseExpr{line: 1, char: 1},
 						Select:   "select",
 						Fields: []QueryExpression{
-							Field{Object: Exists{
-								Exists: "exists",
-								Query: Subquery{
-									BaseExpr: &BaseExpr{line: 1, char: 15},
-									Query: SelectQuery{
-										SelectEntity: SelectEntity{
-											SelectClause: SelectClause{BaseExpr: &BaseExpr{line: 1, char: 16}, Select: "select", Fields: []QueryExpression{Field{Object: NewIntegerValueFromString("1")}}},
-										},
+							Field{
+								Object: FieldReference{BaseExpr: &BaseExpr{line: 1, char: 8}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 8}, Literal: "tag"}},
+							},
+						},
+					},
+					FromClause: FromClause{From: "from", Tables: []QueryExpression{
+						Table{Object: Identifier{BaseExpr: &BaseExpr{line: 1, char: 17}, Literal: "t"}},
+						Table{
+							Object: Unnest{
+								BaseExpr: &BaseExpr{line: 1, char: 20},
+								Unnest:   "unnest",
+								Array: Function{
+									BaseExpr: &BaseExpr{line: 1, char: 27},
+									Name:     "split",
+									Args: []QueryExpression{
+										FieldReference{BaseExpr: &BaseExpr{line: 1, char: 33}, View: Identifier{BaseExpr: &BaseExpr{line: 1, char: 33}, Literal: "t"}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 35}, Literal: "tags"}},
+										NewStringValue(";"),
 									},
 								},
+								With:       "with",
+								Ordinality: "ordinality",
+							},
+							As:    "as",
+							Alias: Identifier{BaseExpr: &BaseExpr{line: 1, char: 66}, Literal: "u"},
+							Columns: []QueryExpression{
+								Identifier{BaseExpr: &BaseExpr{line: 1, char: 68}, Literal: "tag"},
+								Identifier{BaseExpr: &BaseExpr{line: 1, char: 73}, Literal: "ordinality"},
+							},
+						},
+					}},
+				},
+			},
+		},
+	},
+	{
+		Input: "select [1, 'a'], [], col[0] + 1",
+		Output: []Statement{
+			SelectQuery{
+				SelectEntity: SelectEntity{
+					SelectClause: SelectClause{
+						BaseExpr: &BaseExpr{line: 1, char: 1},
+						Select:   "select",
+						Fields: []QueryExpression{
+							Field{Object: ArrayConstructor{
+								BaseExpr: &BaseExpr{line: 1, char: 8},
+								Values: []QueryExpression{
+									NewIntegerValueFromString("1"),
+									NewStringValue("a"),
+								},
+							}},
+							Field{Object: ArrayConstructor{BaseExpr: &BaseExpr{line: 1, char: 18}}},
+							Field{Object: Arithmetic{
+								LHS: ArrayElement{
+									BaseExpr: &BaseExpr{line: 1, char: 25},
+									Array:    FieldReference{BaseExpr: &BaseExpr{line: 1, char: 22}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 22}, Literal: "col"}},
+									Index:    NewIntegerValueFromString("0"),
+								},
+								Operator: int('+'),
+								RHS:      NewIntegerValueFromString("1"),
 							}},
 						},
 					},
@@ -2068,6 +2725,52 @@ var parseTests = []struct {
 			},
 		},
 	},
+	{
+		Input: "select mode(column1)",
+		Output: []Statement{
+			SelectQuery{
+				SelectEntity: SelectEntity{
+					SelectClause: SelectClause{
+						BaseExpr: &BaseExpr{line: 1, char: 1},
+						Select:   "select",
+						Fields: []QueryExpression{
+							Field{Object: AggregateFunction{
+								BaseExpr: &BaseExpr{line: 1, char: 8},
+								Name:     "mode",
+								Args: []QueryExpression{
+									FieldReference{BaseExpr: &BaseExpr{line: 1, char: 13}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 13}, Literal: "column1"}},
+								},
+							}},
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		Input: "select mode(column1) over ()",
+		Output: []Statement{
+			SelectQuery{
+				SelectEntity: SelectEntity{
+					SelectClause: SelectClause{
+						BaseExpr: &BaseExpr{line: 1, char: 1},
+						Select:   "select",
+						Fields: []QueryExpression{
+							Field{Object: AnalyticFunction{
+								BaseExpr: &BaseExpr{line: 1, char: 8},
+								Name:     "mode",
+								Args: []QueryExpression{
+									FieldReference{BaseExpr: &BaseExpr{line: 1, char: 13}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 13}, Literal: "column1"}},
+								},
+								Over:           "over",
+								AnalyticClause: AnalyticClause{},
+							}},
+						},
+					},
+				},
+			},
+		},
+	},
 	{
 		Input: "select count(distinct *)",
 		Output: []Statement{
@@ -2212,6 +2915,131 @@ var parseTests = []struct {
 			},
 		},
 	},
+	{
+		Input: "select array_agg(distinct column1 order by column1 desc)",
+		Output: []Statement{
+			SelectQuery{
+				SelectEntity: SelectEntity{
+					SelectClause: SelectClause{
+						BaseExpr: &BaseExpr{line: 1, char: 1},
+						Select:   "select",
+						Fields: []QueryExpression{
+							Field{Object: ListFunction{
+								BaseExpr: &BaseExpr{line: 1, char: 8},
+								Name:     "array_agg",
+								Distinct: Token{Token: DISTINCT, Literal: "distinct", Line: 1, Char: 18},
+								Args: []QueryExpression{
+									FieldReference{BaseExpr: &BaseExpr{line: 1, char: 27}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 27}, Literal: "column1"}},
+								},
+								OrderBy: OrderByClause{
+									OrderBy: "order by",
+									Items: []QueryExpression{
+										OrderItem{
+											Value:     FieldReference{BaseExpr: &BaseExpr{line: 1, char: 44}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 44}, Literal: "column1"}},
+											Direction: Token{Token: DESC, Literal: "desc", Line: 1, Char: 52},
+										},
+									},
+								},
+							}},
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		Input: "select percentile_cont(0.5) within group (order by column1 desc)",
+		Output: []Statement{
+			SelectQuery{
+				SelectEntity: SelectEntity{
+					SelectClause: SelectClause{
+						BaseExpr: &BaseExpr{line: 1, char: 1},
+						Select:   "select",
+						Fields: []QueryExpression{
+							Field{Object: ListFunction{
+								BaseExpr: &BaseExpr{line: 1, char: 8},
+								Name:     "percentile_cont",
+								Args: []QueryExpression{
+									NewFloatValueFromString("0.5"),
+								},
+								WithinGroup: "within group",
+								OrderBy: OrderByClause{
+									OrderBy: "order by",
+									Items: []QueryExpression{
+										OrderItem{
+											Value:     FieldReference{BaseExpr: &BaseExpr{line: 1, char: 52}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 52}, Literal: "column1"}},
+											Direction: Token{Token: DESC, Literal: "desc", Line: 1, Char: 60},
+										},
+									},
+								},
+							}},
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		Input: "select first(column1 order by column2 desc)",
+		Output: []Statement{
+			SelectQuery{
+				SelectEntity: SelectEntity{
+					SelectClause: SelectClause{
+						BaseExpr: &BaseExpr{line: 1, char: 1},
+						Select:   "select",
+						Fields: []QueryExpression{
+							Field{Object: ListFunction{
+								BaseExpr: &BaseExpr{line: 1, char: 8},
+								Name:     "first",
+								Args: []QueryExpression{
+									FieldReference{BaseExpr: &BaseExpr{line: 1, char: 14}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 14}, Literal: "column1"}},
+								},
+								OrderBy: OrderByClause{
+									OrderBy: "order by",
+									Items: []QueryExpression{
+										OrderItem{
+											Value:     FieldReference{BaseExpr: &BaseExpr{line: 1, char: 31}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 31}, Literal: "column2"}},
+											Direction: Token{Token: DESC, Literal: "desc", Line: 1, Char: 39},
+										},
+									},
+								},
+							}},
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		Input: "select last(column1 order by column2)",
+		Output: []Statement{
+			SelectQuery{
+				SelectEntity: SelectEntity{
+					SelectClause: SelectClause{
+						BaseExpr: &BaseExpr{line: 1, char: 1},
+						Select:   "select",
+						Fields: []QueryExpression{
+							Field{Object: ListFunction{
+								BaseExpr: &BaseExpr{line: 1, char: 8},
+								Name:     "last",
+								Args: []QueryExpression{
+									FieldReference{BaseExpr: &BaseExpr{line: 1, char: 13}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 13}, Literal: "column1"}},
+								},
+								OrderBy: OrderByClause{
+									OrderBy: "order by",
+									Items: []QueryExpression{
+										OrderItem{
+											Value: FieldReference{BaseExpr: &BaseExpr{line: 1, char: 30}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 30}, Literal: "column2"}},
+										},
+									},
+								},
+							}},
+						},
+					},
+				},
+			},
+		},
+	},
 	{
 		Input: "select listagg(column1, ',') within group (order by column1)",
 		Output: []Statement{
@@ -2345,7 +3173,82 @@ var parseTests = []struct {
 		},
 	},
 	{
-		Input: "select userfunc() over (order by column2 rows current row)",
+		Input: "select userfunc() over (order by column2 rows current row)",
+		Output: []Statement{
+			SelectQuery{
+				SelectEntity: SelectEntity{
+					SelectClause: SelectClause{
+						BaseExpr: &BaseExpr{line: 1, char: 1},
+						Select:   "select",
+						Fields: []QueryExpression{
+							Field{Object: AnalyticFunction{
+								BaseExpr: &BaseExpr{line: 1, char: 8},
+								Name:     "userfunc",
+								Over:     "over",
+								AnalyticClause: AnalyticClause{
+									OrderByClause: OrderByClause{
+										OrderBy: "order by",
+										Items: []QueryExpression{
+											OrderItem{
+												Value: FieldReference{BaseExpr: &BaseExpr{line: 1, char: 34}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 34}, Literal: "column2"}},
+											},
+										},
+									},
+									WindowingClause: WindowingClause{
+										Rows: "rows",
+										FrameLow: WindowFramePosition{
+											Direction: CURRENT,
+											Literal:   "current row",
+										},
+									},
+								},
+							}},
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		Input: "select userfunc() over (order by column2 rows unbounded preceding)",
+		Output: []Statement{
+			SelectQuery{
+				SelectEntity: SelectEntity{
+					SelectClause: SelectClause{
+						BaseExpr: &BaseExpr{line: 1, char: 1},
+						Select:   "select",
+						Fields: []QueryExpression{
+							Field{Object: AnalyticFunction{
+								BaseExpr: &BaseExpr{line: 1, char: 8},
+								Name:     "userfunc",
+								Over:     "over",
+								AnalyticClause: AnalyticClause{
+									OrderByClause: OrderByClause{
+										OrderBy: "order by",
+										Items: []QueryExpression{
+											OrderItem{
+												Value: FieldReference{BaseExpr: &BaseExpr{line: 1, char: 34}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 34}, Literal: "column2"}},
+											},
+										},
+									},
+									WindowingClause: WindowingClause{
+										Rows: "rows",
+										FrameLow: WindowFramePosition{
+											Direction: PRECEDING,
+											Unbounded: true,
+											Literal:   "unbounded preceding",
+										},
+									},
+								},
+							}},
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		Input: "select userfunc() over (order by column2 rows 1 preceding)",
 		Output: []Statement{
 			SelectQuery{
 				SelectEntity: SelectEntity{
@@ -2369,8 +3272,9 @@ var parseTests = []struct {
 									WindowingClause: WindowingClause{
 										Rows: "rows",
 										FrameLow: WindowFramePosition{
-											Direction: CURRENT,
-											Literal:   "current row",
+											Direction: PRECEDING,
+											Offset:    1,
+											Literal:   "1 preceding",
 										},
 									},
 								},
@@ -2382,7 +3286,7 @@ var parseTests = []struct {
 		},
 	},
 	{
-		Input: "select userfunc() over (order by column2 rows unbounded preceding)",
+		Input: "select userfunc() over (order by column2 rows between unbounded preceding and 1 following)",
 		Output: []Statement{
 			SelectQuery{
 				SelectEntity: SelectEntity{
@@ -2410,6 +3314,13 @@ var parseTests = []struct {
 											Unbounded: true,
 											Literal:   "unbounded preceding",
 										},
+										FrameHigh: WindowFramePosition{
+											Direction: FOLLOWING,
+											Offset:    1,
+											Literal:   "1 following",
+										},
+										Between: "between",
+										And:     "and",
 									},
 								},
 							}},
@@ -2420,7 +3331,7 @@ var parseTests = []struct {
 		},
 	},
 	{
-		Input: "select userfunc() over (order by column2 rows 1 preceding)",
+		Input: "select userfunc() over (order by column2 rows between 1 preceding and unbounded following)",
 		Output: []Statement{
 			SelectQuery{
 				SelectEntity: SelectEntity{
@@ -2448,6 +3359,13 @@ var parseTests = []struct {
 											Offset:    1,
 											Literal:   "1 preceding",
 										},
+										FrameHigh: WindowFramePosition{
+											Direction: FOLLOWING,
+											Unbounded: true,
+											Literal:   "unbounded following",
+										},
+										Between: "between",
+										And:     "and",
 									},
 								},
 							}},
@@ -2458,7 +3376,7 @@ var parseTests = []struct {
 		},
 	},
 	{
-		Input: "select userfunc() over (order by column2 rows between unbounded preceding and 1 following)",
+		Input: "select userfunc() over (order by column2 groups between 1 preceding and unbounded following exclude current row)",
 		Output: []Statement{
 			SelectQuery{
 				SelectEntity: SelectEntity{
@@ -2480,19 +3398,22 @@ var parseTests = []struct {
 										},
 									},
 									WindowingClause: WindowingClause{
-										Rows: "rows",
+										Rows:   "groups",
+										Groups: true,
 										FrameLow: WindowFramePosition{
 											Direction: PRECEDING,
-											Unbounded: true,
-											Literal:   "unbounded preceding",
+											Offset:    1,
+											Literal:   "1 preceding",
 										},
 										FrameHigh: WindowFramePosition{
 											Direction: FOLLOWING,
-											Offset:    1,
-											Literal:   "1 following",
+											Unbounded: true,
+											Literal:   "unbounded following",
 										},
-										Between: "between",
-										And:     "and",
+										Between:      "between",
+										And:          "and",
+										Exclusion:    CURRENT,
+										ExclusionLit: "exclude current row",
 									},
 								},
 							}},
@@ -2503,7 +3424,7 @@ var parseTests = []struct {
 		},
 	},
 	{
-		Input: "select userfunc() over (order by column2 rows between 1 preceding and unbounded following)",
+		Input: "select userfunc() over (order by column2 rows between 1 preceding and unbounded following exclude no others)",
 		Output: []Statement{
 			SelectQuery{
 				SelectEntity: SelectEntity{
@@ -2536,8 +3457,10 @@ var parseTests = []struct {
 											Unbounded: true,
 											Literal:   "unbounded following",
 										},
-										Between: "between",
-										And:     "and",
+										Between:      "between",
+										And:          "and",
+										Exclusion:    NO,
+										ExclusionLit: "exclude no others",
 									},
 								},
 							}},
@@ -3533,6 +4456,7 @@ var parseTests = []struct {
 		Input: "with ct as (select 1) insert into table1 values (1, 'str1'), (2, 'str2')",
 		Output: []Statement{
 			InsertQuery{
+				BaseExpr: &BaseExpr{line: 1, char: 23},
 				WithClause: WithClause{
 					With: "with",
 					InlineTables: []QueryExpression{
@@ -3581,7 +4505,8 @@ var parseTests = []struct {
 		Input: "insert into table1 (column1, column2, table1.3) values (1, 'str1'), (2, 'str2')",
 		Output: []Statement{
 			InsertQuery{
-				Table: Table{Object: Identifier{BaseExpr: &BaseExpr{line: 1, char: 13}, Literal: "table1"}},
+				BaseExpr: &BaseExpr{line: 1, char: 1},
+				Table:    Table{Object: Identifier{BaseExpr: &BaseExpr{line: 1, char: 13}, Literal: "table1"}},
 				Fields: []QueryExpression{
 					FieldReference{BaseExpr: &BaseExpr{line: 1, char: 21}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 21}, Literal: "column1"}},
 					FieldReference{BaseExpr: &BaseExpr{line: 1, char: 30}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 30}, Literal: "column2"}},
@@ -3614,7 +4539,8 @@ var parseTests = []struct {
 		Input: "insert into table1 select 1, 2",
 		Output: []Statement{
 			InsertQuery{
-				Table: Table{Object: Identifier{BaseExpr: &BaseExpr{line: 1, char: 13}, Literal: "table1"}},
+				BaseExpr: &BaseExpr{line: 1, char: 1},
+				Table:    Table{Object: Identifier{BaseExpr: &BaseExpr{line: 1, char: 13}, Literal: "table1"}},
 				Query: SelectQuery{
 					SelectEntity: SelectEntity{
 						SelectClause: SelectClause{
@@ -3634,7 +4560,8 @@ var parseTests = []struct {
 		Input: "insert into table1 (column1, column2) select 1, 2",
 		Output: []Statement{
 			InsertQuery{
-				Table: Table{Object: Identifier{BaseExpr: &BaseExpr{line: 1, char: 13}, Literal: "table1"}},
+				BaseExpr: &BaseExpr{line: 1, char: 1},
+				Table:    Table{Object: Identifier{BaseExpr: &BaseExpr{line: 1, char: 13}, Literal: "table1"}},
 				Fields: []QueryExpression{
 					FieldReference{BaseExpr: &BaseExpr{line: 1, char: 21}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 21}, Literal: "column1"}},
 					FieldReference{BaseExpr: &BaseExpr{line: 1, char: 30}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 30}, Literal: "column2"}},
@@ -3654,10 +4581,102 @@ var parseTests = []struct {
 			},
 		},
 	},
+	{
+		Input: "with ct as (select 1) replace into table1 (column1, column2) using (column1) values (1, 'str1')",
+		Output: []Statement{
+			ReplaceQuery{
+				BaseExpr: &BaseExpr{line: 1, char: 23},
+				WithClause: WithClause{
+					With: "with",
+					InlineTables: []QueryExpression{
+						InlineTable{
+							Name: Identifier{BaseExpr: &BaseExpr{line: 1, char: 6}, Literal: "ct"},
+							As:   "as",
+							Query: SelectQuery{
+								SelectEntity: SelectEntity{
+									SelectClause: SelectClause{
+										BaseExpr: &BaseExpr{line: 1, char: 13},
+										Select:   "select",
+										Fields: []QueryExpression{
+											Field{Object: NewIntegerValueFromString("1")},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				Table: Table{Object: Identifier{BaseExpr: &BaseExpr{line: 1, char: 36}, Literal: "table1"}},
+				Fields: []QueryExpression{
+					FieldReference{BaseExpr: &BaseExpr{line: 1, char: 44}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 44}, Literal: "column1"}},
+					FieldReference{BaseExpr: &BaseExpr{line: 1, char: 53}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 53}, Literal: "column2"}},
+				},
+				Keys: []QueryExpression{
+					FieldReference{BaseExpr: &BaseExpr{line: 1, char: 69}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 69}, Literal: "column1"}},
+				},
+				ValuesList: []QueryExpression{
+					RowValue{
+						BaseExpr: &BaseExpr{line: 1, char: 85},
+						Value: ValueList{
+							Values: []QueryExpression{
+								NewIntegerValueFromString("1"),
+								NewStringValue("str1"),
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		Input: "replace into table1 using (column1) select 1, 2",
+		Output: []Statement{
+			ReplaceQuery{
+				BaseExpr: &BaseExpr{line: 1, char: 1},
+				Table:    Table{Object: Identifier{BaseExpr: &BaseExpr{line: 1, char: 14}, Literal: "table1"}},
+				Keys: []QueryExpression{
+					FieldReference{BaseExpr: &BaseExpr{line: 1, char: 28}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 28}, Literal: "column1"}},
+				},
+				Query: SelectQuery{
+					SelectEntity: SelectEntity{
+						SelectClause: SelectClause{
+							BaseExpr: &BaseExpr{line: 1, char: 37},
+							Select:   "select",
+							Fields: []QueryExpression{
+								Field{Object: NewIntegerValueFromString("1")},
+								Field{Object: NewIntegerValueFromString("2")},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		Input: "select replace(column1, 'a', 'b')",
+		Output: []Statement{
+			SelectQuery{
+				SelectEntity: SelectEntity{
+					SelectClause: SelectClause{BaseExpr: &BaseExpr{line: 1, char: 1}, Select: "select", Fields: []QueryExpression{
+						Field{Object: Function{
+							BaseExpr: &BaseExpr{line: 1, char: 8},
+							Name:     "replace",
+							Args: []QueryExpression{
+								FieldReference{BaseExpr: &BaseExpr{line: 1, char: 16}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 16}, Literal: "column1"}},
+								NewStringValue("a"),
+								NewStringValue("b"),
+							},
+						}},
+					}},
+				},
+			},
+		},
+	},
 	{
 		Input: "with ct as (select 1) update table1 set column1 = 1, column2 = 2, table1.3 = 3 from table1 where true",
 		Output: []Statement{
 			UpdateQuery{
+				BaseExpr: &BaseExpr{line: 1, char: 23},
 				WithClause: WithClause{
 					With: "with",
 					InlineTables: []QueryExpression{
@@ -3703,6 +4722,7 @@ var parseTests = []struct {
 		Input: "update csv(',', table1) set column1 = 1, column2 = 2, table1.3 = 3 where true",
 		Output: []Statement{
 			UpdateQuery{
+				BaseExpr: &BaseExpr{line: 1, char: 1},
 				Tables: []QueryExpression{
 					Table{Object: TableObject{
 						BaseExpr:      &BaseExpr{line: 1, char: 8},
@@ -3723,6 +4743,183 @@ var parseTests = []struct {
 			},
 		},
 	},
+	{
+		Input: "insert into table1 values (1) returning *",
+		Output: []Statement{
+			InsertQuery{
+				BaseExpr: &BaseExpr{line: 1, char: 1},
+				Table:    Table{Object: Identifier{BaseExpr: &BaseExpr{line: 1, char: 13}, Literal: "table1"}},
+				ValuesList: []QueryExpression{
+					RowValue{
+						BaseExpr: &BaseExpr{line: 1, char: 27},
+						Value: ValueList{
+							Values: []QueryExpression{
+								NewIntegerValueFromString("1"),
+							},
+						},
+					},
+				},
+				ReturningClause: ReturningClause{
+					BaseExpr:  &BaseExpr{line: 1, char: 31},
+					Returning: "returning",
+					Fields: []QueryExpression{
+						Field{Object: AllColumns{BaseExpr: &BaseExpr{line: 1, char: 41}}},
+					},
+				},
+			},
+		},
+	},
+	{
+		Input: "update table1 set column1 = 1 returning column1 as c1",
+		Output: []Statement{
+			UpdateQuery{
+				BaseExpr: &BaseExpr{line: 1, char: 1},
+				Tables: []QueryExpression{
+					Table{Object: Identifier{BaseExpr: &BaseExpr{line: 1, char: 8}, Literal: "table1"}},
+				},
+				SetList: []UpdateSet{
+					{Field: FieldReference{BaseExpr: &BaseExpr{line: 1, char: 19}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 19}, Literal: "column1"}}, Value: NewIntegerValueFromString("1")},
+				},
+				ReturningClause: ReturningClause{
+					BaseExpr:  &BaseExpr{line: 1, char: 31},
+					Returning: "returning",
+					Fields: []QueryExpression{
+						Field{Object: FieldReference{BaseExpr: &BaseExpr{line: 1, char: 41}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 41}, Literal: "column1"}}, As: "as", Alias: Identifier{BaseExpr: &BaseExpr{line: 1, char: 52}, Literal: "c1"}},
+					},
+				},
+			},
+		},
+	},
+	{
+		Input: "delete from table1 where true returning column1",
+		Output: []Statement{
+			DeleteQuery{
+				BaseExpr: &BaseExpr{line: 1, char: 1},
+				FromClause: FromClause{
+					From: "from",
+					Tables: []QueryExpression{
+						Table{Object: Identifier{BaseExpr: &BaseExpr{line: 1, char: 13}, Literal: "table1"}},
+					},
+				},
+				WhereClause: WhereClause{
+					Where:  "where",
+					Filter: NewTernaryValueFromString("true"),
+				},
+				ReturningClause: ReturningClause{
+					BaseExpr:  &BaseExpr{line: 1, char: 31},
+					Returning: "returning",
+					Fields: []QueryExpression{
+						Field{Object: FieldReference{BaseExpr: &BaseExpr{line: 1, char: 41}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 41}, Literal: "column1"}}},
+					},
+				},
+			},
+		},
+	},
+	{
+		Input: "insert /*+ wait_timeout(5) */ into table1 values (1)",
+		Output: []Statement{
+			InsertQuery{
+				BaseExpr: &BaseExpr{line: 1, char: 1},
+				Hints:    []Hint{{Name: "WAIT_TIMEOUT", Args: []string{"5"}}},
+				Table:    Table{Object: Identifier{BaseExpr: &BaseExpr{line: 1, char: 36}, Literal: "table1"}},
+				ValuesList: []QueryExpression{
+					RowValue{
+						BaseExpr: &BaseExpr{line: 1, char: 50},
+						Value: ValueList{
+							Values: []QueryExpression{
+								NewIntegerValueFromString("1"),
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		Input: "update /*+ wait_timeout(5) */ table1 set column1 = 1",
+		Output: []Statement{
+			UpdateQuery{
+				BaseExpr: &BaseExpr{line: 1, char: 1},
+				Hints:    []Hint{{Name: "WAIT_TIMEOUT", Args: []string{"5"}}},
+				Tables: []QueryExpression{
+					Table{Object: Identifier{BaseExpr: &BaseExpr{line: 1, char: 31}, Literal: "table1"}},
+				},
+				SetList: []UpdateSet{
+					{Field: FieldReference{BaseExpr: &BaseExpr{line: 1, char: 42}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 42}, Literal: "column1"}}, Value: NewIntegerValueFromString("1")},
+				},
+			},
+		},
+	},
+	{
+		Input: "delete /*+ wait_timeout(5) */ from table1",
+		Output: []Statement{
+			DeleteQuery{
+				BaseExpr: &BaseExpr{line: 1, char: 1},
+				Hints:    []Hint{{Name: "WAIT_TIMEOUT", Args: []string{"5"}}},
+				FromClause: FromClause{
+					From: "from",
+					Tables: []QueryExpression{
+						Table{Object: Identifier{BaseExpr: &BaseExpr{line: 1, char: 36}, Literal: "table1"}},
+					},
+				},
+			},
+		},
+	},
+	{
+		Input: "merge into table1 t using table2 s on t.column1 = s.column3 when matched and s.column4 = 'x' then delete when matched then update set column2 = s.column4 when not matched then insert (column1) values (s.column3)",
+		Output: []Statement{
+			MergeQuery{
+				BaseExpr: &BaseExpr{line: 1, char: 1},
+				Table: Table{
+					Object: Identifier{BaseExpr: &BaseExpr{line: 1, char: 12}, Literal: "table1"},
+					Alias:  Identifier{BaseExpr: &BaseExpr{line: 1, char: 19}, Literal: "t"},
+				},
+				Source: Table{
+					Object: Identifier{BaseExpr: &BaseExpr{line: 1, char: 27}, Literal: "table2"},
+					Alias:  Identifier{BaseExpr: &BaseExpr{line: 1, char: 34}, Literal: "s"},
+				},
+				Condition: Comparison{
+					LHS:      FieldReference{BaseExpr: &BaseExpr{line: 1, char: 39}, View: Identifier{BaseExpr: &BaseExpr{line: 1, char: 39}, Literal: "t"}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 41}, Literal: "column1"}},
+					Operator: "=",
+					RHS:      FieldReference{BaseExpr: &BaseExpr{line: 1, char: 51}, View: Identifier{BaseExpr: &BaseExpr{line: 1, char: 51}, Literal: "s"}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 53}, Literal: "column3"}},
+				},
+				WhenList: []MergeWhen{
+					{
+						BaseExpr: &BaseExpr{line: 1, char: 61},
+						Condition: Comparison{
+							LHS:      FieldReference{BaseExpr: &BaseExpr{line: 1, char: 78}, View: Identifier{BaseExpr: &BaseExpr{line: 1, char: 78}, Literal: "s"}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 80}, Literal: "column4"}},
+							Operator: "=",
+							RHS:      NewStringValue("x"),
+						},
+						Operation: Token{Token: DELETE, Literal: "delete", Line: 1, Char: 99},
+					},
+					{
+						BaseExpr:  &BaseExpr{line: 1, char: 106},
+						Operation: Token{Token: UPDATE, Literal: "update", Line: 1, Char: 124},
+						SetList: []UpdateSet{
+							{Field: FieldReference{BaseExpr: &BaseExpr{line: 1, char: 135}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 135}, Literal: "column2"}}, Value: FieldReference{BaseExpr: &BaseExpr{line: 1, char: 145}, View: Identifier{BaseExpr: &BaseExpr{line: 1, char: 145}, Literal: "s"}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 147}, Literal: "column4"}}},
+						},
+					},
+					{
+						BaseExpr:   &BaseExpr{line: 1, char: 155},
+						NotMatched: true,
+						Operation:  Token{Token: INSERT, Literal: "insert", Line: 1, Char: 177},
+						Fields: []QueryExpression{
+							FieldReference{BaseExpr: &BaseExpr{line: 1, char: 185}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 185}, Literal: "column1"}},
+						},
+						Values: RowValue{
+							BaseExpr: &BaseExpr{line: 1, char: 201},
+							Value: ValueList{
+								Values: []QueryExpression{
+									FieldReference{BaseExpr: &BaseExpr{line: 1, char: 202}, View: Identifier{BaseExpr: &BaseExpr{line: 1, char: 202}, Literal: "s"}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 204}, Literal: "column3"}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
 	{
 		Input: "with ct as (select 1) delete from table1",
 		Output: []Statement{
@@ -3778,6 +4975,30 @@ var parseTests = []struct {
 			},
 		},
 	},
+	{
+		Input: "delete from table1 using table2 t2 where true",
+		Output: []Statement{
+			DeleteQuery{
+				BaseExpr: &BaseExpr{line: 1, char: 1},
+				FromClause: FromClause{
+					From: "from",
+					Tables: []QueryExpression{
+						Table{Object: Identifier{BaseExpr: &BaseExpr{line: 1, char: 13}, Literal: "table1"}},
+					},
+				},
+				Using: []QueryExpression{
+					Table{
+						Object: Identifier{BaseExpr: &BaseExpr{line: 1, char: 26}, Literal: "table2"},
+						Alias:  Identifier{BaseExpr: &BaseExpr{line: 1, char: 33}, Literal: "t2"},
+					},
+				},
+				WhereClause: WhereClause{
+					Where:  "where",
+					Filter: NewTernaryValueFromString("true"),
+				},
+			},
+		},
+	},
 	{
 		Input: "create table newtable (column1, column2)",
 		Output: []Statement{
@@ -3790,6 +5011,40 @@ var parseTests = []struct {
 			},
 		},
 	},
+	{
+		Input: "create table newtable (column1 not null unique, column2 check (column2 > 0))",
+		Output: []Statement{
+			CreateTable{
+				Table: Identifier{BaseExpr: &BaseExpr{line: 1, char: 14}, Literal: "newtable"},
+				Fields: []QueryExpression{
+					Identifier{BaseExpr: &BaseExpr{line: 1, char: 24}, Literal: "column1"},
+					Identifier{BaseExpr: &BaseExpr{line: 1, char: 49}, Literal: "column2"},
+				},
+				Constraints: []ColumnConstraint{
+					{
+						BaseExpr: &BaseExpr{line: 1, char: 32},
+						Column:   Identifier{BaseExpr: &BaseExpr{line: 1, char: 24}, Literal: "column1"},
+						Type:     Token{Token: NOT, Literal: "not", Line: 1, Char: 32},
+					},
+					{
+						BaseExpr: &BaseExpr{line: 1, char: 41},
+						Column:   Identifier{BaseExpr: &BaseExpr{line: 1, char: 24}, Literal: "column1"},
+						Type:     Token{Token: UNIQUE, Literal: "unique", Line: 1, Char: 41},
+					},
+					{
+						BaseExpr: &BaseExpr{line: 1, char: 57},
+						Column:   Identifier{BaseExpr: &BaseExpr{line: 1, char: 49}, Literal: "column2"},
+						Type:     Token{Token: CHECK, Literal: "check", Line: 1, Char: 57},
+						Condition: Comparison{
+							LHS:      FieldReference{BaseExpr: &BaseExpr{line: 1, char: 64}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 64}, Literal: "column2"}},
+							Operator: ">",
+							RHS:      NewIntegerValueFromString("0"),
+						},
+					},
+				},
+			},
+		},
+	},
 	{
 		Input: "create table newtable (column1, column2) select 1, 2",
 		Output: []Statement{
@@ -3894,6 +5149,31 @@ var parseTests = []struct {
 			},
 		},
 	},
+	{
+		Input: "create view view1 (column1) as select 1",
+		Output: []Statement{
+			CreateView{
+				BaseExpr: &BaseExpr{line: 1, char: 1},
+				View:     Identifier{BaseExpr: &BaseExpr{line: 1, char: 13}, Literal: "view1"},
+				Fields: []QueryExpression{
+					Identifier{BaseExpr: &BaseExpr{line: 1, char: 20}, Literal: "column1"},
+				},
+				Query: SelectQuery{
+					SelectEntity: SelectEntity{
+						SelectClause: SelectClause{
+							BaseExpr: &BaseExpr{line: 1, char: 32},
+							Select:   "select",
+							Fields: []QueryExpression{
+								Field{
+									Object: NewIntegerValueFromString("1"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
 	{
 		Input: "alter table table1 add column1",
 		Output: []Statement{
@@ -4077,6 +5357,57 @@ var parseTests = []struct {
 			},
 		},
 	},
+	{
+		Input: "commit table table1",
+		Output: []Statement{
+			TransactionControl{
+				BaseExpr: &BaseExpr{line: 1, char: 1},
+				Token:    COMMIT,
+				Table:    Identifier{BaseExpr: &BaseExpr{line: 1, char: 14}, Literal: "table1"},
+			},
+		},
+	},
+	{
+		Input: "rollback table table1",
+		Output: []Statement{
+			TransactionControl{
+				BaseExpr: &BaseExpr{line: 1, char: 1},
+				Token:    ROLLBACK,
+				Table:    Identifier{BaseExpr: &BaseExpr{line: 1, char: 16}, Literal: "table1"},
+			},
+		},
+	},
+	{
+		Input: "lock table table1, table2 in exclusive mode",
+		Output: []Statement{
+			LockTable{
+				BaseExpr: &BaseExpr{line: 1, char: 1},
+				Tables: []QueryExpression{
+					Identifier{BaseExpr: &BaseExpr{line: 1, char: 12}, Literal: "table1"},
+					Identifier{BaseExpr: &BaseExpr{line: 1, char: 20}, Literal: "table2"},
+				},
+			},
+		},
+	},
+	{
+		Input: "unlock",
+		Output: []Statement{
+			UnlockTable{
+				BaseExpr: &BaseExpr{line: 1, char: 1},
+			},
+		},
+	},
+	{
+		Input: "unlock table table1",
+		Output: []Statement{
+			UnlockTable{
+				BaseExpr: &BaseExpr{line: 1, char: 1},
+				Tables: []QueryExpression{
+					Identifier{BaseExpr: &BaseExpr{line: 1, char: 14}, Literal: "table1"},
+				},
+			},
+		},
+	},
 	{
 		Input: "echo 'foo'",
 		Output: []Statement{
@@ -4219,6 +5550,55 @@ var parseTests = []struct {
 			},
 		},
 	},
+	{
+		Input: "explain select c1 from stdin",
+		Output: []Statement{
+			Explain{
+				BaseExpr: &BaseExpr{line: 1, char: 1},
+				Query: SelectQuery{
+					SelectEntity: SelectEntity{
+						SelectClause: SelectClause{
+							BaseExpr: &BaseExpr{line: 1, char: 9},
+							Select:   "select",
+							Fields: []QueryExpression{
+								Field{
+									Object: FieldReference{BaseExpr: &BaseExpr{line: 1, char: 16}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 16}, Literal: "c1"}},
+								},
+							},
+						},
+						FromClause: FromClause{From: "from", Tables: []QueryExpression{
+							Table{Object: Stdin{BaseExpr: &BaseExpr{line: 1, char: 24}, Stdin: "stdin"}},
+						}},
+					},
+				},
+			},
+		},
+	},
+	{
+		Input: "explain analyze select c1 from stdin",
+		Output: []Statement{
+			Explain{
+				BaseExpr: &BaseExpr{line: 1, char: 1},
+				Option:   Identifier{BaseExpr: &BaseExpr{line: 1, char: 9}, Literal: "analyze"},
+				Query: SelectQuery{
+					SelectEntity: SelectEntity{
+						SelectClause: SelectClause{
+							BaseExpr: &BaseExpr{line: 1, char: 17},
+							Select:   "select",
+							Fields: []QueryExpression{
+								Field{
+									Object: FieldReference{BaseExpr: &BaseExpr{line: 1, char: 24}, Column: Identifier{BaseExpr: &BaseExpr{line: 1, char: 24}, Literal: "c1"}},
+								},
+							},
+						},
+						FromClause: FromClause{From: "from", Tables: []QueryExpression{
+							Table{Object: Stdin{BaseExpr: &BaseExpr{line: 1, char: 32}, Stdin: "stdin"}},
+						}},
+					},
+				},
+			},
+		},
+	},
 	{
 		Input: "set @@delimiter = ','",
 		Output: []Statement{
@@ -4259,6 +5639,28 @@ var parseTests = []struct {
 			},
 		},
 	},
+	{
+		Input: "set local @@delimiter = ','",
+		Output: []Statement{
+			SetFlag{
+				BaseExpr: &BaseExpr{line: 1, char: 1},
+				Local:    Token{Token: LOCAL, Literal: "local", Line: 1, Char: 5},
+				Name:     "delimiter",
+				Value:    NewStringValue(","),
+			},
+		},
+	},
+	{
+		Input: "set local @@encoding to sjis",
+		Output: []Statement{
+			SetFlag{
+				BaseExpr: &BaseExpr{line: 1, char: 1},
+				Local:    Token{Token: LOCAL, Literal: "local", Line: 1, Char: 5},
+				Name:     "encoding",
+				Value:    Identifier{BaseExpr: &BaseExpr{line: 1, char: 25}, Literal: "sjis"},
+			},
+		},
+	},
 	{
 		Input: "add '%Y%m%d' to @@datetime_format",
 		Output: []Statement{
@@ -4535,6 +5937,74 @@ var parseTests = []struct {
 			},
 		},
 	},
+	{
+		Input: "create temporary table tbl (column1)",
+		Output: []Statement{
+			ViewDeclaration{
+				View: Identifier{BaseExpr: &BaseExpr{line: 1, char: 24}, Literal: "tbl"},
+				Fields: []QueryExpression{
+					Identifier{BaseExpr: &BaseExpr{line: 1, char: 29}, Literal: "column1"},
+				},
+			},
+		},
+	},
+	{
+		Input: "create temporary table tbl (key, column2) primary key (key) as select 1, 2",
+		Output: []Statement{
+			ViewDeclaration{
+				View: Identifier{BaseExpr: &BaseExpr{line: 1, char: 24}, Literal: "tbl"},
+				Fields: []QueryExpression{
+					Identifier{BaseExpr: &BaseExpr{line: 1, char: 29}, Literal: "key"},
+					Identifier{BaseExpr: &BaseExpr{line: 1, char: 34}, Literal: "column2"},
+				},
+				PrimaryKey: []QueryExpression{
+					Identifier{BaseExpr: &BaseExpr{line: 1, char: 56}, Literal: "key"},
+				},
+				Query: SelectQuery{
+					SelectEntity: SelectEntity{
+						SelectClause: SelectClause{
+							BaseExpr: &BaseExpr{line: 1, char: 64},
+							Select:   "select",
+							Fields: []QueryExpression{
+								Field{
+									Object: NewIntegerValueFromString("1"),
+								},
+								Field{
+									Object: NewIntegerValueFromString("2"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		Input: "create temporary table tbl primary key (column1) as select 1 as column1",
+		Output: []Statement{
+			ViewDeclaration{
+				View: Identifier{BaseExpr: &BaseExpr{line: 1, char: 24}, Literal: "tbl"},
+				PrimaryKey: []QueryExpression{
+					Identifier{BaseExpr: &BaseExpr{line: 1, char: 41}, Literal: "column1"},
+				},
+				Query: SelectQuery{
+					SelectEntity: SelectEntity{
+						SelectClause: SelectClause{
+							BaseExpr: &BaseExpr{line: 1, char: 53},
+							Select:   "select",
+							Fields: []QueryExpression{
+								Field{
+									Object: NewIntegerValueFromString("1"),
+									As:     "as",
+									Alias:  Identifier{BaseExpr: &BaseExpr{line: 1, char: 65}, Literal: "column1"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
 	{
 		Input: "declare tbl view as select 1, 2",
 		Output: []Statement{
@@ -4567,6 +6037,53 @@ var parseTests = []struct {
 			},
 		},
 	},
+	{
+		Input: "declare index idx on tbl (column1, column2)",
+		Output: []Statement{
+			IndexDeclaration{
+				Index: Identifier{BaseExpr: &BaseExpr{line: 1, char: 15}, Literal: "idx"},
+				Table: Identifier{BaseExpr: &BaseExpr{line: 1, char: 22}, Literal: "tbl"},
+				Columns: []QueryExpression{
+					Identifier{BaseExpr: &BaseExpr{line: 1, char: 27}, Literal: "column1"},
+					Identifier{BaseExpr: &BaseExpr{line: 1, char: 36}, Literal: "column2"},
+				},
+			},
+		},
+	},
+	{
+		Input: "dispose index idx",
+		Output: []Statement{
+			DisposeIndex{
+				Index: Identifier{BaseExpr: &BaseExpr{line: 1, char: 15}, Literal: "idx"},
+			},
+		},
+	},
+	{
+		Input: "declare sequence seq start 10 increment 2",
+		Output: []Statement{
+			SequenceDeclaration{
+				Sequence:  Identifier{BaseExpr: &BaseExpr{line: 1, char: 18}, Literal: "seq"},
+				Start:     NewIntegerValueFromString("10"),
+				Increment: NewIntegerValueFromString("2"),
+			},
+		},
+	},
+	{
+		Input: "declare sequence seq",
+		Output: []Statement{
+			SequenceDeclaration{
+				Sequence: Identifier{BaseExpr: &BaseExpr{line: 1, char: 18}, Literal: "seq"},
+			},
+		},
+	},
+	{
+		Input: "dispose sequence seq",
+		Output: []Statement{
+			DisposeSequence{
+				Sequence: Identifier{BaseExpr: &BaseExpr{line: 1, char: 18}, Literal: "seq"},
+			},
+		},
+	},
 	{
 		Input: "prepare stmt from 'select :val'",
 		Output: []Statement{
@@ -4576,6 +6093,19 @@ var parseTests = []struct {
 			},
 		},
 	},
+	{
+		Input: "prepare stmt (integer, ids array) from 'select :ids'",
+		Output: []Statement{
+			StatementPreparation{
+				Name: Identifier{BaseExpr: &BaseExpr{line: 1, char: 9}, Literal: "stmt"},
+				Parameters: []StatementParameter{
+					{BaseExpr: &BaseExpr{line: 1, char: 15}, Type: Identifier{BaseExpr: &BaseExpr{line: 1, char: 15}, Literal: "integer"}},
+					{BaseExpr: &BaseExpr{line: 1, char: 24}, Name: Identifier{BaseExpr: &BaseExpr{line: 1, char: 24}, Literal: "ids"}, Type: Identifier{BaseExpr: &BaseExpr{line: 1, char: 28}, Literal: "array"}},
+				},
+				Statement: value.NewString("select :ids"),
+			},
+		},
+	},
 	{
 		Input: "execute stmt",
 		Output: []Statement{
@@ -4971,6 +6501,55 @@ var parseTests = []struct {
 			},
 		},
 	},
+	{
+		Input: "declare func1 function () deterministic as begin end",
+		Output: []Statement{
+			FunctionDeclaration{
+				Name:          Identifier{BaseExpr: &BaseExpr{line: 1, char: 9}, Literal: "func1"},
+				Deterministic: Token{Token: DETERMINISTIC, Literal: "deterministic", Line: 1, Char: 27},
+			},
+		},
+	},
+	{
+		Input: "declare func1 function (@arg1) deterministic as begin return @arg1; end",
+		Output: []Statement{
+			FunctionDeclaration{
+				Name: Identifier{BaseExpr: &BaseExpr{line: 1, char: 9}, Literal: "func1"},
+				Parameters: []VariableAssignment{
+					{Variable: Variable{BaseExpr: &BaseExpr{line: 1, char: 25}, Name: "arg1"}},
+				},
+				Deterministic: Token{Token: DETERMINISTIC, Literal: "deterministic", Line: 1, Char: 32},
+				Statements: []Statement{
+					Return{Value: Variable{BaseExpr: &BaseExpr{line: 1, char: 62}, Name: "arg1"}},
+				},
+			},
+		},
+	},
+	{
+		Input: "declare func1 function () language lua as 'return 1'",
+		Output: []Statement{
+			FunctionDeclaration{
+				Name:     Identifier{BaseExpr: &BaseExpr{line: 1, char: 9}, Literal: "func1"},
+				Language: Identifier{BaseExpr: &BaseExpr{line: 1, char: 36}, Literal: "lua"},
+				Source:   value.NewString("return 1"),
+			},
+		},
+	},
+	{
+		Input: "declare func1 function (@arg1, @arg2 default 0) deterministic language lua as 'return arg1 + arg2'",
+		Output: []Statement{
+			FunctionDeclaration{
+				Name: Identifier{BaseExpr: &BaseExpr{line: 1, char: 9}, Literal: "func1"},
+				Parameters: []VariableAssignment{
+					{Variable: Variable{BaseExpr: &BaseExpr{line: 1, char: 25}, Name: "arg1"}},
+					{Variable: Variable{BaseExpr: &BaseExpr{line: 1, char: 32}, Name: "arg2"}, Value: NewIntegerValueFromString("0")},
+				},
+				Deterministic: Token{Token: DETERMINISTIC, Literal: "deterministic", Line: 1, Char: 49},
+				Language:      Identifier{BaseExpr: &BaseExpr{line: 1, char: 72}, Literal: "lua"},
+				Source:        value.NewString("return arg1 + arg2"),
+			},
+		},
+	},
 	{
 		Input: "declare func1 function (@arg1, @arg2 default 0) as begin \n" +
 			"if @var1 = 1 then print 1; end if; \n" +
@@ -5602,6 +7181,12 @@ var parseTests = []struct {
 		ErrorLine: 1,
 		ErrorChar: 14,
 	},
+	{
+		Input:     "lock table table1 in exclusive max",
+		Error:     "syntax error: unexpected token \"max\"",
+		ErrorLine: 1,
+		ErrorChar: 32,
+	},
 	{
 		Input:     "select 'literal not terminated",
 		Error:     "literal not terminated",
@@ -30,6 +30,7 @@ const (
 	EnvironmentVariableSign = '%'
 	ExternalCommandSign     = '$'
 	RuntimeInformationSign  = '#'
+	HintSign                = '+'
 
 	SubstitutionOperator = ":="
 
@@ -57,11 +58,33 @@ var aggregateFunctions = []string{
 	"SUM",
 	"AVG",
 	"MEDIAN",
+	"STDDEV_POP",
+	"STDDEV_SAMP",
+	"VAR_POP",
+	"VAR_SAMP",
+	"CORR",
+	"COVAR_POP",
+	"COVAR_SAMP",
+	"REGR_SLOPE",
+	"REGR_INTERCEPT",
+	"BIT_AND",
+	"BIT_OR",
+	"BIT_XOR",
+	"BOOL_AND",
+	"BOOL_OR",
+	"EVERY",
+	"CHECKSUM_AGG",
+	"HASH_AGG",
 }
 
 var listFunctions = []string{
 	"LISTAGG",
 	"JSON_AGG",
+	"ARRAY_AGG",
+	"PERCENTILE_CONT",
+	"PERCENTILE_DISC",
+	"NPV",
+	"IRR",
 }
 
 var analyticFunctions = []string{
@@ -105,6 +128,8 @@ type Scanner struct {
 	forPrepared     bool
 
 	holderOrdinal int
+
+	prevToken int
 }
 
 func (s *Scanner) Init(src string, sourceFile string, datetimeFormats []string, forPrepared bool) *Scanner {
@@ -118,6 +143,7 @@ func (s *Scanner) Init(src string, sourceFile string, datetimeFormats []string,
 	s.datetimeFormats = datetimeFormats
 	s.forPrepared = forPrepared
 	s.holderOrdinal = 0
+	s.prevToken = 0
 	return s
 }
 
@@ -166,6 +192,12 @@ func (s *Scanner) checkNewLine(ch rune) rune {
 }
 
 func (s *Scanner) Scan() (Token, error) {
+	tok, err := s.scan()
+	s.prevToken = tok.Token
+	return tok, err
+}
+
+func (s *Scanner) scan() (Token, error) {
 	for unicode.IsSpace(s.peek()) {
 		s.next()
 	}
@@ -265,11 +297,16 @@ func (s *Scanner) Scan() (Token, error) {
 		literal = s.literal.String()
 		token = EXTERNAL_COMMAND
 	case s.isCommentRune(ch):
+		if s.isHintPosition() && s.peek() == HintSign {
+			s.next()
+			s.scanHint()
+			return Token{Token: HINT, Literal: s.literal.String(), Line: line, Char: char, SourceFile: s.sourceFile}, s.err
+		}
 		s.scanComment()
-		return s.Scan()
+		return s.scan()
 	case s.isLineCommentRune(ch):
 		s.scanLineComment()
-		return s.Scan()
+		return s.scan()
 	default:
 		switch ch {
 		case EOF:
@@ -469,6 +506,33 @@ func (s *Scanner) scanComment() {
 	}
 }
 
+// isHintPosition reports whether a hint comment can be written at the current position,
+// that is immediately after a keyword beginning a select clause or a statement changing tables.
+func (s *Scanner) isHintPosition() bool {
+	switch s.prevToken {
+	case SELECT, INSERT, REPLACE, UPDATE, DELETE:
+		return true
+	}
+	return false
+}
+
+func (s *Scanner) scanHint() {
+	s.literal.Reset()
+
+	for {
+		ch := s.next()
+		if ch == EOF {
+			break
+		} else if ch == '*' {
+			if s.peek() == '/' {
+				s.next()
+				break
+			}
+		}
+		s.literal.WriteRune(ch)
+	}
+}
+
 func (s *Scanner) isLineCommentRune(ch rune) bool {
 	if ch == '-' && s.peek() == '-' {
 		s.next()
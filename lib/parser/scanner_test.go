@@ -352,6 +352,24 @@ var scanTests = []struct {
 			},
 		},
 	},
+	{
+		Name:  "Hint",
+		Input: "select /*+ cpu(8) */ 1 /*+ cpu(2) */",
+		Output: []scanResult{
+			{
+				Token:   SELECT,
+				Literal: "select",
+			},
+			{
+				Token:   HINT,
+				Literal: " cpu(8) ",
+			},
+			{
+				Token:   INTEGER,
+				Literal: "1",
+			},
+		},
+	},
 	{
 		Name:  "External Command",
 		Input: "$abc",
@@ -1,8 +1,12 @@
 package query
 
 import (
+	"bytes"
+	"hash/fnv"
+	"math"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/mithrandie/csvq/lib/cmd"
 
@@ -16,12 +20,35 @@ import (
 type AggregateFunction func([]value.Primary, *cmd.Flags) value.Primary
 
 var AggregateFunctions = map[string]AggregateFunction{
-	"COUNT":  Count,
-	"MAX":    Max,
-	"MIN":    Min,
-	"SUM":    Sum,
-	"AVG":    Avg,
-	"MEDIAN": Median,
+	"COUNT":        Count,
+	"MAX":          Max,
+	"MIN":          Min,
+	"SUM":          Sum,
+	"AVG":          Avg,
+	"MEDIAN":       Median,
+	"MODE":         Mode,
+	"STDDEV_POP":   StdDevPop,
+	"STDDEV_SAMP":  StdDevSamp,
+	"VAR_POP":      VarPop,
+	"VAR_SAMP":     VarSamp,
+	"BIT_AND":      BitAnd,
+	"BIT_OR":       BitOr,
+	"BIT_XOR":      BitXor,
+	"BOOL_AND":     BoolAnd,
+	"BOOL_OR":      BoolOr,
+	"EVERY":        BoolAnd,
+	"CHECKSUM_AGG": ChecksumAgg,
+	"HASH_AGG":     ChecksumAgg,
+}
+
+type BivariateAggregateFunction func([]value.Primary, []value.Primary, *cmd.Flags) value.Primary
+
+var BivariateAggregateFunctions = map[string]BivariateAggregateFunction{
+	"CORR":           Corr,
+	"COVAR_POP":      CovarPop,
+	"COVAR_SAMP":     CovarSamp,
+	"REGR_SLOPE":     RegrSlope,
+	"REGR_INTERCEPT": RegrIntercept,
 }
 
 func Count(list []value.Primary, _ *cmd.Flags) value.Primary {
@@ -152,6 +179,314 @@ func Median(list []value.Primary, flags *cmd.Flags) value.Primary {
 	return value.ParseFloat64(median)
 }
 
+// Mode returns the most frequent value. If some values appear the same number of times,
+// the least one in the sort order is returned so that the result does not depend on the order of records.
+func Mode(list []value.Primary, flags *cmd.Flags) value.Primary {
+	counts := make(map[string]int)
+	values := make(map[string]value.Primary)
+	keys := make([]string, 0, len(list))
+
+	keyBuf := new(bytes.Buffer)
+
+	for _, v := range list {
+		if value.IsNull(v) {
+			continue
+		}
+
+		keyBuf.Reset()
+		SerializeComparisonKeys(keyBuf, []value.Primary{v}, flags)
+		key := keyBuf.String()
+		if _, ok := counts[key]; !ok {
+			values[key] = v
+			keys = append(keys, key)
+		}
+		counts[key]++
+	}
+
+	if len(keys) < 1 {
+		return value.NewNull()
+	}
+
+	modeKey := keys[0]
+	for _, key := range keys[1:] {
+		if counts[modeKey] < counts[key] {
+			modeKey = key
+		} else if counts[modeKey] == counts[key] {
+			if NewSortValue(values[key], flags).Less(NewSortValue(values[modeKey], flags)) == ternary.TRUE {
+				modeKey = key
+			}
+		}
+	}
+	return values[modeKey]
+}
+
+// sumOfSquaredDeviations uses Welford's online algorithm to avoid the loss of precision
+// that occurs when subtracting the square of the sum from the sum of the squares.
+func sumOfSquaredDeviations(list []value.Primary) (float64, int) {
+	var mean float64
+	var m2 float64
+	var count int
+
+	for _, v := range list {
+		f := value.ToFloat(v)
+		if value.IsNull(f) {
+			continue
+		}
+
+		x := f.(value.Float).Raw()
+		count++
+		delta := x - mean
+		mean += delta / float64(count)
+		m2 += delta * (x - mean)
+	}
+
+	return m2, count
+}
+
+func variance(list []value.Primary, ddof int) value.Primary {
+	m2, count := sumOfSquaredDeviations(list)
+	if count <= ddof {
+		return value.NewNull()
+	}
+	return value.ParseFloat64(m2 / float64(count-ddof))
+}
+
+func stdDev(list []value.Primary, ddof int) value.Primary {
+	v := variance(list, ddof)
+	if value.IsNull(v) {
+		return v
+	}
+	return value.ParseFloat64(math.Sqrt(value.ToFloat(v).(value.Float).Raw()))
+}
+
+func VarPop(list []value.Primary, _ *cmd.Flags) value.Primary {
+	return variance(list, 0)
+}
+
+func VarSamp(list []value.Primary, _ *cmd.Flags) value.Primary {
+	return variance(list, 1)
+}
+
+func StdDevPop(list []value.Primary, _ *cmd.Flags) value.Primary {
+	return stdDev(list, 0)
+}
+
+func StdDevSamp(list []value.Primary, _ *cmd.Flags) value.Primary {
+	return stdDev(list, 1)
+}
+
+func execBitAggregate(list []value.Primary, bitf func(int64, int64) int64) value.Primary {
+	var result int64
+	var count int
+
+	for _, v := range list {
+		i := value.ToInteger(v)
+		if value.IsNull(i) {
+			continue
+		}
+
+		if count < 1 {
+			result = i.(value.Integer).Raw()
+		} else {
+			result = bitf(result, i.(value.Integer).Raw())
+		}
+		count++
+	}
+
+	if count < 1 {
+		return value.NewNull()
+	}
+	return value.NewInteger(result)
+}
+
+func BitAnd(list []value.Primary, _ *cmd.Flags) value.Primary {
+	return execBitAggregate(list, func(a int64, b int64) int64 { return a & b })
+}
+
+func BitOr(list []value.Primary, _ *cmd.Flags) value.Primary {
+	return execBitAggregate(list, func(a int64, b int64) int64 { return a | b })
+}
+
+func BitXor(list []value.Primary, _ *cmd.Flags) value.Primary {
+	return execBitAggregate(list, func(a int64, b int64) int64 { return a ^ b })
+}
+
+func execBoolAggregate(list []value.Primary, ternaryf func([]ternary.Value) ternary.Value) value.Primary {
+	values := make([]ternary.Value, 0, len(list))
+	for _, v := range list {
+		if value.IsNull(v) {
+			continue
+		}
+		values = append(values, v.Ternary())
+	}
+
+	if len(values) < 1 {
+		return value.NewNull()
+	}
+	return value.NewTernary(ternaryf(values))
+}
+
+func BoolAnd(list []value.Primary, _ *cmd.Flags) value.Primary {
+	return execBoolAggregate(list, ternary.All)
+}
+
+func BoolOr(list []value.Primary, _ *cmd.Flags) value.Primary {
+	return execBoolAggregate(list, ternary.Any)
+}
+
+func checksumValue(v value.Primary) uint64 {
+	h := fnv.New64a()
+	switch v.(type) {
+	case value.Null:
+		_, _ = h.Write([]byte{0xff})
+	case value.Datetime:
+		_, _ = h.Write([]byte(v.(value.Datetime).Raw().Format(time.RFC3339Nano)))
+	case value.String:
+		_, _ = h.Write([]byte(v.(value.String).Raw()))
+	default:
+		_, _ = h.Write([]byte(v.String()))
+	}
+	return h.Sum64()
+}
+
+func ChecksumAgg(list []value.Primary, _ *cmd.Flags) value.Primary {
+	if len(list) < 1 {
+		return value.NewNull()
+	}
+
+	var sum uint64
+	for _, v := range list {
+		sum += checksumValue(v)
+	}
+	return value.NewInteger(int64(sum))
+}
+
+type coMoments struct {
+	Count int
+	MeanY float64
+	MeanX float64
+	M2Y   float64
+	M2X   float64
+	C     float64
+}
+
+// calcCoMoments accumulates the co-moment of the pairs in the same way as sumOfSquaredDeviations.
+// Pairs in which either value is not a number are ignored.
+func calcCoMoments(ylist []value.Primary, xlist []value.Primary) coMoments {
+	m := coMoments{}
+
+	for i := range ylist {
+		fy := value.ToFloat(ylist[i])
+		if value.IsNull(fy) {
+			continue
+		}
+		fx := value.ToFloat(xlist[i])
+		if value.IsNull(fx) {
+			continue
+		}
+
+		y := fy.(value.Float).Raw()
+		x := fx.(value.Float).Raw()
+
+		m.Count++
+		dy := y - m.MeanY
+		dx := x - m.MeanX
+		m.MeanY += dy / float64(m.Count)
+		m.MeanX += dx / float64(m.Count)
+		m.M2Y += dy * (y - m.MeanY)
+		m.M2X += dx * (x - m.MeanX)
+		m.C += dx * (y - m.MeanY)
+	}
+
+	return m
+}
+
+func CovarPop(ylist []value.Primary, xlist []value.Primary, _ *cmd.Flags) value.Primary {
+	m := calcCoMoments(ylist, xlist)
+	if m.Count < 1 {
+		return value.NewNull()
+	}
+	return value.ParseFloat64(m.C / float64(m.Count))
+}
+
+func CovarSamp(ylist []value.Primary, xlist []value.Primary, _ *cmd.Flags) value.Primary {
+	m := calcCoMoments(ylist, xlist)
+	if m.Count < 2 {
+		return value.NewNull()
+	}
+	return value.ParseFloat64(m.C / float64(m.Count-1))
+}
+
+func Corr(ylist []value.Primary, xlist []value.Primary, _ *cmd.Flags) value.Primary {
+	m := calcCoMoments(ylist, xlist)
+	if m.Count < 1 || m.M2Y == 0 || m.M2X == 0 {
+		return value.NewNull()
+	}
+	return value.ParseFloat64(m.C / math.Sqrt(m.M2Y*m.M2X))
+}
+
+func RegrSlope(ylist []value.Primary, xlist []value.Primary, _ *cmd.Flags) value.Primary {
+	m := calcCoMoments(ylist, xlist)
+	if m.Count < 1 || m.M2X == 0 {
+		return value.NewNull()
+	}
+	return value.ParseFloat64(m.C / m.M2X)
+}
+
+func RegrIntercept(ylist []value.Primary, xlist []value.Primary, _ *cmd.Flags) value.Primary {
+	m := calcCoMoments(ylist, xlist)
+	if m.Count < 1 || m.M2X == 0 {
+		return value.NewNull()
+	}
+	return value.ParseFloat64(m.MeanY - (m.C/m.M2X)*m.MeanX)
+}
+
+func PercentileCont(list []value.Primary, fraction float64, flags *cmd.Flags) value.Primary {
+	var values []float64
+
+	for _, v := range list {
+		if f := value.ToFloat(v); !value.IsNull(f) {
+			values = append(values, f.(value.Float).Raw())
+			continue
+		}
+		if d := value.ToDatetime(v, flags.DatetimeFormat); !value.IsNull(d) {
+			values = append(values, float64(d.(value.Datetime).Raw().UnixNano())/1e9)
+			continue
+		}
+	}
+
+	if len(values) < 1 {
+		return value.NewNull()
+	}
+
+	rn := fraction * float64(len(values)-1)
+	lo := math.Floor(rn)
+	hi := math.Ceil(rn)
+	if lo == hi {
+		return value.ParseFloat64(values[int(lo)])
+	}
+	return value.ParseFloat64(values[int(lo)] + (rn-lo)*(values[int(hi)]-values[int(lo)]))
+}
+
+func PercentileDisc(list []value.Primary, fraction float64) value.Primary {
+	values := make([]value.Primary, 0, len(list))
+	for _, v := range list {
+		if !value.IsNull(v) {
+			values = append(values, v)
+		}
+	}
+
+	if len(values) < 1 {
+		return value.NewNull()
+	}
+
+	idx := int(math.Ceil(fraction*float64(len(values)))) - 1
+	if idx < 0 {
+		idx = 0
+	}
+	return values[idx]
+}
+
 func ListAgg(list []value.Primary, separator string) value.Primary {
 	strlist := make([]string, 0)
 	for _, v := range list {
@@ -169,6 +504,20 @@ func ListAgg(list []value.Primary, separator string) value.Primary {
 	return value.NewString(strings.Join(strlist, separator))
 }
 
+func First(list []value.Primary) value.Primary {
+	if len(list) < 1 {
+		return value.NewNull()
+	}
+	return list[0]
+}
+
+func Last(list []value.Primary) value.Primary {
+	if len(list) < 1 {
+		return value.NewNull()
+	}
+	return list[len(list)-1]
+}
+
 func JsonAgg(list []value.Primary) value.Primary {
 	array := make(txjson.Array, 0, len(list))
 
@@ -176,5 +525,78 @@ func JsonAgg(list []value.Primary) value.Primary {
 		array = append(array, json.ParseValueToStructure(v))
 	}
 
-	return value.NewString(array.Encode())
+	return value.NewJson(array)
+}
+
+func ArrayAgg(list []value.Primary) value.Primary {
+	values := make([]value.Primary, len(list))
+	copy(values, list)
+	return value.NewArray(values)
+}
+
+func cashFlows(list []value.Primary) []float64 {
+	flows := make([]float64, 0, len(list))
+	for _, v := range list {
+		if f := value.ToFloat(v); !value.IsNull(f) {
+			flows = append(flows, f.(value.Float).Raw())
+		}
+	}
+	return flows
+}
+
+func Npv(list []value.Primary, rate float64) value.Primary {
+	flows := cashFlows(list)
+	if len(flows) < 1 {
+		return value.NewNull()
+	}
+
+	var npv float64
+	for i, v := range flows {
+		npv += v / math.Pow(1+rate, float64(i+1))
+	}
+	return value.ParseFloat64(npv)
+}
+
+const (
+	IrrMaxIterations = 100
+	IrrTolerance     = 1e-10
+)
+
+func Irr(list []value.Primary, guess float64) value.Primary {
+	flows := cashFlows(list)
+
+	hasPositive, hasNegative := false, false
+	for _, v := range flows {
+		if 0 < v {
+			hasPositive = true
+		} else if v < 0 {
+			hasNegative = true
+		}
+	}
+	if !hasPositive || !hasNegative {
+		return value.NewNull()
+	}
+
+	rate := guess
+	for i := 0; i < IrrMaxIterations; i++ {
+		var npv, derivative float64
+		for t, v := range flows {
+			d := math.Pow(1+rate, float64(t))
+			npv += v / d
+			derivative -= float64(t) * v / (d * (1 + rate))
+		}
+		if derivative == 0 {
+			break
+		}
+
+		next := rate - npv/derivative
+		if math.IsInf(next, 0) || math.IsNaN(next) || next <= -1 {
+			break
+		}
+		if math.Abs(next-rate) < IrrTolerance {
+			return value.ParseFloat64(next)
+		}
+		rate = next
+	}
+	return value.NewNull()
 }
@@ -1,11 +1,19 @@
 package query
 
 import (
+	"context"
+	"math"
+	"os"
 	"reflect"
 	"testing"
 	"time"
 
+	"github.com/mithrandie/csvq/lib/parser"
 	"github.com/mithrandie/csvq/lib/value"
+
+	txjson "github.com/mithrandie/go-text/json"
+
+	"github.com/mithrandie/ternary"
 )
 
 type aggregateTests struct {
@@ -208,6 +216,484 @@ func TestMedian(t *testing.T) {
 	}
 }
 
+var modeTests = []aggregateTests{
+	{
+		List: []value.Primary{
+			value.NewInteger(2),
+			value.NewNull(),
+			value.NewInteger(1),
+			value.NewNull(),
+			value.NewFloat(2),
+			value.NewNull(),
+		},
+		Result: value.NewInteger(2),
+	},
+	{
+		List: []value.Primary{
+			value.NewString("b"),
+			value.NewString("A"),
+			value.NewString("B"),
+			value.NewString("a"),
+			value.NewString("c"),
+		},
+		Result: value.NewString("A"),
+	},
+	{
+		List: []value.Primary{
+			value.NewNull(),
+		},
+		Result: value.NewNull(),
+	},
+}
+
+func TestMode(t *testing.T) {
+	for _, v := range modeTests {
+		r := Mode(v.List, TestTx.Flags)
+		if !reflect.DeepEqual(r, v.Result) {
+			t.Errorf("mode list = %s: result = %s, want %s", v.List, r, v.Result)
+		}
+	}
+}
+
+var varianceTestList = []value.Primary{
+	value.NewInteger(1),
+	value.NewNull(),
+	value.NewInteger(2),
+	value.NewString("3"),
+	value.NewInteger(4),
+	value.NewFloat(5),
+}
+
+var varPopTests = []aggregateTests{
+	{
+		List:   varianceTestList,
+		Result: value.NewInteger(2),
+	},
+	{
+		List: []value.Primary{
+			value.NewFloat(1e9 + 4),
+			value.NewFloat(1e9 + 7),
+			value.NewFloat(1e9 + 13),
+			value.NewFloat(1e9 + 16),
+		},
+		Result: value.NewFloat(22.5),
+	},
+	{
+		List: []value.Primary{
+			value.NewInteger(3),
+		},
+		Result: value.NewInteger(0),
+	},
+	{
+		List: []value.Primary{
+			value.NewNull(),
+		},
+		Result: value.NewNull(),
+	},
+}
+
+func TestVarPop(t *testing.T) {
+	for _, v := range varPopTests {
+		r := VarPop(v.List, TestTx.Flags)
+		if !reflect.DeepEqual(r, v.Result) {
+			t.Errorf("var_pop list = %s: result = %s, want %s", v.List, r, v.Result)
+		}
+	}
+}
+
+var varSampTests = []aggregateTests{
+	{
+		List:   varianceTestList,
+		Result: value.NewFloat(2.5),
+	},
+	{
+		List: []value.Primary{
+			value.NewFloat(1e9 + 4),
+			value.NewFloat(1e9 + 7),
+			value.NewFloat(1e9 + 13),
+			value.NewFloat(1e9 + 16),
+		},
+		Result: value.NewInteger(30),
+	},
+	{
+		List: []value.Primary{
+			value.NewInteger(3),
+		},
+		Result: value.NewNull(),
+	},
+}
+
+func TestVarSamp(t *testing.T) {
+	for _, v := range varSampTests {
+		r := VarSamp(v.List, TestTx.Flags)
+		if !reflect.DeepEqual(r, v.Result) {
+			t.Errorf("var_samp list = %s: result = %s, want %s", v.List, r, v.Result)
+		}
+	}
+}
+
+var stdDevPopTests = []aggregateTests{
+	{
+		List:   varianceTestList,
+		Result: value.NewFloat(math.Sqrt(2)),
+	},
+	{
+		List: []value.Primary{
+			value.NewNull(),
+		},
+		Result: value.NewNull(),
+	},
+}
+
+func TestStdDevPop(t *testing.T) {
+	for _, v := range stdDevPopTests {
+		r := StdDevPop(v.List, TestTx.Flags)
+		if !reflect.DeepEqual(r, v.Result) {
+			t.Errorf("stddev_pop list = %s: result = %s, want %s", v.List, r, v.Result)
+		}
+	}
+}
+
+var stdDevSampTests = []aggregateTests{
+	{
+		List:   varianceTestList,
+		Result: value.NewFloat(math.Sqrt(2.5)),
+	},
+	{
+		List: []value.Primary{
+			value.NewInteger(3),
+		},
+		Result: value.NewNull(),
+	},
+}
+
+func TestStdDevSamp(t *testing.T) {
+	for _, v := range stdDevSampTests {
+		r := StdDevSamp(v.List, TestTx.Flags)
+		if !reflect.DeepEqual(r, v.Result) {
+			t.Errorf("stddev_samp list = %s: result = %s, want %s", v.List, r, v.Result)
+		}
+	}
+}
+
+var bivariateTestYList = []value.Primary{
+	value.NewInteger(3),
+	value.NewInteger(5),
+	value.NewNull(),
+	value.NewInteger(7),
+	value.NewInteger(13),
+}
+
+var bivariateTestXList = []value.Primary{
+	value.NewInteger(1),
+	value.NewInteger(2),
+	value.NewInteger(3),
+	value.NewString("3"),
+	value.NewNull(),
+}
+
+var bivariateAggregateTests = []struct {
+	Name     string
+	Function BivariateAggregateFunction
+	YList    []value.Primary
+	XList    []value.Primary
+	Result   value.Primary
+}{
+	{
+		Name:     "CovarPop",
+		Function: CovarPop,
+		YList:    bivariateTestYList,
+		XList:    bivariateTestXList,
+		Result:   value.NewFloat(4.0 / 3.0),
+	},
+	{
+		Name:     "CovarSamp",
+		Function: CovarSamp,
+		YList:    bivariateTestYList,
+		XList:    bivariateTestXList,
+		Result:   value.NewInteger(2),
+	},
+	{
+		Name:     "CovarSamp Single Pair",
+		Function: CovarSamp,
+		YList:    []value.Primary{value.NewInteger(1)},
+		XList:    []value.Primary{value.NewInteger(1)},
+		Result:   value.NewNull(),
+	},
+	{
+		Name:     "Corr",
+		Function: Corr,
+		YList:    bivariateTestYList,
+		XList:    bivariateTestXList,
+		Result:   value.NewInteger(1),
+	},
+	{
+		Name:     "Corr Negative",
+		Function: Corr,
+		YList:    []value.Primary{value.NewInteger(3), value.NewInteger(2), value.NewInteger(1)},
+		XList:    []value.Primary{value.NewInteger(1), value.NewInteger(2), value.NewInteger(3)},
+		Result:   value.NewInteger(-1),
+	},
+	{
+		Name:     "Corr Constant Values",
+		Function: Corr,
+		YList:    []value.Primary{value.NewInteger(3), value.NewInteger(3)},
+		XList:    []value.Primary{value.NewInteger(1), value.NewInteger(2)},
+		Result:   value.NewNull(),
+	},
+	{
+		Name:     "RegrSlope",
+		Function: RegrSlope,
+		YList:    bivariateTestYList,
+		XList:    bivariateTestXList,
+		Result:   value.NewInteger(2),
+	},
+	{
+		Name:     "RegrIntercept",
+		Function: RegrIntercept,
+		YList:    bivariateTestYList,
+		XList:    bivariateTestXList,
+		Result:   value.NewInteger(1),
+	},
+	{
+		Name:     "RegrIntercept All Nulls",
+		Function: RegrIntercept,
+		YList:    []value.Primary{value.NewNull()},
+		XList:    []value.Primary{value.NewInteger(1)},
+		Result:   value.NewNull(),
+	},
+}
+
+func TestBivariateAggregateFunctions(t *testing.T) {
+	for _, v := range bivariateAggregateTests {
+		r := v.Function(v.YList, v.XList, TestTx.Flags)
+		if !reflect.DeepEqual(r, v.Result) {
+			t.Errorf("%s: result = %s, want %s", v.Name, r, v.Result)
+		}
+	}
+}
+
+var bitAggregateTestList = []value.Primary{
+	value.NewInteger(6),
+	value.NewNull(),
+	value.NewString("12"),
+	value.NewString("abc"),
+	value.NewInteger(7),
+}
+
+var bitAggregateTests = []struct {
+	Name     string
+	Function AggregateFunction
+	List     []value.Primary
+	Result   value.Primary
+}{
+	{
+		Name:     "BitAnd",
+		Function: BitAnd,
+		List:     bitAggregateTestList,
+		Result:   value.NewInteger(4),
+	},
+	{
+		Name:     "BitOr",
+		Function: BitOr,
+		List:     bitAggregateTestList,
+		Result:   value.NewInteger(15),
+	},
+	{
+		Name:     "BitXor",
+		Function: BitXor,
+		List:     bitAggregateTestList,
+		Result:   value.NewInteger(13),
+	},
+	{
+		Name:     "BitAnd All Nulls",
+		Function: BitAnd,
+		List:     []value.Primary{value.NewNull()},
+		Result:   value.NewNull(),
+	},
+}
+
+func TestBitAggregateFunctions(t *testing.T) {
+	for _, v := range bitAggregateTests {
+		r := v.Function(v.List, TestTx.Flags)
+		if !reflect.DeepEqual(r, v.Result) {
+			t.Errorf("%s: result = %s, want %s", v.Name, r, v.Result)
+		}
+	}
+}
+
+var boolAggregateTests = []struct {
+	Name     string
+	Function AggregateFunction
+	List     []value.Primary
+	Result   value.Primary
+}{
+	{
+		Name:     "BoolAnd",
+		Function: BoolAnd,
+		List:     []value.Primary{value.NewTernary(ternary.TRUE), value.NewNull(), value.NewBoolean(true), value.NewInteger(1)},
+		Result:   value.NewTernary(ternary.TRUE),
+	},
+	{
+		Name:     "BoolAnd False",
+		Function: BoolAnd,
+		List:     []value.Primary{value.NewTernary(ternary.UNKNOWN), value.NewTernary(ternary.TRUE), value.NewString("false")},
+		Result:   value.NewTernary(ternary.FALSE),
+	},
+	{
+		Name:     "BoolAnd Unknown",
+		Function: BoolAnd,
+		List:     []value.Primary{value.NewTernary(ternary.UNKNOWN), value.NewTernary(ternary.TRUE)},
+		Result:   value.NewTernary(ternary.UNKNOWN),
+	},
+	{
+		Name:     "BoolOr",
+		Function: BoolOr,
+		List:     []value.Primary{value.NewTernary(ternary.UNKNOWN), value.NewTernary(ternary.FALSE), value.NewTernary(ternary.TRUE)},
+		Result:   value.NewTernary(ternary.TRUE),
+	},
+	{
+		Name:     "BoolOr Unknown",
+		Function: BoolOr,
+		List:     []value.Primary{value.NewTernary(ternary.UNKNOWN), value.NewTernary(ternary.FALSE)},
+		Result:   value.NewTernary(ternary.UNKNOWN),
+	},
+	{
+		Name:     "BoolOr All Nulls",
+		Function: BoolOr,
+		List:     []value.Primary{value.NewNull()},
+		Result:   value.NewNull(),
+	},
+}
+
+func TestBoolAggregateFunctions(t *testing.T) {
+	for _, v := range boolAggregateTests {
+		r := v.Function(v.List, TestTx.Flags)
+		if !reflect.DeepEqual(r, v.Result) {
+			t.Errorf("%s: result = %s, want %s", v.Name, r, v.Result)
+		}
+	}
+}
+
+func TestChecksumAgg(t *testing.T) {
+	list := []value.Primary{
+		value.NewString("a"),
+		value.NewInteger(1),
+		value.NewNull(),
+		value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 0, GetTestLocation())),
+	}
+	reversed := []value.Primary{list[3], list[2], list[1], list[0]}
+
+	r1 := ChecksumAgg(list, TestTx.Flags)
+	r2 := ChecksumAgg(reversed, TestTx.Flags)
+	if !reflect.DeepEqual(r1, r2) {
+		t.Errorf("result = %s, want %s for the reversed list", r2, r1)
+	}
+
+	r3 := ChecksumAgg(list[:3], TestTx.Flags)
+	if reflect.DeepEqual(r1, r3) {
+		t.Errorf("result = %s, want a different value for the different list", r3)
+	}
+
+	r4 := ChecksumAgg([]value.Primary{value.NewString("A")}, TestTx.Flags)
+	r5 := ChecksumAgg([]value.Primary{value.NewString("a")}, TestTx.Flags)
+	if reflect.DeepEqual(r4, r5) {
+		t.Errorf("result = %s, want a different value for the different case", r4)
+	}
+
+	r6 := ChecksumAgg([]value.Primary{}, TestTx.Flags)
+	if !reflect.DeepEqual(r6, value.NewNull()) {
+		t.Errorf("result = %s, want %s for the empty list", r6, value.NewNull())
+	}
+}
+
+var percentileTestList = []value.Primary{
+	value.NewNull(),
+	value.NewInteger(10),
+	value.NewInteger(20),
+	value.NewInteger(30),
+	value.NewInteger(40),
+}
+
+var percentileContTests = []struct {
+	List     []value.Primary
+	Fraction float64
+	Result   value.Primary
+}{
+	{
+		List:     percentileTestList,
+		Fraction: 0.5,
+		Result:   value.NewInteger(25),
+	},
+	{
+		List:     percentileTestList,
+		Fraction: 1,
+		Result:   value.NewInteger(40),
+	},
+	{
+		List: []value.Primary{
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 0, GetTestLocation())),
+			value.NewDatetime(time.Date(2012, 2, 5, 9, 18, 15, 0, GetTestLocation())),
+		},
+		Fraction: 0.5,
+		Result:   value.NewInteger(1328347095),
+	},
+	{
+		List: []value.Primary{
+			value.NewNull(),
+		},
+		Fraction: 0.5,
+		Result:   value.NewNull(),
+	},
+}
+
+func TestPercentileCont(t *testing.T) {
+	for _, v := range percentileContTests {
+		r := PercentileCont(v.List, v.Fraction, TestTx.Flags)
+		if !reflect.DeepEqual(r, v.Result) {
+			t.Errorf("percentile_cont list = %s, fraction = %f: result = %s, want %s", v.List, v.Fraction, r, v.Result)
+		}
+	}
+}
+
+var percentileDiscTests = []struct {
+	List     []value.Primary
+	Fraction float64
+	Result   value.Primary
+}{
+	{
+		List:     percentileTestList,
+		Fraction: 0.5,
+		Result:   value.NewInteger(20),
+	},
+	{
+		List:     percentileTestList,
+		Fraction: 0.51,
+		Result:   value.NewInteger(30),
+	},
+	{
+		List:     percentileTestList,
+		Fraction: 0,
+		Result:   value.NewInteger(10),
+	},
+	{
+		List: []value.Primary{
+			value.NewNull(),
+		},
+		Fraction: 0.5,
+		Result:   value.NewNull(),
+	},
+}
+
+func TestPercentileDisc(t *testing.T) {
+	for _, v := range percentileDiscTests {
+		r := PercentileDisc(v.List, v.Fraction)
+		if !reflect.DeepEqual(r, v.Result) {
+			t.Errorf("percentile_disc list = %s, fraction = %f: result = %s, want %s", v.List, v.Fraction, r, v.Result)
+		}
+	}
+}
+
 var listAggTests = []struct {
 	List      []value.Primary
 	Separator string
@@ -243,13 +729,136 @@ func TestListAgg(t *testing.T) {
 	}
 }
 
+var npvTests = []struct {
+	List   []value.Primary
+	Rate   float64
+	Result value.Primary
+}{
+	{
+		List: []value.Primary{
+			value.NewInteger(-100),
+			value.NewNull(),
+			value.NewInteger(200),
+		},
+		Rate:   1,
+		Result: value.NewInteger(0),
+	},
+	{
+		List: []value.Primary{
+			value.NewInteger(100),
+			value.NewInteger(200),
+		},
+		Rate:   0,
+		Result: value.NewInteger(300),
+	},
+	{
+		List: []value.Primary{
+			value.NewNull(),
+		},
+		Rate:   0.1,
+		Result: value.NewNull(),
+	},
+}
+
+func TestNpv(t *testing.T) {
+	for _, v := range npvTests {
+		r := Npv(v.List, v.Rate)
+		if !reflect.DeepEqual(r, v.Result) {
+			t.Errorf("npv list = %s, rate = %f: result = %s, want %s", v.List, v.Rate, r, v.Result)
+		}
+	}
+}
+
+var irrTests = []struct {
+	List   []value.Primary
+	Guess  float64
+	Result value.Primary
+}{
+	{
+		List: []value.Primary{
+			value.NewInteger(-100),
+			value.NewInteger(110),
+		},
+		Guess:  0.5,
+		Result: value.NewFloat(0.1),
+	},
+	{
+		List: []value.Primary{
+			value.NewInteger(-100),
+			value.NewNull(),
+			value.NewInteger(121),
+		},
+		Guess:  0.1,
+		Result: value.NewFloat(0.21),
+	},
+	{
+		List: []value.Primary{
+			value.NewInteger(100),
+			value.NewInteger(110),
+		},
+		Guess:  0.1,
+		Result: value.NewNull(),
+	},
+}
+
+func TestIrr(t *testing.T) {
+	for _, v := range irrTests {
+		r := Irr(v.List, v.Guess)
+		if f, ok := r.(value.Float); ok {
+			r = value.NewFloat(math.Round(f.Raw()*1e9) / 1e9)
+		}
+		if !reflect.DeepEqual(r, v.Result) {
+			t.Errorf("irr list = %s, guess = %f: result = %s, want %s", v.List, v.Guess, r, v.Result)
+		}
+	}
+}
+
+var firstLastTests = []struct {
+	List  []value.Primary
+	First value.Primary
+	Last  value.Primary
+}{
+	{
+		List:  []value.Primary{},
+		First: value.NewNull(),
+		Last:  value.NewNull(),
+	},
+	{
+		List: []value.Primary{
+			value.NewNull(),
+			value.NewString("str2"),
+			value.NewString("str3"),
+		},
+		First: value.NewNull(),
+		Last:  value.NewString("str3"),
+	},
+}
+
+func TestFirst(t *testing.T) {
+	for _, v := range firstLastTests {
+		r := First(v.List)
+		if !reflect.DeepEqual(r, v.First) {
+			t.Errorf("First list = %s, result = %s, want %s", v.List, r, v.First)
+		}
+	}
+}
+
+func TestLast(t *testing.T) {
+	for _, v := range firstLastTests {
+		r := Last(v.List)
+		if !reflect.DeepEqual(r, v.Last) {
+			t.Errorf("Last list = %s, result = %s, want %s", v.List, r, v.Last)
+		}
+	}
+}
+
 var jsonAggTests = []struct {
 	List   []value.Primary
 	Result value.Primary
 }{
 	{
 		List:   []value.Primary{},
-		Result: value.NewString("[]"),
+		Result: value.NewJson(txjson.Array{}),
 	},
 	{
 		List: []value.Primary{
@@ -257,7 +866,7 @@ var jsonAggTests = []struct {
 			value.NewNull(),
 			value.NewString("str2"),
 		},
-		Result: value.NewString("[\"str3\",null,\"str2\"]"),
+		Result: value.NewJson(txjson.Array{txjson.String("str3"), txjson.Null{}, txjson.String("str2")}),
 	},
 }
 
@@ -269,3 +878,85 @@ func TestJsonAgg(t *testing.T) {
 		}
 	}
 }
+
+var arrayAggTests = []aggregateTests{
+	{
+		List:   []value.Primary{},
+		Result: value.NewArray([]value.Primary{}),
+	},
+	{
+		List: []value.Primary{
+			value.NewString("str3"),
+			value.NewNull(),
+			value.NewInteger(2),
+		},
+		Result: value.NewArray([]value.Primary{value.NewString("str3"), value.NewNull(), value.NewInteger(2)}),
+	},
+}
+
+func TestArrayAgg(t *testing.T) {
+	for _, v := range arrayAggTests {
+		r := ArrayAgg(v.List)
+		if !reflect.DeepEqual(r, v.Result) {
+			t.Errorf("ArrayAgg list = %s, result = %s, want %s", v.List, r, v.Result)
+		}
+	}
+}
+
+var selectArrayAggTests = []struct {
+	Query  string
+	Result [][]string
+}{
+	{
+		Query: "SELECT c1, ARRAY_AGG(c2 ORDER BY c2 DESC), ARRAY_LENGTH(ARRAY_AGG(DISTINCT c2)) FROM array_agg_test GROUP BY c1",
+		Result: [][]string{
+			{"\"a\"", "[\"3\", \"1\", \"1\"]", "2"},
+			{"\"b\"", "[\"2\"]", "1"},
+		},
+	},
+	{
+		Query: "SELECT value FROM UNNEST((SELECT ARRAY_AGG(c2 ORDER BY c2) FROM array_agg_test WHERE c1 = 'a'))",
+		Result: [][]string{
+			{"\"1\""},
+			{"\"1\""},
+			{"\"3\""},
+		},
+	},
+}
+
+func TestSelectArrayAgg(t *testing.T) {
+	fpath := GetTestFilePath("array_agg_test.csv")
+	if err := os.WriteFile(fpath, []byte("c1,c2\na,1\nb,2\na,3\na,1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+		_ = os.Remove(fpath)
+		initFlag(TestTx.Flags)
+	}()
+
+	TestTx.Flags.Repository = TestDir
+
+	for _, v := range selectArrayAggTests {
+		statements, _, err := parser.Parse(v.Query, "", nil, false)
+		if err != nil {
+			t.Fatalf("%s: unexpected parse error %q", v.Query, err)
+		}
+		view, err := Select(context.Background(), NewFilter(TestTx).CreateNode(), statements[0].(parser.SelectQuery))
+		if err != nil {
+			t.Errorf("%s: unexpected error %q", v.Query, err)
+			continue
+		}
+
+		result := make([][]string, view.RecordLen())
+		for i, record := range view.RecordSet {
+			result[i] = make([]string, len(record))
+			for j, cell := range record {
+				result[i][j] = cell.Value().String()
+			}
+		}
+		if !reflect.DeepEqual(result, v.Result) {
+			t.Errorf("%s: records = %q, want %q", v.Query, result, v.Result)
+		}
+	}
+}
@@ -24,6 +24,7 @@ var AnalyticFunctions = map[string]AnalyticFunction{
 	"LEAD":         Lead{},
 	"LISTAGG":      AnalyticListAgg{},
 	"JSON_AGG":     AnalyticJsonAgg{},
+	"ARRAY_AGG":    AnalyticArrayAgg{},
 }
 
 type AnalyticFunction interface {
@@ -43,11 +44,13 @@ func Analyze(ctx context.Context, view *View, fn parser.AnalyticFunction, partit
 	const (
 		Analytic = iota
 		Aggregate
+		BivariateAggregate
 		UserDefined
 	)
 
 	var anfn AnalyticFunction
 	var aggfn AggregateFunction
+	var bivarfn BivariateAggregateFunction
 	var udfn *UserDefinedFunction
 
 	fnType := -1
@@ -60,12 +63,18 @@ func Analyze(ctx context.Context, view *View, fn parser.AnalyticFunction, partit
 	} else if f, ok := AggregateFunctions[uname]; ok {
 		aggfn = f
 		fnType = Aggregate
+	} else if f, ok := BivariateAggregateFunctions[uname]; ok {
+		bivarfn = f
+		fnType = BivariateAggregate
 	} else {
 		if udfn, err = view.Filter.functions.Get(fn, uname); err != nil || !udfn.IsAggregate {
 			return NewFunctionNotExistError(fn, fn.Name)
 		}
 		fnType = UserDefined
 	}
+	if fnType == UserDefined || isNondeterministicFunction(uname) {
+		view.Filter.dependencies.setUncacheable()
+	}
 
 	switch fnType {
 	case Analytic:
@@ -76,6 +85,13 @@ func Analyze(ctx context.Context, view *View, fn parser.AnalyticFunction, partit
 		if len(fn.Args) != 1 {
 			return NewFunctionArgumentLengthError(fn, fn.Name, []int{1})
 		}
+	case BivariateAggregate:
+		if len(fn.Args) != 2 {
+			return NewFunctionArgumentLengthError(fn, fn.Name, []int{2})
+		}
+		if fn.IsDistinct() {
+			return NewFunctionInvalidArgumentError(fn, fn.Name, "DISTINCT cannot be used")
+		}
 	case UserDefined:
 		if err := udfn.CheckArgsLen(fn, fn.Name, len(fn.Args)-1); err != nil {
 			return err
@@ -156,7 +172,21 @@ func Analyze(ctx context.Context, view *View, fn parser.AnalyticFunction, partit
 
 					if fnType == Aggregate {
 						partition := partitions[partitionMapKeys[i]]
-						frameSet := WindowFrameSet(partition, fn.AnalyticClause)
+						frameSet := WindowFrameSet(partition, fn.AnalyticClause, view)
+
+						if newAggregator, ok := windowAggregatorFunction(uname, fn, frameSet); ok {
+							results, e := windowAggregate(ctx, filter, frameSet, partition, fn, newAggregator)
+							if e != nil {
+								gm.SetError(e)
+								break AnalyzeLoop
+							}
+							for j, frame := range frameSet {
+								for _, idx := range frame.Records {
+									view.RecordSet[idx] = append(view.RecordSet[idx], NewCell(results[j]))
+								}
+							}
+							continue
+						}
 
 						valueCache := make(map[int]value.Primary, len(partition))
 
@@ -168,13 +198,42 @@ func Analyze(ctx context.Context, view *View, fn parser.AnalyticFunction, partit
 							}
 							val := aggfn(values, view.Tx.Flags)
 
+							for _, idx := range frame.Records {
+								view.RecordSet[idx] = append(view.RecordSet[idx], NewCell(val))
+							}
+						}
+					} else if fnType == BivariateAggregate {
+						partition := partitions[partitionMapKeys[i]]
+						frameSet := WindowFrameSet(partition, fn.AnalyticClause, view)
+
+						xfn := fn
+						xfn.Args = fn.Args[1:]
+
+						valueCache := make(map[int]value.Primary, len(partition))
+						xValueCache := make(map[int]value.Primary, len(partition))
+
+						for _, frame := range frameSet {
+							values, e := windowValues(ctx, filter, frame, partition, fn, valueCache)
+							if e != nil {
+								gm.SetError(e)
+								break AnalyzeLoop
+							}
+							xvalues, e := windowValues(ctx, filter, frame, partition, xfn, xValueCache)
+							if e != nil {
+								gm.SetError(e)
+								break AnalyzeLoop
+							}
+							val := bivarfn(values, xvalues, view.Tx.Flags)
+
 							for _, idx := range frame.Records {
 								view.RecordSet[idx] = append(view.RecordSet[idx], NewCell(val))
 							}
 						}
 					} else { //User Defined Function
+						// User defined aggregate functions cannot be calculated incrementally, so they are
+						// executed with all the values in each frame.
 						partition := partitions[partitionMapKeys[i]]
-						frameSet := WindowFrameSet(partition, fn.AnalyticClause)
+						frameSet := WindowFrameSet(partition, fn.AnalyticClause, view)
 
 						valueCache := make(map[int]value.Primary, len(partition))
 
@@ -229,12 +288,22 @@ func Analyze(ctx context.Context, view *View, fn parser.AnalyticFunction, partit
 }
 
 type WindowFrame struct {
-	Low     int
-	High    int
-	Records []int
+	Low      int
+	High     int
+	Records  []int
+	Excludes []int
+}
+
+func (frame WindowFrame) IsExcluded(i int) bool {
+	for _, v := range frame.Excludes {
+		if v == i {
+			return true
+		}
+	}
+	return false
 }
 
-func WindowFrameSet(partition Partition, expr parser.AnalyticClause) []WindowFrame {
+func WindowFrameSet(partition Partition, expr parser.AnalyticClause, view *View) []WindowFrame {
 	var singleFrameSet = func(partition Partition) []WindowFrame {
 		indices := make([]int, len(partition))
 		for i, idx := range partition {
@@ -272,8 +341,6 @@ func WindowFrameSet(partition Partition, expr parser.AnalyticClause) []WindowFra
 		return singleFrameSet(partition)
 	}
 
-	frameSet := make([]WindowFrame, 0, length)
-
 	var windowClause parser.WindowingClause
 	if expr.WindowingClause == nil {
 		windowClause = parser.WindowingClause{
@@ -286,38 +353,89 @@ func WindowFrameSet(partition Partition, expr parser.AnalyticClause) []WindowFra
 		windowClause = expr.WindowingClause.(parser.WindowingClause)
 	}
 	frameLow := windowClause.FrameLow.(parser.WindowFramePosition)
+	frameHigh := parser.WindowFramePosition{Direction: parser.CURRENT}
+	if windowClause.FrameHigh != nil {
+		frameHigh = windowClause.FrameHigh.(parser.WindowFramePosition)
+	}
+
+	exclusion := windowClause.Exclusion
+	if exclusion == parser.NO {
+		exclusion = 0
+	}
+
+	if exclusion == 0 && frameLow.Direction == parser.PRECEDING && frameLow.Unbounded && frameHigh.Direction == parser.FOLLOWING && frameHigh.Unbounded {
+		return singleFrameSet(partition)
+	}
 
-	if windowClause.FrameHigh == nil {
-		for current := 0; current < length; current++ {
-			frameSet = append(frameSet, WindowFrame{
-				Low:     frameIndex(current, length, frameLow),
-				High:    current,
-				Records: []int{partition[current]},
-			})
+	var groupOf []int
+	var groupStart []int
+	var groupEnd []int
+	if windowClause.Groups || exclusion == parser.GROUP || exclusion == parser.TIES {
+		groupOf = make([]int, 0, length)
+		for i, group := range perseCumulativeGroups(partition, view) {
+			groupStart = append(groupStart, len(groupOf))
+			for range group {
+				groupOf = append(groupOf, i)
+			}
+			groupEnd = append(groupEnd, len(groupOf)-1)
 		}
-	} else {
-		frameHigh := windowClause.FrameHigh.(parser.WindowFramePosition)
-		if frameLow.Direction == parser.PRECEDING && frameLow.Unbounded && frameHigh.Direction == parser.FOLLOWING && frameHigh.Unbounded {
-			return singleFrameSet(partition)
+	}
+
+	frameSet := make([]WindowFrame, 0, length)
+	for current := 0; current < length; current++ {
+		frame := WindowFrame{Records: []int{partition[current]}}
+
+		if windowClause.Groups {
+			low := frameIndex(groupOf[current], len(groupStart), frameLow)
+			high := frameIndex(groupOf[current], len(groupStart), frameHigh)
+			if low < 0 {
+				low = 0
+			}
+			if len(groupStart) <= high {
+				high = len(groupStart) - 1
+			}
+			if high < low {
+				frame.Low, frame.High = 0, -1
+			} else {
+				frame.Low, frame.High = groupStart[low], groupEnd[high]
+			}
+		} else {
+			frame.Low = frameIndex(current, length, frameLow)
+			frame.High = frameIndex(current, length, frameHigh)
 		}
 
-		for current := 0; current < length; current++ {
-			frameSet = append(frameSet, WindowFrame{
-				Low:     frameIndex(current, length, frameLow),
-				High:    frameIndex(current, length, frameHigh),
-				Records: []int{partition[current]},
-			})
+		switch exclusion {
+		case parser.CURRENT:
+			frame.Excludes = []int{current}
+		case parser.GROUP, parser.TIES:
+			for i := groupStart[groupOf[current]]; i <= groupEnd[groupOf[current]]; i++ {
+				if exclusion == parser.TIES && i == current {
+					continue
+				}
+				frame.Excludes = append(frame.Excludes, i)
+			}
 		}
+
+		frameSet = append(frameSet, frame)
 	}
 
 	return frameSet
 }
 
+func hasWindowFrameExclusion(frameSet []WindowFrame) bool {
+	for _, frame := range frameSet {
+		if 0 < len(frame.Excludes) {
+			return true
+		}
+	}
+	return false
+}
+
 func windowValues(ctx context.Context, filter *Filter, frame WindowFrame, partition Partition, expr parser.AnalyticFunction, valueCache map[int]value.Primary) ([]value.Primary, error) {
 	values := make([]value.Primary, 0, frame.High-frame.Low+1)
 
 	for i := frame.Low; i <= frame.High; i++ {
-		if i < 0 || len(partition) <= i {
+		if i < 0 || len(partition) <= i || frame.IsExcluded(i) {
 			continue
 		}
 
@@ -459,7 +577,7 @@ func (fn PercentRank) Execute(ctx context.Context, filter *Filter, partition Par
 	denom := float64(len(partition) - 1)
 	cumulative := float64(0)
 	for _, group := range groups {
-		var dist float64 = 1
+		var dist float64 = 0
 		if 0 < denom {
 			dist = cumulative / denom
 		}
@@ -475,14 +593,16 @@ func (fn PercentRank) Execute(ctx context.Context, filter *Filter, partition Par
 }
 
 func perseCumulativeGroups(partition Partition, view *View) [][]int {
+	if view.sortValuesInEachRecord == nil {
+		return [][]int{partition}
+	}
+
 	groups := make([][]int, 0)
 	var currentRank SortValues
 	for _, idx := range partition {
-		if view.sortValuesInEachRecord == nil || !view.sortValuesInEachRecord[idx].EquivalentTo(currentRank) {
+		if !view.sortValuesInEachRecord[idx].EquivalentTo(currentRank) {
 			groups = append(groups, []int{idx})
-			if view.sortValuesInEachRecord != nil {
-				currentRank = view.sortValuesInEachRecord[idx]
-			}
+			currentRank = view.sortValuesInEachRecord[idx]
 		} else {
 			groups[len(groups)-1] = append(groups[len(groups)-1], idx)
 		}
@@ -596,7 +716,7 @@ func (fn NthValue) Execute(ctx context.Context, filter *Filter, partition Partit
 }
 
 func setNthValue(ctx context.Context, filter *Filter, partition Partition, expr parser.AnalyticFunction, n int) (map[int]value.Primary, error) {
-	frameSet := WindowFrameSet(partition, expr.AnalyticClause)
+	frameSet := WindowFrameSet(partition, expr.AnalyticClause, filter.records[0].view)
 	list := make(map[int]value.Primary, len(partition))
 
 	valueCache := make(map[int]value.Primary, len(partition))
@@ -606,7 +726,7 @@ func setNthValue(ctx context.Context, filter *Filter, partition Partition, expr
 		count := 0
 
 		for i := frame.Low; i <= frame.High; i++ {
-			if i < 0 || len(partition) <= i {
+			if i < 0 || len(partition) <= i || frame.IsExcluded(i) {
 				continue
 			}
 
@@ -793,3 +913,36 @@ func (fn AnalyticJsonAgg) Execute(ctx context.Context, filter *Filter, partition
 
 	return list, nil
 }
+
+type AnalyticArrayAgg struct{}
+
+func (fn AnalyticArrayAgg) CheckArgsLen(expr parser.AnalyticFunction) error {
+	return CheckArgsLen(expr, []int{1})
+}
+
+func (fn AnalyticArrayAgg) Execute(ctx context.Context, filter *Filter, partition Partition, expr parser.AnalyticFunction) (map[int]value.Primary, error) {
+	argsFilter := filter.CreateNode()
+	argsFilter.records = nil
+
+	values := make([]value.Primary, len(partition))
+	for i, idx := range partition {
+		filter.records[0].recordIndex = idx
+		val, e := filter.Evaluate(ctx, expr.Args[0])
+		if e != nil {
+			return nil, e
+		}
+		values[i] = val
+	}
+	if expr.IsDistinct() {
+		values = Distinguish(values, filter.tx.Flags)
+	}
+
+	val := ArrayAgg(values)
+
+	list := make(map[int]value.Primary, len(partition))
+	for _, idx := range partition {
+		list[idx] = val
+	}
+
+	return list, nil
+}
@@ -7,6 +7,8 @@ import (
 
 	"github.com/mithrandie/csvq/lib/parser"
 	"github.com/mithrandie/csvq/lib/value"
+
+	txjson "github.com/mithrandie/go-text/json"
 )
 
 var analyzeTests = []struct {
@@ -422,6 +424,304 @@ var analyzeTests = []struct {
 			Tx: TestTx,
 		},
 	},
+	{
+		Name: "Analyze AggregateFunction with Sliding Windowing Clause",
+		View: &View{
+			Header: NewHeader("table1", []string{"column1", "column2"}),
+			RecordSet: []Record{
+				NewRecord([]value.Primary{
+					value.NewString("a"),
+					value.NewInteger(1),
+				}),
+				NewRecord([]value.Primary{
+					value.NewString("a"),
+					value.NewInteger(2),
+				}),
+				NewRecord([]value.Primary{
+					value.NewString("b"),
+					value.NewInteger(1),
+				}),
+				NewRecord([]value.Primary{
+					value.NewString("b"),
+					value.NewNull(),
+				}),
+				NewRecord([]value.Primary{
+					value.NewString("b"),
+					value.NewInteger(4),
+				}),
+			},
+			Filter: NewFilter(TestTx),
+			Tx:     TestTx,
+		},
+		Function: parser.AnalyticFunction{
+			Name: "avg",
+			Args: []parser.QueryExpression{
+				parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+			},
+			AnalyticClause: parser.AnalyticClause{
+				PartitionClause: parser.PartitionClause{
+					Values: []parser.QueryExpression{
+						parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+					},
+				},
+				OrderByClause: parser.OrderByClause{
+					Items: []parser.QueryExpression{
+						parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+					},
+				},
+				WindowingClause: parser.WindowingClause{
+					FrameLow: parser.WindowFramePosition{
+						Direction: parser.PRECEDING,
+						Offset:    1,
+					},
+					FrameHigh: parser.WindowFramePosition{
+						Direction: parser.FOLLOWING,
+						Offset:    1,
+					},
+				},
+			},
+		},
+		PartitionIndices: []int{0},
+		Result: &View{
+			Header: NewHeader("table1", []string{"column1", "column2"}),
+			RecordSet: []Record{
+				NewRecord([]value.Primary{
+					value.NewString("a"),
+					value.NewInteger(1),
+					value.NewFloat(1.5),
+				}),
+				NewRecord([]value.Primary{
+					value.NewString("a"),
+					value.NewInteger(2),
+					value.NewFloat(1.5),
+				}),
+				NewRecord([]value.Primary{
+					value.NewString("b"),
+					value.NewInteger(1),
+					value.NewInteger(1),
+				}),
+				NewRecord([]value.Primary{
+					value.NewString("b"),
+					value.NewNull(),
+					value.NewFloat(2.5),
+				}),
+				NewRecord([]value.Primary{
+					value.NewString("b"),
+					value.NewInteger(4),
+					value.NewInteger(4),
+				}),
+			},
+			Filter: NewFilter(TestTx),
+			sortValuesInEachCell: [][]*SortValue{
+				{NewSortValue(value.NewString("a"), TestTx.Flags), nil},
+				{NewSortValue(value.NewString("a"), TestTx.Flags), nil},
+				{NewSortValue(value.NewString("b"), TestTx.Flags), nil},
+				{NewSortValue(value.NewString("b"), TestTx.Flags), nil},
+				{NewSortValue(value.NewString("b"), TestTx.Flags), nil},
+			},
+			Tx: TestTx,
+		},
+	},
+	{
+		Name: "Analyze AggregateFunction with Sliding Windowing Clause Calculating Maximum Values",
+		View: &View{
+			Header: NewHeader("table1", []string{"column1", "column2"}),
+			RecordSet: []Record{
+				NewRecord([]value.Primary{
+					value.NewString("a"),
+					value.NewInteger(1),
+				}),
+				NewRecord([]value.Primary{
+					value.NewString("a"),
+					value.NewInteger(2),
+				}),
+				NewRecord([]value.Primary{
+					value.NewString("b"),
+					value.NewInteger(1),
+				}),
+				NewRecord([]value.Primary{
+					value.NewString("b"),
+					value.NewNull(),
+				}),
+				NewRecord([]value.Primary{
+					value.NewString("b"),
+					value.NewInteger(4),
+				}),
+			},
+			Filter: NewFilter(TestTx),
+			Tx:     TestTx,
+		},
+		Function: parser.AnalyticFunction{
+			Name: "max",
+			Args: []parser.QueryExpression{
+				parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+			},
+			AnalyticClause: parser.AnalyticClause{
+				PartitionClause: parser.PartitionClause{
+					Values: []parser.QueryExpression{
+						parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+					},
+				},
+				OrderByClause: parser.OrderByClause{
+					Items: []parser.QueryExpression{
+						parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+					},
+				},
+				WindowingClause: parser.WindowingClause{
+					FrameLow: parser.WindowFramePosition{
+						Direction: parser.PRECEDING,
+						Offset:    1,
+					},
+					FrameHigh: parser.WindowFramePosition{
+						Direction: parser.CURRENT,
+					},
+				},
+			},
+		},
+		PartitionIndices: []int{0},
+		Result: &View{
+			Header: NewHeader("table1", []string{"column1", "column2"}),
+			RecordSet: []Record{
+				NewRecord([]value.Primary{
+					value.NewString("a"),
+					value.NewInteger(1),
+					value.NewInteger(1),
+				}),
+				NewRecord([]value.Primary{
+					value.NewString("a"),
+					value.NewInteger(2),
+					value.NewInteger(2),
+				}),
+				NewRecord([]value.Primary{
+					value.NewString("b"),
+					value.NewInteger(1),
+					value.NewInteger(1),
+				}),
+				NewRecord([]value.Primary{
+					value.NewString("b"),
+					value.NewNull(),
+					value.NewInteger(1),
+				}),
+				NewRecord([]value.Primary{
+					value.NewString("b"),
+					value.NewInteger(4),
+					value.NewInteger(4),
+				}),
+			},
+			Filter: NewFilter(TestTx),
+			sortValuesInEachCell: [][]*SortValue{
+				{NewSortValue(value.NewString("a"), TestTx.Flags), nil},
+				{NewSortValue(value.NewString("a"), TestTx.Flags), nil},
+				{NewSortValue(value.NewString("b"), TestTx.Flags), nil},
+				{NewSortValue(value.NewString("b"), TestTx.Flags), nil},
+				{NewSortValue(value.NewString("b"), TestTx.Flags), nil},
+			},
+			Tx: TestTx,
+		},
+	},
+	{
+		Name: "Analyze BivariateAggregateFunction",
+		View: &View{
+			Header: NewHeader("table1", []string{"column1", "column2", "column3"}),
+			RecordSet: []Record{
+				NewRecord([]value.Primary{
+					value.NewString("a"),
+					value.NewInteger(1),
+					value.NewInteger(3),
+				}),
+				NewRecord([]value.Primary{
+					value.NewString("a"),
+					value.NewInteger(2),
+					value.NewInteger(5),
+				}),
+				NewRecord([]value.Primary{
+					value.NewString("b"),
+					value.NewInteger(1),
+					value.NewInteger(2),
+				}),
+				NewRecord([]value.Primary{
+					value.NewString("b"),
+					value.NewInteger(3),
+					value.NewInteger(2),
+				}),
+			},
+			Filter: NewFilter(TestTx),
+			Tx:     TestTx,
+		},
+		Function: parser.AnalyticFunction{
+			Name: "regr_slope",
+			Args: []parser.QueryExpression{
+				parser.FieldReference{Column: parser.Identifier{Literal: "column3"}},
+				parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+			},
+			AnalyticClause: parser.AnalyticClause{
+				PartitionClause: parser.PartitionClause{
+					Values: []parser.QueryExpression{
+						parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+					},
+				},
+			},
+		},
+		PartitionIndices: []int{0},
+		Result: &View{
+			Header: NewHeader("table1", []string{"column1", "column2", "column3"}),
+			RecordSet: []Record{
+				NewRecord([]value.Primary{
+					value.NewString("a"),
+					value.NewInteger(1),
+					value.NewInteger(3),
+					value.NewInteger(2),
+				}),
+				NewRecord([]value.Primary{
+					value.NewString("a"),
+					value.NewInteger(2),
+					value.NewInteger(5),
+					value.NewInteger(2),
+				}),
+				NewRecord([]value.Primary{
+					value.NewString("b"),
+					value.NewInteger(1),
+					value.NewInteger(2),
+					value.NewInteger(0),
+				}),
+				NewRecord([]value.Primary{
+					value.NewString("b"),
+					value.NewInteger(3),
+					value.NewInteger(2),
+					value.NewInteger(0),
+				}),
+			},
+			Filter: NewFilter(TestTx),
+			sortValuesInEachCell: [][]*SortValue{
+				{NewSortValue(value.NewString("a"), TestTx.Flags), nil, nil},
+				{NewSortValue(value.NewString("a"), TestTx.Flags), nil, nil},
+				{NewSortValue(value.NewString("b"), TestTx.Flags), nil, nil},
+				{NewSortValue(value.NewString("b"), TestTx.Flags), nil, nil},
+			},
+			Tx: TestTx,
+		},
+	},
+	{
+		Name: "Analyze BivariateAggregateFunction Argument Length Error",
+		View: &View{
+			Header: NewHeader("table1", []string{"column1", "column2"}),
+			RecordSet: []Record{
+				NewRecord([]value.Primary{
+					value.NewString("a"),
+					value.NewInteger(1),
+				}),
+			},
+			Filter: NewFilter(TestTx),
+			Tx:     TestTx,
+		},
+		Function: parser.AnalyticFunction{
+			Name: "corr",
+			Args: []parser.QueryExpression{
+				parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+			},
+		},
+		Error: "function corr takes exactly 2 arguments",
+	},
 	{
 		Name: "Analyze AggregateFunction With Distinct",
 		View: &View{
@@ -1254,6 +1554,127 @@ var analyzeTests = []struct {
 	},
 }
 
+var windowFrameSetTests = []struct {
+	Name            string
+	Partition       Partition
+	SortValues      []SortValues
+	WindowingClause parser.WindowingClause
+	Result          []WindowFrame
+}{
+	{
+		Name:      "WindowFrameSet Groups",
+		Partition: Partition{0, 1, 2, 3},
+		SortValues: []SortValues{
+			{NewSortValue(value.NewInteger(1), TestTx.Flags)},
+			{NewSortValue(value.NewInteger(2), TestTx.Flags)},
+			{NewSortValue(value.NewInteger(2), TestTx.Flags)},
+			{NewSortValue(value.NewInteger(3), TestTx.Flags)},
+		},
+		WindowingClause: parser.WindowingClause{
+			Groups: true,
+			FrameLow: parser.WindowFramePosition{
+				Direction: parser.PRECEDING,
+				Offset:    1,
+			},
+			FrameHigh: parser.WindowFramePosition{
+				Direction: parser.CURRENT,
+			},
+		},
+		Result: []WindowFrame{
+			{Low: 0, High: 0, Records: []int{0}},
+			{Low: 0, High: 2, Records: []int{1}},
+			{Low: 0, High: 2, Records: []int{2}},
+			{Low: 1, High: 3, Records: []int{3}},
+		},
+	},
+	{
+		Name:      "WindowFrameSet Groups Out Of Range",
+		Partition: Partition{0, 1, 2},
+		SortValues: []SortValues{
+			{NewSortValue(value.NewInteger(1), TestTx.Flags)},
+			{NewSortValue(value.NewInteger(1), TestTx.Flags)},
+			{NewSortValue(value.NewInteger(2), TestTx.Flags)},
+		},
+		WindowingClause: parser.WindowingClause{
+			Groups: true,
+			FrameLow: parser.WindowFramePosition{
+				Direction: parser.FOLLOWING,
+				Offset:    1,
+			},
+			FrameHigh: parser.WindowFramePosition{
+				Direction: parser.FOLLOWING,
+				Unbounded: true,
+			},
+		},
+		Result: []WindowFrame{
+			{Low: 2, High: 2, Records: []int{0}},
+			{Low: 2, High: 2, Records: []int{1}},
+			{Low: 0, High: -1, Records: []int{2}},
+		},
+	},
+	{
+		Name:      "WindowFrameSet Exclude Ties",
+		Partition: Partition{0, 1, 2},
+		SortValues: []SortValues{
+			{NewSortValue(value.NewInteger(1), TestTx.Flags)},
+			{NewSortValue(value.NewInteger(1), TestTx.Flags)},
+			{NewSortValue(value.NewInteger(2), TestTx.Flags)},
+		},
+		WindowingClause: parser.WindowingClause{
+			FrameLow: parser.WindowFramePosition{
+				Direction: parser.PRECEDING,
+				Unbounded: true,
+			},
+			FrameHigh: parser.WindowFramePosition{
+				Direction: parser.FOLLOWING,
+				Unbounded: true,
+			},
+			Exclusion: parser.TIES,
+		},
+		Result: []WindowFrame{
+			{Low: 0, High: 2, Records: []int{0}, Excludes: []int{1}},
+			{Low: 0, High: 2, Records: []int{1}, Excludes: []int{0}},
+			{Low: 0, High: 2, Records: []int{2}},
+		},
+	},
+	{
+		Name:      "WindowFrameSet Exclude Current Row and Group",
+		Partition: Partition{0, 1, 2},
+		SortValues: []SortValues{
+			{NewSortValue(value.NewInteger(1), TestTx.Flags)},
+			{NewSortValue(value.NewInteger(1), TestTx.Flags)},
+			{NewSortValue(value.NewInteger(2), TestTx.Flags)},
+		},
+		WindowingClause: parser.WindowingClause{
+			FrameLow: parser.WindowFramePosition{
+				Direction: parser.PRECEDING,
+				Offset:    1,
+			},
+			Exclusion: parser.GROUP,
+		},
+		Result: []WindowFrame{
+			{Low: -1, High: 0, Records: []int{0}, Excludes: []int{0, 1}},
+			{Low: 0, High: 1, Records: []int{1}, Excludes: []int{0, 1}},
+			{Low: 1, High: 2, Records: []int{2}, Excludes: []int{2}},
+		},
+	},
+}
+
+func TestWindowFrameSet(t *testing.T) {
+	for _, v := range windowFrameSetTests {
+		view := &View{sortValuesInEachRecord: v.SortValues}
+		expr := parser.AnalyticClause{
+			OrderByClause:   parser.OrderByClause{},
+			WindowingClause: v.WindowingClause,
+		}
+
+		result := WindowFrameSet(v.Partition, expr, view)
+		if !reflect.DeepEqual(result, v.Result) {
+			t.Errorf("%s: result = %v, want %v", v.Name, result, v.Result)
+		}
+	}
+}
+
 func TestAnalyze(t *testing.T) {
 	defer initFlag(TestTx.Flags)
 
@@ -1621,6 +2042,31 @@ var percentRankExecuteTests = []analyticFunctionExecuteTests{
 			5: value.NewFloat(1),
 		},
 	},
+	{
+		Name:  "PercentRank Execute Without Order By",
+		Items: Partition{2, 4, 1},
+		Function: parser.AnalyticFunction{
+			Name: "percent_rank",
+		},
+		Result: map[int]value.Primary{
+			2: value.NewFloat(0),
+			4: value.NewFloat(0),
+			1: value.NewFloat(0),
+		},
+	},
+	{
+		Name:  "PercentRank Execute Single Record",
+		Items: Partition{2},
+		SortValues: map[int]SortValues{
+			2: {NewSortValue(value.NewString("1"), TestTx.Flags)},
+		},
+		Function: parser.AnalyticFunction{
+			Name: "percent_rank",
+		},
+		Result: map[int]value.Primary{
+			2: value.NewFloat(0),
+		},
+	},
 }
 
 func TestPercentRank_Execute(t *testing.T) {
@@ -2402,11 +2848,11 @@ var analyticJsonAggExecuteTests = []analyticFunctionExecuteTests{
 			},
 		},
 		Result: map[int]value.Primary{
-			0: value.NewString("[100,200,null,200,300]"),
-			1: value.NewString("[100,200,null,200,300]"),
-			2: value.NewString("[100,200,null,200,300]"),
-			3: value.NewString("[100,200,null,200,300]"),
-			4: value.NewString("[100,200,null,200,300]"),
+			0: value.NewJson(txjson.Array{txjson.Integer(100), txjson.Integer(200), txjson.Null{}, txjson.Integer(200), txjson.Integer(300)}),
+			1: value.NewJson(txjson.Array{txjson.Integer(100), txjson.Integer(200), txjson.Null{}, txjson.Integer(200), txjson.Integer(300)}),
+			2: value.NewJson(txjson.Array{txjson.Integer(100), txjson.Integer(200), txjson.Null{}, txjson.Integer(200), txjson.Integer(300)}),
+			3: value.NewJson(txjson.Array{txjson.Integer(100), txjson.Integer(200), txjson.Null{}, txjson.Integer(200), txjson.Integer(300)}),
+			4: value.NewJson(txjson.Array{txjson.Integer(100), txjson.Integer(200), txjson.Null{}, txjson.Integer(200), txjson.Integer(300)}),
 		},
 	},
 	{
@@ -2420,11 +2866,11 @@ var analyticJsonAggExecuteTests = []analyticFunctionExecuteTests{
 			},
 		},
 		Result: map[int]value.Primary{
-			0: value.NewString("[100,200,null,300]"),
-			1: value.NewString("[100,200,null,300]"),
-			2: value.NewString("[100,200,null,300]"),
-			3: value.NewString("[100,200,null,300]"),
-			4: value.NewString("[100,200,null,300]"),
+			0: value.NewJson(txjson.Array{txjson.Integer(100), txjson.Integer(200), txjson.Null{}, txjson.Integer(300)}),
+			1: value.NewJson(txjson.Array{txjson.Integer(100), txjson.Integer(200), txjson.Null{}, txjson.Integer(300)}),
+			2: value.NewJson(txjson.Array{txjson.Integer(100), txjson.Integer(200), txjson.Null{}, txjson.Integer(300)}),
+			3: value.NewJson(txjson.Array{txjson.Integer(100), txjson.Integer(200), txjson.Null{}, txjson.Integer(300)}),
+			4: value.NewJson(txjson.Array{txjson.Integer(100), txjson.Integer(200), txjson.Null{}, txjson.Integer(300)}),
 		},
 	},
 	{
@@ -2443,3 +2889,28 @@ var analyticJsonAggExecuteTests = []analyticFunctionExecuteTests{
 func TestAnalyticJsonAgg_Execute(t *testing.T) {
 	testAnalyticFunctionExecute(t, AnalyticJsonAgg{}, analyticJsonAggExecuteTests)
 }
+
+var analyticArrayAggExecuteTests = []analyticFunctionExecuteTests{
+	{
+		Name:  "AnalyticArrayAgg Execute",
+		Items: Partition{0, 1, 2, 3, 4},
+		Function: parser.AnalyticFunction{
+			Name:     "array_agg",
+			Distinct: parser.Token{Token: parser.DISTINCT, Literal: "distinct"},
+			Args: []parser.QueryExpression{
+				parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+			},
+		},
+		Result: map[int]value.Primary{
+			0: value.NewArray([]value.Primary{value.NewInteger(100), value.NewInteger(200), value.NewNull(), value.NewInteger(300)}),
+			1: value.NewArray([]value.Primary{value.NewInteger(100), value.NewInteger(200), value.NewNull(), value.NewInteger(300)}),
+			2: value.NewArray([]value.Primary{value.NewInteger(100), value.NewInteger(200), value.NewNull(), value.NewInteger(300)}),
+			3: value.NewArray([]value.Primary{value.NewInteger(100), value.NewInteger(200), value.NewNull(), value.NewInteger(300)}),
+			4: value.NewArray([]value.Primary{value.NewInteger(100), value.NewInteger(200), value.NewNull(), value.NewInteger(300)}),
+		},
+	},
+}
+
+func TestAnalyticArrayAgg_Execute(t *testing.T) {
+	testAnalyticFunctionExecute(t, AnalyticArrayAgg{}, analyticArrayAggExecuteTests)
+}
@@ -9,7 +9,7 @@ import (
 func Calculate(p1 value.Primary, p2 value.Primary, operator int) value.Primary {
 	if operator != '/' {
 		if pi1 := value.ToInteger(p1); !value.IsNull(pi1) {
-			if pi2 := value.ToInteger(p2); !value.IsNull(pi2) {
+			if pi2 := value.ToInteger(p2); !value.IsNull(pi2) && !(operator == '%' && pi2.(value.Integer).Raw() == 0) {
 				return calculateInteger(pi1.(value.Integer).Raw(), pi2.(value.Integer).Raw(), operator)
 			}
 		}
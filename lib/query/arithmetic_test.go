@@ -1,6 +1,7 @@
 package query
 
 import (
+	"math"
 	"reflect"
 	"testing"
 
@@ -83,3 +84,10 @@ func TestCalculate(t *testing.T) {
 		}
 	}
 }
+
+func TestCalculateModuloByZero(t *testing.T) {
+	r := Calculate(value.NewInteger(9), value.NewInteger(0), '%')
+	if f, ok := r.(value.Float); !ok || !math.IsNaN(f.Raw()) {
+		t.Errorf("result = %s, want NaN for (9 %% 0)", r)
+	}
+}
@@ -0,0 +1,194 @@
+package query
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mithrandie/csvq/lib/cmd"
+	"github.com/mithrandie/csvq/lib/json"
+	"github.com/mithrandie/csvq/lib/parser"
+	"github.com/mithrandie/csvq/lib/value"
+
+	txjson "github.com/mithrandie/go-text/json"
+)
+
+const (
+	AuditInsert = "INSERT"
+	AuditUpdate = "UPDATE"
+	AuditDelete = "DELETE"
+)
+
+// AuditEntry is a change of a record in a file recorded in the audit log.
+type AuditEntry struct {
+	Time      time.Time
+	Source    string
+	Line      int
+	Statement string
+
+	Path      string
+	Operation string
+	Fields    []string
+	Values    []value.Primary
+
+	// Values of the record before the change, set only for updated records
+	Previous []value.Primary
+}
+
+// Encode returns the entry as a JSON object in a line.
+func (e AuditEntry) Encode() string {
+	obj := txjson.NewObject(8)
+	obj.Add("time", txjson.String(e.Time.Format(time.RFC3339Nano)))
+	if 0 < len(e.Source) {
+		obj.Add("source", txjson.String(e.Source))
+	} else {
+		obj.Add("source", txjson.Null{})
+	}
+	if 0 < e.Line {
+		obj.Add("line", txjson.Integer(e.Line))
+	} else {
+		obj.Add("line", txjson.Null{})
+	}
+	obj.Add("statement", txjson.String(e.Statement))
+	obj.Add("file", txjson.String(e.Path))
+	obj.Add("operation", txjson.String(e.Operation))
+	obj.Add("record", auditRecord(e.Fields, e.Values))
+	if e.Previous != nil {
+		obj.Add("previous", auditRecord(e.Fields, e.Previous))
+	}
+
+	return txjson.NewEncoder().Encode(obj)
+}
+
+func auditRecord(fields []string, values []value.Primary) txjson.Object {
+	obj := txjson.NewObject(len(fields))
+	for i, f := range fields {
+		obj.Add(f, json.ParseValueToStructure(values[i]))
+	}
+	return obj
+}
+
+// AuditLog is the list of the changes of records not committed yet.
+type AuditLog []AuditEntry
+
+func (l *AuditLog) Add(entries ...AuditEntry) {
+	*l = append(*l, entries...)
+}
+
+// Extract removes the entries of the file from the list, and returns them.
+func (l *AuditLog) Extract(path string) []AuditEntry {
+	upath := strings.ToUpper(path)
+
+	extracted := make([]AuditEntry, 0, 10)
+	entries := (*l)[:0]
+	for _, e := range *l {
+		if strings.ToUpper(e.Path) == upath {
+			extracted = append(extracted, e)
+		} else {
+			entries = append(entries, e)
+		}
+	}
+	*l = entries
+	return extracted
+}
+
+func (l *AuditLog) Dispose(path string) {
+	_ = l.Extract(path)
+}
+
+func (l *AuditLog) Clean() {
+	*l = (*l)[:0]
+}
+
+// WriteAuditLog appends the entries to the file in JSON Lines format.
+// The entries are written at once so that the lines written by other processes are not interleaved.
+func WriteAuditLog(fpath string, entries []AuditEntry) error {
+	if len(entries) < 1 {
+		return nil
+	}
+
+	buf := &bytes.Buffer{}
+	for _, e := range entries {
+		buf.WriteString(e.Encode())
+		buf.WriteByte('\n')
+	}
+
+	fp, err := os.OpenFile(fpath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err = fp.Write(buf.Bytes()); err != nil {
+		_ = fp.Close()
+		return err
+	}
+	return fp.Close()
+}
+
+// auditValues returns the values of the table columns in the record.
+func auditValues(header Header, record Record) []value.Primary {
+	values := make([]value.Primary, 0, len(header))
+	for i, f := range header {
+		if f.IsFromTable {
+			values = append(values, record[i].Value())
+		}
+	}
+	return values
+}
+
+// auditRecordReplaced reports whether any of the cells in the record have been replaced
+// since the previous record was copied.
+func auditRecordReplaced(record Record, previous Record) bool {
+	for i := range record {
+		if &record[i][0] != &previous[i][0] {
+			return true
+		}
+	}
+	return false
+}
+
+// auditEnabled reports whether the changes of records are recorded in the audit log.
+func (tx *Transaction) auditEnabled() bool {
+	return 0 < len(tx.Flags.AuditLog)
+}
+
+// recordAudit records the changes of the records in the view made by the statement.
+// The previous values are specified for updated records.
+// Changes of temporary tables and encrypted files are not recorded.
+func (tx *Transaction) recordAudit(stmt parser.QueryExpression, view *View, operation string, records RecordSet, previous [][]value.Primary) {
+	if !tx.auditEnabled() || view.FileInfo.IsTemporary || view.FileInfo.Encrypted || len(records) < 1 {
+		return
+	}
+
+	entry := AuditEntry{
+		Time:      cmd.Now(),
+		Statement: stmt.String(),
+		Path:      view.FileInfo.Path,
+		Operation: operation,
+		Fields:    view.Header.TableColumnNames(),
+	}
+	if stmt.HasParseInfo() {
+		entry.Source = stmt.SourceFile()
+		entry.Line = stmt.Line()
+	}
+
+	for i, record := range records {
+		e := entry
+		e.Values = auditValues(view.Header, record)
+		if previous != nil {
+			e.Previous = previous[i]
+		}
+		tx.auditLog.Add(e)
+	}
+}
+
+// writeAuditLog writes the changes of the files to the audit log.
+func (tx *Transaction) writeAuditLog(expr parser.Expression, entries []AuditEntry) error {
+	if !tx.auditEnabled() {
+		return nil
+	}
+	if err := WriteAuditLog(tx.Flags.AuditLog, entries); err != nil {
+		return NewCommitError(expr, "writing audit log failed: "+err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package query
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mithrandie/csvq/lib/parser"
+	"github.com/mithrandie/csvq/lib/value"
+)
+
+var auditEntryEncodeTests = []struct {
+	Name   string
+	Entry  AuditEntry
+	Expect string
+}{
+	{
+		Name: "Insert",
+		Entry: AuditEntry{
+			Time:      time.Date(2012, 2, 3, 9, 18, 15, 0, time.UTC),
+			Source:    "/path/to/script.sql",
+			Line:      3,
+			Statement: "INSERT INTO t VALUES (1, 'a')",
+			Path:      "/path/to/t.csv",
+			Operation: AuditInsert,
+			Fields:    []string{"c1", "c2"},
+			Values:    []value.Primary{value.NewInteger(1), value.NewString("a")},
+		},
+		Expect: "{\"time\":\"2012-02-03T09:18:15Z\",\"source\":\"\\/path\\/to\\/script.sql\",\"line\":3," +
+			"\"statement\":\"INSERT INTO t VALUES (1, 'a')\",\"file\":\"\\/path\\/to\\/t.csv\"," +
+			"\"operation\":\"INSERT\",\"record\":{\"c1\":1,\"c2\":\"a\"}}",
+	},
+	{
+		Name: "Update without Source",
+		Entry: AuditEntry{
+			Time:      time.Date(2012, 2, 3, 9, 18, 15, 0, time.UTC),
+			Statement: "UPDATE t SET c2 = 'b'",
+			Path:      "/path/to/t.csv",
+			Operation: AuditUpdate,
+			Fields:    []string{"c1", "c2"},
+			Values:    []value.Primary{value.NewString("1"), value.NewString("b")},
+			Previous:  []value.Primary{value.NewString("1"), value.NewNull()},
+		},
+		Expect: "{\"time\":\"2012-02-03T09:18:15Z\",\"source\":null,\"line\":null," +
+			"\"statement\":\"UPDATE t SET c2 = 'b'\",\"file\":\"\\/path\\/to\\/t.csv\"," +
+			"\"operation\":\"UPDATE\",\"record\":{\"c1\":\"1\",\"c2\":\"b\"},\"previous\":{\"c1\":\"1\",\"c2\":null}}",
+	},
+}
+
+func TestAuditEntry_Encode(t *testing.T) {
+	for _, v := range auditEntryEncodeTests {
+		result := v.Entry.Encode()
+		if result != v.Expect {
+			t.Errorf("%s: result = %s, want %s", v.Name, result, v.Expect)
+		}
+	}
+}
+
+func TestAuditLog_Extract(t *testing.T) {
+	log := AuditLog{
+		{Path: "/path/to/t1.csv", Operation: AuditInsert},
+		{Path: "/path/to/t2.csv", Operation: AuditInsert},
+		{Path: "/path/to/t1.csv", Operation: AuditDelete},
+	}
+
+	extracted := log.Extract("/PATH/TO/T1.CSV")
+	if len(extracted) != 2 || extracted[0].Operation != AuditInsert || extracted[1].Operation != AuditDelete {
+		t.Errorf("extracted entries = %v, want the entries of t1.csv", extracted)
+	}
+	if len(log) != 1 || log[0].Path != "/path/to/t2.csv" {
+		t.Errorf("remaining entries = %v, want the entries of t2.csv", log)
+	}
+
+	log.Clean()
+	if len(log) != 0 {
+		t.Errorf("entries = %v, want no entry", log)
+	}
+}
+
+func TestTransaction_AuditLog(t *testing.T) {
+	fpath := GetTestFilePath("audit_log_test.csv")
+	logPath := GetTestFilePath("audit_log_test.jsonl")
+	defer func() {
+		_ = TestTx.Rollback(nil, nil)
+		_ = os.Remove(fpath)
+		_ = os.Remove(logPath)
+		initFlag(TestTx.Flags)
+	}()
+
+	if err := ioutil.WriteFile(fpath, []byte("c1,c2\n1,a\n2,b"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	TestTx.Flags.Repository = TestDir
+	TestTx.Flags.SetQuiet(true)
+	TestTx.Session.Stdout = NewDiscard()
+	if err := TestTx.Flags.SetAuditLog(logPath); err != nil {
+		t.Fatal(err)
+	}
+
+	statements, _, err := parser.Parse(""+
+		"INSERT INTO audit_log_test VALUES (3, 'c');"+
+		"UPDATE audit_log_test SET c2 = 'x' WHERE c1 = 1;"+
+		"DELETE FROM audit_log_test WHERE c1 = 2;"+
+		"COMMIT;"+
+		"DELETE FROM audit_log_test;"+
+		"ROLLBACK;", "", nil, false)
+	if err != nil {
+		t.Fatalf("unexpected parse error %q", err)
+	}
+
+	proc := NewProcessor(TestTx)
+	if _, err = proc.Execute(context.Background(), statements); err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	b, err := ioutil.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	ts := NowForTest.Format(time.RFC3339Nano)
+	expect := []string{
+		"{\"time\":\"" + ts + "\",\"source\":null,\"line\":1,\"statement\":\"INSERT INTO audit_log_test VALUES (3, 'c')\"," +
+			"\"file\":\"" + strings.Replace(fpath, "/", "\\/", -1) + "\",\"operation\":\"INSERT\",\"record\":{\"c1\":3,\"c2\":\"c\"}}",
+		"{\"time\":\"" + ts + "\",\"source\":null,\"line\":1,\"statement\":\"UPDATE audit_log_test SET c2 = 'x' WHERE c1 = 1\"," +
+			"\"file\":\"" + strings.Replace(fpath, "/", "\\/", -1) + "\",\"operation\":\"UPDATE\",\"record\":{\"c1\":\"1\",\"c2\":\"x\"},\"previous\":{\"c1\":\"1\",\"c2\":\"a\"}}",
+		"{\"time\":\"" + ts + "\",\"source\":null,\"line\":1,\"statement\":\"DELETE FROM audit_log_test WHERE c1 = 2\"," +
+			"\"file\":\"" + strings.Replace(fpath, "/", "\\/", -1) + "\",\"operation\":\"DELETE\",\"record\":{\"c1\":\"2\",\"c2\":\"b\"}}",
+	}
+	if string(b) != strings.Join(expect, "\n")+"\n" {
+		t.Errorf("audit log = %s, want %s", string(b), strings.Join(expect, "\n")+"\n")
+	}
+	if 0 < len(TestTx.auditLog) {
+		t.Errorf("audit entries = %v, want no entry after rollback", TestTx.auditLog)
+	}
+}
@@ -208,14 +208,16 @@ func SetFlag(ctx context.Context, filter *Filter, expr parser.SetFlag) error {
 	switch strings.ToUpper(expr.Name) {
 	case cmd.RepositoryFlag, cmd.TimezoneFlag, cmd.DatetimeFormatFlag,
 		cmd.ImportFormatFlag, cmd.DelimiterFlag, cmd.DelimiterPositionsFlag, cmd.JsonQueryFlag, cmd.EncodingFlag,
-		cmd.WriteEncodingFlag, cmd.FormatFlag, cmd.WriteDelimiterFlag, cmd.WriteDelimiterPositionsFlag, cmd.LineBreakFlag, cmd.JsonEscape:
+		cmd.WriteEncodingFlag, cmd.FormatFlag, cmd.WriteDelimiterFlag, cmd.WriteDelimiterPositionsFlag, cmd.LineBreakFlag, cmd.JsonEscape,
+		cmd.BackupExtensionFlag, cmd.BackupDirFlag, cmd.AuditLogFlag, cmd.EncryptionKeyFileFlag, cmd.OnCommitFlag, cmd.CoordinatorTokenFlag, cmd.LockBackoffFlag:
 		p = value.ToString(p)
-	case cmd.NoHeaderFlag, cmd.WithoutNullFlag, cmd.WithoutHeaderFlag, cmd.EncloseAll, cmd.PrettyPrintFlag,
-		cmd.EastAsianEncodingFlag, cmd.CountDiacriticalSignFlag, cmd.CountFormatCodeFlag, cmd.ColorFlag, cmd.QuietFlag, cmd.StatsFlag:
+	case cmd.CaseSensitiveFlag,
+		cmd.NoHeaderFlag, cmd.WithoutNullFlag, cmd.WithoutHeaderFlag, cmd.EncloseAll, cmd.PrettyPrintFlag,
+		cmd.EastAsianEncodingFlag, cmd.CountDiacriticalSignFlag, cmd.CountFormatCodeFlag, cmd.ColorFlag, cmd.QuietFlag, cmd.MmapFlag, cmd.ColumnarFlag, cmd.StatisticsCacheFlag, cmd.ResultCacheFlag, cmd.ProgressFlag, cmd.ReadOnlyFlag, cmd.DryRunFlag, cmd.StatsFlag:
 		p = value.ToBoolean(p)
 	case cmd.WaitTimeoutFlag:
 		p = value.ToFloat(p)
-	case cmd.CPUFlag:
+	case cmd.LockRetryLimitFlag, cmd.ParticipantsFlag, cmd.CPUFlag, cmd.LimitRecursionFlag, cmd.RandomSeedFlag, cmd.SortBufferSizeFlag, cmd.MaxMemoryFlag:
 		p = value.ToInteger(p)
 	default:
 		return NewInvalidFlagNameError(expr, expr.Name)
@@ -231,8 +233,17 @@ func SetFlag(ctx context.Context, filter *Filter, expr parser.SetFlag) error {
 		err = filter.tx.Flags.SetLocation(p.(value.String).Raw())
 	case cmd.DatetimeFormatFlag:
 		filter.tx.Flags.SetDatetimeFormat(p.(value.String).Raw())
+	case cmd.CaseSensitiveFlag:
+		filter.tx.Flags.SetCaseSensitive(p.(value.Boolean).Raw())
 	case cmd.WaitTimeoutFlag:
 		filter.tx.UpdateWaitTimeout(p.(value.Float).Raw(), file.DefaultRetryDelay)
+	case cmd.LockRetryLimitFlag:
+		filter.tx.Flags.SetLockRetryLimit(int(p.(value.Integer).Raw()))
+		filter.tx.UpdateRetryPolicy()
+	case cmd.LockBackoffFlag:
+		if err = filter.tx.Flags.SetLockBackoff(p.(value.String).Raw()); err == nil {
+			filter.tx.UpdateRetryPolicy()
+		}
 	case cmd.ImportFormatFlag:
 		err = filter.tx.Flags.SetImportFormat(p.(value.String).Raw())
 	case cmd.DelimiterFlag:
@@ -277,6 +288,42 @@ func SetFlag(ctx context.Context, filter *Filter, expr parser.SetFlag) error {
 		filter.tx.Flags.SetQuiet(p.(value.Boolean).Raw())
 	case cmd.CPUFlag:
 		filter.tx.Flags.SetCPU(int(p.(value.Integer).Raw()))
+	case cmd.LimitRecursionFlag:
+		filter.tx.Flags.SetLimitRecursion(int(p.(value.Integer).Raw()))
+	case cmd.RandomSeedFlag:
+		filter.tx.Flags.SetRandomSeed(p.(value.Integer).Raw())
+	case cmd.SortBufferSizeFlag:
+		filter.tx.Flags.SetSortBufferSize(int(p.(value.Integer).Raw()))
+	case cmd.MaxMemoryFlag:
+		filter.tx.Flags.SetMaxMemory(int(p.(value.Integer).Raw()))
+	case cmd.MmapFlag:
+		filter.tx.Flags.SetMmap(p.(value.Boolean).Raw())
+	case cmd.ColumnarFlag:
+		filter.tx.Flags.SetColumnar(p.(value.Boolean).Raw())
+	case cmd.StatisticsCacheFlag:
+		filter.tx.Flags.SetStatisticsCache(p.(value.Boolean).Raw())
+	case cmd.ResultCacheFlag:
+		filter.tx.Flags.SetResultCache(p.(value.Boolean).Raw())
+	case cmd.ProgressFlag:
+		filter.tx.Flags.SetProgress(p.(value.Boolean).Raw())
+	case cmd.BackupExtensionFlag:
+		err = filter.tx.Flags.SetBackupExtension(p.(value.String).Raw())
+	case cmd.BackupDirFlag:
+		err = filter.tx.Flags.SetBackupDir(p.(value.String).Raw())
+	case cmd.AuditLogFlag:
+		err = filter.tx.Flags.SetAuditLog(p.(value.String).Raw())
+	case cmd.EncryptionKeyFileFlag:
+		err = filter.tx.Flags.SetEncryptionKeyFile(p.(value.String).Raw())
+	case cmd.OnCommitFlag:
+		err = filter.tx.Flags.SetOnCommit(p.(value.String).Raw())
+	case cmd.CoordinatorTokenFlag:
+		err = filter.tx.Flags.SetCoordinatorToken(p.(value.String).Raw())
+	case cmd.ParticipantsFlag:
+		err = filter.tx.Flags.SetParticipants(int(p.(value.Integer).Raw()))
+	case cmd.ReadOnlyFlag:
+		err = filter.tx.Flags.SetReadOnly(p.(value.Boolean).Raw())
+	case cmd.DryRunFlag:
+		filter.tx.Flags.SetDryRun(p.(value.Boolean).Raw())
 	case cmd.StatsFlag:
 		filter.tx.Flags.SetStats(p.(value.Boolean).Raw())
 	}
@@ -299,9 +346,10 @@ func AddFlagElement(ctx context.Context, filter *Filter, expr parser.AddFlagElem
 	case cmd.RepositoryFlag, cmd.TimezoneFlag, cmd.DelimiterFlag, cmd.JsonQueryFlag, cmd.EncodingFlag,
 		cmd.WriteEncodingFlag, cmd.FormatFlag, cmd.WriteDelimiterFlag, cmd.LineBreakFlag, cmd.JsonEscape,
 		cmd.NoHeaderFlag, cmd.WithoutNullFlag, cmd.WithoutHeaderFlag, cmd.EncloseAll, cmd.PrettyPrintFlag,
-		cmd.EastAsianEncodingFlag, cmd.CountDiacriticalSignFlag, cmd.CountFormatCodeFlag, cmd.ColorFlag, cmd.QuietFlag, cmd.StatsFlag,
-		cmd.WaitTimeoutFlag,
-		cmd.CPUFlag:
+		cmd.EastAsianEncodingFlag, cmd.CountDiacriticalSignFlag, cmd.CountFormatCodeFlag, cmd.ColorFlag, cmd.QuietFlag, cmd.MmapFlag, cmd.ColumnarFlag, cmd.StatisticsCacheFlag, cmd.ResultCacheFlag, cmd.ProgressFlag, cmd.ReadOnlyFlag, cmd.DryRunFlag, cmd.StatsFlag,
+		cmd.BackupExtensionFlag, cmd.BackupDirFlag, cmd.AuditLogFlag, cmd.EncryptionKeyFileFlag, cmd.OnCommitFlag, cmd.CoordinatorTokenFlag, cmd.ParticipantsFlag,
+		cmd.CaseSensitiveFlag, cmd.WaitTimeoutFlag, cmd.LockRetryLimitFlag, cmd.LockBackoffFlag,
+		cmd.CPUFlag, cmd.LimitRecursionFlag, cmd.RandomSeedFlag, cmd.SortBufferSizeFlag, cmd.MaxMemoryFlag:
 
 		return NewAddFlagNotSupportedNameError(expr)
 	default:
@@ -342,9 +390,10 @@ func RemoveFlagElement(ctx context.Context, filter *Filter, expr parser.RemoveFl
 		cmd.ImportFormatFlag, cmd.DelimiterFlag, cmd.DelimiterPositionsFlag, cmd.JsonQueryFlag, cmd.EncodingFlag,
 		cmd.WriteEncodingFlag, cmd.FormatFlag, cmd.WriteDelimiterFlag, cmd.WriteDelimiterPositionsFlag, cmd.LineBreakFlag, cmd.JsonEscape,
 		cmd.NoHeaderFlag, cmd.WithoutNullFlag, cmd.WithoutHeaderFlag, cmd.EncloseAll, cmd.PrettyPrintFlag,
-		cmd.EastAsianEncodingFlag, cmd.CountDiacriticalSignFlag, cmd.CountFormatCodeFlag, cmd.ColorFlag, cmd.QuietFlag, cmd.StatsFlag,
-		cmd.WaitTimeoutFlag,
-		cmd.CPUFlag:
+		cmd.EastAsianEncodingFlag, cmd.CountDiacriticalSignFlag, cmd.CountFormatCodeFlag, cmd.ColorFlag, cmd.QuietFlag, cmd.MmapFlag, cmd.ColumnarFlag, cmd.StatisticsCacheFlag, cmd.ResultCacheFlag, cmd.ProgressFlag, cmd.ReadOnlyFlag, cmd.DryRunFlag, cmd.StatsFlag,
+		cmd.BackupExtensionFlag, cmd.BackupDirFlag, cmd.AuditLogFlag, cmd.EncryptionKeyFileFlag, cmd.OnCommitFlag, cmd.CoordinatorTokenFlag, cmd.ParticipantsFlag,
+		cmd.CaseSensitiveFlag, cmd.WaitTimeoutFlag, cmd.LockRetryLimitFlag, cmd.LockBackoffFlag,
+		cmd.CPUFlag, cmd.LimitRecursionFlag, cmd.RandomSeedFlag, cmd.SortBufferSizeFlag, cmd.MaxMemoryFlag:
 
 		return NewRemoveFlagNotSupportedNameError(expr)
 	default:
@@ -389,8 +438,18 @@ func showFlag(flags *cmd.Flags, flag string) (string, error) {
 			}
 			s = palette.Render(cmd.StringEffect, "["+strings.Join(list, ", ")+"]")
 		}
+	case cmd.CaseSensitiveFlag:
+		s = palette.Render(cmd.BooleanEffect, strconv.FormatBool(flags.CaseSensitive))
 	case cmd.WaitTimeoutFlag:
 		s = palette.Render(cmd.NumberEffect, value.Float64ToStr(flags.WaitTimeout))
+	case cmd.LockRetryLimitFlag:
+		if flags.LockRetryLimit < 0 {
+			s = palette.Render(cmd.NullEffect, "(no limit)")
+		} else {
+			s = palette.Render(cmd.NumberEffect, strconv.Itoa(flags.LockRetryLimit))
+		}
+	case cmd.LockBackoffFlag:
+		s = palette.Render(cmd.StringEffect, flags.LockBackoff.String())
 	case cmd.ImportFormatFlag:
 		s = palette.Render(cmd.StringEffect, flags.ImportFormat.String())
 	case cmd.DelimiterFlag:
@@ -513,6 +572,82 @@ func showFlag(flags *cmd.Flags, flag string) (string, error) {
 		s = palette.Render(cmd.BooleanEffect, strconv.FormatBool(flags.Quiet))
 	case cmd.CPUFlag:
 		s = palette.Render(cmd.NumberEffect, strconv.Itoa(flags.CPU))
+	case cmd.LimitRecursionFlag:
+		if flags.LimitRecursion < 0 {
+			s = palette.Render(cmd.NullEffect, "(no limit)")
+		} else {
+			s = palette.Render(cmd.NumberEffect, strconv.Itoa(flags.LimitRecursion))
+		}
+	case cmd.RandomSeedFlag:
+		if flags.RandomSeed < 0 {
+			s = palette.Render(cmd.NullEffect, "(not set)")
+		} else {
+			s = palette.Render(cmd.NumberEffect, strconv.FormatInt(flags.RandomSeed, 10))
+		}
+	case cmd.SortBufferSizeFlag:
+		if flags.SortBufferSize < 0 {
+			s = palette.Render(cmd.NullEffect, "(no limit)")
+		} else {
+			s = palette.Render(cmd.NumberEffect, strconv.Itoa(flags.SortBufferSize))
+		}
+	case cmd.MaxMemoryFlag:
+		if flags.MaxMemory < 0 {
+			s = palette.Render(cmd.NullEffect, "(no limit)")
+		} else {
+			s = palette.Render(cmd.NumberEffect, strconv.Itoa(flags.MaxMemory))
+		}
+	case cmd.MmapFlag:
+		s = palette.Render(cmd.BooleanEffect, strconv.FormatBool(flags.Mmap))
+	case cmd.ColumnarFlag:
+		s = palette.Render(cmd.BooleanEffect, strconv.FormatBool(flags.Columnar))
+	case cmd.StatisticsCacheFlag:
+		s = palette.Render(cmd.BooleanEffect, strconv.FormatBool(flags.StatisticsCache))
+	case cmd.ResultCacheFlag:
+		s = palette.Render(cmd.BooleanEffect, strconv.FormatBool(flags.ResultCache))
+	case cmd.ProgressFlag:
+		s = palette.Render(cmd.BooleanEffect, strconv.FormatBool(flags.Progress))
+	case cmd.BackupExtensionFlag:
+		if len(flags.BackupExtension) < 1 {
+			s = palette.Render(cmd.NullEffect, "(empty)")
+		} else {
+			s = palette.Render(cmd.StringEffect, flags.BackupExtension)
+		}
+	case cmd.BackupDirFlag:
+		if len(flags.BackupDir) < 1 {
+			s = palette.Render(cmd.NullEffect, "(empty)")
+		} else {
+			s = palette.Render(cmd.StringEffect, flags.BackupDir)
+		}
+	case cmd.AuditLogFlag:
+		if len(flags.AuditLog) < 1 {
+			s = palette.Render(cmd.NullEffect, "(empty)")
+		} else {
+			s = palette.Render(cmd.StringEffect, flags.AuditLog)
+		}
+	case cmd.EncryptionKeyFileFlag:
+		if len(flags.EncryptionKeyFile) < 1 {
+			s = palette.Render(cmd.NullEffect, "(empty)")
+		} else {
+			s = palette.Render(cmd.StringEffect, flags.EncryptionKeyFile)
+		}
+	case cmd.OnCommitFlag:
+		if len(flags.OnCommit) < 1 {
+			s = palette.Render(cmd.NullEffect, "(empty)")
+		} else {
+			s = palette.Render(cmd.StringEffect, flags.OnCommit)
+		}
+	case cmd.CoordinatorTokenFlag:
+		if len(flags.CoordinatorToken) < 1 {
+			s = palette.Render(cmd.NullEffect, "(empty)")
+		} else {
+			s = palette.Render(cmd.StringEffect, flags.CoordinatorToken)
+		}
+	case cmd.ParticipantsFlag:
+		s = palette.Render(cmd.NumberEffect, strconv.Itoa(flags.Participants))
+	case cmd.ReadOnlyFlag:
+		s = palette.Render(cmd.BooleanEffect, strconv.FormatBool(flags.ReadOnly))
+	case cmd.DryRunFlag:
+		s = palette.Render(cmd.BooleanEffect, strconv.FormatBool(flags.DryRun))
 	case cmd.StatsFlag:
 		s = palette.Render(cmd.BooleanEffect, strconv.FormatBool(flags.Stats))
 	default:
@@ -843,6 +978,12 @@ func writeTableAttribute(w *ObjectWriter, flags *cmd.Flags, info *FileInfo) {
 			w.WriteWithoutLineBreak(strconv.FormatBool(!info.NoHeader))
 		}
 	}
+
+	if info.Encrypted {
+		w.NewLine()
+		w.WriteColor("Encrypted: ", cmd.LableEffect)
+		w.WriteWithoutLineBreak(strconv.FormatBool(info.Encrypted))
+	}
 }
 
 func writeFields(w *ObjectWriter, fields []string) {
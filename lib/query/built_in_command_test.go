@@ -377,6 +377,7 @@ func TestParseExecuteStatements(t *testing.T) {
 var setFlagTests = []struct {
 	Name  string
 	Expr  parser.SetFlag
+	Init  func(*cmd.Flags)
 	Error string
 }{
 	{
@@ -400,6 +401,13 @@ var setFlagTests = []struct {
 			Value: parser.NewStringValue("%Y%m%d"),
 		},
 	},
+	{
+		Name: "Set CaseSensitive",
+		Expr: parser.SetFlag{
+			Name:  "case_sensitive",
+			Value: parser.NewTernaryValueFromString("true"),
+		},
+	},
 	{
 		Name: "Set WaitTimeout",
 		Expr: parser.SetFlag{
@@ -407,6 +415,20 @@ var setFlagTests = []struct {
 			Value: parser.NewFloatValue(15),
 		},
 	},
+	{
+		Name: "Set LockRetryLimit",
+		Expr: parser.SetFlag{
+			Name:  "lock_retry_limit",
+			Value: parser.NewIntegerValue(5),
+		},
+	},
+	{
+		Name: "Set LockBackoff",
+		Expr: parser.SetFlag{
+			Name:  "lock_backoff",
+			Value: parser.NewStringValue("jitter"),
+		},
+	},
 	{
 		Name: "Set Delimiter",
 		Expr: parser.SetFlag{
@@ -540,6 +562,167 @@ var setFlagTests = []struct {
 			Value: parser.NewIntegerValue(int64(runtime.NumCPU())),
 		},
 	},
+	{
+		Name: "Set LimitRecursion",
+		Expr: parser.SetFlag{
+			Name:  "limit_recursion",
+			Value: parser.NewIntegerValue(10),
+		},
+	},
+	{
+		Name: "Set RandomSeed",
+		Expr: parser.SetFlag{
+			Name:  "random_seed",
+			Value: parser.NewIntegerValue(10),
+		},
+	},
+	{
+		Name: "Set SortBufferSize",
+		Expr: parser.SetFlag{
+			Name:  "sort_buffer_size",
+			Value: parser.NewIntegerValue(256),
+		},
+	},
+	{
+		Name: "Set MaxMemory",
+		Expr: parser.SetFlag{
+			Name:  "max_memory",
+			Value: parser.NewIntegerValue(1024),
+		},
+	},
+	{
+		Name: "Set Mmap",
+		Expr: parser.SetFlag{
+			Name:  "mmap",
+			Value: parser.NewTernaryValueFromString("true"),
+		},
+	},
+	{
+		Name: "Set Columnar",
+		Expr: parser.SetFlag{
+			Name:  "columnar",
+			Value: parser.NewTernaryValueFromString("true"),
+		},
+	},
+	{
+		Name: "Set StatisticsCache",
+		Expr: parser.SetFlag{
+			Name:  "statistics_cache",
+			Value: parser.NewTernaryValueFromString("true"),
+		},
+	},
+	{
+		Name: "Set ResultCache",
+		Expr: parser.SetFlag{
+			Name:  "result_cache",
+			Value: parser.NewTernaryValueFromString("true"),
+		},
+	},
+	{
+		Name: "Set Progress",
+		Expr: parser.SetFlag{
+			Name:  "progress",
+			Value: parser.NewTernaryValueFromString("true"),
+		},
+	},
+	{
+		Name: "Set ReadOnly",
+		Expr: parser.SetFlag{
+			Name:  "read_only",
+			Value: parser.NewTernaryValueFromString("true"),
+		},
+	},
+	{
+		Name: "Set ReadOnly Disable Error",
+		Expr: parser.SetFlag{
+			Name:  "read_only",
+			Value: parser.NewTernaryValueFromString("false"),
+		},
+		Init: func(flags *cmd.Flags) {
+			_ = flags.SetReadOnly(true)
+		},
+		Error: "read-only mode cannot be disabled",
+	},
+	{
+		Name: "Set DryRun",
+		Expr: parser.SetFlag{
+			Name:  "dry_run",
+			Value: parser.NewTernaryValueFromString("true"),
+		},
+	},
+	{
+		Name: "Set BackupExtension",
+		Expr: parser.SetFlag{
+			Name:  "backup_extension",
+			Value: parser.NewStringValue(".bak"),
+		},
+	},
+	{
+		Name: "Set BackupDir",
+		Expr: parser.SetFlag{
+			Name:  "backup_dir",
+			Value: parser.NewStringValue(TestDir),
+		},
+	},
+	{
+		Name: "Set AuditLog",
+		Expr: parser.SetFlag{
+			Name:  "audit_log",
+			Value: parser.NewStringValue(filepath.Join(TestDir, "audit.jsonl")),
+		},
+	},
+	{
+		Name: "Set EncryptionKeyFile",
+		Expr: parser.SetFlag{
+			Name:  "encryption_key_file",
+			Value: parser.NewStringValue(""),
+		},
+	},
+	{
+		Name: "Set EncryptionKeyFile Not Exist Error",
+		Expr: parser.SetFlag{
+			Name:  "encryption_key_file",
+			Value: parser.NewStringValue(filepath.Join(TestDir, "notexist.key")),
+		},
+		Error: "encryption-key-file cannot be read",
+	},
+	{
+		Name: "Set OnCommit",
+		Expr: parser.SetFlag{
+			Name:  "on_commit",
+			Value: parser.NewStringValue(""),
+		},
+	},
+	{
+		Name: "Set OnCommit Not Exist Error",
+		Expr: parser.SetFlag{
+			Name:  "on_commit",
+			Value: parser.NewStringValue(filepath.Join(TestDir, "notexist.sql")),
+		},
+		Error: "on-commit file does not exist",
+	},
+	{
+		Name: "Set CoordinatorToken",
+		Expr: parser.SetFlag{
+			Name:  "coordinator_token",
+			Value: parser.NewStringValue(""),
+		},
+	},
+	{
+		Name: "Set Participants",
+		Expr: parser.SetFlag{
+			Name:  "participants",
+			Value: parser.NewIntegerValue(2),
+		},
+	},
+	{
+		Name: "Set Participants Error",
+		Expr: parser.SetFlag{
+			Name:  "participants",
+			Value: parser.NewIntegerValue(1),
+		},
+		Error: "participants must be at least 2",
+	},
 	{
 		Name: "Set Stats",
 		Expr: parser.SetFlag{
@@ -578,6 +761,14 @@ var setFlagTests = []struct {
 		},
 		Error: "true for @@wait_timeout is not allowed",
 	},
+	{
+		Name: "Set LockBackoff Value Error",
+		Expr: parser.SetFlag{
+			Name:  "lock_backoff",
+			Value: parser.NewStringValue("linear"),
+		},
+		Error: "backoff must be one of FIXED|EXPONENTIAL|JITTER",
+	},
 	{
 		Name: "Set WithoutNull Value Error",
 		Expr: parser.SetFlag{
@@ -613,12 +804,18 @@ var setFlagTests = []struct {
 }
 
 func TestSetFlag(t *testing.T) {
-	defer initFlag(TestTx.Flags)
+	defer func() {
+		initFlag(TestTx.Flags)
+		TestTx.UpdateRetryPolicy()
+	}()
 
 	filter := NewFilter(TestTx)
 
 	for _, v := range setFlagTests {
 		initFlag(TestTx.Flags)
+		if v.Init != nil {
+			v.Init(TestTx.Flags)
+		}
 		err := SetFlag(context.Background(), filter, v.Expr)
 		if err != nil {
 			if len(v.Error) < 1 {
@@ -885,6 +1082,19 @@ var showFlagTests = []struct {
 		},
 		Result: "\033[34;1m@@DATETIME_FORMAT:\033[0m \033[32m[\"%Y%m%d\", \"%Y%m%d %H%i%s\"]\033[0m",
 	},
+	{
+		Name: "Show CaseSensitive",
+		Expr: parser.ShowFlag{
+			Name: "case_sensitive",
+		},
+		SetExprs: []parser.SetFlag{
+			{
+				Name:  "case_sensitive",
+				Value: parser.NewTernaryValueFromString("true"),
+			},
+		},
+		Result: "\033[34;1m@@CASE_SENSITIVE:\033[0m \033[33;1mtrue\033[0m",
+	},
 	{
 		Name: "Show WaitTimeout",
 		Expr: parser.ShowFlag{
@@ -898,6 +1108,39 @@ var showFlagTests = []struct {
 		},
 		Result: "\033[34;1m@@WAIT_TIMEOUT:\033[0m \033[35m15\033[0m",
 	},
+	{
+		Name: "Show LockRetryLimit",
+		Expr: parser.ShowFlag{
+			Name: "lock_retry_limit",
+		},
+		SetExprs: []parser.SetFlag{
+			{
+				Name:  "lock_retry_limit",
+				Value: parser.NewIntegerValue(5),
+			},
+		},
+		Result: "\033[34;1m@@LOCK_RETRY_LIMIT:\033[0m \033[35m5\033[0m",
+	},
+	{
+		Name: "Show LockRetryLimit No Limit",
+		Expr: parser.ShowFlag{
+			Name: "lock_retry_limit",
+		},
+		Result: "\033[34;1m@@LOCK_RETRY_LIMIT:\033[0m \033[90m(no limit)\033[0m",
+	},
+	{
+		Name: "Show LockBackoff",
+		Expr: parser.ShowFlag{
+			Name: "lock_backoff",
+		},
+		SetExprs: []parser.SetFlag{
+			{
+				Name:  "lock_backoff",
+				Value: parser.NewStringValue("exponential"),
+			},
+		},
+		Result: "\033[34;1m@@LOCK_BACKOFF:\033[0m \033[32mEXPONENTIAL\033[0m",
+	},
 	{
 		Name: "Show Import Format",
 		Expr: parser.ShowFlag{
@@ -1466,6 +1709,280 @@ var showFlagTests = []struct {
 		},
 		Result: "\033[34;1m@@CPU:\033[0m \033[35m1\033[0m",
 	},
+	{
+		Name: "Show LimitRecursion",
+		Expr: parser.ShowFlag{
+			Name: "limit_recursion",
+		},
+		SetExprs: []parser.SetFlag{
+			{
+				Name:  "limit_recursion",
+				Value: parser.NewIntegerValue(10),
+			},
+		},
+		Result: "\033[34;1m@@LIMIT_RECURSION:\033[0m \033[35m10\033[0m",
+	},
+	{
+		Name: "Show LimitRecursion No Limit",
+		Expr: parser.ShowFlag{
+			Name: "limit_recursion",
+		},
+		SetExprs: []parser.SetFlag{
+			{
+				Name:  "limit_recursion",
+				Value: parser.NewIntegerValue(-1),
+			},
+		},
+		Result: "\033[34;1m@@LIMIT_RECURSION:\033[0m \033[90m(no limit)\033[0m",
+	},
+	{
+		Name: "Show RandomSeed",
+		Expr: parser.ShowFlag{
+			Name: "random_seed",
+		},
+		SetExprs: []parser.SetFlag{
+			{
+				Name:  "random_seed",
+				Value: parser.NewIntegerValue(10),
+			},
+		},
+		Result: "\033[34;1m@@RANDOM_SEED:\033[0m \033[35m10\033[0m",
+	},
+	{
+		Name: "Show RandomSeed Not Set",
+		Expr: parser.ShowFlag{
+			Name: "random_seed",
+		},
+		SetExprs: []parser.SetFlag{
+			{
+				Name:  "random_seed",
+				Value: parser.NewIntegerValue(-1),
+			},
+		},
+		Result: "\033[34;1m@@RANDOM_SEED:\033[0m \033[90m(not set)\033[0m",
+	},
+	{
+		Name: "Show SortBufferSize",
+		Expr: parser.ShowFlag{
+			Name: "sort_buffer_size",
+		},
+		SetExprs: []parser.SetFlag{
+			{
+				Name:  "sort_buffer_size",
+				Value: parser.NewIntegerValue(256),
+			},
+		},
+		Result: "\033[34;1m@@SORT_BUFFER_SIZE:\033[0m \033[35m256\033[0m",
+	},
+	{
+		Name: "Show SortBufferSize No Limit",
+		Expr: parser.ShowFlag{
+			Name: "sort_buffer_size",
+		},
+		SetExprs: []parser.SetFlag{
+			{
+				Name:  "sort_buffer_size",
+				Value: parser.NewIntegerValue(-1),
+			},
+		},
+		Result: "\033[34;1m@@SORT_BUFFER_SIZE:\033[0m \033[90m(no limit)\033[0m",
+	},
+	{
+		Name: "Show MaxMemory",
+		Expr: parser.ShowFlag{
+			Name: "max_memory",
+		},
+		SetExprs: []parser.SetFlag{
+			{
+				Name:  "max_memory",
+				Value: parser.NewIntegerValue(1024),
+			},
+		},
+		Result: "\033[34;1m@@MAX_MEMORY:\033[0m \033[35m1024\033[0m",
+	},
+	{
+		Name: "Show Mmap",
+		Expr: parser.ShowFlag{
+			Name: "mmap",
+		},
+		SetExprs: []parser.SetFlag{
+			{
+				Name:  "mmap",
+				Value: parser.NewTernaryValueFromString("true"),
+			},
+		},
+		Result: "\033[34;1m@@MMAP:\033[0m \033[33;1mtrue\033[0m",
+	},
+	{
+		Name: "Show Columnar",
+		Expr: parser.ShowFlag{
+			Name: "columnar",
+		},
+		SetExprs: []parser.SetFlag{
+			{
+				Name:  "columnar",
+				Value: parser.NewTernaryValueFromString("true"),
+			},
+		},
+		Result: "\033[34;1m@@COLUMNAR:\033[0m \033[33;1mtrue\033[0m",
+	},
+	{
+		Name: "Show StatisticsCache",
+		Expr: parser.ShowFlag{
+			Name: "statistics_cache",
+		},
+		SetExprs: []parser.SetFlag{
+			{
+				Name:  "statistics_cache",
+				Value: parser.NewTernaryValueFromString("true"),
+			},
+		},
+		Result: "\033[34;1m@@STATISTICS_CACHE:\033[0m \033[33;1mtrue\033[0m",
+	},
+	{
+		Name: "Show ResultCache",
+		Expr: parser.ShowFlag{
+			Name: "result_cache",
+		},
+		SetExprs: []parser.SetFlag{
+			{
+				Name:  "result_cache",
+				Value: parser.NewTernaryValueFromString("true"),
+			},
+		},
+		Result: "\033[34;1m@@RESULT_CACHE:\033[0m \033[33;1mtrue\033[0m",
+	},
+	{
+		Name: "Show Progress",
+		Expr: parser.ShowFlag{
+			Name: "progress",
+		},
+		SetExprs: []parser.SetFlag{
+			{
+				Name:  "progress",
+				Value: parser.NewTernaryValueFromString("true"),
+			},
+		},
+		Result: "\033[34;1m@@PROGRESS:\033[0m \033[33;1mtrue\033[0m",
+	},
+	{
+		Name: "Show BackupExtension",
+		Expr: parser.ShowFlag{
+			Name: "backup_extension",
+		},
+		SetExprs: []parser.SetFlag{
+			{
+				Name:  "backup_extension",
+				Value: parser.NewStringValue(".bak"),
+			},
+		},
+		Result: "\033[34;1m@@BACKUP_EXTENSION:\033[0m \033[32m.bak\033[0m",
+	},
+	{
+		Name: "Show BackupDir",
+		Expr: parser.ShowFlag{
+			Name: "backup_dir",
+		},
+		SetExprs: []parser.SetFlag{
+			{
+				Name:  "backup_dir",
+				Value: parser.NewStringValue(TestDir),
+			},
+		},
+		Result: "\033[34;1m@@BACKUP_DIR:\033[0m \033[32m" + TestDir + "\033[0m",
+	},
+	{
+		Name: "Show BackupDir Empty",
+		Expr: parser.ShowFlag{
+			Name: "backup_dir",
+		},
+		SetExprs: []parser.SetFlag{},
+		Result:   "\033[34;1m@@BACKUP_DIR:\033[0m \033[90m(empty)\033[0m",
+	},
+	{
+		Name: "Show AuditLog",
+		Expr: parser.ShowFlag{
+			Name: "audit_log",
+		},
+		SetExprs: []parser.SetFlag{
+			{
+				Name:  "audit_log",
+				Value: parser.NewStringValue(filepath.Join(TestDir, "audit.jsonl")),
+			},
+		},
+		Result: "\033[34;1m@@AUDIT_LOG:\033[0m \033[32m" + filepath.Join(TestDir, "audit.jsonl") + "\033[0m",
+	},
+	{
+		Name: "Show AuditLog Empty",
+		Expr: parser.ShowFlag{
+			Name: "audit_log",
+		},
+		SetExprs: []parser.SetFlag{},
+		Result:   "\033[34;1m@@AUDIT_LOG:\033[0m \033[90m(empty)\033[0m",
+	},
+	{
+		Name: "Show EncryptionKeyFile Empty",
+		Expr: parser.ShowFlag{
+			Name: "encryption_key_file",
+		},
+		SetExprs: []parser.SetFlag{},
+		Result:   "\033[34;1m@@ENCRYPTION_KEY_FILE:\033[0m \033[90m(empty)\033[0m",
+	},
+	{
+		Name: "Show OnCommit Empty",
+		Expr: parser.ShowFlag{
+			Name: "on_commit",
+		},
+		SetExprs: []parser.SetFlag{},
+		Result:   "\033[34;1m@@ON_COMMIT:\033[0m \033[90m(empty)\033[0m",
+	},
+	{
+		Name: "Show CoordinatorToken",
+		Expr: parser.ShowFlag{
+			Name: "coordinator_token",
+		},
+		SetExprs: []parser.SetFlag{
+			{
+				Name:  "coordinator_token",
+				Value: parser.NewStringValue("pipeline-1"),
+			},
+		},
+		Result: "\033[34;1m@@COORDINATOR_TOKEN:\033[0m \033[32mpipeline-1\033[0m",
+	},
+	{
+		Name: "Show Participants",
+		Expr: parser.ShowFlag{
+			Name: "participants",
+		},
+		SetExprs: []parser.SetFlag{},
+		Result:   "\033[34;1m@@PARTICIPANTS:\033[0m \033[35m2\033[0m",
+	},
+	{
+		Name: "Show ReadOnly",
+		Expr: parser.ShowFlag{
+			Name: "read_only",
+		},
+		SetExprs: []parser.SetFlag{
+			{
+				Name:  "read_only",
+				Value: parser.NewTernaryValueFromString("true"),
+			},
+		},
+		Result: "\033[34;1m@@READ_ONLY:\033[0m \033[33;1mtrue\033[0m",
+	},
+	{
+		Name: "Show DryRun",
+		Expr: parser.ShowFlag{
+			Name: "dry_run",
+		},
+		SetExprs: []parser.SetFlag{
+			{
+				Name:  "dry_run",
+				Value: parser.NewTernaryValueFromString("true"),
+			},
+		},
+		Result: "\033[34;1m@@DRY_RUN:\033[0m \033[33;1mtrue\033[0m",
+	},
 	{
 		Name: "Show Stats",
 		Expr: parser.ShowFlag{
@@ -1489,7 +2006,10 @@ var showFlagTests = []struct {
 }
 
 func TestShowFlag(t *testing.T) {
-	defer initFlag(TestTx.Flags)
+	defer func() {
+		initFlag(TestTx.Flags)
+		TestTx.UpdateRetryPolicy()
+	}()
 
 	filter := NewFilter(TestTx)
 
@@ -1988,7 +2508,7 @@ var showObjectsTests = []struct {
 								Select:   "select",
 								Fields: []parser.QueryExpression{
 									parser.Field{
-										Object: parser.NewIntegerValueFromString("1"),
+										Object: parser.NewIntegerValue(1),
 									},
 								},
 							},
@@ -2044,7 +2564,10 @@ var showObjectsTests = []struct {
 			"                @@REPOSITORY: .\n" +
 			"                  @@TIMEZONE: UTC\n" +
 			"           @@DATETIME_FORMAT: (not set)\n" +
+			"            @@CASE_SENSITIVE: false\n" +
 			"              @@WAIT_TIMEOUT: 15\n" +
+			"          @@LOCK_RETRY_LIMIT: (no limit)\n" +
+			"              @@LOCK_BACKOFF: FIXED\n" +
 			"             @@IMPORT_FORMAT: CSV\n" +
 			"                 @@DELIMITER: ','\n" +
 			"       @@DELIMITER_POSITIONS: SPACES\n" +
@@ -2067,6 +2590,24 @@ var showObjectsTests = []struct {
 			"                     @@COLOR: false\n" +
 			"                     @@QUIET: false\n" +
 			"                       @@CPU: " + strconv.Itoa(TestTx.Flags.CPU) + "\n" +
+			"           @@LIMIT_RECURSION: 1000\n" +
+			"               @@RANDOM_SEED: (not set)\n" +
+			"          @@SORT_BUFFER_SIZE: (no limit)\n" +
+			"                @@MAX_MEMORY: (no limit)\n" +
+			"                      @@MMAP: false\n" +
+			"                  @@COLUMNAR: false\n" +
+			"          @@STATISTICS_CACHE: false\n" +
+			"              @@RESULT_CACHE: false\n" +
+			"                  @@PROGRESS: false\n" +
+			"          @@BACKUP_EXTENSION: (empty)\n" +
+			"                @@BACKUP_DIR: (empty)\n" +
+			"                 @@AUDIT_LOG: (empty)\n" +
+			"       @@ENCRYPTION_KEY_FILE: (empty)\n" +
+			"                 @@ON_COMMIT: (empty)\n" +
+			"         @@COORDINATOR_TOKEN: (empty)\n" +
+			"              @@PARTICIPANTS: 2\n" +
+			"                 @@READ_ONLY: false\n" +
+			"                   @@DRY_RUN: false\n" +
 			"                     @@STATS: false\n" +
 			"\n",
 	},
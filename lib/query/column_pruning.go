@@ -0,0 +1,139 @@
+package query
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/mithrandie/csvq/lib/parser"
+)
+
+// ColumnSet is a set of the column names in upper case that are referred to in a statement.
+// Fields of files that are not in the set are loaded as placeholders without parsing their values.
+type ColumnSet map[string]bool
+
+// ReferredColumns returns the names of the columns referred to in the statement.
+// If the statement can refer to the columns without their names, such as all columns
+// or column numbers, then false is returned and all the columns must be loaded.
+func ReferredColumns(stmt parser.Statement) (ColumnSet, bool) {
+	columns := make(ColumnSet)
+	if !columns.collect(reflect.ValueOf(stmt)) {
+		return nil, false
+	}
+	return columns, true
+}
+
+func (s ColumnSet) collect(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		if v.IsNil() {
+			return true
+		}
+		return s.collect(v.Elem())
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			if !s.collect(v.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		if !v.CanInterface() {
+			return true
+		}
+	default:
+		return true
+	}
+
+	switch e := v.Interface().(type) {
+	case parser.BaseExpr, parser.PrimitiveType:
+		return true
+	case parser.FieldReference:
+		s[strings.ToUpper(e.Column.Literal)] = true
+		return true
+	case parser.AllColumns, parser.ColumnNumber, parser.Pivot, parser.Unpivot:
+		return false
+	case parser.Table:
+		if e.Columns != nil {
+			return false
+		}
+	case parser.Join:
+		if !e.Natural.IsEmpty() {
+			return false
+		}
+	case parser.JoinCondition:
+		for _, expr := range e.Using {
+			if ident, ok := expr.(parser.Identifier); ok {
+				s[strings.ToUpper(ident.Literal)] = true
+			}
+		}
+	case parser.AggregateFunction:
+		if strings.EqualFold(e.Name, "COUNT") && len(e.Args) == 1 {
+			if _, ok := e.Args[0].(parser.AllColumns); ok {
+				return true
+			}
+		}
+	case parser.AnalyticFunction:
+		if strings.EqualFold(e.Name, "COUNT") && len(e.Args) == 1 {
+			if _, ok := e.Args[0].(parser.AllColumns); ok {
+				return s.collect(reflect.ValueOf(e.AnalyticClause))
+			}
+		}
+	case parser.Function:
+		if strings.EqualFold(e.Name, "JSON_OBJECT") && len(e.Args) < 1 {
+			return false
+		}
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		if !s.collect(v.Field(i)) {
+			return false
+		}
+	}
+	return true
+}
+
+// CreateNodeForColumnPruning returns a node of the filter that loads only the columns referred to in the statement.
+func (f *Filter) CreateNodeForColumnPruning(stmt parser.Statement) *Filter {
+	node := f.CreateNode()
+	if columns, ok := ReferredColumns(stmt); ok {
+		node.loadColumns = columns
+	}
+	return node
+}
+
+// prunedFields returns the flags of the fields that are not referred to, or nil if all the fields are referred to.
+// If the header is nil, then the fields are named in the same way as files without header.
+func (s ColumnSet) prunedFields(header []string, fieldLen int) []bool {
+	if s == nil {
+		return nil
+	}
+
+	pruned := make([]bool, fieldLen)
+	exists := false
+	for i := range pruned {
+		var column string
+		if header == nil {
+			column = "c" + strconv.Itoa(i+1)
+		} else if i < len(header) {
+			column = header[i]
+		}
+		pruned[i] = !s[strings.ToUpper(column)]
+		exists = exists || pruned[i]
+	}
+	if !exists {
+		return nil
+	}
+	return pruned
+}
+
+// hasColumns reports whether all the columns in the set are loaded in the view.
+// A nil set means all the columns of the view.
+func (view *View) hasColumns(columns ColumnSet) bool {
+	for i, pruned := range view.prunedFields {
+		if pruned && (columns == nil || columns[strings.ToUpper(view.Header[i].Column)]) {
+			return false
+		}
+	}
+	return true
+}
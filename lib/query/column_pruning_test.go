@@ -0,0 +1,119 @@
+package query
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mithrandie/csvq/lib/parser"
+	"github.com/mithrandie/csvq/lib/value"
+)
+
+var referredColumnsTests = []struct {
+	Query  string
+	Result ColumnSet
+	OK     bool
+}{
+	{
+		Query:  "SELECT t1.column1 FROM table1 t1 WHERE column2 = 'a' ORDER BY Column1",
+		Result: ColumnSet{"COLUMN1": true, "COLUMN2": true},
+		OK:     true,
+	},
+	{
+		Query:  "SELECT COUNT(*) FROM table1 WHERE column1 IN (SELECT column3 FROM table2)",
+		Result: ColumnSet{"COLUMN1": true, "COLUMN3": true},
+		OK:     true,
+	},
+	{
+		Query:  "SELECT column1 FROM table1 JOIN table2 USING (column2)",
+		Result: ColumnSet{"COLUMN1": true, "COLUMN2": true},
+		OK:     true,
+	},
+	{
+		Query: "SELECT * FROM table1",
+	},
+	{
+		Query: "SELECT column1 FROM table1 WHERE EXISTS (SELECT * FROM table2)",
+	},
+	{
+		Query: "SELECT table1.1 FROM table1",
+	},
+	{
+		Query: "SELECT a FROM table1 t(a, b)",
+	},
+	{
+		Query: "SELECT column1 FROM table1 NATURAL JOIN table2",
+	},
+	{
+		Query: "SELECT JSON_OBJECT() FROM table1",
+	},
+}
+
+func TestReferredColumns(t *testing.T) {
+	for _, v := range referredColumnsTests {
+		statements, _, err := parser.Parse(v.Query, "", nil, false)
+		if err != nil {
+			t.Fatalf("%s: unexpected parse error %q", v.Query, err)
+		}
+
+		result, ok := ReferredColumns(statements[0])
+		if ok != v.OK {
+			t.Errorf("%s: ok = %t, want %t", v.Query, ok, v.OK)
+			continue
+		}
+		if !reflect.DeepEqual(result, v.Result) {
+			t.Errorf("%s: result = %v, want %v", v.Query, result, v.Result)
+		}
+	}
+}
+
+func TestColumnPruning(t *testing.T) {
+	defer func() {
+		_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+		initFlag(TestTx.Flags)
+	}()
+
+	TestTx.Flags.Repository = TestDir
+	_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+
+	sel := func(query string) *View {
+		statements, _, err := parser.Parse(query, "", nil, false)
+		if err != nil {
+			t.Fatalf("%s: unexpected parse error %q", query, err)
+		}
+		view, err := Select(context.Background(), NewFilter(TestTx).CreateNodeForColumnPruning(statements[0]), statements[0].(parser.SelectQuery))
+		if err != nil {
+			t.Fatalf("%s: unexpected error %q", query, err)
+		}
+		return view
+	}
+	cached := func() *View {
+		for k, v := range TestTx.cachedViews {
+			if strings.HasSuffix(k, "TABLE1.CSV") {
+				return v
+			}
+		}
+		t.Fatal("table1 is not loaded")
+		return nil
+	}
+
+	view := sel("SELECT column1 FROM table1 WHERE column1 = 2")
+	if !reflect.DeepEqual(view.RecordSet, RecordSet{NewRecord([]value.Primary{value.NewString("2")})}) {
+		t.Errorf("records = %v, want only the record of column1 = 2", view.RecordSet)
+	}
+	if !reflect.DeepEqual(cached().prunedFields, []bool{false, true}) {
+		t.Errorf("pruned fields = %v, want %v", cached().prunedFields, []bool{false, true})
+	}
+	if !value.IsNull(cached().RecordSet[0][1].Value()) {
+		t.Errorf("pruned field = %s, want NULL", cached().RecordSet[0][1].Value())
+	}
+
+	view = sel("SELECT column2 FROM table1 WHERE column1 = 2")
+	if !reflect.DeepEqual(view.RecordSet, RecordSet{NewRecord([]value.Primary{value.NewString("str2")})}) {
+		t.Errorf("records = %v, want the reloaded value of column2", view.RecordSet)
+	}
+	if cached().prunedFields != nil {
+		t.Errorf("pruned fields = %v, want nil", cached().prunedFields)
+	}
+}
@@ -0,0 +1,167 @@
+package query
+
+import (
+	"strconv"
+
+	"github.com/mithrandie/csvq/lib/parser"
+	"github.com/mithrandie/csvq/lib/value"
+)
+
+// recordSetBuilder builds a RecordSet from rows of values.
+//
+// In the columnar layout, the values are stored in a slice for each column, and the cells of the
+// records refer to the elements of those slices, so that a few large allocations replace an allocation
+// for each cell.
+// The cells are sliced with their capacities limited, so appending values to a cell never overwrites
+// the values of other records.
+type recordSetBuilder struct {
+	columnar bool
+
+	records RecordSet
+	columns [][]value.Primary
+	length  int
+
+	progress *progress
+
+	// If the sampler is set, then only the records selected by the sampler are kept.
+	sampler *tableSampler
+}
+
+func newRecordSetBuilder(columnar bool, capacity int) *recordSetBuilder {
+	return &recordSetBuilder{
+		columnar: columnar,
+		records:  make(RecordSet, 0, capacity),
+	}
+}
+
+func (b *recordSetBuilder) Append(values []value.Primary) {
+	b.progress.add(1)
+
+	if b.sampler != nil {
+		b.sampler.Add(NewRecord(values))
+		return
+	}
+
+	if b.columnar {
+		if b.columns == nil {
+			b.columns = make([][]value.Primary, len(values))
+			for i := range b.columns {
+				b.columns[i] = make([]value.Primary, 0, cap(b.records))
+			}
+		}
+
+		if len(values) == len(b.columns) {
+			for i, v := range values {
+				b.columns[i] = append(b.columns[i], v)
+			}
+			b.length++
+			return
+		}
+
+		// Rows with different lengths cannot be stored in the columns.
+		b.records = b.columnRecords()
+		b.columnar = false
+		b.columns = nil
+	}
+
+	b.records = append(b.records, NewRecord(values))
+}
+
+func (b *recordSetBuilder) RecordSet() RecordSet {
+	if b.sampler != nil {
+		return b.sampler.RecordSet()
+	}
+	if b.columnar {
+		return b.columnRecords()
+	}
+	return b.records
+}
+
+func (b *recordSetBuilder) columnRecords() RecordSet {
+	return newColumnarRecordSet(b.columns, b.length)
+}
+
+// newColumnarRecordSet returns the records whose cells refer to the values of the columns.
+func newColumnarRecordSet(columns [][]value.Primary, length int) RecordSet {
+	fieldLen := len(columns)
+	records := make(RecordSet, length)
+	cells := make([]Cell, length*fieldLen)
+
+	for i := range records {
+		offset := i * fieldLen
+		record := cells[offset : offset+fieldLen : offset+fieldLen]
+		for j := range record {
+			record[j] = columns[j][i : i+1 : i+1]
+		}
+		records[i] = record
+	}
+	return records
+}
+
+// newGroupedRecordSet returns the grouped records of the indices.
+// The values of each column are stored in a slice in the order of the groups, and each grouped cell refers to
+// a part of that slice.
+func newGroupedRecordSet(records RecordSet, fieldLen int, groups [][]int) RecordSet {
+	length := 0
+	for _, indices := range groups {
+		length += len(indices)
+	}
+
+	columns := make([][]value.Primary, fieldLen)
+	for j := range columns {
+		columns[j] = make([]value.Primary, length)
+	}
+
+	grouped := make(RecordSet, len(groups))
+	cells := make([]Cell, len(groups)*fieldLen)
+
+	offset := 0
+	for i, indices := range groups {
+		record := cells[i*fieldLen : (i+1)*fieldLen : (i+1)*fieldLen]
+		end := offset + len(indices)
+		for j := range record {
+			column := columns[j][offset:end:end]
+			for k, idx := range indices {
+				column[k] = records[idx][j].Value()
+			}
+			record[j] = NewGroupCell(column)
+		}
+		grouped[i] = record
+		offset = end
+	}
+	return grouped
+}
+
+// groupedValues returns the values of the field in the grouped record without evaluating the records one by one.
+// If the expression is not a reference to a field of the view, then false is returned.
+func groupedValues(filterRecord filterRecord, expr parser.QueryExpression) ([]value.Primary, bool) {
+	switch expr.(type) {
+	case parser.FieldReference, parser.ColumnNumber:
+	default:
+		return nil, false
+	}
+
+	view := filterRecord.view
+	idx, err := view.FieldIndex(expr)
+	if err != nil {
+		return nil, false
+	}
+
+	record := view.RecordSet[filterRecord.recordIndex]
+	if view.Header[idx].IsGroupKey {
+		if i, ok := view.Header.ContainsGroupingColumn(GroupingValuesColumnPrefix + strconv.Itoa(idx)); ok {
+			idx = i
+		}
+	}
+
+	cell := record[idx]
+	list := make([]value.Primary, record.GroupLen())
+	if cell.Len() < 2 {
+		for i := range list {
+			list[i] = cell.Value()
+		}
+	} else {
+		copy(list, cell)
+	}
+	return list, true
+}
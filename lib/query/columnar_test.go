@@ -0,0 +1,155 @@
+package query
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/mithrandie/csvq/lib/parser"
+	"github.com/mithrandie/csvq/lib/value"
+)
+
+var recordSetBuilderTests = []struct {
+	Name     string
+	Columnar bool
+	Rows     [][]value.Primary
+}{
+	{
+		Name: "Row Layout",
+		Rows: [][]value.Primary{
+			{value.NewString("1"), value.NewString("str1")},
+			{value.NewString("2"), value.NewNull()},
+		},
+	},
+	{
+		Name:     "Columnar Layout",
+		Columnar: true,
+		Rows: [][]value.Primary{
+			{value.NewString("1"), value.NewString("str1")},
+			{value.NewString("2"), value.NewNull()},
+			{value.NewString("3"), value.NewString("str3")},
+		},
+	},
+	{
+		Name:     "Columnar Layout with Rows of Different Lengths",
+		Columnar: true,
+		Rows: [][]value.Primary{
+			{value.NewString("1"), value.NewString("str1")},
+			{value.NewString("2")},
+			{value.NewString("3"), value.NewString("str3")},
+		},
+	},
+	{
+		Name:     "Columnar Layout without Rows",
+		Columnar: true,
+		Rows:     [][]value.Primary{},
+	},
+}
+
+func TestRecordSetBuilder(t *testing.T) {
+	for _, v := range recordSetBuilderTests {
+		expect := make(RecordSet, 0, len(v.Rows))
+		builder := newRecordSetBuilder(v.Columnar, 2)
+		for _, row := range v.Rows {
+			expect = append(expect, NewRecord(row))
+			builder.Append(row)
+		}
+
+		result := builder.RecordSet()
+		if !reflect.DeepEqual(result, expect) {
+			t.Errorf("%s: records = %v, want %v", v.Name, result, expect)
+			continue
+		}
+
+		for i := range result {
+			for j := range result[i] {
+				if cap(result[i][j]) != 1 {
+					t.Errorf("%s: capacity of cell[%d][%d] = %d, want 1", v.Name, i, j, cap(result[i][j]))
+				}
+			}
+			if cap(result[i]) != len(result[i]) {
+				t.Errorf("%s: capacity of record[%d] = %d, want %d", v.Name, i, cap(result[i]), len(result[i]))
+			}
+		}
+	}
+}
+
+func TestNewGroupedRecordSet(t *testing.T) {
+	records := RecordSet{
+		NewRecord([]value.Primary{value.NewInteger(1), value.NewString("a")}),
+		NewRecord([]value.Primary{value.NewInteger(2), value.NewString("b")}),
+		NewRecord([]value.Primary{value.NewInteger(3), value.NewString("a")}),
+	}
+	expect := RecordSet{
+		{
+			NewGroupCell([]value.Primary{value.NewInteger(1), value.NewInteger(3)}),
+			NewGroupCell([]value.Primary{value.NewString("a"), value.NewString("a")}),
+		},
+		{
+			NewGroupCell([]value.Primary{value.NewInteger(2)}),
+			NewGroupCell([]value.Primary{value.NewString("b")}),
+		},
+	}
+
+	result := newGroupedRecordSet(records, 2, [][]int{{0, 2}, {1}})
+	if !reflect.DeepEqual(result, expect) {
+		t.Fatalf("records = %v, want %v", result, expect)
+	}
+
+	_ = append(result[0][0], value.NewInteger(4))
+	if !reflect.DeepEqual(result[1][0], expect[1][0]) {
+		t.Errorf("cell = %v, want %v", result[1][0], expect[1][0])
+	}
+}
+
+var columnarSelectTests = []string{
+	"SELECT * FROM table1",
+	"SELECT * FROM table1 WHERE column1 > 1 ORDER BY column2 DESC",
+	"SELECT column1, COUNT(*), SUM(column1), MAX(column2) FROM table1 GROUP BY column1",
+	"SELECT COUNT(column2), COUNT(DISTINCT column2), AVG(column1), MIN(1) FROM table1",
+	"SELECT COUNT(DISTINCT t.column1), SUM(column1 + 1), LISTAGG(column2, ',') FROM table1 AS t",
+	"SELECT SUM(column1), COVAR_POP(column1, column1) FROM table1 GROUP BY column1 % 2",
+	"SELECT column1, column3, COUNT(column4) FROM table1 CROSS JOIN table2 GROUP BY ROLLUP(column1, column3)",
+	"SELECT SUM(1) FROM table1 WHERE column1 > 5",
+}
+
+func TestColumnarSelect(t *testing.T) {
+	defer func() {
+		_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+		initFlag(TestTx.Flags)
+	}()
+
+	TestTx.Flags.Repository = TestDir
+
+	selectRecords := func(query string, columnar bool) (RecordSet, error) {
+		_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+		TestTx.Flags.Columnar = columnar
+
+		statements, _, err := parser.Parse(query, "", nil, false)
+		if err != nil {
+			return nil, err
+		}
+		view, err := Select(context.Background(), NewFilter(TestTx).CreateNode(), statements[0].(parser.SelectQuery))
+		if err != nil {
+			return nil, err
+		}
+		return view.RecordSet, nil
+	}
+
+	for _, query := range columnarSelectTests {
+		expect, err := selectRecords(query, false)
+		if err != nil {
+			t.Errorf("%s: unexpected error %q", query, err)
+			continue
+		}
+
+		result, err := selectRecords(query, true)
+		if err != nil {
+			t.Errorf("%s: unexpected error %q in columnar layout", query, err)
+			continue
+		}
+		if !reflect.DeepEqual(result, expect) {
+			t.Errorf("%s: records = %v, want %v", query, result, expect)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package query
+
+import (
+	"context"
+
+	"github.com/mithrandie/csvq/lib/parser"
+	"github.com/mithrandie/csvq/lib/value"
+
+	txjson "github.com/mithrandie/go-text/json"
+)
+
+const (
+	CreatedFilesVariable = "CREATED_FILES"
+	UpdatedFilesVariable = "UPDATED_FILES"
+)
+
+// CommittedFiles is the list of the paths of the files written by a commit.
+type CommittedFiles struct {
+	Created []string
+	Updated []string
+}
+
+func (f CommittedFiles) IsEmpty() bool {
+	return len(f.Created) < 1 && len(f.Updated) < 1
+}
+
+// CommitHook is a function called by a transaction when it commits changes to files.
+type CommitHook func(files CommittedFiles) error
+
+// AddPreCommitHook registers a function called after the changes have been written to temporary files
+// and before any file is replaced.
+// If the function returns an error, then the commit is aborted and the files remain unchanged.
+func (tx *Transaction) AddPreCommitHook(hook CommitHook) {
+	tx.preCommitHooks = append(tx.preCommitHooks, hook)
+}
+
+// AddPostCommitHook registers a function called after the files have been replaced.
+// If the function returns an error, then the error is returned from the commit, but the files remain committed.
+func (tx *Transaction) AddPostCommitHook(hook CommitHook) {
+	tx.postCommitHooks = append(tx.postCommitHooks, hook)
+}
+
+func (tx *Transaction) runPreCommitHooks(expr parser.Expression, files CommittedFiles) error {
+	for _, hook := range tx.preCommitHooks {
+		if err := hook(files); err != nil {
+			return NewCommitError(expr, "pre-commit hook failed: "+err.Error())
+		}
+	}
+	return nil
+}
+
+// postCommit runs the post-commit hooks, and then executes the statements in the file specified by the ON_COMMIT flag.
+func (tx *Transaction) postCommit(filter *Filter, expr parser.Expression, files CommittedFiles) error {
+	if files.IsEmpty() {
+		return nil
+	}
+
+	for _, hook := range tx.postCommitHooks {
+		if err := hook(files); err != nil {
+			return NewCommitError(expr, "post-commit hook failed: "+err.Error())
+		}
+	}
+
+	return tx.executeCommitTrigger(filter, files)
+}
+
+// executeCommitTrigger executes the statements in the file specified by the ON_COMMIT flag in a child scope
+// where the paths of the committed files are declared as variables.
+// Commits by the statements do not execute the statements again.
+func (tx *Transaction) executeCommitTrigger(filter *Filter, files CommittedFiles) error {
+	if len(tx.Flags.OnCommit) < 1 || tx.commitTriggerRunning {
+		return nil
+	}
+
+	tx.commitTriggerRunning = true
+	defer func() {
+		tx.commitTriggerRunning = false
+	}()
+
+	ctx := context.Background()
+	fpath := tx.Flags.OnCommit
+	statements, err := LoadStatementsFromFile(ctx, tx, parser.Source{FilePath: parser.NewStringValue(fpath)}, fpath)
+	if err != nil {
+		return err
+	}
+
+	child := filter.CreateChildScope()
+	_ = child.variables[0].Add(parser.Variable{Name: CreatedFilesVariable}, committedFilesValue(files.Created))
+	_ = child.variables[0].Add(parser.Variable{Name: UpdatedFilesVariable}, committedFilesValue(files.Updated))
+
+	_, err = NewProcessorWithFilter(tx, child).execute(ctx, statements)
+	return err
+}
+
+// committedFilesValue returns the paths of the files as a string of a JSON array.
+func committedFilesValue(paths []string) value.Primary {
+	array := make(txjson.Array, 0, len(paths))
+	for _, p := range paths {
+		array = append(array, txjson.String(p))
+	}
+	return value.NewString(txjson.NewEncoder().Encode(array))
+}
@@ -0,0 +1,100 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/mithrandie/csvq/lib/parser"
+)
+
+func TestTransaction_CommitHooks(t *testing.T) {
+	fpath := GetTestFilePath("commit_hook_test.csv")
+	logPath := GetTestFilePath("commit_hook_log.csv")
+	triggerPath := GetTestFilePath("commit_hook_trigger.sql")
+	defer func() {
+		_ = TestTx.Rollback(nil, nil)
+		TestTx.preCommitHooks = nil
+		TestTx.postCommitHooks = nil
+		_ = os.Remove(fpath)
+		_ = os.Remove(logPath)
+		_ = os.Remove(triggerPath)
+		initFlag(TestTx.Flags)
+	}()
+
+	for p, s := range map[string]string{
+		fpath:       "c1,c2\n1,a",
+		logPath:     "path",
+		triggerPath: "INSERT INTO commit_hook_log VALUES (JSON_VALUE('[0]', @UPDATED_FILES)); COMMIT;",
+	} {
+		if err := ioutil.WriteFile(p, []byte(s), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	TestTx.Flags.Repository = TestDir
+	TestTx.Flags.SetQuiet(true)
+	TestTx.Session.Stdout = NewDiscard()
+
+	var preCommitted []CommittedFiles
+	var postCommitted []CommittedFiles
+	var preCommitErr error
+	TestTx.AddPreCommitHook(func(files CommittedFiles) error {
+		preCommitted = append(preCommitted, files)
+		return preCommitErr
+	})
+	TestTx.AddPostCommitHook(func(files CommittedFiles) error {
+		postCommitted = append(postCommitted, files)
+		return nil
+	})
+
+	execute := func(src string) error {
+		statements, _, err := parser.Parse(src, "", nil, false)
+		if err != nil {
+			t.Fatalf("unexpected parse error %q", err)
+		}
+		_, err = NewProcessor(TestTx).Execute(context.Background(), statements)
+		return err
+	}
+
+	preCommitErr = errors.New("rejected")
+	err := execute("INSERT INTO commit_hook_test VALUES (2, 'b'); COMMIT;")
+	if err == nil {
+		t.Fatalf("no error, want error for the rejected commit")
+	} else if err.Error() != "[L:1 C:47] failed to commit: pre-commit hook failed: rejected" {
+		t.Errorf("error = %q, want the error of the pre-commit hook", err.Error())
+	}
+	if b, _ := ioutil.ReadFile(fpath); string(b) != "c1,c2\n1,a" {
+		t.Errorf("file = %q, want the file unchanged", string(b))
+	}
+	if len(postCommitted) != 0 {
+		t.Errorf("post-commit hook is called %d times, want no call", len(postCommitted))
+	}
+	_ = TestTx.Rollback(nil, nil)
+
+	preCommitErr = nil
+	preCommitted = nil
+	if err = TestTx.Flags.SetOnCommit(triggerPath); err != nil {
+		t.Fatal(err)
+	}
+	if err = execute("INSERT INTO commit_hook_test VALUES (2, 'b'); COMMIT;"); err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	expect := []CommittedFiles{
+		{Updated: []string{fpath}},
+		{Updated: []string{logPath}},
+	}
+	if !reflect.DeepEqual(preCommitted, expect) {
+		t.Errorf("pre-commit hook is called with %v, want %v", preCommitted, expect)
+	}
+	if !reflect.DeepEqual(postCommitted, expect) {
+		t.Errorf("post-commit hook is called with %v, want %v", postCommitted, expect)
+	}
+	if b, _ := ioutil.ReadFile(logPath); string(b) != "path\n"+fpath {
+		t.Errorf("log = %q, want %q", string(b), "path\n"+fpath)
+	}
+}
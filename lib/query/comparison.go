@@ -19,6 +19,14 @@ func Is(p1 value.Primary, p2 value.Primary) ternary.Value {
 }
 
 func Like(p1 value.Primary, p2 value.Primary) ternary.Value {
+	return like(p1, p2, false)
+}
+
+func CaseSensitiveLike(p1 value.Primary, p2 value.Primary) ternary.Value {
+	return like(p1, p2, true)
+}
+
+func like(p1 value.Primary, p2 value.Primary, caseSensitive bool) ternary.Value {
 	if value.IsNull(p1) || value.IsNull(p2) {
 		return ternary.UNKNOWN
 	}
@@ -32,8 +40,12 @@ func Like(p1 value.Primary, p2 value.Primary) ternary.Value {
 		return ternary.UNKNOWN
 	}
 
-	s := strings.ToUpper(p1.(value.String).Raw())
-	pattern := strings.ToUpper(p2.(value.String).Raw())
+	s := s1.(value.String).Raw()
+	pattern := s2.(value.String).Raw()
+	if !caseSensitive {
+		s = strings.ToUpper(s)
+		pattern = strings.ToUpper(pattern)
+	}
 
 	if s == pattern {
 		return ternary.TRUE
@@ -141,6 +141,42 @@ func TestLike(t *testing.T) {
 	}
 }
 
+var caseSensitiveLikeTests = []struct {
+	LHS     value.Primary
+	Pattern value.Primary
+	Result  ternary.Value
+}{
+	{
+		LHS:     value.NewString("abcdefghijk"),
+		Pattern: value.NewString("abc%"),
+		Result:  ternary.TRUE,
+	},
+	{
+		LHS:     value.NewString("abcdefghijk"),
+		Pattern: value.NewString("ABC%"),
+		Result:  ternary.FALSE,
+	},
+	{
+		LHS:     value.NewInteger(12),
+		Pattern: value.NewString("1_"),
+		Result:  ternary.TRUE,
+	},
+	{
+		LHS:     value.NewString("str"),
+		Pattern: value.NewNull(),
+		Result:  ternary.UNKNOWN,
+	},
+}
+
+func TestCaseSensitiveLike(t *testing.T) {
+	for _, v := range caseSensitiveLikeTests {
+		r := CaseSensitiveLike(v.LHS, v.Pattern)
+		if r != v.Result {
+			t.Errorf("result = %s, want %s for (%s like %s)", r, v.Result, v.LHS, v.Pattern)
+		}
+	}
+}
+
 var inRowValueListTests = []struct {
 	LHS      value.RowValue
 	List     []value.RowValue
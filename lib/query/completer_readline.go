@@ -11,6 +11,7 @@ import (
 	"unicode"
 
 	"github.com/mithrandie/csvq/lib/cmd"
+	"github.com/mithrandie/csvq/lib/file"
 	"github.com/mithrandie/csvq/lib/parser"
 
 	"github.com/mithrandie/go-text"
@@ -154,14 +155,23 @@ func NewCompleter(filter *Filter) *Completer {
 	completer.funcs = append(completer.funcs, "NOW")
 	completer.funcs = append(completer.funcs, "JSON_OBJECT")
 
-	completer.aggFuncs = make([]string, 0, len(AggregateFunctions)+2)
-	completer.analyticFuncs = make([]string, 0, len(AnalyticFunctions)+len(AggregateFunctions))
+	completer.aggFuncs = make([]string, 0, len(AggregateFunctions)+len(BivariateAggregateFunctions)+7)
+	completer.analyticFuncs = make([]string, 0, len(AnalyticFunctions)+len(AggregateFunctions)+len(BivariateAggregateFunctions))
 	for k := range AggregateFunctions {
 		completer.aggFuncs = append(completer.aggFuncs, k)
 		completer.analyticFuncs = append(completer.analyticFuncs, k)
 	}
+	for k := range BivariateAggregateFunctions {
+		completer.aggFuncs = append(completer.aggFuncs, k)
+		completer.analyticFuncs = append(completer.analyticFuncs, k)
+	}
 	completer.aggFuncs = append(completer.aggFuncs, "LISTAGG")
 	completer.aggFuncs = append(completer.aggFuncs, "JSON_AGG")
+	completer.aggFuncs = append(completer.aggFuncs, "ARRAY_AGG")
+	completer.aggFuncs = append(completer.aggFuncs, "PERCENTILE_CONT")
+	completer.aggFuncs = append(completer.aggFuncs, "PERCENTILE_DISC")
+	completer.aggFuncs = append(completer.aggFuncs, "NPV")
+	completer.aggFuncs = append(completer.aggFuncs, "IRR")
 	for k := range AnalyticFunctions {
 		completer.analyticFuncs = append(completer.analyticFuncs, k)
 	}
@@ -373,10 +383,8 @@ func (c *Completer) Statements(line string, origLine string, index int) readline
 		return c.candidateList(c.cursorList, false)
 	case parser.FETCH:
 		return c.FetchArgs(line, origLine, index)
-	case parser.COMMIT:
-		return nil
-	case parser.ROLLBACK:
-		return nil
+	case parser.COMMIT, parser.ROLLBACK:
+		return c.TransactionArgs(line, origLine, index)
 	case parser.EXIT:
 		return nil
 	case parser.PWD:
@@ -511,7 +519,7 @@ func (c *Completer) FunctionArgs(line string, origLine string, index int) readli
 						}, false)
 					}
 				}
-			case parser.ROWS:
+			case parser.ROWS, parser.GROUPS:
 				if i == c.lastIdx {
 					customList = append(customList, c.candidateList([]string{
 						"UNBOUNDED PRECEDING",
@@ -565,10 +573,11 @@ func (c *Completer) FunctionArgs(line string, origLine string, index int) readli
 							if funcName == "FIRST_VALUE" ||
 								funcName == "LAST_VALUE" ||
 								funcName == "NTH_VALUE" ||
-								(funcName != "LISTAGG" && funcName != "JSON_AGG" && InStrSliceWithCaseInsensitive(funcName, c.aggFuncs)) ||
+								(funcName != "LISTAGG" && funcName != "JSON_AGG" && funcName != "ARRAY_AGG" && funcName != "PERCENTILE_CONT" && funcName != "PERCENTILE_DISC" && funcName != "NPV" && funcName != "IRR" && InStrSliceWithCaseInsensitive(funcName, c.aggFuncs)) ||
 								InStrSliceWithCaseInsensitive(funcName, c.userAggFuncs) {
 
 								customList = append(customList, c.candidate("ROWS", true))
+								customList = append(customList, c.candidate("GROUPS", true))
 							}
 						}
 					} else {
@@ -1371,7 +1380,7 @@ func (c *Completer) AlterArgs(line string, origLine string, index int) readline.
 						return nil, c.candidateList(c.lineBreakList(), false), true
 					case TableJsonEscape:
 						return nil, c.candidateList(c.jsonEscapeTypeList(), false), true
-					case TableHeader, TableEncloseAll, TablePrettyPrint:
+					case TableHeader, TableEncloseAll, TablePrettyPrint, TableEncrypted:
 						return nil, c.candidateList([]string{ternary.TRUE.String(), ternary.FALSE.String()}, false), true
 					}
 				}
@@ -1516,7 +1525,7 @@ func (c *Completer) SetArgs(line string, origLine string, index int) readline.Ca
 			case parser.TO:
 				if i == c.lastIdx && c.tokens[c.lastIdx-1].Token == parser.FLAG {
 					switch strings.ToUpper(c.tokens[c.lastIdx-1].Literal) {
-					case cmd.RepositoryFlag:
+					case cmd.RepositoryFlag, cmd.BackupDirFlag, cmd.AuditLogFlag, cmd.EncryptionKeyFileFlag, cmd.OnCommitFlag:
 						return nil, c.SearchDirs(line, origLine, index), true
 					case cmd.TimezoneFlag:
 						return nil, c.candidateList([]string{"Local", "UTC"}, false), true
@@ -1528,9 +1537,10 @@ func (c *Completer) SetArgs(line string, origLine string, index int) readline.Ca
 						return nil, c.candidateList(delimiterPositionsCandidates, false), true
 					case cmd.EncodingFlag, cmd.WriteEncodingFlag:
 						return nil, c.candidateList(c.encodingList(), false), true
-					case cmd.NoHeaderFlag, cmd.WithoutNullFlag, cmd.WithoutHeaderFlag, cmd.EncloseAll, cmd.PrettyPrintFlag,
+					case cmd.CaseSensitiveFlag,
+						cmd.NoHeaderFlag, cmd.WithoutNullFlag, cmd.WithoutHeaderFlag, cmd.EncloseAll, cmd.PrettyPrintFlag,
 						cmd.EastAsianEncodingFlag, cmd.CountDiacriticalSignFlag, cmd.CountFormatCodeFlag,
-						cmd.ColorFlag, cmd.QuietFlag, cmd.StatsFlag:
+						cmd.ColorFlag, cmd.QuietFlag, cmd.MmapFlag, cmd.ColumnarFlag, cmd.StatisticsCacheFlag, cmd.ResultCacheFlag, cmd.ProgressFlag, cmd.ReadOnlyFlag, cmd.DryRunFlag, cmd.StatsFlag:
 						return nil, c.candidateList([]string{ternary.TRUE.String(), ternary.FALSE.String()}, false), true
 					case cmd.FormatFlag:
 						return nil, c.candidateList(c.tableFormatList(), false), true
@@ -1538,6 +1548,8 @@ func (c *Completer) SetArgs(line string, origLine string, index int) readline.Ca
 						return nil, c.candidateList(c.lineBreakList(), false), true
 					case cmd.JsonEscape:
 						return nil, c.candidateList(c.jsonEscapeTypeList(), false), true
+					case cmd.LockBackoffFlag:
+						return nil, c.candidateList(c.backoffList(), false), true
 					}
 				}
 				return nil, c.SearchValues(line, origLine, index), true
@@ -1676,6 +1688,31 @@ func (c *Completer) DisposeArgs(line string, origLine string, index int) readlin
 	)
 }
 
+func (c *Completer) TransactionArgs(line string, origLine string, index int) readline.CandidateList {
+	return c.completeArgs(
+		line,
+		origLine,
+		index,
+		func(i int) (keywords []string, customList readline.CandidateList, breakLoop bool) {
+			switch c.tokens[i].Token {
+			case parser.TABLE:
+				switch i {
+				case c.lastIdx:
+					return nil, c.SearchAllTables(line, origLine, index), true
+				}
+			case parser.COMMIT, parser.ROLLBACK:
+				switch i {
+				case c.lastIdx:
+					return []string{"TABLE"}, nil, true
+				}
+			default:
+				return nil, nil, false
+			}
+			return nil, nil, true
+		},
+	)
+}
+
 func (c *Completer) ShowArgs(line string, origLine string, index int) readline.CandidateList {
 	var showChild = func() readline.CandidateList {
 		cands := c.candidateList(ShowObjectList, false)
@@ -1831,6 +1868,7 @@ func (c *Completer) SearchValues(line string, origLine string, index int) readli
 		"IS",
 		"BETWEEN",
 		"LIKE",
+		"ILIKE",
 		"IN",
 		"ANY",
 		"ALL",
@@ -2321,6 +2359,7 @@ func (c *Completer) isFunction(token parser.Token) bool {
 		token.Token == parser.IF ||
 		token.Token == parser.AGGREGATE_FUNCTION ||
 		token.Token == parser.COUNT ||
+		token.Token == parser.MODE ||
 		token.Token == parser.LIST_FUNCTION ||
 		token.Token == parser.ANALYTIC_FUNCTION ||
 		token.Token == parser.FUNCTION_NTH ||
@@ -2458,3 +2497,12 @@ func (c *Completer) jsonEscapeTypeList() []string {
 	sort.Strings(list)
 	return list
 }
+
+func (c *Completer) backoffList() []string {
+	list := make([]string, 0, len(file.BackoffLiteral))
+	for _, v := range file.BackoffLiteral {
+		list = append(list, v)
+	}
+	sort.Strings(list)
+	return list
+}
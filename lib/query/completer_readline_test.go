@@ -113,10 +113,10 @@ func TestCompleter_Update(t *testing.T) {
 	if len(c.funcs) != len(Functions)+3 {
 		t.Error("functions are not set correctly")
 	}
-	if len(c.aggFuncs) != len(AggregateFunctions)+2 {
+	if len(c.aggFuncs) != len(AggregateFunctions)+len(BivariateAggregateFunctions)+7 {
 		t.Error("aggregate functions are not set correctly")
 	}
-	if len(c.analyticFuncs) != len(AnalyticFunctions)+len(AggregateFunctions) {
+	if len(c.analyticFuncs) != len(AnalyticFunctions)+len(AggregateFunctions)+len(BivariateAggregateFunctions) {
 		t.Error("analytic functions are not set correctly")
 	}
 
@@ -136,10 +136,10 @@ func TestCompleter_Update(t *testing.T) {
 	if len(c.funcList) != len(Functions)+3+1 || !strings.HasSuffix(c.funcList[0], "()") {
 		t.Error("function list is not set correctly")
 	}
-	if len(c.aggFuncList) != len(AggregateFunctions)+2+1 || !strings.HasSuffix(c.aggFuncList[0], "()") {
+	if len(c.aggFuncList) != len(AggregateFunctions)+len(BivariateAggregateFunctions)+7+1 || !strings.HasSuffix(c.aggFuncList[0], "()") {
 		t.Error("aggregate function list is not set correctly")
 	}
-	if len(c.analyticFuncList) != len(AnalyticFunctions)+len(AggregateFunctions)+1 || !strings.HasSuffix(c.analyticFuncList[0], "() OVER ()") {
+	if len(c.analyticFuncList) != len(AnalyticFunctions)+len(AggregateFunctions)+len(BivariateAggregateFunctions)+1 || !strings.HasSuffix(c.analyticFuncList[0], "() OVER ()") {
 		t.Error("analytic function list is not set correctly")
 	}
 	if !reflect.DeepEqual(c.varList, []string{"@var"}) {
@@ -529,14 +529,18 @@ var completerStatementsTests = []completerTest{
 		Line:     "",
 		OrigLine: "commit ",
 		Index:    7,
-		Expect:   readline.CandidateList(nil),
+		Expect: readline.CandidateList{
+			{Name: []rune("TABLE"), AppendSpace: true},
+		},
 	},
 	{
 		Name:     "Statements Rollback",
 		Line:     "",
 		OrigLine: "rollback ",
 		Index:    9,
-		Expect:   readline.CandidateList(nil),
+		Expect: readline.CandidateList{
+			{Name: []rune("TABLE"), AppendSpace: true},
+		},
 	},
 	{
 		Name:     "Statements Exit",
@@ -801,6 +805,7 @@ var completerFunctionArgs = []completerTest{
 		Expect: readline.CandidateList{
 			{Name: []rune("ASC")},
 			{Name: []rune("DESC")},
+			{Name: []rune("GROUPS"), AppendSpace: true},
 			{Name: []rune("NULLS FIRST")},
 			{Name: []rune("NULLS LAST")},
 			{Name: []rune("ROWS"), AppendSpace: true},
@@ -812,6 +817,7 @@ var completerFunctionArgs = []completerTest{
 		OrigLine: "count(1) over (order by f1 asc ",
 		Index:    31,
 		Expect: readline.CandidateList{
+			{Name: []rune("GROUPS"), AppendSpace: true},
 			{Name: []rune("NULLS FIRST")},
 			{Name: []rune("NULLS LAST")},
 			{Name: []rune("ROWS"), AppendSpace: true},
@@ -833,6 +839,7 @@ var completerFunctionArgs = []completerTest{
 		OrigLine: "count(1) over (order by f1 asc nulls first ",
 		Index:    43,
 		Expect: readline.CandidateList{
+			{Name: []rune("GROUPS"), AppendSpace: true},
 			{Name: []rune("ROWS"), AppendSpace: true},
 		},
 	},
@@ -2149,6 +2156,7 @@ var completerAlterArgsTests = []completerTest{
 			{Name: []rune("DELIMITER_POSITIONS"), AppendSpace: true},
 			{Name: []rune("ENCLOSE_ALL"), AppendSpace: true},
 			{Name: []rune("ENCODING"), AppendSpace: true},
+			{Name: []rune("ENCRYPTED"), AppendSpace: true},
 			{Name: []rune("FORMAT"), AppendSpace: true},
 			{Name: []rune("HEADER"), AppendSpace: true},
 			{Name: []rune("JSON_ESCAPE"), AppendSpace: true},
@@ -2828,6 +2836,44 @@ func TestCompleter_DisposeArgs(t *testing.T) {
 	testCompleter(t, completer.DisposeArgs, completerDisposeArgsTests)
 }
 
+var completerTransactionArgsTests = []completerTest{
+	{
+		Name:     "TransactionArgs",
+		Line:     "",
+		OrigLine: "commit ",
+		Index:    7,
+		Expect: readline.CandidateList{
+			{Name: []rune("TABLE"), AppendSpace: true},
+		},
+	},
+	{
+		Name:     "TransactionArgs After TABLE",
+		Line:     "",
+		OrigLine: "rollback table ",
+		Index:    15,
+		Expect: readline.CandidateList{
+			{Name: []rune(filepath.Join(CompletionTestDir, "sub", "table2.csv")), FormatAsIdentifier: true},
+			{Name: []rune("newtable.csv"), FormatAsIdentifier: true},
+			{Name: []rune("tempview"), FormatAsIdentifier: true},
+			{Name: []rune("."), FormatAsIdentifier: true},
+			{Name: []rune(".."), FormatAsIdentifier: true},
+			{Name: []rune("sub/"), FormatAsIdentifier: true},
+			{Name: []rune("table1.csv"), FormatAsIdentifier: true},
+		},
+	},
+	{
+		Name:     "TransactionArgs After Table Name",
+		Line:     "",
+		OrigLine: "commit table table1 ",
+		Index:    20,
+		Expect:   readline.CandidateList{},
+	},
+}
+
+func TestCompleter_TransactionArgs(t *testing.T) {
+	testCompleter(t, completer.TransactionArgs, completerTransactionArgsTests)
+}
+
 var completerShowArgsTests = []completerTest{
 	{
 		Name:     "ShowArgs",
@@ -0,0 +1,55 @@
+package query
+
+import (
+	"context"
+
+	"github.com/mithrandie/csvq/lib/file"
+	"github.com/mithrandie/csvq/lib/parser"
+)
+
+// commitCoordinator returns the coordinator of the commit if the coordinator token is specified.
+// A token identifies only one coordinated commit, so the flag is cleared.
+func (tx *Transaction) commitCoordinator() *file.Coordinator {
+	if len(tx.Flags.CoordinatorToken) < 1 {
+		return nil
+	}
+
+	c := file.NewCoordinator(CoordinatorDir(), tx.Flags.CoordinatorToken, tx.Flags.Participants)
+	tx.Flags.CoordinatorToken = ""
+	return c
+}
+
+// coordinateCommit flushes the temporary files, and waits until all the participants are prepared.
+// An error is returned if the coordinated commit is aborted.
+func (tx *Transaction) coordinateCommit(expr parser.Expression, coordinator *file.Coordinator, journal *file.Journal) error {
+	if err := journal.Prepare(); err != nil {
+		_ = coordinator.Abort()
+		return NewCommitError(expr, err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), tx.WaitTimeout)
+	defer cancel()
+
+	committed, err := coordinator.Prepare(ctx, tx.RetryDelay)
+	if err != nil {
+		_ = coordinator.Abort()
+		return NewCommitError(expr, err.Error())
+	}
+	if !committed {
+		return NewCommitError(expr, "coordinated commit is aborted")
+	}
+	return nil
+}
+
+// abortCoordinatedCommit makes the other participants of the coordinated commit abort.
+// The decision is left to be read by the participants that have not been prepared yet.
+func (tx *Transaction) abortCoordinatedCommit(expr parser.Expression) error {
+	coordinator := tx.commitCoordinator()
+	if coordinator == nil {
+		return nil
+	}
+	if err := coordinator.Abort(); err != nil {
+		return NewRollbackError(expr, err.Error())
+	}
+	return nil
+}
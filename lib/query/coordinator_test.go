@@ -0,0 +1,80 @@
+package query
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/mithrandie/csvq/lib/file"
+	"github.com/mithrandie/csvq/lib/parser"
+)
+
+func TestTransaction_CoordinatedCommit(t *testing.T) {
+	fpath := GetTestFilePath("coordinated_commit_test.csv")
+	defer func() {
+		_ = TestTx.Rollback(nil, nil)
+		_ = os.Remove(fpath)
+		initFlag(TestTx.Flags)
+	}()
+
+	if err := ioutil.WriteFile(fpath, []byte("c1,c2\n1,a"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	TestTx.Flags.Repository = TestDir
+	TestTx.Flags.SetQuiet(true)
+	TestTx.Session.Stdout = NewDiscard()
+
+	execute := func(src string) error {
+		statements, _, err := parser.Parse(src, "", nil, false)
+		if err != nil {
+			t.Fatalf("unexpected parse error %q", err)
+		}
+		_, err = NewProcessor(TestTx).Execute(context.Background(), statements)
+		return err
+	}
+
+	prepare := func(token string) chan bool {
+		ch := make(chan bool, 1)
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), TestTx.WaitTimeout)
+			defer cancel()
+			c := file.NewCoordinator(CoordinatorDir(), token, 2)
+			committed, _ := c.Prepare(ctx, TestTx.RetryDelay)
+			_ = c.Close()
+			ch <- committed
+		}()
+		return ch
+	}
+
+	other := prepare("commit")
+	if err := TestTx.Flags.SetCoordinatorToken("commit"); err != nil {
+		t.Fatal(err)
+	}
+	if err := execute("INSERT INTO coordinated_commit_test VALUES (2, 'b'); COMMIT;"); err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	if !<-other {
+		t.Errorf("the other participant aborted, expect to commit")
+	}
+	if b, _ := ioutil.ReadFile(fpath); string(b) != "c1,c2\n1,a\n2,b" {
+		t.Errorf("file = %q, want %q", string(b), "c1,c2\n1,a\n2,b")
+	}
+	if TestTx.Flags.CoordinatorToken != "" {
+		t.Errorf("coordinator token = %q, want to be cleared after the commit", TestTx.Flags.CoordinatorToken)
+	}
+
+	if err := TestTx.Flags.SetCoordinatorToken("rollback"); err != nil {
+		t.Fatal(err)
+	}
+	if err := execute("INSERT INTO coordinated_commit_test VALUES (3, 'c'); ROLLBACK;"); err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	if <-prepare("rollback") {
+		t.Errorf("the other participant committed, expect to abort after the rollback")
+	}
+	if b, _ := ioutil.ReadFile(fpath); string(b) != "c1,c2\n1,a\n2,b" {
+		t.Errorf("file = %q, want the file unchanged", string(b))
+	}
+}
@@ -0,0 +1,231 @@
+package query
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+const (
+	// Maximum number of lines compared to make a sample diff, following the lines at the beginning
+	// that are not changed.
+	diffSampleWindow = 500
+
+	// Maximum number of changed lines shown in a sample diff.
+	diffSampleLines = 10
+)
+
+type diffOperation int
+
+const (
+	diffEqual diffOperation = iota
+	diffDelete
+	diffInsert
+)
+
+type diffEdit struct {
+	Operation diffOperation
+	Line      string
+}
+
+// diffLines returns the shortest edit script that changes the lines a into the lines b,
+// using Myers' difference algorithm.
+func diffLines(a []string, b []string) []diffEdit {
+	n, m := len(a), len(b)
+	max := n + m
+	offset := max + 1
+	v := make([]int, 2*max+3)
+
+	// trace[d] holds the furthest reaching x of each diagonal k in [-d, d] before the step d.
+	trace := make([][]int, 0, 8)
+
+	found := false
+	for d := 0; d <= max && !found; d++ {
+		trace = append(trace, append(make([]int, 0, 2*d+1), v[offset-d:offset+d+1]...))
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+
+			if n <= x && m <= y {
+				found = true
+				break
+			}
+		}
+	}
+
+	edits := make([]diffEdit, 0, max)
+	x, y := n, m
+	for d := len(trace) - 1; 0 < d; d-- {
+		prev := trace[d]
+		k := x - y
+
+		var prevK int
+		if k == -d || (k != d && prev[k-1+d] < prev[k+1+d]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := prev[prevK+d]
+		prevY := prevX - prevK
+
+		for prevX < x && prevY < y {
+			x--
+			y--
+			edits = append(edits, diffEdit{Operation: diffEqual, Line: a[x]})
+		}
+		if x == prevX {
+			y--
+			edits = append(edits, diffEdit{Operation: diffInsert, Line: b[y]})
+		} else {
+			x--
+			edits = append(edits, diffEdit{Operation: diffDelete, Line: a[x]})
+		}
+	}
+	for 0 < x && 0 < y {
+		x--
+		y--
+		edits = append(edits, diffEdit{Operation: diffEqual, Line: a[x]})
+	}
+
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits
+}
+
+func splitLines(b []byte) []string {
+	s := strings.ReplaceAll(string(b), "\r\n", "\n")
+	s = strings.TrimSuffix(s, "\n")
+	if len(s) < 1 {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+// SampleDiff returns a diff in unified format without context lines showing a part of the changes
+// from the contents src to the contents dst.
+// At most diffSampleLines changed lines following the unchanged lines at the beginning are shown.
+func SampleDiff(src []byte, dst []byte) string {
+	a := splitLines(src)
+	b := splitLines(dst)
+
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	if prefix == len(a) && prefix == len(b) {
+		return ""
+	}
+
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	aEnd, bEnd := len(a)-suffix, len(b)-suffix
+	truncated := false
+	if prefix+diffSampleWindow < aEnd {
+		aEnd = prefix + diffSampleWindow
+		truncated = true
+	}
+	if prefix+diffSampleWindow < bEnd {
+		bEnd = prefix + diffSampleWindow
+		truncated = true
+	}
+
+	edits := diffLines(a[prefix:aEnd], b[prefix:bEnd])
+	if truncated {
+		// The changes after the last unchanged line may be caused by cutting off the lines.
+		last := len(edits) - 1
+		for 0 <= last && edits[last].Operation != diffEqual {
+			last--
+		}
+		if 0 <= last {
+			edits = edits[:last+1]
+		}
+	}
+
+	changes := 0
+	for _, e := range edits {
+		if e.Operation != diffEqual {
+			changes++
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	aLine, bLine := prefix+1, prefix+1
+	shown := 0
+	for i := 0; i < len(edits); {
+		if edits[i].Operation == diffEqual {
+			aLine++
+			bLine++
+			i++
+			continue
+		}
+
+		var deleted, inserted []string
+		for ; i < len(edits) && edits[i].Operation != diffEqual; i++ {
+			if edits[i].Operation == diffDelete {
+				deleted = append(deleted, edits[i].Line)
+			} else {
+				inserted = append(inserted, edits[i].Line)
+			}
+		}
+
+		if rest := diffSampleLines - shown; rest < len(deleted)+len(inserted) {
+			// The beginning of a large hunk is shown as a smaller hunk with both of the deleted lines
+			// and the inserted lines.
+			d := (rest + 1) / 2
+			if len(inserted) < rest-d {
+				d = rest - len(inserted)
+			}
+			if d < len(deleted) {
+				deleted = deleted[:d]
+			}
+			if rest-len(deleted) < len(inserted) {
+				inserted = inserted[:rest-len(deleted)]
+			}
+		}
+
+		buf.WriteString(fmt.Sprintf("@@ -%s +%s @@\n", diffRange(aLine, len(deleted)), diffRange(bLine, len(inserted))))
+		for _, s := range deleted {
+			buf.WriteString("-" + s + "\n")
+		}
+		for _, s := range inserted {
+			buf.WriteString("+" + s + "\n")
+		}
+		shown += len(deleted) + len(inserted)
+		aLine += len(deleted)
+		bLine += len(inserted)
+
+		if diffSampleLines <= shown {
+			break
+		}
+	}
+
+	if shown < changes || truncated {
+		buf.WriteString("...\n")
+	}
+	return buf.String()
+}
+
+func diffRange(start int, length int) string {
+	switch length {
+	case 0:
+		return fmt.Sprintf("%d,0", start-1)
+	case 1:
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, length)
+}
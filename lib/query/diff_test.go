@@ -0,0 +1,85 @@
+package query
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var sampleDiffTests = []struct {
+	Name   string
+	Src    string
+	Dst    string
+	Expect string
+}{
+	{
+		Name:   "No Changes",
+		Src:    "c1,c2\n1,a\n2,b\n",
+		Dst:    "c1,c2\n1,a\n2,b\n",
+		Expect: "",
+	},
+	{
+		Name: "Update",
+		Src:  "c1,c2\n1,a\n2,b\n3,c\n",
+		Dst:  "c1,c2\n1,a\n2,x\n3,c\n",
+		Expect: "@@ -3 +3 @@\n" +
+			"-2,b\n" +
+			"+2,x\n",
+	},
+	{
+		Name: "Insert and Delete",
+		Src:  "c1,c2\n1,a\n2,b\n3,c\n",
+		Dst:  "c1,c2\n2,b\n3,c\n4,d\n5,e\n",
+		Expect: "@@ -2 +1,0 @@\n" +
+			"-1,a\n" +
+			"@@ -4,0 +4,2 @@\n" +
+			"+4,d\n" +
+			"+5,e\n",
+	},
+	{
+		Name: "Create",
+		Src:  "",
+		Dst:  "c1,c2\r\n1,a\r\n",
+		Expect: "@@ -0,0 +1,2 @@\n" +
+			"+c1,c2\n" +
+			"+1,a\n",
+	},
+}
+
+func TestSampleDiff(t *testing.T) {
+	for _, v := range sampleDiffTests {
+		result := SampleDiff([]byte(v.Src), []byte(v.Dst))
+		if result != v.Expect {
+			t.Errorf("%s: result = %q, want %q", v.Name, result, v.Expect)
+		}
+	}
+}
+
+func TestSampleDiff_Limit(t *testing.T) {
+	src := make([]string, 0, 1001)
+	dst := make([]string, 0, 1001)
+	src = append(src, "c1,c2")
+	dst = append(dst, "c1,c2")
+	for i := 0; i < 1000; i++ {
+		src = append(src, strconv.Itoa(i)+",a")
+		dst = append(dst, strconv.Itoa(i)+",b")
+	}
+
+	expect := "@@ -2,5 +2,5 @@\n" +
+		"-0,a\n" +
+		"-1,a\n" +
+		"-2,a\n" +
+		"-3,a\n" +
+		"-4,a\n" +
+		"+0,b\n" +
+		"+1,b\n" +
+		"+2,b\n" +
+		"+3,b\n" +
+		"+4,b\n" +
+		"...\n"
+
+	result := SampleDiff([]byte(strings.Join(src, "\n")), []byte(strings.Join(dst, "\n")))
+	if result != expect {
+		t.Errorf("result = %q, want %q", result, expect)
+	}
+}
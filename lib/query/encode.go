@@ -360,6 +360,10 @@ func ConvertFieldContents(val value.Primary, forTextTable bool) (string, string,
 	case value.Datetime:
 		s = val.(value.Datetime).Format(time.RFC3339Nano)
 		effect = cmd.DatetimeEffect
+	case value.Array:
+		s = val.(value.Array).String()
+	case value.Json:
+		s = val.(value.Json).String()
 	case value.Null:
 		if forTextTable {
 			s = "NULL"
@@ -0,0 +1,72 @@
+package query
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+
+	"github.com/mithrandie/csvq/lib/cmd"
+	"github.com/mithrandie/csvq/lib/file"
+)
+
+// decryptFile returns the reader of the contents of the file and whether the file is encrypted.
+// Encrypted files are decrypted in memory, so that the decrypted contents are never written to disk.
+func decryptFile(fp io.ReadSeeker, flags *cmd.Flags) (io.ReadSeeker, bool, error) {
+	encrypted, err := file.IsEncrypted(fp)
+	if err != nil || !encrypted {
+		return fp, false, err
+	}
+
+	key, err := flags.EncryptionKey()
+	if err != nil {
+		return nil, true, err
+	}
+	contents, err := ioutil.ReadAll(fp)
+	if err != nil {
+		return nil, true, err
+	}
+	data, err := file.Decrypt(key, contents)
+	if err != nil {
+		return nil, true, err
+	}
+	return bytes.NewReader(data), true, nil
+}
+
+// readFileContents returns the contents of the file, decrypting them if the file is encrypted.
+func readFileContents(fpath string, flags *cmd.Flags) ([]byte, error) {
+	b, err := ioutil.ReadFile(fpath)
+	if err != nil {
+		return nil, err
+	}
+
+	r, encrypted, err := decryptFile(bytes.NewReader(b), flags)
+	if err != nil || !encrypted {
+		return b, err
+	}
+	return ioutil.ReadAll(r)
+}
+
+// encodeViewToFile writes the records of the view to w in the format of the file.
+// If the file is encrypted, then the encoded records are encrypted in memory before being written.
+func encodeViewToFile(w io.Writer, view *View, fileInfo *FileInfo, flags *cmd.Flags) error {
+	if !fileInfo.Encrypted {
+		_, err := EncodeView(w, view, fileInfo, flags)
+		return err
+	}
+
+	key, err := flags.EncryptionKey()
+	if err != nil {
+		return err
+	}
+
+	buf := &bytes.Buffer{}
+	if _, err = EncodeView(buf, view, fileInfo, flags); err != nil {
+		return err
+	}
+	contents, err := file.Encrypt(key, buf.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(contents)
+	return err
+}
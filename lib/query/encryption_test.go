@@ -0,0 +1,68 @@
+package query
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/mithrandie/csvq/lib/file"
+	"github.com/mithrandie/csvq/lib/parser"
+)
+
+func TestEncryptedFile(t *testing.T) {
+	fpath := GetTestFilePath("encrypted_test.csv")
+	key := bytes.Repeat([]byte{1}, 32)
+	defer func() {
+		_ = TestTx.Rollback(nil, nil)
+		_ = os.Remove(fpath)
+		_ = os.Unsetenv(file.EncryptionKeyEnv)
+		initFlag(TestTx.Flags)
+	}()
+
+	contents, _ := file.Encrypt(key, []byte("c1,c2\n1,a\n2,b"))
+	if err := ioutil.WriteFile(fpath, contents, 0644); err != nil {
+		t.Fatal(err)
+	}
+	_ = os.Setenv(file.EncryptionKeyEnv, base64.StdEncoding.EncodeToString(key))
+
+	TestTx.Flags.Repository = TestDir
+	TestTx.Flags.SetQuiet(true)
+	TestTx.Session.Stdout = NewDiscard()
+
+	statements, _, err := parser.Parse(""+
+		"UPDATE encrypted_test SET c2 = 'x' WHERE c1 = 1;"+
+		"INSERT INTO encrypted_test VALUES (3, 'c');"+
+		"COMMIT;", "", nil, false)
+	if err != nil {
+		t.Fatalf("unexpected parse error %q", err)
+	}
+
+	proc := NewProcessor(TestTx)
+	if _, err = proc.Execute(context.Background(), statements); err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	b, _ := ioutil.ReadFile(fpath)
+	if bytes.Contains(b, []byte("c1,c2")) {
+		t.Fatalf("file is written without encryption")
+	}
+	data, err := file.Decrypt(key, b)
+	if err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	if string(data) != "c1,c2\n1,x\n2,b\n3,c" {
+		t.Errorf("decrypted contents = %q, want %q", string(data), "c1,c2\n1,x\n2,b\n3,c")
+	}
+
+	_ = os.Setenv(file.EncryptionKeyEnv, base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{2}, 32)))
+	statements, _, _ = parser.Parse("SELECT * FROM encrypted_test;", "", nil, false)
+	expect := "[L:1 C:15] data parse error in file " + fpath + ": decryption failed with the encryption key"
+	if _, err = proc.Execute(context.Background(), statements); err == nil {
+		t.Errorf("no error, want error %q", expect)
+	} else if err.Error() != expect {
+		t.Errorf("error %q, want error %q", err.Error(), expect)
+	}
+}
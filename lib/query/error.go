@@ -66,7 +66,9 @@ const (
 	ErrMsgFileNotExist                         = "file %s does not exist"
 	ErrMsgFileAlreadyExist                     = "file %s already exists"
 	ErrMsgFileUnableToRead                     = "file %s is unable to be read"
+	ErrMsgFileReadOnly                         = "file %s cannot be changed in read-only mode"
 	ErrMsgFileLockTimeout                      = "file %s: lock wait timeout period exceeded"
+	ErrMsgFileLockRetryLimit                   = "file %s: lock retry limit exceeded"
 	ErrMsgFileNameAmbiguous                    = "filename %s is ambiguous"
 	ErrMsgDataParsing                          = "data parse error in file %s: %s"
 	ErrMsgTableFieldLength                     = "select query should return exactly %s for table %s"
@@ -115,6 +117,41 @@ const (
 	ErrMsgDuplicateStatementName               = "statement %s is a duplicate"
 	ErrMsgStatementNotExist                    = "statement %s does not exist"
 	ErrMsgStatementReplaceValueNotSpecified    = "replace value for %s is not specified"
+	ErrMsgInvalidTableSamplePercentage         = "tablesample percentage %s is not a float value"
+	ErrMsgInvalidTableSampleNumber             = "tablesample number of records %s is not an integer value"
+	ErrMsgInvalidTableSampleSeed               = "tablesample seed %s is not an integer value"
+	ErrMsgInvalidPivotAggregation              = "pivot aggregation %s must be an aggregate function with a column"
+	ErrMsgMergeRecordAmbiguous                 = "record to merge in %s is matched with multiple source records"
+	ErrMsgReplaceKeyNotSet                     = "replace key %s is not set"
+	ErrMsgReturningMultipleTables              = "%s cannot be used when multiple tables are modified"
+	ErrMsgTableColumnAliasesLength             = "column aliases for table %s should contain exactly %s"
+	ErrMsgTableColumnAliasesForUpdate          = "column aliases cannot be specified for table %s to be updated"
+	ErrMsgRecursionExceededLimit               = "iteration of recursive query %s exceeded the limit %d"
+	ErrMsgIndexRedeclared                      = "index %s is redeclared"
+	ErrMsgUndeclaredIndex                      = "index %s is undeclared"
+	ErrMsgIndexTableNotSupported               = "index cannot be declared on %s that is not a file"
+	ErrMsgNotNullConstraintViolation           = "column %s in table %s cannot be null"
+	ErrMsgUniqueConstraintViolation            = "value %s is duplicated in column %s in table %s"
+	ErrMsgCheckConstraintViolation             = "value %s in table %s violates the constraint %s"
+	ErrMsgRecursiveViewDefinition              = "view %s is defined recursively"
+	ErrMsgPrimaryKeyViolation                  = "value %s is duplicated in primary key of table %s"
+	ErrMsgUnnestNotArray                       = "value %s in %s is not an array"
+	ErrMsgInvalidParameterType                 = "%s is not a valid parameter type"
+	ErrMsgParameterTypeMismatch                = "value %s for %s cannot be converted to %s"
+	ErrMsgUnsupportedParameterValue            = "value of type %T is not supported as a parameter"
+	ErrMsgInvalidCollation                     = "%q is not a valid collation"
+	ErrMsgSequenceRedeclared                   = "sequence %s is redeclared"
+	ErrMsgUndeclaredSequence                   = "sequence %s is undeclared"
+	ErrMsgInvalidSequenceParameter             = "invalid %s value for sequence %s"
+	ErrMsgUnsupportedFunctionLanguage          = "language %s is not supported for user defined functions"
+	ErrMsgFunctionScript                       = "%s in function %s"
+	ErrMsgMemoryLimitExceeded                  = "records cannot be held within the memory limit of %d megabytes"
+	ErrMsgExplainInvalidOption                 = "explain option %s is invalid"
+	ErrMsgUnlockUncommittedTable               = "table %s cannot be unlocked before its changes are committed"
+	ErrMsgExternalFunctionInReadOnlyMode       = "function %s written in language %s cannot be declared in read-only mode"
+	ErrMsgCallInReadOnlyMode                   = "function %s cannot run commands in read-only mode"
+	ErrMsgRestrictedStatement                  = "%s is not allowed in restricted mode"
+	ErrMsgLoadConstraints                      = "constraints of file %s cannot be loaded: %s"
 )
 
 type Error interface {
@@ -830,6 +867,16 @@ func NewFileUnableToReadError(file parser.Identifier) error {
 	}
 }
 
+type FileReadOnlyError struct {
+	*BaseError
+}
+
+func NewFileReadOnlyError(file parser.Identifier) error {
+	return &FileReadOnlyError{
+		NewBaseError(file, fmt.Sprintf(ErrMsgFileReadOnly, file), ReturnCodeIOError, ErrorFileReadOnly),
+	}
+}
+
 type FileLockTimeoutError struct {
 	*BaseError
 }
@@ -840,6 +887,16 @@ func NewFileLockTimeoutError(file parser.Identifier, path string) error {
 	}
 }
 
+type FileLockRetryLimitError struct {
+	*BaseError
+}
+
+func NewFileLockRetryLimitError(file parser.Identifier, path string) error {
+	return &FileLockRetryLimitError{
+		NewBaseError(file, fmt.Sprintf(ErrMsgFileLockRetryLimit, path), ReturnCodeContextIsDone, ErrorFileLockRetryLimit),
+	}
+}
+
 type FileNameAmbiguousError struct {
 	*BaseError
 }
@@ -1330,18 +1387,378 @@ func NewStatementReplaceValueNotSpecifiedError(placeholder parser.Placeholder) e
 	}
 }
 
-func searchSelectClause(query parser.SelectQuery) parser.SelectClause {
+type InvalidTableSamplePercentageError struct {
+	*BaseError
+}
+
+func NewInvalidTableSamplePercentageError(sample parser.TableSample) error {
+	return &InvalidTableSamplePercentageError{
+		NewBaseError(sample, fmt.Sprintf(ErrMsgInvalidTableSamplePercentage, sample.Value), ReturnCodeApplicationError, ErrorInvalidTableSamplePercentage),
+	}
+}
+
+type InvalidTableSampleNumberError struct {
+	*BaseError
+}
+
+func NewInvalidTableSampleNumberError(sample parser.TableSample) error {
+	return &InvalidTableSampleNumberError{
+		NewBaseError(sample, fmt.Sprintf(ErrMsgInvalidTableSampleNumber, sample.Value), ReturnCodeApplicationError, ErrorInvalidTableSampleNumber),
+	}
+}
+
+type InvalidTableSampleSeedError struct {
+	*BaseError
+}
+
+func NewInvalidTableSampleSeedError(sample parser.TableSample) error {
+	return &InvalidTableSampleSeedError{
+		NewBaseError(sample, fmt.Sprintf(ErrMsgInvalidTableSampleSeed, sample.Seed), ReturnCodeApplicationError, ErrorInvalidTableSampleSeed),
+	}
+}
+
+type InvalidPivotAggregationError struct {
+	*BaseError
+}
+
+func NewInvalidPivotAggregationError(pivot parser.Pivot) error {
+	return &InvalidPivotAggregationError{
+		NewBaseError(pivot, fmt.Sprintf(ErrMsgInvalidPivotAggregation, pivot.Aggregate), ReturnCodeApplicationError, ErrorInvalidPivotAggregation),
+	}
+}
+
+type MergeRecordAmbiguousError struct {
+	*BaseError
+}
+
+func NewMergeRecordAmbiguousError(query parser.MergeQuery) error {
+	return &MergeRecordAmbiguousError{
+		NewBaseError(query, fmt.Sprintf(ErrMsgMergeRecordAmbiguous, query.Table.Name()), ReturnCodeApplicationError, ErrorMergeRecordAmbiguous),
+	}
+}
+
+type ReplaceKeyNotSetError struct {
+	*BaseError
+}
+
+func NewReplaceKeyNotSetError(key parser.QueryExpression) error {
+	return &ReplaceKeyNotSetError{
+		NewBaseError(key, fmt.Sprintf(ErrMsgReplaceKeyNotSet, key), ReturnCodeApplicationError, ErrorReplaceKeyNotSet),
+	}
+}
+
+type ReturningMultipleTablesError struct {
+	*BaseError
+}
+
+func NewReturningMultipleTablesError(clause parser.ReturningClause) error {
+	return &ReturningMultipleTablesError{
+		NewBaseError(clause, fmt.Sprintf(ErrMsgReturningMultipleTables, clause.Returning), ReturnCodeApplicationError, ErrorReturningMultipleTables),
+	}
+}
+
+type TableColumnAliasesLengthError struct {
+	*BaseError
+}
+
+func NewTableColumnAliasesLengthError(table parser.Table, fieldLen int) error {
+	return &TableColumnAliasesLengthError{
+		NewBaseError(table.Alias, fmt.Sprintf(ErrMsgTableColumnAliasesLength, table.Name(), FormatCount(fieldLen, "field")), ReturnCodeApplicationError, ErrorTableColumnAliasesLength),
+	}
+}
+
+type TableColumnAliasesForUpdateError struct {
+	*BaseError
+}
+
+func NewTableColumnAliasesForUpdateError(table parser.Table) error {
+	return &TableColumnAliasesForUpdateError{
+		NewBaseError(table.Alias, fmt.Sprintf(ErrMsgTableColumnAliasesForUpdate, table.Name()), ReturnCodeApplicationError, ErrorTableColumnAliasesForUpdate),
+	}
+}
+
+type RecursionExceededLimitError struct {
+	*BaseError
+}
+
+func NewRecursionExceededLimitError(table parser.InlineTable, limit int) error {
+	return &RecursionExceededLimitError{
+		NewBaseError(table.Name, fmt.Sprintf(ErrMsgRecursionExceededLimit, table.Name, limit), ReturnCodeApplicationError, ErrorRecursionExceededLimit),
+	}
+}
+
+type IndexRedeclaredError struct {
+	*BaseError
+}
+
+func NewIndexRedeclaredError(index parser.Identifier) error {
+	return &IndexRedeclaredError{
+		NewBaseError(index, fmt.Sprintf(ErrMsgIndexRedeclared, index), ReturnCodeApplicationError, ErrorIndexRedeclared),
+	}
+}
+
+type UndeclaredIndexError struct {
+	*BaseError
+}
+
+func NewUndeclaredIndexError(index parser.Identifier) error {
+	return &UndeclaredIndexError{
+		NewBaseError(index, fmt.Sprintf(ErrMsgUndeclaredIndex, index), ReturnCodeApplicationError, ErrorUndeclaredIndex),
+	}
+}
+
+type IndexTableNotSupportedError struct {
+	*BaseError
+}
+
+func NewIndexTableNotSupportedError(table parser.QueryExpression) error {
+	return &IndexTableNotSupportedError{
+		NewBaseError(table, fmt.Sprintf(ErrMsgIndexTableNotSupported, table), ReturnCodeApplicationError, ErrorIndexTableNotSupported),
+	}
+}
+
+type NotNullConstraintViolationError struct {
+	*BaseError
+}
+
+func NewNotNullConstraintViolationError(expr parser.Expression, constraint parser.ColumnConstraint, table string) error {
+	return &NotNullConstraintViolationError{
+		NewBaseError(expr, fmt.Sprintf(ErrMsgNotNullConstraintViolation, constraint.Column, table), ReturnCodeApplicationError, ErrorNotNullConstraintViolation),
+	}
+}
+
+type UniqueConstraintViolationError struct {
+	*BaseError
+}
+
+func NewUniqueConstraintViolationError(expr parser.Expression, constraint parser.ColumnConstraint, table string, val value.Primary) error {
+	return &UniqueConstraintViolationError{
+		NewBaseError(expr, fmt.Sprintf(ErrMsgUniqueConstraintViolation, val, constraint.Column, table), ReturnCodeApplicationError, ErrorUniqueConstraintViolation),
+	}
+}
+
+type CheckConstraintViolationError struct {
+	*BaseError
+}
+
+func NewCheckConstraintViolationError(expr parser.Expression, constraint parser.ColumnConstraint, table string, val value.Primary) error {
+	return &CheckConstraintViolationError{
+		NewBaseError(expr, fmt.Sprintf(ErrMsgCheckConstraintViolation, val, table, constraint), ReturnCodeApplicationError, ErrorCheckConstraintViolation),
+	}
+}
+
+type RecursiveViewDefinitionError struct {
+	*BaseError
+}
+
+func NewRecursiveViewDefinitionError(view parser.Identifier) error {
+	return &RecursiveViewDefinitionError{
+		NewBaseError(view, fmt.Sprintf(ErrMsgRecursiveViewDefinition, view), ReturnCodeApplicationError, ErrorRecursiveViewDefinition),
+	}
+}
+
+type PrimaryKeyViolationError struct {
+	*BaseError
+}
+
+func NewPrimaryKeyViolationError(expr parser.Expression, table string, values []value.Primary) error {
+	var key string
+	if len(values) == 1 {
+		key = values[0].String()
+	} else {
+		list := make([]string, len(values))
+		for i, v := range values {
+			list[i] = v.String()
+		}
+		key = "(" + strings.Join(list, ", ") + ")"
+	}
+
+	return &PrimaryKeyViolationError{
+		NewBaseError(expr, fmt.Sprintf(ErrMsgPrimaryKeyViolation, key, table), ReturnCodeApplicationError, ErrorPrimaryKeyViolation),
+	}
+}
+
+type UnnestNotArrayError struct {
+	*BaseError
+}
+
+func NewUnnestNotArrayError(expr parser.Unnest, val value.Primary) error {
+	return &UnnestNotArrayError{
+		NewBaseError(expr, fmt.Sprintf(ErrMsgUnnestNotArray, val, expr), ReturnCodeApplicationError, ErrorUnnestNotArray),
+	}
+}
+
+type InvalidParameterTypeError struct {
+	*BaseError
+}
+
+func NewInvalidParameterTypeError(param parser.StatementParameter) error {
+	return &InvalidParameterTypeError{
+		NewBaseError(param.Type, fmt.Sprintf(ErrMsgInvalidParameterType, param.Type), ReturnCodeApplicationError, ErrorInvalidParameterType),
+	}
+}
+
+type ParameterTypeMismatchError struct {
+	*BaseError
+}
+
+func NewParameterTypeMismatchError(placeholder parser.Placeholder, val value.Primary, paramType string) error {
+	return &ParameterTypeMismatchError{
+		NewBaseError(placeholder, fmt.Sprintf(ErrMsgParameterTypeMismatch, val, placeholder, paramType), ReturnCodeApplicationError, ErrorParameterTypeMismatch),
+	}
+}
+
+type UnsupportedParameterValueError struct {
+	*BaseError
+}
+
+func NewUnsupportedParameterValueError(val interface{}) error {
+	return &UnsupportedParameterValueError{
+		NewBaseError(parser.NewNullValue(), fmt.Sprintf(ErrMsgUnsupportedParameterValue, val), ReturnCodeApplicationError, ErrorUnsupportedParameterValue),
+	}
+}
+
+type InvalidCollationError struct {
+	*BaseError
+}
+
+func NewInvalidCollationError(collation parser.Collation) error {
+	return &InvalidCollationError{
+		NewBaseError(collation, fmt.Sprintf(ErrMsgInvalidCollation, collation.Name), ReturnCodeApplicationError, ErrorInvalidCollation),
+	}
+}
+
+type SequenceRedeclaredError struct {
+	*BaseError
+}
+
+func NewSequenceRedeclaredError(sequence parser.Identifier) error {
+	return &SequenceRedeclaredError{
+		NewBaseError(sequence, fmt.Sprintf(ErrMsgSequenceRedeclared, sequence), ReturnCodeApplicationError, ErrorSequenceRedeclared),
+	}
+}
+
+type UndeclaredSequenceError struct {
+	*BaseError
+}
+
+func NewUndeclaredSequenceError(sequence parser.Identifier) error {
+	return &UndeclaredSequenceError{
+		NewBaseError(sequence, fmt.Sprintf(ErrMsgUndeclaredSequence, sequence), ReturnCodeApplicationError, ErrorUndeclaredSequence),
+	}
+}
+
+type InvalidSequenceParameterError struct {
+	*BaseError
+}
+
+func NewInvalidSequenceParameterError(expr parser.QueryExpression, param string, sequence parser.Identifier) error {
+	return &InvalidSequenceParameterError{
+		NewBaseError(expr, fmt.Sprintf(ErrMsgInvalidSequenceParameter, param, sequence), ReturnCodeApplicationError, ErrorInvalidSequenceParameter),
+	}
+}
+
+type UnsupportedFunctionLanguageError struct {
+	*BaseError
+}
+
+func NewUnsupportedFunctionLanguageError(language parser.Identifier) error {
+	return &UnsupportedFunctionLanguageError{
+		NewBaseError(language, fmt.Sprintf(ErrMsgUnsupportedFunctionLanguage, language.Literal), ReturnCodeApplicationError, ErrorUnsupportedFunctionLanguage),
+	}
+}
+
+type FunctionScriptError struct {
+	*BaseError
+}
+
+func NewFunctionScriptError(name parser.Identifier, message string) error {
+	return &FunctionScriptError{
+		NewBaseError(name, fmt.Sprintf(ErrMsgFunctionScript, message, name.Literal), ReturnCodeApplicationError, ErrorFunctionScript),
+	}
+}
+
+type MemoryLimitExceededError struct {
+	*BaseError
+}
+
+func NewMemoryLimitExceededError(limit int) error {
+	return &MemoryLimitExceededError{
+		NewBaseErrorWithPrefix("Memory Limit", fmt.Sprintf(ErrMsgMemoryLimitExceeded, limit), ReturnCodeApplicationError, ErrorMemoryLimitExceeded),
+	}
+}
+
+type ExplainInvalidOptionError struct {
+	*BaseError
+}
+
+func NewExplainInvalidOptionError(option parser.Identifier) error {
+	return &ExplainInvalidOptionError{
+		NewBaseError(option, fmt.Sprintf(ErrMsgExplainInvalidOption, option.Literal), ReturnCodeApplicationError, ErrorExplainInvalidOption),
+	}
+}
+
+type UnlockUncommittedTableError struct {
+	*BaseError
+}
+
+func NewUnlockUncommittedTableError(expr parser.Expression, table string) error {
+	return &UnlockUncommittedTableError{
+		NewBaseError(expr, fmt.Sprintf(ErrMsgUnlockUncommittedTable, table), ReturnCodeApplicationError, ErrorUnlockUncommittedTable),
+	}
+}
+
+type ExternalFunctionInReadOnlyModeError struct {
+	*BaseError
+}
+
+func NewExternalFunctionInReadOnlyModeError(expr parser.FunctionDeclaration) error {
+	return &ExternalFunctionInReadOnlyModeError{
+		NewBaseError(expr.Name, fmt.Sprintf(ErrMsgExternalFunctionInReadOnlyMode, expr.Name.Literal, expr.Language.Literal), ReturnCodeApplicationError, ErrorExternalFunctionInReadOnlyMode),
+	}
+}
+
+func NewCallInReadOnlyModeError(expr parser.Function) error {
+	return &ExternalFunctionInReadOnlyModeError{
+		NewBaseError(expr, fmt.Sprintf(ErrMsgCallInReadOnlyMode, expr.Name), ReturnCodeApplicationError, ErrorExternalFunctionInReadOnlyMode),
+	}
+}
+
+type RestrictedStatementError struct {
+	*BaseError
+}
+
+func NewRestrictedStatementError(expr parser.Expression, statement string) error {
+	return &RestrictedStatementError{
+		NewBaseError(expr, fmt.Sprintf(ErrMsgRestrictedStatement, statement), ReturnCodeApplicationError, ErrorRestrictedStatement),
+	}
+}
+
+type LoadConstraintsError struct {
+	*BaseError
+}
+
+func NewLoadConstraintsError(expr parser.Expression, fpath string, message string) error {
+	return &LoadConstraintsError{
+		NewBaseError(expr, fmt.Sprintf(ErrMsgLoadConstraints, fpath, message), ReturnCodeApplicationError, ErrorLoadConstraints),
+	}
+}
+
+func searchSelectClause(query parser.SelectQuery) parser.QueryExpression {
 	return searchSelectClauseInSelectEntity(query.SelectEntity)
 }
 
-func searchSelectClauseInSelectEntity(selectEntity parser.QueryExpression) parser.SelectClause {
-	if entity, ok := selectEntity.(parser.SelectEntity); ok {
-		return entity.SelectClause.(parser.SelectClause)
+func searchSelectClauseInSelectEntity(selectEntity parser.QueryExpression) parser.QueryExpression {
+	switch entity := selectEntity.(type) {
+	case parser.SelectEntity:
+		return entity.SelectClause
+	case parser.ValuesTable:
+		return entity
 	}
 	return searchSelectClauseInSelectSetEntity(selectEntity.(parser.SelectSet).LHS)
 }
 
-func searchSelectClauseInSelectSetEntity(selectSetEntity parser.QueryExpression) parser.SelectClause {
+func searchSelectClauseInSelectSetEntity(selectSetEntity parser.QueryExpression) parser.QueryExpression {
 	if subquery, ok := selectSetEntity.(parser.Subquery); ok {
 		return searchSelectClause(subquery.Query)
 	}
@@ -1352,6 +1769,8 @@ func ConvertFileHandlerError(err error, ident parser.Identifier, fpath string) e
 	switch err.(type) {
 	case *file.TimeoutError:
 		err = NewFileLockTimeoutError(ident, fpath)
+	case *file.RetryLimitError:
+		err = NewFileLockRetryLimitError(ident, fpath)
 	case *file.ContextIsDone:
 		err = NewContextIsDone(err.Error())
 	default:
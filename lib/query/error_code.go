@@ -24,10 +24,12 @@ const (
 	ErrorFileNotExist     = 2201
 	ErrorFileAlreadyExist = 2202
 	ErrorFileUnableToRead = 2203
+	ErrorFileReadOnly     = 2204
 
 	//Context Error
-	ErrorContextIsDone   = 4000
-	ErrorFileLockTimeout = 4001
+	ErrorContextIsDone      = 4000
+	ErrorFileLockTimeout    = 4001
+	ErrorFileLockRetryLimit = 4002
 
 	//Syntax Error
 	ErrorSyntaxError                  = 8000
@@ -120,6 +122,40 @@ const (
 	ErrorDuplicateStatementName               = 16082
 	ErrorStatementNotExist                    = 16083
 	ErrorStatementReplaceValueNotSpecified    = 16084
+	ErrorInvalidTableSamplePercentage         = 16085
+	ErrorInvalidTableSampleNumber             = 16086
+	ErrorInvalidTableSampleSeed               = 16087
+	ErrorInvalidPivotAggregation              = 16088
+	ErrorMergeRecordAmbiguous                 = 16089
+	ErrorReplaceKeyNotSet                     = 16090
+	ErrorReturningMultipleTables              = 16091
+	ErrorTableColumnAliasesLength             = 16092
+	ErrorTableColumnAliasesForUpdate          = 16093
+	ErrorRecursionExceededLimit               = 16094
+	ErrorIndexRedeclared                      = 16095
+	ErrorUndeclaredIndex                      = 16096
+	ErrorIndexTableNotSupported               = 16097
+	ErrorNotNullConstraintViolation           = 16098
+	ErrorUniqueConstraintViolation            = 16099
+	ErrorCheckConstraintViolation             = 16100
+	ErrorRecursiveViewDefinition              = 16101
+	ErrorPrimaryKeyViolation                  = 16102
+	ErrorUnnestNotArray                       = 16103
+	ErrorInvalidParameterType                 = 16104
+	ErrorParameterTypeMismatch                = 16105
+	ErrorUnsupportedParameterValue            = 16106
+	ErrorInvalidCollation                     = 16107
+	ErrorSequenceRedeclared                   = 16108
+	ErrorUndeclaredSequence                   = 16109
+	ErrorInvalidSequenceParameter             = 16110
+	ErrorUnsupportedFunctionLanguage          = 16111
+	ErrorFunctionScript                       = 16112
+	ErrorMemoryLimitExceeded                  = 16113
+	ErrorExplainInvalidOption                 = 16114
+	ErrorUnlockUncommittedTable               = 16115
+	ErrorExternalFunctionInReadOnlyMode       = 16116
+	ErrorRestrictedStatement                  = 16117
+	ErrorLoadConstraints                      = 16118
 
 	//User Triggered Error
 	ErrorExit          = 32000
@@ -0,0 +1,862 @@
+package query
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mithrandie/csvq/lib/cmd"
+	"github.com/mithrandie/csvq/lib/parser"
+	"github.com/mithrandie/csvq/lib/value"
+)
+
+// PlanNode is an operation in the execution plan of a query.
+type PlanNode struct {
+	Operation  string
+	Attributes []PlanAttribute
+
+	// EstimatedRows is the estimated number of records returned by the operation, or -1 if it is unknown.
+	EstimatedRows int
+
+	// Analyzed is true if the operation has been executed by EXPLAIN ANALYZE.
+	// ActualRows is the total number of records returned by the operation in the Loops executions,
+	// and ActualTime is the total elapsed time of the operation excluding the child operations.
+	Analyzed   bool
+	ActualRows int
+	Loops      int
+	ActualTime time.Duration
+
+	Children []*PlanNode
+}
+
+type PlanAttribute struct {
+	Name  string
+	Value string
+}
+
+func NewPlanNode(operation string, estimatedRows int, children ...*PlanNode) *PlanNode {
+	return &PlanNode{
+		Operation:     operation,
+		EstimatedRows: estimatedRows,
+		Children:      children,
+	}
+}
+
+func (node *PlanNode) AddAttribute(name string, value string) *PlanNode {
+	node.Attributes = append(node.Attributes, PlanAttribute{Name: name, Value: value})
+	return node
+}
+
+func (node *PlanNode) write(w *ObjectWriter) {
+	w.WriteColorWithoutLineBreak(node.Operation, cmd.ObjectEffect)
+	w.WriteWithoutLineBreak("  (estimated rows: ")
+	if node.EstimatedRows < 0 {
+		w.WriteWithoutLineBreak("unknown")
+	} else {
+		w.WriteColorWithoutLineBreak(strconv.Itoa(node.EstimatedRows), cmd.NumberEffect)
+	}
+	if node.Analyzed {
+		w.WriteWithoutLineBreak(", actual rows: ")
+		w.WriteColorWithoutLineBreak(strconv.Itoa(node.ActualRows), cmd.NumberEffect)
+		if 1 < node.Loops {
+			w.WriteWithoutLineBreak(", loops: ")
+			w.WriteColorWithoutLineBreak(strconv.Itoa(node.Loops), cmd.NumberEffect)
+		}
+		w.WriteWithoutLineBreak(", time: " + formatSeconds(node.ActualTime))
+	}
+	w.WriteWithoutLineBreak(")")
+
+	w.BeginBlock()
+	for _, attr := range node.Attributes {
+		w.NewLine()
+		w.WriteColorWithoutLineBreak(attr.Name+": ", cmd.LableEffect)
+		w.WriteWithoutLineBreak(attr.Value)
+	}
+	for _, child := range node.Children {
+		w.NewLine()
+		w.WriteWithoutLineBreak("-> ")
+		child.write(w)
+	}
+	w.EndBlock()
+}
+
+// Explain returns the execution plan of the query.
+//
+// Without options, the query is not executed. The tables in the FROM clauses are loaded to estimate
+// the numbers of records, but the other operations such as joins, filtering and grouping are not executed.
+// With the ANALYZE option, the query is executed and the plan shows the actual numbers of records and
+// the elapsed times of the operations, and the times spent to load the files.
+func Explain(ctx context.Context, filter *Filter, expr parser.Explain) (string, error) {
+	query := expr.Query.(parser.SelectQuery)
+
+	var plan *PlanNode
+	var files []FileLoadStat
+	var elapsed time.Duration
+	var err error
+	switch {
+	case len(expr.Option.Literal) < 1:
+		plan, err = PlanQuery(ctx, filter, query, true)
+	case strings.EqualFold(expr.Option.Literal, "ANALYZE"):
+		plan, files, elapsed, err = AnalyzeQuery(ctx, filter, query)
+	default:
+		err = NewExplainInvalidOptionError(expr.Option)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	w := NewObjectWriter(filter.tx)
+	plan.write(w)
+	if plan.Analyzed {
+		w.NewLine()
+		for _, stat := range files {
+			w.NewLine()
+			w.WriteColorWithoutLineBreak("File Load: ", cmd.LableEffect)
+			w.WriteWithoutLineBreak(stat.Path)
+			w.BeginBlock()
+			w.NewLine()
+			w.WriteColorWithoutLineBreak("Records: ", cmd.LableEffect)
+			w.WriteColorWithoutLineBreak(strconv.Itoa(stat.Records), cmd.NumberEffect)
+			w.NewLine()
+			w.WriteColorWithoutLineBreak("Wait Time: ", cmd.LableEffect)
+			w.WriteWithoutLineBreak(formatSeconds(stat.WaitTime))
+			w.NewLine()
+			w.WriteColorWithoutLineBreak("Parse Time: ", cmd.LableEffect)
+			w.WriteWithoutLineBreak(formatSeconds(stat.ParseTime))
+			w.EndBlock()
+		}
+		w.NewLine()
+		w.WriteColorWithoutLineBreak("Execution Time: ", cmd.LableEffect)
+		w.WriteWithoutLineBreak(formatSeconds(elapsed))
+	}
+	w.Title1 = "Query Plan"
+	return "\n" + w.String() + "\n", nil
+}
+
+// AnalyzeQuery executes the query, and returns the execution plan with the results of the operations,
+// the times spent to load the files, and the execution time of the query.
+// The query is executed without streaming.
+func AnalyzeQuery(ctx context.Context, parentFilter *Filter, query parser.SelectQuery) (*PlanNode, []FileLoadStat, time.Duration, error) {
+	filter := parentFilter.CreateNodeForColumnPruning(query)
+	filter.recorder = newOperationRecorder()
+
+	start := time.Now()
+	if _, err := Select(ctx, filter, query); err != nil {
+		return nil, nil, 0, err
+	}
+	elapsed := time.Since(start)
+	filter.recorder.stop()
+
+	plan, err := PlanQuery(ctx, filter, query, false)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	plan.Analyzed = true
+	return plan, filter.recorder.files, elapsed, nil
+}
+
+// PlanQuery returns the execution plan of the query.
+// If topLevel is true, then the plan of streaming execution is returned if the query can be executed in streaming mode.
+func PlanQuery(ctx context.Context, parentFilter *Filter, query parser.SelectQuery, topLevel bool) (*PlanNode, error) {
+	filter := parentFilter.CreateNode()
+
+	if topLevel {
+		switch filter.tx.Flags.Format {
+		case cmd.CSV, cmd.TSV, cmd.LTSV:
+			if entity, table, ok := streamableQuery(filter, query); ok {
+				if fileInfo, ok := streamableFileInfo(filter, table.Object.(parser.Identifier)); ok {
+					return planStream(ctx, filter, query, entity, table, fileInfo)
+				}
+			}
+		}
+	}
+
+	if query.WithClause != nil {
+		if err := filter.LoadInlineTable(ctx, query.WithClause.(parser.WithClause)); err != nil {
+			return nil, err
+		}
+	}
+
+	node, err := planSelectEntity(ctx, filter, query.SelectEntity)
+	if err != nil {
+		return nil, err
+	}
+
+	if query.OrderByClause != nil {
+		node = NewPlanNode("Sort", node.EstimatedRows, node).
+			AddAttribute("Keys", listExpressions(query.OrderByClause.(parser.OrderByClause).Items))
+		filter.recorder.annotate(node, newOperationKey("sort", query))
+	}
+	return planOffsetAndLimit(filter, query, node), nil
+}
+
+func planOffsetAndLimit(filter *Filter, query parser.SelectQuery, node *PlanNode) *PlanNode {
+	if query.OffsetClause != nil {
+		clause := query.OffsetClause.(parser.OffsetClause)
+		rows := node.EstimatedRows
+		if n, ok := constantInteger(clause.Value); ok && -1 < rows {
+			rows = rows - n
+			if rows < 0 {
+				rows = 0
+			}
+		}
+		node = NewPlanNode("Offset", rows, node).AddAttribute("Clause", clause.String())
+		filter.recorder.annotate(node, newOperationKey("offset", query))
+	}
+
+	if query.LimitClause != nil {
+		clause := query.LimitClause.(parser.LimitClause)
+		rows := node.EstimatedRows
+		if n, ok := constantInteger(clause.Value); ok {
+			if clause.IsPercentage() {
+				if -1 < rows {
+					rows = rows * n / 100
+				}
+			} else if !clause.IsWithTies() && (rows < 0 || n < rows) {
+				rows = n
+			}
+		}
+		node = NewPlanNode("Limit", rows, node).AddAttribute("Clause", clause.String())
+		filter.recorder.annotate(node, newOperationKey("limit", query))
+	}
+	return node
+}
+
+// planStream returns the plan of a query executed in streaming mode by SelectStream.
+// Only the header of the file is read.
+func planStream(ctx context.Context, filter *Filter, query parser.SelectQuery, entity parser.SelectEntity, table parser.Table, fileInfo *FileInfo) (*PlanNode, error) {
+	fp, _, header, err := openStreamReader(ctx, filter, table, fileInfo)
+	if err != nil {
+		return nil, err
+	}
+	if err = fp.Close(); err != nil {
+		return nil, err
+	}
+
+	node := NewPlanNode("Stream Scan", -1).
+		AddAttribute("Table", table.String()).
+		AddAttribute("File", fileInfo.Path).
+		AddAttribute("Format", fileInfo.Format.String())
+
+	if entity.WhereClause != nil {
+		var pushed []parser.QueryExpression
+		var residual []parser.QueryExpression
+		for _, expr := range splitConjunction(entity.WhereClause.(parser.WhereClause).Filter) {
+			if _, ok := newReaderCondition(header, expr); ok && !filter.tx.Flags.CaseSensitive {
+				pushed = append(pushed, expr)
+			} else {
+				residual = append(residual, expr)
+			}
+		}
+		if pushed != nil {
+			node.AddAttribute("Reader Condition", joinConjunction(pushed).String())
+		}
+		if residual != nil {
+			node = planFilter(node, joinConjunction(residual))
+		}
+	}
+
+	node = planSelect(filter, node, entity)
+
+	if query.OrderByClause != nil {
+		node = NewPlanNode("Sort", node.EstimatedRows, node).
+			AddAttribute("Keys", listExpressions(query.OrderByClause.(parser.OrderByClause).Items)).
+			AddAttribute("Method", "External Merge Sort").
+			AddAttribute("Buffer Size", strconv.Itoa(filter.tx.Flags.SortBufferSize)+" MiB")
+	}
+	return planOffsetAndLimit(filter, query, node), nil
+}
+
+func planSelectEntity(ctx context.Context, filter *Filter, expr parser.QueryExpression) (*PlanNode, error) {
+	entity, ok := expr.(parser.SelectEntity)
+	if !ok {
+		if valuesTable, ok := expr.(parser.ValuesTable); ok {
+			return filter.recorder.annotate(NewPlanNode("Values", len(valuesTable.ValuesList)), newOperationKey("values", valuesTable)), nil
+		}
+		return planSelectSet(ctx, filter, expr.(parser.SelectSet))
+	}
+
+	var condition parser.QueryExpression
+	if entity.WhereClause != nil {
+		condition = entity.WhereClause.(parser.WhereClause).Filter
+	}
+
+	var node *PlanNode
+	var err error
+	if entity.FromClause == nil {
+		node = NewPlanNode("Dual", 1)
+		if condition != nil {
+			node = planFilter(node, condition)
+		}
+	} else if node, err = planFromClause(ctx, filter, entity.FromClause.(parser.FromClause), condition); err != nil {
+		return nil, err
+	}
+
+	selectClause := entity.SelectClause.(parser.SelectClause)
+	if entity.GroupByClause != nil {
+		node = NewPlanNode("Group", node.EstimatedRows, node).
+			AddAttribute("Keys", listExpressions(entity.GroupByClause.(parser.GroupByClause).Items))
+		filter.recorder.annotate(node, newOperationKey("group", entity))
+	} else if hasAggregateFunction(reflect.ValueOf(selectClause.Fields)) || (entity.HavingClause != nil && hasAggregateFunction(reflect.ValueOf(entity.HavingClause))) {
+		node = NewPlanNode("Aggregate", 1, node)
+	}
+
+	if entity.HavingClause != nil {
+		node = planFilter(node, entity.HavingClause.(parser.HavingClause).Filter)
+		filter.recorder.annotate(node, newOperationKey("having", entity))
+	}
+
+	return planSelect(filter, node, entity), nil
+}
+
+func planSelectSet(ctx context.Context, filter *Filter, set parser.SelectSet) (*PlanNode, error) {
+	lhs, err := planSelectSetEntity(ctx, filter, set.LHS)
+	if err != nil {
+		return nil, err
+	}
+	rhs, err := planSelectSetEntity(ctx, filter, set.RHS)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := -1
+	if -1 < lhs.EstimatedRows && -1 < rhs.EstimatedRows {
+		switch set.Operator.Token {
+		case parser.UNION:
+			rows = lhs.EstimatedRows + rhs.EstimatedRows
+		case parser.EXCEPT:
+			rows = lhs.EstimatedRows
+		case parser.INTERSECT:
+			rows = lhs.EstimatedRows
+			if rhs.EstimatedRows < rows {
+				rows = rhs.EstimatedRows
+			}
+		}
+	}
+
+	operation := strings.ToUpper(set.Operator.Literal)
+	if !set.All.IsEmpty() {
+		operation = operation + " ALL"
+	}
+	return filter.recorder.annotate(NewPlanNode(operation, rows, lhs, rhs), newJoinOperationKey("set", set.LHS, set.RHS)), nil
+}
+
+func planSelectSetEntity(ctx context.Context, filter *Filter, expr parser.QueryExpression) (*PlanNode, error) {
+	if subquery, ok := expr.(parser.Subquery); ok {
+		return PlanQuery(ctx, filter, subquery.Query, false)
+	}
+	return planSelectEntity(ctx, filter, expr)
+}
+
+func planFilter(node *PlanNode, condition parser.QueryExpression) *PlanNode {
+	return NewPlanNode("Filter", estimateFilterRows(node.EstimatedRows, condition), node).
+		AddAttribute("Condition", condition.String())
+}
+
+func planSelect(filter *Filter, node *PlanNode, entity parser.SelectEntity) *PlanNode {
+	clause := entity.SelectClause.(parser.SelectClause)
+	names := make([]string, 0, len(clause.Fields))
+	for _, f := range clause.Fields {
+		names = append(names, f.(parser.Field).Name())
+	}
+	node = NewPlanNode("Project", node.EstimatedRows, node).AddAttribute("Fields", strings.Join(names, ", "))
+	filter.recorder.annotate(node, newOperationKey("project", entity))
+
+	if clause.IsDistinct() {
+		node = NewPlanNode("Distinct", node.EstimatedRows, node)
+		filter.recorder.annotate(node, newOperationKey("distinct", entity))
+	}
+	return node
+}
+
+// planFromClause returns the plan to load the tables in the FROM clause and filter the records by the condition
+// in the same way as LoadWithCondition.
+func planFromClause(ctx context.Context, filter *Filter, clause parser.FromClause, condition parser.QueryExpression) (*PlanNode, error) {
+	if plan, ok := newJoinPlan(clause); ok {
+		return planJoinPlan(ctx, filter, plan, condition)
+	}
+
+	var node *PlanNode
+	var view *View
+	for _, table := range clause.Tables {
+		tableNode, tableView, err := planTable(ctx, filter, table)
+		if err != nil {
+			return nil, err
+		}
+		if node == nil {
+			node, view = tableNode, tableView
+		} else {
+			node = NewPlanNode("Cross Join", multiplyRows(node.EstimatedRows, tableNode.EstimatedRows), node, tableNode)
+			filter.recorder.annotate(node, newOperationKey("cross", table))
+			view = nil
+		}
+	}
+
+	if condition != nil {
+		node = planIndexFilter(filter, node, condition, view)
+		filter.recorder.annotate(node, whereOperationKey(clause.Tables))
+	}
+	return node, nil
+}
+
+// planIndexFilter returns the plan to filter the records of the view by the condition in the same way as View.filter.
+// If the records are searched using an index of the view, then the index and the conditions used are shown.
+func planIndexFilter(filter *Filter, node *PlanNode, condition parser.QueryExpression, view *View) *PlanNode {
+	rows := node.EstimatedRows
+	node = planFilter(node, condition)
+	if view == nil {
+		return node
+	}
+	node.EstimatedRows = estimateFilterRowsWithStatistics(rows, condition, view)
+
+	if indices, search, ok := view.searchIndexedRecords(condition, filter.tx.Flags); ok {
+		// The records found by the index are filtered by the other conjuncts.
+		var rest []parser.QueryExpression
+		for _, expr := range splitConjunction(condition) {
+			if !search.uses(expr) {
+				rest = append(rest, expr)
+			}
+		}
+		node.EstimatedRows = estimateFilterRows(len(indices), joinConjunction(rest))
+		node.AddAttribute("Index", search.Index.Name).
+			AddAttribute("Index Condition", search.String())
+	}
+	return node
+}
+
+func planJoinPlan(ctx context.Context, filter *Filter, plan *joinPlan, condition parser.QueryExpression) (*PlanNode, error) {
+	if err := plan.Load(ctx, filter, false, false); err != nil {
+		return nil, err
+	}
+	residual, keepsWrittenJoins := plan.classifyConditions(condition)
+
+	nodes := make([]*PlanNode, len(plan.Relations))
+	views := make([]*View, len(plan.Relations))
+	sizes := make([]int, len(plan.Relations))
+	var conditions []joinPlanCondition
+	for i := range plan.Relations {
+		node, _, err := planRelation(ctx, filter, plan.Relations[i], plan.Views[i])
+		if err != nil {
+			return nil, err
+		}
+
+		var exprs []parser.QueryExpression
+		for _, c := range plan.Conditions {
+			if len(c.Relations) == 1 && c.Relations[0] == i {
+				exprs = append(exprs, c.Expr)
+			}
+		}
+		views[i] = plan.Views[i]
+		if exprs != nil {
+			node = planIndexFilter(filter, node, joinConjunction(exprs), plan.Views[i])
+			filter.recorder.annotate(node, newOperationKey("pushdown", plan.Relations[i]))
+
+			// The indexes are not available after filtering.
+			views[i] = &View{Tx: filter.tx, Header: plan.Views[i].Header}
+		}
+		nodes[i] = node
+		sizes[i] = node.EstimatedRows
+	}
+	for _, c := range plan.Conditions {
+		if 1 < len(c.Relations) {
+			conditions = append(conditions, c)
+		}
+	}
+
+	var node *PlanNode
+	if keepsWrittenJoins {
+		next := 0
+		for _, table := range plan.Tables {
+			tableNode, _ := planWrittenJoin(filter, table, nodes, views, &next)
+			if node == nil {
+				node = tableNode
+			} else {
+				node = NewPlanNode("Cross Join", multiplyRows(node.EstimatedRows, tableNode.EstimatedRows), node, tableNode)
+				filter.recorder.annotate(node, newOperationKey("cross", table))
+			}
+		}
+	} else {
+		order := planJoinOrder(sizes, conditions)
+		joined := make([]bool, len(sizes))
+		applied := make([]bool, len(conditions))
+
+		node = nodes[order[0]]
+		view := views[order[0]]
+		size := float64(sizes[order[0]])
+		joined[order[0]] = true
+		for _, r := range order[1:] {
+			size = estimateJoinSize(size, joined, r, sizes, conditions)
+			joined[r] = true
+
+			var exprs []parser.QueryExpression
+			for i, c := range conditions {
+				if !applied[i] && c.isCoveredBy(joined) {
+					exprs = append(exprs, c.Expr)
+					applied[i] = true
+				}
+			}
+			node = planInnerJoin(node, nodes[r], view, views[r], joinConjunction(exprs), int(size))
+			filter.recorder.annotate(node, newOperationKey("join step", plan.Relations[r]))
+			view = &View{Tx: filter.tx, Header: MergeHeader(view.Header, views[r].Header)}
+		}
+
+		for i, r := range order {
+			if i != r {
+				names := make([]string, 0, len(order))
+				for _, r := range order {
+					names = append(names, relationName(plan.Relations[r]))
+				}
+				node.AddAttribute("Join Order", strings.Join(names, ", "))
+				break
+			}
+		}
+	}
+
+	if residual != nil {
+		node = planFilter(node, joinConjunction(residual))
+		filter.recorder.annotate(node, whereOperationKey(plan.Tables))
+	}
+	return node, nil
+}
+
+// planWrittenJoin returns the plan to join the relations in the same way as joinTableExpression,
+// and the view having the header of the joined records.
+func planWrittenJoin(filter *Filter, expr parser.QueryExpression, nodes []*PlanNode, views []*View, next *int) (*PlanNode, *View) {
+	join, ok := innerJoin(expr)
+	if !ok {
+		node, view := nodes[*next], views[*next]
+		*next++
+		return node, view
+	}
+
+	node, view := planWrittenJoin(filter, join.Table, nodes, views, next)
+	joinNode, joinView := planWrittenJoin(filter, join.JoinTable, nodes, views, next)
+
+	var condition parser.QueryExpression
+	if join.Condition != nil {
+		condition = join.Condition.(parser.JoinCondition).On
+	}
+	node = planInnerJoin(node, joinNode, view, joinView, condition, estimateJoinRows(node.EstimatedRows, joinNode.EstimatedRows, condition))
+	filter.recorder.annotate(node, newJoinOperationKey("join", join.Table, join.JoinTable))
+	return node, &View{Tx: filter.tx, Header: MergeHeader(view.Header, joinView.Header)}
+}
+
+// planInnerJoin returns the plan to join the records of the views by the condition in the same way as InnerJoin.
+func planInnerJoin(node *PlanNode, joinNode *PlanNode, view *View, joinView *View, condition parser.QueryExpression, rows int) *PlanNode {
+	if condition == nil {
+		return NewPlanNode("Cross Join", multiplyRows(node.EstimatedRows, joinNode.EstimatedRows), node, joinNode)
+	}
+
+	index, _ := joinIndex(view, joinView, condition)
+	operation := joinMethod(condition)
+	if index != nil {
+		operation = "Index Nested Loop Join"
+	}
+	node = NewPlanNode(operation, rows, node, joinNode).
+		AddAttribute("Type", "Inner").
+		AddAttribute("Condition", condition.String())
+	if index != nil {
+		node.AddAttribute("Index", index.Name)
+	}
+	return node
+}
+
+// planTable returns the plan to load the table expression in the same way as loadView.
+// The view of the table is also returned if the header of the loaded records is known without executing queries.
+func planTable(ctx context.Context, filter *Filter, expr parser.QueryExpression) (*PlanNode, *View, error) {
+	if parentheses, ok := expr.(parser.Parentheses); ok {
+		return planTable(ctx, filter, parentheses.Expr)
+	}
+
+	table := expr.(parser.Table)
+	join, ok := table.Object.(parser.Join)
+	if !ok {
+		return planRelation(ctx, filter, expr, nil)
+	}
+
+	node, view, err := planTable(ctx, filter, join.Table)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var joinNode *PlanNode
+	var joinView *View
+	unnest, lateral := unnestTable(join.JoinTable)
+	if lateral && (join.Direction.Token == parser.RIGHT || join.Direction.Token == parser.FULL) {
+		lateral = false
+	}
+	if lateral {
+		joinNode = NewPlanNode("Unnest", -1).AddAttribute("Table", unnest.String())
+	} else if joinNode, joinView, err = planTable(ctx, filter, join.JoinTable); err != nil {
+		return nil, nil, err
+	}
+
+	var condition parser.QueryExpression
+	var using string
+	if join.Condition != nil {
+		joinCondition := join.Condition.(parser.JoinCondition)
+		condition = joinCondition.On
+		if joinCondition.Using != nil {
+			using = listExpressions(joinCondition.Using)
+		}
+	}
+
+	joinType := "Inner"
+	switch {
+	case join.JoinType.Token == parser.CROSS:
+		joinType = ""
+	case !join.Direction.IsEmpty():
+		joinType = strings.ToUpper(join.Direction.Literal[:1]) + strings.ToLower(join.Direction.Literal[1:]) + " Outer"
+	}
+
+	rows := -1
+	operation := "Cross Join"
+	switch {
+	case lateral:
+		operation = "Lateral Join"
+	case joinType == "":
+		rows = multiplyRows(node.EstimatedRows, joinNode.EstimatedRows)
+	case !join.Natural.IsEmpty() || using != "":
+		operation = "Hash Join"
+		rows = estimateEquiJoinRows(node.EstimatedRows, joinNode.EstimatedRows)
+	default:
+		operation = joinMethod(condition)
+		rows = estimateJoinRows(node.EstimatedRows, joinNode.EstimatedRows, condition)
+	}
+	if -1 < rows {
+		switch join.Direction.Token {
+		case parser.LEFT:
+			rows = maxRows(rows, node.EstimatedRows)
+		case parser.RIGHT:
+			rows = maxRows(rows, joinNode.EstimatedRows)
+		case parser.FULL:
+			rows = maxRows(rows, node.EstimatedRows+joinNode.EstimatedRows)
+		}
+	}
+
+	// The records of the joinView, or the view in right outer joins, are looked up using an index of the view.
+	var index *ViewIndex
+	if joinType != "" && !lateral {
+		indexView, otherView := joinView, view
+		if join.Direction.Token == parser.RIGHT {
+			indexView, otherView = view, joinView
+		}
+		if indexView != nil {
+			joinCondition := condition
+			if view != nil && joinView != nil {
+				joinCondition, _, _, _ = ParseJoinCondition(join, view, joinView)
+			}
+			if index, _ = joinIndex(otherView, indexView, joinCondition); index != nil {
+				operation = "Index Nested Loop Join"
+			}
+		}
+	}
+
+	node = NewPlanNode(operation, rows, node, joinNode)
+	filter.recorder.annotate(node, newJoinOperationKey("join", join.Table, join.JoinTable))
+	if joinType != "" {
+		node.AddAttribute("Type", joinType)
+	}
+	switch {
+	case !join.Natural.IsEmpty():
+		node.AddAttribute("Natural", "true")
+	case using != "":
+		node.AddAttribute("Using", using)
+	case condition != nil:
+		node.AddAttribute("Condition", condition.String())
+	}
+	if index != nil {
+		node.AddAttribute("Index", index.Name)
+	}
+
+	var joinedView *View
+	if view != nil && joinView != nil && join.Natural.IsEmpty() && using == "" {
+		joinedView = &View{Tx: filter.tx, Header: MergeHeader(view.Header, joinView.Header)}
+	}
+	return node, joinedView, nil
+}
+
+// planRelation returns the plan to load a relation of a join plan, and the view of the relation.
+// If the view is nil, then the table is loaded except for subqueries.
+func planRelation(ctx context.Context, filter *Filter, expr parser.QueryExpression, view *View) (*PlanNode, *View, error) {
+	if parentheses, ok := expr.(parser.Parentheses); ok {
+		return planRelation(ctx, filter, parentheses.Expr, view)
+	}
+
+	table := expr.(parser.Table)
+	if _, ok := table.Object.(parser.Join); ok {
+		return planTable(ctx, filter, table)
+	}
+	if subquery, ok := table.Object.(parser.Subquery); ok {
+		child, err := PlanQuery(ctx, filter, subquery.Query, false)
+		if err != nil {
+			return nil, nil, err
+		}
+		rows := child.EstimatedRows
+		if view != nil {
+			rows = view.RecordLen()
+		}
+		node := NewPlanNode("Subquery", rows, child).AddAttribute("Alias", table.Name().Literal)
+		return filter.recorder.annotate(node, newOperationKey("scan", table)), view, nil
+	}
+
+	if view == nil {
+		var err error
+		if view, err = loadView(ctx, filter, table, false, false); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	node := NewPlanNode("Scan", view.RecordLen()).AddAttribute("Table", table.String())
+	filter.recorder.annotate(node, newOperationKey("scan", table))
+	if view.FileInfo != nil {
+		if view.FileInfo.IsTemporary {
+			node.AddAttribute("View", view.FileInfo.Path)
+		} else if 0 < len(view.FileInfo.Path) {
+			node.AddAttribute("File", view.FileInfo.Path).
+				AddAttribute("Format", view.FileInfo.Format.String())
+		}
+	}
+	return node, view, nil
+}
+
+func relationName(expr parser.QueryExpression) string {
+	if parentheses, ok := expr.(parser.Parentheses); ok {
+		return relationName(parentheses.Expr)
+	}
+	if table, ok := expr.(parser.Table); ok {
+		return table.Name().Literal
+	}
+	return expr.String()
+}
+
+func joinMethod(condition parser.QueryExpression) string {
+	if condition == nil {
+		return "Cross Join"
+	}
+	for _, expr := range splitConjunction(condition) {
+		if isFieldEquality(expr) {
+			return "Hash Join"
+		}
+	}
+	return "Nested Loop Join"
+}
+
+// estimateFilterRows estimates the number of records satisfying the condition
+// assuming that each conjunct of the condition is satisfied by one third of the records.
+func estimateFilterRows(rows int, condition parser.QueryExpression) int {
+	if rows < 0 || condition == nil {
+		return rows
+	}
+	for range splitConjunction(condition) {
+		rows = rows / 3
+	}
+	return rows
+}
+
+// estimateJoinRows estimates the number of joined records in the same way as estimateJoinSize.
+func estimateJoinRows(rows int, joinRows int, condition parser.QueryExpression) int {
+	if rows < 0 || joinRows < 0 {
+		return -1
+	}
+
+	size := float64(rows) * float64(joinRows)
+	if condition != nil {
+		larger := maxRows(rows, joinRows)
+		for _, expr := range splitConjunction(condition) {
+			if isFieldEquality(expr) {
+				if 1 < larger {
+					size = size / float64(larger)
+				}
+			} else {
+				size = size / 3
+			}
+		}
+	}
+	return int(size)
+}
+
+// estimateEquiJoinRows estimates the number of records joined by the equality of fields,
+// such as natural joins and joins with USING conditions.
+func estimateEquiJoinRows(rows int, joinRows int) int {
+	if rows < 0 || joinRows < 0 {
+		return -1
+	}
+	if larger := maxRows(rows, joinRows); 1 < larger {
+		return rows * joinRows / larger
+	}
+	return rows * joinRows
+}
+
+func multiplyRows(rows int, joinRows int) int {
+	if rows < 0 || joinRows < 0 {
+		return -1
+	}
+	return rows * joinRows
+}
+
+func maxRows(rows1 int, rows2 int) int {
+	if rows1 < rows2 {
+		return rows2
+	}
+	return rows1
+}
+
+func constantInteger(expr parser.QueryExpression) (int, bool) {
+	pt, ok := expr.(parser.PrimitiveType)
+	if !ok {
+		return 0, false
+	}
+	if i := value.ToInteger(pt.Value); !value.IsNull(i) {
+		return int(i.(value.Integer).Raw()), true
+	}
+	return 0, false
+}
+
+// hasAggregateFunction reports whether the value contains aggregate functions outside of subqueries.
+func hasAggregateFunction(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		if v.IsNil() {
+			return false
+		}
+		return hasAggregateFunction(v.Elem())
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			if hasAggregateFunction(v.Index(i)) {
+				return true
+			}
+		}
+		return false
+	case reflect.Struct:
+		if !v.CanInterface() {
+			return false
+		}
+	default:
+		return false
+	}
+
+	switch v.Interface().(type) {
+	case parser.AggregateFunction, parser.ListFunction:
+		return true
+	case parser.Subquery, parser.AnalyticFunction, parser.BaseExpr:
+		return false
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		if hasAggregateFunction(v.Field(i)) {
+			return true
+		}
+	}
+	return false
+}
+
+func listExpressions(exprs []parser.QueryExpression) string {
+	list := make([]string, 0, len(exprs))
+	for _, expr := range exprs {
+		list = append(list, expr.String())
+	}
+	return strings.Join(list, ", ")
+}
@@ -0,0 +1,379 @@
+package query
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/mithrandie/csvq/lib/cmd"
+	"github.com/mithrandie/csvq/lib/parser"
+)
+
+var planQueryTests = []struct {
+	Name   string
+	Query  string
+	Format cmd.Format
+	Result *PlanNode
+}{
+	{
+		Name:  "PlanQuery",
+		Query: "SELECT column1 FROM table1 WHERE column1 = 2 ORDER BY column1 LIMIT 1",
+		Result: &PlanNode{
+			Operation:     "Limit",
+			Attributes:    []PlanAttribute{{Name: "Clause", Value: "LIMIT 1"}},
+			EstimatedRows: 1,
+			Children: []*PlanNode{{
+				Operation:     "Sort",
+				Attributes:    []PlanAttribute{{Name: "Keys", Value: "column1"}},
+				EstimatedRows: 1,
+				Children: []*PlanNode{{
+					Operation:     "Project",
+					Attributes:    []PlanAttribute{{Name: "Fields", Value: "column1"}},
+					EstimatedRows: 1,
+					Children: []*PlanNode{{
+						Operation:     "Filter",
+						Attributes:    []PlanAttribute{{Name: "Condition", Value: "column1 = 2"}},
+						EstimatedRows: 1,
+						Children: []*PlanNode{{
+							Operation: "Scan",
+							Attributes: []PlanAttribute{
+								{Name: "Table", Value: "table1"},
+								{Name: "File", Value: GetTestFilePath("table1.csv")},
+								{Name: "Format", Value: "CSV"},
+							},
+							EstimatedRows: 3,
+						}},
+					}},
+				}},
+			}},
+		},
+	},
+	{
+		Name:  "PlanQuery Join",
+		Query: "SELECT COUNT(*) FROM table1 JOIN table2 ON table1.column1 = table2.column3",
+		Result: &PlanNode{
+			Operation:     "Project",
+			Attributes:    []PlanAttribute{{Name: "Fields", Value: "COUNT(*)"}},
+			EstimatedRows: 1,
+			Children: []*PlanNode{{
+				Operation:     "Aggregate",
+				EstimatedRows: 1,
+				Children: []*PlanNode{{
+					Operation: "Hash Join",
+					Attributes: []PlanAttribute{
+						{Name: "Type", Value: "Inner"},
+						{Name: "Condition", Value: "table1.column1 = table2.column3"},
+					},
+					EstimatedRows: 3,
+					Children: []*PlanNode{
+						{
+							Operation: "Scan",
+							Attributes: []PlanAttribute{
+								{Name: "Table", Value: "table1"},
+								{Name: "File", Value: GetTestFilePath("table1.csv")},
+								{Name: "Format", Value: "CSV"},
+							},
+							EstimatedRows: 3,
+						},
+						{
+							Operation: "Scan",
+							Attributes: []PlanAttribute{
+								{Name: "Table", Value: "table2"},
+								{Name: "File", Value: GetTestFilePath("table2.csv")},
+								{Name: "Format", Value: "CSV"},
+							},
+							EstimatedRows: 3,
+						},
+					},
+				}},
+			}},
+		},
+	},
+	{
+		Name:   "PlanQuery Streaming",
+		Query:  "SELECT column1 FROM table1 WHERE column1 = 2 AND column2 LIKE 'str%'",
+		Format: cmd.CSV,
+		Result: &PlanNode{
+			Operation:     "Project",
+			Attributes:    []PlanAttribute{{Name: "Fields", Value: "column1"}},
+			EstimatedRows: -1,
+			Children: []*PlanNode{{
+				Operation:     "Filter",
+				Attributes:    []PlanAttribute{{Name: "Condition", Value: "column2 LIKE 'str%'"}},
+				EstimatedRows: -1,
+				Children: []*PlanNode{{
+					Operation: "Stream Scan",
+					Attributes: []PlanAttribute{
+						{Name: "Table", Value: "table1"},
+						{Name: "File", Value: GetTestFilePath("table1.csv")},
+						{Name: "Format", Value: "CSV"},
+						{Name: "Reader Condition", Value: "column1 = 2"},
+					},
+					EstimatedRows: -1,
+				}},
+			}},
+		},
+	},
+	{
+		Name:  "PlanQuery Set Operation",
+		Query: "SELECT 1 UNION ALL VALUES (2), (3)",
+		Result: &PlanNode{
+			Operation:     "UNION ALL",
+			EstimatedRows: 3,
+			Children: []*PlanNode{
+				{
+					Operation:     "Project",
+					Attributes:    []PlanAttribute{{Name: "Fields", Value: "1"}},
+					EstimatedRows: 1,
+					Children: []*PlanNode{{
+						Operation:     "Dual",
+						EstimatedRows: 1,
+					}},
+				},
+				{
+					Operation:     "Values",
+					EstimatedRows: 2,
+				},
+			},
+		},
+	},
+}
+
+func TestPlanQuery(t *testing.T) {
+	defer func() {
+		_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+		initFlag(TestTx.Flags)
+	}()
+
+	TestTx.Flags.Repository = TestDir
+
+	for _, v := range planQueryTests {
+		_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+		TestTx.Flags.Format = v.Format
+
+		statements, _, err := parser.Parse(v.Query, "", nil, false)
+		if err != nil {
+			t.Fatalf("%s: unexpected parse error %q", v.Name, err)
+		}
+
+		result, err := PlanQuery(context.Background(), NewFilter(TestTx), statements[0].(parser.SelectQuery), true)
+		if err != nil {
+			t.Errorf("%s: unexpected error %q", v.Name, err)
+			continue
+		}
+		if !reflect.DeepEqual(result, v.Result) {
+			t.Errorf("%s: result = %v, want %v", v.Name, result, v.Result)
+		}
+	}
+}
+
+func TestPlanQuery_Index(t *testing.T) {
+	defer func() {
+		TestTx.indexes = make(IndexMap, 4)
+		_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+		initFlag(TestTx.Flags)
+	}()
+
+	TestTx.Flags.Repository = TestDir
+	_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+	TestTx.indexes = IndexMap{
+		"IDX1": NewViewIndex("idx1", GetTestFilePath("table1.csv"), []string{"column1"}),
+		"IDX2": NewViewIndex("idx2", GetTestFilePath("table2.csv"), []string{"column3"}),
+	}
+
+	statements, _, err := parser.Parse("SELECT column2 FROM table1 LEFT JOIN table2 ON table1.column1 = table2.column3 WHERE column1 BETWEEN 2 AND 3", "", nil, false)
+	if err != nil {
+		t.Fatalf("unexpected parse error %q", err)
+	}
+
+	expect := &PlanNode{
+		Operation:     "Project",
+		Attributes:    []PlanAttribute{{Name: "Fields", Value: "column2"}},
+		EstimatedRows: 1,
+		Children: []*PlanNode{{
+			Operation: "Filter",
+			Attributes: []PlanAttribute{
+				{Name: "Condition", Value: "column1 BETWEEN 2 AND 3"},
+			},
+			EstimatedRows: 1,
+			Children: []*PlanNode{{
+				Operation: "Index Nested Loop Join",
+				Attributes: []PlanAttribute{
+					{Name: "Type", Value: "Left Outer"},
+					{Name: "Condition", Value: "table1.column1 = table2.column3"},
+					{Name: "Index", Value: "idx2"},
+				},
+				EstimatedRows: 3,
+				Children: []*PlanNode{
+					{
+						Operation: "Scan",
+						Attributes: []PlanAttribute{
+							{Name: "Table", Value: "table1"},
+							{Name: "File", Value: GetTestFilePath("table1.csv")},
+							{Name: "Format", Value: "CSV"},
+						},
+						EstimatedRows: 3,
+					},
+					{
+						Operation: "Scan",
+						Attributes: []PlanAttribute{
+							{Name: "Table", Value: "table2"},
+							{Name: "File", Value: GetTestFilePath("table2.csv")},
+							{Name: "Format", Value: "CSV"},
+						},
+						EstimatedRows: 3,
+					},
+				},
+			}},
+		}},
+	}
+
+	result, err := PlanQuery(context.Background(), NewFilter(TestTx), statements[0].(parser.SelectQuery), false)
+	if err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	if !reflect.DeepEqual(result, expect) {
+		t.Errorf("result = %v, want %v", result, expect)
+	}
+
+	statements, _, err = parser.Parse("SELECT column2 FROM table1 WHERE column1 BETWEEN 2 AND 3", "", nil, false)
+	if err != nil {
+		t.Fatalf("unexpected parse error %q", err)
+	}
+
+	expect = &PlanNode{
+		Operation: "Filter",
+		Attributes: []PlanAttribute{
+			{Name: "Condition", Value: "column1 BETWEEN 2 AND 3"},
+			{Name: "Index", Value: "idx1"},
+			{Name: "Index Condition", Value: "column1 BETWEEN 2 AND 3"},
+		},
+		EstimatedRows: 2,
+	}
+
+	result, err = PlanQuery(context.Background(), NewFilter(TestTx), statements[0].(parser.SelectQuery), false)
+	if err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	filterNode := result.Children[0]
+	filterNode.Children = nil
+	if !reflect.DeepEqual(filterNode, expect) {
+		t.Errorf("result = %v, want %v", filterNode, expect)
+	}
+}
+
+func TestAnalyzeQuery(t *testing.T) {
+	defer func() {
+		_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+		initFlag(TestTx.Flags)
+	}()
+
+	TestTx.Flags.Repository = TestDir
+	_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+
+	statements, _, err := parser.Parse("SELECT column1 FROM table1 WHERE column1 > 1 ORDER BY column1 LIMIT 1", "", nil, false)
+	if err != nil {
+		t.Fatalf("unexpected parse error %q", err)
+	}
+
+	expect := &PlanNode{
+		Operation:     "Limit",
+		Attributes:    []PlanAttribute{{Name: "Clause", Value: "LIMIT 1"}},
+		EstimatedRows: 1,
+		Analyzed:      true,
+		ActualRows:    1,
+		Loops:         1,
+		Children: []*PlanNode{{
+			Operation:     "Sort",
+			Attributes:    []PlanAttribute{{Name: "Keys", Value: "column1"}},
+			EstimatedRows: 1,
+			Analyzed:      true,
+			ActualRows:    2,
+			Loops:         1,
+			Children: []*PlanNode{{
+				Operation:     "Project",
+				Attributes:    []PlanAttribute{{Name: "Fields", Value: "column1"}},
+				EstimatedRows: 1,
+				Analyzed:      true,
+				ActualRows:    2,
+				Loops:         1,
+				Children: []*PlanNode{{
+					Operation:     "Filter",
+					Attributes:    []PlanAttribute{{Name: "Condition", Value: "column1 > 1"}},
+					EstimatedRows: 1,
+					Analyzed:      true,
+					ActualRows:    2,
+					Loops:         1,
+					Children: []*PlanNode{{
+						Operation: "Scan",
+						Attributes: []PlanAttribute{
+							{Name: "Table", Value: "table1"},
+							{Name: "File", Value: GetTestFilePath("table1.csv")},
+							{Name: "Format", Value: "CSV"},
+						},
+						EstimatedRows: 3,
+						Analyzed:      true,
+						ActualRows:    3,
+						Loops:         1,
+					}},
+				}},
+			}},
+		}},
+	}
+
+	result, files, _, err := AnalyzeQuery(context.Background(), NewFilter(TestTx), statements[0].(parser.SelectQuery))
+	if err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	clearActualTime(result)
+	if !reflect.DeepEqual(result, expect) {
+		t.Errorf("result = %v, want %v", result, expect)
+	}
+	if len(files) != 1 || files[0].Path != GetTestFilePath("table1.csv") || files[0].Records != 3 {
+		t.Errorf("file loads = %v, want the load of %s", files, GetTestFilePath("table1.csv"))
+	}
+}
+
+func clearActualTime(node *PlanNode) {
+	node.ActualTime = 0
+	for _, child := range node.Children {
+		clearActualTime(child)
+	}
+}
+
+func TestExplain(t *testing.T) {
+	statements, _, err := parser.Parse("EXPLAIN SELECT 1 UNION ALL VALUES (2), (3)", "", nil, false)
+	if err != nil {
+		t.Fatalf("unexpected parse error %q", err)
+	}
+
+	expect := "\n" +
+		"              Query Plan\n" +
+		"--------------------------------------\n" +
+		" UNION ALL  (estimated rows: 3)\n" +
+		"     -> Project  (estimated rows: 1)\n" +
+		"         Fields: 1\n" +
+		"         -> Dual  (estimated rows: 1)\n" +
+		"     -> Values  (estimated rows: 2)\n"
+
+	result, err := Explain(context.Background(), NewFilter(TestTx), statements[0].(parser.Explain))
+	if err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	if result != expect {
+		t.Errorf("result = %q, want %q", result, expect)
+	}
+
+	statements, _, err = parser.Parse("EXPLAIN VERBOSE SELECT 1", "", nil, false)
+	if err != nil {
+		t.Fatalf("unexpected parse error %q", err)
+	}
+
+	expectErr := "[L:1 C:9] explain option VERBOSE is invalid"
+	_, err = Explain(context.Background(), NewFilter(TestTx), statements[0].(parser.Explain))
+	if err == nil {
+		t.Errorf("no error, want error %q", expectErr)
+	} else if err.Error() != expectErr {
+		t.Errorf("error = %q, want error %q", err, expectErr)
+	}
+}
@@ -0,0 +1,265 @@
+package query
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	gojson "encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/mithrandie/csvq/lib/excmd"
+	"github.com/mithrandie/csvq/lib/parser"
+	"github.com/mithrandie/csvq/lib/value"
+
+	"github.com/mithrandie/ternary"
+)
+
+const ExternalLanguage = "EXTERNAL"
+
+// ExternalFunctionBatchSize is the maximum number of rows sent to an external process in a request.
+var ExternalFunctionBatchSize = 1000
+
+// ExternalFunction is the body of a user defined function executed by an external process.
+//
+// The process is started at the first call and kept running until the function is disposed.
+// Each request is a line of a JSON object {"args": [[arg, ...], ...]} containing the arguments
+// of one or more rows, and the process must write a line of a JSON object {"results": [result, ...]}
+// containing the results in the same order, or {"error": "message"}.
+type ExternalFunction struct {
+	Args []string
+
+	mtx    sync.Mutex
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout *bufio.Reader
+}
+
+type externalFunctionRequest struct {
+	Args [][]interface{} `json:"args"`
+}
+
+type externalFunctionResponse struct {
+	Results []interface{} `json:"results"`
+	Error   string        `json:"error"`
+}
+
+func NewExternalFunction(expr parser.FunctionDeclaration) (*ExternalFunction, error) {
+	splitter := new(excmd.ArgsSplitter).Init(expr.Source.Raw())
+	var args = make([]string, 0, 8)
+	for splitter.Scan() {
+		args = append(args, splitter.Text())
+	}
+	if err := splitter.Err(); err != nil {
+		return nil, NewFunctionScriptError(expr.Name, err.Error())
+	}
+	if len(args) < 1 {
+		return nil, NewFunctionScriptError(expr.Name, "command is empty")
+	}
+
+	return &ExternalFunction{
+		Args: args,
+	}, nil
+}
+
+func (fn *ExternalFunction) start(session *Session) error {
+	c := exec.Command(fn.Args[0], fn.Args[1:]...)
+	c.Stderr = session.Stderr
+
+	stdin, err := c.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := c.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err = c.Start(); err != nil {
+		return err
+	}
+
+	fn.cmd = c
+	fn.stdin = stdin
+	fn.stdout = bufio.NewReader(stdout)
+	return nil
+}
+
+// Close terminates the external process.
+func (fn *ExternalFunction) Close() {
+	fn.mtx.Lock()
+	defer fn.mtx.Unlock()
+	fn.close()
+}
+
+func (fn *ExternalFunction) close() {
+	if fn.cmd == nil {
+		return
+	}
+
+	_ = fn.stdin.Close()
+	done := make(chan struct{})
+	go func() {
+		_ = fn.cmd.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		_ = fn.cmd.Process.Kill()
+		<-done
+	}
+
+	fn.cmd = nil
+	fn.stdin = nil
+	fn.stdout = nil
+}
+
+// Call sends the arguments of rows to the external process, and returns the results of the rows.
+func (fn *ExternalFunction) Call(ctx context.Context, session *Session, name parser.Identifier, rows [][]value.Primary) ([]value.Primary, error) {
+	fn.mtx.Lock()
+	defer fn.mtx.Unlock()
+
+	if fn.cmd == nil {
+		if err := fn.start(session); err != nil {
+			return nil, NewFunctionScriptError(name, fmt.Sprintf("failed to start external process: %s", err.Error()))
+		}
+	}
+
+	req := externalFunctionRequest{
+		Args: make([][]interface{}, len(rows)),
+	}
+	for i, row := range rows {
+		req.Args[i] = make([]interface{}, len(row))
+		for j, p := range row {
+			req.Args[i][j] = convertToJsonValue(p)
+		}
+	}
+	line, err := gojson.Marshal(req)
+	if err != nil {
+		return nil, NewFunctionScriptError(name, err.Error())
+	}
+
+	type result struct {
+		line []byte
+		err  error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		if _, err := fn.stdin.Write(append(line, '\n')); err != nil {
+			// Writing fails when the process has already exited.
+			ch <- result{err: io.EOF}
+			return
+		}
+		l, err := fn.stdout.ReadBytes('\n')
+		ch <- result{line: l, err: err}
+	}()
+
+	var res result
+	select {
+	case <-ctx.Done():
+		_ = fn.cmd.Process.Kill()
+		<-ch
+		fn.close()
+		return nil, NewContextIsDone(ctx.Err().Error())
+	case res = <-ch:
+	}
+
+	if res.err != nil {
+		fn.close()
+		if res.err == io.EOF {
+			res.err = errors.New("external process exited unexpectedly")
+		}
+		return nil, NewFunctionScriptError(name, res.err.Error())
+	}
+
+	results, err := decodeExternalFunctionResponse(res.line, len(rows))
+	if err != nil {
+		return nil, NewFunctionScriptError(name, err.Error())
+	}
+	return results, nil
+}
+
+func decodeExternalFunctionResponse(line []byte, rows int) ([]value.Primary, error) {
+	var response externalFunctionResponse
+
+	d := gojson.NewDecoder(bytes.NewReader(line))
+	d.UseNumber()
+	if err := d.Decode(&response); err != nil {
+		return nil, fmt.Errorf("invalid response from external process: %s", err.Error())
+	}
+	if 0 < len(response.Error) {
+		return nil, errors.New(response.Error)
+	}
+	if len(response.Results) != rows {
+		return nil, fmt.Errorf("external process returned %s for %s", FormatCount(len(response.Results), "result"), FormatCount(rows, "row"))
+	}
+
+	results := make([]value.Primary, rows)
+	for i, v := range response.Results {
+		results[i] = convertFromJsonValue(v)
+	}
+	return results, nil
+}
+
+func convertToJsonValue(p value.Primary) interface{} {
+	switch v := p.(type) {
+	case value.String:
+		return v.Raw()
+	case value.Integer:
+		return v.Raw()
+	case value.Float:
+		if math.IsNaN(v.Raw()) || math.IsInf(v.Raw(), 0) {
+			return nil
+		}
+		return v.Raw()
+	case value.Boolean:
+		return v.Raw()
+	case value.Ternary:
+		if v.Ternary() == ternary.UNKNOWN {
+			return nil
+		}
+		return v.Ternary() == ternary.TRUE
+	case value.Datetime:
+		return v.Format(time.RFC3339Nano)
+	case value.Json:
+		return gojson.RawMessage(v.String())
+	case value.Array:
+		list := make([]interface{}, v.Len())
+		for i, e := range v.Raw() {
+			list[i] = convertToJsonValue(e)
+		}
+		return list
+	}
+	return nil
+}
+
+func convertFromJsonValue(v interface{}) value.Primary {
+	switch x := v.(type) {
+	case string:
+		return value.NewString(x)
+	case gojson.Number:
+		if i, err := x.Int64(); err == nil {
+			return value.NewInteger(i)
+		}
+		if f, err := x.Float64(); err == nil {
+			return value.NewFloat(f)
+		}
+	case bool:
+		return value.NewBoolean(x)
+	case []interface{}:
+		values := make([]value.Primary, len(x))
+		for i, e := range x {
+			values[i] = convertFromJsonValue(e)
+		}
+		return value.NewArray(values)
+	case map[string]interface{}:
+		b, _ := gojson.Marshal(x)
+		return value.NewString(string(b))
+	}
+	return value.NewNull()
+}
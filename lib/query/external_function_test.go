@@ -0,0 +1,165 @@
+package query
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/mithrandie/csvq/lib/parser"
+	"github.com/mithrandie/csvq/lib/value"
+)
+
+var decodeExternalFunctionResponseTests = []struct {
+	Name   string
+	Line   string
+	Rows   int
+	Result []value.Primary
+	Error  string
+}{
+	{
+		Name: "Decode Results",
+		Line: "{\"results\": [\"a\", 1, 1.5, true, null, [1, \"b\"], {\"k\": 1}]}\n",
+		Rows: 7,
+		Result: []value.Primary{
+			value.NewString("a"),
+			value.NewInteger(1),
+			value.NewFloat(1.5),
+			value.NewBoolean(true),
+			value.NewNull(),
+			value.NewArray([]value.Primary{value.NewInteger(1), value.NewString("b")}),
+			value.NewString("{\"k\":1}"),
+		},
+	},
+	{
+		Name:  "Decode Error Message",
+		Line:  "{\"error\": \"model not found\"}\n",
+		Rows:  1,
+		Error: "model not found",
+	},
+	{
+		Name:  "Decode Invalid Response",
+		Line:  "not json\n",
+		Rows:  1,
+		Error: "invalid response from external process: invalid character 'o' in literal null (expecting 'u')",
+	},
+	{
+		Name:  "Decode Results Length Error",
+		Line:  "{\"results\": [1]}\n",
+		Rows:  2,
+		Error: "external process returned 1 result for 2 rows",
+	},
+}
+
+func TestDecodeExternalFunctionResponse(t *testing.T) {
+	for _, v := range decodeExternalFunctionResponseTests {
+		result, err := decodeExternalFunctionResponse([]byte(v.Line), v.Rows)
+		if err != nil {
+			if len(v.Error) < 1 {
+				t.Errorf("%s: unexpected error %q", v.Name, err)
+			} else if err.Error() != v.Error {
+				t.Errorf("%s: error %q, want error %q", v.Name, err.Error(), v.Error)
+			}
+			continue
+		}
+		if 0 < len(v.Error) {
+			t.Errorf("%s: no error, want error %q", v.Name, v.Error)
+			continue
+		}
+		if !reflect.DeepEqual(result, v.Result) {
+			t.Errorf("%s: result = %s, want %s", v.Name, result, v.Result)
+		}
+	}
+}
+
+func TestUserDefinedFunction_Prefetch(t *testing.T) {
+	defer func(size int) {
+		ExternalFunctionBatchSize = size
+	}(ExternalFunctionBatchSize)
+	ExternalFunctionBatchSize = 2
+
+	m := UserDefinedFunctionMap{}
+	err := m.Declare(parser.FunctionDeclaration{
+		Name: parser.Identifier{Literal: "extfunc"},
+		Parameters: []parser.VariableAssignment{
+			{Variable: parser.Variable{Name: "arg1"}},
+		},
+		Deterministic: parser.Token{Token: parser.DETERMINISTIC, Literal: "deterministic"},
+		Language:      parser.Identifier{Literal: "external"},
+		Source:        value.NewString("sh -c 'while read line; do echo \"{\\\"results\\\": [\\\"first\\\", \\\"second\\\"]}\"; done'"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	fn, _ := m.Get(parser.Identifier{Literal: "extfunc"}, "extfunc")
+	defer fn.External.Close()
+
+	filter := NewFilter(TestTx)
+	err = fn.Prefetch(context.Background(), filter, [][]value.Primary{
+		{value.NewInteger(1)},
+		{value.NewInteger(2)},
+		{value.NewInteger(1)},
+		{value.NewInteger(3)},
+		{value.NewInteger(4)},
+		{value.NewInteger(1), value.NewInteger(2)},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	expect := map[int64]value.Primary{
+		1: value.NewString("first"),
+		2: value.NewString("second"),
+		3: value.NewString("first"),
+		4: value.NewString("second"),
+	}
+	for i, want := range expect {
+		result, ok := fn.results.Load(serializeFunctionArguments([]value.Primary{value.NewInteger(i)}))
+		if !ok {
+			t.Errorf("result for %d is not cached", i)
+			continue
+		}
+		if !reflect.DeepEqual(result, want) {
+			t.Errorf("result for %d = %s, want %s", i, result, want)
+		}
+	}
+
+	_, err = fn.Execute(context.Background(), filter, []value.Primary{value.NewInteger(5)})
+	expectErr := "external process returned 2 results for 1 row in function extfunc"
+	if err == nil {
+		t.Errorf("no error, want error %q", expectErr)
+	} else if err.Error() != expectErr {
+		t.Errorf("error = %q, want error %q", err.Error(), expectErr)
+	}
+}
+
+func TestExternalFunction_Call(t *testing.T) {
+	fn, err := NewExternalFunction(parser.FunctionDeclaration{
+		Name:     parser.Identifier{Literal: "extfunc"},
+		Language: parser.Identifier{Literal: "external"},
+		Source:   value.NewString("true"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	defer fn.Close()
+
+	_, err = fn.Call(context.Background(), TestTx.Session, parser.Identifier{Literal: "extfunc"}, [][]value.Primary{{value.NewInteger(1)}})
+	expectErr := "external process exited unexpectedly in function extfunc"
+	if err == nil {
+		t.Errorf("no error, want error %q", expectErr)
+	} else if err.Error() != expectErr {
+		t.Errorf("error = %q, want error %q", err.Error(), expectErr)
+	}
+
+	_, err = NewExternalFunction(parser.FunctionDeclaration{
+		Name:     parser.Identifier{Literal: "extfunc"},
+		Language: parser.Identifier{Literal: "external"},
+		Source:   value.NewString(" "),
+	})
+	expectErr = "command is empty in function extfunc"
+	if err == nil {
+		t.Errorf("no error, want error %q", expectErr)
+	} else if err.Error() != expectErr {
+		t.Errorf("error = %q, want error %q", err.Error(), expectErr)
+	}
+}
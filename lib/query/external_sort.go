@@ -0,0 +1,525 @@
+package query
+
+import (
+	"bufio"
+	"container/heap"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"os"
+	"time"
+
+	"github.com/mithrandie/csvq/lib/value"
+
+	txjson "github.com/mithrandie/go-text/json"
+	"github.com/mithrandie/ternary"
+)
+
+type sortedRecord struct {
+	record     Record
+	sortValues SortValues
+}
+
+// recordSorter sorts records that do not fit in the buffer size.
+//
+// Sorted chunks of records are held in memory until their approximate size exceeds the buffer size,
+// and then they are merged into a run in a temporary file. The records are finally returned
+// by merging all the runs and the chunks in memory.
+type recordSorter struct {
+	bufferSize    int64
+	directions    []int
+	nullPositions []int
+
+	chunks [][]sortedRecord
+	size   int64
+	files  []*os.File
+}
+
+func newRecordSorter(bufferSize int64) *recordSorter {
+	return &recordSorter{
+		bufferSize: bufferSize,
+	}
+}
+
+// Add adds records that have already been sorted.
+func (s *recordSorter) Add(records RecordSet, sortValues []SortValues) error {
+	if len(records) < 1 {
+		return nil
+	}
+
+	chunk := make([]sortedRecord, len(records))
+	for i := range records {
+		chunk[i] = sortedRecord{record: records[i], sortValues: sortValues[i]}
+		s.size += estimateSortedRecordSize(chunk[i])
+	}
+	s.chunks = append(s.chunks, chunk)
+
+	if s.bufferSize < s.size {
+		return s.spill()
+	}
+	return nil
+}
+
+func (s *recordSorter) spill() error {
+	fp, err := os.CreateTemp("", "csvq_sort_")
+	if err != nil {
+		return NewSystemError(err.Error())
+	}
+	s.files = append(s.files, fp)
+
+	w := bufio.NewWriter(fp)
+	runs := make([]sortedRun, len(s.chunks))
+	for i := range s.chunks {
+		runs[i] = &memoryRun{records: s.chunks[i]}
+	}
+	if err = s.merge(context.Background(), runs, func(r sortedRecord) error {
+		return writeSortedRecord(w, r)
+	}); err != nil {
+		return err
+	}
+	if err = w.Flush(); err != nil {
+		return NewSystemError(err.Error())
+	}
+	if _, err = fp.Seek(0, io.SeekStart); err != nil {
+		return NewSystemError(err.Error())
+	}
+
+	s.chunks = nil
+	s.size = 0
+	return nil
+}
+
+// Merge passes the sorted records to fn in chunks.
+// If limit is not negative, then only the first limit records are passed.
+func (s *recordSorter) Merge(ctx context.Context, limit int, fn func(RecordSet) error) error {
+	runs := make([]sortedRun, 0, len(s.files)+len(s.chunks))
+	for _, fp := range s.files {
+		runs = append(runs, &fileRun{reader: bufio.NewReader(fp)})
+	}
+	for i := range s.chunks {
+		runs = append(runs, &memoryRun{records: s.chunks[i]})
+	}
+
+	records := make(RecordSet, 0, StreamChunkSize)
+	err := s.merge(ctx, runs, func(r sortedRecord) error {
+		if limit == 0 {
+			return errSortLimitReached
+		}
+		records = append(records, r.record)
+		if StreamChunkSize <= len(records) {
+			if err := fn(records); err != nil {
+				return err
+			}
+			records = records[:0]
+		}
+		if 0 < limit {
+			limit--
+		}
+		return nil
+	})
+	if err != nil && err != errSortLimitReached {
+		return err
+	}
+	if 0 < len(records) {
+		return fn(records)
+	}
+	return nil
+}
+
+var errSortLimitReached = errors.New("limit reached")
+
+func (s *recordSorter) merge(ctx context.Context, runs []sortedRun, fn func(sortedRecord) error) error {
+	h := &sortedRunHeap{
+		directions:    s.directions,
+		nullPositions: s.nullPositions,
+	}
+	for i, run := range runs {
+		r, ok, err := run.Next()
+		if err != nil {
+			return err
+		}
+		if ok {
+			h.items = append(h.items, &sortedRunHeapItem{run: run, index: i, head: r})
+		}
+	}
+	heap.Init(h)
+
+	for 0 < h.Len() {
+		if ctx.Err() != nil {
+			return NewContextIsDone(ctx.Err().Error())
+		}
+
+		item := h.items[0]
+		if err := fn(item.head); err != nil {
+			return err
+		}
+
+		r, ok, err := item.run.Next()
+		if err != nil {
+			return err
+		}
+		if ok {
+			item.head = r
+			heap.Fix(h, 0)
+		} else {
+			heap.Pop(h)
+		}
+	}
+	return nil
+}
+
+// Close removes the temporary files.
+func (s *recordSorter) Close() error {
+	var err error
+	for _, fp := range s.files {
+		if e := fp.Close(); e != nil {
+			err = AppendCompositeError(err, NewSystemError(e.Error()))
+		}
+		if e := os.Remove(fp.Name()); e != nil {
+			err = AppendCompositeError(err, NewSystemError(e.Error()))
+		}
+	}
+	s.files = nil
+	s.chunks = nil
+	return err
+}
+
+type sortedRun interface {
+	Next() (sortedRecord, bool, error)
+}
+
+type memoryRun struct {
+	records []sortedRecord
+	pos     int
+}
+
+func (r *memoryRun) Next() (sortedRecord, bool, error) {
+	if len(r.records) <= r.pos {
+		return sortedRecord{}, false, nil
+	}
+	r.pos++
+	return r.records[r.pos-1], true, nil
+}
+
+type fileRun struct {
+	reader *bufio.Reader
+}
+
+func (r *fileRun) Next() (sortedRecord, bool, error) {
+	rec, err := readSortedRecord(r.reader)
+	if err == io.EOF {
+		return rec, false, nil
+	}
+	if err != nil {
+		return rec, false, NewSystemError(err.Error())
+	}
+	return rec, true, nil
+}
+
+type sortedRunHeapItem struct {
+	run   sortedRun
+	index int
+	head  sortedRecord
+}
+
+type sortedRunHeap struct {
+	items         []*sortedRunHeapItem
+	directions    []int
+	nullPositions []int
+}
+
+func (h *sortedRunHeap) Len() int {
+	return len(h.items)
+}
+
+func (h *sortedRunHeap) Less(i, j int) bool {
+	a, b := h.items[i], h.items[j]
+	if a.head.sortValues.Less(b.head.sortValues, h.directions, h.nullPositions) {
+		return true
+	}
+	if b.head.sortValues.Less(a.head.sortValues, h.directions, h.nullPositions) {
+		return false
+	}
+	// Records with the same sort keys are returned in the order they were read.
+	return a.index < b.index
+}
+
+func (h *sortedRunHeap) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+}
+
+func (h *sortedRunHeap) Push(x interface{}) {
+	h.items = append(h.items, x.(*sortedRunHeapItem))
+}
+
+func (h *sortedRunHeap) Pop() interface{} {
+	last := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return last
+}
+
+func estimateSortedRecordSize(r sortedRecord) int64 {
+	size := int64(48)
+	for _, cell := range r.record {
+		size += 16 + estimatePrimarySize(cell.Value())
+	}
+	for _, v := range r.sortValues {
+		size += 72 + int64(len(v.String))
+	}
+	return size
+}
+
+func estimatePrimarySize(p value.Primary) int64 {
+	switch v := p.(type) {
+	case value.String:
+		return 32 + int64(len(v.Raw()))
+	case value.Datetime:
+		return 40
+	case value.Array:
+		size := int64(40)
+		for _, e := range v.Raw() {
+			size += 16 + estimatePrimarySize(e)
+		}
+		return size
+	case value.Json:
+		return 64 + int64(len(v.String()))
+	}
+	return 24
+}
+
+const (
+	spillNull byte = iota
+	spillString
+	spillInteger
+	spillFloat
+	spillBoolean
+	spillTernary
+	spillDatetime
+	spillArray
+	spillJson
+)
+
+func writeSortedRecord(w *bufio.Writer, r sortedRecord) error {
+	buf := make([]byte, 0, 256)
+	buf = binary.AppendUvarint(buf, uint64(len(r.record)))
+	for _, cell := range r.record {
+		buf = appendSpilledPrimary(buf, cell.Value())
+	}
+	buf = binary.AppendUvarint(buf, uint64(len(r.sortValues)))
+	for _, v := range r.sortValues {
+		buf = append(buf, byte(v.Type))
+		buf = binary.AppendVarint(buf, v.Integer)
+		buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(v.Float))
+		buf = binary.AppendVarint(buf, v.Datetime)
+		buf = appendSpilledString(buf, v.String)
+		buf = appendSpilledBool(buf, v.Boolean)
+	}
+
+	if _, err := w.Write(buf); err != nil {
+		return NewSystemError(err.Error())
+	}
+	return nil
+}
+
+func appendSpilledPrimary(buf []byte, p value.Primary) []byte {
+	switch v := p.(type) {
+	case value.String:
+		buf = append(buf, spillString)
+		buf = appendSpilledString(buf, v.Raw())
+	case value.Integer:
+		buf = append(buf, spillInteger)
+		buf = binary.AppendVarint(buf, v.Raw())
+	case value.Float:
+		buf = append(buf, spillFloat)
+		buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(v.Raw()))
+	case value.Boolean:
+		buf = append(buf, spillBoolean)
+		buf = appendSpilledBool(buf, v.Raw())
+	case value.Ternary:
+		buf = append(buf, spillTernary, byte(v.Ternary()))
+	case value.Datetime:
+		b, _ := v.Raw().MarshalBinary()
+		buf = append(buf, spillDatetime)
+		buf = appendSpilledString(buf, string(b))
+	case value.Array:
+		buf = append(buf, spillArray)
+		buf = binary.AppendUvarint(buf, uint64(v.Len()))
+		for _, e := range v.Raw() {
+			buf = appendSpilledPrimary(buf, e)
+		}
+	case value.Json:
+		buf = append(buf, spillJson)
+		buf = appendSpilledString(buf, v.String())
+	default:
+		buf = append(buf, spillNull)
+	}
+	return buf
+}
+
+func appendSpilledString(buf []byte, s string) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(s)))
+	return append(buf, s...)
+}
+
+func appendSpilledBool(buf []byte, b bool) []byte {
+	if b {
+		return append(buf, 1)
+	}
+	return append(buf, 0)
+}
+
+func readSortedRecord(r *bufio.Reader) (sortedRecord, error) {
+	var rec sortedRecord
+
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return rec, err
+	}
+	rec.record = make(Record, n)
+	for i := range rec.record {
+		p, err := readSpilledPrimary(r)
+		if err != nil {
+			return rec, unexpectedEOF(err)
+		}
+		rec.record[i] = NewCell(p)
+	}
+
+	if n, err = binary.ReadUvarint(r); err != nil {
+		return rec, unexpectedEOF(err)
+	}
+	rec.sortValues = make(SortValues, n)
+	for i := range rec.sortValues {
+		v := &SortValue{}
+		t, err := r.ReadByte()
+		if err != nil {
+			return rec, unexpectedEOF(err)
+		}
+		v.Type = SortValueType(t)
+		if v.Integer, err = binary.ReadVarint(r); err != nil {
+			return rec, unexpectedEOF(err)
+		}
+		f, err := readSpilledUint64(r)
+		if err != nil {
+			return rec, unexpectedEOF(err)
+		}
+		v.Float = math.Float64frombits(f)
+		if v.Datetime, err = binary.ReadVarint(r); err != nil {
+			return rec, unexpectedEOF(err)
+		}
+		if v.String, err = readSpilledString(r); err != nil {
+			return rec, unexpectedEOF(err)
+		}
+		if v.Boolean, err = readSpilledBool(r); err != nil {
+			return rec, unexpectedEOF(err)
+		}
+		rec.sortValues[i] = v
+	}
+	return rec, nil
+}
+
+func readSpilledPrimary(r *bufio.Reader) (value.Primary, error) {
+	t, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t {
+	case spillString:
+		s, err := readSpilledString(r)
+		if err != nil {
+			return nil, err
+		}
+		return value.NewString(s), nil
+	case spillInteger:
+		i, err := binary.ReadVarint(r)
+		if err != nil {
+			return nil, err
+		}
+		return value.NewInteger(i), nil
+	case spillFloat:
+		f, err := readSpilledUint64(r)
+		if err != nil {
+			return nil, err
+		}
+		return value.NewFloat(math.Float64frombits(f)), nil
+	case spillBoolean:
+		b, err := readSpilledBool(r)
+		if err != nil {
+			return nil, err
+		}
+		return value.NewBoolean(b), nil
+	case spillTernary:
+		b, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		return value.NewTernary(ternary.Value(int8(b))), nil
+	case spillDatetime:
+		s, err := readSpilledString(r)
+		if err != nil {
+			return nil, err
+		}
+		var dt time.Time
+		if err = dt.UnmarshalBinary([]byte(s)); err != nil {
+			return nil, err
+		}
+		return value.NewDatetime(dt), nil
+	case spillArray:
+		n, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]value.Primary, n)
+		for i := range values {
+			if values[i], err = readSpilledPrimary(r); err != nil {
+				return nil, err
+			}
+		}
+		return value.NewArray(values), nil
+	case spillJson:
+		s, err := readSpilledString(r)
+		if err != nil {
+			return nil, err
+		}
+		structure, _, err := txjson.NewDecoder().Decode(s)
+		if err != nil {
+			return nil, err
+		}
+		return value.NewJson(structure), nil
+	}
+	return value.NewNull(), nil
+}
+
+func readSpilledString(r *bufio.Reader) (string, error) {
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return "", err
+	}
+	b := make([]byte, n)
+	if _, err = io.ReadFull(r, b); err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func readSpilledUint64(r *bufio.Reader) (uint64, error) {
+	b := make([]byte, 8)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(b), nil
+}
+
+func readSpilledBool(r *bufio.Reader) (bool, error) {
+	b, err := r.ReadByte()
+	return b == 1, err
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,52 @@
+package query
+
+import (
+	"bufio"
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mithrandie/csvq/lib/value"
+
+	"github.com/mithrandie/ternary"
+)
+
+func TestWriteSortedRecord(t *testing.T) {
+	location := time.FixedZone("", 9*60*60)
+	record := sortedRecord{
+		record: NewRecord([]value.Primary{
+			value.NewNull(),
+			value.NewString("str"),
+			value.NewInteger(-12),
+			value.NewFloat(1.5),
+			value.NewBoolean(true),
+			value.NewTernary(ternary.FALSE),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, location)),
+			value.NewArray([]value.Primary{value.NewInteger(1), value.NewString("a")}),
+		}),
+		sortValues: SortValues{
+			{Type: NullType},
+			{Type: IntegerType, Integer: -12, Float: -12, Datetime: -12e9, String: "-12"},
+			{Type: StringType, String: "STR"},
+		},
+	}
+
+	buf := new(bytes.Buffer)
+	w := bufio.NewWriter(buf)
+	if err := writeSortedRecord(w, record); err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	_ = w.Flush()
+
+	result, err := readSortedRecord(bufio.NewReader(buf))
+	if err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	if !reflect.DeepEqual(result.record, record.record) {
+		t.Errorf("record = %s, want %s", result.record, record.record)
+	}
+	if !reflect.DeepEqual(result.sortValues, record.sortValues) {
+		t.Errorf("sort values = %v, want %v", result.sortValues, record.sortValues)
+	}
+}
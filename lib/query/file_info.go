@@ -27,6 +27,7 @@ const (
 	TableEncloseAll         = "ENCLOSE_ALL"
 	TableJsonEscape         = "JSON_ESCAPE"
 	TablePrettyPrint        = "PRETTY_PRINT"
+	TableEncrypted          = "ENCRYPTED"
 )
 
 var FileAttributeList = []string{
@@ -39,6 +40,7 @@ var FileAttributeList = []string{
 	TableEncloseAll,
 	TableJsonEscape,
 	TablePrettyPrint,
+	TableEncrypted,
 }
 
 type TableAttributeUnchangedError struct {
@@ -70,6 +72,7 @@ type FileInfo struct {
 	EncloseAll         bool
 	JsonEscape         json.EscapeType
 	PrettyPrint        bool
+	Encrypted          bool
 
 	SingleLine bool
 
@@ -78,6 +81,21 @@ type FileInfo struct {
 	IsTemporary      bool
 	InitialHeader    Header
 	InitialRecordSet RecordSet
+	PrimaryKey       *ViewIndex
+
+	spilledRecordSet *spilledRecordSet
+
+	// Statistics of the records in the file, available if the statistics cache is enabled
+	statistics *TableStatistics
+
+	// Status of the file when the records are loaded
+	loadedStat os.FileInfo
+
+	// Progress of loading the records, available while the file is loaded if the progress flag is enabled
+	progress *progress
+
+	// Sampler of the records, available while the file is loaded for a TABLESAMPLE clause
+	sampler *tableSampler
 }
 
 func NewFileInfo(
@@ -252,6 +270,28 @@ func (f *FileInfo) SetPrettyPrint(b bool) error {
 	return nil
 }
 
+func (f *FileInfo) SetEncrypted(b bool) error {
+	if b == f.Encrypted {
+		return NewTableAttributeUnchangedError(f.Path)
+	}
+	f.Encrypted = b
+	return nil
+}
+
+// isModified reports whether the file has been modified or removed since the records were loaded.
+// If the status at the loading is unknown, then the file is regarded as not modified.
+func (f *FileInfo) isModified() bool {
+	if f.loadedStat == nil {
+		return false
+	}
+
+	stat, err := os.Stat(f.Path)
+	if err != nil {
+		return true
+	}
+	return !stat.ModTime().Equal(f.loadedStat.ModTime()) || stat.Size() != f.loadedStat.Size()
+}
+
 func SearchFilePath(filename parser.Identifier, repository string, format cmd.Format, flags *cmd.Flags) (string, cmd.Format, error) {
 	var fpath string
 	var err error
@@ -380,6 +420,16 @@ func NewFileInfoForCreate(filename parser.Identifier, repository string, delimit
 	}, nil
 }
 
+// IsInRepository reports whether the file path refers to a file in the repository.
+// Absolute paths and relative paths leaving the repository are not in the repository.
+func IsInRepository(fpath string) bool {
+	if filepath.IsAbs(fpath) || 0 < len(filepath.VolumeName(fpath)) {
+		return false
+	}
+	p := filepath.Clean(fpath)
+	return p != ".." && !strings.HasPrefix(p, ".."+string(filepath.Separator))
+}
+
 func CreateFilePath(filename parser.Identifier, repository string) (string, error) {
 	fpath := filename.Literal
 	if !filepath.IsAbs(fpath) {
@@ -337,3 +337,23 @@ func TestNewFileInfoForCreate(t *testing.T) {
 		}
 	}
 }
+
+func TestIsInRepository(t *testing.T) {
+	for _, v := range []struct {
+		Path   string
+		Expect bool
+	}{
+		{Path: "table1.csv", Expect: true},
+		{Path: "dir/table1.csv", Expect: true},
+		{Path: "dir/../table1.csv", Expect: true},
+		{Path: "..table1.csv", Expect: true},
+		{Path: "/path/to/table1.csv", Expect: false},
+		{Path: "../table1.csv", Expect: false},
+		{Path: "dir/../../table1.csv", Expect: false},
+		{Path: "..", Expect: false},
+	} {
+		if result := IsInRepository(v.Path); result != v.Expect {
+			t.Errorf("in repository %q = %t, want %t", v.Path, result, v.Expect)
+		}
+	}
+}
@@ -42,8 +42,23 @@ type Filter struct {
 
 	checkAvailableParallelRoutine bool
 
+	// Arguments of batchable functions collected by prefetchExternalFunctionResults
+	externalCalls map[*UserDefinedFunction][][]value.Primary
+
 	cachedFilePath map[string]string
 	now            time.Time
+
+	// Columns to be loaded from files. Nil means all columns.
+	loadColumns ColumnSet
+
+	// Recorder of the executed operations for EXPLAIN ANALYZE
+	recorder *operationRecorder
+
+	// Results of the subqueries that do not refer to the outer queries
+	subqueries *subqueryCache
+
+	// Files that the result of the query depends on, collected for the result cache
+	dependencies *queryDependencies
 }
 
 type ContainsSubstitusion struct{}
@@ -112,6 +127,10 @@ func (f *Filter) Merge(filter *Filter) {
 	f.aliases = filter.aliases
 	f.cachedFilePath = filter.cachedFilePath
 	f.now = filter.now
+	f.loadColumns = filter.loadColumns
+	f.recorder = filter.recorder
+	f.subqueries = filter.subqueries
+	f.dependencies = filter.dependencies
 }
 
 func (f *Filter) CreateChildScope() *Filter {
@@ -132,7 +151,8 @@ func (f *Filter) ResetCurrentScope() {
 		f.variables[0].variables.Delete(k)
 		return true
 	})
-	for k := range f.tempViews[0] {
+	for k, view := range f.tempViews[0] {
+		view.closeSpilledRecordSet()
 		delete(f.tempViews[0], k)
 	}
 	for k := range f.cursors[0] {
@@ -157,6 +177,10 @@ func (f *Filter) CreateNode() *Filter {
 		recursiveTmpView: f.recursiveTmpView,
 		cachedFilePath:   f.cachedFilePath,
 		now:              f.now,
+		loadColumns:      f.loadColumns,
+		recorder:         f.recorder,
+		subqueries:       f.subqueries,
+		dependencies:     f.dependencies,
 	}
 
 	if filter.cachedFilePath == nil {
@@ -165,6 +189,9 @@ func (f *Filter) CreateNode() *Filter {
 	if filter.now.IsZero() {
 		filter.now = cmd.Now()
 	}
+	if filter.subqueries == nil {
+		filter.subqueries = newSubqueryCache()
+	}
 
 	return filter
 }
@@ -213,6 +240,10 @@ func (f *Filter) Evaluate(ctx context.Context, expr parser.QueryExpression) (val
 		val, err = f.evalUnaryArithmetic(ctx, expr.(parser.UnaryArithmetic))
 	case parser.Concat:
 		val, err = f.evalConcat(ctx, expr.(parser.Concat))
+	case parser.ArrayConstructor:
+		val, err = f.evalArrayConstructor(ctx, expr.(parser.ArrayConstructor))
+	case parser.ArrayElement:
+		val, err = f.evalArrayElement(ctx, expr.(parser.ArrayElement))
 	case parser.Comparison:
 		val, err = f.evalComparison(ctx, expr.(parser.Comparison))
 	case parser.Is:
@@ -244,22 +275,29 @@ func (f *Filter) Evaluate(ctx context.Context, expr parser.QueryExpression) (val
 	case parser.UnaryLogic:
 		val, err = f.evalUnaryLogic(ctx, expr.(parser.UnaryLogic))
 	case parser.Variable:
+		f.dependencies.setUncacheable()
 		val, err = f.variables.Get(expr.(parser.Variable))
 	case parser.EnvironmentVariable:
+		f.dependencies.setUncacheable()
 		val = value.NewString(os.Getenv(expr.(parser.EnvironmentVariable).Name))
 	case parser.RuntimeInformation:
+		f.dependencies.setUncacheable()
 		val, err = GetRuntimeInformation(f.tx, expr.(parser.RuntimeInformation))
 	case parser.VariableSubstitution:
+		f.dependencies.setUncacheable()
 		if f.checkAvailableParallelRoutine {
 			err = &ContainsSubstitusion{}
 		} else {
 			val, err = f.variables.Substitute(ctx, f, expr.(parser.VariableSubstitution))
 		}
 	case parser.CursorStatus:
+		f.dependencies.setUncacheable()
 		val, err = f.evalCursorStatus(expr.(parser.CursorStatus))
 	case parser.CursorAttrebute:
+		f.dependencies.setUncacheable()
 		val, err = f.evalCursorAttribute(expr.(parser.CursorAttrebute))
 	case parser.Placeholder:
+		f.dependencies.setUncacheable()
 		val, err = f.evalPlaceholder(ctx, expr.(parser.Placeholder))
 	default:
 		return nil, NewInvalidValueExpressionError(expr)
@@ -270,9 +308,14 @@ func (f *Filter) Evaluate(ctx context.Context, expr parser.QueryExpression) (val
 
 func (f *Filter) EvaluateSequentially(ctx context.Context, fn func(*Filter, int) error, expr interface{}) error {
 	if expr == nil || f.canUseMultithreading(ctx, expr) {
+		if err := f.prefetchExternalFunctionResults(ctx, expr); err != nil {
+			return err
+		}
+
 		header := f.records[0].view.Header
 		recordSet := f.records[0].view.RecordSet
 		isGrouped := f.records[0].view.isGrouped
+		partialAggregates := f.records[0].view.partialAggregates
 		f.records = f.records[1:]
 
 		gm := NewGoroutineTaskManager(len(recordSet), -1, f.tx.Flags.CPU)
@@ -283,10 +326,11 @@ func (f *Filter) EvaluateSequentially(ctx context.Context, fn func(*Filter, int)
 				filter := NewFilterForSequentialEvaluation(
 					f,
 					&View{
-						Tx:        f.tx,
-						Header:    header,
-						RecordSet: recordSet[start:end],
-						isGrouped: isGrouped,
+						Tx:                f.tx,
+						Header:            header,
+						RecordSet:         recordSet[start:end],
+						isGrouped:         isGrouped,
+						partialAggregates: partialAggregates,
 					},
 				)
 				filter.init()
@@ -341,6 +385,46 @@ func (f *Filter) currentIndex() int {
 	return f.records[0].recordIndex
 }
 
+// prefetchExternalFunctionResults collects the arguments of deterministic functions executed by
+// external processes in all records, and executes the functions in batches before the evaluation.
+func (f *Filter) prefetchExternalFunctionResults(ctx context.Context, expr interface{}) error {
+	if expr == nil || !f.functions.hasBatchableFunctions() {
+		return nil
+	}
+
+	var exprs []parser.QueryExpression
+	switch e := expr.(type) {
+	case parser.QueryExpression:
+		exprs = []parser.QueryExpression{e}
+	case []parser.QueryExpression:
+		exprs = e
+	}
+
+	f.externalCalls = make(map[*UserDefinedFunction][][]value.Primary)
+	f.init()
+	for f.next() {
+		if ctx.Err() != nil {
+			break
+		}
+		for _, e := range exprs {
+			_, _ = f.Evaluate(ctx, e)
+		}
+	}
+	calls := f.externalCalls
+	f.externalCalls = nil
+
+	if ctx.Err() != nil {
+		return NewContextIsDone(ctx.Err().Error())
+	}
+
+	for fn, argsList := range calls {
+		if err := fn.Prefetch(ctx, f, argsList); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (f *Filter) canUseMultithreading(ctx context.Context, expr interface{}) bool {
 	if 0 < len(f.records) && f.records[0].view != nil && 0 < f.records[0].view.Len() {
 		f.init()
@@ -469,6 +553,43 @@ func (f *Filter) evalConcat(ctx context.Context, expr parser.Concat) (value.Prim
 	return value.NewString(strings.Join(items, "")), nil
 }
 
+func (f *Filter) evalArrayConstructor(ctx context.Context, expr parser.ArrayConstructor) (value.Primary, error) {
+	values := make([]value.Primary, len(expr.Values))
+	for i, v := range expr.Values {
+		p, err := f.Evaluate(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = p
+	}
+	return value.NewArray(values), nil
+}
+
+func (f *Filter) evalArrayElement(ctx context.Context, expr parser.ArrayElement) (value.Primary, error) {
+	p, err := f.Evaluate(ctx, expr.Array)
+	if err != nil {
+		return nil, err
+	}
+	array, ok := p.(value.Array)
+	if !ok {
+		return value.NewNull(), nil
+	}
+
+	p, err = f.Evaluate(ctx, expr.Index)
+	if err != nil {
+		return nil, err
+	}
+	i := value.ToInteger(p)
+	if value.IsNull(i) {
+		return value.NewNull(), nil
+	}
+	index := int(i.(value.Integer).Raw())
+	if index < 0 || array.Len() <= index {
+		return value.NewNull(), nil
+	}
+	return array.Raw()[index], nil
+}
+
 func (f *Filter) evalComparison(ctx context.Context, expr parser.Comparison) (value.Primary, error) {
 	var t ternary.Value
 
@@ -480,6 +601,10 @@ func (f *Filter) evalComparison(ctx context.Context, expr parser.Comparison) (va
 		return value.NewTernary(ternary.UNKNOWN), nil
 	}
 
+	if !expr.Collation.IsEmpty() || f.tx.Flags.CaseSensitive {
+		return f.evalComparisonWithCollation(ctx, expr, lhs)
+	}
+
 	if 1 == len(lhs) {
 		lhsVal := lhs[0]
 
@@ -508,6 +633,38 @@ func (f *Filter) evalComparison(ctx context.Context, expr parser.Comparison) (va
 	return value.NewTernary(t), nil
 }
 
+func (f *Filter) evalComparisonWithCollation(ctx context.Context, expr parser.Comparison, lhs value.RowValue) (value.Primary, error) {
+	collation := value.CaseSensitiveCollation
+	if !expr.Collation.IsEmpty() {
+		c, err := value.GetCollation(expr.Collation.Name)
+		if err != nil {
+			return nil, NewInvalidCollationError(expr.Collation)
+		}
+		collation = c
+	}
+
+	var rhs value.RowValue
+	var err error
+	if 1 == len(lhs) {
+		p, err := f.Evaluate(ctx, expr.RHS)
+		if err != nil {
+			return nil, err
+		}
+		rhs = value.RowValue{p}
+	} else {
+		rhs, err = f.evalRowValue(ctx, expr.RHS.(parser.RowValue))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	t, err := value.CompareRowValuesWithCollation(lhs, rhs, expr.Operator, f.tx.Flags.DatetimeFormat, collation)
+	if err != nil {
+		return nil, NewRowValueLengthInComparisonError(expr.RHS.(parser.RowValue), len(lhs))
+	}
+	return value.NewTernary(t), nil
+}
+
 func (f *Filter) evalIs(ctx context.Context, expr parser.Is) (value.Primary, error) {
 	lhs, err := f.Evaluate(ctx, expr.LHS)
 	if err != nil {
@@ -594,41 +751,47 @@ func (f *Filter) evalBetween(ctx context.Context, expr parser.Between) (value.Pr
 }
 
 func (f *Filter) valuesForRowValueListComparison(ctx context.Context, lhs parser.QueryExpression, values parser.QueryExpression) (value.RowValue, []value.RowValue, error) {
-	var rowValue value.RowValue
-	var list []value.RowValue
-	var err error
-
-	rowValue, err = f.evalRowValue(ctx, lhs)
+	rowValue, err := f.evalRowValue(ctx, lhs)
 	if err != nil {
-		return rowValue, list, err
+		return rowValue, nil, err
 	}
 
+	list, err := f.listForRowValueComparison(ctx, rowValue, values)
+	return rowValue, list, err
+}
+
+func (f *Filter) listForRowValueComparison(ctx context.Context, rowValue value.RowValue, values parser.QueryExpression) ([]value.RowValue, error) {
 	if rowValue != nil && 1 < len(rowValue) {
-		list, err = f.evalRowValueList(ctx, values)
-	} else {
-		list, err = f.evalArray(ctx, values)
+		return f.evalRowValueList(ctx, values)
 	}
-
-	return rowValue, list, err
+	return f.evalArray(ctx, values)
 }
 
 func (f *Filter) evalIn(ctx context.Context, expr parser.In) (value.Primary, error) {
-	val, list, err := f.valuesForRowValueListComparison(ctx, expr.LHS, expr.Values)
+	val, err := f.evalRowValue(ctx, expr.LHS)
 	if err != nil {
 		return nil, err
 	}
 
-	t, err := Any(val, list, "=", f.tx.Flags.DatetimeFormat)
-	if err != nil {
-		if subquery, ok := expr.Values.(parser.Subquery); ok {
-			return nil, NewSelectFieldLengthInComparisonError(subquery, len(val))
-		} else if jsonQuery, ok := expr.Values.(parser.JsonQuery); ok {
-			return nil, NewRowValueLengthInComparisonError(jsonQuery, len(val))
+	t, ok := f.inSubqueryResult(ctx, val, expr.Values)
+	if !ok {
+		list, err := f.listForRowValueComparison(ctx, val, expr.Values)
+		if err != nil {
+			return nil, err
 		}
 
-		rvlist, _ := expr.Values.(parser.RowValueList)
-		rverr, _ := err.(*RowValueLengthInListError)
-		return nil, NewRowValueLengthInComparisonError(rvlist.RowValues[rverr.Index], len(val))
+		t, err = Any(val, list, "=", f.tx.Flags.DatetimeFormat)
+		if err != nil {
+			if subquery, ok := expr.Values.(parser.Subquery); ok {
+				return nil, NewSelectFieldLengthInComparisonError(subquery, len(val))
+			} else if jsonQuery, ok := expr.Values.(parser.JsonQuery); ok {
+				return nil, NewRowValueLengthInComparisonError(jsonQuery, len(val))
+			}
+
+			rvlist, _ := expr.Values.(parser.RowValueList)
+			rverr, _ := err.(*RowValueLengthInListError)
+			return nil, NewRowValueLengthInComparisonError(rvlist.RowValues[rverr.Index], len(val))
+		}
 	}
 
 	if expr.IsNegated() {
@@ -637,6 +800,25 @@ func (f *Filter) evalIn(ctx context.Context, expr parser.In) (value.Primary, err
 	return value.NewTernary(t), nil
 }
 
+// inSubqueryResult evaluates the IN operator using a hash table of the result of the subquery
+// that does not refer to the outer queries.
+// If the hash table cannot be used, then false is returned as the second value.
+func (f *Filter) inSubqueryResult(ctx context.Context, rowValue value.RowValue, values parser.QueryExpression) (ternary.Value, bool) {
+	if rv, ok := values.(parser.RowValue); ok {
+		values = rv.Value
+	}
+	subquery, ok := values.(parser.Subquery)
+	if !ok {
+		return ternary.FALSE, false
+	}
+
+	r := f.uncorrelatedSubquery(ctx, subquery)
+	if r == nil {
+		return ternary.FALSE, false
+	}
+	return r.in(rowValue, f.tx.Flags)
+}
+
 func (f *Filter) evalAny(ctx context.Context, expr parser.Any) (value.Primary, error) {
 	val, list, err := f.valuesForRowValueListComparison(ctx, expr.LHS, expr.Values)
 	if err != nil {
@@ -689,7 +871,12 @@ func (f *Filter) evalLike(ctx context.Context, expr parser.Like) (value.Primary,
 		return nil, err
 	}
 
-	t := Like(lhs, pattern)
+	var t ternary.Value
+	if f.tx.Flags.CaseSensitive && !expr.IgnoresCase() {
+		t = CaseSensitiveLike(lhs, pattern)
+	} else {
+		t = Like(lhs, pattern)
+	}
 	if expr.IsNegated() {
 		t = ternary.Not(t)
 	}
@@ -697,7 +884,7 @@ func (f *Filter) evalLike(ctx context.Context, expr parser.Like) (value.Primary,
 }
 
 func (f *Filter) evalExists(ctx context.Context, expr parser.Exists) (value.Primary, error) {
-	view, err := Select(ctx, f, expr.Query.Query)
+	view, err := f.selectSubquery(ctx, expr.Query)
 	if err != nil {
 		return nil, err
 	}
@@ -708,7 +895,7 @@ func (f *Filter) evalExists(ctx context.Context, expr parser.Exists) (value.Prim
 }
 
 func (f *Filter) evalSubqueryForValue(ctx context.Context, expr parser.Subquery) (value.Primary, error) {
-	view, err := Select(ctx, f, expr.Query)
+	view, err := f.selectSubquery(ctx, expr)
 	if err != nil {
 		return nil, err
 	}
@@ -731,7 +918,16 @@ func (f *Filter) evalSubqueryForValue(ctx context.Context, expr parser.Subquery)
 func (f *Filter) evalFunction(ctx context.Context, expr parser.Function) (value.Primary, error) {
 	name := strings.ToUpper(expr.Name)
 
-	if _, ok := Functions[name]; !ok && name != "CALL" && name != "NOW" && name != "JSON_OBJECT" {
+	if _, ok := AggregateFunctions[name]; ok {
+		aggrdcl := parser.AggregateFunction{
+			BaseExpr: expr.BaseExpr,
+			Name:     expr.Name,
+			Args:     expr.Args,
+		}
+		return f.evalAggregateFunction(ctx, aggrdcl)
+	}
+
+	if _, ok := Functions[name]; !ok && name != "CALL" && name != "NOW" && name != "NEXTVAL" && name != "JSON_OBJECT" && name != "GROUPING" {
 		udfn, err := f.functions.Get(expr, name)
 		if err != nil {
 			return nil, NewFunctionNotExistError(expr, expr.Name)
@@ -752,6 +948,8 @@ func (f *Filter) evalFunction(ctx context.Context, expr parser.Function) (value.
 
 	if name == "JSON_OBJECT" {
 		return JsonObject(ctx, f, expr)
+	} else if name == "GROUPING" {
+		return Grouping(f, expr)
 	}
 
 	args := make([]value.Primary, len(expr.Args))
@@ -763,22 +961,52 @@ func (f *Filter) evalFunction(ctx context.Context, expr parser.Function) (value.
 		args[i] = arg
 	}
 
+	if isNondeterministicFunction(name) {
+		f.dependencies.setUncacheable()
+	}
+
 	if name == "CALL" {
+		if f.tx.Restricted {
+			return nil, NewRestrictedStatementError(expr, "function CALL")
+		}
+		if f.tx.Flags.ReadOnly {
+			// Commands can change files.
+			return nil, NewCallInReadOnlyModeError(expr)
+		}
 		return Call(ctx, expr, args)
 	} else if name == "NOW" {
 		return Now(f, expr, args)
+	} else if name == "NEXTVAL" {
+		if f.checkAvailableParallelRoutine {
+			return nil, &ContainsSubstitusion{}
+		}
+		return NextVal(f, expr, args)
 	}
 
 	if fn, ok := Functions[name]; ok {
+		if f.checkAvailableParallelRoutine && RandomFunctions[name] && -1 < f.tx.Flags.RandomSeed {
+			return nil, &ContainsSubstitusion{}
+		}
 		return fn(expr, args, f.tx.Flags)
 	}
 
+	// User defined functions can refer to variables and tables out of the query.
+	f.dependencies.setUncacheable()
+
 	udfn, _ := f.functions.Get(expr, name)
+	if f.externalCalls != nil && udfn.isBatchable() {
+		f.externalCalls[udfn] = append(f.externalCalls[udfn], args)
+		return value.NewNull(), nil
+	}
+	if f.checkAvailableParallelRoutine && !udfn.IsDeterministic {
+		return nil, &ContainsSubstitusion{}
+	}
 	return udfn.Execute(ctx, f, args)
 }
 
 func (f *Filter) evalAggregateFunction(ctx context.Context, expr parser.AggregateFunction) (value.Primary, error) {
 	var aggfn func([]value.Primary, *cmd.Flags) value.Primary
+	var bivarfn BivariateAggregateFunction
 	var udfn *UserDefinedFunction
 	var useUserDefined bool
 	var err error
@@ -786,17 +1014,29 @@ func (f *Filter) evalAggregateFunction(ctx context.Context, expr parser.Aggregat
 	uname := strings.ToUpper(expr.Name)
 	if fn, ok := AggregateFunctions[uname]; ok {
 		aggfn = fn
+	} else if fn, ok := BivariateAggregateFunctions[uname]; ok {
+		bivarfn = fn
 	} else {
 		if udfn, err = f.functions.Get(expr, uname); err != nil || !udfn.IsAggregate {
 			return nil, NewFunctionNotExistError(expr, expr.Name)
 		}
 		useUserDefined = true
 	}
+	if useUserDefined || isNondeterministicFunction(uname) {
+		f.dependencies.setUncacheable()
+	}
 
 	if useUserDefined {
 		if err = udfn.CheckArgsLen(expr, expr.Name, len(expr.Args)-1); err != nil {
 			return nil, err
 		}
+	} else if bivarfn != nil {
+		if len(expr.Args) != 2 {
+			return nil, NewFunctionArgumentLengthError(expr, expr.Name, []int{2})
+		}
+		if expr.IsDistinct() {
+			return nil, NewFunctionInvalidArgumentError(expr, expr.Name, "DISTINCT cannot be used")
+		}
 	} else {
 		if len(expr.Args) != 1 {
 			return nil, NewFunctionArgumentLengthError(expr, expr.Name, []int{1})
@@ -811,6 +1051,10 @@ func (f *Filter) evalAggregateFunction(ctx context.Context, expr parser.Aggregat
 		return nil, NewNotGroupingRecordsError(expr, expr.Name)
 	}
 
+	if idx, ok := f.records[0].view.partialAggregates[expr.BaseExpr]; ok {
+		return f.records[0].view.RecordSet[f.records[0].recordIndex][idx].Value(), nil
+	}
+
 	listExpr := expr.Args[0]
 	if _, ok := listExpr.(parser.AllColumns); ok {
 		listExpr = parser.NewIntegerValue(1)
@@ -822,12 +1066,33 @@ func (f *Filter) evalAggregateFunction(ctx context.Context, expr parser.Aggregat
 		}
 	}
 
-	view := NewViewFromGroupedRecord(f.records[0])
-	list, err := view.ListValuesForAggregateFunctions(ctx, expr, listExpr, expr.IsDistinct(), f)
+	var view *View
+	listValues := func(arg parser.QueryExpression, distinct bool) ([]value.Primary, error) {
+		if list, ok := groupedValues(f.records[0], arg); ok {
+			if distinct {
+				list = Distinguish(list, f.tx.Flags)
+			}
+			return list, nil
+		}
+		if view == nil {
+			view = NewViewFromGroupedRecord(f.records[0])
+		}
+		return view.ListValuesForAggregateFunctions(ctx, expr, arg, distinct, f)
+	}
+
+	list, err := listValues(listExpr, expr.IsDistinct())
 	if err != nil {
 		return nil, err
 	}
 
+	if bivarfn != nil {
+		xlist, err := listValues(expr.Args[1], false)
+		if err != nil {
+			return nil, err
+		}
+		return bivarfn(list, xlist, f.tx.Flags), nil
+	}
+
 	if useUserDefined {
 		argsExprs := expr.Args[1:]
 		args := make([]value.Primary, len(argsExprs))
@@ -846,11 +1111,21 @@ func (f *Filter) evalAggregateFunction(ctx context.Context, expr parser.Aggregat
 
 func (f *Filter) evalListFunction(ctx context.Context, expr parser.ListFunction) (value.Primary, error) {
 	var separator string
+	var fraction float64
+	var rate float64
 	var err error
 
 	switch strings.ToUpper(expr.Name) {
-	case "JSON_AGG":
+	case "PERCENTILE_CONT", "PERCENTILE_DISC":
+		fraction, err = f.checkArgsForPercentileFunction(ctx, expr)
+	case "NPV":
+		rate, err = f.checkArgsForNpv(ctx, expr)
+	case "IRR":
+		rate, err = f.checkArgsForIrr(ctx, expr)
+	case "JSON_AGG", "ARRAY_AGG":
 		err = f.checkArgsForJsonAgg(expr)
+	case "FIRST", "LAST":
+		err = f.checkArgsForOrderedValueFunction(expr)
 	default: // LISTAGG
 		separator, err = f.checkArgsForListFunction(ctx, expr)
 	}
@@ -875,14 +1150,36 @@ func (f *Filter) evalListFunction(ctx context.Context, expr parser.ListFunction)
 		}
 	}
 
-	list, err := view.ListValuesForAggregateFunctions(ctx, expr, expr.Args[0], expr.IsDistinct(), f)
+	listExpr := expr.Args[0]
+	switch strings.ToUpper(expr.Name) {
+	case "PERCENTILE_CONT", "PERCENTILE_DISC":
+		listExpr = expr.OrderBy.(parser.OrderByClause).Items[0].(parser.OrderItem).Value
+	case "NPV":
+		listExpr = expr.Args[1]
+	}
+
+	list, err := view.ListValuesForAggregateFunctions(ctx, expr, listExpr, expr.IsDistinct(), f)
 	if err != nil {
 		return nil, err
 	}
 
 	switch strings.ToUpper(expr.Name) {
+	case "PERCENTILE_CONT":
+		return PercentileCont(list, fraction, f.tx.Flags), nil
+	case "PERCENTILE_DISC":
+		return PercentileDisc(list, fraction), nil
+	case "NPV":
+		return Npv(list, rate), nil
+	case "IRR":
+		return Irr(list, rate), nil
 	case "JSON_AGG":
 		return JsonAgg(list), nil
+	case "ARRAY_AGG":
+		return ArrayAgg(list), nil
+	case "FIRST":
+		return First(list), nil
+	case "LAST":
+		return Last(list), nil
 	}
 	return ListAgg(list, separator), nil
 }
@@ -908,6 +1205,68 @@ func (f *Filter) checkArgsForListFunction(ctx context.Context, expr parser.ListF
 	return separator, nil
 }
 
+func (f *Filter) checkArgsForPercentileFunction(ctx context.Context, expr parser.ListFunction) (float64, error) {
+	if len(expr.Args) != 1 {
+		return 0, NewFunctionArgumentLengthError(expr, expr.Name, []int{1})
+	}
+
+	if len(expr.WithinGroup) < 1 || expr.OrderBy == nil || len(expr.OrderBy.(parser.OrderByClause).Items) != 1 {
+		return 0, NewFunctionInvalidArgumentError(expr, expr.Name, "WITHIN GROUP clause with exactly one order item is required")
+	}
+
+	p, err := f.Evaluate(ctx, expr.Args[0])
+	if err != nil {
+		return 0, err
+	}
+	fraction := value.ToFloat(p)
+	if value.IsNull(fraction) || fraction.(value.Float).Raw() < 0 || 1 < fraction.(value.Float).Raw() {
+		return 0, NewFunctionInvalidArgumentError(expr, expr.Name, "the first argument must be a number between 0 and 1")
+	}
+	return fraction.(value.Float).Raw(), nil
+}
+
+func (f *Filter) checkArgsForNpv(ctx context.Context, expr parser.ListFunction) (float64, error) {
+	if len(expr.Args) != 2 {
+		return 0, NewFunctionArgumentLengthError(expr, expr.Name, []int{2})
+	}
+	if expr.IsDistinct() {
+		return 0, NewFunctionInvalidArgumentError(expr, expr.Name, "DISTINCT cannot be used")
+	}
+
+	p, err := f.Evaluate(ctx, expr.Args[0])
+	if err != nil {
+		return 0, err
+	}
+	rate := value.ToFloat(p)
+	if value.IsNull(rate) || rate.(value.Float).Raw() <= -1 {
+		return 0, NewFunctionInvalidArgumentError(expr, expr.Name, "the first argument must be a number greater than -1")
+	}
+	return rate.(value.Float).Raw(), nil
+}
+
+func (f *Filter) checkArgsForIrr(ctx context.Context, expr parser.ListFunction) (float64, error) {
+	if len(expr.Args) < 1 || 2 < len(expr.Args) {
+		return 0, NewFunctionArgumentLengthError(expr, expr.Name, []int{1, 2})
+	}
+	if expr.IsDistinct() {
+		return 0, NewFunctionInvalidArgumentError(expr, expr.Name, "DISTINCT cannot be used")
+	}
+
+	if len(expr.Args) < 2 {
+		return 0.1, nil
+	}
+
+	p, err := f.Evaluate(ctx, expr.Args[1])
+	if err != nil {
+		return 0, err
+	}
+	guess := value.ToFloat(p)
+	if value.IsNull(guess) || guess.(value.Float).Raw() <= -1 {
+		return 0, NewFunctionInvalidArgumentError(expr, expr.Name, "the second argument must be a number greater than -1")
+	}
+	return guess.(value.Float).Raw(), nil
+}
+
 func (f *Filter) checkArgsForJsonAgg(expr parser.ListFunction) error {
 	if 1 != len(expr.Args) {
 		return NewFunctionArgumentLengthError(expr, expr.Name, []int{1})
@@ -915,7 +1274,21 @@ func (f *Filter) checkArgsForJsonAgg(expr parser.ListFunction) error {
 	return nil
 }
 
+func (f *Filter) checkArgsForOrderedValueFunction(expr parser.ListFunction) error {
+	if 1 != len(expr.Args) {
+		return NewFunctionArgumentLengthError(expr, expr.Name, []int{1})
+	}
+	if expr.OrderBy == nil {
+		return NewFunctionInvalidArgumentError(expr, expr.Name, "ORDER BY clause is required")
+	}
+	return nil
+}
+
 func (f *Filter) evalCaseExpr(ctx context.Context, expr parser.CaseExpr) (value.Primary, error) {
+	if f.checkAvailableParallelRoutine {
+		return f.checkCaseExpr(ctx, expr)
+	}
+
 	var val value.Primary
 	var err error
 	if expr.Value != nil {
@@ -959,6 +1332,31 @@ func (f *Filter) evalCaseExpr(ctx context.Context, expr parser.CaseExpr) (value.
 	return result, nil
 }
 
+// checkCaseExpr evaluates all the branches of the case expression, not only the matched one,
+// to find the expressions that prevent parallel evaluation.
+func (f *Filter) checkCaseExpr(ctx context.Context, expr parser.CaseExpr) (value.Primary, error) {
+	exprs := make([]parser.QueryExpression, 0, len(expr.When)*2+2)
+	if expr.Value != nil {
+		exprs = append(exprs, expr.Value)
+	}
+	for _, v := range expr.When {
+		when := v.(parser.CaseExprWhen)
+		exprs = append(exprs, when.Condition, when.Result)
+	}
+	if expr.Else != nil {
+		exprs = append(exprs, expr.Else.(parser.CaseExprElse).Result)
+	}
+
+	for _, e := range exprs {
+		if _, err := f.Evaluate(ctx, e); err != nil {
+			if _, ok := err.(*ContainsSubstitusion); ok {
+				return nil, err
+			}
+		}
+	}
+	return value.NewNull(), nil
+}
+
 func (f *Filter) evalLogic(ctx context.Context, expr parser.Logic) (value.Primary, error) {
 	lhs, err := f.Evaluate(ctx, expr.LHS)
 	if err != nil {
@@ -1061,7 +1459,12 @@ func (f *Filter) evalPlaceholder(ctx context.Context, expr parser.Placeholder) (
 			return nil, NewStatementReplaceValueNotSpecifiedError(expr)
 		}
 	}
-	return f.Evaluate(ctx, replace.Values[idx])
+
+	val, err := f.Evaluate(ctx, replace.Values[idx])
+	if err != nil {
+		return nil, err
+	}
+	return replace.Types.Convert(expr, val, f.tx.Flags)
 }
 
 /*
@@ -1130,13 +1533,23 @@ func (f *Filter) evalArray(ctx context.Context, expr parser.QueryExpression) ([]
 	case parser.JsonQuery:
 		array, err = f.evalJsonQueryForArray(ctx, expr.(parser.JsonQuery))
 	case parser.ValueList:
-		values, e := f.evalValueList(ctx, expr.(parser.ValueList))
+		valueList := expr.(parser.ValueList)
+		values, e := f.evalValueList(ctx, valueList)
 		if e != nil {
 			return array, e
 		}
-		array = make([]value.RowValue, len(values))
+		array = make([]value.RowValue, 0, len(values))
 		for i, v := range values {
-			array[i] = value.RowValue{v}
+			if _, ok := valueList.Values[i].(parser.Placeholder); ok {
+				if elems, ok := v.(value.Array); ok {
+					// An array passed to a placeholder is expanded to the list.
+					for _, elem := range elems.Raw() {
+						array = append(array, value.RowValue{elem})
+					}
+					continue
+				}
+			}
+			array = append(array, value.RowValue{v})
 		}
 	case parser.RowValue:
 		array, err = f.evalArray(ctx, expr.(parser.RowValue).Value)
@@ -1146,7 +1559,7 @@ func (f *Filter) evalArray(ctx context.Context, expr parser.QueryExpression) ([]
 }
 
 func (f *Filter) evalSubqueryForRowValue(ctx context.Context, expr parser.Subquery) (value.RowValue, error) {
-	view, err := Select(ctx, f, expr.Query)
+	view, err := f.selectSubquery(ctx, expr)
 	if err != nil {
 		return nil, err
 	}
@@ -1211,7 +1624,7 @@ func (f *Filter) evalValueList(ctx context.Context, expr parser.ValueList) (valu
 }
 
 func (f *Filter) evalSubqueryForRowValueList(ctx context.Context, expr parser.Subquery) ([]value.RowValue, error) {
-	view, err := Select(ctx, f, expr.Query)
+	view, err := f.selectSubquery(ctx, expr)
 	if err != nil {
 		return nil, err
 	}
@@ -1260,7 +1673,7 @@ func (f *Filter) evalJsonQueryForRowValueList(ctx context.Context, expr parser.J
 }
 
 func (f *Filter) evalSubqueryForArray(ctx context.Context, expr parser.Subquery) ([]value.RowValue, error) {
-	view, err := Select(ctx, f, expr.Query)
+	view, err := f.selectSubquery(ctx, expr)
 	if err != nil {
 		return nil, err
 	}
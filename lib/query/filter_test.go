@@ -10,6 +10,8 @@ import (
 	"github.com/mithrandie/csvq/lib/parser"
 	"github.com/mithrandie/csvq/lib/value"
 
+	txjson "github.com/mithrandie/go-text/json"
+
 	"github.com/mithrandie/ternary"
 )
 
@@ -327,6 +329,58 @@ var filterEvaluateTests = []struct {
 		},
 		Result: value.NewNull(),
 	},
+	{
+		Name: "Array Constructor",
+		Expr: parser.ArrayConstructor{
+			Values: []parser.QueryExpression{
+				parser.NewIntegerValueFromString("1"),
+				parser.NewStringValue("a"),
+			},
+		},
+		Result: value.NewArray([]value.Primary{value.NewInteger(1), value.NewString("a")}),
+	},
+	{
+		Name: "Array Constructor FieldNotExist Error",
+		Expr: parser.ArrayConstructor{
+			Values: []parser.QueryExpression{
+				parser.FieldReference{Column: parser.Identifier{Literal: "notexist"}},
+			},
+		},
+		Error: "field notexist does not exist",
+	},
+	{
+		Name: "Array Element",
+		Expr: parser.ArrayElement{
+			Array: parser.ArrayConstructor{
+				Values: []parser.QueryExpression{
+					parser.NewIntegerValueFromString("1"),
+					parser.NewStringValue("a"),
+				},
+			},
+			Index: parser.NewIntegerValueFromString("1"),
+		},
+		Result: value.NewString("a"),
+	},
+	{
+		Name: "Array Element Index Out Of Range",
+		Expr: parser.ArrayElement{
+			Array: parser.ArrayConstructor{
+				Values: []parser.QueryExpression{
+					parser.NewIntegerValueFromString("1"),
+				},
+			},
+			Index: parser.NewIntegerValueFromString("1"),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "Array Element Not An Array",
+		Expr: parser.ArrayElement{
+			Array: parser.NewStringValue("a"),
+			Index: parser.NewIntegerValueFromString("0"),
+		},
+		Result: value.NewNull(),
+	},
 	{
 		Name: "Concat",
 		Expr: parser.Concat{
@@ -369,6 +423,26 @@ var filterEvaluateTests = []struct {
 		},
 		Result: value.NewTernary(ternary.FALSE),
 	},
+	{
+		Name: "Comparison With Collation",
+		Expr: parser.Comparison{
+			LHS:       parser.NewStringValue("Äpfel"),
+			RHS:       parser.NewStringValue("Birne"),
+			Operator:  "<",
+			Collation: parser.Collation{Collate: "collate", Name: "de_DE"},
+		},
+		Result: value.NewTernary(ternary.TRUE),
+	},
+	{
+		Name: "Comparison With Invalid Collation Error",
+		Expr: parser.Comparison{
+			LHS:       parser.NewStringValue("Äpfel"),
+			RHS:       parser.NewStringValue("Birne"),
+			Operator:  "<",
+			Collation: parser.Collation{Collate: "collate", Name: "!!"},
+		},
+		Error: "\"!!\" is not a valid collation",
+	},
 	{
 		Name: "Comparison LHS Error",
 		Expr: parser.Comparison{
@@ -2657,6 +2731,141 @@ var filterEvaluateTests = []struct {
 		},
 		Result: value.NewInteger(2),
 	},
+	{
+		Name: "Bivariate Aggregate Function",
+		Filter: &Filter{
+			records: []filterRecord{
+				{
+					view: &View{
+						Header: NewHeaderWithId("table1", []string{"column1", "column2"}),
+						RecordSet: []Record{
+							{
+								NewGroupCell([]value.Primary{
+									value.NewInteger(1),
+									value.NewInteger(2),
+									value.NewInteger(3),
+									value.NewInteger(4),
+								}),
+								NewGroupCell([]value.Primary{
+									value.NewInteger(1),
+									value.NewInteger(2),
+									value.NewInteger(3),
+									value.NewNull(),
+								}),
+								NewGroupCell([]value.Primary{
+									value.NewInteger(3),
+									value.NewInteger(5),
+									value.NewInteger(7),
+									value.NewInteger(9),
+								}),
+							},
+						},
+						isGrouped: true,
+						Tx:        TestTx,
+					},
+					recordIndex: 0,
+				},
+			},
+		},
+		Expr: parser.AggregateFunction{
+			Name: "regr_slope",
+			Args: []parser.QueryExpression{
+				parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+				parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+			},
+		},
+		Result: value.NewInteger(2),
+	},
+	{
+		Name: "Bivariate Aggregate Function Argument Length Error",
+		Filter: &Filter{
+			records: []filterRecord{
+				{
+					view: &View{
+						Header: NewHeaderWithId("table1", []string{"column1", "column2"}),
+						RecordSet: []Record{
+							{
+								NewGroupCell([]value.Primary{
+									value.NewInteger(1),
+									value.NewInteger(2),
+									value.NewInteger(3),
+									value.NewInteger(4),
+								}),
+								NewGroupCell([]value.Primary{
+									value.NewInteger(1),
+									value.NewInteger(2),
+									value.NewInteger(3),
+									value.NewNull(),
+								}),
+								NewGroupCell([]value.Primary{
+									value.NewInteger(3),
+									value.NewInteger(5),
+									value.NewInteger(7),
+									value.NewInteger(9),
+								}),
+							},
+						},
+						isGrouped: true,
+						Tx:        TestTx,
+					},
+					recordIndex: 0,
+				},
+			},
+		},
+		Expr: parser.AggregateFunction{
+			Name: "corr",
+			Args: []parser.QueryExpression{
+				parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+			},
+		},
+		Error: "function corr takes exactly 2 arguments",
+	},
+	{
+		Name: "Bivariate Aggregate Function Distinct Error",
+		Filter: &Filter{
+			records: []filterRecord{
+				{
+					view: &View{
+						Header: NewHeaderWithId("table1", []string{"column1", "column2"}),
+						RecordSet: []Record{
+							{
+								NewGroupCell([]value.Primary{
+									value.NewInteger(1),
+									value.NewInteger(2),
+									value.NewInteger(3),
+									value.NewInteger(4),
+								}),
+								NewGroupCell([]value.Primary{
+									value.NewInteger(1),
+									value.NewInteger(2),
+									value.NewInteger(3),
+									value.NewNull(),
+								}),
+								NewGroupCell([]value.Primary{
+									value.NewInteger(3),
+									value.NewInteger(5),
+									value.NewInteger(7),
+									value.NewInteger(9),
+								}),
+							},
+						},
+						isGrouped: true,
+						Tx:        TestTx,
+					},
+					recordIndex: 0,
+				},
+			},
+		},
+		Expr: parser.AggregateFunction{
+			Name:     "corr",
+			Distinct: parser.Token{Token: parser.DISTINCT, Literal: "distinct"},
+			Args: []parser.QueryExpression{
+				parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+				parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+			},
+		},
+		Error: "DISTINCT cannot be used for function corr",
+	},
 	{
 		Name: "Aggregate Function Argument Length Error",
 		Filter: &Filter{
@@ -3635,7 +3844,7 @@ var filterEvaluateTests = []struct {
 				},
 			},
 		},
-		Result: value.NewString("[null,\"str1\",\"str2\"]"),
+		Result: value.NewJson(txjson.Array{txjson.Null{}, txjson.String("str1"), txjson.String("str2")}),
 	},
 	{
 		Name: "JsonAgg Function Arguments Error",
@@ -3681,46 +3890,748 @@ var filterEvaluateTests = []struct {
 		Error: "function json_agg takes exactly 1 argument",
 	},
 	{
-		Name: "CaseExpr Comparison",
-		Expr: parser.CaseExpr{
-			Value: parser.NewIntegerValue(2),
-			When: []parser.QueryExpression{
-				parser.CaseExprWhen{
-					Condition: parser.NewIntegerValue(1),
-					Result:    parser.NewStringValue("A"),
+		Name: "ArrayAgg Function",
+		Filter: &Filter{
+			records: []filterRecord{
+				{
+					view: &View{
+						Header: NewHeaderWithId("table1", []string{"column1", "column2"}),
+						RecordSet: []Record{
+							{
+								NewGroupCell([]value.Primary{
+									value.NewInteger(1),
+									value.NewInteger(2),
+									value.NewInteger(3),
+									value.NewInteger(4),
+								}),
+								NewGroupCell([]value.Primary{
+									value.NewInteger(1),
+									value.NewInteger(2),
+									value.NewInteger(3),
+									value.NewInteger(4),
+								}),
+								NewGroupCell([]value.Primary{
+									value.NewString("str2"),
+									value.NewString("str1"),
+									value.NewNull(),
+									value.NewString("str2"),
+								}),
+							},
+						},
+						Filter:    NewFilter(TestTx),
+						isGrouped: true,
+						Tx:        TestTx,
+					},
+					recordIndex: 0,
 				},
-				parser.CaseExprWhen{
-					Condition: parser.NewIntegerValue(2),
-					Result:    parser.NewStringValue("B"),
+			},
+		},
+		Expr: parser.ListFunction{
+			Name: "array_agg",
+			Args: []parser.QueryExpression{
+				parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+			},
+			OrderBy: parser.OrderByClause{
+				Items: []parser.QueryExpression{
+					parser.OrderItem{Value: parser.FieldReference{Column: parser.Identifier{Literal: "column2"}}},
 				},
 			},
 		},
-		Result: value.NewString("B"),
+		Result: value.NewArray([]value.Primary{value.NewNull(), value.NewString("str1"), value.NewString("str2"), value.NewString("str2")}),
 	},
 	{
-		Name: "CaseExpr Filter",
-		Expr: parser.CaseExpr{
-			When: []parser.QueryExpression{
-				parser.CaseExprWhen{
-					Condition: parser.Comparison{
-						LHS:      parser.NewIntegerValue(2),
-						RHS:      parser.NewIntegerValue(1),
-						Operator: "=",
-					},
-					Result: parser.NewStringValue("A"),
-				},
-				parser.CaseExprWhen{
-					Condition: parser.Comparison{
-						LHS:      parser.NewIntegerValue(2),
-						RHS:      parser.NewIntegerValue(2),
-						Operator: "=",
+		Name: "First Function",
+		Filter: &Filter{
+			records: []filterRecord{
+				{
+					view: &View{
+						Header: NewHeaderWithId("table1", []string{"column1", "column2"}),
+						RecordSet: []Record{
+							{
+								NewGroupCell([]value.Primary{
+									value.NewInteger(1),
+									value.NewInteger(2),
+									value.NewInteger(3),
+									value.NewInteger(4),
+								}),
+								NewGroupCell([]value.Primary{
+									value.NewInteger(1),
+									value.NewInteger(2),
+									value.NewInteger(3),
+									value.NewInteger(4),
+								}),
+								NewGroupCell([]value.Primary{
+									value.NewString("str1"),
+									value.NewString("str2"),
+									value.NewNull(),
+									value.NewString("str4"),
+								}),
+							},
+						},
+						Filter:    NewFilter(TestTx),
+						isGrouped: true,
+						Tx:        TestTx,
 					},
-					Result: parser.NewStringValue("B"),
+					recordIndex: 0,
 				},
 			},
 		},
-		Result: value.NewString("B"),
-	},
+		Expr: parser.ListFunction{
+			Name: "first",
+			Args: []parser.QueryExpression{
+				parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+			},
+			OrderBy: parser.OrderByClause{
+				Items: []parser.QueryExpression{
+					parser.OrderItem{Value: parser.FieldReference{Column: parser.Identifier{Literal: "column1"}}, Direction: parser.Token{Token: parser.DESC, Literal: "desc"}},
+				},
+			},
+		},
+		Result: value.NewString("str4"),
+	}, {
+		Name: "Last Function",
+		Filter: &Filter{
+			records: []filterRecord{
+				{
+					view: &View{
+						Header: NewHeaderWithId("table1", []string{"column1", "column2"}),
+						RecordSet: []Record{
+							{
+								NewGroupCell([]value.Primary{
+									value.NewInteger(1),
+									value.NewInteger(2),
+									value.NewInteger(3),
+									value.NewInteger(4),
+								}),
+								NewGroupCell([]value.Primary{
+									value.NewInteger(1),
+									value.NewInteger(2),
+									value.NewInteger(3),
+									value.NewInteger(4),
+								}),
+								NewGroupCell([]value.Primary{
+									value.NewString("str1"),
+									value.NewString("str2"),
+									value.NewNull(),
+									value.NewString("str4"),
+								}),
+							},
+						},
+						Filter:    NewFilter(TestTx),
+						isGrouped: true,
+						Tx:        TestTx,
+					},
+					recordIndex: 0,
+				},
+			},
+		},
+		Expr: parser.ListFunction{
+			Name: "last",
+			Args: []parser.QueryExpression{
+				parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+			},
+			OrderBy: parser.OrderByClause{
+				Items: []parser.QueryExpression{
+					parser.OrderItem{Value: parser.FieldReference{Column: parser.Identifier{Literal: "column1"}}, Direction: parser.Token{Token: parser.DESC, Literal: "desc"}},
+				},
+			},
+		},
+		Result: value.NewString("str1"),
+	}, {
+		Name: "First Function Order By Error",
+		Filter: &Filter{
+			records: []filterRecord{
+				{
+					view: &View{
+						Header: NewHeaderWithId("table1", []string{"column1", "column2"}),
+						RecordSet: []Record{
+							{
+								NewGroupCell([]value.Primary{
+									value.NewInteger(1),
+									value.NewInteger(2),
+									value.NewInteger(3),
+									value.NewInteger(4),
+								}),
+								NewGroupCell([]value.Primary{
+									value.NewInteger(1),
+									value.NewInteger(2),
+									value.NewInteger(3),
+									value.NewInteger(4),
+								}),
+								NewGroupCell([]value.Primary{
+									value.NewString("str1"),
+									value.NewString("str2"),
+									value.NewNull(),
+									value.NewString("str4"),
+								}),
+							},
+						},
+						Filter:    NewFilter(TestTx),
+						isGrouped: true,
+						Tx:        TestTx,
+					},
+					recordIndex: 0,
+				},
+			},
+		},
+		Expr: parser.ListFunction{
+			Name: "first",
+			Args: []parser.QueryExpression{
+				parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+			},
+		},
+		Error: "ORDER BY clause is required for function first",
+	},
+	{
+		Name: "PercentileCont Function",
+		Filter: &Filter{
+			records: []filterRecord{
+				{
+					view: &View{
+						Header: NewHeaderWithId("table1", []string{"column1", "column2"}),
+						RecordSet: []Record{
+							{
+								NewGroupCell([]value.Primary{
+									value.NewInteger(1),
+									value.NewInteger(2),
+									value.NewInteger(3),
+									value.NewInteger(4),
+									value.NewInteger(5),
+								}),
+								NewGroupCell([]value.Primary{
+									value.NewInteger(1),
+									value.NewInteger(2),
+									value.NewInteger(3),
+									value.NewInteger(4),
+									value.NewInteger(5),
+								}),
+								NewGroupCell([]value.Primary{
+									value.NewInteger(40),
+									value.NewInteger(10),
+									value.NewNull(),
+									value.NewInteger(30),
+									value.NewInteger(20),
+								}),
+							},
+						},
+						Filter:    NewFilter(TestTx),
+						isGrouped: true,
+						Tx:        TestTx,
+					},
+					recordIndex: 0,
+				},
+			},
+		},
+		Expr: parser.ListFunction{
+			Name: "percentile_cont",
+			Args: []parser.QueryExpression{
+				parser.NewFloatValue(0.5),
+			},
+			WithinGroup: "within group",
+			OrderBy: parser.OrderByClause{
+				Items: []parser.QueryExpression{
+					parser.OrderItem{Value: parser.FieldReference{Column: parser.Identifier{Literal: "column2"}}},
+				},
+			},
+		},
+		Result: value.NewInteger(25),
+	},
+	{
+		Name: "PercentileCont Function Descending Order",
+		Filter: &Filter{
+			records: []filterRecord{
+				{
+					view: &View{
+						Header: NewHeaderWithId("table1", []string{"column1", "column2"}),
+						RecordSet: []Record{
+							{
+								NewGroupCell([]value.Primary{
+									value.NewInteger(1),
+									value.NewInteger(2),
+									value.NewInteger(3),
+									value.NewInteger(4),
+									value.NewInteger(5),
+								}),
+								NewGroupCell([]value.Primary{
+									value.NewInteger(1),
+									value.NewInteger(2),
+									value.NewInteger(3),
+									value.NewInteger(4),
+									value.NewInteger(5),
+								}),
+								NewGroupCell([]value.Primary{
+									value.NewInteger(40),
+									value.NewInteger(10),
+									value.NewNull(),
+									value.NewInteger(30),
+									value.NewInteger(20),
+								}),
+							},
+						},
+						Filter:    NewFilter(TestTx),
+						isGrouped: true,
+						Tx:        TestTx,
+					},
+					recordIndex: 0,
+				},
+			},
+		},
+		Expr: parser.ListFunction{
+			Name: "percentile_cont",
+			Args: []parser.QueryExpression{
+				parser.NewFloatValue(0.25),
+			},
+			WithinGroup: "within group",
+			OrderBy: parser.OrderByClause{
+				Items: []parser.QueryExpression{
+					parser.OrderItem{Value: parser.FieldReference{Column: parser.Identifier{Literal: "column2"}}, Direction: parser.Token{Token: parser.DESC, Literal: "desc"}},
+				},
+			},
+		},
+		Result: value.NewFloat(32.5),
+	},
+	{
+		Name: "PercentileDisc Function",
+		Filter: &Filter{
+			records: []filterRecord{
+				{
+					view: &View{
+						Header: NewHeaderWithId("table1", []string{"column1", "column2"}),
+						RecordSet: []Record{
+							{
+								NewGroupCell([]value.Primary{
+									value.NewInteger(1),
+									value.NewInteger(2),
+									value.NewInteger(3),
+									value.NewInteger(4),
+									value.NewInteger(5),
+								}),
+								NewGroupCell([]value.Primary{
+									value.NewInteger(1),
+									value.NewInteger(2),
+									value.NewInteger(3),
+									value.NewInteger(4),
+									value.NewInteger(5),
+								}),
+								NewGroupCell([]value.Primary{
+									value.NewInteger(40),
+									value.NewInteger(10),
+									value.NewNull(),
+									value.NewInteger(30),
+									value.NewInteger(20),
+								}),
+							},
+						},
+						Filter:    NewFilter(TestTx),
+						isGrouped: true,
+						Tx:        TestTx,
+					},
+					recordIndex: 0,
+				},
+			},
+		},
+		Expr: parser.ListFunction{
+			Name: "percentile_disc",
+			Args: []parser.QueryExpression{
+				parser.NewFloatValue(0.5),
+			},
+			WithinGroup: "within group",
+			OrderBy: parser.OrderByClause{
+				Items: []parser.QueryExpression{
+					parser.OrderItem{Value: parser.FieldReference{Column: parser.Identifier{Literal: "column2"}}},
+				},
+			},
+		},
+		Result: value.NewInteger(20),
+	},
+	{
+		Name: "PercentileDisc Function Zero Fraction",
+		Filter: &Filter{
+			records: []filterRecord{
+				{
+					view: &View{
+						Header: NewHeaderWithId("table1", []string{"column1", "column2"}),
+						RecordSet: []Record{
+							{
+								NewGroupCell([]value.Primary{
+									value.NewInteger(1),
+									value.NewInteger(2),
+									value.NewInteger(3),
+									value.NewInteger(4),
+									value.NewInteger(5),
+								}),
+								NewGroupCell([]value.Primary{
+									value.NewInteger(1),
+									value.NewInteger(2),
+									value.NewInteger(3),
+									value.NewInteger(4),
+									value.NewInteger(5),
+								}),
+								NewGroupCell([]value.Primary{
+									value.NewInteger(40),
+									value.NewInteger(10),
+									value.NewNull(),
+									value.NewInteger(30),
+									value.NewInteger(20),
+								}),
+							},
+						},
+						Filter:    NewFilter(TestTx),
+						isGrouped: true,
+						Tx:        TestTx,
+					},
+					recordIndex: 0,
+				},
+			},
+		},
+		Expr: parser.ListFunction{
+			Name: "percentile_disc",
+			Args: []parser.QueryExpression{
+				parser.NewIntegerValue(0),
+			},
+			WithinGroup: "within group",
+			OrderBy: parser.OrderByClause{
+				Items: []parser.QueryExpression{
+					parser.OrderItem{Value: parser.FieldReference{Column: parser.Identifier{Literal: "column2"}}, Direction: parser.Token{Token: parser.DESC, Literal: "desc"}},
+				},
+			},
+		},
+		Result: value.NewInteger(40),
+	},
+	{
+		Name: "Npv Function",
+		Filter: &Filter{
+			records: []filterRecord{
+				{
+					view: &View{
+						Header: NewHeaderWithId("table1", []string{"column1", "column2"}),
+						RecordSet: []Record{
+							{
+								NewGroupCell([]value.Primary{
+									value.NewInteger(1),
+									value.NewInteger(2),
+									value.NewInteger(3),
+								}),
+								NewGroupCell([]value.Primary{
+									value.NewInteger(3),
+									value.NewInteger(1),
+									value.NewInteger(2),
+								}),
+								NewGroupCell([]value.Primary{
+									value.NewInteger(60),
+									value.NewInteger(-100),
+									value.NewInteger(60),
+								}),
+							},
+						},
+						Filter:    NewFilter(TestTx),
+						isGrouped: true,
+						Tx:        TestTx,
+					},
+					recordIndex: 0,
+				},
+			},
+		},
+		Expr: parser.ListFunction{
+			Name: "npv",
+			Args: []parser.QueryExpression{
+				parser.NewFloatValue(0.1),
+				parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+			},
+			WithinGroup: "within group",
+			OrderBy: parser.OrderByClause{
+				Items: []parser.QueryExpression{
+					parser.OrderItem{Value: parser.FieldReference{Column: parser.Identifier{Literal: "column1"}}},
+				},
+			},
+		},
+		Result: value.NewFloat(3.7565740045078755),
+	},
+	{
+		Name: "Npv Function Invalid Rate Error",
+		Filter: &Filter{
+			records: []filterRecord{
+				{
+					view: &View{
+						Header: NewHeaderWithId("table1", []string{"column1", "column2"}),
+						RecordSet: []Record{
+							{
+								NewGroupCell([]value.Primary{
+									value.NewInteger(1),
+									value.NewInteger(2),
+									value.NewInteger(3),
+								}),
+								NewGroupCell([]value.Primary{
+									value.NewInteger(3),
+									value.NewInteger(1),
+									value.NewInteger(2),
+								}),
+								NewGroupCell([]value.Primary{
+									value.NewInteger(60),
+									value.NewInteger(-100),
+									value.NewInteger(60),
+								}),
+							},
+						},
+						Filter:    NewFilter(TestTx),
+						isGrouped: true,
+						Tx:        TestTx,
+					},
+					recordIndex: 0,
+				},
+			},
+		},
+		Expr: parser.ListFunction{
+			Name: "npv",
+			Args: []parser.QueryExpression{
+				parser.NewIntegerValue(-1),
+				parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+			},
+			WithinGroup: "within group",
+			OrderBy: parser.OrderByClause{
+				Items: []parser.QueryExpression{
+					parser.OrderItem{Value: parser.FieldReference{Column: parser.Identifier{Literal: "column1"}}},
+				},
+			},
+		},
+		Error: "the first argument must be a number greater than -1 for function npv",
+	},
+	{
+		Name: "Irr Function",
+		Filter: &Filter{
+			records: []filterRecord{
+				{
+					view: &View{
+						Header: NewHeaderWithId("table1", []string{"column1", "column2"}),
+						RecordSet: []Record{
+							{
+								NewGroupCell([]value.Primary{
+									value.NewInteger(1),
+									value.NewInteger(2),
+									value.NewInteger(3),
+								}),
+								NewGroupCell([]value.Primary{
+									value.NewInteger(3),
+									value.NewInteger(1),
+									value.NewInteger(2),
+								}),
+								NewGroupCell([]value.Primary{
+									value.NewInteger(60),
+									value.NewInteger(-100),
+									value.NewInteger(60),
+								}),
+							},
+						},
+						Filter:    NewFilter(TestTx),
+						isGrouped: true,
+						Tx:        TestTx,
+					},
+					recordIndex: 0,
+				},
+			},
+		},
+		Expr: parser.ListFunction{
+			Name: "irr",
+			Args: []parser.QueryExpression{
+				parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+			},
+			WithinGroup: "within group",
+			OrderBy: parser.OrderByClause{
+				Items: []parser.QueryExpression{
+					parser.OrderItem{Value: parser.FieldReference{Column: parser.Identifier{Literal: "column1"}}},
+				},
+			},
+		},
+		Result: value.NewFloat(0.13066238629180746),
+	},
+	{
+		Name: "Irr Function Arguments Error",
+		Filter: &Filter{
+			records: []filterRecord{
+				{
+					view: &View{
+						Header: NewHeaderWithId("table1", []string{"column1", "column2"}),
+						RecordSet: []Record{
+							{
+								NewGroupCell([]value.Primary{
+									value.NewInteger(1),
+									value.NewInteger(2),
+									value.NewInteger(3),
+								}),
+								NewGroupCell([]value.Primary{
+									value.NewInteger(3),
+									value.NewInteger(1),
+									value.NewInteger(2),
+								}),
+								NewGroupCell([]value.Primary{
+									value.NewInteger(60),
+									value.NewInteger(-100),
+									value.NewInteger(60),
+								}),
+							},
+						},
+						Filter:    NewFilter(TestTx),
+						isGrouped: true,
+						Tx:        TestTx,
+					},
+					recordIndex: 0,
+				},
+			},
+		},
+		Expr: parser.ListFunction{
+			Name: "irr",
+			Args: []parser.QueryExpression{
+				parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+				parser.NewFloatValue(0.1),
+				parser.NewFloatValue(0.1),
+			},
+		},
+		Error: "function irr takes 1 or 2 arguments",
+	},
+	{
+		Name: "PercentileCont Function Without Order By Error",
+		Filter: &Filter{
+			records: []filterRecord{
+				{
+					view: &View{
+						Header: NewHeaderWithId("table1", []string{"column1", "column2"}),
+						RecordSet: []Record{
+							{
+								NewGroupCell([]value.Primary{
+									value.NewInteger(1),
+									value.NewInteger(2),
+									value.NewInteger(3),
+									value.NewInteger(4),
+									value.NewInteger(5),
+								}),
+								NewGroupCell([]value.Primary{
+									value.NewInteger(1),
+									value.NewInteger(2),
+									value.NewInteger(3),
+									value.NewInteger(4),
+									value.NewInteger(5),
+								}),
+								NewGroupCell([]value.Primary{
+									value.NewInteger(40),
+									value.NewInteger(10),
+									value.NewNull(),
+									value.NewInteger(30),
+									value.NewInteger(20),
+								}),
+							},
+						},
+						Filter:    NewFilter(TestTx),
+						isGrouped: true,
+						Tx:        TestTx,
+					},
+					recordIndex: 0,
+				},
+			},
+		},
+		Expr: parser.ListFunction{
+			Name: "percentile_cont",
+			Args: []parser.QueryExpression{
+				parser.NewFloatValue(0.5),
+			},
+			WithinGroup: "within group",
+		},
+		Error: "WITHIN GROUP clause with exactly one order item is required for function percentile_cont",
+	},
+	{
+		Name: "PercentileCont Function Invalid Fraction Error",
+		Filter: &Filter{
+			records: []filterRecord{
+				{
+					view: &View{
+						Header: NewHeaderWithId("table1", []string{"column1", "column2"}),
+						RecordSet: []Record{
+							{
+								NewGroupCell([]value.Primary{
+									value.NewInteger(1),
+									value.NewInteger(2),
+									value.NewInteger(3),
+									value.NewInteger(4),
+									value.NewInteger(5),
+								}),
+								NewGroupCell([]value.Primary{
+									value.NewInteger(1),
+									value.NewInteger(2),
+									value.NewInteger(3),
+									value.NewInteger(4),
+									value.NewInteger(5),
+								}),
+								NewGroupCell([]value.Primary{
+									value.NewInteger(40),
+									value.NewInteger(10),
+									value.NewNull(),
+									value.NewInteger(30),
+									value.NewInteger(20),
+								}),
+							},
+						},
+						Filter:    NewFilter(TestTx),
+						isGrouped: true,
+						Tx:        TestTx,
+					},
+					recordIndex: 0,
+				},
+			},
+		},
+		Expr: parser.ListFunction{
+			Name: "percentile_cont",
+			Args: []parser.QueryExpression{
+				parser.NewFloatValue(1.5),
+			},
+			WithinGroup: "within group",
+			OrderBy: parser.OrderByClause{
+				Items: []parser.QueryExpression{
+					parser.OrderItem{Value: parser.FieldReference{Column: parser.Identifier{Literal: "column2"}}},
+				},
+			},
+		},
+		Error: "the first argument must be a number between 0 and 1 for function percentile_cont",
+	},
+	{
+		Name: "CaseExpr Comparison",
+		Expr: parser.CaseExpr{
+			Value: parser.NewIntegerValue(2),
+			When: []parser.QueryExpression{
+				parser.CaseExprWhen{
+					Condition: parser.NewIntegerValue(1),
+					Result:    parser.NewStringValue("A"),
+				},
+				parser.CaseExprWhen{
+					Condition: parser.NewIntegerValue(2),
+					Result:    parser.NewStringValue("B"),
+				},
+			},
+		},
+		Result: value.NewString("B"),
+	},
+	{
+		Name: "CaseExpr Filter",
+		Expr: parser.CaseExpr{
+			When: []parser.QueryExpression{
+				parser.CaseExprWhen{
+					Condition: parser.Comparison{
+						LHS:      parser.NewIntegerValue(2),
+						RHS:      parser.NewIntegerValue(1),
+						Operator: "=",
+					},
+					Result: parser.NewStringValue("A"),
+				},
+				parser.CaseExprWhen{
+					Condition: parser.Comparison{
+						LHS:      parser.NewIntegerValue(2),
+						RHS:      parser.NewIntegerValue(2),
+						Operator: "=",
+					},
+					Result: parser.NewStringValue("B"),
+				},
+			},
+		},
+		Result: value.NewString("B"),
+	},
 	{
 		Name: "CaseExpr Else",
 		Expr: parser.CaseExpr{
@@ -4085,6 +4996,56 @@ var filterEvaluateTests = []struct {
 		},
 		Error: "replace value for :notexist is not specified",
 	},
+	{
+		Name: "Placeholder Typed",
+		Expr: parser.Placeholder{
+			Literal: "?",
+			Ordinal: 1,
+		},
+		ReplaceValues: &ReplaceValues{
+			Values: []parser.QueryExpression{parser.NewStringValue("1")},
+			Types:  ParameterTypes{Ordinal: []string{"INTEGER"}},
+		},
+		Result: value.NewInteger(1),
+	},
+	{
+		Name: "Placeholder Type Mismatch Error",
+		Expr: parser.Placeholder{
+			Literal: ":val",
+			Ordinal: 1,
+			Name:    "val",
+		},
+		ReplaceValues: &ReplaceValues{
+			Values: []parser.QueryExpression{parser.NewStringValue("a")},
+			Names:  map[string]int{"val": 0},
+			Types:  ParameterTypes{Named: map[string]string{"val": "INTEGER"}},
+		},
+		Error: "value \"a\" for :val cannot be converted to INTEGER",
+	},
+	{
+		Name: "In Placeholder Array",
+		Expr: parser.In{
+			LHS: parser.NewIntegerValueFromString("2"),
+			Values: parser.RowValue{
+				Value: parser.ValueList{
+					Values: []parser.QueryExpression{
+						parser.Placeholder{Literal: "?", Ordinal: 1},
+					},
+				},
+			},
+		},
+		ReplaceValues: &ReplaceValues{
+			Values: []parser.QueryExpression{
+				parser.ArrayConstructor{
+					Values: []parser.QueryExpression{
+						parser.NewIntegerValueFromString("1"),
+						parser.NewIntegerValueFromString("2"),
+					},
+				},
+			},
+		},
+		Result: value.NewTernary(ternary.TRUE),
+	},
 }
 
 func TestFilter_Evaluate(t *testing.T) {
@@ -4142,6 +5103,68 @@ func TestFilter_Evaluate(t *testing.T) {
 	}
 }
 
+var filterEvaluateCaseSensitiveTests = []struct {
+	Name   string
+	Expr   parser.QueryExpression
+	Result value.Primary
+}{
+	{
+		Name: "Comparison",
+		Expr: parser.Comparison{
+			LHS:      parser.NewStringValue("abc"),
+			RHS:      parser.NewStringValue("ABC"),
+			Operator: "=",
+		},
+		Result: value.NewTernary(ternary.FALSE),
+	},
+	{
+		Name: "Comparison With Collation",
+		Expr: parser.Comparison{
+			LHS:       parser.NewStringValue("abc"),
+			RHS:       parser.NewStringValue("ABC"),
+			Operator:  "=",
+			Collation: parser.Collation{Collate: "collate", Name: "en"},
+		},
+		Result: value.NewTernary(ternary.TRUE),
+	},
+	{
+		Name: "Like",
+		Expr: parser.Like{
+			Like:    "like",
+			LHS:     parser.NewStringValue("abcdefg"),
+			Pattern: parser.NewStringValue("ABC%"),
+		},
+		Result: value.NewTernary(ternary.FALSE),
+	},
+	{
+		Name: "ILike",
+		Expr: parser.Like{
+			Like:    "ilike",
+			LHS:     parser.NewStringValue("abcdefg"),
+			Pattern: parser.NewStringValue("ABC%"),
+		},
+		Result: value.NewTernary(ternary.TRUE),
+	},
+}
+
+func TestFilter_Evaluate_CaseSensitive(t *testing.T) {
+	defer initFlag(TestTx.Flags)
+
+	TestTx.Flags.SetCaseSensitive(true)
+	filter := NewFilter(TestTx)
+
+	for _, v := range filterEvaluateCaseSensitiveTests {
+		result, err := filter.Evaluate(context.Background(), v.Expr)
+		if err != nil {
+			t.Errorf("%s: unexpected error %q", v.Name, err)
+			continue
+		}
+		if !reflect.DeepEqual(result, v.Result) {
+			t.Errorf("%s: result = %s, want %s", v.Name, result, v.Result)
+		}
+	}
+}
+
 var filterEvaluateSequentiallyResults []value.Primary
 
 var filterEvaluateSequentiallyTests = []struct {
@@ -4332,6 +5355,132 @@ func TestFilter_EvaluateSequentially(t *testing.T) {
 	}
 }
 
+var filterCanUseMultithreadingTests = []struct {
+	Name       string
+	Expression parser.QueryExpression
+	RandomSeed bool
+	Expect     bool
+}{
+	{
+		Name:       "Field Reference",
+		Expression: parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+		Expect:     true,
+	},
+	{
+		Name: "Substitution in a Case Branch",
+		Expression: parser.CaseExpr{
+			When: []parser.QueryExpression{
+				parser.CaseExprWhen{
+					Condition: parser.Comparison{
+						LHS:      parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+						RHS:      parser.NewIntegerValue(2),
+						Operator: "=",
+					},
+					Result: parser.VariableSubstitution{
+						Variable: parser.Variable{Name: "var1"},
+						Value:    parser.NewIntegerValue(1),
+					},
+				},
+			},
+			Else: parser.CaseExprElse{Result: parser.NewIntegerValue(0)},
+		},
+		Expect: false,
+	},
+	{
+		Name: "Deterministic User Defined Function",
+		Expression: parser.Function{
+			Name: "detfunc",
+			Args: []parser.QueryExpression{
+				parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+			},
+		},
+		Expect: true,
+	},
+	{
+		Name: "Non-Deterministic User Defined Function",
+		Expression: parser.Function{
+			Name: "userfunc",
+			Args: []parser.QueryExpression{
+				parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+			},
+		},
+		Expect: false,
+	},
+	{
+		Name: "Random Function",
+		Expression: parser.Function{
+			Name: "random",
+		},
+		Expect: true,
+	},
+	{
+		Name: "Random Function with Random Seed",
+		Expression: parser.Function{
+			Name: "random",
+		},
+		RandomSeed: true,
+		Expect:     false,
+	},
+}
+
+func TestFilter_canUseMultithreading(t *testing.T) {
+	defer func() {
+		TestTx.Flags.RandomSeed = -1
+	}()
+
+	userfunc := func(name string, deterministic bool) *UserDefinedFunction {
+		fn := &UserDefinedFunction{
+			Name: parser.Identifier{Literal: name},
+			Parameters: []parser.Variable{
+				{Name: "arg1"},
+			},
+			RequiredArgs: 1,
+			Statements: []parser.Statement{
+				parser.Return{Value: parser.Variable{Name: "arg1"}},
+			},
+			IsDeterministic: deterministic,
+		}
+		if deterministic {
+			fn.results = &sync.Map{}
+		}
+		return fn
+	}
+
+	for _, v := range filterCanUseMultithreadingTests {
+		TestTx.Flags.RandomSeed = -1
+		if v.RandomSeed {
+			TestTx.Flags.RandomSeed = 1
+		}
+
+		filter := NewFilterWithScopes(
+			TestTx,
+			[]VariableMap{GenerateVariableMap(map[string]value.Primary{"var1": value.NewInteger(0)})},
+			[]ViewMap{{}},
+			[]CursorMap{{}},
+			[]UserDefinedFunctionMap{{
+				"DETFUNC":  userfunc("detfunc", true),
+				"USERFUNC": userfunc("userfunc", false),
+			}},
+		)
+		filter.records = []filterRecord{
+			{
+				view: &View{
+					Header: NewHeader("table1", []string{"column1"}),
+					RecordSet: []Record{
+						NewRecord([]value.Primary{value.NewInteger(1)}),
+						NewRecord([]value.Primary{value.NewInteger(2)}),
+					},
+				},
+			},
+		}
+
+		result := filter.canUseMultithreading(context.Background(), v.Expression)
+		if result != v.Expect {
+			t.Errorf("%s: result = %t, want %t", v.Name, result, v.Expect)
+		}
+	}
+}
+
 var filterEvaluateEmbeddedStringTests = []struct {
 	Input  string
 	Expect string
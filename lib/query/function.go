@@ -4,13 +4,17 @@ import (
 	"context"
 	"crypto/hmac"
 	"crypto/md5"
+	"crypto/rand"
 	"crypto/sha1"
 	"crypto/sha256"
 	"crypto/sha512"
+	"crypto/subtle"
 	"encoding/base64"
 	"encoding/hex"
 	"hash"
+	"hash/crc32"
 	"math"
+	"net/url"
 	"os/exec"
 	"strconv"
 	"strings"
@@ -22,117 +26,169 @@ import (
 	"github.com/mithrandie/csvq/lib/json"
 	"github.com/mithrandie/csvq/lib/parser"
 	"github.com/mithrandie/csvq/lib/value"
+	"github.com/mithrandie/csvq/lib/xpath"
 
 	"github.com/mithrandie/go-text"
 	"github.com/mithrandie/ternary"
+	"golang.org/x/text/unicode/norm"
 )
 
 var Functions = map[string]func(parser.Function, []value.Primary, *cmd.Flags) (value.Primary, error){
-	"COALESCE":         Coalesce,
-	"IF":               If,
-	"IFNULL":           Ifnull,
-	"NULLIF":           Nullif,
-	"CEIL":             Ceil,
-	"FLOOR":            Floor,
-	"ROUND":            Round,
-	"ABS":              Abs,
-	"ACOS":             Acos,
-	"ASIN":             Asin,
-	"ATAN":             Atan,
-	"ATAN2":            Atan2,
-	"COS":              Cos,
-	"SIN":              Sin,
-	"TAN":              Tan,
-	"EXP":              Exp,
-	"EXP2":             Exp2,
-	"EXPM1":            Expm1,
-	"LOG":              MathLog,
-	"LOG10":            Log10,
-	"LOG2":             Log2,
-	"LOG1P":            Log1p,
-	"SQRT":             Sqrt,
-	"POW":              Pow,
-	"BIN_TO_DEC":       BinToDec,
-	"OCT_TO_DEC":       OctToDec,
-	"HEX_TO_DEC":       HexToDec,
-	"ENOTATION_TO_DEC": EnotationToDec,
-	"BIN":              Bin,
-	"OCT":              Oct,
-	"HEX":              Hex,
-	"ENOTATION":        Enotation,
-	"NUMBER_FORMAT":    NumberFormat,
-	"RAND":             Rand,
-	"TRIM":             Trim,
-	"LTRIM":            Ltrim,
-	"RTRIM":            Rtrim,
-	"UPPER":            Upper,
-	"LOWER":            Lower,
-	"BASE64_ENCODE":    Base64Encode,
-	"BASE64_DECODE":    Base64Decode,
-	"HEX_ENCODE":       HexEncode,
-	"HEX_DECODE":       HexDecode,
-	"LEN":              Len,
-	"BYTE_LEN":         ByteLen,
-	"WIDTH":            Width,
-	"LPAD":             Lpad,
-	"RPAD":             Rpad,
-	"SUBSTR":           Substr,
-	"INSTR":            Instr,
-	"LIST_ELEM":        ListElem,
-	"REPLACE":          Replace,
-	"FORMAT":           Format,
-	"JSON_VALUE":       JsonValue,
-	"MD5":              Md5,
-	"SHA1":             Sha1,
-	"SHA256":           Sha256,
-	"SHA512":           Sha512,
-	"MD5_HMAC":         Md5Hmac,
-	"SHA1_HMAC":        Sha1Hmac,
-	"SHA256_HMAC":      Sha256Hmac,
-	"SHA512_HMAC":      Sha512Hmac,
-	"DATETIME_FORMAT":  DatetimeFormat,
-	"YEAR":             Year,
-	"MONTH":            Month,
-	"DAY":              Day,
-	"HOUR":             Hour,
-	"MINUTE":           Minute,
-	"SECOND":           Second,
-	"MILLISECOND":      Millisecond,
-	"MICROSECOND":      Microsecond,
-	"NANOSECOND":       Nanosecond,
-	"WEEKDAY":          Weekday,
-	"UNIX_TIME":        UnixTime,
-	"UNIX_NANO_TIME":   UnixNanoTime,
-	"DAY_OF_YEAR":      DayOfYear,
-	"WEEK_OF_YEAR":     WeekOfYear,
-	"ADD_YEAR":         AddYear,
-	"ADD_MONTH":        AddMonth,
-	"ADD_DAY":          AddDay,
-	"ADD_HOUR":         AddHour,
-	"ADD_MINUTE":       AddMinute,
-	"ADD_SECOND":       AddSecond,
-	"ADD_MILLI":        AddMilli,
-	"ADD_MICRO":        AddMicro,
-	"ADD_NANO":         AddNano,
-	"TRUNC_MONTH":      TruncMonth,
-	"TRUNC_DAY":        TruncDay,
-	"TRUNC_TIME":       TruncTime,
-	"TRUNC_HOUR":       TruncTime,
-	"TRUNC_MINUTE":     TruncMinute,
-	"TRUNC_SECOND":     TruncSecond,
-	"TRUNC_MILLI":      TruncMilli,
-	"TRUNC_MICRO":      TruncMicro,
-	"TRUNC_NANO":       TruncNano,
-	"DATE_DIFF":        DateDiff,
-	"TIME_DIFF":        TimeDiff,
-	"TIME_NANO_DIFF":   TimeNanoDiff,
-	"UTC":              UTC,
-	"STRING":           String,
-	"INTEGER":          Integer,
-	"FLOAT":            Float,
-	"BOOLEAN":          Boolean,
-	"TERNARY":          Ternary,
-	"DATETIME":         Datetime,
+	"COALESCE":                Coalesce,
+	"IF":                      If,
+	"IFNULL":                  Ifnull,
+	"NULLIF":                  Nullif,
+	"CEIL":                    Ceil,
+	"FLOOR":                   Floor,
+	"ROUND":                   Round,
+	"ABS":                     Abs,
+	"ACOS":                    Acos,
+	"ASIN":                    Asin,
+	"ATAN":                    Atan,
+	"ATAN2":                   Atan2,
+	"COS":                     Cos,
+	"SIN":                     Sin,
+	"TAN":                     Tan,
+	"SINH":                    Sinh,
+	"COSH":                    Cosh,
+	"TANH":                    Tanh,
+	"EXP":                     Exp,
+	"EXP2":                    Exp2,
+	"EXPM1":                   Expm1,
+	"LOG":                     MathLog,
+	"LOG10":                   Log10,
+	"LOG2":                    Log2,
+	"LOG1P":                   Log1p,
+	"SQRT":                    Sqrt,
+	"POW":                     Pow,
+	"GAMMA":                   Gamma,
+	"FACTORIAL":               Factorial,
+	"GCD":                     Gcd,
+	"LCM":                     Lcm,
+	"SAFE_DIVIDE":             SafeDivide,
+	"HAVERSINE":               Haversine,
+	"GEOHASH_ENCODE":          GeohashEncode,
+	"GEOHASH_DECODE":          GeohashDecode,
+	"PMT":                     Pmt,
+	"BIN_TO_DEC":              BinToDec,
+	"OCT_TO_DEC":              OctToDec,
+	"HEX_TO_DEC":              HexToDec,
+	"ENOTATION_TO_DEC":        EnotationToDec,
+	"BIN":                     Bin,
+	"OCT":                     Oct,
+	"HEX":                     Hex,
+	"ENOTATION":               Enotation,
+	"NUMBER_FORMAT":           NumberFormat,
+	"RAND":                    Rand,
+	"RANDOM":                  Random,
+	"RANDOM_BETWEEN":          RandomBetween,
+	"RANDOM_STRING":           RandomString,
+	"TRIM":                    Trim,
+	"LTRIM":                   Ltrim,
+	"RTRIM":                   Rtrim,
+	"UPPER":                   Upper,
+	"LOWER":                   Lower,
+	"TITLECASE":               Titlecase,
+	"NORMALIZE":               Normalize,
+	"UNACCENT":                Unaccent,
+	"TRANSLITERATE":           Transliterate,
+	"BASE64_ENCODE":           Base64Encode,
+	"BASE64_DECODE":           Base64Decode,
+	"HEX_ENCODE":              HexEncode,
+	"HEX_DECODE":              HexDecode,
+	"URL_ENCODE":              UrlEncode,
+	"URL_DECODE":              UrlDecode,
+	"URL_EXTRACT_HOST":        UrlExtractHost,
+	"URL_EXTRACT_PATH":        UrlExtractPath,
+	"URL_EXTRACT_QUERY_PARAM": UrlExtractQueryParam,
+	"LEN":                     Len,
+	"BYTE_LEN":                ByteLen,
+	"WIDTH":                   Width,
+	"LPAD":                    Lpad,
+	"RPAD":                    Rpad,
+	"SUBSTR":                  Substr,
+	"INSTR":                   Instr,
+	"LIST_ELEM":               ListElem,
+	"SPLIT_PART":              SplitPart,
+	"STRTOK":                  Strtok,
+	"REPLACE":                 Replace,
+	"FORMAT":                  Format,
+	"JSON_VALUE":              JsonValue,
+	"XPATH_STRING":            XpathString,
+	"XPATH_NUMBER":            XpathNumber,
+	"XPATH_EXISTS":            XpathExists,
+	"UUID":                    Uuid,
+	"UUID_V7":                 UuidV7,
+	"ARRAY_LENGTH":            ArrayLength,
+	"ARRAY_CONTAINS":          ArrayContains,
+	"STRING_TO_ARRAY":         StringToArray,
+	"SPLIT":                   StringToArray,
+	"ARRAY_TO_STRING":         ArrayToString,
+	"MD5":                     Md5,
+	"SHA1":                    Sha1,
+	"SHA256":                  Sha256,
+	"SHA512":                  Sha512,
+	"CRC32":                   Crc32,
+	"MD5_HMAC":                Md5Hmac,
+	"SHA1_HMAC":               Sha1Hmac,
+	"SHA256_HMAC":             Sha256Hmac,
+	"SHA512_HMAC":             Sha512Hmac,
+	"HMAC_SHA256":             HmacSha256,
+	"SECURE_EQUAL":            SecureEqual,
+	"DATETIME_FORMAT":         DatetimeFormat,
+	"TO_CHAR":                 ToChar,
+	"STRPTIME":                Strptime,
+	"YEAR":                    Year,
+	"MONTH":                   Month,
+	"DAY":                     Day,
+	"HOUR":                    Hour,
+	"MINUTE":                  Minute,
+	"SECOND":                  Second,
+	"MILLISECOND":             Millisecond,
+	"MICROSECOND":             Microsecond,
+	"NANOSECOND":              Nanosecond,
+	"WEEKDAY":                 Weekday,
+	"UNIX_TIME":               UnixTime,
+	"UNIX_NANO_TIME":          UnixNanoTime,
+	"DAY_OF_YEAR":             DayOfYear,
+	"WEEK_OF_YEAR":            WeekOfYear,
+	"ISO_WEEK":                WeekOfYear,
+	"ISO_YEAR":                IsoYear,
+	"MONTH_NAME":              MonthName,
+	"DAY_NAME":                DayName,
+	"LAST_DAY":                LastDay,
+	"FIRST_DAY_OF_WEEK":       FirstDayOfWeek,
+	"AGE":                     Age,
+	"ADD_YEAR":                AddYear,
+	"ADD_MONTH":               AddMonth,
+	"ADD_DAY":                 AddDay,
+	"ADD_HOUR":                AddHour,
+	"ADD_MINUTE":              AddMinute,
+	"ADD_SECOND":              AddSecond,
+	"ADD_MILLI":               AddMilli,
+	"ADD_MICRO":               AddMicro,
+	"ADD_NANO":                AddNano,
+	"DATE_ADD":                DateAdd,
+	"TRUNC_MONTH":             TruncMonth,
+	"TRUNC_DAY":               TruncDay,
+	"TRUNC_TIME":              TruncTime,
+	"TRUNC_HOUR":              TruncTime,
+	"TRUNC_MINUTE":            TruncMinute,
+	"TRUNC_SECOND":            TruncSecond,
+	"TRUNC_MILLI":             TruncMilli,
+	"TRUNC_MICRO":             TruncMicro,
+	"TRUNC_NANO":              TruncNano,
+	"DATE_TRUNC":              DateTrunc,
+	"DATE_DIFF":               DateDiff,
+	"TIME_DIFF":               TimeDiff,
+	"TIME_NANO_DIFF":          TimeNanoDiff,
+	"UTC":                     UTC,
+	"STRING":                  String,
+	"INTEGER":                 Integer,
+	"FLOAT":                   Float,
+	"BOOLEAN":                 Boolean,
+	"TERNARY":                 Ternary,
+	"DATETIME":                Datetime,
 }
 
 type Direction string
@@ -342,6 +398,18 @@ func Tan(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary,
 	return execMath1Arg(fn, args, math.Tan)
 }
 
+func Sinh(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
+	return execMath1Arg(fn, args, math.Sinh)
+}
+
+func Cosh(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
+	return execMath1Arg(fn, args, math.Cosh)
+}
+
+func Tanh(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
+	return execMath1Arg(fn, args, math.Tanh)
+}
+
 func Exp(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
 	return execMath1Arg(fn, args, math.Exp)
 }
@@ -378,6 +446,274 @@ func Pow(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary,
 	return execMath2Args(fn, args, math.Pow)
 }
 
+func Gamma(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
+	return execMath1Arg(fn, args, math.Gamma)
+}
+
+func Factorial(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
+	if len(args) != 1 {
+		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{1})
+	}
+
+	p := value.ToInteger(args[0])
+	if value.IsNull(p) {
+		return value.NewNull(), nil
+	}
+	n := p.(value.Integer).Raw()
+	if n < 0 || 20 < n {
+		return value.NewNull(), nil
+	}
+
+	var result int64 = 1
+	for i := int64(2); i <= n; i++ {
+		result *= i
+	}
+	return value.NewInteger(result), nil
+}
+
+func execIntegerMath2Args(fn parser.Function, args []value.Primary, mathf func(uint64, uint64) (uint64, bool)) (value.Primary, error) {
+	if len(args) != 2 {
+		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{2})
+	}
+
+	operands := make([]uint64, 2)
+	for i := range args {
+		p := value.ToInteger(args[i])
+		if value.IsNull(p) {
+			return value.NewNull(), nil
+		}
+		n := p.(value.Integer).Raw()
+		if n < 0 {
+			operands[i] = uint64(-(n + 1)) + 1
+		} else {
+			operands[i] = uint64(n)
+		}
+	}
+
+	result, ok := mathf(operands[0], operands[1])
+	if !ok || math.MaxInt64 < result {
+		return value.NewNull(), nil
+	}
+	return value.NewInteger(int64(result)), nil
+}
+
+func gcd(a uint64, b uint64) uint64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+func Gcd(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
+	return execIntegerMath2Args(fn, args, func(a uint64, b uint64) (uint64, bool) {
+		return gcd(a, b), true
+	})
+}
+
+func Lcm(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
+	return execIntegerMath2Args(fn, args, func(a uint64, b uint64) (uint64, bool) {
+		if a == 0 || b == 0 {
+			return 0, true
+		}
+		a = a / gcd(a, b)
+		if math.MaxUint64/b < a {
+			return 0, false
+		}
+		return a * b, true
+	})
+}
+
+func SafeDivide(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
+	if len(args) != 2 {
+		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{2})
+	}
+
+	divisor := value.ToFloat(args[1])
+	if value.IsNull(divisor) || divisor.(value.Float).Raw() == 0 {
+		return value.NewNull(), nil
+	}
+	return Calculate(args[0], args[1], '/'), nil
+}
+
+const EarthRadius = 6371.0088
+
+func Haversine(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
+	if len(args) != 4 && len(args) != 5 {
+		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{4, 5})
+	}
+
+	coords := make([]float64, 4)
+	for i := 0; i < 4; i++ {
+		f := value.ToFloat(args[i])
+		if value.IsNull(f) {
+			return value.NewNull(), nil
+		}
+		coords[i] = f.(value.Float).Raw() * math.Pi / 180
+	}
+
+	radius := EarthRadius
+	if len(args) == 5 {
+		f := value.ToFloat(args[4])
+		if value.IsNull(f) {
+			return value.NewNull(), nil
+		}
+		radius = f.(value.Float).Raw()
+	}
+
+	dlat := coords[2] - coords[0]
+	dlon := coords[3] - coords[1]
+	h := math.Pow(math.Sin(dlat/2), 2) + math.Cos(coords[0])*math.Cos(coords[2])*math.Pow(math.Sin(dlon/2), 2)
+	result := 2 * radius * math.Asin(math.Sqrt(math.Min(1, h)))
+
+	if math.IsInf(result, 0) || math.IsNaN(result) {
+		return value.NewNull(), nil
+	}
+	return value.NewFloat(result), nil
+}
+
+const geohashBase32 = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+func GeohashEncode(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
+	if len(args) < 2 || 3 < len(args) {
+		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{2, 3})
+	}
+
+	precision := 12
+	if len(args) == 3 {
+		p := value.ToInteger(args[2])
+		if value.IsNull(p) || p.(value.Integer).Raw() < 1 || 12 < p.(value.Integer).Raw() {
+			return nil, NewFunctionInvalidArgumentError(fn, fn.Name, "the third argument must be an integer from 1 to 12")
+		}
+		precision = int(p.(value.Integer).Raw())
+	}
+
+	latValue := value.ToFloat(args[0])
+	if value.IsNull(latValue) {
+		return value.NewNull(), nil
+	}
+	lonValue := value.ToFloat(args[1])
+	if value.IsNull(lonValue) {
+		return value.NewNull(), nil
+	}
+
+	lat := latValue.(value.Float).Raw()
+	lon := lonValue.(value.Float).Raw()
+	if lat < -90 || 90 < lat || lon < -180 || 180 < lon {
+		return value.NewNull(), nil
+	}
+
+	latRange := [2]float64{-90, 90}
+	lonRange := [2]float64{-180, 180}
+	hash := make([]byte, precision)
+	evenBit := true
+
+	for i := 0; i < precision; i++ {
+		idx := 0
+		for bit := 0; bit < 5; bit++ {
+			r, v := &latRange, lat
+			if evenBit {
+				r, v = &lonRange, lon
+			}
+
+			mid := (r[0] + r[1]) / 2
+			idx <<= 1
+			if mid <= v {
+				idx |= 1
+				r[0] = mid
+			} else {
+				r[1] = mid
+			}
+			evenBit = !evenBit
+		}
+		hash[i] = geohashBase32[idx]
+	}
+
+	return value.NewString(string(hash)), nil
+}
+
+func GeohashDecode(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
+	if len(args) != 1 {
+		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{1})
+	}
+
+	p := value.ToString(args[0])
+	if value.IsNull(p) {
+		return value.NewNull(), nil
+	}
+
+	hash := strings.ToLower(strings.TrimSpace(p.(value.String).Raw()))
+	if len(hash) < 1 {
+		return value.NewNull(), nil
+	}
+
+	latRange := [2]float64{-90, 90}
+	lonRange := [2]float64{-180, 180}
+	evenBit := true
+
+	for i := 0; i < len(hash); i++ {
+		idx := strings.IndexByte(geohashBase32, hash[i])
+		if idx < 0 {
+			return value.NewNull(), nil
+		}
+
+		for bit := 4; 0 <= bit; bit-- {
+			r := &latRange
+			if evenBit {
+				r = &lonRange
+			}
+
+			mid := (r[0] + r[1]) / 2
+			if idx>>uint(bit)&1 == 1 {
+				r[0] = mid
+			} else {
+				r[1] = mid
+			}
+			evenBit = !evenBit
+		}
+	}
+
+	return value.NewArray([]value.Primary{
+		value.NewFloat((latRange[0] + latRange[1]) / 2),
+		value.NewFloat((lonRange[0] + lonRange[1]) / 2),
+	}), nil
+}
+
+func Pmt(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
+	if len(args) < 3 || 5 < len(args) {
+		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{3, 4, 5})
+	}
+
+	params := []float64{0, 0, 0, 0, 0}
+	for i := range args {
+		f := value.ToFloat(args[i])
+		if value.IsNull(f) {
+			return value.NewNull(), nil
+		}
+		params[i] = f.(value.Float).Raw()
+	}
+	rate, nper, pv, fv, due := params[0], params[1], params[2], params[3], params[4]
+
+	if nper == 0 {
+		return nil, NewFunctionInvalidArgumentError(fn, fn.Name, "the number of periods must not be 0")
+	}
+	if due != 0 && due != 1 {
+		return nil, NewFunctionInvalidArgumentError(fn, fn.Name, "the fifth argument must be 0 or 1")
+	}
+
+	var result float64
+	if rate == 0 {
+		result = -(pv + fv) / nper
+	} else {
+		f := math.Pow(1+rate, nper)
+		result = -rate * (pv*f + fv) / ((1 + rate*due) * (f - 1))
+	}
+
+	if math.IsInf(result, 0) || math.IsNaN(result) {
+		return value.NewNull(), nil
+	}
+	return value.NewFloat(result), nil
+}
+
 func execParseInt(fn parser.Function, args []value.Primary, base int) (value.Primary, error) {
 	if len(args) != 1 {
 		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{1})
@@ -546,6 +882,88 @@ func Rand(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary
 	return value.NewInteger(r.Int63n(delta) + low), nil
 }
 
+// RandomFunctions are evaluated sequentially when a random seed is set so that the results are reproducible.
+var RandomFunctions = map[string]bool{
+	"RAND":           true,
+	"RANDOM":         true,
+	"RANDOM_BETWEEN": true,
+	"RANDOM_STRING":  true,
+}
+
+func Random(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
+	if 0 < len(args) {
+		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{0})
+	}
+	return value.NewFloat(cmd.GetRand().Float64()), nil
+}
+
+func RandomBetween(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
+	if len(args) != 2 {
+		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{2})
+	}
+
+	p1 := value.ToInteger(args[0])
+	if value.IsNull(p1) {
+		if value.IsNull(args[0]) {
+			return value.NewNull(), nil
+		}
+		return nil, NewFunctionInvalidArgumentError(fn, fn.Name, "the first argument must be an integer")
+	}
+	p2 := value.ToInteger(args[1])
+	if value.IsNull(p2) {
+		if value.IsNull(args[1]) {
+			return value.NewNull(), nil
+		}
+		return nil, NewFunctionInvalidArgumentError(fn, fn.Name, "the second argument must be an integer")
+	}
+
+	low := p1.(value.Integer).Raw()
+	high := p2.(value.Integer).Raw()
+	if high < low {
+		return nil, NewFunctionInvalidArgumentError(fn, fn.Name, "the second argument must be greater than or equal to the first argument")
+	}
+	return value.NewInteger(cmd.GetRand().Int63n(high-low+1) + low), nil
+}
+
+const randomStringCharacters = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+func RandomString(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
+	if len(args) < 1 || 2 < len(args) {
+		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{1, 2})
+	}
+
+	p := value.ToInteger(args[0])
+	if value.IsNull(p) {
+		if value.IsNull(args[0]) {
+			return value.NewNull(), nil
+		}
+		return nil, NewFunctionInvalidArgumentError(fn, fn.Name, "the first argument must be an integer")
+	}
+	length := p.(value.Integer).Raw()
+	if length < 0 {
+		return nil, NewFunctionInvalidArgumentError(fn, fn.Name, "the first argument must be greater than or equal to 0")
+	}
+
+	characters := []rune(randomStringCharacters)
+	if len(args) == 2 {
+		s := value.ToString(args[1])
+		if value.IsNull(s) {
+			return value.NewNull(), nil
+		}
+		characters = []rune(s.(value.String).Raw())
+		if len(characters) < 1 {
+			return nil, NewFunctionInvalidArgumentError(fn, fn.Name, "the second argument must not be empty")
+		}
+	}
+
+	r := cmd.GetRand()
+	buf := make([]rune, length)
+	for i := range buf {
+		buf[i] = characters[r.Intn(len(characters))]
+	}
+	return value.NewString(string(buf)), nil
+}
+
 func execStrings1Arg(fn parser.Function, args []value.Primary, stringsf func(string) string) (value.Primary, error) {
 	if len(args) != 1 {
 		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{1})
@@ -583,6 +1001,50 @@ func execStringsTrim(fn parser.Function, args []value.Primary, stringsf func(str
 	return value.NewString(result), nil
 }
 
+var unaccentLetters = map[rune]string{
+	'Đ': "D", 'đ': "d", 'Ħ': "H", 'ħ': "h", 'Ł': "L", 'ł': "l", 'Ø': "O", 'ø': "o", 'Ŧ': "T", 'ŧ': "t",
+}
+
+var transliterationLetters = map[rune]string{
+	'Æ': "AE", 'æ': "ae", 'Œ': "OE", 'œ': "oe", 'ß': "ss", 'Þ': "TH", 'þ': "th", 'Ð': "D", 'ð': "d", 'ı': "i",
+	'‘': "'", '’': "'", '‚': "'", '“': "\"", '”': "\"", '„': "\"", '–': "-", '—': "-", '…': "...", '€': "EUR",
+}
+
+func unaccent(s string) string {
+	var buf strings.Builder
+	for _, r := range norm.NFD.String(s) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		if l, ok := unaccentLetters[r]; ok {
+			buf.WriteString(l)
+		} else {
+			buf.WriteRune(r)
+		}
+	}
+	return norm.NFC.String(buf.String())
+}
+
+func transliterate(s string, replacement string) string {
+	var buf strings.Builder
+	for _, r := range norm.NFKD.String(s) {
+		switch {
+		case r < utf8.RuneSelf:
+			buf.WriteRune(r)
+		case unicode.Is(unicode.Mn, r):
+		default:
+			if l, ok := unaccentLetters[r]; ok {
+				buf.WriteString(l)
+			} else if l, ok := transliterationLetters[r]; ok {
+				buf.WriteString(l)
+			} else {
+				buf.WriteString(replacement)
+			}
+		}
+	}
+	return buf.String()
+}
+
 func base64Encode(s string) string {
 	return base64.StdEncoding.EncodeToString([]byte(s))
 }
@@ -793,12 +1255,106 @@ func Rtrim(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primar
 	return execStringsTrim(fn, args, rtrim)
 }
 
+func execStringsCaseMapping(fn parser.Function, args []value.Primary, stringsf func(string) string, casef func(string, string) (string, error)) (value.Primary, error) {
+	if len(args) < 1 || 2 < len(args) {
+		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{1, 2})
+	}
+
+	s := value.ToString(args[0])
+	if value.IsNull(s) {
+		return value.NewNull(), nil
+	}
+
+	if len(args) < 2 && stringsf != nil {
+		return value.NewString(stringsf(s.(value.String).Raw())), nil
+	}
+
+	locale := ""
+	if 1 < len(args) {
+		l := value.ToString(args[1])
+		if value.IsNull(l) {
+			return nil, NewFunctionInvalidArgumentError(fn, fn.Name, "the second argument must be a string")
+		}
+		locale = l.(value.String).Raw()
+	}
+
+	result, err := casef(s.(value.String).Raw(), locale)
+	if err != nil {
+		return nil, NewFunctionInvalidArgumentError(fn, fn.Name, err.Error())
+	}
+	return value.NewString(result), nil
+}
+
 func Upper(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
-	return execStrings1Arg(fn, args, strings.ToUpper)
+	return execStringsCaseMapping(fn, args, strings.ToUpper, value.ToUpperCase)
 }
 
 func Lower(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
-	return execStrings1Arg(fn, args, strings.ToLower)
+	return execStringsCaseMapping(fn, args, strings.ToLower, value.ToLowerCase)
+}
+
+func Titlecase(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
+	return execStringsCaseMapping(fn, args, nil, value.ToTitleCase)
+}
+
+func Normalize(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
+	if len(args) < 1 || 2 < len(args) {
+		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{1, 2})
+	}
+
+	s := value.ToString(args[0])
+	if value.IsNull(s) {
+		return value.NewNull(), nil
+	}
+
+	form := norm.NFC
+	if len(args) == 2 {
+		f := value.ToString(args[1])
+		if value.IsNull(f) {
+			return value.NewNull(), nil
+		}
+
+		switch strings.ToUpper(f.(value.String).Raw()) {
+		case "NFC":
+			form = norm.NFC
+		case "NFD":
+			form = norm.NFD
+		case "NFKC":
+			form = norm.NFKC
+		case "NFKD":
+			form = norm.NFKD
+		default:
+			return nil, NewFunctionInvalidArgumentError(fn, fn.Name, "the second argument must be one of NFC|NFD|NFKC|NFKD")
+		}
+	}
+
+	return value.NewString(form.String(s.(value.String).Raw())), nil
+}
+
+func Unaccent(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
+	return execStrings1Arg(fn, args, unaccent)
+}
+
+func Transliterate(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
+	if len(args) < 1 || 2 < len(args) {
+		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{1, 2})
+	}
+
+	s := value.ToString(args[0])
+	if value.IsNull(s) {
+		return value.NewNull(), nil
+	}
+
+	replacement := "?"
+	if len(args) == 2 {
+		r := value.ToString(args[1])
+		if value.IsNull(r) {
+			return value.NewNull(), nil
+		}
+		replacement = r.(value.String).Raw()
+	}
+
+	return value.NewString(transliterate(s.(value.String).Raw(), replacement)), nil
 }
 
 func Base64Encode(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
@@ -817,6 +1373,90 @@ func HexDecode(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Pr
 	return execStrings1Arg(fn, args, hexDecode)
 }
 
+func UrlEncode(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
+	return execStrings1Arg(fn, args, url.QueryEscape)
+}
+
+func UrlDecode(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
+	if len(args) != 1 {
+		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{1})
+	}
+
+	s := value.ToString(args[0])
+	if value.IsNull(s) {
+		return value.NewNull(), nil
+	}
+
+	r, err := url.QueryUnescape(s.(value.String).Raw())
+	if err != nil {
+		return value.NewNull(), nil
+	}
+	return value.NewString(r), nil
+}
+
+func parseUrlArg(arg value.Primary) *url.URL {
+	s := value.ToString(arg)
+	if value.IsNull(s) {
+		return nil
+	}
+
+	u, err := url.Parse(s.(value.String).Raw())
+	if err != nil {
+		return nil
+	}
+	return u
+}
+
+func UrlExtractHost(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
+	if len(args) != 1 {
+		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{1})
+	}
+
+	u := parseUrlArg(args[0])
+	if u == nil || len(u.Hostname()) < 1 {
+		return value.NewNull(), nil
+	}
+	return value.NewString(u.Hostname()), nil
+}
+
+func UrlExtractPath(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
+	if len(args) != 1 {
+		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{1})
+	}
+
+	u := parseUrlArg(args[0])
+	if u == nil {
+		return value.NewNull(), nil
+	}
+	return value.NewString(u.Path), nil
+}
+
+func UrlExtractQueryParam(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
+	if len(args) != 2 {
+		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{2})
+	}
+
+	u := parseUrlArg(args[0])
+	if u == nil {
+		return value.NewNull(), nil
+	}
+
+	name := value.ToString(args[1])
+	if value.IsNull(name) {
+		return nil, NewFunctionInvalidArgumentError(fn, fn.Name, "the second argument must be a string")
+	}
+
+	query, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		return value.NewNull(), nil
+	}
+	values, ok := query[name.(value.String).Raw()]
+	if !ok || len(values) < 1 {
+		return value.NewNull(), nil
+	}
+	return value.NewString(values[0]), nil
+}
+
 func Len(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
 	return execStringsLen(fn, args, utf8.RuneCountInString)
 }
@@ -968,6 +1608,84 @@ func ListElem(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Pri
 	return value.NewString(list[index]), nil
 }
 
+func SplitPart(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
+	if len(args) != 3 {
+		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{3})
+	}
+
+	s := value.ToString(args[0])
+	if value.IsNull(s) {
+		return value.NewNull(), nil
+	}
+
+	sep := value.ToString(args[1])
+	if value.IsNull(sep) {
+		return value.NewNull(), nil
+	}
+
+	i := value.ToInteger(args[2])
+	if value.IsNull(i) {
+		return value.NewNull(), nil
+	}
+	n := int(i.(value.Integer).Raw())
+	if n == 0 {
+		return nil, NewFunctionInvalidArgumentError(fn, fn.Name, "the third argument must not be zero")
+	}
+
+	var list []string
+	if len(sep.(value.String).Raw()) < 1 {
+		list = []string{s.(value.String).Raw()}
+	} else {
+		list = strings.Split(s.(value.String).Raw(), sep.(value.String).Raw())
+	}
+
+	if n < 0 {
+		n = len(list) + n + 1
+	}
+	if n < 1 || len(list) < n {
+		return value.NewString(""), nil
+	}
+	return value.NewString(list[n-1]), nil
+}
+
+func Strtok(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
+	if len(args) < 1 || 3 < len(args) {
+		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{1, 2, 3})
+	}
+
+	s := value.ToString(args[0])
+	if value.IsNull(s) {
+		return value.NewNull(), nil
+	}
+
+	delimiters := " "
+	if 1 < len(args) {
+		d := value.ToString(args[1])
+		if value.IsNull(d) {
+			return value.NewNull(), nil
+		}
+		delimiters = d.(value.String).Raw()
+	}
+
+	n := 1
+	if 2 < len(args) {
+		i := value.ToInteger(args[2])
+		if value.IsNull(i) {
+			return value.NewNull(), nil
+		}
+		n = int(i.(value.Integer).Raw())
+	}
+
+	tokens := strings.FieldsFunc(s.(value.String).Raw(), func(r rune) bool {
+		return strings.ContainsRune(delimiters, r)
+	})
+
+	if n < 1 || len(tokens) < n {
+		return value.NewNull(), nil
+	}
+	return value.NewString(tokens[n-1]), nil
+}
+
 func Replace(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
 	if 3 != len(args) {
 		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{3})
@@ -1019,16 +1737,217 @@ func JsonValue(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Pr
 		return value.NewNull(), nil
 	}
 
-	jsonText := value.ToString(args[1])
-	if value.IsNull(jsonText) {
+	if j, ok := args[1].(value.Json); ok {
+		v, err := json.ExtractValue(query.(value.String).Raw(), j.Raw())
+		if err != nil {
+			return v, NewFunctionInvalidArgumentError(fn, fn.Name, err.Error())
+		}
+		return v, nil
+	}
+
+	jsonText := value.ToString(args[1])
+	if value.IsNull(jsonText) {
+		return value.NewNull(), nil
+	}
+
+	v, err := json.LoadValue(query.(value.String).Raw(), jsonText.(value.String).Raw())
+	if err != nil {
+		return v, NewFunctionInvalidArgumentError(fn, fn.Name, err.Error())
+	}
+	return v, nil
+}
+
+func loadXpathNodes(fn parser.Function, args []value.Primary) ([]*xpath.Node, bool, error) {
+	if len(args) != 2 {
+		return nil, false, NewFunctionArgumentLengthError(fn, fn.Name, []int{2})
+	}
+
+	path := value.ToString(args[0])
+	if value.IsNull(path) {
+		return nil, false, nil
+	}
+
+	xmlText := value.ToString(args[1])
+	if value.IsNull(xmlText) {
+		return nil, false, nil
+	}
+
+	nodes, err := xpath.Load(path.(value.String).Raw(), xmlText.(value.String).Raw())
+	if err != nil {
+		return nil, false, NewFunctionInvalidArgumentError(fn, fn.Name, err.Error())
+	}
+	return nodes, true, nil
+}
+
+func XpathString(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
+	nodes, ok, err := loadXpathNodes(fn, args)
+	if err != nil {
+		return nil, err
+	}
+	if !ok || len(nodes) < 1 {
+		return value.NewNull(), nil
+	}
+	return value.NewString(nodes[0].StringValue()), nil
+}
+
+func XpathNumber(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
+	nodes, ok, err := loadXpathNodes(fn, args)
+	if err != nil {
+		return nil, err
+	}
+	if !ok || len(nodes) < 1 {
+		return value.NewNull(), nil
+	}
+
+	f, e := strconv.ParseFloat(strings.TrimSpace(nodes[0].StringValue()), 64)
+	if e != nil || math.IsNaN(f) {
+		return value.NewNull(), nil
+	}
+	return value.NewFloat(f), nil
+}
+
+func XpathExists(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
+	nodes, ok, err := loadXpathNodes(fn, args)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return value.NewNull(), nil
+	}
+	return value.NewBoolean(0 < len(nodes)), nil
+}
+
+func Uuid(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
+	if 0 < len(args) {
+		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{0})
+	}
+
+	var u [16]byte
+	if _, err := rand.Read(u[:]); err != nil {
+		return nil, NewSystemError(err.Error())
+	}
+	return value.NewString(formatUuid(u, 4)), nil
+}
+
+func UuidV7(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
+	if 0 < len(args) {
+		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{0})
+	}
+
+	var u [16]byte
+	if _, err := rand.Read(u[6:]); err != nil {
+		return nil, NewSystemError(err.Error())
+	}
+
+	ms := uint64(cmd.Now().UnixNano() / int64(time.Millisecond))
+	for i := 0; i < 6; i++ {
+		u[i] = byte(ms >> uint(40-8*i))
+	}
+	return value.NewString(formatUuid(u, 7)), nil
+}
+
+func formatUuid(u [16]byte, version byte) string {
+	u[6] = (u[6] & 0x0f) | (version << 4)
+	u[8] = (u[8] & 0x3f) | 0x80
+
+	buf := make([]byte, 36)
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf)
+}
+
+func ArrayLength(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
+	if len(args) != 1 {
+		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{1})
+	}
+
+	array, ok := args[0].(value.Array)
+	if !ok {
+		return value.NewNull(), nil
+	}
+	return value.NewInteger(int64(array.Len())), nil
+}
+
+func ArrayContains(fn parser.Function, args []value.Primary, flags *cmd.Flags) (value.Primary, error) {
+	if len(args) != 2 {
+		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{2})
+	}
+
+	array, ok := args[0].(value.Array)
+	if !ok {
+		return value.NewTernary(ternary.UNKNOWN), nil
+	}
+
+	result := ternary.FALSE
+	for _, v := range array.Raw() {
+		switch value.Equal(v, args[1], flags.DatetimeFormat) {
+		case ternary.TRUE:
+			return value.NewTernary(ternary.TRUE), nil
+		case ternary.UNKNOWN:
+			result = ternary.UNKNOWN
+		}
+	}
+	return value.NewTernary(result), nil
+}
+
+func StringToArray(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
+	if len(args) != 2 {
+		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{2})
+	}
+
+	s := value.ToString(args[0])
+	if value.IsNull(s) {
+		return value.NewNull(), nil
+	}
+
+	sep := value.ToString(args[1])
+	if value.IsNull(sep) {
+		return value.NewNull(), nil
+	}
+
+	if len(s.(value.String).Raw()) < 1 {
+		return value.NewArray([]value.Primary{}), nil
+	}
+
+	list := strings.Split(s.(value.String).Raw(), sep.(value.String).Raw())
+	values := make([]value.Primary, len(list))
+	for i, v := range list {
+		values[i] = value.NewString(v)
+	}
+	return value.NewArray(values), nil
+}
+
+func ArrayToString(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
+	if len(args) != 2 {
+		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{2})
+	}
+
+	array, ok := args[0].(value.Array)
+	if !ok {
+		return value.NewNull(), nil
+	}
+
+	sep := value.ToString(args[1])
+	if value.IsNull(sep) {
 		return value.NewNull(), nil
 	}
 
-	v, err := json.LoadValue(query.(value.String).Raw(), jsonText.(value.String).Raw())
-	if err != nil {
-		return v, NewFunctionInvalidArgumentError(fn, fn.Name, err.Error())
+	list := make([]string, 0, array.Len())
+	for _, v := range array.Raw() {
+		if value.IsNull(v) {
+			continue
+		}
+		s, _, _ := ConvertFieldContents(v, false)
+		list = append(list, s)
 	}
-	return v, nil
+	return value.NewString(strings.Join(list, sep.(value.String).Raw())), nil
 }
 
 func Md5(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
@@ -1047,6 +1966,19 @@ func Sha512(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Prima
 	return execCrypto(fn, args, sha512.New)
 }
 
+func Crc32(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
+	if 1 != len(args) {
+		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{1})
+	}
+
+	s := value.ToString(args[0])
+	if value.IsNull(s) {
+		return value.NewNull(), nil
+	}
+
+	return value.NewInteger(int64(crc32.ChecksumIEEE([]byte(s.(value.String).Raw())))), nil
+}
+
 func Md5Hmac(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
 	return execCryptoHMAC(fn, args, md5.New)
 }
@@ -1063,6 +1995,31 @@ func Sha512Hmac(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.P
 	return execCryptoHMAC(fn, args, sha512.New)
 }
 
+func HmacSha256(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
+	if 2 != len(args) {
+		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{2})
+	}
+	return execCryptoHMAC(fn, []value.Primary{args[1], args[0]}, sha256.New)
+}
+
+func SecureEqual(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
+	if len(args) != 2 {
+		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{2})
+	}
+
+	s1 := value.ToString(args[0])
+	if value.IsNull(s1) {
+		return value.NewNull(), nil
+	}
+
+	s2 := value.ToString(args[1])
+	if value.IsNull(s2) {
+		return value.NewNull(), nil
+	}
+
+	return value.NewBoolean(subtle.ConstantTimeCompare([]byte(s1.(value.String).Raw()), []byte(s2.(value.String).Raw())) == 1), nil
+}
+
 func DatetimeFormat(fn parser.Function, args []value.Primary, flags *cmd.Flags) (value.Primary, error) {
 	if len(args) != 2 {
 		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{2})
@@ -1081,6 +2038,82 @@ func DatetimeFormat(fn parser.Function, args []value.Primary, flags *cmd.Flags)
 	return value.NewString(dt.Format(value.DatetimeFormats.Get(format.(value.String).Raw()))), nil
 }
 
+func ToChar(fn parser.Function, args []value.Primary, flags *cmd.Flags) (value.Primary, error) {
+	if len(args) != 2 {
+		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{2})
+	}
+
+	format := value.ToString(args[1])
+	if value.IsNull(format) {
+		return value.NewNull(), nil
+	}
+	pattern := format.(value.String).Raw()
+
+	var number value.Primary = value.NewNull()
+	switch args[0].(type) {
+	case value.Integer, value.Float, value.String:
+		number = value.ToFloat(args[0])
+	}
+
+	if !value.IsNull(number) {
+		s, err := value.FormatNumberByPattern(number.(value.Float).Raw(), pattern)
+		if err != nil {
+			return nil, NewFunctionInvalidArgumentError(fn, fn.Name, err.Error())
+		}
+		return value.NewString(s), nil
+	}
+
+	dt := value.ToDatetime(args[0], flags.DatetimeFormat)
+	if value.IsNull(dt) {
+		return value.NewNull(), nil
+	}
+	return value.NewString(value.FormatTimeByPattern(dt.(value.Datetime).Raw(), pattern)), nil
+}
+
+func Strptime(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
+	if len(args) < 2 || 3 < len(args) {
+		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{2, 3})
+	}
+
+	format := value.ToString(args[1])
+	if value.IsNull(format) {
+		return value.NewNull(), nil
+	}
+	layout := value.DatetimeFormats.Get(format.(value.String).Raw())
+
+	var str string
+	if dt, ok := args[0].(value.Datetime); ok {
+		// String literals that can be converted to datetime values have already been converted by the parser.
+		str = dt.Format(layout)
+	} else {
+		s := value.ToString(args[0])
+		if value.IsNull(s) {
+			return value.NewNull(), nil
+		}
+		str = strings.TrimSpace(s.(value.String).Raw())
+	}
+
+	location := cmd.GetLocation()
+	if len(args) == 3 {
+		tz := value.ToString(args[2])
+		if value.IsNull(tz) {
+			return value.NewNull(), nil
+		}
+
+		l, err := cmd.LoadLocation(tz.(value.String).Raw())
+		if err != nil {
+			return nil, NewFunctionInvalidArgumentError(fn, fn.Name, err.Error())
+		}
+		location = l
+	}
+
+	t, err := time.ParseInLocation(layout, str, location)
+	if err != nil {
+		return value.NewNull(), nil
+	}
+	return value.NewDatetime(t), nil
+}
+
 func execDatetimeToInt(fn parser.Function, args []value.Primary, timef func(time.Time) int64, flags *cmd.Flags) (value.Primary, error) {
 	if len(args) != 1 {
 		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{1})
@@ -1171,6 +2204,11 @@ func weekOfYear(t time.Time) int64 {
 	return int64(w)
 }
 
+func isoYear(t time.Time) int64 {
+	y, _ := t.ISOWeek()
+	return int64(y)
+}
+
 func addYear(t time.Time, duration int) time.Time {
 	return t.AddDate(duration, 0, 0)
 }
@@ -1269,6 +2307,120 @@ func WeekOfYear(fn parser.Function, args []value.Primary, flags *cmd.Flags) (val
 	return execDatetimeToInt(fn, args, weekOfYear, flags)
 }
 
+func IsoYear(fn parser.Function, args []value.Primary, flags *cmd.Flags) (value.Primary, error) {
+	return execDatetimeToInt(fn, args, isoYear, flags)
+}
+
+func execDatetimeToName(fn parser.Function, args []value.Primary, namef func(time.Time, string) (string, error), flags *cmd.Flags) (value.Primary, error) {
+	if len(args) < 1 || 2 < len(args) {
+		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{1, 2})
+	}
+
+	locale := ""
+	if 1 < len(args) {
+		s := value.ToString(args[1])
+		if value.IsNull(s) {
+			return nil, NewFunctionInvalidArgumentError(fn, fn.Name, "the second argument must be a string")
+		}
+		locale = s.(value.String).Raw()
+	}
+
+	dt := value.ToDatetime(args[0], flags.DatetimeFormat)
+	if value.IsNull(dt) {
+		return value.NewNull(), nil
+	}
+
+	name, err := namef(dt.(value.Datetime).Raw(), locale)
+	if err != nil {
+		return nil, NewFunctionInvalidArgumentError(fn, fn.Name, err.Error())
+	}
+	return value.NewString(name), nil
+}
+
+func monthName(t time.Time, locale string) (string, error) {
+	return value.MonthName(t.Month(), locale)
+}
+
+func dayName(t time.Time, locale string) (string, error) {
+	return value.WeekdayName(t.Weekday(), locale)
+}
+
+func MonthName(fn parser.Function, args []value.Primary, flags *cmd.Flags) (value.Primary, error) {
+	return execDatetimeToName(fn, args, monthName, flags)
+}
+
+func DayName(fn parser.Function, args []value.Primary, flags *cmd.Flags) (value.Primary, error) {
+	return execDatetimeToName(fn, args, dayName, flags)
+}
+
+func LastDay(fn parser.Function, args []value.Primary, flags *cmd.Flags) (value.Primary, error) {
+	if len(args) != 1 {
+		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{1})
+	}
+
+	dt := value.ToDatetime(args[0], flags.DatetimeFormat)
+	if value.IsNull(dt) {
+		return value.NewNull(), nil
+	}
+
+	t := dt.(value.Datetime).Raw()
+	return value.NewDatetime(time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, t.Location())), nil
+}
+
+func FirstDayOfWeek(fn parser.Function, args []value.Primary, flags *cmd.Flags) (value.Primary, error) {
+	if len(args) < 1 || 2 < len(args) {
+		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{1, 2})
+	}
+
+	start := time.Monday
+	if 1 < len(args) {
+		p := value.ToInteger(args[1])
+		if value.IsNull(p) || p.(value.Integer).Raw() < 0 || 6 < p.(value.Integer).Raw() {
+			return nil, NewFunctionInvalidArgumentError(fn, fn.Name, "the second argument must be an integer from 0 to 6")
+		}
+		start = time.Weekday(p.(value.Integer).Raw())
+	}
+
+	dt := value.ToDatetime(args[0], flags.DatetimeFormat)
+	if value.IsNull(dt) {
+		return value.NewNull(), nil
+	}
+
+	t := dt.(value.Datetime).Raw()
+	d := t.Day() - (int(t.Weekday())-int(start)+7)%7
+	return value.NewDatetime(time.Date(t.Year(), t.Month(), d, 0, 0, 0, 0, t.Location())), nil
+}
+
+func Age(fn parser.Function, args []value.Primary, flags *cmd.Flags) (value.Primary, error) {
+	if len(args) != 2 {
+		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{2})
+	}
+
+	p1 := value.ToDatetime(args[0], flags.DatetimeFormat)
+	if value.IsNull(p1) {
+		return value.NewNull(), nil
+	}
+	p2 := value.ToDatetime(args[1], flags.DatetimeFormat)
+	if value.IsNull(p2) {
+		return value.NewNull(), nil
+	}
+
+	dt1 := p1.(value.Datetime).Raw()
+	dt2 := p2.(value.Datetime).Raw().In(dt1.Location())
+
+	sign := int64(1)
+	if dt1.Before(dt2) {
+		dt1, dt2 = dt2, dt1
+		sign = -1
+	}
+
+	years := int64(dt1.Year() - dt2.Year())
+	if dt1.Before(dt2.AddDate(int(years), 0, 0)) {
+		years--
+	}
+	return value.NewInteger(sign * years), nil
+}
+
 func AddYear(fn parser.Function, args []value.Primary, flags *cmd.Flags) (value.Primary, error) {
 	return execDatetimeAdd(fn, args, addYear, flags)
 }
@@ -1327,6 +2479,60 @@ func truncateDate(fn parser.Function, args []value.Primary, place int8, flags *c
 	return value.NewDatetime(time.Date(y, m, d, 0, 0, 0, 0, t.Location())), nil
 }
 
+func addCalendarMonth(t time.Time, months int) time.Time {
+	y, m, d := t.Date()
+	first := time.Date(y, m+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	if last := first.AddDate(0, 1, -1).Day(); last < d {
+		d = last
+	}
+	return first.AddDate(0, 0, d-1)
+}
+
+func DateAdd(fn parser.Function, args []value.Primary, flags *cmd.Flags) (value.Primary, error) {
+	if len(args) != 3 {
+		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{3})
+	}
+
+	unit := value.ToString(args[2])
+	if value.IsNull(unit) {
+		return nil, NewFunctionInvalidArgumentError(fn, fn.Name, "the third argument must be a string")
+	}
+
+	p1 := value.ToDatetime(args[0], flags.DatetimeFormat)
+	if value.IsNull(p1) {
+		return value.NewNull(), nil
+	}
+	p2 := value.ToInteger(args[1])
+	if value.IsNull(p2) {
+		return value.NewNull(), nil
+	}
+
+	dt := p1.(value.Datetime).Raw()
+	i := int(p2.(value.Integer).Raw())
+
+	switch strings.ToUpper(unit.(value.String).Raw()) {
+	case "YEAR":
+		dt = addCalendarMonth(dt, i*12)
+	case "QUARTER":
+		dt = addCalendarMonth(dt, i*3)
+	case "MONTH":
+		dt = addCalendarMonth(dt, i)
+	case "WEEK":
+		dt = dt.AddDate(0, 0, i*7)
+	case "DAY":
+		dt = dt.AddDate(0, 0, i)
+	case "HOUR":
+		dt = dt.Add(time.Duration(i) * time.Hour)
+	case "MINUTE":
+		dt = dt.Add(time.Duration(i) * time.Minute)
+	case "SECOND":
+		dt = dt.Add(time.Duration(i) * time.Second)
+	default:
+		return nil, NewFunctionInvalidArgumentError(fn, fn.Name, "the third argument must be one of YEAR, QUARTER, MONTH, WEEK, DAY, HOUR, MINUTE or SECOND")
+	}
+	return value.NewDatetime(dt), nil
+}
+
 func TruncMonth(fn parser.Function, args []value.Primary, flags *cmd.Flags) (value.Primary, error) {
 	return truncateDate(fn, args, 2, flags)
 }
@@ -1372,11 +2578,53 @@ func TruncNano(fn parser.Function, args []value.Primary, flags *cmd.Flags) (valu
 	return truncateDuration(fn, args, time.Microsecond, flags)
 }
 
-func DateDiff(fn parser.Function, args []value.Primary, flags *cmd.Flags) (value.Primary, error) {
+func DateTrunc(fn parser.Function, args []value.Primary, flags *cmd.Flags) (value.Primary, error) {
 	if len(args) != 2 {
 		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{2})
 	}
 
+	unit := value.ToString(args[0])
+	if value.IsNull(unit) {
+		return nil, NewFunctionInvalidArgumentError(fn, fn.Name, "the first argument must be a string")
+	}
+
+	dt := value.ToDatetime(args[1], flags.DatetimeFormat)
+	if value.IsNull(dt) {
+		return value.NewNull(), nil
+	}
+
+	t := dt.(value.Datetime).Raw()
+	y, m, d := t.Date()
+	h, mi := t.Hour(), t.Minute()
+
+	switch strings.ToUpper(unit.(value.String).Raw()) {
+	case "YEAR":
+		m, d, h, mi = 1, 1, 0, 0
+	case "QUARTER":
+		m, d, h, mi = ((m-1)/3)*3+1, 1, 0, 0
+	case "MONTH":
+		d, h, mi = 1, 0, 0
+	case "WEEK":
+		d, h, mi = d-(int(t.Weekday())+6)%7, 0, 0
+	case "DAY":
+		h, mi = 0, 0
+	case "HOUR":
+		mi = 0
+	case "MINUTE":
+	default:
+		return nil, NewFunctionInvalidArgumentError(fn, fn.Name, "the first argument must be one of YEAR, QUARTER, MONTH, WEEK, DAY, HOUR or MINUTE")
+	}
+	return value.NewDatetime(time.Date(y, m, d, h, mi, 0, 0, t.Location())), nil
+}
+
+func DateDiff(fn parser.Function, args []value.Primary, flags *cmd.Flags) (value.Primary, error) {
+	if len(args) == 3 {
+		return dateDiffWithUnit(fn, args, flags)
+	}
+	if len(args) != 2 {
+		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{2, 3})
+	}
+
 	p1 := value.ToDatetime(args[0], flags.DatetimeFormat)
 	if value.IsNull(p1) {
 		return value.NewNull(), nil
@@ -1396,6 +2644,58 @@ func DateDiff(fn parser.Function, args []value.Primary, flags *cmd.Flags) (value
 	return value.NewInteger(int64(dur.Hours() / 24)), nil
 }
 
+func dateDiffWithUnit(fn parser.Function, args []value.Primary, flags *cmd.Flags) (value.Primary, error) {
+	unit := value.ToString(args[0])
+	if value.IsNull(unit) {
+		return nil, NewFunctionInvalidArgumentError(fn, fn.Name, "the first argument must be a string")
+	}
+
+	p1 := value.ToDatetime(args[1], flags.DatetimeFormat)
+	if value.IsNull(p1) {
+		return value.NewNull(), nil
+	}
+	p2 := value.ToDatetime(args[2], flags.DatetimeFormat)
+	if value.IsNull(p2) {
+		return value.NewNull(), nil
+	}
+
+	dt1 := p1.(value.Datetime).Raw()
+	dt2 := p2.(value.Datetime).Raw()
+	y1, m1, d1 := dt1.Date()
+	y2, m2, d2 := dt2.Date()
+
+	days := func() int64 {
+		t1 := time.Date(y1, m1, d1, 0, 0, 0, 0, time.UTC)
+		t2 := time.Date(y2, m2, d2, 0, 0, 0, 0, time.UTC)
+		return int64(t2.Sub(t1) / (24 * time.Hour))
+	}
+
+	var diff int64
+	switch strings.ToUpper(unit.(value.String).Raw()) {
+	case "YEAR":
+		diff = int64(y2 - y1)
+	case "QUARTER":
+		diff = int64((y2*4 + (int(m2)-1)/3) - (y1*4 + (int(m1)-1)/3))
+	case "MONTH":
+		diff = int64((y2*12 + int(m2)) - (y1*12 + int(m1)))
+	case "WEEK":
+		wd1 := int64((int(dt1.Weekday()) + 6) % 7)
+		wd2 := int64((int(dt2.Weekday()) + 6) % 7)
+		diff = (days() - wd2 + wd1) / 7
+	case "DAY":
+		diff = days()
+	case "HOUR":
+		diff = int64(dt2.Truncate(time.Hour).Sub(dt1.Truncate(time.Hour)) / time.Hour)
+	case "MINUTE":
+		diff = int64(dt2.Truncate(time.Minute).Sub(dt1.Truncate(time.Minute)) / time.Minute)
+	case "SECOND":
+		diff = int64(dt2.Truncate(time.Second).Sub(dt1.Truncate(time.Second)) / time.Second)
+	default:
+		return nil, NewFunctionInvalidArgumentError(fn, fn.Name, "the first argument must be one of YEAR, QUARTER, MONTH, WEEK, DAY, HOUR, MINUTE or SECOND")
+	}
+	return value.NewInteger(diff), nil
+}
+
 func timeDiff(fn parser.Function, args []value.Primary, durf func(time.Duration) value.Primary, flags *cmd.Flags) (value.Primary, error) {
 	if len(args) != 2 {
 		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{2})
@@ -1458,6 +2758,10 @@ func String(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Prima
 		return value.NewString(args[0].(value.Ternary).Ternary().String()), nil
 	case value.Datetime:
 		return value.NewString(args[0].(value.Datetime).Format(time.RFC3339Nano)), nil
+	case value.Array:
+		return value.NewString(args[0].(value.Array).String()), nil
+	case value.Json:
+		return value.NewString(args[0].(value.Json).String()), nil
 	default:
 		return value.ToString(args[0]), nil
 	}
@@ -1558,6 +2862,24 @@ func Now(filter *Filter, fn parser.Function, args []value.Primary) (value.Primar
 	return value.NewDatetime(filter.now), nil
 }
 
+func NextVal(filter *Filter, fn parser.Function, args []value.Primary) (value.Primary, error) {
+	if len(args) != 1 {
+		return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{1})
+	}
+
+	s := value.ToString(args[0])
+	if value.IsNull(s) {
+		return nil, NewFunctionInvalidArgumentError(fn, fn.Name, "the first argument must be a sequence name")
+	}
+	name := s.(value.String).Raw()
+
+	i, ok := filter.tx.sequences.NextValue(name)
+	if !ok {
+		return nil, NewUndeclaredSequenceError(parser.Identifier{BaseExpr: fn.BaseExpr, Literal: name})
+	}
+	return value.NewInteger(i), nil
+}
+
 func JsonObject(ctx context.Context, filter *Filter, fn parser.Function) (value.Primary, error) {
 	if len(filter.records) < 1 {
 		return nil, NewUnpermittedFunctionStatementError(fn, fn.Name)
@@ -1594,5 +2916,44 @@ func JsonObject(ctx context.Context, filter *Filter, fn parser.Function) (value.
 		record = append(record, cell.Value())
 	}
 	structure, _ := json.ConvertRecordValueToJsonStructure(pathes, record)
-	return value.NewString(structure.Encode()), nil
+	return value.NewJson(structure), nil
+}
+
+func Grouping(filter *Filter, fn parser.Function) (value.Primary, error) {
+	if len(fn.Args) < 1 {
+		return nil, NewFunctionArgumentLengthErrorWithCustomArgs(fn, fn.Name, "at least 1 argument")
+	}
+
+	if len(filter.records) < 1 {
+		return nil, NewUnpermittedFunctionStatementError(fn, fn.Name)
+	}
+
+	view := filter.records[0].view
+	if !view.isGrouped {
+		return nil, NewNotGroupingRecordsError(fn, fn.Name)
+	}
+
+	var grouping int64 = 0
+	for _, arg := range fn.Args {
+		grouping = grouping << 1
+
+		if idx, ok := view.Header.ContainsGroupingColumn(view.groupingColumn(arg)); ok {
+			if value.Equal(view.RecordSet[filter.records[0].recordIndex][idx].Value(), value.NewInteger(1), filter.tx.Flags.DatetimeFormat) == ternary.TRUE {
+				grouping = grouping | 1
+			}
+			continue
+		}
+
+		switch arg.(type) {
+		case parser.FieldReference, parser.ColumnNumber:
+			idx, err := view.FieldIndex(arg)
+			if err != nil {
+				return nil, err
+			}
+			if !view.Header[idx].IsGroupKey {
+				return nil, NewFieldNotGroupKeyError(arg)
+			}
+		}
+	}
+	return value.NewInteger(grouping), nil
 }
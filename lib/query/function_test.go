@@ -2,7 +2,11 @@ package query
 
 import (
 	"context"
+	"fmt"
+	"math"
 	"reflect"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
 
@@ -11,6 +15,8 @@ import (
 	"github.com/mithrandie/csvq/lib/parser"
 	"github.com/mithrandie/csvq/lib/value"
 
+	txjson "github.com/mithrandie/go-text/json"
+
 	"github.com/mithrandie/ternary"
 )
 
@@ -483,6 +489,57 @@ func TestTan(t *testing.T) {
 	testFunction(t, Tan, tanTests)
 }
 
+var sinhTests = []functionTest{
+	{
+		Name: "Sinh",
+		Function: parser.Function{
+			Name: "sinh",
+		},
+		Args: []value.Primary{
+			value.NewInteger(1),
+		},
+		Result: value.NewFloat(1.1752011936438014),
+	},
+}
+
+func TestSinh(t *testing.T) {
+	testFunction(t, Sinh, sinhTests)
+}
+
+var coshTests = []functionTest{
+	{
+		Name: "Cosh",
+		Function: parser.Function{
+			Name: "cosh",
+		},
+		Args: []value.Primary{
+			value.NewInteger(0),
+		},
+		Result: value.NewInteger(1),
+	},
+}
+
+func TestCosh(t *testing.T) {
+	testFunction(t, Cosh, coshTests)
+}
+
+var tanhTests = []functionTest{
+	{
+		Name: "Tanh",
+		Function: parser.Function{
+			Name: "tanh",
+		},
+		Args: []value.Primary{
+			value.NewFloat(0.5),
+		},
+		Result: value.NewFloat(0.46211715726000974),
+	},
+}
+
+func TestTanh(t *testing.T) {
+	testFunction(t, Tanh, tanhTests)
+}
+
 var expTests = []functionTest{
 	{
 		Name: "Exp",
@@ -688,644 +745,603 @@ func TestPow(t *testing.T) {
 	testFunction(t, Pow, powTests)
 }
 
-var binToDecTests = []functionTest{
-	{
-		Name: "BinToDec",
-		Function: parser.Function{
-			Name: "bin_to_dec",
-		},
-		Args: []value.Primary{
-			value.NewString("1111011"),
-		},
-		Result: value.NewInteger(123),
-	},
+var gammaTests = []functionTest{
 	{
-		Name: "BinToDec Null",
+		Name: "Gamma",
 		Function: parser.Function{
-			Name: "bin_to_dec",
+			Name: "gamma",
 		},
 		Args: []value.Primary{
-			value.NewNull(),
+			value.NewInteger(5),
 		},
-		Result: value.NewNull(),
+		Result: value.NewInteger(24),
 	},
 	{
-		Name: "BinToDec Parse Error",
+		Name: "Gamma Negative Integer",
 		Function: parser.Function{
-			Name: "bin_to_dec",
+			Name: "gamma",
 		},
 		Args: []value.Primary{
-			value.NewString("string"),
+			value.NewInteger(-1),
 		},
 		Result: value.NewNull(),
 	},
-	{
-		Name: "BinToDec Arguments Error",
-		Function: parser.Function{
-			Name: "bin_to_dec",
-		},
-		Args:  []value.Primary{},
-		Error: "function bin_to_dec takes exactly 1 argument",
-	},
-}
-
-func TestBinToDec(t *testing.T) {
-	testFunction(t, BinToDec, binToDecTests)
-}
-
-var octToDecTests = []functionTest{
-	{
-		Name: "OctToDec",
-		Function: parser.Function{
-			Name: "oct_to_dec",
-		},
-		Args: []value.Primary{
-			value.NewString("0173"),
-		},
-		Result: value.NewInteger(123),
-	},
 }
 
-func TestOctToDec(t *testing.T) {
-	testFunction(t, OctToDec, octToDecTests)
+func TestGamma(t *testing.T) {
+	testFunction(t, Gamma, gammaTests)
 }
 
-var hexToDecTests = []functionTest{
+var factorialTests = []functionTest{
 	{
-		Name: "HexToDec",
+		Name: "Factorial",
 		Function: parser.Function{
-			Name: "hex_to_dec",
+			Name: "factorial",
 		},
 		Args: []value.Primary{
-			value.NewString("0x7b"),
+			value.NewInteger(5),
 		},
-		Result: value.NewInteger(123),
+		Result: value.NewInteger(120),
 	},
-}
-
-func TestHexToDec(t *testing.T) {
-	testFunction(t, HexToDec, hexToDecTests)
-}
-
-var enotationToDecTests = []functionTest{
 	{
-		Name: "EnotationToDec",
+		Name: "Factorial Zero",
 		Function: parser.Function{
-			Name: "enotation_to_dec",
+			Name: "factorial",
 		},
 		Args: []value.Primary{
-			value.NewString("1.23e-11"),
+			value.NewInteger(0),
 		},
-		Result: value.NewFloat(0.0000000000123),
+		Result: value.NewInteger(1),
 	},
 	{
-		Name: "EnotationToDec To Integer",
+		Name: "Factorial Overflow",
 		Function: parser.Function{
-			Name: "enotation_to_dec",
+			Name: "factorial",
 		},
 		Args: []value.Primary{
-			value.NewString("1.23e+12"),
+			value.NewInteger(21),
 		},
-		Result: value.NewInteger(1230000000000),
+		Result: value.NewNull(),
 	},
 	{
-		Name: "EnotationToDec Null",
+		Name: "Factorial Negative",
 		Function: parser.Function{
-			Name: "enotation_to_dec",
+			Name: "factorial",
 		},
 		Args: []value.Primary{
-			value.NewNull(),
+			value.NewInteger(-1),
 		},
 		Result: value.NewNull(),
 	},
 	{
-		Name: "EnotationToDec Parse Error",
+		Name: "Factorial Not Integer",
 		Function: parser.Function{
-			Name: "enotation_to_dec",
+			Name: "factorial",
 		},
 		Args: []value.Primary{
-			value.NewString("string"),
+			value.NewFloat(2.5),
 		},
 		Result: value.NewNull(),
 	},
 	{
-		Name: "EnotationToDec Arguments Error",
+		Name: "Factorial Arguments Error",
 		Function: parser.Function{
-			Name: "enotation_to_dec",
+			Name: "factorial",
 		},
 		Args:  []value.Primary{},
-		Error: "function enotation_to_dec takes exactly 1 argument",
+		Error: "function factorial takes exactly 1 argument",
 	},
 }
 
-func TestEnotationToDec(t *testing.T) {
-	testFunction(t, EnotationToDec, enotationToDecTests)
+func TestFactorial(t *testing.T) {
+	testFunction(t, Factorial, factorialTests)
 }
 
-var binTests = []functionTest{
+var gcdTests = []functionTest{
 	{
-		Name: "Bin",
+		Name: "Gcd",
 		Function: parser.Function{
-			Name: "bin",
+			Name: "gcd",
 		},
 		Args: []value.Primary{
-			value.NewInteger(123),
+			value.NewInteger(12),
+			value.NewInteger(-18),
 		},
-		Result: value.NewString("1111011"),
+		Result: value.NewInteger(6),
 	},
 	{
-		Name: "Bin Null",
+		Name: "Gcd Zero",
 		Function: parser.Function{
-			Name: "bin",
+			Name: "gcd",
+		},
+		Args: []value.Primary{
+			value.NewInteger(0),
+			value.NewInteger(0),
+		},
+		Result: value.NewInteger(0),
+	},
+	{
+		Name: "Gcd Null",
+		Function: parser.Function{
+			Name: "gcd",
 		},
 		Args: []value.Primary{
+			value.NewInteger(12),
 			value.NewNull(),
 		},
 		Result: value.NewNull(),
 	},
 	{
-		Name: "Bin Arguments Error",
+		Name: "Gcd Arguments Error",
 		Function: parser.Function{
-			Name: "bin",
+			Name: "gcd",
 		},
-		Args:  []value.Primary{},
-		Error: "function bin takes exactly 1 argument",
+		Args: []value.Primary{
+			value.NewInteger(12),
+		},
+		Error: "function gcd takes exactly 2 arguments",
 	},
 }
 
-func TestBin(t *testing.T) {
-	testFunction(t, Bin, binTests)
+func TestGcd(t *testing.T) {
+	testFunction(t, Gcd, gcdTests)
 }
 
-var octTests = []functionTest{
+var lcmTests = []functionTest{
 	{
-		Name: "Oct",
+		Name: "Lcm",
 		Function: parser.Function{
-			Name: "oct",
+			Name: "lcm",
 		},
 		Args: []value.Primary{
-			value.NewInteger(123),
+			value.NewInteger(4),
+			value.NewInteger(-6),
 		},
-		Result: value.NewString("173"),
+		Result: value.NewInteger(12),
 	},
-}
-
-func TestOct(t *testing.T) {
-	testFunction(t, Oct, octTests)
-}
-
-var hexTests = []functionTest{
 	{
-		Name: "Hex",
+		Name: "Lcm Zero",
 		Function: parser.Function{
-			Name: "hex",
+			Name: "lcm",
 		},
 		Args: []value.Primary{
-			value.NewInteger(123),
+			value.NewInteger(4),
+			value.NewInteger(0),
 		},
-		Result: value.NewString("7b"),
+		Result: value.NewInteger(0),
+	},
+	{
+		Name: "Lcm Overflow",
+		Function: parser.Function{
+			Name: "lcm",
+		},
+		Args: []value.Primary{
+			value.NewInteger(9223372036854775807),
+			value.NewInteger(2),
+		},
+		Result: value.NewNull(),
 	},
 }
 
-func TestHex(t *testing.T) {
-	testFunction(t, Hex, hexTests)
+func TestLcm(t *testing.T) {
+	testFunction(t, Lcm, lcmTests)
 }
 
-var enotationTests = []functionTest{
+var safeDivideTests = []functionTest{
 	{
-		Name: "Enotation",
+		Name: "SafeDivide",
 		Function: parser.Function{
-			Name: "enotation",
+			Name: "safe_divide",
 		},
 		Args: []value.Primary{
-			value.NewFloat(0.0000000000123),
+			value.NewInteger(7),
+			value.NewInteger(2),
 		},
-		Result: value.NewString("1.23e-11"),
+		Result: value.NewFloat(3.5),
 	},
 	{
-		Name: "Enotation From Integer",
+		Name: "SafeDivide Division by Zero",
 		Function: parser.Function{
-			Name: "enotation",
+			Name: "safe_divide",
 		},
 		Args: []value.Primary{
-			value.NewInteger(1230000000000),
+			value.NewInteger(7),
+			value.NewInteger(0),
 		},
-		Result: value.NewString("1.23e+12"),
+		Result: value.NewNull(),
 	},
 	{
-		Name: "Enotation Null",
+		Name: "SafeDivide Null",
 		Function: parser.Function{
-			Name: "enotation",
+			Name: "safe_divide",
 		},
 		Args: []value.Primary{
 			value.NewNull(),
+			value.NewInteger(2),
 		},
 		Result: value.NewNull(),
 	},
 	{
-		Name: "Enotation Arguments Error",
+		Name: "SafeDivide Arguments Error",
 		Function: parser.Function{
-			Name: "enotation",
+			Name: "safe_divide",
 		},
-		Args:  []value.Primary{},
-		Error: "function enotation takes exactly 1 argument",
+		Args: []value.Primary{
+			value.NewInteger(7),
+		},
+		Error: "function safe_divide takes exactly 2 arguments",
 	},
 }
 
-func TestEnotation(t *testing.T) {
-	testFunction(t, Enotation, enotationTests)
+func TestSafeDivide(t *testing.T) {
+	testFunction(t, SafeDivide, safeDivideTests)
 }
 
-var numberFormatTests = []functionTest{
+var haversineTests = []functionTest{
 	{
-		Name: "NumberFormat",
+		Name: "Haversine",
 		Function: parser.Function{
-			Name: "number_format",
+			Name: "haversine",
 		},
 		Args: []value.Primary{
-			value.NewFloat(123456.789123),
-			value.NewInteger(4),
-			value.NewString(","),
-			value.NewString(" "),
-			value.NewString(" "),
+			value.NewFloat(0),
+			value.NewFloat(0),
+			value.NewFloat(0),
+			value.NewFloat(180),
 		},
-		Result: value.NewString("123 456,789 1"),
+		Result: value.NewFloat(EarthRadius * math.Pi),
 	},
 	{
-		Name: "NumberFormat Null",
+		Name: "Haversine with Radius",
 		Function: parser.Function{
-			Name: "number_format",
+			Name: "haversine",
+		},
+		Args: []value.Primary{
+			value.NewFloat(90),
+			value.NewInteger(0),
+			value.NewFloat(-90),
+			value.NewString("0"),
+			value.NewInteger(2),
+		},
+		Result: value.NewFloat(2 * math.Pi),
+	},
+	{
+		Name: "Haversine Argument is Null",
+		Function: parser.Function{
+			Name: "haversine",
 		},
 		Args: []value.Primary{
+			value.NewFloat(0),
 			value.NewNull(),
+			value.NewFloat(0),
+			value.NewFloat(90),
 		},
 		Result: value.NewNull(),
 	},
 	{
-		Name: "NumberFormat Arguments Errlr",
+		Name: "Haversine Arguments Error",
 		Function: parser.Function{
-			Name: "number_format",
+			Name: "haversine",
 		},
-		Args:  []value.Primary{},
-		Error: "function number_format takes 1 to 5 arguments",
+		Args: []value.Primary{
+			value.NewFloat(0),
+			value.NewFloat(0),
+		},
+		Error: "function haversine takes 4 or 5 arguments",
 	},
 }
 
-func TestNumberFormat(t *testing.T) {
-	testFunction(t, NumberFormat, numberFormatTests)
+func TestHaversine(t *testing.T) {
+	testFunction(t, Haversine, haversineTests)
 }
 
-var randTests = []struct {
-	Name      string
-	Function  parser.Function
-	Args      []value.Primary
-	RangeLow  float64
-	RangeHigh float64
-	Error     string
-}{
+var geohashEncodeTests = []functionTest{
 	{
-		Name: "Rand",
+		Name: "GeohashEncode",
 		Function: parser.Function{
-			Name: "rand",
+			Name: "geohash_encode",
 		},
-		RangeLow:  0.0,
-		RangeHigh: 1.0,
+		Args: []value.Primary{
+			value.NewFloat(57.64911),
+			value.NewFloat(10.40744),
+		},
+		Result: value.NewString("u4pruydqqvj8"),
 	},
 	{
-		Name: "Rand Range Specified",
+		Name: "GeohashEncode with Precision",
 		Function: parser.Function{
-			Name: "rand",
+			Name: "geohash_encode",
 		},
 		Args: []value.Primary{
-			value.NewInteger(7),
-			value.NewInteger(12),
+			value.NewFloat(57.64911),
+			value.NewFloat(10.40744),
+			value.NewInteger(5),
 		},
-		RangeLow:  7.0,
-		RangeHigh: 12.0,
+		Result: value.NewString("u4pru"),
 	},
 	{
-		Name: "Range Arguments Error",
+		Name: "GeohashEncode Latitude Out of Range",
 		Function: parser.Function{
-			Name: "rand",
+			Name: "geohash_encode",
 		},
 		Args: []value.Primary{
-			value.NewInteger(1),
+			value.NewFloat(-90.5),
+			value.NewFloat(10.40744),
 		},
-		Error: "function rand takes 0 or 2 arguments",
+		Result: value.NewNull(),
 	},
 	{
-		Name: "Range First Arguments Error",
+		Name: "GeohashEncode Invalid Precision Error",
 		Function: parser.Function{
-			Name: "rand",
+			Name: "geohash_encode",
 		},
 		Args: []value.Primary{
-			value.NewString("a"),
-			value.NewInteger(2),
+			value.NewFloat(57.64911),
+			value.NewFloat(10.40744),
+			value.NewInteger(13),
 		},
-		Error: "the first argument must be an integer for function rand",
-	},
-	{
-		Name: "Range Second Arguments Error",
-		Function: parser.Function{
-			Name: "rand",
-		},
-		Args: []value.Primary{
-			value.NewInteger(1),
-			value.NewString("a"),
-		},
-		Error: "the second argument must be an integer for function rand",
+		Error: "the third argument must be an integer from 1 to 12 for function geohash_encode",
 	},
 	{
-		Name: "Range Arguments Value Error",
+		Name: "GeohashEncode Arguments Error",
 		Function: parser.Function{
-			Name: "rand",
+			Name: "geohash_encode",
 		},
 		Args: []value.Primary{
-			value.NewInteger(1),
-			value.NewInteger(1),
+			value.NewFloat(57.64911),
 		},
-		Error: "the second argument must be greater than the first argument for function rand",
+		Error: "function geohash_encode takes 2 or 3 arguments",
 	},
 }
 
-func TestRand(t *testing.T) {
-	for _, v := range randTests {
-		result, err := Rand(v.Function, v.Args, TestTx.Flags)
-		if err != nil {
-			if len(v.Error) < 1 {
-				t.Errorf("%s: unexpected error %q", v.Name, err)
-			} else if err.Error() != v.Error {
-				t.Errorf("%s: error %q, want error %q", v.Name, err.Error(), v.Error)
-			}
-			continue
-		}
-		if 0 < len(v.Error) {
-			t.Errorf("%s: no error, want error %q", v.Name, v.Error)
-			continue
-		}
-
-		var f float64
-		if len(v.Args) < 1 {
-			f = result.(value.Float).Raw()
-		} else {
-			f = float64(result.(value.Integer).Raw())
-		}
-
-		if f < v.RangeLow || v.RangeHigh < f {
-			t.Errorf("%s: result = %f, want in range from %f to %f", v.Name, f, v.RangeLow, v.RangeHigh)
-		}
-	}
+func TestGeohashEncode(t *testing.T) {
+	testFunction(t, GeohashEncode, geohashEncodeTests)
 }
 
-var trimTests = []functionTest{
-	{
-		Name: "Trim",
-		Function: parser.Function{
-			Name: "trim",
-		},
-		Args: []value.Primary{
-			value.NewString("aabbfoo, baraabb"),
-			value.NewString("ab"),
-		},
-		Result: value.NewString("foo, bar"),
-	},
+var geohashDecodeTests = []functionTest{
 	{
-		Name: "Trim Spaces",
+		Name: "GeohashDecode",
 		Function: parser.Function{
-			Name: "trim",
+			Name: "geohash_decode",
 		},
 		Args: []value.Primary{
-			value.NewString("  foo, bar \n"),
+			value.NewString("S"),
 		},
-		Result: value.NewString("foo, bar"),
+		Result: value.NewArray([]value.Primary{value.NewFloat(22.5), value.NewFloat(22.5)}),
 	},
 	{
-		Name: "Trim Null",
+		Name: "GeohashDecode Invalid Character",
 		Function: parser.Function{
-			Name: "trim",
+			Name: "geohash_decode",
 		},
 		Args: []value.Primary{
-			value.NewNull(),
-			value.NewString("ab"),
+			value.NewString("u4a"),
 		},
 		Result: value.NewNull(),
 	},
 	{
-		Name: "Trim Cutset is Null",
+		Name: "GeohashDecode Argument is Null",
 		Function: parser.Function{
-			Name: "trim",
+			Name: "geohash_decode",
 		},
 		Args: []value.Primary{
-			value.NewString("aabbfoo, baraabb"),
 			value.NewNull(),
 		},
 		Result: value.NewNull(),
 	},
 	{
-		Name: "Trim Arguments Error",
+		Name: "GeohashDecode Arguments Error",
 		Function: parser.Function{
-			Name: "trim",
+			Name: "geohash_decode",
 		},
 		Args:  []value.Primary{},
-		Error: "function trim takes 1 or 2 arguments",
+		Error: "function geohash_decode takes exactly 1 argument",
 	},
 }
 
-func TestTrim(t *testing.T) {
-	testFunction(t, Trim, trimTests)
+func TestGeohashDecode(t *testing.T) {
+	testFunction(t, GeohashDecode, geohashDecodeTests)
 }
 
-var ltrimTests = []functionTest{
+var pmtTests = []functionTest{
 	{
-		Name: "Ltrim",
+		Name: "Pmt",
 		Function: parser.Function{
-			Name: "ltrim",
+			Name: "pmt",
 		},
 		Args: []value.Primary{
-			value.NewString("aabbfoo, baraabb"),
-			value.NewString("ab"),
+			value.NewFloat(0.1),
+			value.NewInteger(2),
+			value.NewInteger(1000),
 		},
-		Result: value.NewString("foo, baraabb"),
+		Result: value.NewFloat(-576.1904761904758),
 	},
 	{
-		Name: "Ltrim Spaces",
+		Name: "Pmt Zero Rate",
 		Function: parser.Function{
-			Name: "ltrim",
+			Name: "pmt",
 		},
 		Args: []value.Primary{
-			value.NewString("  foo, bar \n"),
+			value.NewInteger(0),
+			value.NewInteger(10),
+			value.NewInteger(1000),
+			value.NewInteger(-500),
 		},
-		Result: value.NewString("foo, bar \n"),
+		Result: value.NewFloat(-50),
 	},
-}
-
-func TestLtrim(t *testing.T) {
-	testFunction(t, Ltrim, ltrimTests)
-}
-
-var rtrimTests = []functionTest{
 	{
-		Name: "Rtrim",
+		Name: "Pmt Beginning of Period",
 		Function: parser.Function{
-			Name: "rtrim",
+			Name: "pmt",
 		},
 		Args: []value.Primary{
-			value.NewString("aabbfoo, baraabb"),
-			value.NewString("ab"),
+			value.NewFloat(0.1),
+			value.NewInteger(2),
+			value.NewInteger(100),
+			value.NewInteger(0),
+			value.NewInteger(1),
 		},
-		Result: value.NewString("aabbfoo, bar"),
+		Result: value.NewFloat(-52.38095238095234),
 	},
 	{
-		Name: "Rtrim Spaces",
+		Name: "Pmt Null",
 		Function: parser.Function{
-			Name: "rtrim",
+			Name: "pmt",
 		},
 		Args: []value.Primary{
-			value.NewString("  foo, bar \n"),
+			value.NewFloat(0.1),
+			value.NewNull(),
+			value.NewInteger(1000),
 		},
-		Result: value.NewString("  foo, bar"),
+		Result: value.NewNull(),
 	},
-}
-
-func TestRtrim(t *testing.T) {
-	testFunction(t, Rtrim, rtrimTests)
-}
-
-var upperTests = []functionTest{
 	{
-		Name: "Upper",
+		Name: "Pmt Zero Periods Error",
 		Function: parser.Function{
-			Name: "upper",
+			Name: "pmt",
 		},
 		Args: []value.Primary{
-			value.NewString("Foo"),
+			value.NewFloat(0.1),
+			value.NewInteger(0),
+			value.NewInteger(1000),
 		},
-		Result: value.NewString("FOO"),
+		Error: "the number of periods must not be 0 for function pmt",
 	},
 	{
-		Name: "Upper Null",
+		Name: "Pmt Payment Timing Error",
 		Function: parser.Function{
-			Name: "upper",
+			Name: "pmt",
 		},
 		Args: []value.Primary{
-			value.NewNull(),
+			value.NewFloat(0.1),
+			value.NewInteger(2),
+			value.NewInteger(1000),
+			value.NewInteger(0),
+			value.NewInteger(2),
 		},
-		Result: value.NewNull(),
+		Error: "the fifth argument must be 0 or 1 for function pmt",
 	},
 	{
-		Name: "Upper Arguments Error",
+		Name: "Pmt Arguments Error",
 		Function: parser.Function{
-			Name: "upper",
+			Name: "pmt",
 		},
-		Args:  []value.Primary{},
-		Error: "function upper takes exactly 1 argument",
+		Args: []value.Primary{
+			value.NewFloat(0.1),
+			value.NewInteger(2),
+		},
+		Error: "function pmt takes 3 to 5 arguments",
 	},
 }
 
-func TestUpper(t *testing.T) {
-	testFunction(t, Upper, upperTests)
+func TestPmt(t *testing.T) {
+	testFunction(t, Pmt, pmtTests)
 }
 
-var lowerTests = []functionTest{
+var binToDecTests = []functionTest{
 	{
-		Name: "Lower",
+		Name: "BinToDec",
 		Function: parser.Function{
-			Name: "lower",
+			Name: "bin_to_dec",
 		},
 		Args: []value.Primary{
-			value.NewString("Foo"),
+			value.NewString("1111011"),
 		},
-		Result: value.NewString("foo"),
+		Result: value.NewInteger(123),
 	},
-}
-
-func TestLower(t *testing.T) {
-	testFunction(t, Lower, lowerTests)
-}
-
-var base64EncodeTests = []functionTest{
 	{
-		Name: "Base64Encode",
+		Name: "BinToDec Null",
 		Function: parser.Function{
-			Name: "base64_encode",
+			Name: "bin_to_dec",
 		},
 		Args: []value.Primary{
-			value.NewString("Foo"),
+			value.NewNull(),
 		},
-		Result: value.NewString("Rm9v"),
+		Result: value.NewNull(),
 	},
-}
-
-func TestBase64Encode(t *testing.T) {
-	testFunction(t, Base64Encode, base64EncodeTests)
-}
-
-var base64DecodeTests = []functionTest{
 	{
-		Name: "Base64Decode",
+		Name: "BinToDec Parse Error",
 		Function: parser.Function{
-			Name: "base64_decode",
+			Name: "bin_to_dec",
 		},
 		Args: []value.Primary{
-			value.NewString("Rm9v"),
+			value.NewString("string"),
 		},
-		Result: value.NewString("Foo"),
+		Result: value.NewNull(),
+	},
+	{
+		Name: "BinToDec Arguments Error",
+		Function: parser.Function{
+			Name: "bin_to_dec",
+		},
+		Args:  []value.Primary{},
+		Error: "function bin_to_dec takes exactly 1 argument",
 	},
 }
 
-func TestBase64Decode(t *testing.T) {
-	testFunction(t, Base64Decode, base64DecodeTests)
+func TestBinToDec(t *testing.T) {
+	testFunction(t, BinToDec, binToDecTests)
 }
 
-var hexEncodeTests = []functionTest{
+var octToDecTests = []functionTest{
 	{
-		Name: "HexEncode",
+		Name: "OctToDec",
 		Function: parser.Function{
-			Name: "hex_encode",
+			Name: "oct_to_dec",
 		},
 		Args: []value.Primary{
-			value.NewString("Foo"),
+			value.NewString("0173"),
 		},
-		Result: value.NewString("466f6f"),
+		Result: value.NewInteger(123),
 	},
 }
 
-func TestHexEncode(t *testing.T) {
-	testFunction(t, HexEncode, hexEncodeTests)
+func TestOctToDec(t *testing.T) {
+	testFunction(t, OctToDec, octToDecTests)
 }
 
-var hexDecodeTests = []functionTest{
+var hexToDecTests = []functionTest{
 	{
-		Name: "HexDecode",
+		Name: "HexToDec",
 		Function: parser.Function{
-			Name: "hex_decode",
+			Name: "hex_to_dec",
 		},
 		Args: []value.Primary{
-			value.NewString("466f6f"),
+			value.NewString("0x7b"),
 		},
-		Result: value.NewString("Foo"),
+		Result: value.NewInteger(123),
 	},
 }
 
-func TestHexDecode(t *testing.T) {
-	testFunction(t, HexDecode, hexDecodeTests)
+func TestHexToDec(t *testing.T) {
+	testFunction(t, HexToDec, hexToDecTests)
 }
 
-var lenTests = []functionTest{
+var enotationToDecTests = []functionTest{
 	{
-		Name: "Len",
+		Name: "EnotationToDec",
 		Function: parser.Function{
-			Name: "len",
+			Name: "enotation_to_dec",
 		},
 		Args: []value.Primary{
-			value.NewString("日本語"),
+			value.NewString("1.23e-11"),
 		},
-		Result: value.NewInteger(3),
+		Result: value.NewFloat(0.0000000000123),
 	},
 	{
-		Name: "Len Null",
+		Name: "EnotationToDec To Integer",
 		Function: parser.Function{
-			Name: "len",
+			Name: "enotation_to_dec",
+		},
+		Args: []value.Primary{
+			value.NewString("1.23e+12"),
+		},
+		Result: value.NewInteger(1230000000000),
+	},
+	{
+		Name: "EnotationToDec Null",
+		Function: parser.Function{
+			Name: "enotation_to_dec",
 		},
 		Args: []value.Primary{
 			value.NewNull(),
@@ -1333,1581 +1349,4440 @@ var lenTests = []functionTest{
 		Result: value.NewNull(),
 	},
 	{
-		Name: "Len Arguments Error",
+		Name: "EnotationToDec Parse Error",
 		Function: parser.Function{
-			Name: "len",
+			Name: "enotation_to_dec",
 		},
-		Args:  []value.Primary{},
-		Error: "function len takes exactly 1 argument",
+		Args: []value.Primary{
+			value.NewString("string"),
+		},
+		Result: value.NewNull(),
 	},
-}
-
+	{
+		Name: "EnotationToDec Arguments Error",
+		Function: parser.Function{
+			Name: "enotation_to_dec",
+		},
+		Args:  []value.Primary{},
+		Error: "function enotation_to_dec takes exactly 1 argument",
+	},
+}
+
+func TestEnotationToDec(t *testing.T) {
+	testFunction(t, EnotationToDec, enotationToDecTests)
+}
+
+var binTests = []functionTest{
+	{
+		Name: "Bin",
+		Function: parser.Function{
+			Name: "bin",
+		},
+		Args: []value.Primary{
+			value.NewInteger(123),
+		},
+		Result: value.NewString("1111011"),
+	},
+	{
+		Name: "Bin Null",
+		Function: parser.Function{
+			Name: "bin",
+		},
+		Args: []value.Primary{
+			value.NewNull(),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "Bin Arguments Error",
+		Function: parser.Function{
+			Name: "bin",
+		},
+		Args:  []value.Primary{},
+		Error: "function bin takes exactly 1 argument",
+	},
+}
+
+func TestBin(t *testing.T) {
+	testFunction(t, Bin, binTests)
+}
+
+var octTests = []functionTest{
+	{
+		Name: "Oct",
+		Function: parser.Function{
+			Name: "oct",
+		},
+		Args: []value.Primary{
+			value.NewInteger(123),
+		},
+		Result: value.NewString("173"),
+	},
+}
+
+func TestOct(t *testing.T) {
+	testFunction(t, Oct, octTests)
+}
+
+var hexTests = []functionTest{
+	{
+		Name: "Hex",
+		Function: parser.Function{
+			Name: "hex",
+		},
+		Args: []value.Primary{
+			value.NewInteger(123),
+		},
+		Result: value.NewString("7b"),
+	},
+}
+
+func TestHex(t *testing.T) {
+	testFunction(t, Hex, hexTests)
+}
+
+var enotationTests = []functionTest{
+	{
+		Name: "Enotation",
+		Function: parser.Function{
+			Name: "enotation",
+		},
+		Args: []value.Primary{
+			value.NewFloat(0.0000000000123),
+		},
+		Result: value.NewString("1.23e-11"),
+	},
+	{
+		Name: "Enotation From Integer",
+		Function: parser.Function{
+			Name: "enotation",
+		},
+		Args: []value.Primary{
+			value.NewInteger(1230000000000),
+		},
+		Result: value.NewString("1.23e+12"),
+	},
+	{
+		Name: "Enotation Null",
+		Function: parser.Function{
+			Name: "enotation",
+		},
+		Args: []value.Primary{
+			value.NewNull(),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "Enotation Arguments Error",
+		Function: parser.Function{
+			Name: "enotation",
+		},
+		Args:  []value.Primary{},
+		Error: "function enotation takes exactly 1 argument",
+	},
+}
+
+func TestEnotation(t *testing.T) {
+	testFunction(t, Enotation, enotationTests)
+}
+
+var numberFormatTests = []functionTest{
+	{
+		Name: "NumberFormat",
+		Function: parser.Function{
+			Name: "number_format",
+		},
+		Args: []value.Primary{
+			value.NewFloat(123456.789123),
+			value.NewInteger(4),
+			value.NewString(","),
+			value.NewString(" "),
+			value.NewString(" "),
+		},
+		Result: value.NewString("123 456,789 1"),
+	},
+	{
+		Name: "NumberFormat Null",
+		Function: parser.Function{
+			Name: "number_format",
+		},
+		Args: []value.Primary{
+			value.NewNull(),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "NumberFormat Arguments Errlr",
+		Function: parser.Function{
+			Name: "number_format",
+		},
+		Args:  []value.Primary{},
+		Error: "function number_format takes 1 to 5 arguments",
+	},
+}
+
+func TestNumberFormat(t *testing.T) {
+	testFunction(t, NumberFormat, numberFormatTests)
+}
+
+var randTests = []struct {
+	Name      string
+	Function  parser.Function
+	Args      []value.Primary
+	RangeLow  float64
+	RangeHigh float64
+	Error     string
+}{
+	{
+		Name: "Rand",
+		Function: parser.Function{
+			Name: "rand",
+		},
+		RangeLow:  0.0,
+		RangeHigh: 1.0,
+	},
+	{
+		Name: "Rand Range Specified",
+		Function: parser.Function{
+			Name: "rand",
+		},
+		Args: []value.Primary{
+			value.NewInteger(7),
+			value.NewInteger(12),
+		},
+		RangeLow:  7.0,
+		RangeHigh: 12.0,
+	},
+	{
+		Name: "Range Arguments Error",
+		Function: parser.Function{
+			Name: "rand",
+		},
+		Args: []value.Primary{
+			value.NewInteger(1),
+		},
+		Error: "function rand takes 0 or 2 arguments",
+	},
+	{
+		Name: "Range First Arguments Error",
+		Function: parser.Function{
+			Name: "rand",
+		},
+		Args: []value.Primary{
+			value.NewString("a"),
+			value.NewInteger(2),
+		},
+		Error: "the first argument must be an integer for function rand",
+	},
+	{
+		Name: "Range Second Arguments Error",
+		Function: parser.Function{
+			Name: "rand",
+		},
+		Args: []value.Primary{
+			value.NewInteger(1),
+			value.NewString("a"),
+		},
+		Error: "the second argument must be an integer for function rand",
+	},
+	{
+		Name: "Range Arguments Value Error",
+		Function: parser.Function{
+			Name: "rand",
+		},
+		Args: []value.Primary{
+			value.NewInteger(1),
+			value.NewInteger(1),
+		},
+		Error: "the second argument must be greater than the first argument for function rand",
+	},
+}
+
+func TestRand(t *testing.T) {
+	for _, v := range randTests {
+		result, err := Rand(v.Function, v.Args, TestTx.Flags)
+		if err != nil {
+			if len(v.Error) < 1 {
+				t.Errorf("%s: unexpected error %q", v.Name, err)
+			} else if err.Error() != v.Error {
+				t.Errorf("%s: error %q, want error %q", v.Name, err.Error(), v.Error)
+			}
+			continue
+		}
+		if 0 < len(v.Error) {
+			t.Errorf("%s: no error, want error %q", v.Name, v.Error)
+			continue
+		}
+
+		var f float64
+		if len(v.Args) < 1 {
+			f = result.(value.Float).Raw()
+		} else {
+			f = float64(result.(value.Integer).Raw())
+		}
+
+		if f < v.RangeLow || v.RangeHigh < f {
+			t.Errorf("%s: result = %f, want in range from %f to %f", v.Name, f, v.RangeLow, v.RangeHigh)
+		}
+	}
+}
+
+func TestRandom(t *testing.T) {
+	fn := parser.Function{Name: "random"}
+
+	cmd.SetRandSeed(1)
+	result1, err := Random(fn, []value.Primary{}, TestTx.Flags)
+	if err != nil {
+		t.Fatalf("Random: unexpected error %q", err)
+	}
+	f := result1.(value.Float).Raw()
+	if f < 0 || 1 <= f {
+		t.Errorf("Random: result = %f, want in range from %f to %f", f, 0.0, 1.0)
+	}
+
+	cmd.SetRandSeed(1)
+	result2, _ := Random(fn, []value.Primary{}, TestTx.Flags)
+	if !reflect.DeepEqual(result1, result2) {
+		t.Errorf("Random: result = %s, want %s with the same seed", result2, result1)
+	}
+
+	_, err = Random(fn, []value.Primary{value.NewInteger(1)}, TestTx.Flags)
+	if err == nil {
+		t.Error("Random: no error, want error")
+	} else if err.Error() != "function random takes no argument" {
+		t.Errorf("Random: error %q, want error %q", err.Error(), "function random takes no argument")
+	}
+}
+
+var randomBetweenTests = []functionTest{
+	{
+		Name: "RandomBetween",
+		Function: parser.Function{
+			Name: "random_between",
+		},
+		Args: []value.Primary{
+			value.NewInteger(3),
+			value.NewString("3"),
+		},
+		Result: value.NewInteger(3),
+	},
+	{
+		Name: "RandomBetween Argument is Null",
+		Function: parser.Function{
+			Name: "random_between",
+		},
+		Args: []value.Primary{
+			value.NewNull(),
+			value.NewInteger(3),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "RandomBetween First Argument Error",
+		Function: parser.Function{
+			Name: "random_between",
+		},
+		Args: []value.Primary{
+			value.NewString("a"),
+			value.NewInteger(3),
+		},
+		Error: "the first argument must be an integer for function random_between",
+	},
+	{
+		Name: "RandomBetween Range Error",
+		Function: parser.Function{
+			Name: "random_between",
+		},
+		Args: []value.Primary{
+			value.NewInteger(3),
+			value.NewInteger(2),
+		},
+		Error: "the second argument must be greater than or equal to the first argument for function random_between",
+	},
+	{
+		Name: "RandomBetween Arguments Error",
+		Function: parser.Function{
+			Name: "random_between",
+		},
+		Args: []value.Primary{
+			value.NewInteger(3),
+		},
+		Error: "function random_between takes exactly 2 arguments",
+	},
+}
+
+func TestRandomBetween(t *testing.T) {
+	testFunction(t, RandomBetween, randomBetweenTests)
+}
+
+var randomStringTests = []functionTest{
+	{
+		Name: "RandomString",
+		Function: parser.Function{
+			Name: "random_string",
+		},
+		Args: []value.Primary{
+			value.NewInteger(3),
+			value.NewString("a"),
+		},
+		Result: value.NewString("aaa"),
+	},
+	{
+		Name: "RandomString Zero Length",
+		Function: parser.Function{
+			Name: "random_string",
+		},
+		Args: []value.Primary{
+			value.NewInteger(0),
+		},
+		Result: value.NewString(""),
+	},
+	{
+		Name: "RandomString Length is Null",
+		Function: parser.Function{
+			Name: "random_string",
+		},
+		Args: []value.Primary{
+			value.NewNull(),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "RandomString Negative Length Error",
+		Function: parser.Function{
+			Name: "random_string",
+		},
+		Args: []value.Primary{
+			value.NewInteger(-1),
+		},
+		Error: "the first argument must be greater than or equal to 0 for function random_string",
+	},
+	{
+		Name: "RandomString Empty Characters Error",
+		Function: parser.Function{
+			Name: "random_string",
+		},
+		Args: []value.Primary{
+			value.NewInteger(3),
+			value.NewString(""),
+		},
+		Error: "the second argument must not be empty for function random_string",
+	},
+	{
+		Name: "RandomString Arguments Error",
+		Function: parser.Function{
+			Name: "random_string",
+		},
+		Args:  []value.Primary{},
+		Error: "function random_string takes 1 or 2 arguments",
+	},
+}
+
+func TestRandomString(t *testing.T) {
+	testFunction(t, RandomString, randomStringTests)
+
+	result, _ := RandomString(parser.Function{Name: "random_string"}, []value.Primary{value.NewInteger(16)}, TestTx.Flags)
+	if s := result.(value.String).Raw(); len(s) != 16 || strings.Trim(s, randomStringCharacters) != "" {
+		t.Errorf("RandomString: result = %q, want 16 alphanumeric characters", s)
+	}
+}
+
+var trimTests = []functionTest{
+	{
+		Name: "Trim",
+		Function: parser.Function{
+			Name: "trim",
+		},
+		Args: []value.Primary{
+			value.NewString("aabbfoo, baraabb"),
+			value.NewString("ab"),
+		},
+		Result: value.NewString("foo, bar"),
+	},
+	{
+		Name: "Trim Spaces",
+		Function: parser.Function{
+			Name: "trim",
+		},
+		Args: []value.Primary{
+			value.NewString("  foo, bar \n"),
+		},
+		Result: value.NewString("foo, bar"),
+	},
+	{
+		Name: "Trim Null",
+		Function: parser.Function{
+			Name: "trim",
+		},
+		Args: []value.Primary{
+			value.NewNull(),
+			value.NewString("ab"),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "Trim Cutset is Null",
+		Function: parser.Function{
+			Name: "trim",
+		},
+		Args: []value.Primary{
+			value.NewString("aabbfoo, baraabb"),
+			value.NewNull(),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "Trim Arguments Error",
+		Function: parser.Function{
+			Name: "trim",
+		},
+		Args:  []value.Primary{},
+		Error: "function trim takes 1 or 2 arguments",
+	},
+}
+
+func TestTrim(t *testing.T) {
+	testFunction(t, Trim, trimTests)
+}
+
+var ltrimTests = []functionTest{
+	{
+		Name: "Ltrim",
+		Function: parser.Function{
+			Name: "ltrim",
+		},
+		Args: []value.Primary{
+			value.NewString("aabbfoo, baraabb"),
+			value.NewString("ab"),
+		},
+		Result: value.NewString("foo, baraabb"),
+	},
+	{
+		Name: "Ltrim Spaces",
+		Function: parser.Function{
+			Name: "ltrim",
+		},
+		Args: []value.Primary{
+			value.NewString("  foo, bar \n"),
+		},
+		Result: value.NewString("foo, bar \n"),
+	},
+}
+
+func TestLtrim(t *testing.T) {
+	testFunction(t, Ltrim, ltrimTests)
+}
+
+var rtrimTests = []functionTest{
+	{
+		Name: "Rtrim",
+		Function: parser.Function{
+			Name: "rtrim",
+		},
+		Args: []value.Primary{
+			value.NewString("aabbfoo, baraabb"),
+			value.NewString("ab"),
+		},
+		Result: value.NewString("aabbfoo, bar"),
+	},
+	{
+		Name: "Rtrim Spaces",
+		Function: parser.Function{
+			Name: "rtrim",
+		},
+		Args: []value.Primary{
+			value.NewString("  foo, bar \n"),
+		},
+		Result: value.NewString("  foo, bar"),
+	},
+}
+
+func TestRtrim(t *testing.T) {
+	testFunction(t, Rtrim, rtrimTests)
+}
+
+var upperTests = []functionTest{
+	{
+		Name: "Upper",
+		Function: parser.Function{
+			Name: "upper",
+		},
+		Args: []value.Primary{
+			value.NewString("Foo"),
+		},
+		Result: value.NewString("FOO"),
+	},
+	{
+		Name: "Upper Null",
+		Function: parser.Function{
+			Name: "upper",
+		},
+		Args: []value.Primary{
+			value.NewNull(),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "Upper Arguments Error",
+		Function: parser.Function{
+			Name: "upper",
+		},
+		Args:  []value.Primary{},
+		Error: "function upper takes 1 or 2 arguments",
+	},
+	{
+		Name: "Upper with Locale",
+		Function: parser.Function{
+			Name: "upper",
+		},
+		Args: []value.Primary{
+			value.NewString("istanbul"),
+			value.NewString("tr"),
+		},
+		Result: value.NewString("İSTANBUL"),
+	},
+	{
+		Name: "Upper Locale is Null",
+		Function: parser.Function{
+			Name: "upper",
+		},
+		Args: []value.Primary{
+			value.NewString("istanbul"),
+			value.NewNull(),
+		},
+		Error: "the second argument must be a string for function upper",
+	},
+	{
+		Name: "Upper Invalid Locale",
+		Function: parser.Function{
+			Name: "upper",
+		},
+		Args: []value.Primary{
+			value.NewString("istanbul"),
+			value.NewString("x!"),
+		},
+		Error: "locale x! is not valid for function upper",
+	},
+}
+
+func TestUpper(t *testing.T) {
+	testFunction(t, Upper, upperTests)
+}
+
+var lowerTests = []functionTest{
+	{
+		Name: "Lower",
+		Function: parser.Function{
+			Name: "lower",
+		},
+		Args: []value.Primary{
+			value.NewString("Foo"),
+		},
+		Result: value.NewString("foo"),
+	},
+	{
+		Name: "Lower with Locale",
+		Function: parser.Function{
+			Name: "lower",
+		},
+		Args: []value.Primary{
+			value.NewString("DİYARBAKIR"),
+			value.NewString("tr"),
+		},
+		Result: value.NewString("diyarbakır"),
+	},
+}
+
+func TestLower(t *testing.T) {
+	testFunction(t, Lower, lowerTests)
+}
+
+var titlecaseTests = []functionTest{
+	{
+		Name: "Titlecase",
+		Function: parser.Function{
+			Name: "titlecase",
+		},
+		Args: []value.Primary{
+			value.NewString("hELLO wORLD"),
+		},
+		Result: value.NewString("Hello World"),
+	},
+	{
+		Name: "Titlecase with Locale",
+		Function: parser.Function{
+			Name: "titlecase",
+		},
+		Args: []value.Primary{
+			value.NewString("ijsselmeer"),
+			value.NewString("nl"),
+		},
+		Result: value.NewString("IJsselmeer"),
+	},
+	{
+		Name: "Titlecase Null",
+		Function: parser.Function{
+			Name: "titlecase",
+		},
+		Args: []value.Primary{
+			value.NewNull(),
+		},
+		Result: value.NewNull(),
+	},
+}
+
+func TestTitlecase(t *testing.T) {
+	testFunction(t, Titlecase, titlecaseTests)
+}
+
+var normalizeTests = []functionTest{
+	{
+		Name: "Normalize",
+		Function: parser.Function{
+			Name: "normalize",
+		},
+		Args: []value.Primary{
+			value.NewString("e\u0301"),
+		},
+		Result: value.NewString("\u00e9"),
+	},
+	{
+		Name: "Normalize NFD",
+		Function: parser.Function{
+			Name: "normalize",
+		},
+		Args: []value.Primary{
+			value.NewString("\u00e9"),
+			value.NewString("nfd"),
+		},
+		Result: value.NewString("e\u0301"),
+	},
+	{
+		Name: "Normalize NFKC",
+		Function: parser.Function{
+			Name: "normalize",
+		},
+		Args: []value.Primary{
+			value.NewString("ｶﾞ①"),
+			value.NewString("NFKC"),
+		},
+		Result: value.NewString("ガ1"),
+	},
+	{
+		Name: "Normalize NFKD",
+		Function: parser.Function{
+			Name: "normalize",
+		},
+		Args: []value.Primary{
+			value.NewString("①"),
+			value.NewString("NFKD"),
+		},
+		Result: value.NewString("1"),
+	},
+	{
+		Name: "Normalize Null",
+		Function: parser.Function{
+			Name: "normalize",
+		},
+		Args: []value.Primary{
+			value.NewNull(),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "Normalize Invalid Form Error",
+		Function: parser.Function{
+			Name: "normalize",
+		},
+		Args: []value.Primary{
+			value.NewString("abc"),
+			value.NewString("NFX"),
+		},
+		Error: "the second argument must be one of NFC|NFD|NFKC|NFKD for function normalize",
+	},
+	{
+		Name: "Normalize Arguments Error",
+		Function: parser.Function{
+			Name: "normalize",
+		},
+		Args:  []value.Primary{},
+		Error: "function normalize takes 1 or 2 arguments",
+	},
+}
+
+func TestNormalize(t *testing.T) {
+	testFunction(t, Normalize, normalizeTests)
+}
+
+var unaccentTests = []functionTest{
+	{
+		Name: "Unaccent",
+		Function: parser.Function{
+			Name: "unaccent",
+		},
+		Args: []value.Primary{
+			value.NewString("Crème brûlée, Łódź, Ørsted"),
+		},
+		Result: value.NewString("Creme brulee, Lodz, Orsted"),
+	},
+	{
+		Name: "Unaccent Null",
+		Function: parser.Function{
+			Name: "unaccent",
+		},
+		Args: []value.Primary{
+			value.NewNull(),
+		},
+		Result: value.NewNull(),
+	},
+}
+
+func TestUnaccent(t *testing.T) {
+	testFunction(t, Unaccent, unaccentTests)
+}
+
+var transliterateTests = []functionTest{
+	{
+		Name: "Transliterate",
+		Function: parser.Function{
+			Name: "transliterate",
+		},
+		Args: []value.Primary{
+			value.NewString("Straße “Æsir” – naïve ①"),
+		},
+		Result: value.NewString("Strasse \"AEsir\" - naive 1"),
+	},
+	{
+		Name: "Transliterate Untransliterable Characters",
+		Function: parser.Function{
+			Name: "transliterate",
+		},
+		Args: []value.Primary{
+			value.NewString("abc日本"),
+		},
+		Result: value.NewString("abc??"),
+	},
+	{
+		Name: "Transliterate Replacement",
+		Function: parser.Function{
+			Name: "transliterate",
+		},
+		Args: []value.Primary{
+			value.NewString("abc日本"),
+			value.NewString(""),
+		},
+		Result: value.NewString("abc"),
+	},
+	{
+		Name: "Transliterate Null",
+		Function: parser.Function{
+			Name: "transliterate",
+		},
+		Args: []value.Primary{
+			value.NewNull(),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "Transliterate Arguments Error",
+		Function: parser.Function{
+			Name: "transliterate",
+		},
+		Args:  []value.Primary{},
+		Error: "function transliterate takes 1 or 2 arguments",
+	},
+}
+
+func TestTransliterate(t *testing.T) {
+	testFunction(t, Transliterate, transliterateTests)
+}
+
+var base64EncodeTests = []functionTest{
+	{
+		Name: "Base64Encode",
+		Function: parser.Function{
+			Name: "base64_encode",
+		},
+		Args: []value.Primary{
+			value.NewString("Foo"),
+		},
+		Result: value.NewString("Rm9v"),
+	},
+}
+
+func TestBase64Encode(t *testing.T) {
+	testFunction(t, Base64Encode, base64EncodeTests)
+}
+
+var base64DecodeTests = []functionTest{
+	{
+		Name: "Base64Decode",
+		Function: parser.Function{
+			Name: "base64_decode",
+		},
+		Args: []value.Primary{
+			value.NewString("Rm9v"),
+		},
+		Result: value.NewString("Foo"),
+	},
+}
+
+func TestBase64Decode(t *testing.T) {
+	testFunction(t, Base64Decode, base64DecodeTests)
+}
+
+var hexEncodeTests = []functionTest{
+	{
+		Name: "HexEncode",
+		Function: parser.Function{
+			Name: "hex_encode",
+		},
+		Args: []value.Primary{
+			value.NewString("Foo"),
+		},
+		Result: value.NewString("466f6f"),
+	},
+}
+
+func TestHexEncode(t *testing.T) {
+	testFunction(t, HexEncode, hexEncodeTests)
+}
+
+var hexDecodeTests = []functionTest{
+	{
+		Name: "HexDecode",
+		Function: parser.Function{
+			Name: "hex_decode",
+		},
+		Args: []value.Primary{
+			value.NewString("466f6f"),
+		},
+		Result: value.NewString("Foo"),
+	},
+}
+
+func TestHexDecode(t *testing.T) {
+	testFunction(t, HexDecode, hexDecodeTests)
+}
+
+var urlEncodeTests = []functionTest{
+	{
+		Name: "UrlEncode",
+		Function: parser.Function{
+			Name: "url_encode",
+		},
+		Args: []value.Primary{
+			value.NewString("a b&c=d/é"),
+		},
+		Result: value.NewString("a+b%26c%3Dd%2F%C3%A9"),
+	},
+	{
+		Name: "UrlEncode Null",
+		Function: parser.Function{
+			Name: "url_encode",
+		},
+		Args: []value.Primary{
+			value.NewNull(),
+		},
+		Result: value.NewNull(),
+	},
+}
+
+func TestUrlEncode(t *testing.T) {
+	testFunction(t, UrlEncode, urlEncodeTests)
+}
+
+var urlDecodeTests = []functionTest{
+	{
+		Name: "UrlDecode",
+		Function: parser.Function{
+			Name: "url_decode",
+		},
+		Args: []value.Primary{
+			value.NewString("a+b%26c%3Dd%2F%C3%A9"),
+		},
+		Result: value.NewString("a b&c=d/é"),
+	},
+	{
+		Name: "UrlDecode Invalid Escape",
+		Function: parser.Function{
+			Name: "url_decode",
+		},
+		Args: []value.Primary{
+			value.NewString("%zz"),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "UrlDecode Null",
+		Function: parser.Function{
+			Name: "url_decode",
+		},
+		Args: []value.Primary{
+			value.NewNull(),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "UrlDecode Arguments Error",
+		Function: parser.Function{
+			Name: "url_decode",
+		},
+		Args:  []value.Primary{},
+		Error: "function url_decode takes exactly 1 argument",
+	},
+}
+
+func TestUrlDecode(t *testing.T) {
+	testFunction(t, UrlDecode, urlDecodeTests)
+}
+
+var urlExtractHostTests = []functionTest{
+	{
+		Name: "UrlExtractHost",
+		Function: parser.Function{
+			Name: "url_extract_host",
+		},
+		Args: []value.Primary{
+			value.NewString("https://www.example.com:8080/path/to?q=1"),
+		},
+		Result: value.NewString("www.example.com"),
+	},
+	{
+		Name: "UrlExtractHost Without Host",
+		Function: parser.Function{
+			Name: "url_extract_host",
+		},
+		Args: []value.Primary{
+			value.NewString("/path/to?q=1"),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "UrlExtractHost Invalid URL",
+		Function: parser.Function{
+			Name: "url_extract_host",
+		},
+		Args: []value.Primary{
+			value.NewString("http://[::1"),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "UrlExtractHost Null",
+		Function: parser.Function{
+			Name: "url_extract_host",
+		},
+		Args: []value.Primary{
+			value.NewNull(),
+		},
+		Result: value.NewNull(),
+	},
+}
+
+func TestUrlExtractHost(t *testing.T) {
+	testFunction(t, UrlExtractHost, urlExtractHostTests)
+}
+
+var urlExtractPathTests = []functionTest{
+	{
+		Name: "UrlExtractPath",
+		Function: parser.Function{
+			Name: "url_extract_path",
+		},
+		Args: []value.Primary{
+			value.NewString("https://www.example.com/path/to%20file?q=1"),
+		},
+		Result: value.NewString("/path/to file"),
+	},
+	{
+		Name: "UrlExtractPath Empty Path",
+		Function: parser.Function{
+			Name: "url_extract_path",
+		},
+		Args: []value.Primary{
+			value.NewString("https://www.example.com"),
+		},
+		Result: value.NewString(""),
+	},
+	{
+		Name: "UrlExtractPath Null",
+		Function: parser.Function{
+			Name: "url_extract_path",
+		},
+		Args: []value.Primary{
+			value.NewNull(),
+		},
+		Result: value.NewNull(),
+	},
+}
+
+func TestUrlExtractPath(t *testing.T) {
+	testFunction(t, UrlExtractPath, urlExtractPathTests)
+}
+
+var urlExtractQueryParamTests = []functionTest{
+	{
+		Name: "UrlExtractQueryParam",
+		Function: parser.Function{
+			Name: "url_extract_query_param",
+		},
+		Args: []value.Primary{
+			value.NewString("https://www.example.com/search?q=csv+query&lang=en&lang=ja"),
+			value.NewString("q"),
+		},
+		Result: value.NewString("csv query"),
+	},
+	{
+		Name: "UrlExtractQueryParam Multiple Values",
+		Function: parser.Function{
+			Name: "url_extract_query_param",
+		},
+		Args: []value.Primary{
+			value.NewString("https://www.example.com/search?q=csv+query&lang=en&lang=ja"),
+			value.NewString("lang"),
+		},
+		Result: value.NewString("en"),
+	},
+	{
+		Name: "UrlExtractQueryParam Not Exist",
+		Function: parser.Function{
+			Name: "url_extract_query_param",
+		},
+		Args: []value.Primary{
+			value.NewString("https://www.example.com/search?q=csv+query"),
+			value.NewString("page"),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "UrlExtractQueryParam Null",
+		Function: parser.Function{
+			Name: "url_extract_query_param",
+		},
+		Args: []value.Primary{
+			value.NewNull(),
+			value.NewString("q"),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "UrlExtractQueryParam Name Error",
+		Function: parser.Function{
+			Name: "url_extract_query_param",
+		},
+		Args: []value.Primary{
+			value.NewString("https://www.example.com/search?q=1"),
+			value.NewNull(),
+		},
+		Error: "the second argument must be a string for function url_extract_query_param",
+	},
+	{
+		Name: "UrlExtractQueryParam Arguments Error",
+		Function: parser.Function{
+			Name: "url_extract_query_param",
+		},
+		Args: []value.Primary{
+			value.NewString("https://www.example.com/search?q=1"),
+		},
+		Error: "function url_extract_query_param takes exactly 2 arguments",
+	},
+}
+
+func TestUrlExtractQueryParam(t *testing.T) {
+	testFunction(t, UrlExtractQueryParam, urlExtractQueryParamTests)
+}
+
+var lenTests = []functionTest{
+	{
+		Name: "Len",
+		Function: parser.Function{
+			Name: "len",
+		},
+		Args: []value.Primary{
+			value.NewString("日本語"),
+		},
+		Result: value.NewInteger(3),
+	},
+	{
+		Name: "Len Null",
+		Function: parser.Function{
+			Name: "len",
+		},
+		Args: []value.Primary{
+			value.NewNull(),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "Len Arguments Error",
+		Function: parser.Function{
+			Name: "len",
+		},
+		Args:  []value.Primary{},
+		Error: "function len takes exactly 1 argument",
+	},
+}
+
 func TestLen(t *testing.T) {
 	testFunction(t, Len, lenTests)
 }
 
-var byteLenTests = []functionTest{
+var byteLenTests = []functionTest{
+	{
+		Name: "ByteLen",
+		Function: parser.Function{
+			Name: "byte_len",
+		},
+		Args: []value.Primary{
+			value.NewString("abc日本語"),
+		},
+		Result: value.NewInteger(12),
+	},
+	{
+		Name: "ByteLen SJIS",
+		Function: parser.Function{
+			Name: "byte_len",
+		},
+		Args: []value.Primary{
+			value.NewString("abc日本語"),
+			value.NewString("sjis"),
+		},
+		Result: value.NewInteger(9),
+	},
+	{
+		Name: "ByteLen Null",
+		Function: parser.Function{
+			Name: "byte_len",
+		},
+		Args: []value.Primary{
+			value.NewNull(),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "ByteLen Arguments Error",
+		Function: parser.Function{
+			Name: "byte_len",
+		},
+		Args:  []value.Primary{},
+		Error: "function byte_len takes 1 or 2 arguments",
+	},
+	{
+		Name: "ByteLen Invalid Encoding Error",
+		Function: parser.Function{
+			Name: "byte_len",
+		},
+		Args: []value.Primary{
+			value.NewString("abc日本語"),
+			value.NewString("invalid"),
+		},
+		Error: "encoding must be one of UTF8|UTF8M|SJIS for function byte_len",
+	},
+}
+
+func TestByteLen(t *testing.T) {
+	testFunction(t, ByteLen, byteLenTests)
+}
+
+var widthTests = []functionTest{
+	{
+		Name: "Width",
+		Function: parser.Function{
+			Name: "width",
+		},
+		Args: []value.Primary{
+			value.NewString("abc日本語"),
+		},
+		Result: value.NewInteger(9),
+	},
+	{
+		Name: "Width Arguments Error",
+		Function: parser.Function{
+			Name: "width",
+		},
+		Args:  []value.Primary{},
+		Error: "function width takes exactly 1 argument",
+	},
+}
+
+func TestWidth(t *testing.T) {
+	testFunction(t, Width, widthTests)
+}
+
+var lpadTests = []functionTest{
+	{
+		Name: "Lpad",
+		Function: parser.Function{
+			Name: "lpad",
+		},
+		Args: []value.Primary{
+			value.NewString("aaaaa"),
+			value.NewInteger(10),
+			value.NewString("01"),
+		},
+		Result: value.NewString("01010aaaaa"),
+	},
+	{
+		Name: "Lpad by Byte Length",
+		Function: parser.Function{
+			Name: "lpad",
+		},
+		Args: []value.Primary{
+			value.NewString("日本語"),
+			value.NewInteger(12),
+			value.NewString("空白"),
+			value.NewString("byte"),
+			value.NewString("sjis"),
+		},
+		Result: value.NewString("空白空日本語"),
+	},
+	{
+		Name: "Lpad by Byte Length Error",
+		Function: parser.Function{
+			Name: "lpad",
+		},
+		Args: []value.Primary{
+			value.NewString("日本語"),
+			value.NewInteger(11),
+			value.NewString("空白"),
+			value.NewString("byte"),
+			value.NewString("sjis"),
+		},
+		Error: "cannot split pad string in a byte array of a character for function lpad",
+	},
+	{
+		Name: "Lpad PadType Error",
+		Function: parser.Function{
+			Name: "lpad",
+		},
+		Args: []value.Primary{
+			value.NewString("日本語"),
+			value.NewInteger(11),
+			value.NewString("空白"),
+			value.NewString("invalid"),
+		},
+		Error: "padding type must be one of LEN|BYTE|WIDTH for function lpad",
+	},
+	{
+		Name: "Lpad Encoding Error",
+		Function: parser.Function{
+			Name: "lpad",
+		},
+		Args: []value.Primary{
+			value.NewString("日本語"),
+			value.NewInteger(11),
+			value.NewString("空白"),
+			value.NewString("byte"),
+			value.NewString("invalid"),
+		},
+		Error: "encoding must be one of UTF8|UTF8M|SJIS for function lpad",
+	},
+	{
+		Name: "Lpad by Width",
+		Function: parser.Function{
+			Name: "lpad",
+		},
+		Args: []value.Primary{
+			value.NewString("日本語"),
+			value.NewInteger(12),
+			value.NewString("空白"),
+			value.NewString("width"),
+		},
+		Result: value.NewString("空白空日本語"),
+	},
+	{
+		Name: "Lpad by Width Length Error",
+		Function: parser.Function{
+			Name: "lpad",
+		},
+		Args: []value.Primary{
+			value.NewString("日本語"),
+			value.NewInteger(11),
+			value.NewString("空白"),
+			value.NewString("width"),
+		},
+		Error: "cannot split pad string in a byte array of a character for function lpad",
+	},
+	{
+		Name: "Lpad No Padding",
+		Function: parser.Function{
+			Name: "lpad",
+		},
+		Args: []value.Primary{
+			value.NewString("aaaaa"),
+			value.NewInteger(5),
+			value.NewString("01"),
+		},
+		Result: value.NewString("aaaaa"),
+	},
+	{
+		Name: "Lpad String is Null",
+		Function: parser.Function{
+			Name: "lpad",
+		},
+		Args: []value.Primary{
+			value.NewNull(),
+			value.NewInteger(10),
+			value.NewString("01"),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "Lpad Length is Null",
+		Function: parser.Function{
+			Name: "lpad",
+		},
+		Args: []value.Primary{
+			value.NewString("aaaaa"),
+			value.NewNull(),
+			value.NewString("01"),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "Lpad Pad String is Null",
+		Function: parser.Function{
+			Name: "lpad",
+		},
+		Args: []value.Primary{
+			value.NewString("aaaaa"),
+			value.NewInteger(10),
+			value.NewNull(),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "Lpad Arguments Error",
+		Function: parser.Function{
+			Name: "lpad",
+		},
+		Args:  []value.Primary{},
+		Error: "function lpad takes 3 to 5 arguments",
+	},
+}
+
+func TestLpad(t *testing.T) {
+	testFunction(t, Lpad, lpadTests)
+}
+
+var rpadTests = []functionTest{
+	{
+		Name: "Rpad",
+		Function: parser.Function{
+			Name: "rpad",
+		},
+		Args: []value.Primary{
+			value.NewString("aaaaa"),
+			value.NewInteger(10),
+			value.NewString("01"),
+		},
+		Result: value.NewString("aaaaa01010"),
+	},
+}
+
+func TestRpad(t *testing.T) {
+	testFunction(t, Rpad, rpadTests)
+}
+
+var substrTests = []functionTest{
+	{
+		Name: "Substr",
+		Function: parser.Function{
+			Name: "substr",
+		},
+		Args: []value.Primary{
+			value.NewString("abcdefghijklmn"),
+			value.NewInteger(-5),
+			value.NewInteger(8),
+		},
+		Result: value.NewString("jklmn"),
+	},
+	{
+		Name: "Substr String is Null",
+		Function: parser.Function{
+			Name: "substr",
+		},
+		Args: []value.Primary{
+			value.NewNull(),
+			value.NewInteger(-5),
+			value.NewInteger(8),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "Substr StartIndex is Null",
+		Function: parser.Function{
+			Name: "substr",
+		},
+		Args: []value.Primary{
+			value.NewString("abcdefghijklmn"),
+			value.NewNull(),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "Substr Length is Null",
+		Function: parser.Function{
+			Name: "substr",
+		},
+		Args: []value.Primary{
+			value.NewString("abcdefghijklmn"),
+			value.NewInteger(-5),
+			value.NewNull(),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "Substr Length is Negative",
+		Function: parser.Function{
+			Name: "substr",
+		},
+		Args: []value.Primary{
+			value.NewString("abcdefghijklmn"),
+			value.NewInteger(-5),
+			value.NewInteger(-1),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "Substr StartIndex is Out Of Index",
+		Function: parser.Function{
+			Name: "substr",
+		},
+		Args: []value.Primary{
+			value.NewString("abcdefghijklmn"),
+			value.NewInteger(100),
+			value.NewInteger(8),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "Substr Arguments Error",
+		Function: parser.Function{
+			Name: "substr",
+		},
+		Args:  []value.Primary{},
+		Error: "function substr takes 2 or 3 arguments",
+	},
+}
+
+func TestSubstr(t *testing.T) {
+	testFunction(t, Substr, substrTests)
+}
+
+var instrTests = []functionTest{
+	{
+		Name: "Instr",
+		Function: parser.Function{
+			Name: "instr",
+		},
+		Args: []value.Primary{
+			value.NewString("abcdefghijklmn"),
+			value.NewString("def"),
+		},
+		Result: value.NewInteger(3),
+	},
+	{
+		Name: "Instr String is Null",
+		Function: parser.Function{
+			Name: "instr",
+		},
+		Args: []value.Primary{
+			value.NewNull(),
+			value.NewString("def"),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "Instr Substring is Null",
+		Function: parser.Function{
+			Name: "instr",
+		},
+		Args: []value.Primary{
+			value.NewString("abcdefghijklmn"),
+			value.NewNull(),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "Instr Substring does not exist",
+		Function: parser.Function{
+			Name: "instr",
+		},
+		Args: []value.Primary{
+			value.NewString("abcdefghijklmn"),
+			value.NewString("zzz"),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "Instr Arguments Error",
+		Function: parser.Function{
+			Name: "instr",
+		},
+		Args:  []value.Primary{},
+		Error: "function instr takes exactly 2 arguments",
+	},
+}
+
+func TestInstr(t *testing.T) {
+	testFunction(t, Instr, instrTests)
+}
+
+var listElemTests = []functionTest{
+	{
+		Name: "ListElem",
+		Function: parser.Function{
+			Name: "list_elem",
+		},
+		Args: []value.Primary{
+			value.NewString("abc def ghi"),
+			value.NewString(" "),
+			value.NewInteger(1),
+		},
+		Result: value.NewString("def"),
+	},
+	{
+		Name: "ListElem String is Null",
+		Function: parser.Function{
+			Name: "list_elem",
+		},
+		Args: []value.Primary{
+			value.NewNull(),
+			value.NewString(" "),
+			value.NewInteger(1),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "ListElem Separator is Null",
+		Function: parser.Function{
+			Name: "list_elem",
+		},
+		Args: []value.Primary{
+			value.NewString("abc def ghi"),
+			value.NewNull(),
+			value.NewInteger(1),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "ListElem Index is Null",
+		Function: parser.Function{
+			Name: "list_elem",
+		},
+		Args: []value.Primary{
+			value.NewString("abc def ghi"),
+			value.NewString(" "),
+			value.NewNull(),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "ListElem Index is negative value",
+		Function: parser.Function{
+			Name: "list_elem",
+		},
+		Args: []value.Primary{
+			value.NewString("abc def ghi"),
+			value.NewString(" "),
+			value.NewInteger(-1),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "ListElem Index does not exist",
+		Function: parser.Function{
+			Name: "list_elem",
+		},
+		Args: []value.Primary{
+			value.NewString("abc def ghi"),
+			value.NewString(" "),
+			value.NewInteger(100),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "ListElem Arguments Error",
+		Function: parser.Function{
+			Name: "list_elem",
+		},
+		Args:  []value.Primary{},
+		Error: "function list_elem takes exactly 3 arguments",
+	},
+}
+
+func TestListElem(t *testing.T) {
+	testFunction(t, ListElem, listElemTests)
+}
+
+var splitPartTests = []functionTest{
+	{
+		Name: "SplitPart",
+		Function: parser.Function{
+			Name: "split_part",
+		},
+		Args: []value.Primary{
+			value.NewString("abc,def,,ghi"),
+			value.NewString(","),
+			value.NewInteger(2),
+		},
+		Result: value.NewString("def"),
+	},
+	{
+		Name: "SplitPart Empty Field",
+		Function: parser.Function{
+			Name: "split_part",
+		},
+		Args: []value.Primary{
+			value.NewString("abc,def,,ghi"),
+			value.NewString(","),
+			value.NewInteger(3),
+		},
+		Result: value.NewString(""),
+	},
+	{
+		Name: "SplitPart Negative Position",
+		Function: parser.Function{
+			Name: "split_part",
+		},
+		Args: []value.Primary{
+			value.NewString("abc,def,,ghi"),
+			value.NewString(","),
+			value.NewInteger(-1),
+		},
+		Result: value.NewString("ghi"),
+	},
+	{
+		Name: "SplitPart Position Out of Range",
+		Function: parser.Function{
+			Name: "split_part",
+		},
+		Args: []value.Primary{
+			value.NewString("abc,def,,ghi"),
+			value.NewString(","),
+			value.NewInteger(5),
+		},
+		Result: value.NewString(""),
+	},
+	{
+		Name: "SplitPart Empty Separator",
+		Function: parser.Function{
+			Name: "split_part",
+		},
+		Args: []value.Primary{
+			value.NewString("abc,def"),
+			value.NewString(""),
+			value.NewInteger(1),
+		},
+		Result: value.NewString("abc,def"),
+	},
+	{
+		Name: "SplitPart String is Null",
+		Function: parser.Function{
+			Name: "split_part",
+		},
+		Args: []value.Primary{
+			value.NewNull(),
+			value.NewString(","),
+			value.NewInteger(1),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "SplitPart Separator is Null",
+		Function: parser.Function{
+			Name: "split_part",
+		},
+		Args: []value.Primary{
+			value.NewString("abc,def"),
+			value.NewNull(),
+			value.NewInteger(1),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "SplitPart Position is Null",
+		Function: parser.Function{
+			Name: "split_part",
+		},
+		Args: []value.Primary{
+			value.NewString("abc,def"),
+			value.NewString(","),
+			value.NewNull(),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "SplitPart Position is Zero Error",
+		Function: parser.Function{
+			Name: "split_part",
+		},
+		Args: []value.Primary{
+			value.NewString("abc,def"),
+			value.NewString(","),
+			value.NewInteger(0),
+		},
+		Error: "the third argument must not be zero for function split_part",
+	},
+	{
+		Name: "SplitPart Arguments Error",
+		Function: parser.Function{
+			Name: "split_part",
+		},
+		Args: []value.Primary{
+			value.NewString("abc,def"),
+			value.NewString(","),
+		},
+		Error: "function split_part takes exactly 3 arguments",
+	},
+}
+
+func TestSplitPart(t *testing.T) {
+	testFunction(t, SplitPart, splitPartTests)
+}
+
+var strtokTests = []functionTest{
+	{
+		Name: "Strtok",
+		Function: parser.Function{
+			Name: "strtok",
+		},
+		Args: []value.Primary{
+			value.NewString("abc  def,,ghi"),
+			value.NewString(" ,"),
+			value.NewInteger(3),
+		},
+		Result: value.NewString("ghi"),
+	},
+	{
+		Name: "Strtok Default Arguments",
+		Function: parser.Function{
+			Name: "strtok",
+		},
+		Args: []value.Primary{
+			value.NewString(" abc def"),
+		},
+		Result: value.NewString("abc"),
+	},
+	{
+		Name: "Strtok Position Out of Range",
+		Function: parser.Function{
+			Name: "strtok",
+		},
+		Args: []value.Primary{
+			value.NewString("abc def"),
+			value.NewString(" "),
+			value.NewInteger(3),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "Strtok Empty String",
+		Function: parser.Function{
+			Name: "strtok",
+		},
+		Args: []value.Primary{
+			value.NewString(""),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "Strtok String is Null",
+		Function: parser.Function{
+			Name: "strtok",
+		},
+		Args: []value.Primary{
+			value.NewNull(),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "Strtok Delimiters is Null",
+		Function: parser.Function{
+			Name: "strtok",
+		},
+		Args: []value.Primary{
+			value.NewString("abc def"),
+			value.NewNull(),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "Strtok Position is Null",
+		Function: parser.Function{
+			Name: "strtok",
+		},
+		Args: []value.Primary{
+			value.NewString("abc def"),
+			value.NewString(" "),
+			value.NewNull(),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "Strtok Arguments Error",
+		Function: parser.Function{
+			Name: "strtok",
+		},
+		Args:  []value.Primary{},
+		Error: "function strtok takes 1 to 3 arguments",
+	},
+}
+
+func TestStrtok(t *testing.T) {
+	testFunction(t, Strtok, strtokTests)
+}
+
+var replaceTests = []functionTest{
+	{
+		Name: "Replace",
+		Function: parser.Function{
+			Name: "replace",
+		},
+		Args: []value.Primary{
+			value.NewString("abcdefg abcdefg"),
+			value.NewString("cd"),
+			value.NewString("CD"),
+		},
+		Result: value.NewString("abCDefg abCDefg"),
+	},
+	{
+		Name: "Replace String is Null",
+		Function: parser.Function{
+			Name: "replace",
+		},
+		Args: []value.Primary{
+			value.NewNull(),
+			value.NewString("cd"),
+			value.NewString("CD"),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "Replace Old String is Null",
+		Function: parser.Function{
+			Name: "replace",
+		},
+		Args: []value.Primary{
+			value.NewString("abcdefg abcdefg"),
+			value.NewNull(),
+			value.NewString("CD"),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "Replace New String is Null",
+		Function: parser.Function{
+			Name: "replace",
+		},
+		Args: []value.Primary{
+			value.NewString("abcdefg abcdefg"),
+			value.NewString("cd"),
+			value.NewNull(),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "Replace Arguments Error",
+		Function: parser.Function{
+			Name: "replace",
+		},
+		Args:  []value.Primary{},
+		Error: "function replace takes exactly 3 arguments",
+	},
+}
+
+func TestReplace(t *testing.T) {
+	testFunction(t, Replace, replaceTests)
+}
+
+var formatTests = []functionTest{
+	{
+		Name: "Format",
+		Function: parser.Function{
+			Name: "format",
+		},
+		Args: []value.Primary{
+			value.NewString("string = %q, integer = %q"),
+			value.NewString("str"),
+			value.NewInteger(1),
+		},
+		Result: value.NewString("string = \"str\", integer = \"1\""),
+	},
+	{
+		Name: "Format Argument Length Error",
+		Function: parser.Function{
+			Name: "format",
+		},
+		Args:  []value.Primary{},
+		Error: "function format takes at least 1 argument",
+	},
+	{
+		Name: "Format Argument Value Error",
+		Function: parser.Function{
+			Name: "format",
+		},
+		Args: []value.Primary{
+			value.NewNull(),
+			value.NewString("str"),
+			value.NewInteger(1),
+		},
+		Error: "the first argument must be a string for function format",
+	},
+	{
+		Name: "Format Replace Holder Length Not Match Error",
+		Function: parser.Function{
+			Name: "format",
+		},
+		Args: []value.Primary{
+			value.NewString("string = %s, integer = %s"),
+			value.NewString("str"),
+		},
+		Error: "number of replace values does not match for function format",
+	},
+}
+
+func TestFormat(t *testing.T) {
+	testFunction(t, Format, formatTests)
+}
+
+var jsonValueTests = []functionTest{
+	{
+		Name: "JsonValue",
+		Function: parser.Function{
+			Name: "json_value",
+		},
+		Args: []value.Primary{
+			value.NewString("key1.key2"),
+			value.NewString("{\"key1\":{\"key2\":\"value\"}}"),
+		},
+		Result: value.NewString("value"),
+	},
+	{
+		Name: "JsonValue from Json Value",
+		Function: parser.Function{
+			Name: "json_value",
+		},
+		Args: []value.Primary{
+			value.NewString("key1"),
+			value.NewJson(txjson.Object{Members: []txjson.ObjectMember{{Key: "key1", Value: txjson.Object{Members: []txjson.ObjectMember{{Key: "key2", Value: txjson.String("value")}}}}}}),
+		},
+		Result: value.NewJson(txjson.Object{Members: []txjson.ObjectMember{{Key: "key2", Value: txjson.String("value")}}}),
+	},
+	{
+		Name: "JsonValue Query is Null",
+		Function: parser.Function{
+			Name: "json_value",
+		},
+		Args: []value.Primary{
+			value.NewNull(),
+			value.NewString("{\"key1\":{\"key2\":\"value\"}}"),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "JsonValue Json-Text is Null",
+		Function: parser.Function{
+			Name: "json_value",
+		},
+		Args: []value.Primary{
+			value.NewString("key1.key2"),
+			value.NewNull(),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "JsonValue Arguments Error",
+		Function: parser.Function{
+			Name: "json_value",
+		},
+		Args: []value.Primary{
+			value.NewString("key1.key2"),
+		},
+		Error: "function json_value takes exactly 2 arguments",
+	},
+	{
+		Name: "JsonValue Json Loading Error",
+		Function: parser.Function{
+			Name: "json_value",
+		},
+		Args: []value.Primary{
+			value.NewString("key1.key2"),
+			value.NewString("{key1:{\"key2\":\"value\"}}"),
+		},
+		Error: "line 1, column 2: unexpected token \"key\" for function json_value",
+	},
+}
+
+func TestJsonValue(t *testing.T) {
+	testFunction(t, JsonValue, jsonValueTests)
+}
+
+var xpathStringTests = []functionTest{
+	{
+		Name: "XpathString",
+		Function: parser.Function{
+			Name: "xpath_string",
+		},
+		Args: []value.Primary{
+			value.NewString("/book/author[2]"),
+			value.NewString("<book><author>Jane</author><author>John</author></book>"),
+		},
+		Result: value.NewString("John"),
+	},
+	{
+		Name: "XpathString Node Does Not Exist",
+		Function: parser.Function{
+			Name: "xpath_string",
+		},
+		Args: []value.Primary{
+			value.NewString("/book/title"),
+			value.NewString("<book><author>Jane</author></book>"),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "XpathString Xml is Null",
+		Function: parser.Function{
+			Name: "xpath_string",
+		},
+		Args: []value.Primary{
+			value.NewString("/book/title"),
+			value.NewNull(),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "XpathString Arguments Error",
+		Function: parser.Function{
+			Name: "xpath_string",
+		},
+		Args: []value.Primary{
+			value.NewString("/book/title"),
+		},
+		Error: "function xpath_string takes exactly 2 arguments",
+	},
+	{
+		Name: "XpathString Xpath Error",
+		Function: parser.Function{
+			Name: "xpath_string",
+		},
+		Args: []value.Primary{
+			value.NewString("/book/title["),
+			value.NewString("<book/>"),
+		},
+		Error: "unexpected end of xpath for function xpath_string",
+	},
+}
+
+func TestXpathString(t *testing.T) {
+	testFunction(t, XpathString, xpathStringTests)
+}
+
+var xpathNumberTests = []functionTest{
+	{
+		Name: "XpathNumber",
+		Function: parser.Function{
+			Name: "xpath_number",
+		},
+		Args: []value.Primary{
+			value.NewString("//item[@sku='A']/@price"),
+			value.NewString("<order><item sku=\"A\" price=\" 1.25 \"/><item sku=\"B\" price=\"3\"/></order>"),
+		},
+		Result: value.NewFloat(1.25),
+	},
+	{
+		Name: "XpathNumber Not a Number",
+		Function: parser.Function{
+			Name: "xpath_number",
+		},
+		Args: []value.Primary{
+			value.NewString("//item/@sku"),
+			value.NewString("<order><item sku=\"A\" price=\"1.25\"/></order>"),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "XpathNumber Xml Error",
+		Function: parser.Function{
+			Name: "xpath_number",
+		},
+		Args: []value.Primary{
+			value.NewString("//item/@price"),
+			value.NewString("<order><item></order>"),
+		},
+		Error: "XML syntax error on line 1: element <item> closed by </order> for function xpath_number",
+	},
+}
+
+func TestXpathNumber(t *testing.T) {
+	testFunction(t, XpathNumber, xpathNumberTests)
+}
+
+var xpathExistsTests = []functionTest{
+	{
+		Name: "XpathExists",
+		Function: parser.Function{
+			Name: "xpath_exists",
+		},
+		Args: []value.Primary{
+			value.NewString("//item[@sku='B']"),
+			value.NewString("<order><item sku=\"A\"/><item sku=\"B\"/></order>"),
+		},
+		Result: value.NewBoolean(true),
+	},
+	{
+		Name: "XpathExists Node Does Not Exist",
+		Function: parser.Function{
+			Name: "xpath_exists",
+		},
+		Args: []value.Primary{
+			value.NewString("//item[@sku='C']"),
+			value.NewString("<order><item sku=\"A\"/><item sku=\"B\"/></order>"),
+		},
+		Result: value.NewBoolean(false),
+	},
+	{
+		Name: "XpathExists Xpath is Null",
+		Function: parser.Function{
+			Name: "xpath_exists",
+		},
+		Args: []value.Primary{
+			value.NewNull(),
+			value.NewString("<order/>"),
+		},
+		Result: value.NewNull(),
+	},
+}
+
+func TestXpathExists(t *testing.T) {
+	testFunction(t, XpathExists, xpathExistsTests)
+}
+
+func TestUuid(t *testing.T) {
+	fn := parser.Function{Name: "uuid"}
+
+	_, err := Uuid(fn, []value.Primary{value.NewInteger(1)}, TestTx.Flags)
+	if err == nil {
+		t.Error("Uuid: no error, want error")
+	} else if err.Error() != "function uuid takes no argument" {
+		t.Errorf("Uuid: error %q, want error %q", err.Error(), "function uuid takes no argument")
+	}
+
+	re := regexp.MustCompile("^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$")
+
+	p1, err := Uuid(fn, []value.Primary{}, TestTx.Flags)
+	if err != nil {
+		t.Fatalf("Uuid: unexpected error %q", err)
+	}
+	p2, _ := Uuid(fn, []value.Primary{}, TestTx.Flags)
+
+	s1 := p1.(value.String).Raw()
+	if !re.MatchString(s1) {
+		t.Errorf("Uuid: result = %q, want a version 4 uuid", s1)
+	}
+	if s1 == p2.(value.String).Raw() {
+		t.Errorf("Uuid: generated the same value %q twice", s1)
+	}
+}
+
+func TestUuidV7(t *testing.T) {
+	fn := parser.Function{Name: "uuid_v7"}
+
+	_, err := UuidV7(fn, []value.Primary{value.NewInteger(1)}, TestTx.Flags)
+	if err == nil {
+		t.Error("UuidV7: no error, want error")
+	} else if err.Error() != "function uuid_v7 takes no argument" {
+		t.Errorf("UuidV7: error %q, want error %q", err.Error(), "function uuid_v7 takes no argument")
+	}
+
+	re := regexp.MustCompile("^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$")
+
+	p, err := UuidV7(fn, []value.Primary{}, TestTx.Flags)
+	if err != nil {
+		t.Fatalf("UuidV7: unexpected error %q", err)
+	}
+
+	s := p.(value.String).Raw()
+	if !re.MatchString(s) {
+		t.Errorf("UuidV7: result = %q, want a version 7 uuid", s)
+	}
+
+	ts := fmt.Sprintf("%012x", NowForTest.UnixNano()/int64(time.Millisecond))
+	if prefix := s[0:8] + s[9:13]; prefix != ts {
+		t.Errorf("UuidV7: timestamp = %q, want %q", prefix, ts)
+	}
+}
+
+var arrayLengthTests = []functionTest{
+	{
+		Name: "ArrayLength",
+		Function: parser.Function{
+			Name: "array_length",
+		},
+		Args: []value.Primary{
+			value.NewArray([]value.Primary{value.NewInteger(1), value.NewInteger(2)}),
+		},
+		Result: value.NewInteger(2),
+	},
+	{
+		Name: "ArrayLength Argument Is Not An Array",
+		Function: parser.Function{
+			Name: "array_length",
+		},
+		Args: []value.Primary{
+			value.NewString("[1, 2]"),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "ArrayLength Arguments Error",
+		Function: parser.Function{
+			Name: "array_length",
+		},
+		Args:  []value.Primary{},
+		Error: "function array_length takes exactly 1 argument",
+	},
+}
+
+func TestArrayLength(t *testing.T) {
+	testFunction(t, ArrayLength, arrayLengthTests)
+}
+
+var arrayContainsTests = []functionTest{
+	{
+		Name: "ArrayContains",
+		Function: parser.Function{
+			Name: "array_contains",
+		},
+		Args: []value.Primary{
+			value.NewArray([]value.Primary{value.NewString("a"), value.NewString("2")}),
+			value.NewInteger(2),
+		},
+		Result: value.NewTernary(ternary.TRUE),
+	},
+	{
+		Name: "ArrayContains Not Found",
+		Function: parser.Function{
+			Name: "array_contains",
+		},
+		Args: []value.Primary{
+			value.NewArray([]value.Primary{value.NewString("a"), value.NewString("b")}),
+			value.NewString("c"),
+		},
+		Result: value.NewTernary(ternary.FALSE),
+	},
+	{
+		Name: "ArrayContains Including Null",
+		Function: parser.Function{
+			Name: "array_contains",
+		},
+		Args: []value.Primary{
+			value.NewArray([]value.Primary{value.NewString("a"), value.NewNull()}),
+			value.NewString("c"),
+		},
+		Result: value.NewTernary(ternary.UNKNOWN),
+	},
+	{
+		Name: "ArrayContains Arguments Error",
+		Function: parser.Function{
+			Name: "array_contains",
+		},
+		Args: []value.Primary{
+			value.NewArray([]value.Primary{value.NewString("a")}),
+		},
+		Error: "function array_contains takes exactly 2 arguments",
+	},
+}
+
+func TestArrayContains(t *testing.T) {
+	testFunction(t, ArrayContains, arrayContainsTests)
+}
+
+var stringToArrayTests = []functionTest{
+	{
+		Name: "StringToArray",
+		Function: parser.Function{
+			Name: "string_to_array",
+		},
+		Args: []value.Primary{
+			value.NewString("a;b;c"),
+			value.NewString(";"),
+		},
+		Result: value.NewArray([]value.Primary{value.NewString("a"), value.NewString("b"), value.NewString("c")}),
+	},
+	{
+		Name: "StringToArray Empty String",
+		Function: parser.Function{
+			Name: "string_to_array",
+		},
+		Args: []value.Primary{
+			value.NewString(""),
+			value.NewString(";"),
+		},
+		Result: value.NewArray([]value.Primary{}),
+	},
+	{
+		Name: "StringToArray String Is Null",
+		Function: parser.Function{
+			Name: "string_to_array",
+		},
+		Args: []value.Primary{
+			value.NewNull(),
+			value.NewString(";"),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "StringToArray Arguments Error",
+		Function: parser.Function{
+			Name: "string_to_array",
+		},
+		Args: []value.Primary{
+			value.NewString("a;b;c"),
+		},
+		Error: "function string_to_array takes exactly 2 arguments",
+	},
+}
+
+func TestStringToArray(t *testing.T) {
+	testFunction(t, StringToArray, stringToArrayTests)
+}
+
+var arrayToStringTests = []functionTest{
+	{
+		Name: "ArrayToString",
+		Function: parser.Function{
+			Name: "array_to_string",
+		},
+		Args: []value.Primary{
+			value.NewArray([]value.Primary{value.NewString("a"), value.NewNull(), value.NewInteger(1), value.NewBoolean(true)}),
+			value.NewString(";"),
+		},
+		Result: value.NewString("a;1;true"),
+	},
+	{
+		Name: "ArrayToString Argument Is Not An Array",
+		Function: parser.Function{
+			Name: "array_to_string",
+		},
+		Args: []value.Primary{
+			value.NewString("a"),
+			value.NewString(";"),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "ArrayToString Arguments Error",
+		Function: parser.Function{
+			Name: "array_to_string",
+		},
+		Args: []value.Primary{
+			value.NewArray([]value.Primary{value.NewString("a")}),
+		},
+		Error: "function array_to_string takes exactly 2 arguments",
+	},
+}
+
+func TestArrayToString(t *testing.T) {
+	testFunction(t, ArrayToString, arrayToStringTests)
+}
+
+var md5Tests = []functionTest{
+	{
+		Name: "Md5",
+		Function: parser.Function{
+			Name: "md5",
+		},
+		Args: []value.Primary{
+			value.NewString("foo"),
+		},
+		Result: value.NewString("acbd18db4cc2f85cedef654fccc4a4d8"),
+	},
+	{
+		Name: "Md5 Null",
+		Function: parser.Function{
+			Name: "md5",
+		},
+		Args: []value.Primary{
+			value.NewNull(),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "Md5 Arguments Error",
+		Function: parser.Function{
+			Name: "md5",
+		},
+		Args:  []value.Primary{},
+		Error: "function md5 takes exactly 1 argument",
+	},
+}
+
+func TestMd5(t *testing.T) {
+	testFunction(t, Md5, md5Tests)
+}
+
+var sha1Tests = []functionTest{
+	{
+		Name: "Sha1",
+		Function: parser.Function{
+			Name: "sha1",
+		},
+		Args: []value.Primary{
+			value.NewString("foo"),
+		},
+		Result: value.NewString("0beec7b5ea3f0fdbc95d0dd47f3c5bc275da8a33"),
+	},
+}
+
+func TestSha1(t *testing.T) {
+	testFunction(t, Sha1, sha1Tests)
+}
+
+var sha256Tests = []functionTest{
+	{
+		Name: "Sha256",
+		Function: parser.Function{
+			Name: "sha256",
+		},
+		Args: []value.Primary{
+			value.NewString("foo"),
+		},
+		Result: value.NewString("2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"),
+	},
+}
+
+func TestSha256(t *testing.T) {
+	testFunction(t, Sha256, sha256Tests)
+}
+
+var sha512Tests = []functionTest{
+	{
+		Name: "Sha512",
+		Function: parser.Function{
+			Name: "sha512",
+		},
+		Args: []value.Primary{
+			value.NewString("foo"),
+		},
+		Result: value.NewString("f7fbba6e0636f890e56fbbf3283e524c6fa3204ae298382d624741d0dc6638326e282c41be5e4254d8820772c5518a2c5a8c0c7f7eda19594a7eb539453e1ed7"),
+	},
+}
+
+func TestSha512(t *testing.T) {
+	testFunction(t, Sha512, sha512Tests)
+}
+
+var crc32Tests = []functionTest{
+	{
+		Name: "Crc32",
+		Function: parser.Function{
+			Name: "crc32",
+		},
+		Args: []value.Primary{
+			value.NewString("foo"),
+		},
+		Result: value.NewInteger(2356372769),
+	},
+	{
+		Name: "Crc32 Null",
+		Function: parser.Function{
+			Name: "crc32",
+		},
+		Args: []value.Primary{
+			value.NewNull(),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "Crc32 Arguments Error",
+		Function: parser.Function{
+			Name: "crc32",
+		},
+		Args:  []value.Primary{},
+		Error: "function crc32 takes exactly 1 argument",
+	},
+}
+
+func TestCrc32(t *testing.T) {
+	testFunction(t, Crc32, crc32Tests)
+}
+
+var md5HmacTests = []functionTest{
+	{
+		Name: "Md5Hmac",
+		Function: parser.Function{
+			Name: "md5_hmac",
+		},
+		Args: []value.Primary{
+			value.NewString("foo"),
+			value.NewString("bar"),
+		},
+		Result: value.NewString("31b6db9e5eb4addb42f1a6ca07367adc"),
+	},
+	{
+		Name: "Md5Hmac String is Null",
+		Function: parser.Function{
+			Name: "md5_hmac",
+		},
+		Args: []value.Primary{
+			value.NewNull(),
+			value.NewString("bar"),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "Md5Hmac Key is Null",
+		Function: parser.Function{
+			Name: "md5_hmac",
+		},
+		Args: []value.Primary{
+			value.NewString("foo"),
+			value.NewNull(),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "Md5Hmac Arguments Error",
+		Function: parser.Function{
+			Name: "md5_hmac",
+		},
+		Args:  []value.Primary{},
+		Error: "function md5_hmac takes exactly 2 arguments",
+	},
+}
+
+func TestMd5Hmac(t *testing.T) {
+	testFunction(t, Md5Hmac, md5HmacTests)
+}
+
+var sha1HmacTests = []functionTest{
+	{
+		Name: "Sha1Hmac",
+		Function: parser.Function{
+			Name: "sha1_hmac",
+		},
+		Args: []value.Primary{
+			value.NewString("foo"),
+			value.NewString("bar"),
+		},
+		Result: value.NewString("85d155c55ed286a300bd1cf124de08d87e914f3a"),
+	},
+}
+
+func TestSha1Hmac(t *testing.T) {
+	testFunction(t, Sha1Hmac, sha1HmacTests)
+}
+
+var sha256HmacTests = []functionTest{
+	{
+		Name: "Sha256Hmac",
+		Function: parser.Function{
+			Name: "sha256_hmac",
+		},
+		Args: []value.Primary{
+			value.NewString("foo"),
+			value.NewString("bar"),
+		},
+		Result: value.NewString("147933218aaabc0b8b10a2b3a5c34684c8d94341bcf10a4736dc7270f7741851"),
+	},
+}
+
+func TestSha256Hmac(t *testing.T) {
+	testFunction(t, Sha256Hmac, sha256HmacTests)
+}
+
+var hmacSha256Tests = []functionTest{
+	{
+		Name: "HmacSha256",
+		Function: parser.Function{
+			Name: "hmac_sha256",
+		},
+		Args: []value.Primary{
+			value.NewString("bar"),
+			value.NewString("foo"),
+		},
+		Result: value.NewString("147933218aaabc0b8b10a2b3a5c34684c8d94341bcf10a4736dc7270f7741851"),
+	},
+	{
+		Name: "HmacSha256 Null Key",
+		Function: parser.Function{
+			Name: "hmac_sha256",
+		},
+		Args: []value.Primary{
+			value.NewNull(),
+			value.NewString("foo"),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "HmacSha256 Arguments Error",
+		Function: parser.Function{
+			Name: "hmac_sha256",
+		},
+		Args: []value.Primary{
+			value.NewString("bar"),
+		},
+		Error: "function hmac_sha256 takes exactly 2 arguments",
+	},
+}
+
+func TestHmacSha256(t *testing.T) {
+	testFunction(t, HmacSha256, hmacSha256Tests)
+
+	for _, v := range sha256HmacTests {
+		expect, _ := Sha256Hmac(v.Function, v.Args, TestTx.Flags)
+		result, err := HmacSha256(v.Function, []value.Primary{v.Args[1], v.Args[0]}, TestTx.Flags)
+		if err != nil {
+			t.Errorf("%s: unexpected error %q", v.Name, err)
+			continue
+		}
+		if !reflect.DeepEqual(result, expect) {
+			t.Errorf("%s: result = %s, want %s as SHA256_HMAC", v.Name, result, expect)
+		}
+	}
+}
+
+var sha512HmacTests = []functionTest{
+	{
+		Name: "Sha512Hmac",
+		Function: parser.Function{
+			Name: "sha512_hmac",
+		},
+		Args: []value.Primary{
+			value.NewString("foo"),
+			value.NewString("bar"),
+		},
+		Result: value.NewString("24257d7210582a65c731ec55159c8184cc24c02489453e58587f71f44c23a2d61b4b72154a89d17b2d49448a8452ea066f4fc56a2bcead45c088572ffccdb3d8"),
+	},
+}
+
+func TestSha512Hmac(t *testing.T) {
+	testFunction(t, Sha512Hmac, sha512HmacTests)
+}
+
+var secureEqualTests = []functionTest{
 	{
-		Name: "ByteLen",
+		Name: "SecureEqual",
 		Function: parser.Function{
-			Name: "byte_len",
+			Name: "secure_equal",
 		},
 		Args: []value.Primary{
-			value.NewString("abc日本語"),
+			value.NewString("abc"),
+			value.NewString("abc"),
 		},
-		Result: value.NewInteger(12),
+		Result: value.NewBoolean(true),
 	},
 	{
-		Name: "ByteLen SJIS",
+		Name: "SecureEqual Different Case",
 		Function: parser.Function{
-			Name: "byte_len",
+			Name: "secure_equal",
 		},
 		Args: []value.Primary{
-			value.NewString("abc日本語"),
-			value.NewString("sjis"),
+			value.NewString("abc"),
+			value.NewString("ABC"),
 		},
-		Result: value.NewInteger(9),
+		Result: value.NewBoolean(false),
 	},
 	{
-		Name: "ByteLen Null",
+		Name: "SecureEqual Different Length",
 		Function: parser.Function{
-			Name: "byte_len",
+			Name: "secure_equal",
+		},
+		Args: []value.Primary{
+			value.NewString("abc"),
+			value.NewString("abcd"),
+		},
+		Result: value.NewBoolean(false),
+	},
+	{
+		Name: "SecureEqual Null",
+		Function: parser.Function{
+			Name: "secure_equal",
 		},
 		Args: []value.Primary{
+			value.NewString("abc"),
 			value.NewNull(),
 		},
 		Result: value.NewNull(),
 	},
 	{
-		Name: "ByteLen Arguments Error",
+		Name: "SecureEqual Arguments Error",
 		Function: parser.Function{
-			Name: "byte_len",
+			Name: "secure_equal",
 		},
-		Args:  []value.Primary{},
-		Error: "function byte_len takes 1 or 2 arguments",
+		Args: []value.Primary{
+			value.NewString("abc"),
+		},
+		Error: "function secure_equal takes exactly 2 arguments",
 	},
+}
+
+func TestSecureEqual(t *testing.T) {
+	testFunction(t, SecureEqual, secureEqualTests)
+}
+
+var datetimeFormatTests = []functionTest{
 	{
-		Name: "ByteLen Invalid Encoding Error",
+		Name: "DatetimeFormat",
 		Function: parser.Function{
-			Name: "byte_len",
+			Name: "datetime_format",
 		},
 		Args: []value.Primary{
-			value.NewString("abc日本語"),
-			value.NewString("invalid"),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 0, GetTestLocation())),
+			value.NewString("%Y-%m-%d"),
 		},
-		Error: "encoding must be one of UTF8|UTF8M|SJIS for function byte_len",
+		Result: value.NewString("2012-02-03"),
+	},
+	{
+		Name: "DatetimeFormat Datetime is Null",
+		Function: parser.Function{
+			Name: "datetime_format",
+		},
+		Args: []value.Primary{
+			value.NewNull(),
+			value.NewString("%Y-%m-%d"),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "DatetimeFormat Format is Null",
+		Function: parser.Function{
+			Name: "datetime_format",
+		},
+		Args: []value.Primary{
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 0, GetTestLocation())),
+			value.NewNull(),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "DatetimeFormat Arguments Error",
+		Function: parser.Function{
+			Name: "datetime_format",
+		},
+		Args: []value.Primary{
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 0, GetTestLocation())),
+		},
+		Error: "function datetime_format takes exactly 2 arguments",
 	},
 }
 
-func TestByteLen(t *testing.T) {
-	testFunction(t, ByteLen, byteLenTests)
+func TestDatetimeFormat(t *testing.T) {
+	testFunction(t, DatetimeFormat, datetimeFormatTests)
 }
 
-var widthTests = []functionTest{
+var toCharTests = []functionTest{
 	{
-		Name: "Width",
+		Name: "ToChar Datetime",
 		Function: parser.Function{
-			Name: "width",
+			Name: "to_char",
 		},
 		Args: []value.Primary{
-			value.NewString("abc日本語"),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 0, GetTestLocation())),
+			value.NewString("YYYY-MM-DD HH24:MI"),
 		},
-		Result: value.NewInteger(9),
+		Result: value.NewString("2012-02-03 09:18"),
 	},
 	{
-		Name: "Width Arguments Error",
+		Name: "ToChar Datetime String",
 		Function: parser.Function{
-			Name: "width",
+			Name: "to_char",
 		},
-		Args:  []value.Primary{},
-		Error: "function width takes exactly 1 argument",
+		Args: []value.Primary{
+			value.NewString("2012-02-03 09:18:15"),
+			value.NewString("FMDD Mon YYYY"),
+		},
+		Result: value.NewString("3 Feb 2012"),
+	},
+	{
+		Name: "ToChar Number",
+		Function: parser.Function{
+			Name: "to_char",
+		},
+		Args: []value.Primary{
+			value.NewFloat(1234.5),
+			value.NewString("9,999.00"),
+		},
+		Result: value.NewString(" 1,234.50"),
+	},
+	{
+		Name: "ToChar Number String",
+		Function: parser.Function{
+			Name: "to_char",
+		},
+		Args: []value.Primary{
+			value.NewString("-42"),
+			value.NewString("FM0000"),
+		},
+		Result: value.NewString("-0042"),
+	},
+	{
+		Name: "ToChar Not Datetime",
+		Function: parser.Function{
+			Name: "to_char",
+		},
+		Args: []value.Primary{
+			value.NewString("abc"),
+			value.NewString("YYYY"),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "ToChar Format is Null",
+		Function: parser.Function{
+			Name: "to_char",
+		},
+		Args: []value.Primary{
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 0, GetTestLocation())),
+			value.NewNull(),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "ToChar Invalid Numeric Pattern",
+		Function: parser.Function{
+			Name: "to_char",
+		},
+		Args: []value.Primary{
+			value.NewInteger(1),
+			value.NewString("9X"),
+		},
+		Error: "invalid numeric format pattern \"9X\" at position 2 for function to_char",
+	},
+	{
+		Name: "ToChar Arguments Error",
+		Function: parser.Function{
+			Name: "to_char",
+		},
+		Args: []value.Primary{
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 0, GetTestLocation())),
+		},
+		Error: "function to_char takes exactly 2 arguments",
 	},
 }
 
-func TestWidth(t *testing.T) {
-	testFunction(t, Width, widthTests)
+func TestToChar(t *testing.T) {
+	testFunction(t, ToChar, toCharTests)
 }
 
-var lpadTests = []functionTest{
+var strptimeTests = []functionTest{
 	{
-		Name: "Lpad",
+		Name: "Strptime",
 		Function: parser.Function{
-			Name: "lpad",
+			Name: "strptime",
 		},
 		Args: []value.Primary{
-			value.NewString("aaaaa"),
-			value.NewInteger(10),
-			value.NewString("01"),
+			value.NewString("03/02/2012 09:18"),
+			value.NewString("%d/%m/%Y %H:%i"),
+			value.NewString("UTC"),
 		},
-		Result: value.NewString("01010aaaaa"),
+		Result: value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 0, 0, time.UTC)),
 	},
 	{
-		Name: "Lpad by Byte Length",
+		Name: "Strptime Time Zone in String",
 		Function: parser.Function{
-			Name: "lpad",
+			Name: "strptime",
 		},
 		Args: []value.Primary{
-			value.NewString("日本語"),
-			value.NewInteger(12),
-			value.NewString("空白"),
-			value.NewString("byte"),
-			value.NewString("sjis"),
+			value.NewString("03/02/2012 09:18 +09:00"),
+			value.NewString("%d/%m/%Y %H:%i %Z"),
+			value.NewString("UTC"),
 		},
-		Result: value.NewString("空白空日本語"),
+		Result: value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 0, 0, time.FixedZone("", 9*60*60))),
 	},
 	{
-		Name: "Lpad by Byte Length Error",
+		Name: "Strptime Datetime",
 		Function: parser.Function{
-			Name: "lpad",
+			Name: "strptime",
 		},
 		Args: []value.Primary{
-			value.NewString("日本語"),
-			value.NewInteger(11),
-			value.NewString("空白"),
-			value.NewString("byte"),
-			value.NewString("sjis"),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 0, GetTestLocation())),
+			value.NewString("%Y-%m-%d %H:%i:%s"),
+			value.NewString("UTC"),
 		},
-		Error: "cannot split pad string in a byte array of a character for function lpad",
+		Result: value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 0, time.UTC)),
 	},
 	{
-		Name: "Lpad PadType Error",
+		Name: "Strptime Not Match",
 		Function: parser.Function{
-			Name: "lpad",
+			Name: "strptime",
 		},
 		Args: []value.Primary{
-			value.NewString("日本語"),
-			value.NewInteger(11),
-			value.NewString("空白"),
-			value.NewString("invalid"),
+			value.NewString("2012/02/03"),
+			value.NewString("%d/%m/%Y"),
 		},
-		Error: "padding type must be one of LEN|BYTE|WIDTH for function lpad",
+		Result: value.NewNull(),
 	},
 	{
-		Name: "Lpad Encoding Error",
+		Name: "Strptime Null",
 		Function: parser.Function{
-			Name: "lpad",
+			Name: "strptime",
 		},
 		Args: []value.Primary{
-			value.NewString("日本語"),
-			value.NewInteger(11),
-			value.NewString("空白"),
-			value.NewString("byte"),
-			value.NewString("invalid"),
+			value.NewNull(),
+			value.NewString("%d/%m/%Y"),
 		},
-		Error: "encoding must be one of UTF8|UTF8M|SJIS for function lpad",
+		Result: value.NewNull(),
+	},
+	{
+		Name: "Strptime Invalid Time Zone Error",
+		Function: parser.Function{
+			Name: "strptime",
+		},
+		Args: []value.Primary{
+			value.NewString("03/02/2012"),
+			value.NewString("%d/%m/%Y"),
+			value.NewString("Nowhere/Nothing"),
+		},
+		Error: "timezone \"Nowhere/Nothing\" does not exist for function strptime",
+	},
+	{
+		Name: "Strptime Arguments Error",
+		Function: parser.Function{
+			Name: "strptime",
+		},
+		Args: []value.Primary{
+			value.NewString("03/02/2012"),
+		},
+		Error: "function strptime takes 2 or 3 arguments",
+	},
+}
+
+func TestStrptime(t *testing.T) {
+	testFunction(t, Strptime, strptimeTests)
+}
+
+var yearTests = []functionTest{
+	{
+		Name: "Year",
+		Function: parser.Function{
+			Name: "year",
+		},
+		Args: []value.Primary{
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 0, GetTestLocation())),
+		},
+		Result: value.NewInteger(2012),
+	},
+	{
+		Name: "Year Datetime is Null",
+		Function: parser.Function{
+			Name: "year",
+		},
+		Args: []value.Primary{
+			value.NewNull(),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "Year Arguments Error",
+		Function: parser.Function{
+			Name: "year",
+		},
+		Args:  []value.Primary{},
+		Error: "function year takes exactly 1 argument",
+	},
+}
+
+func TestYear(t *testing.T) {
+	testFunction(t, Year, yearTests)
+}
+
+var monthTests = []functionTest{
+	{
+		Name: "Month",
+		Function: parser.Function{
+			Name: "month",
+		},
+		Args: []value.Primary{
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 0, GetTestLocation())),
+		},
+		Result: value.NewInteger(2),
 	},
+}
+
+func TestMonth(t *testing.T) {
+	testFunction(t, Month, monthTests)
+}
+
+var dayTests = []functionTest{
 	{
-		Name: "Lpad by Width",
+		Name: "Day",
 		Function: parser.Function{
-			Name: "lpad",
+			Name: "day",
 		},
 		Args: []value.Primary{
-			value.NewString("日本語"),
-			value.NewInteger(12),
-			value.NewString("空白"),
-			value.NewString("width"),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 0, GetTestLocation())),
 		},
-		Result: value.NewString("空白空日本語"),
+		Result: value.NewInteger(3),
 	},
+}
+
+func TestDay(t *testing.T) {
+	testFunction(t, Day, dayTests)
+}
+
+var hourTests = []functionTest{
 	{
-		Name: "Lpad by Width Length Error",
+		Name: "Hour",
 		Function: parser.Function{
-			Name: "lpad",
+			Name: "hour",
 		},
 		Args: []value.Primary{
-			value.NewString("日本語"),
-			value.NewInteger(11),
-			value.NewString("空白"),
-			value.NewString("width"),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 0, GetTestLocation())),
 		},
-		Error: "cannot split pad string in a byte array of a character for function lpad",
+		Result: value.NewInteger(9),
 	},
+}
+
+func TestHour(t *testing.T) {
+	testFunction(t, Hour, hourTests)
+}
+
+var minuteTests = []functionTest{
 	{
-		Name: "Lpad No Padding",
+		Name: "Minute",
 		Function: parser.Function{
-			Name: "lpad",
+			Name: "minute",
 		},
 		Args: []value.Primary{
-			value.NewString("aaaaa"),
-			value.NewInteger(5),
-			value.NewString("01"),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 0, GetTestLocation())),
 		},
-		Result: value.NewString("aaaaa"),
+		Result: value.NewInteger(18),
 	},
+}
+
+func TestMinute(t *testing.T) {
+	testFunction(t, Minute, minuteTests)
+}
+
+var secondTests = []functionTest{
 	{
-		Name: "Lpad String is Null",
+		Name: "Second",
 		Function: parser.Function{
-			Name: "lpad",
+			Name: "second",
 		},
 		Args: []value.Primary{
-			value.NewNull(),
-			value.NewInteger(10),
-			value.NewString("01"),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 0, GetTestLocation())),
 		},
-		Result: value.NewNull(),
+		Result: value.NewInteger(15),
 	},
+}
+
+func TestSecond(t *testing.T) {
+	testFunction(t, Second, secondTests)
+}
+
+var millisecondTests = []functionTest{
 	{
-		Name: "Lpad Length is Null",
+		Name: "Millisecond",
 		Function: parser.Function{
-			Name: "lpad",
+			Name: "millisecond",
 		},
 		Args: []value.Primary{
-			value.NewString("aaaaa"),
-			value.NewNull(),
-			value.NewString("01"),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
 		},
-		Result: value.NewNull(),
+		Result: value.NewInteger(123),
 	},
+}
+
+func TestMillisecond(t *testing.T) {
+	testFunction(t, Millisecond, millisecondTests)
+}
+
+var microsecondTests = []functionTest{
 	{
-		Name: "Lpad Pad String is Null",
+		Name: "Microsecond",
 		Function: parser.Function{
-			Name: "lpad",
+			Name: "microsecond",
 		},
 		Args: []value.Primary{
-			value.NewString("aaaaa"),
-			value.NewInteger(10),
-			value.NewNull(),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
 		},
-		Result: value.NewNull(),
+		Result: value.NewInteger(123457),
 	},
+}
+
+func TestMicrosecond(t *testing.T) {
+	testFunction(t, Microsecond, microsecondTests)
+}
+
+var nanosecondTests = []functionTest{
 	{
-		Name: "Lpad Arguments Error",
+		Name: "Nanosecond",
 		Function: parser.Function{
-			Name: "lpad",
+			Name: "nanosecond",
 		},
-		Args:  []value.Primary{},
-		Error: "function lpad takes 3 to 5 arguments",
+		Args: []value.Primary{
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
+		},
+		Result: value.NewInteger(123456789),
 	},
 }
 
-func TestLpad(t *testing.T) {
-	testFunction(t, Lpad, lpadTests)
+func TestNanosecond(t *testing.T) {
+	testFunction(t, Nanosecond, nanosecondTests)
 }
 
-var rpadTests = []functionTest{
+var weekdayTests = []functionTest{
 	{
-		Name: "Rpad",
+		Name: "Weekday",
 		Function: parser.Function{
-			Name: "rpad",
+			Name: "weekday",
 		},
 		Args: []value.Primary{
-			value.NewString("aaaaa"),
-			value.NewInteger(10),
-			value.NewString("01"),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 0, GetTestLocation())),
 		},
-		Result: value.NewString("aaaaa01010"),
+		Result: value.NewInteger(5),
 	},
 }
 
-func TestRpad(t *testing.T) {
-	testFunction(t, Rpad, rpadTests)
+func TestWeekday(t *testing.T) {
+	testFunction(t, Weekday, weekdayTests)
 }
 
-var substrTests = []functionTest{
+var unixTimeTests = []functionTest{
 	{
-		Name: "Substr",
+		Name: "UnixTime",
 		Function: parser.Function{
-			Name: "substr",
+			Name: "unix_time",
 		},
 		Args: []value.Primary{
-			value.NewString("abcdefghijklmn"),
-			value.NewInteger(-5),
-			value.NewInteger(8),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 0, GetTestLocation())),
 		},
-		Result: value.NewString("jklmn"),
+		Result: value.NewInteger(1328260695),
 	},
+}
+
+func TestUnixTime(t *testing.T) {
+	testFunction(t, UnixTime, unixTimeTests)
+}
+
+var unixNanoTimeTests = []functionTest{
 	{
-		Name: "Substr String is Null",
+		Name: "UnixNanoTime",
 		Function: parser.Function{
-			Name: "substr",
+			Name: "unix_nano_time",
 		},
 		Args: []value.Primary{
-			value.NewNull(),
-			value.NewInteger(-5),
-			value.NewInteger(8),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
 		},
-		Result: value.NewNull(),
+		Result: value.NewInteger(1328260695123456789),
 	},
+}
+
+func TestUnixNanoTime(t *testing.T) {
+	testFunction(t, UnixNanoTime, unixNanoTimeTests)
+}
+
+var dayOfYearTests = []functionTest{
 	{
-		Name: "Substr StartIndex is Null",
+		Name: "DayOfYear",
 		Function: parser.Function{
-			Name: "substr",
+			Name: "day_of_year",
 		},
 		Args: []value.Primary{
-			value.NewString("abcdefghijklmn"),
-			value.NewNull(),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 0, GetTestLocation())),
 		},
-		Result: value.NewNull(),
+		Result: value.NewInteger(34),
 	},
+}
+
+func TestDayOfYear(t *testing.T) {
+	testFunction(t, DayOfYear, dayOfYearTests)
+}
+
+var weekOfYearTests = []functionTest{
 	{
-		Name: "Substr Length is Null",
+		Name: "WeekOfYear",
 		Function: parser.Function{
-			Name: "substr",
+			Name: "week_of_year",
 		},
 		Args: []value.Primary{
-			value.NewString("abcdefghijklmn"),
-			value.NewInteger(-5),
-			value.NewNull(),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 0, GetTestLocation())),
 		},
-		Result: value.NewNull(),
+		Result: value.NewInteger(5),
 	},
+}
+
+func TestWeekOfYear(t *testing.T) {
+	testFunction(t, WeekOfYear, weekOfYearTests)
+}
+
+var isoYearTests = []functionTest{
 	{
-		Name: "Substr Length is Negative",
+		Name: "IsoYear",
 		Function: parser.Function{
-			Name: "substr",
+			Name: "iso_year",
 		},
 		Args: []value.Primary{
-			value.NewString("abcdefghijklmn"),
-			value.NewInteger(-5),
-			value.NewInteger(-1),
+			value.NewDatetime(time.Date(2012, 1, 1, 0, 0, 0, 0, GetTestLocation())),
 		},
-		Result: value.NewNull(),
+		Result: value.NewInteger(2011),
 	},
 	{
-		Name: "Substr StartIndex is Out Of Index",
+		Name: "IsoYear Argument Is Null",
 		Function: parser.Function{
-			Name: "substr",
+			Name: "iso_year",
 		},
 		Args: []value.Primary{
-			value.NewString("abcdefghijklmn"),
-			value.NewInteger(100),
-			value.NewInteger(8),
+			value.NewNull(),
 		},
 		Result: value.NewNull(),
 	},
-	{
-		Name: "Substr Arguments Error",
-		Function: parser.Function{
-			Name: "substr",
-		},
-		Args:  []value.Primary{},
-		Error: "function substr takes 2 or 3 arguments",
-	},
 }
 
-func TestSubstr(t *testing.T) {
-	testFunction(t, Substr, substrTests)
+func TestIsoYear(t *testing.T) {
+	testFunction(t, IsoYear, isoYearTests)
 }
 
-var instrTests = []functionTest{
+var monthNameTests = []functionTest{
 	{
-		Name: "Instr",
+		Name: "MonthName",
 		Function: parser.Function{
-			Name: "instr",
+			Name: "month_name",
 		},
 		Args: []value.Primary{
-			value.NewString("abcdefghijklmn"),
-			value.NewString("def"),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
 		},
-		Result: value.NewInteger(3),
+		Result: value.NewString("February"),
 	},
 	{
-		Name: "Instr String is Null",
+		Name: "MonthName With Locale",
 		Function: parser.Function{
-			Name: "instr",
+			Name: "month_name",
+		},
+		Args: []value.Primary{
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
+			value.NewString("fr_FR"),
+		},
+		Result: value.NewString("février"),
+	},
+	{
+		Name: "MonthName Datetime Is Null",
+		Function: parser.Function{
+			Name: "month_name",
 		},
 		Args: []value.Primary{
 			value.NewNull(),
-			value.NewString("def"),
+			value.NewString("fr"),
 		},
 		Result: value.NewNull(),
 	},
 	{
-		Name: "Instr Substring is Null",
+		Name: "MonthName Locale Is Null",
 		Function: parser.Function{
-			Name: "instr",
+			Name: "month_name",
 		},
 		Args: []value.Primary{
-			value.NewString("abcdefghijklmn"),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
 			value.NewNull(),
 		},
-		Result: value.NewNull(),
+		Error: "the second argument must be a string for function month_name",
 	},
 	{
-		Name: "Instr Substring does not exist",
+		Name: "MonthName Unsupported Locale",
 		Function: parser.Function{
-			Name: "instr",
+			Name: "month_name",
 		},
 		Args: []value.Primary{
-			value.NewString("abcdefghijklmn"),
-			value.NewString("zzz"),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
+			value.NewString("sw"),
 		},
-		Result: value.NewNull(),
+		Error: "locale sw is not supported for function month_name",
 	},
 	{
-		Name: "Instr Arguments Error",
+		Name: "MonthName Arguments Error",
 		Function: parser.Function{
-			Name: "instr",
+			Name: "month_name",
 		},
 		Args:  []value.Primary{},
-		Error: "function instr takes exactly 2 arguments",
+		Error: "function month_name takes 1 or 2 arguments",
 	},
 }
 
-func TestInstr(t *testing.T) {
-	testFunction(t, Instr, instrTests)
+func TestMonthName(t *testing.T) {
+	testFunction(t, MonthName, monthNameTests)
 }
 
-var listElemTests = []functionTest{
+var dayNameTests = []functionTest{
 	{
-		Name: "ListElem",
+		Name: "DayName",
 		Function: parser.Function{
-			Name: "list_elem",
+			Name: "day_name",
 		},
 		Args: []value.Primary{
-			value.NewString("abc def ghi"),
-			value.NewString(" "),
-			value.NewInteger(1),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
 		},
-		Result: value.NewString("def"),
+		Result: value.NewString("Friday"),
 	},
 	{
-		Name: "ListElem String is Null",
+		Name: "DayName With Locale",
 		Function: parser.Function{
-			Name: "list_elem",
+			Name: "day_name",
 		},
 		Args: []value.Primary{
-			value.NewNull(),
-			value.NewString(" "),
-			value.NewInteger(1),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
+			value.NewString("de"),
 		},
-		Result: value.NewNull(),
+		Result: value.NewString("Freitag"),
 	},
 	{
-		Name: "ListElem Separator is Null",
+		Name: "DayName Datetime Is Null",
 		Function: parser.Function{
-			Name: "list_elem",
+			Name: "day_name",
 		},
 		Args: []value.Primary{
-			value.NewString("abc def ghi"),
 			value.NewNull(),
-			value.NewInteger(1),
 		},
 		Result: value.NewNull(),
 	},
+}
+
+func TestDayName(t *testing.T) {
+	testFunction(t, DayName, dayNameTests)
+}
+
+var lastDayTests = []functionTest{
 	{
-		Name: "ListElem Index is Null",
+		Name: "LastDay",
 		Function: parser.Function{
-			Name: "list_elem",
+			Name: "last_day",
 		},
 		Args: []value.Primary{
-			value.NewString("abc def ghi"),
-			value.NewString(" "),
-			value.NewNull(),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
 		},
-		Result: value.NewNull(),
+		Result: value.NewDatetime(time.Date(2012, 2, 29, 0, 0, 0, 0, GetTestLocation())),
 	},
 	{
-		Name: "ListElem Index is negative value",
+		Name: "LastDay December",
 		Function: parser.Function{
-			Name: "list_elem",
+			Name: "last_day",
 		},
 		Args: []value.Primary{
-			value.NewString("abc def ghi"),
-			value.NewString(" "),
-			value.NewInteger(-1),
+			value.NewDatetime(time.Date(2012, 12, 15, 0, 0, 0, 0, GetTestLocation())),
 		},
-		Result: value.NewNull(),
+		Result: value.NewDatetime(time.Date(2012, 12, 31, 0, 0, 0, 0, GetTestLocation())),
 	},
 	{
-		Name: "ListElem Index does not exist",
+		Name: "LastDay Argument Is Null",
 		Function: parser.Function{
-			Name: "list_elem",
+			Name: "last_day",
 		},
 		Args: []value.Primary{
-			value.NewString("abc def ghi"),
-			value.NewString(" "),
-			value.NewInteger(100),
+			value.NewNull(),
 		},
 		Result: value.NewNull(),
 	},
 	{
-		Name: "ListElem Arguments Error",
+		Name: "LastDay Arguments Error",
 		Function: parser.Function{
-			Name: "list_elem",
+			Name: "last_day",
 		},
 		Args:  []value.Primary{},
-		Error: "function list_elem takes exactly 3 arguments",
+		Error: "function last_day takes exactly 1 argument",
 	},
 }
 
-func TestListElem(t *testing.T) {
-	testFunction(t, ListElem, listElemTests)
+func TestLastDay(t *testing.T) {
+	testFunction(t, LastDay, lastDayTests)
 }
 
-var replaceTests = []functionTest{
+var firstDayOfWeekTests = []functionTest{
 	{
-		Name: "Replace",
+		Name: "FirstDayOfWeek",
 		Function: parser.Function{
-			Name: "replace",
+			Name: "first_day_of_week",
 		},
 		Args: []value.Primary{
-			value.NewString("abcdefg abcdefg"),
-			value.NewString("cd"),
-			value.NewString("CD"),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
 		},
-		Result: value.NewString("abCDefg abCDefg"),
+		Result: value.NewDatetime(time.Date(2012, 1, 30, 0, 0, 0, 0, GetTestLocation())),
 	},
 	{
-		Name: "Replace String is Null",
+		Name: "FirstDayOfWeek Start On Sunday",
 		Function: parser.Function{
-			Name: "replace",
+			Name: "first_day_of_week",
 		},
 		Args: []value.Primary{
-			value.NewNull(),
-			value.NewString("cd"),
-			value.NewString("CD"),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
+			value.NewInteger(0),
 		},
-		Result: value.NewNull(),
+		Result: value.NewDatetime(time.Date(2012, 1, 29, 0, 0, 0, 0, GetTestLocation())),
 	},
 	{
-		Name: "Replace Old String is Null",
+		Name: "FirstDayOfWeek Start On Same Day",
 		Function: parser.Function{
-			Name: "replace",
+			Name: "first_day_of_week",
 		},
 		Args: []value.Primary{
-			value.NewString("abcdefg abcdefg"),
-			value.NewNull(),
-			value.NewString("CD"),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
+			value.NewInteger(5),
 		},
-		Result: value.NewNull(),
+		Result: value.NewDatetime(time.Date(2012, 2, 3, 0, 0, 0, 0, GetTestLocation())),
 	},
 	{
-		Name: "Replace New String is Null",
+		Name: "FirstDayOfWeek Start On Saturday",
 		Function: parser.Function{
-			Name: "replace",
+			Name: "first_day_of_week",
+		},
+		Args: []value.Primary{
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
+			value.NewInteger(6),
+		},
+		Result: value.NewDatetime(time.Date(2012, 1, 28, 0, 0, 0, 0, GetTestLocation())),
+	},
+	{
+		Name: "FirstDayOfWeek Datetime Is Null",
+		Function: parser.Function{
+			Name: "first_day_of_week",
 		},
 		Args: []value.Primary{
-			value.NewString("abcdefg abcdefg"),
-			value.NewString("cd"),
 			value.NewNull(),
 		},
 		Result: value.NewNull(),
 	},
 	{
-		Name: "Replace Arguments Error",
+		Name: "FirstDayOfWeek Invalid Weekday",
 		Function: parser.Function{
-			Name: "replace",
+			Name: "first_day_of_week",
 		},
-		Args:  []value.Primary{},
-		Error: "function replace takes exactly 3 arguments",
+		Args: []value.Primary{
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
+			value.NewInteger(7),
+		},
+		Error: "the second argument must be an integer from 0 to 6 for function first_day_of_week",
 	},
 }
 
-func TestReplace(t *testing.T) {
-	testFunction(t, Replace, replaceTests)
+func TestFirstDayOfWeek(t *testing.T) {
+	testFunction(t, FirstDayOfWeek, firstDayOfWeekTests)
 }
 
-var formatTests = []functionTest{
+var ageTests = []functionTest{
 	{
-		Name: "Format",
+		Name: "Age",
 		Function: parser.Function{
-			Name: "format",
+			Name: "age",
 		},
 		Args: []value.Primary{
-			value.NewString("string = %q, integer = %q"),
-			value.NewString("str"),
-			value.NewInteger(1),
+			value.NewDatetime(time.Date(2012, 2, 3, 0, 0, 0, 0, GetTestLocation())),
+			value.NewDatetime(time.Date(1980, 2, 3, 0, 0, 0, 0, GetTestLocation())),
 		},
-		Result: value.NewString("string = \"str\", integer = \"1\""),
+		Result: value.NewInteger(32),
 	},
 	{
-		Name: "Format Argument Length Error",
+		Name: "Age Before Anniversary",
 		Function: parser.Function{
-			Name: "format",
+			Name: "age",
 		},
-		Args:  []value.Primary{},
-		Error: "function format takes at least 1 argument",
+		Args: []value.Primary{
+			value.NewDatetime(time.Date(2012, 2, 2, 23, 59, 59, 0, GetTestLocation())),
+			value.NewDatetime(time.Date(1980, 2, 3, 0, 0, 0, 0, GetTestLocation())),
+		},
+		Result: value.NewInteger(31),
 	},
 	{
-		Name: "Format Argument Value Error",
+		Name: "Age Negative",
 		Function: parser.Function{
-			Name: "format",
+			Name: "age",
+		},
+		Args: []value.Primary{
+			value.NewDatetime(time.Date(1980, 2, 3, 0, 0, 0, 0, GetTestLocation())),
+			value.NewDatetime(time.Date(2012, 2, 2, 0, 0, 0, 0, GetTestLocation())),
+		},
+		Result: value.NewInteger(-31),
+	},
+	{
+		Name: "Age Datetime Is Null",
+		Function: parser.Function{
+			Name: "age",
 		},
 		Args: []value.Primary{
 			value.NewNull(),
-			value.NewString("str"),
-			value.NewInteger(1),
+			value.NewDatetime(time.Date(1980, 2, 3, 0, 0, 0, 0, GetTestLocation())),
 		},
-		Error: "the first argument must be a string for function format",
+		Result: value.NewNull(),
 	},
 	{
-		Name: "Format Replace Holder Length Not Match Error",
+		Name: "Age Arguments Error",
 		Function: parser.Function{
-			Name: "format",
+			Name: "age",
 		},
 		Args: []value.Primary{
-			value.NewString("string = %s, integer = %s"),
-			value.NewString("str"),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
 		},
-		Error: "number of replace values does not match for function format",
+		Error: "function age takes exactly 2 arguments",
 	},
 }
 
-func TestFormat(t *testing.T) {
-	testFunction(t, Format, formatTests)
+func TestAge(t *testing.T) {
+	testFunction(t, Age, ageTests)
 }
 
-var jsonValueTests = []functionTest{
+var addYearTests = []functionTest{
 	{
-		Name: "JsonValue",
+		Name: "AddYear",
 		Function: parser.Function{
-			Name: "json_value",
+			Name: "add_year",
 		},
 		Args: []value.Primary{
-			value.NewString("key1.key2"),
-			value.NewString("{\"key1\":{\"key2\":\"value\"}}"),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
+			value.NewInteger(2),
 		},
-		Result: value.NewString("value"),
+		Result: value.NewDatetime(time.Date(2014, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
 	},
 	{
-		Name: "JsonValue Query is Null",
+		Name: "AddYear Datetime is Null",
 		Function: parser.Function{
-			Name: "json_value",
+			Name: "add_year",
 		},
 		Args: []value.Primary{
 			value.NewNull(),
-			value.NewString("{\"key1\":{\"key2\":\"value\"}}"),
+			value.NewInteger(2),
 		},
 		Result: value.NewNull(),
 	},
 	{
-		Name: "JsonValue Json-Text is Null",
+		Name: "AddYear Duration is Null",
 		Function: parser.Function{
-			Name: "json_value",
+			Name: "add_year",
 		},
 		Args: []value.Primary{
-			value.NewString("key1.key2"),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
 			value.NewNull(),
 		},
 		Result: value.NewNull(),
 	},
 	{
-		Name: "JsonValue Arguments Error",
+		Name: "AddYear Arguments Error",
 		Function: parser.Function{
-			Name: "json_value",
+			Name: "add_year",
+		},
+		Args:  []value.Primary{},
+		Error: "function add_year takes exactly 2 arguments",
+	},
+}
+
+func TestAddYear(t *testing.T) {
+	testFunction(t, AddYear, addYearTests)
+}
+
+var addMonthTests = []functionTest{
+	{
+		Name: "AddMonth",
+		Function: parser.Function{
+			Name: "add_month",
 		},
 		Args: []value.Primary{
-			value.NewString("key1.key2"),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
+			value.NewInteger(2),
 		},
-		Error: "function json_value takes exactly 2 arguments",
+		Result: value.NewDatetime(time.Date(2012, 4, 3, 9, 18, 15, 123456789, GetTestLocation())),
 	},
+}
+
+func TestAddMonth(t *testing.T) {
+	testFunction(t, AddMonth, addMonthTests)
+}
+
+var addDayTests = []functionTest{
 	{
-		Name: "JsonValue Json Loading Error",
+		Name: "AddDay",
 		Function: parser.Function{
-			Name: "json_value",
+			Name: "add_day",
 		},
 		Args: []value.Primary{
-			value.NewString("key1.key2"),
-			value.NewString("{key1:{\"key2\":\"value\"}}"),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
+			value.NewInteger(2),
 		},
-		Error: "line 1, column 2: unexpected token \"key\" for function json_value",
+		Result: value.NewDatetime(time.Date(2012, 2, 5, 9, 18, 15, 123456789, GetTestLocation())),
 	},
 }
 
-func TestJsonValue(t *testing.T) {
-	testFunction(t, JsonValue, jsonValueTests)
+func TestAddDay(t *testing.T) {
+	testFunction(t, AddDay, addDayTests)
 }
 
-var md5Tests = []functionTest{
+var addHourTests = []functionTest{
 	{
-		Name: "Md5",
+		Name: "AddHour",
 		Function: parser.Function{
-			Name: "md5",
+			Name: "add_hour",
 		},
 		Args: []value.Primary{
-			value.NewString("foo"),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
+			value.NewInteger(2),
 		},
-		Result: value.NewString("acbd18db4cc2f85cedef654fccc4a4d8"),
+		Result: value.NewDatetime(time.Date(2012, 2, 3, 11, 18, 15, 123456789, GetTestLocation())),
 	},
+}
+
+func TestAddHour(t *testing.T) {
+	testFunction(t, AddHour, addHourTests)
+}
+
+var addMinuteTests = []functionTest{
 	{
-		Name: "Md5 Null",
+		Name: "AddMinute",
 		Function: parser.Function{
-			Name: "md5",
+			Name: "add_minute",
 		},
 		Args: []value.Primary{
-			value.NewNull(),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
+			value.NewInteger(2),
 		},
-		Result: value.NewNull(),
+		Result: value.NewDatetime(time.Date(2012, 2, 3, 9, 20, 15, 123456789, GetTestLocation())),
 	},
+}
+
+func TestAddMinute(t *testing.T) {
+	testFunction(t, AddMinute, addMinuteTests)
+}
+
+var addSecondTests = []functionTest{
 	{
-		Name: "Md5 Arguments Error",
+		Name: "AddSecond",
 		Function: parser.Function{
-			Name: "md5",
+			Name: "add_second",
 		},
-		Args:  []value.Primary{},
-		Error: "function md5 takes exactly 1 argument",
+		Args: []value.Primary{
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
+			value.NewInteger(2),
+		},
+		Result: value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 17, 123456789, GetTestLocation())),
 	},
 }
 
-func TestMd5(t *testing.T) {
-	testFunction(t, Md5, md5Tests)
+func TestAddSecond(t *testing.T) {
+	testFunction(t, AddSecond, addSecondTests)
 }
 
-var sha1Tests = []functionTest{
+var addMilliTests = []functionTest{
 	{
-		Name: "Sha1",
+		Name: "AddMilli",
 		Function: parser.Function{
-			Name: "sha1",
+			Name: "add_milli",
 		},
 		Args: []value.Primary{
-			value.NewString("foo"),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
+			value.NewInteger(2),
 		},
-		Result: value.NewString("0beec7b5ea3f0fdbc95d0dd47f3c5bc275da8a33"),
+		Result: value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 125456789, GetTestLocation())),
 	},
 }
 
-func TestSha1(t *testing.T) {
-	testFunction(t, Sha1, sha1Tests)
+func TestAddMilli(t *testing.T) {
+	testFunction(t, AddMilli, addMilliTests)
 }
 
-var sha256Tests = []functionTest{
+var addMicroTests = []functionTest{
 	{
-		Name: "Sha256",
+		Name: "AddMicro",
 		Function: parser.Function{
-			Name: "sha256",
+			Name: "add_micro",
 		},
 		Args: []value.Primary{
-			value.NewString("foo"),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
+			value.NewInteger(2),
 		},
-		Result: value.NewString("2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"),
+		Result: value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123458789, GetTestLocation())),
 	},
 }
 
-func TestSha256(t *testing.T) {
-	testFunction(t, Sha256, sha256Tests)
+func TestAddMicro(t *testing.T) {
+	testFunction(t, AddMicro, addMicroTests)
 }
 
-var sha512Tests = []functionTest{
+var addNanoTests = []functionTest{
 	{
-		Name: "Sha512",
+		Name: "AddNano",
 		Function: parser.Function{
-			Name: "sha512",
+			Name: "add_nano",
 		},
 		Args: []value.Primary{
-			value.NewString("foo"),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
+			value.NewInteger(2),
 		},
-		Result: value.NewString("f7fbba6e0636f890e56fbbf3283e524c6fa3204ae298382d624741d0dc6638326e282c41be5e4254d8820772c5518a2c5a8c0c7f7eda19594a7eb539453e1ed7"),
+		Result: value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456791, GetTestLocation())),
 	},
 }
 
-func TestSha512(t *testing.T) {
-	testFunction(t, Sha512, sha512Tests)
+func TestAddNano(t *testing.T) {
+	testFunction(t, AddNano, addNanoTests)
 }
 
-var md5HmacTests = []functionTest{
+var dateAddTests = []functionTest{
 	{
-		Name: "Md5Hmac",
+		Name: "DateAdd Month",
 		Function: parser.Function{
-			Name: "md5_hmac",
+			Name: "date_add",
 		},
 		Args: []value.Primary{
-			value.NewString("foo"),
-			value.NewString("bar"),
+			value.NewDatetime(time.Date(2012, 1, 31, 9, 18, 15, 123456789, GetTestLocation())),
+			value.NewInteger(1),
+			value.NewString("month"),
 		},
-		Result: value.NewString("31b6db9e5eb4addb42f1a6ca07367adc"),
+		Result: value.NewDatetime(time.Date(2012, 2, 29, 9, 18, 15, 123456789, GetTestLocation())),
 	},
 	{
-		Name: "Md5Hmac String is Null",
+		Name: "DateAdd Negative Month",
 		Function: parser.Function{
-			Name: "md5_hmac",
+			Name: "date_add",
 		},
 		Args: []value.Primary{
-			value.NewNull(),
-			value.NewString("bar"),
+			value.NewDatetime(time.Date(2012, 3, 31, 0, 0, 0, 0, GetTestLocation())),
+			value.NewInteger(-1),
+			value.NewString("MONTH"),
 		},
-		Result: value.NewNull(),
+		Result: value.NewDatetime(time.Date(2012, 2, 29, 0, 0, 0, 0, GetTestLocation())),
 	},
 	{
-		Name: "Md5Hmac Key is Null",
+		Name: "DateAdd Quarter",
 		Function: parser.Function{
-			Name: "md5_hmac",
+			Name: "date_add",
 		},
 		Args: []value.Primary{
-			value.NewString("foo"),
-			value.NewNull(),
+			value.NewDatetime(time.Date(2012, 1, 31, 9, 18, 15, 123456789, GetTestLocation())),
+			value.NewInteger(1),
+			value.NewString("quarter"),
 		},
-		Result: value.NewNull(),
+		Result: value.NewDatetime(time.Date(2012, 4, 30, 9, 18, 15, 123456789, GetTestLocation())),
 	},
 	{
-		Name: "Md5Hmac Arguments Error",
+		Name: "DateAdd Year",
 		Function: parser.Function{
-			Name: "md5_hmac",
+			Name: "date_add",
 		},
-		Args:  []value.Primary{},
-		Error: "function md5_hmac takes exactly 2 arguments",
+		Args: []value.Primary{
+			value.NewDatetime(time.Date(2012, 2, 29, 0, 0, 0, 0, GetTestLocation())),
+			value.NewInteger(1),
+			value.NewString("year"),
+		},
+		Result: value.NewDatetime(time.Date(2013, 2, 28, 0, 0, 0, 0, GetTestLocation())),
 	},
-}
-
-func TestMd5Hmac(t *testing.T) {
-	testFunction(t, Md5Hmac, md5HmacTests)
-}
-
-var sha1HmacTests = []functionTest{
 	{
-		Name: "Sha1Hmac",
+		Name: "DateAdd Week",
 		Function: parser.Function{
-			Name: "sha1_hmac",
+			Name: "date_add",
 		},
 		Args: []value.Primary{
-			value.NewString("foo"),
-			value.NewString("bar"),
+			value.NewDatetime(time.Date(2012, 1, 31, 9, 18, 15, 123456789, GetTestLocation())),
+			value.NewInteger(1),
+			value.NewString("week"),
 		},
-		Result: value.NewString("85d155c55ed286a300bd1cf124de08d87e914f3a"),
+		Result: value.NewDatetime(time.Date(2012, 2, 7, 9, 18, 15, 123456789, GetTestLocation())),
 	},
-}
-
-func TestSha1Hmac(t *testing.T) {
-	testFunction(t, Sha1Hmac, sha1HmacTests)
-}
-
-var sha256HmacTests = []functionTest{
 	{
-		Name: "Sha256Hmac",
+		Name: "DateAdd Day",
 		Function: parser.Function{
-			Name: "sha256_hmac",
+			Name: "date_add",
 		},
 		Args: []value.Primary{
-			value.NewString("foo"),
-			value.NewString("bar"),
+			value.NewDatetime(time.Date(2012, 1, 31, 9, 18, 15, 123456789, GetTestLocation())),
+			value.NewInteger(1),
+			value.NewString("day"),
 		},
-		Result: value.NewString("147933218aaabc0b8b10a2b3a5c34684c8d94341bcf10a4736dc7270f7741851"),
+		Result: value.NewDatetime(time.Date(2012, 2, 1, 9, 18, 15, 123456789, GetTestLocation())),
 	},
-}
-
-func TestSha256Hmac(t *testing.T) {
-	testFunction(t, Sha256Hmac, sha256HmacTests)
-}
-
-var sha512HmacTests = []functionTest{
 	{
-		Name: "Sha512Hmac",
+		Name: "DateAdd Hour",
 		Function: parser.Function{
-			Name: "sha512_hmac",
+			Name: "date_add",
 		},
 		Args: []value.Primary{
-			value.NewString("foo"),
-			value.NewString("bar"),
+			value.NewDatetime(time.Date(2012, 1, 31, 9, 18, 15, 123456789, GetTestLocation())),
+			value.NewInteger(15),
+			value.NewString("hour"),
 		},
-		Result: value.NewString("24257d7210582a65c731ec55159c8184cc24c02489453e58587f71f44c23a2d61b4b72154a89d17b2d49448a8452ea066f4fc56a2bcead45c088572ffccdb3d8"),
+		Result: value.NewDatetime(time.Date(2012, 2, 1, 0, 18, 15, 123456789, GetTestLocation())),
 	},
-}
-
-func TestSha512Hmac(t *testing.T) {
-	testFunction(t, Sha512Hmac, sha512HmacTests)
-}
-
-var datetimeFormatTests = []functionTest{
 	{
-		Name: "DatetimeFormat",
+		Name: "DateAdd Minute",
 		Function: parser.Function{
-			Name: "datetime_format",
+			Name: "date_add",
 		},
 		Args: []value.Primary{
-			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 0, GetTestLocation())),
-			value.NewString("%Y-%m-%d"),
+			value.NewDatetime(time.Date(2012, 1, 31, 9, 18, 15, 123456789, GetTestLocation())),
+			value.NewInteger(-20),
+			value.NewString("minute"),
 		},
-		Result: value.NewString("2012-02-03"),
+		Result: value.NewDatetime(time.Date(2012, 1, 31, 8, 58, 15, 123456789, GetTestLocation())),
 	},
 	{
-		Name: "DatetimeFormat Datetime is Null",
+		Name: "DateAdd Second",
 		Function: parser.Function{
-			Name: "datetime_format",
+			Name: "date_add",
 		},
 		Args: []value.Primary{
-			value.NewNull(),
-			value.NewString("%Y-%m-%d"),
+			value.NewDatetime(time.Date(2012, 1, 31, 9, 18, 15, 123456789, GetTestLocation())),
+			value.NewInteger(45),
+			value.NewString("second"),
 		},
-		Result: value.NewNull(),
+		Result: value.NewDatetime(time.Date(2012, 1, 31, 9, 19, 0, 123456789, GetTestLocation())),
 	},
 	{
-		Name: "DatetimeFormat Format is Null",
+		Name: "DateAdd Datetime is Null",
 		Function: parser.Function{
-			Name: "datetime_format",
+			Name: "date_add",
 		},
 		Args: []value.Primary{
-			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 0, GetTestLocation())),
 			value.NewNull(),
+			value.NewInteger(1),
+			value.NewString("day"),
 		},
 		Result: value.NewNull(),
 	},
 	{
-		Name: "DatetimeFormat Arguments Error",
+		Name: "DateAdd Duration is Null",
 		Function: parser.Function{
-			Name: "datetime_format",
+			Name: "date_add",
 		},
 		Args: []value.Primary{
-			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 0, GetTestLocation())),
+			value.NewDatetime(time.Date(2012, 1, 31, 9, 18, 15, 123456789, GetTestLocation())),
+			value.NewNull(),
+			value.NewString("day"),
 		},
-		Error: "function datetime_format takes exactly 2 arguments",
+		Result: value.NewNull(),
 	},
-}
-
-func TestDatetimeFormat(t *testing.T) {
-	testFunction(t, DatetimeFormat, datetimeFormatTests)
-}
-
-var yearTests = []functionTest{
 	{
-		Name: "Year",
+		Name: "DateAdd Arguments Error",
 		Function: parser.Function{
-			Name: "year",
+			Name: "date_add",
 		},
 		Args: []value.Primary{
-			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 0, GetTestLocation())),
+			value.NewDatetime(time.Date(2012, 1, 31, 9, 18, 15, 123456789, GetTestLocation())),
+			value.NewInteger(1),
 		},
-		Result: value.NewInteger(2012),
+		Error: "function date_add takes exactly 3 arguments",
 	},
 	{
-		Name: "Year Datetime is Null",
+		Name: "DateAdd Unit is Null",
 		Function: parser.Function{
-			Name: "year",
+			Name: "date_add",
 		},
 		Args: []value.Primary{
+			value.NewDatetime(time.Date(2012, 1, 31, 9, 18, 15, 123456789, GetTestLocation())),
+			value.NewInteger(1),
 			value.NewNull(),
 		},
-		Result: value.NewNull(),
-	},
-	{
-		Name: "Year Arguments Error",
-		Function: parser.Function{
-			Name: "year",
-		},
-		Args:  []value.Primary{},
-		Error: "function year takes exactly 1 argument",
+		Error: "the third argument must be a string for function date_add",
 	},
-}
-
-func TestYear(t *testing.T) {
-	testFunction(t, Year, yearTests)
-}
-
-var monthTests = []functionTest{
 	{
-		Name: "Month",
+		Name: "DateAdd Invalid Unit",
 		Function: parser.Function{
-			Name: "month",
+			Name: "date_add",
 		},
 		Args: []value.Primary{
-			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 0, GetTestLocation())),
+			value.NewDatetime(time.Date(2012, 1, 31, 9, 18, 15, 123456789, GetTestLocation())),
+			value.NewInteger(1),
+			value.NewString("decade"),
 		},
-		Result: value.NewInteger(2),
+		Error: "the third argument must be one of YEAR, QUARTER, MONTH, WEEK, DAY, HOUR, MINUTE or SECOND for function date_add",
 	},
 }
 
-func TestMonth(t *testing.T) {
-	testFunction(t, Month, monthTests)
+func TestDateAdd(t *testing.T) {
+	testFunction(t, DateAdd, dateAddTests)
 }
 
-var dayTests = []functionTest{
+var truncMonthTests = []functionTest{
 	{
-		Name: "Day",
+		Name: "TruncMonth",
 		Function: parser.Function{
-			Name: "day",
+			Name: "trunc_month",
 		},
 		Args: []value.Primary{
-			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 0, GetTestLocation())),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
 		},
-		Result: value.NewInteger(3),
+		Result: value.NewDatetime(time.Date(2012, 1, 1, 0, 0, 0, 0, GetTestLocation())),
 	},
-}
-
-func TestDay(t *testing.T) {
-	testFunction(t, Day, dayTests)
-}
-
-var hourTests = []functionTest{
 	{
-		Name: "Hour",
+		Name: "TruncMonth Argument Error",
 		Function: parser.Function{
-			Name: "hour",
-		},
-		Args: []value.Primary{
-			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 0, GetTestLocation())),
+			Name: "trunc_month",
 		},
-		Result: value.NewInteger(9),
+		Args:  []value.Primary{},
+		Error: "function trunc_month takes exactly 1 argument",
 	},
-}
-
-func TestHour(t *testing.T) {
-	testFunction(t, Hour, hourTests)
-}
-
-var minuteTests = []functionTest{
 	{
-		Name: "Minute",
+		Name: "TruncMonth Argument Is Null",
 		Function: parser.Function{
-			Name: "minute",
+			Name: "trunc_month",
 		},
 		Args: []value.Primary{
-			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 0, GetTestLocation())),
+			value.NewNull(),
 		},
-		Result: value.NewInteger(18),
+		Result: value.NewNull(),
 	},
 }
 
-func TestMinute(t *testing.T) {
-	testFunction(t, Minute, minuteTests)
+func TestTruncMonth(t *testing.T) {
+	testFunction(t, TruncMonth, truncMonthTests)
 }
 
-var secondTests = []functionTest{
+var truncDayTests = []functionTest{
 	{
-		Name: "Second",
+		Name: "TruncDay",
 		Function: parser.Function{
-			Name: "second",
+			Name: "trunc_day",
 		},
 		Args: []value.Primary{
-			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 0, GetTestLocation())),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
 		},
-		Result: value.NewInteger(15),
+		Result: value.NewDatetime(time.Date(2012, 2, 1, 0, 0, 0, 0, GetTestLocation())),
 	},
 }
 
-func TestSecond(t *testing.T) {
-	testFunction(t, Second, secondTests)
+func TestTruncDay(t *testing.T) {
+	testFunction(t, TruncDay, truncDayTests)
 }
 
-var millisecondTests = []functionTest{
+var truncTimeTests = []functionTest{
 	{
-		Name: "Millisecond",
+		Name: "TruncTime",
 		Function: parser.Function{
-			Name: "millisecond",
+			Name: "trunc_time",
 		},
 		Args: []value.Primary{
 			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
 		},
-		Result: value.NewInteger(123),
+		Result: value.NewDatetime(time.Date(2012, 2, 3, 0, 0, 0, 0, GetTestLocation())),
 	},
 }
 
-func TestMillisecond(t *testing.T) {
-	testFunction(t, Millisecond, millisecondTests)
+func TestTruncTime(t *testing.T) {
+	testFunction(t, TruncTime, truncTimeTests)
 }
 
-var microsecondTests = []functionTest{
+var truncMinuteTests = []functionTest{
 	{
-		Name: "Microsecond",
+		Name: "TruncMinute",
 		Function: parser.Function{
-			Name: "microsecond",
+			Name: "trunc_minute",
 		},
 		Args: []value.Primary{
 			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
 		},
-		Result: value.NewInteger(123457),
+		Result: value.NewDatetime(time.Date(2012, 2, 3, 9, 0, 0, 0, GetTestLocation())),
+	},
+	{
+		Name: "TruncMinute Argument Error",
+		Function: parser.Function{
+			Name: "trunc_minute",
+		},
+		Args:  []value.Primary{},
+		Error: "function trunc_minute takes exactly 1 argument",
 	},
-}
-
-func TestMicrosecond(t *testing.T) {
-	testFunction(t, Microsecond, microsecondTests)
-}
-
-var nanosecondTests = []functionTest{
 	{
-		Name: "Nanosecond",
+		Name: "TruncMinute Argument Is Null",
 		Function: parser.Function{
-			Name: "nanosecond",
+			Name: "trunc_minute",
 		},
 		Args: []value.Primary{
-			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
+			value.NewNull(),
 		},
-		Result: value.NewInteger(123456789),
+		Result: value.NewNull(),
 	},
 }
 
-func TestNanosecond(t *testing.T) {
-	testFunction(t, Nanosecond, nanosecondTests)
+func TestTruncMinute(t *testing.T) {
+	testFunction(t, TruncMinute, truncMinuteTests)
 }
 
-var weekdayTests = []functionTest{
+var truncSecondTests = []functionTest{
 	{
-		Name: "Weekday",
+		Name: "TruncSecond",
 		Function: parser.Function{
-			Name: "weekday",
+			Name: "trunc_second",
 		},
 		Args: []value.Primary{
-			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 0, GetTestLocation())),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
 		},
-		Result: value.NewInteger(5),
+		Result: value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 0, 0, GetTestLocation())),
 	},
 }
 
-func TestWeekday(t *testing.T) {
-	testFunction(t, Weekday, weekdayTests)
+func TestTruncSecond(t *testing.T) {
+	testFunction(t, TruncSecond, truncSecondTests)
 }
 
-var unixTimeTests = []functionTest{
+var truncMilliTests = []functionTest{
 	{
-		Name: "UnixTime",
+		Name: "TruncMilli",
 		Function: parser.Function{
-			Name: "unix_time",
+			Name: "trunc_milli",
 		},
 		Args: []value.Primary{
-			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 0, GetTestLocation())),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
 		},
-		Result: value.NewInteger(1328260695),
+		Result: value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 0, GetTestLocation())),
 	},
 }
 
-func TestUnixTime(t *testing.T) {
-	testFunction(t, UnixTime, unixTimeTests)
+func TestTruncMilli(t *testing.T) {
+	testFunction(t, TruncMilli, truncMilliTests)
 }
 
-var unixNanoTimeTests = []functionTest{
+var truncMicroTests = []functionTest{
 	{
-		Name: "UnixNanoTime",
+		Name: "TruncMicro",
 		Function: parser.Function{
-			Name: "unix_nano_time",
+			Name: "trunc_micro",
 		},
 		Args: []value.Primary{
 			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
 		},
-		Result: value.NewInteger(1328260695123456789),
+		Result: value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123000000, GetTestLocation())),
 	},
 }
 
-func TestUnixNanoTime(t *testing.T) {
-	testFunction(t, UnixNanoTime, unixNanoTimeTests)
+func TestTruncateMicro(t *testing.T) {
+	testFunction(t, TruncMicro, truncMicroTests)
 }
 
-var dayOfYearTests = []functionTest{
+var truncNanoTests = []functionTest{
 	{
-		Name: "DayOfYear",
+		Name: "TruncNano",
 		Function: parser.Function{
-			Name: "day_of_year",
+			Name: "trunc_nano",
 		},
 		Args: []value.Primary{
-			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 0, GetTestLocation())),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
 		},
-		Result: value.NewInteger(34),
+		Result: value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456000, GetTestLocation())),
 	},
 }
 
-func TestDayOfYear(t *testing.T) {
-	testFunction(t, DayOfYear, dayOfYearTests)
+func TestTruncateNano(t *testing.T) {
+	testFunction(t, TruncNano, truncNanoTests)
 }
 
-var weekOfYearTests = []functionTest{
+var dateTruncTests = []functionTest{
 	{
-		Name: "WeekOfYear",
+		Name: "DateTrunc Year",
 		Function: parser.Function{
-			Name: "week_of_year",
+			Name: "date_trunc",
 		},
 		Args: []value.Primary{
-			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 0, GetTestLocation())),
+			value.NewString("year"),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
 		},
-		Result: value.NewInteger(5),
+		Result: value.NewDatetime(time.Date(2012, 1, 1, 0, 0, 0, 0, GetTestLocation())),
 	},
-}
-
-func TestWeekOfYear(t *testing.T) {
-	testFunction(t, WeekOfYear, weekOfYearTests)
-}
-
-var addYearTests = []functionTest{
 	{
-		Name: "AddYear",
+		Name: "DateTrunc Quarter",
 		Function: parser.Function{
-			Name: "add_year",
+			Name: "date_trunc",
 		},
 		Args: []value.Primary{
+			value.NewString("quarter"),
 			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
-			value.NewInteger(2),
 		},
-		Result: value.NewDatetime(time.Date(2014, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
+		Result: value.NewDatetime(time.Date(2012, 1, 1, 0, 0, 0, 0, GetTestLocation())),
 	},
 	{
-		Name: "AddYear Datetime is Null",
+		Name: "DateTrunc Month",
 		Function: parser.Function{
-			Name: "add_year",
+			Name: "date_trunc",
 		},
 		Args: []value.Primary{
-			value.NewNull(),
-			value.NewInteger(2),
+			value.NewString("MONTH"),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
 		},
-		Result: value.NewNull(),
+		Result: value.NewDatetime(time.Date(2012, 2, 1, 0, 0, 0, 0, GetTestLocation())),
 	},
 	{
-		Name: "AddYear Duration is Null",
+		Name: "DateTrunc Week",
 		Function: parser.Function{
-			Name: "add_year",
+			Name: "date_trunc",
 		},
 		Args: []value.Primary{
+			value.NewString("week"),
 			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
-			value.NewNull(),
 		},
-		Result: value.NewNull(),
+		Result: value.NewDatetime(time.Date(2012, 1, 30, 0, 0, 0, 0, GetTestLocation())),
 	},
 	{
-		Name: "AddYear Arguments Error",
+		Name: "DateTrunc Day",
 		Function: parser.Function{
-			Name: "add_year",
+			Name: "date_trunc",
 		},
-		Args:  []value.Primary{},
-		Error: "function add_year takes exactly 2 arguments",
+		Args: []value.Primary{
+			value.NewString("day"),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
+		},
+		Result: value.NewDatetime(time.Date(2012, 2, 3, 0, 0, 0, 0, GetTestLocation())),
 	},
-}
-
-func TestAddYear(t *testing.T) {
-	testFunction(t, AddYear, addYearTests)
-}
-
-var addMonthTests = []functionTest{
 	{
-		Name: "AddMonth",
+		Name: "DateTrunc Hour",
 		Function: parser.Function{
-			Name: "add_month",
+			Name: "date_trunc",
 		},
 		Args: []value.Primary{
+			value.NewString("hour"),
 			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
-			value.NewInteger(2),
 		},
-		Result: value.NewDatetime(time.Date(2012, 4, 3, 9, 18, 15, 123456789, GetTestLocation())),
+		Result: value.NewDatetime(time.Date(2012, 2, 3, 9, 0, 0, 0, GetTestLocation())),
 	},
-}
-
-func TestAddMonth(t *testing.T) {
-	testFunction(t, AddMonth, addMonthTests)
-}
-
-var addDayTests = []functionTest{
 	{
-		Name: "AddDay",
+		Name: "DateTrunc Minute",
 		Function: parser.Function{
-			Name: "add_day",
+			Name: "date_trunc",
 		},
 		Args: []value.Primary{
+			value.NewString("minute"),
 			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
-			value.NewInteger(2),
 		},
-		Result: value.NewDatetime(time.Date(2012, 2, 5, 9, 18, 15, 123456789, GetTestLocation())),
+		Result: value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 0, 0, GetTestLocation())),
 	},
-}
-
-func TestAddDay(t *testing.T) {
-	testFunction(t, AddDay, addDayTests)
-}
-
-var addHourTests = []functionTest{
 	{
-		Name: "AddHour",
+		Name: "DateTrunc Quarter From Later Month",
 		Function: parser.Function{
-			Name: "add_hour",
+			Name: "date_trunc",
 		},
 		Args: []value.Primary{
-			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
-			value.NewInteger(2),
+			value.NewString("quarter"),
+			value.NewDatetime(time.Date(2012, 11, 30, 23, 59, 59, 0, GetTestLocation())),
 		},
-		Result: value.NewDatetime(time.Date(2012, 2, 3, 11, 18, 15, 123456789, GetTestLocation())),
+		Result: value.NewDatetime(time.Date(2012, 10, 1, 0, 0, 0, 0, GetTestLocation())),
 	},
-}
-
-func TestAddHour(t *testing.T) {
-	testFunction(t, AddHour, addHourTests)
-}
-
-var addMinuteTests = []functionTest{
 	{
-		Name: "AddMinute",
+		Name: "DateTrunc Week Across Month",
 		Function: parser.Function{
-			Name: "add_minute",
+			Name: "date_trunc",
 		},
 		Args: []value.Primary{
-			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
-			value.NewInteger(2),
+			value.NewString("week"),
+			value.NewDatetime(time.Date(2012, 3, 4, 10, 0, 0, 0, GetTestLocation())),
 		},
-		Result: value.NewDatetime(time.Date(2012, 2, 3, 9, 20, 15, 123456789, GetTestLocation())),
+		Result: value.NewDatetime(time.Date(2012, 2, 27, 0, 0, 0, 0, GetTestLocation())),
 	},
-}
-
-func TestAddMinute(t *testing.T) {
-	testFunction(t, AddMinute, addMinuteTests)
-}
-
-var addSecondTests = []functionTest{
 	{
-		Name: "AddSecond",
+		Name: "DateTrunc Datetime Is Null",
 		Function: parser.Function{
-			Name: "add_second",
+			Name: "date_trunc",
 		},
 		Args: []value.Primary{
-			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
-			value.NewInteger(2),
+			value.NewString("day"),
+			value.NewNull(),
 		},
-		Result: value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 17, 123456789, GetTestLocation())),
+		Result: value.NewNull(),
 	},
-}
-
-func TestAddSecond(t *testing.T) {
-	testFunction(t, AddSecond, addSecondTests)
-}
-
-var addMilliTests = []functionTest{
 	{
-		Name: "AddMilli",
+		Name: "DateTrunc Argument Length Error",
 		Function: parser.Function{
-			Name: "add_milli",
+			Name: "date_trunc",
 		},
 		Args: []value.Primary{
-			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
-			value.NewInteger(2),
+			value.NewString("day"),
 		},
-		Result: value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 125456789, GetTestLocation())),
+		Error: "function date_trunc takes exactly 2 arguments",
 	},
-}
-
-func TestAddMilli(t *testing.T) {
-	testFunction(t, AddMilli, addMilliTests)
-}
-
-var addMicroTests = []functionTest{
 	{
-		Name: "AddMicro",
+		Name: "DateTrunc Unit Is Null",
 		Function: parser.Function{
-			Name: "add_micro",
+			Name: "date_trunc",
 		},
 		Args: []value.Primary{
+			value.NewNull(),
 			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
-			value.NewInteger(2),
 		},
-		Result: value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123458789, GetTestLocation())),
+		Error: "the first argument must be a string for function date_trunc",
 	},
-}
-
-func TestAddMicro(t *testing.T) {
-	testFunction(t, AddMicro, addMicroTests)
-}
-
-var addNanoTests = []functionTest{
 	{
-		Name: "AddNano",
+		Name: "DateTrunc Invalid Unit",
 		Function: parser.Function{
-			Name: "add_nano",
+			Name: "date_trunc",
 		},
 		Args: []value.Primary{
+			value.NewString("fortnight"),
 			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
-			value.NewInteger(2),
 		},
-		Result: value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456791, GetTestLocation())),
+		Error: "the first argument must be one of YEAR, QUARTER, MONTH, WEEK, DAY, HOUR or MINUTE for function date_trunc",
 	},
 }
 
-func TestAddNano(t *testing.T) {
-	testFunction(t, AddNano, addNanoTests)
+func TestDateTrunc(t *testing.T) {
+	testFunction(t, DateTrunc, dateTruncTests)
 }
 
-var truncMonthTests = []functionTest{
+var dateDiffTests = []functionTest{
 	{
-		Name: "TruncMonth",
+		Name: "DateDiff",
 		Function: parser.Function{
-			Name: "trunc_month",
+			Name: "date_diff",
 		},
 		Args: []value.Primary{
 			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
+			value.NewDatetime(time.Date(2012, 2, 5, 1, 18, 55, 123456789, GetTestLocation())),
 		},
-		Result: value.NewDatetime(time.Date(2012, 1, 1, 0, 0, 0, 0, GetTestLocation())),
-	},
-	{
-		Name: "TruncMonth Argument Error",
-		Function: parser.Function{
-			Name: "trunc_month",
-		},
-		Args:  []value.Primary{},
-		Error: "function trunc_month takes exactly 1 argument",
+		Result: value.NewInteger(-2),
 	},
 	{
-		Name: "TruncMonth Argument Is Null",
+		Name: "DateDiff Datetime1 is Null",
 		Function: parser.Function{
-			Name: "trunc_month",
+			Name: "date_diff",
 		},
 		Args: []value.Primary{
 			value.NewNull(),
+			value.NewDatetime(time.Date(2012, 2, 5, 1, 18, 55, 123456789, GetTestLocation())),
 		},
 		Result: value.NewNull(),
 	},
-}
-
-func TestTruncMonth(t *testing.T) {
-	testFunction(t, TruncMonth, truncMonthTests)
-}
-
-var truncDayTests = []functionTest{
 	{
-		Name: "TruncDay",
+		Name: "DateDiff Datetime2 is Null",
 		Function: parser.Function{
-			Name: "trunc_day",
+			Name: "date_diff",
 		},
 		Args: []value.Primary{
 			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
+			value.NewNull(),
 		},
-		Result: value.NewDatetime(time.Date(2012, 2, 1, 0, 0, 0, 0, GetTestLocation())),
+		Result: value.NewNull(),
 	},
-}
-
-func TestTruncDay(t *testing.T) {
-	testFunction(t, TruncDay, truncDayTests)
-}
-
-var truncTimeTests = []functionTest{
 	{
-		Name: "TruncTime",
-		Function: parser.Function{
-			Name: "trunc_time",
-		},
-		Args: []value.Primary{
-			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
+		Name: "DateDiff Arguments Error",
+		Function: parser.Function{
+			Name: "date_diff",
 		},
-		Result: value.NewDatetime(time.Date(2012, 2, 3, 0, 0, 0, 0, GetTestLocation())),
+		Args:  []value.Primary{},
+		Error: "function date_diff takes 2 or 3 arguments",
 	},
-}
-
-func TestTruncTime(t *testing.T) {
-	testFunction(t, TruncTime, truncTimeTests)
-}
-
-var truncMinuteTests = []functionTest{
 	{
-		Name: "TruncMinute",
+		Name: "DateDiff Unit Month",
 		Function: parser.Function{
-			Name: "trunc_minute",
+			Name: "date_diff",
 		},
 		Args: []value.Primary{
-			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
+			value.NewString("month"),
+			value.NewDatetime(time.Date(2012, 1, 31, 23, 59, 59, 0, GetTestLocation())),
+			value.NewDatetime(time.Date(2012, 2, 1, 0, 0, 0, 0, GetTestLocation())),
 		},
-		Result: value.NewDatetime(time.Date(2012, 2, 3, 9, 0, 0, 0, GetTestLocation())),
+		Result: value.NewInteger(1),
 	},
 	{
-		Name: "TruncMinute Argument Error",
+		Name: "DateDiff Unit Year",
 		Function: parser.Function{
-			Name: "trunc_minute",
+			Name: "date_diff",
 		},
-		Args:  []value.Primary{},
-		Error: "function trunc_minute takes exactly 1 argument",
+		Args: []value.Primary{
+			value.NewString("YEAR"),
+			value.NewDatetime(time.Date(2011, 12, 31, 0, 0, 0, 0, GetTestLocation())),
+			value.NewDatetime(time.Date(2012, 2, 1, 0, 0, 0, 0, GetTestLocation())),
+		},
+		Result: value.NewInteger(1),
 	},
 	{
-		Name: "TruncMinute Argument Is Null",
+		Name: "DateDiff Unit Quarter",
 		Function: parser.Function{
-			Name: "trunc_minute",
+			Name: "date_diff",
 		},
 		Args: []value.Primary{
-			value.NewNull(),
+			value.NewString("quarter"),
+			value.NewDatetime(time.Date(2012, 3, 31, 0, 0, 0, 0, GetTestLocation())),
+			value.NewDatetime(time.Date(2011, 12, 1, 0, 0, 0, 0, GetTestLocation())),
 		},
-		Result: value.NewNull(),
+		Result: value.NewInteger(-1),
 	},
-}
-
-func TestTruncMinute(t *testing.T) {
-	testFunction(t, TruncMinute, truncMinuteTests)
-}
-
-var truncSecondTests = []functionTest{
 	{
-		Name: "TruncSecond",
+		Name: "DateDiff Unit Week",
 		Function: parser.Function{
-			Name: "trunc_second",
+			Name: "date_diff",
 		},
 		Args: []value.Primary{
-			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
+			value.NewString("week"),
+			value.NewDatetime(time.Date(2012, 2, 5, 23, 0, 0, 0, GetTestLocation())),
+			value.NewDatetime(time.Date(2012, 2, 6, 1, 0, 0, 0, GetTestLocation())),
 		},
-		Result: value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 0, 0, GetTestLocation())),
+		Result: value.NewInteger(1),
 	},
-}
-
-func TestTruncSecond(t *testing.T) {
-	testFunction(t, TruncSecond, truncSecondTests)
-}
-
-var truncMilliTests = []functionTest{
 	{
-		Name: "TruncMilli",
+		Name: "DateDiff Unit Day",
 		Function: parser.Function{
-			Name: "trunc_milli",
+			Name: "date_diff",
 		},
 		Args: []value.Primary{
-			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
+			value.NewString("day"),
+			value.NewDatetime(time.Date(2012, 1, 31, 23, 59, 59, 0, GetTestLocation())),
+			value.NewDatetime(time.Date(2012, 2, 1, 0, 0, 0, 0, GetTestLocation())),
 		},
-		Result: value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 0, GetTestLocation())),
+		Result: value.NewInteger(1),
 	},
-}
-
-func TestTruncMilli(t *testing.T) {
-	testFunction(t, TruncMilli, truncMilliTests)
-}
-
-var truncMicroTests = []functionTest{
 	{
-		Name: "TruncMicro",
+		Name: "DateDiff Unit Hour",
 		Function: parser.Function{
-			Name: "trunc_micro",
+			Name: "date_diff",
 		},
 		Args: []value.Primary{
-			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
+			value.NewString("hour"),
+			value.NewDatetime(time.Date(2012, 2, 3, 9, 59, 0, 0, GetTestLocation())),
+			value.NewDatetime(time.Date(2012, 2, 3, 11, 0, 0, 0, GetTestLocation())),
 		},
-		Result: value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123000000, GetTestLocation())),
+		Result: value.NewInteger(2),
 	},
-}
-
-func TestTruncateMicro(t *testing.T) {
-	testFunction(t, TruncMicro, truncMicroTests)
-}
-
-var truncNanoTests = []functionTest{
 	{
-		Name: "TruncNano",
+		Name: "DateDiff Unit Minute",
 		Function: parser.Function{
-			Name: "trunc_nano",
+			Name: "date_diff",
 		},
 		Args: []value.Primary{
-			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
+			value.NewString("minute"),
+			value.NewDatetime(time.Date(2012, 1, 31, 23, 59, 59, 0, GetTestLocation())),
+			value.NewDatetime(time.Date(2012, 2, 1, 0, 0, 0, 0, GetTestLocation())),
 		},
-		Result: value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456000, GetTestLocation())),
+		Result: value.NewInteger(1),
 	},
-}
-
-func TestTruncateNano(t *testing.T) {
-	testFunction(t, TruncNano, truncNanoTests)
-}
-
-var dateDiffTests = []functionTest{
 	{
-		Name: "DateDiff",
+		Name: "DateDiff Unit Second",
 		Function: parser.Function{
 			Name: "date_diff",
 		},
 		Args: []value.Primary{
-			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
-			value.NewDatetime(time.Date(2012, 2, 5, 1, 18, 55, 123456789, GetTestLocation())),
+			value.NewString("second"),
+			value.NewDatetime(time.Date(2012, 1, 31, 23, 59, 59, 0, GetTestLocation())),
+			value.NewDatetime(time.Date(2012, 2, 1, 0, 0, 0, 0, GetTestLocation())),
 		},
-		Result: value.NewInteger(-2),
+		Result: value.NewInteger(1),
 	},
 	{
-		Name: "DateDiff Datetime1 is Null",
+		Name: "DateDiff Unit Datetime is Null",
 		Function: parser.Function{
 			Name: "date_diff",
 		},
 		Args: []value.Primary{
+			value.NewString("day"),
 			value.NewNull(),
-			value.NewDatetime(time.Date(2012, 2, 5, 1, 18, 55, 123456789, GetTestLocation())),
+			value.NewDatetime(time.Date(2012, 2, 1, 0, 0, 0, 0, GetTestLocation())),
 		},
 		Result: value.NewNull(),
 	},
 	{
-		Name: "DateDiff Datetime2 is Null",
+		Name: "DateDiff Unit is Null",
 		Function: parser.Function{
 			Name: "date_diff",
 		},
 		Args: []value.Primary{
-			value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, GetTestLocation())),
 			value.NewNull(),
+			value.NewDatetime(time.Date(2012, 1, 31, 23, 59, 59, 0, GetTestLocation())),
+			value.NewDatetime(time.Date(2012, 2, 1, 0, 0, 0, 0, GetTestLocation())),
 		},
-		Result: value.NewNull(),
+		Error: "the first argument must be a string for function date_diff",
 	},
 	{
-		Name: "DateDiff Arguments Error",
+		Name: "DateDiff Invalid Unit",
 		Function: parser.Function{
 			Name: "date_diff",
 		},
-		Args:  []value.Primary{},
-		Error: "function date_diff takes exactly 2 arguments",
+		Args: []value.Primary{
+			value.NewString("decade"),
+			value.NewDatetime(time.Date(2012, 1, 31, 23, 59, 59, 0, GetTestLocation())),
+			value.NewDatetime(time.Date(2012, 2, 1, 0, 0, 0, 0, GetTestLocation())),
+		},
+		Error: "the first argument must be one of YEAR, QUARTER, MONTH, WEEK, DAY, HOUR, MINUTE or SECOND for function date_diff",
 	},
 }
 
@@ -3364,6 +6239,67 @@ func TestNow(t *testing.T) {
 	}
 }
 
+var nextValTests = []struct {
+	Name     string
+	Function parser.Function
+	Args     []value.Primary
+	Result   value.Primary
+	Error    string
+}{
+	{
+		Name:     "NextVal",
+		Function: parser.Function{Name: "nextval"},
+		Args:     []value.Primary{value.NewString("seq")},
+		Result:   value.NewInteger(1),
+	},
+	{
+		Name:     "NextVal Increment",
+		Function: parser.Function{Name: "nextval"},
+		Args:     []value.Primary{value.NewString("SEQ")},
+		Result:   value.NewInteger(2),
+	},
+	{
+		Name:     "NextVal Arguments Error",
+		Function: parser.Function{Name: "nextval"},
+		Args:     []value.Primary{},
+		Error:    "function nextval takes exactly 1 argument",
+	},
+	{
+		Name:     "NextVal Undeclared Error",
+		Function: parser.Function{Name: "nextval"},
+		Args:     []value.Primary{value.NewString("notexist")},
+		Error:    "sequence notexist is undeclared",
+	},
+}
+
+func TestNextVal(t *testing.T) {
+	defer func() {
+		TestTx.sequences = NewSequenceMap()
+	}()
+
+	filter := NewFilter(TestTx)
+	_ = TestTx.sequences.Declare(context.Background(), filter, parser.SequenceDeclaration{Sequence: parser.Identifier{Literal: "seq"}})
+
+	for _, v := range nextValTests {
+		result, err := NextVal(filter, v.Function, v.Args)
+		if err != nil {
+			if len(v.Error) < 1 {
+				t.Errorf("%s: unexpected error %q", v.Name, err)
+			} else if err.Error() != v.Error {
+				t.Errorf("%s: error %q, want error %q", v.Name, err.Error(), v.Error)
+			}
+			continue
+		}
+		if 0 < len(v.Error) {
+			t.Errorf("%s: no error, want error %q", v.Name, v.Error)
+			continue
+		}
+		if !reflect.DeepEqual(result, v.Result) {
+			t.Errorf("%s: result = %s, want %s", v.Name, result, v.Result)
+		}
+	}
+}
+
 var jsonObjectTests = []struct {
 	Name     string
 	Function parser.Function
@@ -3393,7 +6329,7 @@ var jsonObjectTests = []struct {
 				},
 			},
 		},
-		Result: value.NewString("{\"column1\":11}"),
+		Result: value.NewJson(txjson.Object{Members: []txjson.ObjectMember{{Key: "column1", Value: txjson.Integer(11)}}}),
 	},
 	{
 		Name: "Json Object with All Columns",
@@ -3414,7 +6350,7 @@ var jsonObjectTests = []struct {
 				},
 			},
 		},
-		Result: value.NewString("{\"column1\":11,\"column2\":{\"child1\":12}}"),
+		Result: value.NewJson(txjson.Object{Members: []txjson.ObjectMember{{Key: "column1", Value: txjson.Integer(11)}, {Key: "column2", Value: txjson.Object{Members: []txjson.ObjectMember{{Key: "child1", Value: txjson.Integer(12)}}}}}}),
 	},
 	{
 		Name: "Json Object Unpermitted Statement Error",
@@ -3471,3 +6407,183 @@ func TestJsonObject(t *testing.T) {
 		}
 	}
 }
+
+var groupingTestView = &View{
+	Header: []HeaderField{
+		{
+			View:        "table1",
+			Column:      "column1",
+			Number:      1,
+			IsFromTable: true,
+			IsGroupKey:  true,
+		},
+		{
+			View:        "table1",
+			Column:      "column2",
+			Number:      2,
+			IsFromTable: true,
+		},
+		{
+			Column: GroupingColumnPrefix + "0",
+		},
+	},
+	RecordSet: []Record{
+		{
+			NewGroupCell([]value.Primary{value.NewString("group1"), value.NewString("group1")}),
+			NewGroupCell([]value.Primary{value.NewInteger(1), value.NewInteger(2)}),
+			NewCell(value.NewInteger(0)),
+		},
+		{
+			NewGroupCell([]value.Primary{value.NewNull(), value.NewNull()}),
+			NewGroupCell([]value.Primary{value.NewInteger(1), value.NewInteger(2)}),
+			NewCell(value.NewInteger(1)),
+		},
+	},
+	isGrouped: true,
+}
+
+var groupingTests = []struct {
+	Name     string
+	Function parser.Function
+	Filter   *Filter
+	Result   value.Primary
+	Error    string
+}{
+	{
+		Name: "Grouping",
+		Function: parser.Function{
+			Name: "grouping",
+			Args: []parser.QueryExpression{
+				parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+			},
+		},
+		Filter: &Filter{
+			records: []filterRecord{
+				{
+					view:        groupingTestView,
+					recordIndex: 0,
+				},
+			},
+		},
+		Result: value.NewInteger(0),
+	},
+	{
+		Name: "Grouping Rolled Up Field",
+		Function: parser.Function{
+			Name: "grouping",
+			Args: []parser.QueryExpression{
+				parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+			},
+		},
+		Filter: &Filter{
+			records: []filterRecord{
+				{
+					view:        groupingTestView,
+					recordIndex: 1,
+				},
+			},
+		},
+		Result: value.NewInteger(1),
+	},
+	{
+		Name: "Grouping Multiple Arguments",
+		Function: parser.Function{
+			Name: "grouping",
+			Args: []parser.QueryExpression{
+				parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+				parser.ColumnNumber{View: parser.Identifier{Literal: "table1"}, Number: value.NewInteger(1)},
+			},
+		},
+		Filter: &Filter{
+			records: []filterRecord{
+				{
+					view:        groupingTestView,
+					recordIndex: 1,
+				},
+			},
+		},
+		Result: value.NewInteger(3),
+	},
+	{
+		Name: "Grouping Arguments Error",
+		Function: parser.Function{
+			Name: "grouping",
+		},
+		Filter: NewFilter(TestTx),
+		Error:  "function grouping takes at least 1 argument",
+	},
+	{
+		Name: "Grouping Unpermitted Statement Error",
+		Function: parser.Function{
+			Name: "grouping",
+			Args: []parser.QueryExpression{
+				parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+			},
+		},
+		Filter: NewFilter(TestTx),
+		Error:  "function grouping cannot be used as a statement",
+	},
+	{
+		Name: "Grouping Not Grouping Records Error",
+		Function: parser.Function{
+			Name: "grouping",
+			Args: []parser.QueryExpression{
+				parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+			},
+		},
+		Filter: &Filter{
+			records: []filterRecord{
+				{
+					view: &View{
+						Header: NewHeader("table1", []string{"column1"}),
+						RecordSet: []Record{
+							NewRecord([]value.Primary{value.NewInteger(1)}),
+						},
+					},
+					recordIndex: 0,
+				},
+			},
+		},
+		Error: "function grouping cannot aggregate not grouping records",
+	},
+	{
+		Name: "Grouping Field Not Group Key Error",
+		Function: parser.Function{
+			Name: "grouping",
+			Args: []parser.QueryExpression{
+				parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+			},
+		},
+		Filter: &Filter{
+			records: []filterRecord{
+				{
+					view:        groupingTestView,
+					recordIndex: 0,
+				},
+			},
+		},
+		Error: "field column2 is not a group key",
+	},
+}
+
+func TestGrouping(t *testing.T) {
+	for _, v := range groupingTests {
+		v.Filter.tx = TestTx
+		result, err := Grouping(v.Filter, v.Function)
+		if err != nil {
+			if len(v.Error) < 1 {
+				t.Errorf("%s: unexpected error %q", v.Name, err)
+			} else if err.Error() != v.Error {
+				t.Errorf("%s: error %q, want error %q", v.Name, err.Error(), v.Error)
+			}
+			continue
+		}
+		if 0 < len(v.Error) {
+			t.Errorf("%s: no error, want error %q", v.Name, v.Error)
+			continue
+		}
+		if !reflect.DeepEqual(result, v.Result) {
+			t.Errorf("%s: result = %s, want %s", v.Name, result, v.Result)
+		}
+	}
+}
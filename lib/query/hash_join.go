@@ -0,0 +1,167 @@
+package query
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/mithrandie/csvq/lib/cmd"
+	"github.com/mithrandie/csvq/lib/parser"
+
+	"github.com/mithrandie/ternary"
+)
+
+// newJoinHashIndex returns an index that has only a hash table of the records keyed by the values of the fields.
+// If the values of a field cannot be compared by their serialized keys, then nil is returned.
+func newJoinHashIndex(records RecordSet, fieldIndices []int, flags *cmd.Flags) *ViewIndex {
+	types := indexValueTypes(records, fieldIndices, flags)
+	for _, t := range types {
+		if t == indexUnavailable {
+			return nil
+		}
+	}
+
+	idx := &ViewIndex{
+		mutex: &sync.Mutex{},
+		types: types,
+	}
+	idx.buildHashTable(records, fieldIndices, flags)
+	return idx
+}
+
+// joinEqualityFields returns the field indices of the view and the joinView compared by equality in the condition.
+func joinEqualityFields(view *View, joinView *View, condition parser.QueryExpression) ([]int, []int) {
+	if condition == nil {
+		return nil, nil
+	}
+
+	fieldMap := joinEqualityFieldMap(view, joinView, condition)
+	joinViewFieldIndices := make([]int, 0, len(fieldMap))
+	for joinViewIdx := range fieldMap {
+		joinViewFieldIndices = append(joinViewFieldIndices, joinViewIdx)
+	}
+	sort.Ints(joinViewFieldIndices)
+
+	viewFieldIndices := make([]int, len(joinViewFieldIndices))
+	for i, joinViewIdx := range joinViewFieldIndices {
+		viewFieldIndices[i] = fieldMap[joinViewIdx]
+	}
+	return viewFieldIndices, joinViewFieldIndices
+}
+
+// joinHashLookup returns a function to look up the records of the joinView by a record of the view
+// using a hash table built from the records of the joinView.
+// If the condition has no equality comparison between the fields of both views, then nil is returned.
+func joinHashLookup(view *View, joinView *View, condition parser.QueryExpression, flags *cmd.Flags) func(Record) ([]int, bool) {
+	viewFieldIndices, joinViewFieldIndices := joinEqualityFields(view, joinView, condition)
+	if len(viewFieldIndices) < 1 {
+		return nil
+	}
+
+	index := newJoinHashIndex(joinView.RecordSet, joinViewFieldIndices, flags)
+	if index == nil {
+		return nil
+	}
+	return joinRecordLookup(index, viewFieldIndices, flags)
+}
+
+// hashJoinProbingJoinView joins the records using a hash table built from the records of the view,
+// and probing it with each record of the joinView. It is used when the view is smaller than the joinView.
+// If the direction is undefined, then the records are inner joined, otherwise outer joined.
+// The order of the result is the same as that of the join probing the records of the view.
+// If the hash table cannot be built, then false is returned.
+func hashJoinProbingJoinView(ctx context.Context, parentFilter *Filter, view *View, joinView *View, mergedHeader Header, condition parser.QueryExpression, direction int) (RecordSet, bool, error) {
+	joinViewFieldIndices, viewFieldIndices := joinEqualityFields(joinView, view, condition)
+	if len(viewFieldIndices) < 1 {
+		return nil, false, nil
+	}
+	index := newJoinHashIndex(view.RecordSet, viewFieldIndices, parentFilter.tx.Flags)
+	if index == nil {
+		return nil, false, nil
+	}
+	lookup := joinRecordLookup(index, joinViewFieldIndices, parentFilter.tx.Flags)
+
+	viewEmptyRecord := NewEmptyRecord(view.FieldLen())
+
+	gm := NewGoroutineTaskManager(joinView.RecordLen(), CalcMinimumRequired(joinView.RecordLen(), view.RecordLen(), MinimumRequiredPerCPUCore), parentFilter.tx.Flags.CPU)
+	matchedRecordsList := make([][]RecordSet, gm.Number)
+	unmatchedRecordsList := make([]RecordSet, gm.Number)
+
+	for i := 0; i < gm.Number; i++ {
+		gm.Add()
+		go func(thIdx int) {
+			start, end := gm.RecordRange(thIdx)
+			filter := NewFilterForRecord(
+				parentFilter,
+				&View{
+					Tx:        parentFilter.tx,
+					Header:    mergedHeader,
+					RecordSet: make(RecordSet, 1),
+				},
+				0,
+			)
+
+			matchedRecords := make([]RecordSet, view.RecordLen())
+			var unmatchedRecords RecordSet
+
+		HashJoinLoop:
+			for j := start; j < end; j++ {
+				match := false
+				indices, useIndex := joinRecordIndices(lookup, joinView.RecordSet[j])
+				for k := 0; k < joinRecordLen(view, indices, useIndex); k++ {
+					if gm.HasError() || ctx.Err() != nil {
+						break HashJoinLoop
+					}
+
+					i := k
+					if useIndex {
+						i = indices[k]
+					}
+
+					var mergedRecord Record
+					switch direction {
+					case parser.RIGHT:
+						mergedRecord = MergeRecord(joinView.RecordSet[j], view.RecordSet[i])
+					default:
+						mergedRecord = MergeRecord(view.RecordSet[i], joinView.RecordSet[j])
+					}
+					filter.records[0].view.RecordSet[0] = mergedRecord
+
+					primary, e := filter.Evaluate(ctx, condition)
+					if e != nil {
+						gm.SetError(e)
+						break HashJoinLoop
+					}
+					if primary.Ternary() == ternary.TRUE {
+						matchedRecords[i] = append(matchedRecords[i], mergedRecord)
+						match = true
+					}
+				}
+
+				if !match && direction == parser.FULL {
+					unmatchedRecords = append(unmatchedRecords, MergeRecord(viewEmptyRecord, joinView.RecordSet[j]))
+				}
+			}
+
+			matchedRecordsList[thIdx] = matchedRecords
+			unmatchedRecordsList[thIdx] = unmatchedRecords
+			gm.Done()
+		}(i)
+	}
+	gm.Wait()
+
+	if gm.HasError() {
+		return nil, true, gm.Err()
+	}
+	if ctx.Err() != nil {
+		return nil, true, NewContextIsDone(ctx.Err().Error())
+	}
+
+	matchedRecords := matchedRecordsList[0]
+	for _, list := range matchedRecordsList[1:] {
+		for i := range list {
+			matchedRecords[i] = append(matchedRecords[i], list[i]...)
+		}
+	}
+	return mergeJoinedRecords(view, joinView, matchedRecords, MergeRecordSetList(unmatchedRecordsList), direction), true, nil
+}
@@ -0,0 +1,147 @@
+package query
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/mithrandie/csvq/lib/parser"
+	"github.com/mithrandie/csvq/lib/value"
+)
+
+var hashJoinCondition = parser.Comparison{
+	LHS:      parser.FieldReference{View: parser.Identifier{Literal: "table1"}, Column: parser.Identifier{Literal: "column1"}},
+	RHS:      parser.FieldReference{View: parser.Identifier{Literal: "table2"}, Column: parser.Identifier{Literal: "column1"}},
+	Operator: "=",
+}
+
+var hashJoinProbingJoinViewTests = []struct {
+	Name      string
+	JoinView  *View
+	Condition parser.QueryExpression
+	Direction int
+	Applied   bool
+	Result    RecordSet
+}{
+	{
+		Name:      "Hash Join Inner Join",
+		Condition: hashJoinCondition,
+		Applied:   true,
+		Result: RecordSet{
+			NewRecord([]value.Primary{value.NewInteger(1), value.NewString("str1"), value.NewInteger(1), value.NewString("str11")}),
+			NewRecord([]value.Primary{value.NewInteger(2), value.NewString("str2"), value.NewInteger(2), value.NewString("str22")}),
+			NewRecord([]value.Primary{value.NewInteger(2), value.NewString("str2"), value.NewString("2"), value.NewString("str222")}),
+		},
+	},
+	{
+		Name:      "Hash Join Left Outer Join",
+		Condition: hashJoinCondition,
+		Direction: parser.LEFT,
+		Applied:   true,
+		Result: RecordSet{
+			NewRecord([]value.Primary{value.NewInteger(1), value.NewString("str1"), value.NewInteger(1), value.NewString("str11")}),
+			NewRecord([]value.Primary{value.NewInteger(2), value.NewString("str2"), value.NewInteger(2), value.NewString("str22")}),
+			NewRecord([]value.Primary{value.NewInteger(2), value.NewString("str2"), value.NewString("2"), value.NewString("str222")}),
+			NewRecord([]value.Primary{value.NewInteger(5), value.NewString("str5"), value.NewNull(), value.NewNull()}),
+		},
+	},
+	{
+		Name:      "Hash Join Right Outer Join",
+		Condition: hashJoinCondition,
+		Direction: parser.RIGHT,
+		Applied:   true,
+		Result: RecordSet{
+			NewRecord([]value.Primary{value.NewInteger(1), value.NewString("str11"), value.NewInteger(1), value.NewString("str1")}),
+			NewRecord([]value.Primary{value.NewInteger(2), value.NewString("str22"), value.NewInteger(2), value.NewString("str2")}),
+			NewRecord([]value.Primary{value.NewString("2"), value.NewString("str222"), value.NewInteger(2), value.NewString("str2")}),
+			NewRecord([]value.Primary{value.NewNull(), value.NewNull(), value.NewInteger(5), value.NewString("str5")}),
+		},
+	},
+	{
+		Name:      "Hash Join Full Outer Join",
+		Condition: hashJoinCondition,
+		Direction: parser.FULL,
+		Applied:   true,
+		Result: RecordSet{
+			NewRecord([]value.Primary{value.NewInteger(1), value.NewString("str1"), value.NewInteger(1), value.NewString("str11")}),
+			NewRecord([]value.Primary{value.NewInteger(2), value.NewString("str2"), value.NewInteger(2), value.NewString("str22")}),
+			NewRecord([]value.Primary{value.NewInteger(2), value.NewString("str2"), value.NewString("2"), value.NewString("str222")}),
+			NewRecord([]value.Primary{value.NewInteger(5), value.NewString("str5"), value.NewNull(), value.NewNull()}),
+			NewRecord([]value.Primary{value.NewNull(), value.NewNull(), value.NewInteger(3), value.NewString("str33")}),
+			NewRecord([]value.Primary{value.NewNull(), value.NewNull(), value.NewNull(), value.NewString("strnull")}),
+		},
+	},
+	{
+		Name: "Hash Join Not Equality Condition",
+		Condition: parser.Comparison{
+			LHS:      parser.FieldReference{View: parser.Identifier{Literal: "table1"}, Column: parser.Identifier{Literal: "column1"}},
+			RHS:      parser.FieldReference{View: parser.Identifier{Literal: "table2"}, Column: parser.Identifier{Literal: "column1"}},
+			Operator: "<",
+		},
+		Applied: false,
+	},
+	{
+		Name: "Hash Join Probe Values Not Comparable by Keys",
+		JoinView: &View{
+			Header: NewHeader("table2", []string{"column1", "column3"}),
+			RecordSet: RecordSet{
+				NewRecord([]value.Primary{value.NewInteger(1), value.NewString("str11")}),
+				NewRecord([]value.Primary{value.NewString("abc"), value.NewString("strabc")}),
+				NewRecord([]value.Primary{value.NewInteger(2), value.NewString("str22")}),
+				NewRecord([]value.Primary{value.NewInteger(3), value.NewString("str33")}),
+			},
+		},
+		Condition: hashJoinCondition,
+		Applied:   true,
+		Result: RecordSet{
+			NewRecord([]value.Primary{value.NewInteger(1), value.NewString("str1"), value.NewInteger(1), value.NewString("str11")}),
+			NewRecord([]value.Primary{value.NewInteger(2), value.NewString("str2"), value.NewInteger(2), value.NewString("str22")}),
+		},
+	},
+}
+
+func TestHashJoinProbingJoinView(t *testing.T) {
+	view := &View{
+		Header: NewHeader("table1", []string{"column1", "column2"}),
+		RecordSet: RecordSet{
+			NewRecord([]value.Primary{value.NewInteger(1), value.NewString("str1")}),
+			NewRecord([]value.Primary{value.NewInteger(2), value.NewString("str2")}),
+			NewRecord([]value.Primary{value.NewInteger(5), value.NewString("str5")}),
+		},
+	}
+	joinView := &View{
+		Header: NewHeader("table2", []string{"column1", "column3"}),
+		RecordSet: RecordSet{
+			NewRecord([]value.Primary{value.NewInteger(2), value.NewString("str22")}),
+			NewRecord([]value.Primary{value.NewInteger(1), value.NewString("str11")}),
+			NewRecord([]value.Primary{value.NewString("2"), value.NewString("str222")}),
+			NewRecord([]value.Primary{value.NewInteger(3), value.NewString("str33")}),
+			NewRecord([]value.Primary{value.NewNull(), value.NewString("strnull")}),
+		},
+	}
+
+	for _, v := range hashJoinProbingJoinViewTests {
+		jv := joinView
+		if v.JoinView != nil {
+			jv = v.JoinView
+		}
+
+		mergedHeader := MergeHeader(view.Header, jv.Header)
+		if v.Direction == parser.RIGHT {
+			mergedHeader = MergeHeader(jv.Header, view.Header)
+		}
+
+		result, applied, err := hashJoinProbingJoinView(context.Background(), NewFilter(TestTx), view, jv, mergedHeader, v.Condition, v.Direction)
+		if err != nil {
+			t.Errorf("%s: unexpected error %q", v.Name, err)
+			continue
+		}
+		if applied != v.Applied {
+			t.Errorf("%s: applied = %t, want %t", v.Name, applied, v.Applied)
+			continue
+		}
+		if !reflect.DeepEqual(result, v.Result) {
+			t.Errorf("%s: result = %s, want %s", v.Name, result, v.Result)
+		}
+	}
+}
@@ -8,6 +8,8 @@ import (
 )
 
 const InternalIdColumn = "@__internal_id"
+const GroupingColumnPrefix = "@__grouping_"
+const GroupingValuesColumnPrefix = "@__grouping_values_"
 
 type HeaderField struct {
 	View         string
@@ -214,6 +216,15 @@ func (h Header) ContainsInternalId(viewName string) (int, error) {
 	return h.Contains(fieldRef)
 }
 
+func (h Header) ContainsGroupingColumn(column string) (int, bool) {
+	for i, f := range h {
+		if !f.IsFromTable && f.Column == column {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
 func (h Header) Update(reference string, fields []parser.QueryExpression) error {
 	if fields != nil {
 		if len(fields) != h.Len() {
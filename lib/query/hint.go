@@ -0,0 +1,81 @@
+package query
+
+import (
+	"strconv"
+
+	"github.com/mithrandie/csvq/lib/parser"
+)
+
+// StatementHints returns the hints written immediately after the keyword of the statement changing tables,
+// or the hints written in the first select clause of the query in the statement.
+// Hints written in the other select clauses, such as in subqueries, are ignored.
+func StatementHints(stmt parser.Statement) []parser.Hint {
+	var query parser.QueryExpression
+
+	switch stmt.(type) {
+	case parser.SelectQuery:
+		query = stmt.(parser.SelectQuery)
+	case parser.InsertQuery:
+		if hints := stmt.(parser.InsertQuery).Hints; 0 < len(hints) {
+			return hints
+		}
+		query = stmt.(parser.InsertQuery).Query
+	case parser.ReplaceQuery:
+		if hints := stmt.(parser.ReplaceQuery).Hints; 0 < len(hints) {
+			return hints
+		}
+		query = stmt.(parser.ReplaceQuery).Query
+	case parser.UpdateQuery:
+		return stmt.(parser.UpdateQuery).Hints
+	case parser.DeleteQuery:
+		return stmt.(parser.DeleteQuery).Hints
+	case parser.CreateTable:
+		query = stmt.(parser.CreateTable).Query
+	case parser.ViewDeclaration:
+		query = stmt.(parser.ViewDeclaration).Query
+	}
+
+	for query != nil {
+		switch e := query.(type) {
+		case parser.SelectQuery:
+			query = e.SelectEntity
+		case parser.SelectSet:
+			query = e.LHS
+		case parser.Subquery:
+			query = e.Query
+		case parser.SelectEntity:
+			query = e.SelectClause
+		case parser.SelectClause:
+			return e.Hints
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// hintedCPU returns the number of cores specified by the CPU hint.
+func hintedCPU(hints []parser.Hint) (int, bool) {
+	for _, hint := range hints {
+		if hint.Name != "CPU" || len(hint.Args) != 1 {
+			continue
+		}
+		if i, err := strconv.Atoi(hint.Args[0]); err == nil {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// hintedWaitTimeout returns the limit of the waiting time in seconds specified by the WAIT_TIMEOUT hint.
+func hintedWaitTimeout(hints []parser.Hint) (float64, bool) {
+	for _, hint := range hints {
+		if hint.Name != "WAIT_TIMEOUT" || len(hint.Args) != 1 {
+			continue
+		}
+		if f, err := strconv.ParseFloat(hint.Args[0], 64); err == nil {
+			return f, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,83 @@
+package query
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mithrandie/csvq/lib/parser"
+)
+
+var statementHintsTests = []struct {
+	Statement   string
+	Hints       []parser.Hint
+	CPU         int
+	WaitTimeout float64
+}{
+	{
+		Statement: "SELECT /*+ CPU(4) */ * FROM table1",
+		Hints:     []parser.Hint{{Name: "CPU", Args: []string{"4"}}},
+		CPU:       4,
+	},
+	{
+		Statement: "WITH t AS (SELECT /*+ CPU(2) */ 1) (SELECT /*+ NO_CACHE CPU(3) */ 1) UNION SELECT /*+ CPU(4) */ 2",
+		Hints:     []parser.Hint{{Name: "NO_CACHE"}, {Name: "CPU", Args: []string{"3"}}},
+		CPU:       3,
+	},
+	{
+		Statement: "INSERT INTO table1 SELECT /*+ CPU(2) */ * FROM table2",
+		Hints:     []parser.Hint{{Name: "CPU", Args: []string{"2"}}},
+		CPU:       2,
+	},
+	{
+		Statement: "CREATE TABLE newtable AS SELECT /*+ CPU(a) */ 1",
+		Hints:     []parser.Hint{{Name: "CPU", Args: []string{"a"}}},
+	},
+	{
+		Statement: "SELECT * FROM (SELECT /*+ CPU(2) */ 1) t",
+	},
+	{
+		Statement: "UPDATE table1 SET column1 = (SELECT /*+ CPU(2) */ 1)",
+	},
+	{
+		Statement:   "UPDATE /*+ WAIT_TIMEOUT(0.5) */ table1 SET column1 = 1",
+		Hints:       []parser.Hint{{Name: "WAIT_TIMEOUT", Args: []string{"0.5"}}},
+		WaitTimeout: 0.5,
+	},
+	{
+		Statement:   "DELETE /*+ CPU(2) WAIT_TIMEOUT(3) */ FROM table1",
+		Hints:       []parser.Hint{{Name: "CPU", Args: []string{"2"}}, {Name: "WAIT_TIMEOUT", Args: []string{"3"}}},
+		CPU:         2,
+		WaitTimeout: 3,
+	},
+	{
+		Statement:   "INSERT /*+ WAIT_TIMEOUT(1) */ INTO table1 SELECT /*+ CPU(2) */ * FROM table2",
+		Hints:       []parser.Hint{{Name: "WAIT_TIMEOUT", Args: []string{"1"}}},
+		WaitTimeout: 1,
+	},
+	{
+		Statement: "REPLACE /*+ WAIT_TIMEOUT() */ INTO table1 USING (column1) VALUES (1)",
+		Hints:     []parser.Hint{{Name: "WAIT_TIMEOUT", Args: []string{}}},
+	},
+}
+
+func TestStatementHints(t *testing.T) {
+	for _, v := range statementHintsTests {
+		statements, _, err := parser.Parse(v.Statement, "", nil, false)
+		if err != nil {
+			t.Fatalf("%s: unexpected parse error %q", v.Statement, err)
+		}
+
+		hints := StatementHints(statements[0])
+		if !reflect.DeepEqual(hints, v.Hints) {
+			t.Errorf("%s: hints = %v, want %v", v.Statement, hints, v.Hints)
+		}
+		cpu, ok := hintedCPU(hints)
+		if ok != (0 < v.CPU) || cpu != v.CPU {
+			t.Errorf("%s: cpu = %d, %t, want %d", v.Statement, cpu, ok, v.CPU)
+		}
+		waitTimeout, ok := hintedWaitTimeout(hints)
+		if ok != (0 < v.WaitTimeout) || waitTimeout != v.WaitTimeout {
+			t.Errorf("%s: wait timeout = %f, %t, want %f", v.Statement, waitTimeout, ok, v.WaitTimeout)
+		}
+	}
+}
@@ -10,13 +10,27 @@ import (
 type InlineTableNodes []InlineTableMap
 
 func (list InlineTableNodes) Set(ctx context.Context, parentFilter *Filter, inlineTable parser.InlineTable) error {
+	if inlineTable.IsNotMaterialized() {
+		filter := parentFilter.CreateNode()
+		filter.inlineTables = append(InlineTableNodes{list[0].Copy()}, list[1:]...)
+		parentFilter = filter
+	}
 	return list[0].Set(ctx, parentFilter, inlineTable)
 }
 
-func (list InlineTableNodes) Get(name parser.Identifier) (*View, error) {
+func (list InlineTableNodes) Exists(name parser.Identifier) bool {
+	for _, m := range list {
+		if m.Exists(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (list InlineTableNodes) Get(ctx context.Context, name parser.Identifier) (*View, error) {
 	for _, m := range list {
-		if view, err := m.Get(name); err == nil {
-			return view, nil
+		if m.Exists(name) {
+			return m.Get(ctx, name)
 		}
 	}
 	return nil, NewUndefinedInLineTableError(name)
@@ -38,37 +52,70 @@ type InlineTableMap map[string]*View
 
 func (it InlineTableMap) Set(ctx context.Context, parentFilter *Filter, inlineTable parser.InlineTable) error {
 	uname := strings.ToUpper(inlineTable.Name.Literal)
-	if _, err := it.Get(inlineTable.Name); err == nil {
+	if it.Exists(inlineTable.Name) {
 		return NewInLineTableRedefinedError(inlineTable.Name)
 	}
 
-	filter := parentFilter.CreateNode()
-	if inlineTable.IsRecursive() {
-		filter.recursiveTable = &inlineTable
-	}
-	view, err := Select(ctx, filter, inlineTable.Query)
-	if err != nil {
-		return err
+	if inlineTable.IsNotMaterialized() {
+		it[uname] = &View{
+			Tx:          parentFilter.tx,
+			Filter:      parentFilter,
+			inlineTable: &inlineTable,
+		}
+		return nil
 	}
 
-	err = view.Header.Update(inlineTable.Name.Literal, inlineTable.Fields)
+	view, err := selectInlineTable(ctx, parentFilter, inlineTable)
 	if err != nil {
-		if _, ok := err.(*FieldLengthNotMatchError); ok {
-			return NewInlineTableFieldLengthError(inlineTable.Query, inlineTable.Name, len(inlineTable.Fields))
-		}
 		return err
 	}
-
-	view.FileInfo = nil
 	it[uname] = view
 
 	return nil
 }
 
-func (it InlineTableMap) Get(name parser.Identifier) (*View, error) {
+func (it InlineTableMap) Copy() InlineTableMap {
+	m := make(InlineTableMap, len(it))
+	for k, v := range it {
+		m[k] = v
+	}
+	return m
+}
+
+func (it InlineTableMap) Exists(name parser.Identifier) bool {
+	_, ok := it[strings.ToUpper(name.Literal)]
+	return ok
+}
+
+func (it InlineTableMap) Get(ctx context.Context, name parser.Identifier) (*View, error) {
 	uname := strings.ToUpper(name.Literal)
 	if view, ok := it[uname]; ok {
+		if view.inlineTable != nil {
+			return selectInlineTable(ctx, view.Filter, *view.inlineTable)
+		}
 		return view.Copy(), nil
 	}
 	return nil, NewUndefinedInLineTableError(name)
 }
+
+func selectInlineTable(ctx context.Context, parentFilter *Filter, inlineTable parser.InlineTable) (*View, error) {
+	filter := parentFilter.CreateNode()
+	if inlineTable.IsRecursive() {
+		filter.recursiveTable = &inlineTable
+	}
+	view, err := Select(ctx, filter, inlineTable.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	err = view.Header.Update(inlineTable.Name.Literal, inlineTable.Fields)
+	if err != nil {
+		if _, ok := err.(*FieldLengthNotMatchError); ok {
+			return nil, NewInlineTableFieldLengthError(inlineTable.Query, inlineTable.Name, len(inlineTable.Fields))
+		}
+		return nil, err
+	}
+
+	view.FileInfo = nil
+	return view, nil
+}
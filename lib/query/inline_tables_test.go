@@ -202,7 +202,7 @@ func TestInlineTableNodes_Get(t *testing.T) {
 
 	for _, v := range inlineTableNodesGetTests {
 		_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
-		view, err := list.Get(v.TableName)
+		view, err := list.Get(context.Background(), v.TableName)
 		if err != nil {
 			if len(v.Error) < 1 {
 				t.Errorf("%s: unexpected error %q", v.Name, err)
@@ -802,7 +802,7 @@ func TestInlineTableMap_Get(t *testing.T) {
 	}
 
 	for _, v := range inlineTableMapGetTests {
-		ret, err := it.Get(v.TableName)
+		ret, err := it.Get(context.Background(), v.TableName)
 		if err != nil {
 			if len(v.Error) < 1 {
 				t.Errorf("%s: unexpected error %q", v.Name, err)
@@ -103,13 +103,17 @@ func ParseJoinCondition(join parser.Join, view *View, joinView *View) (parser.Qu
 }
 
 func CrossJoin(ctx context.Context, filter *Filter, view *View, joinView *View) error {
+	if err := checkMemoryLimit(filter, estimateJoinedRecordSetSize(view.RecordLen()*joinView.RecordLen(), view.FieldLen()+joinView.FieldLen())); err != nil {
+		return err
+	}
+
 	mergedHeader := MergeHeader(view.Header, joinView.Header)
 	records := make(RecordSet, view.RecordLen()*joinView.RecordLen())
 
 	if err := NewGoroutineTaskManager(view.RecordLen(), CalcMinimumRequired(view.RecordLen(), joinView.RecordLen(), MinimumRequiredPerCPUCore), filter.tx.Flags.CPU).Run(ctx, func(index int) error {
 		start := index * joinView.RecordLen()
 		for i := 0; i < joinView.RecordLen(); i++ {
-			records[start+i] = append(view.RecordSet[index], joinView.RecordSet[i]...)
+			records[start+i] = MergeRecord(view.RecordSet[index], joinView.RecordSet[i])
 		}
 		return nil
 	}); err != nil {
@@ -119,6 +123,7 @@ func CrossJoin(ctx context.Context, filter *Filter, view *View, joinView *View)
 	view.Header = mergedHeader
 	view.RecordSet = records
 	view.FileInfo = nil
+	view.indexes = nil
 	return nil
 }
 
@@ -129,6 +134,41 @@ func InnerJoin(ctx context.Context, parentFilter *Filter, view *View, joinView *
 
 	mergedHeader := MergeHeader(view.Header, joinView.Header)
 
+	spilled, err := spillJoinView(parentFilter, joinView)
+	if err != nil {
+		return err
+	}
+	if spilled != nil {
+		records, err := joinSpilledRecordSet(ctx, parentFilter, view, joinView, spilled, mergedHeader, condition, parser.TokenUndefined)
+		if err != nil {
+			return err
+		}
+
+		view.Header = mergedHeader
+		view.RecordSet = records
+		view.FileInfo = nil
+		view.indexes = nil
+		return nil
+	}
+
+	lookup := joinIndexLookup(view, joinView, condition, parentFilter.tx.Flags)
+	if lookup == nil && view.RecordLen() < joinView.RecordLen() {
+		records, ok, err := hashJoinProbingJoinView(ctx, parentFilter, view, joinView, mergedHeader, condition, parser.TokenUndefined)
+		if err != nil {
+			return err
+		}
+		if ok {
+			view.Header = mergedHeader
+			view.RecordSet = records
+			view.FileInfo = nil
+			view.indexes = nil
+			return nil
+		}
+	}
+	if lookup == nil {
+		lookup = joinHashLookup(view, joinView, condition, parentFilter.tx.Flags)
+	}
+
 	gm := NewGoroutineTaskManager(view.RecordLen(), CalcMinimumRequired(view.RecordLen(), joinView.RecordLen(), MinimumRequiredPerCPUCore), parentFilter.tx.Flags.CPU)
 	recordsList := make([]RecordSet, gm.Number)
 	for i := 0; i < gm.Number; i++ {
@@ -148,12 +188,18 @@ func InnerJoin(ctx context.Context, parentFilter *Filter, view *View, joinView *
 
 		InnerJoinLoop:
 			for i := start; i < end; i++ {
-				for j := 0; j < joinView.RecordLen(); j++ {
+				indices, useIndex := joinRecordIndices(lookup, view.RecordSet[i])
+				for k := 0; k < joinRecordLen(joinView, indices, useIndex); k++ {
 					if gm.HasError() || ctx.Err() != nil {
 						break InnerJoinLoop
 					}
 
-					mergedRecord := append(view.RecordSet[i], joinView.RecordSet[j]...)
+					j := k
+					if useIndex {
+						j = indices[k]
+					}
+
+					mergedRecord := MergeRecord(view.RecordSet[i], joinView.RecordSet[j])
 					filter.records[0].view.RecordSet[0] = mergedRecord
 
 					primary, e := filter.Evaluate(ctx, condition)
@@ -183,6 +229,7 @@ func InnerJoin(ctx context.Context, parentFilter *Filter, view *View, joinView *
 	view.Header = mergedHeader
 	view.RecordSet = MergeRecordSetList(recordsList)
 	view.FileInfo = nil
+	view.indexes = nil
 	return nil
 }
 
@@ -197,6 +244,47 @@ func OuterJoin(ctx context.Context, parentFilter *Filter, view *View, joinView *
 		view, joinView = joinView, view
 	}
 
+	spilled, err := spillJoinView(parentFilter, joinView)
+	if err != nil {
+		return err
+	}
+	if spilled != nil {
+		records, err := joinSpilledRecordSet(ctx, parentFilter, view, joinView, spilled, mergedHeader, condition, direction)
+		if err != nil {
+			return err
+		}
+
+		if direction == parser.RIGHT {
+			view = joinView
+		}
+		view.Header = mergedHeader
+		view.RecordSet = records
+		view.FileInfo = nil
+		view.indexes = nil
+		return nil
+	}
+
+	lookup := joinIndexLookup(view, joinView, condition, parentFilter.tx.Flags)
+	if lookup == nil && view.RecordLen() < joinView.RecordLen() {
+		records, ok, err := hashJoinProbingJoinView(ctx, parentFilter, view, joinView, mergedHeader, condition, direction)
+		if err != nil {
+			return err
+		}
+		if ok {
+			if direction == parser.RIGHT {
+				view = joinView
+			}
+			view.Header = mergedHeader
+			view.RecordSet = records
+			view.FileInfo = nil
+			view.indexes = nil
+			return nil
+		}
+	}
+	if lookup == nil {
+		lookup = joinHashLookup(view, joinView, condition, parentFilter.tx.Flags)
+	}
+
 	viewEmptyRecord := NewEmptyRecord(view.FieldLen())
 	joinViewEmptyRecord := NewEmptyRecord(joinView.FieldLen())
 
@@ -225,17 +313,23 @@ func OuterJoin(ctx context.Context, parentFilter *Filter, view *View, joinView *
 		OuterJoinLoop:
 			for i := start; i < end; i++ {
 				match := false
-				for j := 0; j < joinView.RecordLen(); j++ {
+				indices, useIndex := joinRecordIndices(lookup, view.RecordSet[i])
+				for k := 0; k < joinRecordLen(joinView, indices, useIndex); k++ {
 					if gm.HasError() || ctx.Err() != nil {
 						break OuterJoinLoop
 					}
 
+					j := k
+					if useIndex {
+						j = indices[k]
+					}
+
 					var mergedRecord Record
 					switch direction {
 					case parser.RIGHT:
-						mergedRecord = append(joinView.RecordSet[j], view.RecordSet[i]...)
+						mergedRecord = MergeRecord(joinView.RecordSet[j], view.RecordSet[i])
 					default:
-						mergedRecord = append(view.RecordSet[i], joinView.RecordSet[j]...)
+						mergedRecord = MergeRecord(view.RecordSet[i], joinView.RecordSet[j])
 					}
 					filter.records[0].view.RecordSet[0] = mergedRecord
 
@@ -257,9 +351,9 @@ func OuterJoin(ctx context.Context, parentFilter *Filter, view *View, joinView *
 					var record Record
 					switch direction {
 					case parser.RIGHT:
-						record = append(joinViewEmptyRecord, view.RecordSet[i]...)
+						record = MergeRecord(joinViewEmptyRecord, view.RecordSet[i])
 					default:
-						record = append(view.RecordSet[i], joinViewEmptyRecord...)
+						record = MergeRecord(view.RecordSet[i], joinViewEmptyRecord)
 					}
 					records = append(records, record)
 
@@ -290,7 +384,7 @@ func OuterJoin(ctx context.Context, parentFilter *Filter, view *View, joinView *
 				}
 			}
 			if !match {
-				record := append(viewEmptyRecord, joinView.RecordSet[i]...)
+				record := MergeRecord(viewEmptyRecord, joinView.RecordSet[i])
 				recordsList[len(recordsList)-1] = append(recordsList[len(recordsList)-1], record)
 			}
 		}
@@ -303,6 +397,262 @@ func OuterJoin(ctx context.Context, parentFilter *Filter, view *View, joinView *
 	view.Header = mergedHeader
 	view.RecordSet = MergeRecordSetList(recordsList)
 	view.FileInfo = nil
+	view.indexes = nil
+	return nil
+}
+
+// spillJoinView writes the records of the view to be joined to a temporary file and releases them
+// from memory if they cannot be held within the memory limit.
+// If the records are held in memory, then nil is returned.
+func spillJoinView(filter *Filter, joinView *View) (*spilledRecordSet, error) {
+	if ok, err := reserveMemory(filter, estimateRecordSetSize(joinView.RecordSet)); err != nil || ok {
+		return nil, err
+	}
+
+	spilled, err := spillRecordSet(joinView.RecordSet)
+	if err != nil {
+		return nil, err
+	}
+	joinView.RecordSet = nil
+	return spilled, nil
+}
+
+// joinSpilledRecordSet joins the records of the view and the records of the joinView read in chunks
+// from the temporary file, and closes the file.
+// If the direction is undefined, then the records are inner joined, otherwise outer joined.
+// The order of the result is the same as that of the join in memory.
+func joinSpilledRecordSet(ctx context.Context, parentFilter *Filter, view *View, joinView *View, spilled *spilledRecordSet, mergedHeader Header, condition parser.QueryExpression, direction int) (RecordSet, error) {
+	defer func() {
+		_ = spilled.Close()
+	}()
+
+	indexLookup := joinIndexLookup(view, joinView, condition, parentFilter.tx.Flags)
+
+	viewEmptyRecord := NewEmptyRecord(view.FieldLen())
+
+	matchedRecords := make([]RecordSet, view.RecordLen())
+	var unmatchedJoinViewRecords RecordSet
+	var size int64
+	offset := 0
+
+	err := spilled.ReadChunks(ctx, StreamChunkSize, func(chunk RecordSet) error {
+		lookup, lookupOffset := indexLookup, offset
+		if lookup == nil {
+			lookup = joinHashLookup(view, &View{Header: joinView.Header, RecordSet: chunk}, condition, parentFilter.tx.Flags)
+			lookupOffset = 0
+		}
+
+		gm := NewGoroutineTaskManager(view.RecordLen(), CalcMinimumRequired(view.RecordLen(), len(chunk), MinimumRequiredPerCPUCore), parentFilter.tx.Flags.CPU)
+		chunkMatchesList := make([][]bool, gm.Number)
+		matchCounts := make([]int, gm.Number)
+
+		for i := 0; i < gm.Number; i++ {
+			gm.Add()
+			go func(thIdx int) {
+				start, end := gm.RecordRange(thIdx)
+				filter := NewFilterForRecord(
+					parentFilter,
+					&View{
+						Tx:        parentFilter.tx,
+						Header:    mergedHeader,
+						RecordSet: make(RecordSet, 1),
+					},
+					0,
+				)
+
+				chunkMatches := make([]bool, len(chunk))
+				matchCount := 0
+
+			SpilledJoinLoop:
+				for i := start; i < end; i++ {
+					indices, useIndex := joinRecordIndices(lookup, view.RecordSet[i])
+					n := len(chunk)
+					if useIndex {
+						n = len(indices)
+					}
+
+					for k := 0; k < n; k++ {
+						if gm.HasError() || ctx.Err() != nil {
+							break SpilledJoinLoop
+						}
+
+						j := k
+						if useIndex {
+							j = indices[k] - lookupOffset
+							if j < 0 || len(chunk) <= j {
+								continue
+							}
+						}
+
+						var mergedRecord Record
+						switch direction {
+						case parser.RIGHT:
+							mergedRecord = MergeRecord(chunk[j], view.RecordSet[i])
+						default:
+							mergedRecord = MergeRecord(view.RecordSet[i], chunk[j])
+						}
+
+						if condition != nil {
+							filter.records[0].view.RecordSet[0] = mergedRecord
+
+							primary, e := filter.Evaluate(ctx, condition)
+							if e != nil {
+								gm.SetError(e)
+								break SpilledJoinLoop
+							}
+							if primary.Ternary() != ternary.TRUE {
+								continue
+							}
+						}
+
+						matchedRecords[i] = append(matchedRecords[i], mergedRecord)
+						chunkMatches[j] = true
+						matchCount++
+					}
+				}
+
+				chunkMatchesList[thIdx] = chunkMatches
+				matchCounts[thIdx] = matchCount
+				gm.Done()
+			}(i)
+		}
+		gm.Wait()
+
+		if gm.HasError() {
+			return gm.Err()
+		}
+		if ctx.Err() != nil {
+			return NewContextIsDone(ctx.Err().Error())
+		}
+
+		if direction == parser.FULL {
+			for j := range chunk {
+				match := false
+				for _, chunkMatches := range chunkMatchesList {
+					if chunkMatches[j] {
+						match = true
+						break
+					}
+				}
+				if !match {
+					unmatchedJoinViewRecords = append(unmatchedJoinViewRecords, MergeRecord(viewEmptyRecord, chunk[j]))
+				}
+			}
+		}
+
+		for _, n := range matchCounts {
+			size += estimateJoinedRecordSetSize(n, len(mergedHeader))
+		}
+		offset += len(chunk)
+		return checkMemoryLimit(parentFilter, size)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mergeJoinedRecords(view, joinView, matchedRecords, unmatchedJoinViewRecords, direction), nil
+}
+
+// mergeJoinedRecords returns the records matched with each record of the view in the order of the view,
+// followed by the records of the joinView that match no record in a full outer join.
+func mergeJoinedRecords(view *View, joinView *View, matchedRecords []RecordSet, unmatchedJoinViewRecords RecordSet, direction int) RecordSet {
+	joinViewEmptyRecord := NewEmptyRecord(joinView.FieldLen())
+
+	records := make(RecordSet, 0, view.RecordLen()+len(unmatchedJoinViewRecords))
+	for i, matched := range matchedRecords {
+		if 0 < len(matched) {
+			records = append(records, matched...)
+			continue
+		}
+
+		if direction != parser.TokenUndefined {
+			switch direction {
+			case parser.RIGHT:
+				records = append(records, MergeRecord(joinViewEmptyRecord, view.RecordSet[i]))
+			default:
+				records = append(records, MergeRecord(view.RecordSet[i], joinViewEmptyRecord))
+			}
+		}
+	}
+	return append(records, unmatchedJoinViewRecords...)
+}
+
+func UnnestJoin(ctx context.Context, parentFilter *Filter, view *View, unnest parser.Unnest, joinHeader Header, condition parser.QueryExpression, outer bool) error {
+	mergedHeader := MergeHeader(view.Header, joinHeader)
+	joinViewEmptyRecord := NewEmptyRecord(joinHeader.Len())
+
+	gm := NewGoroutineTaskManager(view.RecordLen(), -1, parentFilter.tx.Flags.CPU)
+	recordsList := make([]RecordSet, gm.Number)
+	for i := 0; i < gm.Number; i++ {
+		gm.Add()
+		go func(thIdx int) {
+			start, end := gm.RecordRange(thIdx)
+			records := make(RecordSet, 0, end-start)
+			filter := NewFilterForRecord(
+				parentFilter,
+				&View{
+					Tx:        parentFilter.tx,
+					Header:    mergedHeader,
+					RecordSet: make(RecordSet, 1),
+				},
+				0,
+			)
+
+		UnnestJoinLoop:
+			for i := start; i < end; i++ {
+				if gm.HasError() || ctx.Err() != nil {
+					break UnnestJoinLoop
+				}
+
+				joinRecords, e := unnestRecordSet(ctx, NewFilterForRecord(parentFilter, view, i), unnest)
+				if e != nil {
+					gm.SetError(e)
+					break UnnestJoinLoop
+				}
+
+				match := false
+				for _, joinRecord := range joinRecords {
+					mergedRecord := make(Record, 0, len(view.RecordSet[i])+len(joinRecord))
+					mergedRecord = append(append(mergedRecord, view.RecordSet[i]...), joinRecord...)
+
+					if condition != nil {
+						filter.records[0].view.RecordSet[0] = mergedRecord
+
+						primary, e := filter.Evaluate(ctx, condition)
+						if e != nil {
+							gm.SetError(e)
+							break UnnestJoinLoop
+						}
+						if primary.Ternary() != ternary.TRUE {
+							continue
+						}
+					}
+					records = append(records, mergedRecord)
+					match = true
+				}
+
+				if !match && outer {
+					records = append(records, MergeRecord(view.RecordSet[i], joinViewEmptyRecord))
+				}
+			}
+
+			recordsList[thIdx] = records
+			gm.Done()
+		}(i)
+	}
+	gm.Wait()
+
+	if gm.HasError() {
+		return gm.Err()
+	}
+	if ctx.Err() != nil {
+		return NewContextIsDone(ctx.Err().Error())
+	}
+
+	view.Header = mergedHeader
+	view.RecordSet = MergeRecordSetList(recordsList)
+	view.FileInfo = nil
+	view.indexes = nil
 	return nil
 }
 
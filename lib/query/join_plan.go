@@ -0,0 +1,750 @@
+package query
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mithrandie/csvq/lib/parser"
+	"github.com/mithrandie/csvq/lib/value"
+)
+
+// joinPlanCondition is a conjunct of the search conditions with the indices of the relations
+// whose fields are referred to in the conjunct.
+// An ON condition can refer to the relations from Start to End-1 joined by the join of the condition.
+type joinPlanCondition struct {
+	Expr      parser.QueryExpression
+	Relations []int
+	Equality  bool
+
+	// Estimated number of distinct values of the fields compared by the equality, or 0 if unknown
+	Distinct int
+
+	Start int
+	End   int
+}
+
+func (c joinPlanCondition) refersTo(relation int) bool {
+	return InIntSlice(relation, c.Relations)
+}
+
+func (c joinPlanCondition) isCoveredBy(joined []bool) bool {
+	for _, r := range c.Relations {
+		if !joined[r] {
+			return false
+		}
+	}
+	return true
+}
+
+// joinPlan holds the relations in the FROM clause, that is, the tables joined by cross joins
+// or inner joins with ON conditions, and the conditions to filter the joined records.
+type joinPlan struct {
+	Tables     []parser.QueryExpression
+	Relations  []parser.QueryExpression
+	Views      []*View
+	Conditions []joinPlanCondition
+
+	next int
+}
+
+// LoadWithCondition loads the tables in the FROM clause and filters the joined records by the condition.
+//
+// Conjuncts of the condition and ON conditions of inner joins referring to only one table are applied
+// to the records of the table before joining, and the other conjuncts are applied to the joins
+// as soon as all the tables referred to in the conjuncts are joined.
+// The tables are joined in the order that keeps the estimated numbers of intermediate records small.
+// The result is the same as that of Load followed by filtering with the condition,
+// including the order of the records and the fields.
+func (view *View) LoadWithCondition(ctx context.Context, filter *Filter, clause parser.FromClause, condition parser.QueryExpression) error {
+	plan, ok := newJoinPlan(clause)
+	if !ok {
+		if err := view.Load(ctx, filter, clause); err != nil {
+			return err
+		}
+		if condition == nil {
+			return nil
+		}
+		return view.filterWithRecord(ctx, filter, condition, clause.Tables)
+	}
+
+	if err := plan.Load(ctx, filter, view.UseInternalId, view.ForUpdate); err != nil {
+		return err
+	}
+
+	residual, err := plan.Join(ctx, filter, view, condition)
+	if err != nil {
+		return err
+	}
+
+	view.Filter = filter
+	if residual == nil {
+		return nil
+	}
+	return view.filterWithRecord(ctx, filter, residual, clause.Tables)
+}
+
+// filterWithRecord filters the records by the search condition in the WHERE clause
+// and records the operation for EXPLAIN ANALYZE.
+func (view *View) filterWithRecord(ctx context.Context, filter *Filter, condition parser.QueryExpression, tables []parser.QueryExpression) error {
+	start := time.Now()
+	if err := view.filter(ctx, condition); err != nil {
+		return err
+	}
+	filter.recorder.record(whereOperationKey(tables), view, start)
+	return nil
+}
+
+func whereOperationKey(tables []parser.QueryExpression) operationKey {
+	if len(tables) < 1 {
+		return operationKey{}
+	}
+	return newOperationKey("where", tables[0])
+}
+
+func newJoinPlan(clause parser.FromClause) (*joinPlan, bool) {
+	if clause.Tables == nil {
+		return nil, false
+	}
+
+	plan := &joinPlan{
+		Tables: clause.Tables,
+	}
+	for i, v := range clause.Tables {
+		if _, ok := unnestTable(v); ok && 0 < i {
+			return nil, false
+		}
+		plan.Relations, plan.Conditions = flattenInnerJoins(v, plan.Relations, plan.Conditions)
+	}
+	if len(plan.Relations) < 2 {
+		return nil, false
+	}
+
+	for _, c := range plan.Conditions {
+		if _, ok := collectJoinPlanFields(c.Expr, nil); !ok {
+			return nil, false
+		}
+	}
+	return plan, true
+}
+
+// innerJoin returns the join if the table expression is a cross join or an inner join with an ON condition.
+func innerJoin(expr parser.QueryExpression) (parser.Join, bool) {
+	if parentheses, ok := expr.(parser.Parentheses); ok {
+		return innerJoin(parentheses.Expr)
+	}
+
+	table, ok := expr.(parser.Table)
+	if !ok || table.Columns != nil || table.Sample != nil {
+		return parser.Join{}, false
+	}
+	join, ok := table.Object.(parser.Join)
+	if !ok || !join.Natural.IsEmpty() {
+		return parser.Join{}, false
+	}
+	if _, ok := unnestTable(join.JoinTable); ok {
+		return parser.Join{}, false
+	}
+
+	switch {
+	case join.JoinType.Token == parser.CROSS:
+		return join, true
+	case join.JoinType.Token == parser.INNER || (join.JoinType.IsEmpty() && join.Direction.IsEmpty()):
+		if join.Condition == nil || join.Condition.(parser.JoinCondition).On != nil {
+			return join, true
+		}
+	}
+	return parser.Join{}, false
+}
+
+// flattenInnerJoins appends the operands of cross joins and inner joins in the table expression
+// to the relations, and the conjuncts of the ON conditions to the conditions.
+func flattenInnerJoins(expr parser.QueryExpression, relations []parser.QueryExpression, conditions []joinPlanCondition) ([]parser.QueryExpression, []joinPlanCondition) {
+	join, ok := innerJoin(expr)
+	if !ok {
+		return append(relations, expr), conditions
+	}
+
+	start := len(relations)
+	relations, conditions = flattenInnerJoins(join.Table, relations, conditions)
+	relations, conditions = flattenInnerJoins(join.JoinTable, relations, conditions)
+	if join.Condition != nil {
+		for _, expr := range splitConjunction(join.Condition.(parser.JoinCondition).On) {
+			conditions = append(conditions, joinPlanCondition{Expr: expr, Start: start, End: len(relations)})
+		}
+	}
+	return relations, conditions
+}
+
+// collectJoinPlanFields appends the field references in the expression to the fields,
+// and reports whether the expression can be evaluated for a record independently of the other records
+// and the number of evaluations.
+func collectJoinPlanFields(expr parser.QueryExpression, fields []parser.QueryExpression) ([]parser.QueryExpression, bool) {
+	if expr == nil {
+		return fields, true
+	}
+
+	switch e := expr.(type) {
+	case parser.PrimitiveType, parser.Placeholder, parser.Variable, parser.EnvironmentVariable, parser.RuntimeInformation:
+		return fields, true
+	case parser.FieldReference, parser.ColumnNumber:
+		return append(fields, e), true
+	case parser.Parentheses:
+		return collectJoinPlanFields(e.Expr, fields)
+	case parser.RowValue:
+		return collectJoinPlanFields(e.Value, fields)
+	case parser.ValueList:
+		return collectJoinPlanFieldsInList(e.Values, fields)
+	case parser.RowValueList:
+		return collectJoinPlanFieldsInList(e.RowValues, fields)
+	case parser.ArrayConstructor:
+		return collectJoinPlanFieldsInList(e.Values, fields)
+	case parser.ArrayElement:
+		return collectJoinPlanFieldsInList([]parser.QueryExpression{e.Array, e.Index}, fields)
+	case parser.Arithmetic:
+		return collectJoinPlanFieldsInList([]parser.QueryExpression{e.LHS, e.RHS}, fields)
+	case parser.UnaryArithmetic:
+		return collectJoinPlanFields(e.Operand, fields)
+	case parser.Concat:
+		return collectJoinPlanFieldsInList(e.Items, fields)
+	case parser.Comparison:
+		return collectJoinPlanFieldsInList([]parser.QueryExpression{e.LHS, e.RHS}, fields)
+	case parser.Is:
+		return collectJoinPlanFieldsInList([]parser.QueryExpression{e.LHS, e.RHS}, fields)
+	case parser.Between:
+		return collectJoinPlanFieldsInList([]parser.QueryExpression{e.LHS, e.Low, e.High}, fields)
+	case parser.In:
+		return collectJoinPlanFieldsInList([]parser.QueryExpression{e.LHS, e.Values}, fields)
+	case parser.Any:
+		return collectJoinPlanFieldsInList([]parser.QueryExpression{e.LHS, e.Values}, fields)
+	case parser.All:
+		return collectJoinPlanFieldsInList([]parser.QueryExpression{e.LHS, e.Values}, fields)
+	case parser.Like:
+		return collectJoinPlanFieldsInList([]parser.QueryExpression{e.LHS, e.Pattern}, fields)
+	case parser.Logic:
+		return collectJoinPlanFieldsInList([]parser.QueryExpression{e.LHS, e.RHS}, fields)
+	case parser.UnaryLogic:
+		return collectJoinPlanFields(e.Operand, fields)
+	case parser.CaseExpr:
+		return collectJoinPlanFieldsInList(append([]parser.QueryExpression{e.Value, e.Else}, e.When...), fields)
+	case parser.CaseExprWhen:
+		return collectJoinPlanFieldsInList([]parser.QueryExpression{e.Condition, e.Result}, fields)
+	case parser.CaseExprElse:
+		return collectJoinPlanFields(e.Result, fields)
+	case parser.Function:
+		// Functions that return different values for each call and user defined functions
+		// are not moved because the number of calls changes.
+		name := strings.ToUpper(e.Name)
+		if _, ok := Functions[name]; (!ok && name != "NOW") || RandomFunctions[name] || name == "UUID" {
+			return fields, false
+		}
+		return collectJoinPlanFieldsInList(e.Args, fields)
+	}
+	return fields, false
+}
+
+func collectJoinPlanFieldsInList(exprs []parser.QueryExpression, fields []parser.QueryExpression) ([]parser.QueryExpression, bool) {
+	var ok bool
+	for _, expr := range exprs {
+		if fields, ok = collectJoinPlanFields(expr, fields); !ok {
+			return fields, false
+		}
+	}
+	return fields, true
+}
+
+// Load loads the views of the relations in the written order.
+func (plan *joinPlan) Load(ctx context.Context, filter *Filter, useInternalId bool, forUpdate bool) error {
+	plan.Views = make([]*View, len(plan.Relations))
+	for i, expr := range plan.Relations {
+		loaded, err := loadView(ctx, filter, expr, useInternalId, forUpdate)
+		if err != nil {
+			return err
+		}
+		loaded.Filter = filter
+		plan.Views[i] = loaded
+	}
+	return nil
+}
+
+// relationsOfExpression returns the indices of the relations whose fields are referred to in the expression.
+// If a field in the expression can be resolved differently depending on the combination of the joined relations,
+// or the expression cannot be moved, then false is returned.
+func (plan *joinPlan) relationsOfExpression(expr parser.QueryExpression) ([]int, bool) {
+	fields, ok := collectJoinPlanFields(expr, nil)
+	if !ok {
+		return nil, false
+	}
+
+	header := make(Header, 0, 20)
+	offsets := make([]int, len(plan.Views))
+	for i, v := range plan.Views {
+		offsets[i] = header.Len()
+		header = MergeHeader(header, v.Header)
+	}
+
+	relations := make([]int, 0, 2)
+	for _, field := range fields {
+		idx, err := header.ContainsObject(field)
+		if err != nil {
+			return nil, false
+		}
+
+		relation := sort.SearchInts(offsets, idx+1) - 1
+		for i, v := range plan.Views {
+			fieldIdx, err := v.FieldIndex(field)
+			if i == relation {
+				if err != nil || fieldIdx != idx-offsets[i] {
+					return nil, false
+				}
+			} else if err == nil {
+				return nil, false
+			}
+		}
+
+		if !InIntSlice(relation, relations) {
+			relations = append(relations, relation)
+		}
+	}
+	sort.Ints(relations)
+	return relations, true
+}
+
+func isJoinPlanEquality(expr parser.QueryExpression, relations []int) bool {
+	return len(relations) == 2 && isFieldEquality(expr)
+}
+
+// isFieldEquality reports whether the expression is an equality comparison between two fields.
+func isFieldEquality(expr parser.QueryExpression) bool {
+	comparison, ok := expr.(parser.Comparison)
+	if !ok || comparison.Operator != "=" {
+		return false
+	}
+	_, lok := comparison.LHS.(parser.FieldReference)
+	_, rok := comparison.RHS.(parser.FieldReference)
+	return lok && rok
+}
+
+// Join joins the relations into the view, and returns the conjuncts of the condition that cannot be applied
+// before the relations are joined.
+func (plan *joinPlan) Join(ctx context.Context, filter *Filter, view *View, condition parser.QueryExpression) (parser.QueryExpression, error) {
+	residual, keepsWrittenJoins := plan.classifyConditions(condition)
+
+	if err := plan.pushDownConditions(ctx, filter); err != nil {
+		return nil, err
+	}
+
+	var joined *View
+	var err error
+	if keepsWrittenJoins {
+		joined, err = plan.joinInWrittenOrder(ctx, filter)
+	} else {
+		joined, err = plan.joinInOrder(ctx, filter, planJoinOrder(plan.sizes(), plan.Conditions))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	view.Header = joined.Header
+	view.RecordSet = joined.RecordSet
+	view.FileInfo = nil
+	view.indexes = nil
+	return joinConjunction(residual), nil
+}
+
+// classifyConditions resolves the relations referred to in the ON conditions and the conjuncts of the condition,
+// and returns the conjuncts that cannot be applied before the relations are joined.
+// If the ON conditions must be evaluated in the written order of the joins, then keepsWrittenJoins is true.
+func (plan *joinPlan) classifyConditions(condition parser.QueryExpression) (residual []parser.QueryExpression, keepsWrittenJoins bool) {
+	for i := range plan.Conditions {
+		relations, ok := plan.relationsOfExpression(plan.Conditions[i].Expr)
+		if !ok || len(relations) < 1 || relations[0] < plan.Conditions[i].Start || plan.Conditions[i].End <= relations[len(relations)-1] {
+			// The ON condition must be evaluated with the fields of the joined tables in the written order.
+			keepsWrittenJoins = true
+			break
+		}
+		plan.Conditions[i].Relations = relations
+		plan.Conditions[i].Equality = isJoinPlanEquality(plan.Conditions[i].Expr, relations)
+		plan.Conditions[i].Distinct = plan.distinctValues(plan.Conditions[i])
+	}
+	if keepsWrittenJoins {
+		plan.Conditions = nil
+	}
+
+	if condition != nil {
+		for _, expr := range splitConjunction(condition) {
+			relations, ok := plan.relationsOfExpression(expr)
+			if !ok || len(relations) < 1 || (keepsWrittenJoins && 1 < len(relations)) {
+				residual = append(residual, expr)
+				continue
+			}
+			c := joinPlanCondition{
+				Expr:      expr,
+				Relations: relations,
+				Equality:  isJoinPlanEquality(expr, relations),
+			}
+			c.Distinct = plan.distinctValues(c)
+			plan.Conditions = append(plan.Conditions, c)
+		}
+	}
+	return residual, keepsWrittenJoins
+}
+
+// distinctValues returns the larger of the estimated numbers of distinct values of the two fields compared by
+// the equality condition, or 0 if the statistics of the files are not available.
+func (plan *joinPlan) distinctValues(c joinPlanCondition) int {
+	if !c.Equality {
+		return 0
+	}
+
+	comparison := c.Expr.(parser.Comparison)
+	distinct := 0
+	for _, field := range []parser.QueryExpression{comparison.LHS, comparison.RHS} {
+		found := false
+		for _, r := range c.Relations {
+			if column, ok := columnStatistics(plan.Views[r], field); ok {
+				if distinct < column.Distinct {
+					distinct = column.Distinct
+				}
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0
+		}
+	}
+	return distinct
+}
+
+func (plan *joinPlan) sizes() []int {
+	sizes := make([]int, len(plan.Views))
+	for i, v := range plan.Views {
+		sizes[i] = v.RecordLen()
+	}
+	return sizes
+}
+
+// pushDownConditions filters the records of each relation with the conditions referring to only the relation.
+func (plan *joinPlan) pushDownConditions(ctx context.Context, filter *Filter) error {
+	conditions := make([]joinPlanCondition, 0, len(plan.Conditions))
+	for i, v := range plan.Views {
+		var exprs []parser.QueryExpression
+		for _, c := range plan.Conditions {
+			if len(c.Relations) == 1 && c.Relations[0] == i {
+				exprs = append(exprs, c.Expr)
+			}
+		}
+		if exprs == nil {
+			continue
+		}
+		start := time.Now()
+		if err := v.filter(ctx, joinConjunction(exprs)); err != nil {
+			return err
+		}
+		filter.recorder.record(newOperationKey("pushdown", plan.Relations[i]), v, start)
+	}
+
+	for _, c := range plan.Conditions {
+		if 1 < len(c.Relations) {
+			conditions = append(conditions, c)
+		}
+	}
+	plan.Conditions = conditions
+	return nil
+}
+
+func joinConjunction(exprs []parser.QueryExpression) parser.QueryExpression {
+	if len(exprs) < 1 {
+		return nil
+	}
+
+	expr := exprs[0]
+	for i := 1; i < len(exprs); i++ {
+		expr = parser.Logic{
+			LHS:      expr,
+			RHS:      exprs[i],
+			Operator: parser.Token{Token: parser.AND, Literal: parser.TokenLiteral(parser.AND)},
+		}
+	}
+	return expr
+}
+
+// joinInWrittenOrder joins the relations in the same structure as the FROM clause.
+func (plan *joinPlan) joinInWrittenOrder(ctx context.Context, filter *Filter) (*View, error) {
+	plan.next = 0
+
+	var view *View
+	for _, table := range plan.Tables {
+		joinView, err := plan.joinTableExpression(ctx, filter, table)
+		if err != nil {
+			return nil, err
+		}
+
+		if view == nil {
+			view = joinView
+			continue
+		}
+		start := time.Now()
+		if err = CrossJoin(ctx, filter, view, joinView); err != nil {
+			return nil, err
+		}
+		filter.recorder.record(newOperationKey("cross", table), view, start)
+	}
+	return view, nil
+}
+
+func (plan *joinPlan) joinTableExpression(ctx context.Context, filter *Filter, expr parser.QueryExpression) (*View, error) {
+	join, ok := innerJoin(expr)
+	if !ok {
+		view := plan.Views[plan.next]
+		plan.next++
+		return view, nil
+	}
+
+	view, err := plan.joinTableExpression(ctx, filter, join.Table)
+	if err != nil {
+		return nil, err
+	}
+	joinView, err := plan.joinTableExpression(ctx, filter, join.JoinTable)
+	if err != nil {
+		return nil, err
+	}
+
+	var condition parser.QueryExpression
+	if join.Condition != nil {
+		condition = join.Condition.(parser.JoinCondition).On
+	}
+	start := time.Now()
+	if err = InnerJoin(ctx, filter, view, joinView, condition); err != nil {
+		return nil, err
+	}
+	filter.recorder.record(newJoinOperationKey("join", join.Table, join.JoinTable), view, start)
+	return view, nil
+}
+
+// joinInOrder joins the relations in the order with the conditions.
+// If the order is not the written order, then the joined records and fields are rearranged
+// in the written order using the positions of the records in each relation.
+func (plan *joinPlan) joinInOrder(ctx context.Context, filter *Filter, order []int) (*View, error) {
+	reordered := false
+	for i, r := range order {
+		if i != r {
+			reordered = true
+			break
+		}
+	}
+	fieldLens := make([]int, len(plan.Views))
+	for i, v := range plan.Views {
+		if reordered {
+			appendRecordPositions(v)
+		}
+		fieldLens[i] = v.FieldLen()
+	}
+
+	joined := make([]bool, len(plan.Views))
+	applied := make([]bool, len(plan.Conditions))
+
+	view := plan.Views[order[0]]
+	joined[order[0]] = true
+	for _, r := range order[1:] {
+		joined[r] = true
+
+		var exprs []parser.QueryExpression
+		for i, c := range plan.Conditions {
+			if !applied[i] && c.isCoveredBy(joined) {
+				exprs = append(exprs, c.Expr)
+				applied[i] = true
+			}
+		}
+		start := time.Now()
+		if err := InnerJoin(ctx, filter, view, plan.Views[r], joinConjunction(exprs)); err != nil {
+			return nil, err
+		}
+		filter.recorder.record(newOperationKey("join step", plan.Relations[r]), view, start)
+	}
+
+	if reordered {
+		restoreWrittenOrder(view, fieldLens, order)
+	}
+	return view, nil
+}
+
+// appendRecordPositions appends a field that cannot be referred to in queries
+// holding the position of each record.
+func appendRecordPositions(view *View) {
+	header := make(Header, view.FieldLen(), view.FieldLen()+1)
+	copy(header, view.Header)
+	view.Header = append(header, HeaderField{})
+
+	for i, record := range view.RecordSet {
+		r := make(Record, len(record), len(record)+1)
+		copy(r, record)
+		view.RecordSet[i] = append(r, NewCell(value.NewInteger(int64(i))))
+	}
+	view.indexes = nil
+}
+
+// restoreWrittenOrder sorts the records joined in the order by the positions of the records in the relations,
+// and rearranges the fields in the written order of the relations.
+// The fieldLens are the numbers of fields of the relations including the fields of the positions.
+func restoreWrittenOrder(view *View, fieldLens []int, order []int) {
+	n := len(fieldLens)
+	offsets := make([]int, n)
+	offset := 0
+	for _, r := range order {
+		offsets[r] = offset
+		offset += fieldLens[r]
+	}
+
+	keys := make([]int64, len(view.RecordSet)*n)
+	for i, record := range view.RecordSet {
+		for r, l := range fieldLens {
+			keys[i*n+r] = record[offsets[r]+l-1].Value().(value.Integer).Raw()
+		}
+	}
+	positions := make([]int, len(view.RecordSet))
+	for i := range positions {
+		positions[i] = i
+	}
+	sort.Slice(positions, func(i, j int) bool {
+		ki := keys[positions[i]*n : (positions[i]+1)*n]
+		kj := keys[positions[j]*n : (positions[j]+1)*n]
+		for r := range ki {
+			if ki[r] != kj[r] {
+				return ki[r] < kj[r]
+			}
+		}
+		return false
+	})
+
+	fieldLen := view.FieldLen() - n
+	header := make(Header, 0, fieldLen)
+	for r, l := range fieldLens {
+		header = append(header, view.Header[offsets[r]:offsets[r]+l-1]...)
+	}
+
+	records := make(RecordSet, len(view.RecordSet))
+	for i, pos := range positions {
+		record := make(Record, 0, fieldLen)
+		for r, l := range fieldLens {
+			record = append(record, view.RecordSet[pos][offsets[r]:offsets[r]+l-1]...)
+		}
+		records[i] = record
+	}
+
+	view.Header = header
+	view.RecordSet = records
+}
+
+// planJoinOrder returns the order of the relations to be joined.
+//
+// The cost of an order is the sum of the estimated numbers of the records created by each join.
+// The number of records of a join is estimated as the product of the numbers of records of the operands,
+// reduced by each condition applied to the join. An equality between fields of two relations is assumed
+// to match each record of the larger relation at most once, and other conditions are assumed to match
+// one-third of the records. If the statistics of the files are available, then an equality is assumed to match
+// the records having the same value of the field that has more distinct values.
+// The order is determined greedily from each relation, and the written order is kept
+// unless the cost is less than half of that of the written order.
+func planJoinOrder(sizes []int, conditions []joinPlanCondition) []int {
+	written := make([]int, len(sizes))
+	for i := range written {
+		written[i] = i
+	}
+	if len(sizes) < 3 {
+		// Two relations are joined by a hash join regardless of the order.
+		return written
+	}
+
+	order := written
+	cost := estimateJoinOrderCost(written, sizes, conditions)
+	writtenCost := cost
+
+	for first := range sizes {
+		o := greedyJoinOrder(first, sizes, conditions)
+		if c := estimateJoinOrderCost(o, sizes, conditions); c < cost {
+			order = o
+			cost = c
+		}
+	}
+
+	if writtenCost <= cost*2 {
+		return written
+	}
+	return order
+}
+
+func greedyJoinOrder(first int, sizes []int, conditions []joinPlanCondition) []int {
+	joined := make([]bool, len(sizes))
+	order := make([]int, 1, len(sizes))
+	order[0] = first
+	joined[first] = true
+	size := float64(sizes[first])
+
+	for len(order) < len(sizes) {
+		next := -1
+		var nextSize float64
+		for r := range sizes {
+			if joined[r] {
+				continue
+			}
+			s := estimateJoinSize(size, joined, r, sizes, conditions)
+			if next < 0 || s < nextSize {
+				next = r
+				nextSize = s
+			}
+		}
+		order = append(order, next)
+		joined[next] = true
+		size = nextSize
+	}
+	return order
+}
+
+func estimateJoinOrderCost(order []int, sizes []int, conditions []joinPlanCondition) float64 {
+	joined := make([]bool, len(sizes))
+	joined[order[0]] = true
+	size := float64(sizes[order[0]])
+
+	var cost float64
+	for _, r := range order[1:] {
+		size = estimateJoinSize(size, joined, r, sizes, conditions)
+		joined[r] = true
+		cost += size
+	}
+	return cost
+}
+
+// estimateJoinSize returns the estimated number of records created by joining the relation
+// to the joined relations with the size.
+func estimateJoinSize(size float64, joined []bool, relation int, sizes []int, conditions []joinPlanCondition) float64 {
+	joined[relation] = true
+	defer func() {
+		joined[relation] = false
+	}()
+
+	size = size * float64(sizes[relation])
+	for _, c := range conditions {
+		if !c.refersTo(relation) || !c.isCoveredBy(joined) {
+			continue
+		}
+
+		if c.Equality {
+			larger := sizes[c.Relations[0]]
+			if larger < sizes[c.Relations[1]] {
+				larger = sizes[c.Relations[1]]
+			}
+			if 0 < c.Distinct && c.Distinct < larger {
+				larger = c.Distinct
+			}
+			if 1 < larger {
+				size = size / float64(larger)
+			}
+		} else {
+			size = size / 3
+		}
+	}
+	return size
+}
@@ -0,0 +1,170 @@
+package query
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/mithrandie/csvq/lib/parser"
+)
+
+var viewLoadWithConditionTests = []struct {
+	Name  string
+	Query string
+	Error string
+}{
+	{
+		Name:  "LoadWithCondition Cross Joins with Equality",
+		Query: "SELECT * FROM table1, table2 WHERE table1.column1 = table2.column3",
+	},
+	{
+		Name:  "LoadWithCondition Conditions for Single Tables",
+		Query: "SELECT * FROM table1, table2 WHERE table1.column1 < 3 AND column4 <> 'str33'",
+	},
+	{
+		Name:  "LoadWithCondition Reordered Joins",
+		Query: "SELECT * FROM table1 a, table2, table1 b, table4 WHERE a.column1 = table4.column3 AND table2.column3 = table4.column3 AND b.column1 = table4.column3",
+	},
+	{
+		Name:  "LoadWithCondition Inner Joins",
+		Query: "SELECT * FROM table1 a CROSS JOIN table2 INNER JOIN table1 b ON b.column1 = table2.column3 JOIN table4 ON table4.column3 = b.column1 AND table4.column4 <> 'str3' WHERE a.column1 = b.column1",
+	},
+	{
+		Name:  "LoadWithCondition Field Not Exist in Join Condition",
+		Query: "SELECT * FROM table1 a CROSS JOIN table2 INNER JOIN table1 b ON b.column1 = a.column1",
+		Error: "[L:1 C:77] field a.column1 does not exist",
+	},
+	{
+		Name:  "LoadWithCondition Outer Join",
+		Query: "SELECT * FROM table1 LEFT JOIN table2 ON table1.column1 = table2.column3, table4 WHERE table2.column4 IS NULL AND table4.column3 = table1.column1",
+	},
+	{
+		Name:  "LoadWithCondition Subquery",
+		Query: "SELECT * FROM table1, table2 WHERE table1.column1 IN (SELECT column3 FROM table4) AND table1.column1 = table2.column3",
+	},
+	{
+		Name:  "LoadWithCondition Keep Written Joins",
+		Query: "SELECT * FROM table2, table1 JOIN table4 ON column1 = column3 WHERE table2.column3 = table1.column1",
+	},
+	{
+		Name:  "LoadWithCondition Field Ambiguous Error",
+		Query: "SELECT * FROM table2, table4 WHERE column3 = 2",
+		Error: "[L:1 C:36] field column3 is ambiguous",
+	},
+}
+
+func TestView_LoadWithCondition(t *testing.T) {
+	defer func() {
+		_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+		initFlag(TestTx.Flags)
+	}()
+
+	TestTx.Flags.Repository = TestDir
+	ctx := context.Background()
+
+	for _, v := range viewLoadWithConditionTests {
+		statements, _, err := parser.Parse(v.Query, "", nil, false)
+		if err != nil {
+			t.Fatalf("%s: unexpected parse error %q", v.Name, err)
+		}
+		entity := statements[0].(parser.SelectQuery).SelectEntity.(parser.SelectEntity)
+		var condition parser.QueryExpression
+		if entity.WhereClause != nil {
+			condition = entity.WhereClause.(parser.WhereClause).Filter
+		}
+
+		expect := NewView(TestTx)
+		err = expect.Load(ctx, NewFilter(TestTx).CreateNode(), entity.FromClause.(parser.FromClause))
+		if err == nil && condition != nil {
+			err = expect.filter(ctx, condition)
+		}
+		if err != nil {
+			if len(v.Error) < 1 {
+				t.Fatalf("%s: unexpected error %q", v.Name, err)
+			} else if err.Error() != v.Error {
+				t.Fatalf("%s: error %q, want error %q", v.Name, err.Error(), v.Error)
+			}
+		}
+
+		view := NewView(TestTx)
+		err = view.LoadWithCondition(ctx, NewFilter(TestTx).CreateNode(), entity.FromClause.(parser.FromClause), condition)
+		if err != nil {
+			if len(v.Error) < 1 {
+				t.Errorf("%s: unexpected error %q", v.Name, err)
+			} else if err.Error() != v.Error {
+				t.Errorf("%s: error %q, want error %q", v.Name, err.Error(), v.Error)
+			}
+			continue
+		}
+		if 0 < len(v.Error) {
+			t.Errorf("%s: no error, want error %q", v.Name, v.Error)
+			continue
+		}
+		if !reflect.DeepEqual(view.Header, expect.Header) {
+			t.Errorf("%s: header = %v, want %v", v.Name, view.Header, expect.Header)
+		}
+		if !reflect.DeepEqual(view.RecordSet, expect.RecordSet) {
+			t.Errorf("%s: records = %s, want %s", v.Name, view.RecordSet, expect.RecordSet)
+		}
+	}
+}
+
+var planJoinOrderTests = []struct {
+	Name       string
+	Sizes      []int
+	Conditions []joinPlanCondition
+	Result     []int
+}{
+	{
+		Name:   "PlanJoinOrder Two Relations",
+		Sizes:  []int{1000, 10},
+		Result: []int{0, 1},
+	},
+	{
+		Name:  "PlanJoinOrder Joined by Equalities",
+		Sizes: []int{3, 3, 3, 3},
+		Conditions: []joinPlanCondition{
+			{Relations: []int{0, 3}, Equality: true},
+			{Relations: []int{1, 3}, Equality: true},
+			{Relations: []int{2, 3}, Equality: true},
+		},
+		Result: []int{0, 3, 1, 2},
+	},
+	{
+		Name:  "PlanJoinOrder Avoid Cross Join",
+		Sizes: []int{1000, 1000, 10},
+		Conditions: []joinPlanCondition{
+			{Relations: []int{0, 2}, Equality: true},
+			{Relations: []int{1, 2}, Equality: true},
+		},
+		Result: []int{0, 2, 1},
+	},
+	{
+		Name:  "PlanJoinOrder Keep Written Order",
+		Sizes: []int{10, 20, 30},
+		Conditions: []joinPlanCondition{
+			{Relations: []int{0, 1}, Equality: true},
+			{Relations: []int{1, 2}},
+		},
+		Result: []int{0, 1, 2},
+	},
+	{
+		Name:  "PlanJoinOrder Distinct Values in Statistics",
+		Sizes: []int{1000, 1000, 1000, 1000},
+		Conditions: []joinPlanCondition{
+			{Relations: []int{0, 1}, Equality: true, Distinct: 2},
+			{Relations: []int{1, 2}, Equality: true},
+			{Relations: []int{2, 3}, Equality: true},
+		},
+		Result: []int{1, 2, 3, 0},
+	},
+}
+
+func TestPlanJoinOrder(t *testing.T) {
+	for _, v := range planJoinOrderTests {
+		result := planJoinOrder(v.Sizes, v.Conditions)
+		if !reflect.DeepEqual(result, v.Result) {
+			t.Errorf("%s: result = %v, want %v", v.Name, result, v.Result)
+		}
+	}
+}
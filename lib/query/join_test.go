@@ -1003,6 +1003,198 @@ func TestOuterJoin(t *testing.T) {
 	}
 }
 
+var unnestJoinTests = []struct {
+	Name      string
+	View      *View
+	Unnest    parser.Unnest
+	Header    Header
+	Condition parser.QueryExpression
+	Outer     bool
+	Result    *View
+	Error     string
+}{
+	{
+		Name: "Unnest Join",
+		View: &View{
+			Header: NewHeader("table1", []string{"column1", "column2"}),
+			RecordSet: []Record{
+				NewRecord([]value.Primary{
+					value.NewInteger(1),
+					value.NewString("a;b"),
+				}),
+				NewRecord([]value.Primary{
+					value.NewInteger(2),
+					value.NewString(""),
+				}),
+			},
+		},
+		Unnest: parser.Unnest{
+			Unnest: "unnest",
+			Array: parser.Function{
+				Name: "split",
+				Args: []parser.QueryExpression{
+					parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+					parser.NewStringValue(";"),
+				},
+			},
+			With:       "with",
+			Ordinality: "ordinality",
+		},
+		Header: NewHeader("u", []string{"value", "ordinality"}),
+		Result: &View{
+			Header: []HeaderField{
+				{View: "table1", Column: "column1", Number: 1, IsFromTable: true},
+				{View: "table1", Column: "column2", Number: 2, IsFromTable: true},
+				{View: "u", Column: "value", Number: 1, IsFromTable: true},
+				{View: "u", Column: "ordinality", Number: 2, IsFromTable: true},
+			},
+			RecordSet: []Record{
+				NewRecord([]value.Primary{
+					value.NewInteger(1),
+					value.NewString("a;b"),
+					value.NewString("a"),
+					value.NewInteger(1),
+				}),
+				NewRecord([]value.Primary{
+					value.NewInteger(1),
+					value.NewString("a;b"),
+					value.NewString("b"),
+					value.NewInteger(2),
+				}),
+			},
+		},
+	},
+	{
+		Name: "Unnest Join With Condition",
+		View: &View{
+			Header: NewHeader("table1", []string{"column1", "column2"}),
+			RecordSet: []Record{
+				NewRecord([]value.Primary{
+					value.NewInteger(1),
+					value.NewString("a;b"),
+				}),
+				NewRecord([]value.Primary{
+					value.NewInteger(2),
+					value.NewString("c"),
+				}),
+			},
+		},
+		Unnest: parser.Unnest{
+			Unnest: "unnest",
+			Array: parser.Function{
+				Name: "split",
+				Args: []parser.QueryExpression{
+					parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+					parser.NewStringValue(";"),
+				},
+			},
+		},
+		Header: NewHeader("u", []string{"value"}),
+		Condition: parser.Comparison{
+			LHS:      parser.FieldReference{View: parser.Identifier{Literal: "u"}, Column: parser.Identifier{Literal: "value"}},
+			RHS:      parser.NewStringValue("a"),
+			Operator: "<>",
+		},
+		Outer: true,
+		Result: &View{
+			Header: []HeaderField{
+				{View: "table1", Column: "column1", Number: 1, IsFromTable: true},
+				{View: "table1", Column: "column2", Number: 2, IsFromTable: true},
+				{View: "u", Column: "value", Number: 1, IsFromTable: true},
+			},
+			RecordSet: []Record{
+				NewRecord([]value.Primary{
+					value.NewInteger(1),
+					value.NewString("a;b"),
+					value.NewString("b"),
+				}),
+				NewRecord([]value.Primary{
+					value.NewInteger(2),
+					value.NewString("c"),
+					value.NewString("c"),
+				}),
+			},
+		},
+	},
+	{
+		Name: "Unnest Join Outer",
+		View: &View{
+			Header: NewHeader("table1", []string{"column1", "column2"}),
+			RecordSet: []Record{
+				NewRecord([]value.Primary{
+					value.NewInteger(1),
+					value.NewNull(),
+				}),
+			},
+		},
+		Unnest: parser.Unnest{
+			Unnest: "unnest",
+			Array: parser.Function{
+				Name: "split",
+				Args: []parser.QueryExpression{
+					parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+					parser.NewStringValue(";"),
+				},
+			},
+		},
+		Header: NewHeader("u", []string{"value"}),
+		Outer:  true,
+		Result: &View{
+			Header: []HeaderField{
+				{View: "table1", Column: "column1", Number: 1, IsFromTable: true},
+				{View: "table1", Column: "column2", Number: 2, IsFromTable: true},
+				{View: "u", Column: "value", Number: 1, IsFromTable: true},
+			},
+			RecordSet: []Record{
+				NewRecord([]value.Primary{
+					value.NewInteger(1),
+					value.NewNull(),
+					value.NewNull(),
+				}),
+			},
+		},
+	},
+	{
+		Name: "Unnest Join Not Array Error",
+		View: &View{
+			Header: NewHeader("table1", []string{"column1", "column2"}),
+			RecordSet: []Record{
+				NewRecord([]value.Primary{
+					value.NewInteger(1),
+					value.NewString("a;b"),
+				}),
+			},
+		},
+		Unnest: parser.Unnest{
+			Unnest: "unnest",
+			Array:  parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+		},
+		Header: NewHeader("u", []string{"value"}),
+		Error:  "value \"a;b\" in unnest(column2) is not an array",
+	},
+}
+
+func TestUnnestJoin(t *testing.T) {
+	for _, v := range unnestJoinTests {
+		err := UnnestJoin(context.Background(), NewFilter(TestTx), v.View, v.Unnest, v.Header, v.Condition, v.Outer)
+		if err != nil {
+			if len(v.Error) < 1 {
+				t.Errorf("%s: unexpected error %q", v.Name, err)
+			} else if err.Error() != v.Error {
+				t.Errorf("%s: error %q, want error %q", v.Name, err.Error(), v.Error)
+			}
+			continue
+		}
+		if 0 < len(v.Error) {
+			t.Errorf("%s: no error, want error %q", v.Name, v.Error)
+			continue
+		}
+		if !reflect.DeepEqual(v.View, v.Result) {
+			t.Errorf("%s: result = %v, want %v", v.Name, v.View, v.Result)
+		}
+	}
+}
+
 var calcMinimumRequiredTests = []struct {
 	Int1    int
 	Int2    int
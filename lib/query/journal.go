@@ -0,0 +1,50 @@
+package query
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/mithrandie/csvq/lib/cmd"
+	"github.com/mithrandie/csvq/lib/file"
+
+	"github.com/mitchellh/go-homedir"
+)
+
+// journalDir is the directory where the journals of commits are written.
+// If it is empty, then the directory "journal" in the csvq directory of the user is used.
+var journalDir = ""
+
+// JournalDir returns the directory where the journals of commits are written.
+// An empty string is returned if the home directory of the user is not found.
+func JournalDir() string {
+	if 0 < len(journalDir) {
+		return journalDir
+	}
+
+	home, err := homedir.Dir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, string(cmd.HiddenPrefix)+cmd.CSVQConfigDir, "journal")
+}
+
+// CoordinatorDir returns the directory where the participants of coordinated commits are recorded.
+func CoordinatorDir() string {
+	return filepath.Join(JournalDir(), "coordinator")
+}
+
+// RecoverInterruptedCommits completes or rolls back the commits interrupted by crashes of processes.
+func RecoverInterruptedCommits(tx *Transaction) error {
+	if len(JournalDir()) < 1 {
+		return nil
+	}
+
+	committed, rolledBack, err := file.Recover(JournalDir())
+	for _, path := range committed {
+		tx.Session.LogNotice(fmt.Sprintf("Recovery: file %q is committed.", path), tx.Flags.Quiet)
+	}
+	for _, path := range rolledBack {
+		tx.Session.LogNotice(fmt.Sprintf("Recovery: changes of file %q are rolled back.", path), tx.Flags.Quiet)
+	}
+	return err
+}
@@ -0,0 +1,152 @@
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mithrandie/csvq/lib/file"
+	"github.com/mithrandie/csvq/lib/parser"
+)
+
+func TestRecoverInterruptedCommits(t *testing.T) {
+	fpath := GetTestFilePath("recover_test.csv")
+	defer func() {
+		_ = os.RemoveAll(JournalDir())
+		_ = os.Remove(fpath)
+		initFlag(TestTx.Flags)
+	}()
+
+	if err := os.MkdirAll(JournalDir(), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(fpath, []byte("c1\n1"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(file.TempFilePath(fpath), []byte("c1\n2"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(file.LockFilePath(fpath), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	journalPath := filepath.Join(JournalDir(), "1-1"+file.JournalFileSuffix)
+	b, _ := json.Marshal([]file.JournalEntry{{Path: fpath, TempPath: file.TempFilePath(fpath), LockPath: file.LockFilePath(fpath)}})
+	if err := ioutil.WriteFile(journalPath, b, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(file.CommitMarkerPath(journalPath), nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tx := TestTx
+	tx.Flags.SetQuiet(false)
+
+	r, w, _ := os.Pipe()
+	tx.Session.Stdout = w
+
+	err := RecoverInterruptedCommits(tx)
+
+	_ = w.Close()
+	log, _ := ioutil.ReadAll(r)
+
+	if err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	expect := fmt.Sprintf("Recovery: file %q is committed.\n", fpath)
+	if string(log) != expect {
+		t.Errorf("log = %q, want %q", string(log), expect)
+	}
+	if b, _ := ioutil.ReadFile(fpath); string(b) != "c1\n2" {
+		t.Errorf("file = %q, want %q", string(b), "c1\n2")
+	}
+}
+
+func TestTransaction_CommitWithoutJournal(t *testing.T) {
+	fpath := GetTestFilePath("journal_commit_test.csv")
+	defer func(dir string) {
+		journalDir = dir
+		_ = TestTx.Rollback(nil, nil)
+		_ = os.Remove(fpath)
+		initFlag(TestTx.Flags)
+	}(journalDir)
+
+	if err := ioutil.WriteFile(fpath, []byte("c1\n1"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// The journal directory cannot be created under a file.
+	journalDir = filepath.Join(fpath, "journal")
+
+	TestTx.Flags.Repository = TestDir
+	TestTx.Flags.SetQuiet(false)
+
+	r, w, _ := os.Pipe()
+	TestTx.Session.Stdout = w
+
+	statements, _, _ := parser.Parse("INSERT INTO journal_commit_test VALUES (2); COMMIT;", "", nil, false)
+	_, err := NewProcessor(TestTx).Execute(context.Background(), statements)
+
+	_ = w.Close()
+	log, _ := ioutil.ReadAll(r)
+
+	if err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	expect := fmt.Sprintf("Warning: unable to create journal directory %s. The commit cannot be recovered if it is interrupted.\n", journalDir)
+	if !strings.Contains(string(log), expect) {
+		t.Errorf("log = %q, want the warning %q", string(log), expect)
+	}
+	if b, _ := ioutil.ReadFile(fpath); string(b) != "c1\n1\n2" {
+		t.Errorf("file = %q, want %q", string(b), "c1\n1\n2")
+	}
+}
+
+func TestTransaction_CommitKeepsJournal(t *testing.T) {
+	fpath := GetTestFilePath("journal_keep_test.csv")
+	defer func() {
+		_ = TestTx.Rollback(nil, nil)
+		_ = os.RemoveAll(JournalDir())
+		_ = os.Remove(fpath)
+		_ = os.Remove(file.LockFilePath(fpath))
+		initFlag(TestTx.Flags)
+	}()
+
+	if err := ioutil.WriteFile(fpath, []byte("c1\n1"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	TestTx.Flags.Repository = TestDir
+	TestTx.Flags.SetQuiet(true)
+	TestTx.Session.Stdout = NewDiscard()
+
+	execute := func(src string) error {
+		statements, _, _ := parser.Parse(src, "", nil, false)
+		_, err := NewProcessor(TestTx).Execute(context.Background(), statements)
+		return err
+	}
+
+	if err := execute("INSERT INTO journal_keep_test VALUES (2);"); err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	// The file cannot be replaced without the temporary file.
+	if err := os.Remove(file.TempFilePath(fpath)); err != nil {
+		t.Fatal(err)
+	}
+	if err := execute("COMMIT;"); err == nil {
+		t.Fatalf("no error, want an error for the file that cannot be replaced")
+	}
+
+	files, _ := ioutil.ReadDir(JournalDir())
+	if len(files) != 2 {
+		t.Errorf("%d files are left in the journal directory, want the journal and the commit marker", len(files))
+	}
+	if !file.Exists(file.LockFilePath(fpath)) {
+		t.Errorf("lock file is removed, want it to be left for the recovery")
+	}
+}
@@ -0,0 +1,121 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/mithrandie/csvq/lib/lua"
+	"github.com/mithrandie/csvq/lib/parser"
+	"github.com/mithrandie/csvq/lib/value"
+
+	"github.com/mithrandie/ternary"
+)
+
+const LuaLanguage = "LUA"
+
+// LuaFunction is the body of a user defined function written in Lua.
+// Global variables in the state persist across calls of the function.
+type LuaFunction struct {
+	fn    *lua.Function
+	state *lua.State
+}
+
+func compileLuaFunction(expr parser.FunctionDeclaration, parameters []parser.Variable) (*LuaFunction, error) {
+	names := make([]string, len(parameters))
+	for i, v := range parameters {
+		names[i] = v.Name
+	}
+
+	fn, err := lua.Compile(expr.Name.Literal, expr.Source.Raw(), names)
+	if err != nil {
+		return nil, NewFunctionScriptError(expr.Name, err.Error())
+	}
+	return &LuaFunction{
+		fn:    fn,
+		state: lua.NewState(lua.DefaultLimits),
+	}, nil
+}
+
+func (fn *LuaFunction) Execute(ctx context.Context, name parser.Identifier, values []value.Primary) (value.Primary, error) {
+	args := make([]lua.Value, len(values))
+	for i, p := range values {
+		args[i] = convertToLuaValue(p)
+	}
+
+	results, err := fn.state.Call(ctx, fn.fn, args...)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, NewContextIsDone(ctx.Err().Error())
+		}
+		return nil, NewFunctionScriptError(name, err.Error())
+	}
+	if len(results) < 1 {
+		return value.NewNull(), nil
+	}
+
+	ret, err := convertFromLuaValue(results[0])
+	if err != nil {
+		return nil, NewFunctionScriptError(name, err.Error())
+	}
+	return ret, nil
+}
+
+func convertToLuaValue(p value.Primary) lua.Value {
+	switch v := p.(type) {
+	case value.String:
+		return v.Raw()
+	case value.Integer:
+		return v.Raw()
+	case value.Float:
+		return v.Raw()
+	case value.Boolean:
+		return v.Raw()
+	case value.Ternary:
+		if v.Ternary() == ternary.UNKNOWN {
+			return nil
+		}
+		return v.Ternary() == ternary.TRUE
+	case value.Datetime:
+		return v.Format(time.RFC3339Nano)
+	case value.Json:
+		return v.String()
+	case value.Array:
+		t := lua.NewTable()
+		for i, e := range v.Raw() {
+			t.Set(int64(i+1), convertToLuaValue(e))
+		}
+		return t
+	}
+	return nil
+}
+
+func convertFromLuaValue(v lua.Value) (value.Primary, error) {
+	switch x := v.(type) {
+	case nil:
+		return value.NewNull(), nil
+	case bool:
+		return value.NewBoolean(x), nil
+	case int64:
+		return value.NewInteger(x), nil
+	case float64:
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return value.NewNull(), nil
+		}
+		return value.NewFloat(x), nil
+	case string:
+		return value.NewString(x), nil
+	case *lua.Table:
+		values := make([]value.Primary, int(x.Len()))
+		for i := range values {
+			e, err := convertFromLuaValue(x.Get(int64(i + 1)))
+			if err != nil {
+				return nil, err
+			}
+			values[i] = e
+		}
+		return value.NewArray(values), nil
+	}
+	return nil, fmt.Errorf("cannot return a %s value", lua.TypeName(v))
+}
@@ -0,0 +1,149 @@
+package query
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/mithrandie/csvq/lib/parser"
+	"github.com/mithrandie/csvq/lib/value"
+
+	"github.com/mithrandie/ternary"
+)
+
+var luaFunctionTests = []struct {
+	Name       string
+	Expr       parser.FunctionDeclaration
+	Args       []value.Primary
+	Result     value.Primary
+	DeclareErr string
+	ExecuteErr string
+}{
+	{
+		Name: "Lua Function",
+		Expr: parser.FunctionDeclaration{
+			Name: parser.Identifier{Literal: "luafunc"},
+			Parameters: []parser.VariableAssignment{
+				{Variable: parser.Variable{Name: "s"}},
+				{Variable: parser.Variable{Name: "sep"}, Value: parser.NewStringValue("-")},
+			},
+			Language: parser.Identifier{Literal: "lua"},
+			Source:   value.NewString("return (s:lower():gsub('[^%w]+', sep))"),
+		},
+		Args:   []value.Primary{value.NewString("Hello, World")},
+		Result: value.NewString("hello-world"),
+	},
+	{
+		Name: "Lua Function Arguments Conversion",
+		Expr: parser.FunctionDeclaration{
+			Name: parser.Identifier{Literal: "luafunc"},
+			Parameters: []parser.VariableAssignment{
+				{Variable: parser.Variable{Name: "i"}},
+				{Variable: parser.Variable{Name: "f"}},
+				{Variable: parser.Variable{Name: "b"}},
+				{Variable: parser.Variable{Name: "t"}},
+				{Variable: parser.Variable{Name: "n"}},
+			},
+			Language: parser.Identifier{Literal: "LUA"},
+			Source:   value.NewString("return {math.type(i), math.type(f), type(b), type(t), type(n)}"),
+		},
+		Args: []value.Primary{
+			value.NewInteger(1),
+			value.NewFloat(1.5),
+			value.NewBoolean(true),
+			value.NewTernary(ternary.UNKNOWN),
+			value.NewNull(),
+		},
+		Result: value.NewArray([]value.Primary{
+			value.NewString("integer"),
+			value.NewString("float"),
+			value.NewString("boolean"),
+			value.NewString("nil"),
+			value.NewString("nil"),
+		}),
+	},
+	{
+		Name: "Lua Function Returns Nothing",
+		Expr: parser.FunctionDeclaration{
+			Name:     parser.Identifier{Literal: "luafunc"},
+			Language: parser.Identifier{Literal: "lua"},
+			Source:   value.NewString("local x = 1"),
+		},
+		Result: value.NewNull(),
+	},
+	{
+		Name: "Lua Function Unsupported Language Error",
+		Expr: parser.FunctionDeclaration{
+			Name:     parser.Identifier{Literal: "luafunc"},
+			Language: parser.Identifier{Literal: "python"},
+			Source:   value.NewString("return 1"),
+		},
+		DeclareErr: "language python is not supported for user defined functions",
+	},
+	{
+		Name: "Lua Function Syntax Error",
+		Expr: parser.FunctionDeclaration{
+			Name:     parser.Identifier{Literal: "luafunc"},
+			Language: parser.Identifier{Literal: "lua"},
+			Source:   value.NewString("return ("),
+		},
+		DeclareErr: "line 1: unexpected symbol near <eof> in function luafunc",
+	},
+	{
+		Name: "Lua Function Runtime Error",
+		Expr: parser.FunctionDeclaration{
+			Name:     parser.Identifier{Literal: "luafunc"},
+			Language: parser.Identifier{Literal: "lua"},
+			Source:   value.NewString("error('failed')"),
+		},
+		ExecuteErr: "line 1: failed in function luafunc",
+	},
+	{
+		Name: "Lua Function Return Value Error",
+		Expr: parser.FunctionDeclaration{
+			Name:     parser.Identifier{Literal: "luafunc"},
+			Language: parser.Identifier{Literal: "lua"},
+			Source:   value.NewString("return print or type"),
+		},
+		ExecuteErr: "cannot return a function value in function luafunc",
+	},
+}
+
+func TestLuaFunction_Execute(t *testing.T) {
+	for _, v := range luaFunctionTests {
+		filter := NewFilter(TestTx)
+
+		m := UserDefinedFunctionMap{}
+		err := m.Declare(v.Expr)
+		if err != nil {
+			if len(v.DeclareErr) < 1 {
+				t.Errorf("%s: unexpected error %q", v.Name, err)
+			} else if err.Error() != v.DeclareErr {
+				t.Errorf("%s: error %q, want error %q", v.Name, err.Error(), v.DeclareErr)
+			}
+			continue
+		}
+		if 0 < len(v.DeclareErr) {
+			t.Errorf("%s: no error, want error %q", v.Name, v.DeclareErr)
+			continue
+		}
+
+		fn, _ := m.Get(v.Expr.Name, v.Expr.Name.Literal)
+		result, err := fn.Execute(context.Background(), filter, v.Args)
+		if err != nil {
+			if len(v.ExecuteErr) < 1 {
+				t.Errorf("%s: unexpected error %q", v.Name, err)
+			} else if err.Error() != v.ExecuteErr {
+				t.Errorf("%s: error %q, want error %q", v.Name, err.Error(), v.ExecuteErr)
+			}
+			continue
+		}
+		if 0 < len(v.ExecuteErr) {
+			t.Errorf("%s: no error, want error %q", v.Name, v.ExecuteErr)
+			continue
+		}
+		if !reflect.DeepEqual(result, v.Result) {
+			t.Errorf("%s: result = %s, want %s", v.Name, result, v.Result)
+		}
+	}
+}
@@ -62,6 +62,7 @@ func setup() {
 	}
 
 	cmd.TestTime = NowForTest
+	journalDir = filepath.Join(TestDir, "journal")
 
 	TestDataDir = filepath.Join(GetWD(), "..", "..", "testdata", "csv")
 
@@ -142,7 +143,10 @@ func initFlag(flags *cmd.Flags) {
 	flags.Repository = "."
 	flags.Location = TestLocation
 	flags.DatetimeFormat = []string{}
+	flags.CaseSensitive = false
 	flags.WaitTimeout = 15
+	flags.LockRetryLimit = -1
+	flags.LockBackoff = file.FixedBackoff
 	flags.ImportFormat = cmd.CSV
 	flags.Delimiter = ','
 	flags.DelimiterPositions = nil
@@ -166,6 +170,24 @@ func initFlag(flags *cmd.Flags) {
 	flags.CountFormatCode = false
 	flags.Quiet = false
 	flags.CPU = cpu
+	flags.LimitRecursion = cmd.DefaultLimitRecursion
+	flags.RandomSeed = -1
+	flags.SortBufferSize = -1
+	flags.MaxMemory = -1
+	flags.Mmap = false
+	flags.Columnar = false
+	flags.StatisticsCache = false
+	flags.ResultCache = false
+	flags.Progress = false
+	flags.BackupExtension = ""
+	flags.BackupDir = ""
+	flags.AuditLog = ""
+	_ = flags.SetEncryptionKeyFile("")
+	flags.OnCommit = ""
+	flags.CoordinatorToken = ""
+	flags.Participants = 2
+	flags.ReadOnly = false
+	flags.DryRun = false
 	flags.Stats = false
 	flags.SetColor(false)
 }
@@ -0,0 +1,285 @@
+package query
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+	"unsafe"
+
+	"github.com/mithrandie/csvq/lib/file"
+	"github.com/mithrandie/csvq/lib/value"
+
+	"github.com/mithrandie/go-text"
+)
+
+// errMappedFileNotReadable means that the mapped file has data that the mappedCSVReader does not read,
+// such as malformed records or invalid byte sequences.
+// The file is read again with the usual reader to get the same results or errors.
+var errMappedFileNotReadable = errors.New("mapped file is not readable")
+
+// mapFile maps the file into memory if memory-mapped files are enabled and the file is a local regular file.
+func mapFile(tx *Transaction, fp io.Reader) ([]byte, bool) {
+	if !tx.Flags.Mmap {
+		return nil, false
+	}
+	f, ok := fp.(*os.File)
+	if !ok {
+		return nil, false
+	}
+	data, err := file.Map(f)
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// mappedString returns the string referring to the bytes without copying them.
+func mappedString(b []byte) string {
+	if len(b) < 1 {
+		return ""
+	}
+	return *(*string)(unsafe.Pointer(&b))
+}
+
+// mappedCSVReader reads records from csv data in a memory-mapped file encoded in UTF-8.
+// The strings of the fields refer to the mapped memory and are not copied unless they contain escaped double quotes.
+type mappedCSVReader struct {
+	data      []byte
+	pos       int
+	delimiter byte
+
+	FieldsPerRecord int
+
+	DetectedLineBreak text.LineBreak
+	EnclosedAll       bool
+}
+
+// canReadMappedCSV reports whether the mappedCSVReader can read the data with the delimiter and the encoding.
+func canReadMappedCSV(delimiter rune, enc text.Encoding) bool {
+	return (enc == text.UTF8 || enc == text.UTF8M) &&
+		delimiter < utf8.RuneSelf && delimiter != '"' && delimiter != '\r' && delimiter != '\n'
+}
+
+func newMappedCSVReader(data []byte, delimiter rune, enc text.Encoding) (*mappedCSVReader, error) {
+	pos := 0
+	if enc == text.UTF8M {
+		if !bytes.HasPrefix(data, text.UTF8BOM()) {
+			return nil, errMappedFileNotReadable
+		}
+		pos = len(text.UTF8BOM())
+	}
+
+	return &mappedCSVReader{
+		data:        data,
+		pos:         pos,
+		delimiter:   byte(delimiter),
+		EnclosedAll: true,
+	}, nil
+}
+
+func (r *mappedCSVReader) ReadHeader() ([]string, error) {
+	record, err := r.read(true)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]string, len(record))
+	for i, v := range record {
+		header[i] = v.(value.String).Raw()
+	}
+	return header, nil
+}
+
+func (r *mappedCSVReader) read(withoutNull bool) ([]value.Primary, error) {
+	record := make([]value.Primary, 0, r.FieldsPerRecord)
+
+	for {
+		if 0 < r.FieldsPerRecord && r.FieldsPerRecord <= len(record) {
+			return nil, errMappedFileNotReadable
+		}
+
+		s, quoted, eol, err := r.readField()
+		if err != nil {
+			return nil, err
+		}
+
+		if eol && len(record) < 1 && len(s) < 1 {
+			if len(r.data) <= r.pos {
+				return nil, io.EOF
+			}
+			continue
+		}
+
+		if !withoutNull && len(s) < 1 && !quoted {
+			record = append(record, value.NewNull())
+		} else {
+			record = append(record, value.NewString(s))
+		}
+
+		if eol {
+			break
+		}
+	}
+
+	if r.FieldsPerRecord < 1 {
+		r.FieldsPerRecord = len(record)
+	} else if len(record) < r.FieldsPerRecord {
+		return nil, errMappedFileNotReadable
+	}
+	return record, nil
+}
+
+func (r *mappedCSVReader) readField() (string, bool, bool, error) {
+	if r.pos < len(r.data) && r.data[r.pos] == '"' {
+		return r.readQuotedField()
+	}
+
+	start := r.pos
+	multibyte := false
+	for i := start; i < len(r.data); i++ {
+		c := r.data[i]
+		switch {
+		case c == r.delimiter:
+			r.pos = i + 1
+			s, err := r.unquotedString(start, i, multibyte)
+			return s, false, false, err
+		case c == '\n' || c == '\r':
+			if err := r.skipLineBreak(i); err != nil {
+				return "", false, true, err
+			}
+			s, err := r.unquotedString(start, i, multibyte)
+			return s, false, true, err
+		case utf8.RuneSelf <= c:
+			multibyte = true
+		case r.EnclosedAll && (('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')):
+			r.EnclosedAll = false
+		}
+	}
+
+	r.pos = len(r.data)
+	s, err := r.unquotedString(start, len(r.data), multibyte)
+	return s, false, true, err
+}
+
+func (r *mappedCSVReader) unquotedString(start int, end int, multibyte bool) (string, error) {
+	b := r.data[start:end]
+	if multibyte {
+		if !utf8.Valid(b) {
+			return "", errMappedFileNotReadable
+		}
+		if r.EnclosedAll && bytes.IndexFunc(b, unicode.IsLetter) != -1 {
+			r.EnclosedAll = false
+		}
+	}
+	return mappedString(b), nil
+}
+
+func (r *mappedCSVReader) readQuotedField() (string, bool, bool, error) {
+	start := r.pos + 1
+	escaped := false
+
+	i := start
+	for {
+		n := bytes.IndexByte(r.data[i:], '"')
+		if n < 0 {
+			return "", true, false, errMappedFileNotReadable
+		}
+		i += n
+
+		if i+1 < len(r.data) && r.data[i+1] == '"' {
+			escaped = true
+			i += 2
+			continue
+		}
+		break
+	}
+
+	b := r.data[start:i]
+	if !utf8.Valid(b) {
+		return "", true, false, errMappedFileNotReadable
+	}
+	s := mappedString(b)
+	if escaped {
+		s = strings.ReplaceAll(s, "\"\"", "\"")
+	}
+
+	next := i + 1
+	switch {
+	case len(r.data) <= next:
+		r.pos = len(r.data)
+		return s, true, true, nil
+	case r.data[next] == r.delimiter:
+		r.pos = next + 1
+		return s, true, false, nil
+	case r.data[next] == '\n' || r.data[next] == '\r':
+		if err := r.skipLineBreak(next); err != nil {
+			return "", true, true, err
+		}
+		return s, true, true, nil
+	}
+	return "", true, false, errMappedFileNotReadable
+}
+
+// skipLineBreak moves the position next to the line break at i.
+func (r *mappedCSVReader) skipLineBreak(i int) error {
+	lineBreak := text.LF
+	next := i + 1
+	if r.data[i] == '\r' {
+		switch {
+		case len(r.data) <= next:
+			// The usual reader fails to read a carriage return at the end of the file.
+			return errMappedFileNotReadable
+		case r.data[next] == '\n':
+			lineBreak = text.CRLF
+			next++
+		default:
+			lineBreak = text.CR
+		}
+	}
+
+	if r.DetectedLineBreak == "" {
+		r.DetectedLineBreak = lineBreak
+	}
+	r.pos = next
+	return nil
+}
+
+// readAll reads all the records.
+// If the columns are not nil, then the fields not in the columns are set to null,
+// and the flags of the pruned fields are returned.
+func (r *mappedCSVReader) readAll(ctx context.Context, records *recordSetBuilder, withoutNull bool, columns ColumnSet, header []string) (RecordSet, []bool, error) {
+	var pruned []bool
+
+	for {
+		if ctx.Err() != nil {
+			return nil, nil, NewContextIsDone(ctx.Err().Error())
+		}
+
+		fields, err := r.read(withoutNull)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if columns != nil {
+			pruned = columns.prunedFields(header, len(fields))
+			columns = nil
+		}
+		for i := range pruned {
+			if pruned[i] {
+				fields[i] = value.NewNull()
+			}
+		}
+
+		records.Append(fields)
+	}
+
+	return records.RecordSet(), pruned, nil
+}
@@ -0,0 +1,170 @@
+package query
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"github.com/mithrandie/go-text"
+)
+
+var loadViewFromMappedCSVFileTests = []struct {
+	Name        string
+	Data        string
+	Delimiter   rune
+	Encoding    text.Encoding
+	NoHeader    bool
+	WithoutNull bool
+	Columns     ColumnSet
+	NotReadable bool
+}{
+	{
+		Name: "Fields",
+		Data: "column1,column2\n1,str1\n2,\n3,\"\"\n",
+	},
+	{
+		Name: "Quoted Fields",
+		Data: "\"column1\",\"column2\"\r\n\"1\",\"str \"\"1\"\"\"\r\n\"2\",\"line1\nline2\"",
+	},
+	{
+		Name: "Empty Lines",
+		Data: "column1,column2\n\n1,str1\n\n\n2,str2",
+	},
+	{
+		Name:      "Tab Delimiter",
+		Data:      "column1\tcolumn2\r1\tstr1\r2\tstr2",
+		Delimiter: '\t',
+	},
+	{
+		Name:     "UTF-8 with BOM",
+		Data:     "\xef\xbb\xbfcolumn1,column2\n1,文字列\n",
+		Encoding: text.UTF8M,
+	},
+	{
+		Name:     "No Header",
+		Data:     "1,str1\n2,str2\n",
+		NoHeader: true,
+	},
+	{
+		Name:        "Without Null",
+		Data:        "column1,column2\n1,\n",
+		WithoutNull: true,
+	},
+	{
+		Name:    "Pruned Columns",
+		Data:    "column1,column2\n1,str1\n2,str2\n",
+		Columns: ColumnSet{"COLUMN1": true},
+	},
+	{
+		Name:        "Wrong Number of Fields",
+		Data:        "column1,column2\n1,str1,3\n",
+		NotReadable: true,
+	},
+	{
+		Name:        "Unexpected Double Quote",
+		Data:        "column1,column2\n1,\"str1\"a\n",
+		NotReadable: true,
+	},
+	{
+		Name:        "Carriage Return at the End of File",
+		Data:        "column1,column2\r1,str1\r",
+		NotReadable: true,
+	},
+	{
+		Name:        "Invalid Byte Sequence",
+		Data:        "column1,column2\n1,\xff\n",
+		NotReadable: true,
+	},
+}
+
+func TestLoadViewFromMappedCSVFile(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("memory-mapped files are not supported")
+	}
+
+	defer initFlag(TestTx.Flags)
+
+	path := filepath.Join(TestDir, "mapped_reader_test.csv")
+	defer func() {
+		_ = os.Remove(path)
+	}()
+
+	ctx := context.Background()
+	for _, v := range loadViewFromMappedCSVFileTests {
+		if err := ioutil.WriteFile(path, []byte(v.Data), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		newFileInfo := func() *FileInfo {
+			fileInfo := &FileInfo{
+				Path:      path,
+				Delimiter: ',',
+				Encoding:  text.UTF8,
+				NoHeader:  v.NoHeader,
+			}
+			if v.Delimiter != 0 {
+				fileInfo.Delimiter = v.Delimiter
+			}
+			if v.Encoding != "" {
+				fileInfo.Encoding = v.Encoding
+			}
+			return fileInfo
+		}
+
+		fp, err := os.Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		TestTx.Flags.Mmap = false
+		expectFileInfo := newFileInfo()
+		expect, expectErr := loadViewFromCSVFile(ctx, TestTx, fp, expectFileInfo, v.WithoutNull, v.Columns)
+
+		data, ok := mapFile(TestTx, fp)
+		if ok {
+			t.Errorf("%s: file is mapped while the flag is disabled", v.Name)
+		}
+
+		TestTx.Flags.Mmap = true
+		data, ok = mapFile(TestTx, fp)
+		_ = fp.Close()
+		if !ok {
+			t.Errorf("%s: file is not mapped", v.Name)
+			continue
+		}
+
+		fileInfo := newFileInfo()
+		view, err := loadViewFromMappedCSVFile(ctx, TestTx, data, fileInfo, v.WithoutNull, v.Columns)
+		if v.NotReadable {
+			if err != errMappedFileNotReadable {
+				t.Errorf("%s: error = %v, want %v", v.Name, err, errMappedFileNotReadable)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error %q", v.Name, err)
+			continue
+		}
+		if expectErr != nil {
+			t.Errorf("%s: unexpected error %q in the usual reader", v.Name, expectErr)
+			continue
+		}
+
+		if !reflect.DeepEqual(view.Header, expect.Header) {
+			t.Errorf("%s: header = %v, want %v", v.Name, view.Header, expect.Header)
+		}
+		if !reflect.DeepEqual(view.RecordSet, expect.RecordSet) {
+			t.Errorf("%s: records = %v, want %v", v.Name, view.RecordSet, expect.RecordSet)
+		}
+		if !reflect.DeepEqual(view.prunedFields, expect.prunedFields) {
+			t.Errorf("%s: pruned fields = %v, want %v", v.Name, view.prunedFields, expect.prunedFields)
+		}
+		if fileInfo.LineBreak != expectFileInfo.LineBreak || fileInfo.EncloseAll != expectFileInfo.EncloseAll {
+			t.Errorf("%s: line break and enclose all = %s and %t, want %s and %t", v.Name, fileInfo.LineBreak, fileInfo.EncloseAll, expectFileInfo.LineBreak, expectFileInfo.EncloseAll)
+		}
+	}
+}
@@ -0,0 +1,327 @@
+package query
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/mithrandie/csvq/lib/cmd"
+)
+
+const megabyte = 1024 * 1024
+
+// MemorySizeSamples is the maximum number of records used to estimate the memory size of a record set.
+var MemorySizeSamples = 1000
+
+func memoryLimit(flags *cmd.Flags) int64 {
+	if flags.MaxMemory < 0 {
+		return -1
+	}
+	return int64(flags.MaxMemory) * megabyte
+}
+
+// estimateRecordSetSize returns the approximate memory size of the records.
+// The size of a large record set is estimated from evenly spaced samples.
+func estimateRecordSetSize(records RecordSet) int64 {
+	if len(records) < 1 {
+		return 0
+	}
+
+	step := 1
+	if MemorySizeSamples < len(records) {
+		step = len(records) / MemorySizeSamples
+	}
+
+	var size int64
+	var samples int64
+	for i := 0; i < len(records); i += step {
+		size += estimateRecordSize(records[i])
+		samples++
+	}
+	return size * int64(len(records)) / samples
+}
+
+func estimateRecordSize(record Record) int64 {
+	size := int64(24)
+	for _, cell := range record {
+		size += 24
+		for _, p := range cell {
+			size += estimatePrimarySize(p)
+		}
+	}
+	return size
+}
+
+// estimateJoinedRecordSetSize returns the approximate memory size of the records created by joining
+// records with fieldLen fields. The values are shared with the source records.
+func estimateJoinedRecordSetSize(recordLen int, fieldLen int) int64 {
+	return int64(recordLen) * (24 + 24*int64(fieldLen))
+}
+
+// memoryUsage returns the approximate memory size of the records of the loaded tables
+// and the temporary tables held in memory.
+func memoryUsage(filter *Filter) int64 {
+	var size int64
+	for _, view := range filter.tx.cachedViews {
+		size += estimateRecordSetSize(view.RecordSet)
+	}
+	for _, m := range filter.tempViews {
+		for _, view := range m {
+			size += estimateRecordSetSize(view.RecordSet)
+		}
+	}
+	return size
+}
+
+// reserveMemory reports whether records of the required size can be held in addition to
+// the records currently held without exceeding the limit specified by the flag MAX_MEMORY.
+// If the limit is exceeded, then the records of temporary tables are written to temporary files
+// in descending order of size until the required size fits in the limit.
+func reserveMemory(filter *Filter, required int64) (bool, error) {
+	filter.tx.viewLoadingMutex.Lock()
+	defer filter.tx.viewLoadingMutex.Unlock()
+
+	return reserveMemoryWithoutLock(filter, required)
+}
+
+func reserveMemoryWithoutLock(filter *Filter, required int64) (bool, error) {
+	limit := memoryLimit(filter.tx.Flags)
+	if limit < 0 {
+		return true, nil
+	}
+
+	usage := memoryUsage(filter) + required
+	if usage <= limit {
+		return true, nil
+	}
+
+	type candidate struct {
+		view *View
+		size int64
+	}
+	candidates := make([]candidate, 0, 10)
+	for _, m := range filter.tempViews {
+		for key, view := range m {
+			if !view.FileInfo.IsTemporary || view.spilled != nil || view.RecordLen() < 1 {
+				continue
+			}
+			if _, ok := filter.tx.uncommittedViews.Updated[strings.ToUpper(key)]; ok {
+				continue
+			}
+			candidates = append(candidates, candidate{view: view, size: estimateRecordSetSize(view.RecordSet)})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].size > candidates[j].size
+	})
+
+	for _, c := range candidates {
+		if err := c.view.spill(); err != nil {
+			return false, err
+		}
+		usage -= c.size
+		if usage <= limit {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// checkMemoryLimit returns an error if records of the required size cannot be held in memory.
+func checkMemoryLimit(filter *Filter, required int64) error {
+	filter.tx.viewLoadingMutex.Lock()
+	defer filter.tx.viewLoadingMutex.Unlock()
+
+	return checkMemoryLimitWithoutLock(filter, required)
+}
+
+func checkMemoryLimitWithoutLock(filter *Filter, required int64) error {
+	ok, err := reserveMemoryWithoutLock(filter, required)
+	if err == nil && !ok {
+		err = NewMemoryLimitExceededError(filter.tx.Flags.MaxMemory)
+	}
+	return err
+}
+
+// spilledRecordSet is a record set written to a temporary file to release memory.
+type spilledRecordSet struct {
+	fp      *os.File
+	len     int
+	removed bool
+}
+
+func spillRecordSet(records RecordSet) (*spilledRecordSet, error) {
+	fp, err := os.CreateTemp("", "csvq_spill_")
+	if err != nil {
+		return nil, NewSystemError(err.Error())
+	}
+
+	// The file can be removed while it is open on most platforms,
+	// and then it is never left even if the process is terminated.
+	s := &spilledRecordSet{
+		fp:      fp,
+		len:     len(records),
+		removed: os.Remove(fp.Name()) == nil,
+	}
+
+	w := bufio.NewWriter(fp)
+	for _, record := range records {
+		if err = writeSpilledRecord(w, record); err != nil {
+			_ = s.Close()
+			return nil, err
+		}
+	}
+	if err = w.Flush(); err != nil {
+		_ = s.Close()
+		return nil, NewSystemError(err.Error())
+	}
+	return s, nil
+}
+
+func (s *spilledRecordSet) Len() int {
+	return s.len
+}
+
+// ReadChunks passes the records to fn in chunks of the size.
+func (s *spilledRecordSet) ReadChunks(ctx context.Context, size int, fn func(RecordSet) error) error {
+	if _, err := s.fp.Seek(0, io.SeekStart); err != nil {
+		return NewSystemError(err.Error())
+	}
+	r := bufio.NewReader(s.fp)
+
+	records := make(RecordSet, 0, size)
+	for i := 0; i < s.len; i++ {
+		if ctx.Err() != nil {
+			return NewContextIsDone(ctx.Err().Error())
+		}
+
+		record, err := readSpilledRecord(r)
+		if err != nil {
+			return NewSystemError(unexpectedEOF(err).Error())
+		}
+		records = append(records, record)
+
+		if size <= len(records) {
+			if err = fn(records); err != nil {
+				return err
+			}
+			records = make(RecordSet, 0, size)
+		}
+	}
+	if 0 < len(records) {
+		return fn(records)
+	}
+	return nil
+}
+
+// Load reads all the records into memory.
+func (s *spilledRecordSet) Load(ctx context.Context) (RecordSet, error) {
+	records := make(RecordSet, 0, s.len)
+	err := s.ReadChunks(ctx, StreamChunkSize, func(chunk RecordSet) error {
+		records = append(records, chunk...)
+		return nil
+	})
+	return records, err
+}
+
+// Close closes and removes the temporary file.
+func (s *spilledRecordSet) Close() error {
+	if s.fp == nil {
+		return nil
+	}
+
+	err := s.fp.Close()
+	if !s.removed {
+		if e := os.Remove(s.fp.Name()); e != nil && err == nil {
+			err = e
+		}
+	}
+	s.fp = nil
+	return err
+}
+
+func writeSpilledRecord(w *bufio.Writer, record Record) error {
+	buf := make([]byte, 0, 256)
+	buf = binary.AppendUvarint(buf, uint64(len(record)))
+	for _, cell := range record {
+		buf = binary.AppendUvarint(buf, uint64(len(cell)))
+		for _, p := range cell {
+			buf = appendSpilledPrimary(buf, p)
+		}
+	}
+
+	if _, err := w.Write(buf); err != nil {
+		return NewSystemError(err.Error())
+	}
+	return nil
+}
+
+func readSpilledRecord(r *bufio.Reader) (Record, error) {
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+
+	record := make(Record, n)
+	for i := range record {
+		if n, err = binary.ReadUvarint(r); err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		cell := make(Cell, n)
+		for j := range cell {
+			if cell[j], err = readSpilledPrimary(r); err != nil {
+				return nil, unexpectedEOF(err)
+			}
+		}
+		record[i] = cell
+	}
+	return record, nil
+}
+
+// spill writes the records of the temporary table to a temporary file and releases them from memory.
+// The table must not have been updated in the transaction, so that the records are also used
+// as the initial records to be restored by rollback.
+func (view *View) spill() error {
+	s, err := spillRecordSet(view.RecordSet)
+	if err != nil {
+		return err
+	}
+
+	view.closeSpilledRecordSet()
+	view.RecordSet = nil
+	view.spilled = s
+	view.FileInfo.InitialRecordSet = nil
+	view.FileInfo.spilledRecordSet = s
+	return nil
+}
+
+func (view *View) closeSpilledRecordSet() {
+	if view.FileInfo != nil && view.FileInfo.spilledRecordSet != nil {
+		_ = view.FileInfo.spilledRecordSet.Close()
+		view.FileInfo.spilledRecordSet = nil
+	}
+	view.spilled = nil
+}
+
+// copyView returns a copy of the view. If the records of the view have been spilled,
+// then the copy has the records read from the temporary file.
+func copyView(view *View) (*View, error) {
+	if view.spilled == nil {
+		return view.Copy(), nil
+	}
+
+	records, err := view.spilled.Load(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return &View{
+		Header:    view.Header.Copy(),
+		RecordSet: records,
+		FileInfo:  view.FileInfo,
+		ForUpdate: view.ForUpdate,
+	}, nil
+}
@@ -0,0 +1,250 @@
+package query
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mithrandie/csvq/lib/parser"
+	"github.com/mithrandie/csvq/lib/value"
+
+	"github.com/mithrandie/ternary"
+)
+
+func TestSpilledRecordSet(t *testing.T) {
+	location := time.FixedZone("", 9*60*60)
+	records := RecordSet{
+		NewRecord([]value.Primary{
+			value.NewNull(),
+			value.NewString("str"),
+			value.NewInteger(-12),
+		}),
+		NewRecord([]value.Primary{
+			value.NewFloat(1.5),
+			value.NewBoolean(true),
+			value.NewTernary(ternary.UNKNOWN),
+		}),
+		{
+			NewCell(value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 123456789, location))),
+			NewGroupCell([]value.Primary{value.NewInteger(1), value.NewInteger(2)}),
+			NewCell(value.NewArray([]value.Primary{value.NewInteger(1), value.NewString("a")})),
+		},
+	}
+
+	spilled, err := spillRecordSet(records)
+	if err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	defer func() {
+		_ = spilled.Close()
+	}()
+
+	if spilled.Len() != len(records) {
+		t.Errorf("length = %d, want %d", spilled.Len(), len(records))
+	}
+
+	var chunkLengths []int
+	err = spilled.ReadChunks(context.Background(), 2, func(chunk RecordSet) error {
+		chunkLengths = append(chunkLengths, len(chunk))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	if !reflect.DeepEqual(chunkLengths, []int{2, 1}) {
+		t.Errorf("chunk lengths = %v, want %v", chunkLengths, []int{2, 1})
+	}
+
+	result, err := spilled.Load(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	if !reflect.DeepEqual(result, records) {
+		t.Errorf("records = %s, want %s", result, records)
+	}
+}
+
+var joinSpilledRecordSetTests = []struct {
+	Name      string
+	Condition parser.QueryExpression
+	Direction int
+}{
+	{
+		Name: "Join Spilled Record Set Cross Join",
+	},
+	{
+		Name: "Join Spilled Record Set Inner Join",
+		Condition: parser.Comparison{
+			LHS:      parser.FieldReference{View: parser.Identifier{Literal: "table1"}, Column: parser.Identifier{Literal: "column1"}},
+			RHS:      parser.FieldReference{View: parser.Identifier{Literal: "table2"}, Column: parser.Identifier{Literal: "column1"}},
+			Operator: "=",
+		},
+	},
+	{
+		Name: "Join Spilled Record Set Left Outer Join",
+		Condition: parser.Comparison{
+			LHS:      parser.FieldReference{View: parser.Identifier{Literal: "table1"}, Column: parser.Identifier{Literal: "column1"}},
+			RHS:      parser.FieldReference{View: parser.Identifier{Literal: "table2"}, Column: parser.Identifier{Literal: "column1"}},
+			Operator: "=",
+		},
+		Direction: parser.LEFT,
+	},
+	{
+		Name: "Join Spilled Record Set Right Outer Join",
+		Condition: parser.Comparison{
+			LHS:      parser.FieldReference{View: parser.Identifier{Literal: "table1"}, Column: parser.Identifier{Literal: "column1"}},
+			RHS:      parser.FieldReference{View: parser.Identifier{Literal: "table2"}, Column: parser.Identifier{Literal: "column1"}},
+			Operator: "=",
+		},
+		Direction: parser.RIGHT,
+	},
+	{
+		Name: "Join Spilled Record Set Full Outer Join",
+		Condition: parser.Comparison{
+			LHS:      parser.FieldReference{View: parser.Identifier{Literal: "table1"}, Column: parser.Identifier{Literal: "column1"}},
+			RHS:      parser.FieldReference{View: parser.Identifier{Literal: "table2"}, Column: parser.Identifier{Literal: "column1"}},
+			Operator: "=",
+		},
+		Direction: parser.FULL,
+	},
+}
+
+func TestJoinSpilledRecordSet(t *testing.T) {
+	defer func(size int) {
+		StreamChunkSize = size
+	}(StreamChunkSize)
+	StreamChunkSize = 2
+
+	view := &View{
+		Header: NewHeader("table1", []string{"column1", "column2"}),
+		RecordSet: RecordSet{
+			NewRecord([]value.Primary{value.NewInteger(1), value.NewString("str1")}),
+			NewRecord([]value.Primary{value.NewInteger(2), value.NewString("str2")}),
+			NewRecord([]value.Primary{value.NewInteger(3), value.NewString("str3")}),
+		},
+	}
+	joinView := &View{
+		Header: NewHeader("table2", []string{"column1", "column3"}),
+		RecordSet: RecordSet{
+			NewRecord([]value.Primary{value.NewInteger(3), value.NewString("str33")}),
+			NewRecord([]value.Primary{value.NewInteger(2), value.NewString("str22")}),
+			NewRecord([]value.Primary{value.NewInteger(3), value.NewString("str333")}),
+			NewRecord([]value.Primary{value.NewInteger(4), value.NewString("str44")}),
+		},
+	}
+
+	ctx := context.Background()
+	filter := NewFilter(TestTx)
+
+	for _, v := range joinSpilledRecordSetTests {
+		expect := view.Copy()
+		var err error
+		switch v.Direction {
+		case parser.TokenUndefined:
+			err = InnerJoin(ctx, filter, expect, joinView.Copy(), v.Condition)
+		default:
+			err = OuterJoin(ctx, filter, expect, joinView.Copy(), v.Condition, v.Direction)
+		}
+		if err != nil {
+			t.Fatalf("%s: unexpected error %q", v.Name, err)
+		}
+
+		v1 := view.Copy()
+		v2 := joinView.Copy()
+		mergedHeader := MergeHeader(v1.Header, v2.Header)
+		if v.Direction == parser.RIGHT {
+			v1, v2 = v2, v1
+		}
+		spilled, err := spillRecordSet(v2.RecordSet)
+		if err != nil {
+			t.Fatalf("%s: unexpected error %q", v.Name, err)
+		}
+		v2.RecordSet = nil
+
+		result, err := joinSpilledRecordSet(ctx, filter, v1, v2, spilled, mergedHeader, v.Condition, v.Direction)
+		if err != nil {
+			t.Errorf("%s: unexpected error %q", v.Name, err)
+			continue
+		}
+		if !reflect.DeepEqual(result, expect.RecordSet) {
+			t.Errorf("%s: result = %s, want %s", v.Name, result, expect.RecordSet)
+		}
+	}
+}
+
+func TestReserveMemory(t *testing.T) {
+	defer func() {
+		_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+		TestTx.uncommittedViews.Clean()
+		initFlag(TestTx.Flags)
+	}()
+	_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+
+	records := make(RecordSet, 10000)
+	for i := range records {
+		records[i] = NewRecord([]value.Primary{value.NewInteger(int64(i)), value.NewString(strings.Repeat("a", 100))})
+	}
+	view := &View{
+		Header:    NewHeader("tmpview", []string{"column1", "column2"}),
+		RecordSet: records,
+		FileInfo: &FileInfo{
+			Path:             "tmpview",
+			IsTemporary:      true,
+			InitialHeader:    NewHeader("tmpview", []string{"column1", "column2"}),
+			InitialRecordSet: records.Copy(),
+		},
+	}
+	ident := parser.Identifier{Literal: "tmpview"}
+
+	filter := NewFilter(TestTx)
+	filter.tempViews.Set(view)
+	defer func() {
+		_ = filter.tempViews.Dispose(ident)
+	}()
+
+	TestTx.Flags.MaxMemory = 2
+
+	ok, err := reserveMemory(filter, 0)
+	if err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	if !ok {
+		t.Errorf("reserved = %t, want %t", ok, true)
+	}
+	if view.RecordSet != nil || view.spilled == nil || view.FileInfo.InitialRecordSet != nil {
+		t.Fatalf("records of temporary table are not spilled")
+	}
+
+	loaded, err := filter.tempViews.Get(ident)
+	if err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	if !reflect.DeepEqual(loaded.RecordSet, records) {
+		t.Errorf("records loaded from spilled temporary table are not the same as the original records")
+	}
+
+	expectErr := "[Memory Limit] records cannot be held within the memory limit of 2 megabytes"
+	err = checkMemoryLimit(filter, 3*megabyte)
+	if err == nil {
+		t.Errorf("no error, want error %q", expectErr)
+	} else if err.Error() != expectErr {
+		t.Errorf("error = %q, want error %q", err.Error(), expectErr)
+	}
+
+	loaded.RecordSet = loaded.RecordSet[:1]
+	filter.tempViews.Replace(loaded)
+	TestTx.uncommittedViews.SetForUpdatedView(loaded.FileInfo)
+
+	if _, err = filter.tempViews.Restore(TestTx.uncommittedViews.UncommittedTempViews()); err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	restored, _ := filter.tempViews.Get(ident)
+	if !reflect.DeepEqual(restored.RecordSet, records) {
+		t.Errorf("records restored from spilled temporary table are not the same as the original records")
+	}
+	if restored.FileInfo.spilledRecordSet != nil {
+		t.Errorf("temporary file of spilled temporary table is not closed")
+	}
+}
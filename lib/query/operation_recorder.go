@@ -0,0 +1,150 @@
+package query
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mithrandie/csvq/lib/cmd"
+	"github.com/mithrandie/csvq/lib/parser"
+)
+
+// operationKey identifies an operation of a query by the positions of the expressions in the statement.
+type operationKey struct {
+	Operation string
+	Expr      *parser.BaseExpr
+	JoinExpr  *parser.BaseExpr
+}
+
+func newOperationKey(operation string, expr parser.QueryExpression) operationKey {
+	return operationKey{Operation: operation, Expr: operationPosition(expr)}
+}
+
+func newJoinOperationKey(operation string, expr parser.QueryExpression, joinExpr parser.QueryExpression) operationKey {
+	return operationKey{Operation: operation, Expr: operationPosition(expr), JoinExpr: operationPosition(joinExpr)}
+}
+
+// operationPosition returns the position of the leftmost object in the expression.
+func operationPosition(expr parser.QueryExpression) *parser.BaseExpr {
+	switch e := expr.(type) {
+	case nil:
+		return nil
+	case parser.Parentheses:
+		return operationPosition(e.Expr)
+	case parser.Table:
+		if join, ok := e.Object.(parser.Join); ok {
+			return operationPosition(join.Table)
+		}
+		return operationPosition(e.Object)
+	case parser.SelectQuery:
+		return operationPosition(e.SelectEntity)
+	case parser.SelectEntity:
+		return operationPosition(e.SelectClause)
+	case parser.SelectSet:
+		return operationPosition(e.LHS)
+	}
+	return expr.GetBaseExpr()
+}
+
+type operationStats struct {
+	Rows  int
+	Loops int
+	Time  time.Duration
+}
+
+// FileLoadStat is the time spent to load a file.
+type FileLoadStat struct {
+	Path    string
+	Records int
+
+	// WaitTime is the time to lock and open the file, and ParseTime is the time to read and parse the file.
+	WaitTime  time.Duration
+	ParseTime time.Duration
+
+	start time.Time
+}
+
+// operationRecorder records the numbers of records and the elapsed times of the operations
+// executed by EXPLAIN ANALYZE.
+type operationRecorder struct {
+	mtx       *sync.Mutex
+	recording bool
+
+	stats map[operationKey]*operationStats
+	files []FileLoadStat
+}
+
+func newOperationRecorder() *operationRecorder {
+	return &operationRecorder{
+		mtx:       &sync.Mutex{},
+		recording: true,
+		stats:     make(map[operationKey]*operationStats),
+	}
+}
+
+// record adds the number of records in the view and the time elapsed since the start to the operation.
+// Operations at unknown positions are ignored.
+func (r *operationRecorder) record(key operationKey, view *View, start time.Time) {
+	if r == nil || key.Expr == nil {
+		return
+	}
+	elapsed := time.Since(start)
+
+	r.mtx.Lock()
+	if r.recording {
+		stats, ok := r.stats[key]
+		if !ok {
+			stats = &operationStats{}
+			r.stats[key] = stats
+		}
+		stats.Rows += view.RecordLen()
+		stats.Loops++
+		stats.Time += elapsed
+	}
+	r.mtx.Unlock()
+}
+
+func (r *operationRecorder) recordFileLoad(stat FileLoadStat) {
+	r.mtx.Lock()
+	if r.recording {
+		r.files = append(r.files, stat)
+	}
+	r.mtx.Unlock()
+}
+
+func (r *operationRecorder) stop() {
+	r.mtx.Lock()
+	r.recording = false
+	r.mtx.Unlock()
+}
+
+// annotate sets the recorded results of the operation to the node.
+func (r *operationRecorder) annotate(node *PlanNode, key operationKey) *PlanNode {
+	if r == nil {
+		return node
+	}
+
+	r.mtx.Lock()
+	if stats, ok := r.stats[key]; ok {
+		node.Analyzed = true
+		node.ActualRows = stats.Rows
+		node.Loops = stats.Loops
+		node.ActualTime = stats.Time
+	}
+	r.mtx.Unlock()
+	return node
+}
+
+// recordFileLoad records the time spent to load the file for EXPLAIN ANALYZE and the statistics.
+// This function must be called while the view loading mutex is locked.
+func recordFileLoad(filter *Filter, stat FileLoadStat) {
+	if filter.recorder != nil {
+		filter.recorder.recordFileLoad(stat)
+	}
+	if filter.tx.Flags.Stats {
+		filter.tx.fileLoadStats = append(filter.tx.fileLoadStats, stat)
+	}
+}
+
+func formatSeconds(d time.Duration) string {
+	return cmd.FormatNumber(d.Seconds(), 6, ".", ",", "") + " seconds"
+}
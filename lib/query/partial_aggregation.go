@@ -0,0 +1,402 @@
+package query
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/mithrandie/csvq/lib/parser"
+	"github.com/mithrandie/csvq/lib/value"
+
+	"github.com/mithrandie/ternary"
+)
+
+const PartialAggregateColumnPrefix = "@__aggregate_"
+
+// errPartialAggregationFailed means that an argument of an aggregate function cannot be evaluated for a record.
+// The records are grouped again without partial aggregation, so that the error is returned only if
+// the aggregate function is evaluated for the group of the record.
+var errPartialAggregationFailed = errors.New("partial aggregation failed")
+
+// partialAggregate is an aggregate function whose result can be computed from the partial results
+// of parts of the records.
+type partialAggregate struct {
+	name string
+	arg  parser.QueryExpression
+
+	// Counts the records instead of the values of the argument, as in COUNT(*).
+	countsRecords bool
+
+	// Nodes of the function in the query that the result is used for
+	nodes []*parser.BaseExpr
+}
+
+// aggregateState is a partial result of an aggregate function.
+type aggregateState struct {
+	count int64
+	sum   float64
+	value value.Primary
+}
+
+func (aggr *partialAggregate) update(state *aggregateState, p value.Primary, datetimeFormat []string) {
+	switch aggr.name {
+	case "COUNT":
+		if !value.IsNull(p) {
+			state.count++
+		}
+	case "SUM", "AVG":
+		f := value.ToFloat(p)
+		if !value.IsNull(f) {
+			state.sum += f.(value.Float).Raw()
+			state.count++
+		}
+	case "MIN":
+		if !value.IsNull(p) && (state.value == nil || value.Less(p, state.value, datetimeFormat) == ternary.TRUE) {
+			state.value = p
+		}
+	case "MAX":
+		if !value.IsNull(p) && (state.value == nil || value.Greater(p, state.value, datetimeFormat) == ternary.TRUE) {
+			state.value = p
+		}
+	}
+}
+
+// merge merges the state of the succeeding records into the state.
+func (aggr *partialAggregate) merge(state *aggregateState, next aggregateState, datetimeFormat []string) {
+	state.count += next.count
+	state.sum += next.sum
+	if next.value != nil {
+		aggr.update(state, next.value, datetimeFormat)
+	}
+}
+
+func (aggr *partialAggregate) result(state aggregateState, groupLen int) value.Primary {
+	switch aggr.name {
+	case "COUNT":
+		if aggr.countsRecords {
+			return value.NewInteger(int64(groupLen))
+		}
+		return value.NewInteger(state.count)
+	case "SUM":
+		if state.count < 1 {
+			return value.NewNull()
+		}
+		return value.ParseFloat64(state.sum)
+	case "AVG":
+		if state.count < 1 {
+			return value.NewNull()
+		}
+		return value.ParseFloat64(state.sum / float64(state.count))
+	}
+
+	if state.value == nil {
+		return value.NewNull()
+	}
+	return state.value
+}
+
+// partialAggregates returns the aggregate functions in the select clause and the having clause
+// that can be computed by partial aggregation.
+func partialAggregates(entity parser.SelectEntity) []*partialAggregate {
+	list := make([]*partialAggregate, 0)
+	collectPartialAggregates(reflect.ValueOf(entity.SelectClause), &list)
+	if entity.HavingClause != nil {
+		collectPartialAggregates(reflect.ValueOf(entity.HavingClause), &list)
+	}
+	return list
+}
+
+func collectPartialAggregates(v reflect.Value, list *[]*partialAggregate) {
+	switch v.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		if !v.IsNil() {
+			collectPartialAggregates(v.Elem(), list)
+		}
+		return
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			collectPartialAggregates(v.Index(i), list)
+		}
+		return
+	case reflect.Struct:
+		if !v.CanInterface() {
+			return
+		}
+	default:
+		return
+	}
+
+	switch e := v.Interface().(type) {
+	case parser.BaseExpr, parser.Subquery:
+		// Aggregate functions in subqueries are evaluated in the subqueries.
+		return
+	case parser.Function:
+		if _, ok := AggregateFunctions[strings.ToUpper(e.Name)]; ok {
+			addPartialAggregate(parser.AggregateFunction{BaseExpr: e.BaseExpr, Name: e.Name, Args: e.Args}, list)
+			return
+		}
+	case parser.AggregateFunction:
+		addPartialAggregate(e, list)
+		return
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		collectPartialAggregates(v.Field(i), list)
+	}
+}
+
+func addPartialAggregate(expr parser.AggregateFunction, list *[]*partialAggregate) {
+	name := strings.ToUpper(expr.Name)
+	switch name {
+	case "COUNT", "SUM", "AVG", "MIN", "MAX":
+	default:
+		return
+	}
+	if expr.BaseExpr == nil || expr.IsDistinct() || len(expr.Args) != 1 || !isRowExpression(reflect.ValueOf(expr.Args[0])) {
+		return
+	}
+
+	aggr := &partialAggregate{
+		name: name,
+		arg:  expr.Args[0],
+	}
+	switch expr.Args[0].(type) {
+	case parser.AllColumns:
+		if name != "COUNT" {
+			return
+		}
+		aggr.countsRecords = true
+	case parser.PrimitiveType:
+		aggr.countsRecords = name == "COUNT"
+	}
+
+	s := name + "(" + aggr.arg.String() + ")"
+	for _, a := range *list {
+		if a.name+"("+a.arg.String()+")" == s {
+			a.nodes = append(a.nodes, expr.BaseExpr)
+			return
+		}
+	}
+	aggr.nodes = []*parser.BaseExpr{expr.BaseExpr}
+	*list = append(*list, aggr)
+}
+
+// isRowExpression reports whether the expression can be evaluated for each record independently
+// without side effects.
+func isRowExpression(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil() || isRowExpression(v.Elem())
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			if !isRowExpression(v.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		if !v.CanInterface() {
+			return true
+		}
+	default:
+		return true
+	}
+
+	switch e := v.Interface().(type) {
+	case parser.BaseExpr:
+		return true
+	case parser.AggregateFunction, parser.ListFunction, parser.AnalyticFunction, parser.Subquery, parser.VariableSubstitution:
+		return false
+	case parser.Function:
+		name := strings.ToUpper(e.Name)
+		if _, ok := Functions[name]; !ok || isNondeterministicFunction(name) {
+			return false
+		}
+		if _, ok := AggregateFunctions[name]; ok {
+			return false
+		}
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		if !isRowExpression(v.Field(i)) {
+			return false
+		}
+	}
+	return true
+}
+
+// aggregatePartition is the groups of a part of the records and the partial results of the aggregate functions.
+type aggregatePartition struct {
+	keys    map[string]int
+	groups  []string
+	indices [][]int
+
+	// Partial results of the aggregate functions for each group, in the order of the groups.
+	states []aggregateState
+}
+
+// groupWithPartialAggregation groups the records in the same way as group, and computes the aggregate functions
+// at the same time.
+// The records are divided into parts, and each goroutine groups a part and computes the partial results.
+// The groups and the partial results are merged in the order of the parts, so the groups are in the same
+// order as grouped sequentially.
+// The results are held in the hidden columns of the grouped records, and used instead of evaluating
+// the aggregate functions for each group.
+func (view *View) groupWithPartialAggregation(ctx context.Context, items []parser.QueryExpression, aggregates []*partialAggregate) error {
+	datetimeFormat := view.Tx.Flags.DatetimeFormat
+
+	// Values of the fields are read from the records directly without evaluation.
+	fieldIndices := make([]int, len(aggregates))
+	for i, aggr := range aggregates {
+		fieldIndices[i] = -1
+		switch aggr.arg.(type) {
+		case parser.FieldReference, parser.ColumnNumber:
+			if idx, err := view.FieldIndex(aggr.arg); err == nil {
+				fieldIndices[i] = idx
+			}
+		}
+	}
+
+	gm := NewGoroutineTaskManager(view.RecordLen(), -1, view.Tx.Flags.CPU)
+	partitions := make([]*aggregatePartition, gm.Number)
+	for i := 0; i < gm.Number; i++ {
+		gm.Add()
+		go func(thIdx int) {
+			start, end := gm.RecordRange(thIdx)
+			partition := &aggregatePartition{
+				keys:    make(map[string]int),
+				groups:  make([]string, 0),
+				indices: make([][]int, 0),
+				states:  make([]aggregateState, 0),
+			}
+			partitions[thIdx] = partition
+
+			filter := NewFilterForSequentialEvaluation(
+				view.Filter,
+				&View{
+					Tx:        view.Tx,
+					Header:    view.Header,
+					RecordSet: view.RecordSet[start:end],
+				},
+			)
+			filter.init()
+
+			values := make([]value.Primary, len(items))
+			keyBuf := new(bytes.Buffer)
+
+			for filter.next() {
+				if gm.HasError() || ctx.Err() != nil {
+					break
+				}
+
+				for i, item := range items {
+					p, e := filter.Evaluate(ctx, item)
+					if e != nil {
+						gm.SetError(e)
+						break
+					}
+					values[i] = p
+				}
+				if gm.HasError() {
+					break
+				}
+				keyBuf.Reset()
+				SerializeComparisonKeys(keyBuf, values, view.Tx.Flags)
+
+				gIdx, ok := partition.keys[keyBuf.String()]
+				if !ok {
+					key := keyBuf.String()
+					gIdx = len(partition.groups)
+					partition.keys[key] = gIdx
+					partition.groups = append(partition.groups, key)
+					partition.indices = append(partition.indices, make([]int, 0, 1))
+					partition.states = append(partition.states, make([]aggregateState, len(aggregates))...)
+				}
+				rIdx := start + filter.currentIndex()
+				partition.indices[gIdx] = append(partition.indices[gIdx], rIdx)
+				states := partition.states[gIdx*len(aggregates) : (gIdx+1)*len(aggregates)]
+
+				for i, aggr := range aggregates {
+					var p value.Primary
+
+					switch {
+					case aggr.countsRecords:
+						continue
+					case -1 < fieldIndices[i]:
+						p = view.RecordSet[rIdx][fieldIndices[i]].Value()
+					default:
+						var e error
+						if p, e = filter.Evaluate(ctx, aggr.arg); e != nil {
+							gm.SetError(errPartialAggregationFailed)
+						}
+					}
+					if gm.HasError() {
+						break
+					}
+					aggr.update(&states[i], p, datetimeFormat)
+				}
+			}
+
+			gm.Done()
+		}(i)
+	}
+	gm.Wait()
+
+	if gm.HasError() {
+		if gm.Err() == errPartialAggregationFailed {
+			return view.group(ctx, items, nil)
+		}
+		return gm.Err()
+	}
+	if ctx.Err() != nil {
+		return NewContextIsDone(ctx.Err().Error())
+	}
+
+	merged := partitions[0]
+	for _, partition := range partitions[1:] {
+		for i, key := range partition.groups {
+			gIdx, ok := merged.keys[key]
+			if !ok {
+				merged.keys[key] = len(merged.groups)
+				merged.groups = append(merged.groups, key)
+				merged.indices = append(merged.indices, partition.indices[i])
+				merged.states = append(merged.states, partition.states[i*len(aggregates):(i+1)*len(aggregates)]...)
+				continue
+			}
+
+			merged.indices[gIdx] = append(merged.indices[gIdx], partition.indices[i]...)
+			for j, aggr := range aggregates {
+				aggr.merge(&merged.states[gIdx*len(aggregates)+j], partition.states[i*len(aggregates)+j], datetimeFormat)
+			}
+		}
+	}
+
+	fieldLen := view.FieldLen()
+	recordLen := fieldLen + len(aggregates)
+	grouped := newGroupedRecordSet(view.RecordSet, fieldLen, merged.indices)
+	records := make(RecordSet, len(grouped))
+	cells := make([]Cell, len(grouped)*recordLen)
+	for i := range grouped {
+		records[i] = cells[i*recordLen : (i+1)*recordLen : (i+1)*recordLen]
+		copy(records[i], grouped[i])
+		for j, aggr := range aggregates {
+			records[i][fieldLen+j] = NewCell(aggr.result(merged.states[i*len(aggregates)+j], len(merged.indices[i])))
+		}
+	}
+
+	view.partialAggregates = make(map[*parser.BaseExpr]int)
+	for _, aggr := range aggregates {
+		var idx int
+		view.Header, idx = AddHeaderField(view.Header, PartialAggregateColumnPrefix+aggr.name+"("+aggr.arg.String()+")", "")
+		for _, node := range aggr.nodes {
+			view.partialAggregates[node] = idx
+		}
+	}
+
+	view.RecordSet = records
+	view.isGrouped = true
+	view.setGroupKeys(items)
+	return nil
+}
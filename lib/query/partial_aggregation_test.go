@@ -0,0 +1,135 @@
+package query
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/mithrandie/csvq/lib/parser"
+)
+
+var partialAggregatesTests = []struct {
+	Query  string
+	Result []string
+}{
+	{
+		Query:  "SELECT c1, COUNT(*), count(c2), SUM(c2) + SUM(c2), AVG(c2 * 2), MIN(c2), MAX(c2) FROM t GROUP BY c1",
+		Result: []string{"COUNT(*)", "COUNT(c2)", "SUM(c2)", "AVG(c2 * 2)", "MIN(c2)", "MAX(c2)"},
+	},
+	{
+		Query:  "SELECT c1 FROM t GROUP BY c1 HAVING COUNT(1) > 1 AND MAX(TRIM(c2)) = 'a'",
+		Result: []string{"COUNT(1)", "MAX(TRIM(c2))"},
+	},
+	{
+		Query:  "SELECT COUNT(DISTINCT c2), SUM(c2, 1), MEDIAN(c2), LISTAGG(c2), MAX(RAND()), MIN(@var := c2), SUM(c2) OVER () FROM t GROUP BY c1",
+		Result: []string{},
+	},
+	{
+		Query:  "SELECT (SELECT SUM(c3) FROM u), SUM(c2) FROM t GROUP BY c1",
+		Result: []string{"SUM(c2)"},
+	},
+}
+
+func TestPartialAggregates(t *testing.T) {
+	for _, v := range partialAggregatesTests {
+		statements, _, err := parser.Parse(v.Query, "", nil, false)
+		if err != nil {
+			t.Fatalf("%s: unexpected parse error %q", v.Query, err)
+		}
+		entity := statements[0].(parser.SelectQuery).SelectEntity.(parser.SelectEntity)
+
+		result := make([]string, 0)
+		for _, aggr := range partialAggregates(entity) {
+			result = append(result, aggr.name+"("+aggr.arg.String()+")")
+		}
+		if !reflect.DeepEqual(result, v.Result) {
+			t.Errorf("%s: aggregates = %q, want %q", v.Query, result, v.Result)
+		}
+	}
+}
+
+var selectWithPartialAggregationTests = []struct {
+	Query  string
+	Result [][]string
+	Error  string
+}{
+	{
+		Query: "SELECT c1, COUNT(*), COUNT(c2), SUM(c2), AVG(c2 * 2), MIN(c2), MAX(c2), SUM(c2) + COUNT(1) FROM partial_aggregation_test GROUP BY c1",
+		Result: [][]string{
+			{"\"a\"", "3", "3", "8", "5.333333333333333", "\"1\"", "\"4\"", "11"},
+			{"\"b\"", "2", "1", "2", "4", "\"2\"", "\"2\"", "4"},
+			{"\"c\"", "1", "1", "NULL", "NULL", "\"str\"", "\"str\"", "NULL"},
+		},
+	},
+	{
+		Query: "SELECT c1, SUM(c2) FROM partial_aggregation_test GROUP BY c1 HAVING COUNT(*) > 1 ORDER BY SUM(c2) DESC",
+		Result: [][]string{
+			{"\"a\"", "8"},
+			{"\"b\"", "2"},
+		},
+	},
+	{
+		Query: "SELECT c1, CASE c1 WHEN 'a' THEN MAX(JSON_VALUE(c3, '{\"key\":5}')) END FROM partial_aggregation_test GROUP BY c1",
+		Result: [][]string{
+			{"\"a\"", "5"},
+			{"\"b\"", "NULL"},
+			{"\"c\"", "NULL"},
+		},
+	},
+	{
+		Query: "SELECT c1, MAX(JSON_VALUE(c3, '{\"key\":5}')) FROM partial_aggregation_test GROUP BY c1",
+		Error: "[L:1 C:16] column 1: unexpected termination for function JSON_VALUE",
+	},
+}
+
+func TestSelectWithPartialAggregation(t *testing.T) {
+	fpath := GetTestFilePath("partial_aggregation_test.csv")
+	if err := os.WriteFile(fpath, []byte("c1,c2,c3\na,1,key\na,3,key\nb,,[\nb,2,x\na,4,key\nc,str,key\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+		_ = os.Remove(fpath)
+		initFlag(TestTx.Flags)
+	}()
+
+	TestTx.Flags.Repository = TestDir
+
+	for _, cpu := range []int{1, 3} {
+		TestTx.Flags.CPU = cpu
+
+		for _, v := range selectWithPartialAggregationTests {
+			_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+
+			statements, _, err := parser.Parse(v.Query, "", nil, false)
+			if err != nil {
+				t.Fatalf("%s: unexpected parse error %q", v.Query, err)
+			}
+			view, err := Select(context.Background(), NewFilter(TestTx).CreateNode(), statements[0].(parser.SelectQuery))
+			if err != nil {
+				if len(v.Error) < 1 {
+					t.Errorf("%s: cpu %d: unexpected error %q", v.Query, cpu, err)
+				} else if err.Error() != v.Error {
+					t.Errorf("%s: cpu %d: error %q, want error %q", v.Query, cpu, err.Error(), v.Error)
+				}
+				continue
+			}
+			if 0 < len(v.Error) {
+				t.Errorf("%s: cpu %d: no error, want error %q", v.Query, cpu, v.Error)
+				continue
+			}
+
+			result := make([][]string, view.RecordLen())
+			for i, record := range view.RecordSet {
+				result[i] = make([]string, len(record))
+				for j, cell := range record {
+					result[i][j] = cell.Value().String()
+				}
+			}
+			if !reflect.DeepEqual(result, v.Result) {
+				t.Errorf("%s: cpu %d: records = %q, want %q", v.Query, cpu, result, v.Result)
+			}
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"plugin"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/mithrandie/csvq/lib/cmd"
+	"github.com/mithrandie/csvq/lib/parser"
+	"github.com/mithrandie/csvq/lib/value"
+	"github.com/mithrandie/csvq/lib/wasm"
+)
+
+const (
+	PluginExtension     = ".so"
+	WasmPluginExtension = ".wasm"
+)
+
+const (
+	PluginFunctionsSymbol          = "Functions"
+	PluginAggregateFunctionsSymbol = "AggregateFunctions"
+)
+
+// pluginFunctions is the set of the names of the functions registered by plugins.
+var pluginFunctions = make(map[string]bool)
+
+// LoadPlugins opens all plugin files in dir and registers the functions
+// exported by the plugins. A plugin exports a variable named Functions or
+// AggregateFunctions whose type is the same as the built-in map.
+//
+// WebAssembly modules in dir are also loaded by LoadWasmPlugin.
+func LoadPlugins(dir string) error {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return errors.New(fmt.Sprintf("failed to read plugin directory %q", dir))
+	}
+
+	paths := make([]string, 0, len(files))
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+		ext := filepath.Ext(f.Name())
+		if !strings.EqualFold(ext, PluginExtension) && !strings.EqualFold(ext, WasmPluginExtension) {
+			continue
+		}
+		paths = append(paths, filepath.Join(dir, f.Name()))
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		var err error
+		if strings.EqualFold(filepath.Ext(path), WasmPluginExtension) {
+			err = LoadWasmPlugin(path)
+		} else {
+			err = LoadPlugin(path)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func LoadPlugin(path string) error {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return errors.New(fmt.Sprintf("failed to load plugin %q: %s", path, err.Error()))
+	}
+
+	var fns map[string]func(parser.Function, []value.Primary, *cmd.Flags) (value.Primary, error)
+	var aggfns map[string]func([]value.Primary, *cmd.Flags) value.Primary
+
+	if sym, err := p.Lookup(PluginFunctionsSymbol); err == nil {
+		m, ok := sym.(*map[string]func(parser.Function, []value.Primary, *cmd.Flags) (value.Primary, error))
+		if !ok {
+			return errors.New(fmt.Sprintf("%s in plugin %q has an invalid type", PluginFunctionsSymbol, path))
+		}
+		fns = *m
+	}
+	if sym, err := p.Lookup(PluginAggregateFunctionsSymbol); err == nil {
+		m, ok := sym.(*map[string]func([]value.Primary, *cmd.Flags) value.Primary)
+		if !ok {
+			return errors.New(fmt.Sprintf("%s in plugin %q has an invalid type", PluginAggregateFunctionsSymbol, path))
+		}
+		aggfns = *m
+	}
+
+	if fns == nil && aggfns == nil {
+		return errors.New(fmt.Sprintf("plugin %q does not export any functions", path))
+	}
+
+	for name := range fns {
+		if err := checkPluginFunctionName(name, path); err != nil {
+			return err
+		}
+	}
+	for name := range aggfns {
+		if err := checkPluginFunctionName(name, path); err != nil {
+			return err
+		}
+	}
+
+	for name, fn := range fns {
+		Functions[strings.ToUpper(name)] = fn
+		pluginFunctions[strings.ToUpper(name)] = true
+	}
+	for name, fn := range aggfns {
+		AggregateFunctions[strings.ToUpper(name)] = fn
+		pluginFunctions[strings.ToUpper(name)] = true
+	}
+	return nil
+}
+
+func checkPluginFunctionName(name string, path string) error {
+	uname := strings.ToUpper(name)
+	if _, ok := Functions[uname]; ok {
+		return errors.New(fmt.Sprintf("function %s in plugin %q is already defined", name, path))
+	}
+	if _, ok := AggregateFunctions[uname]; ok {
+		return errors.New(fmt.Sprintf("function %s in plugin %q is already defined", name, path))
+	}
+	if _, ok := BivariateAggregateFunctions[uname]; ok {
+		return errors.New(fmt.Sprintf("function %s in plugin %q is already defined", name, path))
+	}
+	if _, ok := AnalyticFunctions[uname]; ok {
+		return errors.New(fmt.Sprintf("function %s in plugin %q is already defined", name, path))
+	}
+	return nil
+}
+
+// LoadWasmPlugin registers the functions exported by a WebAssembly module
+// as scalar functions.
+//
+// The module cannot import anything, so the functions have no access to
+// files, networks or any other resources of the host, and the execution
+// of the functions is restricted by wasm.DefaultLimits.
+func LoadWasmPlugin(path string) error {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.New(fmt.Sprintf("failed to load plugin %q: %s", path, err.Error()))
+	}
+	m, err := wasm.Decode(b)
+	if err != nil {
+		return errors.New(fmt.Sprintf("failed to load plugin %q: %s", path, err.Error()))
+	}
+	inst, err := wasm.Instantiate(m, wasm.DefaultLimits)
+	if err != nil {
+		return errors.New(fmt.Sprintf("failed to load plugin %q: %s", path, err.Error()))
+	}
+
+	fns := make(map[string]func(parser.Function, []value.Primary, *cmd.Flags) (value.Primary, error))
+	mtx := &sync.Mutex{}
+	for _, e := range m.Exports {
+		if e.Kind != wasm.ExternalFunction {
+			continue
+		}
+		ft, _ := m.FuncType(e.Name)
+		if len(ft.Results) != 1 {
+			return errors.New(fmt.Sprintf("function %s in plugin %q must return exactly one value", e.Name, path))
+		}
+		if err := checkPluginFunctionName(e.Name, path); err != nil {
+			return err
+		}
+		fns[strings.ToUpper(e.Name)] = wasmFunction(inst, mtx, e.Name, ft)
+	}
+
+	if len(fns) < 1 {
+		return errors.New(fmt.Sprintf("plugin %q does not export any functions", path))
+	}
+
+	for name, fn := range fns {
+		Functions[name] = fn
+		pluginFunctions[name] = true
+	}
+	return nil
+}
+
+func wasmFunction(inst *wasm.Instance, mtx *sync.Mutex, name string, ft wasm.FuncType) func(parser.Function, []value.Primary, *cmd.Flags) (value.Primary, error) {
+	return func(fn parser.Function, args []value.Primary, _ *cmd.Flags) (value.Primary, error) {
+		if len(args) != len(ft.Params) {
+			return nil, NewFunctionArgumentLengthError(fn, fn.Name, []int{len(ft.Params)})
+		}
+
+		params := make([]uint64, len(args))
+		for i, t := range ft.Params {
+			switch t {
+			case wasm.I32, wasm.I64:
+				p := value.ToInteger(args[i])
+				if value.IsNull(p) {
+					return value.NewNull(), nil
+				}
+				params[i] = uint64(p.(value.Integer).Raw())
+				if t == wasm.I32 {
+					params[i] = uint64(uint32(params[i]))
+				}
+			default:
+				p := value.ToFloat(args[i])
+				if value.IsNull(p) {
+					return value.NewNull(), nil
+				}
+				if t == wasm.F32 {
+					params[i] = uint64(math.Float32bits(float32(p.(value.Float).Raw())))
+				} else {
+					params[i] = math.Float64bits(p.(value.Float).Raw())
+				}
+			}
+		}
+
+		mtx.Lock()
+		results, err := inst.Call(name, params...)
+		mtx.Unlock()
+		if err != nil {
+			return nil, NewFunctionInvalidArgumentError(fn, fn.Name, err.Error())
+		}
+
+		var f float64
+		switch ft.Results[0] {
+		case wasm.I32:
+			return value.NewInteger(int64(int32(results[0]))), nil
+		case wasm.I64:
+			return value.NewInteger(int64(results[0])), nil
+		case wasm.F32:
+			f = float64(math.Float32frombits(uint32(results[0])))
+		default:
+			f = math.Float64frombits(results[0])
+		}
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return value.NewNull(), nil
+		}
+		return value.NewFloat(f), nil
+	}
+}
@@ -0,0 +1,226 @@
+package query
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mithrandie/csvq/lib/cmd"
+	"github.com/mithrandie/csvq/lib/parser"
+	"github.com/mithrandie/csvq/lib/value"
+)
+
+func TestLoadPlugins(t *testing.T) {
+	dir := filepath.Join(TestDir, "plugins")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	if err := LoadPlugins(dir); err != nil {
+		t.Errorf("unexpected error %q for an empty directory", err)
+	}
+
+	notExist := filepath.Join(TestDir, "notexist")
+	expectErr := "failed to read plugin directory \"" + notExist + "\""
+	if err := LoadPlugins(notExist); err == nil {
+		t.Errorf("no error, want error %q", expectErr)
+	} else if err.Error() != expectErr {
+		t.Errorf("error = %q, want error %q", err.Error(), expectErr)
+	}
+
+	invalid := filepath.Join(dir, "invalid.so")
+	if err := ioutil.WriteFile(invalid, []byte("invalid"), 0644); err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	if err := LoadPlugins(dir); err == nil {
+		t.Errorf("no error, want error for an invalid plugin file")
+	}
+}
+
+var checkPluginFunctionNameTests = []struct {
+	Name  string
+	Error string
+}{
+	{
+		Name: "plugin_func",
+	},
+	{
+		Name:  "trim",
+		Error: "function trim in plugin \"/path/to/plugin.so\" is already defined",
+	},
+	{
+		Name:  "sum",
+		Error: "function sum in plugin \"/path/to/plugin.so\" is already defined",
+	},
+	{
+		Name:  "corr",
+		Error: "function corr in plugin \"/path/to/plugin.so\" is already defined",
+	},
+	{
+		Name:  "rank",
+		Error: "function rank in plugin \"/path/to/plugin.so\" is already defined",
+	},
+}
+
+func TestCheckPluginFunctionName(t *testing.T) {
+	for _, v := range checkPluginFunctionNameTests {
+		err := checkPluginFunctionName(v.Name, "/path/to/plugin.so")
+		if err != nil {
+			if len(v.Error) < 1 {
+				t.Errorf("%s: unexpected error %q", v.Name, err)
+			} else if err.Error() != v.Error {
+				t.Errorf("%s: error %q, want error %q", v.Name, err.Error(), v.Error)
+			}
+			continue
+		}
+		if 0 < len(v.Error) {
+			t.Errorf("%s: no error, want error %q", v.Name, v.Error)
+		}
+	}
+}
+
+func TestFilter_EvaluateRegisteredAggregateFunction(t *testing.T) {
+	AggregateFunctions["PLUGIN_COUNT"] = func(list []value.Primary, _ *cmd.Flags) value.Primary {
+		return value.NewInteger(int64(len(list)))
+	}
+	defer delete(AggregateFunctions, "PLUGIN_COUNT")
+
+	filter := &Filter{
+		records: []filterRecord{
+			{
+				view: &View{
+					Header: NewHeaderWithId("table1", []string{"column1"}),
+					RecordSet: []Record{
+						{
+							NewGroupCell([]value.Primary{
+								value.NewInteger(1),
+								value.NewInteger(2),
+							}),
+							NewGroupCell([]value.Primary{
+								value.NewInteger(1),
+								value.NewInteger(2),
+							}),
+						},
+					},
+					isGrouped: true,
+					Tx:        TestTx,
+				},
+				recordIndex: 0,
+			},
+		},
+		tx: TestTx,
+	}
+
+	result, err := filter.Evaluate(context.Background(), parser.Function{
+		Name: "plugin_count",
+		Args: []parser.QueryExpression{parser.FieldReference{Column: parser.Identifier{Literal: "column1"}}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	if !reflect.DeepEqual(result, value.NewInteger(2)) {
+		t.Errorf("result = %s, want %s", result, value.NewInteger(2))
+	}
+}
+
+// wasmTestModule exports wasm_add(i64, i64) i64 and wasm_div(i32, i32) i32.
+var wasmTestModule = []byte{
+	0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00,
+	0x01, 0x0d, 0x02, 0x60, 0x02, 0x7e, 0x7e, 0x01, 0x7e, 0x60, 0x02, 0x7f, 0x7f, 0x01, 0x7f,
+	0x03, 0x03, 0x02, 0x00, 0x01,
+	0x07, 0x17, 0x02,
+	0x08, 'w', 'a', 's', 'm', '_', 'a', 'd', 'd', 0x00, 0x00,
+	0x08, 'w', 'a', 's', 'm', '_', 'd', 'i', 'v', 0x00, 0x01,
+	0x0a, 0x11, 0x02,
+	0x07, 0x00, 0x20, 0x00, 0x20, 0x01, 0x7c, 0x0b,
+	0x07, 0x00, 0x20, 0x00, 0x20, 0x01, 0x6d, 0x0b,
+}
+
+var loadWasmPluginTests = []struct {
+	Name   string
+	Func   string
+	Args   []value.Primary
+	Result value.Primary
+	Error  string
+}{
+	{
+		Name:   "WASM_ADD",
+		Func:   "WASM_ADD",
+		Args:   []value.Primary{value.NewInteger(1), value.NewString("2")},
+		Result: value.NewInteger(3),
+	},
+	{
+		Name:   "WASM_ADD Null",
+		Func:   "WASM_ADD",
+		Args:   []value.Primary{value.NewInteger(1), value.NewNull()},
+		Result: value.NewNull(),
+	},
+	{
+		Name:   "WASM_DIV",
+		Func:   "WASM_DIV",
+		Args:   []value.Primary{value.NewInteger(-7), value.NewInteger(2)},
+		Result: value.NewInteger(-3),
+	},
+	{
+		Name:  "WASM_DIV Trap Error",
+		Func:  "WASM_DIV",
+		Args:  []value.Primary{value.NewInteger(1), value.NewInteger(0)},
+		Error: "integer divide by zero for function wasm_div",
+	},
+	{
+		Name:  "WASM_DIV Arguments Error",
+		Func:  "WASM_DIV",
+		Args:  []value.Primary{value.NewInteger(1)},
+		Error: "function wasm_div takes exactly 2 arguments",
+	},
+}
+
+func TestLoadWasmPlugin(t *testing.T) {
+	path := filepath.Join(TestDir, "plugin_test.wasm")
+	if err := ioutil.WriteFile(path, wasmTestModule, 0644); err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	defer func() {
+		_ = os.Remove(path)
+		delete(Functions, "WASM_ADD")
+		delete(Functions, "WASM_DIV")
+	}()
+
+	if err := LoadWasmPlugin(path); err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	for _, v := range loadWasmPluginTests {
+		fn := parser.Function{Name: strings.ToLower(v.Func)}
+		result, err := Functions[v.Func](fn, v.Args, TestTx.Flags)
+		if err != nil {
+			if len(v.Error) < 1 {
+				t.Errorf("%s: unexpected error %q", v.Name, err)
+			} else if err.Error() != v.Error {
+				t.Errorf("%s: error %q, want error %q", v.Name, err.Error(), v.Error)
+			}
+			continue
+		}
+		if 0 < len(v.Error) {
+			t.Errorf("%s: no error, want error %q", v.Name, v.Error)
+			continue
+		}
+		if !reflect.DeepEqual(result, v.Result) {
+			t.Errorf("%s: result = %s, want %s", v.Name, result, v.Result)
+		}
+	}
+
+	expectErr := "function wasm_add in plugin \"" + path + "\" is already defined"
+	if err := LoadWasmPlugin(path); err == nil {
+		t.Errorf("no error, want error %q", expectErr)
+	} else if err.Error() != expectErr {
+		t.Errorf("error = %q, want error %q", err.Error(), expectErr)
+	}
+}
@@ -1,11 +1,28 @@
 package query
 
 import (
+	"reflect"
 	"strings"
+	"time"
 
+	"github.com/mithrandie/csvq/lib/cmd"
 	"github.com/mithrandie/csvq/lib/parser"
+	"github.com/mithrandie/csvq/lib/value"
+
+	"github.com/mithrandie/ternary"
 )
 
+const ArrayParameterType = "ARRAY"
+
+var parameterTypeConversions = map[string]func(parser.Function, []value.Primary, *cmd.Flags) (value.Primary, error){
+	"STRING":   String,
+	"INTEGER":  Integer,
+	"FLOAT":    Float,
+	"BOOLEAN":  Boolean,
+	"TERNARY":  Ternary,
+	"DATETIME": Datetime,
+}
+
 type PreparedStatementMap map[string]*PreparedStatement
 
 func (m PreparedStatementMap) Prepare(filter *Filter, expr parser.StatementPreparation) error {
@@ -43,9 +60,15 @@ type PreparedStatement struct {
 	StatementString string
 	Statements      []parser.Statement
 	HolderNumber    int
+	ParameterTypes  ParameterTypes
 }
 
 func NewPreparedStatement(filter *Filter, expr parser.StatementPreparation) (*PreparedStatement, error) {
+	paramTypes, err := NewParameterTypes(expr.Parameters)
+	if err != nil {
+		return nil, err
+	}
+
 	statements, holderNum, err := parser.Parse(expr.Statement.Raw(), expr.Name.Literal, filter.tx.Flags.DatetimeFormat, true)
 	if err != nil {
 		return nil, NewPreparedStatementSyntaxError(err.(*parser.SyntaxError))
@@ -56,12 +79,74 @@ func NewPreparedStatement(filter *Filter, expr parser.StatementPreparation) (*Pr
 		StatementString: expr.Statement.Raw(),
 		Statements:      statements,
 		HolderNumber:    holderNum,
+		ParameterTypes:  paramTypes,
 	}, nil
 }
 
+// ParameterTypes holds the types declared for the placeholders of a prepared statement.
+// Ordinal holds the types of positional placeholders in order, and Named holds the types
+// of named placeholders by their names.
+type ParameterTypes struct {
+	Ordinal []string
+	Named   map[string]string
+}
+
+func NewParameterTypes(params []parser.StatementParameter) (ParameterTypes, error) {
+	var types ParameterTypes
+
+	for _, param := range params {
+		t := strings.ToUpper(param.Type.Literal)
+		if _, ok := parameterTypeConversions[t]; !ok && t != ArrayParameterType {
+			return types, NewInvalidParameterTypeError(param)
+		}
+
+		if param.IsNamed() {
+			if types.Named == nil {
+				types.Named = make(map[string]string, len(params))
+			}
+			types.Named[param.Name.Literal] = t
+		} else {
+			types.Ordinal = append(types.Ordinal, t)
+		}
+	}
+	return types, nil
+}
+
+func (t ParameterTypes) Get(placeholder parser.Placeholder) (string, bool) {
+	if 0 < len(placeholder.Name) {
+		paramType, ok := t.Named[placeholder.Name]
+		return paramType, ok
+	}
+	if 0 < placeholder.Ordinal && placeholder.Ordinal <= len(t.Ordinal) {
+		return t.Ordinal[placeholder.Ordinal-1], true
+	}
+	return "", false
+}
+
+func (t ParameterTypes) Convert(placeholder parser.Placeholder, val value.Primary, flags *cmd.Flags) (value.Primary, error) {
+	paramType, ok := t.Get(placeholder)
+	if !ok || value.IsNull(val) {
+		return val, nil
+	}
+
+	if paramType == ArrayParameterType {
+		if _, ok := val.(value.Array); !ok {
+			return nil, NewParameterTypeMismatchError(placeholder, val, paramType)
+		}
+		return val, nil
+	}
+
+	converted, _ := parameterTypeConversions[paramType](parser.Function{Name: paramType}, []value.Primary{val}, flags)
+	if value.IsNull(converted) {
+		return nil, NewParameterTypeMismatchError(placeholder, val, paramType)
+	}
+	return converted, nil
+}
+
 type ReplaceValues struct {
 	Values []parser.QueryExpression
 	Names  map[string]int
+	Types  ParameterTypes
 }
 
 func NewReplaceValues(replace []parser.ReplaceValue) *ReplaceValues {
@@ -80,3 +165,72 @@ func NewReplaceValues(replace []parser.ReplaceValue) *ReplaceValues {
 		Names:  names,
 	}
 }
+
+// Parameter is a value passed from Go code to replace a placeholder of a prepared statement.
+// A parameter with a Name replaces the named placeholder, and a parameter without a Name
+// replaces the positional placeholder in the order of the parameters.
+type Parameter struct {
+	Name  string
+	Value interface{}
+}
+
+// NewReplaceValuesFromParameters converts Go values to ReplaceValues that can be passed to
+// ContextForPreparedStatement when executing the statements of a prepared statement.
+//
+// Supported values are nil, bool, ternary.Value, signed and unsigned integers, float32,
+// float64, string, time.Time and value.Primary. Slices of these values are converted to
+// arrays, so they can be used in IN lists.
+func NewReplaceValuesFromParameters(params []Parameter) (*ReplaceValues, error) {
+	replace := make([]parser.ReplaceValue, 0, len(params))
+	for _, param := range params {
+		p, err := ParseParameterValue(param.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		rv := parser.ReplaceValue{Value: parser.PrimitiveType{Value: p}}
+		if 0 < len(param.Name) {
+			rv.Name = parser.Identifier{Literal: param.Name}
+		}
+		replace = append(replace, rv)
+	}
+	return NewReplaceValues(replace), nil
+}
+
+func ParseParameterValue(v interface{}) (value.Primary, error) {
+	switch val := v.(type) {
+	case nil:
+		return value.NewNull(), nil
+	case value.Primary:
+		return val, nil
+	case bool:
+		return value.NewBoolean(val), nil
+	case ternary.Value:
+		return value.NewTernary(val), nil
+	case string:
+		return value.NewString(val), nil
+	case time.Time:
+		return value.NewDatetime(val), nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.NewInteger(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return value.NewInteger(int64(rv.Uint())), nil
+	case reflect.Float32, reflect.Float64:
+		return value.NewFloat(rv.Float()), nil
+	case reflect.Slice, reflect.Array:
+		values := make([]value.Primary, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			p, err := ParseParameterValue(rv.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			values[i] = p
+		}
+		return value.NewArray(values), nil
+	}
+	return nil, NewUnsupportedParameterValueError(v)
+}
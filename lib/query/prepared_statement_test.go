@@ -3,6 +3,7 @@ package query
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/mithrandie/csvq/lib/value"
 
@@ -218,11 +219,47 @@ func TestNewPreparedStatement(t *testing.T) {
 		}
 	}
 
+	expr = parser.StatementPreparation{
+		Name: parser.Identifier{Literal: "stmt"},
+		Parameters: []parser.StatementParameter{
+			{Type: parser.Identifier{Literal: "integer"}},
+			{Name: parser.Identifier{Literal: "val"}, Type: parser.Identifier{Literal: "array"}},
+		},
+		Statement: value.NewString("select 1"),
+	}
+	expectTypes := ParameterTypes{
+		Ordinal: []string{"INTEGER"},
+		Named:   map[string]string{"val": "ARRAY"},
+	}
+
+	result, err = NewPreparedStatement(NewFilter(TestTx), expr)
+	if err != nil {
+		t.Errorf("error %q, want no error", err.Error())
+	} else if !reflect.DeepEqual(result.ParameterTypes, expectTypes) {
+		t.Errorf("parameter types = %v, want %v", result.ParameterTypes, expectTypes)
+	}
+
+	expr = parser.StatementPreparation{
+		Name: parser.Identifier{Literal: "stmt"},
+		Parameters: []parser.StatementParameter{
+			{Type: parser.Identifier{Literal: "notexist"}},
+		},
+		Statement: value.NewString("select 1"),
+	}
+	expectErr := "notexist is not a valid parameter type"
+
+	_, err = NewPreparedStatement(NewFilter(TestTx), expr)
+	if err == nil {
+		t.Errorf("no error, want error %q", expectErr)
+	} else if err.Error() != expectErr {
+		t.Errorf("error %q, want error %q", err.Error(), expectErr)
+	}
+
 	expr = parser.StatementPreparation{
 		Name:      parser.Identifier{Literal: "stmt"},
 		Statement: value.NewString("select from"),
 	}
-	expectErr := "prepare stmt [L:1 C:8] syntax error: unexpected token \"from\""
+	expectErr = "prepare stmt [L:1 C:8] syntax error: unexpected token \"from\""
 
 	_, err = NewPreparedStatement(NewFilter(TestTx), expr)
 	if err == nil {
@@ -252,3 +289,107 @@ func TestNewReplaceValues(t *testing.T) {
 		t.Errorf("result = %v, want %v", result, expect)
 	}
 }
+
+var parameterTypesConvertTests = []struct {
+	Name        string
+	Placeholder parser.Placeholder
+	Value       value.Primary
+	Result      value.Primary
+	Error       string
+}{
+	{
+		Name:        "ParameterTypes Convert",
+		Placeholder: parser.Placeholder{Literal: "?", Ordinal: 1},
+		Value:       value.NewString("2012-02-03 09:18:15"),
+		Result:      value.NewDatetime(time.Date(2012, 2, 3, 9, 18, 15, 0, GetTestLocation())),
+	},
+	{
+		Name:        "ParameterTypes Convert Type Not Declared",
+		Placeholder: parser.Placeholder{Literal: "?", Ordinal: 2},
+		Value:       value.NewString("a"),
+		Result:      value.NewString("a"),
+	},
+	{
+		Name:        "ParameterTypes Convert Null",
+		Placeholder: parser.Placeholder{Literal: ":val", Ordinal: 1, Name: "val"},
+		Value:       value.NewNull(),
+		Result:      value.NewNull(),
+	},
+	{
+		Name:        "ParameterTypes Convert Array",
+		Placeholder: parser.Placeholder{Literal: ":val", Ordinal: 1, Name: "val"},
+		Value:       value.NewArray([]value.Primary{value.NewInteger(1)}),
+		Result:      value.NewArray([]value.Primary{value.NewInteger(1)}),
+	},
+	{
+		Name:        "ParameterTypes Convert Array Error",
+		Placeholder: parser.Placeholder{Literal: ":val", Ordinal: 1, Name: "val"},
+		Value:       value.NewInteger(1),
+		Error:       "value 1 for :val cannot be converted to ARRAY",
+	},
+	{
+		Name:        "ParameterTypes Convert Error",
+		Placeholder: parser.Placeholder{Literal: "?", Ordinal: 1},
+		Value:       value.NewString("a"),
+		Error:       "value \"a\" for ? cannot be converted to DATETIME",
+	},
+}
+
+func TestParameterTypes_Convert(t *testing.T) {
+	types := ParameterTypes{
+		Ordinal: []string{"DATETIME"},
+		Named:   map[string]string{"val": "ARRAY"},
+	}
+
+	for _, v := range parameterTypesConvertTests {
+		result, err := types.Convert(v.Placeholder, v.Value, TestTx.Flags)
+		if err != nil {
+			if len(v.Error) < 1 {
+				t.Errorf("%s: unexpected error %q", v.Name, err)
+			} else if err.Error() != v.Error {
+				t.Errorf("%s: error %q, want error %q", v.Name, err.Error(), v.Error)
+			}
+			continue
+		}
+		if 0 < len(v.Error) {
+			t.Errorf("%s: no error, want error %q", v.Name, v.Error)
+			continue
+		}
+		if !reflect.DeepEqual(result, v.Result) {
+			t.Errorf("%s: result = %s, want %s", v.Name, result, v.Result)
+		}
+	}
+}
+
+func TestNewReplaceValuesFromParameters(t *testing.T) {
+	params := []Parameter{
+		{Value: 1},
+		{Value: []string{"a", "b"}},
+		{Name: "val", Value: nil},
+	}
+	expect := &ReplaceValues{
+		Values: []parser.QueryExpression{
+			parser.PrimitiveType{Value: value.NewInteger(1)},
+			parser.PrimitiveType{Value: value.NewArray([]value.Primary{value.NewString("a"), value.NewString("b")})},
+			parser.PrimitiveType{Value: value.NewNull()},
+		},
+		Names: map[string]int{
+			"val": 2,
+		},
+	}
+
+	result, err := NewReplaceValuesFromParameters(params)
+	if err != nil {
+		t.Errorf("unexpected error %q", err)
+	} else if !reflect.DeepEqual(result, expect) {
+		t.Errorf("result = %v, want %v", result, expect)
+	}
+
+	expectErr := "value of type struct {} is not supported as a parameter"
+	_, err = NewReplaceValuesFromParameters([]Parameter{{Value: struct{}{}}})
+	if err == nil {
+		t.Errorf("no error, want error %q", expectErr)
+	} else if err.Error() != expectErr {
+		t.Errorf("error %q, want error %q", err.Error(), expectErr)
+	}
+}
@@ -38,11 +38,17 @@ func ContextForPreparedStatement(ctx context.Context, values *ReplaceValues) con
 	return context.WithValue(ctx, StatementReplaceValuesContextKey, values)
 }
 
+type localFlag struct {
+	Name  string
+	Flags *cmd.Flags
+}
+
 type Processor struct {
 	Tx     *Transaction
 	Filter *Filter
 
 	storeResults bool
+	localFlags   []localFlag
 
 	returnVal        value.Primary
 	measurementStart time.Time
@@ -76,6 +82,14 @@ func (proc *Processor) Execute(ctx context.Context, statements []parser.Statemen
 	proc.Tx.SelectedViews = nil
 	proc.Tx.AffectedRows = 0
 
+	if proc.Tx.Flags.ReadOnly {
+		// No file is changed in read-only mode, so the files modified by other processes can be reloaded
+		// before any statements.
+		if err := proc.Tx.DisposeStaleViews(); err != nil {
+			return TerminateWithError, err
+		}
+	}
+
 	flow, err := proc.execute(ctx, statements)
 	if err == nil && flow == Terminate && proc.Tx.AutoCommit {
 		err = proc.AutoCommit()
@@ -84,6 +98,13 @@ func (proc *Processor) Execute(ctx context.Context, statements []parser.Statemen
 }
 
 func (proc *Processor) execute(ctx context.Context, statements []parser.Statement) (StatementFlow, error) {
+	outerLocalFlags := proc.localFlags
+	proc.localFlags = nil
+	defer func() {
+		proc.restoreLocalFlags()
+		proc.localFlags = outerLocalFlags
+	}()
+
 	flow := Terminate
 
 	for _, stmt := range statements {
@@ -119,8 +140,43 @@ func (proc *Processor) ExecuteStatement(ctx context.Context, stmt parser.Stateme
 
 	var printstr string
 
+	hints := StatementHints(stmt)
+	if cpu, ok := hintedCPU(hints); ok {
+		defer func(cpu int) {
+			proc.Tx.Flags.CPU = cpu
+		}(proc.Tx.Flags.CPU)
+		proc.Tx.Flags.SetCPU(cpu)
+	}
+	if waitTimeout, ok := hintedWaitTimeout(hints); ok {
+		defer func(waitTimeout float64) {
+			proc.Tx.UpdateWaitTimeout(waitTimeout, proc.Tx.RetryDelay)
+		}(proc.Tx.Flags.WaitTimeout)
+		proc.Tx.UpdateWaitTimeout(waitTimeout, proc.Tx.RetryDelay)
+	}
+
+	if profile, e := startProfile(stmt); e != nil {
+		proc.LogError(e.Error())
+	} else if profile != nil {
+		defer func() {
+			if e := profile.stop(); e != nil {
+				proc.LogError(e.Error())
+			} else {
+				_ = proc.Tx.Session.WriteToStderrWithLineBreak(cmd.GetPalette().Render(cmd.LableEffect, "Profile: ") + profile.path)
+			}
+		}()
+	}
+
+	if proc.Tx != nil && proc.Tx.Restricted {
+		if err = checkRestrictedStatement(stmt); err != nil {
+			return TerminateWithError, err
+		}
+	}
+
 	switch stmt.(type) {
 	case parser.SetFlag:
+		if stmt.(parser.SetFlag).IsLocal() {
+			proc.localFlags = append(proc.localFlags, localFlag{Name: stmt.(parser.SetFlag).Name, Flags: proc.Tx.Flags.Copy()})
+		}
 		err = SetFlag(ctx, proc.Filter, stmt.(parser.SetFlag))
 	case parser.AddFlagElement:
 		err = AddFlagElement(ctx, proc.Filter, stmt.(parser.AddFlagElement))
@@ -155,8 +211,22 @@ func (proc *Processor) ExecuteStatement(ctx context.Context, stmt parser.Stateme
 		err = DeclareView(ctx, proc.Filter, stmt.(parser.ViewDeclaration))
 	case parser.DisposeView:
 		err = proc.Filter.tempViews.Dispose(stmt.(parser.DisposeView).View)
+	case parser.IndexDeclaration:
+		err = proc.Tx.indexes.Declare(ctx, proc.Filter, stmt.(parser.IndexDeclaration))
+	case parser.DisposeIndex:
+		err = proc.Tx.indexes.Dispose(stmt.(parser.DisposeIndex).Index)
+	case parser.SequenceDeclaration:
+		err = proc.Tx.sequences.Declare(ctx, proc.Filter, stmt.(parser.SequenceDeclaration))
+	case parser.DisposeSequence:
+		err = proc.Tx.sequences.Dispose(stmt.(parser.DisposeSequence).Sequence)
 	case parser.FunctionDeclaration:
-		err = proc.Filter.functions.Declare(stmt.(parser.FunctionDeclaration))
+		decl := stmt.(parser.FunctionDeclaration)
+		if proc.Tx.Flags.ReadOnly && strings.EqualFold(decl.Language.Literal, ExternalLanguage) {
+			// External functions can run any commands, so they could change files.
+			err = NewExternalFunctionInReadOnlyModeError(decl)
+		} else {
+			err = proc.Filter.functions.Declare(decl)
+		}
 	case parser.DisposeFunction:
 		err = proc.Filter.functions.Dispose(stmt.(parser.DisposeFunction).Name)
 	case parser.AggregateDeclaration:
@@ -169,7 +239,9 @@ func (proc *Processor) ExecuteStatement(ctx context.Context, stmt parser.Stateme
 		if e != nil {
 			err = e
 		} else {
-			flow, err = proc.execute(ContextForPreparedStatement(ctx, NewReplaceValues(execStmt.Values)), prepared.Statements)
+			replace := NewReplaceValues(execStmt.Values)
+			replace.Types = prepared.ParameterTypes
+			flow, err = proc.execute(ContextForPreparedStatement(ctx, replace), prepared.Statements)
 		}
 	case parser.DisposeStatement:
 		err = proc.Tx.PreparedStatements.Dispose(stmt.(parser.DisposeStatement))
@@ -178,43 +250,35 @@ func (proc *Processor) ExecuteStatement(ctx context.Context, stmt parser.Stateme
 			proc.measurementStart = time.Now()
 		}
 
-		view, e := Select(ctx, proc.Filter, stmt.(parser.SelectQuery))
-		if e == nil {
-			if proc.storeResults {
-				proc.Tx.SelectedViews = append(proc.Tx.SelectedViews, view)
-
-			} else {
-				fileInfo := &FileInfo{
-					Format:             proc.Tx.Flags.Format,
-					Delimiter:          proc.Tx.Flags.WriteDelimiter,
-					DelimiterPositions: proc.Tx.Flags.WriteDelimiterPositions,
-					Encoding:           proc.Tx.Flags.WriteEncoding,
-					LineBreak:          proc.Tx.Flags.LineBreak,
-					NoHeader:           proc.Tx.Flags.WithoutHeader,
-					EncloseAll:         proc.Tx.Flags.EncloseAll,
-					PrettyPrint:        proc.Tx.Flags.PrettyPrint,
-					SingleLine:         proc.Tx.Flags.WriteAsSingleLine,
-				}
+		streamed, e := proc.streamSelectedView(ctx, stmt.(parser.SelectQuery))
+		if streamed {
+			err = e
+		} else if view, e := selectWithResultCache(ctx, proc.Filter.CreateNodeForColumnPruning(stmt), stmt.(parser.SelectQuery)); e == nil {
+			err = proc.writeSelectedView(view)
+		} else {
+			err = e
+		}
 
-				var writer io.Writer
-				if proc.Tx.Session.OutFile != nil {
-					writer = proc.Tx.Session.OutFile
-				} else {
-					writer = proc.Tx.Session.Stdout
-				}
-				warnmsg, e := EncodeView(writer, view, fileInfo, proc.Tx.Flags)
+		if proc.Tx.Flags.Stats {
+			proc.showExecutionTime()
+		}
+	case parser.InsertQuery:
+		if proc.Tx.Flags.Stats {
+			proc.measurementStart = time.Now()
+		}
 
-				if e != nil {
-					if _, ok := e.(*EmptyResultSetError); ok {
-						if 0 < len(warnmsg) {
-							proc.LogWarn(warnmsg, proc.Tx.Flags.Quiet)
-						}
-					} else {
-						err = e
-					}
-				} else if !(proc.Tx.Session.OutFile != nil && fileInfo.Format == cmd.FIXED && fileInfo.SingleLine) {
-					_, err = writer.Write([]byte(proc.Tx.Flags.LineBreak.Value()))
-				}
+		fileInfo, cnt, returningView, e := Insert(ctx, proc.Filter, stmt.(parser.InsertQuery))
+		if e == nil {
+			if 0 < cnt {
+				proc.Tx.uncommittedViews.SetForUpdatedView(fileInfo)
+				proc.Tx.tableChanges.Add(fileInfo.Path, TableChanges{Inserted: cnt})
+			}
+			proc.Log(fmt.Sprintf("%s inserted on %q.", FormatCount(cnt, "record"), fileInfo.Path), proc.Tx.Flags.Quiet)
+			if proc.storeResults {
+				proc.Tx.AffectedRows = cnt
+			}
+			if returningView != nil {
+				err = proc.writeSelectedView(returningView)
 			}
 		} else {
 			err = e
@@ -223,17 +287,18 @@ func (proc *Processor) ExecuteStatement(ctx context.Context, stmt parser.Stateme
 		if proc.Tx.Flags.Stats {
 			proc.showExecutionTime()
 		}
-	case parser.InsertQuery:
+	case parser.ReplaceQuery:
 		if proc.Tx.Flags.Stats {
 			proc.measurementStart = time.Now()
 		}
 
-		fileInfo, cnt, e := Insert(ctx, proc.Filter, stmt.(parser.InsertQuery))
+		fileInfo, cnt, e := ReplaceInto(ctx, proc.Filter, stmt.(parser.ReplaceQuery))
 		if e == nil {
 			if 0 < cnt {
 				proc.Tx.uncommittedViews.SetForUpdatedView(fileInfo)
+				proc.Tx.tableChanges.Add(fileInfo.Path, TableChanges{Replaced: cnt})
 			}
-			proc.Log(fmt.Sprintf("%s inserted on %q.", FormatCount(cnt, "record"), fileInfo.Path), proc.Tx.Flags.Quiet)
+			proc.Log(fmt.Sprintf("%s replaced on %q.", FormatCount(cnt, "record"), fileInfo.Path), proc.Tx.Flags.Quiet)
 			if proc.storeResults {
 				proc.Tx.AffectedRows = cnt
 			}
@@ -249,12 +314,13 @@ func (proc *Processor) ExecuteStatement(ctx context.Context, stmt parser.Stateme
 			proc.measurementStart = time.Now()
 		}
 
-		infos, cnts, e := Update(ctx, proc.Filter, stmt.(parser.UpdateQuery))
+		infos, cnts, returningView, e := Update(ctx, proc.Filter, stmt.(parser.UpdateQuery))
 		if e == nil {
 			cntTotal := 0
 			for i, info := range infos {
 				if 0 < cnts[i] {
 					proc.Tx.uncommittedViews.SetForUpdatedView(info)
+					proc.Tx.tableChanges.Add(info.Path, TableChanges{Updated: cnts[i]})
 					cntTotal += cnts[i]
 				}
 				proc.Log(fmt.Sprintf("%s updated on %q.", FormatCount(cnts[i], "record"), info.Path), proc.Tx.Flags.Quiet)
@@ -262,6 +328,40 @@ func (proc *Processor) ExecuteStatement(ctx context.Context, stmt parser.Stateme
 			if proc.storeResults {
 				proc.Tx.AffectedRows = cntTotal
 			}
+			if returningView != nil {
+				err = proc.writeSelectedView(returningView)
+			}
+		} else {
+			err = e
+		}
+
+		if proc.Tx.Flags.Stats {
+			proc.showExecutionTime()
+		}
+	case parser.MergeQuery:
+		if proc.Tx.Flags.Stats {
+			proc.measurementStart = time.Now()
+		}
+
+		mergeQuery := stmt.(parser.MergeQuery)
+		fileInfo, insertedCnt, updatedCnt, deletedCnt, e := Merge(ctx, proc.Filter, mergeQuery)
+		if e == nil {
+			if 0 < insertedCnt+updatedCnt+deletedCnt {
+				proc.Tx.uncommittedViews.SetForUpdatedView(fileInfo)
+				proc.Tx.tableChanges.Add(fileInfo.Path, TableChanges{Inserted: insertedCnt, Updated: updatedCnt, Deleted: deletedCnt})
+			}
+			if mergeQuery.HasOperation(parser.INSERT) {
+				proc.Log(fmt.Sprintf("%s inserted on %q.", FormatCount(insertedCnt, "record"), fileInfo.Path), proc.Tx.Flags.Quiet)
+			}
+			if mergeQuery.HasOperation(parser.UPDATE) {
+				proc.Log(fmt.Sprintf("%s updated on %q.", FormatCount(updatedCnt, "record"), fileInfo.Path), proc.Tx.Flags.Quiet)
+			}
+			if mergeQuery.HasOperation(parser.DELETE) {
+				proc.Log(fmt.Sprintf("%s deleted on %q.", FormatCount(deletedCnt, "record"), fileInfo.Path), proc.Tx.Flags.Quiet)
+			}
+			if proc.storeResults {
+				proc.Tx.AffectedRows = insertedCnt + updatedCnt + deletedCnt
+			}
 		} else {
 			err = e
 		}
@@ -274,12 +374,13 @@ func (proc *Processor) ExecuteStatement(ctx context.Context, stmt parser.Stateme
 			proc.measurementStart = time.Now()
 		}
 
-		infos, cnts, e := Delete(ctx, proc.Filter, stmt.(parser.DeleteQuery))
+		infos, cnts, returningView, e := Delete(ctx, proc.Filter, stmt.(parser.DeleteQuery))
 		if e == nil {
 			cntTotal := 0
 			for i, info := range infos {
 				if 0 < cnts[i] {
 					proc.Tx.uncommittedViews.SetForUpdatedView(info)
+					proc.Tx.tableChanges.Add(info.Path, TableChanges{Deleted: cnts[i]})
 					cntTotal += cnts[i]
 				}
 				proc.Log(fmt.Sprintf("%s deleted on %q.", FormatCount(cnts[i], "record"), info.Path), proc.Tx.Flags.Quiet)
@@ -287,6 +388,9 @@ func (proc *Processor) ExecuteStatement(ctx context.Context, stmt parser.Stateme
 			if proc.storeResults {
 				proc.Tx.AffectedRows = cntTotal
 			}
+			if returningView != nil {
+				err = proc.writeSelectedView(returningView)
+			}
 		} else {
 			err = e
 		}
@@ -302,6 +406,12 @@ func (proc *Processor) ExecuteStatement(ctx context.Context, stmt parser.Stateme
 		} else {
 			err = e
 		}
+	case parser.CreateView:
+		if fpath, e := CreateView(ctx, proc.Filter, stmt.(parser.CreateView)); e == nil {
+			proc.Log(fmt.Sprintf("view definition %q is created.", fpath), proc.Tx.Flags.Quiet)
+		} else {
+			err = e
+		}
 	case parser.AddColumns:
 		info, cnt, e := AddColumns(ctx, proc.Filter, stmt.(parser.AddColumns))
 		if e == nil {
@@ -340,12 +450,25 @@ func (proc *Processor) ExecuteStatement(ctx context.Context, stmt parser.Stateme
 			}
 		}
 	case parser.TransactionControl:
-		switch stmt.(parser.TransactionControl).Token {
+		tc := stmt.(parser.TransactionControl)
+		switch tc.Token {
 		case parser.COMMIT:
-			err = proc.Commit(stmt.(parser.Expression))
+			if 0 < len(tc.Table.Literal) {
+				err = proc.Tx.CommitTable(proc.Filter, tc, tc.Table)
+			} else {
+				err = proc.Commit(tc)
+			}
 		case parser.ROLLBACK:
-			err = proc.Rollback(stmt.(parser.Expression))
+			if 0 < len(tc.Table.Literal) {
+				err = proc.Tx.RollbackTable(proc.Filter, tc, tc.Table)
+			} else {
+				err = proc.Rollback(tc)
+			}
 		}
+	case parser.LockTable:
+		err = proc.Tx.LockTables(ctx, proc.Filter, stmt.(parser.LockTable))
+	case parser.UnlockTable:
+		err = proc.Tx.UnlockTables(proc.Filter, stmt.(parser.UnlockTable))
 	case parser.FlowControl:
 		switch stmt.(parser.FlowControl).Token {
 		case parser.CONTINUE:
@@ -419,6 +542,10 @@ func (proc *Processor) ExecuteStatement(ctx context.Context, stmt parser.Stateme
 		if printstr, err = ShowFields(ctx, proc.Filter, stmt.(parser.ShowFields)); err == nil {
 			proc.Log(printstr, false)
 		}
+	case parser.Explain:
+		if printstr, err = Explain(ctx, proc.Filter, stmt.(parser.Explain)); err == nil {
+			proc.Log(printstr, false)
+		}
 	case parser.Syntax:
 		printstr = Syntax(ctx, proc.Filter, stmt.(parser.Syntax))
 		proc.Log(printstr, false)
@@ -597,6 +724,13 @@ func (proc *Processor) WhileInCursor(ctx context.Context, stmt parser.WhileInCur
 	return Terminate, nil
 }
 
+func (proc *Processor) restoreLocalFlags() {
+	for i := len(proc.localFlags) - 1; 0 <= i; i-- {
+		proc.Tx.RestoreFlag(proc.localFlags[i].Name, proc.localFlags[i].Flags)
+	}
+	proc.localFlags = nil
+}
+
 func (proc *Processor) ExecExternalCommand(ctx context.Context, stmt parser.ExternalCommand) error {
 	splitter := new(excmd.ArgsSplitter).Init(stmt.Command)
 	var argStrs = make([]string, 0, 8)
@@ -638,11 +772,81 @@ func (proc *Processor) ExecExternalCommand(ctx context.Context, stmt parser.Exte
 	return err
 }
 
+func (proc *Processor) writeSelectedView(view *View) error {
+	if proc.storeResults {
+		proc.Tx.SelectedViews = append(proc.Tx.SelectedViews, view)
+		return nil
+	}
+
+	fileInfo := proc.outputFileInfo()
+	writer := proc.outputWriter()
+	p := startProgress(proc.Tx, "Writing", formatRecordCount(view.RecordLen()), "bytes")
+	warnmsg, err := EncodeView(p.writer(writer), view, fileInfo, proc.Tx.Flags)
+	p.finish()
+
+	if err != nil {
+		if _, ok := err.(*EmptyResultSetError); ok {
+			if 0 < len(warnmsg) {
+				proc.LogWarn(warnmsg, proc.Tx.Flags.Quiet)
+			}
+			return nil
+		}
+		return err
+	}
+
+	if !(proc.Tx.Session.OutFile != nil && fileInfo.Format == cmd.FIXED && fileInfo.SingleLine) {
+		_, err = writer.Write([]byte(proc.Tx.Flags.LineBreak.Value()))
+	}
+	return err
+}
+
+// streamSelectedView writes the result of the query while reading the records if the query can be
+// executed in streaming mode. It returns false if the query must be executed by Select.
+func (proc *Processor) streamSelectedView(ctx context.Context, query parser.SelectQuery) (bool, error) {
+	// The result to be cached must be held in the view.
+	if proc.storeResults || proc.Tx.Flags.ResultCache {
+		return false, nil
+	}
+
+	return SelectStream(ctx, proc.Filter, query, proc.outputWriter(), proc.outputFileInfo())
+}
+
+func (proc *Processor) outputFileInfo() *FileInfo {
+	return &FileInfo{
+		Format:             proc.Tx.Flags.Format,
+		Delimiter:          proc.Tx.Flags.WriteDelimiter,
+		DelimiterPositions: proc.Tx.Flags.WriteDelimiterPositions,
+		Encoding:           proc.Tx.Flags.WriteEncoding,
+		LineBreak:          proc.Tx.Flags.LineBreak,
+		NoHeader:           proc.Tx.Flags.WithoutHeader,
+		EncloseAll:         proc.Tx.Flags.EncloseAll,
+		PrettyPrint:        proc.Tx.Flags.PrettyPrint,
+		SingleLine:         proc.Tx.Flags.WriteAsSingleLine,
+	}
+}
+
+func (proc *Processor) outputWriter() io.Writer {
+	if proc.Tx.Session.OutFile != nil {
+		return proc.Tx.Session.OutFile
+	}
+	return proc.Tx.Session.Stdout
+}
+
 func (proc *Processor) showExecutionTime() {
 	palette := cmd.GetPalette()
 	exectime := cmd.FormatNumber(time.Since(proc.measurementStart).Seconds(), 6, ".", ",", "")
 	stats := fmt.Sprintf(palette.Render(cmd.LableEffect, "Query Execution Time: ")+"%s seconds", exectime)
 	proc.Log(stats, false)
+
+	for _, stat := range proc.Tx.fileLoadStats {
+		if stat.start.Before(proc.measurementStart) {
+			continue
+		}
+		stats = fmt.Sprintf(palette.Render(cmd.LableEffect, "File Load Time: ")+"%s (%s, wait %s, parse %s)",
+			stat.Path, FormatCount(stat.Records, "record"), formatSeconds(stat.WaitTime), formatSeconds(stat.ParseTime))
+		proc.Log(stats, false)
+	}
+	proc.Tx.fileLoadStats = nil
 }
 
 func (proc *Processor) Log(log string, quiet bool) {
@@ -684,3 +888,30 @@ func (proc *Processor) ReleaseResources() error {
 func (proc *Processor) ReleaseResourcesWithErrors() error {
 	return proc.Tx.ReleaseResourcesWithErrors()
 }
+
+// checkRestrictedStatement returns an error if the statement is not allowed in restricted transactions.
+func checkRestrictedStatement(stmt parser.Statement) error {
+	switch s := stmt.(type) {
+	case parser.SetFlag:
+		return NewRestrictedStatementError(s, "SET statement for flags")
+	case parser.AddFlagElement:
+		return NewRestrictedStatementError(s, "ADD statement for flags")
+	case parser.RemoveFlagElement:
+		return NewRestrictedStatementError(s, "REMOVE statement for flags")
+	case parser.SetEnvVar:
+		return NewRestrictedStatementError(s.EnvVar, "SET statement for environment variables")
+	case parser.UnsetEnvVar:
+		return NewRestrictedStatementError(s.EnvVar, "UNSET statement")
+	case parser.Source:
+		return NewRestrictedStatementError(s, "SOURCE statement")
+	case parser.Chdir:
+		return NewRestrictedStatementError(s, "CHDIR statement")
+	case parser.ExternalCommand:
+		return NewRestrictedStatementError(s, "external command")
+	case parser.FunctionDeclaration:
+		if s.IsExternalLanguage() {
+			return NewRestrictedStatementError(s.Name, fmt.Sprintf("function written in language %s", s.Language.Literal))
+		}
+	}
+	return nil
+}
@@ -573,6 +573,53 @@ var processorExecuteStatementTests = []struct {
 		},
 		Logs: fmt.Sprintf("1 record deleted on %q.\n", GetTestFilePath("table1.csv")),
 	},
+	{
+		Input: parser.MergeQuery{
+			Table:  parser.Table{Object: parser.Identifier{Literal: "table1"}},
+			Source: parser.Table{Object: parser.Identifier{Literal: "table2"}},
+			Condition: parser.Comparison{
+				LHS:      parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+				RHS:      parser.FieldReference{Column: parser.Identifier{Literal: "column3"}},
+				Operator: "=",
+			},
+			WhenList: []parser.MergeWhen{
+				{
+					Operation: parser.Token{Token: parser.UPDATE, Literal: "update"},
+					SetList: []parser.UpdateSet{
+						{
+							Field: parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+							Value: parser.FieldReference{Column: parser.Identifier{Literal: "column4"}},
+						},
+					},
+				},
+				{
+					NotMatched: true,
+					Operation:  parser.Token{Token: parser.INSERT, Literal: "insert"},
+					Values: parser.RowValue{
+						Value: parser.ValueList{
+							Values: []parser.QueryExpression{
+								parser.FieldReference{Column: parser.Identifier{Literal: "column3"}},
+								parser.FieldReference{Column: parser.Identifier{Literal: "column4"}},
+							},
+						},
+					},
+				},
+			},
+		},
+		UncommittedViews: &UncommittedViews{
+			Created: map[string]*FileInfo{},
+			Updated: map[string]*FileInfo{
+				strings.ToUpper(GetTestFilePath("TABLE1.CSV")): {
+					Path:      GetTestFilePath("table1.csv"),
+					Delimiter: ',',
+					NoHeader:  false,
+					Encoding:  text.UTF8,
+					LineBreak: text.LF,
+				},
+			},
+		},
+		Logs: fmt.Sprintf("1 record inserted on %q.\n2 records updated on %q.\n", GetTestFilePath("table1.csv"), GetTestFilePath("table1.csv")),
+	},
 	{
 		Input: parser.CreateTable{
 			Table: parser.Identifier{Literal: "newtable.csv"},
@@ -1059,6 +1106,163 @@ func TestProcessor_IfStmt(t *testing.T) {
 	}
 }
 
+func TestProcessor_ExternalFunctionInReadOnlyMode(t *testing.T) {
+	defer func() {
+		initFlag(TestTx.Flags)
+	}()
+
+	TestTx.Flags.SetQuiet(true)
+	_ = TestTx.Flags.SetReadOnly(true)
+	proc := NewProcessor(TestTx)
+
+	statements, _, err := parser.Parse("DECLARE fn FUNCTION (@a) LANGUAGE EXTERNAL AS 'cat';", "", nil, false)
+	if err != nil {
+		t.Fatalf("unexpected parse error %q", err)
+	}
+
+	expectErr := "[L:1 C:9] function fn written in language EXTERNAL cannot be declared in read-only mode"
+	_, err = proc.Execute(context.Background(), statements)
+	if err == nil {
+		t.Fatalf("no error, want error %q", expectErr)
+	}
+	if err.Error() != expectErr {
+		t.Errorf("error = %q, want error %q", err.Error(), expectErr)
+	}
+	if _, err := proc.Filter.functions.Get(parser.Identifier{Literal: "fn"}, "fn"); err == nil {
+		t.Errorf("function fn is declared in read-only mode")
+	}
+}
+
+var processorRestrictedTests = []struct {
+	Query string
+	Error string
+}{
+	{
+		Query: "DECLARE fn FUNCTION (@a) AS BEGIN RETURN @a; END; VAR @b := fn(1);",
+	},
+	{
+		Query: "SET @@FORMAT = JSON;",
+		Error: "[L:1 C:1] SET statement for flags is not allowed in restricted mode",
+	},
+	{
+		Query: "ADD '%Y' TO @@DATETIME_FORMAT;",
+		Error: "[L:1 C:1] ADD statement for flags is not allowed in restricted mode",
+	},
+	{
+		Query: "REMOVE '%Y' FROM @@DATETIME_FORMAT;",
+		Error: "[L:1 C:1] REMOVE statement for flags is not allowed in restricted mode",
+	},
+	{
+		Query: "SET @%CSVQ_TEST = 'a';",
+		Error: "[L:1 C:5] SET statement for environment variables is not allowed in restricted mode",
+	},
+	{
+		Query: "UNSET @%CSVQ_TEST;",
+		Error: "[L:1 C:7] UNSET statement is not allowed in restricted mode",
+	},
+	{
+		Query: "SOURCE 'source.sql';",
+		Error: "[L:1 C:1] SOURCE statement is not allowed in restricted mode",
+	},
+	{
+		Query: "CHDIR '/';",
+		Error: "[L:1 C:1] CHDIR statement is not allowed in restricted mode",
+	},
+	{
+		Query: "$echo a;",
+		Error: "[L:1 C:1] external command is not allowed in restricted mode",
+	},
+	{
+		Query: "DECLARE fn FUNCTION (@a) LANGUAGE EXTERNAL AS 'cat';",
+		Error: "[L:1 C:9] function written in language EXTERNAL is not allowed in restricted mode",
+	},
+	{
+		Query: "DECLARE fn FUNCTION (@a) LANGUAGE LUA AS 'return a';",
+		Error: "[L:1 C:9] function written in language LUA is not allowed in restricted mode",
+	},
+	{
+		Query: "VAR @a := CALL('echo', 'a');",
+		Error: "[L:1 C:11] function CALL is not allowed in restricted mode",
+	},
+	{
+		Query: "EXECUTE \"SOURCE 'source.sql'\";",
+		Error: "(L:1 C:1) EXECUTE [L:1 C:1] SOURCE statement is not allowed in restricted mode",
+	},
+}
+
+func TestProcessor_Restricted(t *testing.T) {
+	defer func() {
+		TestTx.Restricted = false
+		initFlag(TestTx.Flags)
+	}()
+
+	TestTx.Flags.SetQuiet(true)
+	TestTx.Restricted = true
+
+	for _, v := range processorRestrictedTests {
+		statements, _, err := parser.Parse(v.Query, "", nil, false)
+		if err != nil {
+			t.Fatalf("%s: unexpected parse error %q", v.Query, err)
+		}
+
+		_, err = NewProcessor(TestTx).Execute(context.Background(), statements)
+		if err != nil {
+			if len(v.Error) < 1 {
+				t.Errorf("%s: unexpected error %q", v.Query, err)
+			} else if err.Error() != v.Error {
+				t.Errorf("%s: error %q, want error %q", v.Query, err.Error(), v.Error)
+			}
+			continue
+		}
+		if 0 < len(v.Error) {
+			t.Errorf("%s: no error, want error %q", v.Query, v.Error)
+		}
+	}
+}
+
+func TestProcessor_SetLocalFlag(t *testing.T) {
+	defer initFlag(TestTx.Flags)
+
+	TestTx.Flags.SetQuiet(true)
+	proc := NewProcessor(TestTx)
+
+	_, err := proc.Execute(context.Background(), []parser.Statement{
+		parser.SetFlag{Name: "format", Value: parser.NewStringValue("csv")},
+		parser.If{
+			Condition: parser.NewTernaryValue(ternary.TRUE),
+			Statements: []parser.Statement{
+				parser.SetFlag{Local: parser.Token{Token: parser.LOCAL, Literal: "local"}, Name: "format", Value: parser.NewStringValue("json")},
+				parser.SetFlag{Local: parser.Token{Token: parser.LOCAL, Literal: "local"}, Name: "delimiter", Value: parser.NewStringValue(";")},
+				parser.SetFlag{Name: "no_header", Value: parser.NewTernaryValue(ternary.TRUE)},
+				parser.SetFlag{Local: parser.Token{Token: parser.LOCAL, Literal: "local"}, Name: "format", Value: parser.NewStringValue("tsv")},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if TestTx.Flags.Format != cmd.CSV {
+		t.Errorf("format = %s, want %s", TestTx.Flags.Format, cmd.CSV)
+	}
+	if TestTx.Flags.Delimiter != ',' {
+		t.Errorf("delimiter = %q, want %q", TestTx.Flags.Delimiter, ',')
+	}
+	if !TestTx.Flags.NoHeader {
+		t.Errorf("no-header = %t, want %t", TestTx.Flags.NoHeader, true)
+	}
+
+	_, err = proc.Execute(context.Background(), []parser.Statement{
+		parser.SetFlag{Local: parser.Token{Token: parser.LOCAL, Literal: "local"}, Name: "format", Value: parser.NewStringValue("json")},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	if TestTx.Flags.Format != cmd.CSV {
+		t.Errorf("format = %s, want %s", TestTx.Flags.Format, cmd.CSV)
+	}
+}
+
 var processorCaseStmtTests = []struct {
 	Name       string
 	Stmt       parser.Case
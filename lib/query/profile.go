@@ -0,0 +1,141 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mithrandie/csvq/lib/parser"
+)
+
+const (
+	CPUProfile    = "cpu"
+	MemoryProfile = "mem"
+	TraceProfile  = "trace"
+)
+
+var (
+	profileType = ""
+
+	// profileDir is the directory where the profiles are written.
+	// If it is empty, then the profiles are written in the working directory.
+	profileDir = ""
+
+	profileMutex sync.Mutex
+	profileSeq   = 0
+	profiling    = false
+)
+
+// SetProfile sets the type of the profiles written for each query.
+// If the type is empty, then no profile is written.
+func SetProfile(s string) error {
+	t := strings.ToLower(strings.TrimSpace(s))
+	switch t {
+	case "", CPUProfile, MemoryProfile, TraceProfile:
+		profileType = t
+		return nil
+	}
+	return errors.New("profile must be one of cpu|mem|trace")
+}
+
+func isProfiledStatement(stmt parser.Statement) bool {
+	switch stmt.(type) {
+	case parser.SelectQuery, parser.InsertQuery, parser.ReplaceQuery, parser.UpdateQuery, parser.MergeQuery, parser.DeleteQuery, parser.CreateTable:
+		return true
+	}
+	return false
+}
+
+// statementProfile is a profile of the execution of a query written in pprof format,
+// or in the format of the execution tracer.
+type statementProfile struct {
+	profileType string
+	path        string
+	fp          *os.File
+}
+
+// startProfile starts profiling the execution of the statement.
+//
+// Nil is returned if profiling is disabled, the statement is not a query, or another query is being
+// profiled, so that the queries executed in user defined functions are profiled as a part of the
+// outer query.
+func startProfile(stmt parser.Statement) (*statementProfile, error) {
+	if len(profileType) < 1 || !isProfiledStatement(stmt) {
+		return nil, nil
+	}
+
+	profileMutex.Lock()
+	defer profileMutex.Unlock()
+
+	if profiling {
+		return nil, nil
+	}
+
+	profileSeq++
+	ext := ".pprof"
+	if profileType == TraceProfile {
+		ext = ".trace"
+	}
+	fpath := filepath.Join(profileDir, fmt.Sprintf("csvq-%s-%s-%d%s", profileType, time.Now().Format("20060102-150405"), profileSeq, ext))
+	if abs, err := filepath.Abs(fpath); err == nil {
+		fpath = abs
+	}
+
+	fp, err := os.Create(fpath)
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("failed to create profile: %s", err.Error()))
+	}
+
+	switch profileType {
+	case CPUProfile:
+		err = pprof.StartCPUProfile(fp)
+	case TraceProfile:
+		err = trace.Start(fp)
+	}
+	if err != nil {
+		_ = fp.Close()
+		_ = os.Remove(fpath)
+		return nil, errors.New(fmt.Sprintf("failed to start profiling: %s", err.Error()))
+	}
+
+	profiling = true
+	return &statementProfile{
+		profileType: profileType,
+		path:        fpath,
+		fp:          fp,
+	}, nil
+}
+
+// stop stops profiling and closes the file.
+func (p *statementProfile) stop() error {
+	var err error
+
+	switch p.profileType {
+	case CPUProfile:
+		pprof.StopCPUProfile()
+	case MemoryProfile:
+		runtime.GC()
+		err = pprof.WriteHeapProfile(p.fp)
+	case TraceProfile:
+		trace.Stop()
+	}
+	if e := p.fp.Close(); err == nil {
+		err = e
+	}
+
+	profileMutex.Lock()
+	profiling = false
+	profileMutex.Unlock()
+
+	if err != nil {
+		return errors.New(fmt.Sprintf("failed to write profile: %s", err.Error()))
+	}
+	return nil
+}
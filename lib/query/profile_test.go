@@ -0,0 +1,115 @@
+package query
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mithrandie/csvq/lib/parser"
+)
+
+var setProfileTests = []struct {
+	Value  string
+	Expect string
+	Error  string
+}{
+	{
+		Value:  "cpu",
+		Expect: CPUProfile,
+	},
+	{
+		Value:  " MEM ",
+		Expect: MemoryProfile,
+	},
+	{
+		Value:  "trace",
+		Expect: TraceProfile,
+	},
+	{
+		Value:  "",
+		Expect: "",
+	},
+	{
+		Value: "block",
+		Error: "profile must be one of cpu|mem|trace",
+	},
+}
+
+func TestSetProfile(t *testing.T) {
+	defer func() {
+		profileType = ""
+	}()
+
+	for _, v := range setProfileTests {
+		profileType = ""
+		err := SetProfile(v.Value)
+		if err != nil {
+			if len(v.Error) < 1 {
+				t.Errorf("%q: unexpected error %q", v.Value, err)
+			} else if err.Error() != v.Error {
+				t.Errorf("%q: error %q, want error %q", v.Value, err.Error(), v.Error)
+			}
+			continue
+		}
+		if 0 < len(v.Error) {
+			t.Errorf("%q: no error, want error %q", v.Value, v.Error)
+			continue
+		}
+		if profileType != v.Expect {
+			t.Errorf("%q: profile = %q, want %q", v.Value, profileType, v.Expect)
+		}
+	}
+}
+
+func TestStartProfile(t *testing.T) {
+	profileDir = filepath.Join(TestDir, "profile")
+	if err := os.MkdirAll(profileDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = os.RemoveAll(profileDir)
+		profileDir = ""
+		profileType = ""
+	}()
+
+	query := parser.SelectQuery{}
+
+	if p, err := startProfile(query); p != nil || err != nil {
+		t.Fatalf("profile = %v, error = %v, want no profile while profiling is disabled", p, err)
+	}
+
+	for _, typ := range []string{CPUProfile, MemoryProfile, TraceProfile} {
+		profileType = typ
+
+		if p, err := startProfile(parser.Print{}); p != nil || err != nil {
+			t.Errorf("%s: profile = %v, error = %v, want no profile for a statement other than queries", typ, p, err)
+		}
+
+		p, err := startProfile(query)
+		if err != nil {
+			t.Errorf("%s: unexpected error %q", typ, err)
+			continue
+		}
+		if p == nil {
+			t.Errorf("%s: profile is not started", typ)
+			continue
+		}
+
+		if nested, err := startProfile(query); nested != nil || err != nil {
+			t.Errorf("%s: profile = %v, error = %v, want no profile for a nested query", typ, nested, err)
+		}
+
+		if err = p.stop(); err != nil {
+			t.Errorf("%s: unexpected error %q", typ, err)
+			continue
+		}
+
+		if filepath.Dir(p.path) != profileDir || !strings.HasPrefix(filepath.Base(p.path), "csvq-"+typ+"-") {
+			t.Errorf("%s: path = %q, want a file named csvq-%s-* in %q", typ, p.path, typ, profileDir)
+		}
+		if stat, err := os.Stat(p.path); err != nil || stat.Size() < 1 {
+			t.Errorf("%s: profile is not written to %q", typ, p.path)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package query
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mithrandie/csvq/lib/cmd"
+)
+
+// ProgressInterval is the interval at which the progress of an operation is reported.
+var ProgressInterval = time.Second
+
+// progress reports the progress of a long operation, such as loading, sorting or writing records,
+// to the standard error output at regular intervals while the operation is running.
+// Nothing is reported for operations finished within the interval.
+//
+// The methods can be called on nil, which means that the progress is not reported.
+type progress struct {
+	session *Session
+	label   string
+	target  string
+
+	// Unit of the counted amount. If it is empty, then only the elapsed time is reported.
+	unit  string
+	count int64
+
+	start    time.Time
+	done     chan struct{}
+	finished sync.WaitGroup
+}
+
+// startProgress starts reporting the progress of the operation if the progress flag is enabled.
+func startProgress(tx *Transaction, label string, target string, unit string) *progress {
+	if !tx.Flags.Progress {
+		return nil
+	}
+
+	p := &progress{
+		session: tx.Session,
+		label:   label,
+		target:  target,
+		unit:    unit,
+		start:   time.Now(),
+		done:    make(chan struct{}),
+	}
+	p.finished.Add(1)
+	go p.report()
+	return p
+}
+
+func (p *progress) add(n int) {
+	if p == nil {
+		return
+	}
+	atomic.AddInt64(&p.count, int64(n))
+}
+
+// writer returns the writer that counts the bytes written to w.
+func (p *progress) writer(w io.Writer) io.Writer {
+	if p == nil {
+		return w
+	}
+	return &progressWriter{writer: w, progress: p}
+}
+
+// finish stops reporting. If the progress has been reported, then the final state is reported.
+func (p *progress) finish() {
+	if p == nil {
+		return
+	}
+	close(p.done)
+	p.finished.Wait()
+}
+
+func (p *progress) report() {
+	defer p.finished.Done()
+
+	ticker := time.NewTicker(ProgressInterval)
+	defer ticker.Stop()
+
+	reported := false
+	for {
+		select {
+		case <-p.done:
+			if reported {
+				_ = p.session.WriteToStderrWithLineBreak(p.message(true))
+			}
+			return
+		case <-ticker.C:
+			_ = p.session.WriteToStderrWithLineBreak(p.message(false))
+			reported = true
+		}
+	}
+}
+
+func (p *progress) message(done bool) string {
+	s := formatSeconds(time.Since(p.start))
+	if 0 < len(p.unit) {
+		s = cmd.FormatNumber(float64(atomic.LoadInt64(&p.count)), 0, ".", ",", "") + " " + p.unit + ", " + s
+	}
+	if done {
+		s = s + ", done"
+	}
+	return fmt.Sprintf(cmd.GetPalette().Render(cmd.LableEffect, p.label+": ")+"%s (%s)", p.target, s)
+}
+
+func formatRecordCount(n int) string {
+	return cmd.FormatNumber(float64(n), 0, ".", ",", "") + " records"
+}
+
+type progressWriter struct {
+	writer   io.Writer
+	progress *progress
+}
+
+func (w *progressWriter) Write(b []byte) (int, error) {
+	n, err := w.writer.Write(b)
+	w.progress.add(n)
+	return n, err
+}
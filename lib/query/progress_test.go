@@ -0,0 +1,90 @@
+package query
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mithrandie/csvq/lib/file"
+)
+
+func TestProgress(t *testing.T) {
+	interval := ProgressInterval
+	ProgressInterval = 10 * time.Millisecond
+	defer func() {
+		ProgressInterval = interval
+	}()
+
+	tx, _ := NewTransaction(context.Background(), file.DefaultWaitTimeout, file.DefaultRetryDelay, NewSession())
+	tx.Flags.SetColor(false)
+	r, w, _ := os.Pipe()
+	tx.Session.Stderr = w
+
+	p := startProgress(tx, "Loading", "table1.csv", "records")
+	if p != nil {
+		t.Fatalf("progress is started while the progress flag is disabled")
+	}
+	p.add(1)
+	_, _ = p.writer(ioutil.Discard).Write([]byte("abc"))
+	p.finish()
+
+	tx.Flags.SetProgress(true)
+
+	p = startProgress(tx, "Sorting", formatRecordCount(1000), "")
+	p.finish()
+
+	p = startProgress(tx, "Loading", "table1.csv", "records")
+	p.add(2)
+	time.Sleep(35 * time.Millisecond)
+	p.add(1)
+	p.finish()
+
+	p = startProgress(tx, "Writing", "table1.csv", "bytes")
+	buf := new(bytes.Buffer)
+	_, _ = p.writer(buf).Write([]byte("abcd"))
+	time.Sleep(25 * time.Millisecond)
+	p.finish()
+
+	_ = w.Close()
+	log, _ := ioutil.ReadAll(r)
+	lines := strings.Split(strings.TrimSuffix(string(log), "\n"), "\n")
+
+	if buf.String() != "abcd" {
+		t.Errorf("written = %q, want %q", buf.String(), "abcd")
+	}
+	loading := make([]string, 0, len(lines))
+	writing := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if strings.HasPrefix(line, "Writing: ") {
+			writing = append(writing, line)
+		} else {
+			loading = append(loading, line)
+		}
+	}
+
+	check := func(lines []string, progressPrefix string, donePrefix string) {
+		if len(lines) < 2 {
+			t.Errorf("progress = %q, want at least 2 lines", lines)
+			return
+		}
+		for i, line := range lines {
+			prefix := progressPrefix
+			done := i == len(lines)-1
+			if done {
+				prefix = donePrefix
+			}
+			if !strings.HasPrefix(line, prefix) {
+				t.Errorf("line %q, want prefix %q", line, prefix)
+			}
+			if strings.HasSuffix(line, " seconds, done)") != done {
+				t.Errorf("line %q, want done = %t", line, done)
+			}
+		}
+	}
+	check(loading, "Loading: table1.csv (2 records, ", "Loading: table1.csv (3 records, ")
+	check(writing, "Writing: table1.csv (4 bytes, ", "Writing: table1.csv (4 bytes, ")
+}
@@ -1,13 +1,19 @@
 package query
 
 import (
+	"bytes"
 	"context"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/mithrandie/csvq/lib/cmd"
 	"github.com/mithrandie/csvq/lib/file"
 	"github.com/mithrandie/csvq/lib/parser"
 	"github.com/mithrandie/csvq/lib/value"
+
+	"github.com/mithrandie/ternary"
 )
 
 func FetchCursor(ctx context.Context, filter *Filter, name parser.Identifier, fetchPosition parser.FetchPosition, vars []parser.Variable) (bool, error) {
@@ -90,9 +96,26 @@ func DeclareView(ctx context.Context, filter *Filter, expr parser.ViewDeclaratio
 		InitialRecordSet: view.RecordSet.Copy(),
 	}
 
+	if expr.PrimaryKey != nil {
+		columns := make([]string, 0, len(expr.PrimaryKey))
+		for _, v := range expr.PrimaryKey {
+			ident := v.(parser.Identifier)
+			idx, err := view.FieldIndex(parser.FieldReference{BaseExpr: ident.BaseExpr, Column: ident})
+			if err != nil {
+				return err
+			}
+			columns = append(columns, view.Header[idx].Column)
+		}
+		view.FileInfo.PrimaryKey = NewViewIndex(expr.View.Literal, expr.View.Literal, columns)
+
+		if err = filter.tx.constraints.Validate(ctx, filter, view, expr.View); err != nil {
+			return err
+		}
+	}
+
 	filter.tempViews.Set(view)
 
-	return err
+	return checkMemoryLimit(filter, 0)
 }
 
 func Select(ctx context.Context, parentFilter *Filter, query parser.SelectQuery) (*View, error) {
@@ -104,70 +127,137 @@ func Select(ctx context.Context, parentFilter *Filter, query parser.SelectQuery)
 		}
 	}
 
-	view, err := selectEntity(ctx, filter, query.SelectEntity)
-	if err != nil {
-		return nil, err
+	view, ok := countRecordsWithStatistics(filter, query)
+	if !ok {
+		var err error
+		if view, err = selectEntity(ctx, filter, query.SelectEntity); err != nil {
+			return nil, err
+		}
 	}
 
 	if query.OrderByClause != nil {
+		start := time.Now()
 		if err := view.OrderBy(ctx, query.OrderByClause.(parser.OrderByClause)); err != nil {
 			return nil, err
 		}
+		filter.recorder.record(newOperationKey("sort", query), view, start)
+	}
+
+	if err := distinctOn(ctx, filter, view, query); err != nil {
+		return nil, err
 	}
 
 	if query.OffsetClause != nil {
+		start := time.Now()
 		if err := view.Offset(ctx, query.OffsetClause.(parser.OffsetClause)); err != nil {
 			return nil, err
 		}
+		filter.recorder.record(newOperationKey("offset", query), view, start)
 	}
 
 	if query.LimitClause != nil {
+		start := time.Now()
 		if err := view.Limit(ctx, query.LimitClause.(parser.LimitClause)); err != nil {
 			return nil, err
 		}
+		filter.recorder.record(newOperationKey("limit", query), view, start)
 	}
 
-	err = view.Fix(ctx)
+	err := view.Fix(ctx)
 	return view, err
 }
 
 func selectEntity(ctx context.Context, filter *Filter, expr parser.QueryExpression) (*View, error) {
 	entity, ok := expr.(parser.SelectEntity)
 	if !ok {
+		if valuesTable, ok := expr.(parser.ValuesTable); ok {
+			return selectValuesTable(ctx, filter, valuesTable)
+		}
 		return selectSet(ctx, filter, expr.(parser.SelectSet))
 	}
 
 	if entity.FromClause == nil {
 		entity.FromClause = parser.FromClause{}
 	}
-	view := NewView(filter.tx)
-	err := view.Load(ctx, filter, entity.FromClause.(parser.FromClause))
-	if err != nil {
-		return nil, err
+	var condition parser.QueryExpression
+	if entity.WhereClause != nil {
+		condition = entity.WhereClause.(parser.WhereClause).Filter
 	}
 
-	if entity.WhereClause != nil {
-		if err := view.Where(ctx, entity.WhereClause.(parser.WhereClause)); err != nil {
-			return nil, err
-		}
+	view := NewView(filter.tx)
+	if err := view.LoadWithCondition(ctx, filter, entity.FromClause.(parser.FromClause), condition); err != nil {
+		return nil, err
 	}
 
 	if entity.GroupByClause != nil {
-		if err := view.GroupBy(ctx, entity.GroupByClause.(parser.GroupByClause)); err != nil {
+		start := time.Now()
+		if err := view.groupBy(ctx, entity.GroupByClause.(parser.GroupByClause), partialAggregates(entity)); err != nil {
 			return nil, err
 		}
+		filter.recorder.record(newOperationKey("group", entity), view, start)
 	}
 
 	if entity.HavingClause != nil {
+		start := time.Now()
 		if err := view.Having(ctx, entity.HavingClause.(parser.HavingClause)); err != nil {
 			return nil, err
 		}
+		filter.recorder.record(newOperationKey("having", entity), view, start)
 	}
 
+	start := time.Now()
 	if err := view.Select(ctx, entity.SelectClause.(parser.SelectClause)); err != nil {
 		return nil, err
 	}
+	filter.recorder.record(newOperationKey("project", entity), view, start)
+
+	return view, nil
+}
+
+// distinctOn removes the duplicate records selected by the DISTINCT clause of the query.
+func distinctOn(ctx context.Context, filter *Filter, view *View, query parser.QueryExpression) error {
+	if view.distinctOnFields == nil {
+		return nil
+	}
+
+	start := time.Now()
+	if err := view.DistinctOn(ctx); err != nil {
+		return err
+	}
+	filter.recorder.record(newOperationKey("distinct", query), view, start)
+	return nil
+}
+
+func selectValuesTable(ctx context.Context, filter *Filter, table parser.ValuesTable) (*View, error) {
+	start := time.Now()
+	view := NewView(filter.tx)
+	view.RecordSet = make(RecordSet, 0, len(table.ValuesList))
+	view.Filter = filter
+
+	for i, item := range table.ValuesList {
+		rv := item.(parser.RowValue)
+		values, err := filter.evalRowValue(ctx, rv)
+		if err != nil {
+			return nil, err
+		}
+
+		if i == 0 {
+			columns := make([]string, len(values))
+			for j := range values {
+				columns[j] = "column" + strconv.Itoa(j+1)
+			}
+			view.Header = NewHeader("", columns)
+		} else if len(values) != view.FieldLen() {
+			return nil, NewInsertRowValueLengthError(rv, view.FieldLen())
+		}
+
+		view.RecordSet = append(view.RecordSet, NewRecord(values))
+	}
 
+	if err := view.SelectAllColumns(ctx); err != nil {
+		return nil, err
+	}
+	filter.recorder.record(newOperationKey("values", table), view, start)
 	return view, nil
 }
 
@@ -180,6 +270,9 @@ func selectSetEntity(ctx context.Context, filter *Filter, expr parser.QueryExpre
 	if err != nil {
 		return nil, err
 	}
+	if err = distinctOn(ctx, filter, view, expr); err != nil {
+		return nil, err
+	}
 	err = view.Fix(ctx)
 	return view, err
 }
@@ -206,6 +299,7 @@ func selectSet(ctx context.Context, filter *Filter, set parser.SelectSet) (*View
 			return nil, NewCombinedSetFieldLengthError(set.RHS, lview.FieldLen())
 		}
 
+		start := time.Now()
 		switch set.Operator.Token {
 		case parser.UNION:
 			if err = lview.Union(ctx, rview, !set.All.IsEmpty()); err != nil {
@@ -220,6 +314,7 @@ func selectSet(ctx context.Context, filter *Filter, set parser.SelectSet) (*View
 				return nil, err
 			}
 		}
+		filter.recorder.record(newJoinOperationKey("set", set.LHS, set.RHS), lview, start)
 	}
 
 	err = lview.SelectAllColumns(ctx)
@@ -229,56 +324,172 @@ func selectSet(ctx context.Context, filter *Filter, set parser.SelectSet) (*View
 func selectSetForRecursion(ctx context.Context, filter *Filter, view *View, set parser.SelectSet) error {
 	tmpViewName := strings.ToUpper(filter.recursiveTable.Name.Literal)
 
-	if filter.recursiveTmpView == nil {
-		err := view.Header.Update(tmpViewName, filter.recursiveTable.Fields)
+	if err := view.Header.Update(tmpViewName, filter.recursiveTable.Fields); err != nil {
+		return err
+	}
+	filter.recursiveTmpView = view
+
+	var cycleFields []parser.QueryExpression
+	var cycleKeys map[string]bool
+	if filter.recursiveTable.CycleClause != nil {
+		cycleClause := filter.recursiveTable.CycleClause.(parser.CycleClause)
+		cycleFields = make([]parser.QueryExpression, 0, len(cycleClause.Fields))
+		for _, f := range cycleClause.Fields {
+			ident := f.(parser.Identifier)
+			cycleFields = append(cycleFields, parser.FieldReference{BaseExpr: ident.BaseExpr, Column: ident})
+		}
+		cycleKeys = make(map[string]bool)
+		if _, err := excludeCycleRecords(view, cycleFields, cycleKeys, filter.tx.Flags); err != nil {
+			return err
+		}
+	}
+
+	for i := 1; ; i++ {
+		rview, err := selectSetEntity(ctx, filter.CreateNode(), set.RHS)
 		if err != nil {
 			return err
 		}
-		filter.recursiveTmpView = view
+		if view.FieldLen() != rview.FieldLen() {
+			return NewCombinedSetFieldLengthError(set.RHS, view.FieldLen())
+		}
+
+		if rview.RecordLen() < 1 {
+			return nil
+		}
+		if err = rview.Header.Update(tmpViewName, filter.recursiveTable.Fields); err != nil {
+			return err
+		}
+
+		if cycleKeys != nil {
+			if rview.RecordSet, err = excludeCycleRecords(rview, cycleFields, cycleKeys, filter.tx.Flags); err != nil {
+				return err
+			}
+			if rview.RecordLen() < 1 {
+				return nil
+			}
+		}
+
+		if -1 < filter.tx.Flags.LimitRecursion && filter.tx.Flags.LimitRecursion < i {
+			return NewRecursionExceededLimitError(*filter.recursiveTable, filter.tx.Flags.LimitRecursion)
+		}
+		filter.recursiveTmpView = rview
+
+		switch set.Operator.Token {
+		case parser.UNION:
+			if err = view.Union(ctx, rview, !set.All.IsEmpty()); err != nil {
+				return err
+			}
+		case parser.EXCEPT:
+			if err = view.Except(ctx, rview, !set.All.IsEmpty()); err != nil {
+				return err
+			}
+		case parser.INTERSECT:
+			if err = view.Intersect(ctx, rview, !set.All.IsEmpty()); err != nil {
+				return err
+			}
+		}
 	}
+}
 
-	rview, err := selectSetEntity(ctx, filter.CreateNode(), set.RHS)
+func excludeCycleRecords(view *View, cycleFields []parser.QueryExpression, cycleKeys map[string]bool, flags *cmd.Flags) (RecordSet, error) {
+	indices, err := view.FieldIndices(cycleFields)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if view.FieldLen() != rview.FieldLen() {
-		return NewCombinedSetFieldLengthError(set.RHS, view.FieldLen())
+
+	buf := new(bytes.Buffer)
+	values := make([]value.Primary, len(indices))
+	records := make(RecordSet, 0, view.RecordLen())
+	for _, record := range view.RecordSet {
+		for i, idx := range indices {
+			values[i] = record[idx].Value()
+		}
+		buf.Reset()
+		SerializeComparisonKeys(buf, values, flags)
+		key := buf.String()
+
+		if cycleKeys[key] {
+			continue
+		}
+		cycleKeys[key] = true
+		records = append(records, record)
 	}
+	return records, nil
+}
 
-	if rview.RecordLen() < 1 {
-		return nil
+func Insert(ctx context.Context, parentFilter *Filter, query parser.InsertQuery) (*FileInfo, int, *View, error) {
+	filter := parentFilter.CreateNode()
+
+	var insertRecords int
+
+	if query.WithClause != nil {
+		if err := filter.LoadInlineTable(context.Background(), query.WithClause.(parser.WithClause)); err != nil {
+			return nil, insertRecords, nil, err
+		}
 	}
-	if err = rview.Header.Update(tmpViewName, filter.recursiveTable.Fields); err != nil {
-		return err
+
+	fromClause := parser.FromClause{
+		Tables: []parser.QueryExpression{
+			query.Table,
+		},
+	}
+	view := NewView(parentFilter.tx)
+	view.ForUpdate = true
+	err := view.Load(ctx, filter, fromClause)
+	if err != nil {
+		return nil, insertRecords, nil, err
 	}
-	filter.recursiveTmpView = rview
 
-	switch set.Operator.Token {
-	case parser.UNION:
-		if err = view.Union(ctx, rview, !set.All.IsEmpty()); err != nil {
-			return err
+	fields := query.Fields
+	if fields == nil {
+		fields = view.Header.TableColumns()
+	}
+
+	recordLen := view.RecordLen()
+
+	if query.ValuesList != nil {
+		if insertRecords, err = view.InsertValues(ctx, fields, query.ValuesList); err != nil {
+			return nil, insertRecords, nil, err
 		}
-	case parser.EXCEPT:
-		if err = view.Except(ctx, rview, !set.All.IsEmpty()); err != nil {
-			return err
+	} else {
+		if insertRecords, err = view.InsertFromQuery(ctx, fields, query.Query.(parser.SelectQuery)); err != nil {
+			return nil, insertRecords, nil, err
 		}
-	case parser.INTERSECT:
-		if err = view.Intersect(ctx, rview, !set.All.IsEmpty()); err != nil {
-			return err
+	}
+
+	var returningView *View
+	if query.ReturningClause != nil {
+		if returningView, err = selectReturningFields(ctx, filter, query.ReturningClause.(parser.ReturningClause), view.Header, view.RecordSet[recordLen:]); err != nil {
+			return nil, insertRecords, nil, err
 		}
 	}
 
-	return selectSetForRecursion(ctx, filter, view, set)
+	if err = view.RestoreHeaderReferences(); err != nil {
+		return nil, insertRecords, nil, err
+	}
+	if err = filter.tx.constraints.Validate(ctx, filter, view, query.Table.Object); err != nil {
+		return nil, insertRecords, nil, err
+	}
+	filter.tx.recordAudit(query, view, AuditInsert, view.RecordSet[recordLen:], nil)
+	view.Filter = nil
+
+	if view.FileInfo.IsTemporary {
+		filter.tempViews.Replace(view)
+	} else {
+		err = filter.tx.cachedViews.Replace(view)
+	}
+
+	return view.FileInfo, insertRecords, returningView, err
 }
 
-func Insert(ctx context.Context, parentFilter *Filter, query parser.InsertQuery) (*FileInfo, int, error) {
+func ReplaceInto(ctx context.Context, parentFilter *Filter, query parser.ReplaceQuery) (*FileInfo, int, error) {
 	filter := parentFilter.CreateNode()
 
-	var insertRecords int
+	var replaceRecords int
 
 	if query.WithClause != nil {
 		if err := filter.LoadInlineTable(context.Background(), query.WithClause.(parser.WithClause)); err != nil {
-			return nil, insertRecords, err
+			return nil, replaceRecords, err
 		}
 	}
 
@@ -291,7 +502,7 @@ func Insert(ctx context.Context, parentFilter *Filter, query parser.InsertQuery)
 	view.ForUpdate = true
 	err := view.Load(ctx, filter, fromClause)
 	if err != nil {
-		return nil, insertRecords, err
+		return nil, replaceRecords, err
 	}
 
 	fields := query.Fields
@@ -299,18 +510,42 @@ func Insert(ctx context.Context, parentFilter *Filter, query parser.InsertQuery)
 		fields = view.Header.TableColumns()
 	}
 
+	recordLen := view.RecordLen()
+	var previousRecords RecordSet
+	if filter.tx.auditEnabled() {
+		previousRecords = make(RecordSet, recordLen)
+		for i := 0; i < recordLen; i++ {
+			previousRecords[i] = append(Record(nil), view.RecordSet[i]...)
+		}
+	}
+
 	if query.ValuesList != nil {
-		if insertRecords, err = view.InsertValues(ctx, fields, query.ValuesList); err != nil {
-			return nil, insertRecords, err
+		if replaceRecords, err = view.ReplaceValues(ctx, fields, query.ValuesList, query.Keys); err != nil {
+			return nil, replaceRecords, err
 		}
 	} else {
-		if insertRecords, err = view.InsertFromQuery(ctx, fields, query.Query.(parser.SelectQuery)); err != nil {
-			return nil, insertRecords, err
+		if replaceRecords, err = view.ReplaceFromQuery(ctx, fields, query.Query.(parser.SelectQuery), query.Keys); err != nil {
+			return nil, replaceRecords, err
 		}
 	}
 
 	if err = view.RestoreHeaderReferences(); err != nil {
-		return nil, insertRecords, err
+		return nil, replaceRecords, err
+	}
+	if err = filter.tx.constraints.Validate(ctx, filter, view, query.Table.Object); err != nil {
+		return nil, replaceRecords, err
+	}
+	if filter.tx.auditEnabled() {
+		replacedRecords := make(RecordSet, 0, replaceRecords)
+		replacedPrevious := make([][]value.Primary, 0, replaceRecords)
+		for i := 0; i < recordLen; i++ {
+			if auditRecordReplaced(view.RecordSet[i], previousRecords[i]) {
+				replacedRecords = append(replacedRecords, view.RecordSet[i])
+				replacedPrevious = append(replacedPrevious, auditValues(view.Header, previousRecords[i]))
+			}
+		}
+		filter.tx.recordAudit(query, view, AuditUpdate, replacedRecords, replacedPrevious)
+		filter.tx.recordAudit(query, view, AuditInsert, view.RecordSet[recordLen:], nil)
 	}
 	view.Filter = nil
 
@@ -320,20 +555,69 @@ func Insert(ctx context.Context, parentFilter *Filter, query parser.InsertQuery)
 		err = filter.tx.cachedViews.Replace(view)
 	}
 
-	return view.FileInfo, insertRecords, err
+	return view.FileInfo, replaceRecords, err
 }
 
-func Update(ctx context.Context, parentFilter *Filter, query parser.UpdateQuery) ([]*FileInfo, []int, error) {
+// updateFieldViewName returns the name of the table that has the field to be updated.
+// Unqualified fields are searched for only in the tables to be updated, so that the fields having the same names
+// in the other tables of the from clause are not ambiguous.
+func updateFieldViewName(view *View, tables []parser.QueryExpression, field parser.QueryExpression) (string, error) {
+	fieldRef, ok := field.(parser.FieldReference)
+	if !ok || 0 < len(fieldRef.View.Literal) {
+		return view.FieldViewName(field)
+	}
+
+	name := ""
+	for _, v := range tables {
+		tableName := v.(parser.Table).Name()
+		ref := fieldRef
+		ref.View = tableName
+		if _, err := view.Header.Contains(ref); err != nil {
+			if _, ok := err.(*FieldNotExistError); ok {
+				continue
+			}
+			return "", err
+		}
+
+		if 0 < len(name) {
+			return "", NewFieldAmbiguousError(field)
+		}
+		name = tableName.Literal
+	}
+
+	if len(name) < 1 {
+		return view.FieldViewName(field)
+	}
+	return name, nil
+}
+
+func Update(ctx context.Context, parentFilter *Filter, query parser.UpdateQuery) ([]*FileInfo, []int, *View, error) {
 	filter := parentFilter.CreateNode()
+	// The statement is recorded in the audit log as written, before the tables are complemented.
+	stmt := query
 
 	if query.WithClause != nil {
 		if err := filter.LoadInlineTable(context.Background(), query.WithClause.(parser.WithClause)); err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
 	}
 
 	if query.FromClause == nil {
 		query.FromClause = parser.FromClause{Tables: query.Tables}
+	} else {
+		fromClause := query.FromClause.(parser.FromClause)
+		names := tableNames(fromClause.Tables)
+
+		tables := make([]parser.QueryExpression, 0, len(query.Tables)+len(fromClause.Tables))
+		for _, v := range query.Tables {
+			if !InStrSliceWithCaseInsensitive(v.(parser.Table).Name().Literal, names) {
+				tables = append(tables, v)
+			}
+		}
+		if 0 < len(tables) {
+			fromClause.Tables = append(tables, fromClause.Tables...)
+			query.FromClause = fromClause
+		}
 	}
 
 	view := NewView(parentFilter.tx)
@@ -341,24 +625,26 @@ func Update(ctx context.Context, parentFilter *Filter, query parser.UpdateQuery)
 	view.UseInternalId = true
 	err := view.Load(ctx, filter, query.FromClause.(parser.FromClause))
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	if query.WhereClause != nil {
 		if err := view.Where(ctx, query.WhereClause.(parser.WhereClause)); err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
 	}
 
 	viewsToUpdate := make(map[string]*View)
+	tablesToUpdate := make(map[string]parser.QueryExpression)
 	updatedCount := make(map[string]int)
 	for _, v := range query.Tables {
 		table := v.(parser.Table)
 		fpath, err := filter.aliases.Get(table.Name())
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
 		viewKey := strings.ToUpper(table.Name().Literal)
+		tablesToUpdate[viewKey] = table.Object
 
 		if filter.tempViews.Exists(fpath) {
 			viewsToUpdate[viewKey], _ = filter.tempViews.Get(parser.Identifier{Literal: fpath})
@@ -366,11 +652,16 @@ func Update(ctx context.Context, parentFilter *Filter, query parser.UpdateQuery)
 			viewsToUpdate[viewKey], _ = filter.tx.cachedViews.Get(parser.Identifier{Literal: fpath})
 		}
 		if err = viewsToUpdate[viewKey].Header.Update(table.Name().Literal, nil); err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
 	}
 
+	if query.ReturningClause != nil && 1 < len(viewsToUpdate) {
+		return nil, nil, nil, NewReturningMultipleTablesError(query.ReturningClause.(parser.ReturningClause))
+	}
+
 	updatesList := make(map[string]map[int][]int)
+	previousValues := make(map[string]map[int][]value.Primary)
 	filterForLoop := NewFilterForSequentialEvaluation(filter, view)
 	for i := range view.RecordSet {
 		filterForLoop.records[0].recordIndex = i
@@ -379,17 +670,17 @@ func Update(ctx context.Context, parentFilter *Filter, query parser.UpdateQuery)
 		for _, uset := range query.SetList {
 			val, err := filterForLoop.Evaluate(ctx, uset.Value)
 			if err != nil {
-				return nil, nil, err
+				return nil, nil, nil, err
 			}
 
-			viewref, err := view.FieldViewName(uset.Field)
+			viewref, err := updateFieldViewName(view, query.Tables, uset.Field)
 			if err != nil {
-				return nil, nil, err
+				return nil, nil, nil, err
 			}
 			viewref = strings.ToUpper(viewref)
 
 			if _, ok := viewsToUpdate[viewref]; !ok {
-				return nil, nil, NewUpdateFieldNotExistError(uset.Field)
+				return nil, nil, nil, NewUpdateFieldNotExistError(uset.Field)
 			}
 
 			var internalId int
@@ -398,7 +689,7 @@ func Update(ctx context.Context, parentFilter *Filter, query parser.UpdateQuery)
 			} else {
 				id, err := view.InternalRecordId(viewref, i)
 				if err != nil {
-					return nil, nil, NewUpdateValueAmbiguousError(uset.Field, uset.Value)
+					return nil, nil, nil, NewUpdateValueAmbiguousError(uset.Field, uset.Value)
 				}
 
 				internalId = id
@@ -412,27 +703,62 @@ func Update(ctx context.Context, parentFilter *Filter, query parser.UpdateQuery)
 			if _, ok := updatesList[viewref][internalId]; !ok {
 				updatesList[viewref][internalId] = []int{}
 				updatedCount[viewref]++
+
+				if filter.tx.auditEnabled() {
+					if _, ok := previousValues[viewref]; !ok {
+						previousValues[viewref] = make(map[int][]value.Primary)
+					}
+					previousValues[viewref][internalId] = auditValues(viewsToUpdate[viewref].Header, viewsToUpdate[viewref].RecordSet[internalId])
+				}
 			}
 			if InIntSlice(fieldIdx, updatesList[viewref][internalId]) {
-				return nil, nil, NewUpdateValueAmbiguousError(uset.Field, uset.Value)
+				return nil, nil, nil, NewUpdateValueAmbiguousError(uset.Field, uset.Value)
 			}
 			updatesList[viewref][internalId] = append(updatesList[viewref][internalId], fieldIdx)
 			viewsToUpdate[viewref].RecordSet[internalId][fieldIdx] = NewCell(val)
 		}
 	}
 
+	var returningView *View
 	fileInfos := make([]*FileInfo, 0)
 	updateRecords := make([]int, 0)
 	for k, v := range viewsToUpdate {
+		internalIds := make([]int, 0, len(updatesList[k]))
+		for id := range updatesList[k] {
+			internalIds = append(internalIds, id)
+		}
+		sort.Ints(internalIds)
+
+		records := make(RecordSet, len(internalIds))
+		for i, id := range internalIds {
+			records[i] = v.RecordSet[id]
+		}
+
+		if query.ReturningClause != nil {
+			if returningView, err = selectReturningFields(ctx, filter, query.ReturningClause.(parser.ReturningClause), v.Header, records); err != nil {
+				return nil, nil, nil, err
+			}
+		}
+
 		if err = v.RestoreHeaderReferences(); err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
+		}
+		if err = filter.tx.constraints.Validate(ctx, filter, v, tablesToUpdate[k]); err != nil {
+			return nil, nil, nil, err
+		}
+		if filter.tx.auditEnabled() {
+			previous := make([][]value.Primary, len(internalIds))
+			for i, id := range internalIds {
+				previous[i] = previousValues[k][id]
+			}
+			filter.tx.recordAudit(stmt, v, AuditUpdate, records, previous)
 		}
 
 		if v.FileInfo.IsTemporary {
 			filter.tempViews.Replace(v)
 		} else {
 			if err = filter.tx.cachedViews.Replace(v); err != nil {
-				return nil, nil, err
+				return nil, nil, nil, err
 			}
 		}
 
@@ -440,43 +766,79 @@ func Update(ctx context.Context, parentFilter *Filter, query parser.UpdateQuery)
 		updateRecords = append(updateRecords, updatedCount[k])
 	}
 
-	return fileInfos, updateRecords, nil
+	return fileInfos, updateRecords, returningView, nil
+}
+
+func isDeletableTable(expr parser.QueryExpression) bool {
+	table, ok := expr.(parser.Table)
+	if !ok {
+		return false
+	}
+	switch table.Object.(type) {
+	case parser.Identifier, parser.TableObject, parser.Stdin:
+		return true
+	}
+	return false
+}
+
+func tableNames(tables []parser.QueryExpression) []string {
+	names := make([]string, 0, len(tables))
+	for _, v := range tables {
+		switch t := v.(type) {
+		case parser.Parentheses:
+			names = append(names, tableNames([]parser.QueryExpression{t.Expr})...)
+		case parser.Table:
+			if join, ok := t.Object.(parser.Join); ok {
+				names = append(names, tableNames([]parser.QueryExpression{join.Table, join.JoinTable})...)
+			} else {
+				names = append(names, t.Name().Literal)
+			}
+		}
+	}
+	return names
 }
 
-func Delete(ctx context.Context, parentFilter *Filter, query parser.DeleteQuery) ([]*FileInfo, []int, error) {
+func Delete(ctx context.Context, parentFilter *Filter, query parser.DeleteQuery) ([]*FileInfo, []int, *View, error) {
 	filter := parentFilter.CreateNode()
+	// The statement is recorded in the audit log as written, before the tables are complemented.
+	stmt := query
 
 	if query.WithClause != nil {
 		if err := filter.LoadInlineTable(context.Background(), query.WithClause.(parser.WithClause)); err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
 	}
 
 	fromClause := query.FromClause
-	if query.Tables == nil {
-		if 1 < len(fromClause.Tables) {
-			return nil, nil, NewDeleteTableNotSpecifiedError(query)
+	if query.Using != nil {
+		for _, v := range fromClause.Tables {
+			if !isDeletableTable(v) {
+				return nil, nil, nil, NewDeleteTableNotSpecifiedError(query)
+			}
 		}
-		table := fromClause.Tables[0].(parser.Table)
-		switch table.Object.(type) {
-		case parser.Identifier, parser.TableObject, parser.Stdin:
-			query.Tables = fromClause.Tables
-		default:
-			return nil, nil, NewDeleteTableNotSpecifiedError(query)
+		query.Tables = fromClause.Tables
+
+		tables := make([]parser.QueryExpression, 0, len(fromClause.Tables)+len(query.Using))
+		tables = append(tables, fromClause.Tables...)
+		fromClause.Tables = append(tables, query.Using...)
+	} else if query.Tables == nil {
+		if 1 < len(fromClause.Tables) || !isDeletableTable(fromClause.Tables[0]) {
+			return nil, nil, nil, NewDeleteTableNotSpecifiedError(query)
 		}
+		query.Tables = fromClause.Tables
 	}
 
 	view := NewView(parentFilter.tx)
 	view.UseInternalId = true
 	view.ForUpdate = true
-	err := view.Load(ctx, filter, query.FromClause)
+	err := view.Load(ctx, filter, fromClause)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	if query.WhereClause != nil {
 		if err := view.Where(ctx, query.WhereClause.(parser.WhereClause)); err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
 	}
 
@@ -486,7 +848,7 @@ func Delete(ctx context.Context, parentFilter *Filter, query parser.DeleteQuery)
 		table := v.(parser.Table)
 		fpath, err := filter.aliases.Get(table.Name())
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
 
 		viewKey := strings.ToUpper(table.Name().Literal)
@@ -496,11 +858,15 @@ func Delete(ctx context.Context, parentFilter *Filter, query parser.DeleteQuery)
 			viewsToDelete[viewKey], _ = filter.tx.cachedViews.Get(parser.Identifier{Literal: fpath})
 		}
 		if err = viewsToDelete[viewKey].Header.Update(table.Name().Literal, nil); err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
 		deletedIndices[viewKey] = make(map[int]bool)
 	}
 
+	if query.ReturningClause != nil && 1 < len(viewsToDelete) {
+		return nil, nil, nil, NewReturningMultipleTablesError(query.ReturningClause.(parser.ReturningClause))
+	}
+
 	for i := range view.RecordSet {
 		for viewref := range viewsToDelete {
 			internalId, err := view.InternalRecordId(viewref, i)
@@ -513,26 +879,37 @@ func Delete(ctx context.Context, parentFilter *Filter, query parser.DeleteQuery)
 		}
 	}
 
+	var returningView *View
 	fileInfos := make([]*FileInfo, 0)
 	deletedCounts := make([]int, 0)
 	for k, v := range viewsToDelete {
 		records := make(RecordSet, 0, v.RecordLen()-len(deletedIndices[k]))
+		var deletedRecords RecordSet
 		for i, record := range v.RecordSet {
 			if !deletedIndices[k][i] {
 				records = append(records, record)
+			} else if query.ReturningClause != nil || filter.tx.auditEnabled() {
+				deletedRecords = append(deletedRecords, record)
+			}
+		}
+
+		if query.ReturningClause != nil {
+			if returningView, err = selectReturningFields(ctx, filter, query.ReturningClause.(parser.ReturningClause), v.Header, deletedRecords); err != nil {
+				return nil, nil, nil, err
 			}
 		}
 		v.RecordSet = records
 
 		if err = v.RestoreHeaderReferences(); err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
+		filter.tx.recordAudit(stmt, v, AuditDelete, deletedRecords, nil)
 
 		if v.FileInfo.IsTemporary {
 			filter.tempViews.Replace(v)
 		} else {
 			if err = filter.tx.cachedViews.Replace(v); err != nil {
-				return nil, nil, err
+				return nil, nil, nil, err
 			}
 		}
 
@@ -540,10 +917,215 @@ func Delete(ctx context.Context, parentFilter *Filter, query parser.DeleteQuery)
 		deletedCounts = append(deletedCounts, len(deletedIndices[k]))
 	}
 
-	return fileInfos, deletedCounts, nil
+	return fileInfos, deletedCounts, returningView, nil
+}
+
+func selectReturningFields(ctx context.Context, filter *Filter, clause parser.ReturningClause, header Header, records RecordSet) (*View, error) {
+	view := NewView(filter.tx)
+	view.Header = header.Copy()
+	view.RecordSet = records.Copy()
+	view.Filter = filter
+
+	selectClause := parser.SelectClause{
+		BaseExpr: clause.BaseExpr,
+		Select:   clause.Returning,
+		Fields:   clause.Fields,
+	}
+	if err := view.Select(ctx, selectClause); err != nil {
+		return nil, err
+	}
+	if err := view.Fix(ctx); err != nil {
+		return nil, err
+	}
+	return view, nil
+}
+
+func Merge(ctx context.Context, parentFilter *Filter, query parser.MergeQuery) (*FileInfo, int, int, int, error) {
+	filter := parentFilter.CreateNode()
+
+	var insertedCount, updatedCount, deletedCount int
+
+	if query.WithClause != nil {
+		if err := filter.LoadInlineTable(context.Background(), query.WithClause.(parser.WithClause)); err != nil {
+			return nil, insertedCount, updatedCount, deletedCount, err
+		}
+	}
+
+	fromClause := parser.FromClause{
+		Tables: []parser.QueryExpression{
+			parser.Table{
+				Object: parser.Join{
+					BaseExpr:  query.GetBaseExpr(),
+					Join:      "join",
+					Table:     query.Source,
+					JoinTable: query.Table,
+					JoinType:  parser.Token{Token: parser.OUTER, Literal: "outer"},
+					Direction: parser.Token{Token: parser.LEFT, Literal: "left"},
+					Condition: parser.JoinCondition{Literal: "on", On: query.Condition},
+				},
+			},
+		},
+	}
+
+	view := NewView(parentFilter.tx)
+	view.ForUpdate = true
+	view.UseInternalId = true
+	err := view.Load(ctx, filter, fromClause)
+	if err != nil {
+		return nil, insertedCount, updatedCount, deletedCount, err
+	}
+
+	fpath, err := filter.aliases.Get(query.Table.Name())
+	if err != nil {
+		return nil, insertedCount, updatedCount, deletedCount, err
+	}
+	viewref := query.Table.Name().Literal
+
+	var viewToMerge *View
+	if filter.tempViews.Exists(fpath) {
+		viewToMerge, _ = filter.tempViews.Get(parser.Identifier{Literal: fpath})
+	} else {
+		viewToMerge, _ = filter.tx.cachedViews.Get(parser.Identifier{Literal: fpath})
+	}
+	if err = viewToMerge.Header.Update(viewref, nil); err != nil {
+		return nil, insertedCount, updatedCount, deletedCount, err
+	}
+
+	recordLen := viewToMerge.RecordLen()
+	matchedIds := make(map[int]bool)
+	deletedIds := make(map[int]bool)
+	updatedIds := make([]int, 0)
+	previous := make([][]value.Primary, 0)
+	filterForLoop := NewFilterForSequentialEvaluation(filter, view)
+	for i := range view.RecordSet {
+		filterForLoop.records[0].recordIndex = i
+
+		internalId, err := view.InternalRecordId(viewref, i)
+		isMatched := err == nil
+		if isMatched {
+			if matchedIds[internalId] {
+				return nil, insertedCount, updatedCount, deletedCount, NewMergeRecordAmbiguousError(query)
+			}
+			matchedIds[internalId] = true
+		}
+
+		for _, when := range query.WhenList {
+			if when.NotMatched == isMatched {
+				continue
+			}
+
+			if when.Condition != nil {
+				p, err := filterForLoop.Evaluate(ctx, when.Condition)
+				if err != nil {
+					return nil, insertedCount, updatedCount, deletedCount, err
+				}
+				if p.Ternary() != ternary.TRUE {
+					continue
+				}
+			}
+
+			switch when.Operation.Token {
+			case parser.UPDATE:
+				if filter.tx.auditEnabled() {
+					updatedIds = append(updatedIds, internalId)
+					previous = append(previous, auditValues(viewToMerge.Header, viewToMerge.RecordSet[internalId]))
+				}
+
+				fieldIndices := make([]int, 0, len(when.SetList))
+				for _, uset := range when.SetList {
+					val, err := filterForLoop.Evaluate(ctx, uset.Value)
+					if err != nil {
+						return nil, insertedCount, updatedCount, deletedCount, err
+					}
+
+					fieldIdx, err := viewToMerge.FieldIndex(uset.Field)
+					if err != nil {
+						return nil, insertedCount, updatedCount, deletedCount, NewUpdateFieldNotExistError(uset.Field)
+					}
+					if InIntSlice(fieldIdx, fieldIndices) {
+						return nil, insertedCount, updatedCount, deletedCount, NewUpdateValueAmbiguousError(uset.Field, uset.Value)
+					}
+					fieldIndices = append(fieldIndices, fieldIdx)
+					viewToMerge.RecordSet[internalId][fieldIdx] = NewCell(val)
+				}
+				updatedCount++
+			case parser.DELETE:
+				deletedIds[internalId] = true
+			default:
+				rv := when.Values.(parser.RowValue)
+				values, err := filterForLoop.evalRowValue(ctx, rv)
+				if err != nil {
+					return nil, insertedCount, updatedCount, deletedCount, err
+				}
+
+				fields := when.Fields
+				if fields == nil {
+					fields = viewToMerge.Header.TableColumns()
+				}
+				if len(fields) != len(values) {
+					return nil, insertedCount, updatedCount, deletedCount, NewInsertRowValueLengthError(rv, len(fields))
+				}
+
+				if _, err = viewToMerge.insert(fields, [][]value.Primary{values}); err != nil {
+					return nil, insertedCount, updatedCount, deletedCount, err
+				}
+				insertedCount++
+			}
+			break
+		}
+	}
+
+	var updatedRecords RecordSet
+	var insertedRecords RecordSet
+	var deletedRecords RecordSet
+	if filter.tx.auditEnabled() {
+		updatedRecords = make(RecordSet, len(updatedIds))
+		for i, id := range updatedIds {
+			updatedRecords[i] = viewToMerge.RecordSet[id]
+		}
+		insertedRecords = viewToMerge.RecordSet[recordLen:]
+	}
+
+	if 0 < len(deletedIds) {
+		records := make(RecordSet, 0, viewToMerge.RecordLen()-len(deletedIds))
+		for i, record := range viewToMerge.RecordSet {
+			if !deletedIds[i] {
+				records = append(records, record)
+			} else if filter.tx.auditEnabled() {
+				deletedRecords = append(deletedRecords, record)
+			}
+		}
+		viewToMerge.RecordSet = records
+		deletedCount = len(deletedIds)
+	}
+
+	if err = viewToMerge.RestoreHeaderReferences(); err != nil {
+		return nil, insertedCount, updatedCount, deletedCount, err
+	}
+	if err = filter.tx.constraints.Validate(ctx, filter, viewToMerge, query.Table.Object); err != nil {
+		return nil, insertedCount, updatedCount, deletedCount, err
+	}
+	filter.tx.recordAudit(query, viewToMerge, AuditInsert, insertedRecords, nil)
+	filter.tx.recordAudit(query, viewToMerge, AuditUpdate, updatedRecords, previous)
+	filter.tx.recordAudit(query, viewToMerge, AuditDelete, deletedRecords, nil)
+
+	if viewToMerge.FileInfo.IsTemporary {
+		filter.tempViews.Replace(viewToMerge)
+	} else {
+		err = filter.tx.cachedViews.Replace(viewToMerge)
+	}
+
+	return viewToMerge.FileInfo, insertedCount, updatedCount, deletedCount, err
 }
 
 func CreateTable(ctx context.Context, parentFilter *Filter, query parser.CreateTable) (*FileInfo, error) {
+	if parentFilter.tx.Flags.ReadOnly {
+		return nil, NewFileReadOnlyError(query.Table)
+	}
+	if err := parentFilter.tx.checkFilePath(query.Table); err != nil {
+		return nil, err
+	}
+
 	filter := parentFilter.CreateNode()
 
 	var view *View
@@ -606,6 +1188,15 @@ func CreateTable(ctx context.Context, parentFilter *Filter, query parser.CreateT
 	view.FileInfo = fileInfo
 	view.ForUpdate = true
 
+	filter.tx.constraints.Set(fileInfo.Path, query.Constraints)
+	if err = filter.tx.constraints.Validate(ctx, filter, view, query.Table); err != nil {
+		filter.tx.constraints.Dispose(fileInfo.Path)
+		if e := filter.tx.FileContainer.Close(fileInfo.Handler); e != nil {
+			err = AppendCompositeError(err, e)
+		}
+		return nil, err
+	}
+
 	filter.tx.cachedViews.Set(view)
 
 	return view.FileInfo, nil
@@ -734,12 +1325,14 @@ func DropColumns(ctx context.Context, parentFilter *Filter, query parser.DropCol
 	}
 
 	dropIndices := make([]int, len(query.Columns))
+	dropColumns := make([]string, len(query.Columns))
 	for i, v := range query.Columns {
 		idx, err := view.FieldIndex(v)
 		if err != nil {
 			return nil, 0, err
 		}
 		dropIndices[i] = idx
+		dropColumns[i] = view.Header[idx].Column
 	}
 
 	view.selectFields = []int{}
@@ -756,7 +1349,11 @@ func DropColumns(ctx context.Context, parentFilter *Filter, query parser.DropCol
 	if view.FileInfo.IsTemporary {
 		filter.tempViews.Replace(view)
 	} else {
-		err = filter.tx.cachedViews.Replace(view)
+		if err = filter.tx.cachedViews.Replace(view); err == nil {
+			if err = filter.tx.constraints.DropColumns(view.FileInfo.Path, dropColumns); err != nil {
+				err = NewLoadConstraintsError(query.Table, view.FileInfo.Path, err.Error())
+			}
+		}
 	}
 
 	return view.FileInfo, len(dropIndices), err
@@ -783,13 +1380,18 @@ func RenameColumn(ctx context.Context, parentFilter *Filter, query parser.Rename
 		return nil, err
 	}
 
+	oldColumn := view.Header[idx].Column
 	view.Header[idx].Column = query.New.Literal
 	view.Filter = nil
 
 	if view.FileInfo.IsTemporary {
 		filter.tempViews.Replace(view)
 	} else {
-		err = filter.tx.cachedViews.Replace(view)
+		if err = filter.tx.cachedViews.Replace(view); err == nil {
+			if err = filter.tx.constraints.RenameColumn(view.FileInfo.Path, oldColumn, query.New); err != nil {
+				err = NewLoadConstraintsError(query.Table, view.FileInfo.Path, err.Error())
+			}
+		}
 	}
 
 	return view.FileInfo, err
@@ -841,7 +1443,7 @@ func SetTableAttribute(ctx context.Context, parentFilter *Filter, query parser.S
 		case TableJsonEscape:
 			err = fileInfo.SetJsonEscape(s.(value.String).Raw())
 		}
-	case TableHeader, TableEncloseAll, TablePrettyPrint:
+	case TableHeader, TableEncloseAll, TablePrettyPrint, TableEncrypted:
 		b := value.ToBoolean(p)
 		if value.IsNull(b) {
 			return nil, log, NewTableAttributeValueNotAllowedFormatError(query)
@@ -853,6 +1455,13 @@ func SetTableAttribute(ctx context.Context, parentFilter *Filter, query parser.S
 			err = fileInfo.SetEncloseAll(b.(value.Boolean).Raw())
 		case TablePrettyPrint:
 			err = fileInfo.SetPrettyPrint(b.(value.Boolean).Raw())
+		case TableEncrypted:
+			if b.(value.Boolean).Raw() {
+				if _, err = filter.tx.Flags.EncryptionKey(); err != nil {
+					break
+				}
+			}
+			err = fileInfo.SetEncrypted(b.(value.Boolean).Raw())
 		}
 	default:
 		return nil, log, NewInvalidTableAttributeNameError(query.Attribute)
@@ -350,6 +350,97 @@ var declareViewTests = []struct {
 		},
 		Error: "field name column1 is a duplicate",
 	},
+	{
+		Name: "Declare View With Primary Key",
+		Expr: parser.ViewDeclaration{
+			View: parser.Identifier{Literal: "tbl"},
+			Fields: []parser.QueryExpression{
+				parser.Identifier{Literal: "column1"},
+				parser.Identifier{Literal: "column2"},
+			},
+			PrimaryKey: []parser.QueryExpression{
+				parser.Identifier{Literal: "column1"},
+			},
+			Query: parser.SelectQuery{
+				SelectEntity: parser.SelectEntity{
+					SelectClause: parser.SelectClause{
+						Fields: []parser.QueryExpression{
+							parser.Field{Object: parser.NewIntegerValueFromString("1")},
+							parser.Field{Object: parser.NewIntegerValueFromString("2")},
+						},
+					},
+				},
+			},
+		},
+		Result: ViewMap{
+			"TBL": {
+				FileInfo: &FileInfo{
+					Path:          "tbl",
+					IsTemporary:   true,
+					InitialHeader: NewHeader("tbl", []string{"column1", "column2"}),
+					InitialRecordSet: RecordSet{
+						NewRecord([]value.Primary{
+							value.NewInteger(1),
+							value.NewInteger(2),
+						}),
+					},
+					PrimaryKey: NewViewIndex("tbl", "tbl", []string{"column1"}),
+				},
+				Header: NewHeader("tbl", []string{"column1", "column2"}),
+				RecordSet: RecordSet{
+					NewRecord([]value.Primary{
+						value.NewInteger(1),
+						value.NewInteger(2),
+					}),
+				},
+				Tx: TestTx,
+			},
+		},
+	},
+	{
+		Name: "Declare View Primary Key Field Not Exist Error",
+		Expr: parser.ViewDeclaration{
+			View: parser.Identifier{Literal: "tbl"},
+			Fields: []parser.QueryExpression{
+				parser.Identifier{Literal: "column1"},
+				parser.Identifier{Literal: "column2"},
+			},
+			PrimaryKey: []parser.QueryExpression{
+				parser.Identifier{Literal: "notexist"},
+			},
+		},
+		Error: "field notexist does not exist",
+	},
+	{
+		Name: "Declare View Primary Key Violation Error",
+		Expr: parser.ViewDeclaration{
+			View: parser.Identifier{Literal: "tbl"},
+			PrimaryKey: []parser.QueryExpression{
+				parser.Identifier{Literal: "column1"},
+			},
+			Query: parser.SelectQuery{
+				SelectEntity: parser.SelectSet{
+					LHS: parser.SelectEntity{
+						SelectClause: parser.SelectClause{
+							Fields: []parser.QueryExpression{
+								parser.Field{Object: parser.NewIntegerValueFromString("1"), Alias: parser.Identifier{Literal: "column1"}},
+							},
+						},
+					},
+					Operator: parser.Token{Token: parser.UNION, Literal: "union"},
+					All:      parser.Token{Token: parser.ALL, Literal: "all"},
+					RHS: parser.SelectEntity{
+						SelectClause: parser.SelectClause{
+							Fields: []parser.QueryExpression{
+								parser.Field{Object: parser.NewIntegerValueFromString("1")},
+							},
+						},
+					},
+				},
+			},
+		},
+		Error: "value 1 is duplicated in primary key of table tbl",
+	},
 	{
 		Name: "Declare View Redeclaration Error",
 		ViewMap: ViewMap{
@@ -885,6 +976,116 @@ var selectTests = []struct {
 			Tx: TestTx,
 		},
 	},
+	{
+		Name: "Inline Tables Not Materialized",
+		Query: parser.SelectQuery{
+			WithClause: parser.WithClause{
+				With: "with",
+				InlineTables: []parser.QueryExpression{
+					parser.InlineTable{
+						Name: parser.Identifier{Literal: "it"},
+						Fields: []parser.QueryExpression{
+							parser.Identifier{Literal: "c1"},
+						},
+						As:              "as",
+						Materialization: parser.Token{Token: parser.NOT, Literal: "not materialized"},
+						Query: parser.SelectQuery{
+							SelectEntity: parser.SelectEntity{
+								SelectClause: parser.SelectClause{
+									Select: "select",
+									Fields: []parser.QueryExpression{
+										parser.Field{Object: parser.NewIntegerValueFromString("2")},
+									},
+								},
+							},
+						},
+					},
+					parser.InlineTable{
+						Name: parser.Identifier{Literal: "it2"},
+						As:   "as",
+						Query: parser.SelectQuery{
+							SelectEntity: parser.SelectEntity{
+								SelectClause: parser.SelectClause{
+									Select: "select",
+									Fields: []parser.QueryExpression{
+										parser.Field{Object: parser.FieldReference{Column: parser.Identifier{Literal: "c1"}}},
+									},
+								},
+								FromClause: parser.FromClause{
+									Tables: []parser.QueryExpression{
+										parser.Table{Object: parser.Identifier{Literal: "it"}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			SelectEntity: parser.SelectEntity{
+				SelectClause: parser.SelectClause{
+					Fields: []parser.QueryExpression{
+						parser.Field{Object: parser.FieldReference{Column: parser.Identifier{Literal: "c1"}}},
+					},
+				},
+				FromClause: parser.FromClause{
+					Tables: []parser.QueryExpression{
+						parser.Table{Object: parser.Identifier{Literal: "it2"}},
+					},
+				},
+			},
+		},
+		Result: &View{
+			Header: NewHeader("it2", []string{"c1"}),
+			RecordSet: []Record{
+				NewRecord([]value.Primary{
+					value.NewInteger(2),
+				}),
+			},
+			Tx: TestTx,
+		},
+	},
+	{
+		Name: "Inline Tables Not Materialized Field Length Error",
+		Query: parser.SelectQuery{
+			WithClause: parser.WithClause{
+				With: "with",
+				InlineTables: []parser.QueryExpression{
+					parser.InlineTable{
+						Name: parser.Identifier{Literal: "it"},
+						Fields: []parser.QueryExpression{
+							parser.Identifier{Literal: "c1"},
+							parser.Identifier{Literal: "c2"},
+						},
+						As:              "as",
+						Materialization: parser.Token{Token: parser.NOT, Literal: "not materialized"},
+						Query: parser.SelectQuery{
+							SelectEntity: parser.SelectEntity{
+								SelectClause: parser.SelectClause{
+									Select: "select",
+									Fields: []parser.QueryExpression{
+										parser.Field{Object: parser.NewIntegerValueFromString("2")},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			SelectEntity: parser.SelectEntity{
+				SelectClause: parser.SelectClause{
+					Fields: []parser.QueryExpression{
+						parser.Field{Object: parser.FieldReference{Column: parser.Identifier{Literal: "c1"}}},
+					},
+				},
+				FromClause: parser.FromClause{
+					Tables: []parser.QueryExpression{
+						parser.Table{Object: parser.Identifier{Literal: "it"}},
+					},
+				},
+			},
+		},
+		Error: "select query should return exactly 2 fields for inline table it",
+	},
 	{
 		Name: "Inline Tables Field Length Error",
 		Query: parser.SelectQuery{
@@ -1038,7 +1239,7 @@ var selectTests = []struct {
 		},
 	},
 	{
-		Name: "Inline Tables Recursion Field Length Error",
+		Name: "Inline Tables Recursion with Cycle Clause",
 		Query: parser.SelectQuery{
 			WithClause: parser.WithClause{
 				With: "with",
@@ -1061,18 +1262,22 @@ var selectTests = []struct {
 									},
 								},
 								Operator: parser.Token{Token: parser.UNION, Literal: "union"},
+								All:      parser.Token{Token: parser.ALL, Literal: "all"},
 								RHS: parser.SelectEntity{
 									SelectClause: parser.SelectClause{
 										Select: "select",
 										Fields: []parser.QueryExpression{
 											parser.Field{
 												Object: parser.Arithmetic{
-													LHS:      parser.FieldReference{Column: parser.Identifier{Literal: "n"}},
+													LHS: parser.Arithmetic{
+														LHS:      parser.FieldReference{Column: parser.Identifier{Literal: "n"}},
+														RHS:      parser.NewIntegerValueFromString("3"),
+														Operator: '%',
+													},
 													RHS:      parser.NewIntegerValueFromString("1"),
 													Operator: '+',
 												},
 											},
-											parser.Field{Object: parser.NewIntegerValueFromString("2")},
 										},
 									},
 									FromClause: parser.FromClause{
@@ -1080,16 +1285,14 @@ var selectTests = []struct {
 											parser.Table{Object: parser.Identifier{Literal: "it"}},
 										},
 									},
-									WhereClause: parser.WhereClause{
-										Filter: parser.Comparison{
-											LHS:      parser.FieldReference{Column: parser.Identifier{Literal: "n"}},
-											RHS:      parser.NewIntegerValueFromString("3"),
-											Operator: "<",
-										},
-									},
 								},
 							},
 						},
+						CycleClause: parser.CycleClause{
+							Cycle:    "cycle",
+							Fields:   []parser.QueryExpression{parser.Identifier{Literal: "n"}},
+							Restrict: "restrict",
+						},
 					},
 				},
 			},
@@ -1106,67 +1309,224 @@ var selectTests = []struct {
 				},
 			},
 		},
-		Error: "result set to be combined should contain exactly 1 field",
+		Result: &View{
+			Header: []HeaderField{
+				{
+					View:        "it",
+					Column:      "n",
+					Number:      1,
+					IsFromTable: true,
+				},
+			},
+			RecordSet: []Record{
+				NewRecord([]value.Primary{
+					value.NewInteger(1),
+				}),
+				NewRecord([]value.Primary{
+					value.NewInteger(2),
+				}),
+				NewRecord([]value.Primary{
+					value.NewInteger(3),
+				}),
+			},
+			Tx: TestTx,
+		},
 	},
-}
-
-func TestSelect(t *testing.T) {
-	defer func() {
-		_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
-		initFlag(TestTx.Flags)
-	}()
-
-	TestTx.Flags.Repository = TestDir
-
-	filter := NewFilter(TestTx)
-
-	for _, v := range selectTests {
-		_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
-		result, err := Select(context.Background(), filter, v.Query)
-		if err != nil {
-			if len(v.Error) < 1 {
-				t.Errorf("%s: unexpected error %q", v.Name, err)
-			} else if err.Error() != v.Error {
-				t.Errorf("%s: error %q, want error %q", v.Name, err.Error(), v.Error)
-			}
-			continue
-		}
-		if 0 < len(v.Error) {
-			t.Errorf("%s: no error, want error %q", v.Name, v.Error)
-			continue
-		}
-		if !reflect.DeepEqual(result, v.Result) {
-			t.Errorf("%s: result = %v, want %v", v.Name, result, v.Result)
-		}
-	}
-}
-
-var insertTests = []struct {
-	Name         string
-	Query        parser.InsertQuery
-	ResultFile   *FileInfo
-	UpdateCount  int
-	ViewCache    ViewMap
-	TempViewList TemporaryViewScopes
-	Error        string
-}{
 	{
-		Name: "Insert Query",
-		Query: parser.InsertQuery{
+		Name: "Inline Tables Recursion Exceeded Limit Error",
+		Query: parser.SelectQuery{
 			WithClause: parser.WithClause{
 				With: "with",
 				InlineTables: []parser.QueryExpression{
 					parser.InlineTable{
-						Name: parser.Identifier{Literal: "it"},
+						Recursive: parser.Token{Token: parser.RECURSIVE, Literal: "recursive"},
+						Name:      parser.Identifier{Literal: "it"},
 						Fields: []parser.QueryExpression{
-							parser.Identifier{Literal: "c1"},
+							parser.Identifier{Literal: "n"},
 						},
 						As: "as",
 						Query: parser.SelectQuery{
-							SelectEntity: parser.SelectEntity{
-								SelectClause: parser.SelectClause{
-									Select: "select",
-									Fields: []parser.QueryExpression{
+							SelectEntity: parser.SelectSet{
+								LHS: parser.SelectEntity{
+									SelectClause: parser.SelectClause{
+										Select: "select",
+										Fields: []parser.QueryExpression{
+											parser.Field{Object: parser.NewIntegerValueFromString("1")},
+										},
+									},
+								},
+								Operator: parser.Token{Token: parser.UNION, Literal: "union"},
+								All:      parser.Token{Token: parser.ALL, Literal: "all"},
+								RHS: parser.SelectEntity{
+									SelectClause: parser.SelectClause{
+										Select: "select",
+										Fields: []parser.QueryExpression{
+											parser.Field{
+												Object: parser.Arithmetic{
+													LHS:      parser.FieldReference{Column: parser.Identifier{Literal: "n"}},
+													RHS:      parser.NewIntegerValueFromString("1"),
+													Operator: '+',
+												},
+											},
+										},
+									},
+									FromClause: parser.FromClause{
+										Tables: []parser.QueryExpression{
+											parser.Table{Object: parser.Identifier{Literal: "it"}},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			SelectEntity: parser.SelectEntity{
+				SelectClause: parser.SelectClause{
+					Fields: []parser.QueryExpression{
+						parser.Field{Object: parser.FieldReference{Column: parser.Identifier{Literal: "n"}}},
+					},
+				},
+				FromClause: parser.FromClause{
+					Tables: []parser.QueryExpression{
+						parser.Table{Object: parser.Identifier{Literal: "it"}},
+					},
+				},
+			},
+		},
+		Error: "iteration of recursive query it exceeded the limit 1000",
+	},
+	{
+		Name: "Inline Tables Recursion Field Length Error",
+		Query: parser.SelectQuery{
+			WithClause: parser.WithClause{
+				With: "with",
+				InlineTables: []parser.QueryExpression{
+					parser.InlineTable{
+						Recursive: parser.Token{Token: parser.RECURSIVE, Literal: "recursive"},
+						Name:      parser.Identifier{Literal: "it"},
+						Fields: []parser.QueryExpression{
+							parser.Identifier{Literal: "n"},
+						},
+						As: "as",
+						Query: parser.SelectQuery{
+							SelectEntity: parser.SelectSet{
+								LHS: parser.SelectEntity{
+									SelectClause: parser.SelectClause{
+										Select: "select",
+										Fields: []parser.QueryExpression{
+											parser.Field{Object: parser.NewIntegerValueFromString("1")},
+										},
+									},
+								},
+								Operator: parser.Token{Token: parser.UNION, Literal: "union"},
+								RHS: parser.SelectEntity{
+									SelectClause: parser.SelectClause{
+										Select: "select",
+										Fields: []parser.QueryExpression{
+											parser.Field{
+												Object: parser.Arithmetic{
+													LHS:      parser.FieldReference{Column: parser.Identifier{Literal: "n"}},
+													RHS:      parser.NewIntegerValueFromString("1"),
+													Operator: '+',
+												},
+											},
+											parser.Field{Object: parser.NewIntegerValueFromString("2")},
+										},
+									},
+									FromClause: parser.FromClause{
+										Tables: []parser.QueryExpression{
+											parser.Table{Object: parser.Identifier{Literal: "it"}},
+										},
+									},
+									WhereClause: parser.WhereClause{
+										Filter: parser.Comparison{
+											LHS:      parser.FieldReference{Column: parser.Identifier{Literal: "n"}},
+											RHS:      parser.NewIntegerValueFromString("3"),
+											Operator: "<",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			SelectEntity: parser.SelectEntity{
+				SelectClause: parser.SelectClause{
+					Fields: []parser.QueryExpression{
+						parser.Field{Object: parser.FieldReference{Column: parser.Identifier{Literal: "n"}}},
+					},
+				},
+				FromClause: parser.FromClause{
+					Tables: []parser.QueryExpression{
+						parser.Table{Object: parser.Identifier{Literal: "it"}},
+					},
+				},
+			},
+		},
+		Error: "result set to be combined should contain exactly 1 field",
+	},
+}
+
+func TestSelect(t *testing.T) {
+	defer func() {
+		_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+		initFlag(TestTx.Flags)
+	}()
+
+	TestTx.Flags.Repository = TestDir
+
+	filter := NewFilter(TestTx)
+
+	for _, v := range selectTests {
+		_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+		result, err := Select(context.Background(), filter, v.Query)
+		if err != nil {
+			if len(v.Error) < 1 {
+				t.Errorf("%s: unexpected error %q", v.Name, err)
+			} else if err.Error() != v.Error {
+				t.Errorf("%s: error %q, want error %q", v.Name, err.Error(), v.Error)
+			}
+			continue
+		}
+		if 0 < len(v.Error) {
+			t.Errorf("%s: no error, want error %q", v.Name, v.Error)
+			continue
+		}
+		if !reflect.DeepEqual(result, v.Result) {
+			t.Errorf("%s: result = %v, want %v", v.Name, result, v.Result)
+		}
+	}
+}
+
+var insertTests = []struct {
+	Name         string
+	Query        parser.InsertQuery
+	ResultFile   *FileInfo
+	UpdateCount  int
+	Returning    *View
+	ViewCache    ViewMap
+	TempViewList TemporaryViewScopes
+	Error        string
+}{
+	{
+		Name: "Insert Query",
+		Query: parser.InsertQuery{
+			WithClause: parser.WithClause{
+				With: "with",
+				InlineTables: []parser.QueryExpression{
+					parser.InlineTable{
+						Name: parser.Identifier{Literal: "it"},
+						Fields: []parser.QueryExpression{
+							parser.Identifier{Literal: "c1"},
+						},
+						As: "as",
+						Query: parser.SelectQuery{
+							SelectEntity: parser.SelectEntity{
+								SelectClause: parser.SelectClause{
+									Select: "select",
+									Fields: []parser.QueryExpression{
 										parser.Field{Object: parser.NewIntegerValueFromString("2")},
 									},
 								},
@@ -1248,8 +1608,445 @@ var insertTests = []struct {
 						value.NewNull(),
 					}),
 					NewRecord([]value.Primary{
-						value.NewInteger(2),
-						value.NewNull(),
+						value.NewInteger(2),
+						value.NewNull(),
+					}),
+				},
+				ForUpdate: true,
+				Tx:        TestTx,
+			},
+		},
+	},
+	{
+		Name: "Insert Query For Temporary View",
+		Query: parser.InsertQuery{
+			Table: parser.Table{Object: parser.Identifier{Literal: "tmpview"}, Alias: parser.Identifier{Literal: "t"}},
+			Fields: []parser.QueryExpression{
+				parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+			},
+			ValuesList: []parser.QueryExpression{
+				parser.RowValue{
+					Value: parser.ValueList{
+						Values: []parser.QueryExpression{
+							parser.NewIntegerValueFromString("4"),
+						},
+					},
+				},
+				parser.RowValue{
+					Value: parser.ValueList{
+						Values: []parser.QueryExpression{
+							parser.NewIntegerValueFromString("2"),
+						},
+					},
+				},
+			},
+		},
+		ResultFile: &FileInfo{
+			Path:        "tmpview",
+			Delimiter:   ',',
+			IsTemporary: true,
+		},
+		UpdateCount: 2,
+		TempViewList: TemporaryViewScopes{
+			ViewMap{
+				"TMPVIEW": &View{
+					Header: NewHeader("tmpview", []string{"column1", "column2"}),
+					RecordSet: []Record{
+						NewRecord([]value.Primary{
+							value.NewString("1"),
+							value.NewString("str1"),
+						}),
+						NewRecord([]value.Primary{
+							value.NewString("2"),
+							value.NewString("str2"),
+						}),
+						NewRecord([]value.Primary{
+							value.NewInteger(4),
+							value.NewNull(),
+						}),
+						NewRecord([]value.Primary{
+							value.NewInteger(2),
+							value.NewNull(),
+						}),
+					},
+					FileInfo: &FileInfo{
+						Path:        "tmpview",
+						Delimiter:   ',',
+						IsTemporary: true,
+					},
+					ForUpdate: true,
+					Tx:        TestTx,
+				},
+			},
+		},
+	},
+	{
+		Name: "Insert Query All Fields",
+		Query: parser.InsertQuery{
+			Table: parser.Table{Object: parser.Identifier{Literal: "table1"}},
+			ValuesList: []parser.QueryExpression{
+				parser.RowValue{
+					Value: parser.ValueList{
+						Values: []parser.QueryExpression{
+							parser.NewIntegerValueFromString("4"),
+							parser.NewStringValue("str4"),
+						},
+					},
+				},
+				parser.RowValue{
+					Value: parser.ValueList{
+						Values: []parser.QueryExpression{
+							parser.NewIntegerValueFromString("5"),
+							parser.NewStringValue("str5"),
+						},
+					},
+				},
+			},
+		},
+		ResultFile: &FileInfo{
+			Path:      GetTestFilePath("table1.csv"),
+			Delimiter: ',',
+			NoHeader:  false,
+			Encoding:  text.UTF8,
+			LineBreak: text.LF,
+		},
+		UpdateCount: 2,
+	},
+	{
+		Name: "Insert Query File Does Not Exist Error",
+		Query: parser.InsertQuery{
+			Table: parser.Table{Object: parser.Identifier{Literal: "notexist"}},
+			Fields: []parser.QueryExpression{
+				parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+			},
+			ValuesList: []parser.QueryExpression{
+				parser.RowValue{
+					Value: parser.ValueList{
+						Values: []parser.QueryExpression{
+							parser.NewIntegerValueFromString("4"),
+						},
+					},
+				},
+				parser.RowValue{
+					Value: parser.ValueList{
+						Values: []parser.QueryExpression{
+							parser.NewIntegerValueFromString("5"),
+						},
+					},
+				},
+			},
+		},
+		Error: "file notexist does not exist",
+	},
+	{
+		Name: "Insert Query Field Does Not Exist Error",
+		Query: parser.InsertQuery{
+			Table: parser.Table{Object: parser.Identifier{Literal: "table1"}},
+			Fields: []parser.QueryExpression{
+				parser.FieldReference{Column: parser.Identifier{Literal: "notexist"}},
+			},
+			ValuesList: []parser.QueryExpression{
+				parser.RowValue{
+					Value: parser.ValueList{
+						Values: []parser.QueryExpression{
+							parser.NewIntegerValueFromString("4"),
+						},
+					},
+				},
+				parser.RowValue{
+					Value: parser.ValueList{
+						Values: []parser.QueryExpression{
+							parser.NewIntegerValueFromString("5"),
+						},
+					},
+				},
+			},
+		},
+		Error: "field notexist does not exist",
+	},
+	{
+		Name: "Insert Select Query",
+		Query: parser.InsertQuery{
+			Table: parser.Table{Object: parser.Identifier{Literal: "table1"}},
+			Fields: []parser.QueryExpression{
+				parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+				parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+			},
+			Query: parser.SelectQuery{
+				SelectEntity: parser.SelectEntity{
+					SelectClause: parser.SelectClause{
+						Fields: []parser.QueryExpression{
+							parser.Field{Object: parser.FieldReference{Column: parser.Identifier{Literal: "column3"}}},
+							parser.Field{Object: parser.FieldReference{Column: parser.Identifier{Literal: "column4"}}},
+						},
+					},
+					FromClause: parser.FromClause{
+						Tables: []parser.QueryExpression{
+							parser.Table{Object: parser.Identifier{Literal: "table2"}},
+						},
+					},
+				},
+			},
+		},
+		ResultFile: &FileInfo{
+			Path:      GetTestFilePath("table1.csv"),
+			Delimiter: ',',
+			NoHeader:  false,
+			Encoding:  text.UTF8,
+			LineBreak: text.LF,
+		},
+		UpdateCount: 3,
+	},
+	{
+		Name: "Insert Select Query Field Does Not Exist Error",
+		Query: parser.InsertQuery{
+			Table: parser.Table{Object: parser.Identifier{Literal: "table1"}},
+			Fields: []parser.QueryExpression{
+				parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+			},
+			Query: parser.SelectQuery{
+				SelectEntity: parser.SelectEntity{
+					SelectClause: parser.SelectClause{
+						Fields: []parser.QueryExpression{
+							parser.Field{Object: parser.FieldReference{Column: parser.Identifier{Literal: "column3"}}},
+							parser.Field{Object: parser.FieldReference{Column: parser.Identifier{Literal: "column4"}}},
+						},
+					},
+					FromClause: parser.FromClause{
+						Tables: []parser.QueryExpression{
+							parser.Table{Object: parser.Identifier{Literal: "table2"}},
+						},
+					},
+				},
+			},
+		},
+		Error: "select query should return exactly 1 field",
+	},
+	{
+		Name: "Insert Query With Returning Clause",
+		Query: parser.InsertQuery{
+			Table: parser.Table{Object: parser.Identifier{Literal: "table1"}},
+			Fields: []parser.QueryExpression{
+				parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+			},
+			ValuesList: []parser.QueryExpression{
+				parser.RowValue{
+					Value: parser.ValueList{
+						Values: []parser.QueryExpression{
+							parser.NewIntegerValueFromString("4"),
+						},
+					},
+				},
+			},
+			ReturningClause: parser.ReturningClause{
+				Returning: "returning",
+				Fields: []parser.QueryExpression{
+					parser.Field{Object: parser.FieldReference{Column: parser.Identifier{Literal: "column1"}}},
+					parser.Field{Object: parser.FieldReference{Column: parser.Identifier{Literal: "column2"}}, As: "as", Alias: parser.Identifier{Literal: "c2"}},
+				},
+			},
+		},
+		ResultFile: &FileInfo{
+			Path:      GetTestFilePath("table1.csv"),
+			Delimiter: ',',
+			NoHeader:  false,
+			Encoding:  text.UTF8,
+			LineBreak: text.LF,
+		},
+		UpdateCount: 1,
+		Returning: &View{
+			Header: []HeaderField{
+				{
+					View:        "table1",
+					Column:      "column1",
+					Number:      1,
+					IsFromTable: true,
+				},
+				{
+					View:        "table1",
+					Column:      "c2",
+					Number:      2,
+					IsFromTable: true,
+				},
+			},
+			RecordSet: []Record{
+				NewRecord([]value.Primary{
+					value.NewInteger(4),
+					value.NewNull(),
+				}),
+			},
+			Tx: TestTx,
+		},
+	},
+}
+
+func TestInsert(t *testing.T) {
+	defer func() {
+		_ = TestTx.ReleaseResources()
+		_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+		initFlag(TestTx.Flags)
+	}()
+
+	TestTx.Flags.Repository = TestDir
+	TestTx.Flags.Quiet = false
+
+	filter := NewFilter(TestTx)
+	filter.tempViews = TemporaryViewScopes{
+		ViewMap{
+			"TMPVIEW": &View{
+				Header: NewHeader("tmpview", []string{"column1", "column2"}),
+				RecordSet: []Record{
+					NewRecord([]value.Primary{
+						value.NewString("1"),
+						value.NewString("str1"),
+					}),
+					NewRecord([]value.Primary{
+						value.NewString("2"),
+						value.NewString("str2"),
+					}),
+				},
+				FileInfo: &FileInfo{
+					Path:        "tmpview",
+					Delimiter:   ',',
+					IsTemporary: true,
+				},
+				Tx: TestTx,
+			},
+		},
+	}
+
+	for _, v := range insertTests {
+		_ = TestTx.ReleaseResources()
+		result, cnt, returning, err := Insert(context.Background(), filter, v.Query)
+		if err != nil {
+			if len(v.Error) < 1 {
+				t.Errorf("%s: unexpected error %q", v.Name, err)
+			} else if err.Error() != v.Error {
+				t.Errorf("%s: error %q, want error %q", v.Name, err.Error(), v.Error)
+			}
+			continue
+		}
+		if 0 < len(v.Error) {
+			t.Errorf("%s: no error, want error %q", v.Name, v.Error)
+			continue
+		}
+
+		for _, v2 := range TestTx.cachedViews {
+			if v2.FileInfo.Handler != nil {
+				if v2.FileInfo.Path != v2.FileInfo.Handler.Path() {
+					t.Errorf("file pointer = %q, want %q for %q", v2.FileInfo.Handler.Path(), v2.FileInfo.Path, v.Name)
+				}
+				_ = TestTx.FileContainer.Close(v2.FileInfo.Handler)
+				v2.FileInfo.Handler = nil
+			}
+		}
+
+		if !reflect.DeepEqual(result, v.ResultFile) {
+			t.Errorf("%s: fileinfo = %v, want %v", v.Name, result, v.ResultFile)
+		}
+
+		if !reflect.DeepEqual(cnt, v.UpdateCount) {
+			t.Errorf("%s: update count = %d, want %d", v.Name, cnt, v.UpdateCount)
+		}
+
+		if !reflect.DeepEqual(returning, v.Returning) {
+			t.Errorf("%s: returning = %v, want %v", v.Name, returning, v.Returning)
+		}
+
+		if v.ViewCache != nil {
+			if !reflect.DeepEqual(TestTx.cachedViews, v.ViewCache) {
+				t.Errorf("%s: view cache = %v, want %v", v.Name, TestTx.cachedViews, v.ViewCache)
+			}
+		}
+		if v.TempViewList != nil {
+			if !reflect.DeepEqual(filter.tempViews, v.TempViewList) {
+				t.Errorf("%s: temporary views list = %v, want %v", v.Name, filter.tempViews, v.TempViewList)
+			}
+		}
+	}
+}
+
+var replaceIntoTests = []struct {
+	Name         string
+	Query        parser.ReplaceQuery
+	ResultFile   *FileInfo
+	UpdateCount  int
+	ViewCache    ViewMap
+	TempViewList TemporaryViewScopes
+	Error        string
+}{
+	{
+		Name: "Replace Query",
+		Query: parser.ReplaceQuery{
+			Table: parser.Table{Object: parser.Identifier{Literal: "table1"}},
+			Fields: []parser.QueryExpression{
+				parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+				parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+			},
+			Keys: []parser.QueryExpression{
+				parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+			},
+			ValuesList: []parser.QueryExpression{
+				parser.RowValue{
+					Value: parser.ValueList{
+						Values: []parser.QueryExpression{
+							parser.NewIntegerValueFromString("2"),
+							parser.NewStringValue("updated"),
+						},
+					},
+				},
+				parser.RowValue{
+					Value: parser.ValueList{
+						Values: []parser.QueryExpression{
+							parser.NewIntegerValueFromString("4"),
+							parser.NewStringValue("str4"),
+						},
+					},
+				},
+				parser.RowValue{
+					Value: parser.ValueList{
+						Values: []parser.QueryExpression{
+							parser.NewIntegerValueFromString("4"),
+							parser.NewStringValue("str44"),
+						},
+					},
+				},
+			},
+		},
+		ResultFile: &FileInfo{
+			Path:      GetTestFilePath("table1.csv"),
+			Delimiter: ',',
+			NoHeader:  false,
+			Encoding:  text.UTF8,
+			LineBreak: text.LF,
+		},
+		UpdateCount: 3,
+		ViewCache: ViewMap{
+			strings.ToUpper(GetTestFilePath("table1.csv")): &View{
+				FileInfo: &FileInfo{
+					Path:      GetTestFilePath("table1.csv"),
+					Delimiter: ',',
+					NoHeader:  false,
+					Encoding:  text.UTF8,
+					LineBreak: text.LF,
+				},
+				Header: NewHeader("table1", []string{"column1", "column2"}),
+				RecordSet: []Record{
+					NewRecord([]value.Primary{
+						value.NewString("1"),
+						value.NewString("str1"),
+					}),
+					NewRecord([]value.Primary{
+						value.NewInteger(2),
+						value.NewString("updated"),
+					}),
+					NewRecord([]value.Primary{
+						value.NewString("3"),
+						value.NewString("str3"),
+					}),
+					NewRecord([]value.Primary{
+						value.NewInteger(4),
+						value.NewString("str44"),
 					}),
 				},
 				ForUpdate: true,
@@ -1258,24 +2055,22 @@ var insertTests = []struct {
 		},
 	},
 	{
-		Name: "Insert Query For Temporary View",
-		Query: parser.InsertQuery{
-			Table: parser.Table{Object: parser.Identifier{Literal: "tmpview"}, Alias: parser.Identifier{Literal: "t"}},
+		Name: "Replace Query For Temporary View",
+		Query: parser.ReplaceQuery{
+			Table: parser.Table{Object: parser.Identifier{Literal: "tmpview"}},
 			Fields: []parser.QueryExpression{
 				parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+				parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+			},
+			Keys: []parser.QueryExpression{
+				parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
 			},
 			ValuesList: []parser.QueryExpression{
 				parser.RowValue{
 					Value: parser.ValueList{
 						Values: []parser.QueryExpression{
-							parser.NewIntegerValueFromString("4"),
-						},
-					},
-				},
-				parser.RowValue{
-					Value: parser.ValueList{
-						Values: []parser.QueryExpression{
-							parser.NewIntegerValueFromString("2"),
+							parser.NewIntegerValueFromString("5"),
+							parser.NewStringValue("str1"),
 						},
 					},
 				},
@@ -1286,28 +2081,20 @@ var insertTests = []struct {
 			Delimiter:   ',',
 			IsTemporary: true,
 		},
-		UpdateCount: 2,
+		UpdateCount: 1,
 		TempViewList: TemporaryViewScopes{
 			ViewMap{
 				"TMPVIEW": &View{
 					Header: NewHeader("tmpview", []string{"column1", "column2"}),
 					RecordSet: []Record{
 						NewRecord([]value.Primary{
-							value.NewString("1"),
+							value.NewInteger(5),
 							value.NewString("str1"),
 						}),
 						NewRecord([]value.Primary{
 							value.NewString("2"),
 							value.NewString("str2"),
 						}),
-						NewRecord([]value.Primary{
-							value.NewInteger(4),
-							value.NewNull(),
-						}),
-						NewRecord([]value.Primary{
-							value.NewInteger(2),
-							value.NewNull(),
-						}),
 					},
 					FileInfo: &FileInfo{
 						Path:        "tmpview",
@@ -1321,44 +2108,15 @@ var insertTests = []struct {
 		},
 	},
 	{
-		Name: "Insert Query All Fields",
-		Query: parser.InsertQuery{
+		Name: "Replace Query Key Is Not Set Error",
+		Query: parser.ReplaceQuery{
 			Table: parser.Table{Object: parser.Identifier{Literal: "table1"}},
-			ValuesList: []parser.QueryExpression{
-				parser.RowValue{
-					Value: parser.ValueList{
-						Values: []parser.QueryExpression{
-							parser.NewIntegerValueFromString("4"),
-							parser.NewStringValue("str4"),
-						},
-					},
-				},
-				parser.RowValue{
-					Value: parser.ValueList{
-						Values: []parser.QueryExpression{
-							parser.NewIntegerValueFromString("5"),
-							parser.NewStringValue("str5"),
-						},
-					},
-				},
-			},
-		},
-		ResultFile: &FileInfo{
-			Path:      GetTestFilePath("table1.csv"),
-			Delimiter: ',',
-			NoHeader:  false,
-			Encoding:  text.UTF8,
-			LineBreak: text.LF,
-		},
-		UpdateCount: 2,
-	},
-	{
-		Name: "Insert Query File Does Not Exist Error",
-		Query: parser.InsertQuery{
-			Table: parser.Table{Object: parser.Identifier{Literal: "notexist"}},
 			Fields: []parser.QueryExpression{
 				parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
 			},
+			Keys: []parser.QueryExpression{
+				parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+			},
 			ValuesList: []parser.QueryExpression{
 				parser.RowValue{
 					Value: parser.ValueList{
@@ -1367,22 +2125,15 @@ var insertTests = []struct {
 						},
 					},
 				},
-				parser.RowValue{
-					Value: parser.ValueList{
-						Values: []parser.QueryExpression{
-							parser.NewIntegerValueFromString("5"),
-						},
-					},
-				},
 			},
 		},
-		Error: "file notexist does not exist",
+		Error: "replace key column2 is not set",
 	},
 	{
-		Name: "Insert Query Field Does Not Exist Error",
-		Query: parser.InsertQuery{
+		Name: "Replace Query Key Does Not Exist Error",
+		Query: parser.ReplaceQuery{
 			Table: parser.Table{Object: parser.Identifier{Literal: "table1"}},
-			Fields: []parser.QueryExpression{
+			Keys: []parser.QueryExpression{
 				parser.FieldReference{Column: parser.Identifier{Literal: "notexist"}},
 			},
 			ValuesList: []parser.QueryExpression{
@@ -1390,13 +2141,7 @@ var insertTests = []struct {
 					Value: parser.ValueList{
 						Values: []parser.QueryExpression{
 							parser.NewIntegerValueFromString("4"),
-						},
-					},
-				},
-				parser.RowValue{
-					Value: parser.ValueList{
-						Values: []parser.QueryExpression{
-							parser.NewIntegerValueFromString("5"),
+							parser.NewStringValue("str4"),
 						},
 					},
 				},
@@ -1405,13 +2150,16 @@ var insertTests = []struct {
 		Error: "field notexist does not exist",
 	},
 	{
-		Name: "Insert Select Query",
-		Query: parser.InsertQuery{
+		Name: "Replace Select Query",
+		Query: parser.ReplaceQuery{
 			Table: parser.Table{Object: parser.Identifier{Literal: "table1"}},
 			Fields: []parser.QueryExpression{
 				parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
 				parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
 			},
+			Keys: []parser.QueryExpression{
+				parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+			},
 			Query: parser.SelectQuery{
 				SelectEntity: parser.SelectEntity{
 					SelectClause: parser.SelectClause{
@@ -1437,34 +2185,9 @@ var insertTests = []struct {
 		},
 		UpdateCount: 3,
 	},
-	{
-		Name: "Insert Select Query Field Does Not Exist Error",
-		Query: parser.InsertQuery{
-			Table: parser.Table{Object: parser.Identifier{Literal: "table1"}},
-			Fields: []parser.QueryExpression{
-				parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
-			},
-			Query: parser.SelectQuery{
-				SelectEntity: parser.SelectEntity{
-					SelectClause: parser.SelectClause{
-						Fields: []parser.QueryExpression{
-							parser.Field{Object: parser.FieldReference{Column: parser.Identifier{Literal: "column3"}}},
-							parser.Field{Object: parser.FieldReference{Column: parser.Identifier{Literal: "column4"}}},
-						},
-					},
-					FromClause: parser.FromClause{
-						Tables: []parser.QueryExpression{
-							parser.Table{Object: parser.Identifier{Literal: "table2"}},
-						},
-					},
-				},
-			},
-		},
-		Error: "select query should return exactly 1 field",
-	},
 }
 
-func TestInsert(t *testing.T) {
+func TestReplaceInto(t *testing.T) {
 	defer func() {
 		_ = TestTx.ReleaseResources()
 		_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
@@ -1499,9 +2222,9 @@ func TestInsert(t *testing.T) {
 		},
 	}
 
-	for _, v := range insertTests {
+	for _, v := range replaceIntoTests {
 		_ = TestTx.ReleaseResources()
-		result, cnt, err := Insert(context.Background(), filter, v.Query)
+		result, cnt, err := ReplaceInto(context.Background(), filter, v.Query)
 		if err != nil {
 			if len(v.Error) < 1 {
 				t.Errorf("%s: unexpected error %q", v.Name, err)
@@ -1551,6 +2274,7 @@ var updateTests = []struct {
 	Query        parser.UpdateQuery
 	ResultFiles  []*FileInfo
 	UpdateCounts []int
+	Returning    *View
 	ViewCache    ViewMap
 	TempViewList TemporaryViewScopes
 	Error        string
@@ -1744,6 +2468,110 @@ var updateTests = []struct {
 		},
 		UpdateCounts: []int{2},
 	},
+	{
+		Name: "Update Query Joined From Other Tables",
+		Query: parser.UpdateQuery{
+			Tables: []parser.QueryExpression{
+				parser.Table{Object: parser.Identifier{Literal: "table1"}},
+			},
+			SetList: []parser.UpdateSet{
+				{
+					Field: parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+					Value: parser.FieldReference{View: parser.Identifier{Literal: "t2"}, Column: parser.Identifier{Literal: "column4"}},
+				},
+			},
+			FromClause: parser.FromClause{
+				Tables: []parser.QueryExpression{
+					parser.Table{
+						Object: parser.Identifier{Literal: "table2"},
+						Alias:  parser.Identifier{Literal: "t2"},
+					},
+				},
+			},
+			WhereClause: parser.WhereClause{
+				Filter: parser.Comparison{
+					LHS:      parser.FieldReference{View: parser.Identifier{Literal: "table1"}, Column: parser.Identifier{Literal: "column1"}},
+					RHS:      parser.FieldReference{View: parser.Identifier{Literal: "t2"}, Column: parser.Identifier{Literal: "column3"}},
+					Operator: "=",
+				},
+			},
+		},
+		ResultFiles: []*FileInfo{
+			{
+				Path:      GetTestFilePath("table1.csv"),
+				Delimiter: ',',
+				NoHeader:  false,
+				Encoding:  text.UTF8,
+				LineBreak: text.LF,
+			},
+		},
+		UpdateCounts: []int{2},
+	},
+	{
+		Name: "Update Query Joined From Other Tables Having Same Field Names",
+		Query: parser.UpdateQuery{
+			Tables: []parser.QueryExpression{
+				parser.Table{Object: parser.Identifier{Literal: "table1"}},
+			},
+			SetList: []parser.UpdateSet{
+				{
+					Field: parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+					Value: parser.FieldReference{View: parser.Identifier{Literal: "tmpview"}, Column: parser.Identifier{Literal: "column2"}},
+				},
+			},
+			FromClause: parser.FromClause{
+				Tables: []parser.QueryExpression{
+					parser.Table{Object: parser.Identifier{Literal: "tmpview"}},
+				},
+			},
+			WhereClause: parser.WhereClause{
+				Filter: parser.Comparison{
+					LHS:      parser.FieldReference{View: parser.Identifier{Literal: "table1"}, Column: parser.Identifier{Literal: "column1"}},
+					RHS:      parser.FieldReference{View: parser.Identifier{Literal: "tmpview"}, Column: parser.Identifier{Literal: "column1"}},
+					Operator: "=",
+				},
+			},
+		},
+		ResultFiles: []*FileInfo{
+			{
+				Path:      GetTestFilePath("table1.csv"),
+				Delimiter: ',',
+				NoHeader:  false,
+				Encoding:  text.UTF8,
+				LineBreak: text.LF,
+			},
+		},
+		UpdateCounts: []int{2},
+	},
+	{
+		Name: "Update Query Joined Field Ambiguous Error",
+		Query: parser.UpdateQuery{
+			Tables: []parser.QueryExpression{
+				parser.Table{Object: parser.Identifier{Literal: "table1"}},
+				parser.Table{Object: parser.Identifier{Literal: "tmpview"}},
+			},
+			SetList: []parser.UpdateSet{
+				{
+					Field: parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+					Value: parser.NewStringValue("update"),
+				},
+			},
+			FromClause: parser.FromClause{
+				Tables: []parser.QueryExpression{
+					parser.Table{Object: parser.Identifier{Literal: "table1"}},
+					parser.Table{Object: parser.Identifier{Literal: "tmpview"}},
+				},
+			},
+			WhereClause: parser.WhereClause{
+				Filter: parser.Comparison{
+					LHS:      parser.FieldReference{View: parser.Identifier{Literal: "table1"}, Column: parser.Identifier{Literal: "column1"}},
+					RHS:      parser.FieldReference{View: parser.Identifier{Literal: "tmpview"}, Column: parser.Identifier{Literal: "column1"}},
+					Operator: "=",
+				},
+			},
+		},
+		Error: "field column2 is ambiguous",
+	},
 	{
 		Name: "Update Query File Does Not Exist Error",
 		Query: parser.UpdateQuery{
@@ -1789,7 +2617,7 @@ var updateTests = []struct {
 		Error: "field notexist does not exist",
 	},
 	{
-		Name: "Update Query File Is Not Loaded Error",
+		Name: "Update Query Joined File Does Not Exist Error",
 		Query: parser.UpdateQuery{
 			Tables: []parser.QueryExpression{
 				parser.Table{Object: parser.Identifier{Literal: "notexist"}},
@@ -1822,7 +2650,7 @@ var updateTests = []struct {
 				},
 			},
 		},
-		Error: "table notexist is not loaded",
+		Error: "file notexist does not exist",
 	},
 	{
 		Name: "Update Query Update Table Is Not Specified Error",
@@ -1880,18 +2708,70 @@ var updateTests = []struct {
 				},
 			},
 		},
-		Error: "field notexist does not exist",
+		Error: "field notexist does not exist",
+	},
+	{
+		Name: "Update Query Update Value Error",
+		Query: parser.UpdateQuery{
+			Tables: []parser.QueryExpression{
+				parser.Table{Object: parser.Identifier{Literal: "table1"}},
+			},
+			SetList: []parser.UpdateSet{
+				{
+					Field: parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+					Value: parser.FieldReference{Column: parser.Identifier{Literal: "notexist"}},
+				},
+			},
+			WhereClause: parser.WhereClause{
+				Filter: parser.Comparison{
+					LHS:      parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+					RHS:      parser.NewIntegerValueFromString("2"),
+					Operator: "=",
+				},
+			},
+		},
+		Error: "field notexist does not exist",
+	},
+	{
+		Name: "Update Query Record Is Ambiguous Error",
+		Query: parser.UpdateQuery{
+			Tables: []parser.QueryExpression{
+				parser.Table{Object: parser.Identifier{Literal: "t1"}},
+			},
+			SetList: []parser.UpdateSet{
+				{
+					Field: parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+					Value: parser.FieldReference{Column: parser.Identifier{Literal: "column4"}},
+				},
+			},
+			FromClause: parser.FromClause{
+				Tables: []parser.QueryExpression{
+					parser.Table{Object: parser.Join{
+						Table: parser.Table{
+							Object: parser.Identifier{Literal: "table1"},
+							Alias:  parser.Identifier{Literal: "t1"},
+						},
+						JoinTable: parser.Table{
+							Object: parser.Identifier{Literal: "table2"},
+							Alias:  parser.Identifier{Literal: "t2"},
+						},
+						JoinType: parser.Token{Token: parser.CROSS, Literal: "cross"},
+					}},
+				},
+			},
+		},
+		Error: "value column4 to set in the field column2 is ambiguous",
 	},
 	{
-		Name: "Update Query Update Value Error",
+		Name: "Update Query With Returning Clause",
 		Query: parser.UpdateQuery{
 			Tables: []parser.QueryExpression{
 				parser.Table{Object: parser.Identifier{Literal: "table1"}},
 			},
 			SetList: []parser.UpdateSet{
 				{
-					Field: parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
-					Value: parser.FieldReference{Column: parser.Identifier{Literal: "notexist"}},
+					Field: parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+					Value: parser.NewStringValue("update"),
 				},
 			},
 			WhereClause: parser.WhereClause{
@@ -1901,18 +2781,44 @@ var updateTests = []struct {
 					Operator: "=",
 				},
 			},
+			ReturningClause: parser.ReturningClause{
+				Returning: "returning",
+				Fields: []parser.QueryExpression{
+					parser.Field{Object: parser.AllColumns{}},
+				},
+			},
+		},
+		ResultFiles: []*FileInfo{
+			{
+				Path:      GetTestFilePath("table1.csv"),
+				Delimiter: ',',
+				NoHeader:  false,
+				Encoding:  text.UTF8,
+				LineBreak: text.LF,
+			},
+		},
+		UpdateCounts: []int{1},
+		Returning: &View{
+			Header: NewHeader("table1", []string{"column1", "column2"}),
+			RecordSet: []Record{
+				NewRecord([]value.Primary{
+					value.NewString("2"),
+					value.NewString("update"),
+				}),
+			},
+			Tx: TestTx,
 		},
-		Error: "field notexist does not exist",
 	},
 	{
-		Name: "Update Query Record Is Ambiguous Error",
+		Name: "Update Query Returning Clause With Multiple Tables Error",
 		Query: parser.UpdateQuery{
 			Tables: []parser.QueryExpression{
 				parser.Table{Object: parser.Identifier{Literal: "t1"}},
+				parser.Table{Object: parser.Identifier{Literal: "t2"}},
 			},
 			SetList: []parser.UpdateSet{
 				{
-					Field: parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+					Field: parser.FieldReference{View: parser.Identifier{Literal: "t1"}, Column: parser.Identifier{Literal: "column2"}},
 					Value: parser.FieldReference{Column: parser.Identifier{Literal: "column4"}},
 				},
 			},
@@ -1927,12 +2833,24 @@ var updateTests = []struct {
 							Object: parser.Identifier{Literal: "table2"},
 							Alias:  parser.Identifier{Literal: "t2"},
 						},
-						JoinType: parser.Token{Token: parser.CROSS, Literal: "cross"},
+						Condition: parser.JoinCondition{
+							On: parser.Comparison{
+								LHS:      parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+								RHS:      parser.FieldReference{Column: parser.Identifier{Literal: "column3"}},
+								Operator: "=",
+							},
+						},
 					}},
 				},
 			},
+			ReturningClause: parser.ReturningClause{
+				Returning: "returning",
+				Fields: []parser.QueryExpression{
+					parser.Field{Object: parser.AllColumns{}},
+				},
+			},
 		},
-		Error: "value column4 to set in the field column2 is ambiguous",
+		Error: "returning cannot be used when multiple tables are modified",
 	},
 }
 
@@ -1972,7 +2890,7 @@ func TestUpdate(t *testing.T) {
 
 	for _, v := range updateTests {
 		_ = TestTx.ReleaseResources()
-		files, cnt, err := Update(context.Background(), filter, v.Query)
+		files, cnt, returning, err := Update(context.Background(), filter, v.Query)
 		if err != nil {
 			if len(v.Error) < 1 {
 				t.Errorf("%s: unexpected error %q", v.Name, err)
@@ -2004,6 +2922,10 @@ func TestUpdate(t *testing.T) {
 			t.Errorf("%s: update count = %v, want %v", v.Name, cnt, v.UpdateCounts)
 		}
 
+		if !reflect.DeepEqual(returning, v.Returning) {
+			t.Errorf("%s: returning = %v, want %v", v.Name, returning, v.Returning)
+		}
+
 		if v.ViewCache != nil {
 			if !reflect.DeepEqual(TestTx.cachedViews, v.ViewCache) {
 				t.Errorf("%s: view cache = %v, want %v", v.Name, TestTx.cachedViews, v.ViewCache)
@@ -2022,6 +2944,7 @@ var deleteTests = []struct {
 	Query        parser.DeleteQuery
 	ResultFiles  []*FileInfo
 	UpdateCounts []int
+	Returning    *View
 	ViewCache    ViewMap
 	TempViewList TemporaryViewScopes
 	Error        string
@@ -2162,6 +3085,39 @@ var deleteTests = []struct {
 			},
 		},
 	},
+	{
+		Name: "Delete Query Using Other Tables",
+		Query: parser.DeleteQuery{
+			FromClause: parser.FromClause{
+				Tables: []parser.QueryExpression{
+					parser.Table{Object: parser.Identifier{Literal: "table1"}},
+				},
+			},
+			Using: []parser.QueryExpression{
+				parser.Table{
+					Object: parser.Identifier{Literal: "table2"},
+					Alias:  parser.Identifier{Literal: "t2"},
+				},
+			},
+			WhereClause: parser.WhereClause{
+				Filter: parser.Comparison{
+					LHS:      parser.FieldReference{View: parser.Identifier{Literal: "table1"}, Column: parser.Identifier{Literal: "column1"}},
+					RHS:      parser.FieldReference{View: parser.Identifier{Literal: "t2"}, Column: parser.Identifier{Literal: "column3"}},
+					Operator: "=",
+				},
+			},
+		},
+		ResultFiles: []*FileInfo{
+			{
+				Path:      GetTestFilePath("table1.csv"),
+				Delimiter: ',',
+				NoHeader:  false,
+				Encoding:  text.UTF8,
+				LineBreak: text.LF,
+			},
+		},
+		UpdateCounts: []int{2},
+	},
 	{
 		Name: "Delete Query Multiple Table",
 		Query: parser.DeleteQuery{
@@ -2298,6 +3254,53 @@ var deleteTests = []struct {
 		},
 		Error: "table notexist is not loaded",
 	},
+	{
+		Name: "Delete Query With Returning Clause",
+		Query: parser.DeleteQuery{
+			FromClause: parser.FromClause{
+				Tables: []parser.QueryExpression{
+					parser.Table{
+						Object: parser.Identifier{Literal: "table1"},
+					},
+				},
+			},
+			WhereClause: parser.WhereClause{
+				Filter: parser.Comparison{
+					LHS:      parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+					RHS:      parser.NewIntegerValueFromString("2"),
+					Operator: ">=",
+				},
+			},
+			ReturningClause: parser.ReturningClause{
+				Returning: "returning",
+				Fields: []parser.QueryExpression{
+					parser.Field{Object: parser.FieldReference{Column: parser.Identifier{Literal: "column2"}}},
+				},
+			},
+		},
+		ResultFiles: []*FileInfo{
+			{
+				Path:      GetTestFilePath("table1.csv"),
+				Delimiter: ',',
+				NoHeader:  false,
+				Encoding:  text.UTF8,
+				LineBreak: text.LF,
+			},
+		},
+		UpdateCounts: []int{2},
+		Returning: &View{
+			Header: NewHeader("table1", []string{"column2"}),
+			RecordSet: []Record{
+				NewRecord([]value.Primary{
+					value.NewString("str2"),
+				}),
+				NewRecord([]value.Primary{
+					value.NewString("str3"),
+				}),
+			},
+			Tx: TestTx,
+		},
+	},
 }
 
 func TestDelete(t *testing.T) {
@@ -2336,7 +3339,7 @@ func TestDelete(t *testing.T) {
 
 	for _, v := range deleteTests {
 		_ = TestTx.ReleaseResources()
-		files, cnt, err := Delete(context.Background(), filter, v.Query)
+		files, cnt, returning, err := Delete(context.Background(), filter, v.Query)
 		if err != nil {
 			if len(v.Error) < 1 {
 				t.Errorf("%s: unexpected error %q", v.Name, err)
@@ -2368,6 +3371,10 @@ func TestDelete(t *testing.T) {
 			t.Errorf("%s: update count = %v, want %v", v.Name, cnt, v.UpdateCounts)
 		}
 
+		if !reflect.DeepEqual(returning, v.Returning) {
+			t.Errorf("%s: returning = %v, want %v", v.Name, returning, v.Returning)
+		}
+
 		if v.ViewCache != nil {
 			if !reflect.DeepEqual(TestTx.cachedViews, v.ViewCache) {
 				t.Errorf("%s: view cache = %v, want %v", v.Name, TestTx.cachedViews, v.ViewCache)
@@ -2381,6 +3388,254 @@ func TestDelete(t *testing.T) {
 	}
 }
 
+var mergeTests = []struct {
+	Name          string
+	Query         parser.MergeQuery
+	ResultFile    *FileInfo
+	InsertedCount int
+	UpdatedCount  int
+	DeletedCount  int
+	ViewCache     ViewMap
+	Error         string
+}{
+	{
+		Name: "Merge Query",
+		Query: parser.MergeQuery{
+			Table:  parser.Table{Object: parser.Identifier{Literal: "table1"}},
+			Source: parser.Table{Object: parser.Identifier{Literal: "table2"}},
+			Condition: parser.Comparison{
+				LHS:      parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+				RHS:      parser.FieldReference{Column: parser.Identifier{Literal: "column3"}},
+				Operator: "=",
+			},
+			WhenList: []parser.MergeWhen{
+				{
+					Condition: parser.Comparison{
+						LHS:      parser.FieldReference{Column: parser.Identifier{Literal: "column3"}},
+						RHS:      parser.NewIntegerValueFromString("3"),
+						Operator: "=",
+					},
+					Operation: parser.Token{Token: parser.DELETE, Literal: "delete"},
+				},
+				{
+					Operation: parser.Token{Token: parser.UPDATE, Literal: "update"},
+					SetList: []parser.UpdateSet{
+						{
+							Field: parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+							Value: parser.FieldReference{Column: parser.Identifier{Literal: "column4"}},
+						},
+					},
+				},
+				{
+					NotMatched: true,
+					Operation:  parser.Token{Token: parser.INSERT, Literal: "insert"},
+					Values: parser.RowValue{
+						Value: parser.ValueList{
+							Values: []parser.QueryExpression{
+								parser.FieldReference{Column: parser.Identifier{Literal: "column3"}},
+								parser.FieldReference{Column: parser.Identifier{Literal: "column4"}},
+							},
+						},
+					},
+				},
+			},
+		},
+		ResultFile: &FileInfo{
+			Path:      GetTestFilePath("table1.csv"),
+			Delimiter: ',',
+			NoHeader:  false,
+			Encoding:  text.UTF8,
+			LineBreak: text.LF,
+		},
+		InsertedCount: 1,
+		UpdatedCount:  1,
+		DeletedCount:  1,
+		ViewCache: ViewMap{
+			strings.ToUpper(GetTestFilePath("table1.csv")): &View{
+				FileInfo: &FileInfo{
+					Path:      GetTestFilePath("table1.csv"),
+					Delimiter: ',',
+					NoHeader:  false,
+					Encoding:  text.UTF8,
+					LineBreak: text.LF,
+				},
+				Header: NewHeader("table1", []string{"column1", "column2"}),
+				RecordSet: []Record{
+					NewRecord([]value.Primary{
+						value.NewString("1"),
+						value.NewString("str1"),
+					}),
+					NewRecord([]value.Primary{
+						value.NewString("2"),
+						value.NewString("str22"),
+					}),
+					NewRecord([]value.Primary{
+						value.NewString("4"),
+						value.NewString("str44"),
+					}),
+				},
+				ForUpdate: true,
+			},
+			strings.ToUpper(GetTestFilePath("table2.csv")): &View{
+				FileInfo: &FileInfo{
+					Path:      GetTestFilePath("table2.csv"),
+					Delimiter: ',',
+					NoHeader:  false,
+					Encoding:  text.UTF8,
+					LineBreak: text.LF,
+				},
+				Header: NewHeader("table2", []string{"column3", "column4"}),
+				RecordSet: []Record{
+					NewRecord([]value.Primary{
+						value.NewString("2"),
+						value.NewString("str22"),
+					}),
+					NewRecord([]value.Primary{
+						value.NewString("3"),
+						value.NewString("str33"),
+					}),
+					NewRecord([]value.Primary{
+						value.NewString("4"),
+						value.NewString("str44"),
+					}),
+				},
+				Tx:        TestTx,
+				ForUpdate: true,
+			},
+		},
+	},
+	{
+		Name: "Merge Query Record Is Ambiguous Error",
+		Query: parser.MergeQuery{
+			BaseExpr: parser.NewBaseExpr(parser.Token{}),
+			Table:    parser.Table{Object: parser.Identifier{Literal: "table1"}},
+			Source:   parser.Table{Object: parser.Identifier{Literal: "table2"}},
+			Condition: parser.Comparison{
+				LHS:      parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+				RHS:      parser.NewIntegerValueFromString("1"),
+				Operator: "=",
+			},
+			WhenList: []parser.MergeWhen{
+				{
+					Operation: parser.Token{Token: parser.DELETE, Literal: "delete"},
+				},
+			},
+		},
+		Error: "record to merge in table1 is matched with multiple source records",
+	},
+	{
+		Name: "Merge Query Update Field Error",
+		Query: parser.MergeQuery{
+			Table:  parser.Table{Object: parser.Identifier{Literal: "table1"}},
+			Source: parser.Table{Object: parser.Identifier{Literal: "table2"}},
+			Condition: parser.Comparison{
+				LHS:      parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+				RHS:      parser.FieldReference{Column: parser.Identifier{Literal: "column3"}},
+				Operator: "=",
+			},
+			WhenList: []parser.MergeWhen{
+				{
+					Operation: parser.Token{Token: parser.UPDATE, Literal: "update"},
+					SetList: []parser.UpdateSet{
+						{
+							Field: parser.FieldReference{Column: parser.Identifier{Literal: "column4"}},
+							Value: parser.NewStringValue("str"),
+						},
+					},
+				},
+			},
+		},
+		Error: "field column4 does not exist in the tables to update",
+	},
+	{
+		Name: "Merge Query Insert Row Value Length Error",
+		Query: parser.MergeQuery{
+			Table:  parser.Table{Object: parser.Identifier{Literal: "table1"}},
+			Source: parser.Table{Object: parser.Identifier{Literal: "table2"}},
+			Condition: parser.Comparison{
+				LHS:      parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+				RHS:      parser.FieldReference{Column: parser.Identifier{Literal: "column3"}},
+				Operator: "=",
+			},
+			WhenList: []parser.MergeWhen{
+				{
+					NotMatched: true,
+					Operation:  parser.Token{Token: parser.INSERT, Literal: "insert"},
+					Values: parser.RowValue{
+						Value: parser.ValueList{
+							Values: []parser.QueryExpression{
+								parser.FieldReference{Column: parser.Identifier{Literal: "column3"}},
+							},
+						},
+					},
+				},
+			},
+		},
+		Error: "row value should contain exactly 2 values",
+	},
+}
+
+func TestMerge(t *testing.T) {
+	defer func() {
+		_ = TestTx.ReleaseResources()
+		_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+		initFlag(TestTx.Flags)
+	}()
+
+	TestTx.Flags.Repository = TestDir
+	TestTx.Flags.Quiet = false
+
+	filter := NewFilter(TestTx)
+
+	for _, v := range mergeTests {
+		_ = TestTx.ReleaseResources()
+		_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+		file, inserted, updated, deleted, err := Merge(context.Background(), filter, v.Query)
+		if err != nil {
+			if len(v.Error) < 1 {
+				t.Errorf("%s: unexpected error %q", v.Name, err)
+			} else if err.Error() != v.Error {
+				t.Errorf("%s: error %q, want error %q", v.Name, err.Error(), v.Error)
+			}
+			continue
+		}
+		if 0 < len(v.Error) {
+			t.Errorf("%s: no error, want error %q", v.Name, v.Error)
+			continue
+		}
+
+		for _, v2 := range TestTx.cachedViews {
+			if v2.FileInfo.Handler != nil {
+				if v2.FileInfo.Path != v2.FileInfo.Handler.Path() {
+					t.Errorf("file pointer = %q, want %q for %q", v2.FileInfo.Handler.Path(), v2.FileInfo.Path, v.Name)
+				}
+				_ = TestTx.FileContainer.Close(v2.FileInfo.Handler)
+				v2.FileInfo.Handler = nil
+			}
+		}
+
+		if !reflect.DeepEqual(file, v.ResultFile) {
+			t.Errorf("%s: fileinfo = %v, want %v", v.Name, file, v.ResultFile)
+		}
+
+		if inserted != v.InsertedCount {
+			t.Errorf("%s: inserted count = %d, want %d", v.Name, inserted, v.InsertedCount)
+		}
+		if updated != v.UpdatedCount {
+			t.Errorf("%s: updated count = %d, want %d", v.Name, updated, v.UpdatedCount)
+		}
+		if deleted != v.DeletedCount {
+			t.Errorf("%s: deleted count = %d, want %d", v.Name, deleted, v.DeletedCount)
+		}
+
+		if v.ViewCache != nil {
+			if !reflect.DeepEqual(TestTx.cachedViews, v.ViewCache) {
+				t.Errorf("%s: view cache = %v, want %v", v.Name, TestTx.cachedViews, v.ViewCache)
+			}
+		}
+	}
+}
+
 var createTableTests = []struct {
 	Name       string
 	Query      parser.CreateTable
@@ -62,6 +62,15 @@ func (r Record) Copy() Record {
 
 }
 
+// MergeRecord returns a new record that has the cells of r1 followed by the cells of r2.
+// Unlike append, the result never shares the underlying array with r1.
+func MergeRecord(r1 Record, r2 Record) Record {
+	record := make(Record, len(r1)+len(r2))
+	copy(record, r1)
+	copy(record[len(r1):], r2)
+	return record
+}
+
 func (r Record) SerializeComparisonKeys(buf *bytes.Buffer, flags *cmd.Flags) {
 	for i, cell := range r {
 		if 0 < i {
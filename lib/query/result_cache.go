@@ -0,0 +1,296 @@
+package query
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mithrandie/csvq/lib/cmd"
+	"github.com/mithrandie/csvq/lib/parser"
+)
+
+const (
+	resultCacheVersion   = 1
+	resultCacheExtension = ".csvq-result"
+)
+
+// resultCacheDir is the directory where the results are cached.
+// If it is empty, then a directory in the cache directory of the user is used.
+var resultCacheDir = ""
+
+// ResultCacheDir returns the directory where the results of queries are cached.
+func ResultCacheDir() (string, error) {
+	if 0 < len(resultCacheDir) {
+		return resultCacheDir, nil
+	}
+
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "csvq", "results"), nil
+}
+
+// ResultCacheFile is the status of a file that a cached result depends on.
+type ResultCacheFile struct {
+	Path    string `json:"path"`
+	ModTime int64  `json:"mod_time"`
+	Size    int64  `json:"size"`
+}
+
+func (f ResultCacheFile) isModified() bool {
+	stat, err := os.Stat(f.Path)
+	return err != nil || stat.ModTime().UnixNano() != f.ModTime || stat.Size() != f.Size
+}
+
+// resultCacheEntry is the header of a cache file.
+// The records of the result follow the header line in the same encoding as spilled records.
+type resultCacheEntry struct {
+	Version int               `json:"version"`
+	Key     string            `json:"key"`
+	Files   []ResultCacheFile `json:"files"`
+	Header  Header            `json:"header"`
+	Records int               `json:"records"`
+}
+
+// queryDependencies collects the files that the result of a query depends on.
+//
+// If the query refers to anything other than the files and the query itself, such as variables,
+// temporary tables or the current time, then the result is not cached.
+// The methods can be called on nil, which means that the result cache is not used.
+type queryDependencies struct {
+	mtx         sync.Mutex
+	files       map[string]ResultCacheFile
+	uncacheable bool
+}
+
+func newQueryDependencies() *queryDependencies {
+	return &queryDependencies{
+		files: make(map[string]ResultCacheFile),
+	}
+}
+
+func (d *queryDependencies) addFile(fpath string, stat os.FileInfo) {
+	if d == nil {
+		return
+	}
+
+	d.mtx.Lock()
+	d.files[fpath] = ResultCacheFile{
+		Path:    fpath,
+		ModTime: stat.ModTime().UnixNano(),
+		Size:    stat.Size(),
+	}
+	d.mtx.Unlock()
+}
+
+func (d *queryDependencies) setUncacheable() {
+	if d == nil {
+		return
+	}
+
+	d.mtx.Lock()
+	d.uncacheable = true
+	d.mtx.Unlock()
+}
+
+func (d *queryDependencies) Files() ([]ResultCacheFile, bool) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	if d.uncacheable {
+		return nil, false
+	}
+
+	files := make([]ResultCacheFile, 0, len(d.files))
+	for _, f := range d.files {
+		files = append(files, f)
+	}
+	return files, true
+}
+
+// dependOnFile records that the result of the query depends on the file loaded in the view.
+func (f *Filter) dependOnFile(fileInfo *FileInfo) {
+	if f.dependencies == nil {
+		return
+	}
+
+	// The records changed in the transaction are different from the file.
+	// The results of encrypted files are not saved in the cache files.
+	if fileInfo.loadedStat == nil || fileInfo.Encrypted || f.tx.uncommittedViews.Contains(fileInfo.Path) {
+		f.dependencies.setUncacheable()
+		return
+	}
+	f.dependencies.addFile(fileInfo.Path, fileInfo.loadedStat)
+}
+
+// isNondeterministicFunction reports whether the function can return different results for the same arguments.
+func isNondeterministicFunction(name string) bool {
+	switch name {
+	case "CALL", "NOW", "NEXTVAL", "UUID", "UUID_V7":
+		return true
+	}
+	return RandomFunctions[name] || pluginFunctions[name]
+}
+
+// resultCacheKey returns the string identifying the result of the query.
+// It includes the flags that affect the results.
+func resultCacheKey(flags *cmd.Flags, query parser.SelectQuery) (string, error) {
+	repository, err := filepath.Abs(flags.Repository)
+	if err != nil {
+		return "", err
+	}
+
+	location := flags.Location
+	if strings.EqualFold(location, "Local") {
+		name, offset := time.Now().Zone()
+		location = fmt.Sprintf("%s %s %d", location, name, offset)
+	}
+
+	return fmt.Sprintf(
+		"version:%d\nrepository:%s\nlocation:%s\ndatetime_format:%q\ncase_sensitive:%t\n"+
+			"import_format:%s\ndelimiter:%q\ndelimiter_positions:%v\nsingle_line:%t\njson_query:%q\nencoding:%s\nno_header:%t\nwithout_null:%t\n"+
+			"limit_recursion:%d\nquery:%s",
+		resultCacheVersion, repository, location, flags.DatetimeFormat, flags.CaseSensitive,
+		flags.ImportFormat, flags.Delimiter, flags.DelimiterPositions, flags.SingleLine, flags.JsonQuery, flags.Encoding, flags.NoHeader, flags.WithoutNull,
+		flags.LimitRecursion, query.String(),
+	), nil
+}
+
+func resultCacheFilePath(dir string, key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(dir, hex.EncodeToString(sum[:])+resultCacheExtension)
+}
+
+// selectWithResultCache returns the cached result of the query if the files that the result depends on
+// have not been modified. Otherwise, the query is executed by Select, and the result is cached if possible.
+func selectWithResultCache(ctx context.Context, filter *Filter, query parser.SelectQuery) (*View, error) {
+	if !filter.tx.Flags.ResultCache {
+		return Select(ctx, filter, query)
+	}
+
+	dir, err := ResultCacheDir()
+	if err != nil {
+		return Select(ctx, filter, query)
+	}
+	key, err := resultCacheKey(filter.tx.Flags, query)
+	if err != nil {
+		return Select(ctx, filter, query)
+	}
+	fpath := resultCacheFilePath(dir, key)
+
+	if view, ok := loadCachedResult(ctx, filter.tx, fpath, key); ok {
+		return view, nil
+	}
+
+	filter.dependencies = newQueryDependencies()
+	view, err := Select(ctx, filter, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if files, ok := filter.dependencies.Files(); ok {
+		// Failure to cache the result does not affect the query.
+		_ = saveCachedResult(fpath, key, files, view)
+	}
+	return view, nil
+}
+
+// loadCachedResult reads the result cached in the file.
+// If the file does not exist or the result is no longer valid, then false is returned.
+func loadCachedResult(ctx context.Context, tx *Transaction, fpath string, key string) (*View, bool) {
+	fp, err := os.Open(fpath)
+	if err != nil {
+		return nil, false
+	}
+	defer func() {
+		_ = fp.Close()
+	}()
+
+	r := bufio.NewReader(fp)
+	line, err := r.ReadBytes('\n')
+	if err != nil {
+		return nil, false
+	}
+
+	entry := resultCacheEntry{}
+	if err = json.Unmarshal(line, &entry); err != nil {
+		return nil, false
+	}
+	if entry.Version != resultCacheVersion || entry.Key != key {
+		return nil, false
+	}
+	for _, f := range entry.Files {
+		if f.isModified() || tx.uncommittedViews.Contains(f.Path) {
+			return nil, false
+		}
+	}
+
+	records := make(RecordSet, entry.Records)
+	for i := range records {
+		if ctx.Err() != nil {
+			return nil, false
+		}
+		if records[i], err = readSpilledRecord(r); err != nil {
+			return nil, false
+		}
+	}
+
+	view := NewView(tx)
+	view.Header = entry.Header
+	view.RecordSet = records
+	return view, true
+}
+
+// saveCachedResult writes the result of the query to the cache file.
+func saveCachedResult(fpath string, key string, files []ResultCacheFile, view *View) error {
+	header, err := json.Marshal(resultCacheEntry{
+		Version: resultCacheVersion,
+		Key:     key,
+		Files:   files,
+		Header:  view.Header,
+		Records: view.RecordLen(),
+	})
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(fpath)
+	if err = os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	fp, err := ioutil.TempFile(dir, "."+filepath.Base(fpath)+".*")
+	if err != nil {
+		return err
+	}
+	tmpPath := fp.Name()
+
+	w := bufio.NewWriter(fp)
+	_, err = w.Write(append(header, '\n'))
+	for i := 0; err == nil && i < view.RecordLen(); i++ {
+		err = writeSpilledRecord(w, view.RecordSet[i])
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if e := fp.Close(); err == nil {
+		err = e
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, fpath)
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+	}
+	return err
+}
@@ -0,0 +1,137 @@
+package query
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/mithrandie/csvq/lib/parser"
+)
+
+var selectWithResultCacheTests = []struct {
+	Query  string
+	Cached bool
+}{
+	{
+		Query:  "SELECT * FROM result_cache_test",
+		Cached: true,
+	},
+	{
+		Query:  "SELECT c1, COUNT(*) FROM result_cache_test GROUP BY c1 ORDER BY c1",
+		Cached: true,
+	},
+	{
+		Query:  "WITH t AS (SELECT c1 FROM result_cache_test) SELECT * FROM t WHERE c1 > (SELECT MIN(c1) FROM t)",
+		Cached: true,
+	},
+	{
+		Query:  "SELECT COUNT(*) FROM result_cache_test WHERE c1 > 5 AND RAND() < 1",
+		Cached: true,
+	},
+	{
+		Query: "SELECT NOW(), c1 FROM result_cache_test",
+	},
+	{
+		Query: "SELECT c1 FROM result_cache_test WHERE RAND() < 1",
+	},
+	{
+		Query: "SELECT @%CSVQ_TEST_ENV, c1 FROM result_cache_test",
+	},
+	{
+		Query: "SELECT @#VERSION FROM result_cache_test",
+	},
+	{
+		Query: "SELECT c1, UUID() FROM result_cache_test",
+	},
+}
+
+func TestSelectWithResultCache(t *testing.T) {
+	fpath := GetTestFilePath("result_cache_test.csv")
+	if err := os.WriteFile(fpath, []byte("c1,c2\n1,a\n2,b\n2,c\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	resultCacheDir = filepath.Join(TestDir, "result_cache")
+	defer func() {
+		_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+		_ = os.Remove(fpath)
+		_ = os.RemoveAll(resultCacheDir)
+		resultCacheDir = ""
+		initFlag(TestTx.Flags)
+	}()
+
+	TestTx.Flags.Repository = TestDir
+	TestTx.Flags.ResultCache = true
+
+	selectQuery := func(query parser.SelectQuery) (*View, error) {
+		_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+		return selectWithResultCache(context.Background(), NewFilter(TestTx).CreateNode(), query)
+	}
+
+	for _, v := range selectWithResultCacheTests {
+		_ = os.RemoveAll(resultCacheDir)
+
+		statements, _, err := parser.Parse(v.Query, "", nil, false)
+		if err != nil {
+			t.Fatalf("%s: unexpected parse error %q", v.Query, err)
+		}
+		query := statements[0].(parser.SelectQuery)
+
+		expect, err := selectQuery(query)
+		if err != nil {
+			t.Errorf("%s: unexpected error %q", v.Query, err)
+			continue
+		}
+
+		key, _ := resultCacheKey(TestTx.Flags, query)
+		cachePath := resultCacheFilePath(resultCacheDir, key)
+		_, err = os.Stat(cachePath)
+		if cached := err == nil; cached != v.Cached {
+			t.Errorf("%s: cached = %t, want %t", v.Query, cached, v.Cached)
+			continue
+		}
+		if !v.Cached {
+			continue
+		}
+
+		result, ok := loadCachedResult(context.Background(), TestTx, cachePath, key)
+		if !ok {
+			t.Errorf("%s: cached result is not loaded", v.Query)
+			continue
+		}
+		if !reflect.DeepEqual(result.Header, expect.Header) {
+			t.Errorf("%s: header = %v, want %v", v.Query, result.Header, expect.Header)
+		}
+		if !reflect.DeepEqual(result.RecordSet, expect.RecordSet) {
+			t.Errorf("%s: records = %v, want %v", v.Query, result.RecordSet, expect.RecordSet)
+		}
+
+		if _, ok = loadCachedResult(context.Background(), TestTx, cachePath, key+" "); ok {
+			t.Errorf("%s: cached result is loaded with a different key", v.Query)
+		}
+	}
+
+	_ = os.RemoveAll(resultCacheDir)
+	statements, _, _ := parser.Parse("SELECT COUNT(*) FROM result_cache_test", "", nil, false)
+	query := statements[0].(parser.SelectQuery)
+	if _, err := selectQuery(query); err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if err := os.WriteFile(fpath, []byte("c1,c2\n1,a\n2,b\n2,c\n3,d\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	key, _ := resultCacheKey(TestTx.Flags, query)
+	if _, ok := loadCachedResult(context.Background(), TestTx, resultCacheFilePath(resultCacheDir, key), key); ok {
+		t.Errorf("cached result is loaded after the file is modified")
+	}
+
+	result, err := selectQuery(query)
+	if err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	if count := result.RecordSet[0][0].Value().String(); count != "4" {
+		t.Errorf("count = %s, want %s", count, "4")
+	}
+}
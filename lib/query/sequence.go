@@ -0,0 +1,112 @@
+package query
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/mithrandie/csvq/lib/parser"
+	"github.com/mithrandie/csvq/lib/value"
+)
+
+type SequenceMap struct {
+	mutex     *sync.Mutex
+	sequences map[string]*Sequence
+}
+
+func NewSequenceMap() *SequenceMap {
+	return &SequenceMap{
+		mutex:     &sync.Mutex{},
+		sequences: make(map[string]*Sequence, 4),
+	}
+}
+
+func (m *SequenceMap) Declare(ctx context.Context, filter *Filter, expr parser.SequenceDeclaration) error {
+	start, err := evalSequenceParameter(ctx, filter, expr.Sequence, expr.Start, "start")
+	if err != nil {
+		return err
+	}
+	increment, err := evalSequenceParameter(ctx, filter, expr.Sequence, expr.Increment, "increment")
+	if err != nil {
+		return err
+	}
+	if increment == 0 {
+		return NewInvalidSequenceParameterError(expr.Increment, "increment", expr.Sequence)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	uname := strings.ToUpper(expr.Sequence.Literal)
+	if _, ok := m.sequences[uname]; ok {
+		return NewSequenceRedeclaredError(expr.Sequence)
+	}
+	m.sequences[uname] = NewSequence(expr.Sequence.Literal, start, increment)
+	return nil
+}
+
+func evalSequenceParameter(ctx context.Context, filter *Filter, sequence parser.Identifier, param parser.QueryExpression, name string) (int64, error) {
+	if param == nil {
+		return 1, nil
+	}
+
+	p, err := filter.Evaluate(ctx, param)
+	if err != nil {
+		return 0, err
+	}
+	i := value.ToInteger(p)
+	if value.IsNull(i) {
+		return 0, NewInvalidSequenceParameterError(param, name, sequence)
+	}
+	return i.(value.Integer).Raw(), nil
+}
+
+func (m *SequenceMap) Dispose(name parser.Identifier) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	uname := strings.ToUpper(name.Literal)
+	if _, ok := m.sequences[uname]; ok {
+		delete(m.sequences, uname)
+		return nil
+	}
+	return NewUndeclaredSequenceError(name)
+}
+
+func (m *SequenceMap) NextValue(name string) (int64, bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	seq, ok := m.sequences[strings.ToUpper(name)]
+	if !ok {
+		return 0, false
+	}
+	return seq.Next(), true
+}
+
+type Sequence struct {
+	Name      string
+	Start     int64
+	Increment int64
+
+	current int64
+	started bool
+}
+
+func NewSequence(name string, start int64, increment int64) *Sequence {
+	return &Sequence{
+		Name:      name,
+		Start:     start,
+		Increment: increment,
+	}
+}
+
+func (seq *Sequence) Next() int64 {
+	if seq.started {
+		seq.current += seq.Increment
+	} else {
+		seq.current = seq.Start
+		seq.started = true
+	}
+	return seq.current
+}
@@ -0,0 +1,103 @@
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mithrandie/csvq/lib/parser"
+)
+
+var sequenceMapDeclareTests = []struct {
+	Name   string
+	Expr   parser.SequenceDeclaration
+	Result []int64
+	Error  string
+}{
+	{
+		Name: "SequenceMap Declare",
+		Expr: parser.SequenceDeclaration{
+			Sequence: parser.Identifier{Literal: "seq"},
+		},
+		Result: []int64{1, 2, 3},
+	},
+	{
+		Name: "SequenceMap Declare with Start and Increment",
+		Expr: parser.SequenceDeclaration{
+			Sequence:  parser.Identifier{Literal: "seq2"},
+			Start:     parser.NewIntegerValueFromString("10"),
+			Increment: parser.NewIntegerValueFromString("-5"),
+		},
+		Result: []int64{10, 5, 0},
+	},
+	{
+		Name: "SequenceMap Declare Redeclared Error",
+		Expr: parser.SequenceDeclaration{
+			Sequence: parser.Identifier{Literal: "seq"},
+		},
+		Error: "sequence seq is redeclared",
+	},
+	{
+		Name: "SequenceMap Declare Invalid Start Error",
+		Expr: parser.SequenceDeclaration{
+			Sequence: parser.Identifier{Literal: "seq3"},
+			Start:    parser.NewStringValue("a"),
+		},
+		Error: "invalid start value for sequence seq3",
+	},
+	{
+		Name: "SequenceMap Declare Invalid Increment Error",
+		Expr: parser.SequenceDeclaration{
+			Sequence:  parser.Identifier{Literal: "seq3"},
+			Increment: parser.NewIntegerValueFromString("0"),
+		},
+		Error: "invalid increment value for sequence seq3",
+	},
+}
+
+func TestSequenceMap_Declare(t *testing.T) {
+	m := NewSequenceMap()
+	for _, v := range sequenceMapDeclareTests {
+		err := m.Declare(context.Background(), NewFilter(TestTx), v.Expr)
+		if err != nil {
+			if len(v.Error) < 1 {
+				t.Errorf("%s: unexpected error %q", v.Name, err)
+			} else if err.Error() != v.Error {
+				t.Errorf("%s: error %q, want error %q", v.Name, err.Error(), v.Error)
+			}
+			continue
+		}
+		if 0 < len(v.Error) {
+			t.Errorf("%s: no error, want error %q", v.Name, v.Error)
+			continue
+		}
+		for _, expect := range v.Result {
+			i, ok := m.NextValue(v.Expr.Sequence.Literal)
+			if !ok {
+				t.Errorf("%s: sequence %s is not declared", v.Name, v.Expr.Sequence.Literal)
+				break
+			}
+			if i != expect {
+				t.Errorf("%s: next value = %d, want %d", v.Name, i, expect)
+			}
+		}
+	}
+}
+
+func TestSequenceMap_Dispose(t *testing.T) {
+	m := NewSequenceMap()
+	_ = m.Declare(context.Background(), NewFilter(TestTx), parser.SequenceDeclaration{Sequence: parser.Identifier{Literal: "seq"}})
+
+	if err := m.Dispose(parser.Identifier{Literal: "SEQ"}); err != nil {
+		t.Errorf("unexpected error %q", err)
+	} else if _, ok := m.NextValue("seq"); ok {
+		t.Errorf("sequence seq is not disposed")
+	}
+
+	expectErr := "sequence seq is undeclared"
+	err := m.Dispose(parser.Identifier{Literal: "seq"})
+	if err == nil {
+		t.Errorf("no error, want error %q", expectErr)
+	} else if err.Error() != expectErr {
+		t.Errorf("error = %q, want error %q", err.Error(), expectErr)
+	}
+}
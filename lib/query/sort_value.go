@@ -101,6 +101,10 @@ type SortValue struct {
 }
 
 func NewSortValue(val value.Primary, flags *cmd.Flags) *SortValue {
+	return NewSortValueWithCollation(val, flags, nil)
+}
+
+func NewSortValueWithCollation(val value.Primary, flags *cmd.Flags, collation *value.Collation) *SortValue {
 	sortValue := &SortValue{}
 
 	if value.IsNull(val) {
@@ -150,7 +154,11 @@ func NewSortValue(val value.Primary, flags *cmd.Flags) *SortValue {
 		}
 	} else if s, ok := val.(value.String); ok {
 		sortValue.Type = StringType
-		sortValue.String = strings.ToUpper(strings.TrimSpace(s.Raw()))
+		if collation != nil {
+			sortValue.String = collation.Key(strings.TrimSpace(s.Raw()))
+		} else {
+			sortValue.String = strings.ToUpper(strings.TrimSpace(s.Raw()))
+		}
 	} else {
 		sortValue.Type = NullType
 	}
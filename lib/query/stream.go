@@ -0,0 +1,641 @@
+package query
+
+import (
+	"context"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mithrandie/csvq/lib/cmd"
+	"github.com/mithrandie/csvq/lib/file"
+	"github.com/mithrandie/csvq/lib/parser"
+	"github.com/mithrandie/csvq/lib/value"
+
+	"github.com/mithrandie/go-text"
+	"github.com/mithrandie/go-text/csv"
+)
+
+// StreamChunkSize is the maximum number of records held in memory at a time
+// when a query is executed in streaming mode.
+var StreamChunkSize = 10000
+
+// StreamFlushInterval is the maximum time to wait for a chunk to be filled in streaming mode.
+// If records are read slowly, such as from a pipe, the records read so far are written when
+// the interval has passed since the first record of the chunk was read.
+var StreamFlushInterval = 100 * time.Millisecond
+
+// SelectStream executes a query that only filters and projects records of a single csv or tsv file
+// by reading, evaluating and writing the records one chunk at a time, so that the whole file
+// is never loaded into memory.
+//
+// If the query or the output format cannot be processed in streaming mode, then SelectStream
+// returns false without reading anything, and the query must be executed by Select.
+func SelectStream(ctx context.Context, parentFilter *Filter, query parser.SelectQuery, writer io.Writer, outFileInfo *FileInfo) (streamed bool, err error) {
+	switch outFileInfo.Format {
+	case cmd.CSV, cmd.TSV, cmd.LTSV:
+	default:
+		return false, nil
+	}
+
+	filter := parentFilter.CreateNode()
+
+	entity, table, ok := streamableQuery(filter, query)
+	if !ok {
+		return false, nil
+	}
+
+	fileInfo, ok := streamableFileInfo(filter, table.Object.(parser.Identifier))
+	if !ok {
+		return false, nil
+	}
+
+	limit := -1
+	if query.LimitClause != nil {
+		clause := query.LimitClause.(parser.LimitClause)
+		val, err := filter.Evaluate(ctx, clause.Value)
+		if err != nil {
+			return true, err
+		}
+		number := value.ToInteger(val)
+		if value.IsNull(number) {
+			return true, NewInvalidLimitNumberError(clause)
+		}
+		limit = int(number.(value.Integer).Raw())
+		if limit < 0 {
+			limit = 0
+		}
+	}
+
+	var sampler *tableSampler
+	if table.Sample != nil {
+		if sampler, err = newTableSampler(ctx, filter, table.Sample.(parser.TableSample)); err != nil {
+			return true, err
+		}
+	}
+
+	tableIdentifier := table.Object.(parser.Identifier)
+	fp, reader, header, err := openStreamReader(ctx, filter, table, fileInfo)
+	if err != nil {
+		return true, err
+	}
+	defer func() {
+		if e := fp.Close(); e != nil {
+			err = AppendCompositeError(err, e)
+		}
+	}()
+
+	// Comparisons between fields and constant values are evaluated with the raw texts while reading,
+	// so that the records not satisfying them are never created.
+	// Records are sampled before they are filtered, so the condition is not pushed down to sampled tables.
+	var condition parser.QueryExpression
+	if entity.WhereClause != nil {
+		if sampler != nil {
+			condition = entity.WhereClause.(parser.WhereClause).Filter
+		} else {
+			condition = reader.PushDown(header, entity.WhereClause.(parser.WhereClause).Filter, filter.tx.Flags.CaseSensitive)
+		}
+	}
+
+	if err = filter.aliases.Add(table.Name(), fileInfo.Path); err != nil {
+		return true, err
+	}
+
+	w := newStreamWriter(writer, outFileInfo, filter.tx.Flags)
+
+	var sorter *recordSorter
+	if query.OrderByClause != nil {
+		sorter = newRecordSorter(int64(filter.tx.Flags.SortBufferSize) * 1024 * 1024)
+		defer func() {
+			if e := sorter.Close(); e != nil {
+				err = AppendCompositeError(err, e)
+			}
+		}()
+	}
+
+	chunkReader := newRecordChunkReader(reader, StreamChunkSize)
+	defer chunkReader.Close()
+
+	for chunkIdx := 0; ; chunkIdx++ {
+		records, eof, e := chunkReader.Read(ctx, StreamChunkSize)
+		if e != nil {
+			if _, ok := e.(*ContextIsDone); ok {
+				return true, e
+			}
+			return true, NewDataParsingError(tableIdentifier, fileInfo.Path, e.Error())
+		}
+		if 0 < chunkIdx && len(records) < 1 {
+			break
+		}
+		if sampler != nil {
+			records = sampler.Filter(records)
+		}
+
+		view := NewView(filter.tx)
+		view.Header = header.Copy()
+		view.RecordSet = records
+		view.FileInfo = fileInfo
+		view.Filter = filter
+
+		if condition != nil {
+			if err = view.filter(ctx, condition); err != nil {
+				return true, err
+			}
+		}
+		if err = view.Select(ctx, entity.SelectClause.(parser.SelectClause)); err != nil {
+			return true, err
+		}
+
+		var sortValues []SortValues
+		if sorter != nil {
+			if err = view.OrderBy(ctx, query.OrderByClause.(parser.OrderByClause)); err != nil {
+				return true, err
+			}
+			sortValues = view.sortValuesInEachRecord
+			sorter.directions = view.sortDirections
+			sorter.nullPositions = view.sortNullPositions
+		}
+
+		if -1 < limit && limit < view.RecordLen() {
+			view.RecordSet = view.RecordSet[:limit]
+		}
+		if err = view.Fix(ctx); err != nil {
+			return true, err
+		}
+
+		if sorter != nil {
+			if chunkIdx == 0 {
+				w.header = view.Header
+			}
+			if err = sorter.Add(view.RecordSet, sortValues); err != nil {
+				return true, err
+			}
+		} else {
+			if err = w.Write(view); err != nil {
+				return true, err
+			}
+
+			if -1 < limit {
+				limit = limit - view.RecordLen()
+				if limit < 1 {
+					break
+				}
+			}
+		}
+
+		if eof {
+			break
+		}
+	}
+
+	if sorter != nil {
+		if err = sorter.Merge(ctx, limit, w.WriteRecords); err != nil {
+			return true, err
+		}
+	}
+	err = w.Flush()
+	return true, err
+}
+
+type streamWriter struct {
+	writer   io.Writer
+	fileInfo *FileInfo
+	flags    *cmd.Flags
+
+	header  Header
+	written bool
+}
+
+func newStreamWriter(writer io.Writer, fileInfo *FileInfo, flags *cmd.Flags) *streamWriter {
+	fileInfo.NoHeader = fileInfo.NoHeader || fileInfo.Format == cmd.LTSV
+	return &streamWriter{
+		writer:   writer,
+		fileInfo: fileInfo,
+		flags:    flags,
+	}
+}
+
+func (w *streamWriter) Write(view *View) error {
+	if view.RecordLen() < 1 && (w.written || w.fileInfo.NoHeader) {
+		return nil
+	}
+
+	if _, err := EncodeView(w.writer, view, w.fileInfo, w.flags); err != nil {
+		return err
+	}
+	// Each line is terminated when it is written, so that the records can be read by the following
+	// processes in a pipeline before the next records are written.
+	if _, err := w.writer.Write([]byte(w.fileInfo.LineBreak.Value())); err != nil {
+		return err
+	}
+	w.written = true
+
+	// Only the first records are written with a header and a byte order mark.
+	w.fileInfo.NoHeader = true
+	if w.fileInfo.Encoding == text.UTF8M {
+		w.fileInfo.Encoding = text.UTF8
+	}
+	return nil
+}
+
+func (w *streamWriter) WriteRecords(records RecordSet) error {
+	return w.Write(&View{
+		Header:    w.header,
+		RecordSet: records,
+	})
+}
+
+// Flush writes the header if nothing has been written.
+// If there is nothing to write, then only a line break is written as the end of the result.
+func (w *streamWriter) Flush() error {
+	if w.written {
+		return nil
+	}
+	if err := w.WriteRecords(RecordSet{}); err != nil || w.written {
+		return err
+	}
+	_, err := w.writer.Write([]byte(w.fileInfo.LineBreak.Value()))
+	return err
+}
+
+// openStreamReader opens the file of the table and returns a reader to read the records, and the header of the table.
+func openStreamReader(ctx context.Context, filter *Filter, table parser.Table, fileInfo *FileInfo) (*os.File, *conditionRecordReader, Header, error) {
+	fp, csvReader, fields, err := openCSVReaderForStream(ctx, filter.tx, table.Object.(parser.Identifier), fileInfo)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	reader := newConditionRecordReader(csvReader, filter.tx.Flags.DatetimeFormat)
+	if fileInfo.NoHeader {
+		fields = make([]string, reader.FieldsPerRecord())
+		for i := range fields {
+			fields[i] = "c" + strconv.Itoa(i+1)
+		}
+	}
+	header := NewHeader(parser.FormatTableName(fileInfo.Path), fields)
+	if !strings.EqualFold(parser.FormatTableName(fileInfo.Path), table.Name().Literal) {
+		if err = header.Update(table.Name().Literal, nil); err != nil {
+			_ = fp.Close()
+			return nil, nil, nil, err
+		}
+	}
+	if table.Columns != nil {
+		if err = header.Update(table.Name().Literal, table.Columns); err != nil {
+			if _, ok := err.(*FieldLengthNotMatchError); ok {
+				err = NewTableColumnAliasesLengthError(table, header.Len())
+			}
+			_ = fp.Close()
+			return nil, nil, nil, err
+		}
+	}
+	return fp, reader, header, nil
+}
+
+// openFileForStream opens another descriptor of the file while holding the read lock, and releases
+// the lock so that subqueries and functions can load the same file during streaming.
+// Files are updated by replacing them, so the descriptor keeps reading the same contents.
+func openFileForStream(ctx context.Context, tx *Transaction, tableIdentifier parser.Identifier, fpath string) (*os.File, error) {
+	h, err := file.NewHandlerForRead(ctx, tx.FileContainer, fpath, tx.WaitTimeout, tx.RetryDelay)
+	if err != nil {
+		return nil, ConvertFileHandlerError(err, tableIdentifier, fpath)
+	}
+
+	fp, err := os.Open(fpath)
+	if e := tx.FileContainer.Close(h); e != nil {
+		if fp != nil {
+			_ = fp.Close()
+		}
+		return nil, e
+	}
+	if err != nil {
+		return nil, ConvertFileHandlerError(err, tableIdentifier, fpath)
+	}
+	return fp, nil
+}
+
+// openCSVReaderForStream opens the csv or tsv file and returns a reader to read the records,
+// and the fields in the header line unless the file has no header.
+func openCSVReaderForStream(ctx context.Context, tx *Transaction, tableIdentifier parser.Identifier, fileInfo *FileInfo) (*os.File, *csv.Reader, []string, error) {
+	fp, err := openFileForStream(ctx, tx, tableIdentifier, fileInfo.Path)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if enc, e := text.DetectEncoding(fp); e == nil {
+		fileInfo.Encoding = enc
+	}
+	reader, err := csv.NewReader(fp, fileInfo.Encoding)
+	if err != nil {
+		_ = fp.Close()
+		return nil, nil, nil, NewDataParsingError(tableIdentifier, fileInfo.Path, err.Error())
+	}
+	reader.Delimiter = fileInfo.Delimiter
+	reader.WithoutNull = tx.Flags.WithoutNull
+
+	var fields []string
+	if !fileInfo.NoHeader {
+		fields, err = reader.ReadHeader()
+		if err != nil && err != io.EOF {
+			_ = fp.Close()
+			return nil, nil, nil, NewDataParsingError(tableIdentifier, fileInfo.Path, err.Error())
+		}
+	}
+	return fp, reader, fields, nil
+}
+
+func streamableQuery(filter *Filter, query parser.SelectQuery) (parser.SelectEntity, parser.Table, bool) {
+	var entity parser.SelectEntity
+	var table parser.Table
+
+	if query.WithClause != nil || query.OffsetClause != nil {
+		return entity, table, false
+	}
+	if query.OrderByClause != nil {
+		// Records are sorted in streaming mode only when the memory size for sorting is limited.
+		if filter.tx.Flags.SortBufferSize < 0 {
+			return entity, table, false
+		}
+		for _, item := range query.OrderByClause.(parser.OrderByClause).Items {
+			if !isStreamableExpression(filter, item.(parser.OrderItem).Value) {
+				return entity, table, false
+			}
+		}
+	}
+	if query.LimitClause != nil {
+		clause := query.LimitClause.(parser.LimitClause)
+		if clause.IsPercentage() || clause.IsWithTies() {
+			return entity, table, false
+		}
+	}
+
+	entity, ok := query.SelectEntity.(parser.SelectEntity)
+	if !ok || entity.FromClause == nil || entity.GroupByClause != nil || entity.HavingClause != nil {
+		return entity, table, false
+	}
+
+	tables := entity.FromClause.(parser.FromClause).Tables
+	if len(tables) != 1 {
+		return entity, table, false
+	}
+	table, ok = tables[0].(parser.Table)
+	if !ok {
+		return entity, table, false
+	}
+	// A number of records cannot be sampled until all the records are read.
+	if table.Sample != nil && !table.Sample.(parser.TableSample).IsPercentage() {
+		return entity, table, false
+	}
+	ident, ok := table.Object.(parser.Identifier)
+	if !ok || filter.inlineTables.Exists(ident) || filter.tempViews.Exists(ident.Literal) {
+		return entity, table, false
+	}
+
+	selectClause := entity.SelectClause.(parser.SelectClause)
+	if selectClause.IsDistinct() || selectClause.DistinctOn != nil {
+		return entity, table, false
+	}
+	for _, f := range selectClause.Fields {
+		if !isStreamableExpression(filter, f.(parser.Field).Object) {
+			return entity, table, false
+		}
+	}
+	if entity.WhereClause != nil && !isStreamableExpression(filter, entity.WhereClause.(parser.WhereClause).Filter) {
+		return entity, table, false
+	}
+
+	return entity, table, true
+}
+
+func streamableFileInfo(filter *Filter, tableIdentifier parser.Identifier) (*FileInfo, bool) {
+	if filter.tx.checkFilePath(tableIdentifier) != nil {
+		return nil, false
+	}
+	flags := filter.tx.Flags
+
+	fileInfo, err := NewFileInfo(tableIdentifier, flags.Repository, cmd.AutoSelect, flags.Delimiter, flags.Encoding, flags)
+	if err != nil || (fileInfo.Format != cmd.CSV && fileInfo.Format != cmd.TSV) {
+		return nil, false
+	}
+
+	fileInfo.DelimiterPositions = flags.DelimiterPositions
+	fileInfo.SingleLine = flags.SingleLine
+	fileInfo.JsonQuery = strings.TrimSpace(flags.JsonQuery)
+	fileInfo.LineBreak = flags.LineBreak
+	fileInfo.NoHeader = flags.NoHeader
+	fileInfo.EncloseAll = flags.EncloseAll
+	fileInfo.JsonEscape = flags.JsonEscape
+
+	// Views already loaded in the transaction may contain uncommitted changes.
+	if filter.tx.cachedViews.Exists(fileInfo.Path) {
+		return nil, false
+	}
+	if file.IsEncryptedFile(fileInfo.Path) {
+		return nil, false
+	}
+	if filter.tx.sharedViews != nil {
+		if _, ok := filter.tx.sharedViews.Get(fileInfo.Path, sharedViewOptions(fileInfo, flags.WithoutNull)); ok {
+			return nil, false
+		}
+	}
+	return fileInfo, true
+}
+
+// isStreamableExpression reports whether the expression can be evaluated for each record
+// independently of the other records in the view.
+func isStreamableExpression(filter *Filter, expr parser.QueryExpression) bool {
+	if expr == nil {
+		return true
+	}
+
+	switch e := expr.(type) {
+	case parser.PrimitiveType, parser.Placeholder, parser.FieldReference, parser.ColumnNumber, parser.AllColumns,
+		parser.Variable, parser.EnvironmentVariable, parser.RuntimeInformation, parser.Subquery, parser.Exists:
+		return true
+	case parser.Parentheses:
+		return isStreamableExpression(filter, e.Expr)
+	case parser.RowValue:
+		return isStreamableExpression(filter, e.Value)
+	case parser.ValueList:
+		return isStreamableExpressions(filter, e.Values)
+	case parser.RowValueList:
+		return isStreamableExpressions(filter, e.RowValues)
+	case parser.ArrayConstructor:
+		return isStreamableExpressions(filter, e.Values)
+	case parser.ArrayElement:
+		return isStreamableExpression(filter, e.Array) && isStreamableExpression(filter, e.Index)
+	case parser.Arithmetic:
+		return isStreamableExpression(filter, e.LHS) && isStreamableExpression(filter, e.RHS)
+	case parser.UnaryArithmetic:
+		return isStreamableExpression(filter, e.Operand)
+	case parser.Concat:
+		return isStreamableExpressions(filter, e.Items)
+	case parser.Comparison:
+		return isStreamableExpression(filter, e.LHS) && isStreamableExpression(filter, e.RHS)
+	case parser.Is:
+		return isStreamableExpression(filter, e.LHS) && isStreamableExpression(filter, e.RHS)
+	case parser.Between:
+		return isStreamableExpression(filter, e.LHS) && isStreamableExpression(filter, e.Low) && isStreamableExpression(filter, e.High)
+	case parser.In:
+		return isStreamableExpression(filter, e.LHS) && isStreamableExpression(filter, e.Values)
+	case parser.Any:
+		return isStreamableExpression(filter, e.LHS) && isStreamableExpression(filter, e.Values)
+	case parser.All:
+		return isStreamableExpression(filter, e.LHS) && isStreamableExpression(filter, e.Values)
+	case parser.Like:
+		return isStreamableExpression(filter, e.LHS) && isStreamableExpression(filter, e.Pattern)
+	case parser.Logic:
+		return isStreamableExpression(filter, e.LHS) && isStreamableExpression(filter, e.RHS)
+	case parser.UnaryLogic:
+		return isStreamableExpression(filter, e.Operand)
+	case parser.VariableSubstitution:
+		return isStreamableExpression(filter, e.Value)
+	case parser.CaseExpr:
+		return isStreamableExpression(filter, e.Value) && isStreamableExpressions(filter, e.When) && isStreamableExpression(filter, e.Else)
+	case parser.CaseExprWhen:
+		return isStreamableExpression(filter, e.Condition) && isStreamableExpression(filter, e.Result)
+	case parser.CaseExprElse:
+		return isStreamableExpression(filter, e.Result)
+	case parser.Function:
+		name := strings.ToUpper(e.Name)
+		if _, ok := AggregateFunctions[name]; ok || name == "GROUPING" {
+			return false
+		}
+		if udfn, err := filter.functions.Get(e, e.Name); err == nil && udfn.IsAggregate {
+			return false
+		}
+		return isStreamableExpressions(filter, e.Args)
+	}
+	return false
+}
+
+func isStreamableExpressions(filter *Filter, exprs []parser.QueryExpression) bool {
+	for _, expr := range exprs {
+		if !isStreamableExpression(filter, expr) {
+			return false
+		}
+	}
+	return true
+}
+
+// recordChunkReader reads records in a goroutine, and passes them in chunks.
+type recordChunkReader struct {
+	mtx        sync.Mutex
+	records    RecordSet
+	bufferSize int
+	eof        bool
+	err        error
+
+	// Notified when records are added to the buffer.
+	ready chan struct{}
+	// Notified when records are taken from the buffer.
+	taken chan struct{}
+	done  chan struct{}
+}
+
+func newRecordChunkReader(reader RecordReader, bufferSize int) *recordChunkReader {
+	r := &recordChunkReader{
+		records:    make(RecordSet, 0, bufferSize),
+		bufferSize: bufferSize,
+		ready:      make(chan struct{}, 1),
+		taken:      make(chan struct{}, 1),
+		done:       make(chan struct{}),
+	}
+	go r.readAll(reader)
+	return r
+}
+
+func notify(c chan struct{}) {
+	select {
+	case c <- struct{}{}:
+	default:
+	}
+}
+
+func (r *recordChunkReader) readAll(reader RecordReader) {
+	defer notify(r.ready)
+
+	for {
+		row, err := reader.Read()
+		if err != nil {
+			r.mtx.Lock()
+			if err == io.EOF {
+				r.eof = true
+			} else {
+				r.err = err
+			}
+			r.mtx.Unlock()
+			return
+		}
+
+		fields := make([]value.Primary, len(row))
+		for i, v := range row {
+			if v == nil {
+				fields[i] = value.NewNull()
+			} else {
+				fields[i] = value.NewString(string(v))
+			}
+		}
+
+		r.mtx.Lock()
+		for r.bufferSize <= len(r.records) {
+			r.mtx.Unlock()
+			select {
+			case <-r.taken:
+			case <-r.done:
+				return
+			}
+			r.mtx.Lock()
+		}
+		r.records = append(r.records, NewRecord(fields))
+		r.mtx.Unlock()
+		notify(r.ready)
+	}
+}
+
+// Read returns the records read until the chunk is filled or StreamFlushInterval has passed since
+// the first record of the chunk was read, and whether all the records have been read.
+func (r *recordChunkReader) Read(ctx context.Context, size int) (RecordSet, bool, error) {
+	records := make(RecordSet, 0, size)
+
+	var timeout <-chan time.Time
+	for {
+		r.mtx.Lock()
+		n := len(r.records)
+		if size-len(records) < n {
+			n = size - len(records)
+		}
+		records = append(records, r.records[:n]...)
+		r.records = append(r.records[:0], r.records[n:]...)
+		eof, err := r.eof && len(r.records) < 1, r.err
+		r.mtx.Unlock()
+		notify(r.taken)
+
+		if err != nil {
+			return nil, false, err
+		}
+		if eof || size <= len(records) {
+			return records, eof, nil
+		}
+
+		if timeout == nil && 0 < len(records) {
+			timer := time.NewTimer(StreamFlushInterval)
+			defer timer.Stop()
+			timeout = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, false, NewContextIsDone(ctx.Err().Error())
+		case <-timeout:
+			return records, false, nil
+		case <-r.ready:
+		}
+	}
+}
+
+// Close stops reading the records.
+func (r *recordChunkReader) Close() {
+	close(r.done)
+}
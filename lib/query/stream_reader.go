@@ -0,0 +1,155 @@
+package query
+
+import (
+	"github.com/mithrandie/csvq/lib/parser"
+	"github.com/mithrandie/csvq/lib/value"
+
+	"github.com/mithrandie/go-text"
+	"github.com/mithrandie/go-text/csv"
+	"github.com/mithrandie/ternary"
+)
+
+// readerCondition is a comparison between a field and a constant value
+// that can be evaluated with the raw text of a record before the record is created.
+type readerCondition struct {
+	FieldIndex int
+	Operator   string
+	Value      value.Primary
+
+	// FieldIsRHS is true if the field is on the right-hand side of the operator.
+	FieldIsRHS bool
+}
+
+func (c readerCondition) Match(row []text.RawText, datetimeFormat []string) bool {
+	if len(row) <= c.FieldIndex {
+		return true
+	}
+
+	var p value.Primary
+	if row[c.FieldIndex] == nil {
+		p = value.NewNull()
+	} else {
+		p = value.NewString(string(row[c.FieldIndex]))
+	}
+
+	var t ternary.Value
+	if c.FieldIsRHS {
+		t = value.Compare(c.Value, p, c.Operator, datetimeFormat)
+	} else {
+		t = value.Compare(p, c.Value, c.Operator, datetimeFormat)
+	}
+	return t == ternary.TRUE
+}
+
+// conditionRecordReader reads records from a csv reader, and skips the records
+// that do not satisfy the conditions pushed down from the where clause.
+type conditionRecordReader struct {
+	reader         *csv.Reader
+	conditions     []readerCondition
+	datetimeFormat []string
+
+	peeked  bool
+	row     []text.RawText
+	peekErr error
+}
+
+func newConditionRecordReader(reader *csv.Reader, datetimeFormat []string) *conditionRecordReader {
+	return &conditionRecordReader{
+		reader:         reader,
+		datetimeFormat: datetimeFormat,
+	}
+}
+
+// FieldsPerRecord returns the number of fields in a record.
+// If no record has been read yet, then the first record is read in advance.
+func (r *conditionRecordReader) FieldsPerRecord() int {
+	if r.reader.FieldsPerRecord < 1 && !r.peeked {
+		r.row, r.peekErr = r.reader.Read()
+		r.peeked = true
+	}
+	return r.reader.FieldsPerRecord
+}
+
+// PushDown takes the conjuncts of the condition that compare a field in the header with a constant value,
+// and returns the rest of the conjuncts that must be evaluated for the records.
+func (r *conditionRecordReader) PushDown(header Header, condition parser.QueryExpression, caseSensitive bool) parser.QueryExpression {
+	if condition == nil || caseSensitive {
+		return condition
+	}
+
+	var residual []parser.QueryExpression
+	for _, expr := range splitConjunction(condition) {
+		if c, ok := newReaderCondition(header, expr); ok {
+			r.conditions = append(r.conditions, c)
+		} else {
+			residual = append(residual, expr)
+		}
+	}
+	return joinConjunction(residual)
+}
+
+func newReaderCondition(header Header, expr parser.QueryExpression) (readerCondition, bool) {
+	comparison, ok := expr.(parser.Comparison)
+	if !ok || !comparison.Collation.IsEmpty() {
+		return readerCondition{}, false
+	}
+	if _, ok := reversedComparisonOperators[comparison.Operator]; !ok && comparison.Operator != "<>" {
+		return readerCondition{}, false
+	}
+
+	field, constant, fieldIsRHS := comparison.LHS, comparison.RHS, false
+	if _, ok := constant.(parser.PrimitiveType); !ok {
+		field, constant, fieldIsRHS = constant, field, true
+	}
+	pt, ok := constant.(parser.PrimitiveType)
+	if !ok {
+		return readerCondition{}, false
+	}
+	switch field.(type) {
+	case parser.FieldReference, parser.ColumnNumber:
+	default:
+		return readerCondition{}, false
+	}
+
+	idx, err := header.ContainsObject(field)
+	if err != nil {
+		return readerCondition{}, false
+	}
+	return readerCondition{
+		FieldIndex: idx,
+		Operator:   comparison.Operator,
+		Value:      pt.Value,
+		FieldIsRHS: fieldIsRHS,
+	}, true
+}
+
+func (r *conditionRecordReader) Read() ([]text.RawText, error) {
+	for {
+		row, err := r.read()
+		if err != nil {
+			return row, err
+		}
+		if r.match(row) {
+			return row, nil
+		}
+	}
+}
+
+func (r *conditionRecordReader) read() ([]text.RawText, error) {
+	if r.peeked {
+		r.peeked = false
+		row, err := r.row, r.peekErr
+		r.row, r.peekErr = nil, nil
+		return row, err
+	}
+	return r.reader.Read()
+}
+
+func (r *conditionRecordReader) match(row []text.RawText) bool {
+	for _, c := range r.conditions {
+		if !c.Match(row, r.datetimeFormat) {
+			return false
+		}
+	}
+	return true
+}
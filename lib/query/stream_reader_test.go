@@ -0,0 +1,147 @@
+package query
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mithrandie/csvq/lib/parser"
+	"github.com/mithrandie/csvq/lib/value"
+
+	"github.com/mithrandie/go-text"
+	"github.com/mithrandie/go-text/csv"
+)
+
+var conditionRecordReaderTests = []struct {
+	Name          string
+	Data          string
+	Condition     parser.QueryExpression
+	CaseSensitive bool
+	Residual      parser.QueryExpression
+	Result        [][]string
+}{
+	{
+		Name: "ConditionRecordReader",
+		Data: "1,a\n2,b\n3,c\n",
+		Condition: parser.Logic{
+			LHS: parser.Comparison{
+				LHS:      parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+				RHS:      parser.NewIntegerValue(1),
+				Operator: ">",
+			},
+			RHS: parser.Comparison{
+				LHS:      parser.NewStringValue("c"),
+				RHS:      parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+				Operator: "<>",
+			},
+			Operator: parser.Token{Token: parser.AND, Literal: "and"},
+		},
+		Result: [][]string{{"2", "b"}},
+	},
+	{
+		Name: "ConditionRecordReader Residual Conditions",
+		Data: "1,a\n2,b\n3,c\n",
+		Condition: parser.Logic{
+			LHS: parser.Comparison{
+				LHS:      parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+				RHS:      parser.NewIntegerValue(2),
+				Operator: "<=",
+			},
+			RHS: parser.Comparison{
+				LHS:      parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+				RHS:      parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+				Operator: "=",
+			},
+			Operator: parser.Token{Token: parser.AND, Literal: "and"},
+		},
+		Residual: parser.Comparison{
+			LHS:      parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+			RHS:      parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+			Operator: "=",
+		},
+		Result: [][]string{{"1", "a"}, {"2", "b"}},
+	},
+	{
+		Name: "ConditionRecordReader Case Sensitive",
+		Data: "1,a\n2,b\n",
+		Condition: parser.Comparison{
+			LHS:      parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+			RHS:      parser.NewStringValue("A"),
+			Operator: "=",
+		},
+		CaseSensitive: true,
+		Residual: parser.Comparison{
+			LHS:      parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+			RHS:      parser.NewStringValue("A"),
+			Operator: "=",
+		},
+		Result: [][]string{{"1", "a"}, {"2", "b"}},
+	},
+	{
+		Name: "ConditionRecordReader Field Not Exists",
+		Data: "1,a\n2,b\n",
+		Condition: parser.Comparison{
+			LHS:      parser.FieldReference{Column: parser.Identifier{Literal: "notexist"}},
+			RHS:      parser.NewIntegerValue(1),
+			Operator: "=",
+		},
+		Residual: parser.Comparison{
+			LHS:      parser.FieldReference{Column: parser.Identifier{Literal: "notexist"}},
+			RHS:      parser.NewIntegerValue(1),
+			Operator: "=",
+		},
+		Result: [][]string{{"1", "a"}, {"2", "b"}},
+	},
+}
+
+func TestConditionRecordReader(t *testing.T) {
+	header := NewHeader("table1", []string{"column1", "column2"})
+
+	for _, v := range conditionRecordReaderTests {
+		csvReader, _ := csv.NewReader(strings.NewReader(v.Data), text.UTF8)
+		reader := newConditionRecordReader(csvReader, nil)
+
+		if n := reader.FieldsPerRecord(); n != 2 {
+			t.Errorf("%s: fields per record = %d, want %d", v.Name, n, 2)
+		}
+
+		residual := reader.PushDown(header, v.Condition, v.CaseSensitive)
+		if !reflect.DeepEqual(residual, v.Residual) {
+			t.Errorf("%s: residual = %s, want %s", v.Name, residual, v.Residual)
+		}
+
+		var result [][]string
+		for {
+			row, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("%s: unexpected error %q", v.Name, err)
+			}
+			fields := make([]string, len(row))
+			for i := range row {
+				fields[i] = string(row[i])
+			}
+			result = append(result, fields)
+		}
+		if !reflect.DeepEqual(result, v.Result) {
+			t.Errorf("%s: result = %v, want %v", v.Name, result, v.Result)
+		}
+	}
+}
+
+func TestReaderCondition_Match(t *testing.T) {
+	c := readerCondition{
+		FieldIndex: 0,
+		Operator:   "=",
+		Value:      value.NewInteger(1),
+	}
+	if !c.Match([]text.RawText{text.RawText("1")}, nil) {
+		t.Error("Match(1) = false, want true")
+	}
+	if c.Match([]text.RawText{nil}, nil) {
+		t.Error("Match(NULL) = true, want false")
+	}
+}
@@ -0,0 +1,278 @@
+package query
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/mithrandie/csvq/lib/cmd"
+	"github.com/mithrandie/csvq/lib/parser"
+
+	"github.com/mithrandie/go-text"
+)
+
+var selectStreamTests = []struct {
+	Name           string
+	Query          string
+	Format         cmd.Format
+	NoHeader       bool
+	SortBufferSize int
+	Streamed       bool
+	Result         string
+	Error          string
+}{
+	{
+		Name:     "SelectStream",
+		Query:    "SELECT * FROM table1",
+		Format:   cmd.CSV,
+		Streamed: true,
+		Result:   "column1,column2\n1,str1\n2,str2\n3,str3\n",
+	},
+	{
+		Name:     "SelectStream Where Clause with Table Alias",
+		Query:    "SELECT t.column2, t.column1 * 2 AS double FROM table1 t WHERE t.column1 > 1",
+		Format:   cmd.TSV,
+		Streamed: true,
+		Result:   "column2\tdouble\nstr2\t4\nstr3\t6\n",
+	},
+	{
+		Name:     "SelectStream Table Column Aliases",
+		Query:    "SELECT a FROM table1 t(a, b) WHERE b = 'str2'",
+		Format:   cmd.CSV,
+		Streamed: true,
+		Result:   "a\n2\n",
+	},
+	{
+		Name:     "SelectStream Conditions Pushed Down to Reader",
+		Query:    "SELECT column2 FROM table1 WHERE column1 >= 2 AND 'str3' > column2 AND column2 = column2",
+		Format:   cmd.CSV,
+		Streamed: true,
+		Result:   "column2\nstr2\n",
+	},
+	{
+		Name:     "SelectStream Limit Clause",
+		Query:    "SELECT column1 FROM table1 LIMIT 3 - 1",
+		Format:   cmd.CSV,
+		Streamed: true,
+		Result:   "column1\n1\n2\n",
+	},
+	{
+		Name:     "SelectStream Limit Zero",
+		Query:    "SELECT column1 FROM table1 LIMIT 0",
+		Format:   cmd.CSV,
+		Streamed: true,
+		Result:   "column1\n",
+	},
+	{
+		Name:     "SelectStream Without Header",
+		Query:    "SELECT column2 FROM table1 WHERE column1 = 3",
+		Format:   cmd.CSV,
+		NoHeader: true,
+		Streamed: true,
+		Result:   "str3\n",
+	},
+	{
+		Name:     "SelectStream LTSV",
+		Query:    "SELECT column1 FROM table1 WHERE column1 <> 2",
+		Format:   cmd.LTSV,
+		Streamed: true,
+		Result:   "column1:1\ncolumn1:3\n",
+	},
+	{
+		Name:     "SelectStream Empty Result",
+		Query:    "SELECT column1 FROM table1 WHERE FALSE",
+		Format:   cmd.LTSV,
+		Streamed: true,
+		Result:   "\n",
+	},
+	{
+		Name:     "SelectStream Subquery Loading the Same File",
+		Query:    "SELECT column1 FROM table1 WHERE column1 = (SELECT MAX(column1) FROM table1)",
+		Format:   cmd.CSV,
+		Streamed: true,
+		Result:   "column1\n3\n",
+	},
+	{
+		Name:     "SelectStream Field Does Not Exist Error",
+		Query:    "SELECT notexist FROM table1",
+		Format:   cmd.CSV,
+		Streamed: true,
+		Error:    "[L:1 C:8] field notexist does not exist",
+	},
+	{
+		Name:   "SelectStream Aggregate Function",
+		Query:  "SELECT COUNT(*) + 1 FROM table1",
+		Format: cmd.CSV,
+	},
+	{
+		Name:   "SelectStream Analytic Function",
+		Query:  "SELECT column1, ROW_NUMBER() OVER () FROM table1",
+		Format: cmd.CSV,
+	},
+	{
+		Name:           "SelectStream Order By Clause",
+		Query:          "SELECT column2 FROM table1 ORDER BY column1 DESC",
+		Format:         cmd.CSV,
+		SortBufferSize: 0,
+		Streamed:       true,
+		Result:         "column2\nstr3\nstr2\nstr1\n",
+	},
+	{
+		Name:           "SelectStream Order By Clause with Limit Clause",
+		Query:          "SELECT column1 * -1 AS n FROM table1 ORDER BY n LIMIT 2",
+		Format:         cmd.CSV,
+		SortBufferSize: 0,
+		Streamed:       true,
+		Result:         "n\n-3\n-2\n",
+	},
+	{
+		Name:           "SelectStream Order By Clause Empty Result",
+		Query:          "SELECT column2 FROM table1 WHERE FALSE ORDER BY column1",
+		Format:         cmd.CSV,
+		SortBufferSize: 0,
+		Streamed:       true,
+		Result:         "column2\n",
+	},
+	{
+		Name:           "SelectStream Order By Clause Without Sort Buffer Size",
+		Query:          "SELECT column1 FROM table1 ORDER BY column1 DESC",
+		Format:         cmd.CSV,
+		SortBufferSize: -1,
+	},
+	{
+		Name:     "SelectStream Table Sample Percentage",
+		Query:    "SELECT column1 FROM table1 TABLESAMPLE (30 PERCENT) REPEATABLE (4) WHERE column1 > 1",
+		Format:   cmd.CSV,
+		Streamed: true,
+		Result:   "column1\n2\n",
+	},
+	{
+		Name:   "SelectStream Table Sample Number of Records",
+		Query:  "SELECT column1 FROM table1 TABLESAMPLE (2 ROWS)",
+		Format: cmd.CSV,
+	},
+	{
+		Name:   "SelectStream Join",
+		Query:  "SELECT * FROM table1, table2",
+		Format: cmd.CSV,
+	},
+	{
+		Name:   "SelectStream Unsupported Output Format",
+		Query:  "SELECT * FROM table1",
+		Format: cmd.TEXT,
+	},
+}
+
+func TestSelectStream(t *testing.T) {
+	defer func(size int) {
+		StreamChunkSize = size
+		_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+		initFlag(TestTx.Flags)
+	}(StreamChunkSize)
+
+	StreamChunkSize = 2
+	TestTx.Flags.Repository = TestDir
+
+	for _, v := range selectStreamTests {
+		_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+		TestTx.Flags.SortBufferSize = v.SortBufferSize
+
+		statements, _, err := parser.Parse(v.Query, "", nil, false)
+		if err != nil {
+			t.Fatalf("%s: unexpected parse error %q", v.Name, err)
+		}
+
+		buf := new(bytes.Buffer)
+		fileInfo := &FileInfo{
+			Format:    v.Format,
+			Delimiter: ',',
+			Encoding:  text.UTF8,
+			LineBreak: text.LF,
+			NoHeader:  v.NoHeader,
+		}
+
+		streamed, err := SelectStream(context.Background(), NewFilter(TestTx), statements[0].(parser.SelectQuery), buf, fileInfo)
+		if streamed != v.Streamed {
+			t.Errorf("%s: streamed = %t, want %t", v.Name, streamed, v.Streamed)
+		}
+		if err != nil {
+			if len(v.Error) < 1 {
+				t.Errorf("%s: unexpected error %q", v.Name, err)
+			} else if err.Error() != v.Error {
+				t.Errorf("%s: error %q, want error %q", v.Name, err.Error(), v.Error)
+			}
+			continue
+		}
+		if 0 < len(v.Error) {
+			t.Errorf("%s: no error, want error %q", v.Name, v.Error)
+			continue
+		}
+		if buf.String() != v.Result {
+			t.Errorf("%s: result = %q, want %q", v.Name, buf.String(), v.Result)
+		}
+	}
+}
+
+type blockingRecordReader struct {
+	rows chan []text.RawText
+}
+
+func (r *blockingRecordReader) Read() ([]text.RawText, error) {
+	row, ok := <-r.rows
+	if !ok {
+		return nil, io.EOF
+	}
+	return row, nil
+}
+
+func TestRecordChunkReader_Read(t *testing.T) {
+	defer func(interval time.Duration) {
+		StreamFlushInterval = interval
+	}(StreamFlushInterval)
+	StreamFlushInterval = 10 * time.Millisecond
+
+	rows := make(chan []text.RawText)
+	r := newRecordChunkReader(&blockingRecordReader{rows: rows}, 3)
+	defer r.Close()
+
+	rows <- []text.RawText{text.RawText("1")}
+	rows <- []text.RawText{text.RawText("2")}
+
+	// The records read so far are returned while the reader is blocked.
+	records, eof, err := r.Read(context.Background(), 3)
+	if err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	if len(records) != 2 || eof {
+		t.Errorf("records = %d, eof = %t, want %d, %t", len(records), eof, 2, false)
+	}
+
+	go func() {
+		for i := 3; i <= 6; i++ {
+			rows <- []text.RawText{text.RawText(strconv.Itoa(i))}
+		}
+		close(rows)
+	}()
+
+	records, eof, err = r.Read(context.Background(), 3)
+	if err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	if len(records) < 1 || records[0][0].Value().String() != "\"3\"" {
+		t.Errorf("records = %v, want records beginning with 3", records)
+	}
+
+	total := 2 + len(records)
+	for !eof {
+		if records, eof, err = r.Read(context.Background(), 3); err != nil {
+			t.Fatalf("unexpected error %q", err)
+		}
+		total += len(records)
+	}
+	if total != 6 {
+		t.Errorf("total records = %d, want %d", total, 6)
+	}
+}
@@ -0,0 +1,160 @@
+package query
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mithrandie/csvq/lib/cmd"
+	"github.com/mithrandie/csvq/lib/parser"
+	"github.com/mithrandie/csvq/lib/value"
+
+	"github.com/mithrandie/ternary"
+)
+
+// subqueryCache holds the results of the subqueries that do not refer to the records of the outer queries
+// so that those subqueries are executed only once in a statement.
+type subqueryCache struct {
+	mtx     *sync.Mutex
+	results map[*parser.BaseExpr]*subqueryResult
+}
+
+func newSubqueryCache() *subqueryCache {
+	return &subqueryCache{
+		mtx:     &sync.Mutex{},
+		results: make(map[*parser.BaseExpr]*subqueryResult),
+	}
+}
+
+func (c *subqueryCache) result(expr *parser.BaseExpr) *subqueryResult {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	r, ok := c.results[expr]
+	if !ok {
+		r = &subqueryResult{mtx: &sync.Mutex{}}
+		c.results[expr] = r
+	}
+	return r
+}
+
+type subqueryResult struct {
+	mtx *sync.Mutex
+
+	evaluated bool
+
+	// View is nil if the subquery refers to the records of the outer queries.
+	view *View
+
+	// Hash table of the records used to evaluate IN operators.
+	setBuilt bool
+	set      *ViewIndex
+	hasNull  bool
+}
+
+// selectSubquery returns the result of the subquery.
+// If the subquery can be executed without the records of the outer queries, then the result is
+// reused in the rest of the statement.
+func (f *Filter) selectSubquery(ctx context.Context, expr parser.Subquery) (*View, error) {
+	if r := f.uncorrelatedSubquery(ctx, expr); r != nil {
+		return r.view, nil
+	}
+	return Select(ctx, f, expr.Query)
+}
+
+// uncorrelatedSubquery returns the cached result of the subquery.
+// If the subquery refers to the records of the outer queries, or the result cannot be cached, then nil is returned.
+func (f *Filter) uncorrelatedSubquery(ctx context.Context, expr parser.Subquery) *subqueryResult {
+	if f.subqueries == nil || expr.BaseExpr == nil || f.recursiveTable != nil {
+		return nil
+	}
+
+	r := f.subqueries.result(expr.BaseExpr)
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if !r.evaluated {
+		r.evaluated = true
+
+		filter := &Filter{tx: f.tx}
+		filter.Merge(f)
+		filter.records = nil
+
+		// Any error, such as a reference to a field of the outer queries, means that the subquery must be
+		// executed for each record, where the error is reported again if it is not caused by the outer queries.
+		if view, err := Select(ctx, filter, expr.Query); err == nil {
+			r.view = view
+		}
+	}
+
+	if r.view == nil {
+		return nil
+	}
+	return r
+}
+
+// in returns whether the row value is included in the records of the result using a hash table.
+// If the hash table cannot determine the result, then false is returned as the second value.
+func (r *subqueryResult) in(rowValue value.RowValue, flags *cmd.Flags) (ternary.Value, bool) {
+	if len(rowValue) != r.view.FieldLen() {
+		return ternary.FALSE, false
+	}
+
+	r.mtx.Lock()
+	if !r.setBuilt {
+		r.setBuilt = true
+		fieldIndices := make([]int, r.view.FieldLen())
+		for i := range fieldIndices {
+			fieldIndices[i] = i
+		}
+		r.set = newJoinHashIndex(r.view.RecordSet, fieldIndices, flags)
+		r.hasNull = recordSetHasNull(r.view.RecordSet)
+	}
+	r.mtx.Unlock()
+
+	if r.set == nil {
+		return ternary.FALSE, false
+	}
+	for _, p := range rowValue {
+		if value.IsNull(p) {
+			return ternary.FALSE, false
+		}
+	}
+
+	indices, ok := r.set.Lookup(rowValue, flags)
+	if !ok {
+		return ternary.FALSE, false
+	}
+	if 0 < len(indices) {
+		for _, i := range indices {
+			record := r.view.RecordSet[i]
+			values := make(value.RowValue, len(record))
+			for j, cell := range record {
+				values[j] = cell.Value()
+			}
+			if t, err := value.CompareRowValues(rowValue, values, "=", flags.DatetimeFormat); err == nil && t == ternary.TRUE {
+				return ternary.TRUE, true
+			}
+		}
+		return ternary.FALSE, false
+	}
+
+	if r.hasNull {
+		if len(rowValue) == 1 {
+			return ternary.UNKNOWN, true
+		}
+		return ternary.FALSE, false
+	}
+	return ternary.FALSE, true
+}
+
+func recordSetHasNull(records RecordSet) bool {
+	for _, record := range records {
+		for _, cell := range record {
+			if value.IsNull(cell.Value()) {
+				return true
+			}
+		}
+	}
+	return false
+}
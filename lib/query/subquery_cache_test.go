@@ -0,0 +1,188 @@
+package query
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/mithrandie/csvq/lib/parser"
+	"github.com/mithrandie/csvq/lib/value"
+
+	"github.com/mithrandie/ternary"
+)
+
+var filterSelectSubqueryTests = []struct {
+	Query        string
+	Result       []value.Primary
+	Uncorrelated int
+	Correlated   int
+}{
+	{
+		Query:        "SELECT column1 FROM table1 WHERE column1 IN (SELECT column3 FROM table2)",
+		Result:       []value.Primary{value.NewString("2"), value.NewString("3")},
+		Uncorrelated: 1,
+	},
+	{
+		Query:        "SELECT column1 FROM table1 WHERE column1 NOT IN (SELECT column3 FROM table2)",
+		Result:       []value.Primary{value.NewString("1")},
+		Uncorrelated: 1,
+	},
+	{
+		Query:        "SELECT column1 FROM table1 WHERE column1 NOT IN (SELECT NULL UNION SELECT 2)",
+		Result:       []value.Primary{},
+		Uncorrelated: 1,
+	},
+	{
+		Query:        "SELECT column1 FROM table1 WHERE (column1, column2) IN (SELECT column3, 'str2' FROM table2)",
+		Result:       []value.Primary{value.NewString("2")},
+		Uncorrelated: 1,
+	},
+	{
+		Query:      "SELECT column1 FROM table1 WHERE EXISTS (SELECT 1 FROM table2 WHERE column3 = column1)",
+		Result:     []value.Primary{value.NewString("2"), value.NewString("3")},
+		Correlated: 1,
+	},
+	{
+		Query:        "SELECT column1 FROM table1 WHERE EXISTS (SELECT 1 FROM table2 WHERE column3 = 4)",
+		Result:       []value.Primary{value.NewString("1"), value.NewString("2"), value.NewString("3")},
+		Uncorrelated: 1,
+	},
+	{
+		Query:        "SELECT column1 FROM table1 WHERE column1 = (SELECT MIN(column3) FROM table2 WHERE column3 IN (SELECT column1 FROM table1))",
+		Result:       []value.Primary{value.NewString("2")},
+		Uncorrelated: 2,
+	},
+}
+
+func TestFilter_SelectSubquery(t *testing.T) {
+	defer func() {
+		_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+		initFlag(TestTx.Flags)
+	}()
+
+	TestTx.Flags.Repository = TestDir
+
+	for _, v := range filterSelectSubqueryTests {
+		_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+
+		statements, _, err := parser.Parse(v.Query, "", nil, false)
+		if err != nil {
+			t.Fatalf("%s: unexpected parse error %q", v.Query, err)
+		}
+
+		filter := NewFilter(TestTx).CreateNode()
+		view, err := Select(context.Background(), filter, statements[0].(parser.SelectQuery))
+		if err != nil {
+			t.Errorf("%s: unexpected error %q", v.Query, err)
+			continue
+		}
+
+		result := make([]value.Primary, 0, view.RecordLen())
+		for _, record := range view.RecordSet {
+			result = append(result, record[0].Value())
+		}
+		if !reflect.DeepEqual(result, v.Result) {
+			t.Errorf("%s: result = %s, want %s", v.Query, result, v.Result)
+		}
+
+		uncorrelated := 0
+		correlated := 0
+		for _, r := range filter.subqueries.results {
+			if r.view == nil {
+				correlated++
+			} else {
+				uncorrelated++
+			}
+		}
+		if uncorrelated != v.Uncorrelated || correlated != v.Correlated {
+			t.Errorf("%s: cached subqueries = %d uncorrelated and %d correlated, want %d and %d", v.Query, uncorrelated, correlated, v.Uncorrelated, v.Correlated)
+		}
+	}
+}
+
+var subqueryResultInTests = []struct {
+	Name      string
+	RecordSet RecordSet
+	RowValue  value.RowValue
+	Result    ternary.Value
+	Available bool
+}{
+	{
+		Name: "Found",
+		RecordSet: RecordSet{
+			NewRecord([]value.Primary{value.NewInteger(1)}),
+			NewRecord([]value.Primary{value.NewInteger(2)}),
+		},
+		RowValue:  value.RowValue{value.NewString("2")},
+		Result:    ternary.TRUE,
+		Available: true,
+	},
+	{
+		Name: "Not Found",
+		RecordSet: RecordSet{
+			NewRecord([]value.Primary{value.NewInteger(1)}),
+			NewRecord([]value.Primary{value.NewInteger(2)}),
+		},
+		RowValue:  value.RowValue{value.NewInteger(3)},
+		Result:    ternary.FALSE,
+		Available: true,
+	},
+	{
+		Name: "Not Found with Null",
+		RecordSet: RecordSet{
+			NewRecord([]value.Primary{value.NewInteger(1)}),
+			NewRecord([]value.Primary{value.NewNull()}),
+		},
+		RowValue:  value.RowValue{value.NewInteger(3)},
+		Result:    ternary.UNKNOWN,
+		Available: true,
+	},
+	{
+		Name: "Null Value",
+		RecordSet: RecordSet{
+			NewRecord([]value.Primary{value.NewInteger(1)}),
+		},
+		RowValue:  value.RowValue{value.NewNull()},
+		Available: false,
+	},
+	{
+		Name: "Mixed Types",
+		RecordSet: RecordSet{
+			NewRecord([]value.Primary{value.NewInteger(1)}),
+			NewRecord([]value.Primary{value.NewString("a")}),
+		},
+		RowValue:  value.RowValue{value.NewInteger(1)},
+		Available: false,
+	},
+	{
+		Name: "Row Value Length Not Match",
+		RecordSet: RecordSet{
+			NewRecord([]value.Primary{value.NewInteger(1), value.NewString("a")}),
+		},
+		RowValue:  value.RowValue{value.NewInteger(1)},
+		Available: false,
+	},
+}
+
+func TestSubqueryResult_In(t *testing.T) {
+	for _, v := range subqueryResultInTests {
+		r := &subqueryResult{
+			mtx:       &sync.Mutex{},
+			evaluated: true,
+			view: &View{
+				Header:    NewHeader("t", make([]string, len(v.RecordSet[0]))),
+				RecordSet: v.RecordSet,
+			},
+		}
+
+		result, ok := r.in(v.RowValue, TestTx.Flags)
+		if ok != v.Available {
+			t.Errorf("%s: available = %t, want %t", v.Name, ok, v.Available)
+			continue
+		}
+		if ok && result != v.Result {
+			t.Errorf("%s: result = %s, want %s", v.Name, result, v.Result)
+		}
+	}
+}
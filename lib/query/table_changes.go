@@ -0,0 +1,62 @@
+package query
+
+import "strings"
+
+// TableChanges is the numbers of records changed in a file by the statements in a transaction.
+type TableChanges struct {
+	Inserted int
+	Replaced int
+	Updated  int
+	Deleted  int
+}
+
+func (c TableChanges) String() string {
+	list := make([]string, 0, 4)
+	if 0 < c.Inserted {
+		list = append(list, FormatCount(c.Inserted, "record")+" inserted")
+	}
+	if 0 < c.Replaced {
+		list = append(list, FormatCount(c.Replaced, "record")+" replaced")
+	}
+	if 0 < c.Updated {
+		list = append(list, FormatCount(c.Updated, "record")+" updated")
+	}
+	if 0 < c.Deleted {
+		list = append(list, FormatCount(c.Deleted, "record")+" deleted")
+	}
+	if len(list) < 1 {
+		return "no record changed"
+	}
+	return strings.Join(list, ", ")
+}
+
+type TableChangesMap map[string]*TableChanges
+
+func (m TableChangesMap) Add(path string, changes TableChanges) {
+	upath := strings.ToUpper(path)
+	if c, ok := m[upath]; ok {
+		c.Inserted += changes.Inserted
+		c.Replaced += changes.Replaced
+		c.Updated += changes.Updated
+		c.Deleted += changes.Deleted
+		return
+	}
+	m[upath] = &changes
+}
+
+func (m TableChangesMap) Get(path string) TableChanges {
+	if c, ok := m[strings.ToUpper(path)]; ok {
+		return *c
+	}
+	return TableChanges{}
+}
+
+func (m TableChangesMap) Dispose(path string) {
+	delete(m, strings.ToUpper(path))
+}
+
+func (m TableChangesMap) Clean() {
+	for k := range m {
+		delete(m, k)
+	}
+}
@@ -0,0 +1,283 @@
+package query
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mithrandie/csvq/lib/cmd"
+	"github.com/mithrandie/csvq/lib/parser"
+	"github.com/mithrandie/csvq/lib/value"
+
+	"github.com/mithrandie/ternary"
+)
+
+const (
+	tableConstraintsVersion   = 1
+	tableConstraintsExtension = ".csvq-constraints"
+)
+
+// tableConstraints is the content of the sidecar file holding the column constraints of a file.
+// The constraints are written in the syntax of column definitions.
+type tableConstraints struct {
+	Version     int      `json:"version"`
+	Constraints []string `json:"constraints"`
+}
+
+// ConstraintsFilePath returns the path of the sidecar file holding the column constraints of the file.
+func ConstraintsFilePath(fpath string) string {
+	return filepath.Join(filepath.Dir(fpath), "."+filepath.Base(fpath)+tableConstraintsExtension)
+}
+
+// LoadTableConstraints reads the column constraints of the file from the sidecar file.
+// If the sidecar file does not exist, then nil is returned.
+func LoadTableConstraints(fpath string) ([]parser.ColumnConstraint, error) {
+	b, err := ioutil.ReadFile(ConstraintsFilePath(fpath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	c := &tableConstraints{}
+	if err = json.Unmarshal(b, c); err != nil {
+		return nil, err
+	}
+	if c.Version != tableConstraintsVersion {
+		return nil, errors.New("unsupported version")
+	}
+	if len(c.Constraints) < 1 {
+		return nil, nil
+	}
+
+	statements, _, err := parser.Parse("CREATE TABLE t ("+strings.Join(c.Constraints, ", ")+")", "", nil, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(statements) != 1 {
+		return nil, errors.New("invalid constraints")
+	}
+	ct, ok := statements[0].(parser.CreateTable)
+	if !ok || len(ct.Constraints) != len(c.Constraints) {
+		return nil, errors.New("invalid constraints")
+	}
+	return ct.Constraints, nil
+}
+
+// SaveTableConstraints writes the column constraints of the file to the sidecar file.
+// If the file has no constraints, then the sidecar file is removed.
+func SaveTableConstraints(fpath string, constraints []parser.ColumnConstraint) error {
+	if len(constraints) < 1 {
+		if err := os.Remove(ConstraintsFilePath(fpath)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	c := &tableConstraints{
+		Version:     tableConstraintsVersion,
+		Constraints: make([]string, 0, len(constraints)),
+	}
+	for _, constraint := range constraints {
+		c.Constraints = append(c.Constraints, constraint.String())
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	fp, err := ioutil.TempFile(filepath.Dir(fpath), "."+filepath.Base(fpath)+".*"+tableConstraintsExtension)
+	if err != nil {
+		return err
+	}
+	tmpPath := fp.Name()
+
+	_, err = fp.Write(b)
+	if e := fp.Close(); err == nil {
+		err = e
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, ConstraintsFilePath(fpath))
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+	}
+	return err
+}
+
+// ConstraintMap is the column constraints of files.
+//
+// The constraints of a file are read from the sidecar file when they are used for the first time,
+// and written to the sidecar file when the changes of the file are committed.
+type ConstraintMap map[string][]parser.ColumnConstraint
+
+func (m ConstraintMap) Set(path string, constraints []parser.ColumnConstraint) {
+	m[strings.ToUpper(path)] = constraints
+}
+
+func (m ConstraintMap) Dispose(path string) {
+	delete(m, strings.ToUpper(path))
+}
+
+// Load returns the constraints of the file, and reads them from the sidecar file if they are not loaded yet.
+func (m ConstraintMap) Load(path string) ([]parser.ColumnConstraint, error) {
+	upath := strings.ToUpper(path)
+	if constraints, ok := m[upath]; ok {
+		return constraints, nil
+	}
+
+	constraints, err := LoadTableConstraints(path)
+	if err != nil {
+		return nil, err
+	}
+	m[upath] = constraints
+	return constraints, nil
+}
+
+// Save writes the constraints of the file to the sidecar file if they are loaded.
+func (m ConstraintMap) Save(path string) error {
+	constraints, ok := m[strings.ToUpper(path)]
+	if !ok {
+		return nil
+	}
+	return SaveTableConstraints(path, constraints)
+}
+
+func (m ConstraintMap) DropColumns(path string, columns []string) error {
+	list, err := m.Load(path)
+	if err != nil {
+		return err
+	}
+
+	constraints := make([]parser.ColumnConstraint, 0, len(list))
+	for _, c := range list {
+		if !InStrSliceWithCaseInsensitive(c.Column.Literal, columns) {
+			constraints = append(constraints, c)
+		}
+	}
+	m.Set(path, constraints)
+	return nil
+}
+
+func (m ConstraintMap) RenameColumn(path string, old string, new parser.Identifier) error {
+	list, err := m.Load(path)
+	if err != nil {
+		return err
+	}
+
+	constraints := make([]parser.ColumnConstraint, len(list))
+	copy(constraints, list)
+	for i := range constraints {
+		if strings.EqualFold(constraints[i].Column.Literal, old) {
+			constraints[i].Column.Literal = new.Literal
+			constraints[i].Column.Quoted = new.Quoted
+		}
+	}
+	m.Set(path, constraints)
+	return nil
+}
+
+func (m ConstraintMap) Validate(ctx context.Context, filter *Filter, view *View, expr parser.Expression) error {
+	if view.FileInfo == nil {
+		return nil
+	}
+	if view.FileInfo.PrimaryKey != nil {
+		if err := validatePrimaryKey(view, expr, filter.tx.Flags); err != nil {
+			return err
+		}
+	}
+	if view.FileInfo.IsTemporary {
+		return nil
+	}
+	constraints, err := m.Load(view.FileInfo.Path)
+	if err != nil {
+		return NewLoadConstraintsError(expr, view.FileInfo.Path, err.Error())
+	}
+
+	table := parser.FormatTableName(view.FileInfo.Path)
+	for _, c := range constraints {
+		fieldIdx, err := view.Header.Contains(parser.FieldReference{Column: c.Column})
+		if err != nil {
+			continue
+		}
+
+		switch c.Type.Token {
+		case parser.NOT:
+			for _, record := range view.RecordSet {
+				if value.IsNull(record[fieldIdx].Value()) {
+					return NewNotNullConstraintViolationError(expr, c, table)
+				}
+			}
+		case parser.UNIQUE:
+			buf := new(bytes.Buffer)
+			keys := make(map[string]bool, view.RecordLen())
+			for _, record := range view.RecordSet {
+				p := record[fieldIdx].Value()
+				if value.IsNull(p) {
+					continue
+				}
+
+				buf.Reset()
+				SerializeKey(buf, p, filter.tx.Flags)
+				key := buf.String()
+				if keys[key] {
+					return NewUniqueConstraintViolationError(expr, c, table, p)
+				}
+				keys[key] = true
+			}
+		case parser.CHECK:
+			filterForLoop := NewFilterForSequentialEvaluation(filter, view)
+			for i := range view.RecordSet {
+				filterForLoop.records[0].recordIndex = i
+				p, err := filterForLoop.Evaluate(ctx, c.Condition)
+				if err != nil {
+					return err
+				}
+				if p.Ternary() == ternary.FALSE {
+					return NewCheckConstraintViolationError(expr, c, table, view.RecordSet[i][fieldIdx].Value())
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func validatePrimaryKey(view *View, expr parser.Expression, flags *cmd.Flags) error {
+	columns := view.FileInfo.PrimaryKey.Columns
+	fieldIndices := make([]int, 0, len(columns))
+	for _, column := range columns {
+		idx, err := view.Header.Contains(parser.FieldReference{Column: parser.Identifier{Literal: column}})
+		if err != nil {
+			return nil
+		}
+		fieldIndices = append(fieldIndices, idx)
+	}
+
+	table := parser.FormatTableName(view.FileInfo.Path)
+	buf := new(bytes.Buffer)
+	keys := make(map[string]bool, view.RecordLen())
+	values := make([]value.Primary, len(fieldIndices))
+	for _, record := range view.RecordSet {
+		for i, fieldIdx := range fieldIndices {
+			values[i] = record[fieldIdx].Value()
+			if value.IsNull(values[i]) {
+				return NewNotNullConstraintViolationError(expr, parser.ColumnConstraint{Column: parser.Identifier{Literal: columns[i]}}, table)
+			}
+		}
+
+		buf.Reset()
+		SerializeComparisonKeys(buf, values, flags)
+		key := buf.String()
+		if keys[key] {
+			return NewPrimaryKeyViolationError(expr, table, values)
+		}
+		keys[key] = true
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/mithrandie/csvq/lib/parser"
+	"github.com/mithrandie/csvq/lib/value"
+)
+
+var constraintMapValidateTests = []struct {
+	Name        string
+	Constraints []parser.ColumnConstraint
+	RecordSet   RecordSet
+	Error       string
+}{
+	{
+		Name: "ConstraintMap Validate",
+		Constraints: []parser.ColumnConstraint{
+			{Column: parser.Identifier{Literal: "column1"}, Type: parser.Token{Token: parser.NOT, Literal: "not"}},
+			{Column: parser.Identifier{Literal: "column1"}, Type: parser.Token{Token: parser.UNIQUE, Literal: "unique"}},
+			{
+				Column: parser.Identifier{Literal: "column2"},
+				Type:   parser.Token{Token: parser.CHECK, Literal: "check"},
+				Condition: parser.Comparison{
+					LHS:      parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+					RHS:      parser.NewIntegerValueFromString("0"),
+					Operator: ">",
+				},
+			},
+		},
+		RecordSet: RecordSet{
+			NewRecord([]value.Primary{value.NewInteger(1), value.NewInteger(1)}),
+			NewRecord([]value.Primary{value.NewInteger(2), value.NewNull()}),
+		},
+	},
+	{
+		Name: "ConstraintMap Validate Not Null Error",
+		Constraints: []parser.ColumnConstraint{
+			{Column: parser.Identifier{Literal: "column1"}, Type: parser.Token{Token: parser.NOT, Literal: "not"}},
+		},
+		RecordSet: RecordSet{
+			NewRecord([]value.Primary{value.NewInteger(1), value.NewInteger(1)}),
+			NewRecord([]value.Primary{value.NewNull(), value.NewInteger(2)}),
+		},
+		Error: "column column1 in table table1 cannot be null",
+	},
+	{
+		Name: "ConstraintMap Validate Unique Error",
+		Constraints: []parser.ColumnConstraint{
+			{Column: parser.Identifier{Literal: "column1"}, Type: parser.Token{Token: parser.UNIQUE, Literal: "unique"}},
+		},
+		RecordSet: RecordSet{
+			NewRecord([]value.Primary{value.NewInteger(1), value.NewInteger(1)}),
+			NewRecord([]value.Primary{value.NewNull(), value.NewInteger(2)}),
+			NewRecord([]value.Primary{value.NewNull(), value.NewInteger(3)}),
+			NewRecord([]value.Primary{value.NewString("1"), value.NewInteger(4)}),
+		},
+		Error: "value \"1\" is duplicated in column column1 in table table1",
+	},
+	{
+		Name: "ConstraintMap Validate Check Error",
+		Constraints: []parser.ColumnConstraint{
+			{
+				Column: parser.Identifier{Literal: "column2"},
+				Type:   parser.Token{Token: parser.CHECK, Literal: "check"},
+				Condition: parser.Comparison{
+					LHS:      parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+					RHS:      parser.NewIntegerValueFromString("0"),
+					Operator: ">",
+				},
+			},
+		},
+		RecordSet: RecordSet{
+			NewRecord([]value.Primary{value.NewInteger(1), value.NewInteger(1)}),
+			NewRecord([]value.Primary{value.NewInteger(2), value.NewInteger(0)}),
+		},
+		Error: "value 0 in table table1 violates the constraint column2 check (column2 > 0)",
+	},
+}
+
+func TestConstraintMap_Validate(t *testing.T) {
+	for _, v := range constraintMapValidateTests {
+		m := ConstraintMap{}
+		m.Set("/path/to/table1.csv", v.Constraints)
+
+		view := &View{
+			Header:    NewHeader("table1", []string{"column1", "column2"}),
+			RecordSet: v.RecordSet,
+			FileInfo:  &FileInfo{Path: "/path/to/table1.csv"},
+		}
+
+		err := m.Validate(context.Background(), NewFilter(TestTx), view, parser.Identifier{Literal: "table1"})
+		if err != nil {
+			if len(v.Error) < 1 {
+				t.Errorf("%s: unexpected error %q", v.Name, err)
+			} else if err.Error() != v.Error {
+				t.Errorf("%s: error %q, want error %q", v.Name, err.Error(), v.Error)
+			}
+			continue
+		}
+		if 0 < len(v.Error) {
+			t.Errorf("%s: no error, want error %q", v.Name, v.Error)
+		}
+	}
+}
+
+func TestConstraintMap_DropColumns(t *testing.T) {
+	m := ConstraintMap{}
+	m.Set("/path/to/table1.csv", []parser.ColumnConstraint{
+		{Column: parser.Identifier{Literal: "column1"}, Type: parser.Token{Token: parser.NOT, Literal: "not"}},
+		{Column: parser.Identifier{Literal: "column2"}, Type: parser.Token{Token: parser.UNIQUE, Literal: "unique"}},
+	})
+
+	m.DropColumns("/path/to/table1.csv", []string{"column1"})
+	expect := ConstraintMap{
+		"/PATH/TO/TABLE1.CSV": {
+			{Column: parser.Identifier{Literal: "column2"}, Type: parser.Token{Token: parser.UNIQUE, Literal: "unique"}},
+		},
+	}
+	if !reflect.DeepEqual(m, expect) {
+		t.Errorf("result = %v, want %v", m, expect)
+	}
+
+	m.DropColumns("/path/to/table1.csv", []string{"column2"})
+	expect = ConstraintMap{
+		"/PATH/TO/TABLE1.CSV": {},
+	}
+	if !reflect.DeepEqual(m, expect) {
+		t.Errorf("result = %v, want %v", m, expect)
+	}
+}
+
+func TestSaveTableConstraints(t *testing.T) {
+	fpath := GetTestFilePath("table_constraint_test.csv")
+	defer func() {
+		_ = os.Remove(ConstraintsFilePath(fpath))
+	}()
+
+	statements, _, _ := parser.Parse("CREATE TABLE t (c1 NOT NULL, `c 2` UNIQUE, c3 CHECK (c3 > 0 AND `c 2` <> 'a'))", "", nil, false)
+	constraints := statements[0].(parser.CreateTable).Constraints
+
+	if err := SaveTableConstraints(fpath, constraints); err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	result, err := LoadTableConstraints(fpath)
+	if err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	if len(result) != len(constraints) {
+		t.Fatalf("constraints = %v, want %v", result, constraints)
+	}
+	for i := range constraints {
+		if result[i].Column.Literal != constraints[i].Column.Literal || result[i].Type.Token != constraints[i].Type.Token || fmt.Sprint(result[i].Condition) != fmt.Sprint(constraints[i].Condition) {
+			t.Errorf("constraint = %s, want %s", result[i], constraints[i])
+		}
+	}
+
+	if err := SaveTableConstraints(fpath, nil); err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	if _, err := os.Stat(ConstraintsFilePath(fpath)); !os.IsNotExist(err) {
+		t.Errorf("sidecar file exists, want to be removed when the file has no constraints")
+	}
+	if result, err := LoadTableConstraints(fpath); err != nil || result != nil {
+		t.Errorf("constraints = %v, %v, want nil for no sidecar file", result, err)
+	}
+}
+
+func TestConstraintMap_Persistence(t *testing.T) {
+	fpath := GetTestFilePath("table_constraint_test.csv")
+	defer func() {
+		_ = TestTx.ReleaseResources()
+		_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+		TestTx.constraints = make(ConstraintMap, 4)
+		_ = os.Remove(fpath)
+		_ = os.Remove(ConstraintsFilePath(fpath))
+		initFlag(TestTx.Flags)
+	}()
+
+	TestTx.Flags.Repository = TestDir
+	TestTx.Flags.SetQuiet(true)
+
+	execute := func(query string) error {
+		statements, _, err := parser.Parse(query, "", nil, false)
+		if err != nil {
+			t.Fatalf("%s: unexpected parse error %q", query, err)
+		}
+		_, err = NewProcessor(TestTx).Execute(context.Background(), statements)
+		return err
+	}
+
+	if err := execute("CREATE TABLE `table_constraint_test.csv` (c1 NOT NULL, c2 UNIQUE); COMMIT;"); err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	if _, err := os.Stat(ConstraintsFilePath(fpath)); err != nil {
+		t.Fatalf("sidecar file is not saved: %s", err)
+	}
+
+	// Constraints of files are read from the sidecar files in later sessions.
+	TestTx.constraints = make(ConstraintMap, 4)
+	expect := "[L:1 C:13] column c1 in table table_constraint_test cannot be null"
+	if err := execute("INSERT INTO table_constraint_test VALUES (NULL, 1)"); err == nil || err.Error() != expect {
+		t.Errorf("error = %v, want error %q", err, expect)
+	}
+	if err := execute("ROLLBACK; INSERT INTO table_constraint_test VALUES (1, 1); COMMIT;"); err != nil {
+		t.Errorf("unexpected error %q", err)
+	}
+
+	// Changes of constraints are discarded by rollback.
+	if err := execute("ALTER TABLE table_constraint_test DROP c2; ROLLBACK;"); err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	expect = "[L:1 C:13] value 1 is duplicated in column c2 in table table_constraint_test"
+	if err := execute("INSERT INTO table_constraint_test VALUES (2, 1)"); err == nil || err.Error() != expect {
+		t.Errorf("error = %v, want error %q", err, expect)
+	}
+	if err := execute("ROLLBACK; ALTER TABLE table_constraint_test RENAME c2 TO `c 3`; COMMIT;"); err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	TestTx.constraints = make(ConstraintMap, 4)
+	expect = "[L:1 C:13] value 1 is duplicated in column `c 3` in table table_constraint_test"
+	if err := execute("INSERT INTO table_constraint_test VALUES (2, 1)"); err == nil || err.Error() != expect {
+		t.Errorf("error = %v, want error %q", err, expect)
+	}
+	_ = execute("ROLLBACK")
+}
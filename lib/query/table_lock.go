@@ -0,0 +1,64 @@
+package query
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mithrandie/csvq/lib/parser"
+)
+
+// LockTables loads the tables to be updated, so that the exclusive locks of the files are held
+// until the transaction is committed or rolled back, or the tables are unlocked.
+func (tx *Transaction) LockTables(ctx context.Context, filter *Filter, expr parser.LockTable) error {
+	for _, table := range expr.Tables {
+		view := NewView(tx)
+		view.ForUpdate = true
+		if err := view.Load(ctx, filter.CreateNode(), parser.FromClause{Tables: []parser.QueryExpression{parser.Table{Object: table}}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnlockTables releases the locks of the tables. If no table is specified, then the locks of all the tables are released.
+// Tables having uncommitted changes cannot be unlocked.
+func (tx *Transaction) UnlockTables(filter *Filter, expr parser.UnlockTable) error {
+	var keys []string
+	names := make(map[string]string)
+	if expr.Tables == nil {
+		for key, view := range tx.cachedViews {
+			if view.ForUpdate && !view.FileInfo.IsTemporary {
+				keys = append(keys, key)
+				names[key] = view.FileInfo.Path
+			}
+		}
+	} else {
+		keys = make([]string, 0, len(expr.Tables))
+		for _, table := range expr.Tables {
+			ident := table.(parser.Identifier)
+			if filter.tempViews.Exists(ident.Literal) {
+				continue
+			}
+
+			fpath, err := SearchFilePathFromAllTypes(ident, tx.Flags.Repository)
+			if err != nil {
+				return err
+			}
+			key := strings.ToUpper(fpath)
+			keys = append(keys, key)
+			names[key] = ident.Literal
+		}
+	}
+
+	for _, key := range keys {
+		if tx.uncommittedViews.Contains(key) {
+			return NewUnlockUncommittedTableError(expr, names[key])
+		}
+	}
+	for _, key := range keys {
+		if err := tx.cachedViews.Dispose(tx.FileContainer, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
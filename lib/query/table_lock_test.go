@@ -0,0 +1,69 @@
+package query
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/mithrandie/csvq/lib/parser"
+)
+
+func TestTransaction_LockTables(t *testing.T) {
+	fpath := GetTestFilePath("table_lock_test.csv")
+	key := strings.ToUpper(fpath)
+	defer func() {
+		_ = TestTx.Rollback(nil, nil)
+		_ = os.Remove(fpath)
+		initFlag(TestTx.Flags)
+	}()
+
+	if err := ioutil.WriteFile(fpath, []byte("c1,c2\n1,a"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	TestTx.Flags.Repository = TestDir
+	TestTx.Flags.SetQuiet(true)
+	TestTx.Session.Stdout = NewDiscard()
+
+	execute := func(src string) error {
+		statements, _, err := parser.Parse(src, "", nil, false)
+		if err != nil {
+			t.Fatalf("unexpected parse error %q", err)
+		}
+		_, err = NewProcessor(TestTx).Execute(context.Background(), statements)
+		return err
+	}
+
+	if err := execute("LOCK TABLE table_lock_test IN EXCLUSIVE MODE;"); err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	if view, ok := TestTx.cachedViews[key]; !ok || !view.ForUpdate || view.FileInfo.Handler == nil {
+		t.Fatalf("table is not locked after the lock statement")
+	}
+
+	if err := execute("SELECT * FROM table_lock_test; UNLOCK;"); err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	if _, ok := TestTx.cachedViews[key]; ok {
+		t.Fatalf("table is still locked after the unlock statement")
+	}
+
+	err := execute("LOCK TABLE table_lock_test IN EXCLUSIVE MODE; INSERT INTO table_lock_test VALUES (2, 'b'); UNLOCK TABLE table_lock_test;")
+	if err == nil {
+		t.Fatalf("no error, want error for unlocking the table having uncommitted changes")
+	} else if err.Error() != "[L:1 C:92] table table_lock_test cannot be unlocked before its changes are committed" {
+		t.Errorf("error = %q, want the error for unlocking the table having uncommitted changes", err.Error())
+	}
+	if _, ok := TestTx.cachedViews[key]; !ok {
+		t.Errorf("table is unlocked, want the lock to be held")
+	}
+
+	if err := execute("COMMIT; UNLOCK TABLE table_lock_test;"); err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	if b, _ := ioutil.ReadFile(fpath); string(b) != "c1,c2\n1,a\n2,b" {
+		t.Errorf("file = %q, want %q", string(b), "c1,c2\n1,a\n2,b")
+	}
+}
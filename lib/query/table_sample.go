@@ -0,0 +1,160 @@
+package query
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+
+	"github.com/mithrandie/csvq/lib/cmd"
+	"github.com/mithrandie/csvq/lib/parser"
+	"github.com/mithrandie/csvq/lib/value"
+)
+
+// tableSampler selects the records of a table specified by a TABLESAMPLE clause.
+//
+// Records are passed to the sampler one by one in the order of the table, so that the records
+// not selected can be discarded while the table is read.
+// A sample of a percentage selects each record with the probability, and a sample of a number of records
+// keeps the selected records in a reservoir of the size.
+type tableSampler struct {
+	random     *rand.Rand
+	percentage float64
+
+	// The number of records to be selected, or -1 if the records are selected by percentage
+	size int
+
+	count   int
+	indices []int
+	records RecordSet
+
+	// Whether the selected records have been taken
+	applied bool
+}
+
+// newTableSampler evaluates the TABLESAMPLE clause and returns the sampler.
+// If all the records are selected, then nil is returned.
+func newTableSampler(ctx context.Context, filter *Filter, sample parser.TableSample) (*tableSampler, error) {
+	val, err := filter.Evaluate(ctx, sample.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	random := cmd.GetRand()
+	if sample.Seed != nil {
+		s, err := filter.Evaluate(ctx, sample.Seed)
+		if err != nil {
+			return nil, err
+		}
+		seed := value.ToInteger(s)
+		if value.IsNull(seed) {
+			return nil, NewInvalidTableSampleSeedError(sample)
+		}
+		random = rand.New(rand.NewSource(seed.(value.Integer).Raw()))
+	}
+
+	if sample.IsPercentage() {
+		number := value.ToFloat(val)
+		if value.IsNull(number) {
+			return nil, NewInvalidTableSamplePercentageError(sample)
+		}
+		percentage := number.(value.Float).Raw()
+		if 100 <= percentage {
+			return nil, nil
+		}
+		return &tableSampler{
+			random:     random,
+			percentage: percentage,
+			size:       -1,
+			records:    make(RecordSet, 0, 1000),
+		}, nil
+	}
+
+	number := value.ToInteger(val)
+	if value.IsNull(number) {
+		return nil, NewInvalidTableSampleNumberError(sample)
+	}
+	n := int(number.(value.Integer).Raw())
+	if n < 0 {
+		n = 0
+	}
+	capacity := n
+	if 1000 < capacity {
+		capacity = 1000
+	}
+	return &tableSampler{
+		random:  random,
+		size:    n,
+		indices: make([]int, 0, capacity),
+		records: make(RecordSet, 0, capacity),
+	}, nil
+}
+
+func (s *tableSampler) IsPercentage() bool {
+	return s.size < 0
+}
+
+// Add passes the next record of the table to the sampler.
+func (s *tableSampler) Add(record Record) {
+	if s.IsPercentage() {
+		if s.selects() {
+			s.records = append(s.records, record)
+		}
+		return
+	}
+
+	i := s.count
+	s.count++
+	if i < s.size {
+		s.indices = append(s.indices, i)
+		s.records = append(s.records, record)
+		return
+	}
+	if j := s.random.Intn(i + 1); j < s.size {
+		s.indices[j] = i
+		s.records[j] = record
+	}
+}
+
+// RecordSet returns the selected records in the order of the table.
+func (s *tableSampler) RecordSet() RecordSet {
+	s.applied = true
+	if !s.IsPercentage() {
+		sort.Sort(sampledRecordSet{indices: s.indices, records: s.records})
+	}
+	return s.records
+}
+
+// Filter returns the records selected from the records by percentage.
+// The sampler can be applied to the records of a table read in several chunks.
+func (s *tableSampler) Filter(records RecordSet) RecordSet {
+	s.applied = true
+	selected := records[:0]
+	for _, record := range records {
+		if s.selects() {
+			selected = append(selected, record)
+		}
+	}
+	return selected
+}
+
+func (s *tableSampler) selects() bool {
+	return s.random.Float64()*100 < s.percentage
+}
+
+type sampledRecordSet struct {
+	indices []int
+	records RecordSet
+}
+
+func (r sampledRecordSet) Len() int {
+	return len(r.indices)
+}
+
+func (r sampledRecordSet) Less(i, j int) bool {
+	return r.indices[i] < r.indices[j]
+}
+
+func (r sampledRecordSet) Swap(i, j int) {
+	r.indices[i], r.indices[j] = r.indices[j], r.indices[i]
+	r.records[i], r.records[j] = r.records[j], r.records[i]
+}
@@ -0,0 +1,116 @@
+package query
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/mithrandie/csvq/lib/parser"
+	"github.com/mithrandie/csvq/lib/value"
+
+	"github.com/mithrandie/ternary"
+)
+
+func TestTableSampler(t *testing.T) {
+	records := make(RecordSet, 100)
+	for i := range records {
+		records[i] = NewRecord([]value.Primary{value.NewInteger(int64(i))})
+	}
+
+	for _, query := range []string{
+		"SELECT * FROM t TABLESAMPLE (30 PERCENT) REPEATABLE (5)",
+		"SELECT * FROM t TABLESAMPLE (10 ROWS) REPEATABLE (5)",
+	} {
+		statements, _, err := parser.Parse(query, "", nil, false)
+		if err != nil {
+			t.Fatalf("%s: unexpected parse error %q", query, err)
+		}
+		sample := statements[0].(parser.SelectQuery).SelectEntity.(parser.SelectEntity).FromClause.(parser.FromClause).Tables[0].(parser.Table).Sample.(parser.TableSample)
+
+		sampler, err := newTableSampler(context.Background(), NewFilter(TestTx), sample)
+		if err != nil {
+			t.Fatalf("%s: unexpected error %q", query, err)
+		}
+		for _, record := range records {
+			sampler.Add(record)
+		}
+		result := sampler.RecordSet()
+
+		// The records are selected in the same way as the records sampled in chunks.
+		sampler, _ = newTableSampler(context.Background(), NewFilter(TestTx), sample)
+		if sampler.IsPercentage() {
+			var chunks RecordSet
+			for i := 0; i < len(records); i += 7 {
+				end := i + 7
+				if len(records) < end {
+					end = len(records)
+				}
+				chunks = append(chunks, sampler.Filter(append(RecordSet{}, records[i:end]...))...)
+			}
+			if !reflect.DeepEqual(chunks, result) {
+				t.Errorf("%s: records sampled in chunks = %s, want %s", query, chunks, result)
+			}
+		} else if len(result) != 10 {
+			t.Errorf("%s: %d records are selected, want %d", query, len(result), 10)
+		}
+
+		for i := 1; i < len(result); i++ {
+			if value.Less(result[i-1][0].Value(), result[i][0].Value(), nil) != ternary.TRUE {
+				t.Errorf("%s: records = %s, want the records in the order of the table", query, result)
+				break
+			}
+		}
+	}
+}
+
+func TestSelectTableSample(t *testing.T) {
+	fpath := GetTestFilePath("table_sample_test.csv")
+	data := make([]string, 0, 101)
+	data = append(data, "c1")
+	for i := 0; i < 100; i++ {
+		data = append(data, strconv.Itoa(i))
+	}
+	if err := os.WriteFile(fpath, []byte(strings.Join(data, "\n")), 0644); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+		_ = os.Remove(fpath)
+		initFlag(TestTx.Flags)
+	}()
+
+	TestTx.Flags.Repository = TestDir
+
+	selectRecords := func(query string) RecordSet {
+		statements, _, err := parser.Parse(query, "", nil, false)
+		if err != nil {
+			t.Fatalf("%s: unexpected parse error %q", query, err)
+		}
+		view, err := Select(context.Background(), NewFilter(TestTx).CreateNode(), statements[0].(parser.SelectQuery))
+		if err != nil {
+			t.Fatalf("%s: unexpected error %q", query, err)
+		}
+		return view.RecordSet
+	}
+
+	for _, sample := range []string{"TABLESAMPLE (30 PERCENT) REPEATABLE (5)", "TABLESAMPLE (10 ROWS) REPEATABLE (5)"} {
+		_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+
+		sampled := selectRecords("SELECT c1 FROM table_sample_test " + sample)
+		if len(sampled) < 1 || 100 <= len(sampled) {
+			t.Fatalf("%s: %d records are selected, want a part of the records", sample, len(sampled))
+		}
+		if TestTx.cachedViews.Exists(fpath) {
+			t.Errorf("%s: sampled view is cached, want the records to be sampled while loading", sample)
+		}
+
+		// Views already loaded are sampled after loading, and the same records are selected.
+		_ = selectRecords("SELECT c1 FROM table_sample_test")
+		if result := selectRecords("SELECT c1 FROM table_sample_test " + sample); !reflect.DeepEqual(result, sampled) {
+			t.Errorf("%s: records sampled from the loaded view = %s, want %s", sample, result, sampled)
+		}
+	}
+}
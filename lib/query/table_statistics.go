@@ -0,0 +1,442 @@
+package query
+
+import (
+	"encoding/json"
+	"hash/fnv"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mithrandie/csvq/lib/cmd"
+	"github.com/mithrandie/csvq/lib/parser"
+	"github.com/mithrandie/csvq/lib/value"
+)
+
+const (
+	tableStatisticsVersion   = 1
+	tableStatisticsExtension = ".csvq-stats"
+)
+
+// TableStatistics is the statistics of the records loaded from a file.
+//
+// The statistics are saved in a sidecar file next to the file, and are valid while the modification time
+// and the size of the file and the options to load the file remain unchanged.
+type TableStatistics struct {
+	Version int    `json:"version"`
+	Options string `json:"options"`
+	ModTime int64  `json:"mod_time"`
+	Size    int64  `json:"size"`
+
+	Rows    int                `json:"rows"`
+	Columns []ColumnStatistics `json:"columns"`
+}
+
+// ColumnStatistics is the statistics of the values in a column.
+// Columns that are not loaded are not included in the statistics.
+type ColumnStatistics struct {
+	Number int    `json:"number"`
+	Name   string `json:"name"`
+	Nulls  int    `json:"nulls"`
+
+	// Estimated number of distinct values
+	Distinct int `json:"distinct"`
+
+	// Minimum and maximum values as numbers if all the values except nulls are numbers
+	Numeric   bool    `json:"numeric"`
+	MinNumber float64 `json:"min_number"`
+	MaxNumber float64 `json:"max_number"`
+}
+
+// StatisticsFilePath returns the path of the sidecar file holding the statistics of the file.
+func StatisticsFilePath(fpath string) string {
+	return filepath.Join(filepath.Dir(fpath), "."+filepath.Base(fpath)+tableStatisticsExtension)
+}
+
+// LoadTableStatistics reads the statistics of the file from the sidecar file.
+// If the sidecar file does not exist or the statistics are no longer valid, then nil is returned.
+func LoadTableStatistics(fpath string, options string, stat os.FileInfo) *TableStatistics {
+	b, err := ioutil.ReadFile(StatisticsFilePath(fpath))
+	if err != nil {
+		return nil
+	}
+
+	stats := &TableStatistics{}
+	if err = json.Unmarshal(b, stats); err != nil {
+		return nil
+	}
+	if stats.Version != tableStatisticsVersion || stats.Options != options || stats.ModTime != stat.ModTime().UnixNano() || stats.Size != stat.Size() {
+		return nil
+	}
+	return stats
+}
+
+// Save writes the statistics to the sidecar file of the file.
+func (stats *TableStatistics) Save(fpath string) error {
+	b, err := json.Marshal(stats)
+	if err != nil {
+		return err
+	}
+
+	fp, err := ioutil.TempFile(filepath.Dir(fpath), "."+filepath.Base(fpath)+".*"+tableStatisticsExtension)
+	if err != nil {
+		return err
+	}
+	tmpPath := fp.Name()
+
+	_, err = fp.Write(b)
+	if e := fp.Close(); err == nil {
+		err = e
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, StatisticsFilePath(fpath))
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+	}
+	return err
+}
+
+// Column returns the statistics of the column at the number in the file.
+func (stats *TableStatistics) Column(number int) (ColumnStatistics, bool) {
+	for _, c := range stats.Columns {
+		if c.Number == number {
+			return c, true
+		}
+	}
+	return ColumnStatistics{}, false
+}
+
+// hasColumns reports whether the statistics include all the columns loaded in the view.
+func (stats *TableStatistics) hasColumns(view *View) bool {
+	for i := range view.Header {
+		if i < len(view.prunedFields) && view.prunedFields[i] {
+			continue
+		}
+		if _, ok := stats.Column(i + 1); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// CollectTableStatistics returns the statistics of the records of the view loaded from a file.
+func CollectTableStatistics(view *View, options string, stat os.FileInfo) *TableStatistics {
+	stats := &TableStatistics{
+		Version: tableStatisticsVersion,
+		Options: options,
+		ModTime: stat.ModTime().UnixNano(),
+		Size:    stat.Size(),
+		Rows:    view.RecordLen(),
+		Columns: make([]ColumnStatistics, 0, view.FieldLen()),
+	}
+
+	hashes := make([]uint64, 0, view.RecordLen())
+	for i := range view.Header {
+		if i < len(view.prunedFields) && view.prunedFields[i] {
+			continue
+		}
+
+		column := ColumnStatistics{
+			Number:  i + 1,
+			Name:    view.Header[i].Column,
+			Numeric: true,
+		}
+
+		hashes = hashes[:0]
+		for _, record := range view.RecordSet {
+			p := record[i].Value()
+			if value.IsNull(p) {
+				column.Nulls++
+				continue
+			}
+			hashes = append(hashes, hashStatisticsValue(p))
+
+			if column.Numeric {
+				f := value.ToFloat(p)
+				if value.IsNull(f) || math.IsNaN(f.(value.Float).Raw()) || math.IsInf(f.(value.Float).Raw(), 0) {
+					column.Numeric = false
+					continue
+				}
+				n := f.(value.Float).Raw()
+				if len(hashes) == 1 || n < column.MinNumber {
+					column.MinNumber = n
+				}
+				if len(hashes) == 1 || column.MaxNumber < n {
+					column.MaxNumber = n
+				}
+			}
+		}
+
+		column.Distinct = countDistinctHashes(hashes)
+		if len(hashes) < 1 || !column.Numeric {
+			column.Numeric = false
+			column.MinNumber = 0
+			column.MaxNumber = 0
+		}
+		stats.Columns = append(stats.Columns, column)
+	}
+	return stats
+}
+
+// merge adds the columns of the other statistics that are not included in the statistics.
+func (stats *TableStatistics) merge(other *TableStatistics) {
+	for _, c := range other.Columns {
+		if _, ok := stats.Column(c.Number); !ok {
+			stats.Columns = append(stats.Columns, c)
+		}
+	}
+	sort.Slice(stats.Columns, func(i, j int) bool {
+		return stats.Columns[i].Number < stats.Columns[j].Number
+	})
+}
+
+// cacheTableStatistics returns the statistics of the view loaded from the file.
+// The statistics are read from the sidecar file, and if the sidecar file does not have the statistics of
+// the loaded columns, then the statistics are collected from the view, and saved if save is true.
+func cacheTableStatistics(view *View, options string, stat os.FileInfo, save bool) *TableStatistics {
+	stats := LoadTableStatistics(view.FileInfo.Path, options, stat)
+	if stats != nil && stats.hasColumns(view) {
+		return stats
+	}
+
+	collected := CollectTableStatistics(view, options, stat)
+	if stats != nil {
+		collected.merge(stats)
+	}
+	// The statistics are only a cache, so the file is read again next time if they cannot be saved.
+	if save {
+		_ = collected.Save(view.FileInfo.Path)
+	}
+	return collected
+}
+
+func hashStatisticsValue(p value.Primary) uint64 {
+	h := fnv.New64a()
+	if s, ok := p.(value.String); ok {
+		_, _ = h.Write([]byte(s.Raw()))
+	} else {
+		_, _ = h.Write([]byte(p.String()))
+	}
+	return h.Sum64()
+}
+
+func countDistinctHashes(hashes []uint64) int {
+	if len(hashes) < 1 {
+		return 0
+	}
+	sort.Slice(hashes, func(i, j int) bool { return hashes[i] < hashes[j] })
+
+	n := 1
+	for i := 1; i < len(hashes); i++ {
+		if hashes[i] != hashes[i-1] {
+			n++
+		}
+	}
+	return n
+}
+
+// viewStatistics returns the statistics of the file from which the view is loaded, or nil if not available.
+func viewStatistics(view *View) *TableStatistics {
+	if view == nil || view.FileInfo == nil {
+		return nil
+	}
+	return view.FileInfo.statistics
+}
+
+// columnStatistics returns the statistics of the column referred to by the field in the view.
+func columnStatistics(view *View, field parser.QueryExpression) (ColumnStatistics, bool) {
+	stats := viewStatistics(view)
+	if stats == nil {
+		return ColumnStatistics{}, false
+	}
+	idx, err := view.FieldIndex(field)
+	if err != nil || !view.Header[idx].IsFromTable {
+		return ColumnStatistics{}, false
+	}
+	return stats.Column(view.Header[idx].Number)
+}
+
+// estimateFilterRowsWithStatistics estimates the number of records of the view satisfying the condition
+// in the same way as estimateFilterRows, except that comparisons between a field and a literal are estimated
+// with the statistics of the file.
+func estimateFilterRowsWithStatistics(rows int, condition parser.QueryExpression, view *View) int {
+	stats := viewStatistics(view)
+	if stats == nil || rows < 0 || condition == nil {
+		return estimateFilterRows(rows, condition)
+	}
+
+	size := float64(rows)
+	for _, expr := range splitConjunction(condition) {
+		if s, ok := comparisonSelectivity(stats, view, expr); ok {
+			size = size * s
+		} else {
+			size = size / 3
+		}
+	}
+	return int(size)
+}
+
+// comparisonSelectivity returns the estimated ratio of the records satisfying the comparison between a field
+// and a literal.
+func comparisonSelectivity(stats *TableStatistics, view *View, expr parser.QueryExpression) (float64, bool) {
+	comparison, ok := expr.(parser.Comparison)
+	if !ok || stats.Rows < 1 {
+		return 0, false
+	}
+
+	field, literal := comparison.LHS, comparison.RHS
+	operator := comparison.Operator
+	if _, ok := literal.(parser.PrimitiveType); !ok {
+		field, literal = literal, field
+		switch operator {
+		case "<":
+			operator = ">"
+		case "<=":
+			operator = ">="
+		case ">":
+			operator = "<"
+		case ">=":
+			operator = "<="
+		}
+	}
+	if _, ok := field.(parser.FieldReference); !ok {
+		return 0, false
+	}
+	p, ok := literal.(parser.PrimitiveType)
+	if !ok || value.IsNull(p.Value) {
+		return 0, false
+	}
+
+	column, ok := columnStatistics(view, field)
+	if !ok {
+		return 0, false
+	}
+	nonNull := float64(stats.Rows-column.Nulls) / float64(stats.Rows)
+
+	switch operator {
+	case "=", "==":
+		if column.Distinct < 1 {
+			return 0, true
+		}
+		return nonNull / float64(column.Distinct), true
+	case "<>", "!=":
+		if column.Distinct < 1 {
+			return 0, true
+		}
+		return nonNull * (1 - 1/float64(column.Distinct)), true
+	case "<", "<=", ">", ">=":
+		f := value.ToFloat(p.Value)
+		if !column.Numeric || value.IsNull(f) {
+			return 0, false
+		}
+		n := f.(value.Float).Raw()
+
+		var below float64
+		switch {
+		case n < column.MinNumber:
+			below = 0
+		case column.MaxNumber < n:
+			below = 1
+		case column.MinNumber == column.MaxNumber:
+			below = 0.5
+		default:
+			below = (n - column.MinNumber) / (column.MaxNumber - column.MinNumber)
+		}
+
+		if operator == "<" || operator == "<=" {
+			return nonNull * below, true
+		}
+		return nonNull * (1 - below), true
+	}
+	return 0, false
+}
+
+// countRecordsWithStatistics returns the view of the result of the query counting all the records in a file
+// with the statistics of the file, without loading the file.
+// If the query is not such a query, or the statistics are not available, then false is returned.
+func countRecordsWithStatistics(filter *Filter, query parser.SelectQuery) (*View, bool) {
+	if !filter.tx.Flags.StatisticsCache || filter.recorder != nil || filter.recursiveTable != nil || query.OrderByClause != nil {
+		return nil, false
+	}
+
+	entity, ok := query.SelectEntity.(parser.SelectEntity)
+	if !ok || entity.FromClause == nil || entity.WhereClause != nil || entity.GroupByClause != nil || entity.HavingClause != nil {
+		return nil, false
+	}
+
+	clause := entity.SelectClause.(parser.SelectClause)
+	if clause.IsDistinct() || clause.DistinctOn != nil || len(clause.Fields) != 1 {
+		return nil, false
+	}
+	field := clause.Fields[0].(parser.Field)
+	fn, ok := field.Object.(parser.AggregateFunction)
+	if !ok || !strings.EqualFold(fn.Name, "COUNT") || fn.IsDistinct() || len(fn.Args) != 1 {
+		return nil, false
+	}
+	switch fn.Args[0].(type) {
+	case parser.AllColumns, parser.PrimitiveType:
+	default:
+		return nil, false
+	}
+
+	tables := entity.FromClause.(parser.FromClause).Tables
+	if len(tables) != 1 {
+		return nil, false
+	}
+	table, ok := tables[0].(parser.Table)
+	if !ok || table.Sample != nil || table.Columns != nil {
+		return nil, false
+	}
+	tableIdentifier, ok := table.Object.(parser.Identifier)
+	if !ok || filter.inlineTables.Exists(tableIdentifier) || filter.tempViews.Exists(tableIdentifier.Literal) || filter.tx.checkFilePath(tableIdentifier) != nil {
+		return nil, false
+	}
+
+	flags := filter.tx.Flags
+	fileInfo, err := NewFileInfo(tableIdentifier, flags.Repository, cmd.AutoSelect, flags.Delimiter, flags.Encoding, flags)
+	if err != nil {
+		return nil, false
+	}
+	fileInfo.DelimiterPositions = flags.DelimiterPositions
+	fileInfo.SingleLine = flags.SingleLine
+	fileInfo.JsonQuery = strings.TrimSpace(flags.JsonQuery)
+	fileInfo.LineBreak = flags.LineBreak
+	fileInfo.NoHeader = flags.NoHeader
+	fileInfo.EncloseAll = flags.EncloseAll
+	fileInfo.JsonEscape = flags.JsonEscape
+
+	filter.tx.viewLoadingMutex.Lock()
+	loaded := filter.tx.cachedViews.Exists(fileInfo.Path)
+	filter.tx.viewLoadingMutex.Unlock()
+	if loaded {
+		// The loaded records may have been changed in the transaction.
+		return nil, false
+	}
+
+	stat, err := os.Stat(fileInfo.Path)
+	if err != nil {
+		return nil, false
+	}
+	stats := LoadTableStatistics(fileInfo.Path, sharedViewOptions(fileInfo, flags.WithoutNull), stat)
+	if stats == nil {
+		return nil, false
+	}
+	filter.dependencies.addFile(fileInfo.Path, stat)
+
+	alias := ""
+	if field.Alias != nil {
+		alias = field.Alias.(parser.Identifier).Literal
+	}
+
+	view := NewView(filter.tx)
+	view.Header, _ = AddHeaderField(NewEmptyHeader(0), parser.FormatFieldIdentifier(fn), alias)
+	view.RecordSet = RecordSet{NewRecord([]value.Primary{value.NewInteger(int64(stats.Rows))})}
+	view.Filter = filter
+	view.isGrouped = true
+	view.selectFields = []int{0}
+	view.selectLabels = []string{field.Name()}
+	return view, true
+}
@@ -0,0 +1,246 @@
+package query
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mithrandie/csvq/lib/parser"
+	"github.com/mithrandie/csvq/lib/value"
+)
+
+func TestCollectTableStatistics(t *testing.T) {
+	view := &View{
+		Header: NewHeader("t", []string{"c1", "c2", "c3", "c4"}),
+		RecordSet: RecordSet{
+			NewRecord([]value.Primary{value.NewString("3"), value.NewString("a"), value.NewNull(), value.NewNull()}),
+			NewRecord([]value.Primary{value.NewString("-1.5"), value.NewString("1"), value.NewNull(), value.NewNull()}),
+			NewRecord([]value.Primary{value.NewNull(), value.NewString("a"), value.NewNull(), value.NewNull()}),
+			NewRecord([]value.Primary{value.NewString("3"), value.NewString("b"), value.NewNull(), value.NewNull()}),
+		},
+		prunedFields: []bool{false, false, false, true},
+	}
+	stat := dummyFileInfo{modTime: time.Date(2012, 2, 3, 9, 18, 15, 0, time.UTC), size: 100}
+
+	expect := &TableStatistics{
+		Version: tableStatisticsVersion,
+		Options: "options",
+		ModTime: stat.modTime.UnixNano(),
+		Size:    100,
+		Rows:    4,
+		Columns: []ColumnStatistics{
+			{Number: 1, Name: "c1", Nulls: 1, Distinct: 2, Numeric: true, MinNumber: -1.5, MaxNumber: 3},
+			{Number: 2, Name: "c2", Nulls: 0, Distinct: 3},
+			{Number: 3, Name: "c3", Nulls: 4, Distinct: 0},
+		},
+	}
+
+	result := CollectTableStatistics(view, "options", stat)
+	if !reflect.DeepEqual(result, expect) {
+		t.Errorf("statistics = %v, want %v", result, expect)
+	}
+	if result.hasColumns(view) != true {
+		t.Errorf("has columns = %t, want %t", false, true)
+	}
+
+	view.prunedFields = nil
+	if result.hasColumns(view) != false {
+		t.Errorf("has columns = %t, want %t", true, false)
+	}
+}
+
+func TestTableStatistics_Save(t *testing.T) {
+	fpath := filepath.Join(TestDir, "table_statistics_test.csv")
+	if err := os.WriteFile(fpath, []byte("c1\n1\n2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = os.Remove(fpath)
+		_ = os.Remove(StatisticsFilePath(fpath))
+	}()
+
+	stat, _ := os.Stat(fpath)
+	stats := &TableStatistics{
+		Version: tableStatisticsVersion,
+		Options: "options",
+		ModTime: stat.ModTime().UnixNano(),
+		Size:    stat.Size(),
+		Rows:    2,
+		Columns: []ColumnStatistics{
+			{Number: 1, Name: "c1", Distinct: 2, Numeric: true, MinNumber: 1, MaxNumber: 2},
+		},
+	}
+	if err := stats.Save(fpath); err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if result := LoadTableStatistics(fpath, "options", stat); !reflect.DeepEqual(result, stats) {
+		t.Errorf("statistics = %v, want %v", result, stats)
+	}
+	if result := LoadTableStatistics(fpath, "other options", stat); result != nil {
+		t.Errorf("statistics = %v, want nil for different options", result)
+	}
+
+	if err := os.WriteFile(fpath, []byte("c1\n1\n2\n3\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	stat, _ = os.Stat(fpath)
+	if result := LoadTableStatistics(fpath, "options", stat); result != nil {
+		t.Errorf("statistics = %v, want nil for modified file", result)
+	}
+}
+
+var countRecordsWithStatisticsTests = []struct {
+	Query  string
+	Counts bool
+}{
+	{
+		Query:  "SELECT COUNT(*) FROM table1",
+		Counts: true,
+	},
+	{
+		Query:  "SELECT count(1) AS cnt FROM table1 t LIMIT 1",
+		Counts: true,
+	},
+	{
+		Query: "SELECT COUNT(column1) FROM table1",
+	},
+	{
+		Query: "SELECT COUNT(*) FROM table1 WHERE column1 > 1",
+	},
+	{
+		Query: "SELECT COUNT(*) FROM table1 GROUP BY column1",
+	},
+	{
+		Query: "SELECT COUNT(*) FROM table1, table2",
+	},
+	{
+		Query: "SELECT COUNT(DISTINCT 1) FROM table1",
+	},
+	{
+		Query: "SELECT COUNT(*) FROM table1 ORDER BY 1",
+	},
+	{
+		Query: "WITH table1 AS (SELECT 1) SELECT COUNT(*) FROM table1",
+	},
+}
+
+func TestCountRecordsWithStatistics(t *testing.T) {
+	defer func() {
+		_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+		_ = os.Remove(StatisticsFilePath(GetTestFilePath("table1.csv")))
+		_ = os.Remove(StatisticsFilePath(GetTestFilePath("table2.csv")))
+		initFlag(TestTx.Flags)
+	}()
+
+	TestTx.Flags.Repository = TestDir
+
+	selectQuery := func(query string) (*View, error) {
+		statements, _, err := parser.Parse(query, "", nil, false)
+		if err != nil {
+			return nil, err
+		}
+		return Select(context.Background(), NewFilter(TestTx).CreateNode(), statements[0].(parser.SelectQuery))
+	}
+
+	for _, v := range countRecordsWithStatisticsTests {
+		_ = os.Remove(StatisticsFilePath(GetTestFilePath("table1.csv")))
+		_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+		TestTx.Flags.StatisticsCache = false
+
+		expect, err := selectQuery(v.Query)
+		if err != nil {
+			t.Errorf("%s: unexpected error %q", v.Query, err)
+			continue
+		}
+		if _, err := os.Stat(StatisticsFilePath(GetTestFilePath("table1.csv"))); err == nil {
+			t.Errorf("%s: statistics are saved while the flag is disabled", v.Query)
+		}
+
+		_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+		TestTx.Flags.StatisticsCache = true
+
+		if _, err := selectQuery("SELECT * FROM table1"); err != nil {
+			t.Errorf("%s: unexpected error %q", v.Query, err)
+			continue
+		}
+		_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+
+		statements, _, _ := parser.Parse(v.Query, "", nil, false)
+		filter := NewFilter(TestTx).CreateNode()
+		if query := statements[0].(parser.SelectQuery); query.WithClause != nil {
+			_ = filter.LoadInlineTable(context.Background(), query.WithClause.(parser.WithClause))
+		}
+		if _, ok := countRecordsWithStatistics(filter, statements[0].(parser.SelectQuery)); ok != v.Counts {
+			t.Errorf("%s: counted with statistics = %t, want %t", v.Query, ok, v.Counts)
+		}
+
+		result, err := selectQuery(v.Query)
+		if err != nil {
+			t.Errorf("%s: unexpected error %q", v.Query, err)
+			continue
+		}
+		if !reflect.DeepEqual(result.Header, expect.Header) {
+			t.Errorf("%s: header = %v, want %v", v.Query, result.Header, expect.Header)
+		}
+		if !reflect.DeepEqual(result.RecordSet, expect.RecordSet) {
+			t.Errorf("%s: records = %v, want %v", v.Query, result.RecordSet, expect.RecordSet)
+		}
+	}
+}
+
+func TestComparisonSelectivity(t *testing.T) {
+	view := &View{
+		Header:   NewHeader("t", []string{"c1", "c2"}),
+		FileInfo: &FileInfo{},
+	}
+	stats := &TableStatistics{
+		Rows: 100,
+		Columns: []ColumnStatistics{
+			{Number: 1, Name: "c1", Nulls: 20, Distinct: 40, Numeric: true, MinNumber: 0, MaxNumber: 100},
+			{Number: 2, Name: "c2", Distinct: 10},
+		},
+	}
+	view.FileInfo.statistics = stats
+
+	tests := []struct {
+		Condition string
+		Rows      int
+	}{
+		{Condition: "c1 = 5", Rows: 2},
+		{Condition: "c1 <> 5", Rows: 78},
+		{Condition: "c1 < 25", Rows: 20},
+		{Condition: "25 < c1", Rows: 60},
+		{Condition: "c1 > 200", Rows: 0},
+		{Condition: "c2 = 'a' AND c1 >= 50", Rows: 4},
+		{Condition: "c2 > 'a'", Rows: 33},
+		{Condition: "c1 = c2", Rows: 33},
+	}
+
+	for _, v := range tests {
+		statements, _, err := parser.Parse("SELECT 1 FROM t WHERE "+v.Condition, "", nil, false)
+		if err != nil {
+			t.Fatalf("%s: unexpected parse error %q", v.Condition, err)
+		}
+		condition := statements[0].(parser.SelectQuery).SelectEntity.(parser.SelectEntity).WhereClause.(parser.WhereClause).Filter
+
+		if rows := estimateFilterRowsWithStatistics(100, condition, view); rows != v.Rows {
+			t.Errorf("%s: rows = %d, want %d", v.Condition, rows, v.Rows)
+		}
+	}
+}
+
+type dummyFileInfo struct {
+	modTime time.Time
+	size    int64
+}
+
+func (fi dummyFileInfo) Name() string       { return "" }
+func (fi dummyFileInfo) Size() int64        { return fi.size }
+func (fi dummyFileInfo) Mode() os.FileMode  { return 0 }
+func (fi dummyFileInfo) ModTime() time.Time { return fi.modTime }
+func (fi dummyFileInfo) IsDir() bool        { return false }
+func (fi dummyFileInfo) Sys() interface{}   { return nil }
@@ -1,9 +1,12 @@
 package query
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -26,16 +29,36 @@ type Transaction struct {
 	FileContainer *file.Container
 
 	cachedViews      ViewMap
+	sharedViews      *SharedViewMap
 	uncommittedViews *UncommittedViews
+	indexes          IndexMap
+	sequences        *SequenceMap
+	constraints      ConstraintMap
+
+	// Numbers of records changed in the files by the statements not committed yet
+	tableChanges TableChangesMap
+	// Changes of records in the files not committed yet, recorded when the audit log is enabled
+	auditLog AuditLog
+
+	preCommitHooks       []CommitHook
+	postCommitHooks      []CommitHook
+	commitTriggerRunning bool
 
 	viewLoadingMutex *sync.Mutex
 
+	// Times spent to load files recorded when the statistics flag is set
+	fileLoadStats []FileLoadStat
+
 	PreparedStatements PreparedStatementMap
 
 	SelectedViews []*View
 	AffectedRows  int
 
 	AutoCommit bool
+
+	// Restricted transactions reject the statements and the functions that run commands, execute source files,
+	// or change the flags and the environment of the process.
+	Restricted bool
 }
 
 func NewTransaction(ctx context.Context, defaultWaitTimeout time.Duration, retryDelay time.Duration, session *Session) (*Transaction, error) {
@@ -49,21 +72,46 @@ func NewTransaction(ctx context.Context, defaultWaitTimeout time.Duration, retry
 		return nil, NewTransactionOpenError(err.Error())
 	}
 
+	return newTransaction(session, environment, flags, file.DefaultWaitTimeout, file.DefaultRetryDelay), nil
+}
+
+// Fork returns a new transaction having the same environment, a copy of the flags, the shared view cache
+// and the restriction of the transaction. Uncommitted changes, cached views and locks of the transaction
+// are not inherited, so the new transaction can run concurrently with the transaction.
+func (tx *Transaction) Fork(session *Session) *Transaction {
+	forked := newTransaction(session, tx.Environment, tx.Flags.Copy(), tx.WaitTimeout, tx.RetryDelay)
+	forked.sharedViews = tx.sharedViews
+	forked.Restricted = tx.Restricted
+	forked.UpdateRetryPolicy()
+	return forked
+}
+
+func newTransaction(session *Session, environment *cmd.Environment, flags *cmd.Flags, waitTimeout time.Duration, retryDelay time.Duration) *Transaction {
 	return &Transaction{
 		Session:            session,
 		Environment:        environment,
 		Flags:              flags,
-		WaitTimeout:        file.DefaultWaitTimeout,
-		RetryDelay:         file.DefaultRetryDelay,
+		WaitTimeout:        waitTimeout,
+		RetryDelay:         retryDelay,
 		FileContainer:      file.NewContainer(),
 		cachedViews:        make(ViewMap, 10),
 		uncommittedViews:   NewUncommittedViews(),
+		indexes:            make(IndexMap, 4),
+		sequences:          NewSequenceMap(),
+		constraints:        make(ConstraintMap, 4),
+		tableChanges:       make(TableChangesMap, 4),
 		viewLoadingMutex:   new(sync.Mutex),
 		PreparedStatements: make(PreparedStatementMap, 4),
 		SelectedViews:      nil,
 		AffectedRows:       0,
 		AutoCommit:         false,
-	}, nil
+	}
+}
+
+// UseSharedViews makes the transaction read tables through the shared cache.
+// A SharedViewMap can be used by multiple transactions running concurrently.
+func (tx *Transaction) UseSharedViews(m *SharedViewMap) {
+	tx.sharedViews = m
 }
 
 func (tx *Transaction) UpdateWaitTimeout(waitTimeout float64, retryDelay time.Duration) {
@@ -77,9 +125,106 @@ func (tx *Transaction) UpdateWaitTimeout(waitTimeout float64, retryDelay time.Du
 	tx.Flags.SetWaitTimeout(waitTimeout)
 }
 
+// UpdateRetryPolicy applies the lock retry limit and the lock backoff flags to the file container.
+func (tx *Transaction) UpdateRetryPolicy() {
+	tx.FileContainer.RetryPolicy = file.RetryPolicy{
+		Limit:   tx.Flags.LockRetryLimit,
+		Backoff: tx.Flags.LockBackoff,
+	}
+}
+
+func (tx *Transaction) RestoreFlag(name string, flags *cmd.Flags) {
+	switch strings.ToUpper(name) {
+	case cmd.WaitTimeoutFlag:
+		tx.UpdateWaitTimeout(flags.WaitTimeout, tx.RetryDelay)
+	case cmd.LockRetryLimitFlag, cmd.LockBackoffFlag:
+		tx.Flags.Restore(name, flags)
+		tx.UpdateRetryPolicy()
+	default:
+		tx.Flags.Restore(name, flags)
+	}
+}
+
 func (tx *Transaction) Commit(filter *Filter, expr parser.Expression) error {
 	createdFiles, updatedFiles := tx.uncommittedViews.UncommittedFiles()
 
+	if tx.Flags.DryRun {
+		return tx.commitDryRun(filter, expr, createdFiles, updatedFiles)
+	}
+
+	files, err := tx.commitFiles(expr, createdFiles, updatedFiles)
+	if err != nil {
+		return err
+	}
+
+	msglist := filter.tempViews.Store(tx.uncommittedViews.UncommittedTempViews())
+	if 0 < len(msglist) {
+		tx.Session.LogNotice(strings.Join(msglist, "\n"), tx.Flags.Quiet)
+	}
+	tx.uncommittedViews.Clean()
+	tx.tableChanges.Clean()
+	tx.auditLog.Clean()
+	if err := tx.ReleaseResources(); err != nil {
+		return NewCommitError(expr, err.Error())
+	}
+	return tx.postCommit(filter, expr, files)
+}
+
+// CommitTable writes the changes of a table to the file, or creates a restore point of a temporary table.
+// The changes of the other tables remain uncommitted.
+func (tx *Transaction) CommitTable(filter *Filter, expr parser.Expression, table parser.Identifier) error {
+	fileInfo, err := tx.uncommittedTable(filter, table)
+	if err != nil {
+		return err
+	}
+	if fileInfo == nil {
+		tx.Session.LogNotice(fmt.Sprintf("Commit: table %s has no changes.", table.Literal), tx.Flags.Quiet)
+		return nil
+	}
+
+	key := strings.ToUpper(fileInfo.Path)
+
+	if fileInfo.IsTemporary {
+		msglist := filter.tempViews.Store(map[string]*FileInfo{key: fileInfo})
+		if 0 < len(msglist) {
+			tx.Session.LogNotice(strings.Join(msglist, "\n"), tx.Flags.Quiet)
+		}
+		tx.uncommittedViews.Unset(fileInfo)
+		return nil
+	}
+
+	createdFiles := make(map[string]*FileInfo)
+	updatedFiles := make(map[string]*FileInfo)
+	if _, ok := tx.uncommittedViews.Created[key]; ok {
+		createdFiles[key] = fileInfo
+	} else {
+		updatedFiles[key] = fileInfo
+	}
+
+	var files CommittedFiles
+	if tx.Flags.DryRun {
+		err = tx.showDryRunChanges(expr, createdFiles, updatedFiles)
+	} else {
+		files, err = tx.commitFiles(expr, createdFiles, updatedFiles)
+	}
+	if err != nil {
+		return err
+	}
+
+	tx.uncommittedViews.Unset(fileInfo)
+	tx.tableChanges.Dispose(fileInfo.Path)
+	tx.auditLog.Dispose(fileInfo.Path)
+	if err := tx.cachedViews.Dispose(tx.FileContainer, fileInfo.Path); err != nil {
+		return NewCommitError(expr, err.Error())
+	}
+	return tx.postCommit(filter, expr, files)
+}
+
+// commitFiles writes the changes of the files, and replaces the files with them.
+// The pre-commit hooks are run before any file is replaced.
+func (tx *Transaction) commitFiles(expr parser.Expression, createdFiles map[string]*FileInfo, updatedFiles map[string]*FileInfo) (CommittedFiles, error) {
+	var files CommittedFiles
+
 	createFileInfo := make([]*FileInfo, 0, len(createdFiles))
 	updateFileInfo := make([]*FileInfo, 0, len(updatedFiles))
 
@@ -89,15 +234,17 @@ func (tx *Transaction) Commit(filter *Filter, expr parser.Expression) error {
 
 			fp := view.FileInfo.Handler.FileForUpdate()
 			if err := fp.Truncate(0); err != nil {
-				return NewSystemError(err.Error())
+				return files, NewSystemError(err.Error())
 			}
 			if _, err := fp.Seek(0, io.SeekStart); err != nil {
-				return NewSystemError(err.Error())
+				return files, NewSystemError(err.Error())
 			}
 
-			_, err := EncodeView(fp, view, fileinfo, tx.Flags)
+			p := startProgress(tx, "Writing", fileinfo.Path, "bytes")
+			err := encodeViewToFile(p.writer(fp), view, fileinfo, tx.Flags)
+			p.finish()
 			if err != nil {
-				return NewCommitError(expr, err.Error())
+				return files, NewCommitError(expr, err.Error())
 			}
 			createFileInfo = append(createFileInfo, view.FileInfo)
 		}
@@ -109,33 +256,172 @@ func (tx *Transaction) Commit(filter *Filter, expr parser.Expression) error {
 
 			fp := view.FileInfo.Handler.FileForUpdate()
 			if err := fp.Truncate(0); err != nil {
-				return NewSystemError(err.Error())
+				return files, NewSystemError(err.Error())
 			}
 			if _, err := fp.Seek(0, io.SeekStart); err != nil {
-				return NewSystemError(err.Error())
+				return files, NewSystemError(err.Error())
 			}
 
-			if _, err := EncodeView(fp, view, fileinfo, tx.Flags); err != nil {
-				return NewCommitError(expr, err.Error())
+			p := startProgress(tx, "Writing", fileinfo.Path, "bytes")
+			err := encodeViewToFile(p.writer(fp), view, fileinfo, tx.Flags)
+			p.finish()
+			if err != nil {
+				return files, NewCommitError(expr, err.Error())
 			}
 
 			updateFileInfo = append(updateFileInfo, view.FileInfo)
 		}
 	}
 
+	// Files modified by other processes after they were loaded are not overwritten.
+	for _, f := range updateFileInfo {
+		if err := f.Handler.VerifyUnmodified(); err != nil {
+			return files, NewCommitError(expr, err.Error())
+		}
+	}
+
+	for _, f := range createFileInfo {
+		files.Created = append(files.Created, f.Path)
+	}
+	for _, f := range updateFileInfo {
+		files.Updated = append(files.Updated, f.Path)
+	}
+	sort.Strings(files.Created)
+	sort.Strings(files.Updated)
+	if !files.IsEmpty() {
+		if err := tx.runPreCommitHooks(expr, files); err != nil {
+			return files, err
+		}
+	}
+
+	handlers := make([]*file.Handler, 0, len(createFileInfo)+len(updateFileInfo))
+	for _, f := range createFileInfo {
+		handlers = append(handlers, f.Handler)
+	}
+	for _, f := range updateFileInfo {
+		handlers = append(handlers, f.Handler)
+	}
+
+	// The files to be replaced are recorded in a journal, so that the commit interrupted by a crash
+	// is completed or rolled back at the next startup.
+	// A coordinated commit proceeds after all the participants have prepared their commits.
+	coordinator := tx.commitCoordinator()
+	if coordinator != nil {
+		defer func() {
+			_ = coordinator.Close()
+		}()
+	}
+
+	var journal *file.Journal
+	// The journal is left if any file cannot be replaced after the commit is decided.
+	keepJournal := false
+	if 0 < len(handlers) {
+		var err error
+		if dir := JournalDir(); len(dir) < 1 {
+			err = errors.New("journal directory is not found")
+		} else {
+			journal, err = file.WriteCoordinatedJournal(dir, handlers, coordinator)
+		}
+		if err != nil {
+			if coordinator != nil {
+				// Coordinated commits cannot be decided without the journal.
+				_ = coordinator.Abort()
+				return files, NewCommitError(expr, err.Error())
+			}
+			tx.Session.LogWarn(fmt.Sprintf("Warning: %s. The commit cannot be recovered if it is interrupted.", err.Error()), tx.Flags.Quiet)
+		}
+		defer func() {
+			if keepJournal {
+				if journal.Release() == nil {
+					tx.Session.LogWarn("Warning: the commit is left in the journal to be completed at the next startup.", tx.Flags.Quiet)
+				}
+			} else {
+				_ = journal.Close()
+			}
+		}()
+	}
+
+	if coordinator != nil {
+		if err := tx.coordinateCommit(expr, coordinator, journal); err != nil {
+			return files, err
+		}
+	}
+	if err := journal.Commit(); err != nil {
+		return files, NewCommitError(expr, err.Error())
+	}
+
+	// The files before the commit are preserved before any file is replaced.
+	backupPaths := make([]string, len(updateFileInfo))
+	for i, f := range updateFileInfo {
+		backupPaths[i] = file.BackupFilePath(f.Path, tx.Flags.BackupDir, tx.Flags.BackupExtension)
+		if 0 < len(backupPaths[i]) {
+			if err := file.Backup(f.Path, backupPaths[i]); err != nil {
+				return files, NewCommitError(expr, err.Error())
+			}
+		}
+	}
+
+	// The column constraints are saved with the files, so that they are enforced in later sessions.
+	for _, f := range createFileInfo {
+		if err := tx.constraints.Save(f.Path); err != nil {
+			return files, NewCommitError(expr, err.Error())
+		}
+	}
+	for _, f := range updateFileInfo {
+		if err := tx.constraints.Save(f.Path); err != nil {
+			return files, NewCommitError(expr, err.Error())
+		}
+	}
+
+	keepJournal = journal != nil
 	for _, f := range createFileInfo {
 		if err := tx.FileContainer.Commit(f.Handler); err != nil {
-			return NewCommitError(expr, err.Error())
+			return files, NewCommitError(expr, err.Error())
 		}
 		tx.uncommittedViews.Unset(f)
+		if tx.sharedViews != nil {
+			tx.sharedViews.Dispose(f.Path)
+		}
 		tx.Session.LogNotice(fmt.Sprintf("Commit: file %q is created.", f.Path), tx.Flags.Quiet)
 	}
-	for _, f := range updateFileInfo {
+	for i, f := range updateFileInfo {
 		if err := tx.FileContainer.Commit(f.Handler); err != nil {
-			return NewCommitError(expr, err.Error())
+			return files, NewCommitError(expr, err.Error())
 		}
 		tx.uncommittedViews.Unset(f)
-		tx.Session.LogNotice(fmt.Sprintf("Commit: file %q is updated.", f.Path), tx.Flags.Quiet)
+		if tx.sharedViews != nil {
+			tx.sharedViews.Dispose(f.Path)
+		}
+		if 0 < len(backupPaths[i]) {
+			tx.Session.LogNotice(fmt.Sprintf("Commit: file %q is updated. The previous file is saved as %q.", f.Path, backupPaths[i]), tx.Flags.Quiet)
+		} else {
+			tx.Session.LogNotice(fmt.Sprintf("Commit: file %q is updated.", f.Path), tx.Flags.Quiet)
+		}
+	}
+
+	keepJournal = false
+	if err := journal.Close(); err != nil {
+		return files, NewCommitError(expr, err.Error())
+	}
+
+	auditEntries := make([]AuditEntry, 0, len(tx.auditLog))
+	for _, f := range createFileInfo {
+		auditEntries = append(auditEntries, tx.auditLog.Extract(f.Path)...)
+	}
+	for _, f := range updateFileInfo {
+		auditEntries = append(auditEntries, tx.auditLog.Extract(f.Path)...)
+	}
+	sort.SliceStable(auditEntries, func(i, j int) bool {
+		return auditEntries[i].Time.Before(auditEntries[j].Time)
+	})
+	return files, tx.writeAuditLog(expr, auditEntries)
+}
+
+// commitDryRun shows the numbers of changed records and samples of the changes of the files instead of
+// writing them, and then discards the changes of the files.
+func (tx *Transaction) commitDryRun(filter *Filter, expr parser.Expression, createdFiles map[string]*FileInfo, updatedFiles map[string]*FileInfo) error {
+	if err := tx.showDryRunChanges(expr, createdFiles, updatedFiles); err != nil {
+		return err
 	}
 
 	msglist := filter.tempViews.Store(tx.uncommittedViews.UncommittedTempViews())
@@ -143,40 +429,197 @@ func (tx *Transaction) Commit(filter *Filter, expr parser.Expression) error {
 		tx.Session.LogNotice(strings.Join(msglist, "\n"), tx.Flags.Quiet)
 	}
 	tx.uncommittedViews.Clean()
+	tx.tableChanges.Clean()
+	tx.auditLog.Clean()
 	if err := tx.ReleaseResources(); err != nil {
 		return NewCommitError(expr, err.Error())
 	}
 	return nil
 }
 
+// showDryRunChanges shows the numbers of changed records and samples of the changes of the files.
+func (tx *Transaction) showDryRunChanges(expr parser.Expression, createdFiles map[string]*FileInfo, updatedFiles map[string]*FileInfo) error {
+	files := make([]*FileInfo, 0, len(createdFiles)+len(updatedFiles))
+	for _, fileinfo := range createdFiles {
+		files = append(files, fileinfo)
+	}
+	for _, fileinfo := range updatedFiles {
+		files = append(files, fileinfo)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+
+	for _, fileinfo := range files {
+		view, _ := tx.cachedViews.Get(parser.Identifier{Literal: fileinfo.Path})
+
+		buf := &bytes.Buffer{}
+		if _, err := EncodeView(buf, view, fileinfo, tx.Flags); err != nil {
+			return NewCommitError(expr, err.Error())
+		}
+
+		var src []byte
+		operation := "created"
+		if _, ok := createdFiles[strings.ToUpper(fileinfo.Path)]; !ok {
+			operation = "updated"
+
+			b, err := readFileContents(fileinfo.Path, tx.Flags)
+			if err != nil {
+				return NewCommitError(expr, err.Error())
+			}
+			src = b
+		}
+
+		tx.Session.LogNotice(fmt.Sprintf("Dry run: file %q would be %s (%s).", fileinfo.Path, operation, tx.tableChanges.Get(fileinfo.Path)), false)
+		if diff := SampleDiff(src, buf.Bytes()); 0 < len(diff) {
+			if err := tx.Session.WriteToStdout(diff); err != nil {
+				return NewCommitError(expr, err.Error())
+			}
+		}
+	}
+
+	for _, fileinfo := range createdFiles {
+		tx.constraints.Dispose(fileinfo.Path)
+	}
+	for _, fileinfo := range updatedFiles {
+		tx.constraints.Dispose(fileinfo.Path)
+	}
+	if 0 < len(files) {
+		tx.Session.LogNotice("Dry run: no file is changed.", false)
+	}
+	return nil
+}
+
 func (tx *Transaction) Rollback(filter *Filter, expr parser.Expression) error {
+	if err := tx.abortCoordinatedCommit(expr); err != nil {
+		return err
+	}
+
 	createdFiles, updatedFiles := tx.uncommittedViews.UncommittedFiles()
 
 	if 0 < len(createdFiles) {
 		for _, fileinfo := range createdFiles {
+			tx.constraints.Dispose(fileinfo.Path)
 			tx.Session.LogNotice(fmt.Sprintf("Rollback: file %q is deleted.", fileinfo.Path), tx.Flags.Quiet)
 		}
 	}
 
 	if 0 < len(updatedFiles) {
 		for _, fileinfo := range updatedFiles {
+			tx.constraints.Dispose(fileinfo.Path)
 			tx.Session.LogNotice(fmt.Sprintf("Rollback: file %q is restored.", fileinfo.Path), tx.Flags.Quiet)
 		}
 	}
 
 	if filter != nil {
-		msglist := filter.tempViews.Restore(tx.uncommittedViews.UncommittedTempViews())
+		msglist, err := filter.tempViews.Restore(tx.uncommittedViews.UncommittedTempViews())
 		if 0 < len(msglist) {
 			tx.Session.LogNotice(strings.Join(msglist, "\n"), tx.Flags.Quiet)
 		}
+		if err != nil {
+			return NewRollbackError(expr, err.Error())
+		}
 	}
 	tx.uncommittedViews.Clean()
+	tx.tableChanges.Clean()
+	tx.auditLog.Clean()
 	if err := tx.ReleaseResources(); err != nil {
 		return NewRollbackError(expr, err.Error())
 	}
 	return nil
 }
 
+// RollbackTable discards the changes of a table. The changes of the other tables remain uncommitted.
+func (tx *Transaction) RollbackTable(filter *Filter, expr parser.Expression, table parser.Identifier) error {
+	fileInfo, err := tx.uncommittedTable(filter, table)
+	if err != nil {
+		return err
+	}
+	if fileInfo == nil {
+		tx.Session.LogNotice(fmt.Sprintf("Rollback: table %s has no changes.", table.Literal), tx.Flags.Quiet)
+		return nil
+	}
+
+	if fileInfo.IsTemporary {
+		msglist, err := filter.tempViews.Restore(map[string]*FileInfo{strings.ToUpper(fileInfo.Path): fileInfo})
+		if 0 < len(msglist) {
+			tx.Session.LogNotice(strings.Join(msglist, "\n"), tx.Flags.Quiet)
+		}
+		if err != nil {
+			return NewRollbackError(expr, err.Error())
+		}
+		tx.uncommittedViews.Unset(fileInfo)
+		return nil
+	}
+
+	if _, ok := tx.uncommittedViews.Created[strings.ToUpper(fileInfo.Path)]; ok {
+		tx.constraints.Dispose(fileInfo.Path)
+		tx.Session.LogNotice(fmt.Sprintf("Rollback: file %q is deleted.", fileInfo.Path), tx.Flags.Quiet)
+	} else {
+		tx.constraints.Dispose(fileInfo.Path)
+		tx.Session.LogNotice(fmt.Sprintf("Rollback: file %q is restored.", fileInfo.Path), tx.Flags.Quiet)
+	}
+
+	tx.uncommittedViews.Unset(fileInfo)
+	tx.tableChanges.Dispose(fileInfo.Path)
+	tx.auditLog.Dispose(fileInfo.Path)
+	if err := tx.cachedViews.Dispose(tx.FileContainer, fileInfo.Path); err != nil {
+		return NewRollbackError(expr, err.Error())
+	}
+	return nil
+}
+
+// checkFilePath returns an error if the transaction is restricted and the file is outside the repository.
+func (tx *Transaction) checkFilePath(filename parser.Identifier) error {
+	if tx.Restricted && !IsInRepository(filename.Literal) {
+		return NewRestrictedStatementError(filename, fmt.Sprintf("file path %s outside the repository", filename.Literal))
+	}
+	return nil
+}
+
+// uncommittedTable returns the information of the table having uncommitted changes.
+// If the table has no changes, then nil is returned.
+func (tx *Transaction) uncommittedTable(filter *Filter, table parser.Identifier) (*FileInfo, error) {
+	if filter.tempViews.Exists(table.Literal) {
+		if fileInfo, ok := tx.uncommittedViews.Updated[strings.ToUpper(table.Literal)]; ok && fileInfo.IsTemporary {
+			return fileInfo, nil
+		}
+		return nil, nil
+	}
+
+	fpath, err := SearchFilePathFromAllTypes(table, tx.Flags.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	key := strings.ToUpper(fpath)
+	if fileInfo, ok := tx.uncommittedViews.Created[key]; ok && !fileInfo.IsTemporary {
+		return fileInfo, nil
+	}
+	if fileInfo, ok := tx.uncommittedViews.Updated[key]; ok && !fileInfo.IsTemporary {
+		return fileInfo, nil
+	}
+	return nil, nil
+}
+
+// DisposeStaleViews disposes the cached views of the files modified or removed since the records were loaded,
+// so that the following queries load the files again.
+// The views loaded to be updated and the views changed in the transaction are not disposed.
+func (tx *Transaction) DisposeStaleViews() error {
+	for key, view := range tx.cachedViews {
+		if view.ForUpdate || view.FileInfo.IsTemporary || tx.uncommittedViews.Contains(view.FileInfo.Path) {
+			continue
+		}
+		if !view.FileInfo.isModified() {
+			continue
+		}
+		if err := tx.cachedViews.Dispose(tx.FileContainer, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (tx *Transaction) ReleaseResources() error {
 	if err := tx.cachedViews.Clean(tx.FileContainer); err != nil {
 		return err
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -87,6 +88,242 @@ func TestTransaction_Commit(t *testing.T) {
 	if string(log) != expect {
 		t.Errorf("Commit: log = %q, want %q", string(log), expect)
 	}
+	if files, _ := ioutil.ReadDir(JournalDir()); len(files) != 0 {
+		t.Errorf("Commit: %d files are left in the journal directory, want no file", len(files))
+	}
+}
+
+func TestTransaction_CommitWithBackup(t *testing.T) {
+	fpath := GetTestFilePath("backup_test.csv")
+	backupDir := filepath.Join(TestDir, "backup")
+	defer func() {
+		_ = TestTx.ReleaseResources()
+		_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+		_ = os.Remove(fpath)
+		_ = os.Remove(fpath + ".bak")
+		_ = os.RemoveAll(backupDir)
+		initFlag(TestTx.Flags)
+	}()
+
+	if err := os.Mkdir(backupDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(fpath, []byte("c1,c2\n1,a\n2,b"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	TestTx.Flags.Repository = TestDir
+	TestTx.Flags.SetQuiet(true)
+
+	update := func(c2 string) {
+		_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+		statements, _, err := parser.Parse(fmt.Sprintf("UPDATE backup_test SET c2 = '%s' WHERE c1 = 1; COMMIT;", c2), "", nil, false)
+		if err != nil {
+			t.Fatalf("unexpected parse error %q", err)
+		}
+		if _, err = NewProcessor(TestTx).Execute(context.Background(), statements); err != nil {
+			t.Fatalf("unexpected error %q", err)
+		}
+	}
+
+	_ = TestTx.Flags.SetBackupExtension(".bak")
+	update("x")
+	if b, _ := ioutil.ReadFile(fpath); string(b) != "c1,c2\n1,x\n2,b" {
+		t.Errorf("file = %q, want %q", string(b), "c1,c2\n1,x\n2,b")
+	}
+	if b, _ := ioutil.ReadFile(fpath + ".bak"); string(b) != "c1,c2\n1,a\n2,b" {
+		t.Errorf("backup = %q, want %q", string(b), "c1,c2\n1,a\n2,b")
+	}
+
+	_ = TestTx.Flags.SetBackupExtension("")
+	_ = TestTx.Flags.SetBackupDir(backupDir)
+	update("y")
+	if b, _ := ioutil.ReadFile(filepath.Join(backupDir, "backup_test.csv")); string(b) != "c1,c2\n1,x\n2,b" {
+		t.Errorf("backup = %q, want %q", string(b), "c1,c2\n1,x\n2,b")
+	}
+	if b, _ := ioutil.ReadFile(fpath + ".bak"); string(b) != "c1,c2\n1,a\n2,b" {
+		t.Errorf("backup = %q, want %q", string(b), "c1,c2\n1,a\n2,b")
+	}
+}
+
+func TestTransaction_CommitModifiedFile(t *testing.T) {
+	fpath := GetTestFilePath("modified_test.csv")
+	defer func() {
+		_ = TestTx.Rollback(nil, nil)
+		_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+		_ = os.Remove(fpath)
+		initFlag(TestTx.Flags)
+	}()
+
+	if err := ioutil.WriteFile(fpath, []byte("c1,c2\n1,a\n2,b"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	TestTx.Flags.Repository = TestDir
+	TestTx.Flags.SetQuiet(true)
+
+	statements, _, err := parser.Parse("UPDATE modified_test SET c2 = 'x' WHERE c1 = 2", "", nil, false)
+	if err != nil {
+		t.Fatalf("unexpected parse error %q", err)
+	}
+	if _, err = NewProcessor(TestTx).execute(context.Background(), statements); err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if err = ioutil.WriteFile(fpath, []byte("c1,c2\n1,a\n2,b\n3,c"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	expectErr := fmt.Sprintf("[Auto Commit] failed to commit: file %s has been modified by another process since it was loaded", fpath)
+	err = TestTx.Commit(NewFilter(TestTx), nil)
+	if err == nil {
+		t.Errorf("no error, want error %q", expectErr)
+	} else if err.Error() != expectErr {
+		t.Errorf("error = %q, want error %q", err.Error(), expectErr)
+	}
+
+	if b, _ := ioutil.ReadFile(fpath); string(b) != "c1,c2\n1,a\n2,b\n3,c" {
+		t.Errorf("file = %q, want %q", string(b), "c1,c2\n1,a\n2,b\n3,c")
+	}
+}
+
+func TestTransaction_CommitDryRun(t *testing.T) {
+	fpath := GetTestFilePath("dry_run_test.csv")
+	defer func() {
+		_ = TestTx.ReleaseResources()
+		_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+		_ = os.Remove(fpath)
+		initFlag(TestTx.Flags)
+	}()
+
+	if err := ioutil.WriteFile(fpath, []byte("c1,c2\n1,a\n2,b"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	TestTx.Flags.Repository = TestDir
+	TestTx.Flags.SetQuiet(true)
+	TestTx.Flags.SetDryRun(true)
+
+	statements, _, err := parser.Parse("UPDATE dry_run_test SET c2 = 'x' WHERE c1 = 2; INSERT INTO dry_run_test VALUES (3, 'c'); COMMIT;", "", nil, false)
+	if err != nil {
+		t.Fatalf("unexpected parse error %q", err)
+	}
+
+	r, w, _ := os.Pipe()
+	TestTx.Session.Stdout = w
+
+	_, err = NewProcessor(TestTx).Execute(context.Background(), statements)
+
+	_ = w.Close()
+	log, _ := ioutil.ReadAll(r)
+
+	if err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	expect := fmt.Sprintf("Dry run: file %q would be updated (1 record inserted, 1 record updated).\n", fpath) +
+		"@@ -3 +3,2 @@\n" +
+		"-2,b\n" +
+		"+2,x\n" +
+		"+3,c\n" +
+		"Dry run: no file is changed.\n"
+	if string(log) != expect {
+		t.Errorf("log = %q, want %q", string(log), expect)
+	}
+	if b, _ := ioutil.ReadFile(fpath); string(b) != "c1,c2\n1,a\n2,b" {
+		t.Errorf("file = %q, want %q", string(b), "c1,c2\n1,a\n2,b")
+	}
+	if file.Exists(file.LockFilePath(fpath)) || file.Exists(file.TempFilePath(fpath)) {
+		t.Errorf("temporary file or lock file is not removed")
+	}
+}
+
+var transactionReadOnlyTests = []struct {
+	Query string
+	Error string
+}{
+	{
+		Query: "SELECT * FROM read_only_test",
+	},
+	{
+		Query: "DECLARE tbl VIEW (c1); INSERT INTO tbl VALUES (1);",
+	},
+	{
+		Query: "UPDATE read_only_test SET c2 = 'x' WHERE c1 = 1",
+		Error: "[L:1 C:8] file read_only_test cannot be changed in read-only mode",
+	},
+	{
+		Query: "INSERT INTO read_only_test VALUES (3, 'c')",
+		Error: "[L:1 C:13] file read_only_test cannot be changed in read-only mode",
+	},
+	{
+		Query: "DELETE FROM read_only_test WHERE c1 = 1",
+		Error: "[L:1 C:13] file read_only_test cannot be changed in read-only mode",
+	},
+	{
+		Query: "ALTER TABLE read_only_test ADD c3",
+		Error: "[L:1 C:13] file read_only_test cannot be changed in read-only mode",
+	},
+	{
+		Query: "CREATE TABLE read_only_new (c1)",
+		Error: "[L:1 C:14] file read_only_new cannot be changed in read-only mode",
+	},
+	{
+		Query: "SELECT CALL('echo', 'a')",
+		Error: "[L:1 C:8] function CALL cannot run commands in read-only mode",
+	},
+}
+
+func TestTransaction_ReadOnly(t *testing.T) {
+	fpath := GetTestFilePath("read_only_test.csv")
+	defer func() {
+		_ = TestTx.ReleaseResources()
+		_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+		_ = os.Remove(fpath)
+		initFlag(TestTx.Flags)
+	}()
+
+	if err := ioutil.WriteFile(fpath, []byte("c1,c2\n1,a\n2,b"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	TestTx.Flags.Repository = TestDir
+	TestTx.Flags.SetQuiet(true)
+	_ = TestTx.Flags.SetReadOnly(true)
+	TestTx.Session.Stdout = NewDiscard()
+
+	for _, v := range transactionReadOnlyTests {
+		_ = TestTx.Rollback(nil, nil)
+
+		statements, _, err := parser.Parse(v.Query, "", nil, false)
+		if err != nil {
+			t.Fatalf("unexpected parse error %q", err)
+		}
+
+		proc := NewProcessor(TestTx)
+		proc.storeResults = false
+		_, err = proc.Execute(context.Background(), statements)
+		if err != nil {
+			if len(v.Error) < 1 {
+				t.Errorf("%s: unexpected error %q", v.Query, err)
+			} else if err.Error() != v.Error {
+				t.Errorf("%s: error %q, want error %q", v.Query, err.Error(), v.Error)
+			}
+		} else if 0 < len(v.Error) {
+			t.Errorf("%s: no error, want error %q", v.Query, v.Error)
+		}
+
+		if file.Exists(file.LockFilePath(fpath)) {
+			t.Errorf("%s: file is locked", v.Query)
+		}
+	}
+
+	if b, _ := ioutil.ReadFile(fpath); string(b) != "c1,c2\n1,a\n2,b" {
+		t.Errorf("file = %q, want %q", string(b), "c1,c2\n1,a\n2,b")
+	}
+	if file.Exists(GetTestFilePath("read_only_new.csv")) {
+		t.Errorf("file %q is created", GetTestFilePath("read_only_new.csv"))
+	}
 }
 
 func TestTransaction_Rollback(t *testing.T) {
@@ -127,3 +364,175 @@ func TestTransaction_Rollback(t *testing.T) {
 		t.Errorf("Rollback: log = %q, want %q", string(log), expect)
 	}
 }
+
+func TestTransaction_CommitTable(t *testing.T) {
+	committed := GetTestFilePath("commit_table_test.csv")
+	rolledBack := GetTestFilePath("rollback_table_test.csv")
+	defer func() {
+		_ = TestTx.Rollback(nil, nil)
+		_ = os.Remove(committed)
+		_ = os.Remove(rolledBack)
+		initFlag(TestTx.Flags)
+	}()
+
+	for _, fpath := range []string{committed, rolledBack} {
+		if err := ioutil.WriteFile(fpath, []byte("c1\n1"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	TestTx.Flags.Repository = TestDir
+	TestTx.Flags.SetQuiet(true)
+	TestTx.Session.Stdout = NewDiscard()
+
+	statements, _, err := parser.Parse(""+
+		"INSERT INTO commit_table_test VALUES (2);"+
+		"INSERT INTO rollback_table_test VALUES (2);"+
+		"COMMIT TABLE commit_table_test;"+
+		"INSERT INTO commit_table_test VALUES (3);"+
+		"ROLLBACK TABLE rollback_table_test;"+
+		"ROLLBACK TABLE commit_table_test;", "", nil, false)
+	if err != nil {
+		t.Fatalf("unexpected parse error %q", err)
+	}
+
+	proc := NewProcessor(TestTx)
+	if _, err = proc.Execute(context.Background(), statements); err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if b, _ := ioutil.ReadFile(committed); string(b) != "c1\n1\n2" {
+		t.Errorf("committed file = %q, want %q", string(b), "c1\n1\n2")
+	}
+	if b, _ := ioutil.ReadFile(rolledBack); string(b) != "c1\n1" {
+		t.Errorf("rolled back file = %q, want %q", string(b), "c1\n1")
+	}
+	if !TestTx.uncommittedViews.IsEmpty() {
+		t.Errorf("uncommitted views = %v, want no view", TestTx.uncommittedViews)
+	}
+	for _, fpath := range []string{committed, rolledBack} {
+		if file.Exists(file.LockFilePath(fpath)) {
+			t.Errorf("file %q is locked", fpath)
+		}
+	}
+
+	statements, _, _ = parser.Parse("COMMIT TABLE commit_table_not_exist;", "", nil, false)
+	if _, err = proc.Execute(context.Background(), statements); err == nil {
+		t.Errorf("no error, want error for a table that does not exist")
+	}
+}
+
+func TestTransaction_UpdateRetryPolicy(t *testing.T) {
+	defer func() {
+		initFlag(TestTx.Flags)
+		TestTx.UpdateRetryPolicy()
+	}()
+
+	filter := NewFilter(TestTx)
+	flags := TestTx.Flags.Copy()
+
+	_ = SetFlag(context.Background(), filter, parser.SetFlag{Name: "lock_retry_limit", Value: parser.NewIntegerValue(3)})
+	_ = SetFlag(context.Background(), filter, parser.SetFlag{Name: "lock_backoff", Value: parser.NewStringValue("exponential")})
+
+	expect := file.RetryPolicy{Limit: 3, Backoff: file.ExponentialBackoff}
+	if TestTx.FileContainer.RetryPolicy != expect {
+		t.Errorf("retry policy = %v, want %v", TestTx.FileContainer.RetryPolicy, expect)
+	}
+
+	TestTx.RestoreFlag("lock_retry_limit", flags)
+	TestTx.RestoreFlag("lock_backoff", flags)
+
+	if TestTx.FileContainer.RetryPolicy != file.DefaultRetryPolicy {
+		t.Errorf("retry policy = %v, want %v", TestTx.FileContainer.RetryPolicy, file.DefaultRetryPolicy)
+	}
+}
+
+func TestTransaction_Fork(t *testing.T) {
+	defer func() {
+		TestTx.Restricted = false
+		initFlag(TestTx.Flags)
+	}()
+
+	TestTx.Flags.LockRetryLimit = 3
+	TestTx.Restricted = true
+	session := NewSession()
+	forked := TestTx.Fork(session)
+
+	if forked.Session != session {
+		t.Errorf("session is not replaced")
+	}
+	if forked.Environment != TestTx.Environment {
+		t.Errorf("environment is not shared")
+	}
+	if forked.Flags == TestTx.Flags || forked.Flags.LockRetryLimit != 3 {
+		t.Errorf("flags are not copied")
+	}
+	if forked.FileContainer == TestTx.FileContainer || forked.FileContainer.RetryPolicy.Limit != 3 {
+		t.Errorf("file container is not created with the retry policy of the flags")
+	}
+	if !forked.Restricted {
+		t.Errorf("restriction is not inherited")
+	}
+
+	forked.Flags.SetQuiet(!TestTx.Flags.Quiet)
+	if forked.Flags.Quiet == TestTx.Flags.Quiet {
+		t.Errorf("flags of the forked transaction affect the original transaction")
+	}
+}
+
+func TestTransaction_DisposeStaleViews(t *testing.T) {
+	fpath := GetTestFilePath("stale_view_test.csv")
+	defer func() {
+		_ = TestTx.ReleaseResources()
+		_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+		_ = os.Remove(fpath)
+		initFlag(TestTx.Flags)
+	}()
+
+	if err := ioutil.WriteFile(fpath, []byte("c1\n1\n2"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	TestTx.Flags.Repository = TestDir
+	TestTx.Flags.SetQuiet(true)
+	TestTx.Session.Stdout = NewDiscard()
+
+	count := func() int64 {
+		statements, _, err := parser.Parse("VAR @cnt := (SELECT COUNT(*) FROM stale_view_test);", "", nil, false)
+		if err != nil {
+			t.Fatalf("unexpected parse error %q", err)
+		}
+		proc := NewProcessor(TestTx)
+		if _, err = proc.Execute(context.Background(), statements); err != nil {
+			t.Fatalf("unexpected error %q", err)
+		}
+		p, err := proc.Filter.variables.Get(parser.Variable{Name: "cnt"})
+		if err != nil {
+			t.Fatalf("unexpected error %q", err)
+		}
+		return p.(value.Integer).Raw()
+	}
+
+	for _, readOnly := range []bool{false, true} {
+		_ = TestTx.Rollback(nil, nil)
+		TestTx.Flags.ReadOnly = readOnly
+
+		if err := ioutil.WriteFile(fpath, []byte("c1\n1\n2"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if n := count(); n != 2 {
+			t.Fatalf("read-only %t: count = %d, want %d", readOnly, n, 2)
+		}
+
+		if err := ioutil.WriteFile(fpath, []byte("c1\n1\n2\n3"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		expect := int64(2)
+		if readOnly {
+			expect = 3
+		}
+		if n := count(); n != expect {
+			t.Errorf("read-only %t: count after the file is modified = %d, want %d", readOnly, n, expect)
+		}
+	}
+}
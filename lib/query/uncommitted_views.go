@@ -47,6 +47,14 @@ func (m *UncommittedViews) Unset(fileInfo *FileInfo) {
 	}
 }
 
+// Contains reports whether the file has been created or updated and not committed.
+func (m *UncommittedViews) Contains(fpath string) bool {
+	ufpath := strings.ToUpper(fpath)
+	_, created := m.Created[ufpath]
+	_, updated := m.Updated[ufpath]
+	return created || updated
+}
+
 func (m *UncommittedViews) Clean() {
 	for k := range m.Updated {
 		delete(m.Updated, k)
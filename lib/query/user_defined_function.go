@@ -1,9 +1,11 @@
 package query
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 
 	"sort"
 
@@ -61,6 +63,17 @@ func (list UserDefinedFunctionScopes) All() (UserDefinedFunctionMap, UserDefined
 	return scalaAll, aggregateAll
 }
 
+func (list UserDefinedFunctionScopes) hasBatchableFunctions() bool {
+	for _, m := range list {
+		for _, fn := range m {
+			if fn.isBatchable() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 type UserDefinedFunctionMap map[string]*UserDefinedFunction
 
 func (m UserDefinedFunctionMap) Declare(expr parser.FunctionDeclaration) error {
@@ -75,13 +88,31 @@ func (m UserDefinedFunctionMap) Declare(expr parser.FunctionDeclaration) error {
 
 	uname := strings.ToUpper(expr.Name.Literal)
 
-	m[uname] = &UserDefinedFunction{
-		Name:         expr.Name,
-		Statements:   expr.Statements,
-		Parameters:   parameters,
-		Defaults:     defaults,
-		RequiredArgs: required,
+	fn := &UserDefinedFunction{
+		Name:            expr.Name,
+		Statements:      expr.Statements,
+		Parameters:      parameters,
+		Defaults:        defaults,
+		RequiredArgs:    required,
+		IsDeterministic: expr.IsDeterministic(),
 	}
+	if expr.IsExternalLanguage() {
+		switch strings.ToUpper(expr.Language.Literal) {
+		case LuaLanguage:
+			fn.Script, err = compileLuaFunction(expr, parameters)
+		case ExternalLanguage:
+			fn.External, err = NewExternalFunction(expr)
+		default:
+			err = NewUnsupportedFunctionLanguageError(expr.Language)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if fn.IsDeterministic {
+		fn.results = &sync.Map{}
+	}
+	m[uname] = fn
 	return nil
 }
 
@@ -141,12 +172,15 @@ func (m UserDefinedFunctionMap) parseParameters(parameters []parser.VariableAssi
 func (m UserDefinedFunctionMap) CheckDuplicate(name parser.Identifier) error {
 	uname := strings.ToUpper(name.Literal)
 
-	if _, ok := Functions[uname]; ok || uname == "CALL" || uname == "NOW" || uname == "JSON_OBJECT" {
+	if _, ok := Functions[uname]; ok || uname == "CALL" || uname == "NOW" || uname == "NEXTVAL" || uname == "JSON_OBJECT" {
 		return NewBuiltInFunctionDeclaredError(name)
 	}
 	if _, ok := AggregateFunctions[uname]; ok {
 		return NewBuiltInFunctionDeclaredError(name)
 	}
+	if _, ok := BivariateAggregateFunctions[uname]; ok {
+		return NewBuiltInFunctionDeclaredError(name)
+	}
 	if _, ok := AnalyticFunctions[uname]; ok {
 		return NewBuiltInFunctionDeclaredError(name)
 	}
@@ -180,7 +214,10 @@ func (m UserDefinedFunctionMap) SortedKeys() []string {
 
 func (m UserDefinedFunctionMap) Dispose(name parser.Identifier) error {
 	uname := strings.ToUpper(name.Literal)
-	if _, ok := m[uname]; ok {
+	if fn, ok := m[uname]; ok {
+		if fn.External != nil {
+			fn.External.Close()
+		}
 		delete(m, uname)
 		return nil
 	}
@@ -196,11 +233,96 @@ type UserDefinedFunction struct {
 
 	IsAggregate bool
 	Cursor      parser.Identifier // For Aggregate Functions
+
+	// Results of a deterministic function are cached for each combination of arguments,
+	// and the function can be evaluated in parallel.
+	IsDeterministic bool
+	results         *sync.Map
+
+	// Script is set for functions written in Lua instead of statements.
+	Script *LuaFunction
+
+	// External is set for functions executed by external processes.
+	External *ExternalFunction
 }
 
 func (fn *UserDefinedFunction) Execute(ctx context.Context, filter *Filter, args []value.Primary) (value.Primary, error) {
-	childScope := filter.CreateChildScope()
-	return fn.execute(ctx, childScope, args)
+	if !fn.IsDeterministic {
+		childScope := filter.CreateChildScope()
+		return fn.execute(ctx, childScope, args)
+	}
+
+	key := serializeFunctionArguments(args)
+	if ret, ok := fn.results.Load(key); ok {
+		return ret.(value.Primary), nil
+	}
+
+	ret, err := fn.execute(ctx, filter.CreateChildScope(), args)
+	if err != nil {
+		return nil, err
+	}
+	fn.results.Store(key, ret)
+	return ret, nil
+}
+
+// isBatchable returns whether the function can be executed for multiple rows at once.
+func (fn *UserDefinedFunction) isBatchable() bool {
+	return fn.External != nil && fn.IsDeterministic
+}
+
+// Prefetch executes the function for each list of arguments in batches, and caches the results.
+func (fn *UserDefinedFunction) Prefetch(ctx context.Context, filter *Filter, argsList [][]value.Primary) error {
+	keys := make([]string, 0, ExternalFunctionBatchSize)
+	rows := make([][]value.Primary, 0, ExternalFunctionBatchSize)
+	pending := make(map[string]bool)
+
+	flush := func() error {
+		if len(rows) < 1 {
+			return nil
+		}
+		results, err := fn.External.Call(ctx, filter.tx.Session, fn.Name, rows)
+		if err != nil {
+			return err
+		}
+		for i := range results {
+			fn.results.Store(keys[i], results[i])
+		}
+		keys = keys[:0]
+		rows = rows[:0]
+		return nil
+	}
+
+	for _, args := range argsList {
+		key := serializeFunctionArguments(args)
+		if _, ok := fn.results.Load(key); ok || pending[key] {
+			continue
+		}
+
+		values, err := fn.bindArguments(ctx, filter.CreateChildScope(), args)
+		if err != nil {
+			// Errors are returned when the function is executed.
+			continue
+		}
+		pending[key] = true
+		keys = append(keys, key)
+		rows = append(rows, values)
+
+		if len(rows) == ExternalFunctionBatchSize {
+			if err = flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return flush()
+}
+
+func serializeFunctionArguments(args []value.Primary) string {
+	buf := &bytes.Buffer{}
+	for _, arg := range args {
+		buf.WriteString(fmt.Sprintf("%T:%d:", arg, len(arg.String())))
+		buf.WriteString(arg.String())
+	}
+	return buf.String()
 }
 
 func (fn *UserDefinedFunction) ExecuteAggregate(ctx context.Context, filter *Filter, values []value.Primary, args []value.Primary) (value.Primary, error) {
@@ -232,26 +354,47 @@ func (fn *UserDefinedFunction) CheckArgsLen(expr parser.QueryExpression, name st
 	return nil
 }
 
-func (fn *UserDefinedFunction) execute(ctx context.Context, filter *Filter, args []value.Primary) (value.Primary, error) {
+// bindArguments sets the arguments and the default values of omitted parameters
+// to the variables in the current scope of the filter, and returns all the values.
+func (fn *UserDefinedFunction) bindArguments(ctx context.Context, filter *Filter, args []value.Primary) ([]value.Primary, error) {
 	if err := fn.CheckArgsLen(fn.Name, fn.Name.Literal, len(args)); err != nil {
 		return nil, err
 	}
 
+	values := make([]value.Primary, len(fn.Parameters))
 	for i, v := range fn.Parameters {
 		if i < len(args) {
-			if err := filter.variables[0].Add(v, args[i]); err != nil {
-				return nil, err
-			}
+			values[i] = args[i]
 		} else {
 			defaultValue, _ := fn.Defaults[v.Name]
 			val, err := filter.Evaluate(ctx, defaultValue)
 			if err != nil {
 				return nil, err
 			}
-			if err = filter.variables[0].Add(v, val); err != nil {
-				return nil, err
-			}
+			values[i] = val
+		}
+		if err := filter.variables[0].Add(v, values[i]); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+func (fn *UserDefinedFunction) execute(ctx context.Context, filter *Filter, args []value.Primary) (value.Primary, error) {
+	values, err := fn.bindArguments(ctx, filter, args)
+	if err != nil {
+		return nil, err
+	}
+
+	if fn.Script != nil {
+		return fn.Script.Execute(ctx, fn.Name, values)
+	}
+	if fn.External != nil {
+		results, err := fn.External.Call(ctx, filter.tx.Session, fn.Name, [][]value.Primary{values})
+		if err != nil {
+			return nil, err
 		}
+		return results[0], nil
 	}
 
 	proc := NewProcessorWithFilter(filter.tx, filter)
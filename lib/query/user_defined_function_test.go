@@ -3,6 +3,8 @@ package query
 import (
 	"context"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/mithrandie/csvq/lib/parser"
@@ -539,6 +541,48 @@ var userDefinedFunctionMapDeclareTests = []struct {
 		},
 		Error: "parameter @arg1 is a duplicate",
 	},
+	{
+		Name: "UserDefinedFunctionMap Declare Deterministic Function",
+		Expr: parser.FunctionDeclaration{
+			Name: parser.Identifier{Literal: "detfunc"},
+			Parameters: []parser.VariableAssignment{
+				{
+					Variable: parser.Variable{Name: "arg1"},
+				},
+			},
+			Deterministic: parser.Token{Token: parser.DETERMINISTIC, Literal: "deterministic"},
+			Statements: []parser.Statement{
+				parser.Return{Value: parser.Variable{Name: "arg1"}},
+			},
+		},
+		Result: UserDefinedFunctionMap{
+			"USERFUNC": &UserDefinedFunction{
+				Name: parser.Identifier{Literal: "userfunc"},
+				Parameters: []parser.Variable{
+					{Name: "arg1"},
+					{Name: "arg2"},
+				},
+				Defaults:     map[string]parser.QueryExpression{},
+				RequiredArgs: 2,
+				Statements: []parser.Statement{
+					parser.Print{Value: parser.Variable{Name: "var1"}},
+				},
+			},
+			"DETFUNC": &UserDefinedFunction{
+				Name: parser.Identifier{Literal: "detfunc"},
+				Parameters: []parser.Variable{
+					{Name: "arg1"},
+				},
+				Defaults:     map[string]parser.QueryExpression{},
+				RequiredArgs: 1,
+				Statements: []parser.Statement{
+					parser.Return{Value: parser.Variable{Name: "arg1"}},
+				},
+				IsDeterministic: true,
+				results:         &sync.Map{},
+			},
+		},
+	},
 }
 
 func TestUserDefinedFunctionMap_Declare(t *testing.T) {
@@ -675,6 +719,11 @@ var userDefinedFunctionMapCheckDuplicateTests = []struct {
 		FuncName: parser.Identifier{Literal: "now"},
 		Error:    "function now is a built-in function",
 	},
+	{
+		Name:     "UserDefinedFunctionMap CheckDuplicate Duplicate with NEXTVAL Function Error",
+		FuncName: parser.Identifier{Literal: "nextval"},
+		Error:    "function nextval is a built-in function",
+	},
 	{
 		Name:     "UserDefinedFunctionMap CheckDuplicate Duplicate with Aggregate Function Error",
 		FuncName: parser.Identifier{Literal: "count"},
@@ -1016,6 +1065,63 @@ func TestUserDefinedFunction_Execute(t *testing.T) {
 	}
 }
 
+func TestUserDefinedFunction_Execute_Deterministic(t *testing.T) {
+	vars := GenerateVariableMap(map[string]value.Primary{
+		"count": value.NewInteger(0),
+	})
+	filter := NewFilterWithScopes(
+		TestTx,
+		[]VariableMap{vars},
+		[]ViewMap{{}},
+		[]CursorMap{{}},
+		[]UserDefinedFunctionMap{{}},
+	)
+
+	fn := &UserDefinedFunction{
+		Name: parser.Identifier{Literal: "detfunc"},
+		Parameters: []parser.Variable{
+			{Name: "arg1"},
+		},
+		Defaults:     map[string]parser.QueryExpression{},
+		RequiredArgs: 1,
+		Statements: []parser.Statement{
+			parser.VariableSubstitution{
+				Variable: parser.Variable{Name: "count"},
+				Value: parser.Arithmetic{
+					LHS:      parser.Variable{Name: "count"},
+					RHS:      parser.NewIntegerValue(1),
+					Operator: '+',
+				},
+			},
+			parser.Return{Value: parser.Variable{Name: "arg1"}},
+		},
+		IsDeterministic: true,
+		results:         &sync.Map{},
+	}
+
+	argsList := [][]value.Primary{
+		{value.NewInteger(1)},
+		{value.NewInteger(1)},
+		{value.NewString("1")},
+		{value.NewInteger(1)},
+	}
+	for _, args := range argsList {
+		result, err := fn.Execute(context.Background(), filter, args)
+		if err != nil {
+			t.Fatalf("unexpected error %q", err)
+		}
+		if !reflect.DeepEqual(result, args[0]) {
+			t.Errorf("result = %s, want %s", result, args[0])
+		}
+	}
+
+	count, _ := vars.Get(parser.Variable{Name: "count"})
+	expect := value.NewInteger(2)
+	if !reflect.DeepEqual(count, expect) {
+		t.Errorf("function executed %s times, want %s", count, expect)
+	}
+}
+
 var userDefinedFunctionExecuteAggregateTests = []struct {
 	Name   string
 	Func   *UserDefinedFunction
@@ -1381,3 +1487,66 @@ func TestUserDefinedFunction_CheckArgsLen(t *testing.T) {
 		}
 	}
 }
+
+func TestUserDefinedFunction_ExecuteAggregateInOrderedWindowFrame(t *testing.T) {
+	defer func() {
+		_ = TestTx.Rollback(nil, nil)
+	}()
+
+	src := `
+DECLARE ema AGGREGATE (list, @alpha)
+AS
+BEGIN
+    VAR @value, @fetch;
+
+    WHILE @fetch IN list
+    DO
+        IF @fetch IS NULL THEN
+            CONTINUE;
+        END IF;
+
+        IF @value IS NULL THEN
+            @value := @fetch;
+            CONTINUE;
+        END IF;
+
+        @value := @alpha * @fetch + (1 - @alpha) * @value;
+    END WHILE;
+
+    RETURN @value;
+END;
+
+DECLARE prices VIEW (item, day, price);
+INSERT INTO prices VALUES ('a', 4, 40), ('b', 2, 50), ('a', 2, 20), ('a', 1, 10), ('b', 1, 100), ('a', 3, NULL);
+`
+	statements, _, err := parser.Parse(src, "", nil, false)
+	if err != nil {
+		t.Fatalf("unexpected parse error %q", err)
+	}
+	proc := NewProcessor(TestTx)
+	if _, err = proc.Execute(context.Background(), statements); err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	statements, _, err = parser.Parse("SELECT item, day, ema(price, 0.5) OVER (PARTITION BY item ORDER BY day) FROM prices ORDER BY item, day", "", nil, false)
+	if err != nil {
+		t.Fatalf("unexpected parse error %q", err)
+	}
+	view, err := Select(context.Background(), proc.Filter, statements[0].(parser.SelectQuery))
+	if err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	expect := []string{"a 1 10", "a 2 15", "a 3 15", "a 4 27.5", "b 1 100", "b 2 75"}
+	result := make([]string, 0, view.RecordLen())
+	for _, record := range view.RecordSet {
+		s := make([]string, 0, len(record))
+		for _, cell := range record {
+			s = append(s, value.ToString(cell.Value()).(value.String).Raw())
+		}
+		result = append(result, strings.Join(s, " "))
+	}
+	if !reflect.DeepEqual(result, expect) {
+		t.Errorf("result = %q, want %q", result, expect)
+	}
+}
@@ -98,6 +98,10 @@ func SerializeKey(buf *bytes.Buffer, val value.Primary, flags *cmd.Flags) {
 		serializeBoolean(buf, b.(value.Boolean).Raw())
 	} else if s, ok := val.(value.String); ok {
 		serializeString(buf, s.Raw())
+	} else if a, ok := val.(value.Array); ok {
+		serializeArray(buf, a.Raw(), flags)
+	} else if j, ok := val.(value.Json); ok {
+		serializeString(buf, j.String())
 	} else {
 		serializeNull(buf)
 	}
@@ -154,3 +158,11 @@ func serializeString(buf *bytes.Buffer, s string) {
 	buf.WriteString("[S]")
 	buf.WriteString(strings.ToUpper(strings.TrimSpace(s)))
 }
+
+func serializeArray(buf *bytes.Buffer, values []value.Primary, flags *cmd.Flags) {
+	buf.WriteString("[A]")
+	buf.WriteString(value.Int64ToStr(int64(len(values))))
+	buf.WriteString("(")
+	SerializeComparisonKeys(buf, values, flags)
+	buf.WriteString(")")
+}
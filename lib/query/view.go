@@ -13,6 +13,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/mithrandie/csvq/lib/cmd"
 	"github.com/mithrandie/csvq/lib/file"
@@ -42,9 +43,15 @@ type View struct {
 	Tx     *Transaction
 	Filter *Filter
 
-	selectFields []int
-	selectLabels []string
-	isGrouped    bool
+	inlineTable *parser.InlineTable
+
+	selectFields     []int
+	selectLabels     []string
+	distinctOnFields []int
+	isGrouped        bool
+
+	// Indices of the columns holding the results of the aggregate functions computed by partial aggregation
+	partialAggregates map[*parser.BaseExpr]int
 
 	comparisonKeysInEachRecord []string
 	sortValuesInEachCell       [][]*SortValue
@@ -52,7 +59,12 @@ type View struct {
 	sortDirections             []int
 	sortNullPositions          []int
 
-	offset int
+	offset  int
+	indexes []*ViewIndex
+	spilled *spilledRecordSet
+
+	// Flags of the fields loaded as placeholders because they were not referred to.
+	prunedFields []bool
 
 	UseInternalId bool
 	ForUpdate     bool
@@ -78,6 +90,11 @@ func (view *View) Load(ctx context.Context, filter *Filter, clause parser.FromCl
 
 	views := make([]*View, len(clause.Tables))
 	for i, v := range clause.Tables {
+		if _, ok := unnestTable(v); ok && 0 < i {
+			// UNNEST is expanded for each record of the preceding tables.
+			continue
+		}
+
 		loaded, err := loadView(ctx, filter, v, view.UseInternalId, view.ForUpdate)
 		if err != nil {
 			return err
@@ -88,11 +105,26 @@ func (view *View) Load(ctx context.Context, filter *Filter, clause parser.FromCl
 	view.Header = views[0].Header
 	view.RecordSet = views[0].RecordSet
 	view.FileInfo = views[0].FileInfo
+	view.indexes = views[0].indexes
 
 	for i := 1; i < len(views); i++ {
+		if views[i] == nil {
+			table, _ := unnestTable(clause.Tables[i])
+			header, err := loadUnnestHeader(filter, table)
+			if err != nil {
+				return err
+			}
+			if err = UnnestJoin(ctx, filter, view, table.Object.(parser.Unnest), header, nil, false); err != nil {
+				return err
+			}
+			continue
+		}
+
+		start := time.Now()
 		if err := CrossJoin(ctx, filter, view, views[i]); err != nil {
 			return err
 		}
+		filter.recorder.record(newOperationKey("cross", clause.Tables[i]), view, start)
 	}
 
 	view.Filter = filter
@@ -115,11 +147,21 @@ func loadView(ctx context.Context, filter *Filter, tableExpr parser.QueryExpress
 	}
 
 	table := tableExpr.(parser.Table)
+	start := time.Now()
+
+	var sampler *tableSampler
+	if table.Sample != nil {
+		if sampler, err = newTableSampler(ctx, filter, table.Sample.(parser.TableSample)); err != nil {
+			return nil, err
+		}
+	}
 
 	switch table.Object.(type) {
 	case parser.Dual:
 		view = loadDualView(filter.tx)
 	case parser.Stdin:
+		filter.dependencies.setUncacheable()
+
 		fileInfo := &FileInfo{
 			Path:               table.Object.String(),
 			Format:             filter.tx.Flags.ImportFormat,
@@ -307,6 +349,7 @@ func loadView(ctx context.Context, filter *Filter, tableExpr parser.QueryExpress
 			filter,
 			useInternalId,
 			forUpdate,
+			sampler,
 			importFormat,
 			delimiter,
 			delimiterPositions,
@@ -331,6 +374,7 @@ func loadView(ctx context.Context, filter *Filter, tableExpr parser.QueryExpress
 			filter,
 			useInternalId,
 			forUpdate,
+			sampler,
 			cmd.AutoSelect,
 			filter.tx.Flags.Delimiter,
 			filter.tx.Flags.DelimiterPositions,
@@ -352,11 +396,26 @@ func loadView(ctx context.Context, filter *Filter, tableExpr parser.QueryExpress
 		if err != nil {
 			return nil, err
 		}
-		view2, err := loadView(ctx, filter, join.JoinTable, useInternalId, forUpdate)
-		if err != nil {
-			return nil, err
+
+		var view2 *View
+		unnest, lateral := unnestTable(join.JoinTable)
+		if lateral && (join.Direction.Token == parser.RIGHT || join.Direction.Token == parser.FULL) {
+			lateral = false
+		}
+		if lateral {
+			header, err := loadUnnestHeader(filter, unnest)
+			if err != nil {
+				return nil, err
+			}
+			view2 = &View{Tx: filter.tx, Header: header}
+		} else {
+			view2, err = loadView(ctx, filter, join.JoinTable, useInternalId, forUpdate)
+			if err != nil {
+				return nil, err
+			}
 		}
 
+		joinStart := time.Now()
 		condition, includeFields, excludeFields, err := ParseJoinCondition(join, view, view2)
 		if err != nil {
 			return nil, err
@@ -371,16 +430,20 @@ func loadView(ctx context.Context, filter *Filter, tableExpr parser.QueryExpress
 			}
 		}
 
-		switch joinType {
-		case parser.CROSS:
+		switch {
+		case lateral:
+			if err = UnnestJoin(ctx, filter, view, unnest.Object.(parser.Unnest), view2.Header, condition, joinType == parser.OUTER); err != nil {
+				return nil, err
+			}
+		case joinType == parser.CROSS:
 			if err = CrossJoin(ctx, filter, view, view2); err != nil {
 				return nil, err
 			}
-		case parser.INNER:
+		case joinType == parser.INNER:
 			if err = InnerJoin(ctx, filter, view, view2, condition); err != nil {
 				return nil, err
 			}
-		case parser.OUTER:
+		case joinType == parser.OUTER:
 			if err = OuterJoin(ctx, filter, view, view2, condition, join.Direction.Token); err != nil {
 				return nil, err
 			}
@@ -426,6 +489,7 @@ func loadView(ctx context.Context, filter *Filter, tableExpr parser.QueryExpress
 				return nil, err
 			}
 		}
+		filter.recorder.record(newJoinOperationKey("join", join.Table, join.JoinTable), view, joinStart)
 
 	case parser.JsonQuery:
 		jsonQuery := table.Object.(parser.JsonQuery)
@@ -444,6 +508,11 @@ func loadView(ctx context.Context, filter *Filter, tableExpr parser.QueryExpress
 		var reader io.Reader
 
 		if jsonPath, ok := jsonQuery.JsonText.(parser.Identifier); ok {
+			filter.dependencies.setUncacheable()
+
+			if err := filter.tx.checkFilePath(jsonPath); err != nil {
+				return nil, err
+			}
 			fpath, err := SearchJsonFilePath(jsonPath, filter.tx.Flags.Repository)
 			if err != nil {
 				return nil, err
@@ -458,7 +527,11 @@ func loadView(ctx context.Context, filter *Filter, tableExpr parser.QueryExpress
 					err = AppendCompositeError(err, e)
 				}
 			}()
-			reader = h.FileForRead()
+			r, _, err := decryptFile(h.FileForRead(), filter.tx.Flags)
+			if err != nil {
+				return nil, NewDataParsingError(jsonPath, fpath, err.Error())
+			}
+			reader = r
 		} else {
 			jsonTextValue, err := filter.Evaluate(ctx, jsonQuery.JsonText)
 			if err != nil {
@@ -491,12 +564,72 @@ func loadView(ctx context.Context, filter *Filter, tableExpr parser.QueryExpress
 			return nil, err
 		}
 
+	case parser.Unnest:
+		unnest := table.Object.(parser.Unnest)
+		records, err := unnestRecordSet(ctx, filter, unnest)
+		if err != nil {
+			return nil, err
+		}
+
+		view = &View{
+			Tx:        filter.tx,
+			Header:    NewHeader(table.Name().Literal, unnestColumns(unnest)),
+			RecordSet: records,
+		}
+
+		if err = filter.aliases.Add(table.Name(), ""); err != nil {
+			return nil, err
+		}
+
+	case parser.Pivot:
+		pivot := table.Object.(parser.Pivot)
+		view, err = loadView(ctx, filter, pivot.Table, false, false)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = view.Pivot(ctx, filter, pivot); err != nil {
+			return nil, err
+		}
+
+		if table.Alias != nil {
+			if err = view.Header.Update(table.Name().Literal, nil); err != nil {
+				return nil, err
+			}
+
+			if err = filter.aliases.Add(table.Name(), ""); err != nil {
+				return nil, err
+			}
+		}
+
+	case parser.Unpivot:
+		unpivot := table.Object.(parser.Unpivot)
+		view, err = loadView(ctx, filter, unpivot.Table, false, false)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = view.Unpivot(unpivot); err != nil {
+			return nil, err
+		}
+
+		if table.Alias != nil {
+			if err = view.Header.Update(table.Name().Literal, nil); err != nil {
+				return nil, err
+			}
+
+			if err = filter.aliases.Add(table.Name(), ""); err != nil {
+				return nil, err
+			}
+		}
+
 	case parser.Subquery:
 		subquery := table.Object.(parser.Subquery)
 		view, err = Select(ctx, filter, subquery.Query)
 		if err != nil {
 			return nil, err
 		}
+		start = time.Now()
 
 		if err = view.Header.Update(table.Name().Literal, nil); err != nil {
 			return nil, err
@@ -507,9 +640,320 @@ func loadView(ctx context.Context, filter *Filter, tableExpr parser.QueryExpress
 		}
 	}
 
+	if err == nil && table.Columns != nil {
+		if forUpdate {
+			err = NewTableColumnAliasesForUpdateError(table)
+		} else if err = view.Header.Update(table.Name().Literal, table.Columns); err != nil {
+			if _, ok := err.(*FieldLengthNotMatchError); ok {
+				err = NewTableColumnAliasesLengthError(table, view.FieldLen())
+			}
+		}
+	}
+
+	// Records of files are sampled while they are loaded.
+	if err == nil && sampler != nil && !sampler.applied {
+		view.sample(sampler)
+	}
+
+	if _, ok := table.Object.(parser.Join); !ok && err == nil {
+		filter.recorder.record(newOperationKey("scan", table), view, start)
+	}
 	return view, err
 }
 
+func unnestTable(expr parser.QueryExpression) (parser.Table, bool) {
+	if parentheses, ok := expr.(parser.Parentheses); ok {
+		return unnestTable(parentheses.Expr)
+	}
+	if table, ok := expr.(parser.Table); ok {
+		if _, ok := table.Object.(parser.Unnest); ok {
+			return table, true
+		}
+	}
+	return parser.Table{}, false
+}
+
+func unnestColumns(unnest parser.Unnest) []string {
+	if unnest.WithOrdinality() {
+		return []string{"value", "ordinality"}
+	}
+	return []string{"value"}
+}
+
+func loadUnnestHeader(filter *Filter, table parser.Table) (Header, error) {
+	header := NewHeader(table.Name().Literal, unnestColumns(table.Object.(parser.Unnest)))
+	if table.Columns != nil {
+		if err := header.Update(table.Name().Literal, table.Columns); err != nil {
+			if _, ok := err.(*FieldLengthNotMatchError); ok {
+				err = NewTableColumnAliasesLengthError(table, header.Len())
+			}
+			return nil, err
+		}
+	}
+
+	if err := filter.aliases.Add(table.Name(), ""); err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
+func unnestRecordSet(ctx context.Context, filter *Filter, unnest parser.Unnest) (RecordSet, error) {
+	p, err := filter.Evaluate(ctx, unnest.Array)
+	if err != nil {
+		return nil, err
+	}
+	if value.IsNull(p) {
+		return RecordSet{}, nil
+	}
+
+	array, ok := p.(value.Array)
+	if !ok {
+		return nil, NewUnnestNotArrayError(unnest, p)
+	}
+
+	records := make(RecordSet, array.Len())
+	for i, v := range array.Raw() {
+		values := []value.Primary{v}
+		if unnest.WithOrdinality() {
+			values = append(values, value.NewInteger(int64(i+1)))
+		}
+		records[i] = NewRecord(values)
+	}
+	return records, nil
+}
+
+func (view *View) Pivot(ctx context.Context, filter *Filter, pivot parser.Pivot) error {
+	var args []parser.QueryExpression
+	switch fn := pivot.Aggregate.(type) {
+	case parser.AggregateFunction:
+		args = fn.Args
+	case parser.Function:
+		args = fn.Args
+	default:
+		return NewInvalidPivotAggregationError(pivot)
+	}
+
+	pivotIdx, err := view.FieldIndex(pivot.Column)
+	if err != nil {
+		return err
+	}
+
+	excludeIndices := []int{pivotIdx}
+	if 0 < len(args) {
+		switch args[0].(type) {
+		case parser.FieldReference, parser.ColumnNumber:
+			idx, err := view.FieldIndex(args[0])
+			if err != nil {
+				return err
+			}
+			excludeIndices = append(excludeIndices, idx)
+		case parser.AllColumns, parser.PrimitiveType:
+		default:
+			return NewInvalidPivotAggregationError(pivot)
+		}
+	}
+
+	groupIndices := make([]int, 0, view.FieldLen())
+	for i := range view.Header {
+		if !InIntSlice(i, excludeIndices) {
+			groupIndices = append(groupIndices, i)
+		}
+	}
+
+	columns := make(map[string]int, len(pivot.Values))
+	for i, v := range pivot.Values {
+		p, err := filter.Evaluate(ctx, v.(parser.Field).Object)
+		if err != nil {
+			return err
+		}
+		buf := new(bytes.Buffer)
+		SerializeKey(buf, p, filter.tx.Flags)
+		if _, ok := columns[buf.String()]; !ok {
+			columns[buf.String()] = i
+		}
+	}
+
+	rows := make(map[string]int)
+	rowRecords := make([]int, 0)
+	groups := make(map[int][]int)
+	groupKeys := make([]int, 0)
+	for i, record := range view.RecordSet {
+		buf := new(bytes.Buffer)
+		primaries := make([]value.Primary, len(groupIndices))
+		for j, idx := range groupIndices {
+			primaries[j] = record[idx].Value()
+		}
+		SerializeComparisonKeys(buf, primaries, filter.tx.Flags)
+		rowIdx, ok := rows[buf.String()]
+		if !ok {
+			rowIdx = len(rowRecords)
+			rows[buf.String()] = rowIdx
+			rowRecords = append(rowRecords, i)
+		}
+
+		buf.Reset()
+		SerializeKey(buf, record[pivotIdx].Value(), filter.tx.Flags)
+		colIdx, ok := columns[buf.String()]
+		if !ok {
+			continue
+		}
+
+		groupKey := rowIdx*len(pivot.Values) + colIdx
+		if _, ok := groups[groupKey]; ok {
+			groups[groupKey] = append(groups[groupKey], i)
+		} else {
+			groups[groupKey] = []int{i}
+			groupKeys = append(groupKeys, groupKey)
+		}
+	}
+
+	groupedView := &View{
+		Header:    view.Header.Copy(),
+		RecordSet: make(RecordSet, len(groupKeys)),
+		Tx:        filter.tx,
+		isGrouped: true,
+	}
+	for _, idx := range groupIndices {
+		groupedView.Header[idx].IsGroupKey = true
+	}
+	groupedView.Header[pivotIdx].IsGroupKey = true
+
+	for i, groupKey := range groupKeys {
+		record := make(Record, view.FieldLen())
+		indices := groups[groupKey]
+
+		for j := 0; j < view.FieldLen(); j++ {
+			primaries := make([]value.Primary, len(indices))
+			for k, idx := range indices {
+				primaries[k] = view.RecordSet[idx][j].Value()
+			}
+			record[j] = NewGroupCell(primaries)
+		}
+
+		groupedView.RecordSet[i] = record
+	}
+
+	aggregated := make([]value.Primary, groupedView.RecordLen())
+	if err = NewFilterForSequentialEvaluation(filter, groupedView).EvaluateSequentially(ctx, func(f *Filter, rIdx int) error {
+		p, e := f.Evaluate(ctx, pivot.Aggregate)
+		if e != nil {
+			return e
+		}
+		aggregated[rIdx] = p
+		return nil
+	}, pivot.Aggregate); err != nil {
+		return err
+	}
+
+	header := make(Header, 0, len(groupIndices)+len(pivot.Values))
+	for _, idx := range groupIndices {
+		header = append(header, view.Header[idx])
+	}
+	for _, v := range pivot.Values {
+		header = append(header, HeaderField{
+			View:        view.Header[pivotIdx].View,
+			Column:      v.(parser.Field).Name(),
+			IsFromTable: true,
+		})
+	}
+	for i := range header {
+		header[i].Number = i + 1
+	}
+
+	records := make(RecordSet, len(rowRecords))
+	for i, recordIdx := range rowRecords {
+		record := make(Record, header.Len())
+		for j, idx := range groupIndices {
+			record[j] = NewCell(view.RecordSet[recordIdx][idx].Value())
+		}
+		for j := len(groupIndices); j < len(record); j++ {
+			record[j] = NewCell(value.NewNull())
+		}
+		records[i] = record
+	}
+	for i, groupKey := range groupKeys {
+		records[groupKey/len(pivot.Values)][len(groupIndices)+groupKey%len(pivot.Values)] = NewCell(aggregated[i])
+	}
+
+	view.Header = header
+	view.RecordSet = records
+	view.FileInfo = nil
+	view.indexes = nil
+	return nil
+}
+
+func (view *View) Unpivot(unpivot parser.Unpivot) error {
+	columnIndices, err := view.FieldIndices(unpivot.Columns)
+	if err != nil {
+		return err
+	}
+
+	fieldIndices := make([]int, 0, view.FieldLen())
+	header := make(Header, 0, view.FieldLen()-len(columnIndices)+2)
+	for i := range view.Header {
+		if InIntSlice(i, columnIndices) {
+			continue
+		}
+		fieldIndices = append(fieldIndices, i)
+		header = append(header, view.Header[i])
+	}
+
+	reference := view.Header[columnIndices[0]].View
+	header = append(
+		header,
+		HeaderField{View: reference, Column: unpivot.Name.Literal, IsFromTable: true},
+		HeaderField{View: reference, Column: unpivot.Value.Literal, IsFromTable: true},
+	)
+	for i := range header {
+		header[i].Number = i + 1
+	}
+
+	names := make([]value.Primary, len(columnIndices))
+	for i, idx := range columnIndices {
+		names[i] = value.NewString(view.Header[idx].Column)
+	}
+
+	records := make(RecordSet, 0, view.RecordLen()*len(columnIndices))
+	for _, record := range view.RecordSet {
+		for i, idx := range columnIndices {
+			if value.IsNull(record[idx].Value()) {
+				continue
+			}
+
+			r := make(Record, header.Len())
+			for j, fidx := range fieldIndices {
+				r[j] = record[fidx]
+			}
+			r[len(fieldIndices)] = NewCell(names[i])
+			r[len(fieldIndices)+1] = record[idx]
+			records = append(records, r)
+		}
+	}
+
+	view.Header = header
+	view.RecordSet = records
+	view.FileInfo = nil
+	view.indexes = nil
+	return nil
+}
+
+func (view *View) Sample(ctx context.Context, filter *Filter, sample parser.TableSample) error {
+	sampler, err := newTableSampler(ctx, filter, sample)
+	if err != nil || sampler == nil {
+		return err
+	}
+	view.sample(sampler)
+	return nil
+}
+
+func (view *View) sample(sampler *tableSampler) {
+	for _, record := range view.RecordSet {
+		sampler.Add(record)
+	}
+	view.RecordSet = sampler.RecordSet()
+	view.indexes = nil
+}
+
 func loadStdin(ctx context.Context, filter *Filter, table parser.Table, fileInfo *FileInfo) error {
 	stdinLoadingMutex.Lock()
 	defer stdinLoadingMutex.Unlock()
@@ -528,7 +972,7 @@ func loadStdin(ctx context.Context, filter *Filter, table parser.Table, fileInfo
 			}
 
 			br := bytes.NewReader(buf)
-			loadView, err = loadViewFromFile(ctx, filter.tx, br, fileInfo, filter.tx.Flags.WithoutNull)
+			loadView, err = loadViewFromFile(ctx, filter.tx, br, fileInfo, filter.tx.Flags.WithoutNull, nil)
 			if err != nil {
 				return NewDataParsingError(table.Object, fileInfo.Path, err.Error())
 			}
@@ -572,6 +1016,7 @@ func loadObject(
 	filter *Filter,
 	useInternalId bool,
 	forUpdate bool,
+	sampler *tableSampler,
 	importFormat cmd.Format,
 	delimiter rune,
 	delimiterPositions []int,
@@ -585,7 +1030,7 @@ func loadObject(
 	withoutNull bool,
 ) (*View, error) {
 	if filter.recursiveTable != nil && strings.EqualFold(tableIdentifier.Literal, filter.recursiveTable.Name.Literal) && filter.recursiveTmpView != nil {
-		view := filter.recursiveTmpView
+		view := filter.recursiveTmpView.Copy()
 		if !strings.EqualFold(filter.recursiveTable.Name.Literal, tableName.Literal) {
 			if err := view.Header.Update(tableName.Literal, nil); err != nil {
 				return nil, err
@@ -594,7 +1039,11 @@ func loadObject(
 		return view, nil
 	}
 
-	if view, err := filter.inlineTables.Get(tableIdentifier); err == nil {
+	if filter.inlineTables.Exists(tableIdentifier) {
+		view, err := filter.inlineTables.Get(ctx, tableIdentifier)
+		if err != nil {
+			return nil, err
+		}
 		if err = filter.aliases.Add(tableName, ""); err != nil {
 			return nil, err
 		}
@@ -608,20 +1057,32 @@ func loadObject(
 
 	filePath := tableIdentifier.Literal
 	if filter.tempViews.Exists(filePath) {
+		filter.dependencies.setUncacheable()
+
 		var view *View
+		var err error
 		pathIdent := parser.Identifier{Literal: filePath}
+
+		filter.tx.viewLoadingMutex.Lock()
 		if useInternalId {
-			view, _ = filter.tempViews.GetWithInternalId(ctx, pathIdent, filter.tx.Flags)
+			view, err = filter.tempViews.GetWithInternalId(ctx, pathIdent, filter.tx.Flags)
 		} else {
-			view, _ = filter.tempViews.Get(pathIdent)
+			view, err = filter.tempViews.Get(pathIdent)
+		}
+		if err == nil {
+			view.indexes = filter.tempViews.PrimaryKeyIndexes(filePath, filter.tx.Flags)
+		}
+		filter.tx.viewLoadingMutex.Unlock()
+		if err != nil {
+			return nil, err
 		}
 
-		if err := filter.aliases.Add(tableName, filePath); err != nil {
+		if err = filter.aliases.Add(tableName, filePath); err != nil {
 			return nil, err
 		}
 
 		if !strings.EqualFold(parser.FormatTableName(filePath), tableName.Literal) {
-			if err := view.Header.Update(tableName.Literal, nil); err != nil {
+			if err = view.Header.Update(tableName.Literal, nil); err != nil {
 				return nil, err
 			}
 		}
@@ -629,6 +1090,43 @@ func loadObject(
 		return view, nil
 	}
 
+	if sampler != nil && !forUpdate && !useInternalId {
+		view, err := loadSampledViewFromFile(
+			ctx,
+			tableIdentifier,
+			filter,
+			sampler,
+			importFormat,
+			delimiter,
+			delimiterPositions,
+			singleLine,
+			jsonQuery,
+			encoding,
+			lineBreak,
+			noHeader,
+			encloseAll,
+			jsonEscape,
+			withoutNull,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if view != nil {
+			filter.dependOnFile(view.FileInfo)
+
+			if err = filter.aliases.Add(tableName, view.FileInfo.Path); err != nil {
+				return nil, err
+			}
+
+			if !strings.EqualFold(parser.FormatTableName(view.FileInfo.Path), tableName.Literal) {
+				if err = view.Header.Update(tableName.Literal, nil); err != nil {
+					return nil, err
+				}
+			}
+			return view, nil
+		}
+	}
+
 	filePath, err := cacheViewFromFile(
 		ctx,
 		tableIdentifier,
@@ -647,7 +1145,23 @@ func loadObject(
 		withoutNull,
 	)
 	if err != nil {
-		return nil, err
+		if _, ok := err.(*FileNotExistError); !ok || forUpdate {
+			return nil, err
+		}
+		definition, fpath, ok := LoadViewDefinition(ctx, filter.tx, tableIdentifier)
+		if !ok {
+			return nil, err
+		}
+		filter.dependencies.setUncacheable()
+
+		view, err := selectViewDefinition(ctx, filter, definition, fpath, tableName)
+		if err != nil {
+			return nil, err
+		}
+		if err = filter.aliases.Add(tableName, ""); err != nil {
+			return nil, err
+		}
+		return view, nil
 	}
 
 	var view *View
@@ -658,6 +1172,9 @@ func loadObject(
 		view, _ = filter.tx.cachedViews.Get(pathIdent)
 	}
 
+	view.indexes = filter.tx.indexes.Build(filter.tx.cachedViews[strings.ToUpper(filePath)], filter.tx.Flags)
+	filter.dependOnFile(view.FileInfo)
+
 	if err = filter.aliases.Add(tableName, filePath); err != nil {
 		return nil, err
 	}
@@ -687,6 +1204,13 @@ func cacheViewFromFile(
 	jsonEscape txjson.EscapeType,
 	withoutNull bool,
 ) (string, error) {
+	if forUpdate && filter.tx.Flags.ReadOnly {
+		return "", NewFileReadOnlyError(tableIdentifier)
+	}
+	if err := filter.tx.checkFilePath(tableIdentifier); err != nil {
+		return "", err
+	}
+
 	filter.tx.viewLoadingMutex.Lock()
 	defer filter.tx.viewLoadingMutex.Unlock()
 
@@ -699,14 +1223,14 @@ func cacheViewFromFile(
 		filePath = p
 	}
 
-	if !filter.tx.cachedViews.Exists(filePath) || (forUpdate && !filter.tx.cachedViews[strings.ToUpper(filePath)].ForUpdate) {
+	if !filter.tx.cachedViews.Exists(filePath) || (forUpdate && !filter.tx.cachedViews[strings.ToUpper(filePath)].ForUpdate) || !filter.tx.cachedViews[strings.ToUpper(filePath)].hasColumns(filter.loadColumns) {
 		fileInfo, err := NewFileInfo(tableIdentifier, filter.tx.Flags.Repository, importFormat, delimiter, encoding, filter.tx.Flags)
 		if err != nil {
 			return filePath, err
 		}
 		filePath = fileInfo.Path
 
-		if !filter.tx.cachedViews.Exists(fileInfo.Path) || (forUpdate && !filter.tx.cachedViews[strings.ToUpper(fileInfo.Path)].ForUpdate) {
+		if !filter.tx.cachedViews.Exists(fileInfo.Path) || (forUpdate && !filter.tx.cachedViews[strings.ToUpper(fileInfo.Path)].ForUpdate) || !filter.tx.cachedViews[strings.ToUpper(fileInfo.Path)].hasColumns(filter.loadColumns) {
 			fileInfo.DelimiterPositions = delimiterPositions
 			fileInfo.SingleLine = singleLine
 			fileInfo.JsonQuery = strings.TrimSpace(jsonQuery)
@@ -723,6 +1247,18 @@ func cacheViewFromFile(
 				return filePath, err
 			}
 
+			sharedOptions := sharedViewOptions(fileInfo, withoutNull)
+			if !forUpdate && filter.tx.sharedViews != nil {
+				if view, ok := filter.tx.sharedViews.Get(fileInfo.Path, sharedOptions); ok {
+					filter.tx.cachedViews.Set(view)
+					if !cacheExists {
+						filter.storeFilePath(tableIdentifier.Literal, filePath)
+					}
+					return filePath, nil
+				}
+			}
+
+			start := time.Now()
 			var fp *os.File
 			if forUpdate {
 				h, err := file.NewHandlerForUpdate(ctx, filter.tx.FileContainer, fileInfo.Path, filter.tx.WaitTimeout, filter.tx.RetryDelay)
@@ -744,7 +1280,33 @@ func cacheViewFromFile(
 				fp = h.FileForRead()
 			}
 
-			loadView, err := loadViewFromFile(ctx, filter.tx, fp, fileInfo, withoutNull)
+			// Views to be updated or indexed are loaded with all the columns.
+			loadColumns := filter.loadColumns
+			if forUpdate || filter.tx.indexes.Exists(fileInfo.Path) {
+				loadColumns = nil
+			}
+
+			// The status is taken before reading, so that the records are never newer than the status.
+			stat, statErr := fp.Stat()
+			if (filter.tx.Flags.ResultCache || filter.tx.Flags.ReadOnly) && statErr == nil {
+				fileInfo.loadedStat = stat
+			}
+
+			opened := time.Now()
+			r, encrypted, err := decryptFile(fp, filter.tx.Flags)
+			if err != nil {
+				err = NewDataParsingError(tableIdentifier, fileInfo.Path, err.Error())
+				if e := filter.tx.FileContainer.Close(fileInfo.Handler); e != nil {
+					err = AppendCompositeError(err, e)
+				}
+				return filePath, err
+			}
+			fileInfo.Encrypted = encrypted
+
+			fileInfo.progress = startProgress(filter.tx, "Loading", fileInfo.Path, "records")
+			loadView, err := loadViewFromFile(ctx, filter.tx, r, fileInfo, withoutNull, loadColumns)
+			fileInfo.progress.finish()
+			fileInfo.progress = nil
 			if err != nil {
 				err = NewDataParsingError(tableIdentifier, fileInfo.Path, err.Error())
 				if e := filter.tx.FileContainer.Close(fileInfo.Handler); e != nil {
@@ -754,6 +1316,32 @@ func cacheViewFromFile(
 			}
 			loadView.ForUpdate = forUpdate
 			filter.tx.cachedViews.Set(loadView)
+
+			if filter.recorder != nil || filter.tx.Flags.Stats {
+				recordFileLoad(filter, FileLoadStat{
+					Path:      fileInfo.Path,
+					Records:   loadView.RecordLen(),
+					WaitTime:  opened.Sub(start),
+					ParseTime: time.Since(opened),
+					start:     start,
+				})
+			}
+
+			if err = checkMemoryLimitWithoutLock(filter, 0); err != nil {
+				if e := filter.tx.cachedViews.Dispose(filter.tx.FileContainer, fileInfo.Path); e != nil {
+					err = AppendCompositeError(err, e)
+				}
+				return filePath, err
+			}
+
+			if filter.tx.Flags.StatisticsCache && statErr == nil {
+				// Statistics of encrypted files are not saved, since they contain values in the files.
+				fileInfo.statistics = cacheTableStatistics(loadView, sharedOptions, stat, !filter.tx.Flags.ReadOnly && !fileInfo.Encrypted)
+			}
+
+			if !forUpdate && filter.tx.sharedViews != nil && loadView.prunedFields == nil && statErr == nil {
+				filter.tx.sharedViews.Set(loadView, sharedOptions, stat)
+			}
 		}
 	}
 	if !cacheExists {
@@ -762,19 +1350,125 @@ func cacheViewFromFile(
 	return filePath, nil
 }
 
-func loadViewFromFile(ctx context.Context, tx *Transaction, fp io.ReadSeeker, fileInfo *FileInfo, withoutNull bool) (*View, error) {
+// loadSampledViewFromFile loads the view from the file with the records selected by the sampler.
+// The records not selected are discarded while the file is read, and the view is not cached.
+// If the file does not exist or has already been loaded, then nil is returned, and the view must be
+// loaded through the cache.
+func loadSampledViewFromFile(
+	ctx context.Context,
+	tableIdentifier parser.Identifier,
+	filter *Filter,
+	sampler *tableSampler,
+	importFormat cmd.Format,
+	delimiter rune,
+	delimiterPositions []int,
+	singleLine bool,
+	jsonQuery string,
+	encoding text.Encoding,
+	lineBreak text.LineBreak,
+	noHeader bool,
+	encloseAll bool,
+	jsonEscape txjson.EscapeType,
+	withoutNull bool,
+) (view *View, err error) {
+	if err := filter.tx.checkFilePath(tableIdentifier); err != nil {
+		return nil, err
+	}
+
+	filter.tx.viewLoadingMutex.Lock()
+	defer filter.tx.viewLoadingMutex.Unlock()
+
+	fileInfo, err := NewFileInfo(tableIdentifier, filter.tx.Flags.Repository, importFormat, delimiter, encoding, filter.tx.Flags)
+	if err != nil {
+		if _, ok := err.(*FileNotExistError); ok {
+			return nil, nil
+		}
+		return nil, err
+	}
+	// Views already loaded in the transaction may contain uncommitted changes.
+	if filter.tx.cachedViews.Exists(fileInfo.Path) {
+		return nil, nil
+	}
+
+	fileInfo.DelimiterPositions = delimiterPositions
+	fileInfo.SingleLine = singleLine
+	fileInfo.JsonQuery = strings.TrimSpace(jsonQuery)
+	fileInfo.LineBreak = lineBreak
+	fileInfo.NoHeader = noHeader
+	fileInfo.EncloseAll = encloseAll
+	fileInfo.JsonEscape = jsonEscape
+
+	if filter.tx.sharedViews != nil {
+		if _, ok := filter.tx.sharedViews.Get(fileInfo.Path, sharedViewOptions(fileInfo, withoutNull)); ok {
+			return nil, nil
+		}
+	}
+
+	start := time.Now()
+	h, err := file.NewHandlerForRead(ctx, filter.tx.FileContainer, fileInfo.Path, filter.tx.WaitTimeout, filter.tx.RetryDelay)
+	if err != nil {
+		return nil, ConvertFileHandlerError(err, tableIdentifier, fileInfo.Path)
+	}
+	defer func() {
+		if e := filter.tx.FileContainer.Close(h); e != nil {
+			err = AppendCompositeError(err, e)
+		}
+	}()
+	fp := h.FileForRead()
+
+	if stat, e := fp.Stat(); (filter.tx.Flags.ResultCache || filter.tx.Flags.ReadOnly) && e == nil {
+		fileInfo.loadedStat = stat
+	}
+
+	opened := time.Now()
+	r, encrypted, err := decryptFile(fp, filter.tx.Flags)
+	if err != nil {
+		return nil, NewDataParsingError(tableIdentifier, fileInfo.Path, err.Error())
+	}
+	fileInfo.Encrypted = encrypted
+
+	fileInfo.progress = startProgress(filter.tx, "Loading", fileInfo.Path, "records")
+	fileInfo.sampler = sampler
+	view, err = loadViewFromFile(ctx, filter.tx, r, fileInfo, withoutNull, filter.loadColumns)
+	fileInfo.progress.finish()
+	fileInfo.progress = nil
+	fileInfo.sampler = nil
+	if err != nil {
+		return nil, NewDataParsingError(tableIdentifier, fileInfo.Path, err.Error())
+	}
+
+	if filter.recorder != nil || filter.tx.Flags.Stats {
+		recordFileLoad(filter, FileLoadStat{
+			Path:      fileInfo.Path,
+			Records:   view.RecordLen(),
+			WaitTime:  opened.Sub(start),
+			ParseTime: time.Since(opened),
+			start:     start,
+		})
+	}
+
+	if err = checkMemoryLimitWithoutLock(filter, estimateRecordSetSize(view.RecordSet)); err != nil {
+		return nil, err
+	}
+	return view, nil
+}
+
+// loadViewFromFile loads the view from the file.
+// If the columns are not nil, then the fields of csv, tsv and fixed-length files not in the columns
+// are loaded as null without being parsed.
+func loadViewFromFile(ctx context.Context, tx *Transaction, fp io.ReadSeeker, fileInfo *FileInfo, withoutNull bool, columns ColumnSet) (*View, error) {
 	switch fileInfo.Format {
 	case cmd.FIXED:
-		return loadViewFromFixedLengthTextFile(ctx, tx, fp, fileInfo, withoutNull)
+		return loadViewFromFixedLengthTextFile(ctx, tx, fp, fileInfo, withoutNull, columns)
 	case cmd.LTSV:
 		return loadViewFromLTSVFile(ctx, tx, fp, fileInfo, withoutNull)
 	case cmd.JSON:
 		return loadViewFromJsonFile(tx, fp, fileInfo)
 	}
-	return loadViewFromCSVFile(ctx, tx, fp, fileInfo, withoutNull)
+	return loadViewFromCSVFile(ctx, tx, fp, fileInfo, withoutNull, columns)
 }
 
-func loadViewFromFixedLengthTextFile(ctx context.Context, tx *Transaction, fp io.ReadSeeker, fileInfo *FileInfo, withoutNull bool) (*View, error) {
+func loadViewFromFixedLengthTextFile(ctx context.Context, tx *Transaction, fp io.ReadSeeker, fileInfo *FileInfo, withoutNull bool, columns ColumnSet) (*View, error) {
 	if enc, err := text.DetectEncoding(fp); err == nil {
 		fileInfo.Encoding = enc
 	}
@@ -782,9 +1476,12 @@ func loadViewFromFixedLengthTextFile(ctx context.Context, tx *Transaction, fp io
 	var r io.Reader
 
 	if fileInfo.DelimiterPositions == nil {
-		data, err := ioutil.ReadAll(fp)
-		if err != nil {
-			return nil, err
+		data, ok := mapFile(tx, fp)
+		if !ok {
+			var err error
+			if data, err = ioutil.ReadAll(fp); err != nil {
+				return nil, err
+			}
 		}
 		br := bytes.NewReader(data)
 
@@ -823,7 +1520,7 @@ func loadViewFromFixedLengthTextFile(ctx context.Context, tx *Transaction, fp io
 		}
 	}
 
-	records, err := readRecordSet(ctx, reader)
+	records, pruned, err := readRecordSet(ctx, newFileRecordSetBuilder(tx, fileInfo, 1000), reader, columns, header)
 	if err != nil {
 		return nil, err
 	}
@@ -843,20 +1540,70 @@ func loadViewFromFixedLengthTextFile(ctx context.Context, tx *Transaction, fp io
 	view.Header = NewHeaderWithAutofill(parser.FormatTableName(fileInfo.Path), header)
 	view.RecordSet = records
 	view.FileInfo = fileInfo
+	view.prunedFields = pruned
 	return view, nil
 }
 
-func loadViewFromCSVFile(ctx context.Context, tx *Transaction, fp io.ReadSeeker, fileInfo *FileInfo, withoutNull bool) (*View, error) {
+func loadViewFromCSVFile(ctx context.Context, tx *Transaction, fp io.ReadSeeker, fileInfo *FileInfo, withoutNull bool, columns ColumnSet) (*View, error) {
 	if enc, err := text.DetectEncoding(fp); err == nil {
 		fileInfo.Encoding = enc
 	}
 
-	reader, err := csv.NewReader(fp, fileInfo.Encoding)
+	if canReadMappedCSV(fileInfo.Delimiter, fileInfo.Encoding) {
+		if data, ok := mapFile(tx, fp); ok {
+			if view, err := loadViewFromMappedCSVFile(ctx, tx, data, fileInfo, withoutNull, columns); err != errMappedFileNotReadable {
+				return view, err
+			}
+		}
+	}
+
+	reader, err := csv.NewReader(fp, fileInfo.Encoding)
+	if err != nil {
+		return nil, err
+	}
+	reader.Delimiter = fileInfo.Delimiter
+	reader.WithoutNull = withoutNull
+
+	var header []string
+	if !fileInfo.NoHeader {
+		header, err = reader.ReadHeader()
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+	}
+
+	records, pruned, err := readRecordSet(ctx, newFileRecordSetBuilder(tx, fileInfo, 1000), reader, columns, header)
+	if err != nil {
+		return nil, err
+	}
+
+	if header == nil {
+		header = make([]string, reader.FieldsPerRecord)
+		for i := 0; i < reader.FieldsPerRecord; i++ {
+			header[i] = "c" + strconv.Itoa(i+1)
+		}
+	}
+
+	if reader.DetectedLineBreak != "" {
+		fileInfo.LineBreak = reader.DetectedLineBreak
+	}
+	fileInfo.EncloseAll = reader.EnclosedAll
+
+	view := NewView(tx)
+	view.Header = NewHeader(parser.FormatTableName(fileInfo.Path), header)
+	view.RecordSet = records
+	view.FileInfo = fileInfo
+	view.prunedFields = pruned
+	return view, nil
+}
+
+// loadViewFromMappedCSVFile loads the view from the csv data in the memory-mapped file.
+// If the data cannot be read by the mappedCSVReader, then errMappedFileNotReadable is returned.
+func loadViewFromMappedCSVFile(ctx context.Context, tx *Transaction, data []byte, fileInfo *FileInfo, withoutNull bool, columns ColumnSet) (*View, error) {
+	reader, err := newMappedCSVReader(data, fileInfo.Delimiter, fileInfo.Encoding)
 	if err != nil {
 		return nil, err
 	}
-	reader.Delimiter = fileInfo.Delimiter
-	reader.WithoutNull = withoutNull
 
 	var header []string
 	if !fileInfo.NoHeader {
@@ -866,7 +1613,7 @@ func loadViewFromCSVFile(ctx context.Context, tx *Transaction, fp io.ReadSeeker,
 		}
 	}
 
-	records, err := readRecordSet(ctx, reader)
+	records, pruned, err := reader.readAll(ctx, newFileRecordSetBuilder(tx, fileInfo, 1000), withoutNull, columns, header)
 	if err != nil {
 		return nil, err
 	}
@@ -887,6 +1634,7 @@ func loadViewFromCSVFile(ctx context.Context, tx *Transaction, fp io.ReadSeeker,
 	view.Header = NewHeader(parser.FormatTableName(fileInfo.Path), header)
 	view.RecordSet = records
 	view.FileInfo = fileInfo
+	view.prunedFields = pruned
 	return view, nil
 }
 
@@ -901,7 +1649,7 @@ func loadViewFromLTSVFile(ctx context.Context, tx *Transaction, fp io.ReadSeeker
 	}
 	reader.WithoutNull = withoutNull
 
-	records, err := readRecordSet(ctx, reader)
+	records, _, err := readRecordSet(ctx, newFileRecordSetBuilder(tx, fileInfo, 1000), reader, nil, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -931,9 +1679,20 @@ func loadViewFromLTSVFile(ctx context.Context, tx *Transaction, fp io.ReadSeeker
 	return view, nil
 }
 
-func readRecordSet(ctx context.Context, reader RecordReader) (RecordSet, error) {
+// readRecordSet reads all the records from the reader.
+// If the columns are not nil, then the fields not in the columns are set to null without being converted,
+// and the flags of the pruned fields are returned.
+// newFileRecordSetBuilder returns the builder of the records loaded from the file.
+func newFileRecordSetBuilder(tx *Transaction, fileInfo *FileInfo, capacity int) *recordSetBuilder {
+	records := newRecordSetBuilder(tx.Flags.Columnar, capacity)
+	records.progress = fileInfo.progress
+	records.sampler = fileInfo.sampler
+	return records
+}
+
+func readRecordSet(ctx context.Context, records *recordSetBuilder, reader RecordReader, columns ColumnSet, header []string) (RecordSet, []bool, error) {
 	var err error
-	records := make(RecordSet, 0, 1000)
+	var pruned []bool
 	rowch := make(chan []text.RawText, 1000)
 	fieldch := make(chan []value.Primary, 1000)
 
@@ -946,7 +1705,7 @@ func readRecordSet(ctx context.Context, reader RecordReader) (RecordSet, error)
 			if !ok {
 				break
 			}
-			records = append(records, NewRecord(primaries))
+			records.Append(primaries)
 		}
 		wg.Done()
 	}()
@@ -958,9 +1717,14 @@ func readRecordSet(ctx context.Context, reader RecordReader) (RecordSet, error)
 			if !ok {
 				break
 			}
+			if columns != nil {
+				pruned = columns.prunedFields(header, len(row))
+				columns = nil
+			}
+
 			fields := make([]value.Primary, len(row))
 			for i, v := range row {
-				if v == nil {
+				if v == nil || (i < len(pruned) && pruned[i]) {
 					fields[i] = value.NewNull()
 				} else {
 					fields[i] = value.NewString(string(v))
@@ -996,30 +1760,36 @@ func readRecordSet(ctx context.Context, reader RecordReader) (RecordSet, error)
 
 	wg.Wait()
 
-	return records, err
+	return records.RecordSet(), pruned, err
 }
 
 func loadViewFromJsonFile(tx *Transaction, fp io.Reader, fileInfo *FileInfo) (*View, error) {
-	jsonText, err := ioutil.ReadAll(fp)
-	if err != nil {
-		return nil, err
+	var jsonText string
+	if data, ok := mapFile(tx, fp); ok {
+		jsonText = mappedString(data)
+	} else {
+		b, err := ioutil.ReadAll(fp)
+		if err != nil {
+			return nil, err
+		}
+		jsonText = string(b)
 	}
 
-	headerLabels, rows, escapeType, err := json.LoadTable(fileInfo.JsonQuery, string(jsonText))
+	headerLabels, rows, escapeType, err := json.LoadTable(fileInfo.JsonQuery, jsonText)
 	if err != nil {
 		return nil, err
 	}
 
-	records := make([]Record, 0, len(rows))
+	records := newFileRecordSetBuilder(tx, fileInfo, len(rows))
 	for _, row := range rows {
-		records = append(records, NewRecord(row))
+		records.Append(row)
 	}
 
 	fileInfo.JsonEscape = escapeType
 
 	view := NewView(tx)
 	view.Header = NewHeader(parser.FormatTableName(fileInfo.Path), headerLabels)
-	view.RecordSet = records
+	view.RecordSet = records.RecordSet()
 	view.FileInfo = fileInfo
 	return view, nil
 }
@@ -1039,6 +1809,20 @@ func NewViewFromGroupedRecord(filterRecord filterRecord) *View {
 	view.Header = filterRecord.view.Header
 	record := filterRecord.view.RecordSet[filterRecord.recordIndex]
 
+	isCopied := false
+	for j := range view.Header {
+		if !view.Header[j].IsGroupKey {
+			continue
+		}
+		if idx, ok := view.Header.ContainsGroupingColumn(GroupingValuesColumnPrefix + strconv.Itoa(j)); ok {
+			if !isCopied {
+				record = record.Copy()
+				isCopied = true
+			}
+			record[j] = record[idx]
+		}
+	}
+
 	view.RecordSet = make([]Record, record.GroupLen())
 	for i := 0; i < record.GroupLen(); i++ {
 		view.RecordSet[i] = make(Record, view.FieldLen())
@@ -1061,6 +1845,17 @@ func (view *View) Where(ctx context.Context, clause parser.WhereClause) error {
 }
 
 func (view *View) filter(ctx context.Context, condition parser.QueryExpression) error {
+	if view.indexes != nil {
+		if indices, _, ok := view.searchIndexedRecords(condition, view.Filter.tx.Flags); ok {
+			records := make(RecordSet, len(indices))
+			for i, idx := range indices {
+				records[i] = view.RecordSet[idx]
+			}
+			view.RecordSet = records
+		}
+		view.indexes = nil
+	}
+
 	results := make([]bool, view.RecordLen())
 
 	err := NewFilterForSequentialEvaluation(view.Filter, view).EvaluateSequentially(ctx, func(f *Filter, rIdx int) error {
@@ -1091,13 +1886,120 @@ func (view *View) filter(ctx context.Context, condition parser.QueryExpression)
 }
 
 func (view *View) GroupBy(ctx context.Context, clause parser.GroupByClause) error {
-	return view.group(ctx, clause.Items)
+	return view.groupBy(ctx, clause, nil)
+}
+
+// groupBy groups the records, and computes the aggregate functions by partial aggregation if possible.
+func (view *View) groupBy(ctx context.Context, clause parser.GroupByClause, aggregates []*partialAggregate) error {
+	items, groupingSets := parseGroupingSets(clause.Items)
+	if groupingSets == nil {
+		return view.group(ctx, items, aggregates)
+	}
+	return view.groupByGroupingSets(ctx, items, groupingSets)
+}
+
+func parseGroupingSets(exprs []parser.QueryExpression) ([]parser.QueryExpression, [][]int) {
+	items := make([]parser.QueryExpression, 0, len(exprs))
+	hasGroupingSets := false
+
+	var itemIndex = func(expr parser.QueryExpression) int {
+		s := expr.String()
+		for i, item := range items {
+			if item.String() == s {
+				return i
+			}
+		}
+		items = append(items, expr)
+		return len(items) - 1
+	}
+
+	var itemIndices = func(exprs []parser.QueryExpression) []int {
+		indices := make([]int, len(exprs))
+		for i, expr := range exprs {
+			indices[i] = itemIndex(expr)
+		}
+		return indices
+	}
+
+	sets := [][]int{{}}
+	for _, expr := range exprs {
+		var choices [][]int
+
+		switch expr.(type) {
+		case parser.Rollup:
+			hasGroupingSets = true
+			indices := itemIndices(expr.(parser.Rollup).Items)
+			choices = make([][]int, 0, len(indices)+1)
+			for i := len(indices); 0 <= i; i-- {
+				choices = append(choices, indices[:i])
+			}
+		case parser.Cube:
+			hasGroupingSets = true
+			indices := itemIndices(expr.(parser.Cube).Items)
+			n := uint(len(indices))
+			choices = make([][]int, 0, 1<<n)
+			for mask := 1<<n - 1; 0 <= mask; mask-- {
+				choice := make([]int, 0, n)
+				for i := uint(0); i < n; i++ {
+					if mask&(1<<(n-1-i)) != 0 {
+						choice = append(choice, indices[i])
+					}
+				}
+				choices = append(choices, choice)
+			}
+		case parser.GroupingSets:
+			hasGroupingSets = true
+			groupingSets := expr.(parser.GroupingSets).Items
+			choices = make([][]int, 0, len(groupingSets))
+			for _, set := range groupingSets {
+				switch set.(type) {
+				case parser.ValueList:
+					choices = append(choices, itemIndices(set.(parser.ValueList).Values))
+				case parser.Parentheses:
+					choices = append(choices, []int{itemIndex(set.(parser.Parentheses).Expr)})
+				default:
+					choices = append(choices, []int{itemIndex(set)})
+				}
+			}
+		default:
+			choices = [][]int{{itemIndex(expr)}}
+		}
+
+		combined := make([][]int, 0, len(sets)*len(choices))
+		for _, set := range sets {
+			for _, choice := range choices {
+				c := make([]int, 0, len(set)+len(choice))
+				c = append(c, set...)
+				c = append(c, choice...)
+				combined = append(combined, c)
+			}
+		}
+		sets = combined
+	}
+
+	if !hasGroupingSets {
+		return exprs, nil
+	}
+	return items, sets
+}
+
+func (view *View) groupingColumn(expr parser.QueryExpression) string {
+	switch expr.(type) {
+	case parser.FieldReference, parser.ColumnNumber:
+		if idx, err := view.FieldIndex(expr); err == nil {
+			return GroupingColumnPrefix + strconv.Itoa(idx)
+		}
+	}
+	return GroupingColumnPrefix + expr.String()
 }
 
-func (view *View) group(ctx context.Context, items []parser.QueryExpression) error {
+func (view *View) group(ctx context.Context, items []parser.QueryExpression, aggregates []*partialAggregate) error {
 	if items == nil {
 		return view.groupAll()
 	}
+	if 0 < len(aggregates) {
+		return view.groupWithPartialAggregation(ctx, items, aggregates)
+	}
 
 	keys := make([]string, view.RecordLen())
 
@@ -1131,24 +2033,18 @@ func (view *View) group(ctx context.Context, items []parser.QueryExpression) err
 		}
 	}
 
-	records := make(RecordSet, len(groupKeys))
+	indices := make([][]int, len(groupKeys))
 	for i, groupKey := range groupKeys {
-		record := make(Record, view.FieldLen())
-		indices := groups[groupKey]
-
-		for j := 0; j < view.FieldLen(); j++ {
-			primaries := make([]value.Primary, len(indices))
-			for k, idx := range indices {
-				primaries[k] = view.RecordSet[idx][j].Value()
-			}
-			record[j] = NewGroupCell(primaries)
-		}
-
-		records[i] = record
+		indices[i] = groups[groupKey]
 	}
 
-	view.RecordSet = records
+	view.RecordSet = newGroupedRecordSet(view.RecordSet, view.FieldLen(), indices)
 	view.isGrouped = true
+	view.setGroupKeys(items)
+	return nil
+}
+
+func (view *View) setGroupKeys(items []parser.QueryExpression) {
 	for _, item := range items {
 		switch item.(type) {
 		case parser.FieldReference, parser.ColumnNumber:
@@ -1156,22 +2052,142 @@ func (view *View) group(ctx context.Context, items []parser.QueryExpression) err
 			view.Header[idx].IsGroupKey = true
 		}
 	}
+}
+
+func (view *View) groupByGroupingSets(ctx context.Context, items []parser.QueryExpression, groupingSets [][]int) error {
+	itemValues := make([][]value.Primary, view.RecordLen())
+
+	err := NewFilterForSequentialEvaluation(view.Filter, view).EvaluateSequentially(ctx, func(f *Filter, rIdx int) error {
+		values := make([]value.Primary, len(items))
+		for i, item := range items {
+			p, e := f.Evaluate(ctx, item)
+			if e != nil {
+				return e
+			}
+			values[i] = p
+		}
+		itemValues[rIdx] = values
+		return nil
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	keyColumns := make([]int, len(items))
+	for i, item := range items {
+		keyColumns[i] = -1
+		switch item.(type) {
+		case parser.FieldReference, parser.ColumnNumber:
+			idx, err := view.FieldIndex(item)
+			if err != nil {
+				return err
+			}
+			keyColumns[i] = idx
+		}
+	}
+
+	valueColumns := make([]int, 0, len(items))
+	for _, c := range keyColumns {
+		if -1 < c && !InIntSlice(c, valueColumns) {
+			valueColumns = append(valueColumns, c)
+		}
+	}
+
+	fieldLen := view.FieldLen()
+	records := make(RecordSet, 0, view.RecordLen())
+	keyBuf := new(bytes.Buffer)
+
+	for _, set := range groupingSets {
+		groupedColumns := make(map[int]bool, len(set))
+		for _, itemIdx := range set {
+			if -1 < keyColumns[itemIdx] {
+				groupedColumns[keyColumns[itemIdx]] = true
+			}
+		}
+
+		groups := make(map[string][]int)
+		groupKeys := make([]string, 0)
+		keyValues := make([]value.Primary, len(set))
+		for rIdx, values := range itemValues {
+			for i, itemIdx := range set {
+				keyValues[i] = values[itemIdx]
+			}
+			keyBuf.Reset()
+			SerializeComparisonKeys(keyBuf, keyValues, view.Tx.Flags)
+			key := keyBuf.String()
+
+			if _, ok := groups[key]; ok {
+				groups[key] = append(groups[key], rIdx)
+			} else {
+				groups[key] = []int{rIdx}
+				groupKeys = append(groupKeys, key)
+			}
+		}
+
+		for _, groupKey := range groupKeys {
+			record := make(Record, fieldLen+len(items)+len(valueColumns))
+			indices := groups[groupKey]
+
+			for j := 0; j < fieldLen; j++ {
+				primaries := make([]value.Primary, len(indices))
+				for k, idx := range indices {
+					primaries[k] = view.RecordSet[idx][j].Value()
+				}
+				record[j] = NewGroupCell(primaries)
+			}
+
+			for i := range items {
+				var grouping int64 = 1
+				for _, itemIdx := range set {
+					if itemIdx == i {
+						grouping = 0
+						break
+					}
+				}
+
+				if grouping == 1 && -1 < keyColumns[i] && !groupedColumns[keyColumns[i]] {
+					primaries := make([]value.Primary, len(indices))
+					for k := range indices {
+						primaries[k] = value.NewNull()
+					}
+					record[keyColumns[i]] = NewGroupCell(primaries)
+				}
+				record[fieldLen+i] = NewCell(value.NewInteger(grouping))
+			}
+
+			for i, c := range valueColumns {
+				primaries := make([]value.Primary, len(indices))
+				for k, idx := range indices {
+					primaries[k] = view.RecordSet[idx][c].Value()
+				}
+				record[fieldLen+len(items)+i] = NewGroupCell(primaries)
+			}
+
+			records = append(records, record)
+		}
+	}
+
+	for i, item := range items {
+		if -1 < keyColumns[i] {
+			view.Header[keyColumns[i]].IsGroupKey = true
+		}
+		view.Header, _ = AddHeaderField(view.Header, view.groupingColumn(item), "")
+	}
+	for _, c := range valueColumns {
+		view.Header, _ = AddHeaderField(view.Header, GroupingValuesColumnPrefix+strconv.Itoa(c), "")
+	}
+	view.RecordSet = records
+	view.isGrouped = true
 	return nil
 }
 
 func (view *View) groupAll() error {
 	if 0 < view.RecordLen() {
-		records := make(RecordSet, 1)
-		record := make(Record, view.FieldLen())
-		for i := 0; i < view.FieldLen(); i++ {
-			primaries := make([]value.Primary, len(view.RecordSet))
-			for j := range view.RecordSet {
-				primaries[j] = view.RecordSet[j][i].Value()
-			}
-			record[i] = NewGroupCell(primaries)
+		indices := make([]int, view.RecordLen())
+		for i := range indices {
+			indices[i] = i
 		}
-		records[0] = record
-		view.RecordSet = records
+		view.RecordSet = newGroupedRecordSet(view.RecordSet, view.FieldLen(), [][]int{indices})
 	}
 
 	view.isGrouped = true
@@ -1182,7 +2198,7 @@ func (view *View) Having(ctx context.Context, clause parser.HavingClause) error
 	err := view.filter(ctx, clause.Filter)
 	if err != nil {
 		if _, ok := err.(*NotGroupingRecordsError); ok {
-			if err = view.group(ctx, nil); err != nil {
+			if err = view.group(ctx, nil, nil); err != nil {
 				return err
 			}
 			if err = view.filter(ctx, clause.Filter); err != nil {
@@ -1277,7 +2293,7 @@ func (view *View) Select(ctx context.Context, clause parser.SelectClause) error
 				}
 			}
 
-			if err = view.group(ctx, nil); err != nil {
+			if err = view.group(ctx, nil, nil); err != nil {
 				return err
 			}
 			if err = evalFields(view, fields); err != nil {
@@ -1288,7 +2304,21 @@ func (view *View) Select(ctx context.Context, clause parser.SelectClause) error
 		}
 	}
 
-	if clause.IsDistinct() {
+	if clause.DistinctOn != nil {
+		values := clause.DistinctOn.(parser.DistinctOn).Values
+		if err = view.ExtendRecordCapacity(ctx, values); err != nil {
+			return err
+		}
+
+		view.distinctOnFields = make([]int, len(values))
+		for i, v := range values {
+			idx, err := view.evalColumn(ctx, v, "")
+			if err != nil {
+				return err
+			}
+			view.distinctOnFields[i] = idx
+		}
+	} else if clause.IsDistinct() {
 		if err = view.GenerateComparisonKeys(ctx); err != nil {
 			return err
 		}
@@ -1340,6 +2370,50 @@ func (view *View) GenerateComparisonKeys(ctx context.Context) error {
 	})
 }
 
+func (view *View) DistinctOn(ctx context.Context) error {
+	if view.distinctOnFields == nil {
+		return nil
+	}
+
+	keys := make([]string, view.RecordLen())
+	if err := NewGoroutineTaskManager(view.RecordLen(), -1, view.Tx.Flags.CPU).Run(ctx, func(index int) error {
+		buf := new(bytes.Buffer)
+		primaries := make([]value.Primary, len(view.distinctOnFields))
+		for j, idx := range view.distinctOnFields {
+			primaries[j] = view.RecordSet[index][idx].Value()
+		}
+		SerializeComparisonKeys(buf, primaries, view.Tx.Flags)
+		keys[index] = buf.String()
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	records := make(RecordSet, 0, view.RecordLen())
+	var sortValues []SortValues
+	if view.sortValuesInEachRecord != nil {
+		sortValues = make([]SortValues, 0, view.RecordLen())
+	}
+	values := make(map[string]bool)
+	for i, key := range keys {
+		if values[key] {
+			continue
+		}
+		values[key] = true
+
+		records = append(records, view.RecordSet[i])
+		if sortValues != nil {
+			sortValues = append(sortValues, view.sortValuesInEachRecord[i])
+		}
+	}
+
+	view.RecordSet = records
+	view.sortValuesInEachRecord = sortValues
+	view.sortValuesInEachCell = nil
+	view.distinctOnFields = nil
+	return nil
+}
+
 func (view *View) SelectAllColumns(ctx context.Context) error {
 	selectClause := parser.SelectClause{
 		Fields: []parser.QueryExpression{
@@ -1358,6 +2432,9 @@ func (view *View) OrderBy(ctx context.Context, clause parser.OrderByClause) erro
 		return err
 	}
 
+	p := startProgress(view.Tx, "Sorting", formatRecordCount(view.RecordLen()), "")
+	defer p.finish()
+
 	sortIndices := make([]int, len(clause.Items))
 	for i, v := range clause.Items {
 		oi := v.(parser.OrderItem)
@@ -1371,9 +2448,18 @@ func (view *View) OrderBy(ctx context.Context, clause parser.OrderByClause) erro
 	view.sortValuesInEachRecord = make([]SortValues, view.RecordLen())
 	view.sortDirections = make([]int, len(clause.Items))
 	view.sortNullPositions = make([]int, len(clause.Items))
+	collations := make([]*value.Collation, len(clause.Items))
 
 	for i, v := range clause.Items {
 		oi := v.(parser.OrderItem)
+		if !oi.Collation.IsEmpty() {
+			collation, err := value.GetCollation(oi.Collation.Name)
+			if err != nil {
+				return NewInvalidCollationError(oi.Collation)
+			}
+			collations[i] = collation
+		}
+
 		if oi.Direction.IsEmpty() {
 			view.sortDirections[i] = parser.ASC
 		} else {
@@ -1399,7 +2485,9 @@ func (view *View) OrderBy(ctx context.Context, clause parser.OrderByClause) erro
 
 		sortValues := make(SortValues, len(sortIndices))
 		for j, idx := range sortIndices {
-			if view.sortValuesInEachCell != nil && idx < len(view.sortValuesInEachCell[index]) && view.sortValuesInEachCell[index][idx] != nil {
+			if collations[j] != nil {
+				sortValues[j] = NewSortValueWithCollation(view.RecordSet[index][idx].Value(), view.Tx.Flags, collations[j])
+			} else if view.sortValuesInEachCell != nil && idx < len(view.sortValuesInEachCell[index]) && view.sortValuesInEachCell[index][idx] != nil {
 				sortValues[j] = view.sortValuesInEachCell[index][idx]
 			} else {
 				sortValues[j] = NewSortValue(view.RecordSet[index][idx].Value(), view.Tx.Flags)
@@ -1425,6 +2513,9 @@ func (view *View) additionalColumns(expr parser.QueryExpression) ([]string, erro
 	case parser.FieldReference, parser.ColumnNumber:
 		return nil, nil
 	case parser.Function:
+		if _, ok := AggregateFunctions[strings.ToUpper(expr.(parser.Function).Name)]; ok && !view.isGrouped {
+			return nil, NewNotGroupingRecordsError(expr, expr.(parser.Function).Name)
+		}
 		if udfn, err := view.Filter.functions.Get(expr, expr.(parser.Function).Name); err == nil {
 			if udfn.IsAggregate && !view.isGrouped {
 				return nil, NewNotGroupingRecordsError(expr, expr.(parser.Function).Name)
@@ -1564,9 +2655,11 @@ func (view *View) evalColumn(ctx context.Context, obj parser.QueryExpression, al
 func (view *View) evalAnalyticFunction(ctx context.Context, expr parser.AnalyticFunction) error {
 	name := strings.ToUpper(expr.Name)
 	if _, ok := AggregateFunctions[name]; !ok {
-		if _, ok := AnalyticFunctions[name]; !ok {
-			if udfn, err := view.Filter.functions.Get(expr, expr.Name); err != nil || !udfn.IsAggregate {
-				return NewFunctionNotExistError(expr, expr.Name)
+		if _, ok := BivariateAggregateFunctions[name]; !ok {
+			if _, ok := AnalyticFunctions[name]; !ok {
+				if udfn, err := view.Filter.functions.Get(expr, expr.Name); err != nil || !udfn.IsAggregate {
+					return NewFunctionNotExistError(expr, expr.Name)
+				}
 			}
 		}
 	}
@@ -1683,44 +2776,76 @@ func (view *View) Limit(ctx context.Context, clause parser.LimitClause) error {
 }
 
 func (view *View) InsertValues(ctx context.Context, fields []parser.QueryExpression, list []parser.QueryExpression) (int, error) {
+	valuesList, err := view.evalRowValues(ctx, fields, list)
+	if err != nil {
+		return 0, err
+	}
+	return view.insert(fields, valuesList)
+}
+
+func (view *View) InsertFromQuery(ctx context.Context, fields []parser.QueryExpression, query parser.SelectQuery) (int, error) {
+	valuesList, err := view.evalSelectQuery(ctx, fields, query)
+	if err != nil {
+		return 0, err
+	}
+	return view.insert(fields, valuesList)
+}
+
+func (view *View) ReplaceValues(ctx context.Context, fields []parser.QueryExpression, list []parser.QueryExpression, keys []parser.QueryExpression) (int, error) {
+	valuesList, err := view.evalRowValues(ctx, fields, list)
+	if err != nil {
+		return 0, err
+	}
+	return view.replace(fields, valuesList, keys)
+}
+
+func (view *View) ReplaceFromQuery(ctx context.Context, fields []parser.QueryExpression, query parser.SelectQuery, keys []parser.QueryExpression) (int, error) {
+	valuesList, err := view.evalSelectQuery(ctx, fields, query)
+	if err != nil {
+		return 0, err
+	}
+	return view.replace(fields, valuesList, keys)
+}
+
+func (view *View) evalRowValues(ctx context.Context, fields []parser.QueryExpression, list []parser.QueryExpression) ([][]value.Primary, error) {
 	valuesList := make([][]value.Primary, len(list))
 
 	for i, item := range list {
 		rv := item.(parser.RowValue)
 		values, err := view.Filter.evalRowValue(ctx, rv)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
 		if len(fields) != len(values) {
-			return 0, NewInsertRowValueLengthError(rv, len(fields))
+			return nil, NewInsertRowValueLengthError(rv, len(fields))
 		}
 
 		valuesList[i] = values
 	}
 
-	return view.insert(fields, valuesList)
+	return valuesList, nil
 }
 
-func (view *View) InsertFromQuery(ctx context.Context, fields []parser.QueryExpression, query parser.SelectQuery) (int, error) {
-	insertView, err := Select(ctx, view.Filter, query)
+func (view *View) evalSelectQuery(ctx context.Context, fields []parser.QueryExpression, query parser.SelectQuery) ([][]value.Primary, error) {
+	selectedView, err := Select(ctx, view.Filter, query)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	if len(fields) != insertView.FieldLen() {
-		return 0, NewInsertSelectFieldLengthError(query, len(fields))
+	if len(fields) != selectedView.FieldLen() {
+		return nil, NewInsertSelectFieldLengthError(query, len(fields))
 	}
 
-	valuesList := make([][]value.Primary, insertView.RecordLen())
+	valuesList := make([][]value.Primary, selectedView.RecordLen())
 
-	for i, record := range insertView.RecordSet {
-		values := make([]value.Primary, insertView.FieldLen())
+	for i, record := range selectedView.RecordSet {
+		values := make([]value.Primary, selectedView.FieldLen())
 		for j, cell := range record {
 			values[j] = cell.Value()
 		}
 		valuesList[i] = values
 	}
 
-	return view.insert(fields, valuesList)
+	return valuesList, nil
 }
 
 func (view *View) insert(fields []parser.QueryExpression, valuesList [][]value.Primary) (int, error) {
@@ -1758,6 +2883,75 @@ func (view *View) insert(fields []parser.QueryExpression, valuesList [][]value.P
 	return len(valuesList), nil
 }
 
+func (view *View) replace(fields []parser.QueryExpression, valuesList [][]value.Primary, keys []parser.QueryExpression) (int, error) {
+	fieldIndices, err := view.FieldIndices(fields)
+	if err != nil {
+		return 0, err
+	}
+	keyIndices, err := view.FieldIndices(keys)
+	if err != nil {
+		return 0, err
+	}
+
+	keyValueIndices := make([]int, len(keyIndices))
+	for i, keyIdx := range keyIndices {
+		keyValueIndices[i] = -1
+		for j, fieldIdx := range fieldIndices {
+			if keyIdx == fieldIdx {
+				keyValueIndices[i] = j
+				break
+			}
+		}
+		if keyValueIndices[i] < 0 {
+			return 0, NewReplaceKeyNotSetError(keys[i])
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	keyValues := make([]value.Primary, len(keyIndices))
+	serializeKey := func() string {
+		buf.Reset()
+		SerializeComparisonKeys(buf, keyValues, view.Tx.Flags)
+		return buf.String()
+	}
+
+	recordIndices := make(map[string][]int, view.RecordLen())
+	for i, record := range view.RecordSet {
+		for j, idx := range keyIndices {
+			keyValues[j] = record[idx].Value()
+		}
+		key := serializeKey()
+		recordIndices[key] = append(recordIndices[key], i)
+	}
+
+	insertList := make([][]value.Primary, 0, len(valuesList))
+	insertIndices := make(map[string]int)
+	for _, values := range valuesList {
+		for j, idx := range keyValueIndices {
+			keyValues[j] = values[idx]
+		}
+		key := serializeKey()
+
+		if indices, ok := recordIndices[key]; ok {
+			for _, i := range indices {
+				for j, idx := range fieldIndices {
+					view.RecordSet[i][idx] = NewCell(values[j])
+				}
+			}
+		} else if i, ok := insertIndices[key]; ok {
+			insertList[i] = values
+		} else {
+			insertIndices[key] = len(insertList)
+			insertList = append(insertList, values)
+		}
+	}
+
+	if _, err = view.insert(fields, insertList); err != nil {
+		return 0, err
+	}
+	return len(valuesList), nil
+}
+
 func (view *View) Fix(ctx context.Context) error {
 	resize := false
 	if len(view.selectFields) < view.FieldLen() {
@@ -1810,6 +3004,7 @@ func (view *View) Fix(ctx context.Context) error {
 	view.Filter = nil
 	view.selectFields = nil
 	view.selectLabels = nil
+	view.distinctOnFields = nil
 	view.isGrouped = false
 	view.comparisonKeysInEachRecord = nil
 	view.sortValuesInEachCell = nil
@@ -1853,25 +3048,26 @@ func (view *View) Except(ctx context.Context, calcView *View, all bool) (err err
 		return err
 	}
 
-	keys := make(map[string]bool)
+	keys := make(map[string]int)
 	for _, key := range calcView.comparisonKeysInEachRecord {
-		if !keys[key] {
-			keys[key] = true
-		}
+		keys[key]++
 	}
 
 	distinctKeys := make(map[string]bool)
 	records := make(RecordSet, 0, view.RecordLen())
 	for i, key := range view.comparisonKeysInEachRecord {
-		if !keys[key] {
-			if !all {
-				if distinctKeys[key] {
-					continue
-				}
-				distinctKeys[key] = true
+		if all {
+			if 0 < keys[key] {
+				keys[key]--
+				continue
 			}
-			records = append(records, view.RecordSet[i])
+		} else {
+			if 0 < keys[key] || distinctKeys[key] {
+				continue
+			}
+			distinctKeys[key] = true
 		}
+		records = append(records, view.RecordSet[i])
 	}
 	view.RecordSet = records
 	view.FileInfo = nil
@@ -1887,25 +3083,26 @@ func (view *View) Intersect(ctx context.Context, calcView *View, all bool) (err
 		return err
 	}
 
-	keys := make(map[string]bool)
+	keys := make(map[string]int)
 	for _, key := range calcView.comparisonKeysInEachRecord {
-		if !keys[key] {
-			keys[key] = true
-		}
+		keys[key]++
 	}
 
 	distinctKeys := make(map[string]bool)
 	records := make(RecordSet, 0, view.RecordLen())
 	for i, key := range view.comparisonKeysInEachRecord {
-		if _, ok := keys[key]; ok {
-			if !all {
-				if distinctKeys[key] {
-					continue
-				}
-				distinctKeys[key] = true
+		if keys[key] < 1 {
+			continue
+		}
+		if all {
+			keys[key]--
+		} else {
+			if distinctKeys[key] {
+				continue
 			}
-			records = append(records, view.RecordSet[i])
+			distinctKeys[key] = true
 		}
+		records = append(records, view.RecordSet[i])
 	}
 	view.RecordSet = records
 	view.FileInfo = nil
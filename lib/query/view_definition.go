@@ -0,0 +1,125 @@
+package query
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/mithrandie/csvq/lib/cmd"
+	"github.com/mithrandie/csvq/lib/file"
+	"github.com/mithrandie/csvq/lib/parser"
+)
+
+type viewDefinitionContextKey struct{}
+
+func ViewDefinitionFilePath(view parser.Identifier, repository string) (string, error) {
+	fname := view.Literal
+	if !strings.EqualFold(filepath.Ext(fname), cmd.SqlExt) {
+		fname = fname + cmd.SqlExt
+	}
+	return CreateFilePath(parser.Identifier{BaseExpr: view.BaseExpr, Literal: fname}, repository)
+}
+
+func CreateView(ctx context.Context, parentFilter *Filter, query parser.CreateView) (string, error) {
+	if parentFilter.tx.Flags.ReadOnly {
+		return "", NewFileReadOnlyError(query.View)
+	}
+	if err := parentFilter.tx.checkFilePath(query.View); err != nil {
+		return "", err
+	}
+
+	filter := parentFilter.CreateNode()
+
+	view, err := Select(ctx, filter, query.Query.(parser.SelectQuery))
+	if err != nil {
+		return "", err
+	}
+	if err = view.Header.Update(query.View.Literal, query.Fields); err != nil {
+		if _, ok := err.(*FieldLengthNotMatchError); ok {
+			err = NewTableFieldLengthError(query.Query.(parser.SelectQuery), query.View, len(query.Fields))
+		}
+		return "", err
+	}
+
+	fpath, err := ViewDefinitionFilePath(query.View, filter.tx.Flags.Repository)
+	if err != nil {
+		return "", NewWriteFileError(query.View, err.Error())
+	}
+
+	h, err := file.NewHandlerForCreate(filter.tx.FileContainer, fpath)
+	if err != nil {
+		return "", NewFileAlreadyExistError(query.View)
+	}
+
+	if _, err = h.FileForUpdate().WriteString(query.String() + ";" + filter.tx.Flags.LineBreak.Value()); err != nil {
+		err = NewWriteFileError(query.View, err.Error())
+		if e := filter.tx.FileContainer.Close(h); e != nil {
+			err = AppendCompositeError(err, e)
+		}
+		return "", err
+	}
+
+	if err = filter.tx.FileContainer.Commit(h); err != nil {
+		return "", NewCommitError(query.View, err.Error())
+	}
+	return fpath, nil
+}
+
+func LoadViewDefinition(ctx context.Context, tx *Transaction, view parser.Identifier) (parser.CreateView, string, bool) {
+	if ext := filepath.Ext(view.Literal); 0 < len(ext) && !strings.EqualFold(ext, cmd.SqlExt) {
+		return parser.CreateView{}, "", false
+	}
+	if tx.checkFilePath(view) != nil {
+		return parser.CreateView{}, "", false
+	}
+
+	fpath, err := ViewDefinitionFilePath(view, tx.Flags.Repository)
+	if err != nil || !file.Exists(fpath) {
+		return parser.CreateView{}, "", false
+	}
+
+	h, err := file.NewHandlerForRead(ctx, tx.FileContainer, fpath, tx.WaitTimeout, tx.RetryDelay)
+	if err != nil {
+		return parser.CreateView{}, "", false
+	}
+	buf, err := ioutil.ReadAll(h.FileForRead())
+	_ = tx.FileContainer.Close(h)
+	if err != nil {
+		return parser.CreateView{}, "", false
+	}
+
+	statements, _, err := parser.Parse(string(buf), fpath, tx.Flags.DatetimeFormat, false)
+	if err != nil || len(statements) != 1 {
+		return parser.CreateView{}, "", false
+	}
+
+	definition, ok := statements[0].(parser.CreateView)
+	if !ok || !strings.EqualFold(definition.View.Literal, parser.FormatTableName(fpath)) {
+		return parser.CreateView{}, "", false
+	}
+	return definition, fpath, true
+}
+
+func selectViewDefinition(ctx context.Context, parentFilter *Filter, definition parser.CreateView, fpath string, tableName parser.Identifier) (*View, error) {
+	expanding, _ := ctx.Value(viewDefinitionContextKey{}).([]string)
+	if InStrSliceWithCaseInsensitive(fpath, expanding) {
+		return nil, NewRecursiveViewDefinitionError(tableName)
+	}
+	ctx = context.WithValue(ctx, viewDefinitionContextKey{}, append(expanding[:len(expanding):len(expanding)], fpath))
+
+	view, err := Select(ctx, NewFilter(parentFilter.tx), definition.Query.(parser.SelectQuery))
+	if err != nil {
+		return nil, err
+	}
+
+	if err = view.Header.Update(tableName.Literal, definition.Fields); err != nil {
+		if _, ok := err.(*FieldLengthNotMatchError); ok {
+			err = NewTableFieldLengthError(definition.Query.(parser.SelectQuery), tableName, len(definition.Fields))
+		}
+		return nil, err
+	}
+
+	view.FileInfo = nil
+	return view, nil
+}
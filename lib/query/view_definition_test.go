@@ -0,0 +1,151 @@
+package query
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/mithrandie/csvq/lib/parser"
+	"github.com/mithrandie/csvq/lib/value"
+)
+
+var createViewTests = []struct {
+	Name    string
+	Query   parser.CreateView
+	Result  string
+	Content string
+	Error   string
+}{
+	{
+		Name: "Create View",
+		Query: parser.CreateView{
+			View: parser.Identifier{Literal: "view_definition_1"},
+			Fields: []parser.QueryExpression{
+				parser.Identifier{Literal: "id"},
+			},
+			Query: parser.SelectQuery{
+				SelectEntity: parser.SelectEntity{
+					SelectClause: parser.SelectClause{
+						Select: "select",
+						Fields: []parser.QueryExpression{
+							parser.Field{Object: parser.FieldReference{Column: parser.Identifier{Literal: "column1"}}},
+						},
+					},
+					FromClause: parser.FromClause{
+						From: "from",
+						Tables: []parser.QueryExpression{
+							parser.Table{Object: parser.Identifier{Literal: "table1"}},
+						},
+					},
+				},
+			},
+		},
+		Result:  GetTestFilePath("view_definition_1.sql"),
+		Content: "CREATE VIEW view_definition_1 (id) AS select column1 from table1;\n",
+	},
+	{
+		Name: "Create View File Already Exist Error",
+		Query: parser.CreateView{
+			View: parser.Identifier{Literal: "view_definition_1"},
+			Query: parser.SelectQuery{
+				SelectEntity: parser.SelectEntity{
+					SelectClause: parser.SelectClause{
+						Fields: []parser.QueryExpression{
+							parser.Field{Object: parser.NewIntegerValueFromString("1")},
+						},
+					},
+				},
+			},
+		},
+		Error: "file view_definition_1 already exists",
+	},
+	{
+		Name: "Create View Field Length Error",
+		Query: parser.CreateView{
+			View: parser.Identifier{Literal: "view_definition_2"},
+			Fields: []parser.QueryExpression{
+				parser.Identifier{Literal: "id"},
+				parser.Identifier{Literal: "name"},
+			},
+			Query: parser.SelectQuery{
+				SelectEntity: parser.SelectEntity{
+					SelectClause: parser.SelectClause{
+						Fields: []parser.QueryExpression{
+							parser.Field{Object: parser.NewIntegerValueFromString("1")},
+						},
+					},
+				},
+			},
+		},
+		Error: "select query should return exactly 2 fields for table view_definition_2",
+	},
+}
+
+func TestCreateView(t *testing.T) {
+	defer func() {
+		_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+		initFlag(TestTx.Flags)
+	}()
+
+	TestTx.Flags.Repository = TestDir
+
+	for _, v := range createViewTests {
+		result, err := CreateView(context.Background(), NewFilter(TestTx), v.Query)
+		if err != nil {
+			if len(v.Error) < 1 {
+				t.Errorf("%s: unexpected error %q", v.Name, err)
+			} else if err.Error() != v.Error {
+				t.Errorf("%s: error %q, want error %q", v.Name, err.Error(), v.Error)
+			}
+			continue
+		}
+		if 0 < len(v.Error) {
+			t.Errorf("%s: no error, want error %q", v.Name, v.Error)
+			continue
+		}
+		if result != v.Result {
+			t.Errorf("%s: result = %q, want %q", v.Name, result, v.Result)
+		}
+		content, _ := os.ReadFile(result)
+		if string(content) != v.Content {
+			t.Errorf("%s: content = %q, want %q", v.Name, string(content), v.Content)
+		}
+	}
+}
+
+func TestView_LoadViewDefinition(t *testing.T) {
+	defer func() {
+		_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+		initFlag(TestTx.Flags)
+	}()
+
+	TestTx.Flags.Repository = TestDir
+
+	_ = os.WriteFile(GetTestFilePath("view_definition_3.sql"), []byte("CREATE VIEW view_definition_3 (id) AS SELECT column1 FROM table1 WHERE column1 > 1;\n"), 0644)
+	_ = os.WriteFile(GetTestFilePath("view_definition_4.sql"), []byte("CREATE VIEW view_definition_4 AS SELECT * FROM view_definition_4;\n"), 0644)
+
+	view, err := loadView(context.Background(), NewFilter(TestTx).CreateNode(), parser.Table{Object: parser.Identifier{Literal: "view_definition_3"}}, false, false)
+	if err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	expectHeader := NewHeader("view_definition_3", []string{"id"})
+	if !reflect.DeepEqual(view.Header, expectHeader) {
+		t.Errorf("header = %v, want %v", view.Header, expectHeader)
+	}
+	expectRecordSet := RecordSet{
+		NewRecord([]value.Primary{value.NewString("2")}),
+		NewRecord([]value.Primary{value.NewString("3")}),
+	}
+	if !reflect.DeepEqual(view.RecordSet, expectRecordSet) {
+		t.Errorf("records = %s, want %s", view.RecordSet, expectRecordSet)
+	}
+
+	expectErr := "view view_definition_4 is defined recursively"
+	_, err = loadView(context.Background(), NewFilter(TestTx).CreateNode(), parser.Table{Object: parser.Identifier{Literal: "view_definition_4"}}, false, false)
+	if err == nil {
+		t.Errorf("no error, want error %q", expectErr)
+	} else if err.(*RecursiveViewDefinitionError).ErrorMessage() != expectErr {
+		t.Errorf("error = %q, want error %q", err.(*RecursiveViewDefinitionError).ErrorMessage(), expectErr)
+	}
+}
@@ -0,0 +1,739 @@
+package query
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/mithrandie/csvq/lib/cmd"
+	"github.com/mithrandie/csvq/lib/parser"
+	"github.com/mithrandie/csvq/lib/value"
+)
+
+type IndexMap map[string]*ViewIndex
+
+func (m IndexMap) Declare(ctx context.Context, filter *Filter, expr parser.IndexDeclaration) error {
+	uname := strings.ToUpper(expr.Index.Literal)
+	if _, ok := m[uname]; ok {
+		return NewIndexRedeclaredError(expr.Index)
+	}
+
+	view := NewView(filter.tx)
+	if err := view.LoadFromTableIdentifier(ctx, filter.CreateNode(), expr.Table); err != nil {
+		return err
+	}
+	if view.FileInfo == nil || view.FileInfo.IsTemporary {
+		return NewIndexTableNotSupportedError(expr.Table)
+	}
+
+	columns := make([]string, 0, len(expr.Columns))
+	for _, v := range expr.Columns {
+		ident := v.(parser.Identifier)
+		idx, err := view.FieldIndex(parser.FieldReference{BaseExpr: ident.BaseExpr, Column: ident})
+		if err != nil {
+			return err
+		}
+		columns = append(columns, view.Header[idx].Column)
+	}
+
+	m[uname] = NewViewIndex(expr.Index.Literal, view.FileInfo.Path, columns)
+	return nil
+}
+
+func (m IndexMap) Dispose(name parser.Identifier) error {
+	uname := strings.ToUpper(name.Literal)
+	if _, ok := m[uname]; ok {
+		delete(m, uname)
+		return nil
+	}
+	return NewUndeclaredIndexError(name)
+}
+
+// Exists reports whether any index is declared on the file.
+func (m IndexMap) Exists(path string) bool {
+	for _, idx := range m {
+		if strings.EqualFold(idx.Path, path) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m IndexMap) SortedKeys() []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (m IndexMap) Build(view *View, flags *cmd.Flags) []*ViewIndex {
+	if len(m) < 1 || view.FileInfo == nil {
+		return nil
+	}
+
+	var list []*ViewIndex
+	for _, key := range m.SortedKeys() {
+		if strings.EqualFold(m[key].Path, view.FileInfo.Path) {
+			m[key].build(view, flags)
+			list = append(list, m[key])
+		}
+	}
+	return list
+}
+
+type indexValueType int
+
+const (
+	indexNull indexValueType = iota
+	indexInteger
+	indexString
+	indexUnavailable
+)
+
+func getIndexValueType(p value.Primary, flags *cmd.Flags) indexValueType {
+	if value.IsNull(p) {
+		return indexNull
+	}
+	if !value.IsNull(value.ToInteger(p)) {
+		return indexInteger
+	}
+	if _, ok := p.(value.String); ok &&
+		value.IsNull(value.ToFloat(p)) &&
+		value.IsNull(value.ToDatetime(p, flags.DatetimeFormat)) &&
+		value.IsNull(value.ToBoolean(p)) {
+		return indexString
+	}
+	return indexUnavailable
+}
+
+func indexValueTypes(records RecordSet, fieldIndices []int, flags *cmd.Flags) []indexValueType {
+	types := make([]indexValueType, len(fieldIndices))
+	for _, record := range records {
+		for i, fieldIdx := range fieldIndices {
+			t := getIndexValueType(record[fieldIdx].Value(), flags)
+			if t == indexNull || types[i] == t {
+				continue
+			}
+			if types[i] == indexNull {
+				types[i] = t
+			} else {
+				types[i] = indexUnavailable
+			}
+		}
+	}
+	return types
+}
+
+type ViewIndex struct {
+	Name    string
+	Path    string
+	Columns []string
+
+	mutex          *sync.Mutex
+	view           *View
+	datetimeFormat []string
+
+	types     []indexValueType
+	hashTable map[string][]int
+	sorted    []int
+	intKeys   []int64
+	strKeys   []string
+}
+
+func NewViewIndex(name string, path string, columns []string) *ViewIndex {
+	return &ViewIndex{
+		Name:    name,
+		Path:    path,
+		Columns: columns,
+		mutex:   &sync.Mutex{},
+	}
+}
+
+func (idx *ViewIndex) build(view *View, flags *cmd.Flags) {
+	idx.mutex.Lock()
+	defer idx.mutex.Unlock()
+
+	if idx.view == view && equalStrings(idx.datetimeFormat, flags.DatetimeFormat) {
+		return
+	}
+
+	idx.view = view
+	idx.datetimeFormat = make([]string, len(flags.DatetimeFormat))
+	copy(idx.datetimeFormat, flags.DatetimeFormat)
+	idx.types = nil
+	idx.hashTable = nil
+	idx.sorted = nil
+	idx.intKeys = nil
+	idx.strKeys = nil
+
+	fieldIndices := make([]int, 0, len(idx.Columns))
+	for _, column := range idx.Columns {
+		i, err := view.Header.Contains(parser.FieldReference{Column: parser.Identifier{Literal: column}})
+		if err != nil {
+			return
+		}
+		fieldIndices = append(fieldIndices, i)
+	}
+
+	types := indexValueTypes(view.RecordSet, fieldIndices, flags)
+	idx.types = types
+	idx.buildHashTable(view.RecordSet, fieldIndices, flags)
+
+	switch types[0] {
+	case indexInteger, indexString:
+		idx.sorted = make([]int, 0, view.RecordLen())
+		for i, record := range view.RecordSet {
+			if !value.IsNull(record[fieldIndices[0]].Value()) {
+				idx.sorted = append(idx.sorted, i)
+			}
+		}
+
+		if types[0] == indexInteger {
+			keys := make([]int64, view.RecordLen())
+			for _, i := range idx.sorted {
+				keys[i] = value.ToInteger(view.RecordSet[i][fieldIndices[0]].Value()).(value.Integer).Raw()
+			}
+			sort.SliceStable(idx.sorted, func(i, j int) bool { return keys[idx.sorted[i]] < keys[idx.sorted[j]] })
+			idx.intKeys = make([]int64, len(idx.sorted))
+			for i, recordIdx := range idx.sorted {
+				idx.intKeys[i] = keys[recordIdx]
+			}
+		} else {
+			keys := make([]string, view.RecordLen())
+			for _, i := range idx.sorted {
+				keys[i] = indexStringKey(view.RecordSet[i][fieldIndices[0]].Value())
+			}
+			sort.SliceStable(idx.sorted, func(i, j int) bool { return keys[idx.sorted[i]] < keys[idx.sorted[j]] })
+			idx.strKeys = make([]string, len(idx.sorted))
+			for i, recordIdx := range idx.sorted {
+				idx.strKeys[i] = keys[recordIdx]
+			}
+		}
+	}
+}
+
+func (idx *ViewIndex) buildHashTable(records RecordSet, fieldIndices []int, flags *cmd.Flags) {
+	buf := new(bytes.Buffer)
+	values := make([]value.Primary, len(fieldIndices))
+	idx.hashTable = make(map[string][]int, len(records))
+	for i, record := range records {
+		for j, fieldIdx := range fieldIndices {
+			values[j] = record[fieldIdx].Value()
+		}
+		if key, ok := idx.serializeKey(buf, values, flags); ok {
+			idx.hashTable[key] = append(idx.hashTable[key], i)
+		}
+	}
+}
+
+func (idx *ViewIndex) reset() {
+	idx.mutex.Lock()
+	idx.view = nil
+	idx.mutex.Unlock()
+}
+
+func (idx *ViewIndex) serializeKey(buf *bytes.Buffer, values []value.Primary, flags *cmd.Flags) (string, bool) {
+	for _, v := range values {
+		if value.IsNull(v) {
+			return "", false
+		}
+	}
+	buf.Reset()
+	SerializeComparisonKeys(buf, values, flags)
+	return buf.String(), true
+}
+
+func (idx *ViewIndex) isAvailable(i int, p value.Primary, flags *cmd.Flags) bool {
+	if idx.types == nil {
+		return false
+	}
+	t := getIndexValueType(p, flags)
+	return t == indexNull || (t == idx.types[i] && (t == indexInteger || t == indexString))
+}
+
+func (idx *ViewIndex) Lookup(values []value.Primary, flags *cmd.Flags) ([]int, bool) {
+	for i, v := range values {
+		if !idx.isAvailable(i, v, flags) {
+			return nil, false
+		}
+	}
+
+	key, ok := idx.serializeKey(new(bytes.Buffer), values, flags)
+	if !ok {
+		return []int{}, true
+	}
+	return idx.hashTable[key], true
+}
+
+func (idx *ViewIndex) LookupRange(operator string, p value.Primary, flags *cmd.Flags) ([]int, bool) {
+	if idx.sorted == nil || !idx.isAvailable(0, p, flags) {
+		return nil, false
+	}
+	if value.IsNull(p) {
+		return []int{}, true
+	}
+
+	var compare func(i int) int
+	if idx.types[0] == indexInteger {
+		key := value.ToInteger(p).(value.Integer).Raw()
+		compare = func(i int) int {
+			switch {
+			case idx.intKeys[i] < key:
+				return -1
+			case idx.intKeys[i] > key:
+				return 1
+			}
+			return 0
+		}
+	} else {
+		key := indexStringKey(p)
+		compare = func(i int) int {
+			return strings.Compare(idx.strKeys[i], key)
+		}
+	}
+
+	lower := sort.Search(len(idx.sorted), func(i int) bool { return 0 <= compare(i) })
+	upper := sort.Search(len(idx.sorted), func(i int) bool { return 0 < compare(i) })
+
+	var indices []int
+	switch operator {
+	case "=":
+		indices = idx.sorted[lower:upper]
+	case "<":
+		indices = idx.sorted[:lower]
+	case "<=":
+		indices = idx.sorted[:upper]
+	case ">":
+		indices = idx.sorted[upper:]
+	case ">=":
+		indices = idx.sorted[lower:]
+	default:
+		return nil, false
+	}
+
+	ret := make([]int, len(indices))
+	copy(ret, indices)
+	sort.Ints(ret)
+	return ret, true
+}
+
+func indexStringKey(p value.Primary) string {
+	return strings.ToUpper(strings.TrimSpace(p.(value.String).Raw()))
+}
+
+func equalStrings(s1 []string, s2 []string) bool {
+	if len(s1) != len(s2) {
+		return false
+	}
+	for i := range s1 {
+		if s1[i] != s2[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func splitConjunction(expr parser.QueryExpression) []parser.QueryExpression {
+	switch e := expr.(type) {
+	case parser.Parentheses:
+		return splitConjunction(e.Expr)
+	case parser.Logic:
+		if e.Operator.Token == parser.AND {
+			return append(splitConjunction(e.LHS), splitConjunction(e.RHS)...)
+		}
+	}
+	return []parser.QueryExpression{expr}
+}
+
+var reversedComparisonOperators = map[string]string{
+	"=":  "=",
+	"<":  ">",
+	"<=": ">=",
+	">":  "<",
+	">=": "<=",
+}
+
+type indexCondition struct {
+	Expr       parser.QueryExpression
+	FieldIndex int
+	Operator   string
+	Value      value.Primary
+
+	// Values compared by an IN operator
+	Values []value.Primary
+}
+
+func (view *View) indexConditions(condition parser.QueryExpression) []indexCondition {
+	var conditions []indexCondition
+	for _, expr := range splitConjunction(condition) {
+		switch e := expr.(type) {
+		case parser.Comparison:
+			operator, ok := reversedComparisonOperators[e.Operator]
+			if !ok {
+				continue
+			}
+
+			fieldRef, rhs := e.LHS, e.RHS
+			if _, ok := fieldRef.(parser.FieldReference); ok {
+				operator = e.Operator
+			} else {
+				fieldRef, rhs = rhs, fieldRef
+			}
+			pt, ok := rhs.(parser.PrimitiveType)
+			if !ok {
+				continue
+			}
+			fieldIdx, ok := view.indexConditionField(fieldRef)
+			if !ok {
+				continue
+			}
+
+			conditions = append(conditions, indexCondition{
+				Expr:       e,
+				FieldIndex: fieldIdx,
+				Operator:   operator,
+				Value:      pt.Value,
+			})
+		case parser.Between:
+			if !e.Negation.IsEmpty() {
+				continue
+			}
+			low, lok := e.Low.(parser.PrimitiveType)
+			high, hok := e.High.(parser.PrimitiveType)
+			if !lok || !hok {
+				continue
+			}
+			fieldIdx, ok := view.indexConditionField(e.LHS)
+			if !ok {
+				continue
+			}
+
+			conditions = append(conditions,
+				indexCondition{Expr: e, FieldIndex: fieldIdx, Operator: ">=", Value: low.Value},
+				indexCondition{Expr: e, FieldIndex: fieldIdx, Operator: "<=", Value: high.Value},
+			)
+		case parser.In:
+			if e.IsNegated() {
+				continue
+			}
+			values, ok := primitiveValueList(e.Values)
+			if !ok {
+				continue
+			}
+			fieldIdx, ok := view.indexConditionField(e.LHS)
+			if !ok {
+				continue
+			}
+
+			conditions = append(conditions, indexCondition{
+				Expr:       e,
+				FieldIndex: fieldIdx,
+				Operator:   "IN",
+				Values:     values,
+			})
+		}
+	}
+	return conditions
+}
+
+func (view *View) indexConditionField(expr parser.QueryExpression) (int, bool) {
+	if _, ok := expr.(parser.FieldReference); !ok {
+		return -1, false
+	}
+	fieldIdx, err := view.FieldIndex(expr)
+	if err != nil {
+		return -1, false
+	}
+	return fieldIdx, true
+}
+
+// primitiveValueList returns the values of the list if all the items of the list are primitive values.
+func primitiveValueList(expr parser.QueryExpression) ([]value.Primary, bool) {
+	if rowValue, ok := expr.(parser.RowValue); ok {
+		expr = rowValue.Value
+	}
+	list, ok := expr.(parser.ValueList)
+	if !ok {
+		return nil, false
+	}
+
+	values := make([]value.Primary, 0, len(list.Values))
+	for _, v := range list.Values {
+		pt, ok := v.(parser.PrimitiveType)
+		if !ok {
+			return nil, false
+		}
+		values = append(values, pt.Value)
+	}
+	return values, true
+}
+
+func (view *View) indexFieldIndices(idx *ViewIndex) ([]int, bool) {
+	indices := make([]int, 0, len(idx.Columns))
+	for _, column := range idx.Columns {
+		i, err := view.FieldIndex(parser.FieldReference{Column: parser.Identifier{Literal: column}})
+		if err != nil {
+			return nil, false
+		}
+		indices = append(indices, i)
+	}
+	return indices, true
+}
+
+// indexSearch is a search of records using an index with the conjuncts of a condition.
+type indexSearch struct {
+	Index      *ViewIndex
+	Conditions []parser.QueryExpression
+}
+
+func (s indexSearch) String() string {
+	list := make([]string, 0, len(s.Conditions))
+	for _, expr := range s.Conditions {
+		// Both bounds of a BETWEEN operator have the same expression.
+		if str := expr.String(); len(list) < 1 || list[len(list)-1] != str {
+			list = append(list, str)
+		}
+	}
+	return strings.Join(list, " AND ")
+}
+
+func (s indexSearch) uses(expr parser.QueryExpression) bool {
+	str := expr.String()
+	for _, c := range s.Conditions {
+		if c.String() == str {
+			return true
+		}
+	}
+	return false
+}
+
+// searchIndexedRecords returns the indices of the records that can satisfy the condition using an index of the view.
+// An index whose columns are all compared by equality is looked up by the values, otherwise the records are
+// searched by the conditions on the first column of the index, that is, comparisons, BETWEEN and IN with
+// lists of values.
+func (view *View) searchIndexedRecords(condition parser.QueryExpression, flags *cmd.Flags) ([]int, indexSearch, bool) {
+	if view.indexes == nil {
+		return nil, indexSearch{}, false
+	}
+
+	conditions := view.indexConditions(condition)
+	if len(conditions) < 1 {
+		return nil, indexSearch{}, false
+	}
+
+	for _, idx := range view.indexes {
+		fieldIndices, ok := view.indexFieldIndices(idx)
+		if !ok {
+			continue
+		}
+
+		values := make([]value.Primary, len(fieldIndices))
+		exprs := make([]parser.QueryExpression, 0, len(fieldIndices))
+		for i, fieldIdx := range fieldIndices {
+			for _, c := range conditions {
+				if c.FieldIndex == fieldIdx && c.Operator == "=" {
+					values[i] = c.Value
+					exprs = append(exprs, c.Expr)
+					break
+				}
+			}
+			if values[i] == nil {
+				values = nil
+				break
+			}
+		}
+		if values != nil {
+			if indices, ok := idx.Lookup(values, flags); ok {
+				return indices, indexSearch{Index: idx, Conditions: exprs}, true
+			}
+		}
+
+		if indices, exprs, ok := idx.searchRange(fieldIndices[0], conditions, flags); ok {
+			return indices, indexSearch{Index: idx, Conditions: exprs}, true
+		}
+	}
+	return nil, indexSearch{}, false
+}
+
+// searchRange returns the indices of the records satisfying all the conditions on the field of the first column
+// of the index that can be searched using the index.
+func (idx *ViewIndex) searchRange(fieldIdx int, conditions []indexCondition, flags *cmd.Flags) ([]int, []parser.QueryExpression, bool) {
+	var indices []int
+	var exprs []parser.QueryExpression
+	for _, c := range conditions {
+		if c.FieldIndex != fieldIdx {
+			continue
+		}
+
+		var found []int
+		var ok bool
+		if c.Operator == "IN" {
+			found, ok = idx.lookupValues(c.Values, flags)
+		} else {
+			found, ok = idx.LookupRange(c.Operator, c.Value, flags)
+		}
+		if !ok {
+			continue
+		}
+
+		if exprs == nil {
+			indices = found
+		} else {
+			indices = intersectSortedInts(indices, found)
+		}
+		exprs = append(exprs, c.Expr)
+	}
+	return indices, exprs, exprs != nil
+}
+
+// lookupValues returns the indices of the records whose first column is equal to any of the values in ascending order.
+func (idx *ViewIndex) lookupValues(values []value.Primary, flags *cmd.Flags) ([]int, bool) {
+	indices := make([]int, 0, len(values))
+	for _, v := range values {
+		found, ok := idx.LookupRange("=", v, flags)
+		if !ok {
+			return nil, false
+		}
+		indices = append(indices, found...)
+	}
+	sort.Ints(indices)
+
+	unique := indices[:0]
+	for i, v := range indices {
+		if i == 0 || indices[i-1] != v {
+			unique = append(unique, v)
+		}
+	}
+	return unique, true
+}
+
+func intersectSortedInts(s1 []int, s2 []int) []int {
+	ret := make([]int, 0, len(s1))
+	for i, j := 0, 0; i < len(s1) && j < len(s2); {
+		switch {
+		case s1[i] < s2[j]:
+			i++
+		case s2[j] < s1[i]:
+			j++
+		default:
+			ret = append(ret, s1[i])
+			i++
+			j++
+		}
+	}
+	return ret
+}
+
+// joinIndex returns the index of the joinView used to look up the records joined by the equality comparisons
+// in the condition, and the field indices of the view whose values are looked up.
+// If the view is nil, then the fields not in the joinView are assumed to be in the view, and the field indices
+// of the view are not returned.
+func joinIndex(view *View, joinView *View, condition parser.QueryExpression) (*ViewIndex, []int) {
+	if joinView.indexes == nil || condition == nil {
+		return nil, nil
+	}
+
+	fieldMap := joinEqualityFieldMap(view, joinView, condition)
+	if len(fieldMap) < 1 {
+		return nil, nil
+	}
+
+	for _, idx := range joinView.indexes {
+		fieldIndices, ok := joinView.indexFieldIndices(idx)
+		if !ok {
+			continue
+		}
+
+		viewFieldIndices := make([]int, 0, len(fieldIndices))
+		for _, fieldIdx := range fieldIndices {
+			if viewIdx, ok := fieldMap[fieldIdx]; ok {
+				viewFieldIndices = append(viewFieldIndices, viewIdx)
+			}
+		}
+		if len(viewFieldIndices) != len(fieldIndices) {
+			continue
+		}
+
+		return idx, viewFieldIndices
+	}
+	return nil, nil
+}
+
+func joinIndexLookup(view *View, joinView *View, condition parser.QueryExpression, flags *cmd.Flags) func(Record) ([]int, bool) {
+	idx, viewFieldIndices := joinIndex(view, joinView, condition)
+	if idx == nil {
+		return nil
+	}
+	return joinRecordLookup(idx, viewFieldIndices, flags)
+}
+
+// joinEqualityFieldMap returns a map from the field indices of the joinView to the field indices of the view
+// that are compared by equality in the condition.
+// If the view is nil, then the fields not in the joinView are mapped to -1.
+func joinEqualityFieldMap(view *View, joinView *View, condition parser.QueryExpression) map[int]int {
+	fieldMap := make(map[int]int)
+	for _, expr := range splitConjunction(condition) {
+		comparison, ok := expr.(parser.Comparison)
+		if !ok || comparison.Operator != "=" {
+			continue
+		}
+		if _, ok := comparison.LHS.(parser.FieldReference); !ok {
+			continue
+		}
+		if _, ok := comparison.RHS.(parser.FieldReference); !ok {
+			continue
+		}
+
+		for _, refs := range [][2]parser.QueryExpression{{comparison.LHS, comparison.RHS}, {comparison.RHS, comparison.LHS}} {
+			viewIdx := -1
+			if view != nil {
+				idx, err := view.FieldIndex(refs[0])
+				if err != nil {
+					continue
+				}
+				if _, err = view.FieldIndex(refs[1]); err == nil {
+					continue
+				}
+				viewIdx = idx
+			}
+			if _, err := joinView.FieldIndex(refs[0]); err == nil {
+				continue
+			}
+			joinViewIdx, err := joinView.FieldIndex(refs[1])
+			if err != nil {
+				continue
+			}
+			fieldMap[joinViewIdx] = viewIdx
+			break
+		}
+	}
+	return fieldMap
+}
+
+func joinRecordLookup(index *ViewIndex, fieldIndices []int, flags *cmd.Flags) func(Record) ([]int, bool) {
+	return func(record Record) ([]int, bool) {
+		values := make([]value.Primary, len(fieldIndices))
+		for i, fieldIdx := range fieldIndices {
+			values[i] = record[fieldIdx].Value()
+		}
+		return index.Lookup(values, flags)
+	}
+}
+
+func joinRecordIndices(lookup func(Record) ([]int, bool), record Record) ([]int, bool) {
+	if lookup == nil {
+		return nil, false
+	}
+	return lookup(record)
+}
+
+func joinRecordLen(joinView *View, indices []int, useIndex bool) int {
+	if useIndex {
+		return len(indices)
+	}
+	return joinView.RecordLen()
+}
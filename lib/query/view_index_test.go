@@ -0,0 +1,317 @@
+package query
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/mithrandie/csvq/lib/parser"
+	"github.com/mithrandie/csvq/lib/value"
+)
+
+var indexMapDeclareTests = []struct {
+	Name   string
+	Expr   parser.IndexDeclaration
+	Result IndexMap
+	Error  string
+}{
+	{
+		Name: "IndexMap Declare",
+		Expr: parser.IndexDeclaration{
+			Index:   parser.Identifier{Literal: "idx"},
+			Table:   parser.Identifier{Literal: "table1"},
+			Columns: []parser.QueryExpression{parser.Identifier{Literal: "column1"}},
+		},
+		Result: IndexMap{
+			"IDX": NewViewIndex("idx", GetTestFilePath("table1.csv"), []string{"column1"}),
+		},
+	},
+	{
+		Name: "IndexMap Declare Redeclared Error",
+		Expr: parser.IndexDeclaration{
+			Index:   parser.Identifier{Literal: "idx"},
+			Table:   parser.Identifier{Literal: "table1"},
+			Columns: []parser.QueryExpression{parser.Identifier{Literal: "column1"}},
+		},
+		Error: "index idx is redeclared",
+	},
+	{
+		Name: "IndexMap Declare Field Not Exist Error",
+		Expr: parser.IndexDeclaration{
+			Index:   parser.Identifier{Literal: "idx2"},
+			Table:   parser.Identifier{Literal: "table1"},
+			Columns: []parser.QueryExpression{parser.Identifier{Literal: "notexist"}},
+		},
+		Error: "field notexist does not exist",
+	},
+}
+
+func TestIndexMap_Declare(t *testing.T) {
+	defer func() {
+		_ = TestTx.cachedViews.Clean(TestTx.FileContainer)
+		initFlag(TestTx.Flags)
+	}()
+
+	TestTx.Flags.Repository = TestDir
+
+	m := IndexMap{}
+	for _, v := range indexMapDeclareTests {
+		err := m.Declare(context.Background(), NewFilter(TestTx), v.Expr)
+		if err != nil {
+			if len(v.Error) < 1 {
+				t.Errorf("%s: unexpected error %q", v.Name, err)
+			} else if err.Error() != v.Error {
+				t.Errorf("%s: error %q, want error %q", v.Name, err.Error(), v.Error)
+			}
+			continue
+		}
+		if 0 < len(v.Error) {
+			t.Errorf("%s: no error, want error %q", v.Name, v.Error)
+			continue
+		}
+		if !reflect.DeepEqual(m, v.Result) {
+			t.Errorf("%s: result = %v, want %v", v.Name, m, v.Result)
+		}
+	}
+}
+
+func TestIndexMap_Dispose(t *testing.T) {
+	m := IndexMap{
+		"IDX": NewViewIndex("idx", "/path/to/table1.csv", []string{"column1"}),
+	}
+
+	if err := m.Dispose(parser.Identifier{Literal: "idx"}); err != nil {
+		t.Errorf("unexpected error %q", err)
+	} else if len(m) != 0 {
+		t.Errorf("result = %v, want empty map", m)
+	}
+
+	expectErr := "index idx is undeclared"
+	err := m.Dispose(parser.Identifier{Literal: "idx"})
+	if err == nil {
+		t.Errorf("no error, want error %q", expectErr)
+	} else if err.Error() != expectErr {
+		t.Errorf("error = %q, want error %q", err.Error(), expectErr)
+	}
+}
+
+func testIndexedView() *View {
+	return &View{
+		Header: NewHeader("table1", []string{"column1", "column2"}),
+		RecordSet: []Record{
+			NewRecord([]value.Primary{value.NewString("3"), value.NewString("str3")}),
+			NewRecord([]value.Primary{value.NewString("1"), value.NewString("str1")}),
+			NewRecord([]value.Primary{value.NewNull(), value.NewString("str4")}),
+			NewRecord([]value.Primary{value.NewString("2"), value.NewString("str2")}),
+			NewRecord([]value.Primary{value.NewString("1"), value.NewString("str5")}),
+		},
+		FileInfo: &FileInfo{
+			Path: "/path/to/table1.csv",
+		},
+	}
+}
+
+var viewIndexLookupTests = []struct {
+	Name      string
+	Columns   []string
+	Values    []value.Primary
+	Result    []int
+	Available bool
+}{
+	{
+		Name:      "ViewIndex Lookup",
+		Columns:   []string{"column1"},
+		Values:    []value.Primary{value.NewInteger(1)},
+		Result:    []int{1, 4},
+		Available: true,
+	},
+	{
+		Name:      "ViewIndex Lookup Multiple Columns",
+		Columns:   []string{"column1", "column2"},
+		Values:    []value.Primary{value.NewString("1"), value.NewString(" STR5 ")},
+		Result:    []int{4},
+		Available: true,
+	},
+	{
+		Name:      "ViewIndex Lookup Null",
+		Columns:   []string{"column1"},
+		Values:    []value.Primary{value.NewNull()},
+		Result:    []int{},
+		Available: true,
+	},
+	{
+		Name:      "ViewIndex Lookup Unavailable Type",
+		Columns:   []string{"column1"},
+		Values:    []value.Primary{value.NewFloat(1.5)},
+		Available: false,
+	},
+}
+
+func TestViewIndex_Lookup(t *testing.T) {
+	for _, v := range viewIndexLookupTests {
+		view := testIndexedView()
+		idx := NewViewIndex("idx", view.FileInfo.Path, v.Columns)
+		idx.build(view, TestTx.Flags)
+
+		result, ok := idx.Lookup(v.Values, TestTx.Flags)
+		if ok != v.Available {
+			t.Errorf("%s: available = %t, want %t", v.Name, ok, v.Available)
+			continue
+		}
+		if ok && !reflect.DeepEqual(result, v.Result) {
+			t.Errorf("%s: result = %v, want %v", v.Name, result, v.Result)
+		}
+	}
+}
+
+var viewIndexLookupRangeTests = []struct {
+	Operator string
+	Value    value.Primary
+	Result   []int
+}{
+	{
+		Operator: "=",
+		Value:    value.NewInteger(1),
+		Result:   []int{1, 4},
+	},
+	{
+		Operator: "<",
+		Value:    value.NewInteger(2),
+		Result:   []int{1, 4},
+	},
+	{
+		Operator: "<=",
+		Value:    value.NewInteger(2),
+		Result:   []int{1, 3, 4},
+	},
+	{
+		Operator: ">",
+		Value:    value.NewString("2"),
+		Result:   []int{0},
+	},
+	{
+		Operator: ">=",
+		Value:    value.NewInteger(2),
+		Result:   []int{0, 3},
+	},
+}
+
+func TestViewIndex_LookupRange(t *testing.T) {
+	view := testIndexedView()
+	idx := NewViewIndex("idx", view.FileInfo.Path, []string{"column1"})
+	idx.build(view, TestTx.Flags)
+
+	for _, v := range viewIndexLookupRangeTests {
+		result, ok := idx.LookupRange(v.Operator, v.Value, TestTx.Flags)
+		if !ok {
+			t.Errorf("%s %s: index is not available", v.Operator, v.Value)
+			continue
+		}
+		if !reflect.DeepEqual(result, v.Result) {
+			t.Errorf("%s %s: result = %v, want %v", v.Operator, v.Value, result, v.Result)
+		}
+	}
+}
+
+var viewSearchIndexedRecordsTests = []struct {
+	Condition string
+	Result    []int
+	Search    string
+	Available bool
+}{
+	{
+		Condition: "column1 BETWEEN 1 AND 2 AND column2 = 'str1'",
+		Result:    []int{1, 3, 4},
+		Search:    "column1 BETWEEN 1 AND 2",
+		Available: true,
+	},
+	{
+		Condition: "column1 IN (3, 1, 3)",
+		Result:    []int{0, 1, 4},
+		Search:    "column1 IN (3, 1, 3)",
+		Available: true,
+	},
+	{
+		Condition: "2 <= column1 AND column1 IN (1, 2)",
+		Result:    []int{3},
+		Search:    "2 <= column1 AND column1 IN (1, 2)",
+		Available: true,
+	},
+	{
+		Condition: "column1 NOT IN (1, 2)",
+		Available: false,
+	},
+	{
+		Condition: "column2 = 'str1'",
+		Available: false,
+	},
+}
+
+func TestView_SearchIndexedRecords(t *testing.T) {
+	view := testIndexedView()
+	idx := NewViewIndex("idx", view.FileInfo.Path, []string{"column1"})
+	idx.build(view, TestTx.Flags)
+	view.indexes = []*ViewIndex{idx}
+
+	for _, v := range viewSearchIndexedRecordsTests {
+		statements, _, err := parser.Parse("SELECT 1 WHERE "+v.Condition, "", nil, false)
+		if err != nil {
+			t.Fatalf("%s: unexpected parse error %q", v.Condition, err)
+		}
+		condition := statements[0].(parser.SelectQuery).SelectEntity.(parser.SelectEntity).WhereClause.(parser.WhereClause).Filter
+
+		result, search, ok := view.searchIndexedRecords(condition, TestTx.Flags)
+		if ok != v.Available {
+			t.Errorf("%s: available = %t, want %t", v.Condition, ok, v.Available)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if !reflect.DeepEqual(result, v.Result) {
+			t.Errorf("%s: result = %v, want %v", v.Condition, result, v.Result)
+		}
+		if search.Index != idx || search.String() != v.Search {
+			t.Errorf("%s: search = %s with %s, want %s with %s", v.Condition, search.Index.Name, search, idx.Name, v.Search)
+		}
+	}
+}
+
+func TestView_WhereWithIndex(t *testing.T) {
+	view := testIndexedView()
+	idx := NewViewIndex("idx", view.FileInfo.Path, []string{"column1"})
+	idx.build(view, TestTx.Flags)
+	view.indexes = []*ViewIndex{idx}
+	view.Filter = NewFilter(TestTx)
+
+	where := parser.WhereClause{
+		Filter: parser.Logic{
+			LHS: parser.Comparison{
+				LHS:      parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+				RHS:      parser.NewIntegerValueFromString("1"),
+				Operator: "=",
+			},
+			RHS: parser.Comparison{
+				LHS:      parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+				RHS:      parser.NewStringValue("str5"),
+				Operator: "=",
+			},
+			Operator: parser.Token{Token: parser.AND, Literal: "and"},
+		},
+	}
+
+	expect := RecordSet{
+		NewRecord([]value.Primary{value.NewString("1"), value.NewString("str5")}),
+	}
+
+	if err := view.Where(context.Background(), where); err != nil {
+		t.Errorf("unexpected error %q", err)
+	} else {
+		if !reflect.DeepEqual(view.RecordSet, expect) {
+			t.Errorf("result = %s, want %s", view.RecordSet, expect)
+		}
+		if view.indexes != nil {
+			t.Errorf("indexes = %v, want nil", view.indexes)
+		}
+	}
+}
@@ -3,8 +3,11 @@ package query
 import (
 	"context"
 	"fmt"
+	"os"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/mithrandie/csvq/lib/cmd"
 
@@ -27,8 +30,8 @@ func (list TemporaryViewScopes) Exists(name string) bool {
 
 func (list TemporaryViewScopes) Get(name parser.Identifier) (*View, error) {
 	for _, m := range list {
-		if view, err := m.Get(name); err == nil {
-			return view, nil
+		if m.Exists(name.Literal) {
+			return m.Get(name)
 		}
 	}
 	return nil, NewTableNotLoadedError(name)
@@ -36,13 +39,26 @@ func (list TemporaryViewScopes) Get(name parser.Identifier) (*View, error) {
 
 func (list TemporaryViewScopes) GetWithInternalId(ctx context.Context, name parser.Identifier, flags *cmd.Flags) (*View, error) {
 	for _, m := range list {
-		if view, err := m.GetWithInternalId(ctx, name, flags); err == nil {
-			return view, nil
+		if m.Exists(name.Literal) {
+			return m.GetWithInternalId(ctx, name, flags)
 		}
 	}
 	return nil, NewTableNotLoadedError(name)
 }
 
+func (list TemporaryViewScopes) PrimaryKeyIndexes(name string, flags *cmd.Flags) []*ViewIndex {
+	for _, m := range list {
+		if view, ok := m[strings.ToUpper(name)]; ok {
+			if view.FileInfo.PrimaryKey == nil || view.spilled != nil {
+				return nil
+			}
+			view.FileInfo.PrimaryKey.build(view, flags)
+			return []*ViewIndex{view.FileInfo.PrimaryKey}
+		}
+	}
+	return nil
+}
+
 func (list TemporaryViewScopes) Set(view *View) {
 	list[0].Set(view)
 }
@@ -71,6 +87,7 @@ func (list TemporaryViewScopes) Store(uncomittedViews map[string]*FileInfo) []st
 			if _, ok := uncomittedViews[viewKey]; ok {
 				view.FileInfo.InitialRecordSet = view.RecordSet.Copy()
 				view.FileInfo.InitialHeader = view.Header.Copy()
+				view.closeSpilledRecordSet()
 				msglist = append(msglist, fmt.Sprintf("Commit: restore point of view %q is created.", view.FileInfo.Path))
 			}
 		}
@@ -78,18 +95,30 @@ func (list TemporaryViewScopes) Store(uncomittedViews map[string]*FileInfo) []st
 	return msglist
 }
 
-func (list TemporaryViewScopes) Restore(uncomittedViews map[string]*FileInfo) []string {
+func (list TemporaryViewScopes) Restore(uncomittedViews map[string]*FileInfo) ([]string, error) {
 	msglist := make([]string, 0, len(uncomittedViews))
 	for _, m := range list {
 		for viewKey, view := range m {
 			if _, ok := uncomittedViews[viewKey]; ok {
+				if view.FileInfo.spilledRecordSet != nil {
+					records, err := view.FileInfo.spilledRecordSet.Load(context.Background())
+					if err != nil {
+						return msglist, err
+					}
+					view.FileInfo.InitialRecordSet = records
+					view.closeSpilledRecordSet()
+				}
+
 				view.RecordSet = view.FileInfo.InitialRecordSet.Copy()
 				view.Header = view.FileInfo.InitialHeader.Copy()
+				if view.FileInfo.PrimaryKey != nil {
+					view.FileInfo.PrimaryKey.reset()
+				}
 				msglist = append(msglist, fmt.Sprintf("Rollback: view %q is restored.", view.FileInfo.Path))
 			}
 		}
 	}
-	return msglist
+	return msglist, nil
 }
 
 func (list TemporaryViewScopes) All() ViewMap {
@@ -121,7 +150,7 @@ func (m ViewMap) Exists(fpath string) bool {
 func (m ViewMap) Get(fpath parser.Identifier) (*View, error) {
 	ufpath := strings.ToUpper(fpath.Literal)
 	if view, ok := m[ufpath]; ok {
-		return view.Copy(), nil
+		return copyView(view)
 	}
 	return nil, NewTableNotLoadedError(fpath)
 }
@@ -129,7 +158,10 @@ func (m ViewMap) Get(fpath parser.Identifier) (*View, error) {
 func (m ViewMap) GetWithInternalId(ctx context.Context, fpath parser.Identifier, flags *cmd.Flags) (*View, error) {
 	ufpath := strings.ToUpper(fpath.Literal)
 	if view, ok := m[ufpath]; ok {
-		ret := view.Copy()
+		ret, err := copyView(view)
+		if err != nil {
+			return nil, err
+		}
 
 		ret.Header = MergeHeader(NewHeaderWithId(ret.Header[0].View, []string{}), ret.Header)
 
@@ -178,6 +210,7 @@ func (m ViewMap) DisposeTemporaryTable(table parser.Identifier) error {
 	uname := strings.ToUpper(table.Literal)
 	if v, ok := m[uname]; ok {
 		if v.FileInfo.IsTemporary {
+			v.closeSpilledRecordSet()
 			delete(m, uname)
 			return nil
 		} else {
@@ -222,3 +255,100 @@ func (m ViewMap) CleanWithErrors(container *file.Container) error {
 	}
 	return nil
 }
+
+type sharedView struct {
+	View    *View
+	Options string
+	ModTime time.Time
+	Size    int64
+}
+
+// SharedViewMap is a thread-safe cache of views loaded from files in read-only mode.
+// It can be shared by multiple transactions, and each transaction receives a copy of a view
+// so that it keeps reading the same snapshot until the transaction ends.
+type SharedViewMap struct {
+	m     map[string]*sharedView
+	mutex *sync.RWMutex
+}
+
+func NewSharedViewMap() *SharedViewMap {
+	return &SharedViewMap{
+		m:     make(map[string]*sharedView, 10),
+		mutex: &sync.RWMutex{},
+	}
+}
+
+func sharedViewOptions(fileInfo *FileInfo, withoutNull bool) string {
+	return fmt.Sprintf(
+		"%s:%q:%v:%t:%q:%s:%s:%t:%t:%d:%t",
+		fileInfo.Format,
+		fileInfo.Delimiter,
+		fileInfo.DelimiterPositions,
+		fileInfo.SingleLine,
+		fileInfo.JsonQuery,
+		fileInfo.Encoding,
+		fileInfo.LineBreak,
+		fileInfo.NoHeader,
+		fileInfo.EncloseAll,
+		fileInfo.JsonEscape,
+		withoutNull,
+	)
+}
+
+func sharedViewCopy(view *View) *View {
+	ret := view.Copy()
+	fileInfo := *view.FileInfo
+	fileInfo.Handler = nil
+	ret.FileInfo = &fileInfo
+	ret.ForUpdate = false
+	return ret
+}
+
+func (m *SharedViewMap) Get(fpath string, options string) (*View, bool) {
+	stat, err := os.Stat(fpath)
+	if err != nil {
+		return nil, false
+	}
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	sv, ok := m.m[strings.ToUpper(fpath)]
+	if !ok || sv.Options != options || !sv.ModTime.Equal(stat.ModTime()) || sv.Size != stat.Size() {
+		return nil, false
+	}
+	return sharedViewCopy(sv.View), true
+}
+
+func (m *SharedViewMap) Set(view *View, options string, stat os.FileInfo) {
+	if view.FileInfo == nil || view.FileInfo.IsTemporary {
+		return
+	}
+
+	m.mutex.Lock()
+	m.m[strings.ToUpper(view.FileInfo.Path)] = &sharedView{
+		View:    sharedViewCopy(view),
+		Options: options,
+		ModTime: stat.ModTime(),
+		Size:    stat.Size(),
+	}
+	m.mutex.Unlock()
+}
+
+func (m *SharedViewMap) Dispose(fpath string) {
+	m.mutex.Lock()
+	delete(m.m, strings.ToUpper(fpath))
+	m.mutex.Unlock()
+}
+
+func (m *SharedViewMap) Clean() {
+	m.mutex.Lock()
+	m.m = make(map[string]*sharedView, 10)
+	m.mutex.Unlock()
+}
+
+func (m *SharedViewMap) Len() int {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return len(m.m)
+}
@@ -2,8 +2,11 @@ package query
 
 import (
 	"context"
+	"os"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/mithrandie/csvq/lib/parser"
 	"github.com/mithrandie/csvq/lib/value"
@@ -787,7 +790,10 @@ func TestTemporaryViewScopes_Restore(t *testing.T) {
 		"/PATH/TO/TABLE2.CSV": nil,
 	}
 
-	log := list.Restore(UncommittedViews)
+	log, err := list.Restore(UncommittedViews)
+	if err != nil {
+		t.Fatalf("Restore: unexpected error %q", err)
+	}
 
 	if !reflect.DeepEqual(list, expect) {
 		t.Errorf("Restore: view = %v, want %v", list, expect)
@@ -1349,6 +1355,101 @@ func TestViewMap_Clear(t *testing.T) {
 	}
 }
 
+func TestSharedViewMap(t *testing.T) {
+	fpath := GetTestFilePath("shared_view_map.csv")
+	_ = os.WriteFile(fpath, []byte("column1\n1\n"), 0644)
+	defer func() {
+		_ = os.Remove(fpath)
+	}()
+
+	view := &View{
+		Header: NewHeader("shared_view_map", []string{"column1"}),
+		RecordSet: RecordSet{
+			NewRecord([]value.Primary{value.NewString("1")}),
+		},
+		FileInfo:  &FileInfo{Path: fpath, Delimiter: ','},
+		ForUpdate: true,
+	}
+	options := sharedViewOptions(view.FileInfo, false)
+	stat, _ := os.Stat(fpath)
+
+	m := NewSharedViewMap()
+	m.Set(view, options, stat)
+
+	result, ok := m.Get(fpath, options)
+	if !ok {
+		t.Fatalf("view is not cached")
+	}
+	if !reflect.DeepEqual(result.RecordSet, view.RecordSet) {
+		t.Errorf("records = %s, want %s", result.RecordSet, view.RecordSet)
+	}
+	if result.ForUpdate {
+		t.Errorf("cached view is for update")
+	}
+	if result.FileInfo == view.FileInfo {
+		t.Errorf("file info is not copied")
+	}
+
+	result.RecordSet = append(result.RecordSet, NewRecord([]value.Primary{value.NewString("2")}))
+	if result, _ = m.Get(fpath, options); result.RecordLen() != 1 {
+		t.Errorf("record length = %d, want %d", result.RecordLen(), 1)
+	}
+
+	if _, ok = m.Get(fpath, sharedViewOptions(view.FileInfo, true)); ok {
+		t.Errorf("view is returned for different options")
+	}
+
+	_ = os.WriteFile(fpath, []byte("column1\n1\n2\n"), 0644)
+	_ = os.Chtimes(fpath, time.Now(), stat.ModTime().Add(time.Second))
+	if _, ok = m.Get(fpath, options); ok {
+		t.Errorf("view is returned after the file is modified")
+	}
+
+	m.Dispose(fpath)
+	if m.Len() != 0 {
+		t.Errorf("length = %d, want %d", m.Len(), 0)
+	}
+}
+
+func TestSharedViewMap_Transactions(t *testing.T) {
+	defer func() {
+		_ = TestTx.ReleaseResources()
+		TestTx.UseSharedViews(nil)
+		initFlag(TestTx.Flags)
+	}()
+
+	TestTx.Flags.Repository = TestDir
+
+	m := NewSharedViewMap()
+	TestTx.UseSharedViews(m)
+
+	table := parser.Table{Object: parser.Identifier{Literal: "table1"}}
+	view, err := loadView(context.Background(), NewFilter(TestTx).CreateNode(), table, false, false)
+	if err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	if m.Len() != 1 {
+		t.Fatalf("length = %d, want %d", m.Len(), 1)
+	}
+	_ = TestTx.ReleaseResources()
+
+	view2, err := loadView(context.Background(), NewFilter(TestTx).CreateNode(), table, false, false)
+	if err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	if !reflect.DeepEqual(view2.RecordSet, view.RecordSet) {
+		t.Errorf("records = %s, want %s", view2.RecordSet, view.RecordSet)
+	}
+
+	_, err = loadView(context.Background(), NewFilter(TestTx).CreateNode(), table, false, true)
+	if err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	if !TestTx.cachedViews[strings.ToUpper(GetTestFilePath("table1.csv"))].ForUpdate {
+		t.Errorf("view for update is loaded from the shared cache")
+	}
+}
+
 var viewMapGetWithInternalIdBench = generateViewMapGetWithInternalIdBenchViewMap()
 
 func generateViewMapGetWithInternalIdBenchViewMap() ViewMap {
@@ -2394,6 +2394,114 @@ var viewLoadTests = []struct {
 		},
 		Error: "file notexist does not exist",
 	},
+	{
+		Name: "Load Unnest",
+		From: parser.FromClause{
+			Tables: []parser.QueryExpression{
+				parser.Table{
+					Object: parser.Unnest{
+						Unnest: "unnest",
+						Array: parser.ArrayConstructor{
+							Values: []parser.QueryExpression{
+								parser.NewStringValue("a"),
+								parser.NewStringValue("b"),
+							},
+						},
+						With:       "with",
+						Ordinality: "ordinality",
+					},
+					Alias:   parser.Identifier{Literal: "u"},
+					Columns: []parser.QueryExpression{parser.Identifier{Literal: "tag"}, parser.Identifier{Literal: "pos"}},
+				},
+			},
+		},
+		Result: &View{
+			Header: NewHeader("u", []string{"tag", "pos"}),
+			RecordSet: []Record{
+				NewRecord([]value.Primary{
+					value.NewString("a"),
+					value.NewInteger(1),
+				}),
+				NewRecord([]value.Primary{
+					value.NewString("b"),
+					value.NewInteger(2),
+				}),
+			},
+			Filter: &Filter{
+				variables:    []VariableMap{{}},
+				tempViews:    []ViewMap{{}},
+				cursors:      []CursorMap{{}},
+				inlineTables: InlineTableNodes{{}},
+				aliases: AliasNodes{{
+					"U": "",
+				}},
+			},
+			Tx: TestTx,
+		},
+	},
+	{
+		Name: "Load Unnest Not Array Error",
+		From: parser.FromClause{
+			Tables: []parser.QueryExpression{
+				parser.Table{
+					Object: parser.Unnest{
+						Unnest: "unnest",
+						Array:  parser.NewIntegerValueFromString("1"),
+					},
+				},
+			},
+		},
+		Error: "value 1 in unnest(1) is not an array",
+	},
+	{
+		Name: "Load Unnest Expanded For Each Record",
+		From: parser.FromClause{
+			Tables: []parser.QueryExpression{
+				parser.Table{
+					Object: parser.Identifier{Literal: "table1"},
+				},
+				parser.Table{
+					Object: parser.Unnest{
+						Unnest: "unnest",
+						Array: parser.Function{
+							Name: "split",
+							Args: []parser.QueryExpression{
+								parser.FieldReference{View: parser.Identifier{Literal: "table1"}, Column: parser.Identifier{Literal: "column2"}},
+								parser.NewStringValue("r"),
+							},
+						},
+					},
+					Alias: parser.Identifier{Literal: "u"},
+				},
+			},
+		},
+		Result: &View{
+			Header: []HeaderField{
+				{View: "table1", Column: "column1", Number: 1, IsFromTable: true},
+				{View: "table1", Column: "column2", Number: 2, IsFromTable: true},
+				{View: "u", Column: "value", Number: 1, IsFromTable: true},
+			},
+			RecordSet: []Record{
+				NewRecord([]value.Primary{value.NewString("1"), value.NewString("str1"), value.NewString("st")}),
+				NewRecord([]value.Primary{value.NewString("1"), value.NewString("str1"), value.NewString("1")}),
+				NewRecord([]value.Primary{value.NewString("2"), value.NewString("str2"), value.NewString("st")}),
+				NewRecord([]value.Primary{value.NewString("2"), value.NewString("str2"), value.NewString("2")}),
+				NewRecord([]value.Primary{value.NewString("3"), value.NewString("str3"), value.NewString("st")}),
+				NewRecord([]value.Primary{value.NewString("3"), value.NewString("str3"), value.NewString("3")}),
+			},
+			Filter: &Filter{
+				variables:    []VariableMap{{}},
+				tempViews:    []ViewMap{{}},
+				cursors:      []CursorMap{{}},
+				inlineTables: InlineTableNodes{{}},
+				aliases: AliasNodes{{
+					"TABLE1": strings.ToUpper(GetTestFilePath("table1.csv")),
+					"U":      "",
+				}},
+			},
+			Tx: TestTx,
+		},
+	},
 	{
 		Name: "Load Subquery",
 		From: parser.FromClause{
@@ -2451,6 +2559,120 @@ var viewLoadTests = []struct {
 			Tx: TestTx,
 		},
 	},
+	{
+		Name: "Load Values Table With Column Aliases",
+		From: parser.FromClause{
+			Tables: []parser.QueryExpression{
+				parser.Table{
+					Object: parser.Subquery{
+						Query: parser.SelectQuery{
+							SelectEntity: parser.ValuesTable{
+								Values: "values",
+								ValuesList: []parser.QueryExpression{
+									parser.RowValue{
+										Value: parser.ValueList{
+											Values: []parser.QueryExpression{
+												parser.NewIntegerValueFromString("1"),
+												parser.NewStringValue("a"),
+											},
+										},
+									},
+									parser.RowValue{
+										Value: parser.ValueList{
+											Values: []parser.QueryExpression{
+												parser.NewIntegerValueFromString("2"),
+												parser.NewStringValue("b"),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+					Alias: parser.Identifier{Literal: "t"},
+					Columns: []parser.QueryExpression{
+						parser.Identifier{Literal: "id"},
+						parser.Identifier{Literal: "name"},
+					},
+				},
+			},
+		},
+		Result: &View{
+			Header: NewHeader("t", []string{"id", "name"}),
+			RecordSet: []Record{
+				NewRecord([]value.Primary{
+					value.NewInteger(1),
+					value.NewString("a"),
+				}),
+				NewRecord([]value.Primary{
+					value.NewInteger(2),
+					value.NewString("b"),
+				}),
+			},
+			Filter: &Filter{
+				variables:    []VariableMap{{}},
+				tempViews:    []ViewMap{{}},
+				cursors:      []CursorMap{{}},
+				inlineTables: InlineTableNodes{{}},
+				aliases: AliasNodes{
+					{
+						"T": "",
+					},
+				},
+			},
+			Tx: TestTx,
+		},
+	},
+	{
+		Name: "Load Table Column Aliases Length Error",
+		From: parser.FromClause{
+			Tables: []parser.QueryExpression{
+				parser.Table{
+					Object: parser.Identifier{Literal: "table1"},
+					Alias:  parser.Identifier{Literal: "t"},
+					Columns: []parser.QueryExpression{
+						parser.Identifier{Literal: "c1"},
+					},
+				},
+			},
+		},
+		Error: "column aliases for table t should contain exactly 2 fields",
+	},
+	{
+		Name: "Load Values Table Row Value Length Error",
+		From: parser.FromClause{
+			Tables: []parser.QueryExpression{
+				parser.Table{
+					Object: parser.Subquery{
+						Query: parser.SelectQuery{
+							SelectEntity: parser.ValuesTable{
+								Values: "values",
+								ValuesList: []parser.QueryExpression{
+									parser.RowValue{
+										Value: parser.ValueList{
+											Values: []parser.QueryExpression{
+												parser.NewIntegerValueFromString("1"),
+											},
+										},
+									},
+									parser.RowValue{
+										Value: parser.ValueList{
+											Values: []parser.QueryExpression{
+												parser.NewIntegerValueFromString("2"),
+												parser.NewStringValue("b"),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+					Alias: parser.Identifier{Literal: "t"},
+				},
+			},
+		},
+		Error: "row value should contain exactly 1 value",
+	},
 	{
 		Name: "Load Subquery Duplicate Table Name Error",
 		From: parser.FromClause{
@@ -2949,29 +3171,21 @@ var viewGroupByTests = []struct {
 		},
 	},
 	{
-		Name: "Group By With ColumnNumber",
+		Name: "Group By With Rollup",
 		View: &View{
-			Header: NewHeaderWithId("table1", []string{"column1", "column2", "column3"}),
+			Header: NewHeader("table1", []string{"column1", "column2"}),
 			RecordSet: []Record{
-				NewRecordWithId(1, []value.Primary{
-					value.NewString("1"),
-					value.NewString("str1"),
+				NewRecord([]value.Primary{
 					value.NewString("group1"),
+					value.NewInteger(1),
 				}),
-				NewRecordWithId(2, []value.Primary{
-					value.NewString("2"),
-					value.NewString("str2"),
-					value.NewString("group2"),
-				}),
-				NewRecordWithId(3, []value.Primary{
-					value.NewString("3"),
-					value.NewString("str3"),
+				NewRecord([]value.Primary{
 					value.NewString("group1"),
+					value.NewInteger(2),
 				}),
-				NewRecordWithId(4, []value.Primary{
-					value.NewString("4"),
-					value.NewString("str4"),
+				NewRecord([]value.Primary{
 					value.NewString("group2"),
+					value.NewInteger(3),
 				}),
 			},
 			Filter: NewFilter(TestTx),
@@ -2979,20 +3193,21 @@ var viewGroupByTests = []struct {
 		},
 		GroupBy: parser.GroupByClause{
 			Items: []parser.QueryExpression{
-				parser.ColumnNumber{View: parser.Identifier{Literal: "table1"}, Number: value.NewInteger(3)},
+				parser.Rollup{
+					Items: []parser.QueryExpression{
+						parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+					},
+				},
 			},
 		},
 		Result: &View{
 			Header: []HeaderField{
-				{
-					View:   "table1",
-					Column: InternalIdColumn,
-				},
 				{
 					View:        "table1",
 					Column:      "column1",
 					Number:      1,
 					IsFromTable: true,
+					IsGroupKey:  true,
 				},
 				{
 					View:        "table1",
@@ -3001,25 +3216,30 @@ var viewGroupByTests = []struct {
 					IsFromTable: true,
 				},
 				{
-					View:        "table1",
-					Column:      "column3",
-					Number:      3,
-					IsFromTable: true,
-					IsGroupKey:  true,
+					Column: GroupingColumnPrefix + "0",
+				},
+				{
+					Column: GroupingValuesColumnPrefix + "0",
 				},
 			},
 			RecordSet: []Record{
 				{
-					NewGroupCell([]value.Primary{value.NewInteger(1), value.NewInteger(3)}),
-					NewGroupCell([]value.Primary{value.NewString("1"), value.NewString("3")}),
-					NewGroupCell([]value.Primary{value.NewString("str1"), value.NewString("str3")}),
+					NewGroupCell([]value.Primary{value.NewString("group1"), value.NewString("group1")}),
+					NewGroupCell([]value.Primary{value.NewInteger(1), value.NewInteger(2)}),
+					NewCell(value.NewInteger(0)),
 					NewGroupCell([]value.Primary{value.NewString("group1"), value.NewString("group1")}),
 				},
 				{
-					NewGroupCell([]value.Primary{value.NewInteger(2), value.NewInteger(4)}),
-					NewGroupCell([]value.Primary{value.NewString("2"), value.NewString("4")}),
-					NewGroupCell([]value.Primary{value.NewString("str2"), value.NewString("str4")}),
-					NewGroupCell([]value.Primary{value.NewString("group2"), value.NewString("group2")}),
+					NewGroupCell([]value.Primary{value.NewString("group2")}),
+					NewGroupCell([]value.Primary{value.NewInteger(3)}),
+					NewCell(value.NewInteger(0)),
+					NewGroupCell([]value.Primary{value.NewString("group2")}),
+				},
+				{
+					NewGroupCell([]value.Primary{value.NewNull(), value.NewNull(), value.NewNull()}),
+					NewGroupCell([]value.Primary{value.NewInteger(1), value.NewInteger(2), value.NewInteger(3)}),
+					NewCell(value.NewInteger(1)),
+					NewGroupCell([]value.Primary{value.NewString("group1"), value.NewString("group1"), value.NewString("group2")}),
 				},
 			},
 			Filter:    NewFilter(TestTx),
@@ -3028,29 +3248,21 @@ var viewGroupByTests = []struct {
 		},
 	},
 	{
-		Name: "Group By Evaluation Error",
+		Name: "Group By With Grouping Sets",
 		View: &View{
-			Header: NewHeaderWithId("table1", []string{"column1", "column2", "column3"}),
+			Header: NewHeader("table1", []string{"column1", "column2"}),
 			RecordSet: []Record{
-				NewRecordWithId(1, []value.Primary{
-					value.NewString("1"),
-					value.NewString("str1"),
+				NewRecord([]value.Primary{
 					value.NewString("group1"),
+					value.NewInteger(1),
 				}),
-				NewRecordWithId(2, []value.Primary{
-					value.NewString("2"),
-					value.NewString("str2"),
-					value.NewString("group2"),
-				}),
-				NewRecordWithId(3, []value.Primary{
-					value.NewString("3"),
-					value.NewString("str3"),
+				NewRecord([]value.Primary{
 					value.NewString("group1"),
+					value.NewInteger(2),
 				}),
-				NewRecordWithId(4, []value.Primary{
-					value.NewString("4"),
-					value.NewString("str4"),
+				NewRecord([]value.Primary{
 					value.NewString("group2"),
+					value.NewInteger(3),
 				}),
 			},
 			Filter: NewFilter(TestTx),
@@ -3058,48 +3270,242 @@ var viewGroupByTests = []struct {
 		},
 		GroupBy: parser.GroupByClause{
 			Items: []parser.QueryExpression{
-				parser.ColumnNumber{View: parser.Identifier{Literal: "table1"}, Number: value.NewInteger(0)},
-			},
-		},
-		Error: "field table1.0 does not exist",
-	},
-	{
-		Name: "Group By Empty Record",
-		View: &View{
-			Header:    NewHeaderWithId("table1", []string{"column1", "column2", "column3"}),
-			RecordSet: []Record{},
-			Filter:    NewFilter(TestTx),
-			Tx:        TestTx,
-		},
-		GroupBy: parser.GroupByClause{
-			Items: []parser.QueryExpression{
-				parser.FieldReference{Column: parser.Identifier{Literal: "column3"}},
+				parser.GroupingSets{
+					Items: []parser.QueryExpression{
+						parser.Parentheses{Expr: parser.FieldReference{Column: parser.Identifier{Literal: "column1"}}},
+						parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+					},
+				},
 			},
 		},
 		Result: &View{
 			Header: []HeaderField{
-				{
-					View:   "table1",
-					Column: InternalIdColumn,
-				},
 				{
 					View:        "table1",
 					Column:      "column1",
 					Number:      1,
 					IsFromTable: true,
+					IsGroupKey:  true,
 				},
 				{
 					View:        "table1",
 					Column:      "column2",
 					Number:      2,
 					IsFromTable: true,
+					IsGroupKey:  true,
 				},
 				{
-					View:        "table1",
-					Column:      "column3",
-					Number:      3,
-					IsFromTable: true,
-					IsGroupKey:  true,
+					Column: GroupingColumnPrefix + "0",
+				},
+				{
+					Column: GroupingColumnPrefix + "1",
+				},
+				{
+					Column: GroupingValuesColumnPrefix + "0",
+				},
+				{
+					Column: GroupingValuesColumnPrefix + "1",
+				},
+			},
+			RecordSet: []Record{
+				{
+					NewGroupCell([]value.Primary{value.NewString("group1"), value.NewString("group1")}),
+					NewGroupCell([]value.Primary{value.NewNull(), value.NewNull()}),
+					NewCell(value.NewInteger(0)),
+					NewCell(value.NewInteger(1)),
+					NewGroupCell([]value.Primary{value.NewString("group1"), value.NewString("group1")}),
+					NewGroupCell([]value.Primary{value.NewInteger(1), value.NewInteger(2)}),
+				},
+				{
+					NewGroupCell([]value.Primary{value.NewString("group2")}),
+					NewGroupCell([]value.Primary{value.NewNull()}),
+					NewCell(value.NewInteger(0)),
+					NewCell(value.NewInteger(1)),
+					NewGroupCell([]value.Primary{value.NewString("group2")}),
+					NewGroupCell([]value.Primary{value.NewInteger(3)}),
+				},
+				{
+					NewGroupCell([]value.Primary{value.NewNull()}),
+					NewGroupCell([]value.Primary{value.NewInteger(1)}),
+					NewCell(value.NewInteger(1)),
+					NewCell(value.NewInteger(0)),
+					NewGroupCell([]value.Primary{value.NewString("group1")}),
+					NewGroupCell([]value.Primary{value.NewInteger(1)}),
+				},
+				{
+					NewGroupCell([]value.Primary{value.NewNull()}),
+					NewGroupCell([]value.Primary{value.NewInteger(2)}),
+					NewCell(value.NewInteger(1)),
+					NewCell(value.NewInteger(0)),
+					NewGroupCell([]value.Primary{value.NewString("group1")}),
+					NewGroupCell([]value.Primary{value.NewInteger(2)}),
+				},
+				{
+					NewGroupCell([]value.Primary{value.NewNull()}),
+					NewGroupCell([]value.Primary{value.NewInteger(3)}),
+					NewCell(value.NewInteger(1)),
+					NewCell(value.NewInteger(0)),
+					NewGroupCell([]value.Primary{value.NewString("group2")}),
+					NewGroupCell([]value.Primary{value.NewInteger(3)}),
+				},
+			},
+			Filter:    NewFilter(TestTx),
+			isGrouped: true,
+			Tx:        TestTx,
+		},
+	},
+	{
+		Name: "Group By With ColumnNumber",
+		View: &View{
+			Header: NewHeaderWithId("table1", []string{"column1", "column2", "column3"}),
+			RecordSet: []Record{
+				NewRecordWithId(1, []value.Primary{
+					value.NewString("1"),
+					value.NewString("str1"),
+					value.NewString("group1"),
+				}),
+				NewRecordWithId(2, []value.Primary{
+					value.NewString("2"),
+					value.NewString("str2"),
+					value.NewString("group2"),
+				}),
+				NewRecordWithId(3, []value.Primary{
+					value.NewString("3"),
+					value.NewString("str3"),
+					value.NewString("group1"),
+				}),
+				NewRecordWithId(4, []value.Primary{
+					value.NewString("4"),
+					value.NewString("str4"),
+					value.NewString("group2"),
+				}),
+			},
+			Filter: NewFilter(TestTx),
+			Tx:     TestTx,
+		},
+		GroupBy: parser.GroupByClause{
+			Items: []parser.QueryExpression{
+				parser.ColumnNumber{View: parser.Identifier{Literal: "table1"}, Number: value.NewInteger(3)},
+			},
+		},
+		Result: &View{
+			Header: []HeaderField{
+				{
+					View:   "table1",
+					Column: InternalIdColumn,
+				},
+				{
+					View:        "table1",
+					Column:      "column1",
+					Number:      1,
+					IsFromTable: true,
+				},
+				{
+					View:        "table1",
+					Column:      "column2",
+					Number:      2,
+					IsFromTable: true,
+				},
+				{
+					View:        "table1",
+					Column:      "column3",
+					Number:      3,
+					IsFromTable: true,
+					IsGroupKey:  true,
+				},
+			},
+			RecordSet: []Record{
+				{
+					NewGroupCell([]value.Primary{value.NewInteger(1), value.NewInteger(3)}),
+					NewGroupCell([]value.Primary{value.NewString("1"), value.NewString("3")}),
+					NewGroupCell([]value.Primary{value.NewString("str1"), value.NewString("str3")}),
+					NewGroupCell([]value.Primary{value.NewString("group1"), value.NewString("group1")}),
+				},
+				{
+					NewGroupCell([]value.Primary{value.NewInteger(2), value.NewInteger(4)}),
+					NewGroupCell([]value.Primary{value.NewString("2"), value.NewString("4")}),
+					NewGroupCell([]value.Primary{value.NewString("str2"), value.NewString("str4")}),
+					NewGroupCell([]value.Primary{value.NewString("group2"), value.NewString("group2")}),
+				},
+			},
+			Filter:    NewFilter(TestTx),
+			isGrouped: true,
+			Tx:        TestTx,
+		},
+	},
+	{
+		Name: "Group By Evaluation Error",
+		View: &View{
+			Header: NewHeaderWithId("table1", []string{"column1", "column2", "column3"}),
+			RecordSet: []Record{
+				NewRecordWithId(1, []value.Primary{
+					value.NewString("1"),
+					value.NewString("str1"),
+					value.NewString("group1"),
+				}),
+				NewRecordWithId(2, []value.Primary{
+					value.NewString("2"),
+					value.NewString("str2"),
+					value.NewString("group2"),
+				}),
+				NewRecordWithId(3, []value.Primary{
+					value.NewString("3"),
+					value.NewString("str3"),
+					value.NewString("group1"),
+				}),
+				NewRecordWithId(4, []value.Primary{
+					value.NewString("4"),
+					value.NewString("str4"),
+					value.NewString("group2"),
+				}),
+			},
+			Filter: NewFilter(TestTx),
+			Tx:     TestTx,
+		},
+		GroupBy: parser.GroupByClause{
+			Items: []parser.QueryExpression{
+				parser.ColumnNumber{View: parser.Identifier{Literal: "table1"}, Number: value.NewInteger(0)},
+			},
+		},
+		Error: "field table1.0 does not exist",
+	},
+	{
+		Name: "Group By Empty Record",
+		View: &View{
+			Header:    NewHeaderWithId("table1", []string{"column1", "column2", "column3"}),
+			RecordSet: []Record{},
+			Filter:    NewFilter(TestTx),
+			Tx:        TestTx,
+		},
+		GroupBy: parser.GroupByClause{
+			Items: []parser.QueryExpression{
+				parser.FieldReference{Column: parser.Identifier{Literal: "column3"}},
+			},
+		},
+		Result: &View{
+			Header: []HeaderField{
+				{
+					View:   "table1",
+					Column: InternalIdColumn,
+				},
+				{
+					View:        "table1",
+					Column:      "column1",
+					Number:      1,
+					IsFromTable: true,
+				},
+				{
+					View:        "table1",
+					Column:      "column2",
+					Number:      2,
+					IsFromTable: true,
+				},
+				{
+					View:        "table1",
+					Column:      "column3",
+					Number:      3,
+					IsFromTable: true,
+					IsGroupKey:  true,
 				},
 			},
 			RecordSet: []Record{},
@@ -3965,211 +4371,749 @@ var viewSelectTests = []struct {
 				},
 			},
 		},
-		Error: "field notexist does not exist",
+		Error: "field notexist does not exist",
+	},
+	{
+		Name: "Select Analytic Function Order Error",
+		View: &View{
+			Header: NewHeader("table1", []string{"column1", "column2"}),
+			RecordSet: []Record{
+				NewRecord([]value.Primary{
+					value.NewString("a"),
+					value.NewInteger(2),
+				}),
+				NewRecord([]value.Primary{
+					value.NewString("b"),
+					value.NewInteger(3),
+				}),
+				NewRecord([]value.Primary{
+					value.NewString("b"),
+					value.NewInteger(5),
+				}),
+				NewRecord([]value.Primary{
+					value.NewString("a"),
+					value.NewInteger(1),
+				}),
+				NewRecord([]value.Primary{
+					value.NewString("b"),
+					value.NewInteger(4),
+				}),
+			},
+			Filter: NewFilter(TestTx),
+			Tx:     TestTx,
+		},
+		Select: parser.SelectClause{
+			Fields: []parser.QueryExpression{
+				parser.Field{Object: parser.FieldReference{Column: parser.Identifier{Literal: "column1"}}},
+				parser.Field{Object: parser.FieldReference{Column: parser.Identifier{Literal: "column2"}}},
+				parser.Field{
+					Object: parser.AnalyticFunction{
+						Name: "row_number",
+						Over: "over",
+						AnalyticClause: parser.AnalyticClause{
+							PartitionClause: parser.PartitionClause{
+								PartitionBy: "partition by",
+								Values: []parser.QueryExpression{
+									parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+								},
+							},
+							OrderByClause: parser.OrderByClause{
+								OrderBy: "order by",
+								Items: []parser.QueryExpression{
+									parser.OrderItem{
+										Value: parser.FieldReference{Column: parser.Identifier{Literal: "notexist"}},
+									},
+								},
+							},
+						},
+					},
+					Alias: parser.Identifier{Literal: "rownum"},
+				},
+			},
+		},
+		Error: "field notexist does not exist",
+	},
+	{
+		Name: "Select User Defined Analytic Function",
+		View: &View{
+			Header: NewHeader("table1", []string{"column1", "column2"}),
+			RecordSet: []Record{
+				NewRecord([]value.Primary{
+					value.NewString("a"),
+					value.NewInteger(2),
+				}),
+				NewRecord([]value.Primary{
+					value.NewString("b"),
+					value.NewInteger(3),
+				}),
+				NewRecord([]value.Primary{
+					value.NewString("b"),
+					value.NewInteger(5),
+				}),
+				NewRecord([]value.Primary{
+					value.NewString("a"),
+					value.NewInteger(1),
+				}),
+				NewRecord([]value.Primary{
+					value.NewString("b"),
+					value.NewInteger(4),
+				}),
+			},
+			Filter: &Filter{
+				functions: UserDefinedFunctionScopes{
+					UserDefinedFunctionMap{
+						"USERAGGFUNC": &UserDefinedFunction{
+							Name:         parser.Identifier{Literal: "useraggfunc"},
+							IsAggregate:  true,
+							Cursor:       parser.Identifier{Literal: "list"},
+							RequiredArgs: 0,
+							Statements: []parser.Statement{
+								parser.VariableDeclaration{
+									Assignments: []parser.VariableAssignment{
+										{
+											Variable: parser.Variable{Name: "value"},
+										},
+										{
+											Variable: parser.Variable{Name: "fetch"},
+										},
+									},
+								},
+								parser.WhileInCursor{
+									Variables: []parser.Variable{
+										{Name: "fetch"},
+									},
+									Cursor: parser.Identifier{Literal: "list"},
+									Statements: []parser.Statement{
+										parser.If{
+											Condition: parser.Is{
+												LHS: parser.Variable{Name: "value"},
+												RHS: parser.NewNullValue(),
+											},
+											Statements: []parser.Statement{
+												parser.VariableSubstitution{
+													Variable: parser.Variable{Name: "value"},
+													Value:    parser.Variable{Name: "fetch"},
+												},
+												parser.FlowControl{Token: parser.CONTINUE},
+											},
+										},
+										parser.VariableSubstitution{
+											Variable: parser.Variable{Name: "value"},
+											Value: parser.Arithmetic{
+												LHS:      parser.Variable{Name: "value"},
+												RHS:      parser.Variable{Name: "fetch"},
+												Operator: '+',
+											},
+										},
+									},
+								},
+
+								parser.Return{
+									Value: parser.Variable{Name: "value"},
+								},
+							},
+						},
+					},
+				},
+			},
+			Tx: TestTx,
+		},
+		Select: parser.SelectClause{
+			Fields: []parser.QueryExpression{
+				parser.Field{Object: parser.FieldReference{Column: parser.Identifier{Literal: "column1"}}},
+				parser.Field{Object: parser.FieldReference{Column: parser.Identifier{Literal: "column2"}}},
+				parser.Field{
+					Object: parser.AnalyticFunction{
+						Name: "useraggfunc",
+						Args: []parser.QueryExpression{
+							parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+						},
+						Over:           "over",
+						AnalyticClause: parser.AnalyticClause{},
+					},
+				},
+			},
+		},
+		Result: &View{
+			Header: []HeaderField{
+				{View: "table1", Column: "column1", Number: 1, IsFromTable: true},
+				{View: "table1", Column: "column2", Number: 2, IsFromTable: true},
+				{Column: "useraggfunc(column2) over ()"},
+			},
+			RecordSet: []Record{
+				NewRecord([]value.Primary{
+					value.NewString("a"),
+					value.NewInteger(2),
+					value.NewInteger(15),
+				}),
+				NewRecord([]value.Primary{
+					value.NewString("b"),
+					value.NewInteger(3),
+					value.NewInteger(15),
+				}),
+				NewRecord([]value.Primary{
+					value.NewString("b"),
+					value.NewInteger(5),
+					value.NewInteger(15),
+				}),
+				NewRecord([]value.Primary{
+					value.NewString("a"),
+					value.NewInteger(1),
+					value.NewInteger(15),
+				}),
+				NewRecord([]value.Primary{
+					value.NewString("b"),
+					value.NewInteger(4),
+					value.NewInteger(15),
+				}),
+			},
+			selectFields: []int{0, 1, 2},
+			Tx:           TestTx,
+		},
+	},
+}
+
+func TestView_Select(t *testing.T) {
+	for _, v := range viewSelectTests {
+		err := v.View.Select(context.Background(), v.Select)
+		if err != nil {
+			if len(v.Error) < 1 {
+				t.Errorf("%s: unexpected error %q", v.Name, err)
+			} else if err.Error() != v.Error {
+				t.Errorf("%s: error %q, want error %q", v.Name, err.Error(), v.Error)
+			}
+			continue
+		}
+		if 0 < len(v.Error) {
+			t.Errorf("%s: no error, want error %q", v.Name, v.Error)
+			continue
+		}
+		if !reflect.DeepEqual(v.View.Header, v.Result.Header) {
+			t.Errorf("%s: header = %v, want %v", v.Name, v.View.Header, v.Result.Header)
+		}
+		if !reflect.DeepEqual(v.View.RecordSet, v.Result.RecordSet) {
+			t.Errorf("%s: records = %s, want %s", v.Name, v.View.RecordSet, v.Result.RecordSet)
+		}
+		if !reflect.DeepEqual(v.View.selectFields, v.Result.selectFields) {
+			t.Errorf("%s: select indices = %v, want %v", v.Name, v.View.selectFields, v.Result.selectFields)
+		}
+	}
+}
+
+var viewDistinctOnTests = []struct {
+	Name   string
+	View   *View
+	Result RecordSet
+}{
+	{
+		Name: "Distinct On",
+		View: &View{
+			Header: NewHeader("table1", []string{"column1", "column2"}),
+			RecordSet: []Record{
+				NewRecord([]value.Primary{
+					value.NewInteger(1),
+					value.NewString("str1"),
+				}),
+				NewRecord([]value.Primary{
+					value.NewInteger(1),
+					value.NewString("str2"),
+				}),
+				NewRecord([]value.Primary{
+					value.NewInteger(2),
+					value.NewString("str3"),
+				}),
+				NewRecord([]value.Primary{
+					value.NewNull(),
+					value.NewString("str4"),
+				}),
+				NewRecord([]value.Primary{
+					value.NewNull(),
+					value.NewString("str5"),
+				}),
+			},
+			distinctOnFields: []int{0},
+			Tx:               TestTx,
+		},
+		Result: RecordSet{
+			NewRecord([]value.Primary{
+				value.NewInteger(1),
+				value.NewString("str1"),
+			}),
+			NewRecord([]value.Primary{
+				value.NewInteger(2),
+				value.NewString("str3"),
+			}),
+			NewRecord([]value.Primary{
+				value.NewNull(),
+				value.NewString("str4"),
+			}),
+		},
+	},
+	{
+		Name: "Distinct On Not Specified",
+		View: &View{
+			Header: NewHeader("table1", []string{"column1", "column2"}),
+			RecordSet: []Record{
+				NewRecord([]value.Primary{
+					value.NewInteger(1),
+					value.NewString("str1"),
+				}),
+				NewRecord([]value.Primary{
+					value.NewInteger(1),
+					value.NewString("str2"),
+				}),
+			},
+			Tx: TestTx,
+		},
+		Result: RecordSet{
+			NewRecord([]value.Primary{
+				value.NewInteger(1),
+				value.NewString("str1"),
+			}),
+			NewRecord([]value.Primary{
+				value.NewInteger(1),
+				value.NewString("str2"),
+			}),
+		},
+	},
+}
+
+func TestView_DistinctOn(t *testing.T) {
+	for _, v := range viewDistinctOnTests {
+		err := v.View.DistinctOn(context.Background())
+		if err != nil {
+			t.Errorf("%s: unexpected error %q", v.Name, err)
+			continue
+		}
+		if !reflect.DeepEqual(v.View.RecordSet, v.Result) {
+			t.Errorf("%s: records = %s, want %s", v.Name, v.View.RecordSet, v.Result)
+		}
+	}
+}
+
+var viewPivotTests = []struct {
+	Name   string
+	View   *View
+	Pivot  parser.Pivot
+	Result *View
+	Error  string
+}{
+	{
+		Name: "Pivot",
+		View: &View{
+			Header: NewHeader("table1", []string{"column1", "column2", "column3"}),
+			RecordSet: []Record{
+				NewRecord([]value.Primary{value.NewString("a"), value.NewString("p"), value.NewInteger(1)}),
+				NewRecord([]value.Primary{value.NewString("a"), value.NewString("q"), value.NewInteger(2)}),
+				NewRecord([]value.Primary{value.NewString("a"), value.NewString("p"), value.NewInteger(3)}),
+				NewRecord([]value.Primary{value.NewString("b"), value.NewString("p"), value.NewInteger(4)}),
+				NewRecord([]value.Primary{value.NewString("c"), value.NewString("r"), value.NewInteger(5)}),
+			},
+			Tx: TestTx,
+		},
+		Pivot: parser.Pivot{
+			Pivot: "pivot",
+			Aggregate: parser.AggregateFunction{
+				Name: "sum",
+				Args: []parser.QueryExpression{parser.FieldReference{Column: parser.Identifier{Literal: "column3"}}},
+			},
+			For:    "for",
+			Column: parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+			In:     "in",
+			Values: []parser.QueryExpression{
+				parser.Field{Object: parser.NewStringValue("p")},
+				parser.Field{Object: parser.NewStringValue("q"), As: "as", Alias: parser.Identifier{Literal: "alias"}},
+			},
+		},
+		Result: &View{
+			Header: NewHeader("table1", []string{"column1", "p", "alias"}),
+			RecordSet: []Record{
+				NewRecord([]value.Primary{value.NewString("a"), value.NewInteger(4), value.NewInteger(2)}),
+				NewRecord([]value.Primary{value.NewString("b"), value.NewInteger(4), value.NewNull()}),
+				NewRecord([]value.Primary{value.NewString("c"), value.NewNull(), value.NewNull()}),
+			},
+			Tx: TestTx,
+		},
+	},
+	{
+		Name: "Pivot Count All Columns",
+		View: &View{
+			Header: NewHeader("table1", []string{"column1", "column2"}),
+			RecordSet: []Record{
+				NewRecord([]value.Primary{value.NewString("a"), value.NewString("p")}),
+				NewRecord([]value.Primary{value.NewString("a"), value.NewString("p")}),
+				NewRecord([]value.Primary{value.NewString("b"), value.NewString("q")}),
+			},
+			Tx: TestTx,
+		},
+		Pivot: parser.Pivot{
+			Pivot: "pivot",
+			Aggregate: parser.AggregateFunction{
+				Name: "count",
+				Args: []parser.QueryExpression{parser.AllColumns{}},
+			},
+			For:    "for",
+			Column: parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+			In:     "in",
+			Values: []parser.QueryExpression{
+				parser.Field{Object: parser.NewStringValue("p")},
+				parser.Field{Object: parser.NewStringValue("q"), As: "as", Alias: parser.Identifier{Literal: "alias"}},
+			},
+		},
+		Result: &View{
+			Header: NewHeader("table1", []string{"column1", "p", "alias"}),
+			RecordSet: []Record{
+				NewRecord([]value.Primary{value.NewString("a"), value.NewInteger(2), value.NewNull()}),
+				NewRecord([]value.Primary{value.NewString("b"), value.NewNull(), value.NewInteger(1)}),
+			},
+			Tx: TestTx,
+		},
+	},
+	{
+		Name: "Pivot Invalid Aggregation",
+		View: &View{
+			Header: NewHeader("table1", []string{"column1", "column2", "column3"}),
+			RecordSet: []Record{
+				NewRecord([]value.Primary{value.NewString("a"), value.NewString("p"), value.NewInteger(1)}),
+				NewRecord([]value.Primary{value.NewString("a"), value.NewString("q"), value.NewInteger(2)}),
+				NewRecord([]value.Primary{value.NewString("a"), value.NewString("p"), value.NewInteger(3)}),
+				NewRecord([]value.Primary{value.NewString("b"), value.NewString("p"), value.NewInteger(4)}),
+				NewRecord([]value.Primary{value.NewString("c"), value.NewString("r"), value.NewInteger(5)}),
+			},
+			Tx: TestTx,
+		},
+		Pivot: parser.Pivot{
+			Pivot:     "pivot",
+			Aggregate: parser.FieldReference{Column: parser.Identifier{Literal: "column3"}},
+			For:       "for",
+			Column:    parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+			In:        "in",
+			Values: []parser.QueryExpression{
+				parser.Field{Object: parser.NewStringValue("p")},
+				parser.Field{Object: parser.NewStringValue("q"), As: "as", Alias: parser.Identifier{Literal: "alias"}},
+			},
+		},
+		Error: "pivot aggregation column3 must be an aggregate function with a column",
+	},
+	{
+		Name: "Pivot Aggregation Argument Not Exist",
+		View: &View{
+			Header: NewHeader("table1", []string{"column1", "column2", "column3"}),
+			RecordSet: []Record{
+				NewRecord([]value.Primary{value.NewString("a"), value.NewString("p"), value.NewInteger(1)}),
+				NewRecord([]value.Primary{value.NewString("a"), value.NewString("q"), value.NewInteger(2)}),
+				NewRecord([]value.Primary{value.NewString("a"), value.NewString("p"), value.NewInteger(3)}),
+				NewRecord([]value.Primary{value.NewString("b"), value.NewString("p"), value.NewInteger(4)}),
+				NewRecord([]value.Primary{value.NewString("c"), value.NewString("r"), value.NewInteger(5)}),
+			},
+			Tx: TestTx,
+		},
+		Pivot: parser.Pivot{
+			Pivot: "pivot",
+			Aggregate: parser.AggregateFunction{
+				Name: "sum",
+				Args: []parser.QueryExpression{parser.FieldReference{Column: parser.Identifier{Literal: "notexist"}}},
+			},
+			For:    "for",
+			Column: parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+			In:     "in",
+			Values: []parser.QueryExpression{
+				parser.Field{Object: parser.NewStringValue("p")},
+				parser.Field{Object: parser.NewStringValue("q"), As: "as", Alias: parser.Identifier{Literal: "alias"}},
+			},
+		},
+		Error: "field notexist does not exist",
+	},
+}
+
+func TestView_Pivot(t *testing.T) {
+	for _, v := range viewPivotTests {
+		err := v.View.Pivot(context.Background(), NewFilter(TestTx), v.Pivot)
+		if err != nil {
+			if len(v.Error) < 1 {
+				t.Errorf("%s: unexpected error %q", v.Name, err)
+			} else if err.Error() != v.Error {
+				t.Errorf("%s: error %q, want error %q", v.Name, err.Error(), v.Error)
+			}
+			continue
+		}
+		if 0 < len(v.Error) {
+			t.Errorf("%s: no error, want error %q", v.Name, v.Error)
+			continue
+		}
+		if !reflect.DeepEqual(v.View, v.Result) {
+			t.Errorf("%s: result = %v, want %v", v.Name, v.View, v.Result)
+		}
+	}
+}
+
+var viewUnpivotTests = []struct {
+	Name    string
+	View    *View
+	Unpivot parser.Unpivot
+	Result  *View
+	Error   string
+}{
+	{
+		Name: "Unpivot",
+		View: &View{
+			Header: NewHeader("table1", []string{"column1", "column2", "column3"}),
+			RecordSet: []Record{
+				NewRecord([]value.Primary{value.NewString("a"), value.NewInteger(1), value.NewInteger(2)}),
+				NewRecord([]value.Primary{value.NewString("b"), value.NewNull(), value.NewInteger(3)}),
+			},
+			Tx: TestTx,
+		},
+		Unpivot: parser.Unpivot{
+			Unpivot: "unpivot",
+			Value:   parser.Identifier{Literal: "value"},
+			For:     "for",
+			Name:    parser.Identifier{Literal: "name"},
+			In:      "in",
+			Columns: []parser.QueryExpression{
+				parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
+				parser.FieldReference{Column: parser.Identifier{Literal: "column3"}},
+			},
+		},
+		Result: &View{
+			Header: NewHeader("table1", []string{"column1", "name", "value"}),
+			RecordSet: []Record{
+				NewRecord([]value.Primary{value.NewString("a"), value.NewString("column2"), value.NewInteger(1)}),
+				NewRecord([]value.Primary{value.NewString("a"), value.NewString("column3"), value.NewInteger(2)}),
+				NewRecord([]value.Primary{value.NewString("b"), value.NewString("column3"), value.NewInteger(3)}),
+			},
+			Tx: TestTx,
+		},
+	},
+	{
+		Name: "Unpivot Field Not Exist",
+		View: &View{
+			Header: NewHeader("table1", []string{"column1", "column2"}),
+			RecordSet: []Record{
+				NewRecord([]value.Primary{value.NewString("a"), value.NewInteger(1)}),
+			},
+			Tx: TestTx,
+		},
+		Unpivot: parser.Unpivot{
+			Unpivot: "unpivot",
+			Value:   parser.Identifier{Literal: "value"},
+			For:     "for",
+			Name:    parser.Identifier{Literal: "name"},
+			In:      "in",
+			Columns: []parser.QueryExpression{
+				parser.FieldReference{Column: parser.Identifier{Literal: "notexist"}},
+			},
+		},
+		Error: "field notexist does not exist",
+	},
+}
+
+func TestView_Unpivot(t *testing.T) {
+	for _, v := range viewUnpivotTests {
+		err := v.View.Unpivot(v.Unpivot)
+		if err != nil {
+			if len(v.Error) < 1 {
+				t.Errorf("%s: unexpected error %q", v.Name, err)
+			} else if err.Error() != v.Error {
+				t.Errorf("%s: error %q, want error %q", v.Name, err.Error(), v.Error)
+			}
+			continue
+		}
+		if 0 < len(v.Error) {
+			t.Errorf("%s: no error, want error %q", v.Name, v.Error)
+			continue
+		}
+		if !reflect.DeepEqual(v.View, v.Result) {
+			t.Errorf("%s: result = %v, want %v", v.Name, v.View, v.Result)
+		}
+	}
+}
+
+var viewSampleTests = []struct {
+	Name   string
+	View   *View
+	Sample parser.TableSample
+	Result RecordSet
+	Error  string
+}{
+	{
+		Name: "Sample Percentage",
+		View: &View{
+			Header: NewHeader("table1", []string{"column1"}),
+			RecordSet: []Record{
+				NewRecord([]value.Primary{value.NewInteger(1)}),
+				NewRecord([]value.Primary{value.NewInteger(2)}),
+				NewRecord([]value.Primary{value.NewInteger(3)}),
+				NewRecord([]value.Primary{value.NewInteger(4)}),
+			},
+			Tx: TestTx,
+		},
+		Sample: parser.TableSample{
+			TableSample: "tablesample",
+			Value:       parser.NewFloatValueFromString("50"),
+			Unit:        parser.Token{Token: parser.PERCENT, Literal: "percent"},
+			Repeatable:  "repeatable",
+			Seed:        parser.NewIntegerValueFromString("1"),
+		},
+		Result: RecordSet{
+			NewRecord([]value.Primary{value.NewInteger(4)}),
+		},
+	},
+	{
+		Name: "Sample Percentage All Records",
+		View: &View{
+			Header: NewHeader("table1", []string{"column1"}),
+			RecordSet: []Record{
+				NewRecord([]value.Primary{value.NewInteger(1)}),
+				NewRecord([]value.Primary{value.NewInteger(2)}),
+				NewRecord([]value.Primary{value.NewInteger(3)}),
+				NewRecord([]value.Primary{value.NewInteger(4)}),
+			},
+			Tx: TestTx,
+		},
+		Sample: parser.TableSample{
+			TableSample: "tablesample",
+			Value:       parser.NewIntegerValueFromString("100"),
+			Unit:        parser.Token{Token: parser.PERCENT, Literal: "percent"},
+		},
+		Result: RecordSet{
+			NewRecord([]value.Primary{value.NewInteger(1)}),
+			NewRecord([]value.Primary{value.NewInteger(2)}),
+			NewRecord([]value.Primary{value.NewInteger(3)}),
+			NewRecord([]value.Primary{value.NewInteger(4)}),
+		},
+	},
+	{
+		Name: "Sample Percentage No Records",
+		View: &View{
+			Header: NewHeader("table1", []string{"column1"}),
+			RecordSet: []Record{
+				NewRecord([]value.Primary{value.NewInteger(1)}),
+				NewRecord([]value.Primary{value.NewInteger(2)}),
+				NewRecord([]value.Primary{value.NewInteger(3)}),
+				NewRecord([]value.Primary{value.NewInteger(4)}),
+			},
+			Tx: TestTx,
+		},
+		Sample: parser.TableSample{
+			TableSample: "tablesample",
+			Value:       parser.NewIntegerValueFromString("0"),
+			Unit:        parser.Token{Token: parser.PERCENT, Literal: "percent"},
+		},
+		Result: RecordSet{},
+	},
+	{
+		Name: "Sample Number of Records",
+		View: &View{
+			Header: NewHeader("table1", []string{"column1"}),
+			RecordSet: []Record{
+				NewRecord([]value.Primary{value.NewInteger(1)}),
+				NewRecord([]value.Primary{value.NewInteger(2)}),
+				NewRecord([]value.Primary{value.NewInteger(3)}),
+				NewRecord([]value.Primary{value.NewInteger(4)}),
+			},
+			Tx: TestTx,
+		},
+		Sample: parser.TableSample{
+			TableSample: "tablesample",
+			Value:       parser.NewIntegerValueFromString("2"),
+			Unit:        parser.Token{Token: parser.ROWS, Literal: "rows"},
+			Repeatable:  "repeatable",
+			Seed:        parser.NewIntegerValueFromString("1"),
+		},
+		Result: RecordSet{
+			NewRecord([]value.Primary{value.NewInteger(1)}),
+			NewRecord([]value.Primary{value.NewInteger(2)}),
+		},
+	},
+	{
+		Name: "Sample Number of Records Greater Than Records",
+		View: &View{
+			Header: NewHeader("table1", []string{"column1"}),
+			RecordSet: []Record{
+				NewRecord([]value.Primary{value.NewInteger(1)}),
+				NewRecord([]value.Primary{value.NewInteger(2)}),
+				NewRecord([]value.Primary{value.NewInteger(3)}),
+				NewRecord([]value.Primary{value.NewInteger(4)}),
+			},
+			Tx: TestTx,
+		},
+		Sample: parser.TableSample{
+			TableSample: "tablesample",
+			Value:       parser.NewIntegerValueFromString("10"),
+			Unit:        parser.Token{Token: parser.ROWS, Literal: "rows"},
+		},
+		Result: RecordSet{
+			NewRecord([]value.Primary{value.NewInteger(1)}),
+			NewRecord([]value.Primary{value.NewInteger(2)}),
+			NewRecord([]value.Primary{value.NewInteger(3)}),
+			NewRecord([]value.Primary{value.NewInteger(4)}),
+		},
 	},
 	{
-		Name: "Select Analytic Function Order Error",
+		Name: "Sample Percentage Invalid Value",
 		View: &View{
-			Header: NewHeader("table1", []string{"column1", "column2"}),
+			Header: NewHeader("table1", []string{"column1"}),
 			RecordSet: []Record{
-				NewRecord([]value.Primary{
-					value.NewString("a"),
-					value.NewInteger(2),
-				}),
-				NewRecord([]value.Primary{
-					value.NewString("b"),
-					value.NewInteger(3),
-				}),
-				NewRecord([]value.Primary{
-					value.NewString("b"),
-					value.NewInteger(5),
-				}),
-				NewRecord([]value.Primary{
-					value.NewString("a"),
-					value.NewInteger(1),
-				}),
-				NewRecord([]value.Primary{
-					value.NewString("b"),
-					value.NewInteger(4),
-				}),
+				NewRecord([]value.Primary{value.NewInteger(1)}),
+				NewRecord([]value.Primary{value.NewInteger(2)}),
+				NewRecord([]value.Primary{value.NewInteger(3)}),
+				NewRecord([]value.Primary{value.NewInteger(4)}),
 			},
-			Filter: NewFilter(TestTx),
-			Tx:     TestTx,
+			Tx: TestTx,
 		},
-		Select: parser.SelectClause{
-			Fields: []parser.QueryExpression{
-				parser.Field{Object: parser.FieldReference{Column: parser.Identifier{Literal: "column1"}}},
-				parser.Field{Object: parser.FieldReference{Column: parser.Identifier{Literal: "column2"}}},
-				parser.Field{
-					Object: parser.AnalyticFunction{
-						Name: "row_number",
-						Over: "over",
-						AnalyticClause: parser.AnalyticClause{
-							PartitionClause: parser.PartitionClause{
-								PartitionBy: "partition by",
-								Values: []parser.QueryExpression{
-									parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
-								},
-							},
-							OrderByClause: parser.OrderByClause{
-								OrderBy: "order by",
-								Items: []parser.QueryExpression{
-									parser.OrderItem{
-										Value: parser.FieldReference{Column: parser.Identifier{Literal: "notexist"}},
-									},
-								},
-							},
-						},
-					},
-					Alias: parser.Identifier{Literal: "rownum"},
-				},
-			},
+		Sample: parser.TableSample{
+			TableSample: "tablesample",
+			Value:       parser.NewStringValue("a"),
+			Unit:        parser.Token{Token: parser.PERCENT, Literal: "percent"},
 		},
-		Error: "field notexist does not exist",
+		Error: "tablesample percentage 'a' is not a float value",
 	},
 	{
-		Name: "Select User Defined Analytic Function",
+		Name: "Sample Number of Records Invalid Value",
 		View: &View{
-			Header: NewHeader("table1", []string{"column1", "column2"}),
+			Header: NewHeader("table1", []string{"column1"}),
 			RecordSet: []Record{
-				NewRecord([]value.Primary{
-					value.NewString("a"),
-					value.NewInteger(2),
-				}),
-				NewRecord([]value.Primary{
-					value.NewString("b"),
-					value.NewInteger(3),
-				}),
-				NewRecord([]value.Primary{
-					value.NewString("b"),
-					value.NewInteger(5),
-				}),
-				NewRecord([]value.Primary{
-					value.NewString("a"),
-					value.NewInteger(1),
-				}),
-				NewRecord([]value.Primary{
-					value.NewString("b"),
-					value.NewInteger(4),
-				}),
-			},
-			Filter: &Filter{
-				functions: UserDefinedFunctionScopes{
-					UserDefinedFunctionMap{
-						"USERAGGFUNC": &UserDefinedFunction{
-							Name:         parser.Identifier{Literal: "useraggfunc"},
-							IsAggregate:  true,
-							Cursor:       parser.Identifier{Literal: "list"},
-							RequiredArgs: 0,
-							Statements: []parser.Statement{
-								parser.VariableDeclaration{
-									Assignments: []parser.VariableAssignment{
-										{
-											Variable: parser.Variable{Name: "value"},
-										},
-										{
-											Variable: parser.Variable{Name: "fetch"},
-										},
-									},
-								},
-								parser.WhileInCursor{
-									Variables: []parser.Variable{
-										{Name: "fetch"},
-									},
-									Cursor: parser.Identifier{Literal: "list"},
-									Statements: []parser.Statement{
-										parser.If{
-											Condition: parser.Is{
-												LHS: parser.Variable{Name: "value"},
-												RHS: parser.NewNullValue(),
-											},
-											Statements: []parser.Statement{
-												parser.VariableSubstitution{
-													Variable: parser.Variable{Name: "value"},
-													Value:    parser.Variable{Name: "fetch"},
-												},
-												parser.FlowControl{Token: parser.CONTINUE},
-											},
-										},
-										parser.VariableSubstitution{
-											Variable: parser.Variable{Name: "value"},
-											Value: parser.Arithmetic{
-												LHS:      parser.Variable{Name: "value"},
-												RHS:      parser.Variable{Name: "fetch"},
-												Operator: '+',
-											},
-										},
-									},
-								},
-
-								parser.Return{
-									Value: parser.Variable{Name: "value"},
-								},
-							},
-						},
-					},
-				},
+				NewRecord([]value.Primary{value.NewInteger(1)}),
+				NewRecord([]value.Primary{value.NewInteger(2)}),
+				NewRecord([]value.Primary{value.NewInteger(3)}),
+				NewRecord([]value.Primary{value.NewInteger(4)}),
 			},
 			Tx: TestTx,
 		},
-		Select: parser.SelectClause{
-			Fields: []parser.QueryExpression{
-				parser.Field{Object: parser.FieldReference{Column: parser.Identifier{Literal: "column1"}}},
-				parser.Field{Object: parser.FieldReference{Column: parser.Identifier{Literal: "column2"}}},
-				parser.Field{
-					Object: parser.AnalyticFunction{
-						Name: "useraggfunc",
-						Args: []parser.QueryExpression{
-							parser.FieldReference{Column: parser.Identifier{Literal: "column2"}},
-						},
-						Over:           "over",
-						AnalyticClause: parser.AnalyticClause{},
-					},
-				},
-			},
+		Sample: parser.TableSample{
+			TableSample: "tablesample",
+			Value:       parser.NewStringValue("a"),
+			Unit:        parser.Token{Token: parser.ROWS, Literal: "rows"},
 		},
-		Result: &View{
-			Header: []HeaderField{
-				{View: "table1", Column: "column1", Number: 1, IsFromTable: true},
-				{View: "table1", Column: "column2", Number: 2, IsFromTable: true},
-				{Column: "useraggfunc(column2) over ()"},
-			},
+		Error: "tablesample number of records 'a' is not an integer value",
+	},
+	{
+		Name: "Sample Invalid Seed",
+		View: &View{
+			Header: NewHeader("table1", []string{"column1"}),
 			RecordSet: []Record{
-				NewRecord([]value.Primary{
-					value.NewString("a"),
-					value.NewInteger(2),
-					value.NewInteger(15),
-				}),
-				NewRecord([]value.Primary{
-					value.NewString("b"),
-					value.NewInteger(3),
-					value.NewInteger(15),
-				}),
-				NewRecord([]value.Primary{
-					value.NewString("b"),
-					value.NewInteger(5),
-					value.NewInteger(15),
-				}),
-				NewRecord([]value.Primary{
-					value.NewString("a"),
-					value.NewInteger(1),
-					value.NewInteger(15),
-				}),
-				NewRecord([]value.Primary{
-					value.NewString("b"),
-					value.NewInteger(4),
-					value.NewInteger(15),
-				}),
+				NewRecord([]value.Primary{value.NewInteger(1)}),
+				NewRecord([]value.Primary{value.NewInteger(2)}),
+				NewRecord([]value.Primary{value.NewInteger(3)}),
+				NewRecord([]value.Primary{value.NewInteger(4)}),
 			},
-			selectFields: []int{0, 1, 2},
-			Tx:           TestTx,
+			Tx: TestTx,
+		},
+		Sample: parser.TableSample{
+			TableSample: "tablesample",
+			Value:       parser.NewIntegerValueFromString("2"),
+			Unit:        parser.Token{Token: parser.ROWS, Literal: "rows"},
+			Repeatable:  "repeatable",
+			Seed:        parser.NewStringValue("a"),
 		},
+		Error: "tablesample seed 'a' is not an integer value",
 	},
 }
 
-func TestView_Select(t *testing.T) {
-	for _, v := range viewSelectTests {
-		err := v.View.Select(context.Background(), v.Select)
+func TestView_Sample(t *testing.T) {
+	for _, v := range viewSampleTests {
+		err := v.View.Sample(context.Background(), NewFilter(TestTx), v.Sample)
 		if err != nil {
 			if len(v.Error) < 1 {
 				t.Errorf("%s: unexpected error %q", v.Name, err)
@@ -4182,14 +5126,8 @@ func TestView_Select(t *testing.T) {
 			t.Errorf("%s: no error, want error %q", v.Name, v.Error)
 			continue
 		}
-		if !reflect.DeepEqual(v.View.Header, v.Result.Header) {
-			t.Errorf("%s: header = %v, want %v", v.Name, v.View.Header, v.Result.Header)
-		}
-		if !reflect.DeepEqual(v.View.RecordSet, v.Result.RecordSet) {
-			t.Errorf("%s: records = %s, want %s", v.Name, v.View.RecordSet, v.Result.RecordSet)
-		}
-		if !reflect.DeepEqual(v.View.selectFields, v.Result.selectFields) {
-			t.Errorf("%s: select indices = %v, want %v", v.Name, v.View.selectFields, v.Result.selectFields)
+		if !reflect.DeepEqual(v.View.RecordSet, v.Result) {
+			t.Errorf("%s: records = %s, want %s", v.Name, v.View.RecordSet, v.Result)
 		}
 	}
 }
@@ -4201,6 +5139,66 @@ var viewOrderByTests = []struct {
 	Result  *View
 	Error   string
 }{
+	{
+		Name: "Order By With Collation",
+		View: &View{
+			Header: []HeaderField{
+				{View: "table1", Column: InternalIdColumn},
+				{View: "table1", Column: "column1", IsFromTable: true},
+			},
+			RecordSet: []Record{
+				NewRecordWithId(1, []value.Primary{value.NewString("Zebra")}),
+				NewRecordWithId(2, []value.Primary{value.NewString("Öl")}),
+				NewRecordWithId(3, []value.Primary{value.NewString("apfel")}),
+				NewRecordWithId(4, []value.Primary{value.NewString("Orange")}),
+			},
+			Filter: NewFilter(TestTx),
+			Tx:     TestTx,
+		},
+		OrderBy: parser.OrderByClause{
+			Items: []parser.QueryExpression{
+				parser.OrderItem{
+					Value:     parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+					Collation: parser.Collation{Collate: "collate", Name: "de_DE"},
+				},
+			},
+		},
+		Result: &View{
+			Header: []HeaderField{
+				{View: "table1", Column: InternalIdColumn},
+				{View: "table1", Column: "column1", IsFromTable: true},
+			},
+			RecordSet: []Record{
+				NewRecordWithId(3, []value.Primary{value.NewString("apfel")}),
+				NewRecordWithId(2, []value.Primary{value.NewString("Öl")}),
+				NewRecordWithId(4, []value.Primary{value.NewString("Orange")}),
+				NewRecordWithId(1, []value.Primary{value.NewString("Zebra")}),
+			},
+		},
+	},
+	{
+		Name: "Order By Invalid Collation Error",
+		View: &View{
+			Header: []HeaderField{
+				{View: "table1", Column: InternalIdColumn},
+				{View: "table1", Column: "column1", IsFromTable: true},
+			},
+			RecordSet: []Record{
+				NewRecordWithId(1, []value.Primary{value.NewString("Zebra")}),
+			},
+			Filter: NewFilter(TestTx),
+			Tx:     TestTx,
+		},
+		OrderBy: parser.OrderByClause{
+			Items: []parser.QueryExpression{
+				parser.OrderItem{
+					Value:     parser.FieldReference{Column: parser.Identifier{Literal: "column1"}},
+					Collation: parser.Collation{Collate: "collate", Name: "!!"},
+				},
+			},
+		},
+		Error: "\"!!\" is not a valid collation",
+	},
 	{
 		Name: "Order By",
 		View: &View{
@@ -6080,6 +7078,10 @@ func TestView_Except(t *testing.T) {
 				value.NewString("1"),
 				value.NewString("str1"),
 			}),
+			NewRecord([]value.Primary{
+				value.NewString("2"),
+				value.NewString("str2"),
+			}),
 		},
 		Tx: TestTx,
 	}
@@ -6190,10 +7192,6 @@ func TestView_Intersect(t *testing.T) {
 				value.NewString("2"),
 				value.NewString("str2"),
 			}),
-			NewRecord([]value.Primary{
-				value.NewString("2"),
-				value.NewString("str2"),
-			}),
 		},
 		Tx: TestTx,
 	}
@@ -0,0 +1,285 @@
+package query
+
+import (
+	"context"
+	"math"
+
+	"github.com/mithrandie/csvq/lib/cmd"
+	"github.com/mithrandie/csvq/lib/parser"
+	"github.com/mithrandie/csvq/lib/value"
+
+	"github.com/mithrandie/ternary"
+)
+
+// WindowAggregator calculates an aggregate value of a window frame incrementally.
+// Push appends a value entering the frame, and Shift removes the oldest value leaving the frame.
+type WindowAggregator interface {
+	Push(value.Primary)
+	Shift()
+	Result() value.Primary
+}
+
+var WindowAggregators = map[string]func(*cmd.Flags) WindowAggregator{
+	"COUNT": func(_ *cmd.Flags) WindowAggregator { return &countWindowAggregator{} },
+	"SUM":   func(_ *cmd.Flags) WindowAggregator { return &sumWindowAggregator{} },
+	"AVG":   func(_ *cmd.Flags) WindowAggregator { return &sumWindowAggregator{avg: true} },
+	"MAX":   func(flags *cmd.Flags) WindowAggregator { return newExtremumWindowAggregator(flags, true) },
+	"MIN":   func(flags *cmd.Flags) WindowAggregator { return newExtremumWindowAggregator(flags, false) },
+}
+
+// windowAggregatorFunction returns the function creating the aggregator if the aggregate values of
+// the frames can be calculated incrementally.
+// Aggregate functions not in WindowAggregators and user defined aggregate functions are calculated
+// from all the values in each frame.
+func windowAggregatorFunction(name string, expr parser.AnalyticFunction, frameSet []WindowFrame) (func(*cmd.Flags) WindowAggregator, bool) {
+	newAggregator, ok := WindowAggregators[name]
+	if !ok || expr.IsDistinct() || len(frameSet) < 2 || hasWindowFrameExclusion(frameSet) {
+		return nil, false
+	}
+	return newAggregator, true
+}
+
+type countWindowAggregator struct {
+	nulls []bool
+	head  int
+	count int64
+}
+
+func (agg *countWindowAggregator) Push(p value.Primary) {
+	isNull := value.IsNull(p)
+	agg.nulls = append(agg.nulls, isNull)
+	if !isNull {
+		agg.count++
+	}
+}
+
+func (agg *countWindowAggregator) Shift() {
+	if !agg.nulls[agg.head] {
+		agg.count--
+	}
+	agg.head++
+}
+
+func (agg *countWindowAggregator) Result() value.Primary {
+	return value.NewInteger(agg.count)
+}
+
+// maxExactInteger is the upper limit of integers that float64 can represent without rounding.
+const maxExactInteger = 1 << 53
+
+// sumWindowAggregator keeps the sum of integral values as an integer so that values can be
+// removed without rounding errors. If the frame contains other values, then the sum is
+// recalculated from the values in the frame in the same order as Sum and Avg.
+type sumWindowAggregator struct {
+	avg bool
+
+	queue []value.Primary
+	head  int
+	count int
+
+	intSum     int64
+	inexact    int
+	overflowed bool
+}
+
+func isExactInteger(f float64) bool {
+	return f == math.Trunc(f) && math.Abs(f) < maxExactInteger
+}
+
+func (agg *sumWindowAggregator) Push(p value.Primary) {
+	f := value.ToFloat(p)
+	agg.queue = append(agg.queue, f)
+	if value.IsNull(f) {
+		return
+	}
+
+	agg.count++
+	x := f.(value.Float).Raw()
+	if !isExactInteger(x) {
+		agg.inexact++
+		return
+	}
+	agg.addInteger(int64(x))
+}
+
+func (agg *sumWindowAggregator) Shift() {
+	f := agg.queue[agg.head]
+	agg.queue[agg.head] = nil
+	agg.head++
+	if value.IsNull(f) {
+		return
+	}
+
+	agg.count--
+	x := f.(value.Float).Raw()
+	if !isExactInteger(x) {
+		agg.inexact--
+		return
+	}
+	agg.addInteger(-int64(x))
+}
+
+func (agg *sumWindowAggregator) addInteger(i int64) {
+	sum := agg.intSum + i
+	if (0 < i && sum < agg.intSum) || (i < 0 && agg.intSum < sum) {
+		agg.overflowed = true
+	}
+	agg.intSum = sum
+}
+
+func (agg *sumWindowAggregator) Result() value.Primary {
+	if agg.count < 1 {
+		return value.NewNull()
+	}
+
+	var sum float64
+	if agg.inexact == 0 && !agg.overflowed && -maxExactInteger < agg.intSum && agg.intSum < maxExactInteger {
+		sum = float64(agg.intSum)
+	} else {
+		for _, f := range agg.queue[agg.head:] {
+			if value.IsNull(f) {
+				continue
+			}
+			sum += f.(value.Float).Raw()
+		}
+	}
+
+	if agg.avg {
+		return value.ParseFloat64(sum / float64(agg.count))
+	}
+	return value.ParseFloat64(sum)
+}
+
+// extremumWindowAggregator calculates MAX or MIN with a monotonic deque holding the indices of
+// the candidate values, so that each value is compared only when it enters the frame.
+//
+// Only integers and floats are held in the deque because other values can be compared in different ways
+// depending on the counterparts. If the frame contains other values, then the result is calculated
+// from the values in the frame in the same way as Max and Min.
+type extremumWindowAggregator struct {
+	flags   *cmd.Flags
+	greater bool
+
+	queue []value.Primary
+	head  int
+	deque []int
+
+	others int
+}
+
+func newExtremumWindowAggregator(flags *cmd.Flags, greater bool) *extremumWindowAggregator {
+	return &extremumWindowAggregator{
+		flags:   flags,
+		greater: greater,
+	}
+}
+
+func isOrderedNumber(p value.Primary) bool {
+	switch v := p.(type) {
+	case value.Integer:
+		return true
+	case value.Float:
+		return !math.IsNaN(v.Raw())
+	}
+	return false
+}
+
+// precedes reports whether p1 is strictly greater than p2 for MAX, or strictly less than p2 for MIN.
+func (agg *extremumWindowAggregator) precedes(p1 value.Primary, p2 value.Primary) bool {
+	if agg.greater {
+		return value.Greater(p1, p2, agg.flags.DatetimeFormat) == ternary.TRUE
+	}
+	return value.Less(p1, p2, agg.flags.DatetimeFormat) == ternary.TRUE
+}
+
+func (agg *extremumWindowAggregator) Push(p value.Primary) {
+	agg.queue = append(agg.queue, p)
+	if value.IsNull(p) {
+		return
+	}
+	if !isOrderedNumber(p) {
+		agg.others++
+		return
+	}
+
+	// Values equal to the new value are kept, so that the first one in the frame is returned as Max and Min do.
+	for 0 < len(agg.deque) && agg.precedes(p, agg.queue[agg.deque[len(agg.deque)-1]]) {
+		agg.deque = agg.deque[:len(agg.deque)-1]
+	}
+	agg.deque = append(agg.deque, len(agg.queue)-1)
+}
+
+func (agg *extremumWindowAggregator) Shift() {
+	p := agg.queue[agg.head]
+	agg.queue[agg.head] = nil
+	if 0 < len(agg.deque) && agg.deque[0] == agg.head {
+		agg.deque = agg.deque[1:]
+	}
+	agg.head++
+
+	if !value.IsNull(p) && !isOrderedNumber(p) {
+		agg.others--
+	}
+}
+
+func (agg *extremumWindowAggregator) Result() value.Primary {
+	if 0 < agg.others {
+		if agg.greater {
+			return Max(agg.queue[agg.head:], agg.flags)
+		}
+		return Min(agg.queue[agg.head:], agg.flags)
+	}
+	if len(agg.deque) < 1 {
+		return value.NewNull()
+	}
+	return agg.queue[agg.deque[0]]
+}
+
+// windowAggregate returns the aggregate values of the frames in the frame set.
+// The frames are expected to slide forward, and only values entering or leaving the frame
+// are evaluated.
+func windowAggregate(ctx context.Context, filter *Filter, frameSet []WindowFrame, partition Partition, expr parser.AnalyticFunction, newAggregator func(*cmd.Flags) WindowAggregator) ([]value.Primary, error) {
+	results := make([]value.Primary, len(frameSet))
+
+	agg := newAggregator(filter.tx.Flags)
+	low, high := 0, -1
+
+	for i, frame := range frameSet {
+		frameLow := frame.Low
+		if frameLow < 0 {
+			frameLow = 0
+		}
+		frameHigh := frame.High
+		if len(partition) <= frameHigh {
+			frameHigh = len(partition) - 1
+		}
+
+		if frameLow < low || frameHigh < high {
+			agg = newAggregator(filter.tx.Flags)
+			low, high = frameLow, frameLow-1
+		}
+
+		for ; low < frameLow; low++ {
+			if low <= high {
+				agg.Shift()
+			}
+		}
+		if high < low-1 {
+			high = low - 1
+		}
+
+		for high < frameHigh {
+			high++
+			filter.records[0].recordIndex = partition[high]
+			p, err := filter.Evaluate(ctx, expr.Args[0])
+			if err != nil {
+				return nil, err
+			}
+			agg.Push(p)
+		}
+
+		results[i] = agg.Result()
+	}
+
+	return results, nil
+}
@@ -0,0 +1,127 @@
+package query
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mithrandie/csvq/lib/parser"
+	"github.com/mithrandie/csvq/lib/value"
+)
+
+var windowAggregatorTestValues = []value.Primary{
+	value.NewInteger(1),
+	value.NewFloat(0.1),
+	value.NewNull(),
+	value.NewFloat(0.2),
+	value.NewString("3"),
+	value.NewInteger(-4),
+	value.NewString("abc"),
+	value.NewFloat(1e20),
+	value.NewInteger(5),
+	value.NewInteger(9007199254740993),
+	value.NewInteger(9007199254740993),
+	value.NewInteger(6),
+	value.NewInteger(7),
+}
+
+var windowAggregatorTestNumbers = []value.Primary{
+	value.NewInteger(3),
+	value.NewFloat(3),
+	value.NewInteger(1),
+	value.NewNull(),
+	value.NewFloat(2.5),
+	value.NewInteger(5),
+	value.NewInteger(5),
+	value.NewFloat(-1.5),
+	value.NewNull(),
+	value.NewNull(),
+	value.NewInteger(4),
+	value.NewFloat(5),
+}
+
+func TestWindowAggregators(t *testing.T) {
+	aggregateFunctions := map[string]AggregateFunction{
+		"COUNT": Count,
+		"SUM":   Sum,
+		"AVG":   Avg,
+		"MAX":   Max,
+		"MIN":   Min,
+	}
+
+	for name, newAggregator := range WindowAggregators {
+		fn := aggregateFunctions[name]
+
+		for _, values := range [][]value.Primary{windowAggregatorTestValues, windowAggregatorTestNumbers} {
+			for frameLen := 1; frameLen <= 4; frameLen++ {
+				agg := newAggregator(TestTx.Flags)
+				for i, v := range values {
+					agg.Push(v)
+					low := i - frameLen + 1
+					if 0 < low {
+						agg.Shift()
+					} else {
+						low = 0
+					}
+
+					result := agg.Result()
+					expect := fn(values[low:i+1], TestTx.Flags)
+					if !reflect.DeepEqual(result, expect) {
+						t.Errorf("%s: frame [%d, %d]: result = %s, want %s", name, low, i, result, expect)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestExtremumWindowAggregator(t *testing.T) {
+	agg := newExtremumWindowAggregator(TestTx.Flags, true)
+	for i, v := range windowAggregatorTestNumbers {
+		agg.Push(v)
+		if 2 < i {
+			agg.Shift()
+		}
+		if agg.others != 0 {
+			t.Fatalf("frame ending at %d: result is calculated from all the values, want the deque to be used", i)
+		}
+		if 3 < len(agg.deque) {
+			t.Errorf("frame ending at %d: deque length = %d, want at most the frame length", i, len(agg.deque))
+		}
+	}
+
+	agg.Push(value.NewString("abc"))
+	agg.Shift()
+	if agg.others != 1 {
+		t.Errorf("others = %d, want %d for a frame containing a string", agg.others, 1)
+	}
+}
+
+func TestWindowAggregatorFunction(t *testing.T) {
+	frameSet := []WindowFrame{{Low: 0, High: 0}, {Low: 0, High: 1}}
+
+	for _, v := range []struct {
+		Name     string
+		Expr     parser.AnalyticFunction
+		FrameSet []WindowFrame
+		Expect   bool
+	}{
+		{Name: "COUNT", Expect: true},
+		{Name: "SUM", Expect: true},
+		{Name: "AVG", Expect: true},
+		{Name: "MAX", Expect: true},
+		{Name: "MIN", Expect: true},
+		{Name: "MEDIAN", Expect: false},
+		{Name: "USERAGGFUNC", Expect: false},
+		{Name: "MAX", Expr: parser.AnalyticFunction{Distinct: parser.Token{Token: parser.DISTINCT, Literal: "distinct"}}, Expect: false},
+		{Name: "MAX", FrameSet: []WindowFrame{{Low: 0, High: 1}}, Expect: false},
+		{Name: "MAX", FrameSet: []WindowFrame{{Low: 0, High: 0}, {Low: 0, High: 1, Excludes: []int{1}}}, Expect: false},
+	} {
+		fs := v.FrameSet
+		if fs == nil {
+			fs = frameSet
+		}
+		if _, ok := windowAggregatorFunction(v.Name, v.Expr, fs); ok != v.Expect {
+			t.Errorf("%s: incremental = %t, want %t", v.Name, ok, v.Expect)
+		}
+	}
+}
@@ -0,0 +1,1025 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/mithrandie/csvq/lib/parser"
+	"github.com/mithrandie/csvq/lib/query"
+	"github.com/mithrandie/csvq/lib/value"
+
+	"github.com/mithrandie/ternary"
+)
+
+const (
+	pgProtocolVersion   = 196608
+	pgSSLRequestCode    = 80877103
+	pgGSSENCRequestCode = 80877104
+	pgCancelRequestCode = 80877102
+
+	pgMaxMessageSize = 64 * 1024 * 1024
+	pgServerVersion  = "13.0"
+)
+
+// Authentication request codes.
+const (
+	pgAuthenticationOk          = 0
+	pgAuthenticationMD5Password = 5
+)
+
+// Object IDs of the types of the columns sent to clients.
+const (
+	pgBoolOID        = 16
+	pgInt8OID        = 20
+	pgTextOID        = 25
+	pgFloat8OID      = 701
+	pgTimestamptzOID = 1184
+)
+
+// SQLSTATE codes of the errors sent to clients.
+const (
+	pgSyntaxError             = "42601"
+	pgReadOnlySQLTransaction  = "25006"
+	pgFeatureNotSupported     = "0A000"
+	pgProtocolViolation       = "08P01"
+	pgInvalidSQLStatementName = "26000"
+	pgInvalidCursorName       = "34000"
+	pgInvalidPassword         = "28P01"
+	pgInsufficientPrivilege   = "42501"
+	pgInternalError           = "XX000"
+)
+
+// PGServer accepts connections from PostgreSQL clients, and executes the queries received by the simple query protocol
+// or the extended query protocol.
+//
+// Only select queries can be executed, and the values are sent in text format.
+// The queries are executed in restricted transactions in the same way as the queries posted to Server,
+// so that the CALL function and files outside the repository are rejected.
+type PGServer struct {
+	server   *Server
+	password string
+
+	nextProcessID int32
+	mutex         *sync.Mutex
+}
+
+// NewPGServer returns a server executing queries in read-only transactions forked from tx.
+//
+// If password is not empty, clients are required to be authenticated with the password by MD5 password
+// authentication. Any user name is accepted.
+func NewPGServer(tx *query.Transaction, password string) *PGServer {
+	base := tx.Fork(tx.Session)
+	base.Flags.ReadOnly = true
+	return &PGServer{
+		server:   NewServer(base, ""),
+		password: password,
+		mutex:    &sync.Mutex{},
+	}
+}
+
+// Serve accepts connections on the listener, and handles each connection in a new goroutine.
+func (s *PGServer) Serve(l net.Listener) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *PGServer) handle(conn net.Conn) {
+	s.mutex.Lock()
+	s.nextProcessID++
+	pid := s.nextProcessID
+	s.mutex.Unlock()
+
+	c := &pgConn{
+		conn:       conn,
+		r:          bufio.NewReader(conn),
+		w:          bufio.NewWriter(conn),
+		server:     s.server,
+		password:   s.password,
+		processID:  pid,
+		statements: make(map[string]*pgStatement),
+		portals:    make(map[string]*pgPortal),
+	}
+	if err := c.run(); err != nil && err != io.EOF {
+		s.server.tx.Session.LogError(err.Error())
+	}
+	_ = conn.Close()
+}
+
+type pgStatement struct {
+	Query      string
+	Statements []parser.Statement
+	ParamTypes []int32
+	ParamNum   int
+}
+
+type pgPortal struct {
+	Statement *pgStatement
+	Params    []query.Parameter
+
+	Result   *Result
+	Sent     int
+	Executed bool
+}
+
+type pgConn struct {
+	conn      net.Conn
+	r         *bufio.Reader
+	w         *bufio.Writer
+	server    *Server
+	password  string
+	processID int32
+
+	statements map[string]*pgStatement
+	portals    map[string]*pgPortal
+
+	// Messages of the extended query protocol are discarded until the next Sync message after an error.
+	ignoreUntilSync bool
+}
+
+type pgError struct {
+	Code    string
+	Message string
+}
+
+func (e *pgError) Error() string {
+	return e.Message
+}
+
+func newPGError(code string, format string, args ...interface{}) error {
+	return &pgError{
+		Code:    code,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+func (c *pgConn) run() error {
+	ok, err := c.startup()
+	if err != nil || !ok {
+		return err
+	}
+
+	for {
+		typ, body, err := c.readMessage()
+		if err != nil {
+			return err
+		}
+
+		if c.ignoreUntilSync && typ != 'S' {
+			continue
+		}
+
+		switch typ {
+		case 'Q':
+			err = c.simpleQuery(body)
+		case 'P':
+			err = c.parse(body)
+		case 'B':
+			err = c.bind(body)
+		case 'D':
+			err = c.describe(body)
+		case 'E':
+			err = c.execute(body)
+		case 'C':
+			err = c.close(body)
+		case 'S':
+			c.ignoreUntilSync = false
+			err = c.readyForQuery()
+		case 'H':
+			err = c.w.Flush()
+		case 'X':
+			return c.w.Flush()
+		default:
+			err = newPGError(pgProtocolViolation, "message type %q is not supported", typ)
+		}
+
+		if err != nil {
+			if _, ok := err.(*pgError); !ok {
+				if _, ok := err.(query.Error); !ok {
+					return err
+				}
+			}
+			if err = c.sendError(err); err != nil {
+				return err
+			}
+			if typ == 'Q' {
+				err = c.readyForQuery()
+			} else {
+				c.ignoreUntilSync = true
+				err = c.w.Flush()
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// startup reads the startup message, and reports whether the connection has been established.
+func (c *pgConn) startup() (bool, error) {
+	for {
+		body, err := c.readStartupMessage()
+		if err != nil {
+			return false, err
+		}
+
+		r := &pgReader{buf: body}
+		code := r.int32()
+		switch code {
+		case pgSSLRequestCode, pgGSSENCRequestCode:
+			// Encryption is not supported, so the client continues in plain text.
+			if _, err = c.conn.Write([]byte{'N'}); err != nil {
+				return false, err
+			}
+			continue
+		case pgCancelRequestCode:
+			return false, nil
+		}
+
+		if code>>16 != pgProtocolVersion>>16 {
+			msg := newPGMessage('E')
+			msg.errorFields("FATAL", pgFeatureNotSupported, fmt.Sprintf("protocol version %d.%d is not supported", code>>16, code&0xffff))
+			if err = c.send(msg); err != nil {
+				return false, err
+			}
+			return false, c.w.Flush()
+		}
+
+		if 0 < len(c.password) {
+			params := make(map[string]string)
+			for {
+				k := r.string()
+				if len(k) < 1 || r.err != nil {
+					break
+				}
+				params[k] = r.string()
+			}
+			if r.err != nil {
+				return false, r.err
+			}
+
+			if ok, err := c.authenticate(params["user"]); err != nil || !ok {
+				return false, err
+			}
+		}
+		break
+	}
+
+	if err := c.send(newPGMessage('R').int32(pgAuthenticationOk)); err != nil {
+		return false, err
+	}
+	for _, p := range [][2]string{
+		{"server_version", pgServerVersion},
+		{"server_encoding", "UTF8"},
+		{"client_encoding", "UTF8"},
+		{"DateStyle", "ISO, MDY"},
+		{"IntervalStyle", "postgres"},
+		{"integer_datetimes", "on"},
+		{"standard_conforming_strings", "on"},
+	} {
+		if err := c.send(newPGMessage('S').string(p[0]).string(p[1])); err != nil {
+			return false, err
+		}
+	}
+	if err := c.send(newPGMessage('K').int32(c.processID).int32(0)); err != nil {
+		return false, err
+	}
+	return true, c.readyForQuery()
+}
+
+// authenticate requests the client to send the password by MD5 password authentication,
+// and reports whether the password is correct.
+func (c *pgConn) authenticate(user string) (bool, error) {
+	salt := make([]byte, 4)
+	if _, err := rand.Read(salt); err != nil {
+		return false, err
+	}
+
+	msg := newPGMessage('R').int32(pgAuthenticationMD5Password)
+	msg.buf.Write(salt)
+	if err := c.send(msg); err != nil {
+		return false, err
+	}
+	if err := c.w.Flush(); err != nil {
+		return false, err
+	}
+
+	typ, body, err := c.readMessage()
+	if err != nil {
+		return false, err
+	}
+	r := &pgReader{buf: body}
+	password := r.string()
+
+	if typ != 'p' || r.err != nil || subtle.ConstantTimeCompare([]byte(password), []byte(pgMD5Password(c.password, user, salt))) != 1 {
+		msg := newPGMessage('E')
+		msg.errorFields("FATAL", pgInvalidPassword, fmt.Sprintf("password authentication failed for user %q", user))
+		if err = c.send(msg); err != nil {
+			return false, err
+		}
+		return false, c.w.Flush()
+	}
+	return true, nil
+}
+
+// parseQuery parses the query, and returns an error if the query has any statements other than select queries.
+func (c *pgConn) parseQuery(q string) ([]parser.Statement, error) {
+	statements, err := c.server.parse(q)
+	if err != nil {
+		return nil, err
+	}
+	for _, stmt := range statements {
+		if _, ok := stmt.(parser.SelectQuery); !ok {
+			return nil, newPGError(pgReadOnlySQLTransaction, "only select queries can be executed")
+		}
+	}
+	return statements, nil
+}
+
+func (c *pgConn) simpleQuery(body []byte) error {
+	r := &pgReader{buf: body}
+	q := r.string()
+	if r.err != nil {
+		return r.err
+	}
+
+	if len(strings.TrimFunc(q, pgIsSpaceOrSemicolon)) < 1 {
+		if err := c.send(newPGMessage('I')); err != nil {
+			return err
+		}
+		return c.readyForQuery()
+	}
+
+	if tag, ok := pgIgnoredStatement(q); ok {
+		if err := c.send(newPGMessage('C').string(tag)); err != nil {
+			return err
+		}
+		return c.readyForQuery()
+	}
+
+	statements, err := c.parseQuery(q)
+	if err != nil {
+		return err
+	}
+
+	result, err := c.server.execute(context.Background(), statements, nil)
+	if err != nil {
+		return err
+	}
+
+	if len(result.Views) < 1 {
+		if err = c.send(newPGMessage('C').string("SELECT 0")); err != nil {
+			return err
+		}
+	}
+	for _, view := range result.Views {
+		if err = c.send(pgRowDescription(view)); err != nil {
+			return err
+		}
+		if err = c.sendRows(view, 0, view.RecordLen()); err != nil {
+			return err
+		}
+		if err = c.send(newPGMessage('C').string(fmt.Sprintf("SELECT %d", view.RecordLen()))); err != nil {
+			return err
+		}
+	}
+	return c.readyForQuery()
+}
+
+func (c *pgConn) parse(body []byte) error {
+	r := &pgReader{buf: body}
+	name := r.string()
+	q := r.string()
+	n := int(r.int16())
+	types := make([]int32, 0, n)
+	for i := 0; i < n; i++ {
+		types = append(types, r.int32())
+	}
+	if r.err != nil {
+		return r.err
+	}
+
+	if _, ok := c.statements[name]; ok && 0 < len(name) {
+		return newPGError(pgInvalidSQLStatementName, "prepared statement %q already exists", name)
+	}
+
+	q, paramNum := pgReplacePlaceholders(q)
+	var statements []parser.Statement
+	if _, ok := pgIgnoredStatement(q); !ok && 0 < len(strings.TrimFunc(q, pgIsSpaceOrSemicolon)) {
+		var err error
+		if statements, err = c.parseQuery(q); err != nil {
+			return err
+		}
+	}
+
+	for len(types) < paramNum {
+		types = append(types, 0)
+	}
+	for i := range types {
+		if types[i] == 0 {
+			// Types of the parameters not specified by the client are inferred as text.
+			types[i] = pgTextOID
+		}
+	}
+	c.statements[name] = &pgStatement{
+		Query:      q,
+		Statements: statements,
+		ParamTypes: types,
+		ParamNum:   paramNum,
+	}
+	return c.send(newPGMessage('1'))
+}
+
+func (c *pgConn) bind(body []byte) error {
+	r := &pgReader{buf: body}
+	portalName := r.string()
+	statementName := r.string()
+
+	formats := make([]int16, int(r.int16()))
+	for i := range formats {
+		formats[i] = r.int16()
+	}
+
+	values := make([][]byte, int(r.int16()))
+	for i := range values {
+		values[i] = r.bytes()
+	}
+
+	resultFormats := make([]int16, int(r.int16()))
+	for i := range resultFormats {
+		resultFormats[i] = r.int16()
+	}
+	if r.err != nil {
+		return r.err
+	}
+
+	stmt, ok := c.statements[statementName]
+	if !ok {
+		return newPGError(pgInvalidSQLStatementName, "prepared statement %q does not exist", statementName)
+	}
+	if len(values) != stmt.ParamNum {
+		return newPGError(pgProtocolViolation, "bind message supplies %d parameters, but prepared statement %q requires %d", len(values), statementName, stmt.ParamNum)
+	}
+	for _, f := range append(formats, resultFormats...) {
+		if f != 0 {
+			return newPGError(pgFeatureNotSupported, "binary format is not supported")
+		}
+	}
+
+	params := make([]query.Parameter, 0, len(values))
+	for i, v := range values {
+		p := query.Parameter{Name: pgParameterName(i + 1)}
+		if v != nil {
+			p.Value = pgParameterValue(stmt.ParamTypes[i], string(v))
+		}
+		params = append(params, p)
+	}
+
+	c.portals[portalName] = &pgPortal{
+		Statement: stmt,
+		Params:    params,
+	}
+	return c.send(newPGMessage('2'))
+}
+
+func (c *pgConn) describe(body []byte) error {
+	r := &pgReader{buf: body}
+	typ := r.byte()
+	name := r.string()
+	if r.err != nil {
+		return r.err
+	}
+
+	switch typ {
+	case 'S':
+		stmt, ok := c.statements[name]
+		if !ok {
+			return newPGError(pgInvalidSQLStatementName, "prepared statement %q does not exist", name)
+		}
+
+		msg := newPGMessage('t').int16(int16(len(stmt.ParamTypes)))
+		for _, t := range stmt.ParamTypes {
+			msg.int32(t)
+		}
+		if err := c.send(msg); err != nil {
+			return err
+		}
+
+		if 0 < stmt.ParamNum {
+			// Columns cannot be determined without parameters.
+			return c.send(newPGMessage('n'))
+		}
+		return c.describePortal(&pgPortal{Statement: stmt})
+	case 'P':
+		portal, ok := c.portals[name]
+		if !ok {
+			return newPGError(pgInvalidCursorName, "portal %q does not exist", name)
+		}
+		return c.describePortal(portal)
+	}
+	return newPGError(pgProtocolViolation, "invalid describe message subtype %q", typ)
+}
+
+func (c *pgConn) describePortal(portal *pgPortal) error {
+	if err := c.executePortal(portal); err != nil {
+		return err
+	}
+	if portal.Result == nil || len(portal.Result.Views) < 1 {
+		return c.send(newPGMessage('n'))
+	}
+	return c.send(pgRowDescription(portal.Result.Views[0]))
+}
+
+func (c *pgConn) execute(body []byte) error {
+	r := &pgReader{buf: body}
+	name := r.string()
+	maxRows := int(r.int32())
+	if r.err != nil {
+		return r.err
+	}
+
+	portal, ok := c.portals[name]
+	if !ok {
+		return newPGError(pgInvalidCursorName, "portal %q does not exist", name)
+	}
+	if err := c.executePortal(portal); err != nil {
+		return err
+	}
+
+	if portal.Result == nil {
+		return c.send(newPGMessage('I'))
+	}
+	if len(portal.Result.Views) < 1 {
+		return c.send(newPGMessage('C').string("SELECT 0"))
+	}
+
+	view := portal.Result.Views[0]
+	end := view.RecordLen()
+	if 0 < maxRows && portal.Sent+maxRows < end {
+		end = portal.Sent + maxRows
+	}
+	if err := c.sendRows(view, portal.Sent, end); err != nil {
+		return err
+	}
+	portal.Sent = end
+
+	if portal.Sent < view.RecordLen() {
+		return c.send(newPGMessage('s'))
+	}
+	return c.send(newPGMessage('C').string(fmt.Sprintf("SELECT %d", view.RecordLen())))
+}
+
+// executePortal executes the statement of the portal unless it has already been executed.
+// The result is nil if the statement is empty.
+func (c *pgConn) executePortal(portal *pgPortal) error {
+	if portal.Executed {
+		return nil
+	}
+	portal.Executed = true
+
+	q := portal.Statement.Query
+	if len(strings.TrimFunc(q, pgIsSpaceOrSemicolon)) < 1 {
+		return nil
+	}
+	if _, ok := pgIgnoredStatement(q); ok {
+		portal.Result = &Result{}
+		return nil
+	}
+
+	result, err := c.server.execute(context.Background(), portal.Statement.Statements, portal.Params)
+	if err != nil {
+		return err
+	}
+	portal.Result = &result
+	return nil
+}
+
+func (c *pgConn) close(body []byte) error {
+	r := &pgReader{buf: body}
+	typ := r.byte()
+	name := r.string()
+	if r.err != nil {
+		return r.err
+	}
+
+	switch typ {
+	case 'S':
+		delete(c.statements, name)
+	case 'P':
+		delete(c.portals, name)
+	default:
+		return newPGError(pgProtocolViolation, "invalid close message subtype %q", typ)
+	}
+	return c.send(newPGMessage('3'))
+}
+
+func (c *pgConn) readyForQuery() error {
+	// Portals exist only in a transaction, and each query is executed in its own transaction.
+	for k := range c.portals {
+		delete(c.portals, k)
+	}
+	if err := c.send(newPGMessage('Z').byte('I')); err != nil {
+		return err
+	}
+	return c.w.Flush()
+}
+
+func (c *pgConn) sendError(err error) error {
+	code := pgInternalError
+	switch e := err.(type) {
+	case *pgError:
+		code = e.Code
+	case *query.SyntaxError:
+		code = pgSyntaxError
+	case *query.FileReadOnlyError:
+		code = pgReadOnlySQLTransaction
+	case *query.RestrictedStatementError:
+		code = pgInsufficientPrivilege
+	}
+
+	msg := newPGMessage('E')
+	msg.errorFields("ERROR", code, err.Error())
+	return c.send(msg)
+}
+
+func (c *pgConn) sendRows(view *query.View, start int, end int) error {
+	for i := start; i < end; i++ {
+		record := view.RecordSet[i]
+		msg := newPGMessage('D').int16(int16(len(record)))
+		for _, cell := range record {
+			if s, ok := pgTextValue(cell.Value()); ok {
+				msg.int32(int32(len(s)))
+				msg.buf.WriteString(s)
+			} else {
+				msg.int32(-1)
+			}
+		}
+		if err := c.send(msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *pgConn) send(msg *pgMessage) error {
+	if err := c.w.WriteByte(msg.typ); err != nil {
+		return err
+	}
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, uint32(msg.buf.Len()+4))
+	if _, err := c.w.Write(b); err != nil {
+		return err
+	}
+	_, err := c.w.Write(msg.buf.Bytes())
+	return err
+}
+
+func (c *pgConn) readStartupMessage() ([]byte, error) {
+	return c.readBody()
+}
+
+func (c *pgConn) readMessage() (byte, []byte, error) {
+	typ, err := c.r.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+	body, err := c.readBody()
+	return typ, body, err
+}
+
+func (c *pgConn) readBody() ([]byte, error) {
+	b := make([]byte, 4)
+	if _, err := io.ReadFull(c.r, b); err != nil {
+		return nil, err
+	}
+	size := int(binary.BigEndian.Uint32(b))
+	if size < 4 || pgMaxMessageSize < size {
+		return nil, errors.New("invalid message length")
+	}
+
+	body := make([]byte, size-4)
+	if _, err := io.ReadFull(c.r, body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+type pgMessage struct {
+	typ byte
+	buf bytes.Buffer
+}
+
+func newPGMessage(typ byte) *pgMessage {
+	return &pgMessage{typ: typ}
+}
+
+func (m *pgMessage) byte(b byte) *pgMessage {
+	m.buf.WriteByte(b)
+	return m
+}
+
+func (m *pgMessage) int16(i int16) *pgMessage {
+	b := make([]byte, 2)
+	binary.BigEndian.PutUint16(b, uint16(i))
+	m.buf.Write(b)
+	return m
+}
+
+func (m *pgMessage) int32(i int32) *pgMessage {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, uint32(i))
+	m.buf.Write(b)
+	return m
+}
+
+func (m *pgMessage) string(s string) *pgMessage {
+	m.buf.WriteString(s)
+	m.buf.WriteByte(0)
+	return m
+}
+
+func (m *pgMessage) errorFields(severity string, code string, message string) {
+	m.byte('S').string(severity)
+	m.byte('V').string(severity)
+	m.byte('C').string(code)
+	m.byte('M').string(message)
+	m.byte(0)
+}
+
+type pgReader struct {
+	buf []byte
+	err error
+}
+
+func (r *pgReader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if len(r.buf) < n {
+		r.err = newPGError(pgProtocolViolation, "invalid message format")
+		return nil
+	}
+	b := r.buf[:n]
+	r.buf = r.buf[n:]
+	return b
+}
+
+func (r *pgReader) byte() byte {
+	if b := r.next(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+func (r *pgReader) int16() int16 {
+	if b := r.next(2); b != nil {
+		return int16(binary.BigEndian.Uint16(b))
+	}
+	return 0
+}
+
+func (r *pgReader) int32() int32 {
+	if b := r.next(4); b != nil {
+		return int32(binary.BigEndian.Uint32(b))
+	}
+	return 0
+}
+
+func (r *pgReader) string() string {
+	if r.err != nil {
+		return ""
+	}
+	i := bytes.IndexByte(r.buf, 0)
+	if i < 0 {
+		r.err = newPGError(pgProtocolViolation, "invalid message format")
+		return ""
+	}
+	s := string(r.buf[:i])
+	r.buf = r.buf[i+1:]
+	return s
+}
+
+// bytes reads a value prefixed with the length. A length of -1 represents NULL, and nil is returned.
+func (r *pgReader) bytes() []byte {
+	n := r.int32()
+	if r.err != nil || n < 0 {
+		return nil
+	}
+	return r.next(int(n))
+}
+
+func pgRowDescription(view *query.View) *pgMessage {
+	names := view.Header.TableColumnNames()
+	msg := newPGMessage('T').int16(int16(len(names)))
+	for i, name := range names {
+		msg.string(name)
+		msg.int32(0)
+		msg.int16(0)
+		msg.int32(pgColumnType(view, i))
+		msg.int16(-1)
+		msg.int32(-1)
+		msg.int16(0)
+	}
+	return msg
+}
+
+// pgColumnType returns the type of the column that all of the values except nulls can be converted to.
+func pgColumnType(view *query.View, idx int) int32 {
+	var oid int32
+	for _, record := range view.RecordSet {
+		var t int32
+		switch v := record[idx].Value().(type) {
+		case value.Null:
+			continue
+		case value.Integer:
+			t = pgInt8OID
+		case value.Float:
+			t = pgFloat8OID
+		case value.Boolean:
+			t = pgBoolOID
+		case value.Ternary:
+			if v.Ternary() == ternary.UNKNOWN {
+				continue
+			}
+			t = pgBoolOID
+		case value.Datetime:
+			t = pgTimestamptzOID
+		default:
+			return pgTextOID
+		}
+
+		switch {
+		case oid == 0 || oid == t:
+			oid = t
+		case (oid == pgInt8OID && t == pgFloat8OID) || (oid == pgFloat8OID && t == pgInt8OID):
+			oid = pgFloat8OID
+		default:
+			return pgTextOID
+		}
+	}
+	if oid == 0 {
+		return pgTextOID
+	}
+	return oid
+}
+
+// pgTextValue returns the value in text format. It reports false if the value is null.
+func pgTextValue(val value.Primary) (string, bool) {
+	switch v := val.(type) {
+	case value.Null:
+		return "", false
+	case value.Float:
+		f := v.Raw()
+		switch {
+		case math.IsNaN(f):
+			return "NaN", true
+		case math.IsInf(f, 1):
+			return "Infinity", true
+		case math.IsInf(f, -1):
+			return "-Infinity", true
+		}
+		return strconv.FormatFloat(f, 'g', -1, 64), true
+	case value.Boolean:
+		if v.Raw() {
+			return "t", true
+		}
+		return "f", true
+	case value.Ternary:
+		if v.Ternary() == ternary.UNKNOWN {
+			return "", false
+		}
+		if v.Ternary() == ternary.TRUE {
+			return "t", true
+		}
+		return "f", true
+	case value.Datetime:
+		return v.Raw().Format("2006-01-02 15:04:05.999999999Z07:00"), true
+	}
+
+	s, _, _ := query.ConvertFieldContents(val, false)
+	return s, true
+}
+
+// pgParameterValue converts a parameter in text format to the value of the type.
+func pgParameterValue(oid int32, s string) interface{} {
+	switch oid {
+	case 20, 21, 23:
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return i
+		}
+	case 700, pgFloat8OID, 1700:
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f
+		}
+	case pgBoolOID:
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b
+		}
+	case 1082, 1114, pgTimestamptzOID:
+		for _, layout := range []string{"2006-01-02 15:04:05.999999999Z07:00", "2006-01-02 15:04:05.999999999Z07", "2006-01-02 15:04:05.999999999", "2006-01-02"} {
+			if t, err := time.Parse(layout, s); err == nil {
+				return t
+			}
+		}
+	}
+	return s
+}
+
+func pgParameterName(n int) string {
+	return "pgparam" + strconv.Itoa(n)
+}
+
+// pgReplacePlaceholders replaces the placeholders $1, $2, ... outside of quotes with the named placeholders,
+// and returns the number of the parameters.
+func pgReplacePlaceholders(q string) (string, int) {
+	src := []rune(q)
+	buf := &strings.Builder{}
+	paramNum := 0
+
+	var quote rune
+	for i := 0; i < len(src); i++ {
+		ch := src[i]
+		switch {
+		case quote != 0:
+			if ch == '\\' && i+1 < len(src) {
+				buf.WriteRune(ch)
+				i++
+				ch = src[i]
+			} else if ch == quote {
+				quote = 0
+			}
+		case ch == '\'' || ch == '"' || ch == '`':
+			quote = ch
+		case ch == '$' && i+1 < len(src) && '0' <= src[i+1] && src[i+1] <= '9':
+			j := i + 1
+			for j < len(src) && '0' <= src[j] && src[j] <= '9' {
+				j++
+			}
+			n, _ := strconv.Atoi(string(src[i+1 : j]))
+			if paramNum < n {
+				paramNum = n
+			}
+			buf.WriteString(":" + pgParameterName(n))
+			i = j - 1
+			continue
+		}
+		buf.WriteRune(ch)
+	}
+	return buf.String(), paramNum
+}
+
+// pgIgnoredStatement reports whether the statement is one of the statements that clients send to set up sessions
+// or to control transactions. Those statements are not executed, and the returned tag is sent as the result.
+func pgIgnoredStatement(q string) (string, bool) {
+	words := strings.Fields(strings.ToUpper(strings.TrimFunc(q, pgIsSpaceOrSemicolon)))
+	if len(words) < 1 {
+		return "", false
+	}
+
+	switch words[0] {
+	case "SET":
+		if 1 < len(words) && !strings.HasPrefix(words[1], "@") {
+			return "SET", true
+		}
+	case "BEGIN", "COMMIT", "ROLLBACK":
+		if len(words) == 1 || words[1] == "TRANSACTION" || words[1] == "WORK" {
+			return words[0], true
+		}
+	case "START":
+		if 1 < len(words) && words[1] == "TRANSACTION" {
+			return "START TRANSACTION", true
+		}
+	}
+	return "", false
+}
+
+// pgMD5Password returns the password that clients send in MD5 password authentication.
+func pgMD5Password(password string, user string, salt []byte) string {
+	h := md5.Sum([]byte(password + user))
+	h = md5.Sum(append([]byte(hex.EncodeToString(h[:])), salt...))
+	return "md5" + hex.EncodeToString(h[:])
+}
+
+func pgIsSpaceOrSemicolon(r rune) bool {
+	return unicode.IsSpace(r) || r == ';'
+}
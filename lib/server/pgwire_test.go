@@ -0,0 +1,377 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mithrandie/csvq/lib/file"
+	"github.com/mithrandie/csvq/lib/query"
+	"github.com/mithrandie/csvq/lib/value"
+)
+
+type pgTestClient struct {
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+// startup sends the startup message, and the password if the server requests MD5 password authentication.
+func (c *pgTestClient) startup(password string) error {
+	msg := newPGMessage(0).int32(pgProtocolVersion).string("user").string("csvq").byte(0)
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, uint32(msg.buf.Len()+4))
+	if _, err := c.conn.Write(append(b, msg.buf.Bytes()...)); err != nil {
+		return err
+	}
+
+	if typ, err := c.r.Peek(1); err != nil || typ[0] != 'R' {
+		return err
+	}
+	head, err := c.r.Peek(9)
+	if err != nil {
+		return err
+	}
+	if binary.BigEndian.Uint32(head[5:]) != pgAuthenticationMD5Password {
+		return nil
+	}
+
+	body := make([]byte, 13)
+	if _, err = io.ReadFull(c.r, body); err != nil {
+		return err
+	}
+	return c.send(newPGMessage('p').string(pgMD5Password(password, "csvq", body[9:])))
+}
+
+func (c *pgTestClient) send(msg *pgMessage) error {
+	b := make([]byte, 5)
+	b[0] = msg.typ
+	binary.BigEndian.PutUint32(b[1:], uint32(msg.buf.Len()+4))
+	_, err := c.conn.Write(append(b, msg.buf.Bytes()...))
+	return err
+}
+
+// receive reads messages until the ReadyForQuery message, and returns the summaries of the messages.
+func (c *pgTestClient) receive() ([]string, error) {
+	var messages []string
+	for {
+		typ, err := c.r.ReadByte()
+		if err != nil {
+			return messages, err
+		}
+		b := make([]byte, 4)
+		if _, err = io.ReadFull(c.r, b); err != nil {
+			return messages, err
+		}
+		body := make([]byte, binary.BigEndian.Uint32(b)-4)
+		if _, err = io.ReadFull(c.r, body); err != nil {
+			return messages, err
+		}
+
+		r := &pgReader{buf: body}
+		switch typ {
+		case 'T':
+			n := int(r.int16())
+			fields := make([]string, 0, n)
+			for i := 0; i < n; i++ {
+				name := r.string()
+				r.next(6)
+				oid := r.int32()
+				r.next(8)
+				fields = append(fields, name+":"+pgTestTypeName(oid))
+			}
+			messages = append(messages, "T "+strings.Join(fields, ","))
+		case 'D':
+			n := int(r.int16())
+			values := make([]string, 0, n)
+			for i := 0; i < n; i++ {
+				if v := r.bytes(); v == nil {
+					values = append(values, "NULL")
+				} else {
+					values = append(values, string(v))
+				}
+			}
+			messages = append(messages, "D "+strings.Join(values, ","))
+		case 'C':
+			messages = append(messages, "C "+r.string())
+		case 'E':
+			var code string
+			for {
+				f := r.byte()
+				if f == 0 {
+					break
+				}
+				if s := r.string(); f == 'C' {
+					code = s
+				}
+			}
+			messages = append(messages, "E "+code)
+		case 'Z':
+			messages = append(messages, "Z")
+			return messages, r.err
+		default:
+			messages = append(messages, string(typ))
+		}
+		if r.err != nil {
+			return messages, r.err
+		}
+	}
+}
+
+func pgTestTypeName(oid int32) string {
+	switch oid {
+	case pgBoolOID:
+		return "bool"
+	case pgInt8OID:
+		return "int8"
+	case pgFloat8OID:
+		return "float8"
+	case pgTimestamptzOID:
+		return "timestamptz"
+	}
+	return "text"
+}
+
+var pgServerTests = []struct {
+	Name     string
+	Messages []*pgMessage
+	Expect   []string
+}{
+	{
+		Name: "Simple Query",
+		Messages: []*pgMessage{
+			newPGMessage('Q').string("SELECT * FROM users WHERE id = 2; SELECT 1 AS n, 1.5 AS f, TRUE AS b, NULL AS x;"),
+		},
+		Expect: []string{
+			"T id:text,name:text", "D 2,b", "C SELECT 1",
+			"T n:int8,f:float8,b:bool,x:text", "D 1,1.5,t,NULL", "C SELECT 1",
+			"Z",
+		},
+	},
+	{
+		Name: "Simple Query Empty",
+		Messages: []*pgMessage{
+			newPGMessage('Q').string(" ; "),
+		},
+		Expect: []string{"I", "Z"},
+	},
+	{
+		Name: "Simple Query Session Setting",
+		Messages: []*pgMessage{
+			newPGMessage('Q').string("SET extra_float_digits = 3"),
+		},
+		Expect: []string{"C SET", "Z"},
+	},
+	{
+		Name: "Simple Query Syntax Error",
+		Messages: []*pgMessage{
+			newPGMessage('Q').string("SELECT FROM"),
+		},
+		Expect: []string{"E " + pgSyntaxError, "Z"},
+	},
+	{
+		Name: "Simple Query Read-Only Error",
+		Messages: []*pgMessage{
+			newPGMessage('Q').string("INSERT INTO users VALUES (3, 'c')"),
+		},
+		Expect: []string{"E " + pgReadOnlySQLTransaction, "Z"},
+	},
+	{
+		Name: "Simple Query Flag Setting Error",
+		Messages: []*pgMessage{
+			newPGMessage('Q').string("SET @@READ_ONLY = FALSE; INSERT INTO users VALUES (3, 'c');"),
+		},
+		Expect: []string{"E " + pgReadOnlySQLTransaction, "Z"},
+	},
+	{
+		Name: "Simple Query Function Declaration Error",
+		Messages: []*pgMessage{
+			newPGMessage('Q').string("SELECT 1; DECLARE fn FUNCTION () LANGUAGE EXTERNAL AS 'sh'; SELECT fn();"),
+		},
+		Expect: []string{"E " + pgReadOnlySQLTransaction, "Z"},
+	},
+	{
+		Name: "Simple Query Call Function Error",
+		Messages: []*pgMessage{
+			newPGMessage('Q').string("SELECT CALL('echo', 'a')"),
+		},
+		Expect: []string{"E " + pgInsufficientPrivilege, "Z"},
+	},
+	{
+		Name: "Simple Query Absolute File Path Error",
+		Messages: []*pgMessage{
+			newPGMessage('Q').string("SELECT * FROM `/etc/hostname`"),
+		},
+		Expect: []string{"E " + pgInsufficientPrivilege, "Z"},
+	},
+	{
+		Name: "Simple Query File Path Outside Repository Error",
+		Messages: []*pgMessage{
+			newPGMessage('Q').string("SELECT * FROM `../users.csv`"),
+		},
+		Expect: []string{"E " + pgInsufficientPrivilege, "Z"},
+	},
+	{
+		Name: "Extended Query",
+		Messages: []*pgMessage{
+			newPGMessage('P').string("stmt").string("SELECT name FROM users WHERE id = $1 OR name = $2").int16(1).int32(pgInt8OID),
+			newPGMessage('D').byte('S').string("stmt"),
+			newPGMessage('B').string("").string("stmt").int16(0).int16(2).int32(1).byte('1').int32(-1).int16(0),
+			newPGMessage('D').byte('P').string(""),
+			newPGMessage('E').string("").int32(0),
+			newPGMessage('S'),
+		},
+		Expect: []string{"1", "t", "n", "2", "T name:text", "D a", "C SELECT 1", "Z"},
+	},
+	{
+		Name: "Extended Query Max Rows",
+		Messages: []*pgMessage{
+			newPGMessage('P').string("").string("SELECT id FROM users").int16(0),
+			newPGMessage('B').string("").string("").int16(0).int16(0).int16(0),
+			newPGMessage('E').string("").int32(1),
+			newPGMessage('E').string("").int32(1),
+			newPGMessage('S'),
+		},
+		Expect: []string{"1", "2", "D 1", "s", "D 2", "C SELECT 2", "Z"},
+	},
+	{
+		Name: "Extended Query Statement Error",
+		Messages: []*pgMessage{
+			newPGMessage('P').string("").string("VAR @a := $1").int16(0),
+			newPGMessage('B').string("").string("").int16(0).int16(1).int32(1).byte('1').int16(0),
+			newPGMessage('E').string("").int32(0),
+			newPGMessage('S'),
+		},
+		Expect: []string{"E " + pgReadOnlySQLTransaction, "Z"},
+	},
+	{
+		Name: "Extended Query Error",
+		Messages: []*pgMessage{
+			newPGMessage('B').string("").string("notexist").int16(0).int16(0).int16(0),
+			newPGMessage('E').string("").int32(0),
+			newPGMessage('S'),
+		},
+		Expect: []string{"E " + pgInvalidSQLStatementName, "Z"},
+	},
+	{
+		Name: "Extended Query Binary Format",
+		Messages: []*pgMessage{
+			newPGMessage('P').string("").string("SELECT id FROM users").int16(0),
+			newPGMessage('B').string("").string("").int16(0).int16(0).int16(1).int16(1),
+			newPGMessage('S'),
+		},
+		Expect: []string{"1", "E " + pgFeatureNotSupported, "Z"},
+	},
+}
+
+func TestPGServer_Serve(t *testing.T) {
+	dir, err := ioutil.TempDir("", "csvq_pgserver_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+	if err := ioutil.WriteFile(filepath.Join(dir, "users.csv"), []byte("id,name\n1,a\n2,b"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tx, err := query.NewTransaction(context.Background(), file.DefaultWaitTimeout, file.DefaultRetryDelay, query.NewSession())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.Flags.Repository = dir
+	tx.Flags.SetQuiet(true)
+	tx.Session.Stderr = query.NewDiscard()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = l.Close()
+	}()
+	go func() {
+		_ = NewPGServer(tx, "secret").Serve(l)
+	}()
+
+	connect := func(password string) (*pgTestClient, []string) {
+		conn, err := net.Dial("tcp", l.Addr().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = conn.SetDeadline(time.Now().Add(10 * time.Second))
+		c := &pgTestClient{conn: conn, r: bufio.NewReader(conn)}
+
+		if err := c.startup(password); err != nil {
+			t.Fatal(err)
+		}
+		messages, _ := c.receive()
+		return c, messages
+	}
+
+	invalid, messages := connect("invalid")
+	_ = invalid.conn.Close()
+	if !reflect.DeepEqual(messages, []string{"E " + pgInvalidPassword}) {
+		t.Errorf("response to an invalid password = %v, want %v", messages, []string{"E " + pgInvalidPassword})
+	}
+
+	c, messages := connect("secret")
+	defer func() {
+		_ = c.conn.Close()
+	}()
+	if len(messages) < 3 || messages[0] != "R" || messages[len(messages)-2] != "K" || messages[len(messages)-1] != "Z" {
+		t.Fatalf("startup response = %v, want authentication, parameters, backend key data and ready for query", messages)
+	}
+
+	for _, v := range pgServerTests {
+		for _, msg := range v.Messages {
+			if err := c.send(msg); err != nil {
+				t.Fatal(err)
+			}
+		}
+		messages, err := c.receive()
+		if err != nil {
+			t.Fatalf("%s: unexpected error %q", v.Name, err)
+		}
+		if !reflect.DeepEqual(messages, v.Expect) {
+			t.Errorf("%s: messages = %v, want %v", v.Name, messages, v.Expect)
+		}
+	}
+}
+
+func TestPgReplacePlaceholders(t *testing.T) {
+	q, n := pgReplacePlaceholders("SELECT '$1', `$2`, $3 FROM t WHERE c = $12")
+	if q != "SELECT '$1', `$2`, :pgparam3 FROM t WHERE c = :pgparam12" || n != 12 {
+		t.Errorf("result = %q, %d, want %q, %d", q, n, "SELECT '$1', `$2`, :pgparam3 FROM t WHERE c = :pgparam12", 12)
+	}
+}
+
+func TestPgTextValue(t *testing.T) {
+	dt, _ := time.Parse(time.RFC3339Nano, "2012-02-03T09:18:15.123+09:00")
+	for _, v := range []struct {
+		Value  value.Primary
+		Expect string
+		Null   bool
+	}{
+		{Value: value.NewInteger(1), Expect: "1"},
+		{Value: value.NewFloat(1e21), Expect: "1e+21"},
+		{Value: value.NewBoolean(false), Expect: "f"},
+		{Value: value.NewDatetime(dt), Expect: "2012-02-03 09:18:15.123+09:00"},
+		{Value: value.NewString("str"), Expect: "str"},
+		{Value: value.NewNull(), Null: true},
+	} {
+		s, ok := pgTextValue(v.Value)
+		if ok == v.Null || s != v.Expect {
+			t.Errorf("text value of %s = %q, %t, want %q, %t", v.Value, s, ok, v.Expect, !v.Null)
+		}
+	}
+}
@@ -0,0 +1,348 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/mithrandie/csvq/lib/cmd"
+	csvqjson "github.com/mithrandie/csvq/lib/json"
+	"github.com/mithrandie/csvq/lib/parser"
+	"github.com/mithrandie/csvq/lib/query"
+
+	"github.com/mithrandie/go-text"
+	txjson "github.com/mithrandie/go-text/json"
+)
+
+const QueryPath = "/query"
+
+const (
+	JSONFormat = "json"
+	CSVFormat  = "csv"
+)
+
+const MaxRequestSize = 10 * 1024 * 1024
+
+// ContentType is the only media type accepted as the body of a request.
+// Browsers cannot send requests of this type to other sites without CORS preflight requests,
+// so web pages cannot post queries to the server.
+const ContentType = "application/json"
+
+// Request is the body of a request posted to the query API.
+//
+// Params is an array of values replacing the positional placeholders, or an object of values
+// replacing the named placeholders in the query.
+type Request struct {
+	Query  string          `json:"query"`
+	Params json.RawMessage `json:"params"`
+	Format string          `json:"format"`
+}
+
+// Result is the result of the statements executed by a request.
+type Result struct {
+	Views        []*query.View
+	AffectedRows int
+}
+
+// Server is an HTTP handler executing the statements posted to the query API.
+//
+// Each request is executed in its own transaction. The changes are committed if all of the statements
+// succeed, and rolled back otherwise.
+// Files are searched for in the repository of the transaction passed to NewServer, and files outside
+// the repository cannot be read or written.
+//
+// The transactions are restricted, so that statements and functions running commands, executing source files,
+// or changing the flags and the environment of the process are rejected.
+type Server struct {
+	tx    *query.Transaction
+	token string
+}
+
+// NewServer returns a server executing requests in transactions forked from tx.
+// The views loaded to be read are cached and shared by the requests until the files are modified.
+//
+// If token is not empty, requests are required to have the token in the Authorization header as a bearer token.
+func NewServer(tx *query.Transaction, token string) *Server {
+	base := tx.Fork(tx.Session)
+	base.Restricted = true
+	base.UseSharedViews(query.NewSharedViewMap())
+	return &Server{
+		tx:    base,
+		token: token,
+	}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != QueryPath {
+		writeError(w, http.StatusNotFound, fmt.Errorf("path %s is not found", r.URL.Path))
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s is not allowed", r.Method))
+		return
+	}
+	if !s.authorized(r) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		writeError(w, http.StatusUnauthorized, errors.New("authorization token is missing or invalid"))
+		return
+	}
+	if mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err != nil || mediaType != ContentType {
+		writeError(w, http.StatusUnsupportedMediaType, fmt.Errorf("content type %q is not supported", r.Header.Get("Content-Type")))
+		return
+	}
+
+	req, err := decodeRequest(http.MaxBytesReader(w, r.Body, MaxRequestSize))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	params, err := parseParams(req.Params)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := s.Execute(r.Context(), req.Query, params)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if _, ok := err.(query.Error); ok {
+			status = http.StatusBadRequest
+		}
+		writeError(w, status, err)
+		return
+	}
+
+	buf := &bytes.Buffer{}
+	switch req.Format {
+	case CSVFormat:
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		err = encodeCSV(buf, result, s.tx.Flags)
+	default:
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		encodeJSON(buf, result)
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	_, _ = w.Write(buf.Bytes())
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	if len(s.token) < 1 {
+		return true
+	}
+
+	const prefix = "Bearer "
+	auth := r.Header.Get("Authorization")
+	if len(auth) < len(prefix) || !strings.EqualFold(auth[:len(prefix)], prefix) {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(auth[len(prefix):]), []byte(s.token)) == 1
+}
+
+// Execute executes the statements in a new transaction, and returns the views selected by the statements.
+func (s *Server) Execute(ctx context.Context, src string, params []query.Parameter) (Result, error) {
+	statements, err := s.parse(src)
+	if err != nil {
+		return Result{}, err
+	}
+	return s.execute(ctx, statements, params)
+}
+
+// parse parses the source. Placeholders of prepared statements can be used in the source.
+func (s *Server) parse(src string) ([]parser.Statement, error) {
+	statements, _, err := parser.Parse(src, "", s.tx.Flags.DatetimeFormat, true)
+	if err != nil {
+		return nil, query.NewSyntaxError(err.(*parser.SyntaxError))
+	}
+	return statements, nil
+}
+
+func (s *Server) execute(ctx context.Context, statements []parser.Statement, params []query.Parameter) (result Result, err error) {
+	session := &query.Session{
+		ScreenFd: s.tx.Session.ScreenFd,
+		Stdin:    s.tx.Session.Stdin,
+		Stdout:   query.NewDiscard(),
+		Stderr:   s.tx.Session.Stderr,
+	}
+	tx := s.tx.Fork(session)
+	tx.AutoCommit = true
+	proc := query.NewProcessor(tx)
+
+	defer func() {
+		if e := proc.AutoRollback(); e != nil {
+			proc.LogError(e.Error())
+		}
+		if e := proc.ReleaseResourcesWithErrors(); e != nil {
+			proc.LogError(e.Error())
+		}
+	}()
+
+	replace, err := query.NewReplaceValuesFromParameters(params)
+	if err != nil {
+		return result, err
+	}
+
+	ctx = query.ContextForPreparedStatement(query.ContextForStoringResults(ctx), replace)
+	if _, err = proc.Execute(ctx, statements); err != nil {
+		return result, err
+	}
+
+	result.Views = tx.SelectedViews
+	result.AffectedRows = tx.AffectedRows
+	return result, nil
+}
+
+func decodeRequest(r io.Reader) (Request, error) {
+	var req Request
+
+	d := json.NewDecoder(r)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&req); err != nil {
+		return req, fmt.Errorf("invalid request: %s", err.Error())
+	}
+	if len(strings.TrimSpace(req.Query)) < 1 {
+		return req, errors.New("invalid request: query is empty")
+	}
+
+	switch req.Format {
+	case "":
+		req.Format = JSONFormat
+	case JSONFormat, CSVFormat:
+	default:
+		return req, fmt.Errorf("invalid request: format %q is not supported", req.Format)
+	}
+	return req, nil
+}
+
+func parseParams(raw json.RawMessage) ([]query.Parameter, error) {
+	if len(raw) < 1 {
+		return nil, nil
+	}
+
+	d := json.NewDecoder(bytes.NewReader(raw))
+	d.UseNumber()
+	var v interface{}
+	if err := d.Decode(&v); err != nil {
+		return nil, fmt.Errorf("invalid request: %s", err.Error())
+	}
+
+	switch p := v.(type) {
+	case nil:
+		return nil, nil
+	case []interface{}:
+		params := make([]query.Parameter, 0, len(p))
+		for _, val := range p {
+			params = append(params, query.Parameter{Value: parameterValue(val)})
+		}
+		return params, nil
+	case map[string]interface{}:
+		params := make([]query.Parameter, 0, len(p))
+		for name, val := range p {
+			params = append(params, query.Parameter{Name: name, Value: parameterValue(val)})
+		}
+		return params, nil
+	default:
+		return nil, errors.New("invalid request: params must be an array or an object")
+	}
+}
+
+// parameterValue converts numbers in a decoded JSON value to integers or floats.
+func parameterValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case json.Number:
+		if i, err := val.Int64(); err == nil {
+			return i
+		}
+		f, _ := val.Float64()
+		return f
+	case []interface{}:
+		for i := range val {
+			val[i] = parameterValue(val[i])
+		}
+		return val
+	case map[string]interface{}:
+		// Objects are passed as JSON strings that can be parsed by JSON functions.
+		b, _ := json.Marshal(val)
+		return string(b)
+	}
+	return v
+}
+
+func encodeJSON(w io.Writer, result Result) {
+	results := make(txjson.Array, 0, len(result.Views))
+	for _, view := range result.Views {
+		columns := make(txjson.Array, 0, view.FieldLen())
+		for _, c := range view.Header.TableColumnNames() {
+			columns = append(columns, txjson.String(c))
+		}
+
+		rows := make(txjson.Array, 0, view.RecordLen())
+		for _, record := range view.RecordSet {
+			row := make(txjson.Array, 0, len(record))
+			for _, cell := range record {
+				row = append(row, csvqjson.ParseValueToStructure(cell.Value()))
+			}
+			rows = append(rows, row)
+		}
+
+		obj := txjson.NewObject(2)
+		obj.Add("columns", columns)
+		obj.Add("rows", rows)
+		results = append(results, obj)
+	}
+
+	obj := txjson.NewObject(2)
+	obj.Add("results", results)
+	obj.Add("affected_rows", txjson.Integer(result.AffectedRows))
+	_, _ = io.WriteString(w, txjson.NewEncoder().Encode(obj))
+}
+
+// encodeCSV writes the selected views in CSV format. Views are separated by an empty line.
+func encodeCSV(w io.Writer, result Result, flags *cmd.Flags) error {
+	for i, view := range result.Views {
+		if 0 < i {
+			if _, err := io.WriteString(w, text.LF.Value()); err != nil {
+				return err
+			}
+		}
+
+		fileInfo := &query.FileInfo{
+			Format:    cmd.CSV,
+			Delimiter: ',',
+			Encoding:  text.UTF8,
+			LineBreak: text.LF,
+		}
+		if _, err := query.EncodeView(w, view, fileInfo, flags); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, text.LF.Value()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	obj := txjson.NewObject(2)
+	if apperr, ok := err.(query.Error); ok {
+		obj.Add("error", txjson.String(apperr.Error()))
+		obj.Add("code", txjson.Integer(apperr.Number()))
+	} else {
+		obj.Add("error", txjson.String(err.Error()))
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = io.WriteString(w, txjson.NewEncoder().Encode(obj))
+}
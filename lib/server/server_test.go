@@ -0,0 +1,250 @@
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mithrandie/csvq/lib/file"
+	"github.com/mithrandie/csvq/lib/query"
+)
+
+var serverTests = []struct {
+	Name       string
+	Method     string
+	Path       string
+	Header     map[string]string
+	Body       string
+	Status     int
+	Response   string
+	File       string
+	ExpectFile string
+	CreateFile string
+}{
+	{
+		Name:     "Select Query",
+		Body:     `{"query": "SELECT * FROM users WHERE id = ?", "params": [2]}`,
+		Status:   http.StatusOK,
+		Response: `{"results":[{"columns":["id","name"],"rows":[["2","b"]]}],"affected_rows":0}`,
+	},
+	{
+		Name:     "Named Parameters",
+		Body:     `{"query": "SELECT :name AS name, :ids AS ids", "params": {"name": "a", "ids": [1, 2.5]}}`,
+		Status:   http.StatusOK,
+		Response: `{"results":[{"columns":["name","ids"],"rows":[["a",[1,2.5]]]}],"affected_rows":0}`,
+	},
+	{
+		Name:       "Insert Query",
+		Body:       `{"query": "INSERT INTO users VALUES (?, ?)", "params": [3, "c"]}`,
+		Status:     http.StatusOK,
+		Response:   `{"results":[],"affected_rows":1}`,
+		ExpectFile: "id,name\n1,a\n2,b\n3,c",
+	},
+	{
+		Name:       "Rollback on Error",
+		Body:       `{"query": "INSERT INTO users VALUES (3, 'c'); SELECT notexist FROM users;"}`,
+		Status:     http.StatusBadRequest,
+		Response:   `{"error":"[L:1 C:43] field notexist does not exist","code":16002}`,
+		ExpectFile: "id,name\n1,a\n2,b",
+	},
+	{
+		Name:     "CSV Format",
+		Body:     `{"query": "SELECT * FROM users; SELECT 1 AS n;", "format": "csv"}`,
+		Status:   http.StatusOK,
+		Response: "id,name\n1,a\n2,b\n\nn\n1\n",
+	},
+	{
+		Name:     "Syntax Error",
+		Body:     `{"query": "SELECT FROM"}`,
+		Status:   http.StatusBadRequest,
+		Response: `{"error":"[L:1 C:8] syntax error: unexpected token \"FROM\"","code":8000}`,
+	},
+	{
+		Name:     "Invalid Format",
+		Body:     `{"query": "SELECT 1", "format": "xml"}`,
+		Status:   http.StatusBadRequest,
+		Response: `{"error":"invalid request: format \"xml\" is not supported"}`,
+	},
+	{
+		Name:     "Invalid Parameters",
+		Body:     `{"query": "SELECT ?", "params": 1}`,
+		Status:   http.StatusBadRequest,
+		Response: `{"error":"invalid request: params must be an array or an object"}`,
+	},
+	{
+		Name:     "Empty Query",
+		Body:     `{"query": " "}`,
+		Status:   http.StatusBadRequest,
+		Response: `{"error":"invalid request: query is empty"}`,
+	},
+	{
+		Name:     "Restricted Statement",
+		Body:     `{"query": "SET @@FORMAT = JSON"}`,
+		Status:   http.StatusBadRequest,
+		Response: `{"error":"[L:1 C:1] SET statement for flags is not allowed in restricted mode","code":16117}`,
+	},
+	{
+		Name:     "Restricted Function Declaration",
+		Body:     `{"query": "DECLARE fn FUNCTION () LANGUAGE EXTERNAL AS 'sh';"}`,
+		Status:   http.StatusBadRequest,
+		Response: `{"error":"[L:1 C:9] function written in language EXTERNAL is not allowed in restricted mode","code":16117}`,
+	},
+	{
+		Name:     "Restricted Source Statement",
+		Body:     `{"query": "SOURCE '/etc/passwd'"}`,
+		Status:   http.StatusBadRequest,
+		Response: `{"error":"[L:1 C:1] SOURCE statement is not allowed in restricted mode","code":16117}`,
+	},
+	{
+		Name:     "Restricted Call Function",
+		Body:     `{"query": "SELECT CALL('echo', 'a')"}`,
+		Status:   http.StatusBadRequest,
+		Response: `{"error":"[L:1 C:8] function CALL is not allowed in restricted mode","code":16117}`,
+	},
+	{
+		Name:     "Absolute File Path",
+		Body:     `{"query": "SELECT * FROM ` + "`/etc/hostname`" + `"}`,
+		Status:   http.StatusBadRequest,
+		Response: `{"error":"[L:1 C:15] file path \/etc\/hostname outside the repository is not allowed in restricted mode","code":16117}`,
+	},
+	{
+		Name:     "File Path Outside Repository",
+		Body:     `{"query": "SELECT * FROM ` + "`sub/../../users.csv`" + `"}`,
+		Status:   http.StatusBadRequest,
+		Response: `{"error":"[L:1 C:15] file path sub\/..\/..\/users.csv outside the repository is not allowed in restricted mode","code":16117}`,
+	},
+	{
+		Name:     "Create Table Outside Repository",
+		Body:     `{"query": "CREATE TABLE ` + "`../newfile.csv`" + ` (id, name)"}`,
+		Status:   http.StatusBadRequest,
+		Response: `{"error":"[L:1 C:14] file path ..\/newfile.csv outside the repository is not allowed in restricted mode","code":16117}`,
+	},
+	{
+		Name:     "Create Table With Absolute Path",
+		Body:     `{"query": "CREATE TABLE ` + "`/tmp/newfile.csv`" + ` (id, name)"}`,
+		Status:   http.StatusBadRequest,
+		Response: `{"error":"[L:1 C:14] file path \/tmp\/newfile.csv outside the repository is not allowed in restricted mode","code":16117}`,
+	},
+	{
+		Name:       "Create Table In Repository",
+		Body:       `{"query": "CREATE TABLE ` + "`sub/../newfile.csv`" + ` (id, name)"}`,
+		Status:     http.StatusOK,
+		Response:   `{"results":[],"affected_rows":0}`,
+		CreateFile: "newfile.csv",
+	},
+	{
+		Name:     "Content Type With Parameters",
+		Header:   map[string]string{"Content-Type": "application/json; charset=utf-8"},
+		Body:     `{"query": "SELECT 1 AS n"}`,
+		Status:   http.StatusOK,
+		Response: `{"results":[{"columns":["n"],"rows":[[1]]}],"affected_rows":0}`,
+	},
+	{
+		Name:     "Unsupported Content Type",
+		Header:   map[string]string{"Content-Type": "text/plain"},
+		Body:     `{"query": "SELECT 1"}`,
+		Status:   http.StatusUnsupportedMediaType,
+		Response: `{"error":"content type \"text\/plain\" is not supported"}`,
+	},
+	{
+		Name:     "Missing Content Type",
+		Header:   map[string]string{"Content-Type": ""},
+		Body:     `{"query": "SELECT 1"}`,
+		Status:   http.StatusUnsupportedMediaType,
+		Response: `{"error":"content type \"\" is not supported"}`,
+	},
+	{
+		Name:     "Invalid Token",
+		Header:   map[string]string{"Authorization": "Bearer invalid"},
+		Body:     `{"query": "SELECT 1"}`,
+		Status:   http.StatusUnauthorized,
+		Response: `{"error":"authorization token is missing or invalid"}`,
+	},
+	{
+		Name:     "Missing Token",
+		Header:   map[string]string{"Authorization": ""},
+		Body:     `{"query": "SELECT 1"}`,
+		Status:   http.StatusUnauthorized,
+		Response: `{"error":"authorization token is missing or invalid"}`,
+	},
+	{
+		Name:     "Method Not Allowed",
+		Method:   http.MethodGet,
+		Status:   http.StatusMethodNotAllowed,
+		Response: `{"error":"method GET is not allowed"}`,
+	},
+	{
+		Name:     "Path Not Found",
+		Path:     "/notexist",
+		Body:     `{"query": "SELECT 1"}`,
+		Status:   http.StatusNotFound,
+		Response: `{"error":"path \/notexist is not found"}`,
+	},
+}
+
+func TestServer_ServeHTTP(t *testing.T) {
+	dir, err := ioutil.TempDir("", "csvq_server_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+	fpath := filepath.Join(dir, "users.csv")
+
+	tx, err := query.NewTransaction(context.Background(), file.DefaultWaitTimeout, file.DefaultRetryDelay, query.NewSession())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.Flags.Repository = dir
+	tx.Flags.SetQuiet(true)
+	tx.Session.Stderr = query.NewDiscard()
+	srv := NewServer(tx, "secret")
+
+	for _, v := range serverTests {
+		if err := ioutil.WriteFile(fpath, []byte("id,name\n1,a\n2,b"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		method := v.Method
+		if len(method) < 1 {
+			method = http.MethodPost
+		}
+		path := v.Path
+		if len(path) < 1 {
+			path = QueryPath
+		}
+
+		r := httptest.NewRequest(method, path, strings.NewReader(v.Body))
+		r.Header.Set("Content-Type", ContentType)
+		r.Header.Set("Authorization", "Bearer secret")
+		for k, val := range v.Header {
+			r.Header.Set(k, val)
+		}
+
+		w := httptest.NewRecorder()
+		srv.ServeHTTP(w, r)
+
+		if w.Code != v.Status {
+			t.Errorf("%s: status = %d, want %d", v.Name, w.Code, v.Status)
+		}
+		if w.Body.String() != v.Response {
+			t.Errorf("%s: response = %q, want %q", v.Name, w.Body.String(), v.Response)
+		}
+		if 0 < len(v.ExpectFile) {
+			if b, _ := ioutil.ReadFile(fpath); string(b) != v.ExpectFile {
+				t.Errorf("%s: file = %q, want %q", v.Name, string(b), v.ExpectFile)
+			}
+		}
+		if 0 < len(v.CreateFile) {
+			if _, err := os.Stat(filepath.Join(dir, v.CreateFile)); err != nil {
+				t.Errorf("%s: file %s is not created in the repository", v.Name, v.CreateFile)
+			}
+		}
+	}
+}
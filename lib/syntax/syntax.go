@@ -23,6 +23,7 @@ var CsvqSyntax = []Expression{
 				Name: "select_query",
 				Group: []Grammar{
 					{Link("select_entity"), Option{Link("order_by_clause")}, Option{Link("limit_clause")}, Option{Link("offset_clause")}},
+					{Link("select_entity"), Option{Link("order_by_clause")}, Option{Link("offset_clause")}, Link("fetch_clause")},
 				},
 			},
 			{
@@ -30,6 +31,7 @@ var CsvqSyntax = []Expression{
 				Group: []Grammar{
 					{Link("select_clause"), Option{Link("from_clause")}, Option{Link("where_clause")}, Option{Link("group_by_clause")}, Option{Link("having_clause")}},
 					{Link("select_set_entity"), Link("Set Operators"), Option{Keyword("ALL")}, Link("select_set_entity")},
+					{Keyword("VALUES"), ContinuousOption{Link("row_value")}},
 				},
 			},
 			{
@@ -53,7 +55,13 @@ var CsvqSyntax = []Expression{
 					{
 						Name: "common_table_expression",
 						Group: []Grammar{
-							{Option{Keyword("RECURSIVE")}, Identifier("table_name"), Option{Parentheses{ContinuousOption{Identifier("column_name")}}}, Keyword("AS"), Parentheses{Link("select_query")}},
+							{Option{Keyword("RECURSIVE")}, Identifier("table_name"), Option{Parentheses{ContinuousOption{Identifier("column_name")}}}, Keyword("AS"), Option{Option{Keyword("NOT")}, Keyword("MATERIALIZED")}, Parentheses{Link("select_query")}, Option{Link("cycle_clause")}},
+						},
+					},
+					{
+						Name: "cycle_clause",
+						Group: []Grammar{
+							{Keyword("CYCLE"), ContinuousOption{Identifier("column_name")}, Keyword("RESTRICT")},
 						},
 					},
 				},
@@ -64,7 +72,7 @@ var CsvqSyntax = []Expression{
 					{
 						Name: "select_clause",
 						Group: []Grammar{
-							{Keyword("SELECT"), Option{Keyword("DISTINCT")}, ContinuousOption{Link("field")}},
+							{Keyword("SELECT"), Option{Token("/*+"), Identifier("hint"), Option{Identifier("hint"), Token("...")}, Token("*/")}, Option{Keyword("DISTINCT"), Option{Keyword("ON"), Parentheses{ContinuousOption{Link("value")}}}}, ContinuousOption{Link("field")}},
 						},
 					},
 					{
@@ -88,10 +96,15 @@ var CsvqSyntax = []Expression{
 					{
 						Name: "table",
 						Group: []Grammar{
-							{Link("table_entity")},
-							{Link("table_entity"), Identifier("alias")},
-							{Link("table_entity"), Keyword("AS"), Identifier("alias")},
+							{Link("table_entity"), Option{Link("table_sample")}},
+							{Link("table_entity"), Identifier("alias"), Option{Link("table_sample")}},
+							{Link("table_entity"), Keyword("AS"), Identifier("alias"), Option{Link("table_sample")}},
+							{Link("table_entity"), Identifier("alias"), Parentheses{ContinuousOption{Identifier("column_alias")}}, Option{Link("table_sample")}},
+							{Link("table_entity"), Keyword("AS"), Identifier("alias"), Parentheses{ContinuousOption{Identifier("column_alias")}}, Option{Link("table_sample")}},
 							{Link("join")},
+							{Link("pivot_table")},
+							{Link("pivot_table"), Identifier("alias")},
+							{Link("pivot_table"), Keyword("AS"), Identifier("alias")},
 							{Keyword("DUAL")},
 							{Parentheses{Link("table")}},
 						},
@@ -102,6 +115,7 @@ var CsvqSyntax = []Expression{
 							{Identifier("table_name")},
 							{Link("table_object")},
 							{Link("json_inline_table")},
+							{Link("unnest_table")},
 							{Parentheses{Link("select_query")}},
 							{Keyword("STDIN")},
 						},
@@ -140,6 +154,39 @@ var CsvqSyntax = []Expression{
 							{Function{Name: "JSON_TABLE", Args: []Element{String("json_query"), String("json_data")}}},
 						},
 					},
+					{
+						Name: "unnest_table",
+						Group: []Grammar{
+							{Function{Name: "UNNEST", Args: []Element{Identifier("array")}}, Option{Keyword("WITH"), Keyword("ORDINALITY")}},
+						},
+						Description: Description{
+							Template: "" +
+								"Expands %s into a table that has the column \"value\", and the column \"ordinality\" numbering the elements from 1 if %s is specified. " +
+								"If an unnest table follows other tables, %s is evaluated for each record of the preceding tables.",
+							Values: []Element{Identifier("array"), Keyword("WITH ORDINALITY"), Identifier("array")},
+						},
+					},
+					{
+						Name: "pivot_table",
+						Group: []Grammar{
+							{Link("table"), Keyword("PIVOT"), Parentheses{Link("aggregate_function"), Keyword("FOR"), Identifier("column_name"), Keyword("IN"), Parentheses{ContinuousOption{Link("pivot_value")}}}},
+							{Link("table"), Keyword("UNPIVOT"), Parentheses{Identifier("value_column"), Keyword("FOR"), Identifier("name_column"), Keyword("IN"), Parentheses{ContinuousOption{Identifier("column_name")}}}},
+						},
+					},
+					{
+						Name: "pivot_value",
+						Group: []Grammar{
+							{Link("value")},
+							{Link("value"), Keyword("AS"), Identifier("alias")},
+						},
+					},
+					{
+						Name: "table_sample",
+						Group: []Grammar{
+							{Keyword("TABLESAMPLE"), Parentheses{Float("percentage"), Keyword("PERCENT")}, Option{Keyword("REPEATABLE"), Parentheses{Integer("seed")}}},
+							{Keyword("TABLESAMPLE"), Parentheses{Integer("number_of_records"), Keyword("ROWS")}, Option{Keyword("REPEATABLE"), Parentheses{Integer("seed")}}},
+						},
+					},
 				},
 			},
 			{
@@ -165,7 +212,23 @@ var CsvqSyntax = []Expression{
 					{
 						Name: "group_by_clause",
 						Group: []Grammar{
-							{Keyword("GROUP"), Keyword("BY"), ContinuousOption{Link("field")}},
+							{Keyword("GROUP"), Keyword("BY"), ContinuousOption{Link("group_item")}},
+						},
+					},
+					{
+						Name: "group_item",
+						Group: []Grammar{
+							{Link("field")},
+							{Keyword("ROLLUP"), Parentheses{ContinuousOption{Link("field")}}},
+							{Keyword("CUBE"), Parentheses{ContinuousOption{Link("field")}}},
+							{Keyword("GROUPING"), Keyword("SETS"), Parentheses{ContinuousOption{Link("grouping_set")}}},
+						},
+					},
+					{
+						Name: "grouping_set",
+						Group: []Grammar{
+							{Link("field")},
+							{Parentheses{Option{ContinuousOption{Link("field")}}}},
 						},
 					},
 				},
@@ -193,7 +256,7 @@ var CsvqSyntax = []Expression{
 					{
 						Name: "order_item",
 						Group: []Grammar{
-							{Link("field"), Option{Link("order_direction")}, Option{Link("null_position")}},
+							{Link("field"), Option{Keyword("COLLATE"), String("collation")}, Option{Link("order_direction")}, Option{Link("null_position")}},
 						},
 						Description: Description{
 							Template: "If %s keyword is specified in the %s, you can use only enumerated fields in the %s as %s.",
@@ -240,7 +303,23 @@ var CsvqSyntax = []Expression{
 					{
 						Name: "offset_clause",
 						Group: []Grammar{
-							{Keyword("OFFSET"), Integer("row_number")},
+							{Keyword("OFFSET"), Integer("row_number"), Option{AnyOne{Keyword("ROW"), Keyword("ROWS")}}},
+						},
+					},
+				},
+			},
+			{
+				Label: "FETCH Clause",
+				Grammar: []Definition{
+					{
+						Name: "fetch_clause",
+						Group: []Grammar{
+							{Keyword("FETCH"), AnyOne{Keyword("FIRST"), Keyword("NEXT")}, Option{Integer("number_of_records")}, AnyOne{Keyword("ROW"), Keyword("ROWS")}, AnyOne{Keyword("ONLY"), PlainGroup{Keyword("WITH"), Keyword("TIES")}}},
+							{Keyword("FETCH"), AnyOne{Keyword("FIRST"), Keyword("NEXT")}, Float("percent"), Keyword("PERCENT"), AnyOne{Keyword("ROW"), Keyword("ROWS")}, AnyOne{Keyword("ONLY"), PlainGroup{Keyword("WITH"), Keyword("TIES")}}},
+						},
+						Description: Description{
+							Template: "If %s is omitted, then 1 is set.",
+							Values:   []Element{Integer("number_of_records")},
 						},
 					},
 				},
@@ -259,8 +338,32 @@ var CsvqSyntax = []Expression{
 			{
 				Name: "insert_query",
 				Group: []Grammar{
-					{Keyword("INSERT"), Keyword("INTO"), Identifier("table_name"), Option{Parentheses{ContinuousOption{Identifier("column_name")}}}, Keyword("VALUES"), ContinuousOption{Link("row_value")}},
-					{Keyword("INSERT"), Keyword("INTO"), Identifier("table_name"), Option{Parentheses{ContinuousOption{Identifier("column_name")}}}, Link("select_query")},
+					{Keyword("INSERT"), Keyword("INTO"), Identifier("table_name"), Option{Parentheses{ContinuousOption{Identifier("column_name")}}}, Keyword("VALUES"), ContinuousOption{Link("row_value")}, Option{Link("returning_clause")}},
+					{Keyword("INSERT"), Keyword("INTO"), Identifier("table_name"), Option{Parentheses{ContinuousOption{Identifier("column_name")}}}, Link("select_query"), Option{Link("returning_clause")}},
+				},
+			},
+			{
+				Name: "returning_clause",
+				Group: []Grammar{
+					{Keyword("RETURNING"), ContinuousOption{Link("field")}},
+				},
+			},
+		},
+	},
+	{
+		Label: "REPLACE Statement",
+		Grammar: []Definition{
+			{
+				Name: "replace_statement",
+				Group: []Grammar{
+					{Option{Link("with_clause")}, Link("replace_query")},
+				},
+			},
+			{
+				Name: "replace_query",
+				Group: []Grammar{
+					{Keyword("REPLACE"), Keyword("INTO"), Identifier("table_name"), Option{Parentheses{ContinuousOption{Identifier("column_name")}}}, Keyword("USING"), Parentheses{ContinuousOption{Identifier("key_column_name")}}, Keyword("VALUES"), ContinuousOption{Link("row_value")}},
+					{Keyword("REPLACE"), Keyword("INTO"), Identifier("table_name"), Option{Parentheses{ContinuousOption{Identifier("column_name")}}}, Keyword("USING"), Parentheses{ContinuousOption{Identifier("key_column_name")}}, Link("select_query")},
 				},
 			},
 		},
@@ -277,8 +380,8 @@ var CsvqSyntax = []Expression{
 			{
 				Name: "update_query",
 				Group: []Grammar{
-					{Keyword("UPDATE"), Identifier("table_name"), Keyword("SET"), ContinuousOption{Link("set_value")}, Option{Link("where_clause")}},
-					{Keyword("UPDATE"), ContinuousOption{Identifier("table_alias")}, Keyword("SET"), ContinuousOption{Link("set_value")}, Link("from_clause"), Option{Link("where_clause")}},
+					{Keyword("UPDATE"), Identifier("table_name"), Keyword("SET"), ContinuousOption{Link("set_value")}, Option{Link("where_clause")}, Option{Link("returning_clause")}},
+					{Keyword("UPDATE"), ContinuousOption{Identifier("table_alias")}, Keyword("SET"), ContinuousOption{Link("set_value")}, Link("from_clause"), Option{Link("where_clause")}, Option{Link("returning_clause")}},
 				},
 			},
 			{
@@ -301,8 +404,34 @@ var CsvqSyntax = []Expression{
 			{
 				Name: "delete_query",
 				Group: []Grammar{
-					{Keyword("DELETE"), Keyword("FROM"), Identifier("table_name"), Option{Link("where_clause")}},
-					{Keyword("DELETE"), ContinuousOption{Identifier("table_alias")}, Link("from_clause"), Option{Link("where_clause")}},
+					{Keyword("DELETE"), Keyword("FROM"), Identifier("table_name"), Option{Link("where_clause")}, Option{Link("returning_clause")}},
+					{Keyword("DELETE"), ContinuousOption{Identifier("table_alias")}, Link("from_clause"), Option{Link("where_clause")}, Option{Link("returning_clause")}},
+					{Keyword("DELETE"), Keyword("FROM"), ContinuousOption{Identifier("table_name")}, Keyword("USING"), ContinuousOption{Link("table")}, Option{Link("where_clause")}, Option{Link("returning_clause")}},
+				},
+			},
+		},
+	},
+	{
+		Label: "MERGE Statement",
+		Grammar: []Definition{
+			{
+				Name: "merge_statement",
+				Group: []Grammar{
+					{Option{Link("with_clause")}, Link("merge_query")},
+				},
+			},
+			{
+				Name: "merge_query",
+				Group: []Grammar{
+					{Keyword("MERGE"), Keyword("INTO"), Identifier("table_name"), Option{Option{Keyword("AS")}, Identifier("alias")}, Keyword("USING"), Link("table"), Keyword("ON"), Link("condition"), Link("merge_when"), Option{Link("merge_when"), Token("...")}},
+				},
+			},
+			{
+				Name: "merge_when",
+				Group: []Grammar{
+					{Keyword("WHEN"), Keyword("MATCHED"), Option{Keyword("AND"), Link("condition")}, Keyword("THEN"), Keyword("UPDATE"), Keyword("SET"), ContinuousOption{Link("set_value")}},
+					{Keyword("WHEN"), Keyword("MATCHED"), Option{Keyword("AND"), Link("condition")}, Keyword("THEN"), Keyword("DELETE")},
+					{Keyword("WHEN"), Keyword("NOT"), Keyword("MATCHED"), Option{Keyword("AND"), Link("condition")}, Keyword("THEN"), Keyword("INSERT"), Option{Parentheses{ContinuousOption{Identifier("column_name")}}}, Keyword("VALUES"), Link("row_value")},
 				},
 			},
 		},
@@ -313,8 +442,40 @@ var CsvqSyntax = []Expression{
 			{
 				Name: "create_table_statement",
 				Group: []Grammar{
-					{Keyword("CREATE"), Keyword("TABLE"), Identifier("file_path"), Parentheses{ContinuousOption{Identifier("column_name")}}},
-					{Keyword("CREATE"), Keyword("TABLE"), Identifier("file_path"), Option{Parentheses{ContinuousOption{Identifier("column_name")}}}, Option{Keyword("AS")}, Link("select_query")},
+					{Keyword("CREATE"), Keyword("TABLE"), Identifier("file_path"), Parentheses{ContinuousOption{Link("column_definition")}}},
+					{Keyword("CREATE"), Keyword("TABLE"), Identifier("file_path"), Option{Parentheses{ContinuousOption{Link("column_definition")}}}, Option{Keyword("AS")}, Link("select_query")},
+				},
+			},
+			{
+				Name: "column_definition",
+				Group: []Grammar{
+					{Identifier("column_name"), Option{Link("column_constraint"), Token("...")}},
+				},
+			},
+			{
+				Name: "column_constraint",
+				Group: []Grammar{
+					{Keyword("NOT"), Keyword("NULL")},
+					{Keyword("UNIQUE")},
+					{Keyword("CHECK"), Parentheses{Link("condition")}},
+				},
+				Description: Description{
+					Template: "Constraints are validated when records in the table are inserted or updated in the current session.",
+				},
+			},
+		},
+	},
+	{
+		Label: "CREATE VIEW Statement",
+		Grammar: []Definition{
+			{
+				Name: "create_view_statement",
+				Group: []Grammar{
+					{Keyword("CREATE"), Keyword("VIEW"), Identifier("view_name"), Option{Parentheses{ContinuousOption{Identifier("column_name")}}}, Keyword("AS"), Link("select_query")},
+				},
+				Description: Description{
+					Template: "The view definition is saved to %s in the repository, and can be referred to as a table in later sessions.",
+					Values:   []Element{Identifier("view_name.sql")},
 				},
 			},
 		},
@@ -371,7 +532,7 @@ var CsvqSyntax = []Expression{
 			{
 				Name: "table_attribute",
 				Group: []Grammar{
-					{AnyOne{Keyword("FORMAT"), Keyword("DELIMITER"), Keyword("DELIMITER_POSITIONS"), Keyword("JSON_ESCAPE"), Keyword("ENCODING"), Keyword("LINE_BREAK"), Keyword("HEADER"), Keyword("ENCLOSE_ALL"), Keyword("PRETTY_PRINT")}},
+					{AnyOne{Keyword("FORMAT"), Keyword("DELIMITER"), Keyword("DELIMITER_POSITIONS"), Keyword("JSON_ESCAPE"), Keyword("ENCODING"), Keyword("LINE_BREAK"), Keyword("HEADER"), Keyword("ENCLOSE_ALL"), Keyword("PRETTY_PRINT"), Keyword("ENCRYPTED")}},
 				},
 			},
 		},
@@ -383,6 +544,21 @@ var CsvqSyntax = []Expression{
 				Name: "prepare_statement",
 				Group: []Grammar{
 					{Keyword("PREPARE"), Identifier("statement_name"), Keyword("FROM"), String("statement")},
+					{Keyword("PREPARE"), Identifier("statement_name"), Parentheses{ContinuousOption{Link("statement_parameter")}}, Keyword("FROM"), String("statement")},
+				},
+			},
+			{
+				Name: "statement_parameter",
+				Group: []Grammar{
+					{Identifier("parameter_type")},
+					{Identifier("placeholder_name"), Identifier("parameter_type")},
+				},
+				Description: Description{
+					Template: "" +
+						"%s is one of STRING, INTEGER, FLOAT, BOOLEAN, TERNARY, DATETIME and ARRAY. " +
+						"Parameters without %s declare the types of positional placeholders in order. " +
+						"Replace values are converted to the declared types, and an error is returned if the conversion fails.",
+					Values: []Element{Identifier("parameter_type"), Identifier("placeholder_name")},
 				},
 			},
 			{
@@ -508,6 +684,16 @@ var CsvqSyntax = []Expression{
 					{Keyword("DECLARE"), Identifier("view_name"), Keyword("VIEW"), Option{Parentheses{ContinuousOption{Identifier("column_name")}}}, Keyword("AS"), Link("select_query")},
 				},
 			},
+			{
+				Name: "create_temporary_table_statement",
+				Group: []Grammar{
+					{Keyword("CREATE"), Keyword("TEMPORARY"), Keyword("TABLE"), Identifier("view_name"), Parentheses{ContinuousOption{Identifier("column_name")}}, Option{Keyword("PRIMARY"), Keyword("KEY"), Parentheses{ContinuousOption{Identifier("key_column")}}}},
+					{Keyword("CREATE"), Keyword("TEMPORARY"), Keyword("TABLE"), Identifier("view_name"), Option{Parentheses{ContinuousOption{Identifier("column_name")}}}, Option{Keyword("PRIMARY"), Keyword("KEY"), Parentheses{ContinuousOption{Identifier("key_column")}}}, Keyword("AS"), Link("select_query")},
+				},
+				Description: Description{
+					Template: "Values in the primary key columns must be unique and cannot be null. The primary key is used as an index of the table.",
+				},
+			},
 			{
 				Name: "dispose_view_statement",
 				Group: []Grammar{
@@ -516,13 +702,73 @@ var CsvqSyntax = []Expression{
 			},
 		},
 	},
+	{
+		Label: "Table Indices",
+		Grammar: []Definition{
+			{
+				Name: "declare_index_statement",
+				Group: []Grammar{
+					{Keyword("DECLARE"), Keyword("INDEX"), Identifier("index_name"), Keyword("ON"), Identifier("table_name"), Parentheses{ContinuousOption{Identifier("column_name")}}},
+				},
+			},
+			{
+				Name: "dispose_index_statement",
+				Group: []Grammar{
+					{Keyword("DISPOSE"), Keyword("INDEX"), Identifier("index_name")},
+				},
+			},
+		},
+	},
+	{
+		Label: "Sequences",
+		Grammar: []Definition{
+			{
+				Name: "declare_sequence_statement",
+				Group: []Grammar{
+					{Keyword("DECLARE"), Keyword("SEQUENCE"), Identifier("sequence_name"), Option{Keyword("START"), Integer("start")}, Option{Keyword("INCREMENT"), Integer("increment")}},
+				},
+				Description: Description{
+					Template: "Declares a sequence that generates integers from %s by %s. The default values of %s and %s are 1.",
+					Values:   []Element{Integer("start"), Integer("increment"), Integer("start"), Integer("increment")},
+				},
+			},
+			{
+				Name: "dispose_sequence_statement",
+				Group: []Grammar{
+					{Keyword("DISPOSE"), Keyword("SEQUENCE"), Identifier("sequence_name")},
+				},
+			},
+		},
+	},
 	{
 		Label: "User Defined Functions",
 		Grammar: []Definition{
 			{
 				Name: "declare_scala_function_statement",
 				Group: []Grammar{
-					{Keyword("DECLARE"), Identifier("function_name"), Keyword("FUNCTION"), Parentheses{Link("function_parameters")}, Keyword("AS"), Keyword("BEGIN"), Token("statements"), Keyword("END")},
+					{Keyword("DECLARE"), Identifier("function_name"), Keyword("FUNCTION"), Parentheses{Link("function_parameters")}, Option{Keyword("DETERMINISTIC")}, Keyword("AS"), Keyword("BEGIN"), Token("statements"), Keyword("END")},
+				},
+			},
+			{
+				Name: "declare_lua_function_statement",
+				Group: []Grammar{
+					{Keyword("DECLARE"), Identifier("function_name"), Keyword("FUNCTION"), Parentheses{Link("function_parameters")}, Option{Keyword("DETERMINISTIC")}, Keyword("LANGUAGE"), Keyword("LUA"), Keyword("AS"), String("source")},
+				},
+				Description: Description{
+					Template: "Parameters are passed to the Lua code as local variables without the leading %s.",
+					Values:   []Element{Token("@")},
+				},
+			},
+			{
+				Name: "declare_external_function_statement",
+				Group: []Grammar{
+					{Keyword("DECLARE"), Identifier("function_name"), Keyword("FUNCTION"), Parentheses{Link("function_parameters")}, Option{Keyword("DETERMINISTIC")}, Keyword("LANGUAGE"), Keyword("EXTERNAL"), Keyword("AS"), String("command")},
+				},
+				Description: Description{
+					Template: "" +
+						"The command is started at the first call, and exchanges lines of JSON objects through the standard input and output. " +
+						"Arguments of %s functions are sent in batches.",
+					Values: []Element{Keyword("DETERMINISTIC")},
 				},
 			},
 			{
@@ -651,12 +897,27 @@ var CsvqSyntax = []Expression{
 				Name: "commit_statement",
 				Group: []Grammar{
 					{Keyword("COMMIT")},
+					{Keyword("COMMIT"), Keyword("TABLE"), Identifier("table_name")},
 				},
 			},
 			{
 				Name: "rollback_statement",
 				Group: []Grammar{
 					{Keyword("ROLLBACK")},
+					{Keyword("ROLLBACK"), Keyword("TABLE"), Identifier("table_name")},
+				},
+			},
+			{
+				Name: "lock_table_statement",
+				Group: []Grammar{
+					{Keyword("LOCK"), Keyword("TABLE"), ContinuousOption{Identifier("table_name")}, Keyword("IN"), Keyword("EXCLUSIVE"), Keyword("MODE")},
+				},
+			},
+			{
+				Name: "unlock_statement",
+				Group: []Grammar{
+					{Keyword("UNLOCK")},
+					{Keyword("UNLOCK"), Keyword("TABLE"), ContinuousOption{Identifier("table_name")}},
 				},
 			},
 		},
@@ -729,6 +990,18 @@ var CsvqSyntax = []Expression{
 					Template: "Show fields in a table or a view.",
 				},
 			},
+			{
+				Name: "explain",
+				Group: []Grammar{
+					{Keyword("EXPLAIN"), Option{Keyword("ANALYZE")}, Link("select_query")},
+				},
+				Description: Description{
+					Template: "Show the execution plan of a select query with the estimated numbers of records. " +
+						"The tables in the FROM clauses are loaded, but the query is not executed. " +
+						"With the ANALYZE option, the query is executed, and the actual numbers of records and the elapsed times of the operations, " +
+						"and the times spent to load the files are shown.",
+				},
+			},
 			{
 				Name: "chdir",
 				Group: []Grammar{
@@ -960,6 +1233,8 @@ var CsvqSyntax = []Expression{
 				"%s  <type::%s>\n" +
 				"  > Datetime Format to parse strings.\n" +
 				"%s  <type::%s>\n" +
+				"  > Compare strings case-sensitively.\n" +
+				"%s  <type::%s>\n" +
 				"  > Limit of the waiting time in seconds to wait for locked files to be released.\n" +
 				"%s  <type::%s>\n" +
 				"  > Default format to load files.\n" +
@@ -1006,13 +1281,18 @@ var CsvqSyntax = []Expression{
 				"%s  <type::%s>\n" +
 				"  > Hint for the number of cpu cores to be used.\n" +
 				"%s  <type::%s>\n" +
+				"  > Seed for pseudo-random number generation. A negative number means that no seed is set.\n" +
+				"%s  <type::%s>\n" +
 				"  > Show execution time.\n" +
 				"",
 			Values: []Element{
 				Flag("@@REPOSITORY"), String("string"),
 				Flag("@@TIMEZONE"), String("string"), Link("Timezone"),
 				Flag("@@DATETIME_FORMAT"), String("string"),
+				Flag("@@CASE_SENSITIVE"), Boolean("boolean"),
 				Flag("@@WAIT_TIMEOUT"), Float("float"),
+				Flag("@@LOCK_RETRY_LIMIT"), Integer("integer"),
+				Flag("@@LOCK_BACKOFF"), String("string"),
 				Flag("@@IMPORT_FORMAT"), String("string"),
 				Flag("@@DELIMITER"), String("string"),
 				Flag("@@DELIMITER_POSITIONS"), String("string"),
@@ -1035,6 +1315,23 @@ var CsvqSyntax = []Expression{
 				Flag("@@COLOR"), Boolean("boolean"),
 				Flag("@@QUIET"), Boolean("boolean"),
 				Flag("@@CPU"), Integer("integer"),
+				Flag("@@RANDOM_SEED"), Integer("integer"),
+				Flag("@@SORT_BUFFER_SIZE"), Integer("integer"),
+				Flag("@@MAX_MEMORY"), Integer("integer"),
+				Flag("@@MMAP"), Boolean("boolean"),
+				Flag("@@COLUMNAR"), Boolean("boolean"),
+				Flag("@@STATISTICS_CACHE"), Boolean("boolean"),
+				Flag("@@RESULT_CACHE"), Boolean("boolean"),
+				Flag("@@PROGRESS"), Boolean("boolean"),
+				Flag("@@BACKUP_EXTENSION"), String("string"),
+				Flag("@@BACKUP_DIR"), String("string"),
+				Flag("@@AUDIT_LOG"), String("string"),
+				Flag("@@ENCRYPTION_KEY_FILE"), String("string"),
+				Flag("@@ON_COMMIT"), String("string"),
+				Flag("@@COORDINATOR_TOKEN"), String("string"),
+				Flag("@@PARTICIPANTS"), Integer("integer"),
+				Flag("@@READ_ONLY"), Boolean("boolean"),
+				Flag("@@DRY_RUN"), Boolean("boolean"),
 				Flag("@@STATS"), Boolean("boolean"),
 			},
 		},
@@ -1044,6 +1341,12 @@ var CsvqSyntax = []Expression{
 				Group: []Grammar{
 					{Keyword("SET"), Flag("@@FLAG"), Keyword("TO"), Link("value")},
 					{Keyword("SET"), Flag("@@FLAG"), Keyword("="), Link("value")},
+					{Keyword("SET"), Keyword("LOCAL"), Flag("@@FLAG"), Keyword("TO"), Link("value")},
+					{Keyword("SET"), Keyword("LOCAL"), Flag("@@FLAG"), Keyword("="), Link("value")},
+				},
+				Description: Description{
+					Template: "A flag set with %s is restored when the current statement block is exited.",
+					Values:   []Element{Keyword("LOCAL")},
 				},
 			},
 			{
@@ -1278,6 +1581,7 @@ var CsvqSyntax = []Expression{
 						Name: "relational_operator",
 						Group: []Grammar{
 							{Link("value"), Link("relational_operator"), Link("value")},
+							{Link("value"), Link("relational_operator"), Link("value"), Keyword("COLLATE"), String("collation")},
 							{Link("row_value"), Link("relational_operator"), Link("row_value")},
 						},
 						Description: Description{
@@ -1323,6 +1627,7 @@ var CsvqSyntax = []Expression{
 						Name: "like",
 						Group: []Grammar{
 							{String("str"), Option{Keyword("NOT")}, Keyword("LIKE"), String("pattern")},
+							{String("str"), Option{Keyword("NOT")}, Keyword("ILIKE"), String("pattern")},
 						},
 						Description: Description{
 							Template: "Check if %s matches %s. If %s is null, then returns %s. In %s, following special characters can be used.\n" +
@@ -1334,8 +1639,10 @@ var CsvqSyntax = []Expression{
 								"  | %s                   | Any number of characters  |\n" +
 								"  | _ (U+005F Low Line) | Exactly one character     |\n" +
 								"  +---------------------+---------------------------+\n" +
-								"```",
-							Values: []Element{String("str"), String("pattern"), String("str"), Ternary("UNKNOWN"), String("pattern"), Token("%")},
+								"```\n" +
+								"\n" +
+								"%s ignores letter cases unless %s is TRUE. %s always ignores letter cases.",
+							Values: []Element{String("str"), String("pattern"), String("str"), Ternary("UNKNOWN"), String("pattern"), Token("%"), Keyword("LIKE"), Flag("@@CASE_SENSITIVE"), Keyword("ILIKE")},
 						},
 					},
 					{
@@ -1547,6 +1854,27 @@ var CsvqSyntax = []Expression{
 						},
 						Description: Description{Template: "Returns the tangent of %s.", Values: []Element{Float("number")}},
 					},
+					{
+						Name: "sinh",
+						Group: []Grammar{
+							{Function{Name: "SINH", Args: []Element{Float("number")}, Return: Return("float or integer")}},
+						},
+						Description: Description{Template: "Returns the hyperbolic sine of %s.", Values: []Element{Float("number")}},
+					},
+					{
+						Name: "cosh",
+						Group: []Grammar{
+							{Function{Name: "COSH", Args: []Element{Float("number")}, Return: Return("float or integer")}},
+						},
+						Description: Description{Template: "Returns the hyperbolic cosine of %s.", Values: []Element{Float("number")}},
+					},
+					{
+						Name: "tanh",
+						Group: []Grammar{
+							{Function{Name: "TANH", Args: []Element{Float("number")}, Return: Return("float or integer")}},
+						},
+						Description: Description{Template: "Returns the hyperbolic tangent of %s.", Values: []Element{Float("number")}},
+					},
 					{
 						Name: "exp",
 						Group: []Grammar{
@@ -1610,6 +1938,72 @@ var CsvqSyntax = []Expression{
 						},
 						Description: Description{Template: "Returns the value of %s raised to the power of %s.", Values: []Element{Float("base"), Float("exponent")}},
 					},
+					{
+						Name: "gamma",
+						Group: []Grammar{
+							{Function{Name: "GAMMA", Args: []Element{Float("number")}, Return: Return("float or integer")}},
+						},
+						Description: Description{Template: "Returns the value of the gamma function of %s.", Values: []Element{Float("number")}},
+					},
+					{
+						Name: "factorial",
+						Group: []Grammar{
+							{Function{Name: "FACTORIAL", Args: []Element{Integer("number")}, Return: Return("integer")}},
+						},
+						Description: Description{Template: "Returns the factorial of %s. If the result exceeds the range of integer values, then returns %s.", Values: []Element{Integer("number"), Null("NULL")}},
+					},
+					{
+						Name: "gcd",
+						Group: []Grammar{
+							{Function{Name: "GCD", Args: []Element{Integer("number1"), Integer("number2")}, Return: Return("integer")}},
+						},
+						Description: Description{Template: "Returns the greatest common divisor of %s and %s.", Values: []Element{Integer("number1"), Integer("number2")}},
+					},
+					{
+						Name: "lcm",
+						Group: []Grammar{
+							{Function{Name: "LCM", Args: []Element{Integer("number1"), Integer("number2")}, Return: Return("integer")}},
+						},
+						Description: Description{Template: "Returns the least common multiple of %s and %s.", Values: []Element{Integer("number1"), Integer("number2")}},
+					},
+					{
+						Name: "safe_divide",
+						Group: []Grammar{
+							{Function{Name: "SAFE_DIVIDE", Args: []Element{Float("dividend"), Float("divisor")}, Return: Return("float or integer")}},
+						},
+						Description: Description{Template: "Returns %s divided by %s. If %s is 0, then returns %s.", Values: []Element{Float("dividend"), Float("divisor"), Float("divisor"), Null("NULL")}},
+					},
+					{
+						Name: "haversine",
+						Group: []Grammar{
+							{Function{Name: "HAVERSINE", Args: []Element{Float("latitude1"), Float("longitude1"), Float("latitude2"), Float("longitude2"), ArgWithDefValue{Arg: Float("radius"), Default: Float("6371.0088")}}, Return: Return("float")}},
+						},
+						Description: Description{Template: "Returns the great-circle distance between two points specified by latitudes and longitudes in degrees. The distance is expressed in the same unit as %s, and the default radius is the mean radius of the earth in kilometers.", Values: []Element{Float("radius")}},
+					},
+					{
+						Name: "geohash_encode",
+						Group: []Grammar{
+							{Function{Name: "GEOHASH_ENCODE", Args: []Element{Float("latitude"), Float("longitude"), ArgWithDefValue{Arg: Integer("precision"), Default: Integer("12")}}, Return: Return("string")}},
+						},
+						Description: Description{Template: "Returns the geohash string of the location with %s characters. If %s or %s is out of range, then returns %s.", Values: []Element{Integer("precision"), Float("latitude"), Float("longitude"), Null("NULL")}},
+					},
+					{
+						Name: "geohash_decode",
+						Group: []Grammar{
+							{Function{Name: "GEOHASH_DECODE", Args: []Element{String("geohash")}, Return: Return("array")}},
+						},
+						Description: Description{Template: "Returns the center of the area represented by %s as an array of latitude and longitude.", Values: []Element{String("geohash")}},
+					},
+					{
+						Name: "pmt",
+						Group: []Grammar{
+							{Function{Name: "PMT", Args: []Element{Float("rate"), Integer("nper"), Float("pv"), ArgWithDefValue{Arg: Float("fv"), Default: Integer("0")}, ArgWithDefValue{Arg: Integer("type"), Default: Integer("0")}}, Return: Return("float")}},
+						},
+						Description: Description{
+							Template: "Returns the payment per period for a loan with a constant interest %s. %s is the number of periods, %s is the present value and %s is the future value. If %s is 1, payments are due at the beginning of each period.",
+							Values:   []Element{Float("rate"), Integer("nper"), Float("pv"), Float("fv"), Integer("type")},
+						},
+					},
 					{
 						Name: "bin_to_dec",
 						Group: []Grammar{
@@ -1681,6 +2075,20 @@ var CsvqSyntax = []Expression{
 						},
 						Description: Description{Template: "Returns a random float number greater than or equal to 0.0 and less than 1.0. If %s and %s are specified, then returns a random integer between %s and %s.", Values: []Element{Integer("min"), Integer("max"), Integer("min"), Integer("max")}},
 					},
+					{
+						Name: "random",
+						Group: []Grammar{
+							{Function{Name: "RANDOM", Return: Return("float")}},
+						},
+						Description: Description{Template: "Returns a random float number greater than or equal to 0.0 and less than 1.0. If %s is set, then the results are reproducible.", Values: []Element{Flag("@@RANDOM_SEED")}},
+					},
+					{
+						Name: "random_between",
+						Group: []Grammar{
+							{Function{Name: "RANDOM_BETWEEN", Args: []Element{Integer("low"), Integer("high")}, Return: Return("integer")}},
+						},
+						Description: Description{Template: "Returns a random integer greater than or equal to %s and less than or equal to %s.", Values: []Element{Integer("low"), Integer("high")}},
+					},
 				},
 			},
 			{
@@ -1700,6 +2108,21 @@ var CsvqSyntax = []Expression{
 						},
 						Description: Description{Template: "Formats %s according to %s.", Values: []Element{Datetime("datetime"), String("format")}},
 					},
+					{
+						Name: "to_char",
+						Group: []Grammar{
+							{Function{Name: "TO_CHAR", Args: []Element{Datetime("datetime"), String("pattern")}, Return: Return("string")}},
+							{Function{Name: "TO_CHAR", Args: []Element{Float("number"), String("pattern")}, Return: Return("string")}},
+						},
+						Description: Description{Template: "Formats %s or %s according to %s written in the template pattern language of Oracle and PostgreSQL, such as 'YYYY-MM-DD HH24:MI' and '9,999.00'.", Values: []Element{Datetime("datetime"), Float("number"), String("pattern")}},
+					},
+					{
+						Name: "strptime",
+						Group: []Grammar{
+							{Function{Name: "STRPTIME", Args: []Element{String("str"), String("format"), Option{String("timezone")}}, Return: Return("datetime")}},
+						},
+						Description: Description{Template: "Parses %s as a datetime according to %s. If %s does not include time zone information, then it is interpreted as a time in %s. If %s does not match %s, then returns %s.", Values: []Element{String("str"), String("format"), String("str"), String("timezone"), String("str"), String("format"), Null("NULL")}},
+					},
 					{
 						Name: "year",
 						Group: []Grammar{
@@ -1799,53 +2222,102 @@ var CsvqSyntax = []Expression{
 						Description: Description{Template: "Returns the week number of the year of %s as an integer.", Values: []Element{Datetime("datetime")}},
 					},
 					{
-						Name: "add_year",
+						Name: "iso_week",
 						Group: []Grammar{
-							{Function{Name: "ADD_YEAR", Args: []Element{Datetime("datetime"), Integer("duration")}, Return: Return("datetime")}},
+							{Function{Name: "ISO_WEEK", Args: []Element{Datetime("datetime")}, Return: Return("integer")}},
 						},
-						Description: Description{Template: "Adds %s years to %s.", Values: []Element{Integer("duration"), Datetime("datetime")}},
+						Description: Description{Template: "Returns the ISO 8601 week number of %s as an integer.", Values: []Element{Datetime("datetime")}},
 					},
 					{
-						Name: "add_month",
+						Name: "iso_year",
 						Group: []Grammar{
-							{Function{Name: "ADD_MONTH", Args: []Element{Datetime("datetime"), Integer("duration")}, Return: Return("datetime")}},
+							{Function{Name: "ISO_YEAR", Args: []Element{Datetime("datetime")}, Return: Return("integer")}},
 						},
-						Description: Description{Template: "Adds %s monthes to %s.", Values: []Element{Integer("duration"), Datetime("datetime")}},
+						Description: Description{Template: "Returns the ISO 8601 week-numbering year of %s as an integer.", Values: []Element{Datetime("datetime")}},
 					},
 					{
-						Name: "add_day",
+						Name: "month_name",
 						Group: []Grammar{
-							{Function{Name: "ADD_DAY", Args: []Element{Datetime("datetime"), Integer("duration")}, Return: Return("datetime")}},
+							{Function{Name: "MONTH_NAME", Args: []Element{Datetime("datetime"), Option{String("locale")}}, Return: Return("string")}},
 						},
-						Description: Description{Template: "Adds %s days to %s.", Values: []Element{Integer("duration"), Datetime("datetime")}},
+						Description: Description{Template: "Returns the month name of %s in the language of %s. Supported languages are en, de, es, fr, it, ja, pt and zh. The default is en.", Values: []Element{Datetime("datetime"), String("locale")}},
 					},
 					{
-						Name: "add_hour",
+						Name: "day_name",
 						Group: []Grammar{
-							{Function{Name: "ADD_HOUR", Args: []Element{Datetime("datetime"), Integer("duration")}, Return: Return("datetime")}},
+							{Function{Name: "DAY_NAME", Args: []Element{Datetime("datetime"), Option{String("locale")}}, Return: Return("string")}},
 						},
-						Description: Description{Template: "Adds %s hours to %s.", Values: []Element{Integer("duration"), Datetime("datetime")}},
+						Description: Description{Template: "Returns the day name of %s in the language of %s. Supported languages are en, de, es, fr, it, ja, pt and zh. The default is en.", Values: []Element{Datetime("datetime"), String("locale")}},
 					},
 					{
-						Name: "add_minute",
+						Name: "last_day",
 						Group: []Grammar{
-							{Function{Name: "ADD_MINUTE", Args: []Element{Datetime("datetime"), Integer("duration")}, Return: Return("datetime")}},
+							{Function{Name: "LAST_DAY", Args: []Element{Datetime("datetime")}, Return: Return("datetime")}},
 						},
-						Description: Description{Template: "Adds %s minutes to %s.", Values: []Element{Integer("duration"), Datetime("datetime")}},
+						Description: Description{Template: "Returns the last day of the month of %s.", Values: []Element{Datetime("datetime")}},
 					},
 					{
-						Name: "add_second",
+						Name: "first_day_of_week",
 						Group: []Grammar{
-							{Function{Name: "ADD_SECOND", Args: []Element{Datetime("datetime"), Integer("duration")}, Return: Return("datetime")}},
+							{Function{Name: "FIRST_DAY_OF_WEEK", Args: []Element{Datetime("datetime"), Option{Integer("start_weekday")}}, Return: Return("datetime")}},
 						},
-						Description: Description{Template: "Adds %s seconds to %s.", Values: []Element{Integer("duration"), Datetime("datetime")}},
+						Description: Description{Template: "Returns the first day of the week that contains %s. %s is from 0 (Sunday) to 6 (Saturday), and the default is 1 (Monday).", Values: []Element{Datetime("datetime"), Integer("start_weekday")}},
 					},
 					{
-						Name: "add_milli",
+						Name: "age",
 						Group: []Grammar{
-							{Function{Name: "ADD_MILLI", Args: []Element{Datetime("datetime"), Integer("duration")}, Return: Return("datetime")}},
+							{Function{Name: "AGE", Args: []Element{Datetime("datetime1"), Datetime("datetime2")}, Return: Return("integer")}},
 						},
-						Description: Description{Template: "Adds %s milliseconds to %s.", Values: []Element{Integer("duration"), Datetime("datetime")}},
+						Description: Description{Template: "Returns the number of completed years from %s to %s.", Values: []Element{Datetime("datetime2"), Datetime("datetime1")}},
+					},
+					{
+						Name: "add_year",
+						Group: []Grammar{
+							{Function{Name: "ADD_YEAR", Args: []Element{Datetime("datetime"), Integer("duration")}, Return: Return("datetime")}},
+						},
+						Description: Description{Template: "Adds %s years to %s.", Values: []Element{Integer("duration"), Datetime("datetime")}},
+					},
+					{
+						Name: "add_month",
+						Group: []Grammar{
+							{Function{Name: "ADD_MONTH", Args: []Element{Datetime("datetime"), Integer("duration")}, Return: Return("datetime")}},
+						},
+						Description: Description{Template: "Adds %s monthes to %s.", Values: []Element{Integer("duration"), Datetime("datetime")}},
+					},
+					{
+						Name: "add_day",
+						Group: []Grammar{
+							{Function{Name: "ADD_DAY", Args: []Element{Datetime("datetime"), Integer("duration")}, Return: Return("datetime")}},
+						},
+						Description: Description{Template: "Adds %s days to %s.", Values: []Element{Integer("duration"), Datetime("datetime")}},
+					},
+					{
+						Name: "add_hour",
+						Group: []Grammar{
+							{Function{Name: "ADD_HOUR", Args: []Element{Datetime("datetime"), Integer("duration")}, Return: Return("datetime")}},
+						},
+						Description: Description{Template: "Adds %s hours to %s.", Values: []Element{Integer("duration"), Datetime("datetime")}},
+					},
+					{
+						Name: "add_minute",
+						Group: []Grammar{
+							{Function{Name: "ADD_MINUTE", Args: []Element{Datetime("datetime"), Integer("duration")}, Return: Return("datetime")}},
+						},
+						Description: Description{Template: "Adds %s minutes to %s.", Values: []Element{Integer("duration"), Datetime("datetime")}},
+					},
+					{
+						Name: "add_second",
+						Group: []Grammar{
+							{Function{Name: "ADD_SECOND", Args: []Element{Datetime("datetime"), Integer("duration")}, Return: Return("datetime")}},
+						},
+						Description: Description{Template: "Adds %s seconds to %s.", Values: []Element{Integer("duration"), Datetime("datetime")}},
+					},
+					{
+						Name: "add_milli",
+						Group: []Grammar{
+							{Function{Name: "ADD_MILLI", Args: []Element{Datetime("datetime"), Integer("duration")}, Return: Return("datetime")}},
+						},
+						Description: Description{Template: "Adds %s milliseconds to %s.", Values: []Element{Integer("duration"), Datetime("datetime")}},
 					},
 					{
 						Name: "add_micro",
@@ -1861,6 +2333,13 @@ var CsvqSyntax = []Expression{
 						},
 						Description: Description{Template: "Adds %s nanoseconds to %s.", Values: []Element{Integer("duration"), Datetime("datetime")}},
 					},
+					{
+						Name: "date_add",
+						Group: []Grammar{
+							{Function{Name: "DATE_ADD", Args: []Element{Datetime("datetime"), Integer("duration"), String("unit")}, Return: Return("datetime")}},
+						},
+						Description: Description{Template: "Adds %s %s to %s. %s is one of YEAR, QUARTER, MONTH, WEEK, DAY, HOUR, MINUTE or SECOND. When the day does not exist in the resulting month, the last day of the month is used.", Values: []Element{Integer("duration"), String("unit"), Datetime("datetime"), String("unit")}},
+					},
 					{
 						Name: "trunc_month",
 						Group: []Grammar{
@@ -1917,12 +2396,20 @@ var CsvqSyntax = []Expression{
 						},
 						Description: Description{Template: "Truncates time information less than 1 microsecond from %s.", Values: []Element{Datetime("datetime")}},
 					},
+					{
+						Name: "date_trunc",
+						Group: []Grammar{
+							{Function{Name: "DATE_TRUNC", Args: []Element{String("unit"), Datetime("datetime")}, Return: Return("datetime")}},
+						},
+						Description: Description{Template: "Truncates %s to the beginning of the %s that contains it. %s is one of YEAR, QUARTER, MONTH, WEEK, DAY, HOUR or MINUTE, and weeks begin on Monday.", Values: []Element{Datetime("datetime"), String("unit"), String("unit")}},
+					},
 					{
 						Name: "date_diff",
 						Group: []Grammar{
 							{Function{Name: "DATE_DIFF", Args: []Element{Datetime("datetime1"), Datetime("datetime2")}, Return: Return("integer")}},
+							{Function{Name: "DATE_DIFF", Args: []Element{String("unit"), Datetime("datetime1"), Datetime("datetime2")}, Return: Return("integer")}},
 						},
-						Description: Description{Template: "Returns the difference of days between two %s values. The time information less than 1 day are ignored in the calculation.", Values: []Element{Datetime("datetime")}},
+						Description: Description{Template: "Returns the difference of days between two %s values. The time information less than 1 day are ignored in the calculation. If %s is specified, then returns the number of %s boundaries crossed from %s to %s.", Values: []Element{Datetime("datetime"), String("unit"), String("unit"), Datetime("datetime1"), Datetime("datetime2")}},
 					},
 					{
 						Name: "time_diff",
@@ -1989,16 +2476,47 @@ var CsvqSyntax = []Expression{
 					{
 						Name: "upper",
 						Group: []Grammar{
-							{Function{Name: "UPPER", Args: []Element{String("str")}, Return: Return("string")}},
+							{Function{Name: "UPPER", Args: []Element{String("str"), Option{String("locale")}}, Return: Return("string")}},
 						},
-						Description: Description{Template: "Returns the string value replaced %s with characters mapped to their upper case.", Values: []Element{String("str")}},
+						Description: Description{Template: "Returns the string value replaced %s with characters mapped to their upper case. If %s is specified, then the case mapping rules of the language are applied.", Values: []Element{String("str"), String("locale")}},
 					},
 					{
 						Name: "lower",
 						Group: []Grammar{
-							{Function{Name: "LOWER", Args: []Element{String("str")}, Return: Return("string")}},
+							{Function{Name: "LOWER", Args: []Element{String("str"), Option{String("locale")}}, Return: Return("string")}},
+						},
+						Description: Description{Template: "Returns the string value replaced %s with characters mapped to their lower case. If %s is specified, then the case mapping rules of the language are applied.", Values: []Element{String("str"), String("locale")}},
+					},
+					{
+						Name: "titlecase",
+						Group: []Grammar{
+							{Function{Name: "TITLECASE", Args: []Element{String("str"), Option{String("locale")}}, Return: Return("string")}},
+						},
+						Description: Description{Template: "Returns the string value replaced the first letter of each word in %s with its title case and the other letters with their lower case. If %s is specified, then the case mapping rules of the language are applied.", Values: []Element{String("str"), String("locale")}},
+					},
+					{
+						Name: "normalize",
+						Group: []Grammar{
+							{Function{Name: "NORMALIZE", Args: []Element{String("str"), ArgWithDefValue{Arg: String("form"), Default: String("'NFC'")}}, Return: Return("string")}},
+						},
+						Description: Description{
+							Template: "Returns the string value of %s converted to the Unicode normalization %s. %s is one of %s, %s, %s or %s.",
+							Values:   []Element{String("str"), String("form"), String("form"), String("'NFC'"), String("'NFD'"), String("'NFKC'"), String("'NFKD'")},
 						},
-						Description: Description{Template: "Returns the string value replaced %s with characters mapped to their lower case.", Values: []Element{String("str")}},
+					},
+					{
+						Name: "unaccent",
+						Group: []Grammar{
+							{Function{Name: "UNACCENT", Args: []Element{String("str")}, Return: Return("string")}},
+						},
+						Description: Description{Template: "Returns the string value of %s with diacritical marks removed from letters.", Values: []Element{String("str")}},
+					},
+					{
+						Name: "transliterate",
+						Group: []Grammar{
+							{Function{Name: "TRANSLITERATE", Args: []Element{String("str"), ArgWithDefValue{Arg: String("replacement"), Default: String("'?'")}}, Return: Return("string")}},
+						},
+						Description: Description{Template: "Returns the string value of %s converted to ASCII characters. Characters that cannot be converted are replaced with %s.", Values: []Element{String("str"), String("replacement")}},
 					},
 					{
 						Name: "base64_encode",
@@ -2028,6 +2546,41 @@ var CsvqSyntax = []Expression{
 						},
 						Description: Description{Template: "Returns the string value represented by %s that is encoded with hexadecimal.", Values: []Element{String("str")}},
 					},
+					{
+						Name: "url_encode",
+						Group: []Grammar{
+							{Function{Name: "URL_ENCODE", Args: []Element{String("str")}, Return: Return("string")}},
+						},
+						Description: Description{Template: "Returns the URL encoding of %s.", Values: []Element{String("str")}},
+					},
+					{
+						Name: "url_decode",
+						Group: []Grammar{
+							{Function{Name: "URL_DECODE", Args: []Element{String("str")}, Return: Return("string")}},
+						},
+						Description: Description{Template: "Returns the string value represented by %s that is encoded in URL query format.", Values: []Element{String("str")}},
+					},
+					{
+						Name: "url_extract_host",
+						Group: []Grammar{
+							{Function{Name: "URL_EXTRACT_HOST", Args: []Element{String("url")}, Return: Return("string")}},
+						},
+						Description: Description{Template: "Returns the host name of %s.", Values: []Element{String("url")}},
+					},
+					{
+						Name: "url_extract_path",
+						Group: []Grammar{
+							{Function{Name: "URL_EXTRACT_PATH", Args: []Element{String("url")}, Return: Return("string")}},
+						},
+						Description: Description{Template: "Returns the decoded path of %s.", Values: []Element{String("url")}},
+					},
+					{
+						Name: "url_extract_query_param",
+						Group: []Grammar{
+							{Function{Name: "URL_EXTRACT_QUERY_PARAM", Args: []Element{String("url"), String("name")}, Return: Return("string")}},
+						},
+						Description: Description{Template: "Returns the decoded value of the query parameter %s in %s.", Values: []Element{String("name"), String("url")}},
+					},
 					{
 						Name: "len",
 						Group: []Grammar{
@@ -2113,6 +2666,29 @@ var CsvqSyntax = []Expression{
 						},
 						Description: Description{Template: "Returns the string at %s in the list generated by splitting with %s from %s.", Values: []Element{Integer("index"), String("sep"), String("str")}},
 					},
+					{
+						Name: "split_part",
+						Group: []Grammar{
+							{Function{Name: "SPLIT_PART", Args: []Element{String("str"), String("sep"), Integer("position")}, Return: Return("string")}},
+						},
+						Description: Description{
+							Template: "Returns the field at %s in the list generated by splitting %s with %s. " +
+								"%s starts with 1, and a negative %s counts from the end of the list. " +
+								"If %s is out of range, then returns an empty string.",
+							Values: []Element{Integer("position"), String("str"), String("sep"), Integer("position"), Integer("position"), Integer("position")},
+						},
+					},
+					{
+						Name: "strtok",
+						Group: []Grammar{
+							{Function{Name: "STRTOK", Args: []Element{String("str"), ArgWithDefValue{Arg: String("delimiters"), Default: String("' '")}, ArgWithDefValue{Arg: Integer("position"), Default: Integer("1")}}, Return: Return("string")}},
+						},
+						Description: Description{
+							Template: "Returns the token at %s in %s. Each character in %s is treated as a delimiter, and empty tokens are ignored. " +
+								"If %s is out of range, then returns null.",
+							Values: []Element{Integer("position"), String("str"), String("delimiters"), Integer("position")},
+						},
+					},
 					{
 						Name: "replace",
 						Group: []Grammar{
@@ -2127,6 +2703,27 @@ var CsvqSyntax = []Expression{
 						},
 						Description: Description{Template: "Returns the formatted string replaced %s with %s in %s.", Values: []Element{Link("placeholders"), Link("replace_value"), String("format")}},
 					},
+					{
+						Name: "random_string",
+						Group: []Grammar{
+							{Function{Name: "RANDOM_STRING", Args: []Element{Integer("length"), Option{String("characters")}}, Return: Return("string")}},
+						},
+						Description: Description{Template: "Returns a random string of %s characters chosen from %s. The default %s is alphanumeric characters.", Values: []Element{Integer("length"), String("characters"), String("characters")}},
+					},
+					{
+						Name: "uuid",
+						Group: []Grammar{
+							{Function{Name: "UUID", Return: Return("string")}},
+						},
+						Description: Description{Template: "Returns a randomly generated version 4 UUID."},
+					},
+					{
+						Name: "uuid_v7",
+						Group: []Grammar{
+							{Function{Name: "UUID_V7", Return: Return("string")}},
+						},
+						Description: Description{Template: "Returns a version 7 UUID that consists of the current unix time in milliseconds followed by random bits."},
+					},
 					{
 						Name: "json_value",
 						Group: []Grammar{
@@ -2137,9 +2734,64 @@ var CsvqSyntax = []Expression{
 					{
 						Name: "json_object",
 						Group: []Grammar{
-							{Function{Name: "JSON_OBJECT", Args: []Element{ContinuousOption{Link("string")}}, Return: Return("string")}},
+							{Function{Name: "JSON_OBJECT", Args: []Element{ContinuousOption{Link("string")}}, Return: Return("json")}},
 						},
-						Description: Description{Template: "Returns a string formatted in JSON."},
+						Description: Description{Template: "Returns a JSON object."},
+					},
+					{
+						Name: "xpath_string",
+						Group: []Grammar{
+							{Function{Name: "XPATH_STRING", Args: []Element{String("xpath"), String("xml")}, Return: Return("string")}},
+						},
+						Description: Description{Template: "Returns the string value of the first node in %s selected by %s. If no node is selected, then returns %s.", Values: []Element{String("xml"), String("xpath"), Null("NULL")}},
+					},
+					{
+						Name: "xpath_number",
+						Group: []Grammar{
+							{Function{Name: "XPATH_NUMBER", Args: []Element{String("xpath"), String("xml")}, Return: Return("float")}},
+						},
+						Description: Description{Template: "Returns the string value of the first node in %s selected by %s as a float. If no node is selected or the value is not a number, then returns %s.", Values: []Element{String("xml"), String("xpath"), Null("NULL")}},
+					},
+					{
+						Name: "xpath_exists",
+						Group: []Grammar{
+							{Function{Name: "XPATH_EXISTS", Args: []Element{String("xpath"), String("xml")}, Return: Return("boolean")}},
+						},
+						Description: Description{Template: "Returns whether any nodes in %s are selected by %s.", Values: []Element{String("xml"), String("xpath")}},
+					},
+				},
+			},
+			{
+				Label: "Array Functions",
+				Grammar: []Definition{
+					{
+						Name: "array_length",
+						Group: []Grammar{
+							{Function{Name: "ARRAY_LENGTH", Args: []Element{Identifier("array")}, Return: Return("integer")}},
+						},
+						Description: Description{Template: "Returns the number of elements in %s.", Values: []Element{Identifier("array")}},
+					},
+					{
+						Name: "array_contains",
+						Group: []Grammar{
+							{Function{Name: "ARRAY_CONTAINS", Args: []Element{Identifier("array"), Link("value")}, Return: Return("ternary")}},
+						},
+						Description: Description{Template: "Returns whether any element of %s is equal to %s.", Values: []Element{Identifier("array"), Link("value")}},
+					},
+					{
+						Name: "string_to_array",
+						Group: []Grammar{
+							{Function{Name: "STRING_TO_ARRAY", Args: []Element{String("str"), String("sep")}, Return: Return("array")}},
+							{Function{Name: "SPLIT", Args: []Element{String("str"), String("sep")}, Return: Return("array")}},
+						},
+						Description: Description{Template: "Returns an array of strings generated by splitting %s with %s.", Values: []Element{String("str"), String("sep")}},
+					},
+					{
+						Name: "array_to_string",
+						Group: []Grammar{
+							{Function{Name: "ARRAY_TO_STRING", Args: []Element{Identifier("array"), String("sep")}, Return: Return("string")}},
+						},
+						Description: Description{Template: "Returns a string joining the elements of %s with %s. Null elements are ignored.", Values: []Element{Identifier("array"), String("sep")}},
 					},
 				},
 			},
@@ -2174,6 +2826,13 @@ var CsvqSyntax = []Expression{
 						},
 						Description: Description{Template: "Generates a SHA-512 hash value."},
 					},
+					{
+						Name: "crc32",
+						Group: []Grammar{
+							{Function{Name: "CRC32", Args: []Element{String("str")}, Return: Return("integer")}},
+						},
+						Description: Description{Template: "Generates a CRC-32 checksum using the IEEE polynomial."},
+					},
 					{
 						Name: "md5_hmac",
 						Group: []Grammar{
@@ -2202,6 +2861,20 @@ var CsvqSyntax = []Expression{
 						},
 						Description: Description{Template: "Generates a SHA-512 keyed-hash value using the HMAC method."},
 					},
+					{
+						Name: "hmac_sha256",
+						Group: []Grammar{
+							{Function{Name: "HMAC_SHA256", Args: []Element{String("key"), String("str")}, Return: Return("string")}},
+						},
+						Description: Description{Template: "Generates a SHA-256 keyed-hash value using the HMAC method. Same as SHA256_HMAC except for the order of the arguments."},
+					},
+					{
+						Name: "secure_equal",
+						Group: []Grammar{
+							{Function{Name: "SECURE_EQUAL", Args: []Element{String("str1"), String("str2")}, Return: Return("boolean")}},
+						},
+						Description: Description{Template: "Returns whether %s and %s are exactly the same. The time taken is independent of the contents of the strings, so that it can be used to compare keyed-hash values.", Values: []Element{String("str1"), String("str2")}},
+					},
 				},
 			},
 			{
@@ -2265,6 +2938,16 @@ var CsvqSyntax = []Expression{
 							Values: []Element{String("command"), String("command")},
 						},
 					},
+					{
+						Name: "nextval",
+						Group: []Grammar{
+							{Function{Name: "NEXTVAL", Args: []Element{String("sequence_name")}, Return: Return("integer")}},
+						},
+						Description: Description{
+							Template: "Advances the sequence %s and returns the generated value.",
+							Values:   []Element{String("sequence_name")},
+						},
+					},
 				},
 			},
 			{
@@ -2349,6 +3032,177 @@ var CsvqSyntax = []Expression{
 							Values: []Element{Link("value"), Null("NULL"), Link("value"), Keyword("DATETIME")},
 						},
 					},
+					{
+						Name: "mode",
+						Group: []Grammar{
+							{Function{Name: "MODE", Args: []Element{Option{Keyword("DISTINCT")}, Link("value")}, Return: Return("primitive type")}},
+						},
+						Description: Description{
+							Template: "Returns the most frequent value of %s. If all values are null, then returns %s. " +
+								"If some values appear the same number of times, then returns the least of them.",
+							Values: []Element{Link("value"), Null("NULL")},
+						},
+					},
+					{
+						Name: "stddev_pop",
+						Group: []Grammar{
+							{Function{Name: "STDDEV_POP", Args: []Element{Option{Keyword("DISTINCT")}, Link("value")}, Return: Return("float or integer")}},
+						},
+						Description: Description{
+							Template: "Returns the population standard deviation of float values of %s. If all values are null, then returns %s.",
+							Values:   []Element{Link("value"), Null("NULL")},
+						},
+					},
+					{
+						Name: "stddev_samp",
+						Group: []Grammar{
+							{Function{Name: "STDDEV_SAMP", Args: []Element{Option{Keyword("DISTINCT")}, Link("value")}, Return: Return("float or integer")}},
+						},
+						Description: Description{
+							Template: "Returns the sample standard deviation of float values of %s. If there are less than two values that are not null, then returns %s.",
+							Values:   []Element{Link("value"), Null("NULL")},
+						},
+					},
+					{
+						Name: "var_pop",
+						Group: []Grammar{
+							{Function{Name: "VAR_POP", Args: []Element{Option{Keyword("DISTINCT")}, Link("value")}, Return: Return("float or integer")}},
+						},
+						Description: Description{
+							Template: "Returns the population variance of float values of %s. If all values are null, then returns %s.",
+							Values:   []Element{Link("value"), Null("NULL")},
+						},
+					},
+					{
+						Name: "var_samp",
+						Group: []Grammar{
+							{Function{Name: "VAR_SAMP", Args: []Element{Option{Keyword("DISTINCT")}, Link("value")}, Return: Return("float or integer")}},
+						},
+						Description: Description{
+							Template: "Returns the sample variance of float values of %s. If there are less than two values that are not null, then returns %s.",
+							Values:   []Element{Link("value"), Null("NULL")},
+						},
+					},
+					{
+						Name: "bit_and",
+						Group: []Grammar{
+							{Function{Name: "BIT_AND", Args: []Element{Option{Keyword("DISTINCT")}, Link("value")}, Return: Return("integer")}},
+						},
+						Description: Description{
+							Template: "Returns the bitwise AND of integer values of %s. If there are no integer values, then returns %s.",
+							Values:   []Element{Link("value"), Null("NULL")},
+						},
+					},
+					{
+						Name: "bit_or",
+						Group: []Grammar{
+							{Function{Name: "BIT_OR", Args: []Element{Option{Keyword("DISTINCT")}, Link("value")}, Return: Return("integer")}},
+						},
+						Description: Description{
+							Template: "Returns the bitwise OR of integer values of %s. If there are no integer values, then returns %s.",
+							Values:   []Element{Link("value"), Null("NULL")},
+						},
+					},
+					{
+						Name: "bit_xor",
+						Group: []Grammar{
+							{Function{Name: "BIT_XOR", Args: []Element{Option{Keyword("DISTINCT")}, Link("value")}, Return: Return("integer")}},
+						},
+						Description: Description{
+							Template: "Returns the bitwise XOR of integer values of %s. If there are no integer values, then returns %s.",
+							Values:   []Element{Link("value"), Null("NULL")},
+						},
+					},
+					{
+						Name: "bool_and",
+						Group: []Grammar{
+							{Function{Name: "BOOL_AND", Args: []Element{Option{Keyword("DISTINCT")}, Link("value")}, Return: Return("ternary")}},
+							{Function{Name: "EVERY", Args: []Element{Option{Keyword("DISTINCT")}, Link("value")}, Return: Return("ternary")}},
+						},
+						Description: Description{
+							Template: "Returns TRUE if all ternary values of %s are TRUE, FALSE if any of them is FALSE, otherwise UNKNOWN. If all values are null, then returns %s.",
+							Values:   []Element{Link("value"), Null("NULL")},
+						},
+					},
+					{
+						Name: "bool_or",
+						Group: []Grammar{
+							{Function{Name: "BOOL_OR", Args: []Element{Option{Keyword("DISTINCT")}, Link("value")}, Return: Return("ternary")}},
+						},
+						Description: Description{
+							Template: "Returns TRUE if any ternary value of %s is TRUE, FALSE if all of them are FALSE, otherwise UNKNOWN. If all values are null, then returns %s.",
+							Values:   []Element{Link("value"), Null("NULL")},
+						},
+					},
+					{
+						Name: "checksum_agg",
+						Group: []Grammar{
+							{Function{Name: "CHECKSUM_AGG", Args: []Element{Option{Keyword("DISTINCT")}, Link("value")}, Return: Return("integer")}},
+							{Function{Name: "HASH_AGG", Args: []Element{Option{Keyword("DISTINCT")}, Link("value")}, Return: Return("integer")}},
+						},
+						Description: Description{
+							Template: "Returns an order-independent checksum of all values of %s including nulls. If there are no values, then returns %s.",
+							Values:   []Element{Link("value"), Null("NULL")},
+						},
+					},
+					{
+						Name: "corr",
+						Group: []Grammar{
+							{Function{Name: "CORR", Args: []Element{Float("expr1"), Float("expr2")}, Return: Return("float or integer")}},
+						},
+						Description: Description{Template: "Returns the correlation coefficient of the pairs of float values of %s and %s. Pairs in which either value is null are ignored.", Values: []Element{Float("expr1"), Float("expr2")}},
+					},
+					{
+						Name: "covar_pop",
+						Group: []Grammar{
+							{Function{Name: "COVAR_POP", Args: []Element{Float("expr1"), Float("expr2")}, Return: Return("float or integer")}},
+						},
+						Description: Description{Template: "Returns the population covariance of the pairs of float values of %s and %s. Pairs in which either value is null are ignored.", Values: []Element{Float("expr1"), Float("expr2")}},
+					},
+					{
+						Name: "covar_samp",
+						Group: []Grammar{
+							{Function{Name: "COVAR_SAMP", Args: []Element{Float("expr1"), Float("expr2")}, Return: Return("float or integer")}},
+						},
+						Description: Description{Template: "Returns the sample covariance of the pairs of float values of %s and %s. Pairs in which either value is null are ignored.", Values: []Element{Float("expr1"), Float("expr2")}},
+					},
+					{
+						Name: "regr_slope",
+						Group: []Grammar{
+							{Function{Name: "REGR_SLOPE", Args: []Element{Float("y"), Float("x")}, Return: Return("float or integer")}},
+						},
+						Description: Description{Template: "Returns the slope of the least-squares regression line, where %s is the dependent variable and %s is the independent variable. Pairs in which either value is null are ignored.", Values: []Element{Float("y"), Float("x")}},
+					},
+					{
+						Name: "regr_intercept",
+						Group: []Grammar{
+							{Function{Name: "REGR_INTERCEPT", Args: []Element{Float("y"), Float("x")}, Return: Return("float or integer")}},
+						},
+						Description: Description{Template: "Returns the y-intercept of the least-squares regression line, where %s is the dependent variable and %s is the independent variable. Pairs in which either value is null are ignored.", Values: []Element{Float("y"), Float("x")}},
+					},
+					{
+						Name: "percentile_cont",
+						Group: []Grammar{
+							{Function{Name: "PERCENTILE_CONT", Args: []Element{Float("fraction")}, AfterArgs: []Element{Keyword("WITHIN"), Keyword("GROUP"), Parentheses{Keyword("ORDER"), Keyword("BY"), Link("value"), Option{AnyOne{Keyword("ASC"), Keyword("DESC")}}}}, Return: Return("float or integer")}},
+						},
+						Description: Description{
+							Template: "Returns the value at %s of float or datetime values of %s sorted by the order by clause. " +
+								"If no value exists exactly at the position, then the value is calculated by linear interpolation. " +
+								"If all values are null, then returns %s.",
+							Values: []Element{Float("fraction"), Link("value"), Null("NULL")},
+						},
+					},
+					{
+						Name: "percentile_disc",
+						Group: []Grammar{
+							{Function{Name: "PERCENTILE_DISC", Args: []Element{Float("fraction")}, AfterArgs: []Element{Keyword("WITHIN"), Keyword("GROUP"), Parentheses{Keyword("ORDER"), Keyword("BY"), Link("value"), Option{AnyOne{Keyword("ASC"), Keyword("DESC")}}}}, Return: Return("primitive type")}},
+						},
+						Description: Description{
+							Template: "Returns the first value of %s sorted by the order by clause whose cumulative distribution is greater than or equal to %s. " +
+								"If all values are null, then returns %s.",
+							Values: []Element{Link("value"), Float("fraction"), Null("NULL")},
+						},
+					},
 					{
 						Name: "listagg",
 						Group: []Grammar{
@@ -2366,14 +3220,66 @@ var CsvqSyntax = []Expression{
 					{
 						Name: "json_agg",
 						Group: []Grammar{
-							{Function{Name: "JSON_AGG", Args: []Element{Option{Keyword("DISTINCT")}, Link("value")}, AfterArgs: []Element{Option{Keyword("WITHIN"), Keyword("GROUP"), Parentheses{Link("order_by_clause")}}}, Return: Return("string")}},
+							{Function{Name: "JSON_AGG", Args: []Element{Option{Keyword("DISTINCT")}, Link("value")}, AfterArgs: []Element{Option{Keyword("WITHIN"), Keyword("GROUP"), Parentheses{Link("order_by_clause")}}}, Return: Return("json")}},
 						},
 						Description: Description{
-							Template: "Returns the string formatted in JSON array of %s. " +
+							Template: "Returns the JSON array of %s. " +
 								"By using %s, you can sort values.",
 							Values: []Element{Link("value"), Link("order_by_clause")},
 						},
 					},
+					{
+						Name: "array_agg",
+						Group: []Grammar{
+							{Function{Name: "ARRAY_AGG", Args: []Element{Option{Keyword("DISTINCT")}, Link("value")}, AfterArgs: []Element{Option{Keyword("WITHIN"), Keyword("GROUP"), Parentheses{Link("order_by_clause")}}}, Return: Return("array")}},
+							{Function{Name: "ARRAY_AGG", Args: []Element{Option{Keyword("DISTINCT")}, PlainGroup{Link("value"), Link("order_by_clause")}}, Return: Return("array")}},
+						},
+						Description: Description{
+							Template: "Returns the array of %s. " +
+								"By using %s, you can sort values.",
+							Values: []Element{Link("value"), Link("order_by_clause")},
+						},
+					},
+					{
+						Name: "first",
+						Group: []Grammar{
+							{Function{Name: "FIRST", Args: []Element{PlainGroup{Link("value"), Link("order_by_clause")}}, Return: Return("primitive type")}},
+						},
+						Description: Description{
+							Template: "Returns the value of %s in the first row of the group sorted by %s. If there are no rows, then returns %s.",
+							Values:   []Element{Link("value"), Link("order_by_clause"), Null("NULL")},
+						},
+					},
+					{
+						Name: "last",
+						Group: []Grammar{
+							{Function{Name: "LAST", Args: []Element{PlainGroup{Link("value"), Link("order_by_clause")}}, Return: Return("primitive type")}},
+						},
+						Description: Description{
+							Template: "Returns the value of %s in the last row of the group sorted by %s. If there are no rows, then returns %s.",
+							Values:   []Element{Link("value"), Link("order_by_clause"), Null("NULL")},
+						},
+					},
+					{
+						Name: "npv",
+						Group: []Grammar{
+							{Function{Name: "NPV", Args: []Element{Float("rate"), Float("cash_flow")}, AfterArgs: []Element{Option{Keyword("WITHIN"), Keyword("GROUP"), Parentheses{Link("order_by_clause")}}}, Return: Return("float or integer")}},
+						},
+						Description: Description{
+							Template: "Returns the net present value of %s discounted at %s. Values are treated as cash flows at the end of each period in the order specified by %s.",
+							Values:   []Element{Float("cash_flow"), Float("rate"), Link("order_by_clause")},
+						},
+					},
+					{
+						Name: "irr",
+						Group: []Grammar{
+							{Function{Name: "IRR", Args: []Element{Float("cash_flow"), ArgWithDefValue{Arg: Float("guess"), Default: Float("0.1")}}, AfterArgs: []Element{Option{Keyword("WITHIN"), Keyword("GROUP"), Parentheses{Link("order_by_clause")}}}, Return: Return("float or integer")}},
+						},
+						Description: Description{
+							Template: "Returns the internal rate of return of %s in the order specified by %s. If the calculation does not converge, then returns %s.",
+							Values:   []Element{Float("cash_flow"), Link("order_by_clause"), Null("NULL")},
+						},
+					},
 				},
 			},
 			{
@@ -2425,7 +3331,7 @@ var CsvqSyntax = []Expression{
 							{Function{Name: "PERCENT_RANK", AfterArgs: []Element{Keyword("OVER"), Parentheses{Option{Link("partition_clause")}, Option{Link("order_by_clause")}}}, Return: Return("float")}},
 						},
 						Description: Description{
-							Template: "Returns the relative ranks in a group. The return value is greater than or equal to 0 and less than or equal to 1.",
+							Template: "Returns the relative ranks in a group. The return value is greater than or equal to 0 and less than or equal to 1. If a group has only one record, then returns 0.",
 						},
 					},
 					{
@@ -2557,6 +3463,154 @@ var CsvqSyntax = []Expression{
 							Values: []Element{Link("value"), Null("NULL"), Link("value"), Keyword("DATETIME")},
 						},
 					},
+					{
+						Name: "mode",
+						Group: []Grammar{
+							{Function{Name: "MODE", Args: []Element{Option{Keyword("DISTINCT")}, Link("value")}, AfterArgs: []Element{Keyword("OVER"), Parentheses{Option{Link("partition_clause")}, Option{Link("order_by_clause"), Option{Link("windowing_clause")}}}}, Return: Return("primitive type")}},
+						},
+						Description: Description{
+							Template: "Returns the most frequent value of %s. If all values are null, then returns %s. " +
+								"If some values appear the same number of times, then returns the least of them.",
+							Values: []Element{Link("value"), Null("NULL")},
+						},
+					},
+					{
+						Name: "stddev_pop",
+						Group: []Grammar{
+							{Function{Name: "STDDEV_POP", Args: []Element{Option{Keyword("DISTINCT")}, Link("value")}, AfterArgs: []Element{Keyword("OVER"), Parentheses{Option{Link("partition_clause")}, Option{Link("order_by_clause"), Option{Link("windowing_clause")}}}}, Return: Return("float or integer")}},
+						},
+						Description: Description{
+							Template: "Returns the population standard deviation of float values of %s. If all values are null, then returns %s.",
+							Values:   []Element{Link("value"), Null("NULL")},
+						},
+					},
+					{
+						Name: "stddev_samp",
+						Group: []Grammar{
+							{Function{Name: "STDDEV_SAMP", Args: []Element{Option{Keyword("DISTINCT")}, Link("value")}, AfterArgs: []Element{Keyword("OVER"), Parentheses{Option{Link("partition_clause")}, Option{Link("order_by_clause"), Option{Link("windowing_clause")}}}}, Return: Return("float or integer")}},
+						},
+						Description: Description{
+							Template: "Returns the sample standard deviation of float values of %s. If there are less than two values that are not null, then returns %s.",
+							Values:   []Element{Link("value"), Null("NULL")},
+						},
+					},
+					{
+						Name: "var_pop",
+						Group: []Grammar{
+							{Function{Name: "VAR_POP", Args: []Element{Option{Keyword("DISTINCT")}, Link("value")}, AfterArgs: []Element{Keyword("OVER"), Parentheses{Option{Link("partition_clause")}, Option{Link("order_by_clause"), Option{Link("windowing_clause")}}}}, Return: Return("float or integer")}},
+						},
+						Description: Description{
+							Template: "Returns the population variance of float values of %s. If all values are null, then returns %s.",
+							Values:   []Element{Link("value"), Null("NULL")},
+						},
+					},
+					{
+						Name: "var_samp",
+						Group: []Grammar{
+							{Function{Name: "VAR_SAMP", Args: []Element{Option{Keyword("DISTINCT")}, Link("value")}, AfterArgs: []Element{Keyword("OVER"), Parentheses{Option{Link("partition_clause")}, Option{Link("order_by_clause"), Option{Link("windowing_clause")}}}}, Return: Return("float or integer")}},
+						},
+						Description: Description{
+							Template: "Returns the sample variance of float values of %s. If there are less than two values that are not null, then returns %s.",
+							Values:   []Element{Link("value"), Null("NULL")},
+						},
+					},
+					{
+						Name: "bit_and",
+						Group: []Grammar{
+							{Function{Name: "BIT_AND", Args: []Element{Option{Keyword("DISTINCT")}, Link("value")}, AfterArgs: []Element{Keyword("OVER"), Parentheses{Option{Link("partition_clause")}, Option{Link("order_by_clause"), Option{Link("windowing_clause")}}}}, Return: Return("integer")}},
+						},
+						Description: Description{
+							Template: "Returns the bitwise AND of integer values of %s. If there are no integer values, then returns %s.",
+							Values:   []Element{Link("value"), Null("NULL")},
+						},
+					},
+					{
+						Name: "bit_or",
+						Group: []Grammar{
+							{Function{Name: "BIT_OR", Args: []Element{Option{Keyword("DISTINCT")}, Link("value")}, AfterArgs: []Element{Keyword("OVER"), Parentheses{Option{Link("partition_clause")}, Option{Link("order_by_clause"), Option{Link("windowing_clause")}}}}, Return: Return("integer")}},
+						},
+						Description: Description{
+							Template: "Returns the bitwise OR of integer values of %s. If there are no integer values, then returns %s.",
+							Values:   []Element{Link("value"), Null("NULL")},
+						},
+					},
+					{
+						Name: "bit_xor",
+						Group: []Grammar{
+							{Function{Name: "BIT_XOR", Args: []Element{Option{Keyword("DISTINCT")}, Link("value")}, AfterArgs: []Element{Keyword("OVER"), Parentheses{Option{Link("partition_clause")}, Option{Link("order_by_clause"), Option{Link("windowing_clause")}}}}, Return: Return("integer")}},
+						},
+						Description: Description{
+							Template: "Returns the bitwise XOR of integer values of %s. If there are no integer values, then returns %s.",
+							Values:   []Element{Link("value"), Null("NULL")},
+						},
+					},
+					{
+						Name: "bool_and",
+						Group: []Grammar{
+							{Function{Name: "BOOL_AND", Args: []Element{Option{Keyword("DISTINCT")}, Link("value")}, AfterArgs: []Element{Keyword("OVER"), Parentheses{Option{Link("partition_clause")}, Option{Link("order_by_clause"), Option{Link("windowing_clause")}}}}, Return: Return("ternary")}},
+							{Function{Name: "EVERY", Args: []Element{Option{Keyword("DISTINCT")}, Link("value")}, AfterArgs: []Element{Keyword("OVER"), Parentheses{Option{Link("partition_clause")}, Option{Link("order_by_clause"), Option{Link("windowing_clause")}}}}, Return: Return("ternary")}},
+						},
+						Description: Description{
+							Template: "Returns TRUE if all ternary values of %s are TRUE, FALSE if any of them is FALSE, otherwise UNKNOWN. If all values are null, then returns %s.",
+							Values:   []Element{Link("value"), Null("NULL")},
+						},
+					},
+					{
+						Name: "bool_or",
+						Group: []Grammar{
+							{Function{Name: "BOOL_OR", Args: []Element{Option{Keyword("DISTINCT")}, Link("value")}, AfterArgs: []Element{Keyword("OVER"), Parentheses{Option{Link("partition_clause")}, Option{Link("order_by_clause"), Option{Link("windowing_clause")}}}}, Return: Return("ternary")}},
+						},
+						Description: Description{
+							Template: "Returns TRUE if any ternary value of %s is TRUE, FALSE if all of them are FALSE, otherwise UNKNOWN. If all values are null, then returns %s.",
+							Values:   []Element{Link("value"), Null("NULL")},
+						},
+					},
+					{
+						Name: "checksum_agg",
+						Group: []Grammar{
+							{Function{Name: "CHECKSUM_AGG", Args: []Element{Option{Keyword("DISTINCT")}, Link("value")}, AfterArgs: []Element{Keyword("OVER"), Parentheses{Option{Link("partition_clause")}, Option{Link("order_by_clause"), Option{Link("windowing_clause")}}}}, Return: Return("integer")}},
+							{Function{Name: "HASH_AGG", Args: []Element{Option{Keyword("DISTINCT")}, Link("value")}, AfterArgs: []Element{Keyword("OVER"), Parentheses{Option{Link("partition_clause")}, Option{Link("order_by_clause"), Option{Link("windowing_clause")}}}}, Return: Return("integer")}},
+						},
+						Description: Description{
+							Template: "Returns an order-independent checksum of all values of %s including nulls. If there are no values, then returns %s.",
+							Values:   []Element{Link("value"), Null("NULL")},
+						},
+					},
+					{
+						Name: "corr",
+						Group: []Grammar{
+							{Function{Name: "CORR", Args: []Element{Float("expr1"), Float("expr2")}, AfterArgs: []Element{Keyword("OVER"), Parentheses{Option{Link("partition_clause")}, Option{Link("order_by_clause"), Option{Link("windowing_clause")}}}}, Return: Return("float or integer")}},
+						},
+						Description: Description{Template: "Returns the correlation coefficient of the pairs of float values of %s and %s. Pairs in which either value is null are ignored.", Values: []Element{Float("expr1"), Float("expr2")}},
+					},
+					{
+						Name: "covar_pop",
+						Group: []Grammar{
+							{Function{Name: "COVAR_POP", Args: []Element{Float("expr1"), Float("expr2")}, AfterArgs: []Element{Keyword("OVER"), Parentheses{Option{Link("partition_clause")}, Option{Link("order_by_clause"), Option{Link("windowing_clause")}}}}, Return: Return("float or integer")}},
+						},
+						Description: Description{Template: "Returns the population covariance of the pairs of float values of %s and %s. Pairs in which either value is null are ignored.", Values: []Element{Float("expr1"), Float("expr2")}},
+					},
+					{
+						Name: "covar_samp",
+						Group: []Grammar{
+							{Function{Name: "COVAR_SAMP", Args: []Element{Float("expr1"), Float("expr2")}, AfterArgs: []Element{Keyword("OVER"), Parentheses{Option{Link("partition_clause")}, Option{Link("order_by_clause"), Option{Link("windowing_clause")}}}}, Return: Return("float or integer")}},
+						},
+						Description: Description{Template: "Returns the sample covariance of the pairs of float values of %s and %s. Pairs in which either value is null are ignored.", Values: []Element{Float("expr1"), Float("expr2")}},
+					},
+					{
+						Name: "regr_slope",
+						Group: []Grammar{
+							{Function{Name: "REGR_SLOPE", Args: []Element{Float("y"), Float("x")}, AfterArgs: []Element{Keyword("OVER"), Parentheses{Option{Link("partition_clause")}, Option{Link("order_by_clause"), Option{Link("windowing_clause")}}}}, Return: Return("float or integer")}},
+						},
+						Description: Description{Template: "Returns the slope of the least-squares regression line, where %s is the dependent variable and %s is the independent variable. Pairs in which either value is null are ignored.", Values: []Element{Float("y"), Float("x")}},
+					},
+					{
+						Name: "regr_intercept",
+						Group: []Grammar{
+							{Function{Name: "REGR_INTERCEPT", Args: []Element{Float("y"), Float("x")}, AfterArgs: []Element{Keyword("OVER"), Parentheses{Option{Link("partition_clause")}, Option{Link("order_by_clause"), Option{Link("windowing_clause")}}}}, Return: Return("float or integer")}},
+						},
+						Description: Description{Template: "Returns the y-intercept of the least-squares regression line, where %s is the dependent variable and %s is the independent variable. Pairs in which either value is null are ignored.", Values: []Element{Float("y"), Float("x")}},
+					},
 					{
 						Name: "listagg",
 						Group: []Grammar{
@@ -2572,10 +3626,20 @@ var CsvqSyntax = []Expression{
 					{
 						Name: "json_agg",
 						Group: []Grammar{
-							{Function{Name: "JSON_AGG", Args: []Element{Option{Keyword("DISTINCT")}, Link("value")}, AfterArgs: []Element{Keyword("OVER"), Parentheses{Option{Link("partition_clause")}, Option{Link("order_by_clause"), Option{Link("windowing_clause")}}}}, Return: Return("string")}},
+							{Function{Name: "JSON_AGG", Args: []Element{Option{Keyword("DISTINCT")}, Link("value")}, AfterArgs: []Element{Keyword("OVER"), Parentheses{Option{Link("partition_clause")}, Option{Link("order_by_clause"), Option{Link("windowing_clause")}}}}, Return: Return("json")}},
 						},
 						Description: Description{
-							Template: "Returns the string formatted in JSON array of %s.",
+							Template: "Returns the JSON array of %s.",
+							Values:   []Element{Link("value")},
+						},
+					},
+					{
+						Name: "array_agg",
+						Group: []Grammar{
+							{Function{Name: "ARRAY_AGG", Args: []Element{Option{Keyword("DISTINCT")}, Link("value")}, AfterArgs: []Element{Keyword("OVER"), Parentheses{Option{Link("partition_clause")}, Option{Link("order_by_clause"), Option{Link("windowing_clause")}}}}, Return: Return("array")}},
+						},
+						Description: Description{
+							Template: "Returns the array of %s.",
 							Values:   []Element{Link("value")},
 						},
 					},
@@ -2598,8 +3662,12 @@ var CsvqSyntax = []Expression{
 							{
 								Name: "windowing_clause",
 								Group: []Grammar{
-									{Keyword("ROWS"), Link("window_position")},
-									{Keyword("ROWS"), Keyword("BETWEEN"), Link("window_frame_low"), Keyword("AND"), Link("window_frame_high")},
+									{AnyOne{Keyword("ROWS"), Keyword("GROUPS")}, Link("window_position"), Option{Link("window_frame_exclusion")}},
+									{AnyOne{Keyword("ROWS"), Keyword("GROUPS")}, Keyword("BETWEEN"), Link("window_frame_low"), Keyword("AND"), Link("window_frame_high"), Option{Link("window_frame_exclusion")}},
+								},
+								Description: Description{
+									Template: "%s counts offsets in rows, and %s counts offsets in groups of rows that have the same values in %s.",
+									Values:   []Element{Keyword("ROWS"), Keyword("GROUPS"), Link("order_by_clause")},
 								},
 							},
 							{
@@ -2628,6 +3696,20 @@ var CsvqSyntax = []Expression{
 									{Keyword("CURRENT"), Keyword("ROW")},
 								},
 							},
+							{
+								Name: "window_frame_exclusion",
+								Group: []Grammar{
+									{Keyword("EXCLUDE"), Keyword("CURRENT"), Keyword("ROW")},
+									{Keyword("EXCLUDE"), Keyword("GROUP")},
+									{Keyword("EXCLUDE"), Keyword("TIES")},
+									{Keyword("EXCLUDE"), Keyword("NO"), Keyword("OTHERS")},
+								},
+								Description: Description{
+									Template: "Removes the current row, the current row and its peers, or only the peers of the current row from the window frame. " +
+										"Rows that have the same values in %s are peers.",
+									Values: []Element{Link("order_by_clause")},
+								},
+							},
 						},
 					},
 				},
@@ -2753,19 +3835,19 @@ var CsvqSyntax = []Expression{
 				Name: "Reserved Words",
 				Description: Description{
 					Template: "" +
-						"ABSOLUTE ADD AFTER AGGREGATE ALTER ALL AND ANY AS ASC AVG BEFORE BEGIN " +
-						"BETWEEN BREAK BY CASE CHDIR CLOSE COMMIT CONTINUE COUNT CREATE CROSS " +
+						"ABSOLUTE ADD AFTER AGGREGATE ALTER ALL AND ANY ARRAY_AGG AS ASC AVG BEFORE BEGIN " +
+						"BETWEEN BIT_AND BIT_OR BIT_XOR BOOL_AND BOOL_OR BREAK BY CASE CHDIR CHECKSUM_AGG CLOSE COMMIT CONTINUE CORR COUNT COVAR_POP COVAR_SAMP CREATE CROSS " +
 						"CUME_DIST CURRENT CURSOR DECLARE DEFAULT DELETE DENSE_RANK DESC DISPOSE " +
-						"DISTINCT DO DROP DUAL ECHO ELSE ELSEIF END EXCEPT EXECUTE EXISTS " +
+						"DISTINCT DO DROP DUAL ECHO ELSE ELSEIF END EVERY EXCEPT EXECUTE EXISTS EXPLAIN " +
 						"EXIT FALSE FETCH FIRST FIRST_VALUE FOLLOWING FOR FROM FULL FUNCTION " +
-						"GROUP HAVING IF IGNORE IN INNER INSERT INTERSECT INTO IS JOIN " +
+						"GROUP HASH_AGG HAVING IF IGNORE IN INCREMENT INNER INSERT INTERSECT INTO IRR IS JOIN " +
 						"JSON_AGG JSON_OBJECT JSON_ROW JSON_TABLE LAG LAST LAST_VALUE LEAD " +
-						"LEFT LIKE LIMIT LISTAGG MAX MEDIAN MIN NATURAL NEXT NOT NTH_VALUE " +
+						"LEFT LIKE LIMIT LISTAGG MAX MEDIAN MIN MODE NATURAL NEXT NOT NPV NTH_VALUE " +
 						"NTILE NULL OFFSET ON OPEN OR ORDER OUTER OVER PARTITION PERCENT " +
-						"PERCENT_RANK PRECEDING PREPARE PRINT PRINTF PRIOR PWD RANGE RANK RECURSIVE " +
+						"PERCENT_RANK PERCENTILE_CONT PERCENTILE_DISC PRECEDING PREPARE PRINT PRINTF PRIOR PWD RANGE RANK RECURSIVE REGR_INTERCEPT REGR_SLOPE " +
 						"RELATIVE RELOAD REMOVE RENAME RETURN RIGHT ROLLBACK ROW ROW_NUMBER " +
-						"SELECT SEPARATOR SET SHOW SOURCE STDIN SUM SYNTAX TABLE THEN TO TRIGGER TRUE " +
-						"UNBOUNDED UNION UNKNOWN UNSET UPDATE USING VALUES VAR VIEW WHEN WHERE " +
+						"SELECT SEPARATOR SEQUENCE SET SHOW SOURCE START STDDEV_POP STDDEV_SAMP STDIN SUM SYNTAX TABLE THEN TO TRIGGER TRUE " +
+						"UNBOUNDED UNION UNKNOWN UNSET UPDATE USING VALUES VAR VAR_POP VAR_SAMP VIEW WHEN WHERE " +
 						"WHILE WITH WITHIN",
 				},
 			},
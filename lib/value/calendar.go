@@ -0,0 +1,83 @@
+package value
+
+import (
+	"errors"
+	"time"
+)
+
+type calendarNames struct {
+	Months   [12]string
+	Weekdays [7]string
+}
+
+var calendarNamesByLanguage = map[string]calendarNames{
+	"en": {
+		Months:   [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		Weekdays: [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+	},
+	"de": {
+		Months:   [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		Weekdays: [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+	},
+	"es": {
+		Months:   [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		Weekdays: [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+	},
+	"fr": {
+		Months:   [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		Weekdays: [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+	},
+	"it": {
+		Months:   [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+		Weekdays: [7]string{"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+	},
+	"ja": {
+		Months:   [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		Weekdays: [7]string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"},
+	},
+	"pt": {
+		Months:   [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+		Weekdays: [7]string{"domingo", "segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira", "sábado"},
+	},
+	"zh": {
+		Months:   [12]string{"一月", "二月", "三月", "四月", "五月", "六月", "七月", "八月", "九月", "十月", "十一月", "十二月"},
+		Weekdays: [7]string{"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"},
+	},
+}
+
+func getCalendarNames(locale string) (calendarNames, error) {
+	if len(locale) < 1 {
+		return calendarNamesByLanguage["en"], nil
+	}
+
+	tag, err := parseLocale(locale)
+	if err != nil {
+		return calendarNames{}, err
+	}
+	base, _ := tag.Base()
+	names, ok := calendarNamesByLanguage[base.String()]
+	if !ok {
+		return calendarNames{}, errors.New("locale " + locale + " is not supported")
+	}
+	return names, nil
+}
+
+// MonthName returns the name of the month in the language of the locale.
+// An empty locale is treated as English.
+func MonthName(m time.Month, locale string) (string, error) {
+	names, err := getCalendarNames(locale)
+	if err != nil {
+		return "", err
+	}
+	return names.Months[m-1], nil
+}
+
+// WeekdayName returns the name of the day of the week in the language of the locale.
+// An empty locale is treated as English.
+func WeekdayName(d time.Weekday, locale string) (string, error) {
+	names, err := getCalendarNames(locale)
+	if err != nil {
+		return "", err
+	}
+	return names.Weekdays[d], nil
+}
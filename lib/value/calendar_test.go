@@ -0,0 +1,110 @@
+package value
+
+import (
+	"testing"
+	"time"
+)
+
+var monthNameTests = []struct {
+	Month  time.Month
+	Locale string
+	Result string
+	Error  bool
+}{
+	{
+		Month:  time.February,
+		Locale: "",
+		Result: "February",
+	},
+	{
+		Month:  time.February,
+		Locale: "fr",
+		Result: "février",
+	},
+	{
+		Month:  time.December,
+		Locale: "ja_JP",
+		Result: "12月",
+	},
+	{
+		Month:  time.August,
+		Locale: "pt-BR",
+		Result: "agosto",
+	},
+	{
+		Month:  time.August,
+		Locale: "sw",
+		Error:  true,
+	},
+	{
+		Month:  time.August,
+		Locale: "not a locale",
+		Error:  true,
+	},
+}
+
+func TestMonthName(t *testing.T) {
+	for _, v := range monthNameTests {
+		result, err := MonthName(v.Month, v.Locale)
+		if err != nil {
+			if !v.Error {
+				t.Errorf("unexpected error %q for %s, %q", err, v.Month, v.Locale)
+			}
+			continue
+		}
+		if v.Error {
+			t.Errorf("no error, want error for %s, %q", v.Month, v.Locale)
+			continue
+		}
+		if result != v.Result {
+			t.Errorf("result = %q, want %q for %s, %q", result, v.Result, v.Month, v.Locale)
+		}
+	}
+}
+
+var weekdayNameTests = []struct {
+	Weekday time.Weekday
+	Locale  string
+	Result  string
+	Error   bool
+}{
+	{
+		Weekday: time.Friday,
+		Locale:  "",
+		Result:  "Friday",
+	},
+	{
+		Weekday: time.Sunday,
+		Locale:  "de-DE",
+		Result:  "Sonntag",
+	},
+	{
+		Weekday: time.Saturday,
+		Locale:  "zh_TW",
+		Result:  "星期六",
+	},
+	{
+		Weekday: time.Saturday,
+		Locale:  "sw",
+		Error:   true,
+	},
+}
+
+func TestWeekdayName(t *testing.T) {
+	for _, v := range weekdayNameTests {
+		result, err := WeekdayName(v.Weekday, v.Locale)
+		if err != nil {
+			if !v.Error {
+				t.Errorf("unexpected error %q for %s, %q", err, v.Weekday, v.Locale)
+			}
+			continue
+		}
+		if v.Error {
+			t.Errorf("no error, want error for %s, %q", v.Weekday, v.Locale)
+			continue
+		}
+		if result != v.Result {
+			t.Errorf("result = %q, want %q for %s, %q", result, v.Result, v.Weekday, v.Locale)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package value
+
+import (
+	"errors"
+	"strings"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+func parseLocale(locale string) (language.Tag, error) {
+	tag, err := language.Parse(strings.Replace(locale, "_", "-", -1))
+	if err != nil {
+		return tag, errors.New("locale " + locale + " is not valid")
+	}
+	return tag, nil
+}
+
+func mapCase(s string, locale string, caserf func(language.Tag) cases.Caser) (string, error) {
+	tag := language.Und
+	if 0 < len(locale) {
+		t, err := parseLocale(locale)
+		if err != nil {
+			return "", err
+		}
+		tag = t
+	}
+	return caserf(tag).String(s), nil
+}
+
+// ToUpperCase returns s with all letters mapped to their upper case
+// according to the rules of the language of the locale.
+func ToUpperCase(s string, locale string) (string, error) {
+	return mapCase(s, locale, func(tag language.Tag) cases.Caser { return cases.Upper(tag) })
+}
+
+// ToLowerCase returns s with all letters mapped to their lower case
+// according to the rules of the language of the locale.
+func ToLowerCase(s string, locale string) (string, error) {
+	return mapCase(s, locale, func(tag language.Tag) cases.Caser { return cases.Lower(tag) })
+}
+
+// ToTitleCase returns s with the first letter of each word mapped to its
+// title case and the rest of the letters mapped to their lower case
+// according to the rules of the language of the locale.
+func ToTitleCase(s string, locale string) (string, error) {
+	return mapCase(s, locale, func(tag language.Tag) cases.Caser { return cases.Title(tag) })
+}
@@ -0,0 +1,76 @@
+package value
+
+import (
+	"testing"
+)
+
+var caseMappingTests = []struct {
+	Name   string
+	Func   func(string, string) (string, error)
+	Str    string
+	Locale string
+	Result string
+	Error  string
+}{
+	{
+		Name:   "ToUpperCase",
+		Func:   ToUpperCase,
+		Str:    "istanbul",
+		Result: "ISTANBUL",
+	},
+	{
+		Name:   "ToUpperCase Turkish",
+		Func:   ToUpperCase,
+		Str:    "istanbul",
+		Locale: "tr",
+		Result: "İSTANBUL",
+	},
+	{
+		Name:   "ToLowerCase Turkish",
+		Func:   ToLowerCase,
+		Str:    "DİYARBAKIR",
+		Locale: "tr_TR",
+		Result: "diyarbakır",
+	},
+	{
+		Name:   "ToTitleCase",
+		Func:   ToTitleCase,
+		Str:    "hELLO wORLD",
+		Result: "Hello World",
+	},
+	{
+		Name:   "ToTitleCase Dutch",
+		Func:   ToTitleCase,
+		Str:    "ijsselmeer",
+		Locale: "nl",
+		Result: "IJsselmeer",
+	},
+	{
+		Name:   "Invalid Locale",
+		Func:   ToUpperCase,
+		Str:    "abc",
+		Locale: "x!",
+		Error:  "locale x! is not valid",
+	},
+}
+
+func TestCaseMapping(t *testing.T) {
+	for _, v := range caseMappingTests {
+		result, err := v.Func(v.Str, v.Locale)
+		if err != nil {
+			if len(v.Error) < 1 {
+				t.Errorf("%s: unexpected error %q", v.Name, err)
+			} else if err.Error() != v.Error {
+				t.Errorf("%s: error %q, want error %q", v.Name, err.Error(), v.Error)
+			}
+			continue
+		}
+		if 0 < len(v.Error) {
+			t.Errorf("%s: no error, want error %q", v.Name, v.Error)
+			continue
+		}
+		if result != v.Result {
+			t.Errorf("%s: result = %q, want %q", v.Name, result, v.Result)
+		}
+	}
+}
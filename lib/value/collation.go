@@ -0,0 +1,71 @@
+package value
+
+import (
+	"strings"
+	"sync"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+var collations = make(map[string]*Collation)
+var collationsMutex = &sync.Mutex{}
+
+// CaseSensitiveCollation compares strings by their code points without ignoring letter cases.
+var CaseSensitiveCollation = &Collation{
+	Name:  "CASE_SENSITIVE",
+	mutex: &sync.Mutex{},
+}
+
+// Collation compares strings by the rules of a language.
+// Letter cases are ignored in the same way as the default string comparison.
+type Collation struct {
+	Name string
+
+	collator *collate.Collator
+	mutex    *sync.Mutex
+}
+
+func GetCollation(name string) (*Collation, error) {
+	key := strings.ToUpper(name)
+
+	collationsMutex.Lock()
+	defer collationsMutex.Unlock()
+
+	if c, ok := collations[key]; ok {
+		return c, nil
+	}
+
+	tag, err := language.Parse(strings.Replace(name, "_", "-", -1))
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Collation{
+		Name:     name,
+		collator: collate.New(tag, collate.IgnoreCase),
+		mutex:    &sync.Mutex{},
+	}
+	collations[key] = c
+	return c, nil
+}
+
+func (c *Collation) CompareString(s1 string, s2 string) int {
+	if c.collator == nil {
+		return strings.Compare(s1, s2)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.collator.CompareString(s1, s2)
+}
+
+func (c *Collation) Key(s string) string {
+	if c.collator == nil {
+		return s
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return string(c.collator.KeyFromString(&collate.Buffer{}, s))
+}
@@ -0,0 +1,53 @@
+package value
+
+import (
+	"testing"
+)
+
+func TestGetCollation(t *testing.T) {
+	c, err := GetCollation("sv_SE")
+	if err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	if c.Name != "sv_SE" {
+		t.Errorf("name = %q, want %q", c.Name, "sv_SE")
+	}
+
+	c2, _ := GetCollation("SV_se")
+	if c != c2 {
+		t.Errorf("collation is not cached")
+	}
+
+	if _, err = GetCollation("!!"); err == nil {
+		t.Errorf("no error, want error for %q", "!!")
+	}
+}
+
+func TestCollation_CompareString(t *testing.T) {
+	de, _ := GetCollation("de")
+	sv, _ := GetCollation("sv")
+
+	if r := de.CompareString("Ä", "Z"); r != -1 {
+		t.Errorf("result = %d, want %d for %q and %q in %s", r, -1, "Ä", "Z", de.Name)
+	}
+	if r := sv.CompareString("Ä", "Z"); r != 1 {
+		t.Errorf("result = %d, want %d for %q and %q in %s", r, 1, "Ä", "Z", sv.Name)
+	}
+	if r := de.CompareString("abc", "ABC"); r != 0 {
+		t.Errorf("result = %d, want %d for %q and %q in %s", r, 0, "abc", "ABC", de.Name)
+	}
+	if r := CaseSensitiveCollation.CompareString("abc", "ABC"); r != 1 {
+		t.Errorf("result = %d, want %d for %q and %q in %s", r, 1, "abc", "ABC", CaseSensitiveCollation.Name)
+	}
+}
+
+func TestCollation_Key(t *testing.T) {
+	de, _ := GetCollation("de")
+
+	if !(de.Key("Äpfel") < de.Key("Birne")) {
+		t.Errorf("key of %q is not less than key of %q", "Äpfel", "Birne")
+	}
+	if de.Key("apfel") != de.Key("APFEL") {
+		t.Errorf("key of %q is not equal to key of %q", "apfel", "APFEL")
+	}
+}
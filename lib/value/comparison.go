@@ -32,10 +32,31 @@ func (cr ComparisonResult) String() string {
 }
 
 func CompareCombinedly(p1 Primary, p2 Primary, datetimeFormats []string) ComparisonResult {
+	return compareCombinedly(p1, p2, datetimeFormats, nil)
+}
+
+func compareCombinedly(p1 Primary, p2 Primary, datetimeFormats []string, collation *Collation) ComparisonResult {
 	if IsNull(p1) || IsNull(p2) {
 		return IsIncommensurable
 	}
 
+	if j, ok := p1.(Json); ok {
+		p1 = NewString(j.String())
+	}
+	if j, ok := p2.(Json); ok {
+		p2 = NewString(j.String())
+	}
+
+	if a1, ok := p1.(Array); ok {
+		if a2, ok := p2.(Array); ok {
+			return compareArrays(a1, a2, datetimeFormats)
+		}
+		return IsIncommensurable
+	}
+	if _, ok := p2.(Array); ok {
+		return IsIncommensurable
+	}
+
 	if i1 := ToInteger(p1); !IsNull(i1) {
 		if i2 := ToInteger(p2); !IsNull(i2) {
 			v1 := i1.(Integer).Raw()
@@ -92,6 +113,17 @@ func CompareCombinedly(p1 Primary, p2 Primary, datetimeFormats []string) Compari
 
 	if s1, ok := p1.(String); ok {
 		if s2, ok := p2.(String); ok {
+			if collation != nil {
+				switch collation.CompareString(strings.TrimSpace(s1.Raw()), strings.TrimSpace(s2.Raw())) {
+				case 0:
+					return IsEqual
+				case -1:
+					return IsLess
+				default:
+					return IsGreater
+				}
+			}
+
 			v1 := strings.ToUpper(strings.TrimSpace(s1.Raw()))
 			v2 := strings.ToUpper(strings.TrimSpace(s2.Raw()))
 
@@ -108,6 +140,21 @@ func CompareCombinedly(p1 Primary, p2 Primary, datetimeFormats []string) Compari
 	return IsIncommensurable
 }
 
+func compareArrays(a1 Array, a2 Array, datetimeFormats []string) ComparisonResult {
+	if a1.Len() != a2.Len() {
+		return IsNotEqual
+	}
+	for i := range a1.values {
+		if IsNull(a1.values[i]) && IsNull(a2.values[i]) {
+			continue
+		}
+		if r := CompareCombinedly(a1.values[i], a2.values[i], datetimeFormats); r != IsEqual && r != IsBoolEqual {
+			return IsNotEqual
+		}
+	}
+	return IsEqual
+}
+
 func Identical(p1 Primary, p2 Primary) ternary.Value {
 	if t, ok := p1.(Ternary); (ok && t.value == ternary.UNKNOWN) || IsNull(p1) {
 		return ternary.UNKNOWN
@@ -152,6 +199,29 @@ func Identical(p1 Primary, p2 Primary) ternary.Value {
 		}
 	}
 
+	if v1, ok := p1.(Json); ok {
+		if v2, ok := p2.(Json); ok {
+			return ternary.ConvertFromBool(v1.String() == v2.String())
+		}
+	}
+
+	if v1, ok := p1.(Array); ok {
+		if v2, ok := p2.(Array); ok {
+			if v1.Len() != v2.Len() {
+				return ternary.FALSE
+			}
+			for i := range v1.values {
+				if IsNull(v1.values[i]) && IsNull(v2.values[i]) {
+					continue
+				}
+				if Identical(v1.values[i], v2.values[i]) != ternary.TRUE {
+					return ternary.FALSE
+				}
+			}
+			return ternary.TRUE
+		}
+	}
+
 	return ternary.FALSE
 }
 
@@ -217,6 +287,10 @@ func Compare(p1 Primary, p2 Primary, operator string, datetimeFormats []string)
 }
 
 func CompareRowValues(rowValue1 RowValue, rowValue2 RowValue, operator string, datetimeFormats []string) (ternary.Value, error) {
+	return CompareRowValuesWithCollation(rowValue1, rowValue2, operator, datetimeFormats, nil)
+}
+
+func CompareRowValuesWithCollation(rowValue1 RowValue, rowValue2 RowValue, operator string, datetimeFormats []string, collation *Collation) (ternary.Value, error) {
 	if rowValue1 == nil || rowValue2 == nil {
 		return ternary.UNKNOWN, nil
 	}
@@ -238,7 +312,7 @@ func CompareRowValues(rowValue1 RowValue, rowValue2 RowValue, operator string, d
 			continue
 		}
 
-		r := CompareCombinedly(rowValue1[i], rowValue2[i], datetimeFormats)
+		r := compareCombinedly(rowValue1[i], rowValue2[i], datetimeFormats, collation)
 
 		if r == IsIncommensurable {
 			switch operator {
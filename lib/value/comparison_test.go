@@ -17,6 +17,21 @@ var compareCombinedlyTests = []struct {
 	RHS    Primary
 	Result ComparisonResult
 }{
+	{
+		LHS:    NewArray([]Primary{NewInteger(1), NewString("a"), NewNull()}),
+		RHS:    NewArray([]Primary{NewString("1"), NewString("A"), NewNull()}),
+		Result: IsEqual,
+	},
+	{
+		LHS:    NewArray([]Primary{NewInteger(1), NewString("a")}),
+		RHS:    NewArray([]Primary{NewInteger(1)}),
+		Result: IsNotEqual,
+	},
+	{
+		LHS:    NewArray([]Primary{NewInteger(1)}),
+		RHS:    NewInteger(1),
+		Result: IsIncommensurable,
+	},
 	{
 		LHS:    NewInteger(1),
 		RHS:    NewNull(),
@@ -668,6 +683,53 @@ func TestCompareRowValues(t *testing.T) {
 	}
 }
 
+var compareRowValuesWithCollationTests = []struct {
+	LHS    RowValue
+	RHS    RowValue
+	Op     string
+	Result ternary.Value
+}{
+	{
+		LHS:    RowValue{NewString("Äpfel")},
+		RHS:    RowValue{NewString("Birne")},
+		Op:     "<",
+		Result: ternary.TRUE,
+	},
+	{
+		LHS:    RowValue{NewString("apfel ")},
+		RHS:    RowValue{NewString("APFEL")},
+		Op:     "=",
+		Result: ternary.TRUE,
+	},
+	{
+		LHS:    RowValue{NewString("Äpfel"), NewInteger(1)},
+		RHS:    RowValue{NewString("Apfel"), NewInteger(1)},
+		Op:     "<>",
+		Result: ternary.TRUE,
+	},
+	{
+		LHS:    RowValue{NewString("10")},
+		RHS:    RowValue{NewString("9")},
+		Op:     ">",
+		Result: ternary.TRUE,
+	},
+}
+
+func TestCompareRowValuesWithCollation(t *testing.T) {
+	collation, _ := GetCollation("de_DE")
+
+	for _, v := range compareRowValuesWithCollationTests {
+		r, err := CompareRowValuesWithCollation(v.LHS, v.RHS, v.Op, nil, collation)
+		if err != nil {
+			t.Errorf("unexpected error %q for (%s %s %s)", err, v.LHS, v.Op, v.RHS)
+			continue
+		}
+		if r != v.Result {
+			t.Errorf("result = %s, want %s for (%s %s %s)", r, v.Result, v.LHS, v.Op, v.RHS)
+		}
+	}
+}
+
 var equivalentToTests = []struct {
 	LHS    Primary
 	RHS    Primary
@@ -360,6 +360,8 @@ func ToString(p Primary) Primary {
 		return NewString(Int64ToStr(p.(Integer).Raw()))
 	case Float:
 		return NewString(Float64ToStr(p.(Float).Raw()))
+	case Json:
+		return NewString(p.(Json).String())
 	}
 	return NewNull()
 }
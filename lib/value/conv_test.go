@@ -1,11 +1,13 @@
 package value
 
 import (
+	"reflect"
 	"testing"
 	"time"
 
 	"github.com/mithrandie/csvq/lib/cmd"
 
+	"github.com/mithrandie/go-text/json"
 	"github.com/mithrandie/ternary"
 )
 
@@ -511,6 +513,12 @@ func TestToString(t *testing.T) {
 		t.Errorf("primary type = %T, want String for %#v", s, p)
 	}
 
+	p = NewJson(json.Array{json.Integer(1)})
+	s = ToString(p)
+	if !reflect.DeepEqual(s, NewString("[1]")) {
+		t.Errorf("result = %#v, want %#v for %#v", s, NewString("[1]"), p)
+	}
+
 	p = NewDatetimeFromString("2006-01-02 15:04:05", nil)
 	s = ToString(p)
 	if _, ok := s.(Null); !ok {
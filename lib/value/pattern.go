@@ -0,0 +1,323 @@
+package value
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var datetimePatternFields = []string{
+	"HH24", "HH12", "YYYY", "MONTH", "FF1", "FF2", "FF3", "FF4", "FF5", "FF6", "FF7", "FF8", "FF9",
+	"MON", "DDD", "DAY", "TZH", "TZM",
+	"HH", "MI", "SS", "MS", "US", "AM", "PM", "YY", "MM", "DD", "DY", "IW", "TZ", "FM",
+	"D", "Q",
+}
+
+func matchDatetimePatternField(runes []rune) string {
+	for _, f := range datetimePatternFields {
+		if len(f) <= len(runes) && strings.EqualFold(string(runes[:len(f)]), f) {
+			return f
+		}
+	}
+	return ""
+}
+
+func applyLetterCase(s string, pattern []rune) string {
+	switch {
+	case unicode.IsLower(pattern[0]):
+		return strings.ToLower(s)
+	case 1 < len(pattern) && unicode.IsLower(pattern[1]):
+		return strings.ToUpper(s[:1]) + strings.ToLower(s[1:])
+	default:
+		return strings.ToUpper(s)
+	}
+}
+
+func padNumber(i int, digits int, fillMode bool) string {
+	s := strconv.Itoa(i)
+	if !fillMode && len(s) < digits {
+		s = strings.Repeat("0", digits-len(s)) + s
+	}
+	return s
+}
+
+func padName(s string, fillMode bool) string {
+	if !fillMode && len(s) < 9 {
+		s = s + strings.Repeat(" ", 9-len(s))
+	}
+	return s
+}
+
+// FormatTimeByPattern formats t with a pattern such as "YYYY-MM-DD HH24:MI:SS"
+// used by the TO_CHAR function of Oracle and PostgreSQL.
+func FormatTimeByPattern(t time.Time, pattern string) string {
+	runes := []rune(pattern)
+	var buf strings.Builder
+
+	fillMode := false
+	for i := 0; i < len(runes); {
+		if runes[i] == '"' {
+			i++
+			for i < len(runes) && runes[i] != '"' {
+				buf.WriteRune(runes[i])
+				i++
+			}
+			i++
+			continue
+		}
+
+		field := matchDatetimePatternField(runes[i:])
+		if len(field) < 1 {
+			buf.WriteRune(runes[i])
+			i++
+			continue
+		}
+		src := runes[i : i+len(field)]
+		i += len(field)
+
+		if field == "FM" {
+			fillMode = true
+			continue
+		}
+
+		switch field {
+		case "YYYY":
+			buf.WriteString(padNumber(t.Year(), 4, fillMode))
+		case "YY":
+			buf.WriteString(padNumber(t.Year()%100, 2, fillMode))
+		case "Q":
+			buf.WriteString(strconv.Itoa((int(t.Month())-1)/3 + 1))
+		case "MM":
+			buf.WriteString(padNumber(int(t.Month()), 2, fillMode))
+		case "MONTH":
+			buf.WriteString(padName(applyLetterCase(t.Month().String(), src), fillMode))
+		case "MON":
+			buf.WriteString(applyLetterCase(t.Month().String()[:3], src))
+		case "IW":
+			_, week := t.ISOWeek()
+			buf.WriteString(padNumber(week, 2, fillMode))
+		case "DDD":
+			buf.WriteString(padNumber(t.YearDay(), 3, fillMode))
+		case "DD":
+			buf.WriteString(padNumber(t.Day(), 2, fillMode))
+		case "D":
+			buf.WriteString(strconv.Itoa(int(t.Weekday()) + 1))
+		case "DAY":
+			buf.WriteString(padName(applyLetterCase(t.Weekday().String(), src), fillMode))
+		case "DY":
+			buf.WriteString(applyLetterCase(t.Weekday().String()[:3], src))
+		case "HH24":
+			buf.WriteString(padNumber(t.Hour(), 2, fillMode))
+		case "HH", "HH12":
+			h := t.Hour() % 12
+			if h == 0 {
+				h = 12
+			}
+			buf.WriteString(padNumber(h, 2, fillMode))
+		case "MI":
+			buf.WriteString(padNumber(t.Minute(), 2, fillMode))
+		case "SS":
+			buf.WriteString(padNumber(t.Second(), 2, fillMode))
+		case "MS":
+			buf.WriteString(padNumber(t.Nanosecond()/1e6, 3, false))
+		case "US":
+			buf.WriteString(padNumber(t.Nanosecond()/1e3, 6, false))
+		case "AM", "PM":
+			if t.Hour() < 12 {
+				buf.WriteString(applyLetterCase("AM", src))
+			} else {
+				buf.WriteString(applyLetterCase("PM", src))
+			}
+		case "TZH", "TZM":
+			_, offset := t.Zone()
+			sign := "+"
+			if offset < 0 {
+				sign = "-"
+				offset = -offset
+			}
+			if field == "TZH" {
+				buf.WriteString(sign + padNumber(offset/3600, 2, false))
+			} else {
+				buf.WriteString(padNumber(offset%3600/60, 2, false))
+			}
+		case "TZ":
+			name, _ := t.Zone()
+			buf.WriteString(applyLetterCase(name, src))
+		default: // FF1 - FF9
+			digits := int(field[2] - '0')
+			buf.WriteString(padNumber(t.Nanosecond(), 9, false)[:digits])
+		}
+		fillMode = false
+	}
+
+	return buf.String()
+}
+
+const (
+	numberSignDefault = iota
+	numberSignLeading
+	numberSignTrailing
+	numberSignMinus
+)
+
+// FormatNumberByPattern formats f with a pattern such as "9,999.00" used by
+// the TO_CHAR function of Oracle and PostgreSQL.
+func FormatNumberByPattern(f float64, pattern string) (string, error) {
+	runes := []rune(strings.ToUpper(pattern))
+
+	fillMode := false
+	signMode := numberSignDefault
+	currency := false
+	hasPoint := false
+	intMask := make([]rune, 0, len(runes))
+	fracMask := make([]rune, 0, len(runes))
+
+	invalid := func(i int) error {
+		return errors.New(fmt.Sprintf("invalid numeric format pattern %q at position %d", pattern, i+1))
+	}
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == 'F' && i+1 < len(runes) && runes[i+1] == 'M' && len(intMask) < 1 && !hasPoint:
+			fillMode = true
+			i++
+		case r == 'S' && len(intMask) < 1 && !hasPoint && signMode == numberSignDefault:
+			signMode = numberSignLeading
+		case r == 'S' && i == len(runes)-1 && signMode == numberSignDefault:
+			signMode = numberSignTrailing
+		case r == 'M' && i == len(runes)-2 && runes[i+1] == 'I' && signMode == numberSignDefault:
+			signMode = numberSignMinus
+			i++
+		case r == '$' && len(intMask) < 1 && !hasPoint && !currency:
+			currency = true
+		case r == '9' || r == '0':
+			if hasPoint {
+				fracMask = append(fracMask, r)
+			} else {
+				intMask = append(intMask, r)
+			}
+		case (r == ',' || r == 'G') && !hasPoint && 0 < len(intMask):
+			intMask = append(intMask, ',')
+		case (r == '.' || r == 'D') && !hasPoint:
+			hasPoint = true
+		default:
+			return "", invalid(i)
+		}
+	}
+
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return "", errors.New("the number cannot be formatted")
+	}
+
+	digitLen := 0
+	firstZero := len(intMask)
+	for i, r := range intMask {
+		if r == ',' {
+			continue
+		}
+		digitLen++
+		if r == '0' && firstZero == len(intMask) {
+			firstZero = i
+		}
+	}
+
+	scale := math.Pow(10, float64(len(fracMask)))
+	abs := math.Round(math.Abs(f)*scale) / scale
+	digits := strings.SplitN(strconv.FormatFloat(abs, 'f', len(fracMask), 64), ".", 2)
+	intDigits := digits[0]
+	fracDigits := ""
+	if 1 < len(digits) {
+		fracDigits = digits[1]
+	}
+	if intDigits == "0" && (hasPoint || digitLen < 1) {
+		intDigits = ""
+	}
+
+	overflow := digitLen < len(intDigits)
+
+	intPart := make([]rune, len(intMask))
+	di := len(intDigits) - 1
+	padLen := 0
+	for i := len(intMask) - 1; 0 <= i; i-- {
+		switch {
+		case overflow:
+			if intMask[i] == ',' {
+				intPart[i] = ','
+			} else {
+				intPart[i] = '#'
+			}
+		case intMask[i] == ',':
+			if 0 <= di || firstZero < i {
+				intPart[i] = ','
+			} else {
+				intPart[i] = ' '
+				padLen++
+			}
+		case 0 <= di:
+			intPart[i] = rune(intDigits[di])
+			di--
+		case firstZero <= i:
+			intPart[i] = '0'
+		default:
+			intPart[i] = ' '
+			padLen++
+		}
+	}
+
+	fracPart := []rune(fracDigits)
+	if overflow {
+		fracPart = []rune(strings.Repeat("#", len(fracMask)))
+	} else if fillMode {
+		for 0 < len(fracPart) && fracMask[len(fracPart)-1] == '9' && fracPart[len(fracPart)-1] == '0' {
+			fracPart = fracPart[:len(fracPart)-1]
+		}
+	}
+
+	negative := f < 0 && 0 < abs
+	sign := ""
+	switch signMode {
+	case numberSignDefault:
+		if negative {
+			sign = "-"
+		} else if !fillMode {
+			sign = " "
+		}
+	case numberSignLeading, numberSignTrailing:
+		if negative {
+			sign = "-"
+		} else {
+			sign = "+"
+		}
+	case numberSignMinus:
+		if negative {
+			sign = "-"
+		} else if !fillMode {
+			sign = " "
+		}
+	}
+
+	var buf strings.Builder
+	if !fillMode {
+		buf.WriteString(strings.Repeat(" ", padLen))
+	}
+	if signMode == numberSignDefault || signMode == numberSignLeading {
+		buf.WriteString(sign)
+	}
+	if currency {
+		buf.WriteRune('$')
+	}
+	buf.WriteString(string(intPart[padLen:]))
+	if hasPoint {
+		buf.WriteRune('.')
+		buf.WriteString(string(fracPart))
+	}
+	if signMode == numberSignTrailing || signMode == numberSignMinus {
+		buf.WriteString(sign)
+	}
+	return buf.String(), nil
+}
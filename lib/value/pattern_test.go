@@ -0,0 +1,164 @@
+package value
+
+import (
+	"testing"
+	"time"
+)
+
+var formatTimeByPatternTests = []struct {
+	Pattern string
+	Result  string
+}{
+	{
+		Pattern: "YYYY-MM-DD HH24:MI:SS",
+		Result:  "2012-02-03 21:08:05",
+	},
+	{
+		Pattern: "yy/mm/dd hh12:mi:ss am",
+		Result:  "12/02/03 09:08:05 pm",
+	},
+	{
+		Pattern: "Day, Month DD",
+		Result:  "Friday   , February  03",
+	},
+	{
+		Pattern: "FMDay, FMMonth FMDD",
+		Result:  "Friday, February 3",
+	},
+	{
+		Pattern: "DY Dy dy MON Mon mon",
+		Result:  "FRI Fri fri FEB Feb feb",
+	},
+	{
+		Pattern: "DDD D IW \"Q\"Q",
+		Result:  "034 6 05 Q1",
+	},
+	{
+		Pattern: "SS.MS SS.US SS.FF2",
+		Result:  "05.123 05.123456 05.12",
+	},
+	{
+		Pattern: "TZH:TZM TZ",
+		Result:  "+09:00 JST",
+	},
+	{
+		Pattern: "\"Year\" YYYY",
+		Result:  "Year 2012",
+	},
+}
+
+func TestFormatTimeByPattern(t *testing.T) {
+	loc := time.FixedZone("JST", 9*60*60)
+	tm := time.Date(2012, 2, 3, 21, 8, 5, 123456789, loc)
+
+	for _, v := range formatTimeByPatternTests {
+		result := FormatTimeByPattern(tm, v.Pattern)
+		if result != v.Result {
+			t.Errorf("result = %q, want %q for %q", result, v.Result, v.Pattern)
+		}
+	}
+}
+
+var formatNumberByPatternTests = []struct {
+	Number  float64
+	Pattern string
+	Result  string
+	Error   string
+}{
+	{
+		Number:  1234.5,
+		Pattern: "9,999.00",
+		Result:  " 1,234.50",
+	},
+	{
+		Number:  -12,
+		Pattern: "9,999",
+		Result:  "   -12",
+	},
+	{
+		Number:  12,
+		Pattern: "0999",
+		Result:  " 0012",
+	},
+	{
+		Number:  0.5,
+		Pattern: "9.99",
+		Result:  "  .50",
+	},
+	{
+		Number:  0.5,
+		Pattern: "0.99",
+		Result:  " 0.50",
+	},
+	{
+		Number:  0,
+		Pattern: "999",
+		Result:  "   0",
+	},
+	{
+		Number:  2.5,
+		Pattern: "9",
+		Result:  " 3",
+	},
+	{
+		Number:  1.5,
+		Pattern: "FM9.99",
+		Result:  "1.5",
+	},
+	{
+		Number:  1234567.891,
+		Pattern: "FM$9,999,999.00",
+		Result:  "$1,234,567.89",
+	},
+	{
+		Number:  485,
+		Pattern: "S999",
+		Result:  "+485",
+	},
+	{
+		Number:  -485,
+		Pattern: "999S",
+		Result:  "485-",
+	},
+	{
+		Number:  -485,
+		Pattern: "999MI",
+		Result:  "485-",
+	},
+	{
+		Number:  485,
+		Pattern: "FM999MI",
+		Result:  "485",
+	},
+	{
+		Number:  12345,
+		Pattern: "9,999.9",
+		Result:  " #,###.#",
+	},
+	{
+		Number:  1,
+		Pattern: "9X9",
+		Error:   "invalid numeric format pattern \"9X9\" at position 2",
+	},
+}
+
+func TestFormatNumberByPattern(t *testing.T) {
+	for _, v := range formatNumberByPatternTests {
+		result, err := FormatNumberByPattern(v.Number, v.Pattern)
+		if err != nil {
+			if len(v.Error) < 1 {
+				t.Errorf("unexpected error %q for %f, %q", err, v.Number, v.Pattern)
+			} else if err.Error() != v.Error {
+				t.Errorf("error %q, want error %q for %f, %q", err.Error(), v.Error, v.Number, v.Pattern)
+			}
+			continue
+		}
+		if 0 < len(v.Error) {
+			t.Errorf("no error, want error %q for %f, %q", v.Error, v.Number, v.Pattern)
+			continue
+		}
+		if result != v.Result {
+			t.Errorf("result = %q, want %q for %f, %q", result, v.Result, v.Number, v.Pattern)
+		}
+	}
+}
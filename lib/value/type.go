@@ -7,6 +7,7 @@ import (
 
 	"github.com/mithrandie/csvq/lib/cmd"
 
+	"github.com/mithrandie/go-text/json"
 	"github.com/mithrandie/ternary"
 )
 
@@ -200,6 +201,61 @@ func (dt Datetime) Format(s string) string {
 	return dt.value.Format(s)
 }
 
+type Array struct {
+	values []Primary
+}
+
+func NewArray(values []Primary) Array {
+	return Array{
+		values: values,
+	}
+}
+
+func (a Array) String() string {
+	list := make([]string, len(a.values))
+	for i, v := range a.values {
+		list[i] = v.String()
+	}
+	return "[" + strings.Join(list, ", ") + "]"
+}
+
+func (a Array) Raw() []Primary {
+	return a.values
+}
+
+func (a Array) Len() int {
+	return len(a.values)
+}
+
+func (a Array) Ternary() ternary.Value {
+	return ternary.UNKNOWN
+}
+
+type Json struct {
+	value json.Structure
+}
+
+func NewJson(s json.Structure) Json {
+	return Json{
+		value: s,
+	}
+}
+
+func (j Json) String() string {
+	return j.value.Encode()
+}
+
+func (j Json) Raw() json.Structure {
+	return j.value
+}
+
+func (j Json) Ternary() ternary.Value {
+	if b, ok := j.value.(json.Boolean); ok {
+		return ternary.ConvertFromBool(b.Raw())
+	}
+	return ternary.UNKNOWN
+}
+
 type Null struct{}
 
 func NewNull() Null {
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/mithrandie/go-text/json"
 	"github.com/mithrandie/ternary"
 )
 
@@ -201,6 +202,41 @@ func TestDatetime_Format(t *testing.T) {
 	}
 }
 
+func TestArray_String(t *testing.T) {
+	p := NewArray([]Primary{NewInteger(1), NewString("a"), NewNull()})
+	expect := "[1, \"a\", NULL]"
+	if p.String() != expect {
+		t.Errorf("string = %q, want %q for %#v", p.String(), expect, p)
+	}
+}
+
+func TestArray_Len(t *testing.T) {
+	p := NewArray([]Primary{NewInteger(1), NewString("a")})
+	if p.Len() != 2 {
+		t.Errorf("length = %d, want %d for %#v", p.Len(), 2, p)
+	}
+}
+
+func TestJson_String(t *testing.T) {
+	p := NewJson(json.Object{Members: []json.ObjectMember{{Key: "key", Value: json.Array{json.Integer(1), json.Null{}}}}})
+	expect := "{\"key\":[1,null]}"
+	if p.String() != expect {
+		t.Errorf("string = %q, want %q for %#v", p.String(), expect, p)
+	}
+}
+
+func TestJson_Ternary(t *testing.T) {
+	p := NewJson(json.Boolean(true))
+	if p.Ternary() != ternary.TRUE {
+		t.Errorf("ternary = %s, want %s for %#v", p.Ternary(), ternary.TRUE, p)
+	}
+
+	p = NewJson(json.Array{})
+	if p.Ternary() != ternary.UNKNOWN {
+		t.Errorf("ternary = %s, want %s for %#v", p.Ternary(), ternary.UNKNOWN, p)
+	}
+}
+
 func TestNull_String(t *testing.T) {
 	p := NewNull()
 	if p.String() != "NULL" {
@@ -0,0 +1,266 @@
+package wasm
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	opUnreachable  = 0x00
+	opNop          = 0x01
+	opBlock        = 0x02
+	opLoop         = 0x03
+	opIf           = 0x04
+	opElse         = 0x05
+	opEnd          = 0x0b
+	opBr           = 0x0c
+	opBrIf         = 0x0d
+	opBrTable      = 0x0e
+	opReturn       = 0x0f
+	opCall         = 0x10
+	opCallIndirect = 0x11
+	opDrop         = 0x1a
+	opSelect       = 0x1b
+	opSelectT      = 0x1c
+	opLocalGet     = 0x20
+	opLocalSet     = 0x21
+	opLocalTee     = 0x22
+	opGlobalGet    = 0x23
+	opGlobalSet    = 0x24
+	opI32Load      = 0x28
+	opI64Store32   = 0x3e
+	opMemorySize   = 0x3f
+	opMemoryGrow   = 0x40
+	opI32Const     = 0x41
+	opI64Const     = 0x42
+	opF32Const     = 0x43
+	opF64Const     = 0x44
+	opI32Eqz       = 0x45
+	opI64Extend32S = 0xc4
+	opPrefixFC     = 0xfc
+
+	opTruncSatBase = 0x100
+	opMemoryCopy   = 0x10a
+	opMemoryFill   = 0x10b
+)
+
+func isMemoryInstruction(op uint16) bool {
+	return (opI32Load <= op && op <= opMemoryGrow) || op == opMemoryCopy || op == opMemoryFill
+}
+
+type instr struct {
+	op      uint16
+	imm     uint64
+	params  uint32
+	results uint32
+	target  uint32
+	target2 uint32
+	table   []uint32
+}
+
+func (m *Module) blockType(r *reader) (uint32, uint32, error) {
+	b, err := r.byte()
+	if err != nil {
+		return 0, 0, err
+	}
+	switch {
+	case b == 0x40:
+		return 0, 0, nil
+	case ValueType(b) == I32 || ValueType(b) == I64 || ValueType(b) == F32 || ValueType(b) == F64:
+		return 0, 1, nil
+	}
+
+	r.pos--
+	idx, err := r.s33()
+	if err != nil {
+		return 0, 0, err
+	}
+	if idx < 0 || len(m.Types) <= int(idx) {
+		return 0, 0, errors.New("invalid block type")
+	}
+	return uint32(len(m.Types[idx].Params)), uint32(len(m.Types[idx].Results)), nil
+}
+
+func (m *Module) compile(r *reader, numLocals int) ([]instr, error) {
+	code := make([]instr, 0, 64)
+	blocks := []int{-1}
+
+	checkLabel := func(depth uint32) error {
+		if len(blocks) <= int(depth) {
+			return errors.New("unknown label")
+		}
+		return nil
+	}
+
+	for {
+		b, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+		in := instr{op: uint16(b)}
+
+		switch {
+		case b == opBlock || b == opLoop || b == opIf:
+			if in.params, in.results, err = m.blockType(r); err != nil {
+				return nil, err
+			}
+			blocks = append(blocks, len(code))
+		case b == opElse:
+			top := blocks[len(blocks)-1]
+			if top < 0 || code[top].op != opIf || code[top].target != 0 {
+				return nil, errors.New("unexpected else")
+			}
+			code[top].target = uint32(len(code))
+		case b == opEnd:
+			top := blocks[len(blocks)-1]
+			blocks = blocks[:len(blocks)-1]
+			if top < 0 {
+				if !r.eof() {
+					return nil, errors.New("unexpected end")
+				}
+				return append(code, in), nil
+			}
+			switch code[top].op {
+			case opBlock:
+				code[top].target = uint32(len(code))
+			case opIf:
+				if code[top].target == 0 {
+					code[top].target = uint32(len(code))
+				} else {
+					code[code[top].target].target = uint32(len(code))
+				}
+				code[top].target2 = uint32(len(code))
+			}
+		case b == opBr || b == opBrIf:
+			depth, err := r.u32()
+			if err != nil {
+				return nil, err
+			}
+			if err = checkLabel(depth); err != nil {
+				return nil, err
+			}
+			in.imm = uint64(depth)
+		case b == opBrTable:
+			n, err := r.u32()
+			if err != nil {
+				return nil, err
+			}
+			if len(r.buf)-r.pos < int(n) {
+				return nil, errUnexpectedEnd
+			}
+			in.table = make([]uint32, n+1)
+			for i := range in.table {
+				if in.table[i], err = r.u32(); err != nil {
+					return nil, err
+				}
+				if err = checkLabel(in.table[i]); err != nil {
+					return nil, err
+				}
+			}
+		case b == opUnreachable || b == opNop || b == opReturn || b == opDrop || b == opSelect:
+		case b == opSelectT:
+			n, err := r.u32()
+			if err != nil {
+				return nil, err
+			}
+			for i := uint32(0); i < n; i++ {
+				if _, err = readValueType(r); err != nil {
+					return nil, err
+				}
+			}
+			in.op = opSelect
+		case b == opCall:
+			idx, err := r.u32()
+			if err != nil {
+				return nil, err
+			}
+			in.imm = uint64(idx)
+		case b == opCallIndirect:
+			idx, err := r.u32()
+			if err != nil {
+				return nil, err
+			}
+			if table, err := r.u32(); err != nil {
+				return nil, err
+			} else if table != 0 {
+				return nil, errors.New("unknown table")
+			}
+			in.imm = uint64(idx)
+		case b == opLocalGet || b == opLocalSet || b == opLocalTee:
+			idx, err := r.u32()
+			if err != nil {
+				return nil, err
+			}
+			if numLocals <= int(idx) {
+				return nil, errors.New("unknown local")
+			}
+			in.imm = uint64(idx)
+		case b == opGlobalGet || b == opGlobalSet:
+			idx, err := r.u32()
+			if err != nil {
+				return nil, err
+			}
+			in.imm = uint64(idx)
+		case opI32Load <= b && b <= opI64Store32:
+			if _, err = r.u32(); err != nil {
+				return nil, err
+			}
+			offset, err := r.u32()
+			if err != nil {
+				return nil, err
+			}
+			in.imm = uint64(offset)
+		case b == opMemorySize || b == opMemoryGrow:
+			if _, err = r.byte(); err != nil {
+				return nil, err
+			}
+		case b == opI32Const:
+			i, err := r.s32()
+			if err != nil {
+				return nil, err
+			}
+			in.imm = uint64(uint32(i))
+		case b == opI64Const:
+			i, err := r.s64()
+			if err != nil {
+				return nil, err
+			}
+			in.imm = uint64(i)
+		case b == opF32Const:
+			bits, err := r.f32()
+			if err != nil {
+				return nil, err
+			}
+			in.imm = uint64(bits)
+		case b == opF64Const:
+			if in.imm, err = r.f64(); err != nil {
+				return nil, err
+			}
+		case opI32Eqz <= b && b <= opI64Extend32S:
+		case b == opPrefixFC:
+			sub, err := r.u32()
+			if err != nil {
+				return nil, err
+			}
+			in.op = uint16(opTruncSatBase + sub)
+			switch in.op {
+			case opMemoryCopy:
+				if _, err = r.bytes(2); err != nil {
+					return nil, err
+				}
+			case opMemoryFill:
+				if _, err = r.byte(); err != nil {
+					return nil, err
+				}
+			default:
+				if 7 < sub {
+					return nil, errors.New(fmt.Sprintf("unsupported instruction 0xfc %d", sub))
+				}
+			}
+		default:
+			return nil, errors.New(fmt.Sprintf("unsupported instruction 0x%02x", b))
+		}
+
+		code = append(code, in)
+	}
+}
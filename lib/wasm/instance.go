@@ -0,0 +1,396 @@
+package wasm
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+const (
+	PageSize  = 65536
+	MaxPages  = 65536
+	MaxLocals = 50000
+)
+
+// Limits restricts the resources that an instance can use.
+type Limits struct {
+	MaxMemoryPages  uint32
+	MaxInstructions int64
+	MaxCallDepth    int
+}
+
+var DefaultLimits = Limits{
+	MaxMemoryPages:  256,
+	MaxInstructions: 100000000,
+	MaxCallDepth:    1000,
+}
+
+type Trap struct {
+	message string
+}
+
+func (e *Trap) Error() string {
+	return e.message
+}
+
+func trap(message string) *Trap {
+	return &Trap{message: message}
+}
+
+type Instance struct {
+	module  *Module
+	limits  Limits
+	memory  []byte
+	memMax  uint32
+	globals []uint64
+	table   []int64
+	fuel    int64
+}
+
+func Instantiate(m *Module, limits Limits) (inst *Instance, err error) {
+	inst = &Instance{
+		module: m,
+		limits: limits,
+	}
+
+	if m.hasMem {
+		if limits.MaxMemoryPages < m.memMin {
+			return nil, errors.New(fmt.Sprintf("initial memory size exceeds the limit of %d pages", limits.MaxMemoryPages))
+		}
+		inst.memory = make([]byte, int(m.memMin)*PageSize)
+		inst.memMax = limits.MaxMemoryPages
+		if m.memMax < inst.memMax {
+			inst.memMax = m.memMax
+		}
+	}
+
+	inst.globals = make([]uint64, len(m.globals))
+	for i, g := range m.globals {
+		inst.globals[i] = inst.evalConstExpr(g.init)
+	}
+
+	if m.hasTable {
+		if MaxLocals < m.tableMin {
+			return nil, errors.New("table size exceeds the limit")
+		}
+		inst.table = make([]int64, m.tableMin)
+		for i := range inst.table {
+			inst.table[i] = -1
+		}
+	}
+	for _, seg := range m.elems {
+		offset := uint64(uint32(inst.evalConstExpr(seg.offset)))
+		if uint64(len(inst.table)) < offset+uint64(len(seg.funcs)) {
+			return nil, errors.New("out of bounds table access")
+		}
+		for i, idx := range seg.funcs {
+			inst.table[offset+uint64(i)] = int64(idx)
+		}
+	}
+
+	for _, seg := range m.data {
+		offset := uint64(uint32(inst.evalConstExpr(seg.offset)))
+		if uint64(len(inst.memory)) < offset+uint64(len(seg.data)) {
+			return nil, errors.New("out of bounds memory access")
+		}
+		copy(inst.memory[offset:], seg.data)
+	}
+
+	if -1 < m.start {
+		if _, err = inst.call(uint32(m.start), nil); err != nil {
+			return nil, err
+		}
+	}
+	return inst, nil
+}
+
+func (inst *Instance) evalConstExpr(expr constExpr) uint64 {
+	if expr.op == opGlobalGet {
+		return inst.globals[expr.global]
+	}
+	return expr.value
+}
+
+// Call invokes the exported function named name. Arguments and results are
+// represented as raw bits: i32 and f32 values in the lower 32 bits, and
+// f32 and f64 values as their IEEE 754 representations.
+func (inst *Instance) Call(name string, args ...uint64) ([]uint64, error) {
+	for _, e := range inst.module.Exports {
+		if e.Kind == ExternalFunction && e.Name == name {
+			ft := inst.module.Types[inst.module.funcs[e.Index].typeIndex]
+			if len(args) != len(ft.Params) {
+				return nil, errors.New(fmt.Sprintf("function %s takes %d arguments", name, len(ft.Params)))
+			}
+			return inst.call(e.Index, args)
+		}
+	}
+	return nil, errors.New(fmt.Sprintf("function %s is not exported", name))
+}
+
+func (inst *Instance) call(idx uint32, args []uint64) (results []uint64, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if t, ok := r.(*Trap); ok {
+				err = t
+			} else {
+				err = trap(fmt.Sprintf("invalid module: %v", r))
+			}
+			results = nil
+		}
+	}()
+
+	inst.fuel = inst.limits.MaxInstructions
+	return inst.invoke(idx, args, 0), nil
+}
+
+type label struct {
+	height int
+	arity  int
+	cont   int
+	loop   bool
+}
+
+func (inst *Instance) effectiveAddress(base uint64, offset uint64, size uint64) uint64 {
+	ea := uint64(uint32(base)) + offset
+	if uint64(len(inst.memory)) < ea+size {
+		panic(trap("out of bounds memory access"))
+	}
+	return ea
+}
+
+func (inst *Instance) invoke(idx uint32, args []uint64, depth int) []uint64 {
+	if inst.limits.MaxCallDepth <= depth {
+		panic(trap("call stack exhausted"))
+	}
+
+	fn := &inst.module.funcs[idx]
+	ft := &inst.module.Types[fn.typeIndex]
+	code := fn.code
+
+	locals := make([]uint64, len(ft.Params)+len(fn.locals))
+	copy(locals, args)
+	stack := make([]uint64, 0, 32)
+	labels := make([]label, 1, 8)
+	labels[0] = label{arity: len(ft.Results), cont: len(code)}
+
+	pc := 0
+
+	branch := func(depth int) bool {
+		i := len(labels) - 1 - depth
+		l := labels[i]
+		copy(stack[l.height:], stack[len(stack)-l.arity:])
+		stack = stack[:l.height+l.arity]
+		if l.loop {
+			labels = labels[:i+1]
+		} else {
+			labels = labels[:i]
+		}
+		pc = l.cont
+		return len(labels) < 1
+	}
+
+	results := func() []uint64 {
+		res := make([]uint64, len(ft.Results))
+		copy(res, stack[len(stack)-len(res):])
+		return res
+	}
+
+	for {
+		inst.fuel--
+		if inst.fuel < 0 {
+			panic(trap("execution exceeded the instruction limit"))
+		}
+
+		in := &code[pc]
+		sp := len(stack)
+
+		switch in.op {
+		case opUnreachable:
+			panic(trap("unreachable executed"))
+		case opNop:
+		case opBlock:
+			labels = append(labels, label{height: sp - int(in.params), arity: int(in.results), cont: int(in.target) + 1})
+		case opLoop:
+			labels = append(labels, label{height: sp - int(in.params), arity: int(in.params), cont: pc + 1, loop: true})
+		case opIf:
+			cond := uint32(stack[sp-1])
+			stack = stack[:sp-1]
+			if cond != 0 {
+				labels = append(labels, label{height: sp - 1 - int(in.params), arity: int(in.results), cont: int(in.target2) + 1})
+			} else if in.target != in.target2 {
+				labels = append(labels, label{height: sp - 1 - int(in.params), arity: int(in.results), cont: int(in.target2) + 1})
+				pc = int(in.target)
+			} else {
+				pc = int(in.target2)
+			}
+		case opElse:
+			pc = int(in.target)
+			continue
+		case opEnd:
+			labels = labels[:len(labels)-1]
+			if len(labels) < 1 {
+				return results()
+			}
+		case opBr:
+			if branch(int(in.imm)) {
+				return results()
+			}
+			continue
+		case opBrIf:
+			cond := uint32(stack[sp-1])
+			stack = stack[:sp-1]
+			if cond != 0 {
+				if branch(int(in.imm)) {
+					return results()
+				}
+				continue
+			}
+		case opBrTable:
+			i := uint32(stack[sp-1])
+			stack = stack[:sp-1]
+			if uint32(len(in.table)-1) < i {
+				i = uint32(len(in.table) - 1)
+			}
+			if branch(int(in.table[i])) {
+				return results()
+			}
+			continue
+		case opReturn:
+			return results()
+		case opCall:
+			callee := &inst.module.funcs[in.imm]
+			n := len(inst.module.Types[callee.typeIndex].Params)
+			res := inst.invoke(uint32(in.imm), stack[sp-n:], depth+1)
+			stack = append(stack[:sp-n], res...)
+		case opCallIndirect:
+			i := uint32(stack[sp-1])
+			stack = stack[:sp-1]
+			if uint32(len(inst.table)) <= i {
+				panic(trap("undefined element"))
+			}
+			fidx := inst.table[i]
+			if fidx < 0 {
+				panic(trap("uninitialized element"))
+			}
+			calleeType := inst.module.Types[inst.module.funcs[fidx].typeIndex]
+			if !calleeType.equal(inst.module.Types[in.imm]) {
+				panic(trap("indirect call type mismatch"))
+			}
+			n := len(calleeType.Params)
+			res := inst.invoke(uint32(fidx), stack[sp-1-n:sp-1], depth+1)
+			stack = append(stack[:sp-1-n], res...)
+		case opDrop:
+			stack = stack[:sp-1]
+		case opSelect:
+			if uint32(stack[sp-1]) == 0 {
+				stack[sp-3] = stack[sp-2]
+			}
+			stack = stack[:sp-2]
+		case opLocalGet:
+			stack = append(stack, locals[in.imm])
+		case opLocalSet:
+			locals[in.imm] = stack[sp-1]
+			stack = stack[:sp-1]
+		case opLocalTee:
+			locals[in.imm] = stack[sp-1]
+		case opGlobalGet:
+			stack = append(stack, inst.globals[in.imm])
+		case opGlobalSet:
+			inst.globals[in.imm] = stack[sp-1]
+			stack = stack[:sp-1]
+		case opMemorySize:
+			stack = append(stack, uint64(len(inst.memory)/PageSize))
+		case opMemoryGrow:
+			pages := uint64(len(inst.memory) / PageSize)
+			delta := uint64(uint32(stack[sp-1]))
+			if uint64(inst.memMax) < pages+delta {
+				stack[sp-1] = uint64(uint32(0xffffffff))
+			} else {
+				inst.memory = append(inst.memory, make([]byte, int(delta)*PageSize)...)
+				stack[sp-1] = pages
+			}
+		case opMemoryCopy:
+			n := uint64(uint32(stack[sp-1]))
+			src := inst.effectiveAddress(stack[sp-2], 0, n)
+			dst := inst.effectiveAddress(stack[sp-3], 0, n)
+			copy(inst.memory[dst:dst+n], inst.memory[src:src+n])
+			stack = stack[:sp-3]
+		case opMemoryFill:
+			n := uint64(uint32(stack[sp-1]))
+			dst := inst.effectiveAddress(stack[sp-3], 0, n)
+			b := byte(stack[sp-2])
+			for i := dst; i < dst+n; i++ {
+				inst.memory[i] = b
+			}
+			stack = stack[:sp-3]
+		case opI32Const, opI64Const, opF32Const, opF64Const:
+			stack = append(stack, in.imm)
+		default:
+			switch {
+			case opI32Load <= in.op && in.op < 0x36:
+				stack[sp-1] = inst.load(in.op, stack[sp-1], in.imm)
+			case 0x36 <= in.op && in.op <= opI64Store32:
+				inst.store(in.op, stack[sp-2], in.imm, stack[sp-1])
+				stack = stack[:sp-2]
+			case isUnaryOp(in.op):
+				stack[sp-1] = unaryOp(in.op, stack[sp-1])
+			default:
+				stack[sp-2] = binaryOp(in.op, stack[sp-2], stack[sp-1])
+				stack = stack[:sp-1]
+			}
+		}
+		pc++
+	}
+}
+
+func (inst *Instance) load(op uint16, base uint64, offset uint64) uint64 {
+	switch op {
+	case 0x28, 0x2a: // i32.load, f32.load
+		ea := inst.effectiveAddress(base, offset, 4)
+		return uint64(binary.LittleEndian.Uint32(inst.memory[ea:]))
+	case 0x29, 0x2b: // i64.load, f64.load
+		ea := inst.effectiveAddress(base, offset, 8)
+		return binary.LittleEndian.Uint64(inst.memory[ea:])
+	case 0x2c: // i32.load8_s
+		ea := inst.effectiveAddress(base, offset, 1)
+		return uint64(uint32(int32(int8(inst.memory[ea]))))
+	case 0x2d, 0x31: // i32.load8_u, i64.load8_u
+		ea := inst.effectiveAddress(base, offset, 1)
+		return uint64(inst.memory[ea])
+	case 0x2e: // i32.load16_s
+		ea := inst.effectiveAddress(base, offset, 2)
+		return uint64(uint32(int32(int16(binary.LittleEndian.Uint16(inst.memory[ea:])))))
+	case 0x2f, 0x33: // i32.load16_u, i64.load16_u
+		ea := inst.effectiveAddress(base, offset, 2)
+		return uint64(binary.LittleEndian.Uint16(inst.memory[ea:]))
+	case 0x30: // i64.load8_s
+		ea := inst.effectiveAddress(base, offset, 1)
+		return uint64(int64(int8(inst.memory[ea])))
+	case 0x32: // i64.load16_s
+		ea := inst.effectiveAddress(base, offset, 2)
+		return uint64(int64(int16(binary.LittleEndian.Uint16(inst.memory[ea:]))))
+	case 0x34: // i64.load32_s
+		ea := inst.effectiveAddress(base, offset, 4)
+		return uint64(int64(int32(binary.LittleEndian.Uint32(inst.memory[ea:]))))
+	default: // i64.load32_u
+		ea := inst.effectiveAddress(base, offset, 4)
+		return uint64(binary.LittleEndian.Uint32(inst.memory[ea:]))
+	}
+}
+
+func (inst *Instance) store(op uint16, base uint64, offset uint64, v uint64) {
+	switch op {
+	case 0x36, 0x38, 0x3e: // i32.store, f32.store, i64.store32
+		ea := inst.effectiveAddress(base, offset, 4)
+		binary.LittleEndian.PutUint32(inst.memory[ea:], uint32(v))
+	case 0x37, 0x39: // i64.store, f64.store
+		ea := inst.effectiveAddress(base, offset, 8)
+		binary.LittleEndian.PutUint64(inst.memory[ea:], v)
+	case 0x3a, 0x3c: // i32.store8, i64.store8
+		ea := inst.effectiveAddress(base, offset, 1)
+		inst.memory[ea] = byte(v)
+	default: // i32.store16, i64.store16
+		ea := inst.effectiveAddress(base, offset, 2)
+		binary.LittleEndian.PutUint16(inst.memory[ea:], uint16(v))
+	}
+}
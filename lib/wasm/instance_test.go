@@ -0,0 +1,279 @@
+package wasm
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func leb(n uint32) []byte {
+	var b []byte
+	for {
+		c := byte(n & 0x7f)
+		n >>= 7
+		if n == 0 {
+			return append(b, c)
+		}
+		b = append(b, c|0x80)
+	}
+}
+
+func section(id byte, items ...[]byte) []byte {
+	content := leb(uint32(len(items)))
+	for _, item := range items {
+		content = append(content, item...)
+	}
+	return append(append([]byte{id}, leb(uint32(len(content)))...), content...)
+}
+
+func funcType(params []ValueType, results []ValueType) []byte {
+	b := append([]byte{0x60}, leb(uint32(len(params)))...)
+	b = append(b, valueTypeBytes(params)...)
+	b = append(b, leb(uint32(len(results)))...)
+	return append(b, valueTypeBytes(results)...)
+}
+
+func exportFunc(name string, idx uint32) []byte {
+	b := append(leb(uint32(len(name))), name...)
+	b = append(b, ExternalFunction)
+	return append(b, leb(idx)...)
+}
+
+func funcBody(locals []byte, code ...byte) []byte {
+	b := append(locals, code...)
+	return append(leb(uint32(len(b))), b...)
+}
+
+var noLocals = []byte{0x00}
+
+func buildModule(sections ...[]byte) []byte {
+	b := append(append([]byte{}, magic...), version...)
+	for _, s := range sections {
+		b = append(b, s...)
+	}
+	return b
+}
+
+// testModule exports the following functions:
+//
+//	add(i32, i32) i32, div(i32, i32) i32, fact(i64) i64, abs(i32) i32,
+//	sqrt(f64) f64, spin(), recurse(), load(i32) i32, grow() i32, pick(i32) i32
+var testModule = buildModule(
+	section(0x01,
+		funcType([]ValueType{I32, I32}, []ValueType{I32}),
+		funcType([]ValueType{I64}, []ValueType{I64}),
+		funcType([]ValueType{I32}, []ValueType{I32}),
+		funcType([]ValueType{F64}, []ValueType{F64}),
+		funcType(nil, nil),
+		funcType(nil, []ValueType{I32}),
+	),
+	section(0x03,
+		[]byte{0}, []byte{0}, []byte{1}, []byte{2}, []byte{3}, []byte{4}, []byte{4}, []byte{2}, []byte{5}, []byte{2},
+		[]byte{5}, []byte{5},
+	),
+	section(0x04, []byte{0x70, 0x00, 0x02}),
+	section(0x05, []byte{0x00, 0x01}),
+	section(0x07,
+		exportFunc("add", 0),
+		exportFunc("div", 1),
+		exportFunc("fact", 2),
+		exportFunc("abs", 3),
+		exportFunc("sqrt", 4),
+		exportFunc("spin", 5),
+		exportFunc("recurse", 6),
+		exportFunc("load", 7),
+		exportFunc("grow", 8),
+		exportFunc("pick", 9),
+	),
+	section(0x09, []byte{0x00, 0x41, 0x00, 0x0b, 0x02, 0x0a, 0x0b}),
+	section(0x0a,
+		// add
+		funcBody(noLocals, 0x20, 0x00, 0x20, 0x01, 0x6a, 0x0b),
+		// div
+		funcBody(noLocals, 0x20, 0x00, 0x20, 0x01, 0x6d, 0x0b),
+		// fact
+		funcBody([]byte{0x01, 0x01, 0x7e},
+			0x42, 0x01, 0x21, 0x01,
+			0x02, 0x40, 0x03, 0x40,
+			0x20, 0x00, 0x50, 0x0d, 0x01,
+			0x20, 0x01, 0x20, 0x00, 0x7e, 0x21, 0x01,
+			0x20, 0x00, 0x42, 0x01, 0x7d, 0x21, 0x00,
+			0x0c, 0x00,
+			0x0b, 0x0b,
+			0x20, 0x01, 0x0b,
+		),
+		// abs
+		funcBody(noLocals,
+			0x20, 0x00, 0x41, 0x00, 0x48,
+			0x04, 0x7f, 0x41, 0x00, 0x20, 0x00, 0x6b,
+			0x05, 0x20, 0x00,
+			0x0b, 0x0b,
+		),
+		// sqrt
+		funcBody(noLocals, 0x20, 0x00, 0x9f, 0x0b),
+		// spin
+		funcBody(noLocals, 0x03, 0x40, 0x0c, 0x00, 0x0b, 0x0b),
+		// recurse
+		funcBody(noLocals, 0x10, 0x06, 0x0b),
+		// load
+		funcBody(noLocals, 0x20, 0x00, 0x2d, 0x00, 0x00, 0x0b),
+		// grow
+		funcBody(noLocals, 0x41, 0x01, 0x40, 0x00, 0x0b),
+		// pick
+		funcBody(noLocals, 0x20, 0x00, 0x11, 0x05, 0x00, 0x0b),
+		// ten
+		funcBody(noLocals, 0x41, 0x0a, 0x0b),
+		// twenty
+		funcBody(noLocals, 0x41, 0x14, 0x0b),
+	),
+	section(0x0b, []byte{0x00, 0x41, 0x02, 0x0b, 0x03, 'a', 'b', 'c'}),
+)
+
+var instanceCallTests = []struct {
+	Name   string
+	Func   string
+	Args   []uint64
+	Result []uint64
+	Error  string
+}{
+	{
+		Name:   "Add",
+		Func:   "add",
+		Args:   []uint64{2, uint64(uint32(0xfffffffd))},
+		Result: []uint64{uint64(uint32(0xffffffff))},
+	},
+	{
+		Name:   "Signed Division",
+		Func:   "div",
+		Args:   []uint64{uint64(uint32(0xfffffff6)), 3},
+		Result: []uint64{uint64(uint32(0xfffffffd))},
+	},
+	{
+		Name:  "Division by Zero",
+		Func:  "div",
+		Args:  []uint64{1, 0},
+		Error: "integer divide by zero",
+	},
+	{
+		Name:  "Division Overflow",
+		Func:  "div",
+		Args:  []uint64{1 << 31, uint64(uint32(0xffffffff))},
+		Error: "integer overflow",
+	},
+	{
+		Name:   "Loop",
+		Func:   "fact",
+		Args:   []uint64{20},
+		Result: []uint64{2432902008176640000},
+	},
+	{
+		Name:   "If Else",
+		Func:   "abs",
+		Args:   []uint64{uint64(uint32(0xfffffff9))},
+		Result: []uint64{7},
+	},
+	{
+		Name:   "If Else Not Taken",
+		Func:   "abs",
+		Args:   []uint64{7},
+		Result: []uint64{7},
+	},
+	{
+		Name:   "Float",
+		Func:   "sqrt",
+		Args:   []uint64{math.Float64bits(2.25)},
+		Result: []uint64{math.Float64bits(1.5)},
+	},
+	{
+		Name:  "Instruction Limit",
+		Func:  "spin",
+		Error: "execution exceeded the instruction limit",
+	},
+	{
+		Name:  "Call Depth Limit",
+		Func:  "recurse",
+		Error: "call stack exhausted",
+	},
+	{
+		Name:   "Memory Load",
+		Func:   "load",
+		Args:   []uint64{3},
+		Result: []uint64{'b'},
+	},
+	{
+		Name:  "Memory Out of Bounds",
+		Func:  "load",
+		Args:  []uint64{PageSize},
+		Error: "out of bounds memory access",
+	},
+	{
+		Name:   "Memory Grow Beyond Limit",
+		Func:   "grow",
+		Result: []uint64{uint64(uint32(0xffffffff))},
+	},
+	{
+		Name:   "Indirect Call",
+		Func:   "pick",
+		Args:   []uint64{1},
+		Result: []uint64{20},
+	},
+	{
+		Name:  "Indirect Call Undefined Element",
+		Func:  "pick",
+		Args:  []uint64{2},
+		Error: "undefined element",
+	},
+	{
+		Name:  "Not Exported",
+		Func:  "notexist",
+		Error: "function notexist is not exported",
+	},
+	{
+		Name:  "Argument Length Error",
+		Func:  "add",
+		Args:  []uint64{1},
+		Error: "function add takes 2 arguments",
+	},
+}
+
+func TestInstance_Call(t *testing.T) {
+	m, err := Decode(testModule)
+	if err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+	inst, err := Instantiate(m, Limits{
+		MaxMemoryPages:  1,
+		MaxInstructions: 100000,
+		MaxCallDepth:    100,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	for _, v := range instanceCallTests {
+		result, err := inst.Call(v.Func, v.Args...)
+		if err != nil {
+			if len(v.Error) < 1 {
+				t.Errorf("%s: unexpected error %q", v.Name, err)
+			} else if err.Error() != v.Error {
+				t.Errorf("%s: error %q, want error %q", v.Name, err.Error(), v.Error)
+			}
+			continue
+		}
+		if 0 < len(v.Error) {
+			t.Errorf("%s: no error, want error %q", v.Name, v.Error)
+			continue
+		}
+		if !reflect.DeepEqual(result, v.Result) {
+			t.Errorf("%s: result = %v, want %v", v.Name, result, v.Result)
+		}
+	}
+}
+
+func TestInstantiate(t *testing.T) {
+	m, _ := Decode(testModule)
+	_, err := Instantiate(m, Limits{MaxInstructions: 100, MaxCallDepth: 10})
+	if err == nil || err.Error() != "initial memory size exceeds the limit of 0 pages" {
+		t.Errorf("error = %v, want error %q", err, "initial memory size exceeds the limit of 0 pages")
+	}
+}
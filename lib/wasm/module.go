@@ -0,0 +1,599 @@
+package wasm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+var magic = []byte{0x00, 0x61, 0x73, 0x6d}
+var version = []byte{0x01, 0x00, 0x00, 0x00}
+
+type ValueType byte
+
+const (
+	I32 ValueType = 0x7f
+	I64 ValueType = 0x7e
+	F32 ValueType = 0x7d
+	F64 ValueType = 0x7c
+)
+
+func (t ValueType) String() string {
+	switch t {
+	case I32:
+		return "i32"
+	case I64:
+		return "i64"
+	case F32:
+		return "f32"
+	case F64:
+		return "f64"
+	}
+	return fmt.Sprintf("0x%02x", byte(t))
+}
+
+func readValueType(r *reader) (ValueType, error) {
+	b, err := r.byte()
+	if err != nil {
+		return 0, err
+	}
+	switch t := ValueType(b); t {
+	case I32, I64, F32, F64:
+		return t, nil
+	}
+	return 0, errors.New(fmt.Sprintf("unsupported value type 0x%02x", b))
+}
+
+type FuncType struct {
+	Params  []ValueType
+	Results []ValueType
+}
+
+func (t FuncType) equal(t2 FuncType) bool {
+	return bytes.Equal(valueTypeBytes(t.Params), valueTypeBytes(t2.Params)) &&
+		bytes.Equal(valueTypeBytes(t.Results), valueTypeBytes(t2.Results))
+}
+
+func valueTypeBytes(types []ValueType) []byte {
+	b := make([]byte, len(types))
+	for i, t := range types {
+		b[i] = byte(t)
+	}
+	return b
+}
+
+const (
+	ExternalFunction byte = 0x00
+	ExternalTable    byte = 0x01
+	ExternalMemory   byte = 0x02
+	ExternalGlobal   byte = 0x03
+)
+
+type Export struct {
+	Name  string
+	Kind  byte
+	Index uint32
+}
+
+type constExpr struct {
+	op     byte
+	value  uint64
+	global uint32
+}
+
+type global struct {
+	valueType ValueType
+	mutable   bool
+	init      constExpr
+}
+
+type elemSegment struct {
+	offset constExpr
+	funcs  []uint32
+}
+
+type dataSegment struct {
+	offset constExpr
+	data   []byte
+}
+
+type function struct {
+	typeIndex uint32
+	locals    []ValueType
+	code      []instr
+}
+
+type Module struct {
+	Types   []FuncType
+	Exports []Export
+
+	funcs    []function
+	globals  []global
+	elems    []elemSegment
+	data     []dataSegment
+	start    int
+	hasTable bool
+	tableMin uint32
+	tableMax uint32
+	hasMem   bool
+	memMin   uint32
+	memMax   uint32
+}
+
+// FuncType returns the type of the exported function named name.
+func (m *Module) FuncType(name string) (FuncType, bool) {
+	for _, e := range m.Exports {
+		if e.Kind == ExternalFunction && e.Name == name {
+			return m.Types[m.funcs[e.Index].typeIndex], true
+		}
+	}
+	return FuncType{}, false
+}
+
+// Decode decodes a module in the WebAssembly binary format.
+//
+// Modules importing anything from the host are rejected, so that
+// the functions in the module cannot access any resources other than
+// their own memory.
+func Decode(b []byte) (*Module, error) {
+	r := newReader(b)
+
+	if head, err := r.bytes(8); err != nil || !bytes.Equal(head[:4], magic) {
+		return nil, errors.New("not a webassembly module")
+	} else if !bytes.Equal(head[4:], version) {
+		return nil, errors.New("unsupported webassembly version")
+	}
+
+	m := &Module{start: -1}
+	var funcTypes []uint32
+
+	for !r.eof() {
+		id, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+		size, err := r.u32()
+		if err != nil {
+			return nil, err
+		}
+		content, err := r.bytes(int(size))
+		if err != nil {
+			return nil, err
+		}
+		sr := newReader(content)
+
+		switch id {
+		case 0x00: // custom
+			continue
+		case 0x01:
+			err = m.decodeTypes(sr)
+		case 0x02:
+			err = decodeImports(sr)
+		case 0x03:
+			funcTypes, err = m.decodeFunctions(sr)
+		case 0x04:
+			err = m.decodeTable(sr)
+		case 0x05:
+			err = m.decodeMemory(sr)
+		case 0x06:
+			err = m.decodeGlobals(sr)
+		case 0x07:
+			err = m.decodeExports(sr)
+		case 0x08:
+			var idx uint32
+			idx, err = sr.u32()
+			m.start = int(idx)
+		case 0x09:
+			err = m.decodeElements(sr)
+		case 0x0a:
+			err = m.decodeCode(sr, funcTypes)
+		case 0x0b:
+			err = m.decodeData(sr)
+		case 0x0c: // data count
+			_, err = sr.u32()
+		default:
+			err = errors.New(fmt.Sprintf("unknown section %d", id))
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(m.funcs) != len(funcTypes) {
+		return nil, errors.New("function and code section have inconsistent lengths")
+	}
+	if err := m.verifyIndices(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (m *Module) verifyIndices() error {
+	for _, e := range m.Exports {
+		var ok bool
+		switch e.Kind {
+		case ExternalFunction:
+			ok = int(e.Index) < len(m.funcs)
+		case ExternalTable:
+			ok = m.hasTable && e.Index == 0
+		case ExternalMemory:
+			ok = m.hasMem && e.Index == 0
+		case ExternalGlobal:
+			ok = int(e.Index) < len(m.globals)
+		}
+		if !ok {
+			return errors.New(fmt.Sprintf("export %s refers to an unknown index", e.Name))
+		}
+	}
+	if len(m.funcs) <= m.start {
+		return errors.New("unknown start function")
+	}
+	for _, seg := range m.elems {
+		if !m.hasTable {
+			return errors.New("element segment requires a table")
+		}
+		for _, idx := range seg.funcs {
+			if len(m.funcs) <= int(idx) {
+				return errors.New("element segment refers to an unknown function")
+			}
+		}
+	}
+	if 0 < len(m.data) && !m.hasMem {
+		return errors.New("data segment requires a memory")
+	}
+
+	for _, fn := range m.funcs {
+		for _, in := range fn.code {
+			switch in.op {
+			case opCall:
+				if len(m.funcs) <= int(in.imm) {
+					return errors.New("call to an unknown function")
+				}
+			case opCallIndirect:
+				if len(m.Types) <= int(in.imm) || !m.hasTable {
+					return errors.New("invalid indirect call")
+				}
+			case opGlobalGet, opGlobalSet:
+				if len(m.globals) <= int(in.imm) {
+					return errors.New("unknown global")
+				}
+				if in.op == opGlobalSet && !m.globals[in.imm].mutable {
+					return errors.New("global is immutable")
+				}
+			}
+			if isMemoryInstruction(in.op) && !m.hasMem {
+				return errors.New("memory instruction requires a memory")
+			}
+		}
+	}
+	return nil
+}
+
+func (m *Module) decodeTypes(r *reader) error {
+	n, err := r.u32()
+	if err != nil {
+		return err
+	}
+	m.Types = make([]FuncType, n)
+	for i := range m.Types {
+		if b, err := r.byte(); err != nil {
+			return err
+		} else if b != 0x60 {
+			return errors.New("invalid function type")
+		}
+		for _, list := range []*[]ValueType{&m.Types[i].Params, &m.Types[i].Results} {
+			cnt, err := r.u32()
+			if err != nil {
+				return err
+			}
+			*list = make([]ValueType, cnt)
+			for j := range *list {
+				if (*list)[j], err = readValueType(r); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func decodeImports(r *reader) error {
+	n, err := r.u32()
+	if err != nil {
+		return err
+	}
+	if 0 < n {
+		moduleName, err := r.name()
+		if err != nil {
+			return err
+		}
+		name, err := r.name()
+		if err != nil {
+			return err
+		}
+		return errors.New(fmt.Sprintf("module must not import %s.%s", moduleName, name))
+	}
+	return nil
+}
+
+func (m *Module) decodeFunctions(r *reader) ([]uint32, error) {
+	n, err := r.u32()
+	if err != nil {
+		return nil, err
+	}
+	types := make([]uint32, n)
+	for i := range types {
+		if types[i], err = r.u32(); err != nil {
+			return nil, err
+		}
+		if len(m.Types) <= int(types[i]) {
+			return nil, errors.New("unknown function type")
+		}
+	}
+	return types, nil
+}
+
+func (m *Module) decodeTable(r *reader) error {
+	n, err := r.u32()
+	if err != nil {
+		return err
+	}
+	if 1 < n {
+		return errors.New("multiple tables are not supported")
+	}
+	if n == 1 {
+		if b, err := r.byte(); err != nil {
+			return err
+		} else if b != 0x70 {
+			return errors.New("unsupported table type")
+		}
+		if m.tableMin, m.tableMax, err = r.limits(); err != nil {
+			return err
+		}
+		m.hasTable = true
+	}
+	return nil
+}
+
+func (m *Module) decodeMemory(r *reader) error {
+	n, err := r.u32()
+	if err != nil {
+		return err
+	}
+	if 1 < n {
+		return errors.New("multiple memories are not supported")
+	}
+	if n == 1 {
+		if m.memMin, m.memMax, err = r.limits(); err != nil {
+			return err
+		}
+		if MaxPages < m.memMin {
+			return errors.New("memory size must be at most 65536 pages")
+		}
+		m.hasMem = true
+	}
+	return nil
+}
+
+func (m *Module) decodeGlobals(r *reader) error {
+	n, err := r.u32()
+	if err != nil {
+		return err
+	}
+	m.globals = make([]global, n)
+	for i := range m.globals {
+		if m.globals[i].valueType, err = readValueType(r); err != nil {
+			return err
+		}
+		mut, err := r.byte()
+		if err != nil {
+			return err
+		}
+		m.globals[i].mutable = mut == 0x01
+		if m.globals[i].init, err = decodeConstExpr(r); err != nil {
+			return err
+		}
+		if m.globals[i].init.op == opGlobalGet && i <= int(m.globals[i].init.global) {
+			return errors.New("unknown global")
+		}
+	}
+	return nil
+}
+
+func (m *Module) decodeExports(r *reader) error {
+	n, err := r.u32()
+	if err != nil {
+		return err
+	}
+	m.Exports = make([]Export, n)
+	for i := range m.Exports {
+		if m.Exports[i].Name, err = r.name(); err != nil {
+			return err
+		}
+		if m.Exports[i].Kind, err = r.byte(); err != nil {
+			return err
+		}
+		if m.Exports[i].Index, err = r.u32(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Module) decodeElements(r *reader) error {
+	n, err := r.u32()
+	if err != nil {
+		return err
+	}
+	m.elems = make([]elemSegment, n)
+	for i := range m.elems {
+		flag, err := r.u32()
+		if err != nil {
+			return err
+		}
+		switch flag {
+		case 0:
+		case 2:
+			if idx, err := r.u32(); err != nil {
+				return err
+			} else if idx != 0 {
+				return errors.New("unknown table")
+			}
+		default:
+			return errors.New("unsupported element segment")
+		}
+		if m.elems[i].offset, err = decodeConstExpr(r); err != nil {
+			return err
+		}
+		if flag == 2 {
+			if kind, err := r.byte(); err != nil {
+				return err
+			} else if kind != 0x00 {
+				return errors.New("unsupported element kind")
+			}
+		}
+		cnt, err := r.u32()
+		if err != nil {
+			return err
+		}
+		m.elems[i].funcs = make([]uint32, cnt)
+		for j := range m.elems[i].funcs {
+			if m.elems[i].funcs[j], err = r.u32(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (m *Module) decodeData(r *reader) error {
+	n, err := r.u32()
+	if err != nil {
+		return err
+	}
+	m.data = make([]dataSegment, n)
+	for i := range m.data {
+		flag, err := r.u32()
+		if err != nil {
+			return err
+		}
+		switch flag {
+		case 0:
+		case 2:
+			if idx, err := r.u32(); err != nil {
+				return err
+			} else if idx != 0 {
+				return errors.New("unknown memory")
+			}
+		default:
+			return errors.New("unsupported data segment")
+		}
+		if m.data[i].offset, err = decodeConstExpr(r); err != nil {
+			return err
+		}
+		size, err := r.u32()
+		if err != nil {
+			return err
+		}
+		if m.data[i].data, err = r.bytes(int(size)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decodeConstExpr(r *reader) (constExpr, error) {
+	var expr constExpr
+
+	op, err := r.byte()
+	if err != nil {
+		return expr, err
+	}
+	expr.op = op
+
+	switch op {
+	case opI32Const:
+		var i int32
+		i, err = r.s32()
+		expr.value = uint64(uint32(i))
+	case opI64Const:
+		var i int64
+		i, err = r.s64()
+		expr.value = uint64(i)
+	case opF32Const:
+		var bits uint32
+		bits, err = r.f32()
+		expr.value = uint64(bits)
+	case opF64Const:
+		expr.value, err = r.f64()
+	case opGlobalGet:
+		expr.global, err = r.u32()
+	default:
+		return expr, errors.New("unsupported constant expression")
+	}
+	if err != nil {
+		return expr, err
+	}
+
+	if end, err := r.byte(); err != nil {
+		return expr, err
+	} else if end != opEnd {
+		return expr, errors.New("unsupported constant expression")
+	}
+	return expr, nil
+}
+
+func (m *Module) decodeCode(r *reader, funcTypes []uint32) error {
+	n, err := r.u32()
+	if err != nil {
+		return err
+	}
+	if int(n) != len(funcTypes) {
+		return errors.New("function and code section have inconsistent lengths")
+	}
+
+	m.funcs = make([]function, n)
+	for i := range m.funcs {
+		size, err := r.u32()
+		if err != nil {
+			return err
+		}
+		body, err := r.bytes(int(size))
+		if err != nil {
+			return err
+		}
+
+		m.funcs[i].typeIndex = funcTypes[i]
+		if err = m.decodeFunctionBody(newReader(body), &m.funcs[i]); err != nil {
+			return errors.New(fmt.Sprintf("function %d: %s", i, err.Error()))
+		}
+	}
+	return nil
+}
+
+func (m *Module) decodeFunctionBody(r *reader, fn *function) error {
+	groups, err := r.u32()
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < groups; i++ {
+		cnt, err := r.u32()
+		if err != nil {
+			return err
+		}
+		t, err := readValueType(r)
+		if err != nil {
+			return err
+		}
+		if MaxLocals < len(fn.locals)+int(cnt) {
+			return errors.New("too many locals")
+		}
+		for j := uint32(0); j < cnt; j++ {
+			fn.locals = append(fn.locals, t)
+		}
+	}
+
+	numLocals := len(m.Types[fn.typeIndex].Params) + len(fn.locals)
+	fn.code, err = m.compile(r, numLocals)
+	return err
+}
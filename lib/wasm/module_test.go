@@ -0,0 +1,105 @@
+package wasm
+
+import (
+	"reflect"
+	"testing"
+)
+
+var decodeTests = []struct {
+	Name   string
+	Module []byte
+	Types  map[string]FuncType
+	Error  string
+}{
+	{
+		Name:   "Decode",
+		Module: testModule,
+		Types: map[string]FuncType{
+			"add":  {Params: []ValueType{I32, I32}, Results: []ValueType{I32}},
+			"fact": {Params: []ValueType{I64}, Results: []ValueType{I64}},
+			"spin": {Params: []ValueType{}, Results: []ValueType{}},
+		},
+	},
+	{
+		Name:   "Not a Module",
+		Module: []byte("abcdefgh"),
+		Error:  "not a webassembly module",
+	},
+	{
+		Name:   "Unsupported Version",
+		Module: []byte{0x00, 0x61, 0x73, 0x6d, 0x02, 0x00, 0x00, 0x00},
+		Error:  "unsupported webassembly version",
+	},
+	{
+		Name: "Import Error",
+		Module: buildModule(
+			section(0x01, funcType(nil, nil)),
+			section(0x02, []byte{0x03, 'e', 'n', 'v', 0x04, 'e', 'x', 'i', 't', 0x00, 0x00}),
+		),
+		Error: "module must not import env.exit",
+	},
+	{
+		Name: "Unsupported Instruction Error",
+		Module: buildModule(
+			section(0x01, funcType(nil, nil)),
+			section(0x03, []byte{0}),
+			section(0x0a, funcBody(noLocals, 0xd0, 0x70, 0x0b)),
+		),
+		Error: "function 0: unsupported instruction 0xd0",
+	},
+	{
+		Name: "Unknown Label Error",
+		Module: buildModule(
+			section(0x01, funcType(nil, nil)),
+			section(0x03, []byte{0}),
+			section(0x0a, funcBody(noLocals, 0x0c, 0x01, 0x0b)),
+		),
+		Error: "function 0: unknown label",
+	},
+	{
+		Name: "Memory Instruction without Memory Error",
+		Module: buildModule(
+			section(0x01, funcType(nil, []ValueType{I32})),
+			section(0x03, []byte{0}),
+			section(0x0a, funcBody(noLocals, 0x3f, 0x00, 0x0b)),
+		),
+		Error: "memory instruction requires a memory",
+	},
+	{
+		Name: "Unexpected End Error",
+		Module: buildModule(
+			section(0x01, funcType(nil, nil)),
+			section(0x03, []byte{0}),
+			section(0x0a, funcBody(noLocals, 0x01)),
+		),
+		Error: "function 0: unexpected end of module",
+	},
+}
+
+func TestDecode(t *testing.T) {
+	for _, v := range decodeTests {
+		m, err := Decode(v.Module)
+		if err != nil {
+			if len(v.Error) < 1 {
+				t.Errorf("%s: unexpected error %q", v.Name, err)
+			} else if err.Error() != v.Error {
+				t.Errorf("%s: error %q, want error %q", v.Name, err.Error(), v.Error)
+			}
+			continue
+		}
+		if 0 < len(v.Error) {
+			t.Errorf("%s: no error, want error %q", v.Name, v.Error)
+			continue
+		}
+		for name, expect := range v.Types {
+			ft, ok := m.FuncType(name)
+			if !ok {
+				t.Errorf("%s: function %s is not found", v.Name, name)
+				continue
+			}
+			if !reflect.DeepEqual(ft, expect) {
+				t.Errorf("%s: type of %s = %v, want %v", v.Name, name, ft, expect)
+			}
+		}
+	}
+}
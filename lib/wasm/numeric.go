@@ -0,0 +1,459 @@
+package wasm
+
+import (
+	"math"
+	"math/bits"
+)
+
+func isUnaryOp(op uint16) bool {
+	switch {
+	case op == 0x45 || op == 0x50:
+		return true
+	case 0x67 <= op && op <= 0x69, 0x79 <= op && op <= 0x7b:
+		return true
+	case 0x8b <= op && op <= 0x91, 0x99 <= op && op <= 0x9f:
+		return true
+	case 0xa7 <= op && op <= opI64Extend32S:
+		return true
+	case opTruncSatBase <= op && op <= opTruncSatBase+7:
+		return true
+	}
+	return false
+}
+
+func f32(v uint64) float32 {
+	return math.Float32frombits(uint32(v))
+}
+
+func f64(v uint64) float64 {
+	return math.Float64frombits(v)
+}
+
+func fromF32(f float32) uint64 {
+	return uint64(math.Float32bits(f))
+}
+
+func fromF64(f float64) uint64 {
+	return math.Float64bits(f)
+}
+
+func fromBool(b bool) uint64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func truncate(f float64, min float64, max float64) float64 {
+	if math.IsNaN(f) {
+		panic(trap("invalid conversion to integer"))
+	}
+	t := math.Trunc(f)
+	if t < min || max <= t {
+		panic(trap("integer overflow"))
+	}
+	return t
+}
+
+func truncateSat(f float64, min float64, max float64) (float64, int) {
+	if math.IsNaN(f) {
+		return 0, 0
+	}
+	t := math.Trunc(f)
+	if t < min {
+		return 0, -1
+	}
+	if max <= t {
+		return 0, 1
+	}
+	return t, 0
+}
+
+func unaryOp(op uint16, v uint64) uint64 {
+	switch op {
+	case 0x45: // i32.eqz
+		return fromBool(uint32(v) == 0)
+	case 0x50: // i64.eqz
+		return fromBool(v == 0)
+	case 0x67:
+		return uint64(bits.LeadingZeros32(uint32(v)))
+	case 0x68:
+		return uint64(bits.TrailingZeros32(uint32(v)))
+	case 0x69:
+		return uint64(bits.OnesCount32(uint32(v)))
+	case 0x79:
+		return uint64(bits.LeadingZeros64(v))
+	case 0x7a:
+		return uint64(bits.TrailingZeros64(v))
+	case 0x7b:
+		return uint64(bits.OnesCount64(v))
+
+	case 0x8b: // f32.abs
+		return v &^ (1 << 31)
+	case 0x8c: // f32.neg
+		return uint64(uint32(v) ^ (1 << 31))
+	case 0x8d:
+		return fromF32(float32(math.Ceil(float64(f32(v)))))
+	case 0x8e:
+		return fromF32(float32(math.Floor(float64(f32(v)))))
+	case 0x8f:
+		return fromF32(float32(math.Trunc(float64(f32(v)))))
+	case 0x90:
+		return fromF32(float32(math.RoundToEven(float64(f32(v)))))
+	case 0x91:
+		return fromF32(float32(math.Sqrt(float64(f32(v)))))
+	case 0x99: // f64.abs
+		return v &^ (1 << 63)
+	case 0x9a: // f64.neg
+		return v ^ (1 << 63)
+	case 0x9b:
+		return fromF64(math.Ceil(f64(v)))
+	case 0x9c:
+		return fromF64(math.Floor(f64(v)))
+	case 0x9d:
+		return fromF64(math.Trunc(f64(v)))
+	case 0x9e:
+		return fromF64(math.RoundToEven(f64(v)))
+	case 0x9f:
+		return fromF64(math.Sqrt(f64(v)))
+
+	case 0xa7: // i32.wrap_i64
+		return uint64(uint32(v))
+	case 0xa8:
+		return uint64(uint32(int32(truncate(float64(f32(v)), -2147483648, 2147483648))))
+	case 0xa9:
+		return uint64(uint32(truncate(float64(f32(v)), 0, 4294967296)))
+	case 0xaa:
+		return uint64(uint32(int32(truncate(f64(v), -2147483648, 2147483648))))
+	case 0xab:
+		return uint64(uint32(truncate(f64(v), 0, 4294967296)))
+	case 0xac: // i64.extend_i32_s
+		return uint64(int64(int32(v)))
+	case 0xad: // i64.extend_i32_u
+		return uint64(uint32(v))
+	case 0xae:
+		return uint64(int64(truncate(float64(f32(v)), -9223372036854775808, 9223372036854775808)))
+	case 0xaf:
+		return uint64(truncate(float64(f32(v)), 0, 18446744073709551616))
+	case 0xb0:
+		return uint64(int64(truncate(f64(v), -9223372036854775808, 9223372036854775808)))
+	case 0xb1:
+		return uint64(truncate(f64(v), 0, 18446744073709551616))
+	case 0xb2:
+		return fromF32(float32(int32(v)))
+	case 0xb3:
+		return fromF32(float32(uint32(v)))
+	case 0xb4:
+		return fromF32(float32(int64(v)))
+	case 0xb5:
+		return fromF32(float32(v))
+	case 0xb6: // f32.demote_f64
+		return fromF32(float32(f64(v)))
+	case 0xb7:
+		return fromF64(float64(int32(v)))
+	case 0xb8:
+		return fromF64(float64(uint32(v)))
+	case 0xb9:
+		return fromF64(float64(int64(v)))
+	case 0xba:
+		return fromF64(float64(v))
+	case 0xbb: // f64.promote_f32
+		return fromF64(float64(f32(v)))
+	case 0xbc, 0xbd, 0xbe, 0xbf: // reinterpret
+		return v
+	case 0xc0:
+		return uint64(uint32(int32(int8(v))))
+	case 0xc1:
+		return uint64(uint32(int32(int16(v))))
+	case 0xc2:
+		return uint64(int64(int8(v)))
+	case 0xc3:
+		return uint64(int64(int16(v)))
+	case 0xc4:
+		return uint64(int64(int32(v)))
+	}
+
+	// Saturating truncation
+	var f float64
+	if op == opTruncSatBase || op == opTruncSatBase+1 || op == opTruncSatBase+4 || op == opTruncSatBase+5 {
+		f = float64(f32(v))
+	} else {
+		f = f64(v)
+	}
+	switch op - opTruncSatBase {
+	case 0, 2:
+		t, s := truncateSat(f, -2147483648, 2147483648)
+		switch s {
+		case -1:
+			return 1 << 31
+		case 1:
+			return uint64(uint32(math.MaxInt32))
+		}
+		return uint64(uint32(int32(t)))
+	case 1, 3:
+		t, s := truncateSat(f, 0, 4294967296)
+		switch s {
+		case -1:
+			return 0
+		case 1:
+			return math.MaxUint32
+		}
+		return uint64(uint32(t))
+	case 4, 6:
+		t, s := truncateSat(f, -9223372036854775808, 9223372036854775808)
+		switch s {
+		case -1:
+			return 1 << 63
+		case 1:
+			return math.MaxInt64
+		}
+		return uint64(int64(t))
+	default:
+		t, s := truncateSat(f, 0, 18446744073709551616)
+		switch s {
+		case -1:
+			return 0
+		case 1:
+			return math.MaxUint64
+		}
+		return uint64(t)
+	}
+}
+
+func binaryOp(op uint16, a uint64, b uint64) uint64 {
+	switch {
+	case 0x46 <= op && op <= 0x4f:
+		return i32Compare(op, uint32(a), uint32(b))
+	case 0x51 <= op && op <= 0x5a:
+		return i64Compare(op-0x0b, a, b)
+	case 0x5b <= op && op <= 0x60:
+		return floatCompare(op-0x5b, float64(f32(a)), float64(f32(b)))
+	case 0x61 <= op && op <= 0x66:
+		return floatCompare(op-0x61, f64(a), f64(b))
+	case 0x6a <= op && op <= 0x78:
+		return uint64(i32Arith(op, uint32(a), uint32(b)))
+	case 0x7c <= op && op <= 0x8a:
+		return i64Arith(op-0x12, a, b)
+	case 0x92 <= op && op <= 0x98:
+		return f32Arith(op, a, b)
+	case 0xa0 <= op && op <= 0xa6:
+		return f64Arith(op, a, b)
+	}
+	panic(trap("invalid instruction"))
+}
+
+func i32Compare(op uint16, a uint32, b uint32) uint64 {
+	switch op {
+	case 0x46:
+		return fromBool(a == b)
+	case 0x47:
+		return fromBool(a != b)
+	case 0x48:
+		return fromBool(int32(a) < int32(b))
+	case 0x49:
+		return fromBool(a < b)
+	case 0x4a:
+		return fromBool(int32(a) > int32(b))
+	case 0x4b:
+		return fromBool(a > b)
+	case 0x4c:
+		return fromBool(int32(a) <= int32(b))
+	case 0x4d:
+		return fromBool(a <= b)
+	case 0x4e:
+		return fromBool(int32(a) >= int32(b))
+	default:
+		return fromBool(a >= b)
+	}
+}
+
+// i64Compare takes an opcode shifted to the range of the i32 comparisons.
+func i64Compare(op uint16, a uint64, b uint64) uint64 {
+	switch op {
+	case 0x46:
+		return fromBool(a == b)
+	case 0x47:
+		return fromBool(a != b)
+	case 0x48:
+		return fromBool(int64(a) < int64(b))
+	case 0x49:
+		return fromBool(a < b)
+	case 0x4a:
+		return fromBool(int64(a) > int64(b))
+	case 0x4b:
+		return fromBool(a > b)
+	case 0x4c:
+		return fromBool(int64(a) <= int64(b))
+	case 0x4d:
+		return fromBool(a <= b)
+	case 0x4e:
+		return fromBool(int64(a) >= int64(b))
+	default:
+		return fromBool(a >= b)
+	}
+}
+
+func floatCompare(op uint16, a float64, b float64) uint64 {
+	switch op {
+	case 0:
+		return fromBool(a == b)
+	case 1:
+		return fromBool(a != b)
+	case 2:
+		return fromBool(a < b)
+	case 3:
+		return fromBool(a > b)
+	case 4:
+		return fromBool(a <= b)
+	default:
+		return fromBool(a >= b)
+	}
+}
+
+func i32Arith(op uint16, a uint32, b uint32) uint32 {
+	switch op {
+	case 0x6a:
+		return a + b
+	case 0x6b:
+		return a - b
+	case 0x6c:
+		return a * b
+	case 0x6d:
+		if b == 0 {
+			panic(trap("integer divide by zero"))
+		}
+		if int32(a) == math.MinInt32 && int32(b) == -1 {
+			panic(trap("integer overflow"))
+		}
+		return uint32(int32(a) / int32(b))
+	case 0x6e:
+		if b == 0 {
+			panic(trap("integer divide by zero"))
+		}
+		return a / b
+	case 0x6f:
+		if b == 0 {
+			panic(trap("integer divide by zero"))
+		}
+		if int32(b) == -1 {
+			return 0
+		}
+		return uint32(int32(a) % int32(b))
+	case 0x70:
+		if b == 0 {
+			panic(trap("integer divide by zero"))
+		}
+		return a % b
+	case 0x71:
+		return a & b
+	case 0x72:
+		return a | b
+	case 0x73:
+		return a ^ b
+	case 0x74:
+		return a << (b & 31)
+	case 0x75:
+		return uint32(int32(a) >> (b & 31))
+	case 0x76:
+		return a >> (b & 31)
+	case 0x77:
+		return bits.RotateLeft32(a, int(b&31))
+	default:
+		return bits.RotateLeft32(a, -int(b&31))
+	}
+}
+
+// i64Arith takes an opcode shifted to the range of the i32 arithmetic
+// operations.
+func i64Arith(op uint16, a uint64, b uint64) uint64 {
+	switch op {
+	case 0x6a:
+		return a + b
+	case 0x6b:
+		return a - b
+	case 0x6c:
+		return a * b
+	case 0x6d:
+		if b == 0 {
+			panic(trap("integer divide by zero"))
+		}
+		if int64(a) == math.MinInt64 && int64(b) == -1 {
+			panic(trap("integer overflow"))
+		}
+		return uint64(int64(a) / int64(b))
+	case 0x6e:
+		if b == 0 {
+			panic(trap("integer divide by zero"))
+		}
+		return a / b
+	case 0x6f:
+		if b == 0 {
+			panic(trap("integer divide by zero"))
+		}
+		if int64(b) == -1 {
+			return 0
+		}
+		return uint64(int64(a) % int64(b))
+	case 0x70:
+		if b == 0 {
+			panic(trap("integer divide by zero"))
+		}
+		return a % b
+	case 0x71:
+		return a & b
+	case 0x72:
+		return a | b
+	case 0x73:
+		return a ^ b
+	case 0x74:
+		return a << (b & 63)
+	case 0x75:
+		return uint64(int64(a) >> (b & 63))
+	case 0x76:
+		return a >> (b & 63)
+	case 0x77:
+		return bits.RotateLeft64(a, int(b&63))
+	default:
+		return bits.RotateLeft64(a, -int(b&63))
+	}
+}
+
+func f32Arith(op uint16, a uint64, b uint64) uint64 {
+	x, y := f32(a), f32(b)
+	switch op {
+	case 0x92:
+		return fromF32(x + y)
+	case 0x93:
+		return fromF32(x - y)
+	case 0x94:
+		return fromF32(x * y)
+	case 0x95:
+		return fromF32(x / y)
+	case 0x96:
+		return fromF32(float32(math.Min(float64(x), float64(y))))
+	case 0x97:
+		return fromF32(float32(math.Max(float64(x), float64(y))))
+	default: // copysign
+		return uint64(uint32(a)&^(1<<31) | uint32(b)&(1<<31))
+	}
+}
+
+func f64Arith(op uint16, a uint64, b uint64) uint64 {
+	x, y := f64(a), f64(b)
+	switch op {
+	case 0xa0:
+		return fromF64(x + y)
+	case 0xa1:
+		return fromF64(x - y)
+	case 0xa2:
+		return fromF64(x * y)
+	case 0xa3:
+		return fromF64(x / y)
+	case 0xa4:
+		return fromF64(math.Min(x, y))
+	case 0xa5:
+		return fromF64(math.Max(x, y))
+	default: // copysign
+		return a&^(1<<63) | b&(1<<63)
+	}
+}
@@ -0,0 +1,147 @@
+package wasm
+
+import (
+	"errors"
+	"math"
+)
+
+var errUnexpectedEnd = errors.New("unexpected end of module")
+
+type reader struct {
+	buf []byte
+	pos int
+}
+
+func newReader(buf []byte) *reader {
+	return &reader{buf: buf}
+}
+
+func (r *reader) eof() bool {
+	return len(r.buf) <= r.pos
+}
+
+func (r *reader) byte() (byte, error) {
+	if r.eof() {
+		return 0, errUnexpectedEnd
+	}
+	b := r.buf[r.pos]
+	r.pos++
+	return b, nil
+}
+
+func (r *reader) bytes(n int) ([]byte, error) {
+	if n < 0 || len(r.buf)-r.pos < n {
+		return nil, errUnexpectedEnd
+	}
+	b := r.buf[r.pos : r.pos+n]
+	r.pos += n
+	return b, nil
+}
+
+func (r *reader) u32() (uint32, error) {
+	var result uint32
+	var shift uint
+	for i := 0; i < 5; i++ {
+		b, err := r.byte()
+		if err != nil {
+			return 0, err
+		}
+		result |= uint32(b&0x7f) << shift
+		if b&0x80 == 0 {
+			return result, nil
+		}
+		shift += 7
+	}
+	return 0, errors.New("integer representation too long")
+}
+
+func (r *reader) signed(size uint) (int64, error) {
+	var result int64
+	var shift uint
+	var b byte
+	var err error
+	for {
+		if b, err = r.byte(); err != nil {
+			return 0, err
+		}
+		result |= int64(b&0x7f) << shift
+		shift += 7
+		if b&0x80 == 0 {
+			break
+		}
+		if size+7 <= shift {
+			return 0, errors.New("integer representation too long")
+		}
+	}
+	if shift < 64 && b&0x40 != 0 {
+		result |= -1 << shift
+	}
+	return result, nil
+}
+
+func (r *reader) s32() (int32, error) {
+	i, err := r.signed(32)
+	return int32(i), err
+}
+
+func (r *reader) s33() (int64, error) {
+	return r.signed(33)
+}
+
+func (r *reader) s64() (int64, error) {
+	return r.signed(64)
+}
+
+func (r *reader) f32() (uint32, error) {
+	b, err := r.bytes(4)
+	if err != nil {
+		return 0, err
+	}
+	return uint32(b[0]) | uint32(b[1])<<8 | uint32(b[2])<<16 | uint32(b[3])<<24, nil
+}
+
+func (r *reader) f64() (uint64, error) {
+	b, err := r.bytes(8)
+	if err != nil {
+		return 0, err
+	}
+	var bits uint64
+	for i := 7; 0 <= i; i-- {
+		bits = bits<<8 | uint64(b[i])
+	}
+	return bits, nil
+}
+
+func (r *reader) name() (string, error) {
+	n, err := r.u32()
+	if err != nil {
+		return "", err
+	}
+	b, err := r.bytes(int(n))
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func (r *reader) limits() (uint32, uint32, error) {
+	flag, err := r.byte()
+	if err != nil {
+		return 0, 0, err
+	}
+	min, err := r.u32()
+	if err != nil {
+		return 0, 0, err
+	}
+	max := uint32(math.MaxUint32)
+	switch flag {
+	case 0x00:
+	case 0x01:
+		if max, err = r.u32(); err != nil {
+			return 0, 0, err
+		}
+	default:
+		return 0, 0, errors.New("invalid limits")
+	}
+	return min, max, nil
+}
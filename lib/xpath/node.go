@@ -0,0 +1,99 @@
+package xpath
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+)
+
+type NodeType int
+
+const (
+	RootNode NodeType = iota
+	ElementNode
+	AttributeNode
+	TextNode
+)
+
+type Node struct {
+	Type     NodeType
+	Name     string
+	Value    string
+	Attrs    []*Node
+	Children []*Node
+	Parent   *Node
+}
+
+func (n *Node) StringValue() string {
+	switch n.Type {
+	case AttributeNode, TextNode:
+		return n.Value
+	}
+
+	var buf strings.Builder
+	n.writeText(&buf)
+	return buf.String()
+}
+
+func (n *Node) writeText(buf *strings.Builder) {
+	for _, c := range n.Children {
+		if c.Type == TextNode {
+			buf.WriteString(c.Value)
+		} else {
+			c.writeText(buf)
+		}
+	}
+}
+
+func Parse(text string) (*Node, error) {
+	root := &Node{Type: RootNode}
+	current := root
+
+	d := xml.NewDecoder(strings.NewReader(text))
+	for {
+		token, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch token.(type) {
+		case xml.StartElement:
+			elem := token.(xml.StartElement)
+			node := &Node{
+				Type:   ElementNode,
+				Name:   elem.Name.Local,
+				Parent: current,
+			}
+			if 0 < len(elem.Attr) {
+				node.Attrs = make([]*Node, 0, len(elem.Attr))
+				for _, attr := range elem.Attr {
+					node.Attrs = append(node.Attrs, &Node{
+						Type:   AttributeNode,
+						Name:   attr.Name.Local,
+						Value:  attr.Value,
+						Parent: node,
+					})
+				}
+			}
+			current.Children = append(current.Children, node)
+			current = node
+		case xml.EndElement:
+			current = current.Parent
+		case xml.CharData:
+			current.Children = append(current.Children, &Node{
+				Type:   TextNode,
+				Value:  string(token.(xml.CharData)),
+				Parent: current,
+			})
+		}
+	}
+
+	if current != root {
+		return nil, errors.New("unexpected end of xml")
+	}
+	return root, nil
+}
@@ -0,0 +1,306 @@
+package xpath
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type StepKind int
+
+const (
+	ElementStep StepKind = iota
+	AttributeStep
+	TextStep
+	AnyNodeStep
+	SelfStep
+	ParentStep
+)
+
+type Path struct {
+	Absolute bool
+	Steps    []Step
+}
+
+type Step struct {
+	Kind       StepKind
+	Descendant bool
+	Name       string
+	Predicates []Predicate
+}
+
+type Predicate struct {
+	Position int
+	Last     bool
+	Path     *Path
+	Operator string
+	Literal  string
+}
+
+type pathParser struct {
+	src []rune
+	pos int
+}
+
+func ParsePath(s string) (*Path, error) {
+	p := &pathParser{src: []rune(s)}
+
+	path, err := p.parsePath()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpaces()
+	if p.pos < len(p.src) {
+		return nil, p.unexpected()
+	}
+	if !path.Absolute && len(path.Steps) < 1 {
+		return nil, errors.New("xpath is empty")
+	}
+	return path, nil
+}
+
+func (p *pathParser) unexpected() error {
+	if len(p.src) <= p.pos {
+		return errors.New("unexpected end of xpath")
+	}
+	return errors.New(fmt.Sprintf("unexpected character %q in xpath at position %d", p.src[p.pos], p.pos+1))
+}
+
+func (p *pathParser) skipSpaces() {
+	for p.pos < len(p.src) && unicode.IsSpace(p.src[p.pos]) {
+		p.pos++
+	}
+}
+
+func (p *pathParser) hasPrefix(s string) bool {
+	return strings.HasPrefix(string(p.src[p.pos:]), s)
+}
+
+func (p *pathParser) parsePath() (*Path, error) {
+	path := &Path{}
+	p.skipSpaces()
+
+	descendant := false
+	switch {
+	case p.hasPrefix("//"):
+		path.Absolute = true
+		descendant = true
+		p.pos += 2
+	case p.hasPrefix("/"):
+		path.Absolute = true
+		p.pos++
+		p.skipSpaces()
+		if !p.isStepStart() {
+			return path, nil
+		}
+	}
+
+	for {
+		step, err := p.parseStep()
+		if err != nil {
+			return nil, err
+		}
+		step.Descendant = descendant
+		path.Steps = append(path.Steps, step)
+
+		p.skipSpaces()
+		if p.hasPrefix("//") {
+			descendant = true
+			p.pos += 2
+		} else if p.hasPrefix("/") {
+			descendant = false
+			p.pos++
+		} else {
+			break
+		}
+	}
+
+	return path, nil
+}
+
+func (p *pathParser) isStepStart() bool {
+	if len(p.src) <= p.pos {
+		return false
+	}
+	c := p.src[p.pos]
+	return c == '.' || c == '@' || c == '*' || isNameStartChar(c)
+}
+
+func isNameStartChar(c rune) bool {
+	return c == '_' || unicode.IsLetter(c)
+}
+
+func isNameChar(c rune) bool {
+	return isNameStartChar(c) || c == '-' || c == '.' || c == ':' || unicode.IsDigit(c)
+}
+
+func (p *pathParser) parseName() (string, error) {
+	if p.pos < len(p.src) && p.src[p.pos] == '*' {
+		p.pos++
+		return "*", nil
+	}
+	if len(p.src) <= p.pos || !isNameStartChar(p.src[p.pos]) {
+		return "", p.unexpected()
+	}
+
+	start := p.pos
+	for p.pos < len(p.src) && isNameChar(p.src[p.pos]) {
+		p.pos++
+	}
+	return string(p.src[start:p.pos]), nil
+}
+
+func (p *pathParser) parseStep() (Step, error) {
+	var step Step
+	p.skipSpaces()
+
+	switch {
+	case p.hasPrefix(".."):
+		p.pos += 2
+		step.Kind = ParentStep
+		return step, nil
+	case p.hasPrefix("."):
+		p.pos++
+		step.Kind = SelfStep
+		return step, nil
+	case p.hasPrefix("@"):
+		p.pos++
+		name, err := p.parseName()
+		if err != nil {
+			return step, err
+		}
+		step.Kind = AttributeStep
+		step.Name = name
+	default:
+		name, err := p.parseName()
+		if err != nil {
+			return step, err
+		}
+		p.skipSpaces()
+		if p.hasPrefix("(") {
+			p.pos++
+			p.skipSpaces()
+			if !p.hasPrefix(")") {
+				return step, p.unexpected()
+			}
+			p.pos++
+
+			switch name {
+			case "text":
+				step.Kind = TextStep
+			case "node":
+				step.Kind = AnyNodeStep
+			default:
+				return step, errors.New(fmt.Sprintf("function %s() is not supported in xpath", name))
+			}
+		} else {
+			step.Kind = ElementStep
+			step.Name = name
+		}
+	}
+
+	for {
+		p.skipSpaces()
+		if !p.hasPrefix("[") {
+			break
+		}
+		p.pos++
+
+		predicate, err := p.parsePredicate()
+		if err != nil {
+			return step, err
+		}
+		step.Predicates = append(step.Predicates, predicate)
+
+		p.skipSpaces()
+		if !p.hasPrefix("]") {
+			return step, p.unexpected()
+		}
+		p.pos++
+	}
+
+	return step, nil
+}
+
+func (p *pathParser) parsePredicate() (Predicate, error) {
+	var predicate Predicate
+	p.skipSpaces()
+
+	if p.pos < len(p.src) && unicode.IsDigit(p.src[p.pos]) {
+		start := p.pos
+		for p.pos < len(p.src) && unicode.IsDigit(p.src[p.pos]) {
+			p.pos++
+		}
+		i, err := strconv.Atoi(string(p.src[start:p.pos]))
+		if err != nil || i < 1 {
+			return predicate, errors.New(fmt.Sprintf("invalid position %s in xpath", string(p.src[start:p.pos])))
+		}
+		predicate.Position = i
+		return predicate, nil
+	}
+
+	if p.hasPrefix("last()") {
+		p.pos += 6
+		predicate.Last = true
+		return predicate, nil
+	}
+
+	path, err := p.parsePath()
+	if err != nil {
+		return predicate, err
+	}
+	predicate.Path = path
+
+	p.skipSpaces()
+	switch {
+	case p.hasPrefix("!="):
+		predicate.Operator = "!="
+		p.pos += 2
+	case p.hasPrefix("="):
+		predicate.Operator = "="
+		p.pos++
+	default:
+		return predicate, nil
+	}
+
+	p.skipSpaces()
+	literal, err := p.parseLiteral()
+	if err != nil {
+		return predicate, err
+	}
+	predicate.Literal = literal
+	return predicate, nil
+}
+
+func (p *pathParser) parseLiteral() (string, error) {
+	if len(p.src) <= p.pos {
+		return "", p.unexpected()
+	}
+
+	quote := p.src[p.pos]
+	if quote == '\'' || quote == '"' {
+		p.pos++
+		start := p.pos
+		for p.pos < len(p.src) && p.src[p.pos] != quote {
+			p.pos++
+		}
+		if len(p.src) <= p.pos {
+			return "", errors.New("string literal is not terminated in xpath")
+		}
+		s := string(p.src[start:p.pos])
+		p.pos++
+		return s, nil
+	}
+
+	start := p.pos
+	for p.pos < len(p.src) && (unicode.IsDigit(p.src[p.pos]) || p.src[p.pos] == '.' || p.src[p.pos] == '-') {
+		p.pos++
+	}
+	if start == p.pos {
+		return "", p.unexpected()
+	}
+	return string(p.src[start:p.pos]), nil
+}
@@ -0,0 +1,160 @@
+package xpath
+
+import (
+	"strconv"
+	"strings"
+)
+
+func Load(xpath string, xmltext string) ([]*Node, error) {
+	path, err := ParsePath(xpath)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := Parse(xmltext)
+	if err != nil {
+		return nil, err
+	}
+
+	return path.Select(root), nil
+}
+
+func (path *Path) Select(context *Node) []*Node {
+	nodes := []*Node{context}
+	if path.Absolute {
+		for nodes[0].Parent != nil {
+			nodes[0] = nodes[0].Parent
+		}
+	}
+
+	for _, step := range path.Steps {
+		nodes = step.apply(nodes)
+		if len(nodes) < 1 {
+			break
+		}
+	}
+	return nodes
+}
+
+func (step Step) apply(nodes []*Node) []*Node {
+	if step.Descendant {
+		nodes = descendantOrSelf(nodes)
+	}
+
+	result := make([]*Node, 0, len(nodes))
+	selected := make(map[*Node]bool)
+
+	for _, node := range nodes {
+		for _, n := range step.filter(step.candidates(node)) {
+			if !selected[n] {
+				selected[n] = true
+				result = append(result, n)
+			}
+		}
+	}
+	return result
+}
+
+func descendantOrSelf(nodes []*Node) []*Node {
+	list := make([]*Node, 0, len(nodes))
+	var walk func(*Node)
+	walk = func(n *Node) {
+		list = append(list, n)
+		for _, c := range n.Children {
+			if c.Type != TextNode {
+				walk(c)
+			}
+		}
+	}
+	for _, n := range nodes {
+		walk(n)
+	}
+	return list
+}
+
+func (step Step) candidates(node *Node) []*Node {
+	var list []*Node
+
+	switch step.Kind {
+	case SelfStep:
+		list = []*Node{node}
+	case ParentStep:
+		if node.Parent != nil {
+			list = []*Node{node.Parent}
+		}
+	case AttributeStep:
+		for _, attr := range node.Attrs {
+			if step.Name == "*" || attr.Name == step.Name {
+				list = append(list, attr)
+			}
+		}
+	default:
+		for _, c := range node.Children {
+			switch step.Kind {
+			case ElementStep:
+				if c.Type == ElementNode && (step.Name == "*" || c.Name == step.Name) {
+					list = append(list, c)
+				}
+			case TextStep:
+				if c.Type == TextNode {
+					list = append(list, c)
+				}
+			case AnyNodeStep:
+				list = append(list, c)
+			}
+		}
+	}
+
+	return list
+}
+
+func (step Step) filter(nodes []*Node) []*Node {
+	for _, predicate := range step.Predicates {
+		if len(nodes) < 1 {
+			break
+		}
+
+		switch {
+		case predicate.Last:
+			nodes = nodes[len(nodes)-1:]
+		case 0 < predicate.Position:
+			if len(nodes) < predicate.Position {
+				nodes = nil
+			} else {
+				nodes = nodes[predicate.Position-1 : predicate.Position]
+			}
+		default:
+			list := make([]*Node, 0, len(nodes))
+			for _, n := range nodes {
+				if predicate.match(n) {
+					list = append(list, n)
+				}
+			}
+			nodes = list
+		}
+	}
+	return nodes
+}
+
+func (predicate Predicate) match(node *Node) bool {
+	selected := predicate.Path.Select(node)
+	if len(predicate.Operator) < 1 {
+		return 0 < len(selected)
+	}
+
+	for _, n := range selected {
+		if equals(n.StringValue(), predicate.Literal) == (predicate.Operator == "=") {
+			return true
+		}
+	}
+	return false
+}
+
+func equals(s string, literal string) bool {
+	if f2, err := strconv.ParseFloat(literal, 64); err == nil {
+		if f1, err := strconv.ParseFloat(strings.TrimSpace(s), 64); err == nil {
+			return f1 == f2
+		}
+	}
+	return s == literal
+}
@@ -0,0 +1,115 @@
+package xpath
+
+import (
+	"reflect"
+	"testing"
+)
+
+var loadTests = []struct {
+	Path   string
+	Xml    string
+	Result []string
+	Error  string
+}{
+	{
+		Path:   "/root/item",
+		Xml:    "<root><item>a</item><item>b</item></root>",
+		Result: []string{"a", "b"},
+	},
+	{
+		Path:   "root/item[2]",
+		Xml:    "<root><item>a</item><item>b</item></root>",
+		Result: []string{"b"},
+	},
+	{
+		Path:   "//item[last()]",
+		Xml:    "<root><list><item>a</item><item>b</item></list><list><item>c</item></list></root>",
+		Result: []string{"b", "c"},
+	},
+	{
+		Path:   "//item/@id",
+		Xml:    "<root><item id=\"1\">a</item><item>b</item><item id=\"3\">c</item></root>",
+		Result: []string{"1", "3"},
+	},
+	{
+		Path:   "//item[@id = '3']",
+		Xml:    "<root><item id=\"1\">a</item><item id=\"3\">c</item></root>",
+		Result: []string{"c"},
+	},
+	{
+		Path:   "/root/*[price = 1.5]/name",
+		Xml:    "<root><book><name>x</name><price>1.50</price></book><pen><name>y</name><price>2</price></pen></root>",
+		Result: []string{"x"},
+	},
+	{
+		Path:   "//item[sub]",
+		Xml:    "<root><item>a</item><item>b<sub>c</sub></item></root>",
+		Result: []string{"bc"},
+	},
+	{
+		Path:   "//sub/../text()",
+		Xml:    "<root><item>a</item><item>b<sub>c</sub></item></root>",
+		Result: []string{"b"},
+	},
+	{
+		Path:   "/",
+		Xml:    "<a>x</a><b>y</b>",
+		Result: []string{"xy"},
+	},
+	{
+		Path:   "//missing",
+		Xml:    "<root/>",
+		Result: []string{},
+	},
+	{
+		Path:  "//item[",
+		Xml:   "<root/>",
+		Error: "unexpected end of xpath",
+	},
+	{
+		Path:  "//item[0]",
+		Xml:   "<root/>",
+		Error: "invalid position 0 in xpath",
+	},
+	{
+		Path:  "count()",
+		Xml:   "<root/>",
+		Error: "function count() is not supported in xpath",
+	},
+	{
+		Path:  "//item",
+		Xml:   "<root><item></root>",
+		Error: "XML syntax error on line 1: element <item> closed by </root>",
+	},
+	{
+		Path:  "//item",
+		Xml:   "<root><item/>",
+		Error: "XML syntax error on line 1: unexpected EOF",
+	},
+}
+
+func TestLoad(t *testing.T) {
+	for _, v := range loadTests {
+		nodes, err := Load(v.Path, v.Xml)
+		if err != nil {
+			if len(v.Error) < 1 {
+				t.Errorf("unexpected error %q for %q", err, v.Path)
+			} else if err.Error() != v.Error {
+				t.Errorf("error %q, want error %q for %q", err.Error(), v.Error, v.Path)
+			}
+			continue
+		}
+		if 0 < len(v.Error) {
+			t.Errorf("no error, want error %q for %q", v.Error, v.Path)
+			continue
+		}
+
+		result := make([]string, 0, len(nodes))
+		for _, n := range nodes {
+			result = append(result, n.StringValue())
+		}
+		if !reflect.DeepEqual(result, v.Result) {
+			t.Errorf("result = %q, want %q for %q", result, v.Result, v.Path)
+		}
+	}
+}
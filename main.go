@@ -59,11 +59,25 @@ func main() {
 			Name:  "datetime-format, t",
 			Usage: "datetime format to parse strings",
 		},
+		cli.BoolFlag{
+			Name:  "case-sensitive",
+			Usage: "compare strings case-sensitively",
+		},
 		cli.Float64Flag{
 			Name:  "wait-timeout, w",
 			Value: 10,
 			Usage: "limit of the waiting time in seconds to wait for locked files to be released",
 		},
+		cli.IntFlag{
+			Name:  "lock-retry-limit",
+			Value: -1,
+			Usage: "maximum number of retries to lock a file. -1 is no limit",
+		},
+		cli.StringFlag{
+			Name:  "lock-backoff",
+			Value: "FIXED",
+			Usage: "strategy of delays between retries to lock a file. one of: FIXED|EXPONENTIAL|JITTER",
+		},
 		cli.StringFlag{
 			Name:  "source, s",
 			Usage: "load query or statements from `FILE`",
@@ -169,6 +183,91 @@ func main() {
 			Value: cmd.GetDefaultNumberOfCPU(),
 			Usage: "hint for the number of cpu cores to be used",
 		},
+		cli.IntFlag{
+			Name:  "limit-recursion, R",
+			Value: cmd.DefaultLimitRecursion,
+			Usage: "maximum number of iterations for recursive queries. -1 is no limit",
+		},
+		cli.Int64Flag{
+			Name:  "random-seed",
+			Value: -1,
+			Usage: "seed for pseudo-random number generation. -1 is not to set a seed",
+		},
+		cli.IntFlag{
+			Name:  "sort-buffer-size",
+			Value: -1,
+			Usage: "maximum memory size in megabytes for sorting records in streaming mode. -1 is no limit",
+		},
+		cli.IntFlag{
+			Name:  "max-memory",
+			Value: -1,
+			Usage: "approximate maximum memory size in megabytes for holding records. -1 is no limit",
+		},
+		cli.BoolFlag{
+			Name:  "mmap",
+			Usage: "read local files through memory mapping",
+		},
+		cli.BoolFlag{
+			Name:  "columnar",
+			Usage: "store records of loaded tables in column-oriented layout",
+		},
+		cli.BoolFlag{
+			Name:  "statistics-cache",
+			Usage: "save statistics of loaded files in sidecar files and use them for queries",
+		},
+		cli.BoolFlag{
+			Name:  "result-cache",
+			Usage: "cache the results of queries and reuse them while the files are not modified",
+		},
+		cli.BoolFlag{
+			Name:  "progress",
+			Usage: "show progress of loading, sorting and writing records",
+		},
+		cli.StringFlag{
+			Name:  "backup-extension",
+			Usage: "preserve files before updating them as files with names followed by `EXTENSION`",
+		},
+		cli.StringFlag{
+			Name:  "backup-dir",
+			Usage: "preserve files before updating them as files with the same names in `DIRECTORY`",
+		},
+		cli.StringFlag{
+			Name:  "audit-log",
+			Usage: "append changed records to `FILE` in JSON Lines format when they are committed",
+		},
+		cli.StringFlag{
+			Name:  "encryption-key-file",
+			Usage: "read the key to encrypt and decrypt files from `FILE`",
+		},
+		cli.StringFlag{
+			Name:  "on-commit",
+			Usage: "execute the statements in `FILE` after changes are committed to files",
+		},
+		cli.StringFlag{
+			Name:  "coordinator-token",
+			Usage: "commit together with the other processes specifying the same `TOKEN`",
+		},
+		cli.IntFlag{
+			Name:  "participants",
+			Value: 2,
+			Usage: "number of processes committing together with the coordinator token",
+		},
+		cli.BoolFlag{
+			Name:  "read-only",
+			Usage: "reject statements that change files and never lock files",
+		},
+		cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "show changes to be committed instead of writing them to files",
+		},
+		cli.StringFlag{
+			Name:  "profile",
+			Usage: "write profiles of queries to files in the working directory. one of: cpu|mem|trace",
+		},
+		cli.StringFlag{
+			Name:  "plugin",
+			Usage: "load plugins and webassembly modules that register functions from `DIRECTORY`",
+		},
 		cli.BoolFlag{
 			Name:  "stats, x",
 			Usage: "show execution time and memory statistics",
@@ -236,6 +335,60 @@ func main() {
 				return NewExitError(fmt.Sprintf("Incorrect Usage: %s", err.Error()), 1)
 			},
 		},
+		{
+			Name:  "server",
+			Usage: "Run an HTTP server executing posted queries",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "listen, L",
+					Value: "localhost:8080",
+					Usage: "`ADDRESS` to listen on",
+				},
+				cli.StringFlag{
+					Name:   "token, T",
+					EnvVar: "CSVQ_SERVER_TOKEN",
+					Usage:  "require requests to have `TOKEN` as a bearer token in the Authorization header",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				err := action.Serve(proc, c.String("listen"), c.String("token"))
+				if err != nil {
+					return NewExitError(err.Error(), 1)
+				}
+
+				return nil
+			},
+			OnUsageError: func(c *cli.Context, err error, isSubcommand bool) error {
+				return NewExitError(fmt.Sprintf("Incorrect Usage: %s", err.Error()), 1)
+			},
+		},
+		{
+			Name:  "pgserver",
+			Usage: "Run a server accepting connections from PostgreSQL clients",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "listen, L",
+					Value: "localhost:5432",
+					Usage: "`ADDRESS` to listen on",
+				},
+				cli.StringFlag{
+					Name:   "password, P",
+					EnvVar: "CSVQ_PGSERVER_PASSWORD",
+					Usage:  "require clients to be authenticated with `PASSWORD`",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				err := action.ServePG(proc, c.String("listen"), c.String("password"))
+				if err != nil {
+					return NewExitError(err.Error(), 1)
+				}
+
+				return nil
+			},
+			OnUsageError: func(c *cli.Context, err error, isSubcommand bool) error {
+				return NewExitError(fmt.Sprintf("Incorrect Usage: %s", err.Error()), 1)
+			},
+		},
 		{
 			Name:  "check-update",
 			Usage: "Check for updates",
@@ -279,6 +432,25 @@ func main() {
 		if err := overwriteFlags(c, tx); err != nil {
 			return NewExitError(err.Error(), 1)
 		}
+
+		// Interrupted commits are left as they are in read-only mode, since the recovery changes files.
+		if !tx.Flags.ReadOnly {
+			if err := query.RecoverInterruptedCommits(tx); err != nil {
+				return NewExitError(err.Error(), 1)
+			}
+		}
+
+		if c.IsSet("profile") {
+			if err := query.SetProfile(c.GlobalString("profile")); err != nil {
+				return NewExitError(err.Error(), 1)
+			}
+		}
+
+		if c.IsSet("plugin") {
+			if err := query.LoadPlugins(c.GlobalString("plugin")); err != nil {
+				return NewExitError(err.Error(), 1)
+			}
+		}
 		return nil
 	}
 
@@ -377,9 +549,21 @@ func overwriteFlags(c *cli.Context, tx *query.Transaction) error {
 	if c.IsSet("datetime-format") {
 		flags.SetDatetimeFormat(c.GlobalString("datetime-format"))
 	}
+	if c.IsSet("case-sensitive") {
+		flags.SetCaseSensitive(c.GlobalBool("case-sensitive"))
+	}
 	if c.IsSet("wait-timeout") {
 		tx.UpdateWaitTimeout(c.GlobalFloat64("wait-timeout"), file.DefaultRetryDelay)
 	}
+	if c.IsSet("lock-retry-limit") {
+		flags.SetLockRetryLimit(c.GlobalInt("lock-retry-limit"))
+	}
+	if c.IsSet("lock-backoff") {
+		if err := flags.SetLockBackoff(c.GlobalString("lock-backoff")); err != nil {
+			return err
+		}
+	}
+	tx.UpdateRetryPolicy()
 
 	if c.IsSet("import-format") {
 		if err := flags.SetImportFormat(c.GlobalString("import-format")); err != nil {
@@ -467,6 +651,76 @@ func overwriteFlags(c *cli.Context, tx *query.Transaction) error {
 	if c.IsSet("cpu") {
 		flags.SetCPU(c.GlobalInt("cpu"))
 	}
+	if c.IsSet("limit-recursion") {
+		flags.SetLimitRecursion(c.GlobalInt("limit-recursion"))
+	}
+	if c.IsSet("random-seed") {
+		flags.SetRandomSeed(c.GlobalInt64("random-seed"))
+	}
+	if c.IsSet("sort-buffer-size") {
+		flags.SetSortBufferSize(c.GlobalInt("sort-buffer-size"))
+	}
+	if c.IsSet("max-memory") {
+		flags.SetMaxMemory(c.GlobalInt("max-memory"))
+	}
+	if c.IsSet("mmap") {
+		flags.SetMmap(c.GlobalBool("mmap"))
+	}
+	if c.IsSet("columnar") {
+		flags.SetColumnar(c.GlobalBool("columnar"))
+	}
+	if c.IsSet("statistics-cache") {
+		flags.SetStatisticsCache(c.GlobalBool("statistics-cache"))
+	}
+	if c.IsSet("result-cache") {
+		flags.SetResultCache(c.GlobalBool("result-cache"))
+	}
+	if c.IsSet("progress") {
+		flags.SetProgress(c.GlobalBool("progress"))
+	}
+	if c.IsSet("backup-extension") {
+		if err := flags.SetBackupExtension(c.GlobalString("backup-extension")); err != nil {
+			return err
+		}
+	}
+	if c.IsSet("backup-dir") {
+		if err := flags.SetBackupDir(c.GlobalString("backup-dir")); err != nil {
+			return err
+		}
+	}
+	if c.IsSet("audit-log") {
+		if err := flags.SetAuditLog(c.GlobalString("audit-log")); err != nil {
+			return err
+		}
+	}
+	if c.IsSet("encryption-key-file") {
+		if err := flags.SetEncryptionKeyFile(c.GlobalString("encryption-key-file")); err != nil {
+			return err
+		}
+	}
+	if c.IsSet("on-commit") {
+		if err := flags.SetOnCommit(c.GlobalString("on-commit")); err != nil {
+			return err
+		}
+	}
+	if c.IsSet("coordinator-token") {
+		if err := flags.SetCoordinatorToken(c.GlobalString("coordinator-token")); err != nil {
+			return err
+		}
+	}
+	if c.IsSet("participants") {
+		if err := flags.SetParticipants(c.GlobalInt("participants")); err != nil {
+			return err
+		}
+	}
+	if c.IsSet("read-only") {
+		if err := flags.SetReadOnly(c.GlobalBool("read-only")); err != nil {
+			return err
+		}
+	}
+	if c.IsSet("dry-run") {
+		flags.SetDryRun(c.GlobalBool("dry-run"))
+	}
 	if c.IsSet("stats") {
 		flags.SetStats(c.GlobalBool("stats"))
 	}